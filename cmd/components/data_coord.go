@@ -64,3 +64,8 @@ func (s *DataCoord) Stop() error {
 func (s *DataCoord) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if DataCoord is ready to serve
+func (s *DataCoord) CheckReady(ctx context.Context) error {
+	return s.svr.CheckReady(ctx)
+}
@@ -66,3 +66,8 @@ func (d *DataNode) Stop() error {
 func (d *DataNode) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return d.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if DataNode is ready to serve
+func (d *DataNode) CheckReady(ctx context.Context) error {
+	return d.svr.CheckReady(ctx)
+}
@@ -62,3 +62,8 @@ func (s *IndexCoord) Stop() error {
 func (s *IndexCoord) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if IndexCoord is ready to serve
+func (s *IndexCoord) CheckReady(ctx context.Context) error {
+	return s.svr.CheckReady(ctx)
+}
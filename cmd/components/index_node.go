@@ -62,3 +62,8 @@ func (n *IndexNode) Stop() error {
 func (n *IndexNode) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return n.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if IndexNode is ready to serve
+func (n *IndexNode) CheckReady(ctx context.Context) error {
+	return n.svr.CheckReady(ctx)
+}
@@ -63,3 +63,8 @@ func (n *Proxy) Stop() error {
 func (n *Proxy) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return n.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if Proxy is ready to serve
+func (n *Proxy) CheckReady(ctx context.Context) error {
+	return n.svr.CheckReady(ctx)
+}
@@ -64,3 +64,8 @@ func (qs *QueryCoord) Stop() error {
 func (qs *QueryCoord) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return qs.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if QueryCoord is ready to serve
+func (qs *QueryCoord) CheckReady(ctx context.Context) error {
+	return qs.svr.CheckReady(ctx)
+}
@@ -65,3 +65,8 @@ func (q *QueryNode) Stop() error {
 func (q *QueryNode) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return q.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if QueryNode is ready to serve
+func (q *QueryNode) CheckReady(ctx context.Context) error {
+	return q.svr.CheckReady(ctx)
+}
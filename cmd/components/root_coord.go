@@ -68,3 +68,8 @@ func (rc *RootCoord) Stop() error {
 func (rc *RootCoord) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return rc.svr.GetComponentStates(ctx, request)
 }
+
+// CheckReady returns nil if RootCoord is ready to serve
+func (rc *RootCoord) CheckReady(ctx context.Context) error {
+	return rc.svr.CheckReady(ctx)
+}
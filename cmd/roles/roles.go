@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
 	"path"
 	"strings"
 	"sync"
@@ -46,6 +45,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querynode"
 	"github.com/milvus-io/milvus/internal/rootcoord"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
 	"github.com/milvus-io/milvus/internal/util/trace"
 )
 
@@ -53,6 +53,9 @@ func newMsgFactory(localMsg bool) msgstream.Factory {
 	if localMsg {
 		return msgstream.NewRmsFactory()
 	}
+	if paramtable.Params.MsgStreamType == paramtable.MsgStreamTypeKafka {
+		return msgstream.NewKmsFactory(paramtable.Params.KafkaAddress)
+	}
 	return msgstream.NewPmsFactory()
 }
 
@@ -196,6 +199,7 @@ func (mr *MilvusRoles) runQueryNode(ctx context.Context, localMsg bool, alias st
 	wg.Wait()
 
 	metrics.RegisterQueryNode()
+	metrics.ServeHTTP(querynode.Params.MetricsPort)
 	return qn
 }
 
@@ -252,6 +256,7 @@ func (mr *MilvusRoles) runDataNode(ctx context.Context, localMsg bool, alias str
 	wg.Wait()
 
 	metrics.RegisterDataNode()
+	metrics.ServeHTTP(datanode.Params.MetricsPort)
 	return dn
 }
 
@@ -307,6 +312,7 @@ func (mr *MilvusRoles) runIndexNode(ctx context.Context, localMsg bool, alias st
 	wg.Wait()
 
 	metrics.RegisterIndexNode()
+	metrics.ServeHTTP(indexnode.Params.MetricsPort)
 	return in
 }
 
@@ -353,11 +359,31 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 		}
 	}
 
+	paramtable.Params.Init()
+	shutdown := newShutdownGraph(paramtable.Params.ShutdownStageTimeout)
+
+	// the components running in the process share one health check server, the readiness of each one is served
+	// at /readyz/<role>, e.g. /readyz/rootcoord, and /readyz checks all of them
+	healthzServer := mr.startHealthzServer()
+	registerReadiness := func(role string, checker healthz.Checker) {
+		if healthzServer != nil {
+			healthzServer.Register(role, checker)
+		}
+	}
+	if healthzServer != nil {
+		// the process turns not ready as soon as it starts shutting down, and keeps answering /healthz until the end
+		shutdown.add(stageProxy, "healthz readiness", func() error {
+			healthzServer.SetShuttingDown()
+			return nil
+		})
+	}
+
 	var rc *components.RootCoord
 	if mr.EnableRootCoord {
 		rc = mr.runRootCoord(ctx, localMsg)
 		if rc != nil {
-			defer rc.Stop()
+			shutdown.add(stageCoords, "RootCoord", rc.Stop)
+			registerReadiness("RootCoord", rc)
 		}
 	}
 
@@ -365,7 +391,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableProxy {
 		pn = mr.runProxy(ctx, localMsg, alias)
 		if pn != nil {
-			defer pn.Stop()
+			shutdown.add(stageProxy, "Proxy", pn.Stop)
+			registerReadiness("Proxy", pn)
 		}
 	}
 
@@ -373,7 +400,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableQueryCoord {
 		qs = mr.runQueryCoord(ctx, localMsg)
 		if qs != nil {
-			defer qs.Stop()
+			shutdown.add(stageCoords, "QueryCoord", qs.Stop)
+			registerReadiness("QueryCoord", qs)
 		}
 	}
 
@@ -381,7 +409,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableQueryNode {
 		qn = mr.runQueryNode(ctx, localMsg, alias)
 		if qn != nil {
-			defer qn.Stop()
+			shutdown.add(stageNodes, "QueryNode", qn.Stop)
+			registerReadiness("QueryNode", qn)
 		}
 	}
 
@@ -389,7 +418,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableDataCoord {
 		ds = mr.runDataCoord(ctx, localMsg)
 		if ds != nil {
-			defer ds.Stop()
+			shutdown.add(stageCoords, "DataCoord", ds.Stop)
+			registerReadiness("DataCoord", ds)
 		}
 	}
 
@@ -397,7 +427,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableDataNode {
 		dn = mr.runDataNode(ctx, localMsg, alias)
 		if dn != nil {
-			defer dn.Stop()
+			shutdown.add(stageNodes, "DataNode", dn.Stop)
+			registerReadiness("DataNode", dn)
 		}
 	}
 
@@ -405,7 +436,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableIndexCoord {
 		is = mr.runIndexCoord(ctx, localMsg)
 		if is != nil {
-			defer is.Stop()
+			shutdown.add(stageCoords, "IndexCoord", is.Stop)
+			registerReadiness("IndexCoord", is)
 		}
 	}
 
@@ -413,7 +445,8 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableIndexNode {
 		in = mr.runIndexNode(ctx, localMsg, alias)
 		if in != nil {
-			defer in.Stop()
+			shutdown.add(stageNodes, "IndexNode", in.Stop)
+			registerReadiness("IndexNode", in)
 		}
 	}
 
@@ -421,7 +454,7 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 	if mr.EnableMsgStreamCoord {
 		mss = mr.runMsgStreamCoord(ctx)
 		if mss != nil {
-			defer mss.Stop()
+			shutdown.add(stageInfra, "MsgStreamCoord", mss.Stop)
 		}
 	}
 
@@ -458,19 +491,43 @@ func (mr *MilvusRoles) Run(localMsg bool, alias string) {
 		http.HandleFunc(healthz.HealthzRouterPath, standaloneHealthzHandler)
 	}
 
-	metrics.ServeHTTP()
+	metrics.ServeHTTP(paramtable.Params.MetricsPort)
 
-	sc := make(chan os.Signal, 1)
-	signal.Notify(sc,
+	// the context shared by the components is cancelled after all of them stopped, then the rocksmq used in
+	// standalone mode is closed
+	shutdown.add(stageInfra, "context", func() error {
+		cancel()
+		return nil
+	})
+	if healthzServer != nil {
+		shutdown.add(stageInfra, "healthz", healthzServer.Stop)
+	}
+	if localMsg {
+		shutdown.add(stageInfra, "rocksmq", func() error {
+			rocksmq.CloseRocksMQ()
+			return nil
+		})
+	}
+	shutdown.shutdownOnSignal(
 		syscall.SIGHUP,
 		syscall.SIGINT,
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
-	sig := <-sc
-	log.Error("Get signal to exit\n", zap.String("signal", sig.String()))
+}
 
-	// some deferred Stop has race with context cancel
-	cancel()
+// startHealthzServer starts the health check server if it's enabled, nil is returned otherwise
+func (mr *MilvusRoles) startHealthzServer() *healthz.Server {
+	if !paramtable.Params.HealthzEnabled {
+		return nil
+	}
+	server := healthz.NewServer(healthz.DefaultCheckTimeout)
+	if err := server.Start(paramtable.Params.HealthzPort); err != nil {
+		log.Warn("failed to start the health check server",
+			zap.Int("port", paramtable.Params.HealthzPort),
+			zap.Error(err))
+		return nil
+	}
+	return server
 }
 
 func setLoggerFunc(localMsg bool) func(cfg log.Config) {
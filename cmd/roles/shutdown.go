@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// shutdownStage is the order the components running in one process are stopped in, the components of a stage
+// depend on the ones of the later stages only.
+type shutdownStage int
+
+const (
+	// stageProxy stops accepting the external traffic
+	stageProxy shutdownStage = iota
+	// stageNodes flushes the buffered data of the nodes, while the coordinators and the message stream are running
+	stageNodes
+	stageCoords
+	// stageInfra stops the resources shared by the components, such as the message stream
+	stageInfra
+	stageNum
+)
+
+var shutdownStageNames = [stageNum]string{"proxy", "nodes", "coordinators", "infra"}
+
+func (s shutdownStage) String() string {
+	return shutdownStageNames[s]
+}
+
+type stopHook struct {
+	name string
+	stop func() error
+}
+
+// shutdownGraph stops the components in the order of their stages. The components of a stage are stopped
+// concurrently, the next stage starts when all of them stop or timeout passes.
+type shutdownGraph struct {
+	timeout time.Duration
+	stages  [stageNum][]stopHook
+}
+
+func newShutdownGraph(timeout time.Duration) *shutdownGraph {
+	return &shutdownGraph{timeout: timeout}
+}
+
+// add adds the component named name to stage, it's stopped by stop
+func (g *shutdownGraph) add(stage shutdownStage, name string, stop func() error) {
+	g.stages[stage] = append(g.stages[stage], stopHook{name: name, stop: stop})
+}
+
+// shutdown stops all the components stage by stage
+func (g *shutdownGraph) shutdown() {
+	for stage := shutdownStage(0); stage < stageNum; stage++ {
+		g.stopStage(stage)
+	}
+}
+
+func (g *shutdownGraph) stopStage(stage shutdownStage) {
+	hooks := g.stages[stage]
+	if len(hooks) == 0 {
+		return
+	}
+	names := make([]string, 0, len(hooks))
+	for _, hook := range hooks {
+		names = append(names, hook.name)
+	}
+	log.Info("stopping components", zap.Stringer("stage", stage), zap.Strings("components", names))
+
+	start := time.Now()
+	var mu sync.Mutex
+	pending := make(map[string]struct{}, len(hooks))
+	for _, name := range names {
+		pending[name] = struct{}{}
+	}
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for _, hook := range hooks {
+		wg.Add(1)
+		go func(hook stopHook) {
+			defer wg.Done()
+			if err := hook.stop(); err != nil {
+				log.Warn("failed to stop component", zap.String("component", hook.name), zap.Error(err))
+			} else {
+				log.Info("component stopped", zap.String("component", hook.name), zap.Duration("elapsed", time.Since(start)))
+			}
+			mu.Lock()
+			delete(pending, hook.name)
+			mu.Unlock()
+		}(hook)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(g.timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		log.Info("components stopped", zap.Stringer("stage", stage), zap.Duration("elapsed", time.Since(start)))
+	case <-timer.C:
+		mu.Lock()
+		remain := make([]string, 0, len(pending))
+		for name := range pending {
+			remain = append(remain, name)
+		}
+		mu.Unlock()
+		sort.Strings(remain)
+		log.Warn("components not stopped in time, continue shutting down",
+			zap.Stringer("stage", stage),
+			zap.Duration("timeout", g.timeout),
+			zap.Strings("components", remain))
+	}
+}
+
+// shutdownOnSignal waits for one of sigs, then stops all the components. The signal received is returned.
+func (g *shutdownGraph) shutdownOnSignal(sigs ...os.Signal) os.Signal {
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, sigs...)
+	defer signal.Stop(sc)
+	sig := <-sc
+	log.Error("Get signal to exit\n", zap.String("signal", sig.String()))
+
+	g.shutdown()
+	return sig
+}
@@ -0,0 +1,214 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// shutdownRecorder records the order the components are stopped in
+type shutdownRecorder struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (r *shutdownRecorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = append(r.stopped, name)
+}
+
+func (r *shutdownRecorder) order() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.stopped...)
+}
+
+// fakeStream is the message stream shared by the fake components
+type fakeStream struct {
+	mu     sync.Mutex
+	closed bool
+	ch     chan int
+}
+
+func (s *fakeStream) produce(row int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("stream closed")
+	}
+	s.ch <- row
+	return nil
+}
+
+func (s *fakeStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+// fakeDataCoord saves the rows flushed by fakeDataNode until stopped
+type fakeDataCoord struct {
+	mu      sync.Mutex
+	stopped bool
+	saved   []int
+}
+
+func (c *fakeDataCoord) save(rows []int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return errors.New("data coord stopped")
+	}
+	c.saved = append(c.saved, rows...)
+	return nil
+}
+
+// fakeDataNode buffers the rows consumed from the stream, and flushes them to fakeDataCoord when stopped
+type fakeDataNode struct {
+	stream    *fakeStream
+	dataCoord *fakeDataCoord
+	mu        sync.Mutex
+	buffer    []int
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func (n *fakeDataNode) start() {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		for {
+			select {
+			case row := <-n.stream.ch:
+				n.mu.Lock()
+				n.buffer = append(n.buffer, row)
+				n.mu.Unlock()
+			case <-n.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (n *fakeDataNode) stop() error {
+	// consume the rows left in the stream before flushing
+	for len(n.stream.ch) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(n.closeCh)
+	n.wg.Wait()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.dataCoord.save(n.buffer)
+}
+
+func TestShutdownGraph_SIGTERM(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	stream := &fakeStream{ch: make(chan int, 16)}
+	dataCoord := &fakeDataCoord{}
+	dataNode := &fakeDataNode{stream: stream, dataCoord: dataCoord, closeCh: make(chan struct{})}
+	dataNode.start()
+
+	// the fake proxy inserts rows until stopped, the rows accepted are expected to be saved by DataCoord
+	var accepted []int
+	proxyCloseCh := make(chan struct{})
+	proxyDone := make(chan struct{})
+	go func() {
+		defer close(proxyDone)
+		for row := 0; ; row++ {
+			select {
+			case <-proxyCloseCh:
+				return
+			default:
+			}
+			if err := stream.produce(row); err != nil {
+				return
+			}
+			accepted = append(accepted, row)
+		}
+	}()
+
+	g := newShutdownGraph(time.Second)
+	g.add(stageInfra, "msgstream", func() error {
+		recorder.record("msgstream")
+		stream.close()
+		return nil
+	})
+	g.add(stageCoords, "DataCoord", func() error {
+		recorder.record("DataCoord")
+		dataCoord.mu.Lock()
+		defer dataCoord.mu.Unlock()
+		dataCoord.stopped = true
+		return nil
+	})
+	g.add(stageNodes, "DataNode", func() error {
+		recorder.record("DataNode")
+		return dataNode.stop()
+	})
+	g.add(stageProxy, "Proxy", func() error {
+		recorder.record("Proxy")
+		close(proxyCloseCh)
+		<-proxyDone
+		return nil
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	}()
+	sig := g.shutdownOnSignal(syscall.SIGTERM)
+	assert.Equal(t, syscall.SIGTERM, sig)
+
+	assert.Equal(t, []string{"Proxy", "DataNode", "DataCoord", "msgstream"}, recorder.order())
+	assert.NotEmpty(t, accepted)
+	// the rows in flight when the signal came are flushed
+	assert.Equal(t, accepted, dataCoord.saved)
+}
+
+func TestShutdownGraph_Timeout(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	block := make(chan struct{})
+	defer close(block)
+
+	g := newShutdownGraph(100 * time.Millisecond)
+	g.add(stageNodes, "QueryNode", func() error {
+		<-block
+		return nil
+	})
+	g.add(stageNodes, "IndexNode", func() error {
+		recorder.record("IndexNode")
+		return errors.New("mock")
+	})
+	g.add(stageCoords, "QueryCoord", func() error {
+		recorder.record("QueryCoord")
+		return nil
+	})
+
+	start := time.Now()
+	g.shutdown()
+	// the stuck node delays the next stage for the timeout only
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(t, []string{"IndexNode", "QueryCoord"}, recorder.order())
+}
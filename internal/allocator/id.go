@@ -18,7 +18,9 @@ package allocator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -31,8 +33,18 @@ import (
 
 const (
 	idCountPerRPC = 200000
+	// the cached ids are refilled in background once fewer than idLowWaterMark of them remain
+	idLowWaterMark = idCountPerRPC / 4
+
+	idRefillTimeout = 5 * time.Second
+	// idRefillBackoff is the duration no refill is started after a failed one
+	idRefillBackoff = time.Second
 )
 
+// ErrIDUnavailable is returned when the cached ids run out and can't be refilled from root coord for now,
+// the allocation could be retried later.
+var ErrIDUnavailable = errors.New("ids unavailable")
+
 // UniqueID is alias of typeutil.UniqueID
 type UniqueID = typeutil.UniqueID
 
@@ -40,17 +52,33 @@ type idAllocatorInterface interface {
 	AllocID(ctx context.Context, req *rootcoordpb.AllocIDRequest) (*rootcoordpb.AllocIDResponse, error)
 }
 
+// idRange is the ids in [start, end)
+type idRange struct {
+	start UniqueID
+	end   UniqueID
+}
+
 // IDAllocator allocates Unique and monotonically increasing IDs from Root Coord.
-// It could also batch allocate for less root coord server access
+// The IDs are allocated from root coord in batch and cached locally, the cache is refilled in background once it
+// runs low, so the allocations only wait for root coord when the cache is exhausted.
 type IDAllocator struct {
-	Allocator
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	idAllocator idAllocatorInterface
 
-	countPerRPC uint32
+	countPerRPC  uint32
+	lowWaterMark uint32
 
-	idStart UniqueID
-	idEnd   UniqueID
+	mu sync.Mutex
+	// ranges are the cached ids in ascending order, the ids are allocated from the first one
+	ranges []idRange
+	// maxID is the end of the ids ever cached, the ranges refilled below it are dropped
+	maxID UniqueID
+	// refilled is closed when the refill in flight finishes, nil if no refill is in flight
+	refilled  chan struct{}
+	refillErr error
+	retryAt   time.Time
 
 	PeerID UniqueID
 }
@@ -59,46 +87,32 @@ type IDAllocator struct {
 func NewIDAllocator(ctx context.Context, idAlloctor idAllocatorInterface, peerID UniqueID) (*IDAllocator, error) {
 	ctx1, cancel := context.WithCancel(ctx)
 	a := &IDAllocator{
-		Allocator: Allocator{
-			Ctx:        ctx1,
-			CancelFunc: cancel,
-			Role:       "IDAllocator",
-		},
-		countPerRPC: idCountPerRPC,
-		idAllocator: idAlloctor,
-		PeerID:      peerID,
+		ctx:          ctx1,
+		cancel:       cancel,
+		countPerRPC:  idCountPerRPC,
+		lowWaterMark: idLowWaterMark,
+		idAllocator:  idAlloctor,
+		PeerID:       peerID,
 	}
-	a.TChan = &EmptyTicker{}
-	a.Allocator.SyncFunc = a.syncID
-	a.Allocator.ProcessFunc = a.processFunc
-	a.Allocator.CheckSyncFunc = a.checkSyncFunc
-	a.Allocator.PickCanDoFunc = a.pickCanDoFunc
-	a.Init()
 	return a, nil
 }
 
-// Start creates some working goroutines of IDAllocator.
+// Start prefetches the ids from root coord.
 func (ia *IDAllocator) Start() error {
-	return ia.Allocator.Start()
+	ia.mu.Lock()
+	defer ia.mu.Unlock()
+	ia.refill(0)
+	return nil
 }
 
-func (ia *IDAllocator) gatherReqIDCount() uint32 {
-	need := uint32(0)
-	for _, req := range ia.ToDoReqs {
-		tReq := req.(*IDRequest)
-		need += tReq.count
-	}
-	return need
+// Close closes the IDAllocator, the allocations waiting for root coord fail.
+func (ia *IDAllocator) Close() {
+	ia.cancel()
 }
 
-func (ia *IDAllocator) syncID() (bool, error) {
-
-	need := ia.gatherReqIDCount()
-	if need < ia.countPerRPC {
-		need = ia.countPerRPC
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (ia *IDAllocator) syncID(count uint32) (UniqueID, UniqueID, error) {
+	ctx, cancel := context.WithTimeout(ia.ctx, idRefillTimeout)
+	defer cancel()
 	req := &rootcoordpb.AllocIDRequest{
 		Base: &commonpb.MsgBase{
 			MsgType:   commonpb.MsgType_RequestID,
@@ -106,49 +120,81 @@ func (ia *IDAllocator) syncID() (bool, error) {
 			Timestamp: 0,
 			SourceID:  ia.PeerID,
 		},
-		Count: need,
+		Count: count,
 	}
 	resp, err := ia.idAllocator.AllocID(ctx, req)
-
-	cancel()
 	if err != nil {
-		return false, fmt.Errorf("syncID Failed:%w", err)
+		return 0, 0, fmt.Errorf("syncID Failed:%w", err)
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return 0, 0, fmt.Errorf("syncID Failed:%s", resp.GetStatus().GetReason())
 	}
-	ia.idStart = resp.GetID()
-	ia.idEnd = ia.idStart + int64(resp.GetCount())
-	return true, nil
+	return resp.GetID(), resp.GetID() + int64(resp.GetCount()), nil
 }
 
-func (ia *IDAllocator) checkSyncFunc(timeout bool) bool {
-	return timeout || len(ia.ToDoReqs) > 0
+// remain returns the num of the cached ids
+func (ia *IDAllocator) remain() int64 {
+	var total int64
+	for _, r := range ia.ranges {
+		total += r.end - r.start
+	}
+	return total
 }
 
-func (ia *IDAllocator) pickCanDoFunc() {
-	total := uint32(ia.idEnd - ia.idStart)
-	need := uint32(0)
-	idx := 0
-	for _, req := range ia.ToDoReqs {
-		iReq := req.(*IDRequest)
-		need += iReq.count
-		if need <= total {
-			ia.CanDoReqs = append(ia.CanDoReqs, req)
-			idx++
-		} else {
-			break
+// take allocates count ids from the cache. The ids of a range too few for the count are skipped if there is a
+// higher range, since the allocated ids must be consecutive.
+func (ia *IDAllocator) take(count uint32) (UniqueID, bool) {
+	for len(ia.ranges) > 0 {
+		r := &ia.ranges[0]
+		if r.end-r.start >= int64(count) {
+			start := r.start
+			r.start += int64(count)
+			if r.start == r.end {
+				ia.ranges = ia.ranges[1:]
+			}
+			return start, true
+		}
+		if len(ia.ranges) == 1 {
+			return 0, false
 		}
+		ia.ranges = ia.ranges[1:]
 	}
-	ia.ToDoReqs = ia.ToDoReqs[idx:]
-	log.Debug("IDAllocator pickCanDoFunc",
-		zap.Any("need", need),
-		zap.Any("total", total),
-		zap.Any("remainReqCnt", len(ia.ToDoReqs)))
+	return 0, false
 }
 
-func (ia *IDAllocator) processFunc(req Request) error {
-	idRequest := req.(*IDRequest)
-	idRequest.id = ia.idStart
-	ia.idStart += int64(idRequest.count)
-	return nil
+// refill starts refilling at least need ids from root coord if no refill is in flight, the returned channel is
+// closed once the refill finishes. It must be called with mu held.
+func (ia *IDAllocator) refill(need uint32) chan struct{} {
+	if ia.refilled != nil {
+		return ia.refilled
+	}
+	count := ia.countPerRPC
+	if need > count {
+		count = need
+	}
+	refilled := make(chan struct{})
+	ia.refilled = refilled
+	go func() {
+		start, end, err := ia.syncID(count)
+
+		ia.mu.Lock()
+		defer ia.mu.Unlock()
+		if err == nil && start < ia.maxID {
+			err = fmt.Errorf("syncID Failed:ids [%d, %d) allocated by root coord are below the cached %d", start, end, ia.maxID)
+		}
+		if err != nil {
+			log.Warn("IDAllocator failed to refill ids", zap.Int64("peerID", ia.PeerID), zap.Error(err))
+			ia.refillErr = err
+			ia.retryAt = time.Now().Add(idRefillBackoff)
+		} else {
+			ia.refillErr = nil
+			ia.ranges = append(ia.ranges, idRange{start: start, end: end})
+			ia.maxID = end
+		}
+		ia.refilled = nil
+		close(refilled)
+	}()
+	return refilled
 }
 
 // AllocOne allocates one id.
@@ -162,14 +208,34 @@ func (ia *IDAllocator) AllocOne() (UniqueID, error) {
 
 // Alloc allocates the id of the count number.
 func (ia *IDAllocator) Alloc(count uint32) (UniqueID, UniqueID, error) {
-	req := &IDRequest{BaseRequest: BaseRequest{Done: make(chan error), Valid: false}}
+	ia.mu.Lock()
+	defer ia.mu.Unlock()
+	for {
+		if start, ok := ia.take(count); ok {
+			if ia.remain() < int64(ia.lowWaterMark) && time.Now().After(ia.retryAt) {
+				ia.refill(0)
+			}
+			return start, start + int64(count), nil
+		}
 
-	req.count = count
-	ia.Reqs <- req
-	if err := req.Wait(); err != nil {
-		return 0, 0, err
+		// the cache is exhausted, fail fast if root coord failed just now
+		if ia.refilled == nil && ia.refillErr != nil && time.Now().Before(ia.retryAt) {
+			return 0, 0, fmt.Errorf("%w: %s", ErrIDUnavailable, ia.refillErr.Error())
+		}
+		refilled := ia.refill(count)
+		ia.mu.Unlock()
+		select {
+		case <-refilled:
+			ia.mu.Lock()
+		case <-ia.ctx.Done():
+			ia.mu.Lock()
+			return 0, 0, fmt.Errorf("%w: IDAllocator is closed", ErrIDUnavailable)
+		}
+		if ia.refillErr != nil {
+			if start, ok := ia.take(count); ok {
+				return start, start + int64(count), nil
+			}
+			return 0, 0, fmt.Errorf("%w: %s", ErrIDUnavailable, ia.refillErr.Error())
+		}
 	}
-
-	start, count := req.id, req.count
-	return start, start + int64(count), nil
 }
@@ -18,11 +18,16 @@ package allocator
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockIDAllocator struct {
@@ -65,3 +70,163 @@ func TestIDAllocator(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, id, int64(20002))
 }
+
+// monotonicIDAllocator allocates increasing ids like root coord, it fails while unavailable is set
+type monotonicIDAllocator struct {
+	next        int64
+	rpcs        int64
+	unavailable int32
+	// block blocks the AllocID calls until the context is done
+	block bool
+}
+
+func (m *monotonicIDAllocator) AllocID(ctx context.Context, req *rootcoordpb.AllocIDRequest) (*rootcoordpb.AllocIDResponse, error) {
+	atomic.AddInt64(&m.rpcs, 1)
+	if m.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if atomic.LoadInt32(&m.unavailable) == 1 {
+		return nil, errors.New("root coord unavailable")
+	}
+	end := atomic.AddInt64(&m.next, int64(req.Count))
+	return &rootcoordpb.AllocIDResponse{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		ID:     end - int64(req.Count),
+		Count:  req.Count,
+	}, nil
+}
+
+func newTestIDAllocator(t *testing.T, rc idAllocatorInterface, countPerRPC uint32) *IDAllocator {
+	idAllocator, err := NewIDAllocator(context.Background(), rc, 1)
+	require.NoError(t, err)
+	idAllocator.countPerRPC = countPerRPC
+	idAllocator.lowWaterMark = countPerRPC / 2
+	return idAllocator
+}
+
+func TestIDAllocator_Refill(t *testing.T) {
+	rc := &monotonicIDAllocator{next: 1}
+	idAllocator := newTestIDAllocator(t, rc, 100)
+	defer idAllocator.Close()
+	require.NoError(t, idAllocator.Start())
+
+	var mu sync.Mutex
+	ids := make(map[UniqueID]struct{})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := UniqueID(0)
+			for j := 0; j < 100; j++ {
+				start, end, err := idAllocator.Alloc(3)
+				assert.NoError(t, err)
+				assert.Equal(t, start+3, end)
+				// the ids allocated by a consumer are increasing
+				assert.Greater(t, start, last)
+				last = start
+				mu.Lock()
+				for id := start; id < end; id++ {
+					_, ok := ids[id]
+					assert.False(t, ok, "id %d is allocated twice", id)
+					ids[id] = struct{}{}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3000, len(ids))
+	// the ids are refilled in batch, the ids skipped at the end of the ranges cost a few more rpcs
+	assert.Less(t, atomic.LoadInt64(&rc.rpcs), int64(3000/100*2))
+
+	// more ids than a batch are allocated by a larger refill
+	start, end, err := idAllocator.Alloc(1000)
+	assert.NoError(t, err)
+	assert.Equal(t, start+1000, end)
+}
+
+func TestIDAllocator_Restart(t *testing.T) {
+	rc := &monotonicIDAllocator{next: 1}
+	idAllocator := newTestIDAllocator(t, rc, 100)
+	require.NoError(t, idAllocator.Start())
+	last, err := idAllocator.AllocOne()
+	assert.NoError(t, err)
+	idAllocator.Close()
+
+	// the ids cached by the closed allocator are never allocated again
+	idAllocator = newTestIDAllocator(t, rc, 100)
+	defer idAllocator.Close()
+	require.NoError(t, idAllocator.Start())
+	id, err := idAllocator.AllocOne()
+	assert.NoError(t, err)
+	assert.Greater(t, id, last)
+}
+
+func TestIDAllocator_Unavailable(t *testing.T) {
+	rc := &monotonicIDAllocator{next: 1}
+	idAllocator := newTestIDAllocator(t, rc, 100)
+	defer idAllocator.Close()
+	require.NoError(t, idAllocator.Start())
+	_, err := idAllocator.AllocOne()
+	require.NoError(t, err)
+
+	// the cached ids are allocated while root coord is unavailable
+	atomic.StoreInt32(&rc.unavailable, 1)
+	start, end, err := idAllocator.Alloc(99)
+	assert.NoError(t, err)
+	assert.Equal(t, start+99, end)
+
+	// then the allocations fail with a retriable error
+	_, err = idAllocator.AllocOne()
+	assert.True(t, errors.Is(err, ErrIDUnavailable))
+	rpcs := atomic.LoadInt64(&rc.rpcs)
+	_, err = idAllocator.AllocOne()
+	assert.True(t, errors.Is(err, ErrIDUnavailable))
+	// no refill is started during the backoff
+	assert.Equal(t, rpcs, atomic.LoadInt64(&rc.rpcs))
+
+	// the allocations succeed once root coord is back
+	atomic.StoreInt32(&rc.unavailable, 0)
+	assert.Eventually(t, func() bool {
+		id, err := idAllocator.AllocOne()
+		return err == nil && id >= end
+	}, 3*idRefillBackoff, 10*time.Millisecond)
+}
+
+func TestIDAllocator_Close(t *testing.T) {
+	idAllocator := newTestIDAllocator(t, &monotonicIDAllocator{block: true}, 100)
+	require.NoError(t, idAllocator.Start())
+
+	errCh := make(chan error)
+	go func() {
+		_, err := idAllocator.AllocOne()
+		errCh <- err
+	}()
+	idAllocator.Close()
+	select {
+	case err := <-errCh:
+		assert.True(t, errors.Is(err, ErrIDUnavailable))
+	case <-time.After(time.Second):
+		t.Fatal("the allocation waiting for root coord isn't failed by Close")
+	}
+}
+
+// BenchmarkIDAllocator_AllocOne allocates ids from the local cache in steady state, the refills happen in background.
+func BenchmarkIDAllocator_AllocOne(b *testing.B) {
+	idAllocator, err := NewIDAllocator(context.Background(), &monotonicIDAllocator{next: 1}, 1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer idAllocator.Close()
+	if err = idAllocator.Start(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err = idAllocator.AllocOne(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+const (
+	// CollectionTTLKey is the time to live of the collection data, in seconds
+	CollectionTTLKey = "collection.ttl.seconds"
+
+	// CollectionReplicaNumberKey is the number of in-memory replicas of the collection
+	CollectionReplicaNumberKey = "collection.replica.number"
+
+	// CollectionNodeSelectorKey restricts the nodes serving the collection, in the form "k1=v1,k2=v2"
+	CollectionNodeSelectorKey = "collection.node.selector"
+
+	// CollectionInsertRateKey is the max insert rate of the collection, in MB/s
+	CollectionInsertRateKey = "collection.insertRate.max.mb"
+
+	// CollectionSearchRateKey is the max search rate of the collection, in queries per second
+	CollectionSearchRateKey = "collection.searchRate.max.qps"
+
+	// CollectionInsertDedupKey decides how the proxy handles the duplicate primary keys within an insert batch
+	CollectionInsertDedupKey = "collection.insert.dedup"
+
+	// CollectionIndexMinSegmentRowsKey is the min rows of a segment to build index, the smaller segments are searched
+	// by brute force until compaction merges them, it overrides the default of IndexCoord
+	CollectionIndexMinSegmentRowsKey = "collection.index.minSegmentRows"
+
+	// CollectionLoadIdleReleaseKey releases the loaded collection after it's not searched or queried for the seconds,
+	// the collection is never released automatically if it's not set
+	CollectionLoadIdleReleaseKey = "load.idle.release.seconds"
+)
+
+const (
+	// InsertDedupNone inserts the rows of duplicate primary keys as they are, it's the default mode
+	InsertDedupNone = "none"
+
+	// InsertDedupReject rejects the insert batch containing duplicate primary keys
+	InsertDedupReject = "reject"
+
+	// InsertDedupKeepLast keeps only the last row of each duplicate primary key in the insert batch,
+	// it's a step towards upsert which also replaces the rows inserted before
+	InsertDedupKeepLast = "keep_last"
+)
+
+// collectionPropertyValidators is the registry of the known collection properties,
+// a property not registered here is rejected by ValidateCollectionProperties
+var collectionPropertyValidators = map[string]func(value string) error{
+	CollectionTTLKey:                 validateNonNegativeInt,
+	CollectionReplicaNumberKey:       validatePositiveInt,
+	CollectionNodeSelectorKey:        validateNodeSelector,
+	CollectionInsertRateKey:          validateNonNegativeFloat,
+	CollectionSearchRateKey:          validateNonNegativeFloat,
+	CollectionInsertDedupKey:         validateInsertDedupMode,
+	CollectionIndexMinSegmentRowsKey: validatePositiveInt,
+	CollectionLoadIdleReleaseKey:     validatePositiveInt,
+}
+
+func validateNonNegativeInt(value string) error {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s is not an integer", value)
+	}
+	if v < 0 {
+		return fmt.Errorf("%s should not be negative", value)
+	}
+	return nil
+}
+
+func validatePositiveInt(value string) error {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s is not an integer", value)
+	}
+	if v <= 0 {
+		return fmt.Errorf("%s should be positive", value)
+	}
+	return nil
+}
+
+func validateNonNegativeFloat(value string) error {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%s is not a number", value)
+	}
+	if v < 0 {
+		return fmt.Errorf("%s should not be negative", value)
+	}
+	return nil
+}
+
+func validateInsertDedupMode(value string) error {
+	switch value {
+	case InsertDedupNone, InsertDedupReject, InsertDedupKeepLast:
+		return nil
+	default:
+		return fmt.Errorf("%s is not one of %s, %s and %s", value, InsertDedupNone, InsertDedupReject, InsertDedupKeepLast)
+	}
+}
+
+func validateNodeSelector(value string) error {
+	_, err := parseNodeSelector(value)
+	return err
+}
+
+func parseNodeSelector(value string) (map[string]string, error) {
+	selector := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("%s is not in the form of key=value", pair)
+		}
+		selector[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return selector, nil
+}
+
+// ValidateCollectionProperties checks that every property is a known collection property with a valid value,
+// an empty value is always valid since it removes the property
+func ValidateCollectionProperties(properties []*commonpb.KeyValuePair) error {
+	for _, kv := range properties {
+		validate, ok := collectionPropertyValidators[kv.GetKey()]
+		if !ok {
+			return fmt.Errorf("unknown collection property %s", kv.GetKey())
+		}
+		if kv.GetValue() == "" {
+			continue
+		}
+		if err := validate(kv.GetValue()); err != nil {
+			return fmt.Errorf("invalid value of collection property %s, %w", kv.GetKey(), err)
+		}
+	}
+	return nil
+}
+
+// MergeCollectionProperties overrides the old properties with the updates and returns the result sorted by key,
+// a property updated with an empty value is removed
+func MergeCollectionProperties(old, updates []*commonpb.KeyValuePair) []*commonpb.KeyValuePair {
+	merged := make(map[string]string, len(old)+len(updates))
+	for _, kv := range old {
+		merged[kv.GetKey()] = kv.GetValue()
+	}
+	for _, kv := range updates {
+		if kv.GetValue() == "" {
+			delete(merged, kv.GetKey())
+			continue
+		}
+		merged[kv.GetKey()] = kv.GetValue()
+	}
+	ret := make([]*commonpb.KeyValuePair, 0, len(merged))
+	for k, v := range merged {
+		ret = append(ret, &commonpb.KeyValuePair{Key: k, Value: v})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
+
+// CollectionProperties is the typed accessor of the collection properties,
+// every getter returns false if the property is not set or can not be parsed
+type CollectionProperties map[string]string
+
+// NewCollectionProperties creates a CollectionProperties from the key value pairs stored in collection meta
+func NewCollectionProperties(properties []*commonpb.KeyValuePair) CollectionProperties {
+	p := make(CollectionProperties, len(properties))
+	for _, kv := range properties {
+		p[kv.GetKey()] = kv.GetValue()
+	}
+	return p
+}
+
+func (p CollectionProperties) getInt64(key string) (int64, bool) {
+	value, ok := p[key]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func (p CollectionProperties) getFloat64(key string) (float64, bool) {
+	value, ok := p[key]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// TTL returns the time to live of the collection data
+func (p CollectionProperties) TTL() (time.Duration, bool) {
+	v, ok := p.getInt64(CollectionTTLKey)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(v) * time.Second, true
+}
+
+// ReplicaNumber returns the number of in-memory replicas of the collection
+func (p CollectionProperties) ReplicaNumber() (int64, bool) {
+	return p.getInt64(CollectionReplicaNumberKey)
+}
+
+// NodeSelector returns the labels a node should have to serve the collection
+func (p CollectionProperties) NodeSelector() (map[string]string, bool) {
+	value, ok := p[CollectionNodeSelectorKey]
+	if !ok {
+		return nil, false
+	}
+	selector, err := parseNodeSelector(value)
+	if err != nil {
+		return nil, false
+	}
+	return selector, true
+}
+
+// InsertRate returns the max insert rate of the collection, in MB/s
+func (p CollectionProperties) InsertRate() (float64, bool) {
+	return p.getFloat64(CollectionInsertRateKey)
+}
+
+// SearchRate returns the max search rate of the collection, in queries per second
+func (p CollectionProperties) SearchRate() (float64, bool) {
+	return p.getFloat64(CollectionSearchRateKey)
+}
+
+// InsertDedupMode returns how the duplicate primary keys within an insert batch are handled
+func (p CollectionProperties) InsertDedupMode() (string, bool) {
+	value, ok := p[CollectionInsertDedupKey]
+	if !ok || validateInsertDedupMode(value) != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// IndexMinSegmentRows returns the min rows of a segment to build index
+func (p CollectionProperties) IndexMinSegmentRows() (int64, bool) {
+	return p.getInt64(CollectionIndexMinSegmentRowsKey)
+}
+
+// LoadIdleRelease returns how long the loaded collection could be idle before it's released
+func (p CollectionProperties) LoadIdleRelease() (time.Duration, bool) {
+	v, ok := p.getInt64(CollectionLoadIdleReleaseKey)
+	if !ok || v <= 0 {
+		return 0, false
+	}
+	return time.Duration(v) * time.Second, true
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCollectionProperties(t *testing.T) {
+	valid := []*commonpb.KeyValuePair{
+		{Key: CollectionTTLKey, Value: "3600"},
+		{Key: CollectionReplicaNumberKey, Value: "2"},
+		{Key: CollectionNodeSelectorKey, Value: "zone=a, disk=ssd"},
+		{Key: CollectionInsertRateKey, Value: "1.5"},
+		{Key: CollectionSearchRateKey, Value: "100"},
+		{Key: CollectionInsertDedupKey, Value: InsertDedupKeepLast},
+		{Key: CollectionIndexMinSegmentRowsKey, Value: "4096"},
+		{Key: CollectionLoadIdleReleaseKey, Value: "86400"},
+		{Key: CollectionTTLKey, Value: ""},
+	}
+	assert.Nil(t, ValidateCollectionProperties(valid))
+	assert.Nil(t, ValidateCollectionProperties(nil))
+
+	invalid := []*commonpb.KeyValuePair{
+		{Key: "unknown.key", Value: "1"},
+		{Key: CollectionTTLKey, Value: "-1"},
+		{Key: CollectionTTLKey, Value: "1h"},
+		{Key: CollectionReplicaNumberKey, Value: "0"},
+		{Key: CollectionNodeSelectorKey, Value: "zone"},
+		{Key: CollectionNodeSelectorKey, Value: "=a"},
+		{Key: CollectionInsertRateKey, Value: "-0.5"},
+		{Key: CollectionSearchRateKey, Value: "fast"},
+		{Key: CollectionInsertDedupKey, Value: "first"},
+		{Key: CollectionIndexMinSegmentRowsKey, Value: "0"},
+		{Key: CollectionLoadIdleReleaseKey, Value: "0"},
+	}
+	for _, kv := range invalid {
+		assert.NotNil(t, ValidateCollectionProperties([]*commonpb.KeyValuePair{kv}), kv.String())
+	}
+}
+
+func TestMergeCollectionProperties(t *testing.T) {
+	old := []*commonpb.KeyValuePair{
+		{Key: CollectionTTLKey, Value: "10"},
+		{Key: CollectionReplicaNumberKey, Value: "1"},
+	}
+	merged := MergeCollectionProperties(old, []*commonpb.KeyValuePair{
+		{Key: CollectionTTLKey, Value: "20"},
+		{Key: CollectionReplicaNumberKey, Value: ""},
+		{Key: CollectionInsertRateKey, Value: "5"},
+	})
+	assert.Equal(t, []*commonpb.KeyValuePair{
+		{Key: CollectionInsertRateKey, Value: "5"},
+		{Key: CollectionTTLKey, Value: "20"},
+	}, merged)
+	assert.Equal(t, "10", old[0].Value)
+}
+
+func TestCollectionProperties(t *testing.T) {
+	p := NewCollectionProperties([]*commonpb.KeyValuePair{
+		{Key: CollectionTTLKey, Value: "60"},
+		{Key: CollectionReplicaNumberKey, Value: "3"},
+		{Key: CollectionNodeSelectorKey, Value: "zone=a,disk=ssd"},
+		{Key: CollectionInsertRateKey, Value: "2.5"},
+		{Key: CollectionSearchRateKey, Value: "bad"},
+		{Key: CollectionInsertDedupKey, Value: InsertDedupReject},
+		{Key: CollectionIndexMinSegmentRowsKey, Value: "4096"},
+		{Key: CollectionLoadIdleReleaseKey, Value: "3600"},
+	})
+	ttl, ok := p.TTL()
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, ttl)
+
+	replica, ok := p.ReplicaNumber()
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), replica)
+
+	selector, ok := p.NodeSelector()
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"zone": "a", "disk": "ssd"}, selector)
+
+	rate, ok := p.InsertRate()
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, rate)
+
+	_, ok = p.SearchRate()
+	assert.False(t, ok)
+
+	mode, ok := p.InsertDedupMode()
+	assert.True(t, ok)
+	assert.Equal(t, InsertDedupReject, mode)
+
+	minRows, ok := p.IndexMinSegmentRows()
+	assert.True(t, ok)
+	assert.Equal(t, int64(4096), minRows)
+
+	idle, ok := p.LoadIdleRelease()
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, idle)
+
+	empty := NewCollectionProperties(nil)
+	_, ok = empty.TTL()
+	assert.False(t, ok)
+	_, ok = empty.NodeSelector()
+	assert.False(t, ok)
+	_, ok = empty.InsertDedupMode()
+	assert.False(t, ok)
+	_, ok = empty.IndexMinSegmentRows()
+	assert.False(t, ok)
+	_, ok = empty.LoadIdleRelease()
+	assert.False(t, ok)
+}
@@ -19,6 +19,7 @@ package datacoord
 import (
 	"context"
 
+	idallocator "github.com/milvus-io/milvus/internal/allocator"
 	"github.com/milvus-io/milvus/internal/types"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -33,15 +34,18 @@ type allocator interface {
 
 var _ allocator = (*rootCoordAllocator)(nil)
 
-// rootCoordAllocator use RootCoord as allocator
+// rootCoordAllocator use RootCoord as allocator, the ids are allocated from RootCoord in batch and cached
 type rootCoordAllocator struct {
 	types.RootCoord
+	ids *idallocator.IDAllocator
 }
 
-// newRootCoordAllocator get an allocator from RootCoord
-func newRootCoordAllocator(rootCoordClient types.RootCoord) allocator {
+// newRootCoordAllocator get an allocator from RootCoord, the ids are cached until ctx is done
+func newRootCoordAllocator(ctx context.Context, rootCoordClient types.RootCoord) allocator {
+	ids, _ := idallocator.NewIDAllocator(ctx, rootCoordClient, Params.NodeID)
 	return &rootCoordAllocator{
 		RootCoord: rootCoordClient,
+		ids:       ids,
 	}
 }
 
@@ -63,20 +67,7 @@ func (alloc *rootCoordAllocator) allocTimestamp(ctx context.Context) (Timestamp,
 	return resp.Timestamp, nil
 }
 
-// allocID allocate an `UniqueID` from RootCoord, invoking AllocID grpc
+// allocID allocate an `UniqueID` from the ids cached from RootCoord, which are refilled by AllocID grpc
 func (alloc *rootCoordAllocator) allocID(ctx context.Context) (UniqueID, error) {
-	resp, err := alloc.AllocID(ctx, &rootcoordpb.AllocIDRequest{
-		Base: &commonpb.MsgBase{
-			MsgType:   commonpb.MsgType_RequestID,
-			MsgID:     0,
-			Timestamp: 0,
-			SourceID:  Params.NodeID,
-		},
-		Count: 1,
-	})
-	if err = VerifyResponse(resp, err); err != nil {
-		return 0, err
-	}
-
-	return resp.ID, nil
+	return alloc.ids.AllocOne()
 }
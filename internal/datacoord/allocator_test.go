@@ -18,15 +18,18 @@ package datacoord
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	idallocator "github.com/milvus-io/milvus/internal/allocator"
 )
 
 func TestAllocator_Basic(t *testing.T) {
 	ms := newMockRootCoordService()
-	allocator := newRootCoordAllocator(ms)
 	ctx := context.Background()
+	allocator := newRootCoordAllocator(ctx, ms)
 
 	t.Run("Test allocTimestamp", func(t *testing.T) {
 		_, err := allocator.allocTimestamp(ctx)
@@ -40,7 +43,7 @@ func TestAllocator_Basic(t *testing.T) {
 
 	t.Run("Test Unhealthy Root", func(t *testing.T) {
 		ms := newMockRootCoordService()
-		allocator := newRootCoordAllocator(ms)
+		allocator := newRootCoordAllocator(ctx, ms)
 		err := ms.Stop()
 		assert.Nil(t, err)
 
@@ -48,5 +51,20 @@ func TestAllocator_Basic(t *testing.T) {
 		assert.Error(t, err)
 		_, err = allocator.allocID(ctx)
 		assert.Error(t, err)
+		assert.True(t, errors.Is(err, idallocator.ErrIDUnavailable))
+	})
+
+	t.Run("Test allocID cached", func(t *testing.T) {
+		ms := newMockRootCoordService()
+		allocator := newRootCoordAllocator(ctx, ms)
+		first, err := allocator.allocID(ctx)
+		assert.NoError(t, err)
+
+		// the cached ids are allocated after RootCoord stopped
+		err = ms.Stop()
+		assert.Nil(t, err)
+		id, err := allocator.allocID(ctx)
+		assert.NoError(t, err)
+		assert.Greater(t, id, first)
 	})
 }
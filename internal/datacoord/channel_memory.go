@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryHighExpiration is how long a memory high report of a channel lasts without being refreshed,
+// so that the channel is not throttled forever if its DataNode stops sending time ticks
+const memoryHighExpiration = 10 * time.Second
+
+// channelMemoryStates records the channels whose DataNodes report memory high in time tick messages
+type channelMemoryStates struct {
+	mu sync.RWMutex
+	// channel name -> the last time memory high is reported
+	highChannels map[string]time.Time
+}
+
+func newChannelMemoryStates() *channelMemoryStates {
+	return &channelMemoryStates{
+		highChannels: make(map[string]time.Time),
+	}
+}
+
+// update records the memory state reported by the DataNode of channel
+func (s *channelMemoryStates) update(channel string, high bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if high {
+		s.highChannels[channel] = time.Now()
+	} else {
+		delete(s.highChannels, channel)
+	}
+}
+
+// isHigh returns whether the DataNode of channel reports memory high recently
+func (s *channelMemoryStates) isHigh(channel string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	reportTime, ok := s.highChannels[channel]
+	return ok && time.Since(reportTime) < memoryHighExpiration
+}
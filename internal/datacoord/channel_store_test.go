@@ -79,6 +79,10 @@ func (m *mockTxnKv) MultiSaveAndRemoveWithPrefix(saves map[string]string, remova
 	panic("not implemented") // TODO: Implement
 }
 
+func (m *mockTxnKv) WalkWithPrefix(prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	panic("not implemented") // TODO: Implement
+}
+
 func genNodeChannelInfos(id int64, num int) *NodeChannelInfo {
 	channels := make([]*channel, 0, num)
 	for i := 0; i < num; i++ {
@@ -18,6 +18,8 @@ package datacoord
 
 import (
 	"context"
+	"errors"
+	"sort"
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -122,6 +124,21 @@ func (c *Cluster) Flush(ctx context.Context, segments []*datapb.SegmentInfo, mar
 	}
 }
 
+// Import assigns the import task to a datanode and returns its id, the nodes running fewer import tasks
+// are tried first, running is the number of running import tasks of the nodes
+func (c *Cluster) Import(ctx context.Context, req *datapb.ImportTask, running map[int64]int) (int64, error) {
+	sessions := c.sessionManager.GetSessions()
+	sort.Slice(sessions, func(i, j int) bool {
+		return running[sessions[i].info.NodeID] < running[sessions[j].info.NodeID]
+	})
+	for _, session := range sessions {
+		if err := c.sessionManager.Import(ctx, session.info.NodeID, req); err == nil {
+			return session.info.NodeID, nil
+		}
+	}
+	return 0, errors.New("no datanode accepts the import task")
+}
+
 // GetSessions returns all sessions
 func (c *Cluster) GetSessions() []*Session {
 	return c.sessionManager.GetSessions()
@@ -0,0 +1,102 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"go.uber.org/zap"
+)
+
+// checkSegmentDroppable returns an error if the segment is still in use, i.e. it's being written or flushed by
+// datanode, or it's persisted by an import task which is not completed yet, such a segment is dropped only if forced
+func (s *Server) checkSegmentDroppable(segment *SegmentInfo) error {
+	if segment.GetState() != commonpb.SegmentState_Flushed {
+		return fmt.Errorf("segment %d is %s, which is still being written or flushed", segment.GetID(), segment.GetState())
+	}
+	tasks := s.meta.SelectImportTasks(func(task *datapb.ImportTaskInfo) bool {
+		if task.GetState() != commonpb.ImportState_ImportPersisted {
+			return false
+		}
+		for _, segmentID := range task.GetSegmentIDs() {
+			if segmentID == segment.GetID() {
+				return true
+			}
+		}
+		return false
+	})
+	if len(tasks) > 0 {
+		return fmt.Errorf("segment %d is referenced by import task %d", segment.GetID(), tasks[0].GetID())
+	}
+	return nil
+}
+
+// cascadeDropSegment releases the dropped segment from QueryCoord and drops its index builds on IndexCoord,
+// both are no-ops if the segment is not loaded or indexed, so the cascade is retried by dropping the segment again
+func (s *Server) cascadeDropSegment(ctx context.Context, segment *SegmentInfo) error {
+	status, err := s.queryCoordClient.ReleaseSegments(ctx, &querypb.ReleaseSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_ReleaseSegments,
+			SourceID: Params.NodeID,
+		},
+		CollectionID: segment.GetCollectionID(),
+		PartitionIDs: []UniqueID{segment.GetPartitionID()},
+		SegmentIDs:   []UniqueID{segment.GetID()},
+	})
+	if err = VerifyResponse(status, err); err != nil {
+		return fmt.Errorf("failed to release segment %d from QueryCoord: %w", segment.GetID(), err)
+	}
+
+	status, err = s.indexCoordClient.DropSegmentIndex(ctx, &indexpb.DropSegmentIndexRequest{
+		Base: &commonpb.MsgBase{
+			SourceID: Params.NodeID,
+		},
+		SegmentID: segment.GetID(),
+	})
+	if err = VerifyResponse(status, err); err != nil {
+		return fmt.Errorf("failed to drop index builds of segment %d on IndexCoord: %w", segment.GetID(), err)
+	}
+	return nil
+}
+
+// auditDropSegment records who dropped which segment and the outcome, the segment is nil if it's not found
+func auditDropSegment(req *datapb.DropSegmentRequest, segment *SegmentInfo, err error) {
+	fields := []zap.Field{
+		zap.String("operation", "DropSegment"),
+		zap.Int64("sourceID", req.GetBase().GetSourceID()),
+		zap.Int64("segmentID", req.GetSegmentID()),
+		zap.Bool("force", req.GetForce()),
+	}
+	if segment != nil {
+		fields = append(fields,
+			zap.Int64("collectionID", segment.GetCollectionID()),
+			zap.Int64("partitionID", segment.GetPartitionID()),
+			zap.String("state", segment.GetState().String()),
+			zap.Int64("numRows", segment.GetNumOfRows()))
+	}
+	if err != nil {
+		log.Warn("audit", append(fields, zap.Bool("success", false), zap.Error(err))...)
+		return
+	}
+	log.Info("audit", append(fields, zap.Bool("success", true))...)
+}
@@ -36,6 +36,17 @@ func msgDataCoordIsUnhealthy(coordID UniqueID) string {
 	return fmt.Sprintf("data coord %d is not ready", coordID)
 }
 
+func msgDataNodeMemoryHigh(channel string) string {
+	return fmt.Sprintf("memory of DataNode watching channel %s is high, retry later", channel)
+}
+
 func errDataCoordIsUnhealthy(coordID UniqueID) error {
 	return errors.New(msgDataCoordIsUnhealthy(coordID))
 }
+
+// errSegmentStatsMismatched is wrapped by the errors of the segment stats inconsistent with its binlogs
+var errSegmentStatsMismatched = errors.New("segment stats mismatched")
+
+func errSegmentStatsMismatch(segmentID UniqueID, reason string) error {
+	return fmt.Errorf("%w, segmentID = %d: %s", errSegmentStatsMismatched, segmentID, reason)
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package datacoord
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/logutil"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// GcOption garbage collection options
+type GcOption struct {
+	cli           kv.DataKV     // the storage of binlogs
+	rootPath      string        // the root path of binlogs in the storage
+	enabled       bool          // enable garbage collection or not
+	checkInterval time.Duration // each interval
+	// retention is the time the dropped segments are kept for the queries with travel timestamps,
+	// the binlogs and deltalogs of a segment dropped within it are never removed
+	retention time.Duration
+}
+
+// garbageCollector removes the binlogs and the meta of the segments dropped longer than the retention duration
+type garbageCollector struct {
+	option GcOption
+	meta   *meta
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	closeCh   chan struct{}
+}
+
+// newGarbageCollector creates a garbageCollector with meta and option
+func newGarbageCollector(meta *meta, opt GcOption) *garbageCollector {
+	log.Info("GC with option", zap.Bool("enabled", opt.enabled), zap.Duration("interval", opt.checkInterval),
+		zap.Duration("retention", opt.retention))
+	return &garbageCollector{
+		meta:    meta,
+		option:  opt,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// start starts the background collecting loop if gc is enabled
+func (gc *garbageCollector) start() {
+	if gc.option.enabled {
+		if gc.option.cli == nil {
+			log.Warn("DataCoord gc enabled, but storage client is not provided")
+			return
+		}
+		gc.startOnce.Do(func() {
+			gc.wg.Add(1)
+			go gc.work()
+		})
+	}
+}
+
+// work contains actual looping check logic
+func (gc *garbageCollector) work() {
+	defer logutil.LogPanic()
+	defer gc.wg.Done()
+	ticker := time.NewTicker(gc.option.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			gc.clearDropped(time.Now())
+		case <-gc.closeCh:
+			log.Warn("garbage collector quit")
+			return
+		}
+	}
+}
+
+// close stops the collecting loop and waits for it to quit
+func (gc *garbageCollector) close() {
+	gc.stopOnce.Do(func() {
+		close(gc.closeCh)
+		gc.wg.Wait()
+	})
+}
+
+// clearDropped removes the binlogs, statslogs and deltalogs of the segments dropped before the retention window
+// ending at now, and then the segments from meta. A segment whose files fail to be removed is retried next round.
+func (gc *garbageCollector) clearDropped(now time.Time) {
+	boundary := tsoutil.ComposeTS(now.Add(-gc.option.retention).UnixNano()/int64(time.Millisecond), 0)
+	for _, segment := range gc.meta.GetSegmentsDroppedBefore(boundary) {
+		binlogs, err := gc.meta.GetSegmentBinlogs(segment.GetID())
+		if err != nil {
+			log.Warn("GC failed to load binlog paths of dropped segment", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+			continue
+		}
+		paths, err := gc.checkPaths(segment, binlogs)
+		if err != nil {
+			log.Error("GC skips dropped segment whose binlog paths don't belong to it", zap.Int64("segmentID", segment.GetID()),
+				zap.Int32("layoutVersion", segment.GetLayoutVersion()), zap.Error(err))
+			continue
+		}
+		if len(paths) > 0 {
+			if err := gc.option.cli.MultiRemove(paths); err != nil {
+				log.Warn("GC failed to remove binlogs of dropped segment", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+				continue
+			}
+		}
+		if err := gc.meta.RemoveDroppedSegment(segment.GetID()); err != nil {
+			log.Warn("GC failed to remove dropped segment from meta", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+			continue
+		}
+		log.Info("GC removed dropped segment", zap.Int64("segmentID", segment.GetID()), zap.Int("file num", len(paths)))
+	}
+}
+
+// checkPaths returns the paths of the binlogs, statslogs and deltalogs of the segment, and validates that they all
+// belong to the segment in its layout, so that a corrupted meta never makes gc remove the objects of other segments
+func (gc *garbageCollector) checkPaths(segment *SegmentInfo, binlogs *datapb.SegmentBinlogs) ([]string, error) {
+	if err := pathutil.CheckLayoutVersion(segment.GetLayoutVersion()); err != nil {
+		return nil, err
+	}
+	var paths []string
+	check := func(p string, parse func(rootPath string, p string) (*pathutil.BinlogPathInfo, error)) error {
+		info, err := parse(gc.option.rootPath, p)
+		if err != nil {
+			return err
+		}
+		if info.CollectionID != segment.GetCollectionID() || info.PartitionID != segment.GetPartitionID() ||
+			info.SegmentID != segment.GetID() {
+			return fmt.Errorf("%s belongs to segment %d of collection %d partition %d", p, info.SegmentID,
+				info.CollectionID, info.PartitionID)
+		}
+		paths = append(paths, p)
+		return nil
+	}
+	for _, fieldBinlogs := range binlogs.GetFieldBinlogs() {
+		for _, p := range fieldBinlogs.GetBinlogs() {
+			if err := check(p, pathutil.ParseInsertBinlogPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, fieldStatslogs := range binlogs.GetStatslogs() {
+		for _, p := range fieldStatslogs.GetBinlogs() {
+			if err := check(p, pathutil.ParseStatslogPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, deltalog := range binlogs.GetDeltalogs() {
+		if err := check(deltalog.GetDeltaLogPath(), pathutil.ParseDeltalogPath); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package datacoord
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type removeFailDataKV struct {
+	kv.DataKV
+}
+
+func (kv *removeFailDataKV) MultiRemove(keys []string) error {
+	return errors.New("mocked fail")
+}
+
+func TestGarbageCollector_clearDropped(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datacoord_gc")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cli := storage.NewChunkManagerKV(storage.NewLocalChunkManager(dir))
+
+	metaKV := memkv.NewMemoryKV()
+	meta, err := newMeta(metaKV)
+	assert.Nil(t, err)
+
+	rootPath := "files"
+	files := func(segmentID UniqueID) []string {
+		return []string{
+			pathutil.InsertBinlogPath(rootPath, 1, 2, segmentID, 100, 10),
+			pathutil.StatslogPath(rootPath, 1, 2, segmentID, 100, 10),
+			pathutil.DeltalogPath(rootPath, 1, 2, segmentID, 11),
+		}
+	}
+	addSegment := func(segmentID UniqueID, layoutVersion int32, paths []string) {
+		for _, p := range paths {
+			assert.Nil(t, cli.Save(p, "data"))
+		}
+		assert.Nil(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            segmentID,
+			CollectionID:  1,
+			PartitionID:   2,
+			State:         commonpb.SegmentState_Flushed,
+			LayoutVersion: layoutVersion,
+			Binlogs:       []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []string{paths[0]}}},
+			Statslogs:     []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []string{paths[1]}}},
+			Deltalogs:     []*datapb.DeltaLogInfo{{DeltaLogPath: paths[2]}},
+		})))
+	}
+	addSegment(1, pathutil.CurrentLayoutVersion, files(1))
+	addSegment(2, pathutil.LegacyLayoutVersion, files(2))
+	addSegment(3, pathutil.CurrentLayoutVersion, files(3))
+	// segment 4 records a path of segment 3 by mistake
+	addSegment(4, pathutil.CurrentLayoutVersion, []string{files(4)[0], files(3)[1], files(4)[2]})
+	// segment 5 is written with an unknown layout
+	addSegment(5, pathutil.CurrentLayoutVersion+1, files(5))
+	assert.Nil(t, meta.DropSegment(1))
+	assert.Nil(t, meta.DropSegment(2))
+	assert.Nil(t, meta.DropSegment(4))
+	assert.Nil(t, meta.DropSegment(5))
+	assert.Nil(t, meta.GetSegment(1))
+	assert.Equal(t, commonpb.SegmentState_Dropped, meta.dropped.GetSegment(1).GetState())
+
+	// the dropped segments are reloaded as dropped
+	reloaded, err := newMeta(metaKV)
+	assert.Nil(t, err)
+	assert.Nil(t, reloaded.GetSegment(2))
+	assert.Equal(t, 4, len(reloaded.GetSegmentsDroppedBefore(tsoutil.ComposeTS(time.Now().Add(time.Minute).UnixNano()/int64(time.Millisecond), 0))))
+
+	// segment 2 was dropped two hours ago
+	meta.dropped.GetSegment(2).DroppedAt = tsoutil.ComposeTS(time.Now().Add(-2*time.Hour).UnixNano()/int64(time.Millisecond), 0)
+
+	exist := func(segmentID UniqueID) bool {
+		for _, p := range files(segmentID) {
+			if _, err := cli.Load(p); err != nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	gc := newGarbageCollector(meta, GcOption{
+		cli:       &removeFailDataKV{DataKV: cli},
+		rootPath:  rootPath,
+		enabled:   true,
+		retention: time.Hour,
+	})
+	// the segment is kept if its files fail to be removed
+	gc.clearDropped(time.Now())
+	_, err = meta.GetSegmentBinlogs(2)
+	assert.Nil(t, err)
+
+	gc.option.cli = cli
+	gc.clearDropped(time.Now())
+	// segment 1 is within the retention window, its binlogs and deltalogs are all kept
+	assert.True(t, exist(1))
+	binlogs, err := meta.GetSegmentBinlogs(1)
+	assert.Nil(t, err)
+	assert.Equal(t, files(1)[2], binlogs.GetDeltalogs()[0].GetDeltaLogPath())
+	// segment 2 is beyond the retention window
+	assert.False(t, exist(2))
+	_, err = meta.GetSegmentBinlogs(2)
+	assert.NotNil(t, err)
+	// segment 3 is not dropped
+	assert.True(t, exist(3))
+
+	gc.clearDropped(time.Now().Add(2 * time.Hour))
+	assert.False(t, exist(1))
+	assert.True(t, exist(3))
+	// segment 4 and 5 are kept as their paths can't be validated
+	_, err = cli.Load(files(4)[0])
+	assert.Nil(t, err)
+	assert.True(t, exist(5))
+	dropped := meta.GetSegmentsDroppedBefore(tsoutil.ComposeTS(time.Now().Add(3*time.Hour).UnixNano()/int64(time.Millisecond), 0))
+	assert.Equal(t, 2, len(dropped))
+	_, values, err := metaKV.LoadWithPrefix(segmentBinlogPrefix)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(values))
+}
+
+func TestGarbageCollector_startAndClose(t *testing.T) {
+	meta, err := newMeta(memkv.NewMemoryKV())
+	assert.Nil(t, err)
+
+	// not started without storage client
+	gc := newGarbageCollector(meta, GcOption{enabled: true, checkInterval: time.Millisecond, retention: time.Hour})
+	gc.start()
+	gc.close()
+
+	dir, err := ioutil.TempDir("", "datacoord_gc")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	gc = newGarbageCollector(meta, GcOption{
+		cli:           storage.NewChunkManagerKV(storage.NewLocalChunkManager(dir)),
+		enabled:       true,
+		checkInterval: time.Millisecond,
+		retention:     time.Hour,
+	})
+	gc.start()
+	time.Sleep(10 * time.Millisecond)
+	gc.close()
+	gc.close()
+}
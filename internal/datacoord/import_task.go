@@ -0,0 +1,121 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/retry"
+	"go.uber.org/zap"
+)
+
+// importFailedReasonKey is the key of the import failure not caused by a single file in the import task infos
+const importFailedReasonKey = "failed_reason"
+
+// isImportRunning returns whether the import task is waiting for the result from datanode
+func isImportRunning(state commonpb.ImportState) bool {
+	return state == commonpb.ImportState_ImportPending || state == commonpb.ImportState_ImportStarted
+}
+
+// runningImportTasks returns the number of running import tasks of each datanode
+func (s *Server) runningImportTasks() map[int64]int {
+	running := make(map[int64]int)
+	tasks := s.meta.SelectImportTasks(func(task *datapb.ImportTaskInfo) bool {
+		return isImportRunning(task.GetState())
+	})
+	for _, task := range tasks {
+		running[task.GetDatanodeID()]++
+	}
+	return running
+}
+
+// failImportTask marks the running import task failed
+func (s *Server) failImportTask(taskID UniqueID, reason string) error {
+	return s.meta.UpdateImportTask(taskID, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		if !isImportRunning(task.GetState()) {
+			return nil, fmt.Errorf("import task %d is already %s", taskID, task.GetState())
+		}
+		task.State = commonpb.ImportState_ImportFailed
+		task.Infos = append(task.Infos, &commonpb.KeyValuePair{Key: importFailedReasonKey, Value: reason})
+		return nil, nil
+	})
+}
+
+// failNodeImportTasks fails the running import tasks of the datanode, which is offline
+func (s *Server) failNodeImportTasks(nodeID int64) {
+	tasks := s.meta.SelectImportTasks(func(task *datapb.ImportTaskInfo) bool {
+		return task.GetDatanodeID() == nodeID && isImportRunning(task.GetState())
+	})
+	for _, task := range tasks {
+		if err := s.failImportTask(task.GetID(), fmt.Sprintf("datanode %d is offline", nodeID)); err != nil {
+			log.Warn("failed to fail import task", zap.Int64("taskID", task.GetID()), zap.Error(err))
+		}
+	}
+}
+
+// completeImportTask notifies RootCoord the segments of the persisted import task are flushed,
+// so that the indexes of them are built, then the import task is completed
+func (s *Server) completeImportTask(ctx context.Context, taskID UniqueID, segmentIDs []UniqueID) {
+	for _, segmentID := range segmentIDs {
+		err := retry.Do(ctx, func() error {
+			return s.postFlush(ctx, segmentID)
+		})
+		if err != nil {
+			log.Warn("failed to complete import task", zap.Int64("taskID", taskID), zap.Int64("segmentID", segmentID), zap.Error(err))
+			return
+		}
+	}
+	err := s.meta.UpdateImportTask(taskID, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		if task.GetState() != commonpb.ImportState_ImportPersisted {
+			return nil, fmt.Errorf("import task %d is %s", taskID, task.GetState())
+		}
+		task.State = commonpb.ImportState_ImportCompleted
+		return nil, nil
+	})
+	if err != nil {
+		log.Warn("failed to complete import task", zap.Int64("taskID", taskID), zap.Error(err))
+		return
+	}
+	log.Info("import task completed", zap.Int64("taskID", taskID), zap.Int64s("segmentIDs", segmentIDs))
+}
+
+// recoverImportTasks completes the import tasks persisted before restart, and fails the running
+// import tasks whose datanodes are offline
+func (s *Server) recoverImportTasks(ctx context.Context) {
+	alive := make(map[int64]struct{})
+	for _, session := range s.cluster.GetSessions() {
+		alive[session.info.NodeID] = struct{}{}
+	}
+	tasks := s.meta.SelectImportTasks(func(task *datapb.ImportTaskInfo) bool {
+		return task.GetState() == commonpb.ImportState_ImportPersisted || isImportRunning(task.GetState())
+	})
+	for _, task := range tasks {
+		if task.GetState() == commonpb.ImportState_ImportPersisted {
+			go s.completeImportTask(ctx, task.GetID(), task.GetSegmentIDs())
+			continue
+		}
+		if _, ok := alive[task.GetDatanodeID()]; !ok {
+			if err := s.failImportTask(task.GetID(), fmt.Sprintf("datanode %d is offline", task.GetDatanodeID())); err != nil {
+				log.Warn("failed to fail import task", zap.Int64("taskID", task.GetID()), zap.Error(err))
+			}
+		}
+	}
+}
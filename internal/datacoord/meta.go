@@ -19,30 +19,46 @@ package datacoord
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 )
 
 const (
 	metaPrefix           = "datacoord-meta"
 	segmentPrefix        = metaPrefix + "/s"
+	channelCPPrefix      = metaPrefix + "/channel-cp"
+	importTaskPrefix     = metaPrefix + "/import-task"
+	segmentBinlogPrefix  = metaPrefix + "/binlog"
 	handoffSegmentPrefix = "querycoord-handoff"
+
+	// reloadPaginationSize is the number of segment records fetched at a time on reload
+	reloadPaginationSize = 1000
+	// migrateBatchSize is the number of legacy segment records rewritten in one transaction on reload,
+	// each record takes two kvs and etcd limits the operations of a transaction to 128 by default
+	migrateBatchSize = 50
 )
 
 type meta struct {
 	sync.RWMutex
 	client      kv.TxnKV                            // client of a reliable kv service, i.e. etcd client
 	collections map[UniqueID]*datapb.CollectionInfo // collection id to collection info
-	segments    *SegmentsInfo                       // segment id to segment info
+	segments    *SegmentsInfo                       // segment id to segment info, without binlog paths
+	dropped     *SegmentsInfo                       // segment id to dropped segment info kept until garbage collected
+	channelCPs  map[string]*internalpb.MsgPosition  // vchannel name to channel checkpoint
+	importTasks map[UniqueID]*datapb.ImportTaskInfo // import task id to import task info
 }
 
 // NewMeta create meta from provided `kv.TxnKV`
@@ -51,6 +67,9 @@ func newMeta(kv kv.TxnKV) (*meta, error) {
 		client:      kv,
 		collections: make(map[UniqueID]*datapb.CollectionInfo),
 		segments:    NewSegmentsInfo(),
+		dropped:     NewSegmentsInfo(),
+		channelCPs:  make(map[string]*internalpb.MsgPosition),
+		importTasks: make(map[UniqueID]*datapb.ImportTaskInfo),
 	}
 	err := mt.reloadFromKV()
 	if err != nil {
@@ -61,18 +80,75 @@ func newMeta(kv kv.TxnKV) (*meta, error) {
 
 // reloadFromKV load meta from KV storage
 func (m *meta) reloadFromKV() error {
-	_, values, err := m.client.LoadWithPrefix(segmentPrefix)
+	// segment records are walked page by page and only the compact fields are kept in memory,
+	// the binlog paths are loaded on demand by GetSegmentBinlogs
+	migrated := make(map[string]string)
+	migratedNum := 0
+	err := m.client.WalkWithPrefix(segmentPrefix+"/", reloadPaginationSize, func(_ []byte, value []byte) error {
+		segmentInfo := &datapb.SegmentInfo{}
+		if err := proto.Unmarshal(value, segmentInfo); err != nil {
+			return fmt.Errorf("DataCoord reloadFromKV UnMarshal datapb.SegmentInfo err:%w", err)
+		}
+		segment, binlogs := detachSegmentBinlogs(NewSegmentInfo(segmentInfo))
+		if binlogs != nil {
+			// legacy record with inline binlog paths, move the paths to the binlog key
+			if err := buildSegmentInfoKv(segment, migrated); err != nil {
+				return err
+			}
+			if err := buildSegmentBinlogsKv(segment, binlogs, migrated); err != nil {
+				return err
+			}
+			migratedNum++
+			if migratedNum%migrateBatchSize == 0 {
+				if err := m.client.MultiSave(migrated); err != nil {
+					return err
+				}
+				migrated = make(map[string]string)
+			}
+		}
+		if segment.GetState() == commonpb.SegmentState_Dropped {
+			m.dropped.SetSegment(segment.GetID(), segment)
+			return nil
+		}
+		m.segments.SetSegment(segment.GetID(), segment)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
+	if len(migrated) > 0 {
+		if err := m.client.MultiSave(migrated); err != nil {
+			return err
+		}
+	}
+	if migratedNum > 0 {
+		log.Info("DataCoord moved binlog paths of legacy segment records", zap.Int("segment num", migratedNum))
+	}
 
+	_, values, err := m.client.LoadWithPrefix(channelCPPrefix)
+	if err != nil {
+		return err
+	}
 	for _, value := range values {
-		segmentInfo := &datapb.SegmentInfo{}
-		err = proto.Unmarshal([]byte(value), segmentInfo)
+		pos := &internalpb.MsgPosition{}
+		err = proto.Unmarshal([]byte(value), pos)
 		if err != nil {
-			return fmt.Errorf("DataCoord reloadFromKV UnMarshal datapb.SegmentInfo err:%w", err)
+			return fmt.Errorf("DataCoord reloadFromKV UnMarshal internalpb.MsgPosition err:%w", err)
+		}
+		m.channelCPs[pos.GetChannelName()] = pos
+	}
+
+	_, values, err = m.client.LoadWithPrefix(importTaskPrefix)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		task := &datapb.ImportTaskInfo{}
+		err = proto.Unmarshal([]byte(value), task)
+		if err != nil {
+			return fmt.Errorf("DataCoord reloadFromKV UnMarshal datapb.ImportTaskInfo err:%w", err)
 		}
-		m.segments.SetSegment(segmentInfo.GetID(), NewSegmentInfo(segmentInfo))
+		m.importTasks[task.GetID()] = task
 	}
 
 	return nil
@@ -97,6 +173,17 @@ func (m *meta) GetCollection(collectionID UniqueID) *datapb.CollectionInfo {
 	return collection
 }
 
+// GetCollectionTTL returns the time to live of the collection data, false if the collection or its ttl is not set
+func (m *meta) GetCollectionTTL(collectionID UniqueID) (time.Duration, bool) {
+	m.RLock()
+	defer m.RUnlock()
+	collection, ok := m.collections[collectionID]
+	if !ok {
+		return 0, false
+	}
+	return common.NewCollectionProperties(collection.GetProperties()).TTL()
+}
+
 // GetNumRowsOfCollection returns total rows count of segments belongs to provided collection
 func (m *meta) GetNumRowsOfCollection(collectionID UniqueID) int64 {
 	m.RLock()
@@ -112,17 +199,46 @@ func (m *meta) GetNumRowsOfCollection(collectionID UniqueID) int64 {
 }
 
 // AddSegment records segment info, persisting info into kv store
+// the binlog paths of segment, if any, are persisted separately and not kept in memory
 func (m *meta) AddSegment(segment *SegmentInfo) error {
 	m.Lock()
 	defer m.Unlock()
-	m.segments.SetSegment(segment.GetID(), segment)
-	if err := m.saveSegmentInfo(segment); err != nil {
+	segment, binlogs := detachSegmentBinlogs(segment)
+	kvs := make(map[string]string)
+	if err := buildSegmentKvs(segment, kvs); err != nil {
 		return err
 	}
-	return nil
+	if binlogs != nil {
+		if err := buildSegmentBinlogsKv(segment, binlogs, kvs); err != nil {
+			return err
+		}
+	}
+	m.segments.SetSegment(segment.GetID(), segment)
+	return m.client.MultiSave(kvs)
+}
+
+// GetSegmentBinlogs loads the binlog paths of the segment from kv store, the paths are not cached in memory.
+// The paths of a dropped segment are loadable until the segment is garbage collected.
+func (m *meta) GetSegmentBinlogs(segmentID UniqueID) (*datapb.SegmentBinlogs, error) {
+	m.RLock()
+	defer m.RUnlock()
+	segment := m.segments.GetSegment(segmentID)
+	if segment == nil {
+		segment = m.dropped.GetSegment(segmentID)
+	}
+	if segment == nil {
+		return nil, fmt.Errorf("segment %d not found", segmentID)
+	}
+	binlogs, err := m.loadSegmentBinlogs(segment)
+	if err != nil {
+		return nil, err
+	}
+	binlogs.NumOfRows = segment.GetNumOfRows()
+	return binlogs, nil
 }
 
-// DropSegment remove segment with provided id, etcd persistence also removed
+// DropSegment marks the segment with provided id dropped, the dropped segment is no longer served
+// but kept with its binlog paths until it's garbage collected after the retention duration
 func (m *meta) DropSegment(segmentID UniqueID) error {
 	m.Lock()
 	defer m.Unlock()
@@ -130,10 +246,54 @@ func (m *meta) DropSegment(segmentID UniqueID) error {
 	if segment == nil {
 		return nil
 	}
+	droppedAt := tsoutil.ComposeTS(time.Now().UnixNano()/int64(time.Millisecond), 0)
+	segment = segment.Clone(SetState(commonpb.SegmentState_Dropped), SetDroppedAt(droppedAt))
+	kvs := make(map[string]string)
+	if err := buildSegmentInfoKv(segment, kvs); err != nil {
+		return err
+	}
+	if err := m.client.MultiSave(kvs); err != nil {
+		return err
+	}
 	m.segments.DropSegment(segmentID)
+	m.dropped.SetSegment(segmentID, segment)
+	return nil
+}
+
+// GetSegmentsDroppedBefore returns the dropped segments whose dropped time is before ts
+func (m *meta) GetSegmentsDroppedBefore(ts Timestamp) []*SegmentInfo {
+	m.RLock()
+	defer m.RUnlock()
+	var ret []*SegmentInfo
+	for _, segment := range m.dropped.GetSegments() {
+		if segment.GetDroppedAt() < ts {
+			ret = append(ret, segment)
+		}
+	}
+	return ret
+}
+
+// GetDroppedSegment returns the dropped segment with provided id, which is not garbage collected yet
+// if not segment is found, nil will be returned
+func (m *meta) GetDroppedSegment(segmentID UniqueID) *SegmentInfo {
+	m.RLock()
+	defer m.RUnlock()
+	return m.dropped.GetSegment(segmentID)
+}
+
+// RemoveDroppedSegment removes the dropped segment with provided id, etcd persistence of its info
+// and binlog paths also removed
+func (m *meta) RemoveDroppedSegment(segmentID UniqueID) error {
+	m.Lock()
+	defer m.Unlock()
+	segment := m.dropped.GetSegment(segmentID)
+	if segment == nil {
+		return nil
+	}
 	if err := m.removeSegmentInfo(segment); err != nil {
 		return err
 	}
+	m.dropped.DropSegment(segmentID)
 	return nil
 }
 
@@ -180,39 +340,27 @@ func (m *meta) UpdateFlushSegmentsInfo(segmentID UniqueID, flushed bool,
 		modSegments[segmentID] = clonedSegment
 	}
 
-	currBinlogs := clonedSegment.GetBinlogs()
-
-	var getFieldBinlogs = func(id UniqueID, binlogs []*datapb.FieldBinlog) *datapb.FieldBinlog {
-		for _, binlog := range binlogs {
-			if id == binlog.GetFieldID() {
-				return binlog
-			}
+	if len(binlogs) > 0 || len(statslogs) > 0 || len(deltalogs) > 0 || flushed {
+		segBinlogs, err := m.loadSegmentBinlogs(segment)
+		if err != nil {
+			return err
 		}
-		return nil
-	}
-	// binlogs
-	for _, tBinlogs := range binlogs {
-		fieldBinlogs := getFieldBinlogs(tBinlogs.GetFieldID(), currBinlogs)
-		if fieldBinlogs == nil {
-			currBinlogs = append(currBinlogs, tBinlogs)
-		} else {
-			fieldBinlogs.Binlogs = append(fieldBinlogs.Binlogs, tBinlogs.Binlogs...)
+		mergeSegmentBinlogs(segBinlogs, binlogs, statslogs, deltalogs)
+		for _, cp := range checkpoints {
+			if cp.GetSegmentID() != segmentID {
+				continue
+			}
+			if err := checkBinlogRows(segmentID, segBinlogs, cp.GetNumOfRows(), flushed); err != nil {
+				return err
+			}
 		}
-	}
-	clonedSegment.Binlogs = currBinlogs
-	// statlogs
-	currStatsLogs := clonedSegment.GetStatslogs()
-	for _, tStatsLogs := range statslogs {
-		fieldStatsLog := getFieldBinlogs(tStatsLogs.GetFieldID(), currStatsLogs)
-		if fieldStatsLog == nil {
-			currStatsLogs = append(currStatsLogs, tStatsLogs)
-		} else {
-			fieldStatsLog.Binlogs = append(fieldStatsLog.Binlogs, tStatsLogs.Binlogs...)
+		if len(binlogs) > 0 || len(statslogs) > 0 || len(deltalogs) > 0 {
+			if err := buildSegmentBinlogsKv(segment, segBinlogs, kv); err != nil {
+				return err
+			}
+			clonedSegment.BinlogSize = binlogSize(segBinlogs.GetFieldBinlogs())
 		}
 	}
-	clonedSegment.Statslogs = currStatsLogs
-	// deltalogs
-	clonedSegment.Deltalogs = append(clonedSegment.Deltalogs, deltalogs...)
 
 	modSegments[segmentID] = clonedSegment
 
@@ -255,15 +403,14 @@ func (m *meta) UpdateFlushSegmentsInfo(segmentID UniqueID, flushed bool,
 		modSegments[cp.GetSegmentID()] = s
 	}
 
-	for id := range modSegments {
-		if segment := m.segments.GetSegment(id); segment != nil {
-			segBytes, err := proto.Marshal(segment.SegmentInfo)
-			if err != nil {
-				return fmt.Errorf("DataCoord UpdateFlushSegmentsInfo segmentID:%d, marshal failed:%w", segment.GetID(), err)
-			}
-			key := buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
-			kv[key] = string(segBytes)
+	// persist the updated segments, not the ones still in memory
+	for _, segment := range modSegments {
+		segBytes, err := proto.Marshal(segment.SegmentInfo)
+		if err != nil {
+			return fmt.Errorf("DataCoord UpdateFlushSegmentsInfo segmentID:%d, marshal failed:%w", segment.GetID(), err)
 		}
+		key := buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
+		kv[key] = string(segBytes)
 	}
 
 	if len(kv) == 0 {
@@ -281,6 +428,122 @@ func (m *meta) UpdateFlushSegmentsInfo(segmentID UniqueID, flushed bool,
 	return nil
 }
 
+// UpdateChannelCheckpoint persists the checkpoint of vchannel reported by DataNode,
+// the checkpoint never moves backward
+func (m *meta) UpdateChannelCheckpoint(channel string, pos *internalpb.MsgPosition) error {
+	m.Lock()
+	defer m.Unlock()
+	if old, ok := m.channelCPs[channel]; ok && pos.GetTimestamp() <= old.GetTimestamp() {
+		return nil
+	}
+	pos = proto.Clone(pos).(*internalpb.MsgPosition)
+	pos.ChannelName = channel
+	posBytes, err := proto.Marshal(pos)
+	if err != nil {
+		return fmt.Errorf("DataCoord UpdateChannelCheckpoint channel:%s, marshal failed:%w", channel, err)
+	}
+	if err := m.client.Save(buildChannelCPPath(channel), string(posBytes)); err != nil {
+		return err
+	}
+	m.channelCPs[channel] = pos
+	return nil
+}
+
+// GetChannelCheckpoint returns the checkpoint of vchannel, nil if DataNode never reports it
+func (m *meta) GetChannelCheckpoint(channel string) *internalpb.MsgPosition {
+	m.RLock()
+	defer m.RUnlock()
+	return m.channelCPs[channel]
+}
+
+// AddImportTask persists a new import task
+func (m *meta) AddImportTask(task *datapb.ImportTaskInfo) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.importTasks[task.GetID()]; ok {
+		return fmt.Errorf("import task %d already exists", task.GetID())
+	}
+	task = proto.Clone(task).(*datapb.ImportTaskInfo)
+	kvs := make(map[string]string)
+	if err := buildImportTaskKvs(task, kvs); err != nil {
+		return err
+	}
+	if err := m.client.MultiSave(kvs); err != nil {
+		return err
+	}
+	m.importTasks[task.GetID()] = task
+	return nil
+}
+
+// UpdateImportTask applies update to a copy of the import task, the updated task and the segments
+// returned by update are persisted in one transaction
+func (m *meta) UpdateImportTask(taskID UniqueID, update func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error)) error {
+	m.Lock()
+	defer m.Unlock()
+	task, ok := m.importTasks[taskID]
+	if !ok {
+		return fmt.Errorf("import task %d not found", taskID)
+	}
+	task = proto.Clone(task).(*datapb.ImportTaskInfo)
+	segments, err := update(task)
+	if err != nil {
+		return err
+	}
+
+	kvs := make(map[string]string)
+	if err := buildImportTaskKvs(task, kvs); err != nil {
+		return err
+	}
+	added := make([]*SegmentInfo, 0, len(segments))
+	for _, s := range segments {
+		if m.segments.GetSegment(s.GetID()) != nil {
+			return fmt.Errorf("segment %d already exists", s.GetID())
+		}
+		segment, binlogs := detachSegmentBinlogs(NewSegmentInfo(s))
+		if err := buildSegmentKvs(segment, kvs); err != nil {
+			return err
+		}
+		if binlogs != nil {
+			if err := buildSegmentBinlogsKv(segment, binlogs, kvs); err != nil {
+				return err
+			}
+		}
+		added = append(added, segment)
+	}
+	if err := m.client.MultiSave(kvs); err != nil {
+		return err
+	}
+	m.importTasks[taskID] = task
+	for _, segment := range added {
+		m.segments.SetSegment(segment.GetID(), segment)
+	}
+	return nil
+}
+
+// GetImportTask returns a copy of the import task, nil if not found
+func (m *meta) GetImportTask(taskID UniqueID) *datapb.ImportTaskInfo {
+	m.RLock()
+	defer m.RUnlock()
+	task, ok := m.importTasks[taskID]
+	if !ok {
+		return nil
+	}
+	return proto.Clone(task).(*datapb.ImportTaskInfo)
+}
+
+// SelectImportTasks returns copies of the import tasks selected by selector
+func (m *meta) SelectImportTasks(selector func(task *datapb.ImportTaskInfo) bool) []*datapb.ImportTaskInfo {
+	m.RLock()
+	defer m.RUnlock()
+	var tasks []*datapb.ImportTaskInfo
+	for _, task := range m.importTasks {
+		if selector(task) {
+			tasks = append(tasks, proto.Clone(task).(*datapb.ImportTaskInfo))
+		}
+	}
+	return tasks
+}
+
 // ListSegmentIDs list all segment ids stored in meta (no collection filter)
 func (m *meta) ListSegmentIDs() []UniqueID {
 	m.RLock()
@@ -434,33 +697,41 @@ func (m *meta) MoveSegmentBinlogs(segmentID UniqueID, oldPathPrefix string, fiel
 	m.Lock()
 	defer m.Unlock()
 
-	m.segments.AddSegmentBinlogs(segmentID, field2Binlogs)
-
 	removals := []string{oldPathPrefix}
 	kv := make(map[string]string)
 
 	if segment := m.segments.GetSegment(segmentID); segment != nil {
-		k := buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
-		v, err := proto.Marshal(segment.SegmentInfo)
+		binlogs, err := m.loadSegmentBinlogs(segment)
 		if err != nil {
+			return err
+		}
+		fieldBinlogs := make([]*datapb.FieldBinlog, 0, len(field2Binlogs))
+		for fieldID, paths := range field2Binlogs {
+			fieldBinlogs = append(fieldBinlogs, &datapb.FieldBinlog{FieldID: fieldID, Binlogs: paths})
+		}
+		mergeSegmentBinlogs(binlogs, fieldBinlogs, nil, nil)
+		if err := buildSegmentBinlogsKv(segment, binlogs, kv); err != nil {
 			log.Error("DataCoord MoveSegmentBinlogs marshal failed", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
-			return fmt.Errorf("DataCoord MoveSegmentBinlogs segmentID:%d, marshal failed:%w", segment.GetID(), err)
+			return err
 		}
-		kv[k] = string(v)
 	}
 	return m.client.MultiSaveAndRemoveWithPrefix(kv, removals)
 }
 
 // saveSegmentInfo utility function saving segment info into kv store
 func (m *meta) saveSegmentInfo(segment *SegmentInfo) error {
-	segBytes, err := proto.Marshal(segment.SegmentInfo)
-	if err != nil {
-		log.Error("DataCoord saveSegmentInfo marshal failed", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
-		return fmt.Errorf("DataCoord saveSegmentInfo segmentID:%d, marshal failed:%w", segment.GetID(), err)
-	}
 	kvs := make(map[string]string)
-	dataKey := buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
-	kvs[dataKey] = string(segBytes)
+	if err := buildSegmentKvs(segment, kvs); err != nil {
+		return err
+	}
+	return m.client.MultiSave(kvs)
+}
+
+// buildSegmentKvs adds the kvs of segment info and its handoff info into kvs
+func buildSegmentKvs(segment *SegmentInfo, kvs map[string]string) error {
+	if err := buildSegmentInfoKv(segment, kvs); err != nil {
+		return err
+	}
 	if segment.State == commonpb.SegmentState_Flushed {
 		handoffSegmentInfo := &querypb.SegmentInfo{
 			SegmentID:    segment.ID,
@@ -477,15 +748,242 @@ func (m *meta) saveSegmentInfo(segment *SegmentInfo) error {
 		queryKey := buildQuerySegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
 		kvs[queryKey] = string(handoffSegBytes)
 	}
+	return nil
+}
 
-	return m.client.MultiSave(kvs)
+// buildSegmentInfoKv adds the kv of segment info into kvs
+func buildSegmentInfoKv(segment *SegmentInfo, kvs map[string]string) error {
+	segBytes, err := proto.Marshal(segment.SegmentInfo)
+	if err != nil {
+		log.Error("DataCoord saveSegmentInfo marshal failed", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+		return fmt.Errorf("DataCoord saveSegmentInfo segmentID:%d, marshal failed:%w", segment.GetID(), err)
+	}
+	dataKey := buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
+	kvs[dataKey] = string(segBytes)
+	return nil
+}
+
+// buildSegmentBinlogsKv adds the kv of the binlog paths of segment into kvs
+func buildSegmentBinlogsKv(segment *SegmentInfo, binlogs *datapb.SegmentBinlogs, kvs map[string]string) error {
+	binlogs = &datapb.SegmentBinlogs{
+		SegmentID:    segment.GetID(),
+		FieldBinlogs: binlogs.GetFieldBinlogs(),
+		Statslogs:    binlogs.GetStatslogs(),
+		Deltalogs:    binlogs.GetDeltalogs(),
+	}
+	bytes, err := proto.Marshal(binlogs)
+	if err != nil {
+		return fmt.Errorf("DataCoord save binlogs of segmentID:%d, marshal failed:%w", segment.GetID(), err)
+	}
+	kvs[buildSegmentBinlogsPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())] = string(bytes)
+	return nil
+}
+
+// loadSegmentBinlogs loads the binlog paths of segment from kv store, an empty one is returned if no binlog is saved
+func (m *meta) loadSegmentBinlogs(segment *SegmentInfo) (*datapb.SegmentBinlogs, error) {
+	key := buildSegmentBinlogsPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
+	// Load can not tell a missing key from a failure, so load with the key as prefix and pick the exact one,
+	// the kv store may prepend its root path to the returned keys
+	keys, values, err := m.client.LoadWithPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+	binlogs := &datapb.SegmentBinlogs{SegmentID: segment.GetID()}
+	for i := range keys {
+		if !strings.HasSuffix(keys[i], key) {
+			continue
+		}
+		if err := proto.Unmarshal([]byte(values[i]), binlogs); err != nil {
+			return nil, fmt.Errorf("DataCoord load binlogs of segmentID:%d, unmarshal failed:%w", segment.GetID(), err)
+		}
+		break
+	}
+	return binlogs, nil
 }
 
-// removeSegmentInfo utility function removing segment info from kv store
+// detachSegmentBinlogs returns a copy of segment without binlog paths and the detached paths,
+// segment itself is returned with nil paths if it has no binlog
+func detachSegmentBinlogs(segment *SegmentInfo) (*SegmentInfo, *datapb.SegmentBinlogs) {
+	info := segment.SegmentInfo
+	if len(info.GetBinlogs()) == 0 && len(info.GetStatslogs()) == 0 && len(info.GetDeltalogs()) == 0 {
+		return segment, nil
+	}
+	binlogs := &datapb.SegmentBinlogs{
+		SegmentID:    info.GetID(),
+		FieldBinlogs: info.GetBinlogs(),
+		Statslogs:    info.GetStatslogs(),
+		Deltalogs:    info.GetDeltalogs(),
+	}
+	detached := segment.ShadowClone()
+	detached.SegmentInfo = trimSegmentInfo(info)
+	return detached, binlogs
+}
+
+// mergeSegmentBinlogs appends the paths to the ones of the same field in segment binlogs,
+// the paths already in segment binlogs are skipped so that a retried report is not merged twice
+func mergeSegmentBinlogs(segBinlogs *datapb.SegmentBinlogs, binlogs, statslogs []*datapb.FieldBinlog, deltalogs []*datapb.DeltaLogInfo) {
+	var mergeFieldBinlogs = func(curr, added []*datapb.FieldBinlog) []*datapb.FieldBinlog {
+		for _, tBinlogs := range added {
+			var fieldBinlogs *datapb.FieldBinlog
+			for _, fb := range curr {
+				if fb.GetFieldID() == tBinlogs.GetFieldID() {
+					fieldBinlogs = fb
+					break
+				}
+			}
+			if fieldBinlogs == nil {
+				fieldBinlogs = &datapb.FieldBinlog{FieldID: tBinlogs.GetFieldID()}
+				curr = append(curr, fieldBinlogs)
+			}
+			appendFieldBinlogs(fieldBinlogs, tBinlogs)
+		}
+		return curr
+	}
+	segBinlogs.FieldBinlogs = mergeFieldBinlogs(segBinlogs.FieldBinlogs, binlogs)
+	segBinlogs.Statslogs = mergeFieldBinlogs(segBinlogs.Statslogs, statslogs)
+
+	existed := make(map[string]struct{}, len(segBinlogs.Deltalogs))
+	for _, deltalog := range segBinlogs.Deltalogs {
+		existed[deltalog.GetDeltaLogPath()] = struct{}{}
+	}
+	for _, deltalog := range deltalogs {
+		if _, ok := existed[deltalog.GetDeltaLogPath()]; !ok {
+			segBinlogs.Deltalogs = append(segBinlogs.Deltalogs, deltalog)
+		}
+	}
+}
+
+// appendFieldBinlogs appends the paths of added not in curr, the entries nums and log sizes
+// are kept only if they are reported for all the paths
+func appendFieldBinlogs(curr, added *datapb.FieldBinlog) {
+	entriesReported := len(curr.EntriesNums) == len(curr.Binlogs) && len(added.EntriesNums) == len(added.Binlogs)
+	sizesReported := len(curr.LogSizes) == len(curr.Binlogs) && len(added.LogSizes) == len(added.Binlogs)
+
+	existed := make(map[string]struct{}, len(curr.Binlogs))
+	for _, path := range curr.Binlogs {
+		existed[path] = struct{}{}
+	}
+	for i, path := range added.Binlogs {
+		if _, ok := existed[path]; ok {
+			continue
+		}
+		curr.Binlogs = append(curr.Binlogs, path)
+		if entriesReported {
+			curr.EntriesNums = append(curr.EntriesNums, added.EntriesNums[i])
+		}
+		if sizesReported {
+			curr.LogSizes = append(curr.LogSizes, added.LogSizes[i])
+		}
+	}
+	if !entriesReported {
+		curr.EntriesNums = nil
+	}
+	if !sizesReported {
+		curr.LogSizes = nil
+	}
+}
+
+// binlogRows returns the number of rows in the binlogs of the field,
+// false is returned if the entries num is not reported for all the binlogs
+func binlogRows(fieldBinlog *datapb.FieldBinlog) (int64, bool) {
+	if len(fieldBinlog.GetEntriesNums()) != len(fieldBinlog.GetBinlogs()) {
+		return 0, false
+	}
+	var rows int64
+	for _, num := range fieldBinlog.GetEntriesNums() {
+		rows += num
+	}
+	return rows, true
+}
+
+// checkFieldBinlogs checks the entries nums and log sizes of a report, which are either
+// empty or given for each binlog, and the fields have the same number of rows in the report
+func checkFieldBinlogs(segmentID UniqueID, binlogs []*datapb.FieldBinlog) error {
+	var reportedRows int64 = -1
+	for _, fieldBinlog := range binlogs {
+		numBinlogs := len(fieldBinlog.GetBinlogs())
+		if n := len(fieldBinlog.GetEntriesNums()); n != 0 && n != numBinlogs {
+			return errSegmentStatsMismatch(segmentID,
+				fmt.Sprintf("%d entries nums for %d binlogs of field %d", n, numBinlogs, fieldBinlog.GetFieldID()))
+		}
+		if n := len(fieldBinlog.GetLogSizes()); n != 0 && n != numBinlogs {
+			return errSegmentStatsMismatch(segmentID,
+				fmt.Sprintf("%d log sizes for %d binlogs of field %d", n, numBinlogs, fieldBinlog.GetFieldID()))
+		}
+		for _, num := range fieldBinlog.GetEntriesNums() {
+			if num < 0 {
+				return errSegmentStatsMismatch(segmentID,
+					fmt.Sprintf("negative entries num %d of field %d", num, fieldBinlog.GetFieldID()))
+			}
+		}
+		rows, ok := binlogRows(fieldBinlog)
+		if !ok || numBinlogs == 0 {
+			continue
+		}
+		if reportedRows >= 0 && rows != reportedRows {
+			return errSegmentStatsMismatch(segmentID,
+				fmt.Sprintf("%d rows reported for field %d, %d rows for the other fields", rows, fieldBinlog.GetFieldID(), reportedRows))
+		}
+		reportedRows = rows
+	}
+	return nil
+}
+
+// binlogSize returns the total size in bytes of the binlogs reported with their sizes
+func binlogSize(fieldBinlogs []*datapb.FieldBinlog) int64 {
+	var size int64
+	for _, fieldBinlog := range fieldBinlogs {
+		for _, logSize := range fieldBinlog.GetLogSizes() {
+			size += logSize
+		}
+	}
+	return size
+}
+
+// checkBinlogRows checks that the fields of the segment have the same number of rows in their binlogs,
+// which never exceeds numRows reported for the segment and equals it once the segment is flushed.
+// The fields whose entries nums are not reported are skipped
+func checkBinlogRows(segmentID UniqueID, segBinlogs *datapb.SegmentBinlogs, numRows int64, flushed bool) error {
+	for _, fieldBinlog := range segBinlogs.GetFieldBinlogs() {
+		rows, ok := binlogRows(fieldBinlog)
+		if !ok {
+			continue
+		}
+		if rows > numRows || (flushed && rows != numRows) {
+			return errSegmentStatsMismatch(segmentID,
+				fmt.Sprintf("%d rows in the binlogs of field %d, %d rows reported", rows, fieldBinlog.GetFieldID(), numRows))
+		}
+		if flushed {
+			continue
+		}
+		for _, other := range segBinlogs.GetFieldBinlogs() {
+			if otherRows, ok := binlogRows(other); ok && otherRows != rows {
+				return errSegmentStatsMismatch(segmentID,
+					fmt.Sprintf("%d rows in the binlogs of field %d, %d rows in the ones of field %d",
+						rows, fieldBinlog.GetFieldID(), otherRows, other.GetFieldID()))
+			}
+		}
+	}
+	return nil
+}
+
+// buildImportTaskKvs adds the kv of import task into kvs
+func buildImportTaskKvs(task *datapb.ImportTaskInfo, kvs map[string]string) error {
+	taskBytes, err := proto.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("DataCoord save import task:%d, marshal failed:%w", task.GetID(), err)
+	}
+	kvs[buildImportTaskPath(task.GetID())] = string(taskBytes)
+	return nil
+}
+
+// removeSegmentInfo utility function removing segment info and its binlog paths from kv store
 // Note that nil parameter will cause panicking
 func (m *meta) removeSegmentInfo(segment *SegmentInfo) error {
-	key := buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID())
-	return m.client.Remove(key)
+	return m.client.MultiRemove([]string{
+		buildSegmentPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID()),
+		buildSegmentBinlogsPath(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID()),
+	})
 }
 
 // saveKvTxn batch save kvs
@@ -498,6 +996,21 @@ func buildSegmentPath(collectionID UniqueID, partitionID UniqueID, segmentID Uni
 	return fmt.Sprintf("%s/%d/%d/%d", segmentPrefix, collectionID, partitionID, segmentID)
 }
 
+// buildSegmentBinlogsPath common logic mapping the binlog paths of segment to corresponding key in kv store
+func buildSegmentBinlogsPath(collectionID UniqueID, partitionID UniqueID, segmentID UniqueID) string {
+	return fmt.Sprintf("%s/%d/%d/%d", segmentBinlogPrefix, collectionID, partitionID, segmentID)
+}
+
+// buildChannelCPPath common logic mapping channel checkpoint to corresponding key in kv store
+func buildChannelCPPath(channel string) string {
+	return fmt.Sprintf("%s/%s", channelCPPrefix, channel)
+}
+
+// buildImportTaskPath common logic mapping import task to corresponding key in kv store
+func buildImportTaskPath(taskID UniqueID) string {
+	return fmt.Sprintf("%s/%d", importTaskPrefix, taskID)
+}
+
 // buildQuerySegmentPath common logic mapping segment info to corresponding key of queryCoord in kv store
 func buildQuerySegmentPath(collectionID UniqueID, partitionID UniqueID, segmentID UniqueID) string {
 	return fmt.Sprintf("%s/%d/%d/%d", handoffSegmentPrefix, collectionID, partitionID, segmentID)
@@ -18,11 +18,16 @@ package datacoord
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus/internal/common"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -148,9 +153,21 @@ func TestMeta_Basic(t *testing.T) {
 		// nil, since Save error not injected
 		err = meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{}))
 		assert.Nil(t, err)
-		// error injected
+		// nil, since the dropped segment is kept
 		err = meta.DropSegment(0)
+		assert.Nil(t, err)
+		// error injected
+		err = meta.RemoveDroppedSegment(0)
 		assert.NotNil(t, err)
+
+		// dropping fails with Save error injected
+		fkv3 := &saveFailKV{TxnKV: memkv.NewMemoryKV()}
+		meta, err = newMeta(fkv3)
+		assert.Nil(t, err)
+		meta.segments.SetSegment(1, NewSegmentInfo(&datapb.SegmentInfo{ID: 1}))
+		err = meta.DropSegment(1)
+		assert.NotNil(t, err)
+		assert.NotNil(t, meta.GetSegment(1))
 	})
 
 	t.Run("Test GetCount", func(t *testing.T) {
@@ -234,11 +251,44 @@ func TestUpdateFlushSegmentsInfo(t *testing.T) {
 		expected := &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{
 			ID: 1, State: commonpb.SegmentState_Flushing, NumOfRows: 10,
 			StartPosition: &internalpb.MsgPosition{MsgID: []byte{1, 2, 3}},
-			Binlogs:       []*datapb.FieldBinlog{{FieldID: 1, Binlogs: []string{"binlog0", "binlog1"}}},
-			Statslogs:     []*datapb.FieldBinlog{{FieldID: 1, Binlogs: []string{"statslog0", "statslog1"}}},
-			Deltalogs:     []*datapb.DeltaLogInfo{{RecordEntries: 1, TimestampFrom: 100, TimestampTo: 200, DeltaLogSize: 1000}},
 		}}
-		assert.EqualValues(t, expected, updated)
+		assert.True(t, proto.Equal(expected.SegmentInfo, updated.SegmentInfo))
+
+		binlogs, err := meta.GetSegmentBinlogs(1)
+		assert.Nil(t, err)
+		assert.EqualValues(t, &datapb.SegmentBinlogs{
+			SegmentID:    1,
+			NumOfRows:    10,
+			FieldBinlogs: []*datapb.FieldBinlog{{FieldID: 1, Binlogs: []string{"binlog0", "binlog1"}}},
+			Statslogs:    []*datapb.FieldBinlog{{FieldID: 1, Binlogs: []string{"statslog0", "statslog1"}}},
+			Deltalogs:    []*datapb.DeltaLogInfo{{RecordEntries: 1, TimestampFrom: 100, TimestampTo: 200, DeltaLogSize: 1000}},
+		}, binlogs)
+	})
+
+	t.Run("reload persisted segment", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		meta, err := newMeta(kv)
+		assert.Nil(t, err)
+
+		segment1 := &SegmentInfo{SegmentInfo: &datapb.SegmentInfo{ID: 1, State: commonpb.SegmentState_Flushed}}
+		err = meta.AddSegment(segment1)
+		assert.Nil(t, err)
+
+		err = meta.UpdateFlushSegmentsInfo(1, false, nil, nil,
+			[]*datapb.DeltaLogInfo{{RecordEntries: 1, TimestampFrom: 100, TimestampTo: 200, DeltaLogSize: 1000, DeltaLogPath: "deltalog1"}},
+			[]*datapb.CheckPoint{{SegmentID: 1, NumOfRows: 10, Position: &internalpb.MsgPosition{Timestamp: 200}}}, nil)
+		assert.Nil(t, err)
+
+		reloaded, err := newMeta(kv)
+		assert.Nil(t, err)
+		segment := reloaded.GetSegment(1)
+		assert.NotNil(t, segment)
+		assert.Equal(t, commonpb.SegmentState_Flushed, segment.GetState())
+		binlogs, err := reloaded.GetSegmentBinlogs(1)
+		assert.Nil(t, err)
+		assert.EqualValues(t, []*datapb.DeltaLogInfo{{RecordEntries: 1, TimestampFrom: 100, TimestampTo: 200, DeltaLogSize: 1000, DeltaLogPath: "deltalog1"}},
+			binlogs.GetDeltalogs())
+		assert.EqualValues(t, 200, segment.GetDmlPosition().GetTimestamp())
 	})
 
 	t.Run("update non-existed segment", func(t *testing.T) {
@@ -293,6 +343,255 @@ func TestUpdateFlushSegmentsInfo(t *testing.T) {
 	})
 }
 
+func TestChannelCheckpoint(t *testing.T) {
+	kv := memkv.NewMemoryKV()
+	meta, err := newMeta(kv)
+	assert.Nil(t, err)
+	assert.Nil(t, meta.GetChannelCheckpoint("ch1"))
+
+	err = meta.UpdateChannelCheckpoint("ch1", &internalpb.MsgPosition{ChannelName: "pch1", MsgID: []byte{1}, Timestamp: 100})
+	assert.Nil(t, err)
+	err = meta.UpdateChannelCheckpoint("ch2", &internalpb.MsgPosition{ChannelName: "ch2", MsgID: []byte{2}, Timestamp: 200})
+	assert.Nil(t, err)
+	// the checkpoint never moves backward
+	err = meta.UpdateChannelCheckpoint("ch1", &internalpb.MsgPosition{ChannelName: "ch1", MsgID: []byte{0}, Timestamp: 50})
+	assert.Nil(t, err)
+	cp := meta.GetChannelCheckpoint("ch1")
+	assert.EqualValues(t, "ch1", cp.ChannelName)
+	assert.EqualValues(t, []byte{1}, cp.MsgID)
+	assert.EqualValues(t, 100, cp.Timestamp)
+
+	// reload from kv
+	meta, err = newMeta(kv)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 100, meta.GetChannelCheckpoint("ch1").GetTimestamp())
+	assert.EqualValues(t, 200, meta.GetChannelCheckpoint("ch2").GetTimestamp())
+	assert.Empty(t, meta.segments.GetSegments())
+}
+
+func TestSegmentBinlogs(t *testing.T) {
+	legacy := func(id UniqueID, paths ...string) *datapb.SegmentInfo {
+		return &datapb.SegmentInfo{
+			ID:           id,
+			CollectionID: 1,
+			PartitionID:  2,
+			State:        commonpb.SegmentState_Flushed,
+			NumOfRows:    100,
+			Binlogs:      []*datapb.FieldBinlog{{FieldID: 100, Binlogs: paths}},
+			Statslogs:    []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []string{"stats"}}},
+		}
+	}
+
+	t.Run("reload legacy records", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		// more records than one migration batch, the ids share prefixes on purpose
+		num := migrateBatchSize + 10
+		for i := 1; i <= num; i++ {
+			bytes, err := proto.Marshal(legacy(UniqueID(i), fmt.Sprintf("binlog%d", i)))
+			assert.Nil(t, err)
+			assert.Nil(t, kv.Save(buildSegmentPath(1, 2, UniqueID(i)), string(bytes)))
+		}
+
+		meta, err := newMeta(kv)
+		assert.Nil(t, err)
+		for i := 1; i <= num; i++ {
+			segment := meta.GetSegment(UniqueID(i))
+			assert.NotNil(t, segment)
+			assert.EqualValues(t, 100, segment.GetNumOfRows())
+			assert.Empty(t, segment.GetBinlogs())
+			assert.Empty(t, segment.GetStatslogs())
+
+			binlogs, err := meta.GetSegmentBinlogs(UniqueID(i))
+			assert.Nil(t, err)
+			assert.EqualValues(t, 100, binlogs.GetNumOfRows())
+			assert.Equal(t, []string{fmt.Sprintf("binlog%d", i)}, binlogs.GetFieldBinlogs()[0].GetBinlogs())
+			assert.Equal(t, []string{"stats"}, binlogs.GetStatslogs()[0].GetBinlogs())
+		}
+
+		// the records are rewritten without the binlog paths
+		value, err := kv.Load(buildSegmentPath(1, 2, 1))
+		assert.Nil(t, err)
+		info := &datapb.SegmentInfo{}
+		assert.Nil(t, proto.Unmarshal([]byte(value), info))
+		assert.Empty(t, info.GetBinlogs())
+
+		reloaded, err := newMeta(kv)
+		assert.Nil(t, err)
+		binlogs, err := reloaded.GetSegmentBinlogs(10)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"binlog10"}, binlogs.GetFieldBinlogs()[0].GetBinlogs())
+	})
+
+	t.Run("add and drop segment", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		meta, err := newMeta(kv)
+		assert.Nil(t, err)
+		assert.Nil(t, meta.AddSegment(NewSegmentInfo(legacy(1, "binlog1"))))
+		assert.Nil(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{ID: 10, CollectionID: 1, PartitionID: 2})))
+		assert.Empty(t, meta.GetSegment(1).GetBinlogs())
+
+		binlogs, err := meta.GetSegmentBinlogs(1)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"binlog1"}, binlogs.GetFieldBinlogs()[0].GetBinlogs())
+		// segment 10 must not pick up the binlogs of segment 1
+		binlogs, err = meta.GetSegmentBinlogs(10)
+		assert.Nil(t, err)
+		assert.Empty(t, binlogs.GetFieldBinlogs())
+
+		_, err = meta.GetSegmentBinlogs(3)
+		assert.NotNil(t, err)
+
+		// the binlog paths of the dropped segment are kept until it's removed
+		assert.Nil(t, meta.DropSegment(1))
+		binlogs, err = meta.GetSegmentBinlogs(1)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"binlog1"}, binlogs.GetFieldBinlogs()[0].GetBinlogs())
+		assert.Nil(t, meta.RemoveDroppedSegment(1))
+		_, values, err := kv.LoadWithPrefix(segmentBinlogPrefix)
+		assert.Nil(t, err)
+		assert.Empty(t, values)
+	})
+}
+
+// BenchmarkMeta_reload compares the reload of the current layout with loading every segment record
+// into memory as a whole, which was how meta was reloaded before binlog paths were detached
+func BenchmarkMeta_reload(b *testing.B) {
+	const segmentNum = 200000
+	kv := memkv.NewMemoryKV()
+	kvs := make(map[string]string)
+	for i := 0; i < segmentNum; i++ {
+		segment := &datapb.SegmentInfo{
+			ID:            UniqueID(i),
+			CollectionID:  1,
+			PartitionID:   UniqueID(i % 16),
+			InsertChannel: "by-dev-rootcoord-dml_0_1v0",
+			State:         commonpb.SegmentState_Flushed,
+			NumOfRows:     10000,
+			DmlPosition:   &internalpb.MsgPosition{ChannelName: "by-dev-rootcoord-dml_0", MsgID: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+			Statslogs:     []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []string{fmt.Sprintf("stats_log/1/%d/%d/100/1", i%16, i)}}},
+		}
+		for fieldID := 0; fieldID < 4; fieldID++ {
+			segment.Binlogs = append(segment.Binlogs, &datapb.FieldBinlog{
+				FieldID: int64(100 + fieldID),
+				Binlogs: []string{
+					fmt.Sprintf("insert_log/1/%d/%d/%d/1", i%16, i, 100+fieldID),
+					fmt.Sprintf("insert_log/1/%d/%d/%d/2", i%16, i, 100+fieldID),
+				},
+			})
+		}
+		detached, binlogs := detachSegmentBinlogs(NewSegmentInfo(segment))
+		if err := buildSegmentInfoKv(detached, kvs); err != nil {
+			b.Fatal(err)
+		}
+		if err := buildSegmentBinlogsKv(detached, binlogs, kvs); err != nil {
+			b.Fatal(err)
+		}
+		// keep the full record aside for the legacy load
+		bytes, err := proto.Marshal(segment)
+		if err != nil {
+			b.Fatal(err)
+		}
+		kvs["legacy/"+strconv.Itoa(i)] = string(bytes)
+	}
+	if err := kv.MultiSave(kvs); err != nil {
+		b.Fatal(err)
+	}
+	kvs = nil
+
+	heapInUse := func() uint64 {
+		runtime.GC()
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return stats.HeapAlloc
+	}
+
+	b.Run("detached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			before := heapInUse()
+			meta, err := newMeta(kv)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(heapInUse()-before)/1024/1024, "heap-MB")
+			runtime.KeepAlive(meta)
+		}
+	})
+
+	b.Run("whole", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			before := heapInUse()
+			_, values, err := kv.LoadWithPrefix("legacy/")
+			if err != nil {
+				b.Fatal(err)
+			}
+			segments := NewSegmentsInfo()
+			for _, value := range values {
+				info := &datapb.SegmentInfo{}
+				if err := proto.Unmarshal([]byte(value), info); err != nil {
+					b.Fatal(err)
+				}
+				segments.SetSegment(info.GetID(), NewSegmentInfo(info))
+			}
+			values = nil
+			b.ReportMetric(float64(heapInUse()-before)/1024/1024, "heap-MB")
+			runtime.KeepAlive(segments)
+		}
+	})
+}
+
+func TestMergeSegmentBinlogs(t *testing.T) {
+	segBinlogs := &datapb.SegmentBinlogs{}
+	mergeSegmentBinlogs(segBinlogs, []*datapb.FieldBinlog{
+		{FieldID: 100, Binlogs: []string{"a", "b"}, EntriesNums: []int64{10, 20}, LogSizes: []int64{1, 2}},
+	}, nil, []*datapb.DeltaLogInfo{{DeltaLogPath: "d"}})
+	// the retried paths are skipped
+	mergeSegmentBinlogs(segBinlogs, []*datapb.FieldBinlog{
+		{FieldID: 100, Binlogs: []string{"b", "c"}, EntriesNums: []int64{20, 30}, LogSizes: []int64{2, 3}},
+	}, nil, []*datapb.DeltaLogInfo{{DeltaLogPath: "d"}})
+	fieldBinlog := segBinlogs.GetFieldBinlogs()[0]
+	assert.Equal(t, []string{"a", "b", "c"}, fieldBinlog.GetBinlogs())
+	assert.Equal(t, []int64{10, 20, 30}, fieldBinlog.GetEntriesNums())
+	assert.EqualValues(t, 6, binlogSize(segBinlogs.GetFieldBinlogs()))
+	assert.Equal(t, 1, len(segBinlogs.GetDeltalogs()))
+	rows, ok := binlogRows(fieldBinlog)
+	assert.True(t, ok)
+	assert.EqualValues(t, 60, rows)
+
+	assert.Nil(t, checkBinlogRows(1, segBinlogs, 60, true))
+	assert.Nil(t, checkBinlogRows(1, segBinlogs, 70, false))
+	assert.True(t, errors.Is(checkBinlogRows(1, segBinlogs, 70, true), errSegmentStatsMismatched))
+	assert.True(t, errors.Is(checkBinlogRows(1, segBinlogs, 50, false), errSegmentStatsMismatched))
+
+	// the stats of the binlogs without entries nums are dropped and not checked
+	mergeSegmentBinlogs(segBinlogs, []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []string{"legacy"}}}, nil, nil)
+	fieldBinlog = segBinlogs.GetFieldBinlogs()[0]
+	assert.Equal(t, 4, len(fieldBinlog.GetBinlogs()))
+	assert.Empty(t, fieldBinlog.GetEntriesNums())
+	assert.Empty(t, fieldBinlog.GetLogSizes())
+	_, ok = binlogRows(fieldBinlog)
+	assert.False(t, ok)
+	assert.Nil(t, checkBinlogRows(1, segBinlogs, 1, true))
+}
+
+func TestGetCollectionTTL(t *testing.T) {
+	meta, err := newMeta(memkv.NewMemoryKV())
+	assert.Nil(t, err)
+	_, ok := meta.GetCollectionTTL(1)
+	assert.False(t, ok)
+
+	meta.AddCollection(&datapb.CollectionInfo{ID: 1})
+	_, ok = meta.GetCollectionTTL(1)
+	assert.False(t, ok)
+
+	meta.AddCollection(&datapb.CollectionInfo{
+		ID:         2,
+		Properties: []*commonpb.KeyValuePair{{Key: common.CollectionTTLKey, Value: "30"}},
+	})
+	ttl, ok := meta.GetCollectionTTL(2)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, ttl)
+}
+
 func TestSaveHandoffMeta(t *testing.T) {
 	meta, err := newMeta(memkv.NewMemoryKV())
 	assert.Nil(t, err)
@@ -315,3 +614,65 @@ func TestSaveHandoffMeta(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 100, int(segmentID))
 }
+
+func TestImportTask(t *testing.T) {
+	kv := memkv.NewMemoryKV()
+	meta, err := newMeta(kv)
+	assert.Nil(t, err)
+	assert.Nil(t, meta.GetImportTask(1))
+
+	err = meta.AddImportTask(&datapb.ImportTaskInfo{ID: 1, CollectionID: 10, State: commonpb.ImportState_ImportPending})
+	assert.Nil(t, err)
+	err = meta.AddImportTask(&datapb.ImportTaskInfo{ID: 1})
+	assert.NotNil(t, err)
+	err = meta.AddImportTask(&datapb.ImportTaskInfo{ID: 2, CollectionID: 10, State: commonpb.ImportState_ImportStarted})
+	assert.Nil(t, err)
+
+	// failed update changes nothing
+	err = meta.UpdateImportTask(1, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		task.State = commonpb.ImportState_ImportFailed
+		return nil, errors.New("mock error")
+	})
+	assert.NotNil(t, err)
+	assert.EqualValues(t, commonpb.ImportState_ImportPending, meta.GetImportTask(1).GetState())
+	err = meta.UpdateImportTask(3, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+
+	// the task and its segments are saved together
+	segment := &datapb.SegmentInfo{ID: 100, CollectionID: 10, State: commonpb.SegmentState_Flushed, NumOfRows: 10}
+	err = meta.UpdateImportTask(1, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		task.State = commonpb.ImportState_ImportPersisted
+		task.SegmentIDs = []int64{100}
+		task.RowCount = 10
+		return []*datapb.SegmentInfo{segment}, nil
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, meta.GetSegment(100))
+	err = meta.UpdateImportTask(2, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		return []*datapb.SegmentInfo{segment}, nil
+	})
+	assert.NotNil(t, err)
+
+	// the returned tasks are copies
+	task := meta.GetImportTask(1)
+	task.State = commonpb.ImportState_ImportFailed
+	assert.EqualValues(t, commonpb.ImportState_ImportPersisted, meta.GetImportTask(1).GetState())
+
+	running := meta.SelectImportTasks(func(task *datapb.ImportTaskInfo) bool {
+		return isImportRunning(task.GetState())
+	})
+	assert.EqualValues(t, 1, len(running))
+	assert.EqualValues(t, 2, running[0].GetID())
+
+	// reload from kv
+	meta, err = newMeta(kv)
+	assert.Nil(t, err)
+	task = meta.GetImportTask(1)
+	assert.EqualValues(t, commonpb.ImportState_ImportPersisted, task.GetState())
+	assert.EqualValues(t, []int64{100}, task.GetSegmentIDs())
+	assert.EqualValues(t, 10, task.GetRowCount())
+	assert.EqualValues(t, commonpb.ImportState_ImportStarted, meta.GetImportTask(2).GetState())
+	assert.NotNil(t, meta.GetSegment(100))
+}
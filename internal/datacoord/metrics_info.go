@@ -19,9 +19,6 @@ package datacoord
 import (
 	"context"
 	"errors"
-	"os"
-
-	"github.com/milvus-io/milvus/internal/util/uniquegenerator"
 
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 
@@ -29,7 +26,6 @@ import (
 
 	"github.com/milvus-io/milvus/internal/log"
 
-	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 )
@@ -43,10 +39,7 @@ func (s *Server) getSystemInfoMetrics(
 
 	// get datacoord info
 	nodes := s.cluster.GetSessions()
-	clusterTopology := metricsinfo.DataClusterTopology{
-		Self:           s.getDataCoordMetrics(),
-		ConnectedNodes: make([]metricsinfo.DataNodeInfos, 0, len(nodes)),
-	}
+	topology := metricsinfo.NewComponentTopology(s.getDataCoordMetrics(), typeutil.RootCoordRole)
 
 	// for each data node, fetch metrics info
 	log.Debug("datacoord.getSystemInfoMetrics",
@@ -57,109 +50,55 @@ func (s *Server) getSystemInfoMetrics(
 			log.Warn("fails to get datanode metrics", zap.Error(err))
 			continue
 		}
-		clusterTopology.ConnectedNodes = append(clusterTopology.ConnectedNodes, infos)
+		topology.ConnectedNodes = append(topology.ConnectedNodes, infos)
 	}
 
-	// compose topolgoy struct
-	coordTopology := metricsinfo.DataCoordTopology{
-		Cluster: clusterTopology,
-		Connections: metricsinfo.ConnTopology{
-			Name: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, Params.NodeID),
-			// TODO(dragondriver): fill ConnectedComponents if necessary
-			ConnectedComponents: []metricsinfo.ConnectionInfo{},
-		},
-	}
-
-	resp := &milvuspb.GetMetricsResponse{
-		Status: &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-		},
-		Response:      "",
-		ComponentName: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, Params.NodeID),
-	}
-	var err error
-	resp.Response, err = metricsinfo.MarshalTopology(coordTopology)
-	if err != nil {
-		resp.Status.Reason = err.Error()
-		return resp, nil
-	}
-
-	resp.Status.ErrorCode = commonpb.ErrorCode_Success
-	return resp, nil
+	return metricsinfo.ComponentTopologyResponse(topology), nil
 }
 
 // getDataCoordMetrics composes datacoord infos
-func (s *Server) getDataCoordMetrics() metricsinfo.DataCoordInfos {
-	return metricsinfo.DataCoordInfos{
-		BaseComponentInfos: metricsinfo.BaseComponentInfos{
-			Name: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, Params.NodeID),
-			HardwareInfos: metricsinfo.HardwareMetrics{
-				IP:           s.session.Address,
-				CPUCoreCount: metricsinfo.GetCPUCoreCount(false),
-				CPUCoreUsage: metricsinfo.GetCPUUsage(),
-				Memory:       metricsinfo.GetMemoryCount(),
-				MemoryUsage:  metricsinfo.GetUsedMemoryCount(),
-				Disk:         metricsinfo.GetDiskCount(),
-				DiskUsage:    metricsinfo.GetDiskUsage(),
-			},
-			SystemInfo: metricsinfo.DeployMetrics{
-				SystemVersion: os.Getenv(metricsinfo.GitCommitEnvKey),
-				DeployMode:    os.Getenv(metricsinfo.DeployModeEnvKey),
-			},
-			CreatedTime: Params.CreatedTime.String(),
-			UpdatedTime: Params.UpdatedTime.String(),
-			Type:        typeutil.DataCoordRole,
-			ID:          s.session.ServerID,
-		},
-		SystemConfigurations: metricsinfo.DataCoordConfiguration{
+func (s *Server) getDataCoordMetrics() metricsinfo.ComponentInfo {
+	return metricsinfo.NewComponentInfo(typeutil.DataCoordRole, s.session.ServerID, s.session.Address,
+		Params.CreatedTime, Params.UpdatedTime,
+		metricsinfo.DataCoordConfiguration{
 			SegmentMaxSize: Params.SegmentMaxSize,
-		},
-	}
+		})
 }
 
 // getDataNodeMetrics composes data node infos
 // this function will invoke GetMetrics with data node specified in NodeInfo
-func (s *Server) getDataNodeMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest, node *Session) (metricsinfo.DataNodeInfos, error) {
-	infos := metricsinfo.DataNodeInfos{
-		BaseComponentInfos: metricsinfo.BaseComponentInfos{
-			HasError: true,
-			ID:       int64(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
-		},
-	}
+func (s *Server) getDataNodeMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest, node *Session) (metricsinfo.ComponentInfo, error) {
 	if node == nil {
-		return infos, errors.New("datanode is nil")
+		return metricsinfo.ComponentInfo{}, errors.New("datanode is nil")
 	}
 
 	cli, err := node.GetOrCreateClient(ctx)
 	if err != nil {
-		return infos, err
+		return metricsinfo.ComponentInfo{}, err
 	}
 
-	metrics, err := cli.GetMetrics(ctx, req)
+	topology, err := metricsinfo.ParseComponentTopology(cli.GetMetrics(ctx, req))
 	if err != nil {
 		log.Warn("invalid metrics of data node was found",
 			zap.Error(err))
-		infos.BaseComponentInfos.ErrorReason = err.Error()
 		// err handled, returns nil
-		return infos, nil
-	}
-	infos.BaseComponentInfos.Name = metrics.GetComponentName()
-
-	if metrics.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
-		log.Warn("invalid metrics of data node was found",
-			zap.Any("error_code", metrics.Status.ErrorCode),
-			zap.Any("error_reason", metrics.Status.Reason))
-		infos.BaseComponentInfos.ErrorReason = metrics.GetStatus().GetReason()
-		return infos, nil
 	}
+	return topology.Self, nil
+}
 
-	err = metricsinfo.UnmarshalComponentInfos(metrics.GetResponse(), &infos)
-	if err != nil {
-		log.Warn("invalid metrics of data node was found",
-			zap.Error(err))
-		infos.BaseComponentInfos.ErrorReason = err.Error()
-		return infos, nil
+// setLogLevel sets the log level of datacoord, then of all the data nodes if the request is valid
+func (s *Server) setLogLevel(ctx context.Context, req *milvuspb.GetMetricsRequest) *milvuspb.GetMetricsResponse {
+	name := metricsinfo.ConstructComponentName(typeutil.DataCoordRole, Params.NodeID)
+	levels := []metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}
+	if !levels[0].HasError {
+		for _, node := range s.cluster.GetSessions() {
+			cli, err := node.GetOrCreateClient(ctx)
+			if err != nil {
+				levels = append(levels, metricsinfo.CollectLogLevels(nil, err)...)
+				continue
+			}
+			levels = append(levels, metricsinfo.CollectLogLevels(cli.GetMetrics(ctx, req))...)
+		}
 	}
-	infos.BaseComponentInfos.HasError = false
-	return infos, nil
+	return metricsinfo.LogLevelsResponse(levels, name)
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 
@@ -31,9 +32,11 @@ import (
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 )
@@ -94,6 +97,10 @@ func (kv *removeFailKV) Remove(key string) error {
 	return errors.New("mocked fail")
 }
 
+func (kv *removeFailKV) MultiRemove(keys []string) error {
+	return errors.New("mocked fail")
+}
+
 func newMockAllocator() *MockAllocator {
 	return &MockAllocator{}
 }
@@ -193,6 +200,13 @@ func (c *mockDataNodeClient) GetMetrics(ctx context.Context, req *milvuspb.GetMe
 	}, nil
 }
 
+func (c *mockDataNodeClient) Import(ctx context.Context, in *datapb.ImportTask) (*commonpb.Status, error) {
+	if c.ch != nil {
+		c.ch <- in
+	}
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+}
+
 func (c *mockDataNodeClient) Stop() error {
 	c.state = internalpb.StateCode_Abnormal
 	return nil
@@ -215,6 +229,10 @@ func (m *mockRootCoordService) AlterAlias(ctx context.Context, req *milvuspb.Alt
 	panic("implement me")
 }
 
+func (m *mockRootCoordService) AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
 func newMockRootCoordService() *mockRootCoordService {
 	return &mockRootCoordService{state: internalpb.StateCode_Healthy}
 }
@@ -439,3 +457,56 @@ func (m *mockRootCoordService) GetMetrics(ctx context.Context, req *milvuspb.Get
 		ComponentName: metricsinfo.ConstructComponentName(typeutil.RootCoordRole, nodeID),
 	}, nil
 }
+
+// mockQueryCoord records the segments released by the dropped segments
+type mockQueryCoord struct {
+	types.QueryCoord
+	released []UniqueID
+	fail     bool
+}
+
+func (m *mockQueryCoord) Init() error {
+	return nil
+}
+
+func (m *mockQueryCoord) Stop() error {
+	return nil
+}
+
+func (m *mockQueryCoord) ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	if m.fail {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    "mock release segments failure",
+		}, nil
+	}
+	m.released = append(m.released, req.GetSegmentIDs()...)
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// mockIndexCoord records the segments whose index builds are dropped
+type mockIndexCoord struct {
+	types.IndexCoord
+	dropped []UniqueID
+	fail    bool
+}
+
+func (m *mockIndexCoord) Init() error {
+	return nil
+}
+
+func (m *mockIndexCoord) Stop() error {
+	return nil
+}
+
+func (m *mockIndexCoord) DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error) {
+	if m.fail {
+		return nil, errors.New("mock drop segment index failure")
+	}
+	m.dropped = append(m.dropped, req.GetSegmentID())
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
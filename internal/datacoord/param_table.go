@@ -17,10 +17,12 @@
 package datacoord
 
 import (
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 )
 
@@ -66,6 +68,27 @@ type ParamTable struct {
 
 	CreatedTime time.Time
 	UpdatedTime time.Time
+
+	// --- Session ---
+	SessionReregisterGrace time.Duration
+
+	// --- MinIO ---
+	MinioAddress         string
+	MinioAccessKeyID     string
+	MinioSecretAccessKey string
+	MinioUseSSL          bool
+	MinioBucketName      string
+	MinioRootPath        string
+
+	StorageType             string
+	LocalStoragePath        string
+	LocalStorageSyncOnWrite bool
+
+	// --- GC ---
+	// RetentionDuration is the time the history is kept for the queries with travel timestamps
+	RetentionDuration       time.Duration
+	EnableGarbageCollection bool
+	GCInterval              time.Duration
 }
 
 // Params is a package scoped variable of type ParamTable.
@@ -108,6 +131,23 @@ func (p *ParamTable) Init() {
 
 	p.initFlushStreamPosSubPath()
 	p.initStatsStreamPosSubPath()
+
+	// --- Session ---
+	p.initSessionReregisterGrace()
+
+	p.initMinioAddress()
+	p.initMinioAccessKeyID()
+	p.initMinioSecretAccessKey()
+	p.initMinioUseSSL()
+	p.initMinioBucketName()
+	p.initMinioRootPath()
+	p.initStorageType()
+	p.initLocalStoragePath()
+	p.initLocalStorageSyncOnWrite()
+
+	p.initRetentionDuration()
+	p.initEnableGarbageCollection()
+	p.initGCInterval()
 }
 
 // InitOnce ensures param table is a singleton
@@ -198,6 +238,9 @@ func (p *ParamTable) initClusterMsgChannelPrefix() {
 	if err != nil {
 		panic(err)
 	}
+	if err = channelutil.ValidatePrefix(config); err != nil {
+		panic(err)
+	}
 	p.ClusterChannelPrefix = config
 }
 
@@ -206,8 +249,7 @@ func (p *ParamTable) initInsertChannelPrefixName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.InsertChannelPrefixName = strings.Join(s, "-")
+	p.InsertChannelPrefixName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initStatisticsChannelName() {
@@ -215,8 +257,7 @@ func (p *ParamTable) initStatisticsChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.StatisticsChannelName = strings.Join(s, "-")
+	p.StatisticsChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initTimeTickChannelName() {
@@ -224,8 +265,7 @@ func (p *ParamTable) initTimeTickChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.TimeTickChannelName = strings.Join(s, "-")
+	p.TimeTickChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initSegmentInfoChannelName() {
@@ -233,8 +273,7 @@ func (p *ParamTable) initSegmentInfoChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.SegmentInfoChannelName = strings.Join(s, "-")
+	p.SegmentInfoChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initDataCoordSubscriptionName() {
@@ -242,8 +281,7 @@ func (p *ParamTable) initDataCoordSubscriptionName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.DataCoordSubscriptionName = strings.Join(s, "-")
+	p.DataCoordSubscriptionName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initRoleName() {
@@ -271,3 +309,129 @@ func (p *ParamTable) initChannelWatchPrefix() {
 	// This will be removed after we reconstruct our config module.
 	p.ChannelWatchSubPath = "channelwatch"
 }
+
+func (p *ParamTable) initSessionReregisterGrace() {
+	grace, err := p.LoadWithDefault("common.session.reregisterGrace", "30")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(grace, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.SessionReregisterGrace = time.Duration(seconds) * time.Second
+}
+
+// --- MinIO ---
+func (p *ParamTable) initMinioAddress() {
+	endpoint, err := p.Load("_MinioAddress")
+	if err != nil {
+		panic(err)
+	}
+	p.MinioAddress = endpoint
+}
+
+func (p *ParamTable) initMinioAccessKeyID() {
+	keyID, err := p.Load("_MinioAccessKeyID")
+	if err != nil {
+		panic(err)
+	}
+	p.MinioAccessKeyID = keyID
+}
+
+func (p *ParamTable) initMinioSecretAccessKey() {
+	key, err := p.Load("_MinioSecretAccessKey")
+	if err != nil {
+		panic(err)
+	}
+	p.MinioSecretAccessKey = key
+}
+
+func (p *ParamTable) initMinioUseSSL() {
+	usessl, err := p.Load("_MinioUseSSL")
+	if err != nil {
+		panic(err)
+	}
+	p.MinioUseSSL, _ = strconv.ParseBool(usessl)
+}
+
+func (p *ParamTable) initMinioRootPath() {
+	rootPath, err := p.Load("minio.rootPath")
+	if err != nil {
+		panic(err)
+	}
+	p.MinioRootPath = rootPath
+}
+
+func (p *ParamTable) initMinioBucketName() {
+	bucketName, err := p.Load("_MinioBucketName")
+	if err != nil {
+		panic(err)
+	}
+	p.MinioBucketName = bucketName
+}
+
+// initStorageType initializes the type of the storage of binlogs, minio or local.
+func (p *ParamTable) initStorageType() {
+	ret, err := p.LoadWithDefault("storage.type", "minio")
+	if err != nil {
+		panic(err)
+	}
+	p.StorageType = ret
+}
+
+// initLocalStoragePath initializes the root path of the files when the storage type is local.
+func (p *ParamTable) initLocalStoragePath() {
+	ret, err := p.LoadWithDefault("storage.path", "/var/lib/milvus/storage")
+	if err != nil {
+		panic(err)
+	}
+	p.LocalStoragePath = ret
+}
+
+// initLocalStorageSyncOnWrite initializes whether to fsync the files before the writes return when the storage type is local.
+func (p *ParamTable) initLocalStorageSyncOnWrite() {
+	ret, err := p.LoadWithDefault("storage.syncOnWrite", "false")
+	if err != nil {
+		panic(err)
+	}
+	p.LocalStorageSyncOnWrite, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (p *ParamTable) initRetentionDuration() {
+	retention, err := p.LoadWithDefault("common.retentionDuration", "432000")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(retention, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.RetentionDuration = time.Duration(seconds) * time.Second
+}
+
+func (p *ParamTable) initEnableGarbageCollection() {
+	enabled, err := p.LoadWithDefault("dataCoord.gc.enabled", "true")
+	if err != nil {
+		panic(err)
+	}
+	p.EnableGarbageCollection, err = strconv.ParseBool(enabled)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (p *ParamTable) initGCInterval() {
+	interval, err := p.LoadWithDefault("dataCoord.gc.interval", "3600")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(interval, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.GCInterval = time.Duration(seconds) * time.Second
+}
@@ -18,6 +18,7 @@ package datacoord
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,4 +42,11 @@ func TestParamTable(t *testing.T) {
 	assert.Equal(t, Params.DataCoordSubscriptionName, "by-dev-dataCoord")
 	t.Logf("data coord subscription channel = %s", Params.DataCoordSubscriptionName)
 
+	assert.Equal(t, 30*time.Second, Params.SessionReregisterGrace)
+	t.Logf("data coord session reregister grace = %v", Params.SessionReregisterGrace)
+
+	assert.Equal(t, 5*24*time.Hour, Params.RetentionDuration)
+	assert.True(t, Params.EnableGarbageCollection)
+	assert.Equal(t, time.Hour, Params.GCInterval)
+	assert.Equal(t, "files", Params.MinioRootPath)
 }
@@ -146,15 +146,6 @@ func (s *SegmentsInfo) SetCurrentRows(segmentID UniqueID, rows int64) {
 	}
 }
 
-// SetBinlogs sets binlog paths for segment
-// if the segment is not found, do nothing
-// uses `Clone` since internal SegmentInfo's Binlogs is changed
-func (s *SegmentsInfo) SetBinlogs(segmentID UniqueID, binlogs []*datapb.FieldBinlog) {
-	if segment, ok := s.segments[segmentID]; ok {
-		s.segments[segmentID] = segment.Clone(SetBinlogs(binlogs))
-	}
-}
-
 // SetFlushTime sets flush time for segment
 // if the segment is not found, do nothing
 // uses `ShadowClone` since internal SegmentInfo is not changed
@@ -164,15 +155,6 @@ func (s *SegmentsInfo) SetFlushTime(segmentID UniqueID, t time.Time) {
 	}
 }
 
-// AddSegmentBinlogs adds binlogs for segment
-// if the segment is not found, do nothing
-// uses `Clone` since internal SegmentInfo's Binlogs is changed
-func (s *SegmentsInfo) AddSegmentBinlogs(segmentID UniqueID, field2Binlogs map[UniqueID][]string) {
-	if segment, ok := s.segments[segmentID]; ok {
-		s.segments[segmentID] = segment.Clone(addSegmentBinlogs(field2Binlogs))
-	}
-}
-
 // Clone deep clone the segment info and return a new instance
 func (s *SegmentInfo) Clone(opts ...SegmentInfoOption) *SegmentInfo {
 	info := proto.Clone(s.SegmentInfo).(*datapb.SegmentInfo)
@@ -227,6 +209,13 @@ func SetState(state commonpb.SegmentState) SegmentInfoOption {
 	}
 }
 
+// SetDroppedAt is the option to set the dropped time for segment info
+func SetDroppedAt(droppedAt Timestamp) SegmentInfoOption {
+	return func(segment *SegmentInfo) {
+		segment.DroppedAt = droppedAt
+	}
+}
+
 // SetDmlPosition is the option to set dml position for segment info
 func SetDmlPosition(pos *internalpb.MsgPosition) SegmentInfoOption {
 	return func(segment *SegmentInfo) {
@@ -263,13 +252,6 @@ func SetCurrentRows(rows int64) SegmentInfoOption {
 	}
 }
 
-// SetBinlogs is the option to set binlogs for segment info
-func SetBinlogs(binlogs []*datapb.FieldBinlog) SegmentInfoOption {
-	return func(segment *SegmentInfo) {
-		segment.Binlogs = binlogs
-	}
-}
-
 // SetFlushTime is the option to set flush time for segment info
 func SetFlushTime(t time.Time) SegmentInfoOption {
 	return func(segment *SegmentInfo) {
@@ -277,28 +259,5 @@ func SetFlushTime(t time.Time) SegmentInfoOption {
 	}
 }
 
-func addSegmentBinlogs(field2Binlogs map[UniqueID][]string) SegmentInfoOption {
-	return func(segment *SegmentInfo) {
-		for fieldID, binlogPaths := range field2Binlogs {
-			found := false
-			for _, binlog := range segment.Binlogs {
-				if binlog.FieldID != fieldID {
-					continue
-				}
-				binlog.Binlogs = append(binlog.Binlogs, binlogPaths...)
-				found = true
-				break
-			}
-			if !found {
-				// if no field matched
-				segment.Binlogs = append(segment.Binlogs, &datapb.FieldBinlog{
-					FieldID: fieldID,
-					Binlogs: binlogPaths,
-				})
-			}
-		}
-	}
-}
-
 // SegmentInfoSelector is the function type to select SegmentInfo from meta
 type SegmentInfoSelector func(*SegmentInfo) bool
@@ -27,8 +27,9 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
-	"github.com/milvus-io/milvus/internal/rootcoord"
 
+	"github.com/milvus-io/milvus/internal/util/channelutil"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
 
@@ -303,7 +304,7 @@ func (s *SegmentManager) openNewSegment(ctx context.Context, collectionID Unique
 	startPosition := []byte{} // default start position
 	coll := s.meta.GetCollection(collectionID)
 	for _, pair := range coll.GetStartPositions() {
-		if pair.Key == rootcoord.ToPhysicalChannel(channelName) { // pchan or vchan
+		if pair.Key == channelutil.ToPhysicalChannel(channelName) { // pchan or vchan
 			startPosition = pair.Data
 			break
 		}
@@ -318,6 +319,7 @@ func (s *SegmentManager) openNewSegment(ctx context.Context, collectionID Unique
 		State:          commonpb.SegmentState_Growing,
 		MaxRowNum:      int64(maxNumOfRows),
 		LastExpireTime: 0,
+		LayoutVersion:  pathutil.CurrentLayoutVersion,
 		StartPosition: &internalpb.MsgPosition{
 			ChannelName: channelName,
 			MsgID:       startPosition,
@@ -25,18 +25,25 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	datanodeclient "github.com/milvus-io/milvus/internal/distributed/datanode/client"
+	indexcoordclient "github.com/milvus-io/milvus/internal/distributed/indexcoord/client"
+	querycoordclient "github.com/milvus-io/milvus/internal/distributed/querycoord/client"
 	rootcoordclient "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
 	"github.com/milvus-io/milvus/internal/logutil"
-	"github.com/milvus-io/milvus/internal/rootcoord"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/mqclient"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
@@ -57,6 +64,8 @@ var (
 	ttMaxInterval             = 3 * time.Minute
 	ttCheckerWarnMsg          = fmt.Sprintf("we haven't received tt for %f minutes", ttMaxInterval.Minutes())
 	segmentTimedFlushDuration = 10.0
+	// channelCPUpdateInterval is the min interval to persist the checkpoint of a channel
+	channelCPUpdateInterval = 10 * time.Second
 )
 
 type (
@@ -80,6 +89,8 @@ const (
 
 type dataNodeCreatorFunc func(ctx context.Context, addr string) (types.DataNode, error)
 type rootCoordCreatorFunc func(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.RootCoord, error)
+type queryCoordCreatorFunc func(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.QueryCoord, error)
+type indexCoordCreatorFunc func(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.IndexCoord, error)
 
 // makes sure Server implements `DataCoord`
 var _ types.DataCoord = (*Server)(nil)
@@ -97,15 +108,20 @@ type Server struct {
 	isServing        ServerState
 	helper           ServerHelper
 
-	kvClient        *etcdkv.EtcdKV
-	meta            *meta
-	segmentManager  Manager
-	allocator       allocator
-	cluster         *Cluster
-	channelManager  *ChannelManager
-	rootCoordClient types.RootCoord
+	kvClient         *etcdkv.EtcdKV
+	meta             *meta
+	segmentManager   Manager
+	allocator        allocator
+	cluster          *Cluster
+	channelManager   *ChannelManager
+	rootCoordClient  types.RootCoord
+	queryCoordClient types.QueryCoord
+	indexCoordClient types.IndexCoord
+	memoryStates     *channelMemoryStates
+	garbageCollector *garbageCollector
 
 	metricsCacheManager *metricsinfo.MetricsCacheManager
+	configUpdater       *paramtable.ConfigUpdater
 
 	flushCh   chan UniqueID
 	msFactory msgstream.Factory
@@ -113,8 +129,10 @@ type Server struct {
 	session *sessionutil.Session
 	eventCh <-chan *sessionutil.SessionEvent
 
-	dataNodeCreator        dataNodeCreatorFunc
-	rootCoordClientCreator rootCoordCreatorFunc
+	dataNodeCreator         dataNodeCreatorFunc
+	rootCoordClientCreator  rootCoordCreatorFunc
+	queryCoordClientCreator queryCoordCreatorFunc
+	indexCoordClientCreator indexCoordCreatorFunc
 }
 
 // ServerHelper datacoord server injection helper
@@ -138,6 +156,20 @@ func SetRootCoordCreator(creator rootCoordCreatorFunc) Option {
 	}
 }
 
+// SetQueryCoordCreator returns an `Option` setting QueryCoord creator with provided parameter
+func SetQueryCoordCreator(creator queryCoordCreatorFunc) Option {
+	return func(svr *Server) {
+		svr.queryCoordClientCreator = creator
+	}
+}
+
+// SetIndexCoordCreator returns an `Option` setting IndexCoord creator with provided parameter
+func SetIndexCoordCreator(creator indexCoordCreatorFunc) Option {
+	return func(svr *Server) {
+		svr.indexCoordClientCreator = creator
+	}
+}
+
 // SetServerHelper returns an `Option` setting ServerHelp with provided parameter
 func SetServerHelper(helper ServerHelper) Option {
 	return func(svr *Server) {
@@ -163,14 +195,18 @@ func SetDataNodeCreator(creator dataNodeCreatorFunc) Option {
 func CreateServer(ctx context.Context, factory msgstream.Factory, opts ...Option) (*Server, error) {
 	rand.Seed(time.Now().UnixNano())
 	s := &Server{
-		ctx:                    ctx,
-		msFactory:              factory,
-		flushCh:                make(chan UniqueID, 1024),
-		dataNodeCreator:        defaultDataNodeCreatorFunc,
-		rootCoordClientCreator: defaultRootCoordCreatorFunc,
-		helper:                 defaultServerHelper(),
+		ctx:                     ctx,
+		msFactory:               factory,
+		flushCh:                 make(chan UniqueID, 1024),
+		dataNodeCreator:         defaultDataNodeCreatorFunc,
+		rootCoordClientCreator:  defaultRootCoordCreatorFunc,
+		queryCoordClientCreator: defaultQueryCoordCreatorFunc,
+		indexCoordClientCreator: defaultIndexCoordCreatorFunc,
+		helper:                  defaultServerHelper(),
+		memoryStates:            newChannelMemoryStates(),
 
 		metricsCacheManager: metricsinfo.NewMetricsCacheManager(),
+		configUpdater:       paramtable.NewConfigUpdater(&Params.BaseTable),
 	}
 
 	for _, opt := range opts {
@@ -187,6 +223,14 @@ func defaultRootCoordCreatorFunc(ctx context.Context, metaRootPath string, etcdE
 	return rootcoordclient.NewClient(ctx, metaRootPath, etcdEndpoints)
 }
 
+func defaultQueryCoordCreatorFunc(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.QueryCoord, error) {
+	return querycoordclient.NewClient(ctx, metaRootPath, etcdEndpoints)
+}
+
+func defaultIndexCoordCreatorFunc(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.IndexCoord, error) {
+	return indexcoordclient.NewClient(ctx, metaRootPath, etcdEndpoints)
+}
+
 // Register register data service at etcd
 func (s *Server) Register() error {
 	s.session = sessionutil.NewSession(s.ctx, Params.MetaRootPath, Params.EtcdEndpoints)
@@ -226,6 +270,10 @@ func (s *Server) Start() error {
 		return err
 	}
 
+	if err = s.initCoordClients(); err != nil {
+		return err
+	}
+
 	if err = s.initMeta(); err != nil {
 		return err
 	}
@@ -234,7 +282,11 @@ func (s *Server) Start() error {
 		return err
 	}
 
-	s.allocator = newRootCoordAllocator(s.rootCoordClient)
+	if err = s.initGarbageCollection(); err != nil {
+		return err
+	}
+
+	s.allocator = newRootCoordAllocator(s.ctx, s.rootCoordClient)
 
 	s.startSegmentManager()
 	if err = s.initServiceDiscovery(); err != nil {
@@ -265,6 +317,35 @@ func (s *Server) initCluster() error {
 	return nil
 }
 
+// initGarbageCollection creates the garbage collector, which connects the storage of binlogs if gc is enabled
+func (s *Server) initGarbageCollection() error {
+	var cli kv.DataKV
+	if Params.EnableGarbageCollection {
+		option := &miniokv.Option{
+			Address:           Params.MinioAddress,
+			AccessKeyID:       Params.MinioAccessKeyID,
+			SecretAccessKeyID: Params.MinioSecretAccessKey,
+			UseSSL:            Params.MinioUseSSL,
+			CreateBucket:      true,
+			BucketName:        Params.MinioBucketName,
+		}
+		factory := storage.NewChunkManagerFactory(Params.StorageType, Params.LocalStoragePath, Params.LocalStorageSyncOnWrite, option)
+		var err error
+		cli, err = factory.NewDataKV(s.ctx)
+		if err != nil {
+			return err
+		}
+	}
+	s.garbageCollector = newGarbageCollector(s.meta, GcOption{
+		cli:           cli,
+		rootPath:      Params.MinioRootPath,
+		enabled:       Params.EnableGarbageCollection,
+		checkInterval: Params.GCInterval,
+		retention:     Params.RetentionDuration,
+	})
+	return nil
+}
+
 func (s *Server) initServiceDiscovery() error {
 	sessions, rev, err := s.session.GetSessions(typeutil.DataNodeRole)
 	if err != nil {
@@ -284,7 +365,7 @@ func (s *Server) initServiceDiscovery() error {
 
 	s.cluster.Startup(datanodes)
 
-	s.eventCh = s.session.WatchServices(typeutil.DataNodeRole, rev+1)
+	s.eventCh = s.session.WatchServices(typeutil.DataNodeRole, rev+1, sessionutil.WithReregisterGrace(Params.SessionReregisterGrace))
 	return nil
 }
 
@@ -299,6 +380,10 @@ func (s *Server) initMeta() error {
 			return err
 		}
 
+		// reject the meta writes once a newer datacoord registered
+		if s.session != nil {
+			etcdKV.SetFence(s.session.FencingKey(), s.session.FencingToken)
+		}
 		s.kvClient = etcdKV
 		s.meta, err = newMeta(s.kvClient)
 		if err != nil {
@@ -316,6 +401,8 @@ func (s *Server) startServerLoop() {
 	go s.startDataNodeTtLoop(s.serverLoopCtx)
 	go s.startWatchService(s.serverLoopCtx)
 	go s.startFlushLoop(s.serverLoopCtx)
+	s.garbageCollector.start()
+	s.recoverImportTasks(s.serverLoopCtx)
 	go s.session.LivenessCheck(s.serverLoopCtx, func() {
 		if err := s.Stop(); err != nil {
 			log.Error("failed to stop server", zap.Error(err))
@@ -381,6 +468,7 @@ func (s *Server) startDataNodeTtLoop(ctx context.Context) {
 		checker.Start()
 		defer checker.Stop()
 	}
+	cpUpdateTime := make(map[string]time.Time)
 	for {
 		select {
 		case <-ctx.Done():
@@ -406,6 +494,14 @@ func (s *Server) startDataNodeTtLoop(ctx context.Context) {
 
 			ch := ttMsg.ChannelName
 			ts := ttMsg.Timestamp
+			s.memoryStates.update(ch, ttMsg.MemoryHigh)
+			if cp := ttMsg.GetCheckpoint(); cp != nil && time.Since(cpUpdateTime[ch]) >= channelCPUpdateInterval {
+				if err := s.meta.UpdateChannelCheckpoint(ch, cp); err != nil {
+					log.Warn("failed to update channel checkpoint", zap.String("channel", ch), zap.Error(err))
+				} else {
+					cpUpdateTime[ch] = time.Now()
+				}
+			}
 			if err := s.segmentManager.ExpireAllocations(ch, ts); err != nil {
 				log.Warn("failed to expire allocations", zap.Error(err))
 				continue
@@ -520,6 +616,7 @@ func (s *Server) handleSessionEvent(ctx context.Context, event *sessionutil.Sess
 			log.Warn("failed to deregisger node", zap.Int64("id", node.NodeID), zap.String("address", node.Address), zap.Error(err))
 			return err
 		}
+		s.failNodeImportTasks(node.NodeID)
 		s.metricsCacheManager.InvalidateSystemInfoMetrics()
 	default:
 		log.Warn("receive unknown service event type",
@@ -601,6 +698,22 @@ func (s *Server) initRootCoordClient() error {
 	return s.rootCoordClient.Start()
 }
 
+// initCoordClients creates the clients of QueryCoord and IndexCoord which a dropped segment is cascaded to,
+// the clients connect on the first call, so DataCoord doesn't wait for the coordinators depending on it
+func (s *Server) initCoordClients() error {
+	var err error
+	if s.queryCoordClient, err = s.queryCoordClientCreator(s.ctx, Params.MetaRootPath, Params.EtcdEndpoints); err != nil {
+		return err
+	}
+	if err = s.queryCoordClient.Init(); err != nil {
+		return err
+	}
+	if s.indexCoordClient, err = s.indexCoordClientCreator(s.ctx, Params.MetaRootPath, Params.EtcdEndpoints); err != nil {
+		return err
+	}
+	return s.indexCoordClient.Init()
+}
+
 // Stop do the Server finalize processes
 // it checks the server status is healthy, if not, just quit
 // if Server is healthy, set server state to stopped, release etcd session,
@@ -611,7 +724,14 @@ func (s *Server) Stop() error {
 	}
 	log.Debug("dataCoord server shutdown")
 	s.cluster.Close()
+	s.garbageCollector.close()
 	s.stopServerLoop()
+	if s.queryCoordClient != nil {
+		_ = s.queryCoordClient.Stop()
+	}
+	if s.indexCoordClient != nil {
+		_ = s.indexCoordClient.Stop()
+	}
 	return nil
 }
 
@@ -674,6 +794,7 @@ func (s *Server) loadCollectionFromRootCoord(ctx context.Context, collectionID i
 		Schema:         resp.Schema,
 		Partitions:     presp.PartitionIDs,
 		StartPositions: resp.GetStartPositions(),
+		Properties:     resp.GetProperties(),
 	}
 	s.meta.AddCollection(collInfo)
 	return nil
@@ -689,7 +810,8 @@ func (s *Server) GetVChanPositions(channel string, collectionID UniqueID, seekFr
 	for _, s := range segments {
 		if s.State == commonpb.SegmentState_Flushing || s.State == commonpb.SegmentState_Flushed {
 			flushed = append(flushed, trimSegmentInfo(s.SegmentInfo))
-			if seekPosition == nil || (!useUnflushedPosition && s.DmlPosition.Timestamp > seekPosition.Timestamp) {
+			// the imported segments have no dml position, they are not written through the dml channel
+			if s.DmlPosition != nil && (seekPosition == nil || (!useUnflushedPosition && s.DmlPosition.Timestamp > seekPosition.Timestamp)) {
 				seekPosition = s.DmlPosition
 			}
 			continue
@@ -710,12 +832,19 @@ func (s *Server) GetVChanPositions(channel string, collectionID UniqueID, seekFr
 			}
 		}
 	}
+	// DataNode replays from the channel checkpoint if it's reported, the data before it is durably flushed
+	if seekFromStartPosition {
+		cp := s.meta.GetChannelCheckpoint(channel)
+		if cp != nil && (seekPosition == nil || cp.GetTimestamp() > seekPosition.GetTimestamp()) {
+			seekPosition = proto.Clone(cp).(*internalpb.MsgPosition)
+		}
+	}
 	// use collection start position when segment position is not found
 	if seekPosition == nil {
 		coll := s.meta.GetCollection(collectionID)
 		if coll != nil {
 			for _, sp := range coll.GetStartPositions() {
-				if sp.GetKey() == rootcoord.ToPhysicalChannel(channel) {
+				if sp.GetKey() == channelutil.ToPhysicalChannel(channel) {
 					seekPosition = &internalpb.MsgPosition{
 						ChannelName: channel,
 						MsgID:       sp.GetData(),
@@ -747,5 +876,7 @@ func trimSegmentInfo(info *datapb.SegmentInfo) *datapb.SegmentInfo {
 		LastExpireTime: info.LastExpireTime,
 		StartPosition:  info.StartPosition,
 		DmlPosition:    info.DmlPosition,
+		DroppedAt:      info.DroppedAt,
+		LayoutVersion:  info.LayoutVersion,
 	}
 }
@@ -19,6 +19,7 @@ package datacoord
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
 	"os"
 	"path"
@@ -42,6 +43,8 @@ import (
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/stretchr/testify/assert"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -673,11 +676,11 @@ func TestServer_getSystemInfoMetrics(t *testing.T) {
 		zap.String("name", resp.ComponentName),
 		zap.String("response", resp.Response))
 
-	var coordTopology metricsinfo.DataCoordTopology
-	err = metricsinfo.UnmarshalTopology(resp.Response, &coordTopology)
+	coordTopology, err := metricsinfo.UnmarshalComponentTopology(resp.Response)
 	assert.Nil(t, err)
-	assert.Equal(t, len(svr.cluster.GetSessions()), len(coordTopology.Cluster.ConnectedNodes))
-	for _, nodeMetrics := range coordTopology.Cluster.ConnectedNodes {
+	assert.Equal(t, typeutil.DataCoordRole, coordTopology.Self.Type)
+	assert.Equal(t, len(svr.cluster.GetSessions()), len(coordTopology.ConnectedNodes))
+	for _, nodeMetrics := range coordTopology.ConnectedNodes {
 		assert.Equal(t, false, nodeMetrics.HasError)
 		assert.Equal(t, 0, len(nodeMetrics.ErrorReason))
 		_, err = metricsinfo.MarshalComponentInfos(nodeMetrics)
@@ -810,7 +813,10 @@ func TestSaveBinlogPaths(t *testing.T) {
 
 		segment := svr.meta.GetSegment(2)
 		assert.NotNil(t, segment)
-		binlogs := segment.GetBinlogs()
+		assert.Empty(t, segment.GetBinlogs())
+		segBinlogs, err := svr.meta.GetSegmentBinlogs(2)
+		assert.Nil(t, err)
+		binlogs := segBinlogs.GetFieldBinlogs()
 		assert.EqualValues(t, 1, len(binlogs))
 		fieldBinlogs := binlogs[0]
 		assert.NotNil(t, fieldBinlogs)
@@ -826,6 +832,84 @@ func TestSaveBinlogPaths(t *testing.T) {
 		assert.EqualValues(t, segmentInfo.NumOfRows, 10)
 	})
 
+	t.Run("inconsistent segment stats", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+
+		svr.meta.AddCollection(&datapb.CollectionInfo{ID: 0, Partitions: []int64{0}})
+		err := svr.meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{ID: 1, CollectionID: 0, PartitionID: 0, State: commonpb.SegmentState_Growing}))
+		assert.Nil(t, err)
+
+		genRequest := func(entriesNums [][]int64, numRows int64, flushed bool, idx int) *datapb.SaveBinlogPathsRequest {
+			req := &datapb.SaveBinlogPathsRequest{
+				Base:      &commonpb.MsgBase{},
+				SegmentID: 1,
+				CheckPoints: []*datapb.CheckPoint{
+					{
+						SegmentID: 1,
+						Position:  &internalpb.MsgPosition{ChannelName: "ch1", Timestamp: uint64(idx)},
+						NumOfRows: numRows,
+					},
+				},
+				Flushed: flushed,
+			}
+			for i, nums := range entriesNums {
+				fieldBinlog := &datapb.FieldBinlog{FieldID: int64(i)}
+				for j, num := range nums {
+					fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, fmt.Sprintf("/by-dev/test/0/0/1/%d/%d_%d", i, idx, j))
+					fieldBinlog.EntriesNums = append(fieldBinlog.EntriesNums, num)
+					fieldBinlog.LogSizes = append(fieldBinlog.LogSizes, num*8)
+				}
+				req.Field2BinlogPaths = append(req.Field2BinlogPaths, fieldBinlog)
+			}
+			return req
+		}
+
+		ctx := context.Background()
+		// the fields have different rows
+		resp, err := svr.SaveBinlogPaths(ctx, genRequest([][]int64{{10}, {9}}, 10, false, 1))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_SegmentStatsMismatch, resp.GetErrorCode())
+		// entries nums are not given for each binlog
+		req := genRequest([][]int64{{10, 10}}, 20, false, 1)
+		req.Field2BinlogPaths[0].EntriesNums = []int64{20}
+		resp, err = svr.SaveBinlogPaths(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_SegmentStatsMismatch, resp.GetErrorCode())
+		// more rows in binlogs than reported
+		resp, err = svr.SaveBinlogPaths(ctx, genRequest([][]int64{{10}, {10}}, 5, false, 1))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_SegmentStatsMismatch, resp.GetErrorCode())
+
+		// nothing is saved for the rejected reports
+		segBinlogs, err := svr.meta.GetSegmentBinlogs(1)
+		assert.Nil(t, err)
+		assert.Empty(t, segBinlogs.GetFieldBinlogs())
+
+		// buffered rows are not in binlogs yet
+		resp, err = svr.SaveBinlogPaths(ctx, genRequest([][]int64{{10}, {10}}, 15, false, 1))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.GetErrorCode())
+		// a retried report is not merged twice
+		resp, err = svr.SaveBinlogPaths(ctx, genRequest([][]int64{{10}, {10}}, 15, false, 1))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.GetErrorCode())
+		assert.EqualValues(t, 160, svr.meta.GetSegment(1).GetBinlogSize())
+
+		// a partial flush misses the binlog of 5 rows
+		resp, err = svr.SaveBinlogPaths(ctx, genRequest([][]int64{{3}, {3}}, 18, true, 2))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_SegmentStatsMismatch, resp.GetErrorCode())
+		assert.Equal(t, commonpb.SegmentState_Growing, svr.meta.GetSegment(1).GetState())
+
+		resp, err = svr.SaveBinlogPaths(ctx, genRequest([][]int64{{3, 5}, {3, 5}}, 18, true, 2))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.GetErrorCode())
+		segment := svr.meta.GetSegment(1)
+		assert.EqualValues(t, 18, segment.GetNumOfRows())
+		assert.EqualValues(t, 288, segment.GetBinlogSize())
+	})
+
 	t.Run("with closed server", func(t *testing.T) {
 		svr := newTestServer(t, nil)
 		closeTestServer(t, svr)
@@ -1049,6 +1133,85 @@ func TestDataNodeTtChannel(t *testing.T) {
 		segment = svr.meta.GetSegment(assignedSegmentID)
 		assert.EqualValues(t, 0, len(segment.allocations))
 	})
+
+	t.Run("throttle assignment when datanode memory is high", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		svr.meta.AddCollection(&datapb.CollectionInfo{
+			ID:         0,
+			Schema:     newTestSchema(),
+			Partitions: []int64{0},
+		})
+		ttMsgStream, err := svr.msFactory.NewMsgStream(context.TODO())
+		assert.Nil(t, err)
+		ttMsgStream.AsProducer([]string{Params.TimeTickChannelName})
+		ttMsgStream.Start()
+		defer ttMsgStream.Close()
+		err = svr.cluster.Register(&NodeInfo{
+			Address: "localhost:7777",
+			NodeID:  0,
+		})
+		assert.Nil(t, err)
+
+		produce := func(memoryHigh bool) {
+			msg := genMsg(commonpb.MsgType_DataNodeTt, "ch-1", 0)
+			msg.MemoryHigh = memoryHigh
+			err := ttMsgStream.Produce(&msgstream.MsgPack{Msgs: []msgstream.TsMsg{msg}})
+			assert.Nil(t, err)
+		}
+		assign := func() *datapb.SegmentIDAssignment {
+			resp, err := svr.AssignSegmentID(context.TODO(), &datapb.AssignSegmentIDRequest{
+				SegmentIDRequests: []*datapb.SegmentIDRequest{
+					{
+						CollectionID: 0,
+						PartitionID:  0,
+						ChannelName:  "ch-1",
+						Count:        100,
+					},
+				},
+			})
+			assert.Nil(t, err)
+			assert.EqualValues(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+			assert.EqualValues(t, 1, len(resp.SegIDAssignments))
+			return resp.SegIDAssignments[0]
+		}
+
+		assert.EqualValues(t, commonpb.ErrorCode_Success, assign().Status.ErrorCode)
+
+		produce(true)
+		assert.Eventually(t, func() bool {
+			return svr.memoryStates.isHigh("ch-1")
+		}, 5*time.Second, 10*time.Millisecond)
+		assignment := assign()
+		assert.EqualValues(t, commonpb.ErrorCode_RateLimit, assignment.Status.ErrorCode)
+		assert.EqualValues(t, "ch-1", assignment.ChannelName)
+
+		// the throttling is lifted once the buffers drain
+		produce(false)
+		assert.Eventually(t, func() bool {
+			return !svr.memoryStates.isHigh("ch-1")
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, assign().Status.ErrorCode)
+	})
+
+	t.Run("persist channel checkpoint", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		ttMsgStream, err := svr.msFactory.NewMsgStream(context.TODO())
+		assert.Nil(t, err)
+		ttMsgStream.AsProducer([]string{Params.TimeTickChannelName})
+		ttMsgStream.Start()
+		defer ttMsgStream.Close()
+
+		msg := genMsg(commonpb.MsgType_DataNodeTt, "ch-1", 200)
+		msg.Checkpoint = &internalpb.MsgPosition{ChannelName: "ch-1", MsgID: []byte{1, 2, 3}, Timestamp: 100}
+		err = ttMsgStream.Produce(&msgstream.MsgPack{Msgs: []msgstream.TsMsg{msg}})
+		assert.Nil(t, err)
+		assert.Eventually(t, func() bool {
+			return svr.meta.GetChannelCheckpoint("ch-1").GetTimestamp() == 100
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.EqualValues(t, []byte{1, 2, 3}, svr.meta.GetChannelCheckpoint("ch-1").MsgID)
+	})
 }
 
 func TestGetVChannelPos(t *testing.T) {
@@ -1132,6 +1295,20 @@ func TestGetVChannelPos(t *testing.T) {
 		assert.EqualValues(t, 0, len(infos.UnflushedSegments))
 		assert.EqualValues(t, []byte{8, 9, 10}, infos.SeekPosition.MsgID)
 	})
+
+	t.Run("seek from channel checkpoint", func(t *testing.T) {
+		vchan := svr.GetVChanPositions("ch1", 0, true)
+		assert.NotEqualValues(t, []byte{4, 5, 6}, vchan.SeekPosition.GetMsgID())
+
+		err := svr.meta.UpdateChannelCheckpoint("ch1", &internalpb.MsgPosition{ChannelName: "ch1", MsgID: []byte{4, 5, 6}, Timestamp: 100})
+		assert.Nil(t, err)
+		vchan = svr.GetVChanPositions("ch1", 0, true)
+		assert.EqualValues(t, []byte{4, 5, 6}, vchan.SeekPosition.MsgID)
+		assert.EqualValues(t, 1, len(vchan.UnflushedSegments))
+		// the recovery of QueryNode doesn't use the channel checkpoint
+		vchan = svr.GetVChanPositions("ch1", 0, false)
+		assert.EqualValues(t, []byte{1, 2, 3}, vchan.SeekPosition.MsgID)
+	})
 }
 
 func TestGetRecoveryInfo(t *testing.T) {
@@ -1491,6 +1668,257 @@ func TestPostFlush(t *testing.T) {
 	})
 }
 
+func TestImport(t *testing.T) {
+	t.Run("no datanode", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+
+		resp, err := svr.Import(context.TODO(), &datapb.ImportTaskRequest{
+			CollectionID: 1,
+			Files:        []string{"a.json"},
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, resp.GetStatus().GetErrorCode())
+
+		state, err := svr.GetImportState(context.TODO(), &milvuspb.GetImportStateRequest{Task: resp.GetTaskID()})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, state.GetStatus().GetErrorCode())
+		assert.EqualValues(t, commonpb.ImportState_ImportFailed, state.GetState())
+		assert.EqualValues(t, 1, len(state.GetInfos()))
+		assert.EqualValues(t, importFailedReasonKey, state.GetInfos()[0].GetKey())
+	})
+
+	t.Run("import and report", func(t *testing.T) {
+		ch := make(chan interface{}, 1)
+		svr := newTestServer(t, ch)
+		defer closeTestServer(t, svr)
+		svr.rootCoordClient = &rootCoordSegFlushComplete{flag: true}
+		err := svr.cluster.Register(&NodeInfo{Address: "localhost:7777", NodeID: 1})
+		assert.Nil(t, err)
+
+		resp, err := svr.Import(context.TODO(), &datapb.ImportTaskRequest{
+			CollectionID: 1,
+			PartitionID:  2,
+			Files:        []string{"a.json"},
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, resp.GetStatus().GetErrorCode())
+		assert.EqualValues(t, 1, resp.GetDatanodeID())
+		task := (<-ch).(*datapb.ImportTask)
+		assert.EqualValues(t, resp.GetTaskID(), task.GetTaskID())
+		assert.EqualValues(t, []string{"a.json"}, task.GetFiles())
+
+		status, err := svr.ReportImport(context.TODO(), &datapb.ImportResult{
+			TaskID: resp.GetTaskID(),
+			State:  commonpb.ImportState_ImportStarted,
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		// segment of other partition is rejected
+		status, err = svr.ReportImport(context.TODO(), &datapb.ImportResult{
+			TaskID:   resp.GetTaskID(),
+			State:    commonpb.ImportState_ImportPersisted,
+			Segments: []*datapb.SegmentInfo{{ID: 100, CollectionID: 1, PartitionID: 3, State: commonpb.SegmentState_Flushed}},
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+		assert.Nil(t, svr.meta.GetSegment(100))
+
+		status, err = svr.ReportImport(context.TODO(), &datapb.ImportResult{
+			TaskID:   resp.GetTaskID(),
+			State:    commonpb.ImportState_ImportPersisted,
+			Segments: []*datapb.SegmentInfo{{ID: 100, CollectionID: 1, PartitionID: 2, State: commonpb.SegmentState_Flushed, NumOfRows: 10}},
+			RowCount: 10,
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+		assert.NotNil(t, svr.meta.GetSegment(100))
+
+		assert.Eventually(t, func() bool {
+			state, err := svr.GetImportState(context.TODO(), &milvuspb.GetImportStateRequest{Task: resp.GetTaskID()})
+			return err == nil && state.GetState() == commonpb.ImportState_ImportCompleted
+		}, 5*time.Second, 10*time.Millisecond)
+		state, err := svr.GetImportState(context.TODO(), &milvuspb.GetImportStateRequest{Task: resp.GetTaskID()})
+		assert.Nil(t, err)
+		assert.EqualValues(t, 10, state.GetRowCount())
+		assert.EqualValues(t, []int64{100}, state.GetSegmentIds())
+
+		// the task is not running anymore
+		status, err = svr.ReportImport(context.TODO(), &datapb.ImportResult{
+			TaskID: resp.GetTaskID(),
+			State:  commonpb.ImportState_ImportFailed,
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	})
+
+	t.Run("datanode offline", func(t *testing.T) {
+		ch := make(chan interface{}, 1)
+		svr := newTestServer(t, ch)
+		defer closeTestServer(t, svr)
+		err := svr.cluster.Register(&NodeInfo{Address: "localhost:7777", NodeID: 1})
+		assert.Nil(t, err)
+
+		resp, err := svr.Import(context.TODO(), &datapb.ImportTaskRequest{Files: []string{"a.npy", "b.npy"}})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, resp.GetStatus().GetErrorCode())
+
+		svr.failNodeImportTasks(1)
+		state, err := svr.GetImportState(context.TODO(), &milvuspb.GetImportStateRequest{Task: resp.GetTaskID()})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ImportState_ImportFailed, state.GetState())
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+
+		resp, err := svr.Import(context.TODO(), &datapb.ImportTaskRequest{})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, resp.GetStatus().GetErrorCode())
+
+		state, err := svr.GetImportState(context.TODO(), &milvuspb.GetImportStateRequest{Task: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, state.GetStatus().GetErrorCode())
+
+		status, err := svr.ReportImport(context.TODO(), &datapb.ImportResult{TaskID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	})
+
+	t.Run("closed server", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		closeTestServer(t, svr)
+
+		resp, err := svr.Import(context.TODO(), &datapb.ImportTaskRequest{Files: []string{"a.json"}})
+		assert.Nil(t, err)
+		assert.EqualValues(t, serverNotServingErrMsg, resp.GetStatus().GetReason())
+
+		state, err := svr.GetImportState(context.TODO(), &milvuspb.GetImportStateRequest{Task: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, serverNotServingErrMsg, state.GetStatus().GetReason())
+
+		status, err := svr.ReportImport(context.TODO(), &datapb.ImportResult{TaskID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, serverNotServingErrMsg, status.GetReason())
+	})
+}
+
+func TestServer_DropSegment(t *testing.T) {
+	addSegment := func(t *testing.T, svr *Server, id UniqueID, state commonpb.SegmentState) {
+		err := svr.meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+			ID:           id,
+			CollectionID: 1,
+			PartitionID:  2,
+			State:        state,
+		}))
+		assert.Nil(t, err)
+	}
+
+	t.Run("segment not found", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+
+		status, err := svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+	})
+
+	t.Run("refused without force", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		queryCoord := svr.queryCoordClient.(*mockQueryCoord)
+		indexCoord := svr.indexCoordClient.(*mockIndexCoord)
+
+		// the growing segment is still being written
+		addSegment(t, svr, 1, commonpb.SegmentState_Growing)
+		status, err := svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+		assert.NotNil(t, svr.meta.GetSegment(1))
+
+		// the flushed segment is being completed by an import task
+		addSegment(t, svr, 2, commonpb.SegmentState_Flushed)
+		err = svr.meta.AddImportTask(&datapb.ImportTaskInfo{
+			ID:         10,
+			State:      commonpb.ImportState_ImportPersisted,
+			SegmentIDs: []int64{2},
+		})
+		assert.Nil(t, err)
+		status, err = svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 2})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+		assert.Contains(t, status.GetReason(), "import task 10")
+		assert.NotNil(t, svr.meta.GetSegment(2))
+
+		assert.Empty(t, queryCoord.released)
+		assert.Empty(t, indexCoord.dropped)
+	})
+
+	t.Run("drop and cascade", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		queryCoord := svr.queryCoordClient.(*mockQueryCoord)
+		indexCoord := svr.indexCoordClient.(*mockIndexCoord)
+
+		addSegment(t, svr, 1, commonpb.SegmentState_Flushed)
+		addSegment(t, svr, 2, commonpb.SegmentState_Growing)
+		status, err := svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+		// the growing segment is dropped only if forced
+		status, err = svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 2, Force: true})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		for _, id := range []UniqueID{1, 2} {
+			assert.Nil(t, svr.meta.GetSegment(id))
+			dropped := svr.meta.GetDroppedSegment(id)
+			assert.NotNil(t, dropped)
+			assert.EqualValues(t, commonpb.SegmentState_Dropped, dropped.GetState())
+		}
+		assert.EqualValues(t, []UniqueID{1, 2}, queryCoord.released)
+		assert.EqualValues(t, []UniqueID{1, 2}, indexCoord.dropped)
+
+		// the binlogs are garbage collected after the retention duration
+		ts := tsoutil.ComposeTS(time.Now().Add(time.Minute).UnixNano()/int64(time.Millisecond), 0)
+		assert.Equal(t, 2, len(svr.meta.GetSegmentsDroppedBefore(ts)))
+	})
+
+	t.Run("cascade retried", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		queryCoord := svr.queryCoordClient.(*mockQueryCoord)
+		indexCoord := svr.indexCoordClient.(*mockIndexCoord)
+
+		addSegment(t, svr, 1, commonpb.SegmentState_Flushed)
+		indexCoord.fail = true
+		status, err := svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+		assert.NotNil(t, svr.meta.GetDroppedSegment(1))
+		assert.Empty(t, indexCoord.dropped)
+
+		// dropping the dropped segment again retries the cascade
+		indexCoord.fail = false
+		status, err = svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+		assert.EqualValues(t, []UniqueID{1, 1}, queryCoord.released)
+		assert.EqualValues(t, []UniqueID{1}, indexCoord.dropped)
+	})
+
+	t.Run("closed server", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		closeTestServer(t, svr)
+
+		status, err := svr.DropSegment(context.TODO(), &datapb.DropSegmentRequest{SegmentID: 1})
+		assert.Nil(t, err)
+		assert.EqualValues(t, serverNotServingErrMsg, status.GetReason())
+	})
+}
+
 func newTestServer(t *testing.T, receiveCh chan interface{}, opts ...Option) *Server {
 	Params.Init()
 	Params.TimeTickChannelName = Params.TimeTickChannelName + strconv.Itoa(rand.Int())
@@ -1519,6 +1947,12 @@ func newTestServer(t *testing.T, receiveCh chan interface{}, opts ...Option) *Se
 	svr.rootCoordClientCreator = func(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.RootCoord, error) {
 		return newMockRootCoordService(), nil
 	}
+	svr.queryCoordClientCreator = func(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.QueryCoord, error) {
+		return &mockQueryCoord{}, nil
+	}
+	svr.indexCoordClientCreator = func(ctx context.Context, metaRootPath string, etcdEndpoints []string) (types.IndexCoord, error) {
+		return &mockIndexCoord{}, nil
+	}
 	assert.Nil(t, err)
 	err = svr.Register()
 	assert.Nil(t, err)
@@ -18,10 +18,13 @@ package datacoord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync/atomic"
+	"time"
 
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 
 	"github.com/milvus-io/milvus/internal/log"
@@ -30,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"go.uber.org/zap"
 )
 
@@ -124,6 +128,20 @@ func (s *Server) AssignSegmentID(ctx context.Context, req *datapb.AssignSegmentI
 
 		s.cluster.Watch(r.ChannelName, r.CollectionID)
 
+		if s.memoryStates.isHigh(r.ChannelName) {
+			log.Warn("reject to assign segment since the memory of DataNode is high", zap.String("channelName", r.ChannelName))
+			assigns = append(assigns, &datapb.SegmentIDAssignment{
+				ChannelName:  r.ChannelName,
+				CollectionID: r.CollectionID,
+				PartitionID:  r.PartitionID,
+				Status: &commonpb.Status{
+					ErrorCode: commonpb.ErrorCode_RateLimit,
+					Reason:    msgDataNodeMemoryHigh(r.ChannelName),
+				},
+			})
+			continue
+		}
+
 		allocations, err := s.segmentManager.AllocSegment(ctx,
 			r.CollectionID, r.PartitionID, r.ChannelName, int64(r.Count))
 		if err != nil {
@@ -206,7 +224,12 @@ func (s *Server) GetInsertBinlogPaths(ctx context.Context, req *datapb.GetInsert
 		resp.Status.Reason = "segment not found"
 		return resp, nil
 	}
-	binlogs := segment.GetBinlogs()
+	segBinlogs, err := s.meta.GetSegmentBinlogs(segment.GetID())
+	if err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+	binlogs := segBinlogs.GetFieldBinlogs()
 	fids := make([]UniqueID, 0, len(binlogs))
 	paths := make([]*internalpb.StringList, 0, len(binlogs))
 	for _, field := range binlogs {
@@ -325,14 +348,22 @@ func (s *Server) SaveBinlogPaths(ctx context.Context, req *datapb.SaveBinlogPath
 		log.Warn("node is not matched with channel", zap.String("channel", channel), zap.Int64("nodeID", nodeID))
 	}
 
-	// set segment to SegmentState_Flushing and save binlogs and checkpoints
-	err := s.meta.UpdateFlushSegmentsInfo(req.GetSegmentID(), req.GetFlushed(),
-		req.GetField2BinlogPaths(), req.GetField2StatslogPaths(), req.GetDeltalogs(),
-		req.GetCheckPoints(), req.GetStartPositions())
+	// the binlog stats are cross-checked with the reported row count, an inconsistent report
+	// is rejected with ErrorCode_SegmentStatsMismatch and the datanode retries the sync
+	err := checkFieldBinlogs(segmentID, req.GetField2BinlogPaths())
+	if err == nil {
+		// set segment to SegmentState_Flushing and save binlogs and checkpoints
+		err = s.meta.UpdateFlushSegmentsInfo(req.GetSegmentID(), req.GetFlushed(),
+			req.GetField2BinlogPaths(), req.GetField2StatslogPaths(), req.GetDeltalogs(),
+			req.GetCheckPoints(), req.GetStartPositions())
+	}
 	if err != nil {
 		log.Error("save binlog and checkpoints failed",
 			zap.Int64("segmentID", req.GetSegmentID()),
 			zap.Error(err))
+		if errors.Is(err, errSegmentStatsMismatched) {
+			resp.ErrorCode = commonpb.ErrorCode_SegmentStatsMismatch
+		}
 		resp.Reason = err.Error()
 		return resp, nil
 	}
@@ -373,6 +404,11 @@ func (s *Server) GetComponentStates(ctx context.Context) (*internalpb.ComponentS
 	return resp, nil
 }
 
+// CheckReady checks whether DataCoord is healthy and its session is registered in etcd
+func (s *Server) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, s, s.session)
+}
+
 // GetRecoveryInfo get recovery info for segment
 func (s *Server) GetRecoveryInfo(ctx context.Context, req *datapb.GetRecoveryInfoRequest) (*datapb.GetRecoveryInfoResponse, error) {
 	collectionID := req.GetCollectionID()
@@ -412,7 +448,16 @@ func (s *Server) GetRecoveryInfo(ctx context.Context, req *datapb.GetRecoveryInf
 			flushedIDs[id] = struct{}{}
 		}
 
-		binlogs := segment.GetBinlogs()
+		// binlog paths are not kept in memory, fetch them from kv store
+		segBinlogs, err := s.meta.GetSegmentBinlogs(id)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to get binlogs of segment %d, err = %s", id, err)
+			log.Error(errMsg)
+			resp.Status.Reason = errMsg
+			return resp, nil
+		}
+
+		binlogs := segBinlogs.GetFieldBinlogs()
 		field2Binlog := make(map[UniqueID][]string)
 		for _, field := range binlogs {
 			field2Binlog[field.GetFieldID()] = append(field2Binlog[field.GetFieldID()], field.GetBinlogs()...)
@@ -428,7 +473,7 @@ func (s *Server) GetRecoveryInfo(ctx context.Context, req *datapb.GetRecoveryInf
 
 		segmentsNumOfRows[id] = segment.NumOfRows
 
-		statsBinlogs := segment.GetStatslogs()
+		statsBinlogs := segBinlogs.GetStatslogs()
 		field2StatsBinlog := make(map[UniqueID][]string)
 		for _, field := range statsBinlogs {
 			field2StatsBinlog[field.GetFieldID()] = append(field2StatsBinlog[field.GetFieldID()], field.GetBinlogs()...)
@@ -442,7 +487,7 @@ func (s *Server) GetRecoveryInfo(ctx context.Context, req *datapb.GetRecoveryInf
 			segment2StatsBinlogs[id] = append(segment2StatsBinlogs[id], fieldBinlogs)
 		}
 
-		segment2DeltaBinlogs[id] = append(segment2DeltaBinlogs[id], segment.GetDeltalogs()...)
+		segment2DeltaBinlogs[id] = append(segment2DeltaBinlogs[id], segBinlogs.GetDeltalogs()...)
 	}
 
 	binlogs := make([]*datapb.SegmentBinlogs, 0, len(segment2Binlogs))
@@ -585,6 +630,31 @@ func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest
 		return metrics, err
 	}
 
+	if metricType == metricsinfo.UpdateConfigMetrics {
+		metrics := s.configUpdater.UpdateByMetricsRequest(req,
+			metricsinfo.ConstructComponentName(typeutil.DataCoordRole, Params.NodeID))
+
+		log.Debug("DataCoord.GetMetrics",
+			zap.Int64("node_id", Params.NodeID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		metrics := s.setLogLevel(ctx, req)
+
+		log.Debug("DataCoord.GetMetrics",
+			zap.Int64("node_id", Params.NodeID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Debug("DataCoord.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", Params.NodeID),
 		zap.String("req", req.Request),
@@ -598,3 +668,220 @@ func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest
 		Response: "",
 	}, nil
 }
+
+// Import creates an import task of the files, and assigns it to a datanode, which reads the files
+// and reports the generated segments by ReportImport
+func (s *Server) Import(ctx context.Context, req *datapb.ImportTaskRequest) (*datapb.ImportTaskResponse, error) {
+	resp := &datapb.ImportTaskResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	log.Debug("receive Import request",
+		zap.Int64("collectionID", req.GetCollectionID()),
+		zap.Int64("partitionID", req.GetPartitionID()),
+		zap.Bool("rowBased", req.GetRowBased()),
+		zap.Strings("files", req.GetFiles()))
+	if s.isClosed() {
+		resp.Status.Reason = serverNotServingErrMsg
+		return resp, nil
+	}
+	if len(req.GetFiles()) == 0 {
+		resp.Status.Reason = "no file to import"
+		return resp, nil
+	}
+
+	taskID, err := s.allocator.allocID(ctx)
+	if err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+	task := &datapb.ImportTaskInfo{
+		ID:           taskID,
+		CollectionID: req.GetCollectionID(),
+		PartitionID:  req.GetPartitionID(),
+		RowBased:     req.GetRowBased(),
+		Files:        req.GetFiles(),
+		Options:      req.GetOptions(),
+		State:        commonpb.ImportState_ImportPending,
+		CreateTs:     time.Now().Unix(),
+	}
+	if err := s.meta.AddImportTask(task); err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+	resp.TaskID = taskID
+
+	nodeID, err := s.cluster.Import(ctx, &datapb.ImportTask{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_Import,
+			SourceID: Params.NodeID,
+		},
+		TaskID:       taskID,
+		CollectionID: task.GetCollectionID(),
+		PartitionID:  task.GetPartitionID(),
+		RowBased:     task.GetRowBased(),
+		Files:        task.GetFiles(),
+		Options:      task.GetOptions(),
+	}, s.runningImportTasks())
+	if err != nil {
+		log.Warn("failed to assign import task", zap.Int64("taskID", taskID), zap.Error(err))
+		if err := s.failImportTask(taskID, err.Error()); err != nil {
+			log.Warn("failed to fail import task", zap.Int64("taskID", taskID), zap.Error(err))
+		}
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+	err = s.meta.UpdateImportTask(taskID, func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		task.DatanodeID = nodeID
+		return nil, nil
+	})
+	if err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+
+	log.Info("import task is assigned", zap.Int64("taskID", taskID), zap.Int64("nodeID", nodeID))
+	resp.DatanodeID = nodeID
+	resp.Status.ErrorCode = commonpb.ErrorCode_Success
+	return resp, nil
+}
+
+// GetImportState gets the state of an import task
+func (s *Server) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	resp := &milvuspb.GetImportStateResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if s.isClosed() {
+		resp.Status.Reason = serverNotServingErrMsg
+		return resp, nil
+	}
+	task := s.meta.GetImportTask(req.GetTask())
+	if task == nil {
+		FailResponse(resp.Status, fmt.Sprintf("import task %d not found", req.GetTask()))
+		return resp, nil
+	}
+	resp.State = task.GetState()
+	resp.RowCount = task.GetRowCount()
+	resp.SegmentIds = task.GetSegmentIDs()
+	resp.Infos = task.GetInfos()
+	resp.Status.ErrorCode = commonpb.ErrorCode_Success
+	return resp, nil
+}
+
+// ReportImport updates the import task with the result reported by datanode, the segments of persisted
+// import task are added as flushed segments, and their indexes are built
+func (s *Server) ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error) {
+	resp := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_UnexpectedError,
+	}
+	if s.isClosed() {
+		resp.Reason = serverNotServingErrMsg
+		return resp, nil
+	}
+	log.Debug("receive ReportImport request",
+		zap.Int64("taskID", req.GetTaskID()),
+		zap.Int64("nodeID", req.GetBase().GetSourceID()),
+		zap.String("state", req.GetState().String()),
+		zap.Int("segments", len(req.GetSegments())),
+		zap.Int64("rows", req.GetRowCount()))
+
+	var segmentIDs []UniqueID
+	err := s.meta.UpdateImportTask(req.GetTaskID(), func(task *datapb.ImportTaskInfo) ([]*datapb.SegmentInfo, error) {
+		if !isImportRunning(task.GetState()) {
+			return nil, fmt.Errorf("import task %d is already %s", task.GetID(), task.GetState())
+		}
+		switch req.GetState() {
+		case commonpb.ImportState_ImportStarted:
+			task.State = commonpb.ImportState_ImportStarted
+			return nil, nil
+		case commonpb.ImportState_ImportFailed:
+			task.State = commonpb.ImportState_ImportFailed
+			task.Infos = req.GetInfos()
+			return nil, nil
+		case commonpb.ImportState_ImportPersisted:
+			segments := make([]*datapb.SegmentInfo, 0, len(req.GetSegments()))
+			for _, segment := range req.GetSegments() {
+				if segment.GetCollectionID() != task.GetCollectionID() || segment.GetPartitionID() != task.GetPartitionID() ||
+					segment.GetState() != commonpb.SegmentState_Flushed {
+					return nil, fmt.Errorf("segment %d does not match import task %d", segment.GetID(), task.GetID())
+				}
+				segments = append(segments, segment)
+				segmentIDs = append(segmentIDs, segment.GetID())
+			}
+			task.State = commonpb.ImportState_ImportPersisted
+			task.RowCount = req.GetRowCount()
+			task.SegmentIDs = segmentIDs
+			return segments, nil
+		default:
+			return nil, fmt.Errorf("unexpected import state %s", req.GetState())
+		}
+	})
+	if err != nil {
+		log.Warn("failed to update import task", zap.Int64("taskID", req.GetTaskID()), zap.Error(err))
+		resp.Reason = err.Error()
+		return resp, nil
+	}
+
+	if req.GetState() == commonpb.ImportState_ImportPersisted {
+		go s.completeImportTask(s.ctx, req.GetTaskID(), segmentIDs)
+	}
+	resp.ErrorCode = commonpb.ErrorCode_Success
+	return resp, nil
+}
+
+// DropSegment drops a segment by an operator, the segment is marked dropped and its binlogs are garbage collected after
+// the retention duration, then the drop is cascaded to QueryCoord releasing the loaded segment and to IndexCoord
+// dropping its index builds. A segment still in use is refused unless forced, and dropping a dropped segment again
+// retries the cascade. Every drop is recorded by an audit log.
+func (s *Server) DropSegment(ctx context.Context, req *datapb.DropSegmentRequest) (*commonpb.Status, error) {
+	resp := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_UnexpectedError,
+	}
+	if s.isClosed() {
+		resp.Reason = serverNotServingErrMsg
+		return resp, nil
+	}
+	segmentID := req.GetSegmentID()
+	log.Debug("receive DropSegment request",
+		zap.Int64("segmentID", segmentID),
+		zap.Bool("force", req.GetForce()),
+		zap.Int64("sourceID", req.GetBase().GetSourceID()))
+
+	segment := s.meta.GetSegment(segmentID)
+	if segment == nil {
+		segment = s.meta.GetDroppedSegment(segmentID)
+		if segment == nil {
+			err := fmt.Errorf("segment %d not found", segmentID)
+			auditDropSegment(req, nil, err)
+			resp.Reason = err.Error()
+			return resp, nil
+		}
+	} else {
+		if !req.GetForce() {
+			if err := s.checkSegmentDroppable(segment); err != nil {
+				auditDropSegment(req, segment, err)
+				resp.Reason = err.Error()
+				return resp, nil
+			}
+		}
+		// release the allocations of the growing segment before it's removed from meta
+		s.segmentManager.DropSegment(ctx, segmentID)
+		if err := s.meta.DropSegment(segmentID); err != nil {
+			auditDropSegment(req, segment, err)
+			resp.Reason = err.Error()
+			return resp, nil
+		}
+	}
+
+	if err := s.cascadeDropSegment(ctx, segment); err != nil {
+		auditDropSegment(req, segment, err)
+		resp.Reason = err.Error()
+		return resp, nil
+	}
+	auditDropSegment(req, segment, nil)
+	resp.ErrorCode = commonpb.ErrorCode_Success
+	return resp, nil
+}
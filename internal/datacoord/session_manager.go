@@ -18,6 +18,7 @@ package datacoord
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -28,7 +29,10 @@ import (
 	"go.uber.org/zap"
 )
 
-const flushTimeout = 5 * time.Second
+const (
+	flushTimeout  = 5 * time.Second
+	importTimeout = 5 * time.Second
+)
 
 // SessionManager provides the grpc interfaces of cluster
 type SessionManager struct {
@@ -131,6 +135,36 @@ func (c *SessionManager) execFlush(ctx context.Context, nodeID int64, req *datap
 	log.Debug("success to flush", zap.Int64("node", nodeID), zap.Any("segments", req))
 }
 
+// Import is a grpc interface. It sends the import task to nodeID synchronously, and returns error
+// if the node does not accept it
+func (c *SessionManager) Import(ctx context.Context, nodeID int64, req *datapb.ImportTask) error {
+	c.sessions.RLock()
+	session, ok := c.sessions.data[nodeID]
+	c.sessions.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("node %d not found", nodeID)
+	}
+
+	cli, err := session.GetOrCreateClient(ctx)
+	if err != nil {
+		log.Warn("unable to connect to node", zap.Int64("node", nodeID), zap.Error(err))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	resp, err := cli.Import(ctx, req)
+	if err := VerifyResponse(resp, err); err != nil {
+		log.Warn("failed to import", zap.Int64("node", nodeID), zap.Int64("taskID", req.GetTaskID()), zap.Error(err))
+		return err
+	}
+
+	log.Debug("success to assign import task", zap.Int64("node", nodeID), zap.Int64("taskID", req.GetTaskID()))
+	return nil
+}
+
 // Close release sessions
 func (c *SessionManager) Close() {
 	c.sessions.Lock()
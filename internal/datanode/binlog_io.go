@@ -20,12 +20,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"path"
 	"strconv"
 
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
@@ -55,6 +55,8 @@ type uploader interface {
 type binlogIO struct {
 	kv.BaseKV
 	allocatorInterface
+	// keepOrder writes the rows of InsertData in their order instead of sorting them by row id
+	keepOrder bool
 }
 
 var _ downloader = (*binlogIO)(nil)
@@ -162,18 +164,19 @@ func (b *binlogIO) upload(
 // returns key, value
 func (b *binlogIO) genDeltaBlobs(data *DeleteData, collID, partID, segID UniqueID) (string, []byte, error) {
 	dCodec := storage.NewDeleteCodec()
+	dCodec.Checksum = Params.FlushChecksum
 
 	blob, err := dCodec.Serialize(collID, partID, segID, data)
 	if err != nil {
 		return "", nil, err
 	}
 
-	k, err := b.genKey(true, collID, partID, segID)
+	logID, err := b.allocID()
 	if err != nil {
 		return "", nil, err
 	}
 
-	key := path.Join(Params.DeleteBinlogRootPath, k)
+	key := pathutil.DeltalogPath(Params.MinioRootPath, collID, partID, segID, logID)
 
 	return key, blob.GetValue(), nil
 }
@@ -181,6 +184,9 @@ func (b *binlogIO) genDeltaBlobs(data *DeleteData, collID, partID, segID UniqueI
 // return kvs, insert-paths, stats-paths
 func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, meta *etcdpb.CollectionMeta) (map[string]string, []*datapb.FieldBinlog, []*datapb.FieldBinlog, error) {
 	inCodec := storage.NewInsertCodec(meta)
+	inCodec.Compression = Params.FlushCompression
+	inCodec.Checksum = Params.FlushChecksum
+	inCodec.KeepOrder = b.keepOrder
 	inlogs, statslogs, err := inCodec.Serialize(partID, segID, data)
 	if err != nil {
 		return nil, nil, nil, err
@@ -204,8 +210,7 @@ func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, meta
 			log.Error("can not parse string to fieldID", zap.Error(err))
 			return nil, nil, nil, err
 		}
-		k := JoinIDPath(meta.GetID(), partID, segID, fID, <-generator)
-		key := path.Join(Params.InsertBinlogRootPath, k)
+		key := pathutil.InsertBinlogPath(Params.MinioRootPath, meta.GetID(), partID, segID, fID, <-generator)
 
 		kvs[key] = bytes.NewBuffer(blob.GetValue()).String()
 		inpaths = append(inpaths, &datapb.FieldBinlog{
@@ -221,8 +226,7 @@ func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, meta
 			return nil, nil, nil, err
 		}
 
-		k := JoinIDPath(meta.GetID(), partID, segID, fID, <-generator)
-		key := path.Join(Params.StatsBinlogRootPath, k)
+		key := pathutil.StatslogPath(Params.MinioRootPath, meta.GetID(), partID, segID, fID, <-generator)
 
 		kvs[key] = bytes.NewBuffer(blob.GetValue()).String()
 		statspaths = append(statspaths, &datapb.FieldBinlog{
@@ -24,6 +24,7 @@ import (
 	"github.com/milvus-io/milvus/internal/kv"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,7 +35,7 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 	alloc := NewAllocatorFactory()
 	kv := memkv.NewMemoryKV()
 
-	b := &binlogIO{kv, alloc}
+	b := &binlogIO{BaseKV: kv, allocatorInterface: alloc}
 	t.Run("Test upload", func(t *testing.T) {
 		f := &MetaFactory{}
 		meta := f.GetCollectionMeta(UniqueID(10001), "uploads")
@@ -116,8 +117,8 @@ func prepareBlob(kv kv.BaseKV, key string) ([]byte, string, error) {
 func TestBinlogIOInnerMethods(t *testing.T) {
 	alloc := NewAllocatorFactory()
 	b := &binlogIO{
-		memkv.NewMemoryKV(),
-		alloc,
+		BaseKV:             memkv.NewMemoryKV(),
+		allocatorInterface: alloc,
 	}
 
 	t.Run("Test genDeltaBlobs", func(t *testing.T) {
@@ -146,6 +147,12 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 					assert.NotEmpty(t, k)
 					assert.NotEmpty(t, v)
 
+					info, err := pathutil.ParseDeltalogPath(Params.MinioRootPath, k)
+					assert.NoError(t, err)
+					assert.Equal(t, meta.GetID(), info.CollectionID)
+					assert.Equal(t, UniqueID(10), info.PartitionID)
+					assert.Equal(t, UniqueID(1), info.SegmentID)
+
 					log.Debug("genDeltaBlobs returns", zap.String("key", k))
 				}
 			})
@@ -164,6 +171,19 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 		assert.Equal(t, 11, len(pin))
 		assert.Equal(t, 14, len(kvs))
 
+		for _, fieldBinlog := range pin {
+			info, err := pathutil.ParseInsertBinlogPath(Params.MinioRootPath, fieldBinlog.GetBinlogs()[0])
+			assert.NoError(t, err)
+			assert.Equal(t, meta.GetID(), info.CollectionID)
+			assert.Equal(t, fieldBinlog.GetFieldID(), info.FieldID)
+		}
+		for _, fieldBinlog := range pstats {
+			info, err := pathutil.ParseStatslogPath(Params.MinioRootPath, fieldBinlog.GetBinlogs()[0])
+			assert.NoError(t, err)
+			assert.Equal(t, UniqueID(1), info.SegmentID)
+			assert.Equal(t, fieldBinlog.GetFieldID(), info.FieldID)
+		}
+
 		log.Debug("test paths",
 			zap.Any("kvs no.", len(kvs)),
 			zap.String("insert paths field0", pin[0].GetBinlogs()[0]),
@@ -0,0 +1,78 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"sync/atomic"
+)
+
+// deleteEntrySize is the memory size in bytes of a buffered delete, a primary key and its timestamp
+const deleteEntrySize = 16
+
+// The memory sizes in bytes held by all the flowgraphs in DataNode
+var (
+	// insertBufferMemory is the memory of insert buffers
+	insertBufferMemory int64
+	// deleteBufferMemory is the memory of delete buffers
+	deleteBufferMemory int64
+	// flushingMemory is the memory of the binlogs being saved to storage by flush tasks
+	flushingMemory int64
+)
+
+func addInsertBufferMemory(delta int64) {
+	atomic.AddInt64(&insertBufferMemory, delta)
+}
+
+func getInsertBufferMemory() int64 {
+	return atomic.LoadInt64(&insertBufferMemory)
+}
+
+func addDeleteBufferMemory(delta int64) {
+	atomic.AddInt64(&deleteBufferMemory, delta)
+}
+
+func getDeleteBufferMemory() int64 {
+	return atomic.LoadInt64(&deleteBufferMemory)
+}
+
+func addFlushingMemory(delta int64) {
+	atomic.AddInt64(&flushingMemory, delta)
+}
+
+func getFlushingMemory() int64 {
+	return atomic.LoadInt64(&flushingMemory)
+}
+
+// getBufferMemory returns the memory held by the buffers and the flush tasks in progress
+func getBufferMemory() int64 {
+	return getInsertBufferMemory() + getDeleteBufferMemory() + getFlushingMemory()
+}
+
+// isMemoryHigh returns whether the memory held by DataNode exceeds Params.MemoryHighWatermark,
+// it's reported to DataCoord in time tick messages, so that the inserts are throttled before DataNode runs out of memory.
+func isMemoryHigh() bool {
+	return Params.MemoryHighWatermark > 0 && getBufferMemory() > Params.MemoryHighWatermark
+}
+
+// kvsMemorySize returns the memory size of the values to save
+func kvsMemorySize(kvs map[string]string) int64 {
+	size := int64(0)
+	for _, v := range kvs {
+		size += int64(len(v))
+	}
+	return size
+}
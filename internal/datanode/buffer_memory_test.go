@@ -0,0 +1,128 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/flowgraph"
+)
+
+// ttCaptureMsgStream records the time tick messages produced
+type ttCaptureMsgStream struct {
+	mockTtMsgStream
+	mu   sync.Mutex
+	msgs []*msgstream.DataNodeTtMsg
+}
+
+func (s *ttCaptureMsgStream) Produce(pack *msgstream.MsgPack) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, msg := range pack.Msgs {
+		s.msgs = append(s.msgs, msg.(*msgstream.DataNodeTtMsg))
+	}
+	return nil
+}
+
+func (s *ttCaptureMsgStream) last() *msgstream.DataNodeTtMsg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.msgs[len(s.msgs)-1]
+}
+
+func TestKvsMemorySize(t *testing.T) {
+	assert.EqualValues(t, 0, kvsMemorySize(nil))
+	assert.EqualValues(t, 7, kvsMemorySize(map[string]string{"a": "abc", "b": "defg"}))
+}
+
+// TestMemoryHighWithSlowStorage keeps buffering deletes while the storage is stuck, the memory high flag
+// is reported in time ticks instead of buffering without bound, and it's cleared once the flushes are done.
+func TestMemoryHighWithSlowStorage(t *testing.T) {
+	const (
+		chanName     = "datanode-test-memory-high"
+		flushedSegID = 77
+	)
+	replica := newMockReplica()
+	seg := &Segment{
+		segmentID:   flushedSegID,
+		channelName: chanName,
+		pkFilter:    bloom.NewWithEstimates(1000, 0.01),
+		minPK:       math.MaxInt64,
+		maxPK:       math.MinInt64,
+	}
+	seg.updatePKRange([]int64{100, 200})
+	replica.flushedSegments[flushedSegID] = seg
+
+	block := make(chan struct{})
+	fm := NewRendezvousFlushManager(NewAllocatorFactory(), &slowKV{MemoryKV: memkv.NewMemoryKV(), block: block}, replica,
+		func(*segmentFlushPack) error {
+			return nil
+		})
+	delNode, err := newDeleteNode(context.Background(), fm, &nodeConfig{
+		replica:      replica,
+		allocator:    NewAllocatorFactory(),
+		vChannelName: chanName,
+	})
+	require.NoError(t, err)
+
+	stream := &ttCaptureMsgStream{}
+	ibNode := &insertBufferNode{
+		channelName:    chanName,
+		timeTickStream: stream,
+	}
+
+	baseline := getBufferMemory()
+	watermark := Params.MemoryHighWatermark
+	Params.MemoryHighWatermark = baseline + 1024
+	defer func() {
+		Params.MemoryHighWatermark = watermark
+	}()
+
+	require.NoError(t, ibNode.writeHardTimeTick(1000))
+	assert.False(t, stream.last().MemoryHigh)
+
+	// the deletes of flushed segment are synced at once, and stay in memory since the storage is stuck
+	for i := 0; i < 1000 && !isMemoryHigh(); i++ {
+		msg := GenFlowGraphDeleteMsg([]int64{100, 200}, chanName)
+		msg.endPositions = []*internalpb.MsgPosition{{ChannelName: chanName, MsgID: []byte{byte(i), byte(i >> 8)}, Timestamp: Timestamp(2000 + i)}}
+		delNode.Operate([]flowgraph.Msg{&msg})
+	}
+	require.True(t, isMemoryHigh())
+	assert.Greater(t, getFlushingMemory(), int64(0))
+	require.NoError(t, ibNode.writeHardTimeTick(2000))
+	assert.True(t, stream.last().MemoryHigh)
+
+	// the flag is cleared once the buffers drain
+	close(block)
+	assert.Eventually(t, func() bool {
+		return getBufferMemory() <= baseline
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, ibNode.writeHardTimeTick(3000))
+	assert.False(t, stream.last().MemoryHigh)
+	delNode.Close()
+}
@@ -0,0 +1,171 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+// bufferKind is the kind of buffers tracked by channelCheckpoint,
+// the insert and delete buffers of a segment are buffered and synced by different flowgraph nodes
+type bufferKind int
+
+const (
+	insertBufferKind bufferKind = iota
+	deleteBufferKind
+)
+
+type bufferKey struct {
+	segmentID UniqueID
+	kind      bufferKind
+}
+
+// syncingSpan is the data of a segment buffered from start and synced at end, which is not saved into DataCoord yet
+type syncingSpan struct {
+	start *internalpb.MsgPosition
+	end   *internalpb.MsgPosition
+}
+
+// channelCheckpoint tracks the checkpoint of a vchannel, the position from which the replay recovers all the data
+// not durably flushed. It's the minimal start position of the buffered and syncing data, or the position processed
+// by the flowgraph if all the data is flushed. Replaying from the checkpoint and skipping the data before the
+// segment checkpoints loses no rows.
+type channelCheckpoint struct {
+	mu          sync.Mutex
+	channelName string
+	// the end position of the last message pack processed by all the flowgraph nodes
+	current *internalpb.MsgPosition
+	// the start positions of the buffers
+	buffering map[bufferKey]*internalpb.MsgPosition
+	// segment id -> the spans being synced, in order of sync
+	syncing map[UniqueID][]syncingSpan
+}
+
+func newChannelCheckpoint(channelName string) *channelCheckpoint {
+	return &channelCheckpoint{
+		channelName: channelName,
+		buffering:   make(map[bufferKey]*internalpb.MsgPosition),
+		syncing:     make(map[UniqueID][]syncingSpan),
+	}
+}
+
+// clonePosition returns a copy of pos in the vchannel, since the flowgraph may carry the positions of pchannel
+func (c *channelCheckpoint) clonePosition(pos *internalpb.MsgPosition) *internalpb.MsgPosition {
+	cloned := proto.Clone(pos).(*internalpb.MsgPosition)
+	cloned.ChannelName = c.channelName
+	return cloned
+}
+
+// buffer records the data of segment is buffered from the message pack starting at start
+func (c *channelCheckpoint) buffer(segmentID UniqueID, kind bufferKind, start *internalpb.MsgPosition) {
+	if start == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := bufferKey{segmentID: segmentID, kind: kind}
+	if _, ok := c.buffering[key]; !ok {
+		c.buffering[key] = c.clonePosition(start)
+	}
+}
+
+// sync records the buffer of segment is handed to flush manager at pos
+func (c *channelCheckpoint) sync(segmentID UniqueID, kind bufferKind, pos *internalpb.MsgPosition) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := bufferKey{segmentID: segmentID, kind: kind}
+	start, ok := c.buffering[key]
+	if !ok {
+		return
+	}
+	delete(c.buffering, key)
+	c.syncing[segmentID] = append(c.syncing[segmentID], syncingSpan{start: start, end: pos})
+}
+
+// done records the data of segment before pos is saved into DataCoord
+func (c *channelCheckpoint) done(segmentID UniqueID, pos *internalpb.MsgPosition) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := make([]syncingSpan, 0, len(c.syncing[segmentID]))
+	for _, span := range c.syncing[segmentID] {
+		if span.end.GetTimestamp() > pos.GetTimestamp() {
+			spans = append(spans, span)
+		}
+	}
+	if len(spans) == 0 {
+		delete(c.syncing, segmentID)
+		return
+	}
+	c.syncing[segmentID] = spans
+}
+
+// advance records the message pack ending at pos is processed by all the flowgraph nodes
+func (c *channelCheckpoint) advance(pos *internalpb.MsgPosition) {
+	if pos == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = c.clonePosition(pos)
+}
+
+// get returns the checkpoint, nil if nothing is processed yet
+func (c *channelCheckpoint) get() *internalpb.MsgPosition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checkpoint := c.current
+	update := func(pos *internalpb.MsgPosition) {
+		if checkpoint == nil || pos.GetTimestamp() < checkpoint.GetTimestamp() {
+			checkpoint = pos
+		}
+	}
+	for _, start := range c.buffering {
+		update(start)
+	}
+	for _, spans := range c.syncing {
+		for _, span := range spans {
+			update(span.start)
+		}
+	}
+	return checkpoint
+}
+
+// processed returns the end position of the last message pack processed by all the flowgraph nodes
+func (c *channelCheckpoint) processed() *internalpb.MsgPosition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+// bufferedSegments returns the segments holding insert or delete buffers not handed to flush manager yet
+func (c *channelCheckpoint) bufferedSegments() []UniqueID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seen := make(map[UniqueID]struct{})
+	segments := make([]UniqueID, 0, len(c.buffering))
+	for key := range c.buffering {
+		if _, ok := seen[key.segmentID]; !ok {
+			seen[key.segmentID] = struct{}{}
+			segments = append(segments, key.segmentID)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,239 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+func TestChannelCheckpoint(t *testing.T) {
+	position := func(ts Timestamp) *internalpb.MsgPosition {
+		return &internalpb.MsgPosition{ChannelName: "pchannel", MsgID: []byte(strconv.Itoa(int(ts))), Timestamp: ts}
+	}
+	cp := newChannelCheckpoint("vchannel")
+	assert.Nil(t, cp.get())
+	assert.Nil(t, cp.processed())
+
+	cp.advance(position(10))
+	assert.EqualValues(t, 10, cp.get().GetTimestamp())
+	assert.EqualValues(t, "vchannel", cp.get().GetChannelName())
+
+	// the buffers hold the checkpoint at their start positions
+	cp.buffer(1, insertBufferKind, position(10))
+	cp.buffer(2, deleteBufferKind, position(20))
+	cp.buffer(1, insertBufferKind, position(30))
+	cp.buffer(1, deleteBufferKind, position(30))
+	cp.advance(position(40))
+	assert.EqualValues(t, 10, cp.get().GetTimestamp())
+	assert.EqualValues(t, 40, cp.processed().GetTimestamp())
+	assert.ElementsMatch(t, []UniqueID{1, 2}, cp.bufferedSegments())
+	cp.sync(1, deleteBufferKind, position(40))
+	cp.done(1, position(40))
+
+	// the syncing data holds the checkpoint until it's saved
+	cp.sync(1, insertBufferKind, position(40))
+	cp.sync(3, insertBufferKind, position(40))
+	assert.EqualValues(t, 10, cp.get().GetTimestamp())
+	cp.buffer(1, insertBufferKind, position(40))
+	cp.done(1, position(40))
+	assert.EqualValues(t, 20, cp.get().GetTimestamp())
+
+	cp.sync(2, deleteBufferKind, position(50))
+	cp.sync(1, insertBufferKind, position(50))
+	cp.advance(position(50))
+	cp.done(2, position(50))
+	assert.EqualValues(t, 40, cp.get().GetTimestamp())
+	cp.done(1, position(50))
+	assert.EqualValues(t, 50, cp.get().GetTimestamp())
+}
+
+// TestChannelCheckpointRecovery crashes a DataNode which reports its channel checkpoint in time ticks,
+// and replays the channel from the reported checkpoint instead of the start positions of the segments.
+// The binlogs and the replayed rows should cover every row exactly once.
+func TestChannelCheckpointRecovery(t *testing.T) {
+	const (
+		segmentNum = 3
+		msgNum     = 300
+		crashAt    = 200
+		// the syncs after inFlightAt are still in flight when crashing
+		inFlightAt = 150
+	)
+	// the i-th message pack has one row i of segment i%segmentNum
+	segmentOf := func(i int) UniqueID {
+		return UniqueID(i % segmentNum)
+	}
+	// the i-th message pack starts at position(i-1) and ends at position(i)
+	position := func(i int) *internalpb.MsgPosition {
+		return &internalpb.MsgPosition{ChannelName: "pchannel", MsgID: []byte(strconv.Itoa(i)), Timestamp: Timestamp(i + 1)}
+	}
+
+	kv := memkv.NewMemoryKV()
+	channelCP := newChannelCheckpoint("vchannel")
+	crash := make(chan struct{})
+	var mu sync.Mutex
+	crashed := false
+	checkpoints := make(map[UniqueID]*internalpb.MsgPosition)
+	binlogs := make(map[UniqueID][]string)
+	notify := func(pack *segmentFlushPack) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if crashed {
+			return nil
+		}
+		for _, key := range pack.insertLogs {
+			binlogs[pack.segmentID] = append(binlogs[pack.segmentID], key)
+		}
+		checkpoints[pack.segmentID] = pack.pos
+		channelCP.done(pack.segmentID, pack.pos)
+		return nil
+	}
+
+	queues := make(map[UniqueID]*orderFlushQueue)
+	buffers := make(map[UniqueID]*BufferData)
+	rows := make(map[UniqueID][]string)
+	lastDone := make(map[UniqueID]*internalpb.MsgPosition)
+	for s := 0; s < segmentNum; s++ {
+		segmentID := UniqueID(s)
+		queues[segmentID] = newOrderFlushQueue(segmentID, notify)
+		queues[segmentID].init()
+		buffers[segmentID] = &BufferData{limit: 1000, startTime: time.Now()}
+	}
+	policies := []segmentSyncPolicy{syncWhenExceedSize(64)}
+
+	var reported *internalpb.MsgPosition
+	for i := 0; i < crashAt; i++ {
+		segmentID := segmentOf(i)
+		rows[segmentID] = append(rows[segmentID], strconv.Itoa(i))
+		buffers[segmentID].size++
+		buffers[segmentID].memorySize += int64(8 * (segmentID + 1))
+		channelCP.buffer(segmentID, insertBufferKind, position(i-1))
+
+		candidates := make([]segmentBuffer, 0, segmentNum)
+		for s := 0; s < segmentNum; s++ {
+			candidates = append(candidates, segmentBuffer{segmentID: UniqueID(s), buffer: buffers[UniqueID(s)]})
+		}
+		for _, syncID := range selectSyncSegments(policies, candidates, time.Now()) {
+			pos := position(i)
+			key := "binlog/" + strconv.FormatInt(syncID, 10) + "/" + strconv.Itoa(i)
+			var block <-chan struct{}
+			if i >= inFlightAt {
+				block = crash
+			} else {
+				lastDone[syncID] = pos
+			}
+			task := &flushBufferInsertTask{
+				BaseKV: &slowKV{MemoryKV: kv, block: block},
+				data:   map[string]string{key: strings.Join(rows[syncID], ",")},
+			}
+			channelCP.sync(syncID, insertBufferKind, pos)
+			queues[syncID].enqueueInsertFlush(task, map[UniqueID]string{0: key}, map[UniqueID]string{}, nil, false, pos)
+			queues[syncID].enqueueDelFlush(&flushBufferDeleteTask{}, nil, pos)
+
+			buffers[syncID] = &BufferData{limit: 1000, startTime: time.Now()}
+			rows[syncID] = nil
+		}
+		channelCP.advance(position(i))
+
+		// the checkpoint reported in time tick never moves backward
+		cp := channelCP.get()
+		if reported != nil {
+			assert.GreaterOrEqual(t, cp.Timestamp, reported.Timestamp)
+		}
+		reported = cp
+		if i == inFlightAt-1 {
+			// wait for the syncs before inFlightAt, so that the checkpoint advances
+			require.Len(t, lastDone, segmentNum)
+			require.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				for segmentID, pos := range lastDone {
+					if checkpoints[segmentID].GetTimestamp() != pos.Timestamp {
+						return false
+					}
+				}
+				return true
+			}, 10*time.Second, 10*time.Millisecond)
+		}
+	}
+
+	mu.Lock()
+	crashed = true
+	mu.Unlock()
+	close(crash)
+
+	// the replay window is trimmed, while the data not flushed is still replayed
+	require.NotNil(t, reported)
+	assert.EqualValues(t, "vchannel", reported.ChannelName)
+	assert.Greater(t, reported.Timestamp, position(inFlightAt/2).Timestamp)
+	assert.Less(t, reported.Timestamp, position(crashAt).Timestamp)
+
+	// recover from the segment checkpoints like DataCoord and ddNode do, seeking to the channel checkpoint
+	vchan := &datapb.VchannelInfo{CollectionID: 1, SeekPosition: reported}
+	for segmentID, cp := range checkpoints {
+		vchan.UnflushedSegments = append(vchan.UnflushedSegments, &datapb.SegmentInfo{
+			ID:          segmentID,
+			DmlPosition: cp,
+		})
+	}
+	dd := newDDNode(nil, 1, vchan)
+
+	recovered := make(map[UniqueID][]string)
+	for segmentID, keys := range binlogs {
+		for _, key := range keys {
+			value, err := kv.Load(key)
+			require.NoError(t, err)
+			recovered[segmentID] = append(recovered[segmentID], strings.Split(value, ",")...)
+		}
+	}
+	for i := 0; i < msgNum; i++ {
+		pos := position(i)
+		if pos.Timestamp <= vchan.SeekPosition.Timestamp {
+			continue
+		}
+		msg := &msgstream.InsertMsg{
+			BaseMsg:       msgstream.BaseMsg{BeginTimestamp: pos.Timestamp, EndTimestamp: pos.Timestamp},
+			InsertRequest: internalpb.InsertRequest{SegmentID: segmentOf(i)},
+		}
+		if !dd.filterFlushedSegmentInsertMessages(msg) {
+			recovered[segmentOf(i)] = append(recovered[segmentOf(i)], strconv.Itoa(i))
+		}
+	}
+
+	for s := 0; s < segmentNum; s++ {
+		expected := make([]string, 0)
+		for i := s; i < msgNum; i += segmentNum {
+			expected = append(expected, strconv.Itoa(i))
+		}
+		actual := recovered[UniqueID(s)]
+		sort.Strings(expected)
+		sort.Strings(actual)
+		assert.Equal(t, expected, actual, "segment %d", s)
+	}
+}
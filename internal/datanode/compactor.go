@@ -0,0 +1,610 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package datanode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+
+	"go.uber.org/zap"
+)
+
+// compactionReadBatch is the number of rows read from the binlogs of each field of a compacted segment at a time
+const compactionReadBatch = 1024
+
+var errCompactionPlanExecuting = errors.New("compaction plan is executing")
+
+// compactionProgress is the progress of a compaction task
+type compactionProgress struct {
+	MergedRows  int64 // rows read from the insert binlogs of the compacted segments
+	DeletedRows int64 // rows dropped by the deletes of the deltalogs
+	WrittenRows int64 // rows written into the binlogs of the target segments
+}
+
+// compactionTask merges the segments of each merge group of a compaction plan into the target segment of the group.
+// The insert binlogs are merged by timestamp in a streaming way, only a batch of rows of each segment and the
+// rows of the binlog being written are kept in memory.
+type compactionTask struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	plan         *datapb.CompactionPlan
+	meta         *etcdpb.CollectionMeta
+	chunkManager storage.ChunkManager
+	io           uploader
+	batchRows    int // rows of each binlog written
+
+	mergedRows  int64
+	deletedRows int64
+	writtenRows int64
+}
+
+func newCompactionTask(ctx context.Context, plan *datapb.CompactionPlan, meta *etcdpb.CollectionMeta,
+	cm storage.ChunkManager, blobKv kv.BaseKV, alloc allocatorInterface) (*compactionTask, error) {
+	batchRows, err := compactionBatchRows(meta.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &compactionTask{
+		ctx:          ctx,
+		cancel:       cancel,
+		plan:         plan,
+		meta:         meta,
+		chunkManager: cm,
+		io:           &binlogIO{BaseKV: blobKv, allocatorInterface: alloc, keepOrder: true},
+		batchRows:    batchRows,
+	}, nil
+}
+
+// compactionBatchRows returns the max number of rows in a binlog written by compaction,
+// which is bounded by the insert buffer size of flush
+func compactionBatchRows(schema *schemapb.CollectionSchema) (int, error) {
+	size, err := typeutil.EstimateSizePerRecord(schema)
+	if err != nil {
+		return 0, err
+	}
+	rows := 1
+	if size > 0 && Params.FlushInsertBufferSize/int64(size) > 1 {
+		rows = int(Params.FlushInsertBufferSize / int64(size))
+	}
+	return rows, nil
+}
+
+func (t *compactionTask) getPlanID() UniqueID {
+	return t.plan.GetPlanID()
+}
+
+// stop cancels the task, compact returns the error of the context then
+func (t *compactionTask) stop() {
+	t.cancel()
+}
+
+func (t *compactionTask) getProgress() compactionProgress {
+	return compactionProgress{
+		MergedRows:  atomic.LoadInt64(&t.mergedRows),
+		DeletedRows: atomic.LoadInt64(&t.deletedRows),
+		WrittenRows: atomic.LoadInt64(&t.writtenRows),
+	}
+}
+
+// compact merges the merge groups of the plan, and returns a result for each target segment
+func (t *compactionTask) compact() ([]*datapb.CompactionResult, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(t.meta.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*datapb.CompactionResult, 0, len(t.plan.GetMergeGroup()))
+	for _, group := range t.plan.GetMergeGroup() {
+		result, err := t.merge(group, pkField.GetFieldID())
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// merge writes the rows of the segments of the group which are not deleted into the target segment.
+// The deletes before the time travel point are applied, the later ones are kept in the deltalog of the
+// target segment, so that the rows can still be searched at the time before they are deleted.
+func (t *compactionTask) merge(group *datapb.CompactionMergeGroup, pkFieldID UniqueID) (*datapb.CompactionResult, error) {
+	applied, retained, err := t.loadDeletes(group)
+	if err != nil {
+		return nil, err
+	}
+
+	iterators := make([]storage.Iterator, 0, len(group.GetSegmentBinlogs()))
+	for _, segment := range group.GetSegmentBinlogs() {
+		itr, err := newSegmentRowIterator(t.chunkManager, segment.GetFieldBinlogs(), compactionReadBatch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid binlogs of segment %d: %w", segment.GetSegmentID(), err)
+		}
+		iterators = append(iterators, itr)
+	}
+	mergeItr := storage.NewTimestampMergeIterator(iterators)
+	defer mergeItr.Dispose()
+
+	result := &datapb.CompactionResult{
+		PlanID:    t.plan.GetPlanID(),
+		SegmentID: group.GetTargetSegmentID(),
+	}
+	buffer, err := newCompactionBuffer(t.meta.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+	for mergeItr.HasNext() {
+		if err := t.ctx.Err(); err != nil {
+			return nil, err
+		}
+		v, err := mergeItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		value := v.(*storage.Value)
+		atomic.AddInt64(&t.mergedRows, 1)
+		row := value.Row()
+		if pk, ok := row[pkFieldID].(int64); ok {
+			if ts, ok := applied[pk]; ok && value.Timestamp() <= ts {
+				atomic.AddInt64(&t.deletedRows, 1)
+				continue
+			}
+		}
+		// a full buffer is flushed only if there are more rows, so that the retained deletes are
+		// written with the last rows
+		if buffer.rows >= t.batchRows {
+			if err := t.flush(result, buffer, nil); err != nil {
+				return nil, err
+			}
+			if buffer, err = newCompactionBuffer(t.meta.GetSchema()); err != nil {
+				return nil, err
+			}
+		}
+		if err := buffer.append(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := mergeItr.Err(); err != nil {
+		return nil, err
+	}
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	// the retained deletes are dropped if no row is left, there is nothing to delete then
+	if buffer.rows > 0 {
+		if err := t.flush(result, buffer, retained); err != nil {
+			return nil, err
+		}
+	}
+	log.Info("compaction merge group done",
+		zap.Int64("planID", t.plan.GetPlanID()),
+		zap.Int64("segmentID", group.GetTargetSegmentID()),
+		zap.Int64("rows", result.GetNumOfRows()),
+		zap.Any("progress", t.getProgress()))
+	return result, nil
+}
+
+// loadDeletes reads the deltalogs of the segments of the group, and returns the latest delete timestamp of
+// each primary key deleted before the time travel point, and the deletes after it
+func (t *compactionTask) loadDeletes(group *datapb.CompactionMergeGroup) (map[int64]int64, *DeleteData, error) {
+	timetravel := int64(t.plan.GetTimetravel())
+	applied := make(map[int64]int64)
+	retained := &DeleteData{Data: make(map[int64]int64)}
+	for _, segment := range group.GetSegmentBinlogs() {
+		for _, deltalog := range segment.GetDeltalogs() {
+			if err := t.ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+			value, err := t.chunkManager.Read(deltalog.GetDeltaLogPath())
+			if err != nil {
+				return nil, nil, err
+			}
+			_, _, data, err := storage.NewDeleteCodec().Deserialize([]*Blob{{Key: deltalog.GetDeltaLogPath(), Value: value}})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read deltalog %s: %w", deltalog.GetDeltaLogPath(), err)
+			}
+			for pk, ts := range data.Data {
+				deletes := applied
+				if timetravel > 0 && ts >= timetravel {
+					deletes = retained.Data
+				}
+				if ts > deletes[pk] {
+					deletes[pk] = ts
+				}
+			}
+		}
+	}
+	return applied, retained, nil
+}
+
+// flush writes the rows of the buffer and the deletes into the binlogs of the target segment
+func (t *compactionTask) flush(result *datapb.CompactionResult, buffer *compactionBuffer, deletes *DeleteData) error {
+	if deletes != nil && len(deletes.Data) == 0 {
+		deletes = nil
+	}
+	paths, err := t.io.upload(t.ctx, result.GetSegmentID(), t.plan.GetPartitionID(), buffer.insertData(), deletes, t.meta)
+	if err != nil {
+		return err
+	}
+	result.InsertLogs = mergeFieldBinlogs(result.GetInsertLogs(), paths.inPaths)
+	result.Field2StatslogPaths = mergeFieldBinlogs(result.GetField2StatslogPaths(), paths.statsPaths)
+	if paths.deltaInfo != nil {
+		result.Deltalogs = append(result.Deltalogs, paths.deltaInfo)
+	}
+	result.NumOfRows += int64(buffer.rows)
+	atomic.AddInt64(&t.writtenRows, int64(buffer.rows))
+	log.Debug("compaction progress",
+		zap.Int64("planID", t.plan.GetPlanID()),
+		zap.Int64("segmentID", result.GetSegmentID()),
+		zap.Any("progress", t.getProgress()))
+	return nil
+}
+
+// mergeFieldBinlogs appends the binlog paths of src to the binlogs of the same field in dst
+func mergeFieldBinlogs(dst []*datapb.FieldBinlog, src []*datapb.FieldBinlog) []*datapb.FieldBinlog {
+	for _, fb := range src {
+		merged := false
+		for _, d := range dst {
+			if d.GetFieldID() == fb.GetFieldID() {
+				d.Binlogs = append(d.Binlogs, fb.GetBinlogs()...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			dst = append(dst, &datapb.FieldBinlog{FieldID: fb.GetFieldID(), Binlogs: fb.GetBinlogs()})
+		}
+	}
+	return dst
+}
+
+// compactionBuffer buffers the merged rows of a binlog to write
+type compactionBuffer struct {
+	fields []*schemapb.FieldSchema
+	data   map[storage.FieldID]storage.FieldData
+	rows   int
+}
+
+func newCompactionBuffer(schema *schemapb.CollectionSchema) (*compactionBuffer, error) {
+	data := make(map[storage.FieldID]storage.FieldData, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		switch field.GetDataType() {
+		case schemapb.DataType_Bool:
+			data[field.GetFieldID()] = &storage.BoolFieldData{}
+		case schemapb.DataType_Int8:
+			data[field.GetFieldID()] = &storage.Int8FieldData{}
+		case schemapb.DataType_Int16:
+			data[field.GetFieldID()] = &storage.Int16FieldData{}
+		case schemapb.DataType_Int32:
+			data[field.GetFieldID()] = &storage.Int32FieldData{}
+		case schemapb.DataType_Int64:
+			data[field.GetFieldID()] = &storage.Int64FieldData{}
+		case schemapb.DataType_Float:
+			data[field.GetFieldID()] = &storage.FloatFieldData{}
+		case schemapb.DataType_Double:
+			data[field.GetFieldID()] = &storage.DoubleFieldData{}
+		case schemapb.DataType_String:
+			data[field.GetFieldID()] = &storage.StringFieldData{}
+		case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector:
+			dim, err := fieldDim(field)
+			if err != nil {
+				return nil, err
+			}
+			if field.GetDataType() == schemapb.DataType_FloatVector {
+				data[field.GetFieldID()] = &storage.FloatVectorFieldData{Dim: dim}
+			} else {
+				data[field.GetFieldID()] = &storage.BinaryVectorFieldData{Dim: dim}
+			}
+		default:
+			return nil, fmt.Errorf("field %s has unsupported data type %s", field.GetName(), field.GetDataType())
+		}
+	}
+	return &compactionBuffer{fields: schema.GetFields(), data: data}, nil
+}
+
+func fieldDim(field *schemapb.FieldSchema) (int, error) {
+	for _, param := range field.GetTypeParams() {
+		if param.GetKey() == "dim" {
+			return strconv.Atoi(param.GetValue())
+		}
+	}
+	return 0, fmt.Errorf("dimension of field %s is not set", field.GetName())
+}
+
+// append appends a row read by storage.BinlogRowIterator, the vectors are copied
+func (b *compactionBuffer) append(row map[storage.FieldID]interface{}) error {
+	for _, field := range b.fields {
+		value, ok := row[field.GetFieldID()]
+		if !ok {
+			return fmt.Errorf("field %d is missing in the binlogs", field.GetFieldID())
+		}
+		var typeOk bool
+		switch d := b.data[field.GetFieldID()].(type) {
+		case *storage.BoolFieldData:
+			var v bool
+			v, typeOk = value.(bool)
+			d.Data = append(d.Data, v)
+		case *storage.Int8FieldData:
+			var v int8
+			v, typeOk = value.(int8)
+			d.Data = append(d.Data, v)
+		case *storage.Int16FieldData:
+			var v int16
+			v, typeOk = value.(int16)
+			d.Data = append(d.Data, v)
+		case *storage.Int32FieldData:
+			var v int32
+			v, typeOk = value.(int32)
+			d.Data = append(d.Data, v)
+		case *storage.Int64FieldData:
+			var v int64
+			v, typeOk = value.(int64)
+			d.Data = append(d.Data, v)
+		case *storage.FloatFieldData:
+			var v float32
+			v, typeOk = value.(float32)
+			d.Data = append(d.Data, v)
+		case *storage.DoubleFieldData:
+			var v float64
+			v, typeOk = value.(float64)
+			d.Data = append(d.Data, v)
+		case *storage.StringFieldData:
+			var v string
+			v, typeOk = value.(string)
+			d.Data = append(d.Data, v)
+		case *storage.FloatVectorFieldData:
+			var v []float32
+			v, typeOk = value.([]float32)
+			typeOk = typeOk && len(v) == d.Dim
+			d.Data = append(d.Data, v...)
+		case *storage.BinaryVectorFieldData:
+			var v []byte
+			v, typeOk = value.([]byte)
+			typeOk = typeOk && len(v) == d.Dim/8
+			d.Data = append(d.Data, v...)
+		}
+		if !typeOk {
+			return fmt.Errorf("unexpected value of field %d in the binlogs", field.GetFieldID())
+		}
+	}
+	b.rows++
+	return nil
+}
+
+func (b *compactionBuffer) insertData() *InsertData {
+	numRows := []int64{int64(b.rows)}
+	for _, data := range b.data {
+		switch d := data.(type) {
+		case *storage.BoolFieldData:
+			d.NumRows = numRows
+		case *storage.Int8FieldData:
+			d.NumRows = numRows
+		case *storage.Int16FieldData:
+			d.NumRows = numRows
+		case *storage.Int32FieldData:
+			d.NumRows = numRows
+		case *storage.Int64FieldData:
+			d.NumRows = numRows
+		case *storage.FloatFieldData:
+			d.NumRows = numRows
+		case *storage.DoubleFieldData:
+			d.NumRows = numRows
+		case *storage.StringFieldData:
+			d.NumRows = numRows
+		case *storage.FloatVectorFieldData:
+			d.NumRows = numRows
+		case *storage.BinaryVectorFieldData:
+			d.NumRows = numRows
+		}
+	}
+	return &InsertData{Data: b.data}
+}
+
+// segmentRowIterator iterates the rows of the insert binlogs of a segment. The i-th binlogs of the fields are
+// written by the same flush, they are read together by a storage.BinlogRowIterator, and the binlogs are opened
+// one after another, so that only a binlog of each field is being read at a time.
+type segmentRowIterator struct {
+	disposed     int32
+	chunkManager storage.ChunkManager
+	fieldBinlogs []*datapb.FieldBinlog
+	batchSize    int
+	pos          int // index of the binlogs to open next
+	current      *storage.BinlogRowIterator
+	streams      []io.Closer
+	err          error
+}
+
+var _ storage.Iterator = (*segmentRowIterator)(nil)
+
+func newSegmentRowIterator(cm storage.ChunkManager, fieldBinlogs []*datapb.FieldBinlog, batchSize int) (*segmentRowIterator, error) {
+	if len(fieldBinlogs) == 0 {
+		return nil, errors.New("no insert binlog")
+	}
+	for _, fb := range fieldBinlogs {
+		if len(fb.GetBinlogs()) != len(fieldBinlogs[0].GetBinlogs()) {
+			return nil, fmt.Errorf("field %d has %d binlogs, expected %d binlogs",
+				fb.GetFieldID(), len(fb.GetBinlogs()), len(fieldBinlogs[0].GetBinlogs()))
+		}
+	}
+	return &segmentRowIterator{
+		chunkManager: cm,
+		fieldBinlogs: fieldBinlogs,
+		batchSize:    batchSize,
+	}, nil
+}
+
+// HasNext returns true if the iterator have unread record
+func (itr *segmentRowIterator) HasNext() bool {
+	return !itr.isDisposed() && itr.hasNext()
+}
+
+// Next returns the next record
+func (itr *segmentRowIterator) Next() (interface{}, error) {
+	if itr.isDisposed() {
+		return nil, storage.ErrDisposed
+	}
+	if !itr.hasNext() {
+		if itr.err != nil {
+			return nil, itr.err
+		}
+		return nil, storage.ErrNoMoreRecord
+	}
+	return itr.current.Next()
+}
+
+// Err returns the error stopped the iteration
+func (itr *segmentRowIterator) Err() error {
+	return itr.err
+}
+
+// Dispose disposes the iterator
+func (itr *segmentRowIterator) Dispose() {
+	if atomic.CompareAndSwapInt32(&itr.disposed, 0, 1) {
+		itr.closeCurrent()
+	}
+}
+
+func (itr *segmentRowIterator) isDisposed() bool {
+	return atomic.LoadInt32(&itr.disposed) == 1
+}
+
+func (itr *segmentRowIterator) hasNext() bool {
+	for itr.err == nil {
+		if itr.current != nil {
+			if itr.current.HasNext() {
+				return true
+			}
+			itr.err = itr.current.Err()
+			itr.closeCurrent()
+			continue
+		}
+		if itr.pos >= len(itr.fieldBinlogs[0].GetBinlogs()) {
+			return false
+		}
+		itr.err = itr.open(itr.pos)
+		itr.pos++
+	}
+	return false
+}
+
+// open opens the i-th binlogs of the fields
+func (itr *segmentRowIterator) open(i int) error {
+	readers := make(map[storage.FieldID]*storage.BinlogReader, len(itr.fieldBinlogs))
+	for _, fb := range itr.fieldBinlogs {
+		stream, err := itr.chunkManager.Reader(fb.GetBinlogs()[i])
+		if err != nil {
+			itr.closeCurrent()
+			return err
+		}
+		itr.streams = append(itr.streams, stream)
+		reader, err := storage.NewBinlogStreamReader(stream)
+		if err != nil {
+			itr.closeCurrent()
+			return fmt.Errorf("failed to read binlog %s: %w", fb.GetBinlogs()[i], err)
+		}
+		readers[fb.GetFieldID()] = reader
+	}
+	current, err := storage.NewBinlogRowIterator(readers, itr.batchSize)
+	if err != nil {
+		for _, reader := range readers {
+			reader.Close()
+		}
+		itr.closeCurrent()
+		return err
+	}
+	itr.current = current
+	return nil
+}
+
+func (itr *segmentRowIterator) closeCurrent() {
+	if itr.current != nil {
+		itr.current.Dispose()
+		itr.current = nil
+	}
+	for _, stream := range itr.streams {
+		stream.Close()
+	}
+	itr.streams = nil
+}
+
+// compactionExecutor executes the compaction tasks of data node, the results of the tasks are reported by report
+type compactionExecutor struct {
+	executing sync.Map // planID -> *compactionTask
+	report    func(ctx context.Context, result *datapb.CompactionResult) error
+}
+
+func newCompactionExecutor(report func(ctx context.Context, result *datapb.CompactionResult) error) *compactionExecutor {
+	return &compactionExecutor{report: report}
+}
+
+// execute starts the task in background, it fails if a task of the same plan is executing
+func (c *compactionExecutor) execute(task *compactionTask) error {
+	if _, loaded := c.executing.LoadOrStore(task.getPlanID(), task); loaded {
+		return errCompactionPlanExecuting
+	}
+	go func() {
+		defer c.executing.Delete(task.getPlanID())
+		defer task.stop()
+
+		log.Info("start compaction", zap.Int64("planID", task.getPlanID()),
+			zap.Int("mergeGroups", len(task.plan.GetMergeGroup())))
+		results, err := task.compact()
+		if err != nil {
+			log.Warn("compaction failed", zap.Int64("planID", task.getPlanID()), zap.Error(err))
+			return
+		}
+		for _, result := range results {
+			if err := c.report(task.ctx, result); err != nil {
+				log.Warn("failed to report compaction result", zap.Int64("planID", task.getPlanID()),
+					zap.Int64("segmentID", result.GetSegmentID()), zap.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+// stop cancels the executing task of the plan, it returns false if the plan isn't executing
+func (c *compactionExecutor) stop(planID UniqueID) bool {
+	task, ok := c.executing.Load(planID)
+	if ok {
+		task.(*compactionTask).stop()
+	}
+	return ok
+}
+
+// getProgress returns the progress of the executing task of the plan
+func (c *compactionExecutor) getProgress(planID UniqueID) (compactionProgress, bool) {
+	task, ok := c.executing.Load(planID)
+	if !ok {
+		return compactionProgress{}, false
+	}
+	return task.(*compactionTask).getProgress(), true
+}
@@ -0,0 +1,309 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seqAllocator allocates sequential ids, so that the binlog paths written by a test never collide
+type seqAllocator struct {
+	sync.Mutex
+	next UniqueID
+}
+
+func (alloc *seqAllocator) allocID() (UniqueID, error) {
+	id, _, err := alloc.allocIDBatch(1)
+	return id, err
+}
+
+func (alloc *seqAllocator) allocIDBatch(count uint32) (UniqueID, uint32, error) {
+	alloc.Lock()
+	defer alloc.Unlock()
+	start := alloc.next + 1
+	alloc.next += UniqueID(count)
+	return start, count, nil
+}
+
+func (alloc *seqAllocator) genKey(isalloc bool, ids ...UniqueID) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// genCompactionInsertData generates the rows of the collection of MetaFactory, the primary keys are the row ids
+func genCompactionInsertData(rowIDs []int64, timestamps []int64) *InsertData {
+	iData := genInsertData()
+	n := len(rowIDs)
+	numRows := []int64{int64(n)}
+	iData.Data[0] = &storage.Int64FieldData{NumRows: numRows, Data: rowIDs}
+	iData.Data[1] = &storage.Int64FieldData{NumRows: numRows, Data: timestamps}
+	iData.Data[100] = &storage.FloatVectorFieldData{NumRows: numRows, Data: make([]float32, 2*n), Dim: 2}
+	iData.Data[101] = &storage.BinaryVectorFieldData{NumRows: numRows, Data: make([]byte, 4*n), Dim: 32}
+	iData.Data[102] = &storage.BoolFieldData{NumRows: numRows, Data: make([]bool, n)}
+	iData.Data[103] = &storage.Int8FieldData{NumRows: numRows, Data: make([]int8, n)}
+	iData.Data[104] = &storage.Int16FieldData{NumRows: numRows, Data: make([]int16, n)}
+	iData.Data[105] = &storage.Int32FieldData{NumRows: numRows, Data: make([]int32, n)}
+	iData.Data[106] = &storage.Int64FieldData{NumRows: numRows, Data: rowIDs}
+	iData.Data[107] = &storage.FloatFieldData{NumRows: numRows, Data: make([]float32, n)}
+	iData.Data[108] = &storage.DoubleFieldData{NumRows: numRows, Data: make([]float64, n)}
+	for i, id := range rowIDs {
+		iData.Data[100].(*storage.FloatVectorFieldData).Data[2*i] = float32(id)
+	}
+	return iData
+}
+
+type compactionTestEnv struct {
+	cm    storage.ChunkManager
+	kv    *storage.ChunkManagerKV
+	alloc *seqAllocator
+	meta  *etcdpb.CollectionMeta
+}
+
+func newCompactionTestEnv(t *testing.T) *compactionTestEnv {
+	dir, err := ioutil.TempDir("", "compaction")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	cm := storage.NewLocalChunkManager(dir)
+	return &compactionTestEnv{
+		cm:    cm,
+		kv:    storage.NewChunkManagerKV(cm),
+		alloc: &seqAllocator{},
+		meta:  (&MetaFactory{}).GetCollectionMeta(1, "compaction"),
+	}
+}
+
+// flush writes a flush of the segment, the deltalog is written if deletes isn't empty
+func (env *compactionTestEnv) flush(t *testing.T, segment *datapb.CompactionSegmentBinlogs,
+	rowIDs []int64, timestamps []int64, deletes map[int64]int64) {
+	b := &binlogIO{BaseKV: env.kv, allocatorInterface: env.alloc}
+	var dData *DeleteData
+	if len(deletes) > 0 {
+		dData = &DeleteData{Data: deletes}
+	}
+	paths, err := b.upload(context.TODO(), segment.GetSegmentID(), 10, genCompactionInsertData(rowIDs, timestamps), dData, env.meta)
+	require.NoError(t, err)
+	segment.FieldBinlogs = mergeFieldBinlogs(segment.GetFieldBinlogs(), paths.inPaths)
+	segment.Field2StatslogPaths = mergeFieldBinlogs(segment.GetField2StatslogPaths(), paths.statsPaths)
+	if paths.deltaInfo != nil {
+		segment.Deltalogs = append(segment.Deltalogs, paths.deltaInfo)
+	}
+}
+
+// readRows reads the primary keys and timestamps of the insert binlogs
+func (env *compactionTestEnv) readRows(t *testing.T, fieldBinlogs []*datapb.FieldBinlog) ([]int64, []int64) {
+	itr, err := newSegmentRowIterator(env.cm, fieldBinlogs, 3)
+	require.NoError(t, err)
+	defer itr.Dispose()
+	var pks, timestamps []int64
+	for itr.HasNext() {
+		v, err := itr.Next()
+		require.NoError(t, err)
+		value := v.(*storage.Value)
+		pks = append(pks, value.Row()[106].(int64))
+		timestamps = append(timestamps, value.Timestamp())
+		// the vectors are kept with their rows
+		assert.Equal(t, float32(value.ID()), value.Row()[100].([]float32)[0])
+	}
+	require.NoError(t, itr.Err())
+	return pks, timestamps
+}
+
+// genCompactionPlan generates a plan of two segments:
+// segment 1 has rows 1, 3 and 5 flushed twice, row 3 is deleted at 45,
+// segment 2 has rows 2, 4 and 6, row 4 is deleted at 35 before it's inserted, and row 6 is deleted at 100.
+func (env *compactionTestEnv) genCompactionPlan(t *testing.T, timetravel Timestamp) *datapb.CompactionPlan {
+	seg1 := &datapb.CompactionSegmentBinlogs{SegmentID: 1}
+	env.flush(t, seg1, []int64{1, 3}, []int64{10, 30}, nil)
+	env.flush(t, seg1, []int64{5}, []int64{50}, map[int64]int64{3: 45})
+	seg2 := &datapb.CompactionSegmentBinlogs{SegmentID: 2}
+	env.flush(t, seg2, []int64{2, 4, 6}, []int64{20, 40, 60}, map[int64]int64{4: 35, 6: 100})
+	return &datapb.CompactionPlan{
+		PlanID:       1,
+		CollectionID: env.meta.GetID(),
+		PartitionID:  10,
+		Timetravel:   timetravel,
+		Type:         datapb.CompactionType_MergeCompaction,
+		MergeGroup: []*datapb.CompactionMergeGroup{
+			{
+				SegmentBinlogs:  []*datapb.CompactionSegmentBinlogs{seg1, seg2},
+				TargetSegmentID: 100,
+			},
+		},
+	}
+}
+
+func TestCompactionTask_compact(t *testing.T) {
+	t.Run("merge and delete", func(t *testing.T) {
+		env := newCompactionTestEnv(t)
+		plan := env.genCompactionPlan(t, 0)
+		task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+		require.NoError(t, err)
+		task.batchRows = 2
+
+		results, err := task.compact()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(results))
+		result := results[0]
+		assert.Equal(t, plan.GetPlanID(), result.GetPlanID())
+		assert.Equal(t, UniqueID(100), result.GetSegmentID())
+		assert.Equal(t, int64(4), result.GetNumOfRows())
+		assert.Empty(t, result.GetDeltalogs())
+		// rows are written in 2 binlogs of each field
+		assert.Equal(t, len(env.meta.GetSchema().GetFields()), len(result.GetInsertLogs()))
+		for _, fb := range result.GetInsertLogs() {
+			assert.Equal(t, 2, len(fb.GetBinlogs()))
+		}
+		assert.NotEmpty(t, result.GetField2StatslogPaths())
+
+		pks, timestamps := env.readRows(t, result.GetInsertLogs())
+		assert.Equal(t, []int64{1, 2, 4, 5}, pks)
+		assert.Equal(t, []int64{10, 20, 40, 50}, timestamps)
+
+		assert.Equal(t, compactionProgress{MergedRows: 6, DeletedRows: 2, WrittenRows: 4}, task.getProgress())
+	})
+
+	t.Run("deletes after time travel", func(t *testing.T) {
+		env := newCompactionTestEnv(t)
+		plan := env.genCompactionPlan(t, 90)
+		task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+		require.NoError(t, err)
+
+		results, err := task.compact()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(results))
+		result := results[0]
+		assert.Equal(t, int64(5), result.GetNumOfRows())
+
+		pks, timestamps := env.readRows(t, result.GetInsertLogs())
+		assert.Equal(t, []int64{1, 2, 4, 5, 6}, pks)
+		assert.Equal(t, []int64{10, 20, 40, 50, 60}, timestamps)
+
+		// the delete of row 6 is kept in the deltalog of the target segment
+		require.Equal(t, 1, len(result.GetDeltalogs()))
+		value, err := env.cm.Read(result.GetDeltalogs()[0].GetDeltaLogPath())
+		require.NoError(t, err)
+		_, _, dData, err := storage.NewDeleteCodec().Deserialize([]*Blob{{Value: value}})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]int64{6: 100}, dData.Data)
+	})
+
+	t.Run("all rows deleted", func(t *testing.T) {
+		env := newCompactionTestEnv(t)
+		seg := &datapb.CompactionSegmentBinlogs{SegmentID: 1}
+		env.flush(t, seg, []int64{1, 2}, []int64{10, 20}, map[int64]int64{1: 30, 2: 30})
+		plan := &datapb.CompactionPlan{
+			PlanID:     1,
+			MergeGroup: []*datapb.CompactionMergeGroup{{SegmentBinlogs: []*datapb.CompactionSegmentBinlogs{seg}, TargetSegmentID: 100}},
+		}
+		task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+		require.NoError(t, err)
+
+		results, err := task.compact()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(results))
+		assert.Equal(t, int64(0), results[0].GetNumOfRows())
+		assert.Empty(t, results[0].GetInsertLogs())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		env := newCompactionTestEnv(t)
+		plan := env.genCompactionPlan(t, 0)
+		task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+		require.NoError(t, err)
+
+		task.stop()
+		_, err = task.compact()
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("missing binlog", func(t *testing.T) {
+		env := newCompactionTestEnv(t)
+		plan := env.genCompactionPlan(t, 0)
+		fb := plan.GetMergeGroup()[0].GetSegmentBinlogs()[0].GetFieldBinlogs()[0]
+		fb.Binlogs[1] = "not-exist"
+		task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+		require.NoError(t, err)
+
+		_, err = task.compact()
+		assert.Error(t, err)
+	})
+
+	t.Run("mismatched binlogs", func(t *testing.T) {
+		env := newCompactionTestEnv(t)
+		plan := env.genCompactionPlan(t, 0)
+		fb := plan.GetMergeGroup()[0].GetSegmentBinlogs()[0].GetFieldBinlogs()[0]
+		fb.Binlogs = fb.Binlogs[:1]
+		task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+		require.NoError(t, err)
+
+		_, err = task.compact()
+		assert.Error(t, err)
+	})
+}
+
+func TestCompactionExecutor(t *testing.T) {
+	env := newCompactionTestEnv(t)
+	plan := env.genCompactionPlan(t, 0)
+
+	reported := make(chan *datapb.CompactionResult, 1)
+	executor := newCompactionExecutor(func(ctx context.Context, result *datapb.CompactionResult) error {
+		reported <- result
+		return nil
+	})
+	_, ok := executor.getProgress(plan.GetPlanID())
+	assert.False(t, ok)
+	assert.False(t, executor.stop(plan.GetPlanID()))
+
+	task, err := newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+	require.NoError(t, err)
+	require.NoError(t, executor.execute(task))
+
+	select {
+	case result := <-reported:
+		assert.Equal(t, UniqueID(100), result.GetSegmentID())
+		assert.Equal(t, int64(4), result.GetNumOfRows())
+	case <-time.After(10 * time.Second):
+		t.Fatal("compaction result is not reported")
+	}
+	assert.Eventually(t, func() bool {
+		_, ok := executor.getProgress(plan.GetPlanID())
+		return !ok
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// a canceled task reports nothing
+	task, err = newCompactionTask(context.TODO(), plan, env.meta, env.cm, env.kv, env.alloc)
+	require.NoError(t, err)
+	task.stop()
+	require.NoError(t, executor.execute(task))
+	assert.Eventually(t, func() bool {
+		_, ok := executor.getProgress(plan.GetPlanID())
+		return !ok
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Empty(t, reported)
+}
@@ -37,12 +37,16 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/logutil"
 	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
@@ -83,11 +87,11 @@ var _ types.DataNode = (*DataNode)(nil)
 //  `NodeID` is unique to each datanode.
 //  `State` is current statement of this data node, indicating whether it's healthy.
 //
-//  `vchan2SyncService` is a map of vchannlName to dataSyncService, so that datanode
-//  has ability to scale flowgraph.
-//  `vchan2FlushCh` holds flush-signal channels for every flowgraph.
+//  `flowgraphManager` is the registry of the flowgraphs keyed by vchannel name, so that datanode
+//  has ability to scale flowgraph. Each flowgraph is started, flushed and closed independently.
 //  `clearSignal` is a signal channel for releasing the flowgraph resources.
 //  `segmentCache` stores all flushing and flushed segments.
+//  `importStorage` opens the storage to read the import files and write the binlogs of imported segments.
 type DataNode struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -95,9 +99,7 @@ type DataNode struct {
 	Role   string
 	State  atomic.Value // internalpb.StateCode_Initializing
 
-	chanMut           sync.RWMutex
-	vchan2SyncService map[string]*dataSyncService // vchannel name
-	vchan2FlushChs    map[string]chan flushMsg    // vchannel name to flush channels
+	flowgraphManager *flowgraphManager
 
 	clearSignal  chan UniqueID // collection ID
 	segmentCache *Cache
@@ -111,6 +113,8 @@ type DataNode struct {
 	closer io.Closer
 
 	msFactory msgstream.Factory
+
+	importStorage func(ctx context.Context) (storage.ChunkManager, kv.BaseKV, error)
 }
 
 // NewDataNode will return a DataNode with abnormal state.
@@ -127,9 +131,9 @@ func NewDataNode(ctx context.Context, factory msgstream.Factory) *DataNode {
 		msFactory:    factory,
 		segmentCache: newCache(),
 
-		vchan2SyncService: make(map[string]*dataSyncService),
-		vchan2FlushChs:    make(map[string]chan flushMsg),
-		clearSignal:       make(chan UniqueID, 100),
+		flowgraphManager: newFlowgraphManager(),
+		clearSignal:      make(chan UniqueID, 100),
+		importStorage:    newImportStorage,
 	}
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	return node
@@ -195,6 +199,7 @@ func (node *DataNode) Init() error {
 		zap.String("SegmentStatisticsChannelName", Params.SegmentStatisticsChannelName),
 		zap.String("TimeTickChannelName", Params.TimeTickChannelName),
 	)
+	storage.SetChecksumWarnOnly(Params.StorageChecksumWarnOnly)
 
 	return nil
 }
@@ -262,7 +267,7 @@ func (node *DataNode) handleChannelEvt(evt *clientv3.Event) {
 	case clientv3.EventTypeDelete:
 		// guaranteed there is no "/" in channel name
 		parts := strings.Split(string(evt.Kv.Key), "/")
-		node.ReleaseDataSyncService(parts[len(parts)-1])
+		node.ReleaseChannel(parts[len(parts)-1])
 	}
 }
 
@@ -299,16 +304,17 @@ func (node *DataNode) handleWatchInfo(key string, data []byte) {
 }
 
 // NewDataSyncService adds a new dataSyncService for new dmlVchannel and starts dataSyncService.
+// It's a no-op if the dataSyncService of the vchannel exists, and the vchannels are added without blocking each other.
 func (node *DataNode) NewDataSyncService(vchan *datapb.VchannelInfo) error {
-	node.chanMut.Lock()
-	defer node.chanMut.Unlock()
-	if _, ok := node.vchan2SyncService[vchan.GetChannelName()]; ok {
-		return nil
-	}
+	return node.flowgraphManager.add(vchan.GetChannelName(), func() (*dataSyncService, error) {
+		return node.newDataSyncService(vchan)
+	})
+}
 
+func (node *DataNode) newDataSyncService(vchan *datapb.VchannelInfo) (*dataSyncService, error) {
 	replica, err := newReplica(node.ctx, node.rootCoord, vchan.CollectionID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var alloc allocatorInterface = newAllocator(node.rootCoord)
@@ -322,19 +328,14 @@ func (node *DataNode) NewDataSyncService(vchan *datapb.VchannelInfo) error {
 
 	dataSyncService, err := newDataSyncService(node.ctx, flushCh, replica, alloc, node.msFactory, vchan, node.clearSignal, node.dataCoord, node.segmentCache)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	node.vchan2SyncService[vchan.GetChannelName()] = dataSyncService
-	node.vchan2FlushChs[vchan.GetChannelName()] = flushCh
-
 	log.Info("Start New dataSyncService",
 		zap.Int64("Collection ID", vchan.GetCollectionID()),
 		zap.String("Vchannel name", vchan.GetChannelName()),
 	)
-	dataSyncService.start()
-
-	return nil
+	return dataSyncService, nil
 }
 
 // BackGroundGC runs in background to release datanode resources
@@ -354,22 +355,33 @@ func (node *DataNode) BackGroundGC(collIDCh <-chan UniqueID) {
 	}
 }
 
-// ReleaseDataSyncService release flowgraph resources for a vchanName
+// ReleaseDataSyncService release flowgraph resources for a vchanName, dropping the buffered data
 func (node *DataNode) ReleaseDataSyncService(vchanName string) {
 	log.Info("Release flowgraph resources begin", zap.String("Vchannel", vchanName))
 
-	node.chanMut.Lock()
-	defer node.chanMut.Unlock()
-	if dss, ok := node.vchan2SyncService[vchanName]; ok {
-		dss.close()
-	}
-
-	delete(node.vchan2SyncService, vchanName)
-	delete(node.vchan2FlushChs, vchanName)
+	node.flowgraphManager.remove(vchanName, nil)
 
 	log.Debug("Release flowgraph resources end", zap.String("Vchannel", vchanName))
 }
 
+// releaseChannelFlushTimeout is the max time to flush the buffers of a released vchannel
+const releaseChannelFlushTimeout = 10 * time.Second
+
+// ReleaseChannel flushes the buffered data of vchanName and releases its flowgraph resources.
+// Flushing the buffers moves the checkpoint forward, so that the datanode watching the vchannel next replays less.
+// If the buffers fail to be flushed in time, the flowgraph is released anyway and the data is replayed.
+func (node *DataNode) ReleaseChannel(vchanName string) {
+	log.Info("Release channel begin", zap.String("Vchannel", vchanName))
+
+	node.flowgraphManager.remove(vchanName, func(service *dataSyncService) {
+		if err := service.flushBuffers(releaseChannelFlushTimeout); err != nil {
+			log.Warn("fail to flush buffers of released channel", zap.String("Vchannel", vchanName), zap.Error(err))
+		}
+	})
+
+	log.Debug("Release channel end", zap.String("Vchannel", vchanName))
+}
+
 // FilterThreshold is the start time ouf DataNode
 var FilterThreshold Timestamp
 
@@ -490,28 +502,22 @@ func (node *DataNode) GetComponentStates(ctx context.Context) (*internalpb.Compo
 	return states, nil
 }
 
+// CheckReady checks whether DataNode is healthy and its session is registered in etcd
+func (node *DataNode) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, node, node.session)
+}
+
 func (node *DataNode) getChannelNamebySegmentID(segID UniqueID) string {
-	node.chanMut.RLock()
-	defer node.chanMut.RUnlock()
-	for name, dataSync := range node.vchan2SyncService {
-		if dataSync.replica.hasSegment(segID, true) {
-			return name
-		}
-	}
-	return ""
+	name, _ := node.flowgraphManager.find(func(dataSync *dataSyncService) bool {
+		return dataSync.replica.hasSegment(segID, true)
+	})
+	return name
 }
 
 func (node *DataNode) getChannelNamesbyCollectionID(collID UniqueID) []string {
-	node.chanMut.RLock()
-	defer node.chanMut.RUnlock()
-
-	channels := make([]string, 0, len(node.vchan2SyncService))
-	for name, dataSync := range node.vchan2SyncService {
-		if dataSync.collectionID == collID {
-			channels = append(channels, name)
-		}
-	}
-	return channels
+	return node.flowgraphManager.filter(func(dataSync *dataSyncService) bool {
+		return dataSync.collectionID == collID
+	})
 }
 
 // ReadyToFlush tells wether DataNode is ready for flushing
@@ -520,21 +526,12 @@ func (node *DataNode) ReadyToFlush() error {
 		return errors.New("DataNode not in HEALTHY state")
 	}
 
-	node.chanMut.RLock()
-	defer node.chanMut.RUnlock()
-	if len(node.vchan2SyncService) == 0 && len(node.vchan2FlushChs) == 0 {
+	if node.flowgraphManager.size() == 0 {
 		// Healthy but Idle
 		msg := "DataNode HEALTHY but IDLE, please try WatchDmChannels to make it work"
 		log.Warn(msg)
 		return errors.New(msg)
 	}
-
-	if len(node.vchan2SyncService) != len(node.vchan2FlushChs) {
-		// TODO restart
-		msg := "DataNode HEALTHY but abnormal inside, restarting..."
-		log.Warn(msg)
-		return errors.New(msg)
-	}
 	return nil
 }
 
@@ -582,23 +579,25 @@ func (node *DataNode) FlushSegments(ctx context.Context, req *datapb.FlushSegmen
 
 			node.segmentCache.Cache(id)
 
-			node.chanMut.RLock()
-			flushChs, ok := node.vchan2FlushChs[chanName]
-			node.chanMut.RUnlock()
+			// hold the flowgraph, so that it's not closed before receiving the flush message
+			cf, ok := node.flowgraphManager.acquire(chanName)
 			if !ok {
-				status.Reason = "DataNode abnormal, restarting"
-				log.Error("DataNode abnormal, no flushCh for a vchannel")
+				node.segmentCache.Remove(id)
+				status.Reason = fmt.Sprintf("DataNode flowgraph of vchannel %s is released", chanName)
+				log.Warn("FlushSegments failed, flowgraph released",
+					zap.Int64("segmentID", id), zap.String("vchannel", chanName))
 				noErr = false
 				continue
 			}
 
-			flushChs <- flushMsg{
+			cf.service.flushCh <- flushMsg{
 				msgID:        req.Base.MsgID,
 				timestamp:    req.Base.Timestamp,
 				segmentID:    id,
 				collectionID: req.CollectionID,
 				flushed:      flushed,
 			}
+			node.flowgraphManager.release(cf)
 		}
 		log.Debug("Flowgraph flushSegment tasks triggered", zap.Bool("flushed", flushed),
 			zap.Int64("collectionID", req.GetCollectionID()), zap.Int64s("segments", segmentIDs))
@@ -620,17 +619,81 @@ func (node *DataNode) FlushSegments(ctx context.Context, req *datapb.FlushSegmen
 	return status, nil
 }
 
-// Stop will release DataNode resources and shutdown datanode
+// Import executes an import task assigned by data coordinator in background, the files are read
+// from object storage, and the segments are written to binlogs without going through the dm channels.
+// The state of the task is reported to data coordinator by ReportImport.
+func (node *DataNode) Import(ctx context.Context, req *datapb.ImportTask) (*commonpb.Status, error) {
+	log.Debug("DataNode receives Import",
+		zap.Int64("taskID", req.GetTaskID()),
+		zap.Int64("collectionID", req.GetCollectionID()),
+		zap.Int64("partitionID", req.GetPartitionID()),
+		zap.Strings("files", req.GetFiles()))
+	status := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_UnexpectedError,
+	}
+
+	if !node.isHealthy() {
+		status.Reason = msgDataNodeIsUnhealthy(Params.NodeID)
+		return status, nil
+	}
+	if len(req.GetFiles()) == 0 {
+		status.Reason = "no file to import"
+		return status, nil
+	}
+
+	cm, blobKv, err := node.importStorage(node.ctx)
+	if err != nil {
+		log.Warn("failed to open import storage", zap.Int64("taskID", req.GetTaskID()), zap.Error(err))
+		status.Reason = err.Error()
+		return status, nil
+	}
+	task := newImportTask(req, node.rootCoord, node.dataCoord, cm, blobKv)
+	go task.execute(node.ctx)
+
+	status.ErrorCode = commonpb.ErrorCode_Success
+	return status, nil
+}
+
+// newChunkManagerFactory creates the factory of the storage of binlogs by the storage type.
+func newChunkManagerFactory() *storage.ChunkManagerFactory {
+	option := &miniokv.Option{
+		Address:           Params.MinioAddress,
+		AccessKeyID:       Params.MinioAccessKeyID,
+		SecretAccessKeyID: Params.MinioSecretAccessKey,
+		UseSSL:            Params.MinioUseSSL,
+		CreateBucket:      true,
+		BucketName:        Params.MinioBucketName,
+	}
+	return storage.NewChunkManagerFactory(Params.StorageType, Params.LocalStoragePath, Params.LocalStorageSyncOnWrite, option)
+}
+
+// newImportStorage reads the import files and writes binlogs in the storage of binlogs
+func newImportStorage(ctx context.Context) (storage.ChunkManager, kv.BaseKV, error) {
+	cm, err := newChunkManagerFactory().NewChunkManager(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cm, storage.NewChunkManagerKV(cm), nil
+}
+
+// Stop will flush the buffered data of the watched vchannels, then release DataNode resources and shutdown datanode.
+// It must be called before the message streams and DataCoord are stopped, which the flush relies on.
 func (node *DataNode) Stop() error {
+	var wg sync.WaitGroup
+	for _, vchanName := range node.flowgraphManager.channels() {
+		wg.Add(1)
+		go func(vchanName string) {
+			defer wg.Done()
+			node.ReleaseChannel(vchanName)
+		}(vchanName)
+	}
+	wg.Wait()
+
 	node.cancel()
 
-	node.chanMut.RLock()
-	defer node.chanMut.RUnlock()
 	// close services
-	for _, syncService := range node.vchan2SyncService {
-		if syncService != nil {
-			(*syncService).close()
-		}
+	for _, vchanName := range node.flowgraphManager.channels() {
+		node.flowgraphManager.remove(vchanName, nil)
 	}
 
 	if node.closer != nil {
@@ -718,6 +781,19 @@ func (node *DataNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRe
 		return systemInfoMetrics, err
 	}
 
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		name := metricsinfo.ConstructComponentName(typeutil.DataNodeRole, Params.NodeID)
+		metrics := metricsinfo.LogLevelsResponse([]metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}, name)
+
+		log.Debug("DataNode.GetMetrics",
+			zap.Int64("node_id", Params.NodeID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Debug("DataNode.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", Params.NodeID),
 		zap.String("req", req.Request),
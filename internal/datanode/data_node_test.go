@@ -117,14 +117,11 @@ func TestDataNode(t *testing.T) {
 			assert.NoError(t, err)
 			if testcase.expect {
 				assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
-				assert.NotNil(t, node1.vchan2FlushChs)
-				assert.NotNil(t, node1.vchan2SyncService)
-				sync, ok := node1.vchan2SyncService[testcase.channels[0]]
+				sync, ok := node1.flowgraphManager.getService(testcase.channels[0])
 				assert.True(t, ok)
 				assert.NotNil(t, sync)
 				assert.Equal(t, UniqueID(1), sync.collectionID)
-				assert.Equal(t, len(testcase.channels), len(node1.vchan2SyncService))
-				assert.Equal(t, len(node1.vchan2FlushChs), len(node1.vchan2SyncService))
+				assert.Equal(t, len(testcase.channels), node1.flowgraphManager.size())
 			} else {
 				assert.NotEqual(t, commonpb.ErrorCode_Success, resp.ErrorCode)
 				assert.Equal(t, testcase.failReason, resp.Reason)
@@ -236,18 +233,15 @@ func TestDataNode(t *testing.T) {
 			UnflushedSegments: []*datapb.SegmentInfo{},
 		}
 
-		require.Equal(t, 0, len(node2.vchan2FlushChs))
-		require.Equal(t, 0, len(node2.vchan2SyncService))
+		require.Equal(t, 0, node2.flowgraphManager.size())
 
 		err := node2.NewDataSyncService(vchan)
 		assert.NoError(t, err)
-		assert.Equal(t, 1, len(node2.vchan2FlushChs))
-		assert.Equal(t, 1, len(node2.vchan2SyncService))
+		assert.Equal(t, 1, node2.flowgraphManager.size())
 
 		err = node2.NewDataSyncService(vchan)
 		assert.NoError(t, err)
-		assert.Equal(t, 1, len(node2.vchan2FlushChs))
-		assert.Equal(t, 1, len(node2.vchan2SyncService))
+		assert.Equal(t, 1, node2.flowgraphManager.size())
 
 		cancel()
 		<-node2.ctx.Done()
@@ -279,7 +273,7 @@ func TestDataNode(t *testing.T) {
 		err := node1.NewDataSyncService(vchan)
 		assert.Nil(t, err)
 
-		service, ok := node1.vchan2SyncService[dmChannelName]
+		service, ok := node1.flowgraphManager.getService(dmChannelName)
 		assert.True(t, ok)
 		err = service.replica.addNewSegment(0, 1, 1, dmChannelName, &internalpb.MsgPosition{}, &internalpb.MsgPosition{})
 		assert.Nil(t, err)
@@ -374,12 +368,17 @@ func TestDataNode(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
 
-		// manual inject meta error
-		node1.chanMut.Lock()
-		node1.vchan2FlushChs[dmChannelName+"1"] = node1.vchan2FlushChs[dmChannelName]
-		delete(node1.vchan2FlushChs, dmChannelName)
-		node1.chanMut.Unlock()
+		// manual inject meta error, the flowgraph is being released
+		node1.flowgraphManager.mu.Lock()
+		cf := node1.flowgraphManager.flowgraphs[dmChannelName]
+		cf.refCount = 0
+		node1.flowgraphManager.mu.Unlock()
 		node1.segmentCache.Remove(0)
+		defer func() {
+			node1.flowgraphManager.mu.Lock()
+			cf.refCount = 1
+			node1.flowgraphManager.mu.Unlock()
+		}()
 
 		req = &datapb.FlushSegmentsRequest{
 			Base:         &commonpb.MsgBase{},
@@ -480,9 +479,7 @@ func TestDataNode(t *testing.T) {
 		}
 
 		assert.Eventually(t, func() bool {
-			node.chanMut.Lock()
-			defer node.chanMut.Unlock()
-			return len(node.vchan2FlushChs) == 0
+			return node.flowgraphManager.size() == 0
 		}, time.Second, time.Millisecond)
 
 		cancel()
@@ -499,15 +496,13 @@ func TestDataNode(t *testing.T) {
 
 		err := node.NewDataSyncService(vchan)
 		require.NoError(t, err)
-		require.Equal(t, 1, len(node.vchan2FlushChs))
-		require.Equal(t, 1, len(node.vchan2SyncService))
+		require.Equal(t, 1, node.flowgraphManager.size())
 		time.Sleep(100 * time.Millisecond)
 
 		node.ReleaseDataSyncService(dmChannelName)
-		assert.Equal(t, 0, len(node.vchan2FlushChs))
-		assert.Equal(t, 0, len(node.vchan2SyncService))
+		assert.Equal(t, 0, node.flowgraphManager.size())
 
-		s, ok := node.vchan2SyncService[dmChannelName]
+		s, ok := node.flowgraphManager.getService(dmChannelName)
 		assert.False(t, ok)
 		assert.Nil(t, s)
 
@@ -521,7 +516,6 @@ func TestDataNode(t *testing.T) {
 		testSegIDs := []UniqueID{10, 11, 12, 13}
 		testchanNames := []string{"a", "b", "c", "d"}
 
-		node.chanMut.Lock()
 		for i, name := range testchanNames {
 			replica := &SegmentReplica{
 				collectionID: testCollIDs[i],
@@ -530,9 +524,11 @@ func TestDataNode(t *testing.T) {
 
 			err = replica.addNewSegment(testSegIDs[i], testCollIDs[i], 0, name, &internalpb.MsgPosition{}, nil)
 			assert.Nil(t, err)
-			node.vchan2SyncService[name] = &dataSyncService{collectionID: testCollIDs[i], replica: replica}
+			err = node.flowgraphManager.add(name, func() (*dataSyncService, error) {
+				return &dataSyncService{collectionID: testCollIDs[i], replica: replica}, nil
+			})
+			assert.Nil(t, err)
 		}
-		node.chanMut.Unlock()
 
 		type Test struct {
 			inCollID         UniqueID
@@ -625,20 +621,14 @@ func TestWatchChannel(t *testing.T) {
 
 		// wait for check goroutine received 2 events
 		<-c
-		node.chanMut.RLock()
-		_, has := node.vchan2SyncService[ch]
-		node.chanMut.RUnlock()
-		assert.True(t, has)
+		assert.True(t, node.flowgraphManager.exist(ch))
 
 		err = kv.RemoveWithPrefix(fmt.Sprintf("%s/%d", Params.ChannelWatchSubPath, node.NodeID))
 		assert.Nil(t, err)
 		//TODO there is not way to sync Release done, use sleep for now
 		time.Sleep(100 * time.Millisecond)
 
-		node.chanMut.RLock()
-		_, has = node.vchan2SyncService[ch]
-		node.chanMut.RUnlock()
-		assert.False(t, has)
+		assert.False(t, node.flowgraphManager.exist(ch))
 	})
 
 	t.Run("watch dm channel fails", func(t *testing.T) {
@@ -650,10 +640,7 @@ func TestWatchChannel(t *testing.T) {
 	t.Run("handle watch info failed", func(t *testing.T) {
 		node.handleWatchInfo("test1", []byte{23})
 
-		node.chanMut.RLock()
-		_, has := node.vchan2SyncService["test1"]
-		assert.False(t, has)
-		node.chanMut.RUnlock()
+		assert.False(t, node.flowgraphManager.exist("test1"))
 
 		info := datapb.ChannelWatchInfo{
 			Vchan: nil,
@@ -663,10 +650,7 @@ func TestWatchChannel(t *testing.T) {
 		assert.NoError(t, err)
 		node.handleWatchInfo("test2", bs)
 
-		node.chanMut.RLock()
-		_, has = node.vchan2SyncService["test2"]
-		assert.False(t, has)
-		node.chanMut.RUnlock()
+		assert.False(t, node.flowgraphManager.exist("test2"))
 
 		info = datapb.ChannelWatchInfo{
 			Vchan: &datapb.VchannelInfo{},
@@ -679,10 +663,121 @@ func TestWatchChannel(t *testing.T) {
 			node.msFactory,
 		}
 		node.handleWatchInfo("test3", bs)
-		node.chanMut.RLock()
-		_, has = node.vchan2SyncService["test3"]
-		assert.False(t, has)
-		node.chanMut.RUnlock()
+		assert.False(t, node.flowgraphManager.exist("test3"))
 
 	})
 }
+
+// NOTE: start pulsar and etcd before test
+func TestDataNodeConcurrentChannels(t *testing.T) {
+	const (
+		channelNum = 20
+		rounds     = 3
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node := newIDLEDataNodeMock(ctx)
+	err := node.Init()
+	require.Nil(t, err)
+	err = node.Start()
+	require.Nil(t, err)
+	err = node.Register()
+	require.Nil(t, err)
+	defer func() {
+		err := node.Stop()
+		assert.Nil(t, err)
+	}()
+
+	msFactory := msgstream.NewPmsFactory()
+	err = msFactory.SetParams(map[string]interface{}{
+		"pulsarAddress":  Params.PulsarAddress,
+		"receiveBufSize": 1024,
+		"pulsarBufSize":  1024})
+	require.NoError(t, err)
+
+	channels := make([]string, 0, channelNum)
+	for i := 0; i < channelNum; i++ {
+		channels = append(channels, fmt.Sprintf("datanode-test-concurrent-channel-%d_%d", i, rand.Int31()))
+	}
+
+	// insert load, keeps producing insert messages and time ticks into every channel
+	loadCtx, stopLoad := context.WithCancel(ctx)
+	loadWg := sync.WaitGroup{}
+	df := NewDataFactory()
+	for i, ch := range channels {
+		stream, err := msFactory.NewMsgStream(ctx)
+		require.NoError(t, err)
+		stream.AsProducer([]string{ch})
+		stream.Start()
+
+		loadWg.Add(1)
+		go func(segmentID UniqueID, ch string, stream msgstream.MsgStream) {
+			defer loadWg.Done()
+			defer stream.Close()
+			for ts := Timestamp(2000); loadCtx.Err() == nil; ts++ {
+				msg := df.GenMsgStreamInsertMsg(int(ts), ch)
+				msg.CollectionID = 1
+				msg.PartitionID = 1
+				msg.SegmentID = segmentID
+				msg.BeginTimestamp = ts
+				msg.EndTimestamp = ts
+				msg.Base.Timestamp = ts
+				msg.Timestamps = []Timestamp{ts}
+				err := stream.Produce(&msgstream.MsgPack{BeginTs: ts, EndTs: ts, Msgs: []msgstream.TsMsg{msg}})
+				assert.NoError(t, err)
+
+				timeTickMsg := &msgstream.TimeTickMsg{
+					BaseMsg: msgstream.BaseMsg{BeginTimestamp: ts, EndTimestamp: ts, HashValues: []uint32{0}},
+					TimeTickMsg: internalpb.TimeTickMsg{
+						Base: &commonpb.MsgBase{MsgType: commonpb.MsgType_TimeTick, Timestamp: ts},
+					},
+				}
+				err = stream.Broadcast(&msgstream.MsgPack{BeginTs: ts, EndTs: ts, Msgs: []msgstream.TsMsg{timeTickMsg}})
+				assert.NoError(t, err)
+				time.Sleep(time.Millisecond)
+			}
+		}(UniqueID(100+i), ch, stream)
+	}
+
+	// watch and release the channels concurrently, flushing the buffers or dropping them in turn
+	wg := sync.WaitGroup{}
+	for _, ch := range channels {
+		wg.Add(1)
+		go func(ch string) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				err := node.NewDataSyncService(&datapb.VchannelInfo{
+					CollectionID:      1,
+					ChannelName:       ch,
+					UnflushedSegments: []*datapb.SegmentInfo{},
+				})
+				assert.NoError(t, err)
+				assert.True(t, node.flowgraphManager.exist(ch))
+				time.Sleep(time.Duration(50+rand.Intn(50)) * time.Millisecond)
+
+				if i%2 == 0 {
+					node.ReleaseChannel(ch)
+				} else {
+					node.ReleaseDataSyncService(ch)
+				}
+				assert.False(t, node.flowgraphManager.exist(ch))
+			}
+		}(ch)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		t.Fatal("watching and releasing channels concurrently timeout")
+	}
+	stopLoad()
+	loadWg.Wait()
+
+	assert.Equal(t, 0, node.flowgraphManager.size())
+}
@@ -20,8 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
-	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -47,6 +47,7 @@ type dataSyncService struct {
 
 	flushingSegCache *Cache
 	flushManager     flushManager
+	checkpoint       *channelCheckpoint
 }
 
 func newDataSyncService(ctx context.Context,
@@ -98,6 +99,7 @@ type nodeConfig struct {
 	vChannelName string
 	replica      Replica // Segment replica
 	allocator    allocatorInterface
+	checkpoint   *channelCheckpoint // checkpoint of the vchannel, shared by the flowgraph nodes
 
 	// defaults
 	parallelConfig
@@ -126,14 +128,58 @@ func (dsService *dataSyncService) close() {
 	dsService.cancelFn()
 }
 
+// flushBuffersCheckInterval is the interval to check whether the buffers synced by flushBuffers are saved
+const flushBuffersCheckInterval = 10 * time.Millisecond
+
+// flushBuffers syncs all the insert and delete buffers of the vchannel, and waits until the data processed before
+// the call is saved into DataCoord, it returns error if the flowgraph doesn't make it in timeout.
+// The flowgraph must be running, since the buffers are synced by the flowgraph nodes.
+func (dsService *dataSyncService) flushBuffers(timeout time.Duration) error {
+	target := dsService.checkpoint.processed()
+	if target == nil {
+		// nothing processed, nothing buffered
+		return nil
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for _, segmentID := range dsService.checkpoint.bufferedSegments() {
+		select {
+		case dsService.flushCh <- flushMsg{segmentID: segmentID, collectionID: dsService.collectionID, flushed: false}:
+		case <-timer.C:
+			return fmt.Errorf("flush buffers of vchannel %s timeout", dsService.checkpoint.channelName)
+		case <-dsService.ctx.Done():
+			return fmt.Errorf("flush buffers of vchannel %s cancelled", dsService.checkpoint.channelName)
+		}
+	}
+
+	ticker := time.NewTicker(flushBuffersCheckInterval)
+	defer ticker.Stop()
+	for dsService.checkpoint.get().GetTimestamp() < target.GetTimestamp() {
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return fmt.Errorf("flush buffers of vchannel %s timeout", dsService.checkpoint.channelName)
+		case <-dsService.ctx.Done():
+			return fmt.Errorf("flush buffers of vchannel %s cancelled", dsService.checkpoint.channelName)
+		}
+	}
+	return nil
+}
+
 // initNodes inits a TimetickedFlowGraph
 func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) error {
 	dsService.fg = flowgraph.NewTimeTickedFlowGraph(dsService.ctx)
+	if Params.FlowGraphProfileEnabled {
+		dsService.fg.EnableProfiling("dataNode-"+vchanInfo.GetChannelName(), Params.FlowGraphStallThreshold)
+	}
 
 	m := map[string]interface{}{
 		"PulsarAddress":  Params.PulsarAddress,
 		"ReceiveBufSize": 1024,
 		"PulsarBufSize":  1024,
+		"DeadLetter":     Params.DeadLetter,
 	}
 
 	err := dsService.msFactory.SetParams(m)
@@ -141,28 +187,28 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 		return err
 	}
 
-	// MinIO
-	option := &miniokv.Option{
-		Address:           Params.MinioAddress,
-		AccessKeyID:       Params.MinioAccessKeyID,
-		SecretAccessKeyID: Params.MinioSecretAccessKey,
-		UseSSL:            Params.MinioUseSSL,
-		CreateBucket:      true,
-		BucketName:        Params.MinioBucketName,
-	}
-
-	minIOKV, err := miniokv.NewMinIOKV(dsService.ctx, option)
+	minIOKV, err := newChunkManagerFactory().NewDataKV(dsService.ctx)
 	if err != nil {
 		return err
 	}
 
+	// the data before the seek position is flushed already
+	dsService.checkpoint = newChannelCheckpoint(vchanInfo.GetChannelName())
+	dsService.checkpoint.advance(vchanInfo.GetSeekPosition())
+
 	dsService.flushManager = NewRendezvousFlushManager(dsService.idAllocator, minIOKV, dsService.replica, func(pack *segmentFlushPack) error {
 		fieldInsert := []*datapb.FieldBinlog{}
 		fieldStats := []*datapb.FieldBinlog{}
 		deltaInfos := []*datapb.DeltaLogInfo{}
 		checkPoints := []*datapb.CheckPoint{}
 		for k, v := range pack.insertLogs {
-			fieldInsert = append(fieldInsert, &datapb.FieldBinlog{FieldID: k, Binlogs: []string{v}})
+			fieldBinlog := &datapb.FieldBinlog{FieldID: k, Binlogs: []string{v}}
+			// the stats let DataCoord cross-check the row count of the segment
+			if pack.insertStat != nil {
+				fieldBinlog.EntriesNums = []int64{pack.insertStat.rows}
+				fieldBinlog.LogSizes = []int64{pack.insertStat.logSizes[k]}
+			}
+			fieldInsert = append(fieldInsert, fieldBinlog)
 		}
 		for k, v := range pack.statsLogs {
 			fieldStats = append(fieldStats, &datapb.FieldBinlog{FieldID: k, Binlogs: []string{v}})
@@ -209,10 +255,15 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 		if err != nil {
 			return fmt.Errorf(err.Error())
 		}
+		if rsp.ErrorCode == commonpb.ErrorCode_SegmentStatsMismatch {
+			log.Warn("segment stats rejected by DataCoord, retry the sync",
+				zap.Int64("segmentID", pack.segmentID), zap.String("reason", rsp.Reason))
+		}
 		if rsp.ErrorCode != commonpb.ErrorCode_Success {
 			return fmt.Errorf("data service save bin log path failed, reason = %s", rsp.Reason)
 		}
 		dsService.flushingSegCache.Remove(req.GetSegmentID())
+		dsService.checkpoint.done(pack.segmentID, pack.pos)
 		return nil
 	})
 
@@ -222,6 +273,7 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 		vChannelName: vchanInfo.GetChannelName(),
 		replica:      dsService.replica,
 		allocator:    dsService.idAllocator,
+		checkpoint:   dsService.checkpoint,
 
 		parallelConfig: newParallelConfig(),
 	}
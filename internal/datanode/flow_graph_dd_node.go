@@ -53,6 +53,7 @@ type ddNode struct {
 
 	clearSignal  chan<- UniqueID
 	collectionID UniqueID
+	vchannelName string
 
 	segID2SegInfo   sync.Map // segment ID to *SegmentInfo
 	flushedSegments []*datapb.SegmentInfo
@@ -112,6 +113,9 @@ func (ddn *ddNode) Operate(in []Msg) []Msg {
 				//	zap.Int64("Expected collID", ddn.collectionID))
 				continue
 			}
+			if !ddn.isOwnShard(imsg.GetShardName()) {
+				continue
+			}
 			if msg.EndTs() < FilterThreshold {
 				log.Info("Filtering Insert Messages",
 					zap.Uint64("Message endts", msg.EndTs()),
@@ -131,6 +135,9 @@ func (ddn *ddNode) Operate(in []Msg) []Msg {
 				//	zap.Int64("Expected collID", ddn.collectionID))
 				continue
 			}
+			if !ddn.isOwnShard(dmsg.GetShardName()) {
+				continue
+			}
 			fgMsg.deleteMessages = append(fgMsg.deleteMessages, dmsg)
 		}
 	}
@@ -145,6 +152,12 @@ func (ddn *ddNode) Operate(in []Msg) []Msg {
 	return []Msg{&fgMsg}
 }
 
+// isOwnShard checks whether a message carrying shardName belongs to the vchannel of this flowgraph.
+// Several vchannels share one physical channel, messages without a shard name come from older proxies.
+func (ddn *ddNode) isOwnShard(shardName string) bool {
+	return shardName == "" || ddn.vchannelName == "" || shardName == ddn.vchannelName
+}
+
 func (ddn *ddNode) filterFlushedSegmentInsertMessages(msg *msgstream.InsertMsg) bool {
 	if ddn.isFlushed(msg.GetSegmentID()) {
 		return true
@@ -185,6 +198,7 @@ func newDDNode(clearSignal chan<- UniqueID, collID UniqueID, vchanInfo *datapb.V
 		BaseNode:        baseNode,
 		clearSignal:     clearSignal,
 		collectionID:    collID,
+		vchannelName:    vchanInfo.GetChannelName(),
 		flushedSegments: fs,
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
 )
 
@@ -252,6 +253,60 @@ func TestFlowGraph_DDNode_Operate(to *testing.T) {
 	})
 }
 
+func TestFlowGraph_DDNode_SharedPhysicalChannels(t *testing.T) {
+	const (
+		poolSize = 4
+		shards   = 2
+	)
+	FilterThreshold = 0
+	prefix := channelutil.JoinName("by-dev", "rootcoord-dml")
+
+	// 100 collections with 2 shards each over a pool of 4 physical channels
+	pchanMsgs := make(map[string][]msgstream.TsMsg)
+	vchan2Coll := make(map[string]UniqueID)
+	vchan2Pchan := make(map[string]string)
+	for collID := UniqueID(1000); collID < 1100; collID++ {
+		for shard, pchan := range channelutil.AssignPhysicalChannels(prefix, poolSize, collID, shards) {
+			vchan := channelutil.VirtualChannelName(pchan, collID, int32(shard))
+			vchan2Coll[vchan] = collID
+			vchan2Pchan[vchan] = pchan
+			pchanMsgs[pchan] = append(pchanMsgs[pchan],
+				&msgstream.InsertMsg{
+					InsertRequest: internalpb.InsertRequest{
+						Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_Insert},
+						CollectionID: collID,
+						ShardName:    vchan,
+					},
+				},
+				&msgstream.DeleteMsg{
+					DeleteRequest: internalpb.DeleteRequest{
+						Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_Delete},
+						CollectionID: collID,
+						ShardName:    vchan,
+					},
+				})
+		}
+	}
+	assert.Equal(t, poolSize, len(pchanMsgs))
+
+	for vchan, collID := range vchan2Coll {
+		ddn := newDDNode(make(chan UniqueID), collID, &datapb.VchannelInfo{
+			CollectionID: collID,
+			ChannelName:  vchan,
+		})
+		var msgStreamMsg Msg = flowgraph.GenerateMsgStreamMsg(pchanMsgs[vchan2Pchan[vchan]], 0, 0, nil, nil)
+		rt := ddn.Operate([]Msg{msgStreamMsg})
+		fgMsg := rt[0].(*flowGraphMsg)
+
+		assert.Equal(t, 1, len(fgMsg.insertMessages))
+		assert.Equal(t, collID, fgMsg.insertMessages[0].GetCollectionID())
+		assert.Equal(t, vchan, fgMsg.insertMessages[0].GetShardName())
+		assert.Equal(t, 1, len(fgMsg.deleteMessages))
+		assert.Equal(t, collID, fgMsg.deleteMessages[0].GetCollectionID())
+		assert.Equal(t, vchan, fgMsg.deleteMessages[0].GetShardName())
+	}
+}
+
 func TestFlowGraph_DDNode_filterMessages(te *testing.T) {
 	tests := []struct {
 		ddnFlushedSegments []UniqueID
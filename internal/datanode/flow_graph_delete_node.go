@@ -18,7 +18,6 @@ package datanode
 
 import (
 	"context"
-	"encoding/binary"
 	"math"
 	"sync"
 
@@ -27,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/opentracing/opentracing-go"
@@ -45,6 +45,7 @@ type deleteNode struct {
 	replica      Replica
 	idAllocator  allocatorInterface
 	flushManager flushManager
+	checkpoint   *channelCheckpoint
 }
 
 // DelDataBuf buffers insert data, monitoring buffer size and limit
@@ -88,9 +89,19 @@ func (dn *deleteNode) Name() string {
 
 func (dn *deleteNode) Close() {
 	log.Info("Flowgraph Delete Node closing")
+	dn.delBuf.Range(func(key, value interface{}) bool {
+		dn.releaseDelBuf(key.(UniqueID), value.(*DelDataBuf))
+		return true
+	})
 }
 
-func (dn *deleteNode) bufferDeleteMsg(msg *msgstream.DeleteMsg, tr TimeRange) error {
+// releaseDelBuf removes the delete buffer of segID and releases its memory
+func (dn *deleteNode) releaseDelBuf(segID UniqueID, buf *DelDataBuf) {
+	dn.delBuf.Delete(segID)
+	addDeleteBufferMemory(-buf.size * deleteEntrySize)
+}
+
+func (dn *deleteNode) bufferDeleteMsg(msg *msgstream.DeleteMsg) error {
 	log.Debug("bufferDeleteMsg", zap.Any("primary keys", msg.PrimaryKeys))
 
 	segIDToPkMap := make(map[UniqueID][]int64)
@@ -125,14 +136,23 @@ func (dn *deleteNode) bufferDeleteMsg(msg *msgstream.DeleteMsg, tr TimeRange) er
 		}
 		delData := delDataBuf.delData
 
+		// the time range of deltalog is the one of the deletes, so that they are replayed at the right point
+		tr := TimeRange{timestampMin: math.MaxUint64, timestampMax: 0}
 		for i := 0; i < rows; i++ {
 			delData.Data[pks[i]] = tss[i]
 			log.Debug("delete", zap.Int64("primary key", pks[i]), zap.Int64("ts", tss[i]))
+			if Timestamp(tss[i]) < tr.timestampMin {
+				tr.timestampMin = Timestamp(tss[i])
+			}
+			if Timestamp(tss[i]) > tr.timestampMax {
+				tr.timestampMax = Timestamp(tss[i])
+			}
 		}
 
 		// store
 		delDataBuf.updateSize(int64(rows))
 		delDataBuf.updateTimeRange(tr)
+		addDeleteBufferMemory(int64(rows) * deleteEntrySize)
 		dn.delBuf.Store(segID, delDataBuf)
 	}
 
@@ -177,7 +197,7 @@ func (dn *deleteNode) Operate(in []Msg) []Msg {
 	}
 
 	for _, msg := range fgMsg.deleteMessages {
-		if err := dn.bufferDeleteMsg(msg, fgMsg.timeRange); err != nil {
+		if err := dn.bufferDeleteMsg(msg); err != nil {
 			log.Error("buffer delete msg failed", zap.Error(err))
 		}
 	}
@@ -185,6 +205,10 @@ func (dn *deleteNode) Operate(in []Msg) []Msg {
 	// show all data in dn.delBuf
 	if len(fgMsg.deleteMessages) != 0 {
 		dn.showDelBuf()
+		dn.delBuf.Range(func(key, _ interface{}) bool {
+			dn.checkpoint.buffer(key.(UniqueID), deleteBufferKind, fgMsg.startPositions[0])
+			return true
+		})
 	}
 
 	// handle flush
@@ -201,19 +225,56 @@ func (dn *deleteNode) Operate(in []Msg) []Msg {
 					log.Warn("Failed to flush delete data", zap.Error(err))
 				} else {
 					// clean up
-					dn.delBuf.Delete(segmentToFlush)
+					dn.releaseDelBuf(segmentToFlush, buf.(*DelDataBuf))
+					dn.checkpoint.sync(segmentToFlush, deleteBufferKind, fgMsg.endPositions[0])
 				}
 			}
 
 		}
 	}
 
+	dn.syncFlushedSegments(fgMsg.segmentsToFlush, fgMsg.endPositions[0])
+	dn.checkpoint.advance(fgMsg.endPositions[0])
+
 	for _, sp := range spans {
 		sp.Finish()
 	}
 	return nil
 }
 
+// syncFlushedSegments syncs the delete buffers of flushed segments, except the ones in flushing.
+// Flushed segments have no insert buffer to sync with, so their deletes are persisted once they arrive,
+// along with an empty insert flush to complete the flush task.
+func (dn *deleteNode) syncFlushedSegments(flushing []UniqueID, pos *internalpb.MsgPosition) {
+	skip := make(map[UniqueID]struct{}, len(flushing))
+	for _, segID := range flushing {
+		skip[segID] = struct{}{}
+	}
+
+	dn.delBuf.Range(func(key, value interface{}) bool {
+		segID := key.(UniqueID)
+		if _, ok := skip[segID]; ok {
+			return true
+		}
+		if !dn.replica.hasSegment(segID, true) || dn.replica.hasSegment(segID, false) {
+			return true
+		}
+
+		log.Debug("DeleteNode syncs deletes of flushed segment", zap.Int64("segID", segID))
+		if err := dn.flushManager.flushDelData(value.(*DelDataBuf), segID, pos); err != nil {
+			log.Warn("Failed to flush delete data of flushed segment", zap.Int64("segID", segID), zap.Error(err))
+			return true
+		}
+		// flushed=false keeps the segment state in DataCoord
+		if err := dn.flushManager.flushBufferData(nil, segID, false, pos); err != nil {
+			log.Warn("Failed to notify insert flush of flushed segment", zap.Int64("segID", segID), zap.Error(err))
+		}
+		dn.releaseDelBuf(segID, value.(*DelDataBuf))
+		dn.checkpoint.sync(segID, deleteBufferKind, pos)
+		return true
+	})
+}
+
 // filterSegmentByPK returns the bloom filter check result.
 // If the key may exists in the segment, returns it in map.
 // If the key not exists in the segment, the segment is filter out.
@@ -223,9 +284,7 @@ func (dn *deleteNode) filterSegmentByPK(partID UniqueID, pks []int64) map[int64]
 	segments := dn.replica.filterSegments(dn.channelName, partID)
 	for _, pk := range pks {
 		for _, segment := range segments {
-			binary.BigEndian.PutUint64(buf, uint64(pk))
-			exist := segment.pkFilter.Test(buf)
-			if exist {
+			if segment.mayContainPK(pk, buf) {
 				result[pk] = append(result[pk], segment.segmentID)
 			}
 		}
@@ -238,6 +297,11 @@ func newDeleteNode(ctx context.Context, fm flushManager, config *nodeConfig) (*d
 	baseNode.SetMaxQueueLength(config.maxQueueLength)
 	baseNode.SetMaxParallelism(config.maxParallelism)
 
+	checkpoint := config.checkpoint
+	if checkpoint == nil {
+		checkpoint = newChannelCheckpoint(config.vChannelName)
+	}
+
 	return &deleteNode{
 		BaseNode: baseNode,
 		delBuf:   sync.Map{},
@@ -246,5 +310,6 @@ func newDeleteNode(ctx context.Context, fm flushManager, config *nodeConfig) (*d
 		idAllocator:  config.allocator,
 		channelName:  config.vChannelName,
 		flushManager: fm,
+		checkpoint:   checkpoint,
 	}, nil
 }
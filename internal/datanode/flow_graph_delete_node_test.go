@@ -20,12 +20,14 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/bits-and-blooms/bloom/v3"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
 	"github.com/stretchr/testify/assert"
@@ -121,13 +123,13 @@ func genMockReplica(segIDs []int64, pks []int64, chanName string) *mockReplica {
 	buf := make([]byte, 8)
 	filter0 := bloom.NewWithEstimates(1000000, 0.01)
 	for i := 0; i < 3; i++ {
-		binary.BigEndian.PutUint64(buf, uint64(pks[i]))
+		binary.LittleEndian.PutUint64(buf, uint64(pks[i]))
 		filter0.Add(buf)
 	}
 
 	filter1 := bloom.NewWithEstimates(1000000, 0.01)
 	for i := 3; i < 5; i++ {
-		binary.BigEndian.PutUint64(buf, uint64(pks[i]))
+		binary.LittleEndian.PutUint64(buf, uint64(pks[i]))
 		filter1.Add(buf)
 	}
 
@@ -135,31 +137,43 @@ func genMockReplica(segIDs []int64, pks []int64, chanName string) *mockReplica {
 		segmentID:   segIDs[0],
 		channelName: chanName,
 		pkFilter:    filter0,
+		minPK:       pks[0],
+		maxPK:       pks[2],
 	}
 	seg1 := &Segment{
 		segmentID:   segIDs[1],
 		channelName: chanName,
 		pkFilter:    filter0,
+		minPK:       pks[0],
+		maxPK:       pks[2],
 	}
 	seg2 := &Segment{
 		segmentID:   segIDs[2],
 		channelName: chanName,
 		pkFilter:    filter0,
+		minPK:       pks[0],
+		maxPK:       pks[2],
 	}
 	seg3 := &Segment{
 		segmentID:   segIDs[3],
 		channelName: chanName,
 		pkFilter:    filter1,
+		minPK:       pks[3],
+		maxPK:       pks[4],
 	}
 	seg4 := &Segment{
 		segmentID:   segIDs[4],
 		channelName: chanName,
 		pkFilter:    filter1,
+		minPK:       pks[3],
+		maxPK:       pks[4],
 	}
 	seg5 := &Segment{
 		segmentID:   segIDs[4],
 		channelName: "test_error",
 		pkFilter:    filter1,
+		minPK:       pks[3],
+		maxPK:       pks[4],
 	}
 
 	replica := newMockReplica()
@@ -239,7 +253,7 @@ func TestFlowGraphDeleteNode_Operate(t *testing.T) {
 		testPath := "/test/datanode/root/meta"
 		assert.NoError(t, clearEtcd(testPath))
 		Params.MetaRootPath = testPath
-		Params.DeleteBinlogRootPath = testPath
+		Params.MinioRootPath = testPath
 
 		c := &nodeConfig{
 			replica:      replica,
@@ -263,7 +277,7 @@ func TestFlowGraphDeleteNode_Operate(t *testing.T) {
 		testPath := "/test/datanode/root/meta"
 		assert.NoError(t, clearEtcd(testPath))
 		Params.MetaRootPath = testPath
-		Params.DeleteBinlogRootPath = testPath
+		Params.MinioRootPath = testPath
 
 		c := &nodeConfig{
 			replica:      replica,
@@ -284,4 +298,93 @@ func TestFlowGraphDeleteNode_Operate(t *testing.T) {
 		// send again shall trigger empty buffer flush
 		delNode.Operate([]flowgraph.Msg{fgMsg})
 	})
+	t.Run("Test deleteNode Operate on flushed, growing and non-existent keys", func(te *testing.T) {
+		const (
+			growingSegID = 66
+			flushedSegID = 77
+		)
+		delReplica := newMockReplica()
+		genSegment := func(segID UniqueID, pks ...int64) *Segment {
+			seg := &Segment{
+				segmentID:   segID,
+				channelName: chanName,
+				pkFilter:    bloom.NewWithEstimates(1000, 0.01),
+				minPK:       math.MaxInt64,
+				maxPK:       math.MinInt64,
+			}
+			seg.updatePKRange(pks)
+			return seg
+		}
+		delReplica.newSegments[growingSegID] = genSegment(growingSegID, 1, 2)
+		delReplica.flushedSegments[flushedSegID] = genSegment(flushedSegID, 100, 200)
+
+		delKV := memkv.NewMemoryKV()
+		packs := make(chan *segmentFlushPack, 10)
+		delFm := NewRendezvousFlushManager(NewAllocatorFactory(), delKV, delReplica, func(pack *segmentFlushPack) error {
+			packs <- pack
+			return nil
+		})
+		c := &nodeConfig{
+			replica:      delReplica,
+			allocator:    NewAllocatorFactory(),
+			vChannelName: chanName,
+		}
+		delNode, err := newDeleteNode(context.Background(), delFm, c)
+		assert.Nil(te, err)
+
+		// pk 999 exists in no segment
+		msg := GenFlowGraphDeleteMsg([]int64{1, 100, 999}, chanName)
+		msg.endPositions = []*internalpb.MsgPosition{{ChannelName: chanName, MsgID: []byte{1}, Timestamp: 2000}}
+		delNode.Operate([]flowgraph.Msg{&msg})
+
+		// deletes of flushed segment are synced at once
+		select {
+		case pack := <-packs:
+			assert.EqualValues(te, flushedSegID, pack.segmentID)
+			assert.False(te, pack.flushed)
+			assert.EqualValues(te, 2000, pack.pos.GetTimestamp())
+			assert.Empty(te, pack.insertLogs)
+			assert.Equal(te, 1, len(pack.deltaLogs))
+			deltaLog := pack.deltaLogs[0]
+			assert.EqualValues(te, 1, deltaLog.size)
+			assert.EqualValues(te, map[int64]int64{100: 1001}, deltaLog.delData.Data)
+			assert.EqualValues(te, 1001, deltaLog.tsFrom)
+			assert.EqualValues(te, 1001, deltaLog.tsTo)
+			value, err := delKV.Load(deltaLog.filePath)
+			assert.Nil(te, err)
+			assert.EqualValues(te, len(value), deltaLog.fileSize)
+		case <-time.After(5 * time.Second):
+			te.Fatal("deletes of flushed segment are not synced")
+		}
+		_, ok := delNode.delBuf.Load(UniqueID(flushedSegID))
+		assert.False(te, ok)
+
+		// deletes of growing segment wait for its flush
+		value, ok := delNode.delBuf.Load(UniqueID(growingSegID))
+		assert.True(te, ok)
+		assert.EqualValues(te, map[int64]int64{1: 1000}, value.(*DelDataBuf).delData.Data)
+		select {
+		case pack := <-packs:
+			te.Fatalf("unexpected sync of segment %d", pack.segmentID)
+		default:
+		}
+
+		msg = GenFlowGraphDeleteMsg([]int64{999}, chanName)
+		msg.endPositions = []*internalpb.MsgPosition{{ChannelName: chanName, MsgID: []byte{2}, Timestamp: 3000}}
+		msg.segmentsToFlush = []UniqueID{growingSegID}
+		delFm.flushBufferData(nil, growingSegID, true, msg.endPositions[0])
+		delNode.Operate([]flowgraph.Msg{&msg})
+		select {
+		case pack := <-packs:
+			assert.EqualValues(te, growingSegID, pack.segmentID)
+			assert.True(te, pack.flushed)
+			assert.Equal(te, 1, len(pack.deltaLogs))
+			assert.EqualValues(te, 1000, pack.deltaLogs[0].tsFrom)
+			assert.EqualValues(te, 1000, pack.deltaLogs[0].tsTo)
+		case <-time.After(5 * time.Second):
+			te.Fatal("deletes of growing segment are not synced")
+		}
+		_, ok = delNode.delBuf.Load(UniqueID(growingSegID))
+		assert.False(te, ok)
+	})
 }
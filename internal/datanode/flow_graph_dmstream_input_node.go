@@ -18,12 +18,14 @@ package datanode
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
-	"github.com/milvus-io/milvus/internal/rootcoord"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/flowgraph"
 	"go.uber.org/zap"
 )
@@ -42,7 +44,7 @@ func newDmInputNode(ctx context.Context, seekPos *internalpb.MsgPosition, dmNode
 
 	// MsgStream needs a physical channel name, but the channel name in seek position from DataCoord
 	//  is virtual channel name, so we need to convert vchannel name into pchannel neme here.
-	pchannelName := rootcoord.ToPhysicalChannel(dmNodeConfig.vChannelName)
+	pchannelName := channelutil.ToPhysicalChannel(dmNodeConfig.vChannelName)
 	insertStream.AsConsumer([]string{pchannelName}, consumeSubName)
 	log.Debug("datanode AsConsumer", zap.String("physical channel", pchannelName), zap.String("subName", consumeSubName))
 
@@ -51,6 +53,12 @@ func newDmInputNode(ctx context.Context, seekPos *internalpb.MsgPosition, dmNode
 		start := time.Now()
 		log.Debug("datanode begin to seek: " + seekPos.GetChannelName())
 		err = insertStream.Seek([]*internalpb.MsgPosition{seekPos})
+		if errors.Is(err, msgstream.ErrPositionExpired) {
+			// The messages after the checkpoint are removed by retention, replay from the earliest retained one
+			log.Warn("datanode seek position expired, recover from the earliest message",
+				zap.String("channel", seekPos.GetChannelName()), zap.Error(err))
+			err = insertStream.SeekToTimestamp([]string{pchannelName}, 0)
+		}
 		if err != nil {
 			return nil, err
 		}
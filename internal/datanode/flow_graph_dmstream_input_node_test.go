@@ -60,8 +60,9 @@ func (mm *mockMsgStreamFactory) NewQueryMsgStream(ctx context.Context) (msgstrea
 type mockTtMsgStream struct {
 }
 
-func (mtm *mockTtMsgStream) Start() {}
-func (mtm *mockTtMsgStream) Close() {}
+func (mtm *mockTtMsgStream) Start()       {}
+func (mtm *mockTtMsgStream) Close()       {}
+func (mtm *mockTtMsgStream) Unsubscribe() {}
 func (mtm *mockTtMsgStream) Chan() <-chan *msgstream.MsgPack {
 	return make(chan *msgstream.MsgPack, 100)
 }
@@ -97,6 +98,10 @@ func (mtm *mockTtMsgStream) Seek(offset []*internalpb.MsgPosition) error {
 	return nil
 }
 
+func (mtm *mockTtMsgStream) SeekToTimestamp(channels []string, ts Timestamp) error {
+	return nil
+}
+
 func TestNewDmInputNode(t *testing.T) {
 	ctx := context.Background()
 	_, err := newDmInputNode(ctx, new(internalpb.MsgPosition), &nodeConfig{msFactory: &mockMsgStreamFactory{}})
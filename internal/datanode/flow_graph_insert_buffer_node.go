@@ -25,6 +25,7 @@ import (
 	"io"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/opentracing/opentracing-go"
@@ -61,6 +62,8 @@ type insertBufferNode struct {
 	flushChan        <-chan flushMsg
 	flushingSegCache *Cache
 	flushManager     flushManager
+	syncPolicies     []segmentSyncPolicy
+	checkpoint       *channelCheckpoint
 
 	timeTickStream          msgstream.MsgStream
 	segmentStatisticsStream msgstream.MsgStream
@@ -86,6 +89,9 @@ type BufferData struct {
 	buffer *InsertData
 	size   int64
 	limit  int64
+
+	memorySize int64     // bytes of the buffered data
+	startTime  time.Time // when the buffer is created
 }
 
 // newBufferData needs an input dimension to calculate the limit of this buffer
@@ -110,7 +116,12 @@ func newBufferData(dimension int64) (*BufferData, error) {
 
 	limit := Params.FlushInsertBufferSize / (dimension * 4)
 
-	return &BufferData{&InsertData{Data: make(map[UniqueID]storage.FieldData)}, 0, limit}, nil
+	return &BufferData{
+		buffer:    &InsertData{Data: make(map[UniqueID]storage.FieldData)},
+		size:      0,
+		limit:     limit,
+		startTime: time.Now(),
+	}, nil
 }
 
 func (bd *BufferData) effectiveCap() int64 {
@@ -121,11 +132,23 @@ func (bd *BufferData) updateSize(no int64) {
 	bd.size += no
 }
 
+func (bd *BufferData) updateMemorySize(no int64) {
+	bd.memorySize += no
+	addInsertBufferMemory(no)
+}
+
 func (ibNode *insertBufferNode) Name() string {
 	return "ibNode"
 }
 
 func (ibNode *insertBufferNode) Close() {
+	// the buffers not synced are dropped with the flowgraph
+	ibNode.insertBuffer.Range(func(k, v interface{}) bool {
+		ibNode.insertBuffer.Delete(k)
+		addInsertBufferMemory(-v.(*BufferData).memorySize)
+		return true
+	})
+
 	if ibNode.timeTickStream != nil {
 		ibNode.timeTickStream.Close()
 	}
@@ -190,7 +213,9 @@ func (ibNode *insertBufferNode) Operate(in []Msg) []Msg {
 		err := ibNode.bufferInsertMsg(msg, endPositions[0])
 		if err != nil {
 			log.Warn("msg to buffer failed", zap.Error(err))
+			continue
 		}
+		ibNode.checkpoint.buffer(msg.GetSegmentID(), insertBufferKind, startPositions[0])
 	}
 
 	// Find and return the smaller input
@@ -226,18 +251,26 @@ func (ibNode *insertBufferNode) Operate(in []Msg) []Msg {
 	flushTaskList := make([]flushTask, 0, len(seg2Upload)+1)
 
 	// Auto Flush
-	for _, segToFlush := range seg2Upload {
-		// If full, auto flush
-		if bd, ok := ibNode.insertBuffer.Load(segToFlush); ok && bd.(*BufferData).effectiveCap() <= 0 {
-			log.Warn("Auto flush", zap.Int64("segment id", segToFlush))
-			ibuffer := bd.(*BufferData)
-
-			flushTaskList = append(flushTaskList, flushTask{
-				buffer:    ibuffer,
-				segmentID: segToFlush,
-				flushed:   false,
-			})
-		}
+	buffers := make([]segmentBuffer, 0)
+	ibNode.insertBuffer.Range(func(k, v interface{}) bool {
+		buffers = append(buffers, segmentBuffer{segmentID: k.(UniqueID), buffer: v.(*BufferData)})
+		return true
+	})
+	for _, segToFlush := range selectSyncSegments(ibNode.syncPolicies, buffers, time.Now()) {
+		bd, _ := ibNode.insertBuffer.Load(segToFlush)
+		ibuffer := bd.(*BufferData)
+		log.Info("Auto flush",
+			zap.Int64("segment id", segToFlush),
+			zap.Int64("buffer rows", ibuffer.size),
+			zap.Int64("buffer bytes", ibuffer.memorySize),
+			zap.Duration("buffer age", time.Since(ibuffer.startTime)),
+			zap.Int64("total buffer bytes", getInsertBufferMemory()))
+
+		flushTaskList = append(flushTaskList, flushTask{
+			buffer:    ibuffer,
+			segmentID: segToFlush,
+			flushed:   false,
+		})
 	}
 
 	// Manual Flush
@@ -279,10 +312,14 @@ func (ibNode *insertBufferNode) Operate(in []Msg) []Msg {
 			log.Warn("failed to invoke flushBufferData", zap.Error(err))
 		} else {
 			segmentsToFlush = append(segmentsToFlush, task.segmentID)
+			ibNode.checkpoint.sync(task.segmentID, insertBufferKind, endPositions[0])
 			if task.flushed {
 				ibNode.replica.segmentFlushed(task.segmentID)
 			}
 			ibNode.insertBuffer.Delete(task.segmentID)
+			if task.buffer != nil {
+				addInsertBufferMemory(-task.buffer.memorySize)
+			}
 		}
 	}
 
@@ -616,6 +653,7 @@ func (ibNode *insertBufferNode) bufferInsertMsg(msg *msgstream.InsertMsg, endPos
 
 	// update buffer size
 	buffer.updateSize(int64(len(msg.RowData)))
+	buffer.updateMemorySize(insertMsgMemorySize(msg))
 
 	// store in buffer
 	ibNode.insertBuffer.Store(currentSegID, buffer)
@@ -626,6 +664,15 @@ func (ibNode *insertBufferNode) bufferInsertMsg(msg *msgstream.InsertMsg, endPos
 	return nil
 }
 
+// insertMsgMemorySize estimates the bytes of the rows in msg, including their row IDs and timestamps
+func insertMsgMemorySize(msg *msgstream.InsertMsg) int64 {
+	size := int64(len(msg.RowIDs)+len(msg.Timestamps)) * 8
+	for _, blob := range msg.RowData {
+		size += int64(len(blob.GetValue()))
+	}
+	return size
+}
+
 // readBinary read data in bytes and write it into receiver.
 //  The receiver can be any type in int8, int16, int32, int64, float32, float64 and bool
 //  readBinary uses LittleEndian ByteOrder.
@@ -653,6 +700,8 @@ func (ibNode *insertBufferNode) writeHardTimeTick(ts Timestamp) error {
 			},
 			ChannelName: ibNode.channelName,
 			Timestamp:   ts,
+			MemoryHigh:  isMemoryHigh(),
+			Checkpoint:  ibNode.checkpoint.get(),
 		},
 	}
 	msgPack.Msgs = append(msgPack.Msgs, &timeTickMsg)
@@ -733,6 +782,11 @@ func newInsertBufferNode(ctx context.Context, flushCh <-chan flushMsg, fm flushM
 	baseNode.SetMaxQueueLength(config.maxQueueLength)
 	baseNode.SetMaxParallelism(config.maxParallelism)
 
+	syncPolicies, err := newSyncPolicies(Params.FlushSyncPolicies)
+	if err != nil {
+		return nil, err
+	}
+
 	//input stream, data node time tick
 	wTt, err := config.msFactory.NewMsgStream(ctx)
 	if err != nil {
@@ -753,6 +807,11 @@ func newInsertBufferNode(ctx context.Context, flushCh <-chan flushMsg, fm flushM
 	var segStatisticsMsgStream msgstream.MsgStream = segS
 	segStatisticsMsgStream.Start()
 
+	checkpoint := config.checkpoint
+	if checkpoint == nil {
+		checkpoint = newChannelCheckpoint(config.vChannelName)
+	}
+
 	return &insertBufferNode{
 		BaseNode:     baseNode,
 		insertBuffer: sync.Map{},
@@ -764,6 +823,8 @@ func newInsertBufferNode(ctx context.Context, flushCh <-chan flushMsg, fm flushM
 		flushChan:        flushCh,
 		flushingSegCache: flushingSegCache,
 		flushManager:     fm,
+		syncPolicies:     syncPolicies,
+		checkpoint:       checkpoint,
 
 		replica:     config.replica,
 		idAllocator: config.allocator,
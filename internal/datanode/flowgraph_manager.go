@@ -0,0 +1,205 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"sync"
+)
+
+// channelFlowgraph is the flowgraph of a vchannel registered in flowgraphManager
+type channelFlowgraph struct {
+	channelName string
+	// closed when the flowgraph is created and started, or failed to be created with err
+	ready   chan struct{}
+	err     error
+	service *dataSyncService
+	// the references held by the registry and the callers using the flowgraph, the flowgraph is closed when it drops to 0
+	refCount int
+	// closed when the flowgraph is closed
+	closed chan struct{}
+}
+
+// isReady tells whether the flowgraph is created and started
+func (cf *channelFlowgraph) isReady() bool {
+	select {
+	case <-cf.ready:
+		return cf.err == nil
+	default:
+		return false
+	}
+}
+
+// flowgraphManager is the registry of the flowgraphs keyed by vchannel name, each flowgraph has its own lifecycle.
+// The flowgraphs are created and closed out of the registry lock, so that the operations on different vchannels
+// don't block each other.
+type flowgraphManager struct {
+	mu         sync.Mutex
+	flowgraphs map[string]*channelFlowgraph
+}
+
+func newFlowgraphManager() *flowgraphManager {
+	return &flowgraphManager{
+		flowgraphs: make(map[string]*channelFlowgraph),
+	}
+}
+
+// add registers the flowgraph of channelName created by create and starts it.
+// It's a no-op if the flowgraph exists, and waits for the flowgraph if it's being created by another call.
+func (fm *flowgraphManager) add(channelName string, create func() (*dataSyncService, error)) error {
+	fm.mu.Lock()
+	if cf, ok := fm.flowgraphs[channelName]; ok {
+		fm.mu.Unlock()
+		<-cf.ready
+		return cf.err
+	}
+	cf := &channelFlowgraph{
+		channelName: channelName,
+		ready:       make(chan struct{}),
+		refCount:    1, // held by the registry
+		closed:      make(chan struct{}),
+	}
+	fm.flowgraphs[channelName] = cf
+	fm.mu.Unlock()
+
+	service, err := create()
+	if err != nil {
+		fm.mu.Lock()
+		if fm.flowgraphs[channelName] == cf {
+			delete(fm.flowgraphs, channelName)
+		}
+		fm.mu.Unlock()
+		cf.err = err
+		close(cf.ready)
+		close(cf.closed)
+		return err
+	}
+	cf.service = service
+	service.start()
+	close(cf.ready)
+	return nil
+}
+
+// acquire returns the started flowgraph of channelName and holds a reference to it,
+// the flowgraph stays open until the reference is released.
+func (fm *flowgraphManager) acquire(channelName string) (*channelFlowgraph, bool) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	cf, ok := fm.flowgraphs[channelName]
+	if !ok || !cf.isReady() || cf.refCount <= 0 {
+		return nil, false
+	}
+	cf.refCount++
+	return cf, true
+}
+
+// release drops a reference to cf, and closes the flowgraph if it's the last one
+func (fm *flowgraphManager) release(cf *channelFlowgraph) {
+	fm.mu.Lock()
+	cf.refCount--
+	last := cf.refCount == 0
+	fm.mu.Unlock()
+	if last {
+		cf.service.close()
+		close(cf.closed)
+	}
+}
+
+// remove unregisters the flowgraph of channelName, calls beforeClose with the flowgraph still running if it's not nil,
+// then waits until the flowgraph is closed by the last reference.
+func (fm *flowgraphManager) remove(channelName string, beforeClose func(service *dataSyncService)) {
+	fm.mu.Lock()
+	cf, ok := fm.flowgraphs[channelName]
+	if ok {
+		delete(fm.flowgraphs, channelName)
+	}
+	fm.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	<-cf.ready
+	if cf.err != nil {
+		return
+	}
+	if beforeClose != nil {
+		beforeClose(cf.service)
+	}
+	fm.release(cf)
+	<-cf.closed
+}
+
+// find returns the name of the first started flowgraph satisfying predicate
+func (fm *flowgraphManager) find(predicate func(service *dataSyncService) bool) (string, bool) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	for name, cf := range fm.flowgraphs {
+		if cf.isReady() && predicate(cf.service) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// filter returns the names of the started flowgraphs satisfying predicate
+func (fm *flowgraphManager) filter(predicate func(service *dataSyncService) bool) []string {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	names := make([]string, 0, len(fm.flowgraphs))
+	for name, cf := range fm.flowgraphs {
+		if cf.isReady() && predicate(cf.service) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// exist tells whether the flowgraph of channelName is registered
+func (fm *flowgraphManager) exist(channelName string) bool {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	_, ok := fm.flowgraphs[channelName]
+	return ok
+}
+
+// getService returns the started dataSyncService of channelName
+func (fm *flowgraphManager) getService(channelName string) (*dataSyncService, bool) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	cf, ok := fm.flowgraphs[channelName]
+	if !ok || !cf.isReady() {
+		return nil, false
+	}
+	return cf.service, true
+}
+
+// channels returns the names of all the registered flowgraphs
+func (fm *flowgraphManager) channels() []string {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	names := make([]string, 0, len(fm.flowgraphs))
+	for name := range fm.flowgraphs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// size returns the number of the registered flowgraphs
+func (fm *flowgraphManager) size() int {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return len(fm.flowgraphs)
+}
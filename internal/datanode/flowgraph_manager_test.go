@@ -0,0 +1,320 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeDataSyncService creates a dataSyncService without flowgraph, the flush messages are drained until it's closed
+func newFakeDataSyncService(ctx context.Context, collectionID UniqueID, consumed *int64) *dataSyncService {
+	ctx1, cancel := context.WithCancel(ctx)
+	service := &dataSyncService{
+		ctx:          ctx1,
+		cancelFn:     cancel,
+		flushCh:      make(chan flushMsg, 100),
+		collectionID: collectionID,
+	}
+	go func() {
+		for {
+			select {
+			case <-service.flushCh:
+				atomic.AddInt64(consumed, 1)
+			case <-ctx1.Done():
+				return
+			}
+		}
+	}()
+	return service
+}
+
+func isClosed(service *dataSyncService) bool {
+	select {
+	case <-service.ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestFlowgraphManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var consumed int64
+
+	t.Run("add and remove", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		service := newFakeDataSyncService(ctx, 1, &consumed)
+		err := fm.add("ch-1", func() (*dataSyncService, error) { return service, nil })
+		require.NoError(t, err)
+		assert.True(t, fm.exist("ch-1"))
+		assert.Equal(t, 1, fm.size())
+
+		// no-op if exists
+		err = fm.add("ch-1", func() (*dataSyncService, error) {
+			t.Error("flowgraph created twice")
+			return nil, errors.New("created twice")
+		})
+		assert.NoError(t, err)
+
+		s, ok := fm.getService("ch-1")
+		assert.True(t, ok)
+		assert.Equal(t, service, s)
+		assert.ElementsMatch(t, []string{"ch-1"}, fm.channels())
+
+		fm.remove("ch-1", nil)
+		assert.True(t, isClosed(service))
+		assert.False(t, fm.exist("ch-1"))
+		assert.Equal(t, 0, fm.size())
+		_, ok = fm.acquire("ch-1")
+		assert.False(t, ok)
+
+		// no-op if not exists
+		fm.remove("ch-1", nil)
+	})
+
+	t.Run("failed to create", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		err := fm.add("ch-1", func() (*dataSyncService, error) { return nil, errors.New("mock error") })
+		assert.Error(t, err)
+		assert.False(t, fm.exist("ch-1"))
+
+		err = fm.add("ch-1", func() (*dataSyncService, error) { return newFakeDataSyncService(ctx, 1, &consumed), nil })
+		assert.NoError(t, err)
+		assert.True(t, fm.exist("ch-1"))
+		fm.remove("ch-1", nil)
+	})
+
+	t.Run("remove waits for references", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		service := newFakeDataSyncService(ctx, 1, &consumed)
+		require.NoError(t, fm.add("ch-1", func() (*dataSyncService, error) { return service, nil }))
+
+		cf, ok := fm.acquire("ch-1")
+		require.True(t, ok)
+
+		removed := make(chan struct{})
+		go func() {
+			fm.remove("ch-1", nil)
+			close(removed)
+		}()
+		assert.Eventually(t, func() bool { return !fm.exist("ch-1") }, time.Second, time.Millisecond)
+		_, ok = fm.acquire("ch-1")
+		assert.False(t, ok)
+
+		select {
+		case <-removed:
+			t.Fatal("flowgraph removed with references held")
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.False(t, isClosed(service))
+		cf.service.flushCh <- flushMsg{segmentID: 1}
+
+		fm.release(cf)
+		<-removed
+		assert.True(t, isClosed(service))
+	})
+
+	t.Run("remove calls beforeClose with flowgraph running", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		service := newFakeDataSyncService(ctx, 1, &consumed)
+		require.NoError(t, fm.add("ch-1", func() (*dataSyncService, error) { return service, nil }))
+
+		called := false
+		fm.remove("ch-1", func(s *dataSyncService) {
+			called = true
+			assert.Equal(t, service, s)
+			assert.False(t, isClosed(s))
+		})
+		assert.True(t, called)
+		assert.True(t, isClosed(service))
+	})
+
+	t.Run("concurrent add of the same channel", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		block := make(chan struct{})
+		var created int32
+		create := func() (*dataSyncService, error) {
+			atomic.AddInt32(&created, 1)
+			<-block
+			return newFakeDataSyncService(ctx, 1, &consumed), nil
+		}
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, fm.add("ch-1", create))
+				_, ok := fm.getService("ch-1")
+				assert.True(t, ok)
+			}()
+		}
+		// the flowgraph being created is not visible to flush
+		assert.Eventually(t, func() bool { return fm.exist("ch-1") }, time.Second, time.Millisecond)
+		_, ok := fm.acquire("ch-1")
+		assert.False(t, ok)
+
+		close(block)
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&created))
+		fm.remove("ch-1", nil)
+	})
+
+	t.Run("slow channel does not block others", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		block := make(chan struct{})
+		slowAdded := make(chan struct{})
+		go func() {
+			err := fm.add("slow", func() (*dataSyncService, error) {
+				<-block
+				return newFakeDataSyncService(ctx, 1, &consumed), nil
+			})
+			assert.NoError(t, err)
+			close(slowAdded)
+		}()
+		assert.Eventually(t, func() bool { return fm.exist("slow") }, time.Second, time.Millisecond)
+
+		service := newFakeDataSyncService(ctx, 1, &consumed)
+		done := make(chan struct{})
+		go func() {
+			assert.NoError(t, fm.add("fast", func() (*dataSyncService, error) { return service, nil }))
+			fm.remove("fast", func(*dataSyncService) {})
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("channel blocked by another channel being created")
+		}
+		assert.True(t, isClosed(service))
+
+		close(block)
+		<-slowAdded
+		fm.remove("slow", nil)
+		assert.Equal(t, 0, fm.size())
+	})
+
+	t.Run("find and filter", func(t *testing.T) {
+		fm := newFlowgraphManager()
+		for i, collID := range []UniqueID{1, 2, 1} {
+			service := newFakeDataSyncService(ctx, collID, &consumed)
+			require.NoError(t, fm.add(fmt.Sprintf("ch-%d", i), func() (*dataSyncService, error) { return service, nil }))
+		}
+
+		name, ok := fm.find(func(s *dataSyncService) bool { return s.collectionID == 2 })
+		assert.True(t, ok)
+		assert.Equal(t, "ch-1", name)
+		_, ok = fm.find(func(s *dataSyncService) bool { return s.collectionID == 3 })
+		assert.False(t, ok)
+
+		assert.ElementsMatch(t, []string{"ch-0", "ch-2"}, fm.filter(func(s *dataSyncService) bool { return s.collectionID == 1 }))
+		assert.Empty(t, fm.filter(func(s *dataSyncService) bool { return s.collectionID == 3 }))
+
+		for _, name := range fm.channels() {
+			fm.remove(name, nil)
+		}
+	})
+}
+
+func TestFlowgraphManagerConcurrentChannels(t *testing.T) {
+	const (
+		channelNum = 20
+		rounds     = 10
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fm := newFlowgraphManager()
+	var consumed int64
+	var created, closed int32
+	names := make([]string, 0, channelNum)
+	for i := 0; i < channelNum; i++ {
+		names = append(names, fmt.Sprintf("concurrent-ch-%d", i))
+	}
+
+	// the load keeps sending messages to random flowgraphs, which must not be blocked by the closed flowgraphs
+	loadCtx, stopLoad := context.WithCancel(ctx)
+	loadWg := sync.WaitGroup{}
+	var sent int64
+	for i := 0; i < 4; i++ {
+		loadWg.Add(1)
+		go func() {
+			defer loadWg.Done()
+			for loadCtx.Err() == nil {
+				cf, ok := fm.acquire(names[rand.Intn(channelNum)])
+				if !ok {
+					time.Sleep(time.Millisecond)
+					continue
+				}
+				cf.service.flushCh <- flushMsg{segmentID: 1}
+				atomic.AddInt64(&sent, 1)
+				fm.release(cf)
+			}
+		}()
+	}
+
+	wg := sync.WaitGroup{}
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				err := fm.add(name, func() (*dataSyncService, error) {
+					atomic.AddInt32(&created, 1)
+					time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+					return newFakeDataSyncService(ctx, 1, &consumed), nil
+				})
+				assert.NoError(t, err)
+				time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+
+				fm.remove(name, func(s *dataSyncService) {
+					assert.False(t, isClosed(s))
+					atomic.AddInt32(&closed, 1)
+				})
+			}
+		}(name)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("adding and removing channels concurrently timeout")
+	}
+	stopLoad()
+	loadWg.Wait()
+
+	assert.Equal(t, int32(channelNum*rounds), atomic.LoadInt32(&created))
+	assert.Equal(t, int32(channelNum*rounds), atomic.LoadInt32(&closed))
+	assert.Equal(t, 0, fm.size())
+	assert.Greater(t, atomic.LoadInt64(&sent), int64(0))
+}
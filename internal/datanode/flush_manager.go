@@ -18,7 +18,6 @@ package datanode
 
 import (
 	"fmt"
-	"path"
 	"strconv"
 	"sync"
 
@@ -27,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 	"go.uber.org/zap"
 )
 
@@ -42,12 +42,19 @@ type flushManager interface {
 type segmentFlushPack struct {
 	segmentID  UniqueID
 	insertLogs map[UniqueID]string
+	insertStat *insertLogStat
 	statsLogs  map[UniqueID]string
 	deltaLogs  []*DelDataBuf
 	pos        *internalpb.MsgPosition
 	flushed    bool
 }
 
+// insertLogStat is the stats of the insert binlogs of a flush, reported to DataCoord with the binlog paths
+type insertLogStat struct {
+	rows     int64              // the number of rows in the binlog of each field
+	logSizes map[UniqueID]int64 // field id => size in bytes of its binlog
+}
+
 // notifyMetaFunc notify meta to persistent flush result
 type notifyMetaFunc func(*segmentFlushPack) error
 
@@ -137,8 +144,8 @@ func (q *orderFlushQueue) postTask(pack *segmentFlushPack, postInjection postInj
 }
 
 // enqueueInsertBuffer put insert buffer data into queue
-func (q *orderFlushQueue) enqueueInsertFlush(task flushInsertTask, binlogs, statslogs map[UniqueID]string, flushed bool, pos *internalpb.MsgPosition) {
-	q.getFlushTaskRunner(pos).runFlushInsert(task, binlogs, statslogs, flushed, pos)
+func (q *orderFlushQueue) enqueueInsertFlush(task flushInsertTask, binlogs, statslogs map[UniqueID]string, stat *insertLogStat, flushed bool, pos *internalpb.MsgPosition) {
+	q.getFlushTaskRunner(pos).runFlushInsert(task, binlogs, statslogs, stat, flushed, pos)
 }
 
 // enqueueDelBuffer put delete buffer data into queue
@@ -222,7 +229,7 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 	// empty flush
 	if data == nil || data.buffer == nil {
 		m.getFlushQueue(segmentID).enqueueInsertFlush(&flushBufferInsertTask{},
-			map[UniqueID]string{}, map[UniqueID]string{}, flushed, pos)
+			map[UniqueID]string{}, map[UniqueID]string{}, nil, flushed, pos)
 		return nil
 	}
 
@@ -233,6 +240,8 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 
 	// encode data and convert output data
 	inCodec := storage.NewInsertCodec(meta)
+	inCodec.Compression = Params.FlushCompression
+	inCodec.Checksum = Params.FlushChecksum
 
 	binLogs, statsBinlogs, err := inCodec.Serialize(partID, segmentID, data.buffer)
 	if err != nil {
@@ -245,6 +254,10 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 	}
 
 	field2Insert := make(map[UniqueID]string, len(binLogs))
+	stat := &insertLogStat{
+		rows:     data.size,
+		logSizes: make(map[UniqueID]int64, len(binLogs)),
+	}
 	kvs := make(map[string]string, len(binLogs))
 	paths := make([]string, 0, len(binLogs))
 	field2Logidx := make(map[UniqueID]UniqueID, len(binLogs))
@@ -257,14 +270,12 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 
 		logidx := start + int64(idx)
 
-		// no error raise if alloc=false
-		k, _ := m.genKey(false, collID, partID, segmentID, fieldID, logidx)
-
-		key := path.Join(Params.InsertBinlogRootPath, k)
+		key := pathutil.InsertBinlogPath(Params.MinioRootPath, collID, partID, segmentID, fieldID, logidx)
 		paths = append(paths, key)
 		kvs[key] = string(blob.Value[:])
 		field2Insert[fieldID] = key
 		field2Logidx[fieldID] = logidx
+		stat.logSizes[fieldID] = int64(len(blob.Value))
 	}
 
 	field2Stats := make(map[UniqueID]string)
@@ -278,19 +289,19 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 
 		logidx := field2Logidx[fieldID]
 
-		// no error raise if alloc=false
-		k, _ := m.genKey(false, collID, partID, segmentID, fieldID, logidx)
-
-		key := path.Join(Params.StatsBinlogRootPath, k)
+		key := pathutil.StatslogPath(Params.MinioRootPath, collID, partID, segmentID, fieldID, logidx)
 		kvs[key] = string(blob.Value[:])
 		field2Stats[fieldID] = key
 	}
 
 	m.updateSegmentCheckPoint(segmentID)
+	memorySize := kvsMemorySize(kvs)
+	addFlushingMemory(memorySize)
 	m.getFlushQueue(segmentID).enqueueInsertFlush(&flushBufferInsertTask{
-		BaseKV: m.BaseKV,
-		data:   kvs,
-	}, field2Insert, field2Stats, flushed, pos)
+		BaseKV:     m.BaseKV,
+		data:       kvs,
+		memorySize: memorySize,
+	}, field2Insert, field2Stats, stat, flushed, pos)
 	return nil
 }
 
@@ -310,6 +321,7 @@ func (m *rendezvousFlushManager) flushDelData(data *DelDataBuf, segmentID Unique
 	}
 
 	delCodec := storage.NewDeleteCodec()
+	delCodec.Checksum = Params.FlushChecksum
 
 	blob, err := delCodec.Serialize(collID, partID, segmentID, data.delData)
 	if err != nil {
@@ -322,16 +334,18 @@ func (m *rendezvousFlushManager) flushDelData(data *DelDataBuf, segmentID Unique
 		return err
 	}
 
-	blobKey, _ := m.genKey(false, collID, partID, segmentID, logID)
-	blobPath := path.Join(Params.DeleteBinlogRootPath, blobKey)
+	blobPath := pathutil.DeltalogPath(Params.MinioRootPath, collID, partID, segmentID, logID)
 	kvs := map[string]string{blobPath: string(blob.Value[:])}
 	data.fileSize = int64(len(blob.Value))
 	data.filePath = blobPath
 	log.Debug("delete blob path", zap.String("path", blobPath))
 
+	memorySize := kvsMemorySize(kvs)
+	addFlushingMemory(memorySize)
 	m.getFlushQueue(segmentID).enqueueDelFlush(&flushBufferDeleteTask{
-		BaseKV: m.BaseKV,
-		data:   kvs,
+		BaseKV:     m.BaseKV,
+		data:       kvs,
+		memorySize: memorySize,
 	}, data, pos)
 	return nil
 }
@@ -369,26 +383,36 @@ func (m *rendezvousFlushManager) getSegmentMeta(segmentID UniqueID, pos *interna
 type flushBufferInsertTask struct {
 	kv.BaseKV
 	data map[string]string
+	// memorySize is accounted as flushing memory until data is saved
+	memorySize int64
 }
 
 // flushInsertData implements flushInsertTask
 func (t *flushBufferInsertTask) flushInsertData() error {
 	if t.BaseKV != nil && len(t.data) > 0 {
-		return t.MultiSave(t.data)
+		if err := t.MultiSave(t.data); err != nil {
+			return err
+		}
 	}
+	addFlushingMemory(-t.memorySize)
 	return nil
 }
 
 type flushBufferDeleteTask struct {
 	kv.BaseKV
 	data map[string]string
+	// memorySize is accounted as flushing memory until data is saved
+	memorySize int64
 }
 
 // flushDeleteData implements flushDeleteTask
 func (t *flushBufferDeleteTask) flushDeleteData() error {
 	if len(t.data) > 0 && t.BaseKV != nil {
-		return t.MultiSave(t.data)
+		if err := t.MultiSave(t.data); err != nil {
+			return err
+		}
 	}
+	addFlushingMemory(-t.memorySize)
 	return nil
 }
 
@@ -68,7 +68,7 @@ func TestOrderFlushQueue_Execute(t *testing.T) {
 			wg.Done()
 		}(ids[i])
 		go func(id []byte) {
-			q.enqueueInsertFlush(&emptyFlushTask{}, map[UniqueID]string{}, map[UniqueID]string{}, false, &internalpb.MsgPosition{
+			q.enqueueInsertFlush(&emptyFlushTask{}, map[UniqueID]string{}, map[UniqueID]string{}, nil, false, &internalpb.MsgPosition{
 				MsgID: id,
 			})
 			wg.Done()
@@ -107,7 +107,7 @@ func TestOrderFlushQueue_Order(t *testing.T) {
 		q.enqueueDelFlush(&emptyFlushTask{}, &DelDataBuf{}, &internalpb.MsgPosition{
 			MsgID: ids[i],
 		})
-		q.enqueueInsertFlush(&emptyFlushTask{}, map[UniqueID]string{}, map[UniqueID]string{}, false, &internalpb.MsgPosition{
+		q.enqueueInsertFlush(&emptyFlushTask{}, map[UniqueID]string{}, map[UniqueID]string{}, nil, false, &internalpb.MsgPosition{
 			MsgID: ids[i],
 		})
 		wg.Done()
@@ -54,6 +54,7 @@ type flushTaskRunner struct {
 
 	segmentID  UniqueID
 	insertLogs map[UniqueID]string
+	insertStat *insertLogStat
 	statsLogs  map[UniqueID]string
 	deltaLogs  []*DelDataBuf
 	pos        *internalpb.MsgPosition
@@ -76,9 +77,10 @@ func (t *flushTaskRunner) init(f notifyMetaFunc, postFunc taskPostFunc, signal <
 }
 
 // runFlushInsert executei flush insert task with once and retry
-func (t *flushTaskRunner) runFlushInsert(task flushInsertTask, binlogs, statslogs map[UniqueID]string, flushed bool, pos *internalpb.MsgPosition) {
+func (t *flushTaskRunner) runFlushInsert(task flushInsertTask, binlogs, statslogs map[UniqueID]string, stat *insertLogStat, flushed bool, pos *internalpb.MsgPosition) {
 	t.insertOnce.Do(func() {
 		t.insertLogs = binlogs
+		t.insertStat = stat
 		t.statsLogs = statslogs
 		t.flushed = flushed
 		t.pos = pos
@@ -146,6 +148,7 @@ func (t *flushTaskRunner) getFlushPack() *segmentFlushPack {
 	pack := &segmentFlushPack{
 		segmentID:  t.segmentID,
 		insertLogs: t.insertLogs,
+		insertStat: t.insertStat,
 		statsLogs:  t.statsLogs,
 		pos:        t.pos,
 		deltaLogs:  t.deltaLogs,
@@ -0,0 +1,323 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/importutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+
+	"go.uber.org/zap"
+)
+
+// importFailedReasonKey is the key of the import failure not caused by a single file in ImportResult.Infos
+const importFailedReasonKey = "failed_reason"
+
+// importTask parses the files of a datapb.ImportTask, and writes the binlogs of segments directly,
+// the segments are reported to data coordinator as flushed segments
+type importTask struct {
+	req          *datapb.ImportTask
+	rootCoord    types.RootCoord
+	dataCoord    types.DataCoord
+	chunkManager storage.ChunkManager
+	io           uploader
+	allocator    allocatorInterface
+}
+
+func newImportTask(req *datapb.ImportTask, rc types.RootCoord, dc types.DataCoord, cm storage.ChunkManager, blobKv kv.BaseKV) *importTask {
+	alloc := newAllocator(rc)
+	return &importTask{
+		req:          req,
+		rootCoord:    rc,
+		dataCoord:    dc,
+		chunkManager: cm,
+		io:           &binlogIO{BaseKV: blobKv, allocatorInterface: alloc},
+		allocator:    alloc,
+	}
+}
+
+// execute runs the task and reports its result, the task is failed with per-file errors
+// if any file cannot be read or parsed
+func (t *importTask) execute(ctx context.Context) {
+	log.Info("start import task", zap.Int64("taskID", t.req.GetTaskID()),
+		zap.Int64("collectionID", t.req.GetCollectionID()), zap.Strings("files", t.req.GetFiles()))
+	t.report(ctx, &datapb.ImportResult{State: commonpb.ImportState_ImportStarted})
+
+	segments, rows, infos := t.run(ctx)
+	if len(infos) > 0 {
+		log.Warn("import task failed", zap.Int64("taskID", t.req.GetTaskID()), zap.Any("infos", infos))
+		t.report(ctx, &datapb.ImportResult{State: commonpb.ImportState_ImportFailed, Infos: infos})
+		return
+	}
+	log.Info("import task persisted", zap.Int64("taskID", t.req.GetTaskID()),
+		zap.Int64("rows", rows), zap.Int("segments", len(segments)))
+	t.report(ctx, &datapb.ImportResult{
+		State:    commonpb.ImportState_ImportPersisted,
+		Segments: segments,
+		RowCount: rows,
+	})
+}
+
+func (t *importTask) report(ctx context.Context, result *datapb.ImportResult) {
+	result.Base = &commonpb.MsgBase{
+		MsgType:  commonpb.MsgType_Import,
+		SourceID: Params.NodeID,
+	}
+	result.TaskID = t.req.GetTaskID()
+	status, err := t.dataCoord.ReportImport(ctx, result)
+	if err == nil && status.GetErrorCode() != commonpb.ErrorCode_Success {
+		err = errors.New(status.GetReason())
+	}
+	if err != nil {
+		log.Warn("failed to report import result", zap.Int64("taskID", t.req.GetTaskID()),
+			zap.String("state", result.GetState().String()), zap.Error(err))
+	}
+}
+
+func failedReason(err error) []*commonpb.KeyValuePair {
+	return []*commonpb.KeyValuePair{{Key: importFailedReasonKey, Value: err.Error()}}
+}
+
+// run imports the files, and returns the persisted segments and the number of rows,
+// or the errors of the failed files
+func (t *importTask) run(ctx context.Context) ([]*datapb.SegmentInfo, int64, []*commonpb.KeyValuePair) {
+	coll, err := t.describeCollection(ctx)
+	if err != nil {
+		return nil, 0, failedReason(err)
+	}
+	imp, err := importutil.NewImporter(coll.GetSchema())
+	if err != nil {
+		return nil, 0, failedReason(err)
+	}
+
+	var infos []*commonpb.KeyValuePair
+	for _, file := range t.req.GetFiles() {
+		data, err := t.chunkManager.Read(file)
+		if err == nil {
+			err = imp.ParseFile(file, data, t.req.GetRowBased())
+		}
+		if err != nil {
+			infos = append(infos, &commonpb.KeyValuePair{Key: file, Value: err.Error()})
+		}
+	}
+	if len(infos) > 0 {
+		return nil, 0, infos
+	}
+	rows, err := imp.RowCount()
+	if err != nil {
+		return nil, 0, failedReason(err)
+	}
+	if rows == 0 {
+		return nil, 0, failedReason(errors.New("no rows in the import files"))
+	}
+
+	segmentRows, err := importSegmentRows(coll.GetSchema())
+	if err != nil {
+		return nil, 0, failedReason(err)
+	}
+	ts, err := t.allocTimestamp(ctx)
+	if err != nil {
+		return nil, 0, failedReason(err)
+	}
+	meta := &etcdpb.CollectionMeta{ID: t.req.GetCollectionID(), Schema: coll.GetSchema()}
+	channels := coll.GetVirtualChannelNames()
+	segments := make([]*datapb.SegmentInfo, 0, (rows+segmentRows-1)/segmentRows)
+	for start := 0; start < rows; start += segmentRows {
+		end := start + segmentRows
+		if end > rows {
+			end = rows
+		}
+		var channel string
+		if len(channels) > 0 {
+			channel = channels[len(segments)%len(channels)]
+		}
+		segment, err := t.persistSegment(ctx, imp, meta, start, end, ts, channel)
+		if err != nil {
+			return nil, 0, failedReason(err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, int64(rows), nil
+}
+
+func (t *importTask) describeCollection(ctx context.Context) (*milvuspb.DescribeCollectionResponse, error) {
+	resp, err := t.rootCoord.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_DescribeCollection,
+			SourceID: Params.NodeID,
+		},
+		CollectionID: t.req.GetCollectionID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe collection %d: %w", t.req.GetCollectionID(), err)
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, fmt.Errorf("failed to describe collection %d: %s", t.req.GetCollectionID(), resp.GetStatus().GetReason())
+	}
+	return resp, nil
+}
+
+func (t *importTask) allocTimestamp(ctx context.Context) (Timestamp, error) {
+	resp, err := t.rootCoord.AllocTimestamp(ctx, &rootcoordpb.AllocTimestampRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_RequestTSO,
+			SourceID: Params.NodeID,
+		},
+		Count: 1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to alloc timestamp: %w", err)
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return 0, fmt.Errorf("failed to alloc timestamp: %s", resp.GetStatus().GetReason())
+	}
+	return resp.GetTimestamp(), nil
+}
+
+// importSegmentRows returns the max number of rows in a segment generated by import
+func importSegmentRows(schema *schemapb.CollectionSchema) (int, error) {
+	size, err := typeutil.EstimateSizePerRecord(schema)
+	if err != nil {
+		return 0, err
+	}
+	rows := 1
+	if size > 0 && Params.ImportSegmentSize/int64(size) > 1 {
+		rows = int(Params.ImportSegmentSize / int64(size))
+	}
+	return rows, nil
+}
+
+// persistSegment writes the binlogs of rows [start, end) as a new segment
+func (t *importTask) persistSegment(ctx context.Context, imp *importutil.Importer, meta *etcdpb.CollectionMeta,
+	start, end int, ts Timestamp, channel string) (*datapb.SegmentInfo, error) {
+	fieldsData, err := imp.FieldsData(start, end)
+	if err != nil {
+		return nil, err
+	}
+	segmentID, err := t.allocator.allocID()
+	if err != nil {
+		return nil, err
+	}
+	rows := end - start
+	rowIDStart, _, err := t.allocator.allocIDBatch(uint32(rows))
+	if err != nil {
+		return nil, err
+	}
+	iData, err := genImportInsertData(meta.GetSchema(), fieldsData, rowIDStart, ts, rows)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := t.io.upload(ctx, segmentID, t.req.GetPartitionID(), iData, nil, meta)
+	if err != nil {
+		return nil, err
+	}
+	return &datapb.SegmentInfo{
+		ID:             segmentID,
+		CollectionID:   t.req.GetCollectionID(),
+		PartitionID:    t.req.GetPartitionID(),
+		InsertChannel:  channel,
+		NumOfRows:      int64(rows),
+		State:          commonpb.SegmentState_Flushed,
+		MaxRowNum:      int64(rows),
+		LastExpireTime: ts,
+		Binlogs:        paths.inPaths,
+		Statslogs:      paths.statsPaths,
+	}, nil
+}
+
+// genImportInsertData converts the imported fields data to InsertData, the row ids are allocated
+// from rowIDStart, which are the primary keys as well if the primary key is auto id
+func genImportInsertData(schema *schemapb.CollectionSchema, fieldsData []*schemapb.FieldData,
+	rowIDStart UniqueID, ts Timestamp, rows int) (*InsertData, error) {
+	numRows := []int64{int64(rows)}
+	rowIDs := make([]int64, rows)
+	timestamps := make([]int64, rows)
+	for i := range rowIDs {
+		rowIDs[i] = rowIDStart + int64(i)
+		timestamps[i] = int64(ts)
+	}
+	iData := &InsertData{Data: make(map[storage.FieldID]storage.FieldData)}
+	iData.Data[common.RowIDField] = &storage.Int64FieldData{NumRows: numRows, Data: rowIDs}
+	iData.Data[common.TimeStampField] = &storage.Int64FieldData{NumRows: numRows, Data: timestamps}
+	for _, field := range schema.GetFields() {
+		if field.GetIsPrimaryKey() && field.GetAutoID() {
+			iData.Data[field.GetFieldID()] = &storage.Int64FieldData{NumRows: numRows, Data: rowIDs}
+		}
+	}
+
+	for _, fd := range fieldsData {
+		var data storage.FieldData
+		switch fd.GetType() {
+		case schemapb.DataType_Bool:
+			data = &storage.BoolFieldData{NumRows: numRows, Data: fd.GetScalars().GetBoolData().GetData()}
+		case schemapb.DataType_Int8:
+			values := fd.GetScalars().GetIntData().GetData()
+			int8s := make([]int8, len(values))
+			for i, v := range values {
+				int8s[i] = int8(v)
+			}
+			data = &storage.Int8FieldData{NumRows: numRows, Data: int8s}
+		case schemapb.DataType_Int16:
+			values := fd.GetScalars().GetIntData().GetData()
+			int16s := make([]int16, len(values))
+			for i, v := range values {
+				int16s[i] = int16(v)
+			}
+			data = &storage.Int16FieldData{NumRows: numRows, Data: int16s}
+		case schemapb.DataType_Int32:
+			data = &storage.Int32FieldData{NumRows: numRows, Data: fd.GetScalars().GetIntData().GetData()}
+		case schemapb.DataType_Int64:
+			data = &storage.Int64FieldData{NumRows: numRows, Data: fd.GetScalars().GetLongData().GetData()}
+		case schemapb.DataType_Float:
+			data = &storage.FloatFieldData{NumRows: numRows, Data: fd.GetScalars().GetFloatData().GetData()}
+		case schemapb.DataType_Double:
+			data = &storage.DoubleFieldData{NumRows: numRows, Data: fd.GetScalars().GetDoubleData().GetData()}
+		case schemapb.DataType_String:
+			data = &storage.StringFieldData{NumRows: numRows, Data: fd.GetScalars().GetStringData().GetData()}
+		case schemapb.DataType_FloatVector:
+			data = &storage.FloatVectorFieldData{
+				NumRows: numRows,
+				Data:    fd.GetVectors().GetFloatVector().GetData(),
+				Dim:     int(fd.GetVectors().GetDim()),
+			}
+		case schemapb.DataType_BinaryVector:
+			data = &storage.BinaryVectorFieldData{
+				NumRows: numRows,
+				Data:    fd.GetVectors().GetBinaryVector(),
+				Dim:     int(fd.GetVectors().GetDim()),
+			}
+		default:
+			return nil, fmt.Errorf("field %s has unsupported data type %s", fd.GetFieldName(), fd.GetType())
+		}
+		iData.Data[fd.GetFieldId()] = data
+	}
+	return iData, nil
+}
@@ -0,0 +1,255 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// importDataCoord collects the import results reported by datanode
+type importDataCoord struct {
+	types.DataCoord
+
+	mu      sync.Mutex
+	results []*datapb.ImportResult
+}
+
+func (dc *importDataCoord) ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.results = append(dc.results, req)
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+}
+
+// waitFinalResult waits for the persisted or failed result of the import task
+func (dc *importDataCoord) waitFinalResult(t *testing.T) *datapb.ImportResult {
+	var result *datapb.ImportResult
+	require.Eventually(t, func() bool {
+		dc.mu.Lock()
+		defer dc.mu.Unlock()
+		for _, r := range dc.results {
+			if r.GetState() == commonpb.ImportState_ImportPersisted || r.GetState() == commonpb.ImportState_ImportFailed {
+				result = r
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 10*time.Millisecond)
+	return result
+}
+
+// importRootCoord returns the vchannels of the collection
+type importRootCoord struct {
+	*RootCoordFactory
+	channels []string
+}
+
+func (rc *importRootCoord) DescribeCollection(ctx context.Context, in *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error) {
+	resp, err := rc.RootCoordFactory.DescribeCollection(ctx, in)
+	if resp != nil {
+		resp.VirtualChannelNames = rc.channels
+	}
+	return resp, err
+}
+
+func genImportRowsJSON(rows int) string {
+	var sb strings.Builder
+	sb.WriteString(`{"rows": [`)
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"float_vector_field": [%d, 0.5], "binary_vector_field": [1, 2, 3, %d], "bool_field": true,
+			"int8_field": 8, "int16_field": 16, "int32_field": 32, "int64_field": %d, "float32_field": 1.5, "float64_field": 2.5}`,
+			i, i, 1000+i)
+	}
+	sb.WriteString("]}")
+	return sb.String()
+}
+
+func newImportDataNode(t *testing.T, ctx context.Context, dir string) (*DataNode, *importDataCoord, *memkv.MemoryKV) {
+	node := newIDLEDataNodeMock(ctx)
+	node.rootCoord = &importRootCoord{
+		RootCoordFactory: &RootCoordFactory{ID: 100, collectionID: 1, collectionName: "collection-1"},
+		channels:         []string{"import-vchan-0", "import-vchan-1"},
+	}
+	dc := &importDataCoord{}
+	node.dataCoord = dc
+	blobKv := memkv.NewMemoryKV()
+	node.importStorage = func(ctx context.Context) (storage.ChunkManager, kv.BaseKV, error) {
+		return storage.NewLocalChunkManager(dir), blobKv, nil
+	}
+	node.UpdateStateCode(internalpb.StateCode_Healthy)
+	return node, dc, blobKv
+}
+
+func TestDataNodeImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	cm := storage.NewLocalChunkManager(dir)
+	require.NoError(t, cm.Write("import/rows.json", []byte(genImportRowsJSON(10))))
+
+	t.Run("import json rows", func(t *testing.T) {
+		node, dc, blobKv := newImportDataNode(t, ctx, dir)
+		status, err := node.Import(ctx, &datapb.ImportTask{
+			TaskID:       1,
+			CollectionID: 1,
+			PartitionID:  2,
+			RowBased:     true,
+			Files:        []string{"import/rows.json"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		result := dc.waitFinalResult(t)
+		assert.Equal(t, commonpb.ImportState_ImportStarted, dc.results[0].GetState())
+		require.Equal(t, commonpb.ImportState_ImportPersisted, result.GetState(), result.GetInfos())
+		assert.Equal(t, int64(1), result.GetTaskID())
+		assert.Equal(t, int64(10), result.GetRowCount())
+		require.Equal(t, 1, len(result.GetSegments()))
+
+		segment := result.GetSegments()[0]
+		assert.Equal(t, int64(1), segment.GetCollectionID())
+		assert.Equal(t, int64(2), segment.GetPartitionID())
+		assert.Equal(t, "import-vchan-0", segment.GetInsertChannel())
+		assert.Equal(t, int64(10), segment.GetNumOfRows())
+		assert.Equal(t, commonpb.SegmentState_Flushed, segment.GetState())
+		// RowID, Timestamp and 9 user fields
+		assert.Equal(t, 11, len(segment.GetBinlogs()))
+		assert.NotEmpty(t, segment.GetStatslogs())
+
+		// the binlogs are readable by the insert codec
+		var keys []string
+		for _, binlog := range segment.GetBinlogs() {
+			keys = append(keys, binlog.GetBinlogs()...)
+		}
+		values, err := blobKv.MultiLoad(keys)
+		require.NoError(t, err)
+		blobs := make([]*Blob, 0, len(values))
+		for i, value := range values {
+			blobs = append(blobs, &Blob{Key: fmt.Sprint(segment.GetBinlogs()[i].GetFieldID()), Value: []byte(value)})
+		}
+		_, _, iData, err := storage.NewInsertCodec(nil).Deserialize(blobs)
+		require.NoError(t, err)
+		pks := iData.Data[106].(*storage.Int64FieldData).Data
+		require.Equal(t, 10, len(pks))
+		assert.Equal(t, int64(1000), pks[0])
+		assert.Equal(t, []float32{9, 0.5}, iData.Data[100].(*storage.FloatVectorFieldData).Data[18:])
+	})
+
+	t.Run("split into segments", func(t *testing.T) {
+		size := Params.ImportSegmentSize
+		defer func() { Params.ImportSegmentSize = size }()
+		// 4 rows in a segment
+		f := &MetaFactory{}
+		recordSize, err := typeutil.EstimateSizePerRecord(f.GetCollectionMeta(1, "collection-1").GetSchema())
+		require.NoError(t, err)
+		Params.ImportSegmentSize = int64(4 * recordSize)
+
+		node, dc, _ := newImportDataNode(t, ctx, dir)
+		status, err := node.Import(ctx, &datapb.ImportTask{
+			TaskID:       2,
+			CollectionID: 1,
+			RowBased:     true,
+			Files:        []string{"import/rows.json"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		result := dc.waitFinalResult(t)
+		require.Equal(t, commonpb.ImportState_ImportPersisted, result.GetState(), result.GetInfos())
+		require.Equal(t, 3, len(result.GetSegments()))
+		assert.Equal(t, []int64{4, 4, 2}, []int64{
+			result.GetSegments()[0].GetNumOfRows(),
+			result.GetSegments()[1].GetNumOfRows(),
+			result.GetSegments()[2].GetNumOfRows(),
+		})
+		// the segments are distributed to the vchannels
+		assert.Equal(t, "import-vchan-1", result.GetSegments()[1].GetInsertChannel())
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		require.NoError(t, cm.Write("import/invalid.json", []byte(`{"rows": [{"int64_field": 1}]}`)))
+		node, dc, _ := newImportDataNode(t, ctx, dir)
+		status, err := node.Import(ctx, &datapb.ImportTask{
+			TaskID:       3,
+			CollectionID: 1,
+			RowBased:     true,
+			Files:        []string{"import/rows.json", "import/invalid.json", "import/missing.json"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		result := dc.waitFinalResult(t)
+		require.Equal(t, commonpb.ImportState_ImportFailed, result.GetState())
+		assert.Empty(t, result.GetSegments())
+		infos := make(map[string]string)
+		for _, info := range result.GetInfos() {
+			infos[info.GetKey()] = info.GetValue()
+		}
+		assert.Equal(t, 2, len(infos))
+		assert.Contains(t, infos, "import/invalid.json")
+		assert.Contains(t, infos, "import/missing.json")
+	})
+
+	t.Run("collection not found", func(t *testing.T) {
+		node, dc, _ := newImportDataNode(t, ctx, dir)
+		node.rootCoord.(*importRootCoord).setCollectionID(-2)
+		status, err := node.Import(ctx, &datapb.ImportTask{
+			TaskID: 4,
+			Files:  []string{"import/rows.json"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		result := dc.waitFinalResult(t)
+		require.Equal(t, commonpb.ImportState_ImportFailed, result.GetState())
+		require.Equal(t, 1, len(result.GetInfos()))
+		assert.Equal(t, importFailedReasonKey, result.GetInfos()[0].GetKey())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		node, _, _ := newImportDataNode(t, ctx, dir)
+		status, err := node.Import(ctx, &datapb.ImportTask{TaskID: 5})
+		assert.NoError(t, err)
+		assert.NotEqual(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		status, err = node.Import(ctx, &datapb.ImportTask{TaskID: 5, Files: []string{"import/rows.json"}})
+		assert.NoError(t, err)
+		assert.NotEqual(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+	})
+}
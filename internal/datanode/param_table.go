@@ -23,6 +23,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 )
 
@@ -37,13 +40,26 @@ type ParamTable struct {
 	IP string
 
 	// Port of the current DataNode
-	Port                    int
+	Port int
+	// MetricsPort is the port serving the metrics of the current DataNode, 0 means not serving
+	MetricsPort int
+
 	FlowGraphMaxQueueLength int32
 	FlowGraphMaxParallelism int32
+	DeadLetter              msgstream.DeadLetterPolicy
+	// FlowGraphProfileEnabled enables the metrics of the flowgraph nodes, the nodes stalled for
+	// FlowGraphStallThreshold are logged, 0 means not watching for the stalls
+	FlowGraphProfileEnabled bool
+	FlowGraphStallThreshold time.Duration
 	FlushInsertBufferSize   int64
-	InsertBinlogRootPath    string
-	StatsBinlogRootPath     string
-	DeleteBinlogRootPath    string
+	FlushSyncPolicies       []string
+	FlushSyncBufferSize     int64
+	FlushSyncPeriod         time.Duration
+	FlushMemoryWatermark    int64
+	FlushCompression        storage.CompressionType
+	FlushChecksum           bool
+	MemoryHighWatermark     int64
+	ImportSegmentSize       int64
 	Alias                   string // Different datanode in one machine
 
 	// Pulsar address
@@ -76,6 +92,12 @@ type ParamTable struct {
 	MinioSecretAccessKey string
 	MinioUseSSL          bool
 	MinioBucketName      string
+	MinioRootPath        string // the root path of the binlogs, see pathutil for the layout under it
+
+	StorageType             string
+	LocalStoragePath        string
+	LocalStorageSyncOnWrite bool
+	StorageChecksumWarnOnly bool
 
 	CreatedTime time.Time
 	UpdatedTime time.Time
@@ -105,12 +127,21 @@ func (p *ParamTable) Init() {
 		panic(err)
 	}
 
+	p.initMetricsPort()
 	p.initFlowGraphMaxQueueLength()
 	p.initFlowGraphMaxParallelism()
+	p.initFlowGraphProfile()
+	p.initDeadLetter()
 	p.initFlushInsertBufferSize()
-	p.initInsertBinlogRootPath()
-	p.initStatsBinlogRootPath()
-	p.initDeleteBinlogRootPath()
+	p.initFlushSyncPolicies()
+	p.initFlushSyncBufferSize()
+	p.initFlushSyncPeriod()
+	p.initFlushMemoryWatermark()
+	p.initFlushCompression()
+	p.initFlushChecksum()
+	p.initMemoryHighWatermark()
+	p.initImportSegmentSize()
+	p.initMinioRootPath()
 
 	p.initPulsarAddress()
 	p.initRocksmqPath()
@@ -129,6 +160,10 @@ func (p *ParamTable) Init() {
 	p.initMinioSecretAccessKey()
 	p.initMinioUseSSL()
 	p.initMinioBucketName()
+	p.initStorageType()
+	p.initLocalStoragePath()
+	p.initLocalStorageSyncOnWrite()
+	p.initStorageChecksumWarnOnly()
 
 	p.initRoleName()
 }
@@ -141,33 +176,102 @@ func (p *ParamTable) initFlowGraphMaxParallelism() {
 	p.FlowGraphMaxParallelism = p.ParseInt32("dataNode.dataSync.flowGraph.maxParallelism")
 }
 
+func (p *ParamTable) initFlowGraphProfile() {
+	p.FlowGraphProfileEnabled = p.ParseBool("dataNode.dataSync.flowGraph.profile.enabled", false)
+	threshold, err := p.LoadWithDefault("dataNode.dataSync.flowGraph.profile.stallThreshold", "60")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(threshold, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.FlowGraphStallThreshold = time.Duration(seconds) * time.Second
+}
+
+// initDeadLetter initializes the policy of the messages failing to unmarshal in dm channels, disabled if not configured.
+func (p *ParamTable) initDeadLetter() {
+	attempts, err := p.LoadWithDefault("dataNode.dataSync.deadLetter.maxUnmarshalAttempts", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.DeadLetter.MaxUnmarshalAttempts, err = strconv.Atoi(attempts)
+	if err != nil {
+		panic(err)
+	}
+	p.DeadLetter.ChannelPrefix, err = p.LoadWithDefault("dataNode.dataSync.deadLetter.channelPrefix", "dead-letter-")
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (p *ParamTable) initFlushInsertBufferSize() {
 	p.FlushInsertBufferSize = p.ParseInt64("_DATANODE_INSERTBUFSIZE")
 }
 
-func (p *ParamTable) initInsertBinlogRootPath() {
-	// GOOSE TODO: rootPath change to  TenentID
-	rootPath, err := p.Load("minio.rootPath")
+func (p *ParamTable) initFlushSyncPolicies() {
+	policies, err := p.LoadWithDefault("dataNode.flush.syncPolicies", "full,size,age,memory")
 	if err != nil {
 		panic(err)
 	}
-	p.InsertBinlogRootPath = path.Join(rootPath, "insert_log")
+	p.FlushSyncPolicies = make([]string, 0)
+	for _, policy := range strings.Split(policies, ",") {
+		if policy = strings.TrimSpace(policy); policy != "" {
+			p.FlushSyncPolicies = append(p.FlushSyncPolicies, policy)
+		}
+	}
 }
 
-func (p *ParamTable) initStatsBinlogRootPath() {
-	rootPath, err := p.Load("minio.rootPath")
+func (p *ParamTable) initFlushSyncBufferSize() {
+	p.FlushSyncBufferSize = p.ParseInt64("dataNode.flush.syncBufferSize")
+}
+
+func (p *ParamTable) initFlushSyncPeriod() {
+	p.FlushSyncPeriod = time.Duration(p.ParseInt64("dataNode.flush.syncPeriod")) * time.Second
+}
+
+func (p *ParamTable) initFlushMemoryWatermark() {
+	p.FlushMemoryWatermark = p.ParseInt64("dataNode.flush.memoryWatermark")
+}
+
+func (p *ParamTable) initFlushCompression() {
+	name, err := p.LoadWithDefault("dataNode.flush.compression", string(storage.CompressionNone))
+	if err != nil {
+		panic(err)
+	}
+	p.FlushCompression, err = storage.ParseCompressionType(name)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initFlushChecksum initializes whether to append the checksums to the events of binlogs flushed.
+func (p *ParamTable) initFlushChecksum() {
+	ret, err := p.LoadWithDefault("dataNode.flush.checksum", "true")
+	if err != nil {
+		panic(err)
+	}
+	p.FlushChecksum, err = strconv.ParseBool(ret)
 	if err != nil {
 		panic(err)
 	}
-	p.StatsBinlogRootPath = path.Join(rootPath, "stats_log")
 }
 
-func (p *ParamTable) initDeleteBinlogRootPath() {
+func (p *ParamTable) initMemoryHighWatermark() {
+	p.MemoryHighWatermark = p.ParseInt64("dataNode.memory.highWatermark")
+}
+
+func (p *ParamTable) initImportSegmentSize() {
+	p.ImportSegmentSize = p.ParseInt64("dataNode.import.segmentSize")
+}
+
+func (p *ParamTable) initMinioRootPath() {
+	// GOOSE TODO: rootPath change to  TenentID
 	rootPath, err := p.Load("minio.rootPath")
 	if err != nil {
 		panic(err)
 	}
-	p.DeleteBinlogRootPath = path.Join(rootPath, "delta_log")
+	p.MinioRootPath = rootPath
 }
 
 func (p *ParamTable) initPulsarAddress() {
@@ -191,6 +295,9 @@ func (p *ParamTable) initClusterMsgChannelPrefix() {
 	if err != nil {
 		panic(err)
 	}
+	if err = channelutil.ValidatePrefix(name); err != nil {
+		panic(err)
+	}
 	p.ClusterChannelPrefix = name
 }
 
@@ -199,8 +306,7 @@ func (p *ParamTable) initSegmentStatisticsChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.SegmentStatisticsChannelName = strings.Join(s, "-")
+	p.SegmentStatisticsChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initTimeTickChannelName() {
@@ -208,8 +314,7 @@ func (p *ParamTable) initTimeTickChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.TimeTickChannelName = strings.Join(s, "-")
+	p.TimeTickChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initMsgChannelSubName() {
@@ -217,8 +322,7 @@ func (p *ParamTable) initMsgChannelSubName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config, strconv.FormatInt(p.NodeID, 10)}
-	p.MsgChannelSubName = strings.Join(s, "-")
+	p.MsgChannelSubName = channelutil.JoinName(p.ClusterChannelPrefix, config, strconv.FormatInt(p.NodeID, 10))
 }
 
 func (p *ParamTable) initEtcdEndpoints() {
@@ -286,6 +390,61 @@ func (p *ParamTable) initMinioBucketName() {
 	p.MinioBucketName = bucketName
 }
 
+// initStorageType initializes the type of the storage of binlogs and index files, minio or local.
+func (p *ParamTable) initStorageType() {
+	ret, err := p.LoadWithDefault("storage.type", "minio")
+	if err != nil {
+		panic(err)
+	}
+	p.StorageType = ret
+}
+
+// initLocalStoragePath initializes the root path of the files when the storage type is local.
+func (p *ParamTable) initLocalStoragePath() {
+	ret, err := p.LoadWithDefault("storage.path", "/var/lib/milvus/storage")
+	if err != nil {
+		panic(err)
+	}
+	p.LocalStoragePath = ret
+}
+
+// initLocalStorageSyncOnWrite initializes whether to fsync the files before the writes return when the storage type is local.
+func (p *ParamTable) initLocalStorageSyncOnWrite() {
+	ret, err := p.LoadWithDefault("storage.syncOnWrite", "false")
+	if err != nil {
+		panic(err)
+	}
+	p.LocalStorageSyncOnWrite, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initStorageChecksumWarnOnly initializes whether the checksum mismatches of the files read are warnings only.
+func (p *ParamTable) initStorageChecksumWarnOnly() {
+	ret, err := p.LoadWithDefault("storage.checksumWarnOnly", "false")
+	if err != nil {
+		panic(err)
+	}
+	p.StorageChecksumWarnOnly, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (p *ParamTable) initRoleName() {
 	p.RoleName = "datanode"
 }
+
+// initMetricsPort initializes the port serving the metrics of the node, 0 means the metrics are only served on the
+// metrics port of the process which nodes sharing a host can't listen on together.
+func (p *ParamTable) initMetricsPort() {
+	port, err := p.LoadWithDefault("dataNode.metricsPort", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.MetricsPort, err = strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -23,6 +23,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 )
 
 func TestParamTable(t *testing.T) {
@@ -50,14 +53,66 @@ func TestParamTable(t *testing.T) {
 		log.Println("flowGraphMaxParallelism:", maxParallelism)
 	})
 
+	t.Run("Test FlowGraphProfile", func(t *testing.T) {
+		assert.False(t, Params.FlowGraphProfileEnabled)
+		assert.Equal(t, 60*time.Second, Params.FlowGraphStallThreshold)
+	})
+
 	t.Run("Test FlushInsertBufSize", func(t *testing.T) {
 		size := Params.FlushInsertBufferSize
 		log.Println("FlushInsertBufferSize:", size)
 	})
 
-	t.Run("Test InsertBinlogRootPath", func(t *testing.T) {
-		path := Params.InsertBinlogRootPath
-		log.Println("InsertBinlogRootPath:", path)
+	t.Run("Test FlushSyncPolicies", func(t *testing.T) {
+		policies := Params.FlushSyncPolicies
+		assert.Equal(t, []string{"full", "size", "age", "memory"}, policies)
+		log.Println("FlushSyncPolicies:", policies)
+	})
+
+	t.Run("Test FlushSyncBufferSize", func(t *testing.T) {
+		size := Params.FlushSyncBufferSize
+		log.Println("FlushSyncBufferSize:", size)
+	})
+
+	t.Run("Test FlushSyncPeriod", func(t *testing.T) {
+		period := Params.FlushSyncPeriod
+		log.Println("FlushSyncPeriod:", period)
+	})
+
+	t.Run("Test FlushMemoryWatermark", func(t *testing.T) {
+		watermark := Params.FlushMemoryWatermark
+		log.Println("FlushMemoryWatermark:", watermark)
+	})
+
+	t.Run("Test MemoryHighWatermark", func(t *testing.T) {
+		watermark := Params.MemoryHighWatermark
+		log.Println("MemoryHighWatermark:", watermark)
+	})
+
+	t.Run("Test ImportSegmentSize", func(t *testing.T) {
+		size := Params.ImportSegmentSize
+		assert.Equal(t, int64(512*1024*1024), size)
+		log.Println("ImportSegmentSize:", size)
+	})
+
+	t.Run("Test FlushCompression", func(t *testing.T) {
+		compression := Params.FlushCompression
+		assert.Equal(t, storage.CompressionNone, compression)
+		log.Println("FlushCompression:", compression)
+	})
+
+	t.Run("Test FlushChecksum", func(t *testing.T) {
+		assert.True(t, Params.FlushChecksum)
+	})
+
+	t.Run("Test DeadLetter", func(t *testing.T) {
+		assert.Equal(t, 3, Params.DeadLetter.MaxUnmarshalAttempts)
+		assert.Equal(t, "dead-letter-", Params.DeadLetter.ChannelPrefix)
+	})
+
+	t.Run("Test MinioRootPath", func(t *testing.T) {
+		path := Params.MinioRootPath
+		log.Println("MinioRootPath:", path)
 	})
 
 	t.Run("Test PulsarAddress", func(t *testing.T) {
@@ -119,6 +174,13 @@ func TestParamTable(t *testing.T) {
 		log.Println("MinioBucketName:", name)
 	})
 
+	t.Run("Test StorageType", func(t *testing.T) {
+		assert.Equal(t, "minio", Params.StorageType)
+		log.Println("StorageType:", Params.StorageType, "LocalStoragePath:", Params.LocalStoragePath)
+		assert.False(t, Params.LocalStorageSyncOnWrite)
+		assert.False(t, Params.StorageChecksumWarnOnly)
+	})
+
 	t.Run("Test CreatedTime", func(t *testing.T) {
 		Params.CreatedTime = time.Now()
 		log.Println("CreatedTime: ", Params.CreatedTime)
@@ -129,14 +191,10 @@ func TestParamTable(t *testing.T) {
 		log.Println("UpdatedTime: ", Params.UpdatedTime)
 	})
 
-	t.Run("Test InsertBinlogRootPath", func(t *testing.T) {
+	t.Run("Test MinioRootPath", func(t *testing.T) {
 		Params.Init()
-		assert.Equal(t, path.Join("files", "insert_log"), Params.InsertBinlogRootPath)
-	})
-
-	t.Run("Test StatsBinlogRootPath", func(t *testing.T) {
-		p := new(ParamTable)
-		p.Init()
-		assert.Equal(t, path.Join("files", "stats_log"), Params.StatsBinlogRootPath)
+		assert.Equal(t, "files", Params.MinioRootPath)
+		assert.Equal(t, path.Join("files", "insert_log"), pathutil.InsertLogRootPath(Params.MinioRootPath))
+		assert.Equal(t, path.Join("files", "stats_log"), pathutil.StatsLogRootPath(Params.MinioRootPath))
 	})
 }
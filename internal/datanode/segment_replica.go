@@ -29,7 +29,6 @@ import (
 	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
-	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
@@ -81,7 +80,9 @@ type Segment struct {
 	startPos   *internalpb.MsgPosition // TODO readonly
 	endPos     *internalpb.MsgPosition
 
-	pkFilter *bloom.BloomFilter //  bloom filter of pk inside a segment
+	pkFilter *bloom.BloomFilter //  bloom filter of pk inserted into the segment in datanode
+	// bloom filters of pk loaded from statslogs, they're sized by the rows of each binlog and can't be merged
+	statsFilters []*bloom.BloomFilter
 	// TODO silverxia, needs to change to interface to support `string` type PK
 	minPK int64 //	minimal pk value, shortcut for checking whether a pk is inside this segment
 	maxPK int64 //  maximal pk value, same above
@@ -102,10 +103,12 @@ type SegmentReplica struct {
 	minIOKV     kv.BaseKV
 }
 
+// updatePKRange adds pks into the pk statistics of the segment,
+// pk is encoded in the same way as the statslog, so that the filters loaded from statslogs are checked alike.
 func (s *Segment) updatePKRange(pks []int64) {
 	buf := make([]byte, 8)
 	for _, pk := range pks {
-		binary.BigEndian.PutUint64(buf, uint64(pk))
+		binary.LittleEndian.PutUint64(buf, uint64(pk))
 		s.pkFilter.Add(buf)
 		if pk > s.maxPK {
 			s.maxPK = pk
@@ -116,20 +119,28 @@ func (s *Segment) updatePKRange(pks []int64) {
 	}
 }
 
+// mayContainPK returns whether pk may be inside the segment, false positive is possible while false negative is not
+func (s *Segment) mayContainPK(pk int64, buf []byte) bool {
+	// pk range is checked first, it's precise while bloom filter has false positive
+	if pk < s.minPK || pk > s.maxPK {
+		return false
+	}
+	binary.LittleEndian.PutUint64(buf, uint64(pk))
+	if s.pkFilter.Test(buf) {
+		return true
+	}
+	for _, filter := range s.statsFilters {
+		if filter.Test(buf) {
+			return true
+		}
+	}
+	return false
+}
+
 var _ Replica = &SegmentReplica{}
 
 func newReplica(ctx context.Context, rc types.RootCoord, collID UniqueID) (*SegmentReplica, error) {
-	// MinIO
-	option := &miniokv.Option{
-		Address:           Params.MinioAddress,
-		AccessKeyID:       Params.MinioAccessKeyID,
-		SecretAccessKeyID: Params.MinioSecretAccessKey,
-		UseSSL:            Params.MinioUseSSL,
-		CreateBucket:      true,
-		BucketName:        Params.MinioBucketName,
-	}
-
-	minIOKV, err := miniokv.NewMinIOKV(ctx, option)
+	minIOKV, err := newChunkManagerFactory().NewDataKV(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -417,10 +428,10 @@ func (replica *SegmentReplica) initPKBloomFilter(s *Segment, statsBinlogs []*dat
 		return err
 	}
 	for _, stat := range stats {
-		err = s.pkFilter.Merge(stat.BF)
-		if err != nil {
-			return err
+		if stat.BF == nil {
+			return fmt.Errorf("nil bloom filter in pk statslog, segmentID = %d", s.segmentID)
 		}
+		s.statsFilters = append(s.statsFilters, stat.BF)
 		if s.minPK > stat.Min {
 			s.minPK = stat.Min
 		}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
@@ -67,7 +68,7 @@ func (kv *mockPkfilterMergeError) MultiLoad(keys []string) ([]string, error) {
 		FieldID: common.RowIDField,
 		Min:     0,
 		Max:     10,
-		BF:      bloom.NewWithEstimates(1, 0.0001),
+		BF:      nil,
 	}
 	buffer, _ := json.Marshal(stats)
 	return []string{string(buffer)}, nil
@@ -683,7 +684,7 @@ func TestSegmentReplica_UpdatePKRange(t *testing.T) {
 		assert.LessOrEqual(t, seg.minPK, c)
 		assert.GreaterOrEqual(t, seg.maxPK, c)
 
-		binary.BigEndian.PutUint64(buf, uint64(c))
+		binary.LittleEndian.PutUint64(buf, uint64(c))
 		assert.True(t, seg.pkFilter.Test(buf))
 	}
 }
@@ -725,10 +726,52 @@ func TestReplica_UpdatePKRange(t *testing.T) {
 		assert.LessOrEqual(t, segNormal.minPK, c)
 		assert.GreaterOrEqual(t, segNormal.maxPK, c)
 
-		binary.BigEndian.PutUint64(buf, uint64(c))
+		binary.LittleEndian.PutUint64(buf, uint64(c))
 		assert.True(t, segNew.pkFilter.Test(buf))
 		assert.True(t, segNormal.pkFilter.Test(buf))
 
 	}
 
 }
+
+func TestReplica_LoadPKStats(t *testing.T) {
+	rc := &RootCoordFactory{}
+	replica, err := newReplica(context.Background(), rc, 1)
+	require.NoError(t, err)
+	kv := memkv.NewMemoryKV()
+	replica.minIOKV = kv
+
+	// the statslogs of binlogs with different rows, their bloom filters are sized differently
+	pks := make([]int64, 0)
+	statslogs := &datapb.FieldBinlog{FieldID: 106}
+	for i, rows := range []int{10, 2000, 50000} {
+		binlogPKs := make([]int64, 0, rows)
+		for j := 0; j < rows; j++ {
+			binlogPKs = append(binlogPKs, rand.Int63n(math.MaxInt32))
+		}
+		sw := &storage.StatsWriter{}
+		require.NoError(t, sw.StatsInt64(106, true, binlogPKs))
+		key := fmt.Sprintf("statslog/%d", i)
+		require.NoError(t, kv.Save(key, string(sw.GetBuffer())))
+		statslogs.Binlogs = append(statslogs.Binlogs, key)
+		pks = append(pks, binlogPKs...)
+	}
+
+	err = replica.addFlushedSegment(1, 1, 2, "insert-01", int64(len(pks)), []*datapb.FieldBinlog{statslogs})
+	require.NoError(t, err)
+	seg := replica.flushedSegments[1]
+	require.Equal(t, 3, len(seg.statsFilters))
+	assert.Less(t, seg.statsFilters[0].Cap(), seg.statsFilters[2].Cap())
+
+	// no false negative after reload
+	buf := make([]byte, 8)
+	for _, pk := range pks {
+		assert.True(t, seg.mayContainPK(pk, buf))
+	}
+	assert.False(t, seg.mayContainPK(math.MaxInt32+1, buf))
+	assert.False(t, seg.mayContainPK(-1, buf))
+
+	// the pks inserted in datanode are checked with the same encoding
+	seg.updatePKRange([]int64{math.MaxInt32 + 1})
+	assert.True(t, seg.mayContainPK(math.MaxInt32+1, buf))
+}
@@ -0,0 +1,147 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// segmentBuffer is the insert buffer of a segment checked by sync policies
+type segmentBuffer struct {
+	segmentID UniqueID
+	buffer    *BufferData
+}
+
+// segmentSyncPolicy selects the segments whose insert buffers should be synced to storage
+type segmentSyncPolicy func(buffers []segmentBuffer, now time.Time) []UniqueID
+
+// syncWhenFull syncs the buffers reaching their row limits
+func syncWhenFull() segmentSyncPolicy {
+	return func(buffers []segmentBuffer, _ time.Time) []UniqueID {
+		segments := make([]UniqueID, 0)
+		for _, b := range buffers {
+			if b.buffer.effectiveCap() <= 0 {
+				segments = append(segments, b.segmentID)
+			}
+		}
+		return segments
+	}
+}
+
+// syncWhenExceedSize syncs the buffers holding at least maxSize bytes, it's disabled if maxSize is not positive
+func syncWhenExceedSize(maxSize int64) segmentSyncPolicy {
+	return func(buffers []segmentBuffer, _ time.Time) []UniqueID {
+		segments := make([]UniqueID, 0)
+		if maxSize <= 0 {
+			return segments
+		}
+		for _, b := range buffers {
+			if b.buffer.memorySize >= maxSize {
+				segments = append(segments, b.segmentID)
+			}
+		}
+		return segments
+	}
+}
+
+// syncWhenExpired syncs the buffers holding data for at least period, it's disabled if period is not positive
+func syncWhenExpired(period time.Duration) segmentSyncPolicy {
+	return func(buffers []segmentBuffer, now time.Time) []UniqueID {
+		segments := make([]UniqueID, 0)
+		if period <= 0 {
+			return segments
+		}
+		for _, b := range buffers {
+			if b.buffer.size > 0 && now.Sub(b.buffer.startTime) >= period {
+				segments = append(segments, b.segmentID)
+			}
+		}
+		return segments
+	}
+}
+
+// syncWhenMemoryHigh syncs the largest buffers until the memory returned by usage drops under watermark,
+// it's disabled if watermark is not positive
+func syncWhenMemoryHigh(watermark int64, usage func() int64) segmentSyncPolicy {
+	return func(buffers []segmentBuffer, _ time.Time) []UniqueID {
+		segments := make([]UniqueID, 0)
+		if watermark <= 0 {
+			return segments
+		}
+		total := usage()
+		if total <= watermark {
+			return segments
+		}
+		sorted := make([]segmentBuffer, len(buffers))
+		copy(sorted, buffers)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].buffer.memorySize > sorted[j].buffer.memorySize
+		})
+		for _, b := range sorted {
+			if total <= watermark || b.buffer.memorySize <= 0 {
+				break
+			}
+			segments = append(segments, b.segmentID)
+			total -= b.buffer.memorySize
+		}
+		return segments
+	}
+}
+
+// syncPolicyFactories are the sync policies which can be enabled by dataNode.flush.syncPolicies
+var syncPolicyFactories = map[string]func() segmentSyncPolicy{
+	"full": syncWhenFull,
+	"size": func() segmentSyncPolicy {
+		return syncWhenExceedSize(Params.FlushSyncBufferSize)
+	},
+	"age": func() segmentSyncPolicy {
+		return syncWhenExpired(Params.FlushSyncPeriod)
+	},
+	"memory": func() segmentSyncPolicy {
+		return syncWhenMemoryHigh(Params.FlushMemoryWatermark, getInsertBufferMemory)
+	},
+}
+
+// newSyncPolicies creates the sync policies of names
+func newSyncPolicies(names []string) ([]segmentSyncPolicy, error) {
+	policies := make([]segmentSyncPolicy, 0, len(names))
+	for _, name := range names {
+		factory, ok := syncPolicyFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sync policy %s", name)
+		}
+		policies = append(policies, factory())
+	}
+	return policies, nil
+}
+
+// selectSyncSegments returns the segments selected by any of policies without duplicates, in order of selection
+func selectSyncSegments(policies []segmentSyncPolicy, buffers []segmentBuffer, now time.Time) []UniqueID {
+	selected := make(map[UniqueID]struct{})
+	segments := make([]UniqueID, 0)
+	for _, policy := range policies {
+		for _, segmentID := range policy(buffers, now) {
+			if _, ok := selected[segmentID]; !ok {
+				selected[segmentID] = struct{}{}
+				segments = append(segments, segmentID)
+			}
+		}
+	}
+	return segments
+}
@@ -0,0 +1,259 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+func TestSyncPolicies(t *testing.T) {
+	now := time.Now()
+	buffers := []segmentBuffer{
+		{segmentID: 1, buffer: &BufferData{size: 10, limit: 10, memorySize: 100, startTime: now.Add(-time.Minute)}},
+		{segmentID: 2, buffer: &BufferData{size: 5, limit: 10, memorySize: 300, startTime: now.Add(-time.Hour)}},
+		{segmentID: 3, buffer: &BufferData{size: 1, limit: 10, memorySize: 200, startTime: now}},
+		{segmentID: 4, buffer: &BufferData{size: 0, limit: 10, memorySize: 0, startTime: now.Add(-time.Hour)}},
+	}
+
+	t.Run("full", func(t *testing.T) {
+		assert.Equal(t, []UniqueID{1}, syncWhenFull()(buffers, now))
+	})
+
+	t.Run("size", func(t *testing.T) {
+		assert.Equal(t, []UniqueID{2, 3}, syncWhenExceedSize(200)(buffers, now))
+		assert.Empty(t, syncWhenExceedSize(1000)(buffers, now))
+		assert.Empty(t, syncWhenExceedSize(0)(buffers, now))
+	})
+
+	t.Run("age", func(t *testing.T) {
+		assert.Equal(t, []UniqueID{1, 2}, syncWhenExpired(time.Minute)(buffers, now))
+		assert.Equal(t, []UniqueID{2}, syncWhenExpired(10*time.Minute)(buffers, now))
+		assert.Empty(t, syncWhenExpired(0)(buffers, now))
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		usage := func() int64 { return 600 }
+		assert.Empty(t, syncWhenMemoryHigh(600, usage)(buffers, now))
+		// the largest buffers are synced first
+		assert.Equal(t, []UniqueID{2}, syncWhenMemoryHigh(500, usage)(buffers, now))
+		assert.Equal(t, []UniqueID{2, 3}, syncWhenMemoryHigh(200, usage)(buffers, now))
+		assert.Equal(t, []UniqueID{2, 3, 1}, syncWhenMemoryHigh(50, usage)(buffers, now))
+		assert.Empty(t, syncWhenMemoryHigh(0, usage)(buffers, now))
+		// the buffers of other flowgraphs take the rest of memory
+		assert.Equal(t, []UniqueID{2, 3}, syncWhenMemoryHigh(500, func() int64 { return 1000 })(buffers, now))
+	})
+
+	t.Run("select", func(t *testing.T) {
+		policies, err := newSyncPolicies([]string{"full", "size"})
+		require.NoError(t, err)
+		_, err = newSyncPolicies([]string{"full", "unknown"})
+		assert.Error(t, err)
+
+		policies = append(policies, syncWhenExceedSize(100), syncWhenExpired(time.Minute))
+		assert.Equal(t, []UniqueID{1, 2, 3}, selectSyncSegments(policies, buffers, now))
+		assert.Empty(t, selectSyncSegments(nil, buffers, now))
+	})
+}
+
+// TestSyncRecovery syncs the buffers of several segments concurrently by the sync policies, and replays
+// the insert messages from the checkpoints after a crash. The binlogs recorded with the checkpoints and
+// the replayed rows should cover every row exactly once.
+func TestSyncRecovery(t *testing.T) {
+	const (
+		segmentNum = 3
+		msgNum     = 300
+		crashAt    = 200
+		// the syncs after inFlightAt are still in flight when crashing
+		inFlightAt = 150
+	)
+	// the i-th insert message has one row i of segment i%segmentNum
+	segmentOf := func(i int) UniqueID {
+		return UniqueID(i % segmentNum)
+	}
+	position := func(i int) *internalpb.MsgPosition {
+		return &internalpb.MsgPosition{MsgID: []byte(strconv.Itoa(i)), Timestamp: Timestamp(i + 1)}
+	}
+
+	kv := memkv.NewMemoryKV()
+	crash := make(chan struct{})
+	var mu sync.Mutex
+	crashed := false
+	checkpoints := make(map[UniqueID]*internalpb.MsgPosition)
+	binlogs := make(map[UniqueID][]string)
+	notify := func(pack *segmentFlushPack) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if crashed {
+			return nil
+		}
+		// the binlogs land before the checkpoint advances
+		for _, key := range pack.insertLogs {
+			value, err := kv.Load(key)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, value)
+			binlogs[pack.segmentID] = append(binlogs[pack.segmentID], key)
+		}
+		if cp, ok := checkpoints[pack.segmentID]; ok {
+			assert.Greater(t, pack.pos.Timestamp, cp.Timestamp)
+		}
+		checkpoints[pack.segmentID] = pack.pos
+		return nil
+	}
+
+	queues := make(map[UniqueID]*orderFlushQueue)
+	buffers := make(map[UniqueID]*BufferData)
+	rows := make(map[UniqueID][]string)
+	var memory int64
+	lastDone := make(map[UniqueID]*internalpb.MsgPosition)
+	for s := 0; s < segmentNum; s++ {
+		segmentID := UniqueID(s)
+		queues[segmentID] = newOrderFlushQueue(segmentID, notify)
+		queues[segmentID].init()
+		buffers[segmentID] = &BufferData{limit: 1000, startTime: time.Now()}
+	}
+	policies := []segmentSyncPolicy{
+		syncWhenExceedSize(64),
+		syncWhenMemoryHigh(128, func() int64 { return memory }),
+	}
+
+	for i := 0; i < crashAt; i++ {
+		segmentID := segmentOf(i)
+		rows[segmentID] = append(rows[segmentID], strconv.Itoa(i))
+		buffers[segmentID].size++
+		// segments grow at different rates
+		buffers[segmentID].memorySize += int64(8 * (segmentID + 1))
+		memory += int64(8 * (segmentID + 1))
+
+		candidates := make([]segmentBuffer, 0, segmentNum)
+		for s := 0; s < segmentNum; s++ {
+			candidates = append(candidates, segmentBuffer{segmentID: UniqueID(s), buffer: buffers[UniqueID(s)]})
+		}
+		for _, syncID := range selectSyncSegments(policies, candidates, time.Now()) {
+			pos := position(i)
+			key := "binlog/" + strconv.FormatInt(syncID, 10) + "/" + strconv.Itoa(i)
+			var block <-chan struct{}
+			if i >= inFlightAt {
+				block = crash
+			} else {
+				lastDone[syncID] = pos
+			}
+			task := &flushBufferInsertTask{
+				BaseKV: &slowKV{MemoryKV: kv, block: block},
+				data:   map[string]string{key: strings.Join(rows[syncID], ",")},
+			}
+			queues[syncID].enqueueInsertFlush(task, map[UniqueID]string{0: key}, map[UniqueID]string{}, nil, false, pos)
+			queues[syncID].enqueueDelFlush(&flushBufferDeleteTask{}, nil, pos)
+
+			memory -= buffers[syncID].memorySize
+			buffers[syncID] = &BufferData{limit: 1000, startTime: time.Now()}
+			rows[syncID] = nil
+		}
+	}
+
+	require.Len(t, lastDone, segmentNum)
+	// crash when the syncs before inFlightAt are done, the syncs in flight are lost
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for segmentID, pos := range lastDone {
+			if checkpoints[segmentID].GetTimestamp() != pos.Timestamp {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	crashed = true
+	mu.Unlock()
+	close(crash)
+
+	// recover from the checkpoints like DataCoord and ddNode do
+	vchan := &datapb.VchannelInfo{CollectionID: 1}
+	seekTs := Timestamp(0)
+	for segmentID, cp := range checkpoints {
+		vchan.UnflushedSegments = append(vchan.UnflushedSegments, &datapb.SegmentInfo{
+			ID:          segmentID,
+			DmlPosition: cp,
+		})
+		if seekTs == 0 || cp.Timestamp < seekTs {
+			seekTs = cp.Timestamp
+		}
+	}
+	dd := newDDNode(nil, 1, vchan)
+
+	recovered := make(map[UniqueID][]string)
+	for segmentID, keys := range binlogs {
+		for _, key := range keys {
+			value, err := kv.Load(key)
+			require.NoError(t, err)
+			recovered[segmentID] = append(recovered[segmentID], strings.Split(value, ",")...)
+		}
+	}
+	assert.Less(t, seekTs, position(inFlightAt).Timestamp)
+	for i := 0; i < msgNum; i++ {
+		pos := position(i)
+		if pos.Timestamp <= seekTs {
+			continue
+		}
+		msg := &msgstream.InsertMsg{
+			BaseMsg:       msgstream.BaseMsg{BeginTimestamp: pos.Timestamp, EndTimestamp: pos.Timestamp},
+			InsertRequest: internalpb.InsertRequest{SegmentID: segmentOf(i)},
+		}
+		if !dd.filterFlushedSegmentInsertMessages(msg) {
+			recovered[segmentOf(i)] = append(recovered[segmentOf(i)], strconv.Itoa(i))
+		}
+	}
+
+	for s := 0; s < segmentNum; s++ {
+		expected := make([]string, 0)
+		for i := s; i < msgNum; i += segmentNum {
+			expected = append(expected, strconv.Itoa(i))
+		}
+		actual := recovered[UniqueID(s)]
+		sort.Strings(expected)
+		sort.Strings(actual)
+		assert.Equal(t, expected, actual, "segment %d", s)
+	}
+}
+
+// slowKV saves with random latency after block is closed, so that the syncs of different segments finish out of order
+type slowKV struct {
+	*memkv.MemoryKV
+	block <-chan struct{}
+}
+
+func (kv *slowKV) MultiSave(kvs map[string]string) error {
+	if kv.block != nil {
+		<-kv.block
+	}
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	return kv.MemoryKV.MultiSave(kvs)
+}
@@ -94,6 +94,22 @@ func (m *MockDataCoordClient) GetMetrics(ctx context.Context, in *milvuspb.GetMe
 	return &milvuspb.GetMetricsResponse{}, m.err
 }
 
+func (m *MockDataCoordClient) Import(ctx context.Context, in *datapb.ImportTaskRequest, opts ...grpc.CallOption) (*datapb.ImportTaskResponse, error) {
+	return &datapb.ImportTaskResponse{}, m.err
+}
+
+func (m *MockDataCoordClient) GetImportState(ctx context.Context, in *milvuspb.GetImportStateRequest, opts ...grpc.CallOption) (*milvuspb.GetImportStateResponse, error) {
+	return &milvuspb.GetImportStateResponse{}, m.err
+}
+
+func (m *MockDataCoordClient) ReportImport(ctx context.Context, in *datapb.ImportResult, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
+func (m *MockDataCoordClient) DropSegment(ctx context.Context, in *datapb.DropSegmentRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
 func Test_NewClient(t *testing.T) {
 	proxy.Params.InitOnce()
 
@@ -166,6 +182,18 @@ func Test_NewClient(t *testing.T) {
 
 		r15, err := client.GetMetrics(ctx, nil)
 		retCheck(retNotNil, r15, err)
+
+		r16, err := client.Import(ctx, nil)
+		retCheck(retNotNil, r16, err)
+
+		r17, err := client.GetImportState(ctx, nil)
+		retCheck(retNotNil, r17, err)
+
+		r18, err := client.ReportImport(ctx, nil)
+		retCheck(retNotNil, r18, err)
+
+		r19, err := client.DropSegment(ctx, nil)
+		retCheck(retNotNil, r19, err)
 	}
 
 	client.getGrpcClient = func() (datapb.DataCoordClient, error) {
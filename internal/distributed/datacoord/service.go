@@ -19,6 +19,7 @@ package grpcdatacoord
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"strconv"
@@ -37,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -188,6 +190,15 @@ func (s *Server) Run() error {
 	return nil
 }
 
+// CheckReady checks whether the DataCoord served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.dataCoord.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("DataCoord does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 // GetComponentStates gets states of datacoord and datanodes
 func (s *Server) GetComponentStates(ctx context.Context, req *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.dataCoord.GetComponentStates(ctx)
@@ -262,3 +273,23 @@ func (s *Server) GetFlushedSegments(ctx context.Context, req *datapb.GetFlushedS
 func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return s.dataCoord.GetMetrics(ctx, req)
 }
+
+// Import creates an import task of the files, and assigns it to a datanode
+func (s *Server) Import(ctx context.Context, req *datapb.ImportTaskRequest) (*datapb.ImportTaskResponse, error) {
+	return s.dataCoord.Import(ctx, req)
+}
+
+// GetImportState gets the state of an import task
+func (s *Server) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	return s.dataCoord.GetImportState(ctx, req)
+}
+
+// ReportImport reports the result of an import task from datanode
+func (s *Server) ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error) {
+	return s.dataCoord.ReportImport(ctx, req)
+}
+
+// DropSegment drops a segment and cascades the drop to QueryCoord and IndexCoord
+func (s *Server) DropSegment(ctx context.Context, req *datapb.DropSegmentRequest) (*commonpb.Status, error) {
+	return s.dataCoord.DropSegment(ctx, req)
+}
@@ -48,6 +48,8 @@ type MockDataCoord struct {
 	recoverResp  *datapb.GetRecoveryInfoResponse
 	flushSegResp *datapb.GetFlushedSegmentsResponse
 	metricResp   *milvuspb.GetMetricsResponse
+	importResp   *datapb.ImportTaskResponse
+	importState  *milvuspb.GetImportStateResponse
 }
 
 func (m *MockDataCoord) Init() error {
@@ -126,6 +128,22 @@ func (m *MockDataCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetrics
 	return m.metricResp, m.err
 }
 
+func (m *MockDataCoord) Import(ctx context.Context, req *datapb.ImportTaskRequest) (*datapb.ImportTaskResponse, error) {
+	return m.importResp, m.err
+}
+
+func (m *MockDataCoord) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	return m.importState, m.err
+}
+
+func (m *MockDataCoord) ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockDataCoord) DropSegment(ctx context.Context, req *datapb.DropSegmentRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 func Test_NewServer(t *testing.T) {
 	ctx := context.Background()
@@ -274,6 +292,42 @@ func Test_NewServer(t *testing.T) {
 		assert.NotNil(t, resp)
 	})
 
+	t.Run("Import", func(t *testing.T) {
+		server.dataCoord = &MockDataCoord{
+			importResp: &datapb.ImportTaskResponse{},
+		}
+		resp, err := server.Import(ctx, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("GetImportState", func(t *testing.T) {
+		server.dataCoord = &MockDataCoord{
+			importState: &milvuspb.GetImportStateResponse{},
+		}
+		resp, err := server.GetImportState(ctx, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("ReportImport", func(t *testing.T) {
+		server.dataCoord = &MockDataCoord{
+			status: &commonpb.Status{},
+		}
+		resp, err := server.ReportImport(ctx, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("DropSegment", func(t *testing.T) {
+		server.dataCoord = &MockDataCoord{
+			status: &commonpb.Status{},
+		}
+		resp, err := server.DropSegment(ctx, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+
 	err = server.Stop()
 	assert.Nil(t, err)
 }
@@ -42,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 )
 
@@ -211,7 +212,6 @@ func (s *Server) init() error {
 			log.Debug("DataNode wait rootCoord ready failed", zap.Error(err))
 			panic(err)
 		}
-		log.Debug("DataNode rootCoord is ready")
 		if err = s.SetRootCoordInterface(rootCoordClient); err != nil {
 			panic(err)
 		}
@@ -239,7 +239,6 @@ func (s *Server) init() error {
 			log.Debug("DataNode wait dataCoordClient ready failed", zap.Error(err))
 			panic(err)
 		}
-		log.Debug("DataNode dataCoord is ready")
 		if err = s.SetDataCoordInterface(dataCoordClient); err != nil {
 			panic(err)
 		}
@@ -268,6 +267,15 @@ func (s *Server) start() error {
 	return nil
 }
 
+// CheckReady checks whether the DataNode served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.datanode.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("DataNode does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 func (s *Server) GetComponentStates(ctx context.Context, req *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.datanode.GetComponentStates(ctx)
 }
@@ -293,3 +301,7 @@ func (s *Server) FlushSegments(ctx context.Context, req *datapb.FlushSegmentsReq
 func (s *Server) GetMetrics(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return s.datanode.GetMetrics(ctx, request)
 }
+
+func (s *Server) Import(ctx context.Context, request *datapb.ImportTask) (*commonpb.Status, error) {
+	return s.datanode.Import(ctx, request)
+}
@@ -72,6 +72,15 @@ func TestIndexCoordClient(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	t.Run("CreateIndexDefinition", func(t *testing.T) {
+		req := &indexpb.CreateIndexDefinitionRequest{
+			Definition: &indexpb.IndexDefinition{IndexID: 1},
+		}
+		resp, err := icc.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
 	t.Run("BuildIndex", func(t *testing.T) {
 		req := &indexpb.BuildIndexRequest{
 			IndexBuildID: 0,
@@ -112,6 +121,28 @@ func TestIndexCoordClient(t *testing.T) {
 		assert.Equal(t, len(req.IndexBuildIDs), len(resp.FilePaths))
 	})
 
+	t.Run("GetIndexBuildProgress", func(t *testing.T) {
+		req := &indexpb.GetIndexBuildProgressRequest{}
+		resp, err := icc.GetIndexBuildProgress(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("GetIndexState", func(t *testing.T) {
+		req := &indexpb.GetIndexStateRequest{}
+		resp, err := icc.GetIndexState(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, commonpb.IndexState_Finished, resp.State)
+	})
+
+	t.Run("DropSegmentIndex", func(t *testing.T) {
+		req := &indexpb.DropSegmentIndexRequest{}
+		resp, err := icc.DropSegmentIndex(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{}
 		resp, err := icc.GetMetrics(ctx, req)
@@ -18,6 +18,7 @@ package grpcindexcoord
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	"google.golang.org/grpc"
 
 	ot "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
+	dsc "github.com/milvus-io/milvus/internal/distributed/datacoord/client"
 	"github.com/milvus-io/milvus/internal/indexcoord"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -36,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
@@ -45,7 +48,7 @@ type UniqueID = typeutil.UniqueID
 
 // Server is the grpc wrapper of IndexCoord.
 type Server struct {
-	indexcoord types.IndexCoord
+	indexcoord types.IndexCoordComponent
 
 	grpcServer  *grpc.Server
 	grpcErrChan chan error
@@ -54,6 +57,8 @@ type Server struct {
 	loopCancel func()
 	loopWg     sync.WaitGroup
 
+	dataCoord types.DataCoord
+
 	closer io.Closer
 }
 
@@ -94,6 +99,28 @@ func (s *Server) init() error {
 		log.Error("IndexCoord", zap.Any("init error", err))
 		return err
 	}
+
+	// the DataCoord client connects lazily, so IndexCoord doesn't wait for DataCoord to start
+	if s.dataCoord == nil {
+		dataCoord, err := dsc.NewClient(s.loopCtx, indexcoord.Params.MetaRootPath, indexcoord.Params.EtcdEndpoints)
+		if err != nil {
+			log.Debug("IndexCoord try to new DataCoord client failed", zap.Error(err))
+			return err
+		}
+		s.dataCoord = dataCoord
+	}
+	if err := s.dataCoord.Init(); err != nil {
+		log.Debug("IndexCoord DataCoordClient Init failed", zap.Error(err))
+		return err
+	}
+	if err := s.dataCoord.Start(); err != nil {
+		log.Debug("IndexCoord DataCoordClient Start failed", zap.Error(err))
+		return err
+	}
+	if err := s.indexcoord.SetDataCoord(s.dataCoord); err != nil {
+		return err
+	}
+
 	if err := s.indexcoord.Init(); err != nil {
 		log.Error("IndexCoord", zap.Any("init error", err))
 		return err
@@ -120,6 +147,11 @@ func (s *Server) Stop() error {
 	if s.indexcoord != nil {
 		s.indexcoord.Stop()
 	}
+	if s.dataCoord != nil {
+		if err := s.dataCoord.Stop(); err != nil {
+			log.Warn("IndexCoord failed to stop DataCoord client", zap.Error(err))
+		}
+	}
 
 	s.loopCancel()
 	if s.grpcServer != nil {
@@ -131,11 +163,26 @@ func (s *Server) Stop() error {
 }
 
 // SetClient sets the IndexCoord's instance.
-func (s *Server) SetClient(indexCoordClient types.IndexCoord) error {
+func (s *Server) SetClient(indexCoordClient types.IndexCoordComponent) error {
 	s.indexcoord = indexCoordClient
 	return nil
 }
 
+// SetDataCoord sets the DataCoord client of IndexCoord, a new client is created when init if it's not set.
+func (s *Server) SetDataCoord(dataCoord types.DataCoord) error {
+	s.dataCoord = dataCoord
+	return nil
+}
+
+// CheckReady checks whether the IndexCoord served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.indexcoord.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("IndexCoord does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 // GetComponentStates gets the component states of IndexCoord.
 func (s *Server) GetComponentStates(ctx context.Context, req *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.indexcoord.GetComponentStates(ctx)
@@ -151,6 +198,11 @@ func (s *Server) GetStatisticsChannel(ctx context.Context, req *internalpb.GetSt
 	return s.indexcoord.GetStatisticsChannel(ctx)
 }
 
+// CreateIndexDefinition sends the create index request to IndexCoord.
+func (s *Server) CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error) {
+	return s.indexcoord.CreateIndexDefinition(ctx, req)
+}
+
 // BuildIndex sends the build index request to IndexCoord.
 func (s *Server) BuildIndex(ctx context.Context, req *indexpb.BuildIndexRequest) (*indexpb.BuildIndexResponse, error) {
 	return s.indexcoord.BuildIndex(ctx, req)
@@ -171,6 +223,21 @@ func (s *Server) GetIndexFilePaths(ctx context.Context, req *indexpb.GetIndexFil
 	return s.indexcoord.GetIndexFilePaths(ctx, req)
 }
 
+// GetIndexBuildProgress gets the index build progress of a field from IndexCoord.
+func (s *Server) GetIndexBuildProgress(ctx context.Context, req *indexpb.GetIndexBuildProgressRequest) (*indexpb.GetIndexBuildProgressResponse, error) {
+	return s.indexcoord.GetIndexBuildProgress(ctx, req)
+}
+
+// GetIndexState gets the index state of a field from IndexCoord.
+func (s *Server) GetIndexState(ctx context.Context, req *indexpb.GetIndexStateRequest) (*indexpb.GetIndexStateResponse, error) {
+	return s.indexcoord.GetIndexState(ctx, req)
+}
+
+// DropSegmentIndex drops the index builds of a dropped segment from IndexCoord.
+func (s *Server) DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error) {
+	return s.indexcoord.DropSegmentIndex(ctx, req)
+}
+
 // GetMetrics gets the metrics info of IndexCoord.
 func (s *Server) GetMetrics(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return s.indexcoord.GetMetrics(ctx, request)
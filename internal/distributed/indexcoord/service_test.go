@@ -60,6 +60,15 @@ func TestIndexCoordinateServer(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	t.Run("CreateIndexDefinition", func(t *testing.T) {
+		req := &indexpb.CreateIndexDefinitionRequest{
+			Definition: &indexpb.IndexDefinition{IndexID: 1},
+		}
+		resp, err := server.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
 	t.Run("BuildIndex", func(t *testing.T) {
 		req := &indexpb.BuildIndexRequest{
 			IndexBuildID: 0,
@@ -100,6 +109,28 @@ func TestIndexCoordinateServer(t *testing.T) {
 		assert.Equal(t, len(req.IndexBuildIDs), len(resp.FilePaths))
 	})
 
+	t.Run("GetIndexBuildProgress", func(t *testing.T) {
+		req := &indexpb.GetIndexBuildProgressRequest{}
+		resp, err := server.GetIndexBuildProgress(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("GetIndexState", func(t *testing.T) {
+		req := &indexpb.GetIndexStateRequest{}
+		resp, err := server.GetIndexState(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, commonpb.IndexState_Finished, resp.State)
+	})
+
+	t.Run("DropSegmentIndex", func(t *testing.T) {
+		req := &indexpb.DropSegmentIndexRequest{}
+		resp, err := server.DropSegmentIndex(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
@@ -27,11 +27,13 @@ import (
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
@@ -47,6 +49,11 @@ type Client struct {
 	conn          *grpc.ClientConn
 	grpcClientMtx sync.RWMutex
 
+	// capabilities caches the capabilities of the connected IndexNode, it is guarded by grpcClientMtx and dropped
+	// whenever the connection changes, since the IndexNode may have been upgraded.
+	capabilities *indexpb.GetCapabilitiesResponse
+	connEpoch    int64
+
 	addr string
 
 	getGrpcClient func() (indexpb.IndexNodeClient, error)
@@ -90,6 +97,13 @@ func (c *Client) resetConnection() {
 	}
 	c.conn = nil
 	c.grpcClient = nil
+	c.dropCapabilities()
+}
+
+// dropCapabilities drops the cached capabilities, the caller must hold grpcClientMtx.
+func (c *Client) dropCapabilities() {
+	c.capabilities = nil
+	c.connEpoch++
 }
 
 // NewClient creates a new IndexNode client.
@@ -160,6 +174,7 @@ func (c *Client) connect(retryOptions ...retry.Option) error {
 	}
 	log.Debug("IndexNodeClient try connect success", zap.String("address", c.addr))
 	c.grpcClient = indexpb.NewIndexNodeClient(c.conn)
+	c.dropCapabilities()
 	return nil
 }
 
@@ -264,6 +279,103 @@ func (c *Client) CreateIndex(ctx context.Context, req *indexpb.CreateIndexReques
 	return ret.(*commonpb.Status), err
 }
 
+// CancelIndexBuild notifies IndexNode to cancel the index building tasks.
+func (c *Client) CancelIndexBuild(ctx context.Context, req *indexpb.CancelIndexBuildRequest) (*commonpb.Status, error) {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.CancelIndexBuild(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*commonpb.Status), err
+}
+
+// GetTaskSlots gets the number of index building tasks IndexNode can start right now.
+func (c *Client) GetTaskSlots(ctx context.Context, req *indexpb.GetTaskSlotsRequest) (*indexpb.GetTaskSlotsResponse, error) {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.GetTaskSlots(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*indexpb.GetTaskSlotsResponse), err
+}
+
+// legacyIndexTypes are the index types the IndexNodes built before GetCapabilities was added can build.
+var legacyIndexTypes = []string{
+	indexparamcheck.IndexFaissIDMap,
+	indexparamcheck.IndexFaissIvfFlat,
+	indexparamcheck.IndexFaissIvfPQ,
+	indexparamcheck.IndexFaissIvfSQ8,
+	indexparamcheck.IndexFaissIvfSQ8H,
+	indexparamcheck.IndexFaissBinIDMap,
+	indexparamcheck.IndexFaissBinIvfFlat,
+	indexparamcheck.IndexNSG,
+	indexparamcheck.IndexHNSW,
+	indexparamcheck.IndexRHNSWFlat,
+	indexparamcheck.IndexRHNSWPQ,
+	indexparamcheck.IndexRHNSWSQ,
+	indexparamcheck.IndexANNOY,
+	indexparamcheck.IndexNGTPANNG,
+	indexparamcheck.IndexNGTONNG,
+}
+
+// GetCapabilities gets the build version, the supported index types and features of IndexNode.
+// The response is fetched once per connection and cached, the cache is dropped when the client reconnects. An
+// IndexNode that does not implement GetCapabilities is reported to build the legacy index types only, without any
+// optional feature.
+func (c *Client) GetCapabilities(ctx context.Context, req *indexpb.GetCapabilitiesRequest) (*indexpb.GetCapabilitiesResponse, error) {
+	c.grpcClientMtx.RLock()
+	cached, epoch := c.capabilities, c.connEpoch
+	c.grpcClientMtx.RUnlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.GetCapabilities(ctx, req)
+		if status.Code(err) == codes.Unimplemented {
+			log.Debug("IndexNode does not implement GetCapabilities, treat it as a legacy IndexNode", zap.String("address", c.addr))
+			return &indexpb.GetCapabilitiesResponse{
+				Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				IndexTypes: legacyIndexTypes,
+			}, nil
+		}
+		return resp, err
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	resp := ret.(*indexpb.GetCapabilitiesResponse)
+	if resp.GetStatus().GetErrorCode() == commonpb.ErrorCode_Success {
+		c.grpcClientMtx.Lock()
+		// the connection has changed during the call if the epoch has changed, leave it to the next call
+		if c.connEpoch == epoch {
+			c.capabilities = resp
+		}
+		c.grpcClientMtx.Unlock()
+		log.Debug("IndexNodeClient get capabilities", zap.String("address", c.addr),
+			zap.String("buildVersion", resp.GetBuildVersion()), zap.Strings("indexTypes", resp.GetIndexTypes()),
+			zap.Uint64("features", resp.GetFeatures()))
+	}
+	return resp, nil
+}
+
 // GetMetrics gets the metrics info of IndexNode.
 func (c *Client) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	ret, err := c.recall(func() (interface{}, error) {
@@ -30,6 +30,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockIndexNodeClient struct {
@@ -52,6 +54,18 @@ func (m *MockIndexNodeClient) CreateIndex(ctx context.Context, in *indexpb.Creat
 	return &commonpb.Status{}, m.err
 }
 
+func (m *MockIndexNodeClient) CancelIndexBuild(ctx context.Context, in *indexpb.CancelIndexBuildRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
+func (m *MockIndexNodeClient) GetTaskSlots(ctx context.Context, in *indexpb.GetTaskSlotsRequest, opts ...grpc.CallOption) (*indexpb.GetTaskSlotsResponse, error) {
+	return &indexpb.GetTaskSlotsResponse{}, m.err
+}
+
+func (m *MockIndexNodeClient) GetCapabilities(ctx context.Context, in *indexpb.GetCapabilitiesRequest, opts ...grpc.CallOption) (*indexpb.GetCapabilitiesResponse, error) {
+	return &indexpb.GetCapabilitiesResponse{}, m.err
+}
+
 func (m *MockIndexNodeClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	return &milvuspb.GetMetricsResponse{}, m.err
 }
@@ -102,6 +116,15 @@ func Test_NewClient(t *testing.T) {
 
 		r5, err := client.GetMetrics(ctx, nil)
 		retCheck(retNotNil, r5, err)
+
+		r6, err := client.CancelIndexBuild(ctx, nil)
+		retCheck(retNotNil, r6, err)
+
+		r7, err := client.GetTaskSlots(ctx, nil)
+		retCheck(retNotNil, r7, err)
+
+		r8, err := client.GetCapabilities(ctx, nil)
+		retCheck(retNotNil, r8, err)
 	}
 
 	client.getGrpcClient = func() (indexpb.IndexNodeClient, error) {
@@ -123,6 +146,84 @@ func Test_NewClient(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// versionedIndexNodeClient reports the capabilities of an IndexNode of the version, it does not implement
+// GetCapabilities if the version is empty.
+type versionedIndexNodeClient struct {
+	MockIndexNodeClient
+	version string
+	calls   int
+}
+
+func (m *versionedIndexNodeClient) GetCapabilities(ctx context.Context, in *indexpb.GetCapabilitiesRequest, opts ...grpc.CallOption) (*indexpb.GetCapabilitiesResponse, error) {
+	m.calls++
+	if m.version == "" {
+		return nil, status.Error(codes.Unimplemented, "unknown method GetCapabilities")
+	}
+	return &indexpb.GetCapabilitiesResponse{
+		Status:       &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		BuildVersion: m.version,
+		IndexTypes:   []string{"IVF_FLAT", m.version},
+		Features:     uint64(indexpb.IndexNodeFeature_FeatureTaskSlots),
+	}, nil
+}
+
+func TestClient_GetCapabilities(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, "test")
+	assert.Nil(t, err)
+	err = client.Init()
+	assert.Nil(t, err)
+
+	legacy := &versionedIndexNodeClient{}
+	client.getGrpcClient = func() (indexpb.IndexNodeClient, error) {
+		return legacy, nil
+	}
+	resp, err := client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	assert.Equal(t, "", resp.BuildVersion)
+	assert.Equal(t, legacyIndexTypes, resp.IndexTypes)
+	assert.Equal(t, uint64(0), resp.Features)
+
+	// the capabilities are cached until the client reconnects
+	_, err = client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, legacy.calls)
+
+	// the IndexNode is upgraded while the client reconnects
+	upgraded := &versionedIndexNodeClient{version: "v2"}
+	client.getGrpcClient = func() (indexpb.IndexNodeClient, error) {
+		return upgraded, nil
+	}
+	client.resetConnection()
+	resp, err = client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "v2", resp.BuildVersion)
+	assert.Equal(t, []string{"IVF_FLAT", "v2"}, resp.IndexTypes)
+	assert.Equal(t, uint64(indexpb.IndexNodeFeature_FeatureTaskSlots), resp.Features)
+	_, err = client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, upgraded.calls)
+
+	// the failed response is not cached
+	client.resetConnection()
+	client.getGrpcClient = func() (indexpb.IndexNodeClient, error) {
+		return &MockIndexNodeClient{err: errors.New("dummy")}, nil
+	}
+	_, err = client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	assert.NotNil(t, err)
+	client.getGrpcClient = func() (indexpb.IndexNodeClient, error) {
+		return upgraded, nil
+	}
+	resp, err = client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "v2", resp.BuildVersion)
+	assert.Equal(t, 2, upgraded.calls)
+
+	err = client.Stop()
+	assert.Nil(t, err)
+}
+
 func TestIndexNodeClient(t *testing.T) {
 	ctx := context.Background()
 
@@ -176,6 +277,32 @@ func TestIndexNodeClient(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
 	})
 
+	t.Run("CancelIndexBuild", func(t *testing.T) {
+		req := &indexpb.CancelIndexBuildRequest{
+			IndexBuildIDs: []int64{0},
+		}
+		resp, err := inc.CancelIndexBuild(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("GetTaskSlots", func(t *testing.T) {
+		req := &indexpb.GetTaskSlotsRequest{
+			IndexBuildIDs: []int64{0},
+		}
+		resp, err := inc.GetTaskSlots(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, 1, len(resp.TaskStates))
+	})
+
+	t.Run("GetCapabilities", func(t *testing.T) {
+		resp, err := inc.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Contains(t, resp.IndexTypes, "IVF_FLAT")
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{}
 		resp, err := inc.GetMetrics(ctx, req)
@@ -36,6 +36,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"google.golang.org/grpc"
 )
@@ -179,6 +180,15 @@ func (s *Server) SetClient(indexNodeClient types.IndexNode) error {
 	return nil
 }
 
+// CheckReady checks whether the IndexNode served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.indexnode.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("IndexNode does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 // GetComponentStates gets the component states of IndexNode.
 func (s *Server) GetComponentStates(ctx context.Context, req *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.indexnode.GetComponentStates(ctx)
@@ -199,6 +209,21 @@ func (s *Server) CreateIndex(ctx context.Context, req *indexpb.CreateIndexReques
 	return s.indexnode.CreateIndex(ctx, req)
 }
 
+// CancelIndexBuild sends the cancel index build request to IndexNode.
+func (s *Server) CancelIndexBuild(ctx context.Context, req *indexpb.CancelIndexBuildRequest) (*commonpb.Status, error) {
+	return s.indexnode.CancelIndexBuild(ctx, req)
+}
+
+// GetTaskSlots gets the number of index building tasks IndexNode can start right now.
+func (s *Server) GetTaskSlots(ctx context.Context, req *indexpb.GetTaskSlotsRequest) (*indexpb.GetTaskSlotsResponse, error) {
+	return s.indexnode.GetTaskSlots(ctx, req)
+}
+
+// GetCapabilities gets the build version, the supported index types and features of IndexNode.
+func (s *Server) GetCapabilities(ctx context.Context, req *indexpb.GetCapabilitiesRequest) (*indexpb.GetCapabilitiesResponse, error) {
+	return s.indexnode.GetCapabilities(ctx, req)
+}
+
 // GetMetrics gets the metrics info of IndexNode.
 func (s *Server) GetMetrics(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return s.indexnode.GetMetrics(ctx, request)
@@ -74,6 +74,32 @@ func TestIndexNodeServer(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
 	})
 
+	t.Run("CancelIndexBuild", func(t *testing.T) {
+		req := &indexpb.CancelIndexBuildRequest{
+			IndexBuildIDs: []int64{0},
+		}
+		resp, err := server.CancelIndexBuild(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("GetTaskSlots", func(t *testing.T) {
+		req := &indexpb.GetTaskSlotsRequest{
+			IndexBuildIDs: []int64{0},
+		}
+		resp, err := server.GetTaskSlots(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, int64(1), resp.Slots)
+	})
+
+	t.Run("GetCapabilities", func(t *testing.T) {
+		resp, err := server.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.NotEmpty(t, resp.IndexTypes)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
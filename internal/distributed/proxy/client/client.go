@@ -257,3 +257,48 @@ func (c *Client) ReleaseDQLMessageStream(ctx context.Context, req *proxypb.Relea
 	}
 	return ret.(*commonpb.Status), err
 }
+
+func (c *Client) SetRateLimit(ctx context.Context, req *proxypb.SetRateLimitRequest) (*commonpb.Status, error) {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.SetRateLimit(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*commonpb.Status), err
+}
+
+func (c *Client) UpdateConfig(ctx context.Context, req *proxypb.UpdateConfigRequest) (*proxypb.UpdateConfigResponse, error) {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.UpdateConfig(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*proxypb.UpdateConfigResponse), err
+}
+
+func (c *Client) SetLogLevel(ctx context.Context, req *proxypb.SetLogLevelRequest) (*proxypb.SetLogLevelResponse, error) {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.SetLogLevel(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*proxypb.SetLogLevelResponse), err
+}
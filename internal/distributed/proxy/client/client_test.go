@@ -54,6 +54,18 @@ func (m *MockProxyClient) ReleaseDQLMessageStream(ctx context.Context, in *proxy
 	return &commonpb.Status{}, m.err
 }
 
+func (m *MockProxyClient) SetRateLimit(ctx context.Context, in *proxypb.SetRateLimitRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
+func (m *MockProxyClient) UpdateConfig(ctx context.Context, in *proxypb.UpdateConfigRequest, opts ...grpc.CallOption) (*proxypb.UpdateConfigResponse, error) {
+	return &proxypb.UpdateConfigResponse{}, m.err
+}
+
+func (m *MockProxyClient) SetLogLevel(ctx context.Context, in *proxypb.SetLogLevelRequest, opts ...grpc.CallOption) (*proxypb.SetLogLevelResponse, error) {
+	return &proxypb.SetLogLevelResponse{}, m.err
+}
+
 func Test_NewClient(t *testing.T) {
 	proxy.Params.InitOnce()
 
@@ -94,6 +106,15 @@ func Test_NewClient(t *testing.T) {
 
 		r4, err := client.ReleaseDQLMessageStream(ctx, nil)
 		retCheck(retNotNil, r4, err)
+
+		r5, err := client.SetRateLimit(ctx, nil)
+		retCheck(retNotNil, r5, err)
+
+		r6, err := client.UpdateConfig(ctx, nil)
+		retCheck(retNotNil, r6, err)
+
+		r7, err := client.SetLogLevel(ctx, nil)
+		retCheck(retNotNil, r7, err)
 	}
 
 	client.getGrpcClient = func() (proxypb.ProxyClient, error) {
@@ -31,18 +31,22 @@ import (
 	grpcdatacoordclient "github.com/milvus-io/milvus/internal/distributed/datacoord/client"
 	grpcindexcoordclient "github.com/milvus-io/milvus/internal/distributed/indexcoord/client"
 	grpcquerycoordclient "github.com/milvus-io/milvus/internal/distributed/querycoord/client"
+	grpcquerynodeclient "github.com/milvus-io/milvus/internal/distributed/querynode/client"
 	rcc "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
 	"github.com/milvus-io/milvus/internal/types"
 
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proxy"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/opentracing/opentracing-go"
 )
@@ -66,6 +70,8 @@ type Server struct {
 
 	tracer opentracing.Tracer
 	closer io.Closer
+
+	accessLogger *accesslog.Logger
 }
 
 func NewServer(ctx context.Context, factory msgstream.Factory) (*Server, error) {
@@ -99,12 +105,18 @@ func (s *Server) startGrpcLoop(grpcPort int) {
 	defer cancel()
 
 	opts := trace.GetInterceptorOpts()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_opentracing.UnaryServerInterceptor(opts...),
+		metrics.ProxyUnaryServerInterceptor(),
+	}
+	if s.accessLogger != nil {
+		unaryInterceptors = append(unaryInterceptors, accesslog.UnaryServerInterceptor(s.accessLogger))
+	}
 	s.grpcServer = grpc.NewServer(
 		grpc.MaxRecvMsgSize(Params.ServerMaxRecvSize),
 		grpc.MaxSendMsgSize(Params.ServerMaxSendSize),
 		grpc.MaxRecvMsgSize(GRPCMaxMagSize),
-		grpc.UnaryInterceptor(
-			grpc_opentracing.UnaryServerInterceptor(opts...)),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.StreamInterceptor(
 			grpc_opentracing.StreamServerInterceptor(opts...)))
 	proxypb.RegisterProxyServer(s.grpcServer, s)
@@ -157,6 +169,15 @@ func (s *Server) init() error {
 	log.Debug("proxy", zap.Int("proxy port", Params.Port))
 	log.Debug("proxy", zap.String("proxy address", Params.Address))
 
+	if proxy.Params.AccessLogEnable {
+		s.accessLogger, err = accesslog.NewLogger(proxy.Params.AccessLog)
+		if err != nil {
+			log.Warn("Proxy init access log failed", zap.Error(err))
+			return err
+		}
+		log.Debug("proxy", zap.String("access log", proxy.Params.AccessLog.Filename))
+	}
+
 	err = s.proxy.Register()
 	if err != nil {
 		log.Debug("Proxy Register etcd failed ", zap.Error(err))
@@ -249,6 +270,10 @@ func (s *Server) init() error {
 	s.proxy.SetQueryCoordClient(s.queryCooedClient)
 	log.Debug("set query coordinator client ...")
 
+	s.proxy.SetQueryNodeCreator(func(ctx context.Context, addr string) (types.QueryNode, error) {
+		return grpcquerynodeclient.NewClient(ctx, addr)
+	})
+
 	s.proxy.UpdateStateCode(internalpb.StateCode_Initializing)
 	log.Debug("proxy", zap.Any("state of proxy", internalpb.StateCode_Initializing))
 
@@ -276,6 +301,12 @@ func (s *Server) Stop() error {
 		s.grpcServer.GracefulStop()
 	}
 
+	if s.accessLogger != nil {
+		if err = s.accessLogger.Close(); err != nil {
+			log.Warn("Proxy close access log failed", zap.Error(err))
+		}
+	}
+
 	err = s.proxy.Stop()
 	if err != nil {
 		return err
@@ -286,6 +317,15 @@ func (s *Server) Stop() error {
 	return nil
 }
 
+// CheckReady checks whether the Proxy served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.proxy.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("Proxy does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 func (s *Server) GetComponentStates(ctx context.Context, request *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.proxy.GetComponentStates(ctx)
 }
@@ -302,6 +342,18 @@ func (s *Server) ReleaseDQLMessageStream(ctx context.Context, request *proxypb.R
 	return s.proxy.ReleaseDQLMessageStream(ctx, request)
 }
 
+func (s *Server) SetRateLimit(ctx context.Context, request *proxypb.SetRateLimitRequest) (*commonpb.Status, error) {
+	return s.proxy.SetRateLimit(ctx, request)
+}
+
+func (s *Server) UpdateConfig(ctx context.Context, request *proxypb.UpdateConfigRequest) (*proxypb.UpdateConfigResponse, error) {
+	return s.proxy.UpdateConfig(ctx, request)
+}
+
+func (s *Server) SetLogLevel(ctx context.Context, request *proxypb.SetLogLevelRequest) (*proxypb.SetLogLevelResponse, error) {
+	return s.proxy.SetLogLevel(ctx, request)
+}
+
 func (s *Server) CreateCollection(ctx context.Context, request *milvuspb.CreateCollectionRequest) (*commonpb.Status, error) {
 	return s.proxy.CreateCollection(ctx, request)
 }
@@ -421,6 +473,18 @@ func (s *Server) GetQuerySegmentInfo(ctx context.Context, request *milvuspb.GetQ
 
 }
 
+func (s *Server) LoadBalance(ctx context.Context, request *milvuspb.LoadBalanceRequest) (*commonpb.Status, error) {
+	return s.proxy.LoadBalance(ctx, request)
+}
+
+func (s *Server) Import(ctx context.Context, request *milvuspb.ImportRequest) (*milvuspb.ImportResponse, error) {
+	return s.proxy.Import(ctx, request)
+}
+
+func (s *Server) GetImportState(ctx context.Context, request *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	return s.proxy.GetImportState(ctx, request)
+}
+
 func (s *Server) Dummy(ctx context.Context, request *milvuspb.DummyRequest) (*milvuspb.DummyResponse, error) {
 	return s.proxy.Dummy(ctx, request)
 }
@@ -444,3 +508,8 @@ func (s *Server) DropAlias(ctx context.Context, request *milvuspb.DropAliasReque
 func (s *Server) AlterAlias(ctx context.Context, request *milvuspb.AlterAliasRequest) (*commonpb.Status, error) {
 	return s.proxy.AlterAlias(ctx, request)
 }
+
+// AlterCollection alter the properties of a collection
+func (s *Server) AlterCollection(ctx context.Context, request *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	return s.proxy.AlterCollection(ctx, request)
+}
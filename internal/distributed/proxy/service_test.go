@@ -136,6 +136,10 @@ func (m *MockRootCoord) AlterAlias(ctx context.Context, req *milvuspb.AlterAlias
 	return nil, nil
 }
 
+func (m *MockRootCoord) AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
 func (m *MockRootCoord) AllocTimestamp(ctx context.Context, req *rootcoordpb.AllocTimestampRequest) (*rootcoordpb.AllocTimestampResponse, error) {
 	return nil, nil
 }
@@ -209,6 +213,22 @@ func (m *MockIndexCoord) GetIndexFilePaths(ctx context.Context, req *indexpb.Get
 	return nil, nil
 }
 
+func (m *MockIndexCoord) CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error) {
+	return nil, nil
+}
+
+func (m *MockIndexCoord) GetIndexBuildProgress(ctx context.Context, req *indexpb.GetIndexBuildProgressRequest) (*indexpb.GetIndexBuildProgressResponse, error) {
+	return nil, nil
+}
+
+func (m *MockIndexCoord) GetIndexState(ctx context.Context, req *indexpb.GetIndexStateRequest) (*indexpb.GetIndexStateResponse, error) {
+	return nil, nil
+}
+
+func (m *MockIndexCoord) DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
 func (m *MockIndexCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, nil
 }
@@ -297,6 +317,18 @@ func (m *MockQueryCoord) GetSegmentInfo(ctx context.Context, req *querypb.GetSeg
 	return nil, nil
 }
 
+func (m *MockQueryCoord) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+	return nil, nil
+}
+
+func (m *MockQueryCoord) LoadBalance(ctx context.Context, req *querypb.LoadBalanceRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *MockQueryCoord) ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
 func (m *MockQueryCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, nil
 }
@@ -375,6 +407,22 @@ func (m *MockDataCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetrics
 	return nil, nil
 }
 
+func (m *MockDataCoord) Import(ctx context.Context, req *datapb.ImportTaskRequest) (*datapb.ImportTaskResponse, error) {
+	return nil, nil
+}
+
+func (m *MockDataCoord) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	return nil, nil
+}
+
+func (m *MockDataCoord) ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *MockDataCoord) DropSegment(ctx context.Context, req *datapb.DropSegmentRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 type MockProxy struct {
 	MockBase
@@ -409,6 +457,18 @@ func (m *MockProxy) ReleaseDQLMessageStream(ctx context.Context, in *proxypb.Rel
 	return nil, nil
 }
 
+func (m *MockProxy) SetRateLimit(ctx context.Context, request *proxypb.SetRateLimitRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *MockProxy) UpdateConfig(ctx context.Context, request *proxypb.UpdateConfigRequest) (*proxypb.UpdateConfigResponse, error) {
+	return nil, nil
+}
+
+func (m *MockProxy) SetLogLevel(ctx context.Context, request *proxypb.SetLogLevelRequest) (*proxypb.SetLogLevelResponse, error) {
+	return nil, nil
+}
+
 func (m *MockProxy) CreateCollection(ctx context.Context, request *milvuspb.CreateCollectionRequest) (*commonpb.Status, error) {
 	return nil, nil
 }
@@ -525,6 +585,18 @@ func (m *MockProxy) GetQuerySegmentInfo(ctx context.Context, request *milvuspb.G
 	return nil, nil
 }
 
+func (m *MockProxy) LoadBalance(ctx context.Context, request *milvuspb.LoadBalanceRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
+func (m *MockProxy) Import(ctx context.Context, request *milvuspb.ImportRequest) (*milvuspb.ImportResponse, error) {
+	return nil, nil
+}
+
+func (m *MockProxy) GetImportState(ctx context.Context, request *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	return nil, nil
+}
+
 func (m *MockProxy) Dummy(ctx context.Context, request *milvuspb.DummyRequest) (*milvuspb.DummyResponse, error) {
 	return nil, nil
 }
@@ -549,6 +621,10 @@ func (m *MockProxy) AlterAlias(ctx context.Context, request *milvuspb.AlterAlias
 	return nil, nil
 }
 
+func (m *MockProxy) AlterCollection(ctx context.Context, request *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	return nil, nil
+}
+
 func (m *MockProxy) SetRootCoordClient(rootCoord types.RootCoord) {
 
 }
@@ -565,6 +641,10 @@ func (m *MockProxy) SetQueryCoordClient(queryCoord types.QueryCoord) {
 
 }
 
+func (m *MockProxy) SetQueryNodeCreator(creator func(ctx context.Context, addr string) (types.QueryNode, error)) {
+
+}
+
 func (m *MockProxy) UpdateStateCode(stateCode internalpb.StateCode) {
 
 }
@@ -607,6 +687,21 @@ func Test_NewServer(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("SetRateLimit", func(t *testing.T) {
+		_, err := server.SetRateLimit(ctx, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("UpdateConfig", func(t *testing.T) {
+		_, err := server.UpdateConfig(ctx, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("SetLogLevel", func(t *testing.T) {
+		_, err := server.SetLogLevel(ctx, nil)
+		assert.Nil(t, err)
+	})
+
 	t.Run("CreateCollection", func(t *testing.T) {
 		_, err := server.CreateCollection(ctx, nil)
 		assert.Nil(t, err)
@@ -752,6 +847,21 @@ func Test_NewServer(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("LoadBalance", func(t *testing.T) {
+		_, err := server.LoadBalance(ctx, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		_, err := server.Import(ctx, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("GetImportState", func(t *testing.T) {
+		_, err := server.GetImportState(ctx, nil)
+		assert.Nil(t, err)
+	})
+
 	t.Run("Dummy", func(t *testing.T) {
 		_, err := server.Dummy(ctx, nil)
 		assert.Nil(t, err)
@@ -782,6 +892,11 @@ func Test_NewServer(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("AlterCollection", func(t *testing.T) {
+		_, err := server.AlterCollection(ctx, nil)
+		assert.Nil(t, err)
+	})
+
 	err = server.Stop()
 	assert.Nil(t, err)
 }
@@ -82,6 +82,18 @@ func (m *MockQueryCoordClient) GetSegmentInfo(ctx context.Context, in *querypb.G
 	return &querypb.GetSegmentInfoResponse{}, m.err
 }
 
+func (m *MockQueryCoordClient) GetShardLeaders(ctx context.Context, in *querypb.GetShardLeadersRequest, opts ...grpc.CallOption) (*querypb.GetShardLeadersResponse, error) {
+	return &querypb.GetShardLeadersResponse{}, m.err
+}
+
+func (m *MockQueryCoordClient) LoadBalance(ctx context.Context, in *querypb.LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
+func (m *MockQueryCoordClient) ReleaseSegments(ctx context.Context, in *querypb.ReleaseSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
 func (m *MockQueryCoordClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	return &milvuspb.GetMetricsResponse{}, m.err
 }
@@ -156,6 +168,15 @@ func Test_NewClient(t *testing.T) {
 		r14, err := client.GetSegmentInfo(ctx, nil)
 		retCheck(retNotNil, r14, err)
 
+		r16, err := client.GetShardLeaders(ctx, nil)
+		retCheck(retNotNil, r16, err)
+
+		r17, err := client.LoadBalance(ctx, nil)
+		retCheck(retNotNil, r17, err)
+
+		r18, err := client.ReleaseSegments(ctx, nil)
+		retCheck(retNotNil, r18, err)
+
 		r15, err := client.GetMetrics(ctx, nil)
 		retCheck(retNotNil, r15, err)
 	}
@@ -18,6 +18,7 @@ package grpcquerycoord
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"strconv"
@@ -26,12 +27,14 @@ import (
 
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	dsc "github.com/milvus-io/milvus/internal/distributed/datacoord/client"
+	isc "github.com/milvus-io/milvus/internal/distributed/indexcoord/client"
 	rcc "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
 	qc "github.com/milvus-io/milvus/internal/querycoord"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -55,8 +58,9 @@ type Server struct {
 
 	msFactory msgstream.Factory
 
-	dataCoord types.DataCoord
-	rootCoord types.RootCoord
+	dataCoord  types.DataCoord
+	rootCoord  types.RootCoord
+	indexCoord types.IndexCoord
 
 	closer io.Closer
 }
@@ -178,6 +182,36 @@ func (s *Server) init() error {
 	}
 	log.Debug("QueryCoord report DataCoord ready")
 
+	// --- IndexCoord client ---
+	log.Debug("QueryCoord try to new IndexCoord client")
+
+	if s.indexCoord == nil {
+		s.indexCoord, err = isc.NewClient(s.loopCtx, qc.Params.MetaRootPath, qc.Params.EtcdEndpoints)
+		if err != nil {
+			log.Debug("QueryCoord try to new IndexCoord client failed", zap.Error(err))
+			panic(err)
+		}
+	}
+
+	if err = s.indexCoord.Init(); err != nil {
+		log.Debug("QueryCoord IndexCoordClient Init failed", zap.Error(err))
+		panic(err)
+	}
+	if err = s.indexCoord.Start(); err != nil {
+		log.Debug("QueryCoord IndexCoordClient Start failed", zap.Error(err))
+		panic(err)
+	}
+	log.Debug("QueryCoord try to wait for IndexCoord ready")
+	err = funcutil.WaitForComponentInitOrHealthy(s.loopCtx, s.indexCoord, "IndexCoord", 1000000, time.Millisecond*200)
+	if err != nil {
+		log.Debug("QueryCoord wait for IndexCoord ready failed", zap.Error(err))
+		panic(err)
+	}
+	if err := s.SetIndexCoord(s.indexCoord); err != nil {
+		panic(err)
+	}
+	log.Debug("QueryCoord report IndexCoord ready")
+
 	s.queryCoord.UpdateStateCode(internalpb.StateCode_Initializing)
 	log.Debug("QueryCoord", zap.Any("State", internalpb.StateCode_Initializing))
 	if err := s.queryCoord.Init(); err != nil {
@@ -245,6 +279,20 @@ func (s *Server) SetDataCoord(d types.DataCoord) error {
 	return nil
 }
 
+func (s *Server) SetIndexCoord(i types.IndexCoord) error {
+	s.queryCoord.SetIndexCoord(i)
+	return nil
+}
+
+// CheckReady checks whether the QueryCoord served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.queryCoord.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("QueryCoord does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 func (s *Server) GetComponentStates(ctx context.Context, req *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	return s.queryCoord.GetComponentStates(ctx)
 }
@@ -261,12 +309,21 @@ func (s *Server) ShowCollections(ctx context.Context, req *querypb.ShowCollectio
 	return s.queryCoord.ShowCollections(ctx, req)
 }
 
+// failedStatusResponse drops the error of a request the status reports as failed,
+// a grpc error would discard the error code and detail of the status and make the client retry
+func failedStatusResponse(status *commonpb.Status, err error) (*commonpb.Status, error) {
+	if err != nil && status != nil && status.ErrorCode != commonpb.ErrorCode_Success {
+		return status, nil
+	}
+	return status, err
+}
+
 func (s *Server) LoadCollection(ctx context.Context, req *querypb.LoadCollectionRequest) (*commonpb.Status, error) {
-	return s.queryCoord.LoadCollection(ctx, req)
+	return failedStatusResponse(s.queryCoord.LoadCollection(ctx, req))
 }
 
 func (s *Server) ReleaseCollection(ctx context.Context, req *querypb.ReleaseCollectionRequest) (*commonpb.Status, error) {
-	return s.queryCoord.ReleaseCollection(ctx, req)
+	return failedStatusResponse(s.queryCoord.ReleaseCollection(ctx, req))
 }
 
 func (s *Server) ShowPartitions(ctx context.Context, req *querypb.ShowPartitionsRequest) (*querypb.ShowPartitionsResponse, error) {
@@ -278,11 +335,11 @@ func (s *Server) GetPartitionStates(ctx context.Context, req *querypb.GetPartiti
 }
 
 func (s *Server) LoadPartitions(ctx context.Context, req *querypb.LoadPartitionsRequest) (*commonpb.Status, error) {
-	return s.queryCoord.LoadPartitions(ctx, req)
+	return failedStatusResponse(s.queryCoord.LoadPartitions(ctx, req))
 }
 
 func (s *Server) ReleasePartitions(ctx context.Context, req *querypb.ReleasePartitionsRequest) (*commonpb.Status, error) {
-	return s.queryCoord.ReleasePartitions(ctx, req)
+	return failedStatusResponse(s.queryCoord.ReleasePartitions(ctx, req))
 }
 
 func (s *Server) CreateQueryChannel(ctx context.Context, req *querypb.CreateQueryChannelRequest) (*querypb.CreateQueryChannelResponse, error) {
@@ -293,6 +350,21 @@ func (s *Server) GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfo
 	return s.queryCoord.GetSegmentInfo(ctx, req)
 }
 
+// GetShardLeaders gets the query nodes serving the dm channels of a collection.
+func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+	return s.queryCoord.GetShardLeaders(ctx, req)
+}
+
+// LoadBalance moves the sealed segments off the source query nodes.
+func (s *Server) LoadBalance(ctx context.Context, req *querypb.LoadBalanceRequest) (*commonpb.Status, error) {
+	return failedStatusResponse(s.queryCoord.LoadBalance(ctx, req))
+}
+
+// ReleaseSegments releases the segments dropped by DataCoord from the query nodes.
+func (s *Server) ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	return failedStatusResponse(s.queryCoord.ReleaseSegments(ctx, req))
+}
+
 func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return s.queryCoord.GetMetrics(ctx, req)
 }
@@ -46,6 +46,7 @@ type MockQueryCoord struct {
 	partResp     *querypb.GetPartitionStatesResponse
 	channelResp  *querypb.CreateQueryChannelResponse
 	infoResp     *querypb.GetSegmentInfoResponse
+	leaderResp   *querypb.GetShardLeadersResponse
 	metricResp   *milvuspb.GetMetricsResponse
 }
 
@@ -77,6 +78,10 @@ func (m *MockQueryCoord) SetDataCoord(types.DataCoord) error {
 	return nil
 }
 
+func (m *MockQueryCoord) SetIndexCoord(types.IndexCoord) error {
+	return nil
+}
+
 func (m *MockQueryCoord) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
 	log.Debug("MockQueryCoord::WaitForComponentStates")
 	return m.states, m.err
@@ -126,6 +131,18 @@ func (m *MockQueryCoord) GetSegmentInfo(ctx context.Context, req *querypb.GetSeg
 	return m.infoResp, m.err
 }
 
+func (m *MockQueryCoord) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+	return m.leaderResp, m.err
+}
+
+func (m *MockQueryCoord) LoadBalance(ctx context.Context, req *querypb.LoadBalanceRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
+func (m *MockQueryCoord) ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	return m.status, m.err
+}
+
 func (m *MockQueryCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return m.metricResp, m.err
 }
@@ -196,6 +213,39 @@ func (m *MockDataCoord) GetComponentStates(ctx context.Context) (*internalpb.Com
 	}, nil
 }
 
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+type MockIndexCoord struct {
+	types.IndexCoord
+	initErr  error
+	startErr error
+	stopErr  error
+	regErr   error
+	stateErr commonpb.ErrorCode
+}
+
+func (m *MockIndexCoord) Init() error {
+	return m.initErr
+}
+
+func (m *MockIndexCoord) Start() error {
+	return m.startErr
+}
+
+func (m *MockIndexCoord) Stop() error {
+	return m.stopErr
+}
+
+func (m *MockIndexCoord) Register() error {
+	return m.regErr
+}
+
+func (m *MockIndexCoord) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
+	return &internalpb.ComponentStates{
+		State:  &internalpb.ComponentInfo{StateCode: internalpb.StateCode_Healthy},
+		Status: &commonpb.Status{ErrorCode: m.stateErr},
+	}, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 func Test_NewServer(t *testing.T) {
 	ctx := context.Background()
@@ -216,6 +266,7 @@ func Test_NewServer(t *testing.T) {
 		partResp:     &querypb.GetPartitionStatesResponse{},
 		channelResp:  &querypb.CreateQueryChannelResponse{},
 		infoResp:     &querypb.GetSegmentInfoResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
+		leaderResp:   &querypb.GetShardLeadersResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 		metricResp:   &milvuspb.GetMetricsResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 	}
 
@@ -227,10 +278,15 @@ func Test_NewServer(t *testing.T) {
 		stateErr: commonpb.ErrorCode_Success,
 	}
 
+	mic := &MockIndexCoord{
+		stateErr: commonpb.ErrorCode_Success,
+	}
+
 	t.Run("Run", func(t *testing.T) {
 		server.queryCoord = mqc
 		server.dataCoord = mdc
 		server.rootCoord = mrc
+		server.indexCoord = mic
 
 		err = server.Run()
 		assert.Nil(t, err)
@@ -317,6 +373,27 @@ func Test_NewServer(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	t.Run("GetShardLeaders", func(t *testing.T) {
+		req := &querypb.GetShardLeadersRequest{}
+		resp, err := server.GetShardLeaders(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("LoadBalance", func(t *testing.T) {
+		req := &querypb.LoadBalanceRequest{}
+		resp, err := server.LoadBalance(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("ReleaseSegments", func(t *testing.T) {
+		req := &querypb.ReleaseSegmentsRequest{}
+		resp, err := server.ReleaseSegments(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
@@ -408,3 +485,55 @@ func TestServer_Run5(t *testing.T) {
 	err = server.Stop()
 	assert.Nil(t, err)
 }
+
+func TestServer_Run6(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewServer(ctx, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, server)
+
+	server.queryCoord = &MockQueryCoord{}
+	server.rootCoord = &MockRootCoord{}
+	server.dataCoord = &MockDataCoord{}
+	server.indexCoord = &MockIndexCoord{
+		initErr: errors.New("error"),
+	}
+	assert.Panics(t, func() { server.Run() })
+	err = server.Stop()
+	assert.Nil(t, err)
+}
+
+func TestServer_Run7(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewServer(ctx, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, server)
+
+	server.queryCoord = &MockQueryCoord{}
+	server.rootCoord = &MockRootCoord{}
+	server.dataCoord = &MockDataCoord{}
+	server.indexCoord = &MockIndexCoord{
+		startErr: errors.New("error"),
+	}
+	assert.Panics(t, func() { server.Run() })
+	err = server.Stop()
+	assert.Nil(t, err)
+}
+
+func Test_failedStatusResponse(t *testing.T) {
+	failed := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_OutOfMemory,
+		Detail:    "OutOfMemory",
+	}
+	status, err := failedStatusResponse(failed, errors.New("mock"))
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_OutOfMemory, status.ErrorCode)
+	assert.Equal(t, "OutOfMemory", status.Detail)
+
+	_, err = failedStatusResponse(nil, errors.New("mock"))
+	assert.NotNil(t, err)
+
+	status, err = failedStatusResponse(&commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+}
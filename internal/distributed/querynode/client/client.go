@@ -19,6 +19,7 @@ package grpcquerynodeclient
 import (
 	"context"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -369,6 +370,51 @@ func (c *Client) GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfo
 	return ret.(*querypb.GetSegmentInfoResponse), err
 }
 
+// Search searches a shard of a collection on QueryNode.
+func (c *Client) Search(ctx context.Context, req *querypb.SearchRequest) (*querypb.SearchResponse, error) {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.Search(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*querypb.SearchResponse), err
+}
+
+// FetchSegmentBinlogs fetches the binlogs of a segment cached on QueryNode, each chunk received is passed to `recv`.
+// The stream is not retried once it's opened, since the chunks received can't be taken back.
+func (c *Client) FetchSegmentBinlogs(ctx context.Context, req *querypb.FetchSegmentBinlogsRequest, recv func(*querypb.SegmentBinlogChunk) error) error {
+	ret, err := c.recall(func() (interface{}, error) {
+		client, err := c.getGrpcClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.FetchSegmentBinlogs(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return err
+	}
+	stream := ret.(querypb.QueryNode_FetchSegmentBinlogsClient)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := recv(chunk); err != nil {
+			return err
+		}
+	}
+}
+
 // GetMetrics gets the metrics information of QueryNode.
 func (c *Client) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	ret, err := c.recall(func() (interface{}, error) {
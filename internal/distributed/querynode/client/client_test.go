@@ -19,6 +19,7 @@ package grpcquerynodeclient
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -77,10 +78,35 @@ func (m *MockQueryNodeClient) GetSegmentInfo(ctx context.Context, in *querypb.Ge
 	return &querypb.GetSegmentInfoResponse{}, m.err
 }
 
+func (m *MockQueryNodeClient) Search(ctx context.Context, in *querypb.SearchRequest, opts ...grpc.CallOption) (*querypb.SearchResponse, error) {
+	return &querypb.SearchResponse{}, m.err
+}
+
+func (m *MockQueryNodeClient) FetchSegmentBinlogs(ctx context.Context, in *querypb.FetchSegmentBinlogsRequest, opts ...grpc.CallOption) (querypb.QueryNode_FetchSegmentBinlogsClient, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &mockFetchSegmentBinlogsClient{chunks: []*querypb.SegmentBinlogChunk{{Path: "a", Last: true}}}, nil
+}
+
 func (m *MockQueryNodeClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	return &milvuspb.GetMetricsResponse{}, m.err
 }
 
+type mockFetchSegmentBinlogsClient struct {
+	grpc.ClientStream
+	chunks []*querypb.SegmentBinlogChunk
+}
+
+func (m *mockFetchSegmentBinlogsClient) Recv() (*querypb.SegmentBinlogChunk, error) {
+	if len(m.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := m.chunks[0]
+	m.chunks = m.chunks[1:]
+	return chunk, nil
+}
+
 func Test_NewClient(t *testing.T) {
 	proxy.Params.InitOnce()
 
@@ -146,8 +172,23 @@ func Test_NewClient(t *testing.T) {
 		r11, err := client.GetSegmentInfo(ctx, nil)
 		retCheck(retNotNil, r11, err)
 
+		r13, err := client.Search(ctx, nil)
+		retCheck(retNotNil, r13, err)
+
 		r12, err := client.GetMetrics(ctx, nil)
 		retCheck(retNotNil, r12, err)
+
+		var chunks []*querypb.SegmentBinlogChunk
+		err = client.FetchSegmentBinlogs(ctx, nil, func(chunk *querypb.SegmentBinlogChunk) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		if retNotNil {
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(chunks))
+		} else {
+			assert.NotNil(t, err)
+		}
 	}
 
 	client.getGrpcClient = func() (querypb.QueryNodeClient, error) {
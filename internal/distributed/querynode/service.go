@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/retry"
 
 	"github.com/milvus-io/milvus/internal/types"
@@ -122,13 +123,11 @@ func (s *Server) init() error {
 		log.Debug("QueryNode RootCoordClient Start failed", zap.Error(err))
 		panic(err)
 	}
-	log.Debug("QueryNode start to wait for RootCoord ready")
 	err = funcutil.WaitForComponentHealthy(s.ctx, s.rootCoord, "RootCoord", 1000000, time.Millisecond*200)
 	if err != nil {
 		log.Debug("QueryNode wait for RootCoord ready failed", zap.Error(err))
 		panic(err)
 	}
-	log.Debug("QueryNode report RootCoord is ready")
 
 	if err := s.SetRootCoord(s.rootCoord); err != nil {
 		panic(err)
@@ -154,13 +153,11 @@ func (s *Server) init() error {
 		panic(err)
 	}
 	// wait IndexCoord healthy
-	log.Debug("QueryNode start to wait for IndexCoord ready")
 	err = funcutil.WaitForComponentHealthy(s.ctx, s.indexCoord, "IndexCoord", 1000000, time.Millisecond*200)
 	if err != nil {
 		log.Debug("QueryNode wait for IndexCoord ready failed", zap.Error(err))
 		panic(err)
 	}
-	log.Debug("QueryNode report IndexCoord is ready")
 
 	if err := s.SetIndexCoord(s.indexCoord); err != nil {
 		panic(err)
@@ -284,6 +281,15 @@ func (s *Server) GetStatisticsChannel(ctx context.Context, req *internalpb.GetSt
 	return s.querynode.GetStatisticsChannel(ctx)
 }
 
+// CheckReady checks whether the QueryNode served is ready, it implements healthz.Checker
+func (s *Server) CheckReady(ctx context.Context) error {
+	checker, ok := s.querynode.(healthz.Checker)
+	if !ok {
+		return fmt.Errorf("QueryNode does not support the readiness check")
+	}
+	return checker.CheckReady(ctx)
+}
+
 // GetComponentStates gets the component states of QueryNode.
 func (s *Server) GetComponentStates(ctx context.Context, req *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error) {
 	// ignore ctx and in
@@ -337,6 +343,16 @@ func (s *Server) GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfo
 	return s.querynode.GetSegmentInfo(ctx, req)
 }
 
+// Search searches a shard of a collection in QueryNode.
+func (s *Server) Search(ctx context.Context, req *querypb.SearchRequest) (*querypb.SearchResponse, error) {
+	return s.querynode.Search(ctx, req)
+}
+
+// FetchSegmentBinlogs streams the binlogs of a segment cached in QueryNode.
+func (s *Server) FetchSegmentBinlogs(req *querypb.FetchSegmentBinlogsRequest, stream querypb.QueryNode_FetchSegmentBinlogsServer) error {
+	return s.querynode.FetchSegmentBinlogs(stream.Context(), req, stream.Send)
+}
+
 // GetMetrics gets the metrics information of QueryNode.
 func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return s.querynode.GetMetrics(ctx, req)
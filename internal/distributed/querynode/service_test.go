@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 )
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -41,6 +42,7 @@ type MockQueryNode struct {
 	stopErr    error
 	strResp    *milvuspb.StringResponse
 	infoResp   *querypb.GetSegmentInfoResponse
+	searchResp *querypb.SearchResponse
 	metricResp *milvuspb.GetMetricsResponse
 }
 
@@ -104,6 +106,17 @@ func (m *MockQueryNode) GetSegmentInfo(ctx context.Context, req *querypb.GetSegm
 	return m.infoResp, m.err
 }
 
+func (m *MockQueryNode) Search(ctx context.Context, req *querypb.SearchRequest) (*querypb.SearchResponse, error) {
+	return m.searchResp, m.err
+}
+
+func (m *MockQueryNode) FetchSegmentBinlogs(ctx context.Context, req *querypb.FetchSegmentBinlogsRequest, send func(*querypb.SegmentBinlogChunk) error) error {
+	if err := send(&querypb.SegmentBinlogChunk{Status: m.status}); err != nil {
+		return err
+	}
+	return m.err
+}
+
 func (m *MockQueryNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return m.metricResp, m.err
 }
@@ -185,6 +198,22 @@ func (m *MockIndexCoord) GetComponentStates(ctx context.Context) (*internalpb.Co
 	}, nil
 }
 
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+type mockFetchSegmentBinlogsServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*querypb.SegmentBinlogChunk
+}
+
+func (m *mockFetchSegmentBinlogsServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockFetchSegmentBinlogsServer) Send(chunk *querypb.SegmentBinlogChunk) error {
+	m.chunks = append(m.chunks, chunk)
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 func Test_NewServer(t *testing.T) {
 	ctx := context.Background()
@@ -198,6 +227,7 @@ func Test_NewServer(t *testing.T) {
 		err:        nil,
 		strResp:    &milvuspb.StringResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 		infoResp:   &querypb.GetSegmentInfoResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
+		searchResp: &querypb.SearchResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 		metricResp: &milvuspb.GetMetricsResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}},
 	}
 	server.querynode = mqn
@@ -287,6 +317,21 @@ func Test_NewServer(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	t.Run("Search", func(t *testing.T) {
+		req := &querypb.SearchRequest{}
+		resp, err := server.Search(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("FetchSegmentBinlogs", func(t *testing.T) {
+		stream := &mockFetchSegmentBinlogsServer{ctx: ctx}
+		err := server.FetchSegmentBinlogs(&querypb.FetchSegmentBinlogsRequest{}, stream)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(stream.chunks))
+		assert.Equal(t, commonpb.ErrorCode_Success, stream.chunks[0].Status.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
@@ -74,6 +74,10 @@ func (m *MockRootCoordClient) AlterAlias(ctx context.Context, in *milvuspb.Alter
 	return &commonpb.Status{}, m.err
 }
 
+func (m *MockRootCoordClient) AlterCollection(ctx context.Context, in *milvuspb.AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	return &commonpb.Status{}, m.err
+}
+
 func (m *MockRootCoordClient) ShowCollections(ctx context.Context, in *milvuspb.ShowCollectionsRequest, opts ...grpc.CallOption) (*milvuspb.ShowCollectionsResponse, error) {
 	return &milvuspb.ShowCollectionsResponse{}, m.err
 }
@@ -243,6 +247,9 @@ func Test_NewClient(t *testing.T) {
 
 		r26, err := client.AlterAlias(ctx, nil)
 		retCheck(retNotNil, r26, err)
+
+		r27, err := client.AlterCollection(ctx, nil)
+		retCheck(retNotNil, r27, err)
 	}
 
 	client.getGrpcClient = func() (rootcoordpb.RootCoordClient, error) {
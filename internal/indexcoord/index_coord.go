@@ -14,9 +14,12 @@ package indexcoord
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
+	"path"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,13 +33,20 @@ import (
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/tso"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
+	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/trace"
@@ -44,8 +54,13 @@ import (
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
-// make sure IndexCoord implements types.IndexCoord
-var _ types.IndexCoord = (*IndexCoord)(nil)
+const (
+	recycleTypeDeleted    = "deleted"
+	recycleTypeLowVersion = "low_version"
+)
+
+// make sure IndexCoord implements types.IndexCoordComponent
+var _ types.IndexCoordComponent = (*IndexCoord)(nil)
 
 // IndexCoord is a component responsible for scheduling index construction tasks and maintaining index status.
 // IndexCoord accepts requests from rootcoord to build indexes, delete indexes, and query index information.
@@ -67,12 +82,16 @@ type IndexCoord struct {
 
 	idAllocator *allocator.GlobalIDAllocator
 
-	kv kv.BaseKV
+	kv           kv.BaseKV
+	chunkManager storage.ChunkManager
 
 	metaTable   *metaTable
 	nodeManager *NodeManager
 
+	dataCoord types.DataCoord
+
 	metricsCacheManager *metricsinfo.MetricsCacheManager
+	configUpdater       *paramtable.ConfigUpdater
 
 	nodeLock sync.RWMutex
 
@@ -108,6 +127,15 @@ func NewIndexCoord(ctx context.Context) (*IndexCoord, error) {
 	return i, nil
 }
 
+// SetDataCoord sets the DataCoord client, which provides the flushed segments to get the index build progress.
+func (i *IndexCoord) SetDataCoord(dataCoord types.DataCoord) error {
+	if dataCoord == nil {
+		return errors.New("null DataCoord interface")
+	}
+	i.dataCoord = dataCoord
+	return nil
+}
+
 // Register register IndexCoord role at etcd.
 func (i *IndexCoord) Register() error {
 	i.session = sessionutil.NewSession(i.loopCtx, Params.MetaRootPath, Params.EtcdEndpoints)
@@ -167,7 +195,7 @@ func (i *IndexCoord) Init() error {
 
 		}
 		log.Debug("IndexCoord", zap.Int("IndexNode number", len(i.nodeManager.nodeClients)))
-		i.eventChan = i.session.WatchServices(typeutil.IndexNodeRole, revision+1)
+		i.eventChan = i.session.WatchServices(typeutil.IndexNodeRole, revision+1, sessionutil.WithReregisterGrace(Params.SessionReregisterGrace))
 		nodeTasks := i.metaTable.GetNodeTaskStats()
 		for nodeID, taskNum := range nodeTasks {
 			i.nodeManager.pq.UpdatePriority(nodeID, taskNum)
@@ -204,13 +232,15 @@ func (i *IndexCoord) Init() error {
 			CreateBucket:      true,
 		}
 
-		i.kv, err = miniokv.NewMinIOKV(i.loopCtx, option)
+		factory := storage.NewChunkManagerFactory(Params.StorageType, Params.LocalStoragePath, Params.LocalStorageSyncOnWrite, option)
+		i.chunkManager, err = factory.NewChunkManager(i.loopCtx)
 		if err != nil {
-			log.Error("IndexCoord new minio kv failed", zap.Error(err))
+			log.Error("IndexCoord new chunk manager failed", zap.String("storageType", Params.StorageType), zap.Error(err))
 			initErr = err
 			return
 		}
-		log.Debug("IndexCoord new minio kv success")
+		i.kv = storage.NewChunkManagerKV(i.chunkManager)
+		log.Debug("IndexCoord new chunk manager success", zap.String("storageType", Params.StorageType))
 
 		i.sched, err = NewTaskScheduler(i.loopCtx, i.idAllocator, i.kv, i.metaTable)
 		if err != nil {
@@ -221,6 +251,7 @@ func (i *IndexCoord) Init() error {
 		log.Debug("IndexCoord new task scheduler success")
 
 		i.metricsCacheManager = metricsinfo.NewMetricsCacheManager()
+		i.configUpdater = paramtable.NewConfigUpdater(&Params.BaseTable)
 	})
 
 	log.Debug("IndexCoord init finished", zap.Error(initErr))
@@ -310,6 +341,11 @@ func (i *IndexCoord) GetComponentStates(ctx context.Context) (*internalpb.Compon
 	return ret, nil
 }
 
+// CheckReady checks whether IndexCoord is healthy and its session is registered in etcd
+func (i *IndexCoord) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, i, i.session)
+}
+
 // GetTimeTickChannel gets the time tick channel of IndexCoord.
 func (i *IndexCoord) GetTimeTickChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
 	log.Debug("get IndexCoord time tick channel ...")
@@ -334,10 +370,98 @@ func (i *IndexCoord) GetStatisticsChannel(ctx context.Context) (*milvuspb.String
 	}, nil
 }
 
+// CreateIndexDefinition creates an index on a field of a collection, the same index can be created repeatedly, and a
+// field can only have one index until it's dropped.
+func (i *IndexCoord) CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error) {
+	definition := req.GetDefinition()
+	log.Debug("IndexCoord CreateIndexDefinition", zap.Int64("collectionID", definition.GetCollectionID()),
+		zap.Int64("fieldID", definition.GetFieldID()), zap.String("indexName", definition.GetIndexName()),
+		zap.Int64("indexID", definition.GetIndexID()), zap.Any("IndexParams", definition.GetIndexParams()))
+	sp, _ := trace.StartSpanFromContextWithOperationName(ctx, "IndexCoord-CreateIndexDefinition")
+	defer sp.Finish()
+
+	ret := &indexpb.CreateIndexDefinitionResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if !i.isHealthy() {
+		ret.Status.Reason = msgIndexCoordIsUnhealthy(i.ID)
+		return ret, nil
+	}
+	if definition == nil {
+		ret.Status.Reason = "index definition is nil"
+		return ret, nil
+	}
+	if err := checkIndexDefinition(definition); err != nil {
+		log.Warn("IndexCoord CreateIndexDefinition with invalid params", zap.Int64("collectionID", definition.CollectionID),
+			zap.Int64("fieldID", definition.FieldID), zap.Error(err))
+		ret.Status.ErrorCode = commonpb.ErrorCode_IllegalArgument
+		ret.Status.Reason = err.Error()
+		return ret, nil
+	}
+	indexID, err := i.metaTable.AddIndexDefinition(definition)
+	if err != nil {
+		log.Warn("IndexCoord CreateIndexDefinition failed", zap.Int64("collectionID", definition.CollectionID),
+			zap.Int64("fieldID", definition.FieldID), zap.Error(err))
+		ret.Status.Reason = err.Error()
+		return ret, nil
+	}
+	log.Debug("IndexCoord CreateIndexDefinition success", zap.Int64("collectionID", definition.CollectionID),
+		zap.Int64("fieldID", definition.FieldID), zap.Int64("indexID", indexID))
+
+	ret.Status.ErrorCode = commonpb.ErrorCode_Success
+	ret.IndexID = indexID
+	return ret, nil
+}
+
+// checkIndexDefinition checks the index params against the index type and the field, so that the invalid params
+// fail the creation of the index instead of the builds on index nodes after downloading the binlogs
+func checkIndexDefinition(definition *indexpb.IndexDefinition) error {
+	params := make(map[string]string)
+	for _, kv := range definition.GetIndexParams() {
+		if kv.Key != "params" {
+			params[kv.Key] = kv.Value
+			continue
+		}
+		extra, err := funcutil.ParseIndexParamsMap(kv.Value)
+		if err != nil {
+			return fmt.Errorf("invalid index params %s: %w", kv.Value, err)
+		}
+		for k, v := range extra {
+			params[k] = v
+		}
+	}
+	for _, kv := range definition.GetTypeParams() {
+		params[kv.Key] = kv.Value
+	}
+	indexType, ok := params[indexparamcheck.IndexTypeKey]
+	if !ok {
+		indexType = indexparamcheck.IndexFaissIvfPQ // IVF_PQ is the default index type
+	}
+	return indexparamcheck.CheckIndexParams(indexType, definition.GetFieldType(), params)
+}
+
+// minSegmentRows returns the threshold of segment rows to build index for the request, the collection may override
+// the default one. The requests without collection are sent by the older versions of RootCoord, which have skipped
+// the small segments, so they are never skipped.
+func minSegmentRows(req *indexpb.BuildIndexRequest) int64 {
+	if req.CollectionID == 0 {
+		return 0
+	}
+	if req.MinSegmentRows > 0 {
+		return req.MinSegmentRows
+	}
+	return Params.MinSegmentSizeToEnableIndex
+}
+
 // BuildIndex receives request from RootCoordinator to build an index.
 // Index building is asynchronous, so when an index building request comes, an IndexBuildID is assigned to the task and
 // the task is recorded in Meta. The background process assignTaskLoop will find this task and assign it to IndexNode for
 // execution.
+// The builds of the segments smaller than the threshold are skipped to avoid many tiny index files, these segments are
+// searched by brute force until compaction merges them into a larger segment, which is indexed when it's flushed.
+// A skipped build returns IndexBuildID 0.
 func (i *IndexCoord) BuildIndex(ctx context.Context, req *indexpb.BuildIndexRequest) (*indexpb.BuildIndexResponse, error) {
 	log.Debug("IndexCoord building index ...",
 		zap.Int64("IndexBuildID", req.IndexBuildID),
@@ -364,6 +488,17 @@ func (i *IndexCoord) BuildIndex(ctx context.Context, req *indexpb.BuildIndexRequ
 			ErrorCode: commonpb.ErrorCode_UnexpectedError,
 		},
 	}
+	if threshold := minSegmentRows(req); req.NumRows < threshold {
+		if err := i.metaTable.SkipIndex(req); err != nil {
+			ret.Status.Reason = err.Error()
+			return ret, nil
+		}
+		log.Debug("IndexCoord BuildIndex skipped, the segment is too small", zap.Int64("segmentID", req.SegmentID),
+			zap.Int64("numRows", req.NumRows), zap.Int64("minSegmentRows", threshold))
+		ret.Status.ErrorCode = commonpb.ErrorCode_Success
+		ret.Status.Reason = "segment is too small to build index"
+		return ret, nil
+	}
 	t := &IndexAddTask{
 		BaseTask: BaseTask{
 			ctx:   ctx,
@@ -456,7 +591,7 @@ func (i *IndexCoord) DropIndex(ctx context.Context, req *indexpb.DropIndexReques
 	ret := &commonpb.Status{
 		ErrorCode: commonpb.ErrorCode_Success,
 	}
-	err := i.metaTable.MarkIndexAsDeleted(req.IndexID)
+	inProgress, err := i.metaTable.MarkIndexAsDeleted(req.IndexID)
 	if err != nil {
 		ret.ErrorCode = commonpb.ErrorCode_UnexpectedError
 		ret.Reason = err.Error()
@@ -469,6 +604,7 @@ func (i *IndexCoord) DropIndex(ctx context.Context, req *indexpb.DropIndexReques
 			for _, indexBuildID := range unissuedIndexBuildIDs {
 				i.metaTable.DeleteIndex(indexBuildID)
 			}
+			i.cancelIndexBuilds(inProgress)
 		}()
 	}()
 
@@ -476,6 +612,240 @@ func (i *IndexCoord) DropIndex(ctx context.Context, req *indexpb.DropIndexReques
 	return ret, nil
 }
 
+// DropSegmentIndex drops the index builds of a segment dropped by DataCoord. The builds are marked as deleted like the
+// builds of a dropped index, so the builds in progress are cancelled and the index files are removed by the recycler.
+func (i *IndexCoord) DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error) {
+	log.Debug("IndexCoord DropSegmentIndex", zap.Int64("segmentID", req.SegmentID))
+	sp, _ := trace.StartSpanFromContextWithOperationName(ctx, "IndexCoord-DropSegmentIndex")
+	defer sp.Finish()
+
+	ret := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_UnexpectedError,
+	}
+	if !i.isHealthy() {
+		ret.Reason = msgIndexCoordIsUnhealthy(i.ID)
+		return ret, nil
+	}
+	inProgress, err := i.metaTable.MarkSegmentIndexAsDeleted(req.SegmentID)
+	if err != nil {
+		ret.Reason = err.Error()
+		return ret, nil
+	}
+
+	go func() {
+		unissuedIndexBuildIDs := i.sched.IndexAddQueue.tryToRemoveSegmentIndexAddTask(req.SegmentID)
+		for _, indexBuildID := range unissuedIndexBuildIDs {
+			i.metaTable.DeleteIndex(indexBuildID)
+		}
+		i.cancelIndexBuilds(inProgress)
+	}()
+
+	log.Debug("IndexCoord DropSegmentIndex success", zap.Int64("segmentID", req.SegmentID),
+		zap.Int("inProgress", len(inProgress)))
+	ret.ErrorCode = commonpb.ErrorCode_Success
+	return ret, nil
+}
+
+// cancelIndexBuilds notifies the IndexNodes to cancel the builds of a dropped index, the builds are finished in meta,
+// so the load of the IndexNodes is released here.
+func (i *IndexCoord) cancelIndexBuilds(metas []Meta) {
+	nodeBuildIDs := make(map[UniqueID][]UniqueID)
+	for _, meta := range metas {
+		nodeBuildIDs[meta.indexMeta.NodeID] = append(nodeBuildIDs[meta.indexMeta.NodeID], meta.indexMeta.IndexBuildID)
+	}
+	for nodeID, indexBuildIDs := range nodeBuildIDs {
+		i.nodeManager.pq.IncPriority(nodeID, -len(indexBuildIDs))
+		client, ok := i.nodeManager.GetClientByID(nodeID)
+		if !ok {
+			log.Warn("IndexCoord cancelIndexBuilds IndexNode is offline", zap.Int64("nodeID", nodeID))
+			continue
+		}
+		ctx, cancel := context.WithTimeout(i.loopCtx, i.reqTimeoutInterval)
+		resp, err := client.CancelIndexBuild(ctx, &indexpb.CancelIndexBuildRequest{
+			Base: &commonpb.MsgBase{
+				SourceID: i.session.ServerID,
+			},
+			IndexBuildIDs: indexBuildIDs,
+		})
+		cancel()
+		if err != nil || resp.GetErrorCode() != commonpb.ErrorCode_Success {
+			// the IndexNode can't save the index files of the builds anyway, which are fenced by the new version
+			log.Warn("IndexCoord cancelIndexBuilds failed", zap.Int64("nodeID", nodeID),
+				zap.Int64s("indexBuildIDs", indexBuildIDs), zap.String("reason", resp.GetReason()), zap.Error(err))
+			continue
+		}
+		log.Debug("IndexCoord cancelIndexBuilds success", zap.Int64("nodeID", nodeID), zap.Int64s("indexBuildIDs", indexBuildIDs))
+	}
+}
+
+// GetIndexBuildProgress gets the indexed rows and total rows of the flushed segments of a collection for the index on a
+// field, the segments whose builds are skipped are counted as indexed.
+func (i *IndexCoord) GetIndexBuildProgress(ctx context.Context, req *indexpb.GetIndexBuildProgressRequest) (*indexpb.GetIndexBuildProgressResponse, error) {
+	log.Debug("IndexCoord GetIndexBuildProgress", zap.Int64("collectionID", req.CollectionID),
+		zap.Int64("fieldID", req.FieldID), zap.String("indexName", req.IndexName))
+	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "IndexCoord-GetIndexBuildProgress")
+	defer sp.Finish()
+
+	ret := &indexpb.GetIndexBuildProgressResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if !i.isHealthy() {
+		ret.Status.Reason = msgIndexCoordIsUnhealthy(i.ID)
+		return ret, nil
+	}
+	segmentRows, metas, skipped, err := i.getSegmentIndexMetas(ctx, req.CollectionID, req.FieldID, req.IndexName)
+	if err != nil {
+		log.Warn("IndexCoord GetIndexBuildProgress failed", zap.Int64("collectionID", req.CollectionID), zap.Error(err))
+		ret.Status.Reason = err.Error()
+		return ret, nil
+	}
+	for segmentID, rows := range segmentRows {
+		ret.TotalRows += rows
+		if isIndexSkipped(segmentID, rows, metas, skipped) || metas[segmentID].GetState() == commonpb.IndexState_Finished {
+			ret.IndexedRows += rows
+		}
+	}
+	log.Debug("IndexCoord GetIndexBuildProgress success", zap.Int64("collectionID", req.CollectionID),
+		zap.Int64("indexedRows", ret.IndexedRows), zap.Int64("totalRows", ret.TotalRows))
+
+	ret.Status.ErrorCode = commonpb.ErrorCode_Success
+	return ret, nil
+}
+
+// GetIndexState gets the state of the index on a field of a collection, which is rolled up from the builds of the
+// flushed segments. It's Failed if any build failed, InProgress if any build is in progress, Unissued if any segment
+// is waiting to build, and Finished otherwise. The reasons of the failed builds are joined into one.
+func (i *IndexCoord) GetIndexState(ctx context.Context, req *indexpb.GetIndexStateRequest) (*indexpb.GetIndexStateResponse, error) {
+	log.Debug("IndexCoord GetIndexState", zap.Int64("collectionID", req.CollectionID),
+		zap.Int64("fieldID", req.FieldID), zap.String("indexName", req.IndexName))
+	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "IndexCoord-GetIndexState")
+	defer sp.Finish()
+
+	ret := &indexpb.GetIndexStateResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if !i.isHealthy() {
+		ret.Status.Reason = msgIndexCoordIsUnhealthy(i.ID)
+		return ret, nil
+	}
+	segmentRows, metas, skipped, err := i.getSegmentIndexMetas(ctx, req.CollectionID, req.FieldID, req.IndexName)
+	if err != nil {
+		log.Warn("IndexCoord GetIndexState failed", zap.Int64("collectionID", req.CollectionID), zap.Error(err))
+		ret.Status.Reason = err.Error()
+		return ret, nil
+	}
+
+	var (
+		cntUnissued   = 0
+		cntInProgress = 0
+		// segments of the failed builds grouped by the reasons
+		failedSegments = make(map[string][]UniqueID)
+	)
+	for segmentID, rows := range segmentRows {
+		if isIndexSkipped(segmentID, rows, metas, skipped) {
+			continue
+		}
+		meta, ok := metas[segmentID]
+		if !ok {
+			cntUnissued++
+			continue
+		}
+		switch meta.State {
+		case commonpb.IndexState_Unissued, commonpb.IndexState_IndexStateNone:
+			cntUnissued++
+		case commonpb.IndexState_InProgress:
+			cntInProgress++
+		case commonpb.IndexState_Failed:
+			failedSegments[meta.FailReason] = append(failedSegments[meta.FailReason], segmentID)
+		}
+	}
+
+	switch {
+	case len(failedSegments) > 0:
+		ret.State = commonpb.IndexState_Failed
+		ret.FailReason = rollUpFailReasons(failedSegments)
+	case cntInProgress > 0:
+		ret.State = commonpb.IndexState_InProgress
+	case cntUnissued > 0:
+		ret.State = commonpb.IndexState_Unissued
+	default:
+		ret.State = commonpb.IndexState_Finished
+	}
+	log.Debug("IndexCoord GetIndexState success", zap.Int64("collectionID", req.CollectionID),
+		zap.String("state", ret.State.String()), zap.String("failReason", ret.FailReason))
+
+	ret.Status.ErrorCode = commonpb.ErrorCode_Success
+	return ret, nil
+}
+
+// rollUpFailReasons joins the fail reasons of builds into one, the segments with the same reason are listed together.
+func rollUpFailReasons(failedSegments map[string][]UniqueID) string {
+	reasons := make([]string, 0, len(failedSegments))
+	for reason, segmentIDs := range failedSegments {
+		sort.Slice(segmentIDs, func(x, y int) bool { return segmentIDs[x] < segmentIDs[y] })
+		reasons = append(reasons, fmt.Sprintf("index builds of segments %v failed: %s", segmentIDs, reason))
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, "; ")
+}
+
+// isIndexSkipped checks whether the build of an index on a segment is skipped. Besides the recorded skipped builds, the
+// segments smaller than the default threshold without any build are skipped by the older versions of RootCoord.
+func isIndexSkipped(segmentID UniqueID, rows int64, metas map[UniqueID]*indexpb.IndexMeta, skipped map[UniqueID]struct{}) bool {
+	if _, ok := skipped[segmentID]; ok {
+		return true
+	}
+	_, ok := metas[segmentID]
+	return !ok && rows < Params.MinSegmentSizeToEnableIndex
+}
+
+// getSegmentIndexMetas gets the rows of the flushed segments of a collection from DataCoord, the metas of the builds
+// on these segments for the index named indexName on field fieldID, and the segments whose builds are skipped.
+func (i *IndexCoord) getSegmentIndexMetas(ctx context.Context, collectionID, fieldID UniqueID, indexName string) (map[UniqueID]int64, map[UniqueID]*indexpb.IndexMeta, map[UniqueID]struct{}, error) {
+	if i.dataCoord == nil {
+		return nil, nil, nil, errors.New("IndexCoord is not connected to DataCoord")
+	}
+	flushed, err := i.dataCoord.GetFlushedSegments(ctx, &datapb.GetFlushedSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			SourceID: i.session.ServerID,
+		},
+		CollectionID: collectionID,
+		PartitionID:  -1,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if flushed.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, nil, nil, fmt.Errorf("get flushed segments from DataCoord failed, reason = %s", flushed.GetStatus().GetReason())
+	}
+
+	segmentRows := make(map[UniqueID]int64, len(flushed.GetSegments()))
+	if len(flushed.GetSegments()) > 0 {
+		infos, err := i.dataCoord.GetSegmentInfo(ctx, &datapb.GetSegmentInfoRequest{
+			Base: &commonpb.MsgBase{
+				SourceID: i.session.ServerID,
+			},
+			SegmentIDs: flushed.GetSegments(),
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if infos.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			return nil, nil, nil, fmt.Errorf("get segment info from DataCoord failed, reason = %s", infos.GetStatus().GetReason())
+		}
+		for _, info := range infos.GetInfos() {
+			segmentRows[info.GetID()] = info.GetNumOfRows()
+		}
+	}
+	metas := i.metaTable.GetSegmentIndexMetas(fieldID, indexName, flushed.GetSegments())
+	skipped := i.metaTable.GetSkippedSegments(fieldID, indexName, flushed.GetSegments())
+	return segmentRows, metas, skipped, nil
+}
+
 // GetIndexFilePaths gets the index file paths from IndexCoord.
 func (i *IndexCoord) GetIndexFilePaths(ctx context.Context, req *indexpb.GetIndexFilePathsRequest) (*indexpb.GetIndexFilePathsResponse, error) {
 	log.Debug("IndexCoord GetIndexFilePaths", zap.Int64s("IndexBuildIds", req.IndexBuildIDs))
@@ -565,6 +935,31 @@ func (i *IndexCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsReq
 		return metrics, err
 	}
 
+	if metricType == metricsinfo.UpdateConfigMetrics {
+		metrics := i.configUpdater.UpdateByMetricsRequest(req,
+			metricsinfo.ConstructComponentName(typeutil.IndexCoordRole, i.ID))
+
+		log.Debug("IndexCoord.GetMetrics",
+			zap.Int64("node_id", i.ID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		metrics := setLogLevel(ctx, req, i)
+
+		log.Debug("IndexCoord.GetMetrics",
+			zap.Int64("node_id", i.ID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Debug("IndexCoord.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", i.ID),
 		zap.String("req", req.Request),
@@ -619,38 +1014,75 @@ func (i *IndexCoord) recycleUnusedIndexFiles() {
 			metas := i.metaTable.GetUnusedIndexFiles(i.taskLimit)
 			log.Debug("IndexCoord recycleUnusedIndexFiles", zap.Int("Need recycle tasks num", len(metas)))
 			for _, meta := range metas {
+				indexBuildID := meta.indexMeta.IndexBuildID
 				if meta.indexMeta.MarkDeleted {
-					unusedIndexFilePathPrefix := Params.IndexRootPath + "/" + strconv.Itoa(int(meta.indexMeta.IndexBuildID))
 					log.Debug("IndexCoord recycleUnusedIndexFiles",
-						zap.Int64("Recycle the index files for deleted index with indexBuildID", meta.indexMeta.IndexBuildID))
-					if err := i.kv.RemoveWithPrefix(unusedIndexFilePathPrefix); err != nil {
+						zap.Int64("Recycle the index files for deleted index with indexBuildID", indexBuildID))
+					// the meta is purged in the round that finds no files left, so the files saved by an IndexNode
+					// racing with the removal are recycled too
+					removed, err := i.recycleIndexFiles(ctx, getIndexFilePrefix(indexBuildID), recycleTypeDeleted)
+					if err != nil {
 						log.Error("IndexCoord recycleUnusedIndexFiles Remove index files failed",
-							zap.Bool("MarkDeleted", true), zap.Error(err))
+							zap.Bool("MarkDeleted", true), zap.Int64("indexBuildID", indexBuildID), zap.Error(err))
+						continue
+					}
+					if removed == 0 {
+						i.metaTable.DeleteIndex(indexBuildID)
+						log.Debug("IndexCoord recycleUnusedIndexFiles",
+							zap.Int64("Recycle the index files successfully for deleted index with indexBuildID", indexBuildID))
 					}
-					i.metaTable.DeleteIndex(meta.indexMeta.IndexBuildID)
-					log.Debug("IndexCoord recycleUnusedIndexFiles",
-						zap.Int64("Recycle the index files successfully for deleted index with indexBuildID", meta.indexMeta.IndexBuildID))
 				} else {
 					log.Debug("IndexCoord recycleUnusedIndexFiles",
-						zap.Int64("Recycle the low version index files of the index with indexBuildID", meta.indexMeta.IndexBuildID))
-					for j := 1; j < int(meta.indexMeta.Version); j++ {
-						unusedIndexFilePathPrefix := Params.IndexRootPath + "/" + strconv.Itoa(int(meta.indexMeta.IndexBuildID)) + "/" + strconv.Itoa(j)
-						if err := i.kv.RemoveWithPrefix(unusedIndexFilePathPrefix); err != nil {
-							log.Error("IndexCoord recycleUnusedIndexFiles Remove index files failed",
-								zap.Bool("MarkDeleted", false), zap.Error(err))
-						}
+						zap.Int64("Recycle the low version index files of the index with indexBuildID", indexBuildID))
+					var err error
+					for j := int64(1); j < meta.indexMeta.Version && err == nil; j++ {
+						_, err = i.recycleIndexFiles(ctx, getIndexFilePrefix(indexBuildID, j), recycleTypeLowVersion)
+					}
+					if err != nil {
+						log.Error("IndexCoord recycleUnusedIndexFiles Remove index files failed",
+							zap.Bool("MarkDeleted", false), zap.Int64("indexBuildID", indexBuildID), zap.Error(err))
+						continue
 					}
-					if err := i.metaTable.UpdateRecycleState(meta.indexMeta.IndexBuildID); err != nil {
+					if err := i.metaTable.UpdateRecycleState(indexBuildID); err != nil {
 						log.Error("IndexCoord recycleUnusedIndexFiles UpdateRecycleState failed", zap.Error(err))
 					}
 					log.Debug("IndexCoord recycleUnusedIndexFiles",
-						zap.Int64("Recycle the low version index files successfully of the index with indexBuildID", meta.indexMeta.IndexBuildID))
+						zap.Int64("Recycle the low version index files successfully of the index with indexBuildID", indexBuildID))
 				}
 			}
 		}
 	}
 }
 
+// getIndexFilePrefix returns the prefix of the index files saved by the build, or by the build of versions if given.
+// The prefix ends with a slash, so that it doesn't match the files of other builds, e.g. build 1 and build 10.
+func getIndexFilePrefix(indexBuildID UniqueID, versions ...int64) string {
+	elems := []string{Params.IndexRootPath, strconv.FormatInt(indexBuildID, 10)}
+	for _, version := range versions {
+		elems = append(elems, strconv.FormatInt(version, 10))
+	}
+	return path.Join(elems...) + "/"
+}
+
+// recycleIndexFiles removes the index files with the prefix from object storage, and returns the number of files removed.
+func (i *IndexCoord) recycleIndexFiles(ctx context.Context, prefix string, recycleType string) (int, error) {
+	keys, err := i.chunkManager.ListWithPrefix(prefix)
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		key := key
+		err = retry.Do(ctx, func() error {
+			return i.chunkManager.Remove(key)
+		}, retry.Attempts(3))
+		if err != nil {
+			return 0, err
+		}
+		metrics.IndexCoordRecycledIndexFilesCounter.WithLabelValues(recycleType).Inc()
+	}
+	return len(keys), nil
+}
+
 // watchNodeLoop is used to monitor IndexNode going online and offline.
 //go:norace
 // fix datarace in unittest
@@ -691,6 +1123,17 @@ func (i *IndexCoord) watchNodeLoop() {
 				log.Debug("IndexCoord watchNodeLoop SessionDelEvent", zap.Int64("serverID", serverID))
 				i.nodeManager.RemoveNode(serverID)
 				i.metricsCacheManager.InvalidateSystemInfoMetrics()
+				// reassign the tasks of the offline IndexNode without waiting for the next assignment
+				sessions, _, err := i.session.GetSessions(typeutil.IndexNodeRole)
+				if err != nil {
+					log.Error("IndexCoord watchNodeLoop", zap.Any("GetSessions error", err))
+					break
+				}
+				var serverIDs []int64
+				for _, session := range sessions {
+					serverIDs = append(serverIDs, session.ServerID)
+				}
+				i.resetLostTasks(serverIDs)
 			}
 		}
 	}
@@ -704,7 +1147,7 @@ func (i *IndexCoord) watchMetaLoop() {
 	defer i.loopWg.Done()
 	log.Debug("IndexCoord watchMetaLoop start")
 
-	watchChan := i.metaTable.client.WatchWithPrefix("indexes")
+	watchChan := i.metaTable.client.WatchWithPrefix(segmentIndexPrefix)
 
 	for {
 		select {
@@ -781,52 +1224,99 @@ func (i *IndexCoord) assignTaskLoop() {
 			for _, session := range sessions {
 				serverIDs = append(serverIDs, session.ServerID)
 			}
-			metas := i.metaTable.GetUnassignedTasks(serverIDs)
-			sort.Slice(metas, func(i, j int) bool {
-				return metas[i].indexMeta.Version <= metas[j].indexMeta.Version
-			})
-			// only log if we find unassigned tasks
-			if len(metas) != 0 {
-				log.Debug("IndexCoord find unassigned tasks ", zap.Int("Unassigned tasks number", len(metas)), zap.Int64s("Available IndexNode IDs", serverIDs))
+			i.resetLostTasks(serverIDs)
+			i.assignTasks(serverIDs)
+		}
+	}
+}
+
+// resetLostTasks resets the in-progress tasks whose IndexNode is offline or which are not finished within
+// the build timeout, so that they are reassigned to the online IndexNodes.
+func (i *IndexCoord) resetLostTasks(onlineNodeIDs []int64) {
+	metas := i.metaTable.GetLostTasks(onlineNodeIDs, Params.TaskBuildTimeout)
+	for _, meta := range metas {
+		indexBuildID := meta.indexMeta.IndexBuildID
+		nodeID := meta.indexMeta.NodeID
+		reason := fmt.Sprintf("IndexNode %d is offline", nodeID)
+		alive := false
+		for _, serverID := range onlineNodeIDs {
+			if serverID == nodeID {
+				alive = true
+				break
 			}
-			for index, meta := range metas {
-				indexBuildID := meta.indexMeta.IndexBuildID
-				if err = i.metaTable.UpdateVersion(indexBuildID); err != nil {
-					log.Warn("IndexCoord assignmentTasksLoop metaTable.UpdateVersion failed", zap.Error(err))
-					continue
-				}
-				log.Debug("The version of the task has been updated", zap.Int64("indexBuildID", indexBuildID))
-				nodeID, builderClient := i.nodeManager.PeekClient()
-				if builderClient == nil {
-					log.Warn("IndexCoord assignmentTasksLoop can not find available IndexNode")
-					break
-				}
-				log.Debug("IndexCoord PeekClient success", zap.Int64("nodeID", nodeID))
-				req := &indexpb.CreateIndexRequest{
-					IndexBuildID: indexBuildID,
-					IndexName:    meta.indexMeta.Req.IndexName,
-					IndexID:      meta.indexMeta.Req.IndexID,
-					Version:      meta.indexMeta.Version + 1,
-					MetaPath:     "/indexes/" + strconv.FormatInt(indexBuildID, 10),
-					DataPaths:    meta.indexMeta.Req.DataPaths,
-					TypeParams:   meta.indexMeta.Req.TypeParams,
-					IndexParams:  meta.indexMeta.Req.IndexParams,
-				}
-				if !i.assignTask(builderClient, req) {
-					log.Warn("IndexCoord assignTask assign task to IndexNode failed")
-					continue
-				}
-				if err = i.metaTable.BuildIndex(indexBuildID, nodeID); err != nil {
-					log.Error("IndexCoord assignmentTasksLoop metaTable.BuildIndex failed", zap.Error(err))
-					break
-				}
-				log.Debug("This task has been assigned", zap.Int64("indexBuildID", indexBuildID),
-					zap.Int64("The IndexNode execute this task", nodeID))
-				i.nodeManager.pq.IncPriority(nodeID, 1)
-				if index > i.taskLimit {
-					break
-				}
+		}
+		if alive {
+			reason = fmt.Sprintf("IndexNode %d did not finish the task in %v", nodeID, Params.TaskBuildTimeout)
+		}
+		if err := i.metaTable.ResetTask(indexBuildID, nodeID, reason, Params.TaskMaxRetry); err != nil {
+			log.Warn("IndexCoord resetLostTasks metaTable.ResetTask failed", zap.Int64("indexBuildID", indexBuildID), zap.Error(err))
+			continue
+		}
+		log.Debug("The lost task has been reset", zap.Int64("indexBuildID", indexBuildID), zap.String("reason", reason))
+		if alive {
+			i.nodeManager.pq.IncPriority(nodeID, -1)
+		}
+	}
+}
+
+// assignTasks assigns the unassigned tasks to the IndexNodes with the least load.
+func (i *IndexCoord) peekClientForIndexType(indexType string) (UniqueID, types.IndexNode) {
+	ctx, cancel := context.WithTimeout(i.loopCtx, i.reqTimeoutInterval)
+	defer cancel()
+	return i.nodeManager.PeekClientForIndexType(ctx, indexType)
+}
+
+func (i *IndexCoord) assignTasks(serverIDs []int64) {
+	metas := i.metaTable.GetUnassignedTasks()
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].indexMeta.Version <= metas[j].indexMeta.Version
+	})
+	// only log if we find unassigned tasks
+	if len(metas) != 0 {
+		log.Debug("IndexCoord find unassigned tasks ", zap.Int("Unassigned tasks number", len(metas)), zap.Int64s("Available IndexNode IDs", serverIDs))
+	}
+	for index, meta := range metas {
+		indexBuildID := meta.indexMeta.IndexBuildID
+		if err := i.metaTable.UpdateVersion(indexBuildID); err != nil {
+			log.Warn("IndexCoord assignmentTasksLoop metaTable.UpdateVersion failed", zap.Error(err))
+			continue
+		}
+		log.Debug("The version of the task has been updated", zap.Int64("indexBuildID", indexBuildID))
+		indexType, _ := funcutil.GetAttrByKeyFromRepeatedKV("index_type", meta.indexMeta.Req.IndexParams)
+		nodeID, builderClient := i.peekClientForIndexType(indexType)
+		if builderClient == nil {
+			log.Warn("IndexCoord assignmentTasksLoop can not find available IndexNode", zap.String("indexType", indexType))
+			if indexType == "" {
+				break
 			}
+			// the IndexNodes that can build the index type may be upgraded later
+			continue
+		}
+		log.Debug("IndexCoord PeekClient success", zap.Int64("nodeID", nodeID))
+		req := &indexpb.CreateIndexRequest{
+			IndexBuildID: indexBuildID,
+			IndexName:    meta.indexMeta.Req.IndexName,
+			IndexID:      meta.indexMeta.Req.IndexID,
+			Version:      meta.indexMeta.Version + 1,
+			MetaPath:     segmentIndexKey(indexBuildID),
+			DataPaths:    meta.indexMeta.Req.DataPaths,
+			TypeParams:   meta.indexMeta.Req.TypeParams,
+			IndexParams:  meta.indexMeta.Req.IndexParams,
+			NumRows:      meta.indexMeta.Req.NumRows,
+		}
+		if !i.assignTask(builderClient, req) {
+			log.Warn("IndexCoord assignTask assign task to IndexNode failed")
+			continue
+		}
+		if err := i.metaTable.BuildIndex(indexBuildID, nodeID); err != nil {
+			log.Error("IndexCoord assignmentTasksLoop metaTable.BuildIndex failed", zap.Error(err))
+			break
+		}
+		log.Debug("This task has been assigned", zap.Int64("indexBuildID", indexBuildID),
+			zap.Int64("The IndexNode execute this task", nodeID))
+		i.nodeManager.pq.IncPriority(nodeID, 1)
+		if index > i.taskLimit {
+			break
 		}
 	}
 }
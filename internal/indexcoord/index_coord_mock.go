@@ -21,6 +21,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
@@ -126,6 +127,23 @@ func (icm *Mock) GetTimeTickChannel(ctx context.Context) (*milvuspb.StringRespon
 	}, nil
 }
 
+// CreateIndexDefinition receives a creating index request, and return success, if Param `Failure` is true, it will return an error.
+func (icm *Mock) CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error) {
+	if icm.Failure {
+		return &indexpb.CreateIndexDefinitionResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			},
+		}, errors.New("IndexCoordinate CreateIndexDefinition failed")
+	}
+	return &indexpb.CreateIndexDefinitionResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		IndexID: req.GetDefinition().GetIndexID(),
+	}, nil
+}
+
 // BuildIndex receives a building index request, and return success, if Param `Failure` is true, it will return an error.
 func (icm *Mock) BuildIndex(ctx context.Context, req *indexpb.BuildIndexRequest) (*indexpb.BuildIndexResponse, error) {
 	if icm.Failure {
@@ -156,6 +174,18 @@ func (icm *Mock) DropIndex(ctx context.Context, req *indexpb.DropIndexRequest) (
 	}, nil
 }
 
+// DropSegmentIndex receives a dropping segment index request, and return success, if Param `Failure` is true, it will return an error.
+func (icm *Mock) DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error) {
+	if icm.Failure {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		}, errors.New("IndexCoordinate DropSegmentIndex failed")
+	}
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
 // GetIndexStates gets the indexes states, if Param `Failure` is true, it will return an error.
 // Under normal circumstances the state of each index is `IndexState_Finished`.
 func (icm *Mock) GetIndexStates(ctx context.Context, req *indexpb.GetIndexStatesRequest) (*indexpb.GetIndexStatesResponse, error) {
@@ -209,6 +239,51 @@ func (icm *Mock) GetIndexFilePaths(ctx context.Context, req *indexpb.GetIndexFil
 	}, nil
 }
 
+// GetIndexBuildProgress gets the index build progress, if Param `Failure` is true, it will return an error.
+// Under normal circumstances all the rows are indexed.
+func (icm *Mock) GetIndexBuildProgress(ctx context.Context, req *indexpb.GetIndexBuildProgressRequest) (*indexpb.GetIndexBuildProgressResponse, error) {
+	if icm.Failure {
+		return &indexpb.GetIndexBuildProgressResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			},
+		}, errors.New("IndexCoordinate GetIndexBuildProgress failed")
+	}
+	return &indexpb.GetIndexBuildProgressResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		IndexedRows: 0,
+		TotalRows:   0,
+	}, nil
+}
+
+// GetIndexState gets the index state, if Param `Failure` is true, it will return an error.
+// Under normal circumstances the state is `IndexState_Finished`.
+func (icm *Mock) GetIndexState(ctx context.Context, req *indexpb.GetIndexStateRequest) (*indexpb.GetIndexStateResponse, error) {
+	if icm.Failure {
+		return &indexpb.GetIndexStateResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			},
+		}, errors.New("IndexCoordinate GetIndexState failed")
+	}
+	return &indexpb.GetIndexStateResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		State: commonpb.IndexState_Finished,
+	}, nil
+}
+
+// SetDataCoord sets the DataCoord of mocked IndexCoord, if Param `Failure` is true, it will return an error.
+func (icm *Mock) SetDataCoord(dataCoord types.DataCoord) error {
+	if icm.Failure {
+		return errors.New("IndexCoordinate SetDataCoord failed")
+	}
+	return nil
+}
+
 // GetMetrics gets the metrics of mocked IndexCoord, if Param `Failure` is true, it will return an error.
 func (icm *Mock) GetMetrics(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	if icm.Failure {
@@ -55,6 +55,16 @@ func TestIndexCoordMock(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	t.Run("CreateIndexDefinition", func(t *testing.T) {
+		req := &indexpb.CreateIndexDefinitionRequest{
+			Definition: &indexpb.IndexDefinition{IndexID: 1},
+		}
+		resp, err := icm.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, int64(1), resp.IndexID)
+	})
+
 	t.Run("BuildIndex", func(t *testing.T) {
 		req := &indexpb.BuildIndexRequest{
 			IndexBuildID: 0,
@@ -85,6 +95,15 @@ func TestIndexCoordMock(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
 	})
 
+	t.Run("DropSegmentIndex", func(t *testing.T) {
+		req := &indexpb.DropSegmentIndexRequest{
+			SegmentID: 0,
+		}
+		resp, err := icm.DropSegmentIndex(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	t.Run("GetIndexFilePaths", func(t *testing.T) {
 		req := &indexpb.GetIndexFilePathsRequest{
 			IndexBuildIDs: []UniqueID{0, 1},
@@ -95,6 +114,19 @@ func TestIndexCoordMock(t *testing.T) {
 		assert.Equal(t, len(req.IndexBuildIDs), len(resp.FilePaths))
 	})
 
+	t.Run("GetIndexBuildProgress", func(t *testing.T) {
+		resp, err := icm.GetIndexBuildProgress(ctx, &indexpb.GetIndexBuildProgressRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	})
+
+	t.Run("GetIndexState", func(t *testing.T) {
+		resp, err := icm.GetIndexState(ctx, &indexpb.GetIndexStateRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, commonpb.IndexState_Finished, resp.State)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
@@ -141,6 +173,12 @@ func TestIndexCoordMockError(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
 	})
 
+	t.Run("CreateIndexDefinition", func(t *testing.T) {
+		resp, err := icm.CreateIndexDefinition(ctx, &indexpb.CreateIndexDefinitionRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
 	t.Run("BuildIndex", func(t *testing.T) {
 		req := &indexpb.BuildIndexRequest{
 			IndexBuildID: 0,
@@ -170,6 +208,15 @@ func TestIndexCoordMockError(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.ErrorCode)
 	})
 
+	t.Run("DropSegmentIndex", func(t *testing.T) {
+		req := &indexpb.DropSegmentIndexRequest{
+			SegmentID: 0,
+		}
+		resp, err := icm.DropSegmentIndex(ctx, req)
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.ErrorCode)
+	})
+
 	t.Run("GetIndexFilePaths", func(t *testing.T) {
 		req := &indexpb.GetIndexFilePathsRequest{
 			IndexBuildIDs: []UniqueID{0, 1},
@@ -179,6 +226,18 @@ func TestIndexCoordMockError(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
 	})
 
+	t.Run("GetIndexBuildProgress", func(t *testing.T) {
+		resp, err := icm.GetIndexBuildProgress(ctx, &indexpb.GetIndexBuildProgressRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("GetIndexState", func(t *testing.T) {
+		resp, err := icm.GetIndexState(ctx, &indexpb.GetIndexStateRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
@@ -13,12 +13,18 @@ package indexcoord
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/types"
 
 	grpcindexnode "github.com/milvus-io/milvus/internal/distributed/indexnode"
 
@@ -34,6 +40,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 )
@@ -83,9 +90,133 @@ func TestIndexCoord(t *testing.T) {
 	assert.Equal(t, internalpb.StateCode_Healthy, state.State.StateCode)
 
 	indexID := int64(rand.Int())
+	collectionID := int64(rand.Int())
+	typeParams := []*commonpb.KeyValuePair{{Key: "dim", Value: "128"}}
+	indexParams := []*commonpb.KeyValuePair{{Key: "index_type", Value: "FLAT"}, {Key: "metric_type", Value: "L2"}}
 
 	var indexBuildID UniqueID
 
+	t.Run("Create Index Definition", func(t *testing.T) {
+		req := &indexpb.CreateIndexDefinitionRequest{
+			Definition: &indexpb.IndexDefinition{
+				CollectionID: collectionID,
+				FieldID:      100,
+				IndexName:    "_default_idx",
+				IndexID:      indexID,
+				TypeParams:   typeParams,
+				IndexParams:  indexParams,
+				FieldType:    schemapb.DataType_FloatVector,
+			},
+		}
+		resp, err := ic.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, indexID, resp.IndexID)
+
+		req.Definition.IndexID = indexID + 1
+		resp, err = ic.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, indexID, resp.IndexID)
+
+		req.Definition.IndexParams = []*commonpb.KeyValuePair{{Key: "index_type", Value: "IVF_FLAT"},
+			{Key: "params", Value: `{"metric_type": "L2", "nlist": 128}`}}
+		resp, err = ic.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+
+		// invalid params are rejected before the index is created
+		req.Definition.IndexParams = []*commonpb.KeyValuePair{{Key: "index_type", Value: "IVF_FLAT"}, {Key: "metric_type", Value: "L2"}}
+		resp, err = ic.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, resp.Status.ErrorCode)
+		assert.Contains(t, resp.Status.Reason, "nlist is required")
+
+		req.Definition.IndexParams = []*commonpb.KeyValuePair{{Key: "index_type", Value: "IVF_PQ"}, {Key: "metric_type", Value: "L2"},
+			{Key: "nlist", Value: "128"}, {Key: "m", Value: "7"}}
+		resp, err = ic.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, resp.Status.ErrorCode)
+		assert.Contains(t, resp.Status.Reason, "m(7)")
+
+		req.Definition.IndexParams = []*commonpb.KeyValuePair{{Key: "index_type", Value: "BIN_FLAT"}, {Key: "metric_type", Value: "JACCARD"}}
+		resp, err = ic.CreateIndexDefinition(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, resp.Status.ErrorCode)
+		assert.Contains(t, resp.Status.Reason, "FloatVector")
+
+		resp, err = ic.CreateIndexDefinition(ctx, &indexpb.CreateIndexDefinitionRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("Coalesce Small Segments", func(t *testing.T) {
+		newReq := func(segmentID UniqueID, numRows int64) *indexpb.BuildIndexRequest {
+			return &indexpb.BuildIndexRequest{
+				IndexName:    "_default_idx",
+				IndexID:      indexID,
+				DataPaths:    []string{fmt.Sprintf("DataPath-%d", segmentID)},
+				TypeParams:   typeParams,
+				IndexParams:  indexParams,
+				SegmentID:    segmentID,
+				FieldID:      100,
+				CollectionID: collectionID,
+				NumRows:      numRows,
+			}
+		}
+		dataCoord := &fakeDataCoord{segmentRows: map[UniqueID]int64{11: 100, 12: 100}}
+		assert.Nil(t, ic.SetDataCoord(dataCoord))
+		progressReq := &indexpb.GetIndexBuildProgressRequest{CollectionID: collectionID, FieldID: 100, IndexName: "_default_idx"}
+
+		// the flushed segments are too small, their builds are skipped
+		for _, segmentID := range []UniqueID{11, 12} {
+			for i := 0; i < 2; i++ {
+				resp, err := ic.BuildIndex(ctx, newReq(segmentID, 100))
+				assert.Nil(t, err)
+				assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+				assert.Equal(t, UniqueID(0), resp.IndexBuildID)
+			}
+		}
+		assert.Equal(t, 2, len(ic.metaTable.GetSkippedSegments(100, "_default_idx", []UniqueID{11, 12})))
+		progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(200), progress.TotalRows)
+		assert.Equal(t, int64(200), progress.IndexedRows)
+
+		// the collection lowers the threshold
+		req := newReq(13, 100)
+		req.MinSegmentRows = 50
+		resp, err := ic.BuildIndex(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.NotEqual(t, UniqueID(0), resp.IndexBuildID)
+
+		// compaction merges the small segments into segment 14, which is indexed exactly once
+		delete(dataCoord.segmentRows, 11)
+		delete(dataCoord.segmentRows, 12)
+		dataCoord.segmentRows[14] = 2000
+		resp, err = ic.BuildIndex(ctx, newReq(14, 2000))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		compactedBuildID := resp.IndexBuildID
+		assert.NotEqual(t, UniqueID(0), compactedBuildID)
+		resp, err = ic.BuildIndex(ctx, newReq(14, 2000))
+		assert.Nil(t, err)
+		assert.Equal(t, compactedBuildID, resp.IndexBuildID)
+
+		builds := 0
+		for _, meta := range ic.metaTable.indexBuildID2Meta {
+			if meta.indexMeta.Req.SegmentID == 14 && meta.indexMeta.Req.IndexID == indexID {
+				builds++
+			}
+		}
+		assert.Equal(t, 1, builds)
+		assert.Eventually(t, func() bool {
+			progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+			return err == nil && progress.TotalRows == 2000 && progress.IndexedRows == 2000
+		}, 10*time.Second, 100*time.Millisecond)
+	})
+
 	t.Run("Create Index", func(t *testing.T) {
 		req := &indexpb.BuildIndexRequest{
 			IndexID:   indexID,
@@ -226,3 +357,451 @@ func TestIndexCoord_watchNodeLoop(t *testing.T) {
 	assert.True(t, flag)
 
 }
+
+// fakeIndexNode records the index tasks assigned to it, and finishes them if finish is true.
+// It can build the indexTypes only if indexTypes is not nil.
+type fakeIndexNode struct {
+	types.IndexNode
+	etcdKV     *etcdkv.EtcdKV
+	finish     bool
+	indexTypes []string
+	reqs       chan *indexpb.CreateIndexRequest
+	canceled   chan []UniqueID
+}
+
+func newFakeIndexNode(etcdKV *etcdkv.EtcdKV, finish bool) *fakeIndexNode {
+	return &fakeIndexNode{
+		etcdKV:   etcdKV,
+		finish:   finish,
+		reqs:     make(chan *indexpb.CreateIndexRequest, 10),
+		canceled: make(chan []UniqueID, 10),
+	}
+}
+
+func (n *fakeIndexNode) GetCapabilities(ctx context.Context, req *indexpb.GetCapabilitiesRequest) (*indexpb.GetCapabilitiesResponse, error) {
+	indexTypes := n.indexTypes
+	if indexTypes == nil {
+		indexTypes = []string{"FLAT", "IVF_FLAT", "HNSW"}
+	}
+	return &indexpb.GetCapabilitiesResponse{
+		Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		IndexTypes: indexTypes,
+	}, nil
+}
+
+func (n *fakeIndexNode) CancelIndexBuild(ctx context.Context, req *indexpb.CancelIndexBuildRequest) (*commonpb.Status, error) {
+	n.canceled <- req.IndexBuildIDs
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+}
+
+func (n *fakeIndexNode) CreateIndex(ctx context.Context, req *indexpb.CreateIndexRequest) (*commonpb.Status, error) {
+	n.reqs <- req
+	if !n.finish {
+		return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+	}
+	_, values, versions, err := n.etcdKV.LoadWithPrefix2(req.MetaPath)
+	if err != nil {
+		return nil, err
+	}
+	indexMeta := &indexpb.IndexMeta{}
+	if err = proto.Unmarshal([]byte(values[0]), indexMeta); err != nil {
+		return nil, err
+	}
+	if indexMeta.Version > req.Version {
+		return &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "version is low"}, nil
+	}
+	indexMeta.State = commonpb.IndexState_Finished
+	indexMeta.IndexFilePaths = []string{"IndexFilePath-1"}
+	value, err := proto.Marshal(indexMeta)
+	if err != nil {
+		return nil, err
+	}
+	if err = n.etcdKV.CompareVersionAndSwap(req.MetaPath, versions[0], string(value)); err != nil {
+		return nil, err
+	}
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+}
+
+func TestIndexCoord_reassignTask(t *testing.T) {
+	Params.Init()
+	etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	err = etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+	assert.Nil(t, err)
+	defer etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+
+	newIndexCoord := func(t *testing.T) *IndexCoord {
+		metaTable, err := NewMetaTable(etcdKV)
+		assert.Nil(t, err)
+		return &IndexCoord{
+			loopCtx:            context.Background(),
+			metaTable:          metaTable,
+			nodeManager:        NewNodeManager(),
+			reqTimeoutInterval: time.Second,
+			taskLimit:          20,
+		}
+	}
+
+	t.Run("IndexNode offline", func(t *testing.T) {
+		ic := newIndexCoord(t)
+		indexBuildID := UniqueID(1)
+		err := ic.metaTable.AddIndex(indexBuildID, &indexpb.BuildIndexRequest{IndexBuildID: indexBuildID, IndexID: 1})
+		assert.Nil(t, err)
+
+		node1 := newFakeIndexNode(etcdKV, false)
+		ic.nodeManager.setClient(1, node1)
+		ic.assignTasks([]int64{1})
+		assert.Equal(t, 1, len(node1.reqs))
+		indexMeta := ic.metaTable.GetIndexMetaByIndexBuildID(indexBuildID)
+		assert.Equal(t, commonpb.IndexState_InProgress, indexMeta.State)
+		assert.Equal(t, int64(1), indexMeta.NodeID)
+
+		// IndexNode 1 is killed while building the index
+		ic.nodeManager.RemoveNode(1)
+		node2 := newFakeIndexNode(etcdKV, true)
+		ic.nodeManager.setClient(2, node2)
+		ic.resetLostTasks([]int64{2})
+		indexMeta = ic.metaTable.GetIndexMetaByIndexBuildID(indexBuildID)
+		assert.Equal(t, commonpb.IndexState_Unissued, indexMeta.State)
+		assert.Equal(t, int64(1), indexMeta.RetryCount)
+
+		ic.assignTasks([]int64{2})
+		assert.Equal(t, 1, len(node2.reqs))
+		meta, err := ic.metaTable.reloadMeta(indexBuildID)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.IndexState_Finished, meta.indexMeta.State)
+		assert.Equal(t, []string{"IndexFilePath-1"}, meta.indexMeta.IndexFilePaths)
+	})
+
+	t.Run("build timeout", func(t *testing.T) {
+		maxRetry, timeout := Params.TaskMaxRetry, Params.TaskBuildTimeout
+		Params.TaskMaxRetry, Params.TaskBuildTimeout = 1, time.Millisecond
+		defer func() {
+			Params.TaskMaxRetry, Params.TaskBuildTimeout = maxRetry, timeout
+		}()
+
+		ic := newIndexCoord(t)
+		indexBuildID := UniqueID(2)
+		err := ic.metaTable.AddIndex(indexBuildID, &indexpb.BuildIndexRequest{IndexBuildID: indexBuildID, IndexID: 2})
+		assert.Nil(t, err)
+
+		node := newFakeIndexNode(etcdKV, false)
+		ic.nodeManager.setClient(1, node)
+		for retry := int64(1); retry <= 2; retry++ {
+			ic.assignTasks([]int64{1})
+			assert.Equal(t, commonpb.IndexState_InProgress, ic.metaTable.GetIndexMetaByIndexBuildID(indexBuildID).State)
+			time.Sleep(10 * time.Millisecond)
+			ic.resetLostTasks([]int64{1})
+			assert.Equal(t, retry, ic.metaTable.GetIndexMetaByIndexBuildID(indexBuildID).RetryCount)
+		}
+		assert.Equal(t, 2, len(node.reqs))
+
+		// the task fails after retried more than TaskMaxRetry times
+		indexMeta := ic.metaTable.GetIndexMetaByIndexBuildID(indexBuildID)
+		assert.Equal(t, commonpb.IndexState_Failed, indexMeta.State)
+		assert.Contains(t, indexMeta.FailReason, "did not finish")
+		states := ic.metaTable.GetIndexStates([]UniqueID{indexBuildID})
+		assert.Equal(t, commonpb.IndexState_Failed, states[0].State)
+		assert.Equal(t, indexMeta.FailReason, states[0].Reason)
+	})
+}
+
+func TestIndexCoord_assignTasksByCapabilities(t *testing.T) {
+	Params.Init()
+	etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	err = etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+	assert.Nil(t, err)
+	defer etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+
+	metaTable, err := NewMetaTable(etcdKV)
+	assert.Nil(t, err)
+	ic := &IndexCoord{
+		loopCtx:            context.Background(),
+		metaTable:          metaTable,
+		nodeManager:        NewNodeManager(),
+		reqTimeoutInterval: time.Second,
+		taskLimit:          20,
+	}
+	addIndex := func(indexBuildID UniqueID, indexType string) {
+		err := ic.metaTable.AddIndex(indexBuildID, &indexpb.BuildIndexRequest{
+			IndexBuildID: indexBuildID,
+			IndexID:      indexBuildID,
+			IndexParams:  []*commonpb.KeyValuePair{{Key: "index_type", Value: indexType}},
+		})
+		assert.Nil(t, err)
+	}
+
+	// IndexNode 1 is of the old version and has the least load, IndexNode 2 is upgraded and supports HNSW
+	oldNode := newFakeIndexNode(etcdKV, false)
+	oldNode.indexTypes = []string{"IVF_FLAT"}
+	newNode := newFakeIndexNode(etcdKV, false)
+	newNode.indexTypes = []string{"IVF_FLAT", "HNSW"}
+	ic.nodeManager.setClient(1, oldNode)
+	ic.nodeManager.setClient(2, newNode)
+
+	addIndex(1, "HNSW")
+	ic.assignTasks([]int64{1, 2})
+	assert.Equal(t, 0, len(oldNode.reqs))
+	assert.Equal(t, 1, len(newNode.reqs))
+	assert.Equal(t, int64(2), ic.metaTable.GetIndexMetaByIndexBuildID(1).NodeID)
+
+	// the task any IndexNode can run is assigned to the one with the least load
+	addIndex(2, "IVF_FLAT")
+	ic.assignTasks([]int64{1, 2})
+	assert.Equal(t, 1, len(oldNode.reqs))
+	assert.Equal(t, int64(1), ic.metaTable.GetIndexMetaByIndexBuildID(2).NodeID)
+
+	// no IndexNode supports the index type yet, the task waits until IndexNode 1 is upgraded
+	addIndex(3, "NEW_INDEX")
+	ic.assignTasks([]int64{1, 2})
+	assert.Equal(t, 1, len(oldNode.reqs))
+	assert.Equal(t, 1, len(newNode.reqs))
+	assert.Equal(t, commonpb.IndexState_Unissued, ic.metaTable.GetIndexMetaByIndexBuildID(3).State)
+
+	oldNode.indexTypes = []string{"IVF_FLAT", "HNSW", "NEW_INDEX"}
+	ic.assignTasks([]int64{1, 2})
+	assert.Equal(t, 2, len(oldNode.reqs))
+	indexMeta := ic.metaTable.GetIndexMetaByIndexBuildID(3)
+	assert.Equal(t, commonpb.IndexState_InProgress, indexMeta.State)
+	assert.Equal(t, int64(1), indexMeta.NodeID)
+}
+
+func TestIndexCoord_dropIndex(t *testing.T) {
+	Params.Init()
+	etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	err = etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+	assert.Nil(t, err)
+	defer etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metaTable, err := NewMetaTable(etcdKV)
+	assert.Nil(t, err)
+	chunkManager := storage.NewLocalChunkManager(t.TempDir())
+	ic := &IndexCoord{
+		loopCtx:            ctx,
+		metaTable:          metaTable,
+		nodeManager:        NewNodeManager(),
+		session:            &sessionutil.Session{ServerID: 1},
+		chunkManager:       chunkManager,
+		reqTimeoutInterval: time.Second,
+		durationInterval:   10 * time.Millisecond,
+		taskLimit:          20,
+	}
+	ic.sched, err = NewTaskScheduler(ctx, nil, nil, metaTable)
+	assert.Nil(t, err)
+
+	// the build of the dropped index is in progress, and the build of the recreated index is finished
+	droppedBuildID, recreatedBuildID := UniqueID(3), UniqueID(30)
+	node := newFakeIndexNode(etcdKV, false)
+	ic.nodeManager.setClient(1, node)
+	err = ic.metaTable.AddIndex(droppedBuildID, &indexpb.BuildIndexRequest{IndexBuildID: droppedBuildID, IndexID: 3})
+	assert.Nil(t, err)
+	ic.assignTasks([]int64{1})
+	node.finish = true
+	err = ic.metaTable.AddIndex(recreatedBuildID, &indexpb.BuildIndexRequest{IndexBuildID: recreatedBuildID, IndexID: 4})
+	assert.Nil(t, err)
+	ic.assignTasks([]int64{1})
+	assert.Equal(t, 2, len(node.reqs))
+	_, _, versions, err := etcdKV.LoadWithPrefix2(segmentIndexKey(recreatedBuildID))
+	assert.Nil(t, err)
+	assert.True(t, ic.metaTable.LoadMetaFromETCD(recreatedBuildID, versions[0]))
+
+	droppedFile := getIndexFilePrefix(droppedBuildID, 1) + "IVF"
+	recreatedFile := getIndexFilePrefix(recreatedBuildID, 1) + "IVF"
+	for _, file := range []string{droppedFile, recreatedFile} {
+		err = chunkManager.Write(file, []byte{1, 2, 3})
+		assert.Nil(t, err)
+	}
+
+	status, err := ic.DropIndex(ctx, &indexpb.DropIndexRequest{IndexID: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+	select {
+	case canceled := <-node.canceled:
+		assert.Equal(t, []UniqueID{droppedBuildID}, canceled)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the index build is not canceled")
+	}
+	assert.Equal(t, 1, ic.nodeManager.pq.getItemByKey(1).(*PQItem).priority)
+
+	ic.loopWg.Add(1)
+	go ic.recycleUnusedIndexFiles()
+	assert.Eventually(t, func() bool {
+		return ic.metaTable.GetIndexMetaByIndexBuildID(droppedBuildID) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	ic.loopWg.Wait()
+
+	assert.False(t, chunkManager.Exist(droppedFile))
+	assert.True(t, chunkManager.Exist(recreatedFile))
+	indexMeta := ic.metaTable.GetIndexMetaByIndexBuildID(recreatedBuildID)
+	assert.Equal(t, commonpb.IndexState_Finished, indexMeta.State)
+	assert.True(t, indexMeta.Recycled)
+}
+
+type fakeDataCoord struct {
+	types.DataCoord
+	segmentRows map[UniqueID]int64
+	fail        bool
+}
+
+func (dc *fakeDataCoord) GetFlushedSegments(ctx context.Context, req *datapb.GetFlushedSegmentsRequest) (*datapb.GetFlushedSegmentsResponse, error) {
+	if dc.fail {
+		return &datapb.GetFlushedSegmentsResponse{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "mock error"},
+		}, nil
+	}
+	var segmentIDs []UniqueID
+	for segmentID := range dc.segmentRows {
+		segmentIDs = append(segmentIDs, segmentID)
+	}
+	return &datapb.GetFlushedSegmentsResponse{
+		Status:   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Segments: segmentIDs,
+	}, nil
+}
+
+func (dc *fakeDataCoord) GetSegmentInfo(ctx context.Context, req *datapb.GetSegmentInfoRequest) (*datapb.GetSegmentInfoResponse, error) {
+	var infos []*datapb.SegmentInfo
+	for _, segmentID := range req.SegmentIDs {
+		infos = append(infos, &datapb.SegmentInfo{ID: segmentID, NumOfRows: dc.segmentRows[segmentID]})
+	}
+	return &datapb.GetSegmentInfoResponse{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Infos:  infos,
+	}, nil
+}
+
+func TestIndexCoord_GetIndexBuildProgressAndState(t *testing.T) {
+	Params.Init()
+	ctx := context.Background()
+	dataCoord := &fakeDataCoord{
+		segmentRows: map[UniqueID]int64{1: 2000, 2: 2000, 3: 2000, 4: 3000, 5: 100, 6: 2000},
+	}
+	newMeta := func(segmentID UniqueID, state commonpb.IndexState, reason string) Meta {
+		return Meta{indexMeta: &indexpb.IndexMeta{
+			IndexBuildID: segmentID,
+			State:        state,
+			FailReason:   reason,
+			Req:          &indexpb.BuildIndexRequest{IndexBuildID: segmentID, IndexName: "idx", SegmentID: segmentID, FieldID: 100},
+		}}
+	}
+	ic := &IndexCoord{
+		session: &sessionutil.Session{ServerID: 1},
+		metaTable: &metaTable{indexBuildID2Meta: map[UniqueID]Meta{
+			1: newMeta(1, commonpb.IndexState_Finished, ""),
+			2: newMeta(2, commonpb.IndexState_Failed, "out of memory"),
+			3: newMeta(3, commonpb.IndexState_Failed, "out of memory"),
+			4: newMeta(4, commonpb.IndexState_Unissued, ""),
+			6: newMeta(6, commonpb.IndexState_Failed, "invalid params"),
+		}},
+	}
+	progressReq := &indexpb.GetIndexBuildProgressRequest{CollectionID: 1, FieldID: 100, IndexName: "idx"}
+	stateReq := &indexpb.GetIndexStateRequest{CollectionID: 1, FieldID: 100, IndexName: "idx"}
+	setState := func(segmentID UniqueID, state commonpb.IndexState) {
+		ic.metaTable.indexBuildID2Meta[segmentID].indexMeta.State = state
+	}
+
+	t.Run("unhealthy", func(t *testing.T) {
+		ic.UpdateStateCode(internalpb.StateCode_Abnormal)
+		progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, progress.Status.ErrorCode)
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, state.Status.ErrorCode)
+	})
+
+	ic.UpdateStateCode(internalpb.StateCode_Healthy)
+	t.Run("no DataCoord", func(t *testing.T) {
+		progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, progress.Status.ErrorCode)
+	})
+
+	assert.NotNil(t, ic.SetDataCoord(nil))
+	assert.Nil(t, ic.SetDataCoord(dataCoord))
+	t.Run("DataCoord failed", func(t *testing.T) {
+		dataCoord.fail = true
+		defer func() { dataCoord.fail = false }()
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, state.Status.ErrorCode)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, progress.Status.ErrorCode)
+		// the rows of segment 5 are less than the threshold, which are counted as indexed
+		assert.Equal(t, int64(11100), progress.TotalRows)
+		assert.Equal(t, int64(2100), progress.IndexedRows)
+
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, state.Status.ErrorCode)
+		assert.Equal(t, commonpb.IndexState_Failed, state.State)
+		assert.Equal(t, "index builds of segments [2 3] failed: out of memory; index builds of segments [6] failed: invalid params", state.FailReason)
+	})
+
+	t.Run("unissued", func(t *testing.T) {
+		setState(2, commonpb.IndexState_Finished)
+		setState(3, commonpb.IndexState_Finished)
+		delete(ic.metaTable.indexBuildID2Meta, 6)
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.IndexState_Unissued, state.State)
+		assert.Equal(t, "", state.FailReason)
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		ic.metaTable.indexBuildID2Meta[6] = newMeta(6, commonpb.IndexState_Finished, "")
+		setState(4, commonpb.IndexState_InProgress)
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.IndexState_InProgress, state.State)
+	})
+
+	t.Run("finished", func(t *testing.T) {
+		setState(4, commonpb.IndexState_Finished)
+		progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(11100), progress.TotalRows)
+		assert.Equal(t, int64(11100), progress.IndexedRows)
+
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.IndexState_Finished, state.State)
+	})
+
+	t.Run("other index", func(t *testing.T) {
+		req := &indexpb.GetIndexBuildProgressRequest{CollectionID: 1, FieldID: 100, IndexName: "other"}
+		progress, err := ic.GetIndexBuildProgress(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(11100), progress.TotalRows)
+		assert.Equal(t, int64(100), progress.IndexedRows)
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		// segment 7 is larger than the default threshold, but smaller than the one of the collection
+		dataCoord.segmentRows[7] = 5000
+		state, err := ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.IndexState_Unissued, state.State)
+
+		ic.metaTable.skippedIndexes = map[UniqueID]map[UniqueID]*indexpb.BuildIndexRequest{
+			10: {7: {IndexName: "idx", SegmentID: 7, FieldID: 100, NumRows: 5000}},
+		}
+		progress, err := ic.GetIndexBuildProgress(ctx, progressReq)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(16100), progress.TotalRows)
+		assert.Equal(t, int64(16100), progress.IndexedRows)
+
+		state, err = ic.GetIndexState(ctx, stateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.IndexState_Finished, state.State)
+	})
+}
@@ -15,8 +15,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/retry"
 
@@ -27,8 +29,34 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
+const (
+	// legacyIndexMetaPrefix is the prefix of the flat index build records of the older versions, which are migrated
+	// to the index definitions and the segment index builds at startup.
+	legacyIndexMetaPrefix = "indexes"
+	// indexDefinitionPrefix is the prefix of the index definitions, keyed by collection, field and index name.
+	indexDefinitionPrefix = "index-definitions"
+	// segmentIndexPrefix is the prefix of the index builds on segments, keyed by IndexBuildID.
+	segmentIndexPrefix = "segment-indexes"
+	// skippedSegmentIndexPrefix is the prefix of the builds skipped for the segments too small to build index, keyed
+	// by index and segment.
+	skippedSegmentIndexPrefix = "skipped-segment-indexes"
+)
+
+func indexDefinitionKey(collectionID, fieldID UniqueID, indexName string) string {
+	return path.Join(indexDefinitionPrefix, strconv.FormatInt(collectionID, 10), strconv.FormatInt(fieldID, 10), indexName)
+}
+
+func segmentIndexKey(indexBuildID UniqueID) string {
+	return path.Join(segmentIndexPrefix, strconv.FormatInt(indexBuildID, 10))
+}
+
+func skippedSegmentIndexKey(indexID, segmentID UniqueID) string {
+	return path.Join(skippedSegmentIndexPrefix, strconv.FormatInt(indexID, 10), strconv.FormatInt(segmentID, 10))
+}
+
 // Meta is used to record the state of the index.
 // revision: The number of times IndexMeta has been changed in ETCD. It's the same as Event.Kv.Version in ETCD.
 // indexMeta:A structure that records the state of the index defined by proto.
@@ -38,8 +66,10 @@ type Meta struct {
 }
 
 type metaTable struct {
-	client            *etcdkv.EtcdKV    // client of a reliable kv service, i.e. etcd client
-	indexBuildID2Meta map[UniqueID]Meta // index build id to index meta
+	client            *etcdkv.EtcdKV                                       // client of a reliable kv service, i.e. etcd client
+	indexDefinitions  map[UniqueID]map[UniqueID]*indexpb.IndexDefinition   // collection id to index id to index definition
+	indexBuildID2Meta map[UniqueID]Meta                                    // index build id to index meta
+	skippedIndexes    map[UniqueID]map[UniqueID]*indexpb.BuildIndexRequest // index id to segment id to skipped build
 
 	lock sync.RWMutex
 }
@@ -59,8 +89,25 @@ func NewMetaTable(kv *etcdkv.EtcdKV) (*metaTable, error) {
 }
 
 func (mt *metaTable) reloadFromKV() error {
+	if err := mt.migrateLegacyMeta(); err != nil {
+		return err
+	}
+
+	mt.indexDefinitions = make(map[UniqueID]map[UniqueID]*indexpb.IndexDefinition)
+	_, definitionValues, err := mt.client.LoadWithPrefix(indexDefinitionPrefix)
+	if err != nil {
+		return err
+	}
+	for _, value := range definitionValues {
+		definition := &indexpb.IndexDefinition{}
+		if err := proto.Unmarshal([]byte(value), definition); err != nil {
+			return fmt.Errorf("IndexCoord metaTable reloadFromKV UnmarshalText indexpb.IndexDefinition err:%w", err)
+		}
+		mt.unlockedPutIndexDefinition(definition)
+	}
+
 	mt.indexBuildID2Meta = make(map[UniqueID]Meta)
-	key := "indexes"
+	key := segmentIndexPrefix
 	log.Debug("IndexCoord metaTable LoadWithPrefix ", zap.String("prefix", key))
 
 	_, values, versions, err := mt.client.LoadWithPrefix2(key)
@@ -81,16 +128,153 @@ func (mt *metaTable) reloadFromKV() error {
 		}
 		mt.indexBuildID2Meta[indexMeta.IndexBuildID] = *meta
 	}
+
+	mt.skippedIndexes = make(map[UniqueID]map[UniqueID]*indexpb.BuildIndexRequest)
+	_, skippedValues, err := mt.client.LoadWithPrefix(skippedSegmentIndexPrefix)
+	if err != nil {
+		return err
+	}
+	for _, value := range skippedValues {
+		req := &indexpb.BuildIndexRequest{}
+		if err := proto.Unmarshal([]byte(value), req); err != nil {
+			return fmt.Errorf("IndexCoord metaTable reloadFromKV UnmarshalText indexpb.BuildIndexRequest err:%w", err)
+		}
+		mt.unlockedPutSkippedIndex(req)
+	}
 	return nil
 }
 
+// migrateLegacyMeta converts the flat index build records of the older versions into the index definitions and the
+// segment index builds. The definitions are only derived from the builds carrying the collection and the field, and
+// the builds in progress are reset to be reassigned, because their IndexNodes update the records at the legacy keys.
+// Each build is moved in a transaction, so an interrupted migration is continued at the next startup.
+func (mt *metaTable) migrateLegacyMeta() error {
+	_, values, err := mt.client.LoadWithPrefix(legacyIndexMetaPrefix + "/")
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	log.Debug("IndexCoord metaTable migrate legacy index meta", zap.Int("count", len(values)))
+
+	indexMetas := make([]*indexpb.IndexMeta, 0, len(values))
+	definitions := make(map[string]*indexpb.IndexDefinition)
+	for _, value := range values {
+		indexMeta := &indexpb.IndexMeta{}
+		if err := proto.Unmarshal([]byte(value), indexMeta); err != nil {
+			return fmt.Errorf("IndexCoord metaTable migrateLegacyMeta UnmarshalText indexpb.IndexMeta err:%w", err)
+		}
+		indexMetas = append(indexMetas, indexMeta)
+
+		req := indexMeta.Req
+		if indexMeta.MarkDeleted || req.GetCollectionID() == 0 || req.GetFieldID() == 0 {
+			continue
+		}
+		key := indexDefinitionKey(req.CollectionID, req.FieldID, req.IndexName)
+		if definition, ok := definitions[key]; ok && definition.IndexID >= req.IndexID {
+			continue
+		}
+		definitions[key] = newIndexDefinition(req)
+	}
+
+	for key, definition := range definitions {
+		value, err := proto.Marshal(definition)
+		if err != nil {
+			return err
+		}
+		if err := mt.client.Save(key, string(value)); err != nil {
+			return err
+		}
+	}
+	for _, indexMeta := range indexMetas {
+		if indexMeta.State == commonpb.IndexState_InProgress {
+			indexMeta.State = commonpb.IndexState_Unissued
+			indexMeta.NodeID = 0
+		}
+		value, err := proto.Marshal(indexMeta)
+		if err != nil {
+			return err
+		}
+		saves := map[string]string{segmentIndexKey(indexMeta.IndexBuildID): string(value)}
+		removals := []string{path.Join(legacyIndexMetaPrefix, strconv.FormatInt(indexMeta.IndexBuildID, 10))}
+		if err := mt.client.MultiSaveAndRemove(saves, removals); err != nil {
+			return err
+		}
+	}
+	log.Debug("IndexCoord metaTable migrate legacy index meta success", zap.Int("builds", len(indexMetas)),
+		zap.Int("definitions", len(definitions)))
+	return nil
+}
+
+func newIndexDefinition(req *indexpb.BuildIndexRequest) *indexpb.IndexDefinition {
+	return &indexpb.IndexDefinition{
+		CollectionID: req.CollectionID,
+		FieldID:      req.FieldID,
+		IndexName:    req.IndexName,
+		IndexID:      req.IndexID,
+		TypeParams:   req.TypeParams,
+		IndexParams:  req.IndexParams,
+	}
+}
+
+func equalParams(params1, params2 []*commonpb.KeyValuePair) bool {
+	return (len(params1) == 0 && len(params2) == 0) || typeutil.CompareIndexParams(params1, params2)
+}
+
+// metaTable.lock.Lock() before call this function
+func (mt *metaTable) unlockedPutIndexDefinition(definition *indexpb.IndexDefinition) {
+	definitions, ok := mt.indexDefinitions[definition.CollectionID]
+	if !ok {
+		definitions = make(map[UniqueID]*indexpb.IndexDefinition)
+		mt.indexDefinitions[definition.CollectionID] = definitions
+	}
+	definitions[definition.IndexID] = definition
+}
+
+// metaTable.lock.Lock() before call this function
+func (mt *metaTable) unlockedAddIndexDefinition(definition *indexpb.IndexDefinition) (UniqueID, error) {
+	for _, existing := range mt.indexDefinitions[definition.CollectionID] {
+		if existing.FieldID != definition.FieldID {
+			continue
+		}
+		if existing.IndexName == definition.IndexName && equalParams(existing.TypeParams, definition.TypeParams) &&
+			equalParams(existing.IndexParams, definition.IndexParams) {
+			return existing.IndexID, nil
+		}
+		return 0, fmt.Errorf("field %d of collection %d already has index %s with different params, drop it before creating a new one",
+			definition.FieldID, definition.CollectionID, existing.IndexName)
+	}
+
+	value, err := proto.Marshal(definition)
+	if err != nil {
+		return 0, err
+	}
+	key := indexDefinitionKey(definition.CollectionID, definition.FieldID, definition.IndexName)
+	if err := mt.client.Save(key, string(value)); err != nil {
+		return 0, err
+	}
+	mt.unlockedPutIndexDefinition(proto.Clone(definition).(*indexpb.IndexDefinition))
+	log.Debug("IndexCoord metaTable add index definition", zap.String("key", key), zap.Int64("indexID", definition.IndexID))
+	return definition.IndexID, nil
+}
+
+// AddIndexDefinition saves the definition of an index. It returns the ID of the existing index if the same index has
+// been created on the field, and fails if the field has an index with different name or params.
+func (mt *metaTable) AddIndexDefinition(definition *indexpb.IndexDefinition) (UniqueID, error) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	return mt.unlockedAddIndexDefinition(definition)
+}
+
 // metaTable.lock.Lock() before call this function
 func (mt *metaTable) saveIndexMeta(meta *Meta) error {
 	value, err := proto.Marshal(meta.indexMeta)
 	if err != nil {
 		return err
 	}
-	key := "indexes/" + strconv.FormatInt(meta.indexMeta.IndexBuildID, 10)
+	key := segmentIndexKey(meta.indexMeta.IndexBuildID)
 	err = mt.client.CompareVersionAndSwap(key, meta.revision, string(value))
 	log.Debug("IndexCoord metaTable saveIndexMeta ", zap.String("key", key), zap.Error(err))
 	if err != nil {
@@ -104,7 +288,7 @@ func (mt *metaTable) saveIndexMeta(meta *Meta) error {
 }
 
 func (mt *metaTable) reloadMeta(indexBuildID UniqueID) (*Meta, error) {
-	key := "indexes/" + strconv.FormatInt(indexBuildID, 10)
+	key := segmentIndexKey(indexBuildID)
 
 	_, values, version, err := mt.client.LoadWithPrefix2(key)
 	log.Debug("IndexCoord reloadMeta mt.client.LoadWithPrefix2", zap.Any("indexBuildID", indexBuildID), zap.Error(err))
@@ -132,6 +316,94 @@ func (mt *metaTable) reloadMeta(indexBuildID UniqueID) (*Meta, error) {
 	return m, nil
 }
 
+// metaTable.lock.Lock() before call this function
+func (mt *metaTable) unlockedCheckIndexDefinition(req *indexpb.BuildIndexRequest) error {
+	// the requests without collection are sent by the older versions of RootCoord
+	if req.CollectionID == 0 {
+		return nil
+	}
+	indexID, err := mt.unlockedAddIndexDefinition(newIndexDefinition(req))
+	if err != nil {
+		return err
+	}
+	if indexID != req.IndexID {
+		return fmt.Errorf("index %s on field %d of collection %d is %d, not %d", req.IndexName, req.FieldID,
+			req.CollectionID, indexID, req.IndexID)
+	}
+	return nil
+}
+
+// metaTable.lock.Lock() before call this function
+func (mt *metaTable) unlockedPutSkippedIndex(req *indexpb.BuildIndexRequest) {
+	segments, ok := mt.skippedIndexes[req.IndexID]
+	if !ok {
+		segments = make(map[UniqueID]*indexpb.BuildIndexRequest)
+		mt.skippedIndexes[req.IndexID] = segments
+	}
+	segments[req.SegmentID] = req
+}
+
+// metaTable.lock.Lock() before call this function
+func (mt *metaTable) unlockedRemoveSkippedIndex(indexID, segmentID UniqueID) error {
+	if _, ok := mt.skippedIndexes[indexID][segmentID]; !ok {
+		return nil
+	}
+	if err := mt.client.Remove(skippedSegmentIndexKey(indexID, segmentID)); err != nil {
+		return err
+	}
+	delete(mt.skippedIndexes[indexID], segmentID)
+	if len(mt.skippedIndexes[indexID]) == 0 {
+		delete(mt.skippedIndexes, indexID)
+	}
+	return nil
+}
+
+// SkipIndex records that the build of an index on a segment is skipped because the segment is too small, the segment
+// is searched by brute force and indexed after compaction merges it into a larger one. Like AddIndex, the definition of
+// the index is added if it's not created.
+func (mt *metaTable) SkipIndex(req *indexpb.BuildIndexRequest) error {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	if err := mt.unlockedCheckIndexDefinition(req); err != nil {
+		return err
+	}
+	if _, ok := mt.skippedIndexes[req.IndexID][req.SegmentID]; ok {
+		return nil
+	}
+	value, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	key := skippedSegmentIndexKey(req.IndexID, req.SegmentID)
+	if err := mt.client.Save(key, string(value)); err != nil {
+		return err
+	}
+	mt.unlockedPutSkippedIndex(proto.Clone(req).(*indexpb.BuildIndexRequest))
+	log.Debug("IndexCoord metaTable skip index", zap.String("key", key), zap.Int64("numRows", req.NumRows))
+	return nil
+}
+
+// GetSkippedSegments returns the segments whose builds are skipped for the index named indexName on field fieldID.
+func (mt *metaTable) GetSkippedSegments(fieldID UniqueID, indexName string, segmentIDs []UniqueID) map[UniqueID]struct{} {
+	mt.lock.RLock()
+	defer mt.lock.RUnlock()
+
+	skipped := make(map[UniqueID]struct{})
+	for _, segments := range mt.skippedIndexes {
+		for _, segmentID := range segmentIDs {
+			req, ok := segments[segmentID]
+			if ok && req.FieldID == fieldID && req.IndexName == indexName {
+				skipped[segmentID] = struct{}{}
+			}
+		}
+	}
+	return skipped
+}
+
+// AddIndex adds the build of an index on a segment. The definition of the index is added if it's not created, which
+// happens for the indexes created before the definitions are introduced, and the build fails if the field has another index.
+// The skipped build of the segment is removed, so a segment is counted either as skipped or by its build.
 func (mt *metaTable) AddIndex(indexBuildID UniqueID, req *indexpb.BuildIndexRequest) error {
 	mt.lock.Lock()
 	defer mt.lock.Unlock()
@@ -140,6 +412,12 @@ func (mt *metaTable) AddIndex(indexBuildID UniqueID, req *indexpb.BuildIndexRequ
 	if ok {
 		return fmt.Errorf("index already exists with ID = %d", indexBuildID)
 	}
+	if err := mt.unlockedCheckIndexDefinition(req); err != nil {
+		return err
+	}
+	if err := mt.unlockedRemoveSkippedIndex(req.IndexID, req.SegmentID); err != nil {
+		return err
+	}
 	meta := &Meta{
 		indexMeta: &indexpb.IndexMeta{
 			State:        commonpb.IndexState_Unissued,
@@ -168,8 +446,10 @@ func (mt *metaTable) BuildIndex(indexBuildID UniqueID, nodeID int64) error {
 	//	return fmt.Errorf("can not set lease key, index with ID = %d state is %d", indexBuildID, meta.indexMeta.State)
 	//}
 
+	assignTime := time.Now().UnixNano()
 	meta.indexMeta.NodeID = nodeID
 	meta.indexMeta.State = commonpb.IndexState_InProgress
+	meta.indexMeta.AssignTime = assignTime
 
 	err := mt.saveIndexMeta(&meta)
 	if err != nil {
@@ -179,6 +459,8 @@ func (mt *metaTable) BuildIndex(indexBuildID UniqueID, nodeID int64) error {
 				return err
 			}
 			m.indexMeta.NodeID = nodeID
+			m.indexMeta.State = commonpb.IndexState_InProgress
+			m.indexMeta.AssignTime = assignTime
 			return mt.saveIndexMeta(m)
 		}
 		err2 := retry.Do(context.TODO(), fn, retry.Attempts(5))
@@ -226,37 +508,104 @@ func (mt *metaTable) UpdateVersion(indexBuildID UniqueID) error {
 	return nil
 }
 
-func (mt *metaTable) MarkIndexAsDeleted(indexID UniqueID) error {
+// MarkIndexAsDeleted removes the definition of the index and marks its builds as deleted, and returns the builds that were in progress, so that
+// their IndexNodes can be notified to cancel them. The unfinished builds are fenced by a new version, which stops the
+// IndexNodes from saving index files, and are finished so that the recycler removes the files already saved.
+func (mt *metaTable) MarkIndexAsDeleted(indexID UniqueID) ([]Meta, error) {
 	mt.lock.Lock()
 	defer mt.lock.Unlock()
 
 	log.Debug("IndexCoord metaTable MarkIndexAsDeleted ", zap.Int64("indexID", indexID))
 
+	for collectionID, definitions := range mt.indexDefinitions {
+		definition, ok := definitions[indexID]
+		if !ok {
+			continue
+		}
+		key := indexDefinitionKey(definition.CollectionID, definition.FieldID, definition.IndexName)
+		if err := mt.client.Remove(key); err != nil {
+			return nil, err
+		}
+		delete(definitions, indexID)
+		if len(definitions) == 0 {
+			delete(mt.indexDefinitions, collectionID)
+		}
+		break
+	}
+
+	if _, ok := mt.skippedIndexes[indexID]; ok {
+		prefix := path.Join(skippedSegmentIndexPrefix, strconv.FormatInt(indexID, 10)) + "/"
+		if err := mt.client.RemoveWithPrefix(prefix); err != nil {
+			return nil, err
+		}
+		delete(mt.skippedIndexes, indexID)
+	}
+
+	return mt.unlockedMarkBuildsAsDeleted(func(req *indexpb.BuildIndexRequest) bool {
+		return req.IndexID == indexID
+	})
+}
+
+// MarkSegmentIndexAsDeleted marks the builds on a dropped segment as deleted and removes its skipped builds, and returns
+// the builds that were in progress. Like the builds of a dropped index, the index files are removed by the recycler.
+func (mt *metaTable) MarkSegmentIndexAsDeleted(segmentID UniqueID) ([]Meta, error) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	log.Debug("IndexCoord metaTable MarkSegmentIndexAsDeleted", zap.Int64("segmentID", segmentID))
+
+	for indexID, segments := range mt.skippedIndexes {
+		if _, ok := segments[segmentID]; !ok {
+			continue
+		}
+		if err := mt.unlockedRemoveSkippedIndex(indexID, segmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	return mt.unlockedMarkBuildsAsDeleted(func(req *indexpb.BuildIndexRequest) bool {
+		return req.SegmentID == segmentID
+	})
+}
+
+// metaTable.lock.Lock() before call this function
+func (mt *metaTable) unlockedMarkBuildsAsDeleted(match func(req *indexpb.BuildIndexRequest) bool) ([]Meta, error) {
+	markDeleted := func(m *Meta) error {
+		m.indexMeta.MarkDeleted = true
+		if m.indexMeta.State == commonpb.IndexState_Unissued || m.indexMeta.State == commonpb.IndexState_InProgress {
+			m.indexMeta.Version = m.indexMeta.Version + 1
+			m.indexMeta.State = commonpb.IndexState_Finished
+		}
+		return mt.saveIndexMeta(m)
+	}
+
+	var inProgress []Meta
 	for _, meta := range mt.indexBuildID2Meta {
-		if meta.indexMeta.Req.IndexID == indexID && !meta.indexMeta.MarkDeleted {
-			meta.indexMeta.MarkDeleted = true
-			// marshal inside
-			/* #nosec G601 */
-			if err := mt.saveIndexMeta(&meta); err != nil {
-				log.Error("IndexCoord metaTable MarkIndexAsDeleted saveIndexMeta failed", zap.Error(err))
+		if match(meta.indexMeta.Req) && !meta.indexMeta.MarkDeleted {
+			origin := Meta{indexMeta: proto.Clone(meta.indexMeta).(*indexpb.IndexMeta), revision: meta.revision}
+			m := &Meta{indexMeta: proto.Clone(meta.indexMeta).(*indexpb.IndexMeta), revision: meta.revision}
+			if err := markDeleted(m); err != nil {
+				log.Error("IndexCoord metaTable mark builds as deleted saveIndexMeta failed", zap.Error(err))
 				fn := func() error {
 					m, err := mt.reloadMeta(meta.indexMeta.IndexBuildID)
 					if m == nil {
 						return err
 					}
-
-					m.indexMeta.MarkDeleted = true
-					return mt.saveIndexMeta(m)
+					origin = Meta{indexMeta: proto.Clone(m.indexMeta).(*indexpb.IndexMeta), revision: m.revision}
+					return markDeleted(m)
 				}
 				err2 := retry.Do(context.TODO(), fn, retry.Attempts(5))
 				if err2 != nil {
-					return err2
+					return inProgress, err2
 				}
 			}
+			if origin.indexMeta.State == commonpb.IndexState_InProgress {
+				inProgress = append(inProgress, origin)
+			}
 		}
 	}
 
-	return nil
+	return inProgress, nil
 }
 
 func (mt *metaTable) GetIndexStates(indexBuildIDs []UniqueID) []*indexpb.IndexInfo {
@@ -283,6 +632,33 @@ func (mt *metaTable) GetIndexStates(indexBuildIDs []UniqueID) []*indexpb.IndexIn
 	return indexStates
 }
 
+// GetSegmentIndexMetas returns the metas of the builds on the segments for the index named indexName on field fieldID,
+// keyed by segment ID. The deleted builds are skipped, and the latest build is returned if a segment has several.
+func (mt *metaTable) GetSegmentIndexMetas(fieldID UniqueID, indexName string, segmentIDs []UniqueID) map[UniqueID]*indexpb.IndexMeta {
+	mt.lock.RLock()
+	defer mt.lock.RUnlock()
+
+	segments := make(map[UniqueID]struct{}, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		segments[segmentID] = struct{}{}
+	}
+	metas := make(map[UniqueID]*indexpb.IndexMeta)
+	for _, meta := range mt.indexBuildID2Meta {
+		req := meta.indexMeta.Req
+		if meta.indexMeta.MarkDeleted || req.FieldID != fieldID || req.IndexName != indexName {
+			continue
+		}
+		if _, ok := segments[req.SegmentID]; !ok {
+			continue
+		}
+		if old, ok := metas[req.SegmentID]; ok && old.IndexBuildID > meta.indexMeta.IndexBuildID {
+			continue
+		}
+		metas[req.SegmentID] = proto.Clone(meta.indexMeta).(*indexpb.IndexMeta)
+	}
+	return metas
+}
+
 func (mt *metaTable) GetIndexFilePathInfo(indexBuildID UniqueID) (*indexpb.IndexFilePathInfo, error) {
 	mt.lock.Lock()
 	defer mt.lock.Unlock()
@@ -305,7 +681,7 @@ func (mt *metaTable) DeleteIndex(indexBuildID UniqueID) {
 	defer mt.lock.Unlock()
 
 	delete(mt.indexBuildID2Meta, indexBuildID)
-	key := "indexes/" + strconv.FormatInt(indexBuildID, 10)
+	key := segmentIndexKey(indexBuildID)
 
 	err := mt.client.Remove(key)
 	log.Debug("IndexCoord metaTable DeleteIndex", zap.Error(err))
@@ -354,7 +730,11 @@ func (mt *metaTable) GetUnusedIndexFiles(limit int) []Meta {
 
 	var metas []Meta
 	for _, meta := range mt.indexBuildID2Meta {
-		if meta.indexMeta.State == commonpb.IndexState_Finished && (meta.indexMeta.MarkDeleted || !meta.indexMeta.Recycled) {
+		// the files of deleted builds are all removed, and the low version files of the others are removed
+		deleted := meta.indexMeta.MarkDeleted &&
+			(meta.indexMeta.State == commonpb.IndexState_Finished || meta.indexMeta.State == commonpb.IndexState_Failed)
+		outdated := !meta.indexMeta.MarkDeleted && meta.indexMeta.State == commonpb.IndexState_Finished && !meta.indexMeta.Recycled
+		if deleted || outdated {
 			metas = append(metas, Meta{indexMeta: proto.Clone(meta.indexMeta).(*indexpb.IndexMeta), revision: meta.revision})
 		}
 		if len(metas) >= limit {
@@ -365,28 +745,43 @@ func (mt *metaTable) GetUnusedIndexFiles(limit int) []Meta {
 	return metas
 }
 
-func (mt *metaTable) GetUnassignedTasks(onlineNodeIDs []int64) []Meta {
+// GetUnassignedTasks returns the tasks waiting to be assigned to IndexNode.
+func (mt *metaTable) GetUnassignedTasks() []Meta {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
 	var metas []Meta
 
 	for _, meta := range mt.indexBuildID2Meta {
-		if meta.indexMeta.State == commonpb.IndexState_Unissued {
+		if meta.indexMeta.State == commonpb.IndexState_Unissued && !meta.indexMeta.MarkDeleted {
 			metas = append(metas, Meta{indexMeta: proto.Clone(meta.indexMeta).(*indexpb.IndexMeta), revision: meta.revision})
-			continue
 		}
-		if meta.indexMeta.State == commonpb.IndexState_Finished || meta.indexMeta.State == commonpb.IndexState_Failed {
+	}
+
+	return metas
+}
+
+// GetLostTasks returns the in-progress tasks whose IndexNode is not online, or which have been assigned for
+// longer than timeout. A timeout of 0 means the tasks never time out.
+func (mt *metaTable) GetLostTasks(onlineNodeIDs []int64, timeout time.Duration) []Meta {
+	mt.lock.RLock()
+	defer mt.lock.RUnlock()
+
+	online := make(map[int64]struct{}, len(onlineNodeIDs))
+	for _, nodeID := range onlineNodeIDs {
+		online[nodeID] = struct{}{}
+	}
+	now := time.Now().UnixNano()
+
+	var metas []Meta
+	for _, meta := range mt.indexBuildID2Meta {
+		if meta.indexMeta.State != commonpb.IndexState_InProgress {
 			continue
 		}
-		alive := false
-		for _, serverID := range onlineNodeIDs {
-			if meta.indexMeta.NodeID == serverID {
-				alive = true
-				break
-			}
-		}
-		if !alive {
+		_, alive := online[meta.indexMeta.NodeID]
+		// tasks assigned before the assign time is recorded never time out
+		timedOut := timeout > 0 && meta.indexMeta.AssignTime > 0 && now-meta.indexMeta.AssignTime > int64(timeout)
+		if !alive || timedOut {
 			metas = append(metas, Meta{indexMeta: proto.Clone(meta.indexMeta).(*indexpb.IndexMeta), revision: meta.revision})
 		}
 	}
@@ -394,11 +789,61 @@ func (mt *metaTable) GetUnassignedTasks(onlineNodeIDs []int64) []Meta {
 	return metas
 }
 
+// ResetTask resets the task being built by nodeID to Unissued, so that it is reassigned to another IndexNode.
+// The task fails with reason instead once it has been reset more than maxRetry times.
+func (mt *metaTable) ResetTask(indexBuildID UniqueID, nodeID int64, reason string, maxRetry int64) error {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	meta, ok := mt.indexBuildID2Meta[indexBuildID]
+	log.Debug("IndexCoord metaTable ResetTask", zap.Int64("indexBuildID", indexBuildID),
+		zap.Int64("nodeID", nodeID), zap.String("reason", reason), zap.Bool("exists", ok))
+	if !ok {
+		return fmt.Errorf("index not exists with ID = %d", indexBuildID)
+	}
+
+	reset := func(m *Meta) error {
+		// the task has been finished or reassigned
+		if m.indexMeta.State != commonpb.IndexState_InProgress || m.indexMeta.NodeID != nodeID {
+			return nil
+		}
+		m.indexMeta.RetryCount++
+		if m.indexMeta.RetryCount > maxRetry {
+			m.indexMeta.State = commonpb.IndexState_Failed
+			m.indexMeta.FailReason = fmt.Sprintf("%s, and the task has been retried %d times", reason, maxRetry)
+		} else {
+			m.indexMeta.State = commonpb.IndexState_Unissued
+		}
+		return mt.saveIndexMeta(m)
+	}
+
+	m := &Meta{indexMeta: proto.Clone(meta.indexMeta).(*indexpb.IndexMeta), revision: meta.revision}
+	if err := reset(m); err != nil {
+		fn := func() error {
+			m, err := mt.reloadMeta(indexBuildID)
+			if m == nil {
+				return err
+			}
+			return reset(m)
+		}
+		err2 := retry.Do(context.TODO(), fn, retry.Attempts(5))
+		if err2 != nil {
+			log.Error("IndexCoord metaTable ResetTask failed", zap.Error(err2))
+			return err2
+		}
+	}
+
+	return nil
+}
+
 func (mt *metaTable) HasSameReq(req *indexpb.BuildIndexRequest) (bool, UniqueID) {
 	mt.lock.Lock()
 	defer mt.lock.Unlock()
 
 	for _, meta := range mt.indexBuildID2Meta {
+		if meta.indexMeta.MarkDeleted {
+			continue
+		}
 		if meta.indexMeta.Req.IndexID != req.IndexID {
 			continue
 		}
@@ -14,6 +14,7 @@ package indexcoord
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
@@ -46,7 +47,7 @@ func TestMetaTable(t *testing.T) {
 	}
 	value, err := proto.Marshal(indexMeta1)
 	assert.Nil(t, err)
-	key := "indexes/" + strconv.FormatInt(indexMeta1.IndexBuildID, 10)
+	key := segmentIndexKey(indexMeta1.IndexBuildID)
 	err = etcdKV.Save(key, string(value))
 	assert.Nil(t, err)
 	metaTable, err := NewMetaTable(etcdKV)
@@ -92,7 +93,7 @@ func TestMetaTable(t *testing.T) {
 		indexMeta1.NodeID = 2
 		value, err = proto.Marshal(indexMeta1)
 		assert.Nil(t, err)
-		key = "indexes/" + strconv.FormatInt(indexMeta1.IndexBuildID, 10)
+		key = segmentIndexKey(indexMeta1.IndexBuildID)
 		err = etcdKV.Save(key, string(value))
 		assert.Nil(t, err)
 		err = metaTable.BuildIndex(indexMeta1.IndexBuildID, 1)
@@ -106,7 +107,7 @@ func TestMetaTable(t *testing.T) {
 		indexMeta1.Version = indexMeta1.Version + 1
 		value, err = proto.Marshal(indexMeta1)
 		assert.Nil(t, err)
-		key = "indexes/" + strconv.FormatInt(indexMeta1.IndexBuildID, 10)
+		key = segmentIndexKey(indexMeta1.IndexBuildID)
 		err = etcdKV.Save(key, string(value))
 		assert.Nil(t, err)
 		err = metaTable.UpdateVersion(indexMeta1.IndexBuildID)
@@ -117,11 +118,12 @@ func TestMetaTable(t *testing.T) {
 		indexMeta1.Version = indexMeta1.Version + 1
 		value, err = proto.Marshal(indexMeta1)
 		assert.Nil(t, err)
-		key = "indexes/" + strconv.FormatInt(indexMeta1.IndexBuildID, 10)
+		key = segmentIndexKey(indexMeta1.IndexBuildID)
 		err = etcdKV.Save(key, string(value))
 		assert.Nil(t, err)
-		err = metaTable.MarkIndexAsDeleted(indexMeta1.Req.IndexID)
+		inProgress, err := metaTable.MarkIndexAsDeleted(indexMeta1.Req.IndexID)
 		assert.Nil(t, err)
+		assert.Empty(t, inProgress)
 	})
 
 	t.Run("GetIndexState", func(t *testing.T) {
@@ -145,7 +147,7 @@ func TestMetaTable(t *testing.T) {
 		indexMeta1.Version = indexMeta1.Version + 1
 		value, err = proto.Marshal(indexMeta1)
 		assert.Nil(t, err)
-		key = "indexes/" + strconv.FormatInt(indexMeta1.IndexBuildID, 10)
+		key = segmentIndexKey(indexMeta1.IndexBuildID)
 		err = etcdKV.Save(key, string(value))
 		assert.Nil(t, err)
 
@@ -250,7 +252,7 @@ func TestMetaTable(t *testing.T) {
 		ok := metaTable.LoadMetaFromETCD(8, 0)
 		assert.Equal(t, false, ok)
 
-		key = "indexes/" + strconv.FormatInt(req4.IndexBuildID, 10)
+		key = segmentIndexKey(req4.IndexBuildID)
 		err = etcdKV.RemoveWithPrefix(key)
 		assert.Nil(t, err)
 
@@ -278,7 +280,108 @@ func TestMetaTable(t *testing.T) {
 		assert.Equal(t, 1, priorities[4])
 	})
 
-	err = etcdKV.RemoveWithPrefix("indexes/")
+	t.Run("ResetTask", func(t *testing.T) {
+		req6 := &indexpb.BuildIndexRequest{
+			IndexBuildID: 10,
+			IndexName:    "test_index",
+			IndexID:      6,
+			DataPaths:    []string{"DataPath-1-1", "DataPath-1-2"},
+		}
+		err = metaTable.AddIndex(req6.IndexBuildID, req6)
+		assert.Nil(t, err)
+		err = metaTable.BuildIndex(req6.IndexBuildID, 5)
+		assert.Nil(t, err)
+
+		isLost := func(onlineNodeIDs []int64, timeout time.Duration) bool {
+			for _, meta := range metaTable.GetLostTasks(onlineNodeIDs, timeout) {
+				if meta.indexMeta.IndexBuildID == req6.IndexBuildID {
+					return true
+				}
+			}
+			return false
+		}
+		assert.False(t, isLost([]int64{4, 5}, 0))
+		assert.False(t, isLost([]int64{4, 5}, time.Hour))
+		assert.True(t, isLost([]int64{4}, 0))
+		time.Sleep(time.Millisecond)
+		assert.True(t, isLost([]int64{4, 5}, time.Millisecond))
+
+		err = metaTable.ResetTask(11, 5, "IndexNode 5 is offline", 1)
+		assert.NotNil(t, err)
+
+		// the task is not built by node 6
+		err = metaTable.ResetTask(req6.IndexBuildID, 6, "IndexNode 6 is offline", 1)
+		assert.Nil(t, err)
+		indexMeta := metaTable.GetIndexMetaByIndexBuildID(req6.IndexBuildID)
+		assert.Equal(t, commonpb.IndexState_InProgress, indexMeta.State)
+
+		err = metaTable.ResetTask(req6.IndexBuildID, 5, "IndexNode 5 is offline", 1)
+		assert.Nil(t, err)
+		indexMeta = metaTable.GetIndexMetaByIndexBuildID(req6.IndexBuildID)
+		assert.Equal(t, commonpb.IndexState_Unissued, indexMeta.State)
+		assert.Equal(t, int64(1), indexMeta.RetryCount)
+		unassigned := false
+		for _, meta := range metaTable.GetUnassignedTasks() {
+			if meta.indexMeta.IndexBuildID == req6.IndexBuildID {
+				unassigned = true
+			}
+		}
+		assert.True(t, unassigned)
+
+		// the task fails when it's retried more than the max retry times
+		err = metaTable.BuildIndex(req6.IndexBuildID, 6)
+		assert.Nil(t, err)
+		err = metaTable.ResetTask(req6.IndexBuildID, 6, "IndexNode 6 is offline", 1)
+		assert.Nil(t, err)
+		indexMeta = metaTable.GetIndexMetaByIndexBuildID(req6.IndexBuildID)
+		assert.Equal(t, commonpb.IndexState_Failed, indexMeta.State)
+		assert.Contains(t, indexMeta.FailReason, "IndexNode 6 is offline")
+		assert.False(t, isLost(nil, 0))
+	})
+
+	t.Run("MarkIndexAsDeleted InProgress", func(t *testing.T) {
+		req7 := &indexpb.BuildIndexRequest{
+			IndexBuildID: 11,
+			IndexName:    "test_index",
+			IndexID:      7,
+			DataPaths:    []string{"DataPath-1-1", "DataPath-1-2"},
+		}
+		err = metaTable.AddIndex(req7.IndexBuildID, req7)
+		assert.Nil(t, err)
+		err = metaTable.UpdateVersion(req7.IndexBuildID)
+		assert.Nil(t, err)
+		err = metaTable.BuildIndex(req7.IndexBuildID, 5)
+		assert.Nil(t, err)
+		has, _ := metaTable.HasSameReq(req7)
+		assert.True(t, has)
+
+		inProgress, err := metaTable.MarkIndexAsDeleted(req7.IndexID)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(inProgress))
+		assert.Equal(t, req7.IndexBuildID, inProgress[0].indexMeta.IndexBuildID)
+		assert.Equal(t, int64(5), inProgress[0].indexMeta.NodeID)
+
+		// the build is fenced by a new version, and its files are recycled
+		indexMeta := metaTable.GetIndexMetaByIndexBuildID(req7.IndexBuildID)
+		assert.True(t, indexMeta.MarkDeleted)
+		assert.Equal(t, commonpb.IndexState_Finished, indexMeta.State)
+		assert.Equal(t, int64(2), indexMeta.Version)
+		unused := false
+		for _, meta := range metaTable.GetUnusedIndexFiles(100) {
+			if meta.indexMeta.IndexBuildID == req7.IndexBuildID {
+				unused = true
+			}
+		}
+		assert.True(t, unused)
+		has, _ = metaTable.HasSameReq(req7)
+		assert.False(t, has)
+
+		inProgress, err = metaTable.MarkIndexAsDeleted(req7.IndexID)
+		assert.Nil(t, err)
+		assert.Empty(t, inProgress)
+	})
+
+	err = etcdKV.RemoveWithPrefix(segmentIndexPrefix)
 	assert.Nil(t, err)
 }
 
@@ -289,7 +392,7 @@ func TestMetaTable_Error(t *testing.T) {
 
 	t.Run("reloadFromKV error", func(t *testing.T) {
 		value := "indexMeta-1"
-		key := "indexes/" + strconv.FormatInt(2, 10)
+		key := segmentIndexKey(2)
 		err = etcdKV.Save(key, value)
 		assert.Nil(t, err)
 		meta, err := NewMetaTable(etcdKV)
@@ -299,6 +402,284 @@ func TestMetaTable_Error(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
-	err = etcdKV.RemoveWithPrefix("indexes/")
+	err = etcdKV.RemoveWithPrefix(segmentIndexPrefix)
+	assert.Nil(t, err)
+}
+
+func TestMetaTable_GetSegmentIndexMetas(t *testing.T) {
+	newMeta := func(indexBuildID, segmentID, fieldID UniqueID, indexName string, markDeleted bool) Meta {
+		return Meta{
+			indexMeta: &indexpb.IndexMeta{
+				IndexBuildID: indexBuildID,
+				State:        commonpb.IndexState_Finished,
+				MarkDeleted:  markDeleted,
+				Req: &indexpb.BuildIndexRequest{
+					IndexBuildID: indexBuildID,
+					IndexName:    indexName,
+					SegmentID:    segmentID,
+					FieldID:      fieldID,
+				},
+			},
+		}
+	}
+	metaTable := &metaTable{
+		indexBuildID2Meta: map[UniqueID]Meta{
+			1: newMeta(1, 100, 10, "idx", true),
+			2: newMeta(2, 100, 10, "idx", false),
+			3: newMeta(3, 101, 10, "idx", false),
+			4: newMeta(4, 101, 10, "idx", false),
+			5: newMeta(5, 102, 10, "other", false),
+			6: newMeta(6, 103, 11, "idx", false),
+			7: newMeta(7, 104, 10, "idx", false),
+		},
+	}
+
+	metas := metaTable.GetSegmentIndexMetas(10, "idx", []UniqueID{100, 101, 102, 103})
+	assert.Equal(t, 2, len(metas))
+	assert.Equal(t, UniqueID(2), metas[100].IndexBuildID)
+	assert.Equal(t, UniqueID(4), metas[101].IndexBuildID)
+
+	metas = metaTable.GetSegmentIndexMetas(10, "idx", nil)
+	assert.Equal(t, 0, len(metas))
+}
+
+func TestMetaTable_IndexDefinition(t *testing.T) {
+	Params.Init()
+	etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	removeAll := func() {
+		err := etcdKV.MultiRemoveWithPrefix([]string{legacyIndexMetaPrefix, indexDefinitionPrefix, segmentIndexPrefix})
+		assert.Nil(t, err)
+	}
+	removeAll()
+	defer removeAll()
+
+	typeParams := []*commonpb.KeyValuePair{{Key: "dim", Value: "8"}}
+	indexParams := []*commonpb.KeyValuePair{{Key: "index_type", Value: "IVF_FLAT"}, {Key: "nlist", Value: "16"}}
+	newReq := func(indexBuildID, collectionID, fieldID, indexID UniqueID) *indexpb.BuildIndexRequest {
+		return &indexpb.BuildIndexRequest{
+			IndexBuildID: indexBuildID,
+			IndexName:    "_default_idx",
+			IndexID:      indexID,
+			TypeParams:   typeParams,
+			IndexParams:  indexParams,
+			SegmentID:    indexBuildID,
+			FieldID:      fieldID,
+			CollectionID: collectionID,
+		}
+	}
+	legacyMetas := []*indexpb.IndexMeta{
+		{IndexBuildID: 1, State: commonpb.IndexState_InProgress, NodeID: 3, Req: newReq(1, 1, 100, 10)},
+		{IndexBuildID: 2, State: commonpb.IndexState_Finished, NodeID: 3, Req: newReq(2, 1, 100, 10)},
+		// written by the older versions of RootCoord without collection and field
+		{IndexBuildID: 3, State: commonpb.IndexState_Finished, Req: newReq(3, 0, 0, 11)},
+		// the build of a dropped index
+		{IndexBuildID: 4, State: commonpb.IndexState_Finished, MarkDeleted: true, Req: newReq(4, 1, 100, 9)},
+	}
+	for _, indexMeta := range legacyMetas {
+		value, err := proto.Marshal(indexMeta)
+		assert.Nil(t, err)
+		err = etcdKV.Save(legacyIndexMetaPrefix+"/"+strconv.FormatInt(indexMeta.IndexBuildID, 10), string(value))
+		assert.Nil(t, err)
+	}
+
+	metaTable, err := NewMetaTable(etcdKV)
+	assert.Nil(t, err)
+
+	t.Run("migrate legacy meta", func(t *testing.T) {
+		_, values, err := etcdKV.LoadWithPrefix(legacyIndexMetaPrefix + "/")
+		assert.Nil(t, err)
+		assert.Empty(t, values)
+		_, values, err = etcdKV.LoadWithPrefix(segmentIndexPrefix)
+		assert.Nil(t, err)
+		assert.Equal(t, len(legacyMetas), len(values))
+
+		assert.Equal(t, len(legacyMetas), len(metaTable.indexBuildID2Meta))
+		// the build in progress is reset to be reassigned
+		assert.Equal(t, commonpb.IndexState_Unissued, metaTable.indexBuildID2Meta[1].indexMeta.State)
+		assert.Equal(t, int64(0), metaTable.indexBuildID2Meta[1].indexMeta.NodeID)
+		assert.Equal(t, commonpb.IndexState_Finished, metaTable.indexBuildID2Meta[2].indexMeta.State)
+		assert.True(t, metaTable.indexBuildID2Meta[4].indexMeta.MarkDeleted)
+
+		assert.Equal(t, 1, len(metaTable.indexDefinitions))
+		assert.Equal(t, 1, len(metaTable.indexDefinitions[1]))
+		definition := metaTable.indexDefinitions[1][10]
+		assert.Equal(t, UniqueID(100), definition.FieldID)
+		assert.Equal(t, "_default_idx", definition.IndexName)
+		value, err := etcdKV.Load(indexDefinitionKey(1, 100, "_default_idx"))
+		assert.Nil(t, err)
+		assert.NotEmpty(t, value)
+	})
+
+	t.Run("AddIndexDefinition", func(t *testing.T) {
+		definition := &indexpb.IndexDefinition{
+			CollectionID: 1,
+			FieldID:      100,
+			IndexName:    "_default_idx",
+			IndexID:      12,
+			TypeParams:   typeParams,
+			IndexParams:  []*commonpb.KeyValuePair{indexParams[1], indexParams[0]},
+		}
+		indexID, err := metaTable.AddIndexDefinition(definition)
+		assert.Nil(t, err)
+		assert.Equal(t, UniqueID(10), indexID)
+
+		definition.IndexParams = []*commonpb.KeyValuePair{{Key: "index_type", Value: "IVF_FLAT"}, {Key: "nlist", Value: "32"}}
+		_, err = metaTable.AddIndexDefinition(definition)
+		assert.NotNil(t, err)
+
+		definition.IndexParams = indexParams
+		definition.IndexName = "other_idx"
+		_, err = metaTable.AddIndexDefinition(definition)
+		assert.NotNil(t, err)
+
+		definition.FieldID = 101
+		indexID, err = metaTable.AddIndexDefinition(definition)
+		assert.Nil(t, err)
+		assert.Equal(t, UniqueID(12), indexID)
+		assert.Equal(t, 2, len(metaTable.indexDefinitions[1]))
+	})
+
+	t.Run("AddIndex", func(t *testing.T) {
+		// the build of an index other than the one created on the field
+		err := metaTable.AddIndex(5, newReq(5, 1, 100, 13))
+		assert.NotNil(t, err)
+
+		// the definition is added by the build of an index which is not created
+		err = metaTable.AddIndex(6, newReq(6, 2, 100, 14))
+		assert.Nil(t, err)
+		assert.Equal(t, UniqueID(14), metaTable.indexDefinitions[2][14].IndexID)
+
+		err = metaTable.AddIndex(7, newReq(7, 1, 100, 10))
+		assert.Nil(t, err)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		reloaded, err := NewMetaTable(etcdKV)
+		assert.Nil(t, err)
+		assert.Equal(t, len(metaTable.indexDefinitions), len(reloaded.indexDefinitions))
+		for collectionID, definitions := range metaTable.indexDefinitions {
+			for indexID, definition := range definitions {
+				assert.True(t, proto.Equal(definition, reloaded.indexDefinitions[collectionID][indexID]))
+			}
+		}
+		assert.Equal(t, len(metaTable.indexBuildID2Meta), len(reloaded.indexBuildID2Meta))
+	})
+
+	t.Run("MarkIndexAsDeleted", func(t *testing.T) {
+		_, err := metaTable.MarkIndexAsDeleted(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(metaTable.indexDefinitions[1]))
+		_, err = etcdKV.Load(indexDefinitionKey(1, 100, "_default_idx"))
+		assert.NotNil(t, err)
+
+		// a new index can be created on the field after the old one is dropped
+		definition := newIndexDefinition(newReq(0, 1, 100, 15))
+		definition.IndexParams = []*commonpb.KeyValuePair{{Key: "index_type", Value: "FLAT"}}
+		indexID, err := metaTable.AddIndexDefinition(definition)
+		assert.Nil(t, err)
+		assert.Equal(t, UniqueID(15), indexID)
+
+		_, err = metaTable.MarkIndexAsDeleted(14)
+		assert.Nil(t, err)
+		_, ok := metaTable.indexDefinitions[2]
+		assert.False(t, ok)
+	})
+}
+
+func TestMetaTable_SkipIndex(t *testing.T) {
+	Params.Init()
+	etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	removeAll := func() {
+		err := etcdKV.MultiRemoveWithPrefix([]string{indexDefinitionPrefix, segmentIndexPrefix, skippedSegmentIndexPrefix})
+		assert.Nil(t, err)
+	}
+	removeAll()
+	defer removeAll()
+
+	newReq := func(segmentID, indexID UniqueID, numRows int64) *indexpb.BuildIndexRequest {
+		return &indexpb.BuildIndexRequest{
+			IndexName:    "_default_idx",
+			IndexID:      indexID,
+			TypeParams:   []*commonpb.KeyValuePair{{Key: "dim", Value: "8"}},
+			IndexParams:  []*commonpb.KeyValuePair{{Key: "index_type", Value: "FLAT"}},
+			SegmentID:    segmentID,
+			FieldID:      100,
+			CollectionID: 1,
+			NumRows:      numRows,
+		}
+	}
+
+	metaTable, err := NewMetaTable(etcdKV)
 	assert.Nil(t, err)
+
+	t.Run("SkipIndex", func(t *testing.T) {
+		err := metaTable.SkipIndex(newReq(1, 10, 100))
+		assert.Nil(t, err)
+		err = metaTable.SkipIndex(newReq(2, 10, 200))
+		assert.Nil(t, err)
+		// skipping again is a no-op
+		err = metaTable.SkipIndex(newReq(2, 10, 200))
+		assert.Nil(t, err)
+		assert.Equal(t, UniqueID(10), metaTable.indexDefinitions[1][10].IndexID)
+
+		// the skipped build of an index other than the one created on the field
+		err = metaTable.SkipIndex(newReq(3, 11, 100))
+		assert.NotNil(t, err)
+
+		skipped := metaTable.GetSkippedSegments(100, "_default_idx", []UniqueID{1, 2, 3})
+		assert.Equal(t, map[UniqueID]struct{}{1: {}, 2: {}}, skipped)
+		assert.Empty(t, metaTable.GetSkippedSegments(101, "_default_idx", []UniqueID{1, 2, 3}))
+		assert.Empty(t, metaTable.GetSkippedSegments(100, "other_idx", []UniqueID{1, 2, 3}))
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		reloaded, err := NewMetaTable(etcdKV)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(reloaded.skippedIndexes))
+		assert.Equal(t, 2, len(reloaded.skippedIndexes[10]))
+		assert.Equal(t, int64(200), reloaded.skippedIndexes[10][2].NumRows)
+	})
+
+	t.Run("AddIndex", func(t *testing.T) {
+		// building the index on a skipped segment removes the skipped build
+		err := metaTable.AddIndex(1000, newReq(2, 10, 2000))
+		assert.Nil(t, err)
+		skipped := metaTable.GetSkippedSegments(100, "_default_idx", []UniqueID{1, 2})
+		assert.Equal(t, map[UniqueID]struct{}{1: {}}, skipped)
+		_, err = etcdKV.Load(skippedSegmentIndexKey(10, 2))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("MarkSegmentIndexAsDeleted", func(t *testing.T) {
+		err := metaTable.SkipIndex(newReq(3, 10, 100))
+		assert.Nil(t, err)
+
+		// the skipped build of the dropped segment is removed
+		inProgress, err := metaTable.MarkSegmentIndexAsDeleted(1)
+		assert.Nil(t, err)
+		assert.Empty(t, inProgress)
+		skipped := metaTable.GetSkippedSegments(100, "_default_idx", []UniqueID{1, 3})
+		assert.Equal(t, map[UniqueID]struct{}{3: {}}, skipped)
+		_, err = etcdKV.Load(skippedSegmentIndexKey(10, 1))
+		assert.NotNil(t, err)
+
+		// the build on the dropped segment is marked deleted, the unissued build isn't returned to be cancelled
+		inProgress, err = metaTable.MarkSegmentIndexAsDeleted(2)
+		assert.Nil(t, err)
+		assert.Empty(t, inProgress)
+		indexMeta := metaTable.GetIndexMetaByIndexBuildID(1000)
+		assert.True(t, indexMeta.MarkDeleted)
+		assert.Equal(t, commonpb.IndexState_Finished, indexMeta.State)
+	})
+
+	t.Run("MarkIndexAsDeleted", func(t *testing.T) {
+		_, err := metaTable.MarkIndexAsDeleted(10)
+		assert.Nil(t, err)
+		assert.Empty(t, metaTable.skippedIndexes)
+		_, values, err := etcdKV.LoadWithPrefix(skippedSegmentIndexPrefix)
+		assert.Nil(t, err)
+		assert.Empty(t, values)
+	})
 }
@@ -15,6 +15,8 @@ import (
 	"context"
 	"sync"
 
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 
 	grpcindexnodeclient "github.com/milvus-io/milvus/internal/distributed/indexnode/client"
@@ -102,6 +104,62 @@ func (nm *NodeManager) PeekClient() (UniqueID, types.IndexNode) {
 	return nodeID, client
 }
 
+// PeekClientForIndexType peeks the client with the least load among the IndexNodes that can build the indexType
+// index, so that the IndexNodes of the old versions are not assigned the index types they do not support during the
+// rolling upgrade. Any IndexNode can be picked if indexType is empty. The IndexNodes whose capabilities can not be
+// fetched are skipped.
+func (nm *NodeManager) PeekClientForIndexType(ctx context.Context, indexType string) (UniqueID, types.IndexNode) {
+	if indexType == "" {
+		return nm.PeekClient()
+	}
+
+	nm.lock.RLock()
+	nodeIDs := nm.pq.PeekAllByPriority()
+	clients := make([]types.IndexNode, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		clients = append(clients, nm.nodeClients[nodeID])
+	}
+	nm.lock.RUnlock()
+
+	// the capabilities are cached by the clients, so they are fetched without holding the lock
+	for idx, client := range clients {
+		if client != nil && supportsIndexType(ctx, nodeIDs[idx], client, indexType) {
+			return nodeIDs[idx], client
+		}
+	}
+	log.Warn("IndexCoord NodeManager no IndexNode can build the index type", zap.String("indexType", indexType),
+		zap.Int64s("nodeIDs", nodeIDs))
+	return UniqueID(-1), nil
+}
+
+func supportsIndexType(ctx context.Context, nodeID UniqueID, client types.IndexNode, indexType string) bool {
+	resp, err := client.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+	if err != nil {
+		log.Warn("IndexCoord NodeManager get IndexNode capabilities failed", zap.Int64("nodeID", nodeID), zap.Error(err))
+		return false
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		log.Warn("IndexCoord NodeManager get IndexNode capabilities failed", zap.Int64("nodeID", nodeID),
+			zap.String("reason", resp.GetStatus().GetReason()))
+		return false
+	}
+	for _, t := range resp.GetIndexTypes() {
+		if t == indexType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientByID returns the client of the IndexNode with nodeID.
+func (nm *NodeManager) GetClientByID(nodeID UniqueID) (types.IndexNode, bool) {
+	nm.lock.RLock()
+	defer nm.lock.RUnlock()
+
+	client, ok := nm.nodeClients[nodeID]
+	return client, ok
+}
+
 type indexNodeGetMetricsResponse struct {
 	resp *milvuspb.GetMetricsResponse
 	err  error
@@ -12,13 +12,13 @@
 package indexcoord
 
 import (
-	"path"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 )
 
 // ParamTable is used to record configuration items.
@@ -39,8 +39,20 @@ type ParamTable struct {
 	MinIOUseSSL          bool
 	MinioBucketName      string
 
+	StorageType             string
+	LocalStoragePath        string
+	LocalStorageSyncOnWrite bool
+
+	TaskMaxRetry     int64
+	TaskBuildTimeout time.Duration
+
+	MinSegmentSizeToEnableIndex int64
+
 	CreatedTime time.Time
 	UpdatedTime time.Time
+
+	// --- Session ---
+	SessionReregisterGrace time.Duration
 }
 
 // Params is an alias for ParamTable.
@@ -64,8 +76,17 @@ func (pt *ParamTable) Init() {
 	pt.initMinIOSecretAccessKey()
 	pt.initMinIOUseSSL()
 	pt.initMinioBucketName()
+	pt.initStorageType()
+	pt.initLocalStoragePath()
+	pt.initLocalStorageSyncOnWrite()
 	pt.initIndexRootPath()
 	pt.initRoleName()
+	pt.initTaskMaxRetry()
+	pt.initTaskBuildTimeout()
+	pt.initMinSegmentSizeToEnableIndex()
+
+	// --- Session ---
+	pt.initSessionReregisterGrace()
 }
 
 // InitOnce is used to initialize configuration items, and it will only be called once.
@@ -157,15 +178,92 @@ func (pt *ParamTable) initMinioBucketName() {
 	pt.MinioBucketName = bucketName
 }
 
+// initStorageType initializes the type of the storage of binlogs and index files, minio or local.
+func (pt *ParamTable) initStorageType() {
+	ret, err := pt.LoadWithDefault("storage.type", "minio")
+	if err != nil {
+		panic(err)
+	}
+	pt.StorageType = ret
+}
+
+// initLocalStoragePath initializes the root path of the files when the storage type is local.
+func (pt *ParamTable) initLocalStoragePath() {
+	ret, err := pt.LoadWithDefault("storage.path", "/var/lib/milvus/storage")
+	if err != nil {
+		panic(err)
+	}
+	pt.LocalStoragePath = ret
+}
+
+// initLocalStorageSyncOnWrite initializes whether to fsync the files before the writes return when the storage type is local.
+func (pt *ParamTable) initLocalStorageSyncOnWrite() {
+	ret, err := pt.LoadWithDefault("storage.syncOnWrite", "false")
+	if err != nil {
+		panic(err)
+	}
+	pt.LocalStorageSyncOnWrite, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // initIndexRootPath initializes the root path of index files.
 func (pt *ParamTable) initIndexRootPath() {
 	rootPath, err := pt.Load("minio.rootPath")
 	if err != nil {
 		panic(err)
 	}
-	pt.IndexRootPath = path.Join(rootPath, "index_files")
+	pt.IndexRootPath = pathutil.IndexFileRootPath(rootPath)
 }
 
 func (pt *ParamTable) initRoleName() {
 	pt.RoleName = "indexcoord"
 }
+
+// initTaskMaxRetry initializes the max times an index task is reassigned after its IndexNode went offline or timed out.
+func (pt *ParamTable) initTaskMaxRetry() {
+	ret, err := pt.LoadWithDefault("indexCoord.task.maxRetry", "3")
+	if err != nil {
+		panic(err)
+	}
+	pt.TaskMaxRetry, err = strconv.ParseInt(ret, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initTaskBuildTimeout initializes the timeout of building an index task, the task is reassigned when it's
+// not finished in time, and 0 means no timeout.
+func (pt *ParamTable) initTaskBuildTimeout() {
+	ret, err := pt.LoadWithDefault("indexCoord.task.buildTimeout", "3600")
+	if err != nil {
+		panic(err)
+	}
+	timeout, err := strconv.ParseInt(ret, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	pt.TaskBuildTimeout = time.Duration(timeout) * time.Second
+}
+
+// initMinSegmentSizeToEnableIndex initializes the default threshold of segment rows to build index, the builds of the
+// smaller segments are skipped until compaction merges them. The key is kept under rootcoord, which used to skip them.
+func (pt *ParamTable) initMinSegmentSizeToEnableIndex() {
+	if err := pt.LoadYaml("advanced/root_coord.yaml"); err != nil {
+		panic(err)
+	}
+	pt.MinSegmentSizeToEnableIndex = pt.ParseInt64("rootcoord.minSegmentSizeToEnableIndex")
+}
+
+func (pt *ParamTable) initSessionReregisterGrace() {
+	grace, err := pt.LoadWithDefault("common.session.reregisterGrace", "30")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(grace, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	pt.SessionReregisterGrace = time.Duration(seconds) * time.Second
+}
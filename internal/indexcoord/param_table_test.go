@@ -14,6 +14,8 @@ package indexcoord
 import (
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParamTable(t *testing.T) {
@@ -59,6 +61,12 @@ func TestParamTable(t *testing.T) {
 		t.Logf("MinioBucketName: %v", Params.MinioBucketName)
 	})
 
+	t.Run("StorageType", func(t *testing.T) {
+		assert.Equal(t, "minio", Params.StorageType)
+		t.Logf("LocalStoragePath: %v", Params.LocalStoragePath)
+		assert.False(t, Params.LocalStorageSyncOnWrite)
+	})
+
 	t.Run("CreatedTime", func(t *testing.T) {
 		Params.CreatedTime = time.Now()
 		t.Logf("CreatedTime: %v", Params.CreatedTime)
@@ -72,6 +80,22 @@ func TestParamTable(t *testing.T) {
 	t.Run("initIndexRootPath", func(t *testing.T) {
 		t.Logf("IndexRootPath: %v", Params.IndexRootPath)
 	})
+
+	t.Run("MinSegmentSizeToEnableIndex", func(t *testing.T) {
+		assert.Equal(t, int64(1024), Params.MinSegmentSizeToEnableIndex)
+	})
+
+	t.Run("TaskMaxRetry", func(t *testing.T) {
+		assert.Equal(t, int64(3), Params.TaskMaxRetry)
+	})
+
+	t.Run("TaskBuildTimeout", func(t *testing.T) {
+		assert.Equal(t, time.Hour, Params.TaskBuildTimeout)
+	})
+
+	t.Run("SessionReregisterGrace", func(t *testing.T) {
+		assert.Equal(t, 30*time.Second, Params.SessionReregisterGrace)
+	})
 }
 
 //TODO: Params Load should be return error when key does not exist.
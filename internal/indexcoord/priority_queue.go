@@ -13,6 +13,7 @@ package indexcoord
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
 )
 
@@ -149,3 +150,21 @@ func (pq *PriorityQueue) PeekAll() []UniqueID {
 
 	return ret
 }
+
+// PeekAllByPriority returns the keys of all the items, from the lowest load to the highest.
+func (pq *PriorityQueue) PeekAllByPriority() []UniqueID {
+	pq.lock.RLock()
+	items := make([]*PQItem, len(pq.items))
+	copy(items, pq.items)
+	pq.lock.RUnlock()
+
+	// the item with the lowest load is always the first one in the heap, stable sort keeps it the first of the ties
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].priority < items[j].priority
+	})
+	ret := make([]UniqueID, 0, len(items))
+	for _, item := range items {
+		ret = append(ret, item.key)
+	}
+	return ret
+}
@@ -82,3 +82,16 @@ func TestPriorityQueue_IncPriority(t *testing.T) {
 	peekKey := pq.Peek()
 	assert.Equal(t, key, peekKey)
 }
+
+func TestPriorityQueue_PeekAllByPriority(t *testing.T) {
+	pq := newPriorityQueue()
+	pq.IncPriority(UniqueID(0), QueueLen)
+	pq.UpdatePriority(UniqueID(QueueLen-1), -1)
+	keys := pq.PeekAllByPriority()
+	assert.Equal(t, QueueLen, len(keys))
+	assert.Equal(t, UniqueID(QueueLen-1), keys[0])
+	for i := 1; i < QueueLen-1; i++ {
+		assert.Equal(t, UniqueID(i), keys[i])
+	}
+	assert.Equal(t, UniqueID(0), keys[QueueLen-1])
+}
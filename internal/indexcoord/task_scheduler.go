@@ -22,6 +22,7 @@ import (
 	"github.com/milvus-io/milvus/internal/allocator"
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/opentracing/opentracing-go"
 	oplog "github.com/opentracing/opentracing-go/log"
@@ -39,6 +40,7 @@ type TaskQueue interface {
 	PopActiveTask(tID UniqueID) task
 	Enqueue(t task) error
 	tryToRemoveUselessIndexAddTask(indexID UniqueID) []UniqueID
+	tryToRemoveSegmentIndexAddTask(segmentID UniqueID) []UniqueID
 }
 
 // BaseTaskQueue is a basic instance of TaskQueue.
@@ -162,6 +164,19 @@ func (queue *IndexAddTaskQueue) Enqueue(t task) error {
 
 // Note: tryToRemoveUselessIndexAddTask must be called by DropIndex
 func (queue *IndexAddTaskQueue) tryToRemoveUselessIndexAddTask(indexID UniqueID) []UniqueID {
+	return queue.removeIndexAddTasks(func(req *indexpb.BuildIndexRequest) bool {
+		return req.IndexID == indexID
+	})
+}
+
+// tryToRemoveSegmentIndexAddTask removes the unissued builds on a dropped segment, and returns their IndexBuildIDs.
+func (queue *IndexAddTaskQueue) tryToRemoveSegmentIndexAddTask(segmentID UniqueID) []UniqueID {
+	return queue.removeIndexAddTasks(func(req *indexpb.BuildIndexRequest) bool {
+		return req.SegmentID == segmentID
+	})
+}
+
+func (queue *IndexAddTaskQueue) removeIndexAddTasks(match func(req *indexpb.BuildIndexRequest) bool) []UniqueID {
 	queue.lock.Lock()
 	defer queue.lock.Unlock()
 
@@ -173,7 +188,7 @@ func (queue *IndexAddTaskQueue) tryToRemoveUselessIndexAddTask(indexID UniqueID)
 		if !ok {
 			continue
 		}
-		if indexAddTask.req.IndexID == indexID {
+		if match(indexAddTask.req) {
 			queue.unissuedTasks.Remove(e)
 			indexAddTask.Notify(nil)
 			indexBuildIDs = append(indexBuildIDs, indexAddTask.req.IndexBuildID)
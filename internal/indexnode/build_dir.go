@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexnode
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/shirou/gopsutil/disk"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+// buildDirManager manages the local directories of the index building tasks, the directory of a task is
+// root/<IndexBuildID>. It's created before the task starts building and removed when the task ends, the
+// directories left by a crash are swept when the index node starts.
+type buildDirManager struct {
+	root string
+	// freeSpace returns the bytes available on the disk of path
+	freeSpace func(path string) (uint64, error)
+
+	mu              sync.Mutex
+	active          map[UniqueID]string
+	cleanedDirs     int64
+	sweptDirs       int64
+	cleanupFailures int64
+}
+
+func diskFreeSpace(path string) (uint64, error) {
+	usage, err := disk.Usage(path)
+	if err != nil {
+		return 0, err
+	}
+	return usage.Free, nil
+}
+
+// newBuildDirManager creates the root directory if it doesn't exist
+func newBuildDirManager(root string) (*buildDirManager, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	return &buildDirManager{
+		root:      root,
+		freeSpace: diskFreeSpace,
+		active:    make(map[UniqueID]string),
+	}, nil
+}
+
+func (m *buildDirManager) dirOf(buildID UniqueID) string {
+	return filepath.Join(m.root, strconv.FormatInt(buildID, 10))
+}
+
+// create checks the disk has the required bytes available, then creates an empty directory for the task,
+// the files left by a previous attempt of the task are removed.
+func (m *buildDirManager) create(buildID UniqueID, required uint64) (string, error) {
+	free, err := m.freeSpace(m.root)
+	if err != nil {
+		return "", fmt.Errorf("failed to check the disk space of %s: %w", m.root, err)
+	}
+	if free < required {
+		return "", fmt.Errorf("no enough disk space to build index %d, required %d bytes, available %d bytes",
+			buildID, required, free)
+	}
+
+	dir := m.dirOf(buildID)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	m.active[buildID] = dir
+	m.mu.Unlock()
+	return dir, nil
+}
+
+// remove removes the directory of the task, it's called when the task succeeded, failed or was canceled
+func (m *buildDirManager) remove(buildID UniqueID) {
+	m.mu.Lock()
+	dir, ok := m.active[buildID]
+	delete(m.active, buildID)
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	err := os.RemoveAll(dir)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.cleanupFailures++
+		log.Warn("IndexNode failed to remove the build directory", zap.Int64("buildID", buildID),
+			zap.String("dir", dir), zap.Error(err))
+		return
+	}
+	m.cleanedDirs++
+}
+
+// sweep removes the entries under root which don't belong to a task in progress, it returns the number removed
+func (m *buildDirManager) sweep(inProgress func(buildID UniqueID) bool) (int, error) {
+	entries, err := ioutil.ReadDir(m.root)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, entry := range entries {
+		buildID, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err == nil && entry.IsDir() {
+			m.mu.Lock()
+			_, active := m.active[buildID]
+			m.mu.Unlock()
+			if active || inProgress(buildID) {
+				continue
+			}
+		}
+		path := filepath.Join(m.root, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			m.mu.Lock()
+			m.cleanupFailures++
+			m.mu.Unlock()
+			log.Warn("IndexNode failed to sweep the stale build directory", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		log.Info("IndexNode swept the stale build directory", zap.String("path", path))
+		removed++
+	}
+	m.mu.Lock()
+	m.sweptDirs += int64(removed)
+	m.mu.Unlock()
+	return removed, nil
+}
+
+// usedBytes returns the total size of the files under root
+func (m *buildDirManager) usedBytes() uint64 {
+	var used uint64
+	_ = filepath.Walk(m.root, func(path string, info os.FileInfo, err error) error {
+		// the directories of the tasks may be removed during the walk
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			used += uint64(info.Size())
+		}
+		return nil
+	})
+	return used
+}
+
+// getInfos returns the disk usage and the cleanup counts for the metrics
+func (m *buildDirManager) getInfos() *metricsinfo.IndexBuildDirInfos {
+	infos := &metricsinfo.IndexBuildDirInfos{
+		Root:      m.root,
+		UsedBytes: m.usedBytes(),
+	}
+	if free, err := m.freeSpace(m.root); err == nil {
+		infos.AvailableBytes = free
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	infos.ActiveDirs = len(m.active)
+	infos.CleanedDirs = m.cleanedDirs
+	infos.SweptDirs = m.sweptDirs
+	infos.CleanupFailures = m.cleanupFailures
+	return infos
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexnode
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDirManager_CreateRemove(t *testing.T) {
+	root, err := ioutil.TempDir("", "index_build")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	m, err := newBuildDirManager(filepath.Join(root, "build"))
+	require.NoError(t, err)
+	m.freeSpace = func(path string) (uint64, error) {
+		return 1024, nil
+	}
+
+	dir, err := m.create(1, 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "build", "1"), dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "intermediate"), make([]byte, 100), 0644))
+
+	infos := m.getInfos()
+	assert.Equal(t, uint64(100), infos.UsedBytes)
+	assert.Equal(t, uint64(1024), infos.AvailableBytes)
+	assert.Equal(t, 1, infos.ActiveDirs)
+
+	// not enough disk space
+	_, err = m.create(2, 1025)
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(root, "build", "2"))
+	assert.True(t, os.IsNotExist(err))
+
+	m.remove(1)
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	// removing twice is harmless
+	m.remove(1)
+
+	infos = m.getInfos()
+	assert.Equal(t, uint64(0), infos.UsedBytes)
+	assert.Equal(t, 0, infos.ActiveDirs)
+	assert.Equal(t, int64(1), infos.CleanedDirs)
+	assert.Equal(t, int64(0), infos.CleanupFailures)
+
+	m.freeSpace = func(path string) (uint64, error) {
+		return 0, errors.New("mocked")
+	}
+	_, err = m.create(3, 0)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(0), m.getInfos().AvailableBytes)
+}
+
+func TestBuildDirManager_Sweep(t *testing.T) {
+	root, err := ioutil.TempDir("", "index_build")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	// the directories left by the tasks of a crashed index node
+	for _, name := range []string{"100", "101", "102"} {
+		dir := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "intermediate"), []byte("data"), 0644))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "garbage"), []byte("data"), 0644))
+
+	m, err := newBuildDirManager(root)
+	require.NoError(t, err)
+	m.freeSpace = func(path string) (uint64, error) {
+		return 1024, nil
+	}
+	_, err = m.create(103, 0)
+	require.NoError(t, err)
+
+	removed, err := m.sweep(func(buildID UniqueID) bool {
+		return buildID == 101
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, removed)
+
+	entries, err := ioutil.ReadDir(root)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"101", "103"}, names)
+
+	infos := m.getInfos()
+	assert.Equal(t, int64(3), infos.SweptDirs)
+	assert.Equal(t, 1, infos.ActiveDirs)
+	assert.Equal(t, uint64(4), infos.UsedBytes)
+}
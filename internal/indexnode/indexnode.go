@@ -27,6 +27,7 @@ import (
 	"errors"
 	"io"
 	"math/rand"
+	"os"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -34,6 +35,7 @@ import (
 	"unsafe"
 
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 
 	"go.uber.org/zap"
@@ -46,6 +48,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/trace"
@@ -78,6 +82,7 @@ type IndexNode struct {
 
 	etcdKV        *etcdkv.EtcdKV
 	finishedTasks map[UniqueID]commonpb.IndexState
+	buildDirs     *buildDirManager
 
 	closer io.Closer
 
@@ -131,6 +136,10 @@ func (i *IndexNode) Init() error {
 	var initErr error = nil
 	i.initOnce.Do(func() {
 		Params.Init()
+		storage.SetChecksumWarnOnly(Params.StorageChecksumWarnOnly)
+		i.sched.IndexBuildQueue.setMaxTaskNum(Params.MaxTaskNum)
+		i.sched.setParallelism(Params.BuildParallel)
+		i.sched.setResourceLimits(Params.BuildMemoryLimit, Params.BuildDiskLimit)
 		i.UpdateStateCode(internalpb.StateCode_Initializing)
 		log.Debug("IndexNode init", zap.Any("State", internalpb.StateCode_Initializing))
 		connectEtcdFn := func() error {
@@ -154,16 +163,24 @@ func (i *IndexNode) Init() error {
 			BucketName:        Params.MinioBucketName,
 			CreateBucket:      true,
 		}
-		kv, err := miniokv.NewMinIOKV(i.loopCtx, option)
+		factory := storage.NewChunkManagerFactory(Params.StorageType, Params.LocalStoragePath, Params.LocalStorageSyncOnWrite, option)
+		kv, err := factory.NewDataKV(i.loopCtx)
 		if err != nil {
-			log.Error("IndexNode NewMinIOKV failed", zap.Error(err))
+			log.Error("IndexNode new storage kv failed", zap.String("storageType", Params.StorageType), zap.Error(err))
 			initErr = err
 			return
 		}
 
 		i.kv = kv
 
-		log.Debug("IndexNode NewMinIOKV succeeded")
+		log.Debug("IndexNode new storage kv succeeded", zap.String("storageType", Params.StorageType))
+
+		i.buildDirs, err = newBuildDirManager(Params.BuildDir)
+		if err != nil {
+			log.Error("IndexNode failed to create the build directory", zap.String("dir", Params.BuildDir), zap.Error(err))
+			initErr = err
+			return
+		}
 		i.closer = trace.InitTracing("index_node")
 
 		i.initKnowhere()
@@ -178,6 +195,7 @@ func (i *IndexNode) Init() error {
 func (i *IndexNode) Start() error {
 	var startErr error = nil
 	i.once.Do(func() {
+		i.sweepBuildDirs()
 		startErr = i.sched.Start()
 
 		Params.CreatedTime = time.Now()
@@ -200,6 +218,21 @@ func (i *IndexNode) Start() error {
 	return startErr
 }
 
+// sweepBuildDirs removes the build directories of the tasks which are not in progress, they are left by a crash
+func (i *IndexNode) sweepBuildDirs() {
+	if i.buildDirs == nil {
+		return
+	}
+	removed, err := i.buildDirs.sweep(func(buildID UniqueID) bool {
+		return i.sched.IndexBuildQueue.TaskStates([]UniqueID{buildID})[0].GetState() != commonpb.IndexState_IndexStateNone
+	})
+	if err != nil {
+		log.Warn("IndexNode failed to sweep the build directories", zap.String("dir", Params.BuildDir), zap.Error(err))
+		return
+	}
+	log.Info("IndexNode swept the stale build directories", zap.String("dir", Params.BuildDir), zap.Int("removed", removed))
+}
+
 // Stop closes the server.
 func (i *IndexNode) Stop() error {
 	i.loopCancel()
@@ -238,6 +271,7 @@ func (i *IndexNode) CreateIndex(ctx context.Context, request *indexpb.CreateInde
 		zap.Int64("IndexID", request.IndexID),
 		zap.Int64("Version", request.Version),
 		zap.String("MetaPath", request.MetaPath),
+		zap.Int64("NumRows", request.NumRows),
 		zap.Strings("DataPaths", request.DataPaths),
 		zap.Any("TypeParams", request.TypeParams),
 		zap.Any("IndexParams", request.IndexParams))
@@ -245,16 +279,19 @@ func (i *IndexNode) CreateIndex(ctx context.Context, request *indexpb.CreateInde
 	sp, ctx2 := trace.StartSpanFromContextWithOperationName(i.loopCtx, "IndexNode-CreateIndex")
 	defer sp.Finish()
 	sp.SetTag("IndexBuildID", strconv.FormatInt(request.IndexBuildID, 10))
+	ctx3, cancel := context.WithCancel(ctx2)
 
 	t := &IndexBuildTask{
 		BaseTask: BaseTask{
-			ctx:  ctx2,
-			done: make(chan error),
+			ctx:    ctx3,
+			cancel: cancel,
+			done:   make(chan error),
 		},
-		req:    request,
-		kv:     i.kv,
-		etcdKV: i.etcdKV,
-		nodeID: Params.NodeID,
+		req:       request,
+		kv:        i.kv,
+		etcdKV:    i.etcdKV,
+		buildDirs: i.buildDirs,
+		nodeID:    Params.NodeID,
 	}
 
 	ret := &commonpb.Status{
@@ -263,6 +300,7 @@ func (i *IndexNode) CreateIndex(ctx context.Context, request *indexpb.CreateInde
 
 	err := i.sched.IndexBuildQueue.Enqueue(t)
 	if err != nil {
+		cancel()
 		log.Warn("IndexNode failed to schedule", zap.Int64("indexBuildID", request.IndexBuildID), zap.Error(err))
 		ret.ErrorCode = commonpb.ErrorCode_UnexpectedError
 		ret.Reason = err.Error()
@@ -273,6 +311,81 @@ func (i *IndexNode) CreateIndex(ctx context.Context, request *indexpb.CreateInde
 	return ret, nil
 }
 
+// CancelIndexBuild removes the index building tasks from the queue, and cancels the tasks in progress.
+func (i *IndexNode) CancelIndexBuild(ctx context.Context, request *indexpb.CancelIndexBuildRequest) (*commonpb.Status, error) {
+	if i.stateCode.Load().(internalpb.StateCode) != internalpb.StateCode_Healthy {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    "state code is not healthy",
+		}, nil
+	}
+	canceled := i.sched.IndexBuildQueue.CancelTasks(request.GetIndexBuildIDs())
+	log.Info("IndexNode cancel index building tasks",
+		zap.Int64s("IndexBuildIDs", request.GetIndexBuildIDs()),
+		zap.Int64s("canceled", canceled))
+
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// GetTaskSlots returns the number of index building tasks IndexNode can start right now, and the states of the
+// requested tasks.
+func (i *IndexNode) GetTaskSlots(ctx context.Context, request *indexpb.GetTaskSlotsRequest) (*indexpb.GetTaskSlotsResponse, error) {
+	if i.stateCode.Load().(internalpb.StateCode) != internalpb.StateCode_Healthy {
+		return &indexpb.GetTaskSlotsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    "state code is not healthy",
+			},
+		}, nil
+	}
+
+	return &indexpb.GetTaskSlotsResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		Slots:      i.sched.GetTaskSlots(),
+		TaskStates: i.sched.IndexBuildQueue.TaskStates(request.GetIndexBuildIDs()),
+	}, nil
+}
+
+// supportedIndexTypes are the index types IndexNode can build.
+var supportedIndexTypes = []string{
+	indexparamcheck.IndexFaissIDMap,
+	indexparamcheck.IndexFaissIvfFlat,
+	indexparamcheck.IndexFaissIvfPQ,
+	indexparamcheck.IndexFaissIvfSQ8,
+	indexparamcheck.IndexFaissIvfSQ8H,
+	indexparamcheck.IndexFaissBinIDMap,
+	indexparamcheck.IndexFaissBinIvfFlat,
+	indexparamcheck.IndexNSG,
+	indexparamcheck.IndexHNSW,
+	indexparamcheck.IndexRHNSWFlat,
+	indexparamcheck.IndexRHNSWPQ,
+	indexparamcheck.IndexRHNSWSQ,
+	indexparamcheck.IndexANNOY,
+	indexparamcheck.IndexNGTPANNG,
+	indexparamcheck.IndexNGTONNG,
+}
+
+// supportedFeatures is the bitmap of the optional features IndexNode supports.
+var supportedFeatures = uint64(indexpb.IndexNodeFeature_FeatureCancelIndexBuild | indexpb.IndexNodeFeature_FeatureTaskSlots)
+
+// GetCapabilities returns the build version of IndexNode, the index types it can build and the optional features it
+// supports. IndexCoord uses them to avoid assigning the tasks to the IndexNodes that can not run them during the
+// rolling upgrade.
+func (i *IndexNode) GetCapabilities(ctx context.Context, request *indexpb.GetCapabilitiesRequest) (*indexpb.GetCapabilitiesResponse, error) {
+	return &indexpb.GetCapabilitiesResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		BuildVersion: os.Getenv(metricsinfo.GitCommitEnvKey),
+		IndexTypes:   supportedIndexTypes,
+		Features:     supportedFeatures,
+	}, nil
+}
+
 // GetComponentStates gets the component states of IndexNode.
 func (i *IndexNode) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
 	log.Debug("get IndexNode components states ...")
@@ -297,6 +410,11 @@ func (i *IndexNode) GetComponentStates(ctx context.Context) (*internalpb.Compone
 	return ret, nil
 }
 
+// CheckReady checks whether IndexNode is healthy and its session is registered in etcd
+func (i *IndexNode) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, i, i.session)
+}
+
 // GetTimeTickChannel gets the time tick channel of IndexNode.
 func (i *IndexNode) GetTimeTickChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
 	log.Debug("get IndexNode time tick channel ...")
@@ -369,6 +487,19 @@ func (i *IndexNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequ
 		return metrics, err
 	}
 
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		name := metricsinfo.ConstructComponentName(typeutil.IndexNodeRole, Params.NodeID)
+		metrics := metricsinfo.LogLevelsResponse([]metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}, name)
+
+		log.Debug("IndexNode.GetMetrics",
+			zap.Int64("node_id", Params.NodeID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Warn("IndexNode.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", Params.NodeID),
 		zap.String("req", req.Request),
@@ -258,6 +258,67 @@ func (inm *Mock) CreateIndex(ctx context.Context, req *indexpb.CreateIndexReques
 	}, nil
 }
 
+// CancelIndexBuild receives a canceling index build request and returns success. If the internal member `Err` is true,
+// it will return an error.
+func (inm *Mock) CancelIndexBuild(ctx context.Context, req *indexpb.CancelIndexBuildRequest) (*commonpb.Status, error) {
+	if inm.Err {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		}, errors.New("IndexNode CancelIndexBuild failed")
+	}
+
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+// GetTaskSlots returns one slot and the tasks as unknown. If the internal member `Err` is true, it will return an error.
+func (inm *Mock) GetTaskSlots(ctx context.Context, req *indexpb.GetTaskSlotsRequest) (*indexpb.GetTaskSlotsResponse, error) {
+	if inm.Err {
+		return &indexpb.GetTaskSlotsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			},
+		}, errors.New("IndexNode GetTaskSlots failed")
+	}
+
+	states := make([]*indexpb.IndexTaskState, 0, len(req.GetIndexBuildIDs()))
+	for _, indexBuildID := range req.GetIndexBuildIDs() {
+		states = append(states, &indexpb.IndexTaskState{
+			IndexBuildID:  indexBuildID,
+			State:         commonpb.IndexState_IndexStateNone,
+			QueuePosition: -1,
+		})
+	}
+	return &indexpb.GetTaskSlotsResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		Slots:      1,
+		TaskStates: states,
+	}, nil
+}
+
+// GetCapabilities returns the capabilities of the real IndexNode. If the internal member `Err` is true, it will return
+// an error.
+func (inm *Mock) GetCapabilities(ctx context.Context, req *indexpb.GetCapabilitiesRequest) (*indexpb.GetCapabilitiesResponse, error) {
+	if inm.Err {
+		return &indexpb.GetCapabilitiesResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			},
+		}, errors.New("IndexNode GetCapabilities failed")
+	}
+
+	return &indexpb.GetCapabilitiesResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		IndexTypes: supportedIndexTypes,
+		Features:   supportedFeatures,
+	}, nil
+}
+
 // GetMetrics gets the metrics of mocked IndexNode, if the internal member `Failure` is true, it will return an error.
 func (inm *Mock) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	if inm.Err {
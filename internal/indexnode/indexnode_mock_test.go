@@ -67,6 +67,27 @@ func TestIndexNodeMock(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
 	})
 
+	t.Run("CancelIndexBuild", func(t *testing.T) {
+		resp, err := inm.CancelIndexBuild(ctx, &indexpb.CancelIndexBuildRequest{IndexBuildIDs: []int64{0}})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
+	t.Run("GetTaskSlots", func(t *testing.T) {
+		resp, err := inm.GetTaskSlots(ctx, &indexpb.GetTaskSlotsRequest{IndexBuildIDs: []int64{0}})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, int64(1), resp.Slots)
+		assert.Equal(t, commonpb.IndexState_IndexStateNone, resp.TaskStates[0].State)
+	})
+
+	t.Run("GetCapabilities", func(t *testing.T) {
+		resp, err := inm.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Contains(t, resp.IndexTypes, "IVF_FLAT")
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{
 			Request: "",
@@ -121,6 +142,24 @@ func TestIndexNodeMockError(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.ErrorCode)
 	})
 
+	t.Run("CancelIndexBuild error", func(t *testing.T) {
+		resp, err := inm.CancelIndexBuild(ctx, &indexpb.CancelIndexBuildRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.ErrorCode)
+	})
+
+	t.Run("GetTaskSlots error", func(t *testing.T) {
+		resp, err := inm.GetTaskSlots(ctx, &indexpb.GetTaskSlotsRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("GetCapabilities error", func(t *testing.T) {
+		resp, err := inm.GetCapabilities(ctx, &indexpb.GetCapabilitiesRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
 	t.Run("GetMetrics error", func(t *testing.T) {
 		req := &milvuspb.GetMetricsRequest{}
 		resp, err := inm.GetMetrics(ctx, req)
@@ -750,6 +750,12 @@ func TestIndexNode_Error(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
 	})
 
+	t.Run("CancelIndexBuild", func(t *testing.T) {
+		status, err := in.CancelIndexBuild(ctx, &indexpb.CancelIndexBuildRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+	})
+
 	t.Run("GetMetrics", func(t *testing.T) {
 		resp, err := in.GetMetrics(ctx, &milvuspb.GetMetricsRequest{})
 		assert.Nil(t, err)
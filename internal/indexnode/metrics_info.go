@@ -13,9 +13,7 @@ package indexnode
 
 import (
 	"context"
-	"os"
 
-	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 
@@ -29,51 +27,15 @@ func getSystemInfoMetrics(
 	node *IndexNode,
 ) (*milvuspb.GetMetricsResponse, error) {
 	// TODO(dragondriver): add more metrics
-	nodeInfos := metricsinfo.IndexNodeInfos{
-		BaseComponentInfos: metricsinfo.BaseComponentInfos{
-			Name: metricsinfo.ConstructComponentName(typeutil.IndexNodeRole, Params.NodeID),
-			HardwareInfos: metricsinfo.HardwareMetrics{
-				IP:           node.session.Address,
-				CPUCoreCount: metricsinfo.GetCPUCoreCount(false),
-				CPUCoreUsage: metricsinfo.GetCPUUsage(),
-				Memory:       metricsinfo.GetMemoryCount(),
-				MemoryUsage:  metricsinfo.GetUsedMemoryCount(),
-				Disk:         metricsinfo.GetDiskCount(),
-				DiskUsage:    metricsinfo.GetDiskUsage(),
-			},
-			SystemInfo: metricsinfo.DeployMetrics{
-				SystemVersion: os.Getenv(metricsinfo.GitCommitEnvKey),
-				DeployMode:    os.Getenv(metricsinfo.DeployModeEnvKey),
-			},
-			CreatedTime: Params.CreatedTime.String(),
-			UpdatedTime: Params.UpdatedTime.String(),
-			Type:        typeutil.IndexNodeRole,
-			ID:          node.session.ServerID,
-		},
-		SystemConfigurations: metricsinfo.IndexNodeConfiguration{
+	self := metricsinfo.NewComponentInfo(typeutil.IndexNodeRole, node.session.ServerID, node.session.Address,
+		Params.CreatedTime, Params.UpdatedTime,
+		metricsinfo.IndexNodeConfiguration{
 			MinioBucketName: Params.MinioBucketName,
 
 			SimdType: Params.SimdType,
-		},
+		})
+	if node.buildDirs != nil {
+		self.BuildDirs = node.buildDirs.getInfos()
 	}
-	resp, err := metricsinfo.MarshalComponentInfos(nodeInfos)
-	if err != nil {
-		return &milvuspb.GetMetricsResponse{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
-			},
-			Response:      "",
-			ComponentName: metricsinfo.ConstructComponentName(typeutil.IndexNodeRole, Params.NodeID),
-		}, nil
-	}
-
-	return &milvuspb.GetMetricsResponse{
-		Status: &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_Success,
-			Reason:    "",
-		},
-		Response:      resp,
-		ComponentName: metricsinfo.ConstructComponentName(typeutil.IndexNodeRole, Params.NodeID),
-	}, nil
+	return metricsinfo.ComponentTopologyResponse(metricsinfo.NewComponentTopology(self)), nil
 }
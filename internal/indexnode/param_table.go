@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 )
 
@@ -31,13 +32,16 @@ type ParamTable struct {
 	IP      string
 	Address string
 	Port    int
+	// MetricsPort is the port serving the metrics of the current IndexNode, 0 means not serving
+	MetricsPort int
 
 	NodeID int64
 	Alias  string
 
 	EtcdEndpoints []string
 	MetaRootPath  string
-	IndexRootPath string
+	// MinioRootPath is the root path of the index files in storage, see pathutil for the layout under it
+	MinioRootPath string
 
 	MinIOAddress         string
 	MinIOAccessKeyID     string
@@ -45,8 +49,21 @@ type ParamTable struct {
 	MinIOUseSSL          bool
 	MinioBucketName      string
 
+	StorageType             string
+	LocalStoragePath        string
+	LocalStorageSyncOnWrite bool
+	StorageChecksumWarnOnly bool
+
 	SimdType string
 
+	MaxTaskNum    int64
+	BuildParallel int
+	// the memory and the disk in bytes the running index building tasks are estimated to take, 0 means no limit
+	BuildMemoryLimit uint64
+	BuildDiskLimit   uint64
+	// BuildDir is the root of the local directories of the index building tasks
+	BuildDir string
+
 	CreatedTime time.Time
 	UpdatedTime time.Time
 }
@@ -90,10 +107,20 @@ func (pt *ParamTable) initParams() {
 	pt.initMinIOSecretAccessKey()
 	pt.initMinIOUseSSL()
 	pt.initMinioBucketName()
+	pt.initStorageType()
+	pt.initLocalStoragePath()
+	pt.initLocalStorageSyncOnWrite()
+	pt.initStorageChecksumWarnOnly()
 	pt.initEtcdEndpoints()
 	pt.initMetaRootPath()
-	pt.initIndexRootPath()
+	pt.initMinioRootPath()
 	pt.initRoleName()
+	pt.initMetricsPort()
+	pt.initMaxTaskNum()
+	pt.initBuildParallel()
+	pt.initBuildMemoryLimit()
+	pt.initBuildDiskLimit()
+	pt.initBuildDir()
 }
 
 func (pt *ParamTable) initMinIOAddress() {
@@ -151,12 +178,12 @@ func (pt *ParamTable) initMetaRootPath() {
 	pt.MetaRootPath = path.Join(rootPath, subPath)
 }
 
-func (pt *ParamTable) initIndexRootPath() {
+func (pt *ParamTable) initMinioRootPath() {
 	rootPath, err := pt.Load("minio.rootPath")
 	if err != nil {
 		panic(err)
 	}
-	pt.IndexRootPath = path.Join(rootPath, "index_files")
+	pt.MinioRootPath = rootPath
 }
 
 func (pt *ParamTable) initMinioBucketName() {
@@ -167,6 +194,48 @@ func (pt *ParamTable) initMinioBucketName() {
 	pt.MinioBucketName = bucketName
 }
 
+// initStorageType initializes the type of the storage of binlogs and index files, minio or local.
+func (pt *ParamTable) initStorageType() {
+	ret, err := pt.LoadWithDefault("storage.type", "minio")
+	if err != nil {
+		panic(err)
+	}
+	pt.StorageType = ret
+}
+
+// initLocalStoragePath initializes the root path of the files when the storage type is local.
+func (pt *ParamTable) initLocalStoragePath() {
+	ret, err := pt.LoadWithDefault("storage.path", "/var/lib/milvus/storage")
+	if err != nil {
+		panic(err)
+	}
+	pt.LocalStoragePath = ret
+}
+
+// initLocalStorageSyncOnWrite initializes whether to fsync the files before the writes return when the storage type is local.
+func (pt *ParamTable) initLocalStorageSyncOnWrite() {
+	ret, err := pt.LoadWithDefault("storage.syncOnWrite", "false")
+	if err != nil {
+		panic(err)
+	}
+	pt.LocalStorageSyncOnWrite, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initStorageChecksumWarnOnly initializes whether the checksum mismatches of the files read are warnings only.
+func (pt *ParamTable) initStorageChecksumWarnOnly() {
+	ret, err := pt.LoadWithDefault("storage.checksumWarnOnly", "false")
+	if err != nil {
+		panic(err)
+	}
+	pt.StorageChecksumWarnOnly, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (pt *ParamTable) initRoleName() {
 	pt.RoleName = "indexnode"
 }
@@ -185,3 +254,80 @@ func (pt *ParamTable) initKnowhereSimdType() {
 	log.Debug("initialize the knowhere simd type",
 		zap.String("simd_type", pt.SimdType))
 }
+
+// initMaxTaskNum initializes the max number of index building tasks waiting in the queue.
+func (pt *ParamTable) initMaxTaskNum() {
+	ret, err := pt.LoadWithDefault("indexNode.scheduler.maxTaskNum", "1024")
+	if err != nil {
+		panic(err)
+	}
+	pt.MaxTaskNum, err = strconv.ParseInt(ret, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initBuildParallel initializes the max number of index building tasks running at the same time.
+func (pt *ParamTable) initBuildParallel() {
+	ret, err := pt.LoadWithDefault("indexNode.scheduler.buildParallel", "1")
+	if err != nil {
+		panic(err)
+	}
+	pt.BuildParallel, err = strconv.Atoi(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initBuildMemoryLimit initializes the memory the running index building tasks are estimated to take,
+// it's the memory of the machine if not configured.
+func (pt *ParamTable) initBuildMemoryLimit() {
+	ret, err := pt.LoadWithDefault("indexNode.scheduler.memoryLimit", "0")
+	if err != nil {
+		panic(err)
+	}
+	limit, err := strconv.ParseUint(ret, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	pt.BuildMemoryLimit = limit * 1024 * 1024
+	if pt.BuildMemoryLimit == 0 {
+		pt.BuildMemoryLimit = metricsinfo.GetMemoryCount()
+	}
+}
+
+// initBuildDiskLimit initializes the local disk the running index building tasks are estimated to take,
+// 0 means no limit.
+func (pt *ParamTable) initBuildDiskLimit() {
+	ret, err := pt.LoadWithDefault("indexNode.scheduler.diskLimit", "0")
+	if err != nil {
+		panic(err)
+	}
+	limit, err := strconv.ParseUint(ret, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	pt.BuildDiskLimit = limit * 1024 * 1024
+}
+
+// initBuildDir initializes the root of the local directories of the index building tasks.
+func (pt *ParamTable) initBuildDir() {
+	ret, err := pt.LoadWithDefault("indexNode.buildDir", "/var/lib/milvus/index_build")
+	if err != nil {
+		panic(err)
+	}
+	pt.BuildDir = ret
+}
+
+// initMetricsPort initializes the port serving the metrics of the node, 0 means the metrics are only served on the
+// metrics port of the process which nodes sharing a host can't listen on together.
+func (pt *ParamTable) initMetricsPort() {
+	port, err := pt.LoadWithDefault("indexNode.metricsPort", "0")
+	if err != nil {
+		panic(err)
+	}
+	pt.MetricsPort, err = strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+}
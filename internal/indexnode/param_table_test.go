@@ -14,6 +14,9 @@ package indexnode
 import (
 	"testing"
 	"time"
+
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParamTable(t *testing.T) {
@@ -67,6 +70,13 @@ func TestParamTable(t *testing.T) {
 		t.Logf("MinioBucketName: %v", Params.MinioBucketName)
 	})
 
+	t.Run("StorageType", func(t *testing.T) {
+		assert.Equal(t, "minio", Params.StorageType)
+		t.Logf("LocalStoragePath: %v", Params.LocalStoragePath)
+		assert.False(t, Params.LocalStorageSyncOnWrite)
+		assert.False(t, Params.StorageChecksumWarnOnly)
+	})
+
 	t.Run("SimdType", func(t *testing.T) {
 		t.Logf("SimdType: %v", Params.SimdType)
 	})
@@ -82,8 +92,23 @@ func TestParamTable(t *testing.T) {
 		t.Logf("UpdatedTime: %v", Params.UpdatedTime)
 	})
 
-	t.Run("IndexRootPath", func(t *testing.T) {
-		t.Logf("IndexRootPath: %v", Params.IndexRootPath)
+	t.Run("MinioRootPath", func(t *testing.T) {
+		t.Logf("MinioRootPath: %v", Params.MinioRootPath)
+	})
+
+	t.Run("MetricsPort", func(t *testing.T) {
+		assert.Equal(t, 0, Params.MetricsPort)
+	})
+
+	t.Run("Scheduler", func(t *testing.T) {
+		assert.Equal(t, int64(1024), Params.MaxTaskNum)
+		assert.Equal(t, 1, Params.BuildParallel)
+		assert.Equal(t, metricsinfo.GetMemoryCount(), Params.BuildMemoryLimit)
+		assert.Equal(t, uint64(0), Params.BuildDiskLimit)
+	})
+
+	t.Run("BuildDir", func(t *testing.T) {
+		assert.Equal(t, "/var/lib/milvus/index_build", Params.BuildDir)
 	})
 }
 
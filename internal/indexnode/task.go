@@ -15,9 +15,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/timerecord"
 	"github.com/milvus-io/milvus/internal/util/trace"
@@ -40,6 +41,13 @@ const (
 
 	// IndexBuildTaskName is the name of the operation to add an index task.
 	IndexBuildTaskName = "IndexBuildTask"
+
+	// indexBuildMemoryFactor is the ratio of the memory to build an index to the size of the vectors, the vectors are
+	// held by the loaded binlogs, the deserialized insert data and the index while building.
+	indexBuildMemoryFactor = 3
+	// indexBuildDiskFactor is the ratio of the local disk to build an index to the size of the vectors, which is taken
+	// by the temporary files of the index.
+	indexBuildDiskFactor = 1
 )
 
 type task interface {
@@ -54,12 +62,15 @@ type task interface {
 	Notify(err error)
 	OnEnqueue() error
 	SetError(err error)
+	Cancel()
+	estimateResources() (memory uint64, disk uint64)
 }
 
 // BaseTask is an basic instance of task.
 type BaseTask struct {
 	done        chan error
 	ctx         context.Context
+	cancel      context.CancelFunc
 	id          UniqueID
 	err         error
 	internalErr error
@@ -89,6 +100,13 @@ func (bt *BaseTask) WaitToFinish() error {
 	}
 }
 
+// Cancel cancels the context of the task, the task stops at its next check of the context.
+func (bt *BaseTask) Cancel() {
+	if bt.cancel != nil {
+		bt.cancel()
+	}
+}
+
 // Notify will notify WaitToFinish that the task is completed or failed.
 func (bt *BaseTask) Notify(err error) {
 	bt.done <- err
@@ -103,6 +121,9 @@ type IndexBuildTask struct {
 	savePaths []string
 	req       *indexpb.CreateIndexRequest
 	nodeID    UniqueID
+	// buildDirs manages the local directory of the task, the intermediate files of the building are written in it
+	buildDirs *buildDirManager
+	buildDir  string
 }
 
 // Ctx is the context of index tasks.
@@ -132,6 +153,46 @@ func (it *IndexBuildTask) OnEnqueue() error {
 	return nil
 }
 
+// findParam finds the value of the key in the params, including the params nested in the value of paramsKeyToParse.
+func findParam(params []*commonpb.KeyValuePair, key string) (string, bool) {
+	for _, kvPair := range params {
+		if kvPair.GetKey() == key {
+			return kvPair.GetValue(), true
+		}
+		if kvPair.GetKey() == paramsKeyToParse {
+			nested, err := funcutil.ParseIndexParamsMap(kvPair.GetValue())
+			if err != nil {
+				continue
+			}
+			if value, ok := nested[key]; ok {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// estimateResources estimates the memory and the local disk to build the index by the number of rows and the
+// dimension of the vectors, it returns zeros if they are unknown.
+func (it *IndexBuildTask) estimateResources() (uint64, uint64) {
+	numRows := it.req.GetNumRows()
+	value, ok := findParam(it.req.GetTypeParams(), "dim")
+	if !ok || numRows <= 0 {
+		return 0, 0
+	}
+	dim, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || dim <= 0 {
+		return 0, 0
+	}
+	// the dimension of a binary vector is the number of bits
+	vectorSize := uint64(dim) * 4
+	if indexType, _ := findParam(it.req.GetIndexParams(), "index_type"); strings.HasPrefix(indexType, "BIN_") {
+		vectorSize = uint64(dim) / 8
+	}
+	dataSize := uint64(numRows) * vectorSize
+	return dataSize * indexBuildMemoryFactor, dataSize * indexBuildDiskFactor
+}
+
 func (it *IndexBuildTask) checkIndexMeta(ctx context.Context, pre bool) error {
 	fn := func() error {
 		//TODO error handling need to be optimized, return Unrecoverable to avoid retry
@@ -234,6 +295,29 @@ func (it *IndexBuildTask) Execute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("IndexBuildTask %d", it.req.IndexBuildID))
 	var err error
 
+	// the index building can't be interrupted, so check whether the task is canceled between the stages
+	checkCanceled := func(stage string) error {
+		if err := ctx.Err(); err != nil {
+			log.Info("IndexNode IndexBuildTask is canceled", zap.Int64("buildId", it.req.IndexBuildID),
+				zap.String("stage", stage))
+			return err
+		}
+		return nil
+	}
+
+	if it.buildDirs != nil {
+		_, disk := it.estimateResources()
+		it.buildDir, err = it.buildDirs.create(it.req.IndexBuildID, disk)
+		if err != nil {
+			log.Warn("IndexNode IndexBuildTask failed to create the build directory",
+				zap.Int64("buildId", it.req.IndexBuildID), zap.Error(err))
+			// the disk of another index node may be enough, the task can be retried
+			it.internalErr = err
+			return nil
+		}
+		defer it.buildDirs.remove(it.req.IndexBuildID)
+	}
+
 	typeParams := make(map[string]string)
 	for _, kvPair := range it.req.GetTypeParams() {
 		key, value := kvPair.GetKey(), kvPair.GetValue()
@@ -241,6 +325,10 @@ func (it *IndexBuildTask) Execute(ctx context.Context) error {
 		if ok {
 			return errors.New("duplicated key in type params")
 		}
+		if key == storage.CompressionKey {
+			// binlog compression is not a param of index
+			continue
+		}
 		if key == paramsKeyToParse {
 			params, err := funcutil.ParseIndexParamsMap(value)
 			if err != nil {
@@ -316,6 +404,9 @@ func (it *IndexBuildTask) Execute(ctx context.Context) error {
 		return blobs
 	}
 
+	if err = checkCanceled("load data"); err != nil {
+		return err
+	}
 	toLoadDataPaths := it.req.GetDataPaths()
 	keys := make([]string, len(toLoadDataPaths))
 	blobs := make([]*Blob, len(toLoadDataPaths))
@@ -352,6 +443,9 @@ func (it *IndexBuildTask) Execute(ctx context.Context) error {
 		return errors.New("we expect only one field in deserialized insert data")
 	}
 	tr.Record("deserialize storage blobs done")
+	if err = checkCanceled("build index"); err != nil {
+		return err
+	}
 
 	for fieldID, value := range insertData.Data {
 		// TODO: BinaryVectorFieldData
@@ -404,11 +498,12 @@ func (it *IndexBuildTask) Execute(ctx context.Context) error {
 		}
 		_ = codec.Close()
 		tr.Record("serialize index codec done")
+		if err = checkCanceled("save index files"); err != nil {
+			return err
+		}
 
 		getSavePathByKey := func(key string) string {
-
-			return path.Join(Params.IndexRootPath, strconv.Itoa(int(it.req.IndexBuildID)), strconv.Itoa(int(it.req.Version)),
-				strconv.Itoa(int(partitionID)), strconv.Itoa(int(segmentID)), key)
+			return pathutil.IndexFilePath(Params.MinioRootPath, it.req.IndexBuildID, it.req.Version, partitionID, segmentID, key)
 		}
 		saveBlob := func(path string, value []byte) error {
 			return it.kv.Save(path, string(value))
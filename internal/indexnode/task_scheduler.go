@@ -21,6 +21,8 @@ import (
 
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/opentracing/opentracing-go"
 	oplog "github.com/opentracing/opentracing-go/log"
@@ -32,11 +34,16 @@ type TaskQueue interface {
 	utEmpty() bool
 	utFull() bool
 	addUnissuedTask(t task) error
+	setMaxTaskNum(maxTaskNum int64)
+	unissuedTaskNum() int
 	//FrontUnissuedTask() task
 	PopUnissuedTask() task
+	popAdmittedTask(admit func(t task) bool) task
 	AddActiveTask(t task)
 	PopActiveTask(tID UniqueID) task
 	Enqueue(t task) error
+	CancelTasks(tIDs []UniqueID) []UniqueID
+	TaskStates(tIDs []UniqueID) []*indexpb.IndexTaskState
 	//tryToRemoveUselessIndexBuildTask(indexID UniqueID) []UniqueID
 }
 
@@ -47,10 +54,9 @@ type BaseTaskQueue struct {
 	utLock        sync.Mutex
 	atLock        sync.Mutex
 
-	// maxTaskNum should keep still
 	maxTaskNum int64
 
-	utBufChan chan int // to block scheduler
+	utBufChan chan int // to notify scheduler of the new tasks
 
 	sched *TaskScheduler
 }
@@ -75,10 +81,28 @@ func (queue *BaseTaskQueue) addUnissuedTask(t task) error {
 		return errors.New("IndexNode task queue is full")
 	}
 	queue.unissuedTasks.PushBack(t)
-	queue.utBufChan <- 1
+	// the scheduler checks all the unissued tasks once notified, so a pending notification is enough
+	select {
+	case queue.utBufChan <- 1:
+	default:
+	}
 	return nil
 }
 
+func (queue *BaseTaskQueue) setMaxTaskNum(maxTaskNum int64) {
+	queue.utLock.Lock()
+	defer queue.utLock.Unlock()
+
+	queue.maxTaskNum = maxTaskNum
+}
+
+func (queue *BaseTaskQueue) unissuedTaskNum() int {
+	queue.utLock.Lock()
+	defer queue.utLock.Unlock()
+
+	return queue.unissuedTasks.Len()
+}
+
 //func (queue *BaseTaskQueue) FrontUnissuedTask() task {
 //	queue.utLock.Lock()
 //	defer queue.utLock.Unlock()
@@ -106,6 +130,20 @@ func (queue *BaseTaskQueue) PopUnissuedTask() task {
 	return ft.Value.(task)
 }
 
+// popAdmittedTask pops the task at the front of the queue if it's admitted, the tasks are never popped out of order.
+func (queue *BaseTaskQueue) popAdmittedTask(admit func(t task) bool) task {
+	queue.utLock.Lock()
+	defer queue.utLock.Unlock()
+
+	ft := queue.unissuedTasks.Front()
+	if ft == nil || !admit(ft.Value.(task)) {
+		return nil
+	}
+	queue.unissuedTasks.Remove(ft)
+
+	return ft.Value.(task)
+}
+
 // AddActiveTask adds a task to activeTasks.
 func (queue *BaseTaskQueue) AddActiveTask(t task) {
 	queue.atLock.Lock()
@@ -164,6 +202,72 @@ func (queue *BaseTaskQueue) Enqueue(t task) error {
 	return queue.addUnissuedTask(t)
 }
 
+// CancelTasks removes the unissued tasks with the ids from the queue and cancels the active ones,
+// it returns the ids of the tasks found.
+func (queue *BaseTaskQueue) CancelTasks(tIDs []UniqueID) []UniqueID {
+	toCancel := make(map[UniqueID]struct{}, len(tIDs))
+	for _, tID := range tIDs {
+		toCancel[tID] = struct{}{}
+	}
+	var canceled []UniqueID
+
+	queue.utLock.Lock()
+	var next *list.Element
+	for e := queue.unissuedTasks.Front(); e != nil; e = next {
+		next = e.Next()
+		t := e.Value.(task)
+		if _, ok := toCancel[t.ID()]; ok {
+			queue.unissuedTasks.Remove(e)
+			t.Cancel()
+			canceled = append(canceled, t.ID())
+		}
+	}
+	queue.utLock.Unlock()
+
+	queue.atLock.Lock()
+	for tID, t := range queue.activeTasks {
+		if _, ok := toCancel[tID]; ok {
+			t.Cancel()
+			canceled = append(canceled, tID)
+		}
+	}
+	queue.atLock.Unlock()
+
+	return canceled
+}
+
+// TaskStates returns the states of the tasks with the ids, a task waiting in the queue is Unissued with its position
+// in the queue, an active task is InProgress, and an unknown task is IndexStateNone.
+func (queue *BaseTaskQueue) TaskStates(tIDs []UniqueID) []*indexpb.IndexTaskState {
+	positions := make(map[UniqueID]int64)
+	queue.utLock.Lock()
+	var pos int64
+	for e := queue.unissuedTasks.Front(); e != nil; e = e.Next() {
+		positions[e.Value.(task).ID()] = pos
+		pos++
+	}
+	queue.utLock.Unlock()
+
+	queue.atLock.Lock()
+	defer queue.atLock.Unlock()
+	states := make([]*indexpb.IndexTaskState, 0, len(tIDs))
+	for _, tID := range tIDs {
+		state := &indexpb.IndexTaskState{
+			IndexBuildID:  tID,
+			State:         commonpb.IndexState_IndexStateNone,
+			QueuePosition: -1,
+		}
+		if p, ok := positions[tID]; ok {
+			state.State = commonpb.IndexState_Unissued
+			state.QueuePosition = p
+		} else if _, ok := queue.activeTasks[tID]; ok {
+			state.State = commonpb.IndexState_InProgress
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
 // IndexBuildTaskQueue is a task queue used to store building index tasks.
 type IndexBuildTaskQueue struct {
 	BaseTaskQueue
@@ -176,21 +280,33 @@ func NewIndexBuildTaskQueue(sched *TaskScheduler) *IndexBuildTaskQueue {
 			unissuedTasks: list.New(),
 			activeTasks:   make(map[UniqueID]task),
 			maxTaskNum:    1024,
-			utBufChan:     make(chan int, 1024),
+			utBufChan:     make(chan int, 1),
 			sched:         sched,
 		},
 	}
 }
 
 // TaskScheduler is a scheduler of indexing tasks.
+// The tasks are started in the order they are enqueued, a task is admitted only if the running tasks are fewer
+// than buildParallel, and the memory and the disk it's estimated to take fit in the limits.
 type TaskScheduler struct {
 	IndexBuildQueue TaskQueue
 
 	buildParallel int
-	kv            kv.BaseKV
-	wg            sync.WaitGroup
-	ctx           context.Context
-	cancel        context.CancelFunc
+	// 0 means no limit
+	memoryLimit uint64
+	diskLimit   uint64
+
+	resLock     sync.Mutex
+	runningNum  int
+	usedMemory  uint64
+	usedDisk    uint64
+	releaseChan chan struct{} // to notify scheduler of the finished tasks
+
+	kv     kv.BaseKV
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewTaskScheduler creates a new task scheduler of indexing tasks.
@@ -202,30 +318,105 @@ func NewTaskScheduler(ctx context.Context,
 		ctx:           ctx1,
 		cancel:        cancel,
 		buildParallel: 1, // default value
+		releaseChan:   make(chan struct{}, 1),
 	}
 	s.IndexBuildQueue = NewIndexBuildTaskQueue(s)
 
 	return s, nil
 }
 
-//func (sched *TaskScheduler) setParallelism(parallel int) {
-//	if parallel <= 0 {
-//		log.Debug("IndexNode can not set parallelism to less than zero!")
-//		return
-//	}
-//	sched.buildParallel = parallel
-//}
+func (sched *TaskScheduler) setParallelism(parallel int) {
+	if parallel <= 0 {
+		log.Debug("IndexNode can not set parallelism to less than zero!")
+		return
+	}
+	sched.resLock.Lock()
+	defer sched.resLock.Unlock()
+	sched.buildParallel = parallel
+}
+
+func (sched *TaskScheduler) setResourceLimits(memoryLimit, diskLimit uint64) {
+	sched.resLock.Lock()
+	defer sched.resLock.Unlock()
+	sched.memoryLimit = memoryLimit
+	sched.diskLimit = diskLimit
+}
+
+func exceedLimit(used, limit uint64) bool {
+	return limit > 0 && used > limit
+}
+
+// admit reserves the resources for a task if it can be started right now.
+func (sched *TaskScheduler) admit(memory, disk uint64) bool {
+	sched.resLock.Lock()
+	defer sched.resLock.Unlock()
+
+	if sched.runningNum >= sched.buildParallel {
+		return false
+	}
+	// a task exceeding the limits by itself is admitted when no task is running, otherwise it waits forever
+	if sched.runningNum > 0 &&
+		(exceedLimit(sched.usedMemory+memory, sched.memoryLimit) || exceedLimit(sched.usedDisk+disk, sched.diskLimit)) {
+		return false
+	}
+	sched.runningNum++
+	sched.usedMemory += memory
+	sched.usedDisk += disk
+	return true
+}
+
+// release returns the resources of a finished task, and notifies scheduler to start the waiting tasks.
+func (sched *TaskScheduler) release(memory, disk uint64) {
+	sched.resLock.Lock()
+	sched.runningNum--
+	sched.usedMemory -= memory
+	sched.usedDisk -= disk
+	sched.resLock.Unlock()
+
+	select {
+	case sched.releaseChan <- struct{}{}:
+	default:
+	}
+}
 
-func (sched *TaskScheduler) scheduleIndexBuildTask() []task {
-	ret := make([]task, 0)
-	for i := 0; i < sched.buildParallel; i++ {
-		t := sched.IndexBuildQueue.PopUnissuedTask()
+// GetTaskSlots returns the number of tasks which can be started right now.
+func (sched *TaskScheduler) GetTaskSlots() int64 {
+	// the tasks waiting in the queue can't be started yet, the new tasks have to wait behind them
+	if sched.IndexBuildQueue.unissuedTaskNum() > 0 {
+		return 0
+	}
+
+	sched.resLock.Lock()
+	defer sched.resLock.Unlock()
+	if (sched.memoryLimit > 0 && sched.usedMemory >= sched.memoryLimit) ||
+		(sched.diskLimit > 0 && sched.usedDisk >= sched.diskLimit) {
+		return 0
+	}
+	return int64(sched.buildParallel - sched.runningNum)
+}
+
+// scheduleIndexBuildTask starts the tasks at the front of the queue until one of them is not admitted, which defers
+// the tasks behind it.
+func (sched *TaskScheduler) scheduleIndexBuildTask() {
+	for {
+		var memory, disk uint64
+		t := sched.IndexBuildQueue.popAdmittedTask(func(t task) bool {
+			memory, disk = t.estimateResources()
+			return sched.admit(memory, disk)
+		})
 		if t == nil {
-			return ret
+			return
 		}
-		ret = append(ret, t)
+		log.Debug("IndexNode TaskScheduler admit task", zap.Int64("TaskID", t.ID()),
+			zap.Uint64("memory", memory), zap.Uint64("disk", disk))
+
+		sched.wg.Add(1)
+		go func(t task, memory, disk uint64) {
+			defer sched.wg.Done()
+			defer sched.release(memory, disk)
+			sched.processTask(t, sched.IndexBuildQueue)
+		}(t, memory, disk)
 	}
-	return ret
 }
 
 func (sched *TaskScheduler) processTask(t task, q TaskQueue) {
@@ -274,19 +465,9 @@ func (sched *TaskScheduler) indexBuildLoop() {
 		case <-sched.ctx.Done():
 			return
 		case <-sched.IndexBuildQueue.utChan():
-			if !sched.IndexBuildQueue.utEmpty() {
-				tasks := sched.scheduleIndexBuildTask()
-				var wg sync.WaitGroup
-				for _, t := range tasks {
-					wg.Add(1)
-					go func(group *sync.WaitGroup, t task) {
-						defer group.Done()
-						sched.processTask(t, sched.IndexBuildQueue)
-					}(&wg, t)
-				}
-				wg.Wait()
-			}
+		case <-sched.releaseChan:
 		}
+		sched.scheduleIndexBuildTask()
 	}
 }
 
@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexnode
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexBuildTaskQueue_CancelTasks(t *testing.T) {
+	sched, err := NewTaskScheduler(context.Background(), nil)
+	assert.Nil(t, err)
+	queue := sched.IndexBuildQueue
+
+	newTask := func(indexBuildID UniqueID) *IndexBuildTask {
+		ctx, cancel := context.WithCancel(context.Background())
+		return &IndexBuildTask{
+			BaseTask: BaseTask{
+				ctx:    ctx,
+				cancel: cancel,
+				done:   make(chan error),
+			},
+			req: &indexpb.CreateIndexRequest{IndexBuildID: indexBuildID},
+		}
+	}
+	tasks := []*IndexBuildTask{newTask(1), newTask(2), newTask(3)}
+	for _, it := range tasks {
+		err = queue.Enqueue(it)
+		assert.Nil(t, err)
+	}
+	// the task 1 is in progress
+	active := queue.PopUnissuedTask()
+	queue.AddActiveTask(active)
+
+	canceled := queue.CancelTasks([]UniqueID{1, 2, 4})
+	assert.ElementsMatch(t, []UniqueID{1, 2}, canceled)
+	assert.Error(t, tasks[0].Ctx().Err())
+	assert.Error(t, tasks[1].Ctx().Err())
+	assert.Nil(t, tasks[2].Ctx().Err())
+
+	next := queue.PopUnissuedTask()
+	assert.Equal(t, UniqueID(3), next.ID())
+	assert.Nil(t, queue.PopUnissuedTask())
+}
+
+// fakeTask reports its start and runs until it's told to finish.
+type fakeTask struct {
+	BaseTask
+	memory  uint64
+	started chan<- *fakeTask
+	finish  chan struct{}
+}
+
+func newFakeTask(id UniqueID, memory uint64, started chan<- *fakeTask) *fakeTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &fakeTask{
+		BaseTask: BaseTask{
+			ctx:    ctx,
+			cancel: cancel,
+			done:   make(chan error),
+		},
+		memory:  memory,
+		started: started,
+		finish:  make(chan struct{}),
+	}
+	t.setID(id)
+	return t
+}
+
+func (t *fakeTask) Ctx() context.Context {
+	return t.ctx
+}
+
+func (t *fakeTask) SetID(id UniqueID) {
+	t.setID(id)
+}
+
+func (t *fakeTask) OnEnqueue() error {
+	return nil
+}
+
+func (t *fakeTask) PreExecute(ctx context.Context) error {
+	return nil
+}
+
+func (t *fakeTask) Execute(ctx context.Context) error {
+	t.started <- t
+	<-t.finish
+	return nil
+}
+
+func (t *fakeTask) PostExecute(ctx context.Context) error {
+	return nil
+}
+
+func (t *fakeTask) estimateResources() (uint64, uint64) {
+	return t.memory, 0
+}
+
+func TestTaskScheduler_Admission(t *testing.T) {
+	const memoryLimit = 100
+	sched, err := NewTaskScheduler(context.Background(), nil)
+	assert.Nil(t, err)
+	sched.setParallelism(2)
+	sched.setResourceLimits(memoryLimit, 0)
+
+	started := make(chan *fakeTask, 10)
+	memories := []uint64{60, 30, 50, 10, 200}
+	tasks := make([]*fakeTask, 0, len(memories))
+	for i, memory := range memories {
+		ft := newFakeTask(UniqueID(i+1), memory, started)
+		tasks = append(tasks, ft)
+		err = sched.IndexBuildQueue.Enqueue(ft)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int64(0), sched.GetTaskSlots())
+	states := sched.IndexBuildQueue.TaskStates([]UniqueID{1, 5, 6})
+	assert.Equal(t, commonpb.IndexState_Unissued, states[0].State)
+	assert.Equal(t, int64(0), states[0].QueuePosition)
+	assert.Equal(t, commonpb.IndexState_Unissued, states[1].State)
+	assert.Equal(t, int64(4), states[1].QueuePosition)
+	assert.Equal(t, commonpb.IndexState_IndexStateNone, states[2].State)
+	assert.Equal(t, int64(-1), states[2].QueuePosition)
+
+	var lock sync.Mutex
+	running := make(map[UniqueID]*fakeTask)
+	// expectStarted checks exactly the tasks are started, and the running tasks never exceed the limits,
+	// the tasks admitted together may start executing in any order
+	expectStarted := func(ids ...UniqueID) {
+		startedIDs := make([]UniqueID, 0, len(ids))
+		for range ids {
+			select {
+			case ft := <-started:
+				startedIDs = append(startedIDs, ft.ID())
+				lock.Lock()
+				running[ft.ID()] = ft
+				var used uint64
+				for _, rt := range running {
+					used += rt.memory
+				}
+				assert.LessOrEqual(t, len(running), 2)
+				assert.True(t, len(running) == 1 || used <= memoryLimit)
+				lock.Unlock()
+			case <-time.After(5 * time.Second):
+				assert.FailNow(t, "task is not started", "tasks %v", ids)
+			}
+		}
+		assert.ElementsMatch(t, ids, startedIDs)
+		select {
+		case ft := <-started:
+			assert.FailNow(t, "task is started unexpectedly", "task %d", ft.ID())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	finishTask := func(id UniqueID) {
+		lock.Lock()
+		delete(running, id)
+		lock.Unlock()
+		close(tasks[id-1].finish)
+	}
+
+	err = sched.Start()
+	assert.Nil(t, err)
+	// the 3rd task waits for the parallelism
+	expectStarted(1, 2)
+	states = sched.IndexBuildQueue.TaskStates([]UniqueID{1, 3, 5})
+	assert.Equal(t, commonpb.IndexState_InProgress, states[0].State)
+	assert.Equal(t, commonpb.IndexState_Unissued, states[1].State)
+	assert.Equal(t, int64(0), states[1].QueuePosition)
+	assert.Equal(t, int64(2), states[2].QueuePosition)
+	assert.Equal(t, int64(0), sched.GetTaskSlots())
+
+	finishTask(1)
+	expectStarted(3)
+	finishTask(2)
+	expectStarted(4)
+	// the 5th task exceeds the memory limit by itself, it waits until no task is running
+	finishTask(3)
+	expectStarted()
+	finishTask(4)
+	expectStarted(5)
+	finishTask(5)
+
+	assert.Eventually(t, func() bool {
+		return sched.GetTaskSlots() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(0), sched.usedMemory)
+	sched.Close()
+}
+
+func TestIndexBuildTask_estimateResources(t *testing.T) {
+	newTask := func(numRows int64, typeParams, indexParams []*commonpb.KeyValuePair) *IndexBuildTask {
+		return &IndexBuildTask{
+			req: &indexpb.CreateIndexRequest{
+				NumRows:     numRows,
+				TypeParams:  typeParams,
+				IndexParams: indexParams,
+			},
+		}
+	}
+
+	memory, disk := newTask(1000, []*commonpb.KeyValuePair{{Key: "dim", Value: "128"}}, nil).estimateResources()
+	assert.Equal(t, uint64(1000*128*4*indexBuildMemoryFactor), memory)
+	assert.Equal(t, uint64(1000*128*4*indexBuildDiskFactor), disk)
+
+	memory, _ = newTask(1000,
+		[]*commonpb.KeyValuePair{{Key: paramsKeyToParse, Value: `{"dim": "128"}`}},
+		[]*commonpb.KeyValuePair{{Key: "index_type", Value: "BIN_IVF_FLAT"}}).estimateResources()
+	assert.Equal(t, uint64(1000*128/8*indexBuildMemoryFactor), memory)
+
+	// unknown number of rows or dimension
+	memory, disk = newTask(0, []*commonpb.KeyValuePair{{Key: "dim", Value: "128"}}, nil).estimateResources()
+	assert.Equal(t, uint64(0), memory)
+	assert.Equal(t, uint64(0), disk)
+	memory, _ = newTask(1000, nil, nil).estimateResources()
+	assert.Equal(t, uint64(0), memory)
+}
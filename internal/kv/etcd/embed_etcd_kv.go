@@ -73,7 +73,7 @@ func (kv *EmbedEtcdKV) GetPath(key string) string {
 }
 
 func (kv *EmbedEtcdKV) LoadWithPrefix(key string) ([]string, []string, error) {
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	log.Debug("LoadWithPrefix ", zap.String("prefix", key))
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
@@ -91,8 +91,13 @@ func (kv *EmbedEtcdKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	return keys, values, nil
 }
 
+// WalkWithPrefix calls fn on every key value pair with the prefix in key order, paginationSize pairs at a time
+func (kv *EmbedEtcdKV) WalkWithPrefix(prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	return walkWithPrefix(kv.client, kv.rootPath, prefix, paginationSize, fn)
+}
+
 func (kv *EmbedEtcdKV) LoadWithPrefix2(key string) ([]string, []string, []int64, error) {
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	log.Debug("LoadWithPrefix ", zap.String("prefix", key))
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
@@ -121,7 +126,7 @@ func (kv *EmbedEtcdKV) Load(key string) (string, error) {
 		return "", err
 	}
 	if resp.Count <= 0 {
-		return "", fmt.Errorf("there is no value on key = %s", key)
+		return "", errKeyNotFound(key)
 	}
 
 	return string(resp.Kvs[0].Value), nil
@@ -156,14 +161,14 @@ func (kv *EmbedEtcdKV) MultiLoad(keys []string) ([]string, error) {
 	if len(invalid) != 0 {
 		log.Debug("MultiLoad: there are invalid keys",
 			zap.Strings("keys", invalid))
-		err = fmt.Errorf("there are invalid keys: %s", invalid)
+		err = errInvalidKeys(invalid)
 		return result, err
 	}
 	return result, nil
 }
 
 func (kv *EmbedEtcdKV) LoadWithRevision(key string) ([]string, []string, int64, error) {
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	log.Debug("LoadWithPrefix ", zap.String("prefix", key))
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
@@ -212,7 +217,7 @@ func (kv *EmbedEtcdKV) MultiSave(kvs map[string]string) error {
 }
 
 func (kv *EmbedEtcdKV) RemoveWithPrefix(prefix string) error {
-	key := path.Join(kv.rootPath, prefix)
+	key := prefixPath(kv.rootPath, prefix)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
@@ -267,13 +272,13 @@ func (kv *EmbedEtcdKV) Watch(key string) clientv3.WatchChan {
 }
 
 func (kv *EmbedEtcdKV) WatchWithPrefix(key string) clientv3.WatchChan {
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	rch := kv.client.Watch(context.Background(), key, clientv3.WithPrefix(), clientv3.WithCreatedNotify())
 	return rch
 }
 
 func (kv *EmbedEtcdKV) WatchWithRevision(key string, revision int64) clientv3.WatchChan {
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	rch := kv.client.Watch(context.Background(), key, clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithRev(revision))
 	return rch
 }
@@ -281,7 +286,7 @@ func (kv *EmbedEtcdKV) WatchWithRevision(key string, revision int64) clientv3.Wa
 func (kv *EmbedEtcdKV) MultiRemoveWithPrefix(keys []string) error {
 	ops := make([]clientv3.Op, 0, len(keys))
 	for _, k := range keys {
-		op := clientv3.OpDelete(path.Join(kv.rootPath, k), clientv3.WithPrefix())
+		op := clientv3.OpDelete(prefixPath(kv.rootPath, k), clientv3.WithPrefix())
 		ops = append(ops, op)
 	}
 	log.Debug("MultiRemoveWithPrefix")
@@ -295,11 +300,11 @@ func (kv *EmbedEtcdKV) MultiRemoveWithPrefix(keys []string) error {
 func (kv *EmbedEtcdKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removals []string) error {
 	ops := make([]clientv3.Op, 0, len(saves))
 	for key, value := range saves {
-		ops = append(ops, clientv3.OpPut(path.Join(kv.rootPath, key), value))
+		ops = append(ops, clientv3.OpPut(prefixPath(kv.rootPath, key), value))
 	}
 
 	for _, keyDelete := range removals {
-		ops = append(ops, clientv3.OpDelete(path.Join(kv.rootPath, keyDelete), clientv3.WithPrefix()))
+		ops = append(ops, clientv3.OpDelete(prefixPath(kv.rootPath, keyDelete), clientv3.WithPrefix()))
 	}
 
 	log.Debug("MultiSaveAndRemove")
@@ -12,6 +12,8 @@
 package etcdkv_test
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 
@@ -36,6 +38,47 @@ func TestEmbedEtcd(te *testing.T) {
 	defer func() {
 		os.RemoveAll("etcd.test.data.dir")
 	}()
+	te.Run("EtcdKV WalkWithPrefix", func(t *testing.T) {
+		rootPath := "/etcd/test/root/walkwithprefix"
+		metaKv, err := embed_etcd_kv.NewMetaKvFactory(rootPath, param)
+		require.NoError(t, err)
+		defer metaKv.Close()
+		defer metaKv.RemoveWithPrefix("")
+
+		for i := 0; i < 10; i++ {
+			err = metaKv.Save(fmt.Sprintf("walk/%d", i), fmt.Sprintf("value%d", i))
+			require.NoError(t, err)
+		}
+		err = metaKv.Save("walkother", "other")
+		require.NoError(t, err)
+
+		var values []string
+		err = metaKv.WalkWithPrefix("walk/", 3, func(k []byte, v []byte) error {
+			values = append(values, string(v))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, len(values))
+		for i, v := range values {
+			assert.Equal(t, fmt.Sprintf("value%d", i), v)
+		}
+
+		// stops at the first error
+		count := 0
+		err = metaKv.WalkWithPrefix("walk/", 3, func(k []byte, v []byte) error {
+			count++
+			if count == 4 {
+				return errors.New("mock")
+			}
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 4, count)
+
+		err = metaKv.WalkWithPrefix("walk/", 0, func(k []byte, v []byte) error { return nil })
+		assert.Error(t, err)
+	})
+
 	te.Run("EtcdKV SaveAndLoad", func(t *testing.T) {
 		rootPath := "/etcd/test/root/saveandload"
 		metaKv, err := embed_etcd_kv.NewMetaKvFactory(rootPath, param)
@@ -13,10 +13,14 @@ package etcdkv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
@@ -27,10 +31,16 @@ const (
 	RequestTimeout = 10 * time.Second
 )
 
+// ErrFenced is returned by the writes of a fenced EtcdKV once its fencing token is stale
+var ErrFenced = errors.New("fencing token is stale")
+
 // EtcdKV implments TxnKv interface, it support to process multiple kvs in a transactions
 type EtcdKV struct {
 	client   *clientv3.Client
 	rootPath string
+
+	fenceKey string
+	fence    []clientv3.Cmp
 }
 
 // NewEtcdKV creates a new etcd kv.
@@ -62,13 +72,43 @@ func (kv *EtcdKV) Close() {
 	kv.client.Close()
 }
 
+// SetFence guards all the writes of the kv with a fencing token, a write fails with ErrFenced
+// unless the value of key, outside rootPath, still equals token.
+// It must be called before the kv is used.
+func (kv *EtcdKV) SetFence(key string, token int64) {
+	kv.fenceKey = key
+	kv.fence = []clientv3.Cmp{clientv3.Compare(clientv3.Value(key), "=", strconv.FormatInt(token, 10))}
+}
+
+// commit applies ops in a single transaction guarded by the fence
+func (kv *EtcdKV) commit(ctx context.Context, ops ...clientv3.Op) (*clientv3.TxnResponse, error) {
+	resp, err := kv.client.Txn(ctx).If(kv.fence...).Then(ops...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Succeeded {
+		return nil, fmt.Errorf("%w, key: %s", ErrFenced, kv.fenceKey)
+	}
+	return resp, nil
+}
+
+// compareAndCommit applies op if cmp holds, the comparison is nested in the fenced transaction
+// so a stale token and a failed comparison are told apart
+func (kv *EtcdKV) compareAndCommit(ctx context.Context, cmp clientv3.Cmp, op clientv3.Op) (bool, error) {
+	resp, err := kv.commit(ctx, clientv3.OpTxn([]clientv3.Cmp{cmp}, []clientv3.Op{op}, nil))
+	if err != nil {
+		return false, err
+	}
+	return resp.Responses[0].GetResponseTxn().Succeeded, nil
+}
+
 func (kv *EtcdKV) GetPath(key string) string {
 	return path.Join(kv.rootPath, key)
 }
 
 func (kv *EtcdKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	start := time.Now()
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 	resp, err := kv.client.Get(ctx, key, clientv3.WithPrefix(),
@@ -86,9 +126,54 @@ func (kv *EtcdKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	return keys, values, nil
 }
 
+// WalkWithPrefix calls fn on every key value pair with the prefix in key order, the pairs are fetched
+// paginationSize at a time from the same revision, so the whole prefix is never held in memory
+func (kv *EtcdKV) WalkWithPrefix(prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	start := time.Now()
+	if err := walkWithPrefix(kv.client, kv.rootPath, prefix, paginationSize, fn); err != nil {
+		return err
+	}
+	CheckElapseAndWarn(start, "Slow etcd operation walk with prefix")
+	return nil
+}
+
+func walkWithPrefix(client *clientv3.Client, rootPath, prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	if paginationSize <= 0 {
+		return fmt.Errorf("invalid pagination size %d", paginationSize)
+	}
+	prefix = prefixPath(rootPath, prefix)
+	opts := []clientv3.OpOption{
+		clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefix)),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+		clientv3.WithLimit(int64(paginationSize)),
+	}
+	key := prefix
+	for {
+		ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
+		resp, err := client.Get(ctx, key, opts...)
+		cancel()
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			if err := fn(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		// continue right after the last key, reading from the revision of the first page
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+		if len(opts) == 3 {
+			opts = append(opts, clientv3.WithRev(resp.Header.Revision))
+		}
+	}
+}
+
 func (kv *EtcdKV) LoadWithPrefix2(key string) ([]string, []string, []int64, error) {
 	start := time.Now()
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 	resp, err := kv.client.Get(ctx, key, clientv3.WithPrefix(),
@@ -118,7 +203,7 @@ func (kv *EtcdKV) Load(key string) (string, error) {
 		return "", err
 	}
 	if resp.Count <= 0 {
-		return "", fmt.Errorf("there is no value on key = %s", key)
+		return "", errKeyNotFound(key)
 	}
 	CheckElapseAndWarn(start, "Slow etcd operation load")
 	return string(resp.Kvs[0].Value), nil
@@ -151,7 +236,7 @@ func (kv *EtcdKV) MultiLoad(keys []string) ([]string, error) {
 	}
 	if len(invalid) != 0 {
 		log.Warn("MultiLoad: there are invalid keys", zap.Strings("keys", invalid))
-		err = fmt.Errorf("there are invalid keys: %s", invalid)
+		err = errInvalidKeys(invalid)
 		return result, err
 	}
 	CheckElapseAndWarn(start, "Slow etcd operation multi load")
@@ -160,7 +245,7 @@ func (kv *EtcdKV) MultiLoad(keys []string) ([]string, error) {
 
 func (kv *EtcdKV) LoadWithRevision(key string) ([]string, []string, int64, error) {
 	start := time.Now()
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 	resp, err := kv.client.Get(ctx, key, clientv3.WithPrefix(),
@@ -183,7 +268,7 @@ func (kv *EtcdKV) Save(key, value string) error {
 	key = path.Join(kv.rootPath, key)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
-	_, err := kv.client.Put(ctx, key, value)
+	_, err := kv.commit(ctx, clientv3.OpPut(key, value))
 	CheckElapseAndWarn(start, "Slow etcd operation save")
 	return err
 }
@@ -194,7 +279,7 @@ func (kv *EtcdKV) SaveWithLease(key, value string, id clientv3.LeaseID) error {
 	key = path.Join(kv.rootPath, key)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
-	_, err := kv.client.Put(ctx, key, value, clientv3.WithLease(id))
+	_, err := kv.commit(ctx, clientv3.OpPut(key, value, clientv3.WithLease(id)))
 	CheckElapseAndWarn(start, "Slow etcd operation save with lease")
 	return err
 }
@@ -209,18 +294,18 @@ func (kv *EtcdKV) MultiSave(kvs map[string]string) error {
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Txn(ctx).If().Then(ops...).Commit()
+	_, err := kv.commit(ctx, ops...)
 	CheckElapseAndWarn(start, "Slow etcd operation multi save")
 	return err
 }
 
 func (kv *EtcdKV) RemoveWithPrefix(prefix string) error {
 	start := time.Now()
-	key := path.Join(kv.rootPath, prefix)
+	key := prefixPath(kv.rootPath, prefix)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Delete(ctx, key, clientv3.WithPrefix())
+	_, err := kv.commit(ctx, clientv3.OpDelete(key, clientv3.WithPrefix()))
 	CheckElapseAndWarn(start, "Slow etcd operation remove with prefix")
 	return err
 }
@@ -231,7 +316,7 @@ func (kv *EtcdKV) Remove(key string) error {
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Delete(ctx, key)
+	_, err := kv.commit(ctx, clientv3.OpDelete(key))
 	CheckElapseAndWarn(start, "Slow etcd operation remove")
 	return err
 }
@@ -246,7 +331,7 @@ func (kv *EtcdKV) MultiRemove(keys []string) error {
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Txn(ctx).If().Then(ops...).Commit()
+	_, err := kv.commit(ctx, ops...)
 	CheckElapseAndWarn(start, "Slow etcd operation multi remove")
 	return err
 }
@@ -265,7 +350,7 @@ func (kv *EtcdKV) MultiSaveAndRemove(saves map[string]string, removals []string)
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Txn(ctx).If().Then(ops...).Commit()
+	_, err := kv.commit(ctx, ops...)
 	CheckElapseAndWarn(start, "Slow etcd operation multi save and remove")
 	return err
 }
@@ -280,7 +365,7 @@ func (kv *EtcdKV) Watch(key string) clientv3.WatchChan {
 
 func (kv *EtcdKV) WatchWithPrefix(key string) clientv3.WatchChan {
 	start := time.Now()
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	rch := kv.client.Watch(context.Background(), key, clientv3.WithPrefix(), clientv3.WithCreatedNotify())
 	CheckElapseAndWarn(start, "Slow etcd operation watch with prefix")
 	return rch
@@ -288,7 +373,7 @@ func (kv *EtcdKV) WatchWithPrefix(key string) clientv3.WatchChan {
 
 func (kv *EtcdKV) WatchWithRevision(key string, revision int64) clientv3.WatchChan {
 	start := time.Now()
-	key = path.Join(kv.rootPath, key)
+	key = prefixPath(kv.rootPath, key)
 	rch := kv.client.Watch(context.Background(), key, clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithRev(revision))
 	CheckElapseAndWarn(start, "Slow etcd operation watch with revision")
 	return rch
@@ -298,13 +383,13 @@ func (kv *EtcdKV) MultiRemoveWithPrefix(keys []string) error {
 	start := time.Now()
 	ops := make([]clientv3.Op, 0, len(keys))
 	for _, k := range keys {
-		op := clientv3.OpDelete(path.Join(kv.rootPath, k), clientv3.WithPrefix())
+		op := clientv3.OpDelete(prefixPath(kv.rootPath, k), clientv3.WithPrefix())
 		ops = append(ops, op)
 	}
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Txn(ctx).If().Then(ops...).Commit()
+	_, err := kv.commit(ctx, ops...)
 	CheckElapseAndWarn(start, "Slow etcd operation multi remove with prefix")
 	return err
 }
@@ -313,17 +398,17 @@ func (kv *EtcdKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removals
 	start := time.Now()
 	ops := make([]clientv3.Op, 0, len(saves))
 	for key, value := range saves {
-		ops = append(ops, clientv3.OpPut(path.Join(kv.rootPath, key), value))
+		ops = append(ops, clientv3.OpPut(prefixPath(kv.rootPath, key), value))
 	}
 
 	for _, keyDelete := range removals {
-		ops = append(ops, clientv3.OpDelete(path.Join(kv.rootPath, keyDelete), clientv3.WithPrefix()))
+		ops = append(ops, clientv3.OpDelete(prefixPath(kv.rootPath, keyDelete), clientv3.WithPrefix()))
 	}
 
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
 
-	_, err := kv.client.Txn(ctx).If().Then(ops...).Commit()
+	_, err := kv.commit(ctx, ops...)
 	CheckElapseAndWarn(start, "Slow etcd operation multi save and move with prefix")
 	return err
 }
@@ -354,16 +439,16 @@ func (kv *EtcdKV) CompareValueAndSwap(key, value, target string, opts ...clientv
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
-	resp, err := kv.client.Txn(ctx).If(
+	succeeded, err := kv.compareAndCommit(ctx,
 		clientv3.Compare(
 			clientv3.Value(path.Join(kv.rootPath, key)),
 			"=",
-			value)).
-		Then(clientv3.OpPut(path.Join(kv.rootPath, key), target, opts...)).Commit()
+			value),
+		clientv3.OpPut(path.Join(kv.rootPath, key), target, opts...))
 	if err != nil {
 		return err
 	}
-	if !resp.Succeeded {
+	if !succeeded {
 		return fmt.Errorf("function CompareAndSwap error for compare is false for key: %s", key)
 	}
 	CheckElapseAndWarn(start, "Slow etcd operation compare value and swap")
@@ -376,22 +461,42 @@ func (kv *EtcdKV) CompareVersionAndSwap(key string, version int64, target string
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(context.TODO(), RequestTimeout)
 	defer cancel()
-	resp, err := kv.client.Txn(ctx).If(
+	succeeded, err := kv.compareAndCommit(ctx,
 		clientv3.Compare(
 			clientv3.Version(path.Join(kv.rootPath, key)),
 			"=",
-			version)).
-		Then(clientv3.OpPut(path.Join(kv.rootPath, key), target, opts...)).Commit()
+			version),
+		clientv3.OpPut(path.Join(kv.rootPath, key), target, opts...))
 	if err != nil {
 		return err
 	}
-	if !resp.Succeeded {
+	if !succeeded {
 		return fmt.Errorf("function CompareAndSwap error for compare is false for key: %s", key)
 	}
 	CheckElapseAndWarn(start, "Slow etcd operation compare version and swap")
 	return nil
 }
 
+// prefixPath joins prefix to rootPath for a prefix operation, keeping the trailing slash dropped by path.Join,
+// otherwise "a/" would match "ab", and an empty prefix would match the siblings of rootPath
+func prefixPath(rootPath, prefix string) string {
+	joined := path.Join(rootPath, prefix)
+	if (strings.HasSuffix(prefix, "/") || (prefix == "" && rootPath != "")) && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// errKeyNotFound returns an error wrapping kv.ErrKeyNotFound for the full path of a missing key
+func errKeyNotFound(key string) error {
+	return fmt.Errorf("%w = %s", kv.ErrKeyNotFound, key)
+}
+
+// errInvalidKeys returns an error wrapping kv.ErrKeyNotFound for the missing keys of a MultiLoad
+func errInvalidKeys(keys []string) error {
+	return fmt.Errorf("there are invalid keys: %s, %w", keys, kv.ErrKeyNotFound)
+}
+
 func CheckElapseAndWarn(start time.Time, message string) bool {
 	elapsed := time.Since(start)
 	if elapsed.Milliseconds() > 2000 {
@@ -12,6 +12,7 @@
 package etcdkv_test
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -488,6 +489,63 @@ func TestEtcdKV_Load(te *testing.T) {
 		}
 
 	})
+
+	te.Run("EtcdKV Fence", func(t *testing.T) {
+		rootPath := "/etcd/test/root/fence"
+		fenceKey := "/etcd/test/fence/token"
+		fenceKV, err := etcdkv.NewEtcdKV(etcdEndPoints, "/etcd/test/fence")
+		require.NoError(t, err)
+		defer fenceKV.Close()
+		defer fenceKV.Remove("token")
+
+		etcdKV, err := etcdkv.NewEtcdKV(etcdEndPoints, rootPath)
+		require.NoError(t, err)
+		defer etcdKV.Close()
+		defer etcdKV.RemoveWithPrefix("")
+
+		// the fence key doesn't exist yet
+		etcdKV.SetFence(fenceKey, 1)
+		err = etcdKV.Save("a", "v1")
+		assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+
+		err = fenceKV.Save("token", "1")
+		require.NoError(t, err)
+
+		err = etcdKV.Save("a", "v1")
+		assert.NoError(t, err)
+		err = etcdKV.MultiSave(map[string]string{"b": "v2", "c": "v3"})
+		assert.NoError(t, err)
+		err = etcdKV.CompareValueAndSwap("a", "v1", "v2")
+		assert.NoError(t, err)
+		err = etcdKV.CompareValueAndSwap("a", "v1", "v3")
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, etcdkv.ErrFenced))
+
+		// a newer holder took the token
+		err = fenceKV.Save("token", "2")
+		require.NoError(t, err)
+
+		err = etcdKV.Save("a", "v3")
+		assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+		err = etcdKV.MultiSaveAndRemove(map[string]string{"d": "v4"}, []string{"b"})
+		assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+		err = etcdKV.Remove("c")
+		assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+		err = etcdKV.CompareValueAndSwap("a", "v2", "v3")
+		assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+		err = etcdKV.CompareVersionAndSwap("e", 0, "v5")
+		assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+
+		value, err := etcdKV.Load("a")
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", value)
+		_, err = etcdKV.Load("d")
+		assert.Error(t, err)
+
+		etcdKV.SetFence(fenceKey, 2)
+		err = etcdKV.Save("a", "v3")
+		assert.NoError(t, err)
+	})
 }
 
 func TestElapse(t *testing.T) {
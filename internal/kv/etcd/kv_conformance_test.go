@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+
+package etcdkv
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/kv/kvtest"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+func TestKVConformance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "etcd_kv_conformance")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	server, err := NewEmbededEtcdKV(cfg, "")
+	require.NoError(t, err)
+	defer server.Close()
+
+	// every test gets its own root path, the keys returned by etcd carry it
+	rootPath := func(t *testing.T) string {
+		return path.Join("/kv/conformance", t.Name())
+	}
+
+	t.Run("EtcdKV", func(t *testing.T) {
+		kvtest.RunTxnKVSuite(t, func(t *testing.T) (kv.TxnKV, string) {
+			etcdKV := NewEtcdKVWithClient(server.client, rootPath(t))
+			t.Cleanup(func() { etcdKV.RemoveWithPrefix("") })
+			return etcdKV, etcdKV.rootPath + "/"
+		})
+	})
+
+	t.Run("EmbedEtcdKV", func(t *testing.T) {
+		kvtest.RunTxnKVSuite(t, func(t *testing.T) (kv.TxnKV, string) {
+			embedKV := &EmbedEtcdKV{client: server.client, rootPath: rootPath(t), etcd: server.etcd}
+			t.Cleanup(func() { embedKV.RemoveWithPrefix("") })
+			return embedKV, embedKV.rootPath + "/"
+		})
+	})
+}
@@ -12,11 +12,24 @@
 package kv
 
 import (
+	"errors"
+
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
+var (
+	// ErrKeyNotFound is wrapped by the error returned when loading a key that does not exist
+	ErrKeyNotFound = errors.New("there is no value on key")
+	// ErrUnsupported is wrapped by the error returned by an operation the kv does not implement
+	ErrUnsupported = errors.New("operation is not supported")
+)
+
 // BaseKV contains base operations of kv. Include save, load and remove.
+// Load fails with ErrKeyNotFound on a missing key, MultiLoad fails with it too but still returns the values
+// of the other keys, with empty strings in place of the missing ones. An empty value is a valid value.
+// Keys with a prefix are returned in ascending key order, a prefix ending with "/" does not match
+// its siblings, e.g. "a/" does not match "ab", and an empty prefix matches all the keys of the kv.
 type BaseKV interface {
 	Load(key string) (string, error)
 	MultiLoad(keys []string) ([]string, error)
@@ -43,6 +56,9 @@ type TxnKV interface {
 	MultiSaveAndRemove(saves map[string]string, removals []string) error
 	MultiRemoveWithPrefix(keys []string) error
 	MultiSaveAndRemoveWithPrefix(saves map[string]string, removals []string) error
+	// WalkWithPrefix calls fn on every key value pair with the prefix in key order and stops at the first error,
+	// implementations fetch at most paginationSize pairs at a time if they support pagination
+	WalkWithPrefix(prefix string, paginationSize int, fn func(key []byte, value []byte) error) error
 }
 
 // MetaKv is TxnKV for meta data. It should save data with lease.
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+
+// Package kvtest is the conformance test suite every kv implementation runs in its own test package,
+// so that all the backends agree on the semantics documented by kv.BaseKV and kv.TxnKV.
+package kvtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// BaseKVFactory returns an empty kv for a single test, along with the prefix the kv puts before
+// the keys it returns, e.g. the root path of etcd. The kv must be released by the factory on cleanup.
+type BaseKVFactory func(t *testing.T) (kv.BaseKV, string)
+
+// TxnKVFactory is the BaseKVFactory of a kv.TxnKV.
+type TxnKVFactory func(t *testing.T) (kv.TxnKV, string)
+
+// prefixTestKeys are saved by the prefix tests, "prefixed" and "other" must survive the prefix "prefix/"
+var prefixTestKeys = []string{"prefix/b", "prefix/a", "prefix/a/c", "prefixed", "other"}
+
+// RunBaseKVSuite runs the conformance tests of kv.BaseKV.
+func RunBaseKVSuite(t *testing.T, newKV BaseKVFactory) {
+	t.Run("Load", func(t *testing.T) {
+		store, _ := newKV(t)
+		require.NoError(t, store.Save("load/a", "1"))
+		value, err := store.Load("load/a")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", value)
+
+		require.NoError(t, store.Save("load/a", "2"))
+		value, err = store.Load("load/a")
+		assert.NoError(t, err)
+		assert.Equal(t, "2", value)
+
+		// an empty value is a value
+		require.NoError(t, store.Save("load/empty", ""))
+		value, err = store.Load("load/empty")
+		assert.NoError(t, err)
+		assert.Equal(t, "", value)
+
+		_, err = store.Load("load/missing")
+		assert.True(t, errors.Is(err, kv.ErrKeyNotFound), "unexpected error: %v", err)
+	})
+
+	t.Run("MultiLoad", func(t *testing.T) {
+		store, _ := newKV(t)
+		require.NoError(t, store.MultiSave(map[string]string{"a": "1", "b": "2", "empty": ""}))
+
+		values, err := store.MultiLoad([]string{"empty", "b", "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"", "2", "1"}, values)
+
+		values, err = store.MultiLoad([]string{"a", "missing", "b"})
+		assert.True(t, errors.Is(err, kv.ErrKeyNotFound), "unexpected error: %v", err)
+		assert.Equal(t, []string{"1", "", "2"}, values)
+
+		values, err = store.MultiLoad([]string{})
+		assert.NoError(t, err)
+		assert.Empty(t, values)
+	})
+
+	t.Run("LoadWithPrefix", func(t *testing.T) {
+		store, keyPrefix := newKV(t)
+		saveKeys(t, store, prefixTestKeys)
+
+		tests := []struct {
+			prefix string
+			keys   []string
+		}{
+			{"prefix/", []string{"prefix/a", "prefix/a/c", "prefix/b"}},
+			{"prefix/a", []string{"prefix/a", "prefix/a/c"}},
+			{"prefix", []string{"prefix/a", "prefix/a/c", "prefix/b", "prefixed"}},
+			{"missing", []string{}},
+			{"", []string{"other", "prefix/a", "prefix/a/c", "prefix/b", "prefixed"}},
+		}
+		for _, test := range tests {
+			keys, values, err := store.LoadWithPrefix(test.prefix)
+			assert.NoError(t, err)
+			assert.Equal(t, test.keys, trimKeys(keys, keyPrefix), "prefix %q", test.prefix)
+			assert.Equal(t, valuesOf(test.keys), values, "prefix %q", test.prefix)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		store, _ := newKV(t)
+		saveKeys(t, store, []string{"a", "b", "c"})
+
+		assert.NoError(t, store.Remove("a"))
+		_, err := store.Load("a")
+		assert.True(t, errors.Is(err, kv.ErrKeyNotFound), "unexpected error: %v", err)
+		// removing a missing key is not an error
+		assert.NoError(t, store.Remove("a"))
+
+		assert.NoError(t, store.MultiRemove([]string{"b", "missing"}))
+		_, err = store.Load("b")
+		assert.True(t, errors.Is(err, kv.ErrKeyNotFound), "unexpected error: %v", err)
+		value, err := store.Load("c")
+		assert.NoError(t, err)
+		assert.Equal(t, valueOf("c"), value)
+	})
+
+	t.Run("RemoveWithPrefix", func(t *testing.T) {
+		store, keyPrefix := newKV(t)
+		saveKeys(t, store, prefixTestKeys)
+
+		assert.NoError(t, store.RemoveWithPrefix("prefix/"))
+		assert.NoError(t, store.RemoveWithPrefix("missing"))
+		assert.Equal(t, []string{"other", "prefixed"}, loadAllKeys(t, store, keyPrefix))
+
+		// an empty prefix removes everything
+		assert.NoError(t, store.RemoveWithPrefix(""))
+		assert.Empty(t, loadAllKeys(t, store, keyPrefix))
+	})
+}
+
+// RunTxnKVSuite runs RunBaseKVSuite and the conformance tests of the extra operations of kv.TxnKV.
+// The tests of an operation failing with kv.ErrUnsupported are skipped.
+func RunTxnKVSuite(t *testing.T, newKV TxnKVFactory) {
+	RunBaseKVSuite(t, func(t *testing.T) (kv.BaseKV, string) {
+		return newKV(t)
+	})
+
+	t.Run("MultiSaveAndRemove", func(t *testing.T) {
+		store, keyPrefix := newKV(t)
+		saveKeys(t, store, []string{"a", "b"})
+
+		err := store.MultiSaveAndRemove(map[string]string{"c": valueOf("c")}, []string{"a", "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "c"}, loadAllKeys(t, store, keyPrefix))
+	})
+
+	t.Run("MultiRemoveWithPrefix", func(t *testing.T) {
+		store, keyPrefix := newKV(t)
+		saveKeys(t, store, prefixTestKeys)
+
+		err := store.MultiRemoveWithPrefix([]string{"prefix/", "other", "missing"})
+		skipUnsupported(t, err)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"prefixed"}, loadAllKeys(t, store, keyPrefix))
+	})
+
+	t.Run("MultiSaveAndRemoveWithPrefix", func(t *testing.T) {
+		store, keyPrefix := newKV(t)
+		saveKeys(t, store, prefixTestKeys)
+
+		err := store.MultiSaveAndRemoveWithPrefix(map[string]string{"new": valueOf("new")}, []string{"prefix/", "missing"})
+		skipUnsupported(t, err)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"new", "other", "prefixed"}, loadAllKeys(t, store, keyPrefix))
+	})
+
+	t.Run("WalkWithPrefix", func(t *testing.T) {
+		store, keyPrefix := newKV(t)
+		keys := []string{"walk/3", "walk/0", "walk/4", "walk/1", "walk/2", "walked"}
+		saveKeys(t, store, keys)
+
+		var walked, values []string
+		err := store.WalkWithPrefix("walk/", 2, func(k []byte, v []byte) error {
+			walked = append(walked, string(k))
+			values = append(values, string(v))
+			return nil
+		})
+		assert.NoError(t, err)
+		expected := []string{"walk/0", "walk/1", "walk/2", "walk/3", "walk/4"}
+		assert.Equal(t, expected, trimKeys(walked, keyPrefix))
+		assert.Equal(t, valuesOf(expected), values)
+
+		// the walk stops at the first error
+		count := 0
+		err = store.WalkWithPrefix("walk/", 2, func(k []byte, v []byte) error {
+			count++
+			if count == 3 {
+				return errors.New("mock")
+			}
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 3, count)
+
+		err = store.WalkWithPrefix("walk/", 0, func(k []byte, v []byte) error { return nil })
+		assert.Error(t, err)
+	})
+}
+
+func valueOf(key string) string {
+	return fmt.Sprintf("value of %s", key)
+}
+
+func valuesOf(keys []string) []string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, valueOf(key))
+	}
+	return values
+}
+
+// saveKeys saves every key with its valueOf one at a time, so the keys are not saved in key order
+func saveKeys(t *testing.T, store kv.BaseKV, keys []string) {
+	for _, key := range keys {
+		require.NoError(t, store.Save(key, valueOf(key)))
+	}
+}
+
+func trimKeys(keys []string, keyPrefix string) []string {
+	trimmed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		trimmed = append(trimmed, strings.TrimPrefix(key, keyPrefix))
+	}
+	return trimmed
+}
+
+func loadAllKeys(t *testing.T, store kv.BaseKV, keyPrefix string) []string {
+	keys, _, err := store.LoadWithPrefix("")
+	require.NoError(t, err)
+	return trimKeys(keys, keyPrefix)
+}
+
+func skipUnsupported(t *testing.T, err error) {
+	if errors.Is(err, kv.ErrUnsupported) {
+		t.Skipf("unsupported: %v", err)
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"sync"
 
 	"github.com/google/btree"
+
+	"github.com/milvus-io/milvus/internal/kv"
 )
 
 type MemoryKV struct {
@@ -31,6 +33,16 @@ func NewMemoryKV() *MemoryKV {
 	}
 }
 
+// errKeyNotFound returns an error wrapping kv.ErrKeyNotFound for a missing key
+func errKeyNotFound(key string) error {
+	return fmt.Errorf("%w = %s", kv.ErrKeyNotFound, key)
+}
+
+// errInvalidKeys returns an error wrapping kv.ErrKeyNotFound for the missing keys of a MultiLoad
+func errInvalidKeys(keys []string) error {
+	return fmt.Errorf("there are invalid keys: %s, %w", keys, kv.ErrKeyNotFound)
+}
+
 type memoryKVItem struct {
 	key, value string
 }
@@ -43,9 +55,8 @@ func (kv *MemoryKV) Load(key string) (string, error) {
 	kv.RLock()
 	defer kv.RUnlock()
 	item := kv.tree.Get(memoryKVItem{key, ""})
-	// TODO，load unexisted key behavior is weird
 	if item == nil {
-		return "", nil
+		return "", errKeyNotFound(key)
 	}
 	return item.(memoryKVItem).value, nil
 }
@@ -96,10 +107,19 @@ func (kv *MemoryKV) MultiLoad(keys []string) ([]string, error) {
 	kv.RLock()
 	defer kv.RUnlock()
 	result := make([]string, 0, len(keys))
+	invalid := make([]string, 0, len(keys))
 	for _, key := range keys {
 		item := kv.tree.Get(memoryKVItem{key, ""})
+		if item == nil {
+			invalid = append(invalid, key)
+			result = append(result, "")
+			continue
+		}
 		result = append(result, item.(memoryKVItem).value)
 	}
+	if len(invalid) != 0 {
+		return result, errInvalidKeys(invalid)
+	}
 	return result, nil
 }
 
@@ -133,10 +153,10 @@ func (kv *MemoryKV) MultiSaveAndRemove(saves map[string]string, removals []strin
 	return nil
 }
 
-// todo
+// LoadWithPrefix returns the key value pairs with the prefix in key order
 func (kv *MemoryKV) LoadWithPrefix(key string) ([]string, []string, error) {
-	kv.Lock()
-	defer kv.Unlock()
+	kv.RLock()
+	defer kv.RUnlock()
 
 	keys := make([]string, 0)
 	values := make([]string, 0)
@@ -151,29 +171,40 @@ func (kv *MemoryKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	return keys, values, nil
 }
 
+// WalkWithPrefix calls fn on every key value pair with the prefix in key order,
+// the pairs are copied before calling fn so fn may access the kv
+func (kv *MemoryKV) WalkWithPrefix(prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	if paginationSize <= 0 {
+		return fmt.Errorf("invalid pagination size %d", paginationSize)
+	}
+	keys, values, err := kv.LoadWithPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		if err := fn([]byte(keys[i]), []byte(values[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (kv *MemoryKV) Close() {
 }
 
 func (kv *MemoryKV) MultiRemoveWithPrefix(keys []string) error {
-	panic("not implement")
+	kv.Lock()
+	defer kv.Unlock()
+
+	kv.removeWithPrefix(keys...)
+	return nil
 }
+
 func (kv *MemoryKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removals []string) error {
 	kv.Lock()
 	defer kv.Unlock()
 
-	keys := make([]memoryKVItem, 0)
-	for _, key := range removals {
-		kv.tree.Ascend(func(i btree.Item) bool {
-			if strings.HasPrefix(i.(memoryKVItem).key, key) {
-				keys = append(keys, i.(memoryKVItem))
-			}
-			return true
-		})
-	}
-	for _, item := range keys {
-		kv.tree.Delete(item)
-	}
-
+	kv.removeWithPrefix(removals...)
 	for key, value := range saves {
 		kv.tree.ReplaceOrInsert(memoryKVItem{key, value})
 	}
@@ -184,18 +215,25 @@ func (kv *MemoryKV) RemoveWithPrefix(key string) error {
 	kv.Lock()
 	defer kv.Unlock()
 
-	keys := make([]btree.Item, 0)
+	kv.removeWithPrefix(key)
+	return nil
+}
 
-	kv.tree.Ascend(func(i btree.Item) bool {
-		if strings.HasPrefix(i.(memoryKVItem).key, key) {
-			keys = append(keys, i.(memoryKVItem))
-		}
-		return true
-	})
-	for _, item := range keys {
+// removeWithPrefix deletes the items with any of the prefixes, the caller must hold the write lock
+func (kv *MemoryKV) removeWithPrefix(prefixes ...string) {
+	items := make([]btree.Item, 0)
+	for _, prefix := range prefixes {
+		kv.tree.AscendGreaterOrEqual(memoryKVItem{prefix, ""}, func(i btree.Item) bool {
+			if !strings.HasPrefix(i.(memoryKVItem).key, prefix) {
+				return false
+			}
+			items = append(items, i)
+			return true
+		})
+	}
+	for _, item := range items {
 		kv.tree.Delete(item)
 	}
-	return nil
 }
 
 // item already in memory, just slice the value.
@@ -12,8 +12,11 @@
 package memkv
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/kv/kvtest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,7 +75,34 @@ func TestMemoryKV_GetSize(t *testing.T) {
 
 	key2 := "TestMemoryKV_GetSize_key2"
 
-	size, err = memKV.GetSize(key2)
+	_, err = memKV.GetSize(key2)
+	assert.True(t, errors.Is(err, kv.ErrKeyNotFound))
+}
+
+func TestMemoryKV_WalkWithPrefix(t *testing.T) {
+	memKV := NewMemoryKV()
+	assert.NoError(t, memKV.Save("walk/1", "1"))
+	assert.NoError(t, memKV.Save("walk/2", "2"))
+	assert.NoError(t, memKV.Save("other", "3"))
+
+	var keys []string
+	err := memKV.WalkWithPrefix("walk/", 1, func(k []byte, v []byte) error {
+		keys = append(keys, string(k))
+		// the kv is accessible while walking
+		_, err := memKV.Load(string(k))
+		return err
+	})
 	assert.NoError(t, err)
-	assert.Equal(t, int64(0), size)
+	assert.Equal(t, []string{"walk/1", "walk/2"}, keys)
+
+	err = memKV.WalkWithPrefix("walk/", 1, func(k []byte, v []byte) error {
+		return errors.New("mock")
+	})
+	assert.Error(t, err)
+}
+
+func TestMemoryKV_Conformance(t *testing.T) {
+	kvtest.RunTxnKVSuite(t, func(t *testing.T) (kv.TxnKV, string) {
+		return NewMemoryKV(), ""
+	})
 }
@@ -13,8 +13,10 @@ package miniokv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"sync"
 
 	"io"
@@ -27,12 +29,17 @@ import (
 	"go.uber.org/zap"
 )
 
+// DefaultPartSize is the size of the parts to upload an object in, the objects smaller than it are uploaded
+// in a single PutObject call.
+const DefaultPartSize = 16 * 1024 * 1024
+
 // MinIOKV implements DataKV interface and relies on underling MinIO service.
 // MinIOKV object contains a client which can be used to access the MinIO service.
 type MinIOKV struct {
 	ctx         context.Context
 	minioClient *minio.Client
 	bucketName  string
+	partSize    uint64
 }
 
 type Option struct {
@@ -41,7 +48,8 @@ type Option struct {
 	BucketName        string
 	SecretAccessKeyID string
 	UseSSL            bool
-	CreateBucket      bool // when bucket not existed, create it
+	CreateBucket      bool   // when bucket not existed, create it
+	PartSize          uint64 // objects are uploaded in parts of PartSize, 0 means DefaultPartSize, it's at least 5MB
 }
 
 // NewMinIOKV creates MinIOKV to save and load object to MinIOKV.
@@ -83,6 +91,10 @@ func NewMinIOKV(ctx context.Context, option *Option) (*MinIOKV, error) {
 		ctx:         ctx,
 		minioClient: minIOClient,
 		bucketName:  option.BucketName,
+		partSize:    option.PartSize,
+	}
+	if kv.partSize == 0 {
+		kv.partSize = DefaultPartSize
 	}
 	log.Debug("MinioKV new MinioKV success.")
 
@@ -114,6 +126,18 @@ func (kv *MinIOKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	return objectsKeys, objectsValues, nil
 }
 
+// ListWithPrefix lists the keys of all objects with the same prefix @prefix from minio.
+func (kv *MinIOKV) ListWithPrefix(prefix string) ([]string, error) {
+	var keys []string
+	for object := range kv.minioClient.ListObjects(kv.ctx, kv.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
 // Load loads an object with @key.
 func (kv *MinIOKV) Load(key string) (string, error) {
 	object, err := kv.minioClient.GetObject(kv.ctx, kv.bucketName, key, minio.GetObjectOptions{})
@@ -132,6 +156,20 @@ func (kv *MinIOKV) Load(key string) (string, error) {
 	return buf.String(), nil
 }
 
+// LoadObject opens the object with @key for reading, the object is read from MinIO as the reader is consumed.
+func (kv *MinIOKV) LoadObject(key string) (io.ReadCloser, error) {
+	object, err := kv.minioClient.GetObject(kv.ctx, kv.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject doesn't send any request until the object is read, stat it to find out whether it exists
+	if _, err = object.Stat(); err != nil {
+		object.Close()
+		return nil, err
+	}
+	return object, nil
+}
+
 // FGetObject download file from minio to local storage system.
 func (kv *MinIOKV) FGetObject(key, localPath string) error {
 	err := kv.minioClient.FGetObject(kv.ctx, kv.bucketName, key, localPath+key, minio.GetObjectOptions{})
@@ -184,13 +222,15 @@ func (kv *MinIOKV) MultiLoad(keys []string) ([]string, error) {
 
 // Save object with @key to Minio. Object value is @value.
 func (kv *MinIOKV) Save(key, value string) error {
-	reader := strings.NewReader(value)
-	_, err := kv.minioClient.PutObject(kv.ctx, kv.bucketName, key, reader, int64(len(value)), minio.PutObjectOptions{})
-
-	if err != nil {
-		return err
-	}
+	return kv.SaveObject(key, strings.NewReader(value), int64(len(value)))
+}
 
+// SaveObject saves the object with @key from @reader, @size is the size of the object, or -1 if it's unknown.
+// The objects not smaller than the part size are uploaded in parts, so they are not buffered in memory as a whole.
+func (kv *MinIOKV) SaveObject(key string, reader io.Reader, size int64) error {
+	_, err := kv.minioClient.PutObject(kv.ctx, kv.bucketName, key, reader, size, minio.PutObjectOptions{
+		PartSize: kv.partSize,
+	})
 	return err
 }
 
@@ -285,10 +325,44 @@ func (kv *MinIOKV) GetSize(key string) (int64, error) {
 	return objectInfo.Size, nil
 }
 
+// Stat obtains the data size and the etag of the object with @key, the etag changes when the object is rewritten.
+func (kv *MinIOKV) Stat(key string) (int64, string, error) {
+	objectInfo, err := kv.minioClient.StatObject(kv.ctx, kv.bucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, "", err
+	}
+
+	return objectInfo.Size, objectInfo.ETag, nil
+}
+
 func (kv *MinIOKV) Close() {
 
 }
 
+// IsNotExist returns whether the error is caused by a missing object or bucket.
+func IsNotExist(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	return code == "NoSuchKey" || code == "NoSuchBucket"
+}
+
+// IsRetryable returns whether the error is transient, e.g. a network failure or an error of the server side,
+// the errors of the requests themselves like NoSuchKey are permanent.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	resp := minio.ToErrorResponse(err)
+	switch resp.Code {
+	case "":
+		// not a response of the server
+		return true
+	case "SlowDown", "RequestTimeout", "RequestTimeTooSkewed", "InternalError", "ServiceUnavailable",
+		"OperationAborted", "XMinioServerNotInitialized":
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
 type errorList []error
 
 func (el errorList) Error() string {
@@ -13,13 +13,17 @@ package miniokv_test
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/minio/minio-go/v7"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -189,6 +193,34 @@ func TestMinIOKV_Remove(t *testing.T) {
 	assert.Empty(t, val)
 }
 
+func TestMinIOKV_ListWithPrefix(t *testing.T) {
+	Params.Init()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bucketName := "fantastic-tech-test"
+	MinIOKV, err := newMinIOKVClient(ctx, bucketName)
+	assert.Nil(t, err)
+	defer MinIOKV.RemoveWithPrefix("")
+
+	kvs := map[string]string{
+		"index/1/1/a":  "1",
+		"index/1/2/b":  "2",
+		"index/10/1/c": "3",
+	}
+	err = MinIOKV.MultiSave(kvs)
+	assert.Nil(t, err)
+
+	keys, err := MinIOKV.ListWithPrefix("index/1/")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"index/1/1/a", "index/1/2/b"}, keys)
+
+	keys, err = MinIOKV.ListWithPrefix("index/2/")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
+
 func TestMinIOKV_LoadPartial(t *testing.T) {
 	Params.Init()
 
@@ -361,4 +393,63 @@ func TestMinIOKV_GetSize(t *testing.T) {
 	size, err = minIOKV.GetSize(key2)
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), size)
+
+	size, etag, err := minIOKV.Stat(key)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(value)), size)
+	assert.NotEmpty(t, etag)
+
+	err = minIOKV.Save(key, value+"_v2")
+	assert.NoError(t, err)
+	_, etag2, err := minIOKV.Stat(key)
+	assert.NoError(t, err)
+	assert.NotEqual(t, etag, etag2)
+
+	_, _, err = minIOKV.Stat(key2)
+	assert.Error(t, err)
+}
+
+func TestMinIOKV_SaveObject(t *testing.T) {
+	Params.Init()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bucketName := "fantastic-tech-test"
+	minIOKV, err := newMinIOKVClient(ctx, bucketName)
+	assert.Nil(t, err)
+	defer minIOKV.RemoveWithPrefix("")
+
+	key := "TestMinIOKV_SaveObject_key"
+	value := "TestMinIOKV_SaveObject_value"
+
+	// the size is unknown
+	err = minIOKV.SaveObject(key, strings.NewReader(value), -1)
+	assert.NoError(t, err)
+
+	reader, err := minIOKV.LoadObject(key)
+	assert.NoError(t, err)
+	content, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, value, string(content))
+	assert.NoError(t, reader.Close())
+
+	_, err = minIOKV.LoadObject("TestMinIOKV_SaveObject_key2")
+	assert.Error(t, err)
+	assert.True(t, miniokv.IsNotExist(err))
+	assert.False(t, miniokv.IsRetryable(err))
+}
+
+func TestMinIOKV_IsRetryable(t *testing.T) {
+	assert.False(t, miniokv.IsRetryable(nil))
+	assert.False(t, miniokv.IsRetryable(context.Canceled))
+	assert.True(t, miniokv.IsRetryable(errors.New("connection reset by peer")))
+	assert.True(t, miniokv.IsRetryable(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, miniokv.IsRetryable(minio.ErrorResponse{Code: "BadGateway", StatusCode: http.StatusBadGateway}))
+	assert.False(t, miniokv.IsRetryable(minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}))
+
+	notExist := minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound}
+	assert.False(t, miniokv.IsRetryable(notExist))
+	assert.True(t, miniokv.IsNotExist(notExist))
+	assert.False(t, miniokv.IsNotExist(errors.New("connection reset by peer")))
 }
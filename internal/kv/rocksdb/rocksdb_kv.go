@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/tecbot/gorocksdb"
 )
 
@@ -74,7 +75,7 @@ func (kv *RocksdbKV) GetName() string {
 	return kv.name
 }
 
-// Load returns the value of specified key
+// Load returns the value of specified key, an error wrapping kv.ErrKeyNotFound is returned if the key does not exist
 func (kv *RocksdbKV) Load(key string) (string, error) {
 	if kv.DB == nil {
 		return "", fmt.Errorf("Rocksdb instance is nil when load %s", key)
@@ -85,14 +86,23 @@ func (kv *RocksdbKV) Load(key string) (string, error) {
 		return "", err
 	}
 	defer value.Free()
+	if !value.Exists() {
+		return "", errKeyNotFound(key)
+	}
 	return string(value.Data()), nil
 }
 
-// LoadWithPrefix returns a batch values of keys with a prefix
-func (kv *RocksdbKV) LoadWithPrefix(key string) ([]string, []string, error) {
-	if key == "" {
-		return nil, nil, errors.New("Key is nil in LoadWithPrefix")
+// LoadWithDefault returns the value of specified key, or defaultValue if the key does not exist
+func (kv *RocksdbKV) LoadWithDefault(key, defaultValue string) (string, error) {
+	value, err := kv.Load(key)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
 	}
+	return value, err
+}
+
+// LoadWithPrefix returns a batch values of keys with a prefix in key order, an empty prefix loads all the keys
+func (kv *RocksdbKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	if kv.DB == nil {
 		return nil, nil, fmt.Errorf("Rocksdb instance is nil when load %s", key)
 	}
@@ -110,7 +120,8 @@ func (kv *RocksdbKV) LoadWithPrefix(key string) ([]string, []string, error) {
 	keys := make([]string, 0)
 	values := make([]string, 0)
 	iter.Seek([]byte(key))
-	for ; iter.Valid(); iter.Next() {
+	// the keys shorter than the prefix extractor are not filtered out by rocksdb
+	for ; iter.ValidForPrefix([]byte(key)); iter.Next() {
 		key := iter.Key()
 		value := iter.Value()
 		keys = append(keys, string(key.Data()))
@@ -133,20 +144,28 @@ func (kv *RocksdbKV) ResetPrefixLength(len int) error {
 	return err
 }
 
-// MultiLoad load a batch of values by keys
+// MultiLoad load a batch of values by keys, empty strings are returned in place of the missing keys
+// along with an error wrapping kv.ErrKeyNotFound
 func (kv *RocksdbKV) MultiLoad(keys []string) ([]string, error) {
 	if kv.DB == nil {
 		return nil, errors.New("Rocksdb instance is nil when do MultiLoad")
 	}
 	values := make([]string, 0, len(keys))
+	invalid := make([]string, 0, len(keys))
 	for _, key := range keys {
 		value, err := kv.DB.Get(kv.ReadOptions, []byte(key))
 		if err != nil {
 			return []string{}, err
 		}
+		if !value.Exists() {
+			invalid = append(invalid, key)
+		}
 		values = append(values, string(value.Data()))
 		value.Free()
 	}
+	if len(invalid) != 0 {
+		return values, errInvalidKeys(invalid)
+	}
 	return values, nil
 }
 
@@ -190,12 +209,12 @@ func (kv *RocksdbKV) RemoveWithPrefix(prefix string) error {
 	iter := kv.DB.NewIterator(kv.ReadOptions)
 	defer iter.Close()
 	iter.Seek([]byte(prefix))
-	for ; iter.Valid(); iter.Next() {
+	for ; iter.ValidForPrefix([]byte(prefix)); iter.Next() {
 		key := iter.Key()
 		err := kv.DB.Delete(kv.WriteOptions, key.Data())
 		key.Free()
 		if err != nil {
-			return nil
+			return err
 		}
 	}
 	if err := iter.Err(); err != nil {
@@ -263,12 +282,50 @@ func (kv *RocksdbKV) DeleteRange(startKey, endKey string) error {
 	return err
 }
 
-// MultiRemoveWithPrefix is used to remove a batch of key-values with the same prefix
+// MultiRemoveWithPrefix is not supported, the prefix iteration of rocksdb reopens the db
+// so the removals can not be done in a single write batch
 func (kv *RocksdbKV) MultiRemoveWithPrefix(keys []string) error {
-	panic("not implement")
+	return errUnsupported("MultiRemoveWithPrefix")
+}
+
+// WalkWithPrefix calls fn on every key value pair with the prefix in key order, no pagination is done for rocksdb
+func (kv *RocksdbKV) WalkWithPrefix(prefix string, paginationSize int, fn func([]byte, []byte) error) error {
+	if paginationSize <= 0 {
+		return fmt.Errorf("invalid pagination size %d", paginationSize)
+	}
+	keys, values, err := kv.LoadWithPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		if err := fn([]byte(keys[i]), []byte(values[i])); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// MultiSaveAndRemoveWithPrefix is used to execute a batch operators with the same prefix
+// MultiSaveAndRemoveWithPrefix is not supported for the same reason as MultiRemoveWithPrefix
 func (kv *RocksdbKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removals []string) error {
-	panic("not implement")
+	return errUnsupported("MultiSaveAndRemoveWithPrefix")
+}
+
+// errKeyNotFound returns an error wrapping kv.ErrKeyNotFound for a missing key
+func errKeyNotFound(key string) error {
+	return fmt.Errorf("%w = %s", kv.ErrKeyNotFound, key)
+}
+
+// errInvalidKeys returns an error wrapping kv.ErrKeyNotFound for the missing keys of a MultiLoad
+func errInvalidKeys(keys []string) error {
+	return fmt.Errorf("there are invalid keys: %s, %w", keys, kv.ErrKeyNotFound)
+}
+
+// isKeyNotFound tells whether err is caused by a missing key
+func isKeyNotFound(err error) bool {
+	return errors.Is(err, kv.ErrKeyNotFound)
+}
+
+// errUnsupported returns an error wrapping kv.ErrUnsupported for the operation
+func errUnsupported(op string) error {
+	return fmt.Errorf("%w: %s of rocksdb kv", kv.ErrUnsupported, op)
 }
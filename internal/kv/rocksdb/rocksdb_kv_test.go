@@ -12,12 +12,18 @@
 package rocksdbkv_test
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"sync"
 	"testing"
 
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/kv/kvtest"
 	rocksdbkv "github.com/milvus-io/milvus/internal/kv/rocksdb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRocksdbKV(t *testing.T) {
@@ -110,7 +116,9 @@ func TestRocksdbKV_Prefix(t *testing.T) {
 
 	err = rocksdbKV.RemoveWithPrefix("abc")
 	assert.Nil(t, err)
-	val, err := rocksdbKV.Load("abc")
+	_, err = rocksdbKV.Load("abcd")
+	assert.True(t, errors.Is(err, kv.ErrKeyNotFound))
+	val, err := rocksdbKV.LoadWithDefault("abcd", "")
 	assert.Nil(t, err)
 	assert.Equal(t, len(val), 0)
 	val, err = rocksdbKV.Load("abdd")
@@ -179,3 +187,17 @@ func TestRocksdbKV_Dummy(t *testing.T) {
 	_, err = rocksdbkv.Load("dummy")
 	assert.Error(t, err)
 }
+
+func TestRocksdbKV_Conformance(t *testing.T) {
+	kvtest.RunTxnKVSuite(t, func(t *testing.T) (kv.TxnKV, string) {
+		dir, err := ioutil.TempDir("", "rocksdb_kv_conformance")
+		require.NoError(t, err)
+		rocksdbKV, err := rocksdbkv.NewRocksdbKV(dir)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			rocksdbKV.Close()
+			os.RemoveAll(dir)
+		})
+		return rocksdbKV, ""
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package log
+
+import (
+	"expvar"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// namedLevels overrides the level of the loggers by name, a logger inherits the level of its closest parent,
+// e.g. the level of "querycoord" applies to "querycoord.scheduler" unless the latter has its own one.
+var namedLevels = struct {
+	sync.RWMutex
+	count  int32 // read without lock, so the loggers pay nothing if no level is overridden
+	levels map[string]zapcore.Level
+}{levels: make(map[string]zapcore.Level)}
+
+func init() {
+	// served at /debug/vars together with the prometheus metrics
+	expvar.Publish("log_levels", expvar.Func(func() interface{} {
+		levels := GetNamedLevels()
+		levels[""] = GetLevel().String()
+		return levels
+	}))
+}
+
+// Named returns the global Logger with name appended, whose level can be overridden by SetNamedLevel.
+// Call it when logging instead of keeping the result, the global Logger may be replaced.
+func Named(name string) *zap.Logger {
+	return L().Named(name)
+}
+
+// SetNamedLevel overrides the logging level of the loggers named name or its children,
+// the global level is altered if name is empty.
+func SetNamedLevel(name string, l zapcore.Level) {
+	if name == "" {
+		SetLevel(l)
+		return
+	}
+	namedLevels.Lock()
+	defer namedLevels.Unlock()
+	namedLevels.levels[name] = l
+	atomic.StoreInt32(&namedLevels.count, int32(len(namedLevels.levels)))
+}
+
+// ResetNamedLevel removes the overridden level of the loggers named name.
+func ResetNamedLevel(name string) {
+	namedLevels.Lock()
+	defer namedLevels.Unlock()
+	delete(namedLevels.levels, name)
+	atomic.StoreInt32(&namedLevels.count, int32(len(namedLevels.levels)))
+}
+
+// GetNamedLevels returns the overridden logging levels by logger name.
+func GetNamedLevels() map[string]string {
+	namedLevels.RLock()
+	defer namedLevels.RUnlock()
+	levels := make(map[string]string, len(namedLevels.levels))
+	for name, l := range namedLevels.levels {
+		levels[name] = l.String()
+	}
+	return levels
+}
+
+// namedLevel returns the overridden level of the logger named name
+func namedLevel(name string) (zapcore.Level, bool) {
+	if atomic.LoadInt32(&namedLevels.count) == 0 {
+		return 0, false
+	}
+	namedLevels.RLock()
+	defer namedLevels.RUnlock()
+	for name != "" {
+		if l, ok := namedLevels.levels[name]; ok {
+			return l, true
+		}
+		idx := strings.LastIndexByte(name, '.')
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+	return 0, false
+}
+
+// levelEnabler enables a level if the global level or any overridden level enables it,
+// the level of a specific logger is checked by namedLevelCore.
+type levelEnabler struct {
+	global zap.AtomicLevel
+}
+
+func (e levelEnabler) Enabled(l zapcore.Level) bool {
+	if e.global.Enabled(l) {
+		return true
+	}
+	if atomic.LoadInt32(&namedLevels.count) == 0 {
+		return false
+	}
+	namedLevels.RLock()
+	defer namedLevels.RUnlock()
+	for _, named := range namedLevels.levels {
+		if named.Enabled(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// namedLevelCore checks the entries against the level of their loggers
+type namedLevelCore struct {
+	zapcore.Core
+	global zap.AtomicLevel
+}
+
+func newNamedLevelCore(core zapcore.Core, global zap.AtomicLevel) zapcore.Core {
+	return &namedLevelCore{Core: core, global: global}
+}
+
+func (c *namedLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &namedLevelCore{Core: c.Core.With(fields), global: c.global}
+}
+
+func (c *namedLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	var enabled bool
+	if l, ok := namedLevel(ent.LoggerName); ok {
+		enabled = l.Enabled(ent.Level)
+	} else {
+		enabled = c.global.Enabled(ent.Level)
+	}
+	if enabled {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
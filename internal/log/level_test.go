@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"expvar"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// logged checks whether msg is in any of the messages logged to ts
+func logged(ts *testLogSpy, msg string) bool {
+	for _, m := range ts.Messages {
+		if strings.Contains(m, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNamedLevel(t *testing.T) {
+	ts := newTestLogSpy(t)
+	conf := &Config{Level: "info", DisableTimestamp: true}
+	logger, p, _ := InitTestLogger(ts, conf)
+	ReplaceGlobals(logger, p)
+	defer ResetNamedLevel("querycoord")
+	defer ResetNamedLevel("querycoord.scheduler")
+
+	Named("querycoord.scheduler").Debug("hidden by global level")
+	Sync()
+	assert.False(t, logged(ts, "hidden by global level"))
+
+	SetNamedLevel("querycoord", zap.DebugLevel)
+	assert.Equal(t, map[string]string{"querycoord": "debug"}, GetNamedLevels())
+	Named("querycoord.scheduler").Debug("shown by parent level")
+	Named("querynode").Debug("hidden by global level")
+	Debug("hidden by global level")
+	Sync()
+	assert.True(t, logged(ts, "shown by parent level"))
+	assert.False(t, logged(ts, "hidden by global level"))
+
+	SetNamedLevel("querycoord.scheduler", zap.WarnLevel)
+	Named("querycoord.scheduler").Info("hidden by own level")
+	Named("querycoord.task").Debug("shown by parent level again")
+	Sync()
+	assert.False(t, logged(ts, "hidden by own level"))
+	assert.True(t, logged(ts, "shown by parent level again"))
+
+	ResetNamedLevel("querycoord.scheduler")
+	ResetNamedLevel("querycoord")
+	assert.Empty(t, GetNamedLevels())
+	Named("querycoord.scheduler").Debug("hidden after reset")
+	Sync()
+	assert.False(t, logged(ts, "hidden after reset"))
+
+	// an empty name alters the global level
+	SetNamedLevel("", zap.DebugLevel)
+	assert.Equal(t, zap.DebugLevel, GetLevel())
+	Named("querynode").Debug("shown by global level")
+	Sync()
+	assert.True(t, logged(ts, "shown by global level"))
+}
+
+func TestLevelsExpvar(t *testing.T) {
+	conf := &Config{Level: "warn", DisableTimestamp: true}
+	logger, p, _ := InitLogger(conf)
+	ReplaceGlobals(logger, p)
+	SetNamedLevel("datanode", zap.DebugLevel)
+	defer ResetNamedLevel("datanode")
+
+	levels := make(map[string]string)
+	err := json.Unmarshal([]byte(expvar.Get("log_levels").String()), &levels)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"": "warn", "datanode": "debug"}, levels)
+}
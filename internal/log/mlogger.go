@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package log
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go"
+	"github.com/uber/jaeger-client-go/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// TraceIDKey is the field name of the trace id added by Ctx
+	TraceIDKey = "traceID"
+	// RoleKey is the field name of the component role added by Ctx
+	RoleKey = "role"
+
+	// mixedRole is the role of a process running several components, e.g. the standalone mode
+	mixedRole = "mixture"
+)
+
+type ctxKey int
+
+const (
+	traceIDCtxKey ctxKey = iota
+	roleCtxKey
+)
+
+var processRole = struct {
+	sync.Mutex
+	role atomic.Value
+}{}
+
+// SetRole sets the component role added to the log lines by Ctx,
+// the role becomes "mixture" if several components are running in the process.
+func SetRole(role string) {
+	if role == "" {
+		return
+	}
+	processRole.Lock()
+	defer processRole.Unlock()
+	switch old, _ := processRole.role.Load().(string); {
+	case old == "":
+		processRole.role.Store(role)
+	case old != role:
+		processRole.role.Store(mixedRole)
+	}
+}
+
+// WithTraceID returns a copy of ctx carrying traceID, for the contexts which don't carry a span,
+// e.g. the ones rebuilt from a message. The trace id of the span has a higher priority.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	if traceID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDCtxKey, traceID)
+}
+
+// WithRole returns a copy of ctx carrying the component role, overriding the one set by SetRole.
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleCtxKey, role)
+}
+
+// TraceID returns the trace id of the span in ctx, or the one set by WithTraceID.
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		if sc, ok := span.Context().(jaeger.SpanContext); ok {
+			return sc.TraceID().String()
+		}
+	}
+	traceID, _ := ctx.Value(traceIDCtxKey).(string)
+	return traceID
+}
+
+// Role returns the component role carried by ctx, or the one set by SetRole.
+func Role(ctx context.Context) string {
+	if ctx != nil {
+		if role, ok := ctx.Value(roleCtxKey).(string); ok {
+			return role
+		}
+	}
+	role, _ := processRole.role.Load().(string)
+	return role
+}
+
+// MLogger is a zap.Logger whose Named and With keep returning MLogger
+type MLogger struct {
+	*zap.Logger
+}
+
+// Ctx returns the global Logger with the trace id and the component role of ctx added,
+// so that the log lines of a request can be stitched together across the components.
+// Call it when logging instead of keeping the result, the global Logger may be replaced.
+func Ctx(ctx context.Context) *MLogger {
+	fields := make([]zap.Field, 0, 2)
+	if traceID := TraceID(ctx); traceID != "" {
+		fields = append(fields, zap.String(TraceIDKey, traceID))
+	}
+	if role := Role(ctx); role != "" {
+		fields = append(fields, zap.String(RoleKey, role))
+	}
+	return &MLogger{Logger: L().With(fields...)}
+}
+
+// Named adds a sub-scope to the logger's name, see zap.Logger.Named.
+func (l *MLogger) Named(name string) *MLogger {
+	return &MLogger{Logger: l.Logger.Named(name)}
+}
+
+// With adds structured context to the logger, see zap.Logger.With.
+func (l *MLogger) With(fields ...zap.Field) *MLogger {
+	return &MLogger{Logger: l.Logger.With(fields...)}
+}
+
+// rateGroups holds the rate limiters shared by the loggers of the same group
+var rateGroups sync.Map
+
+type rateGroup struct {
+	limiter    *utils.ReconfigurableRateLimiter
+	suppressed int64
+}
+
+// WithRateLimit returns a logger writing at most maxBalance entries in a burst and creditsPerSecond entries
+// per second afterwards, the entries beyond are dropped and counted in the "suppressed" field of the next one.
+// The budget is shared by the loggers of the same group, the rate of the first call to a group wins.
+func (l *MLogger) WithRateLimit(group string, creditsPerSecond, maxBalance float64) *MLogger {
+	g, ok := rateGroups.Load(group)
+	if !ok {
+		g, _ = rateGroups.LoadOrStore(group, &rateGroup{limiter: utils.NewRateLimiter(creditsPerSecond, maxBalance)})
+	}
+	return &MLogger{Logger: l.Logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &rateLimitedCore{Core: core, group: g.(*rateGroup)}
+	}))}
+}
+
+// rateLimitedCore drops the entries exceeding the rate of its group
+type rateLimitedCore struct {
+	zapcore.Core
+	group *rateGroup
+}
+
+func (c *rateLimitedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitedCore{Core: c.Core.With(fields), group: c.group}
+}
+
+func (c *rateLimitedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// only the entries to be written pay the credits
+	if c.Core.Check(ent, nil) == nil {
+		return ce
+	}
+	if !c.group.limiter.CheckCredit(1.0) {
+		atomic.AddInt64(&c.group.suppressed, 1)
+		return ce
+	}
+	return ce.AddCore(ent, c)
+}
+
+func (c *rateLimitedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if suppressed := atomic.SwapInt64(&c.group.suppressed, 0); suppressed > 0 {
+		fields = append(fields[:len(fields):len(fields)], zap.Int64("suppressed", suppressed))
+	}
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package log
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-client-go"
+	"go.uber.org/zap"
+)
+
+func TestCtxLogger(t *testing.T) {
+	ts := newTestLogSpy(t)
+	conf := &Config{Level: "debug", DisableTimestamp: true}
+	logger, p, _ := InitTestLogger(ts, conf)
+	ReplaceGlobals(logger, p)
+
+	ctx := context.Background()
+	assert.Equal(t, "", TraceID(ctx))
+	assert.Equal(t, "", TraceID(nil))
+	Ctx(ctx).Info("no trace id")
+
+	ctx = WithTraceID(ctx, "msg-trace")
+	assert.Equal(t, "msg-trace", TraceID(ctx))
+	Ctx(ctx).Named("querynode").With(zap.Int64("segmentID", 1)).Info("trace id by value")
+
+	tracer, closer := jaeger.NewTracer("test", jaeger.NewConstSampler(true), jaeger.NewNullReporter())
+	defer closer.Close()
+	span := tracer.StartSpan("search")
+	defer span.Finish()
+	spanCtx := opentracing.ContextWithSpan(ctx, span)
+	traceID := span.Context().(jaeger.SpanContext).TraceID().String()
+	assert.Equal(t, traceID, TraceID(spanCtx))
+	Ctx(WithRole(spanCtx, "proxy")).Info("trace id of span")
+
+	Sync()
+	assert.True(t, logged(ts, "[\"no trace id\"]"))
+	assert.False(t, logged(ts, "[\"no trace id\"] [traceID="))
+	assert.True(t, logged(ts, "[\"trace id by value\"] [traceID=msg-trace] [segmentID=1]"))
+	assert.True(t, logged(ts, "[\"trace id of span\"] [traceID="+traceID+"] [role=proxy]"))
+}
+
+func TestSetRole(t *testing.T) {
+	defer processRole.role.Store("")
+
+	processRole.role.Store("")
+	assert.Equal(t, "", Role(context.Background()))
+	SetRole("querycoord")
+	SetRole("")
+	SetRole("querycoord")
+	assert.Equal(t, "querycoord", Role(context.Background()))
+	assert.Equal(t, "proxy", Role(WithRole(context.Background(), "proxy")))
+	SetRole("querynode")
+	assert.Equal(t, mixedRole, Role(context.Background()))
+	SetRole("querycoord")
+	assert.Equal(t, mixedRole, Role(context.Background()))
+}
+
+func TestRateLimitedLogger(t *testing.T) {
+	ts := newTestLogSpy(t)
+	conf := &Config{Level: "info", DisableTimestamp: true}
+	logger, p, _ := InitTestLogger(ts, conf)
+	ReplaceGlobals(logger, p)
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		// the debug entries are disabled and don't pay the credits
+		Ctx(ctx).WithRateLimit("TestRateLimitedLogger", 0.001, 2).Debug("disabled")
+		Ctx(ctx).WithRateLimit("TestRateLimitedLogger", 0.001, 2).With(zap.Int("i", i)).Warn("hot path")
+	}
+	Ctx(ctx).Warn("not limited")
+	Sync()
+
+	var written int
+	for _, m := range ts.Messages {
+		if strings.Contains(m, "hot path") {
+			written++
+		}
+	}
+	assert.Equal(t, 2, written)
+	assert.False(t, logged(ts, "disabled"))
+	assert.True(t, logged(ts, "not limited"))
+
+	// the other groups have their own budget
+	Ctx(ctx).WithRateLimit("TestRateLimitedLogger.other", 0.001, 1).Warn("other group")
+	Sync()
+	assert.True(t, logged(ts, "other group"))
+}
+
+func TestRateLimitedLoggerSuppressed(t *testing.T) {
+	ts := newTestLogSpy(t)
+	conf := &Config{Level: "info", DisableTimestamp: true}
+	logger, p, _ := InitTestLogger(ts, conf)
+	ReplaceGlobals(logger, p)
+
+	// one credit per millisecond
+	l := Ctx(context.Background()).WithRateLimit("TestRateLimitedLoggerSuppressed", 1000, 1)
+	l.Warn("first")
+	l.Warn("dropped")
+	l.Warn("dropped")
+	for !logged(ts, "after") {
+		l.Warn("after")
+		Sync()
+	}
+	assert.False(t, logged(ts, "dropped"))
+	assert.True(t, logged(ts, "[after] [suppressed="))
+}
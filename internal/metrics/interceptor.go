@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+// milvusServicePrefix is the prefix of the methods of the MilvusService, the requests of other services aren't counted
+const milvusServicePrefix = "/milvus.proto.milvus.MilvusService/"
+
+type statusGetter interface {
+	GetStatus() *commonpb.Status
+}
+
+// errorCode returns the error code of the response, UnexpectedError if the request failed with an error
+func errorCode(resp interface{}, err error) commonpb.ErrorCode {
+	if err != nil {
+		return commonpb.ErrorCode_UnexpectedError
+	}
+	switch r := resp.(type) {
+	case *commonpb.Status:
+		return r.GetErrorCode()
+	case statusGetter:
+		return r.GetStatus().GetErrorCode()
+	}
+	return commonpb.ErrorCode_Success
+}
+
+// ProxyUnaryServerInterceptor returns an interceptor recording ProxyRequestCounter and ProxyRequestLatency for the
+// requests of the MilvusService.
+func ProxyUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, milvusServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		method := path.Base(info.FullMethod)
+		ProxyRequestLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		ProxyRequestCounter.WithLabelValues(method, errorCode(resp, err).String()).Inc()
+		return resp, err
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+)
+
+func TestProxyUnaryServerInterceptor(t *testing.T) {
+	interceptor := ProxyUnaryServerInterceptor()
+	call := func(method string, resp interface{}, err error) {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return resp, err
+		})
+	}
+	count := func(method string, code commonpb.ErrorCode) float64 {
+		return testutil.ToFloat64(ProxyRequestCounter.WithLabelValues(method, code.String()))
+	}
+
+	success := count("HasCollection", commonpb.ErrorCode_Success)
+	call(milvusServicePrefix+"HasCollection", &milvuspb.BoolResponse{Status: &commonpb.Status{}}, nil)
+	assert.Equal(t, success+1, count("HasCollection", commonpb.ErrorCode_Success))
+
+	failed := count("CreateCollection", commonpb.ErrorCode_IllegalArgument)
+	call(milvusServicePrefix+"CreateCollection", &commonpb.Status{ErrorCode: commonpb.ErrorCode_IllegalArgument}, nil)
+	assert.Equal(t, failed+1, count("CreateCollection", commonpb.ErrorCode_IllegalArgument))
+
+	unexpected := count("Search", commonpb.ErrorCode_UnexpectedError)
+	call(milvusServicePrefix+"Search", nil, errors.New("mock"))
+	assert.Equal(t, unexpected+1, count("Search", commonpb.ErrorCode_UnexpectedError))
+
+	// the requests of other services aren't counted
+	call("/milvus.proto.proxy.Proxy/GetComponentStates", nil, nil)
+	assert.Equal(t, float64(0), count("GetComponentStates", commonpb.ErrorCode_Success))
+}
@@ -1,7 +1,10 @@
 package metrics
 
 import (
+	"net"
 	"net/http"
+	"strconv"
+	"sync"
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/prometheus/client_golang/prometheus"
@@ -11,11 +14,19 @@ import (
 )
 
 const (
-	milvusNamespace    = "milvus"
-	subSystemRootCoord = "rootcoord"
-	subSystemDataCoord = "dataCoord"
-	subSystemDataNode  = "dataNode"
-	subSystemProxy     = "proxy"
+	milvusNamespace     = "milvus"
+	subSystemRootCoord  = "rootcoord"
+	subSystemDataCoord  = "dataCoord"
+	subSystemDataNode   = "dataNode"
+	subSystemIndexCoord = "indexCoord"
+	subSystemProxy      = "proxy"
+	subSystemQueryNode  = "queryNode"
+	subSystemQueryCoord = "queryCoord"
+	subSystemMsgStream  = "msgStream"
+	subSystemFlowGraph  = "flowgraph"
+	subSystemRocksMQ    = "rocksmq"
+
+	metricsRouterPath = "/metrics"
 )
 
 var (
@@ -169,6 +180,24 @@ var (
 			Help:      "Time tick of insert Channel in 24H",
 		}, []string{"vchannel"})
 
+	// RootCoordTimeTickWatermarkLag records how far the time tick sent to insert channel falls behind the wall clock
+	RootCoordTimeTickWatermarkLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemRootCoord,
+			Name:      "time_tick_watermark_lag",
+			Help:      "Lag of the time tick sent to insert channel behind the wall clock in milliseconds",
+		}, []string{"vchannel"})
+
+	// RootCoordProxyTimeTickStaleness records the time since each proxy reported its time tick last
+	RootCoordProxyTimeTickStaleness = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemRootCoord,
+			Name:      "proxy_time_tick_staleness",
+			Help:      "Time since the proxy reported its time tick last in milliseconds",
+		}, []string{"proxy_id"})
+
 	// RootCoordDDChannelTimeTick counts the time tick num of dd channel in 24H
 	RootCoordDDChannelTimeTick = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -181,27 +210,29 @@ var (
 
 //RegisterRootCoord registers RootCoord metrics
 func RegisterRootCoord() {
-	prometheus.MustRegister(RootCoordProxyLister)
+	register(RootCoordProxyLister)
 
 	// for grpc
-	prometheus.MustRegister(RootCoordCreateCollectionCounter)
-	prometheus.MustRegister(RootCoordDropCollectionCounter)
-	prometheus.MustRegister(RootCoordHasCollectionCounter)
-	prometheus.MustRegister(RootCoordDescribeCollectionCounter)
-	prometheus.MustRegister(RootCoordShowCollectionsCounter)
-	prometheus.MustRegister(RootCoordCreatePartitionCounter)
-	prometheus.MustRegister(RootCoordDropPartitionCounter)
-	prometheus.MustRegister(RootCoordHasPartitionCounter)
-	prometheus.MustRegister(RootCoordShowPartitionsCounter)
-	prometheus.MustRegister(RootCoordCreateIndexCounter)
-	prometheus.MustRegister(RootCoordDropIndexCounter)
-	prometheus.MustRegister(RootCoordDescribeIndexCounter)
-	prometheus.MustRegister(RootCoordDescribeSegmentCounter)
-	prometheus.MustRegister(RootCoordShowSegmentsCounter)
+	register(RootCoordCreateCollectionCounter)
+	register(RootCoordDropCollectionCounter)
+	register(RootCoordHasCollectionCounter)
+	register(RootCoordDescribeCollectionCounter)
+	register(RootCoordShowCollectionsCounter)
+	register(RootCoordCreatePartitionCounter)
+	register(RootCoordDropPartitionCounter)
+	register(RootCoordHasPartitionCounter)
+	register(RootCoordShowPartitionsCounter)
+	register(RootCoordCreateIndexCounter)
+	register(RootCoordDropIndexCounter)
+	register(RootCoordDescribeIndexCounter)
+	register(RootCoordDescribeSegmentCounter)
+	register(RootCoordShowSegmentsCounter)
 
 	// for time tick
-	prometheus.MustRegister(RootCoordInsertChannelTimeTick)
-	prometheus.MustRegister(RootCoordDDChannelTimeTick)
+	register(RootCoordInsertChannelTimeTick)
+	register(RootCoordTimeTickWatermarkLag)
+	register(RootCoordProxyTimeTickStaleness)
+	register(RootCoordDDChannelTimeTick)
 	//prometheus.MustRegister(PanicCounter)
 }
 
@@ -520,65 +551,152 @@ var (
 			Name:      "dml_channels_time_tick",
 			Help:      "Time tick of dml channels",
 		}, []string{"pchan"})
+
+	// ProxyMetaCacheCounter used to count the hits, misses and refreshes of the collection meta cache
+	ProxyMetaCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemProxy,
+			Name:      "meta_cache_total",
+			Help:      "Counter of meta cache hits, misses and refreshes",
+		}, []string{"meta", "type"})
+
+	// ProxyRequestCounter counts the requests of the MilvusService by method and the error code of the response
+	ProxyRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemProxy,
+			Name:      "requests_total",
+			Help:      "Counter of requests by method and error code",
+		}, []string{"method", "error_code"})
+
+	// ProxyRequestLatency records the latency in seconds of the requests of the MilvusService by method
+	ProxyRequestLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemProxy,
+			Name:      "request_latency_seconds",
+			Help:      "Latency of requests by method",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16), // 1ms ~ 32s
+		}, []string{"method"})
 )
 
 //RegisterProxy register Proxy metrics
 func RegisterProxy() {
-	prometheus.MustRegister(ProxyCreateCollectionCounter)
-	prometheus.MustRegister(ProxyDropCollectionCounter)
-	prometheus.MustRegister(ProxyHasCollectionCounter)
-	prometheus.MustRegister(ProxyLoadCollectionCounter)
-	prometheus.MustRegister(ProxyReleaseCollectionCounter)
-	prometheus.MustRegister(ProxyDescribeCollectionCounter)
-	prometheus.MustRegister(ProxyGetCollectionStatisticsCounter)
-	prometheus.MustRegister(ProxyShowCollectionsCounter)
-
-	prometheus.MustRegister(ProxyCreatePartitionCounter)
-	prometheus.MustRegister(ProxyDropPartitionCounter)
-	prometheus.MustRegister(ProxyHasPartitionCounter)
-	prometheus.MustRegister(ProxyLoadPartitionsCounter)
-	prometheus.MustRegister(ProxyReleasePartitionsCounter)
-	prometheus.MustRegister(ProxyGetPartitionStatisticsCounter)
-	prometheus.MustRegister(ProxyShowPartitionsCounter)
-
-	prometheus.MustRegister(ProxyCreateIndexCounter)
-	prometheus.MustRegister(ProxyDescribeIndexCounter)
-	prometheus.MustRegister(ProxyGetIndexStateCounter)
-	prometheus.MustRegister(ProxyGetIndexBuildProgressCounter)
-	prometheus.MustRegister(ProxyDropIndexCounter)
-
-	prometheus.MustRegister(ProxyInsertCounter)
-	prometheus.MustRegister(ProxySearchCounter)
-	prometheus.MustRegister(ProxyRetrieveCounter)
-	prometheus.MustRegister(ProxyFlushCounter)
-	prometheus.MustRegister(ProxyQueryCounter)
-
-	prometheus.MustRegister(ProxyGetPersistentSegmentInfoCounter)
-	prometheus.MustRegister(ProxyGetQuerySegmentInfoCounter)
-
-	prometheus.MustRegister(ProxyDummyCounter)
-
-	prometheus.MustRegister(ProxyRegisterLinkCounter)
-
-	prometheus.MustRegister(ProxyGetComponentStatesCounter)
-	prometheus.MustRegister(ProxyGetStatisticsChannelCounter)
-
-	prometheus.MustRegister(ProxyInvalidateCollectionMetaCacheCounter)
-	prometheus.MustRegister(ProxyGetDdChannelCounter)
-
-	prometheus.MustRegister(ProxyReleaseDQLMessageStreamCounter)
-
-	prometheus.MustRegister(ProxyDmlChannelTimeTick)
+	register(ProxyCreateCollectionCounter)
+	register(ProxyDropCollectionCounter)
+	register(ProxyHasCollectionCounter)
+	register(ProxyLoadCollectionCounter)
+	register(ProxyReleaseCollectionCounter)
+	register(ProxyDescribeCollectionCounter)
+	register(ProxyGetCollectionStatisticsCounter)
+	register(ProxyShowCollectionsCounter)
+
+	register(ProxyCreatePartitionCounter)
+	register(ProxyDropPartitionCounter)
+	register(ProxyHasPartitionCounter)
+	register(ProxyLoadPartitionsCounter)
+	register(ProxyReleasePartitionsCounter)
+	register(ProxyGetPartitionStatisticsCounter)
+	register(ProxyShowPartitionsCounter)
+
+	register(ProxyCreateIndexCounter)
+	register(ProxyDescribeIndexCounter)
+	register(ProxyGetIndexStateCounter)
+	register(ProxyGetIndexBuildProgressCounter)
+	register(ProxyDropIndexCounter)
+
+	register(ProxyInsertCounter)
+	register(ProxySearchCounter)
+	register(ProxyRetrieveCounter)
+	register(ProxyFlushCounter)
+	register(ProxyQueryCounter)
+
+	register(ProxyGetPersistentSegmentInfoCounter)
+	register(ProxyGetQuerySegmentInfoCounter)
+
+	register(ProxyDummyCounter)
+
+	register(ProxyRegisterLinkCounter)
+
+	register(ProxyGetComponentStatesCounter)
+	register(ProxyGetStatisticsChannelCounter)
+
+	register(ProxyInvalidateCollectionMetaCacheCounter)
+	register(ProxyGetDdChannelCounter)
+
+	register(ProxyReleaseDQLMessageStreamCounter)
+
+	register(ProxyDmlChannelTimeTick)
+	register(ProxyMetaCacheCounter)
+
+	register(ProxyRequestCounter)
+	register(ProxyRequestLatency)
 }
 
+var (
+	// QueryCoordIdleReleasePendingCollections marks the collections idle longer than their load.idle.release.seconds,
+	// which are released at the next check if they are still idle
+	QueryCoordIdleReleasePendingCollections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemQueryCoord,
+			Name:      "idle_release_pending_collections",
+			Help:      "Collections idle for too long, which are released at the next check if still idle",
+		}, []string{"collection_id"})
+
+	// QueryCoordIdleReleasedCollectionCounter counts the collections released for being idle
+	QueryCoordIdleReleasedCollectionCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemQueryCoord,
+			Name:      "idle_released_collections_total",
+			Help:      "Counter of collections released for being idle",
+		})
+)
+
 //RegisterQueryCoord register QueryCoord metrics
 func RegisterQueryCoord() {
-
+	register(QueryCoordIdleReleasePendingCollections)
+	register(QueryCoordIdleReleasedCollectionCounter)
 }
 
+var (
+	// QueryNodeChunkCacheCounter counts the hits, misses and evictions of the chunk cache on local disk
+	QueryNodeChunkCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemQueryNode,
+			Name:      "chunk_cache_total",
+			Help:      "Counter of chunk cache hits, misses and evictions",
+		}, []string{"type"})
+
+	// QueryNodeChunkCacheSize records the size in bytes of the chunks cached on local disk
+	QueryNodeChunkCacheSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemQueryNode,
+			Name:      "chunk_cache_size",
+			Help:      "Size of the chunks cached on local disk",
+		})
+
+	// QueryNodeIndexFallbackCounter counts the sealed segments loaded without their indexes, which are searched by brute force
+	QueryNodeIndexFallbackCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemQueryNode,
+			Name:      "index_fallback_segments_total",
+			Help:      "Counter of sealed segments loaded without their indexes and searched by brute force",
+		}, []string{"collection_id"})
+)
+
 //RegisterQueryNode register QueryNode metrics
 func RegisterQueryNode() {
-
+	register(QueryNodeChunkCacheCounter)
+	register(QueryNodeChunkCacheSize)
+	register(QueryNodeIndexFallbackCounter)
+	registerMsgStream()
+	registerFlowGraph()
 }
 
 var (
@@ -595,7 +713,7 @@ var (
 
 //RegisterDataCoord register DataCoord metrics
 func RegisterDataCoord() {
-	prometheus.MustRegister(DataCoordDataNodeList)
+	register(DataCoordDataNodeList)
 }
 
 var (
@@ -620,13 +738,85 @@ var (
 
 //RegisterDataNode register DataNode metrics
 func RegisterDataNode() {
-	prometheus.MustRegister(DataNodeFlushSegmentsCounter)
-	prometheus.MustRegister(DataNodeWatchDmChannelsCounter)
+	register(DataNodeFlushSegmentsCounter)
+	register(DataNodeWatchDmChannelsCounter)
+	registerMsgStream()
+	registerFlowGraph()
 }
 
+var (
+	// MsgStreamDeadLetterCounter counts the consumed messages which fail to unmarshal and are sent to the dead letter channels
+	MsgStreamDeadLetterCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemMsgStream,
+			Name:      "dead_letters_total",
+			Help:      "Counter of messages sent to dead letter channels",
+		}, []string{"channel"})
+
+	registerMsgStreamOnce sync.Once
+)
+
+// registerMsgStream registers the metrics of msgstream consumers once, which are shared by the roles in a process
+func registerMsgStream() {
+	registerMsgStreamOnce.Do(func() {
+		register(MsgStreamDeadLetterCounter)
+	})
+}
+
+var (
+	// FlowGraphNodeOperateLatency records the latency in seconds of the flowgraph nodes operating on their inputs
+	FlowGraphNodeOperateLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemFlowGraph,
+			Name:      "node_operate_latency_seconds",
+			Help:      "Latency of flowgraph nodes operating on their inputs",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 18), // 0.1ms ~ 13s
+		}, []string{"graph", "node"})
+
+	// FlowGraphNodeInputQueueLength records the num of messages waiting in the input channels of the flowgraph nodes
+	FlowGraphNodeInputQueueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemFlowGraph,
+			Name:      "node_input_queue_length",
+			Help:      "Length of the input queues of flowgraph nodes",
+		}, []string{"graph", "node"})
+
+	// FlowGraphNodeStallCounter counts the flowgraph nodes found stalled, which haven't consumed their non-empty inputs
+	// for longer than the stall threshold of the flowgraph
+	FlowGraphNodeStallCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemFlowGraph,
+			Name:      "node_stalls_total",
+			Help:      "Counter of flowgraph node stalls",
+		}, []string{"graph", "node"})
+)
+
+// registerFlowGraph registers the metrics of the profiled flowgraphs, which are shared by the roles in a process
+func registerFlowGraph() {
+	register(FlowGraphNodeOperateLatency)
+	register(FlowGraphNodeInputQueueLength)
+	register(FlowGraphNodeStallCounter)
+}
+
+var (
+	// IndexCoordRecycledIndexFilesCounter counts the index files removed from object storage by the recycler,
+	// the type is "deleted" for the files of dropped indexes and "low_version" for the files of outdated builds
+	IndexCoordRecycledIndexFilesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemIndexCoord,
+			Name:      "recycled_index_files_total",
+			Help:      "Counter of recycled index files",
+		}, []string{"type"})
+)
+
 //RegisterIndexCoord register IndexCoord metrics
 func RegisterIndexCoord() {
-
+	register(IndexCoordRecycledIndexFilesCounter)
 }
 
 //RegisterIndexNode register IndexNode metrics
@@ -634,17 +824,86 @@ func RegisterIndexNode() {
 
 }
 
+var (
+	// RocksMQConsumerLag records the id of the latest message of a topic minus the smallest offset of its active consumer groups
+	RocksMQConsumerLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemRocksMQ,
+			Name:      "consumer_lag",
+			Help:      "Lag of the slowest active consumer group of topics",
+		}, []string{"topic"})
+
+	// RocksMQExpiredConsumerCounter counts the consumer groups expired by the janitor, whose owner sessions are gone
+	RocksMQExpiredConsumerCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: subSystemRocksMQ,
+			Name:      "expired_consumers_total",
+			Help:      "Counter of consumer groups expired as their owners are gone",
+		}, []string{"topic"})
+)
+
+// RegisterRocksMQ registers the metrics of the embedded rocksmq
+func RegisterRocksMQ() {
+	register(RocksMQConsumerLag)
+	register(RocksMQExpiredConsumerCounter)
+}
+
 //RegisterMsgStreamCoord register MsgStreamCoord metrics
 func RegisterMsgStreamCoord() {
 
 }
 
-//ServeHTTP serve prometheus http service
-func ServeHTTP() {
-	http.Handle("/metrics", promhttp.Handler())
+// register registers the collectors to the default prometheus registry. The collectors already registered are
+// skipped, the roles running in one process as in standalone mode may register the shared collectors more than once.
+func register(collectors ...prometheus.Collector) {
+	for _, c := range collectors {
+		if err := prometheus.Register(c); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				panic(err)
+			}
+		}
+	}
+}
+
+// registerProcess registers the collectors of the go runtime and the process
+func registerProcess() {
+	register(prometheus.NewGoCollector())
+	register(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+}
+
+var (
+	serveMu      sync.Mutex
+	servingPorts = make(map[int]struct{})
+	handleOnce   sync.Once
+)
+
+// ServeHTTP serves the prometheus metrics at /metrics on the port, together with the other handlers of the default
+// http mux such as /healthz. A port already served by the process is skipped, and 0 means not serving.
+func ServeHTTP(port int) {
+	if port <= 0 {
+		return
+	}
+	serveMu.Lock()
+	defer serveMu.Unlock()
+	if _, ok := servingPorts[port]; ok {
+		return
+	}
+
+	registerProcess()
+	handleOnce.Do(func() {
+		http.Handle(metricsRouterPath, promhttp.Handler())
+	})
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		log.Error("handle metrics failed", zap.Int("port", port), zap.Error(err))
+		return
+	}
+	servingPorts[port] = struct{}{}
 	go func() {
-		if err := http.ListenAndServe(":9091", nil); err != nil {
-			log.Error("handle metrics failed", zap.Error(err))
+		if err := http.Serve(lis, nil); err != nil {
+			log.Error("handle metrics failed", zap.Int("port", port), zap.Error(err))
 		}
 	}()
 }
@@ -1,7 +1,14 @@
 package metrics
 
 import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
 	"testing"
+
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRegisterMetrics(t *testing.T) {
@@ -15,4 +22,46 @@ func TestRegisterMetrics(t *testing.T) {
 	RegisterQueryNode()
 	RegisterQueryCoord()
 	RegisterMsgStreamCoord()
+	RegisterRocksMQ()
+
+	// all the roles register again in standalone mode
+	assert.NotPanics(t, func() {
+		RegisterRootCoord()
+		RegisterProxy()
+		RegisterQueryNode()
+		RegisterDataNode()
+	})
+}
+
+func TestServeHTTP(t *testing.T) {
+	RegisterProxy()
+	RegisterQueryNode()
+	ProxyRequestCounter.WithLabelValues("Search", "Success").Inc()
+	ProxyRequestLatency.WithLabelValues("Search").Observe(0.01)
+	QueryNodeChunkCacheSize.Set(0)
+
+	port := funcutil.GetAvailablePort()
+	ServeHTTP(port)
+	// serving the same port again is skipped
+	ServeHTTP(port)
+	// 0 means not serving
+	ServeHTTP(0)
+
+	resp, err := http.Get("http://localhost:" + strconv.Itoa(port) + metricsRouterPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	for _, family := range []string{
+		"go_goroutines",
+		"process_cpu_seconds_total",
+		"milvus_proxy_requests_total",
+		"milvus_proxy_request_latency_seconds",
+		"milvus_queryNode_chunk_cache_size",
+	} {
+		assert.Contains(t, string(body), "# TYPE "+family+" ", family)
+	}
+	assert.Contains(t, string(body), `milvus_proxy_requests_total{error_code="Success",method="Search"}`)
 }
@@ -0,0 +1,405 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package msgstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+
+	"github.com/milvus-io/milvus/internal/util/mqclient"
+)
+
+// CompressionType is the codec to compress the payloads of batched messages
+type CompressionType string
+
+const (
+	// CompressionNone sends the batched payloads as they are
+	CompressionNone CompressionType = "none"
+	// CompressionLZ4 compresses the batched payloads with lz4, fast but with lower ratio
+	CompressionLZ4 CompressionType = "lz4"
+	// CompressionZstd compresses the batched payloads with zstd, slower but with higher ratio
+	CompressionZstd CompressionType = "zstd"
+)
+
+const (
+	// batchPropertyKey is the message property holding the version of batch format
+	batchPropertyKey = "msgstream.batch"
+	// compressionPropertyKey is the message property holding the compression codec of a batched message
+	compressionPropertyKey = "msgstream.compression"
+	batchVersion           = "1"
+
+	// batchMarker is the first byte of batched payloads. Field number 0 is invalid in protobuf,
+	// so the consumers unaware of batching fail to unmarshal the message header instead of mis-parsing it.
+	batchMarker byte = 0
+)
+
+var (
+	// the encoder and decoder are safe for concurrent EncodeAll and DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// ParseCompressionType parses the name of compression codec, empty name means CompressionNone
+func ParseCompressionType(name string) (CompressionType, error) {
+	switch CompressionType(name) {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionLZ4, CompressionZstd:
+		return CompressionType(name), nil
+	default:
+		return CompressionNone, fmt.Errorf("unknown compression type %s", name)
+	}
+}
+
+func compress(codec CompressionType, data []byte) ([]byte, error) {
+	switch codec {
+	case "", CompressionNone:
+		return data, nil
+	case CompressionLZ4:
+		var buf bytes.Buffer
+		w := lz4.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data))), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type %s", codec)
+	}
+}
+
+func decompress(codec CompressionType, data []byte) ([]byte, error) {
+	switch codec {
+	case "", CompressionNone:
+		return data, nil
+	case CompressionLZ4:
+		return ioutil.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+	case CompressionZstd:
+		return zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unknown compression type %s", codec)
+	}
+}
+
+// ProducerBatchConfig is the config of producer side batching, the TsMsgs sent to a channel are packed
+// into one message of mq till MaxMessages or MaxBytes is reached, or MaxDelay elapsed since the first one
+type ProducerBatchConfig struct {
+	// MaxMessages is the max number of TsMsgs in a batch, batching is disabled if it's not greater than 1
+	MaxMessages int
+	// MaxBytes is the max size of marshaled TsMsgs in a batch, 0 means no limit
+	MaxBytes int
+	// MaxDelay is the max time a TsMsg waits for the following ones, 0 means the TsMsgs are batched
+	// only within a call of Produce or Broadcast
+	MaxDelay time.Duration
+	// Compression is the codec to compress batches
+	Compression CompressionType
+}
+
+// Enabled returns whether the messages are batched
+func (c ProducerBatchConfig) Enabled() bool {
+	return c.MaxMessages > 1 || (c.Compression != "" && c.Compression != CompressionNone)
+}
+
+type batchEntry struct {
+	payload    []byte
+	properties map[string]string
+}
+
+// producerBatch is a batch of TsMsgs waiting to be sent, done is closed after it's sent
+type producerBatch struct {
+	producer *batchProducer
+	entries  []batchEntry
+	size     int
+	timer    *time.Timer
+	sent     bool
+	done     chan struct{}
+	id       MessageID
+	err      error
+}
+
+// wait blocks till the batch is sent and returns the message id of it
+func (b *producerBatch) wait() (MessageID, error) {
+	<-b.done
+	return b.id, b.err
+}
+
+// flush sends the batch if it's not sent yet and waits for it, with MaxDelay the batch is left
+// to be sent by its timer so that the messages of other callers have chance to join it
+func (b *producerBatch) flush() (MessageID, error) {
+	if b.producer.config.MaxDelay <= 0 {
+		b.producer.send(b)
+	}
+	return b.wait()
+}
+
+// batchProducer packs the messages sent to a producer into batches
+type batchProducer struct {
+	producer mqclient.Producer
+	config   ProducerBatchConfig
+
+	// mu serializes the sending of batches, so that they are sent in order
+	mu      sync.Mutex
+	pending *producerBatch
+}
+
+func newBatchProducer(producer mqclient.Producer, config ProducerBatchConfig) *batchProducer {
+	return &batchProducer{
+		producer: producer,
+		config:   config,
+	}
+}
+
+// add appends the message to the pending batch, the pending batch is sent if it's full or seal is true.
+// The returned batch should be flushed before the message is regarded as sent.
+func (bp *batchProducer) add(msg *mqclient.ProducerMessage, seal bool) *producerBatch {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	b := bp.pending
+	if b == nil {
+		b = &producerBatch{producer: bp, done: make(chan struct{})}
+		bp.pending = b
+		if bp.config.MaxDelay > 0 {
+			b.timer = time.AfterFunc(bp.config.MaxDelay, func() {
+				bp.send(b)
+			})
+		}
+	}
+	b.entries = append(b.entries, batchEntry{payload: msg.Payload, properties: msg.Properties})
+	b.size += len(msg.Payload)
+
+	if seal || len(b.entries) >= bp.config.MaxMessages || (bp.config.MaxBytes > 0 && b.size >= bp.config.MaxBytes) {
+		bp.sendLocked(b)
+	}
+	return b
+}
+
+func (bp *batchProducer) send(b *producerBatch) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	bp.sendLocked(b)
+}
+
+// flushAll sends the pending batch immediately
+func (bp *batchProducer) flushAll() {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.pending != nil {
+		bp.sendLocked(bp.pending)
+	}
+}
+
+func (bp *batchProducer) sendLocked(b *producerBatch) {
+	if b.sent {
+		return
+	}
+	b.sent = true
+	if bp.pending == b {
+		bp.pending = nil
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	defer close(b.done)
+
+	msg, err := encodeBatch(b.entries, bp.config.Compression)
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.id, b.err = bp.producer.Send(context.Background(), msg)
+}
+
+// Close sends the pending batch and closes the producer
+func (bp *batchProducer) Close() {
+	bp.flushAll()
+	bp.producer.Close()
+}
+
+func appendUvarintBytes(buf []byte, data []byte) []byte {
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(data)))
+	buf = append(buf, lenBuf[:n]...)
+	return append(buf, data...)
+}
+
+func readUvarintBytes(buf []byte) ([]byte, []byte, error) {
+	l, n := binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf)-n) < l {
+		return nil, nil, errors.New("batched message is corrupted")
+	}
+	return buf[n : n+int(l)], buf[n+int(l):], nil
+}
+
+// encodeBatch encodes the entries into a message, the payload is batchMarker followed by the compressed
+// entries, every entry is its properties and payload, all of them are prefixed with uvarint lengths
+func encodeBatch(entries []batchEntry, codec CompressionType) (*mqclient.ProducerMessage, error) {
+	size := binary.MaxVarintLen64
+	for _, e := range entries {
+		size += len(e.payload) + binary.MaxVarintLen64*(2*len(e.properties)+2)
+		for k, v := range e.properties {
+			size += len(k) + len(v)
+		}
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	data := make([]byte, 0, size)
+	data = append(data, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(entries)))]...)
+	for _, e := range entries {
+		data = append(data, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(e.properties)))]...)
+		for k, v := range e.properties {
+			data = appendUvarintBytes(data, []byte(k))
+			data = appendUvarintBytes(data, []byte(v))
+		}
+		data = appendUvarintBytes(data, e.payload)
+	}
+
+	compressed, err := compress(codec, data)
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, 0, len(compressed)+1)
+	payload = append(payload, batchMarker)
+	payload = append(payload, compressed...)
+
+	if codec == "" {
+		codec = CompressionNone
+	}
+	return &mqclient.ProducerMessage{
+		Payload: payload,
+		Properties: map[string]string{
+			batchPropertyKey:       batchVersion,
+			compressionPropertyKey: string(codec),
+		},
+	}, nil
+}
+
+// batchedConsumerMessage is a TsMsg unpacked from a batched message, it shares the topic and id with the batch
+type batchedConsumerMessage struct {
+	mqclient.ConsumerMessage
+	payload    []byte
+	properties map[string]string
+}
+
+func (m *batchedConsumerMessage) Payload() []byte {
+	return m.payload
+}
+
+func (m *batchedConsumerMessage) Properties() map[string]string {
+	return m.properties
+}
+
+// unpackConsumerMsg returns the messages packed in msg, or msg itself if it's not batched.
+// The batches of unknown format versions or compression codecs are rejected.
+func unpackConsumerMsg(msg mqclient.ConsumerMessage) ([]mqclient.ConsumerMessage, error) {
+	properties := msg.Properties()
+	version, ok := properties[batchPropertyKey]
+	if !ok {
+		return []mqclient.ConsumerMessage{msg}, nil
+	}
+	if version != batchVersion {
+		return nil, fmt.Errorf("unsupported batch version %s", version)
+	}
+	codec, err := ParseCompressionType(properties[compressionPropertyKey])
+	if err != nil {
+		return nil, err
+	}
+	payload := msg.Payload()
+	if len(payload) == 0 || payload[0] != batchMarker {
+		return nil, errors.New("batched message is corrupted")
+	}
+	data, err := decompress(codec, payload[1:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress batched message, err %s", err.Error())
+	}
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("batched message is corrupted")
+	}
+	data = data[n:]
+	// every entry takes 2 bytes at least
+	if count > uint64(len(data)/2) {
+		return nil, errors.New("batched message is corrupted")
+	}
+	msgs := make([]mqclient.ConsumerMessage, 0, count)
+	for i := uint64(0); i < count; i++ {
+		propNum, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errors.New("batched message is corrupted")
+		}
+		data = data[n:]
+		props := make(map[string]string, propNum)
+		for j := uint64(0); j < propNum; j++ {
+			var k, v []byte
+			if k, data, err = readUvarintBytes(data); err != nil {
+				return nil, err
+			}
+			if v, data, err = readUvarintBytes(data); err != nil {
+				return nil, err
+			}
+			props[string(k)] = string(v)
+		}
+		var p []byte
+		if p, data, err = readUvarintBytes(data); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, &batchedConsumerMessage{ConsumerMessage: msg, payload: p, properties: props})
+	}
+	return msgs, nil
+}
+
+// flushBatches flushes the batches and returns the first error
+func flushBatches(batches []*producerBatch) error {
+	var firstErr error
+	for _, b := range batches {
+		if _, err := b.flush(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// batchedMsgID is the id of a message in the batch, to be filled at ids[channel][index] after the batch is sent
+type batchedMsgID struct {
+	channel string
+	index   int
+	batch   *producerBatch
+}
+
+// fillBatchedMsgIDs flushes the batches and fills the ids of the batched messages with the ids of batches
+func fillBatchedMsgIDs(ids map[string][]MessageID, batched []batchedMsgID) error {
+	var firstErr error
+	for _, b := range batched {
+		id, err := b.batch.flush()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		ids[b.channel][b.index] = id
+	}
+	return firstErr
+}
@@ -0,0 +1,424 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package msgstream
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/mqclient"
+	client "github.com/milvus-io/milvus/internal/util/rocksmq/client/rocksmq"
+	"github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
+)
+
+type mockMessageID struct {
+	id int64
+}
+
+func (id *mockMessageID) Serialize() []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(id.id))
+	return b
+}
+
+func (id *mockMessageID) LedgerID() int64     { return 0 }
+func (id *mockMessageID) EntryID() int64      { return id.id }
+func (id *mockMessageID) BatchIdx() int32     { return 0 }
+func (id *mockMessageID) PartitionIdx() int32 { return 0 }
+
+var _ mqclient.Producer = (*mockRecordProducer)(nil)
+
+// mockRecordProducer records the messages sent to it
+type mockRecordProducer struct {
+	mu     sync.Mutex
+	msgs   []*mqclient.ProducerMessage
+	err    error
+	closed bool
+}
+
+func (p *mockRecordProducer) Send(_ context.Context, msg *mqclient.ProducerMessage) (MessageID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.msgs = append(p.msgs, msg)
+	return &mockMessageID{id: int64(len(p.msgs))}, nil
+}
+
+func (p *mockRecordProducer) Close() {
+	p.closed = true
+}
+
+func (p *mockRecordProducer) sent() []*mqclient.ProducerMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.msgs
+}
+
+var _ mqclient.ConsumerMessage = (*mockConsumerMessage)(nil)
+
+type mockConsumerMessage struct {
+	msg *mqclient.ProducerMessage
+	id  MessageID
+}
+
+func (m *mockConsumerMessage) Topic() string                 { return "mock_channel" }
+func (m *mockConsumerMessage) Properties() map[string]string { return m.msg.Properties }
+func (m *mockConsumerMessage) Payload() []byte               { return m.msg.Payload }
+func (m *mockConsumerMessage) ID() MessageID                 { return m.id }
+
+func TestCompression(t *testing.T) {
+	data := []byte("milvus milvus milvus milvus milvus milvus milvus milvus")
+	for _, name := range []string{"", "none", "lz4", "zstd"} {
+		codec, err := ParseCompressionType(name)
+		assert.Nil(t, err)
+		compressed, err := compress(codec, data)
+		assert.Nil(t, err)
+		decompressed, err := decompress(codec, compressed)
+		assert.Nil(t, err)
+		assert.Equal(t, data, decompressed)
+	}
+
+	_, err := ParseCompressionType("snappy")
+	assert.Error(t, err)
+	_, err = compress("snappy", data)
+	assert.Error(t, err)
+	_, err = decompress("snappy", data)
+	assert.Error(t, err)
+	_, err = decompress(CompressionZstd, data)
+	assert.Error(t, err)
+}
+
+func TestEncodeBatch(t *testing.T) {
+	entries := []batchEntry{
+		{payload: []byte("msg0"), properties: map[string]string{"k0": "v0", "k1": "v1"}},
+		{payload: []byte{}, properties: map[string]string{}},
+		{payload: []byte("msg2")},
+	}
+	for _, codec := range []CompressionType{"", CompressionNone, CompressionLZ4, CompressionZstd} {
+		msg, err := encodeBatch(entries, codec)
+		assert.Nil(t, err)
+		assert.Equal(t, batchVersion, msg.Properties[batchPropertyKey])
+
+		id := &mockMessageID{id: 10}
+		msgs, err := unpackConsumerMsg(&mockConsumerMessage{msg: msg, id: id})
+		assert.Nil(t, err)
+		assert.Equal(t, len(entries), len(msgs))
+		for i, m := range msgs {
+			assert.Equal(t, entries[i].payload, m.Payload())
+			assert.Equal(t, len(entries[i].properties), len(m.Properties()))
+			for k, v := range entries[i].properties {
+				assert.Equal(t, v, m.Properties()[k])
+			}
+			assert.Equal(t, "mock_channel", m.Topic())
+			assert.Equal(t, id, m.ID())
+		}
+
+		// the consumers unaware of batching fail to parse the header
+		header := commonpb.MsgHeader{}
+		assert.Error(t, proto.Unmarshal(msg.Payload, &header))
+	}
+
+	_, err := encodeBatch(entries, "snappy")
+	assert.Error(t, err)
+}
+
+func TestUnpackConsumerMsg(t *testing.T) {
+	// messages not batched are returned as they are
+	plain := &mockConsumerMessage{msg: &mqclient.ProducerMessage{Payload: []byte("plain"), Properties: map[string]string{}}}
+	msgs, err := unpackConsumerMsg(plain)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(msgs))
+	assert.Equal(t, plain, msgs[0])
+
+	msg, err := encodeBatch([]batchEntry{{payload: []byte("msg0")}}, CompressionLZ4)
+	assert.Nil(t, err)
+
+	unpackWith := func(properties map[string]string, payload []byte) error {
+		_, err := unpackConsumerMsg(&mockConsumerMessage{msg: &mqclient.ProducerMessage{Payload: payload, Properties: properties}})
+		return err
+	}
+	assert.Error(t, unpackWith(map[string]string{batchPropertyKey: "2", compressionPropertyKey: "lz4"}, msg.Payload))
+	assert.Error(t, unpackWith(map[string]string{batchPropertyKey: batchVersion, compressionPropertyKey: "snappy"}, msg.Payload))
+	assert.Error(t, unpackWith(map[string]string{batchPropertyKey: batchVersion, compressionPropertyKey: "zstd"}, msg.Payload))
+	assert.Error(t, unpackWith(msg.Properties, []byte{}))
+	assert.Error(t, unpackWith(msg.Properties, msg.Payload[1:]))
+
+	// truncated entries
+	msg, err = encodeBatch([]batchEntry{{payload: []byte("msg0"), properties: map[string]string{"k": "v"}}}, CompressionNone)
+	assert.Nil(t, err)
+	for i := 1; i < len(msg.Payload); i++ {
+		assert.Error(t, unpackWith(msg.Properties, msg.Payload[:i]), fmt.Sprintf("truncated at %d", i))
+	}
+	// too many entries
+	assert.Error(t, unpackWith(msg.Properties, []byte{batchMarker, 100, 0, 0}))
+}
+
+func TestBatchProducer(t *testing.T) {
+	newMsg := func(payload string) *mqclient.ProducerMessage {
+		return &mqclient.ProducerMessage{Payload: []byte(payload), Properties: map[string]string{}}
+	}
+
+	t.Run("MaxMessages", func(t *testing.T) {
+		p := &mockRecordProducer{}
+		bp := newBatchProducer(p, ProducerBatchConfig{MaxMessages: 2})
+		b0 := bp.add(newMsg("msg0"), false)
+		assert.Equal(t, 0, len(p.sent()))
+		b1 := bp.add(newMsg("msg1"), false)
+		assert.Equal(t, b0, b1)
+		assert.Equal(t, 1, len(p.sent()))
+		b2 := bp.add(newMsg("msg2"), false)
+		assert.NotEqual(t, b1, b2)
+
+		id, err := b1.flush()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), id.EntryID())
+		id, err = b2.flush()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), id.EntryID())
+		assert.Equal(t, 2, len(p.sent()))
+	})
+
+	t.Run("MaxBytes", func(t *testing.T) {
+		p := &mockRecordProducer{}
+		bp := newBatchProducer(p, ProducerBatchConfig{MaxMessages: 100, MaxBytes: 8})
+		bp.add(newMsg("msg0"), false)
+		assert.Equal(t, 0, len(p.sent()))
+		bp.add(newMsg("msg1"), false)
+		assert.Equal(t, 1, len(p.sent()))
+	})
+
+	t.Run("Seal", func(t *testing.T) {
+		p := &mockRecordProducer{}
+		bp := newBatchProducer(p, ProducerBatchConfig{MaxMessages: 100})
+		bp.add(newMsg("msg0"), false)
+		bp.add(newMsg("tt"), true)
+		assert.Equal(t, 1, len(p.sent()))
+		msgs, err := unpackConsumerMsg(&mockConsumerMessage{msg: p.sent()[0]})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(msgs))
+		assert.Equal(t, []byte("tt"), msgs[1].Payload())
+	})
+
+	t.Run("MaxDelay", func(t *testing.T) {
+		p := &mockRecordProducer{}
+		bp := newBatchProducer(p, ProducerBatchConfig{MaxMessages: 100, MaxDelay: 50 * time.Millisecond})
+		b := bp.add(newMsg("msg0"), false)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// joins the batch of msg0 before it's sent
+			assert.Equal(t, b, bp.add(newMsg("msg1"), false))
+		}()
+		wg.Wait()
+
+		start := time.Now()
+		_, err := b.flush()
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) > 20*time.Millisecond)
+		assert.Equal(t, 1, len(p.sent()))
+		msgs, err := unpackConsumerMsg(&mockConsumerMessage{msg: p.sent()[0]})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(msgs))
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		p := &mockRecordProducer{}
+		bp := newBatchProducer(p, ProducerBatchConfig{MaxMessages: 100, MaxDelay: time.Hour})
+		b := bp.add(newMsg("msg0"), false)
+		bp.Close()
+		_, err := b.wait()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(p.sent()))
+		assert.True(t, p.closed)
+	})
+
+	t.Run("SendFailed", func(t *testing.T) {
+		p := &mockRecordProducer{err: errors.New("mocked error")}
+		bp := newBatchProducer(p, ProducerBatchConfig{MaxMessages: 100})
+		b := bp.add(newMsg("msg0"), false)
+		_, err := b.flush()
+		assert.Error(t, err)
+		assert.Error(t, flushBatches([]*producerBatch{b}))
+	})
+}
+
+func newBatchTestMsgStream(t *testing.T, config ProducerBatchConfig, channels []string) (*mqMsgStream, map[string]*mockRecordProducer) {
+	factory := ProtoUDFactory{}
+	stream, err := NewMqMsgStream(context.Background(), 100, 100, nil, factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	stream.SetProducerBatchConfig(config)
+	producers := make(map[string]*mockRecordProducer)
+	for _, channel := range channels {
+		p := &mockRecordProducer{}
+		producers[channel] = p
+		stream.producers[channel] = p
+		stream.producerChannels = append(stream.producerChannels, channel)
+		stream.batchProducers[channel] = newBatchProducer(p, config)
+	}
+	return stream, producers
+}
+
+func TestMqMsgStream_ProduceBatch(t *testing.T) {
+	channels := []string{"batch_0", "batch_1"}
+	stream, producers := newBatchTestMsgStream(t, ProducerBatchConfig{MaxMessages: 100, Compression: CompressionZstd}, channels)
+
+	msgPack := MsgPack{}
+	for i := 0; i < 10; i++ {
+		msgPack.Msgs = append(msgPack.Msgs, getTsMsg(commonpb.MsgType_Insert, int64(i)))
+	}
+	err := stream.Produce(&msgPack)
+	assert.Nil(t, err)
+	err = stream.Broadcast(&MsgPack{Msgs: []TsMsg{getTimeTickMsg(10)}})
+	assert.Nil(t, err)
+
+	for i, channel := range channels {
+		sent := producers[channel].sent()
+		// the inserts and the time tick are sent in separate calls without MaxDelay
+		assert.Equal(t, 2, len(sent))
+		msgs, err := unpackConsumerMsg(&mockConsumerMessage{msg: sent[0], id: &mockMessageID{id: 1}})
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(msgs))
+		for j, m := range msgs {
+			tsMsg, err := stream.getTsMsgFromConsumerMsg(m)
+			assert.Nil(t, err)
+			assert.Equal(t, commonpb.MsgType_Insert, tsMsg.Type())
+			assert.Equal(t, int64(2*j+i), tsMsg.ID())
+		}
+		msgs, err = unpackConsumerMsg(&mockConsumerMessage{msg: sent[1], id: &mockMessageID{id: 2}})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(msgs))
+		tsMsg, err := stream.getTsMsgFromConsumerMsg(msgs[0])
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.MsgType_TimeTick, tsMsg.Type())
+	}
+
+	ids, err := stream.ProduceMark(&msgPack)
+	assert.Nil(t, err)
+	for _, channel := range channels {
+		assert.Equal(t, 5, len(ids[channel]))
+		for _, id := range ids[channel] {
+			assert.Equal(t, int64(3), id.EntryID())
+		}
+	}
+	ids, err = stream.BroadcastMark(&MsgPack{Msgs: []TsMsg{getTimeTickMsg(20)}})
+	assert.Nil(t, err)
+	for _, channel := range channels {
+		assert.Equal(t, 1, len(ids[channel]))
+		assert.Equal(t, int64(4), ids[channel][0].EntryID())
+	}
+
+	stream.Close()
+	for _, channel := range channels {
+		assert.True(t, producers[channel].closed)
+	}
+}
+
+func TestMqMsgStream_ProduceBatchFailed(t *testing.T) {
+	channels := []string{"batch_0"}
+	stream, producers := newBatchTestMsgStream(t, ProducerBatchConfig{MaxMessages: 100}, channels)
+	producers["batch_0"].err = errors.New("mocked error")
+
+	msgPack := &MsgPack{Msgs: []TsMsg{getTsMsg(commonpb.MsgType_Insert, 1)}}
+	assert.Error(t, stream.Produce(msgPack))
+	assert.Error(t, stream.Broadcast(msgPack))
+	_, err := stream.ProduceMark(msgPack)
+	assert.Error(t, err)
+	_, err = stream.BroadcastMark(msgPack)
+	assert.Error(t, err)
+}
+
+func TestStream_RmqTtMsgStream_ProduceBatch(t *testing.T) {
+	channels := []string{"batch_insert_tt"}
+	rocksdbName := "/tmp/rocksmq_batch_tt"
+	etcdKV := initRmq(rocksdbName)
+	factory := ProtoUDFactory{}
+
+	rmqClient, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+	inputStream, _ := NewMqMsgStream(context.Background(), 100, 100, rmqClient, factory.NewUnmarshalDispatcher())
+	inputStream.SetProducerBatchConfig(ProducerBatchConfig{MaxMessages: 100, Compression: CompressionLZ4})
+	inputStream.AsProducer(channels)
+	inputStream.Start()
+
+	rmqClient2, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+	outputStream, _ := NewMqTtMsgStream(context.Background(), 100, 100, rmqClient2, factory.NewUnmarshalDispatcher())
+	outputStream.AsConsumer(channels, "batch_tt_group")
+	outputStream.Start()
+
+	err := inputStream.Produce(getInsertMsgPack([]int{1, 2, 3}))
+	assert.Nil(t, err)
+	err = inputStream.Broadcast(getTimeTickMsgPack(5))
+	assert.Nil(t, err)
+
+	result := outputStream.Consume()
+	assert.Equal(t, 3, len(result.Msgs))
+	for i, msg := range result.Msgs {
+		assert.Equal(t, int64(i+1), msg.ID())
+	}
+
+	Close(rocksdbName, inputStream, outputStream, etcdKV)
+}
+
+// BenchmarkProduce_Insert compares the publish throughput of inserts with and without batching
+func BenchmarkProduce_Insert(b *testing.B) {
+	rocksdbName := "/tmp/rocksmq_batch_bench"
+	etcdKV := initRmq(rocksdbName)
+	defer func() {
+		rocksmq.CloseRocksMQ()
+		etcdKV.Close()
+		_ = os.RemoveAll(rocksdbName)
+		_ = os.RemoveAll(rocksdbName + "_meta_kv")
+	}()
+
+	configs := []struct {
+		name   string
+		config ProducerBatchConfig
+	}{
+		{"NoBatch", ProducerBatchConfig{}},
+		{"Batch", ProducerBatchConfig{MaxMessages: 100}},
+		{"BatchLZ4", ProducerBatchConfig{MaxMessages: 100, Compression: CompressionLZ4}},
+		{"BatchZstd", ProducerBatchConfig{MaxMessages: 100, Compression: CompressionZstd}},
+	}
+	msgPack := getRandInsertMsgPack(100, 0, 10000)
+	factory := ProtoUDFactory{}
+	for _, c := range configs {
+		b.Run(c.name, func(b *testing.B) {
+			rmqClient, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+			stream, _ := NewMqMsgStream(context.Background(), 100, 100, rmqClient, factory.NewUnmarshalDispatcher())
+			stream.SetProducerBatchConfig(c.config)
+			stream.AsProducer([]string{"batch_bench_" + c.name})
+			defer stream.Close()
+
+			b.ResetTimer()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				if err := stream.Produce(msgPack); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.N*len(msgPack.Msgs))/time.Since(start).Seconds(), "msgs/s")
+		})
+	}
+}
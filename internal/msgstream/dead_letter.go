@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package msgstream
+
+import (
+	"context"
+	"encoding/base64"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/util/mqclient"
+)
+
+// The properties of dead letters, which record why and where the messages can't be parsed
+const (
+	deadLetterErrorKey        = "msgstream.deadLetter.error"
+	deadLetterChannelKey      = "msgstream.deadLetter.channel"
+	deadLetterMsgIDKey        = "msgstream.deadLetter.msgID"
+	deadLetterSubscriptionKey = "msgstream.deadLetter.subscription"
+	deadLetterAttemptsKey     = "msgstream.deadLetter.attempts"
+)
+
+// DeadLetterPolicy is the policy of the consumed messages which can't be parsed. The messages failing to unmarshal
+// MaxUnmarshalAttempts times are copied to the dead letter channel, whose name is ChannelPrefix followed by the
+// channel name, and skipped. It's disabled if MaxUnmarshalAttempts is 0, and the messages are dropped with logs.
+type DeadLetterPolicy struct {
+	MaxUnmarshalAttempts int
+	ChannelPrefix        string
+}
+
+// Enabled returns whether the messages failing to unmarshal are sent to the dead letter channels
+func (p DeadLetterPolicy) Enabled() bool {
+	return p.MaxUnmarshalAttempts > 0
+}
+
+// deadLetterProducer sends the messages which can't be parsed to the dead letter channels
+type deadLetterProducer struct {
+	policy    DeadLetterPolicy
+	client    mqclient.Client
+	mu        sync.Mutex
+	producers map[string]mqclient.Producer
+}
+
+func newDeadLetterProducer(client mqclient.Client, policy DeadLetterPolicy) *deadLetterProducer {
+	return &deadLetterProducer{
+		policy:    policy,
+		client:    client,
+		producers: make(map[string]mqclient.Producer),
+	}
+}
+
+// send copies the raw payload of msg to the dead letter channel of its channel, along with the error and position
+func (dp *deadLetterProducer) send(ctx context.Context, msg mqclient.ConsumerMessage, subName string, cause error) error {
+	channel := filepath.Base(msg.Topic())
+	properties := make(map[string]string, len(msg.Properties())+5)
+	for k, v := range msg.Properties() {
+		properties[k] = v
+	}
+	msgID := base64.StdEncoding.EncodeToString(msg.ID().Serialize())
+	properties[deadLetterErrorKey] = cause.Error()
+	properties[deadLetterChannelKey] = channel
+	properties[deadLetterMsgIDKey] = msgID
+	properties[deadLetterSubscriptionKey] = subName
+	properties[deadLetterAttemptsKey] = strconv.Itoa(dp.policy.MaxUnmarshalAttempts)
+
+	producer, err := dp.getProducer(dp.policy.ChannelPrefix + channel)
+	if err != nil {
+		return err
+	}
+	if _, err = producer.Send(ctx, &mqclient.ProducerMessage{Payload: msg.Payload(), Properties: properties}); err != nil {
+		return err
+	}
+	metrics.MsgStreamDeadLetterCounter.WithLabelValues(channel).Inc()
+	log.Warn("msgstream sent the message failing to unmarshal to dead letter channel",
+		zap.String("channel", channel),
+		zap.String("msgID", msgID),
+		zap.String("subscription", subName),
+		zap.String("deadLetterChannel", dp.policy.ChannelPrefix+channel),
+		zap.Error(cause))
+	return nil
+}
+
+func (dp *deadLetterProducer) getProducer(channel string) (mqclient.Producer, error) {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+	if producer, ok := dp.producers[channel]; ok {
+		return producer, nil
+	}
+	producer, err := dp.client.CreateProducer(mqclient.ProducerOptions{Topic: channel})
+	if err != nil {
+		return nil, err
+	}
+	dp.producers[channel] = producer
+	return producer, nil
+}
+
+// Close closes the producers of dead letter channels
+func (dp *deadLetterProducer) Close() {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+	for _, producer := range dp.producers {
+		producer.Close()
+	}
+	dp.producers = make(map[string]mqclient.Producer)
+}
+
+// unmarshalConsumerMsg gets TsMsg from msg with the dead letter policy of stream. The returned TsMsg is nil
+// without error if msg fails to unmarshal and is sent to the dead letter channel, which should be skipped.
+func (ms *mqMsgStream) unmarshalConsumerMsg(consumer mqclient.Consumer, msg mqclient.ConsumerMessage) (TsMsg, error) {
+	if ms.deadLetter == nil {
+		return ms.getTsMsgFromConsumerMsg(msg)
+	}
+	var err error
+	for i := 0; i < ms.deadLetter.policy.MaxUnmarshalAttempts; i++ {
+		var tsMsg TsMsg
+		if tsMsg, err = ms.getTsMsgFromConsumerMsg(msg); err == nil {
+			return tsMsg, nil
+		}
+	}
+	return nil, ms.sendDeadLetter(consumer, msg, err)
+}
+
+// unpackConsumerMsg unpacks msg with the dead letter policy of stream. The returned messages are empty without error
+// if msg is a corrupted batch and sent to the dead letter channel.
+func (ms *mqMsgStream) unpackConsumerMsg(consumer mqclient.Consumer, msg mqclient.ConsumerMessage) ([]mqclient.ConsumerMessage, error) {
+	msgs, err := unpackConsumerMsg(msg)
+	if err != nil && ms.deadLetter != nil {
+		return nil, ms.sendDeadLetter(consumer, msg, err)
+	}
+	return msgs, err
+}
+
+func (ms *mqMsgStream) sendDeadLetter(consumer mqclient.Consumer, msg mqclient.ConsumerMessage, cause error) error {
+	if err := ms.deadLetter.send(ms.ctx, msg, consumer.Subscription(), cause); err != nil {
+		log.Error("msgstream failed to send dead letter", zap.String("channel", msg.Topic()), zap.Error(err))
+		return cause
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package msgstream
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/mqclient"
+)
+
+var _ mqclient.Client = (*mockDeadLetterClient)(nil)
+
+// mockDeadLetterClient creates mockRecordProducers, which are used as dead letter producers
+type mockDeadLetterClient struct {
+	mu        sync.Mutex
+	producers map[string]*mockRecordProducer
+	err       error
+}
+
+func (c *mockDeadLetterClient) CreateProducer(options mqclient.ProducerOptions) (mqclient.Producer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	p := &mockRecordProducer{}
+	c.producers[options.Topic] = p
+	return p, nil
+}
+
+func (c *mockDeadLetterClient) producer(topic string) *mockRecordProducer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.producers[topic]
+}
+
+func (c *mockDeadLetterClient) Subscribe(options mqclient.ConsumerOptions) (mqclient.Consumer, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *mockDeadLetterClient) EarliestMessageID() MessageID {
+	return nil
+}
+
+func (c *mockDeadLetterClient) StringToMsgID(string) (MessageID, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *mockDeadLetterClient) BytesToMsgID([]byte) (MessageID, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *mockDeadLetterClient) Close() {
+}
+
+var _ mqclient.Consumer = (*mockChanConsumer)(nil)
+
+// mockChanConsumer consumes the messages put into its channel
+type mockChanConsumer struct {
+	ch chan mqclient.ConsumerMessage
+}
+
+func (c *mockChanConsumer) Subscription() string                  { return "mock_sub" }
+func (c *mockChanConsumer) Chan() <-chan mqclient.ConsumerMessage { return c.ch }
+func (c *mockChanConsumer) Seek(MessageID) error                  { return nil }
+func (c *mockChanConsumer) SeekByTime(time.Time) error            { return nil }
+func (c *mockChanConsumer) Ack(mqclient.ConsumerMessage)          {}
+func (c *mockChanConsumer) Close()                                {}
+func (c *mockChanConsumer) Unsubscribe() error                    { return nil }
+
+func marshalConsumerMsg(t *testing.T, tsMsg TsMsg, id int64) mqclient.ConsumerMessage {
+	mb, err := tsMsg.Marshal(tsMsg)
+	assert.Nil(t, err)
+	payload, err := convertToByteArray(mb)
+	assert.Nil(t, err)
+	return &mockConsumerMessage{
+		msg: &mqclient.ProducerMessage{Payload: payload, Properties: map[string]string{}},
+		id:  &mockMessageID{id: id},
+	}
+}
+
+// garbageConsumerMsg is not a valid protobuf message
+func garbageConsumerMsg(id int64) mqclient.ConsumerMessage {
+	return &mockConsumerMessage{
+		msg: &mqclient.ProducerMessage{Payload: []byte{0xff, 0xff, 0xff}, Properties: map[string]string{"key": "value"}},
+		id:  &mockMessageID{id: id},
+	}
+}
+
+func TestDeadLetterPolicy_Enabled(t *testing.T) {
+	assert.False(t, DeadLetterPolicy{}.Enabled())
+	assert.True(t, DeadLetterPolicy{MaxUnmarshalAttempts: 1}.Enabled())
+}
+
+func TestMqMsgStream_DeadLetter(t *testing.T) {
+	client := &mockDeadLetterClient{producers: make(map[string]*mockRecordProducer)}
+	factory := ProtoUDFactory{}
+	stream, err := NewMqMsgStream(context.Background(), 100, 100, client, factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	stream.SetDeadLetterPolicy(DeadLetterPolicy{MaxUnmarshalAttempts: 3, ChannelPrefix: "dead-letter-"})
+
+	consumer := &mockChanConsumer{ch: make(chan mqclient.ConsumerMessage, 10)}
+	stream.consumers["mock_channel"] = consumer
+	stream.consumerChannels = append(stream.consumerChannels, "mock_channel")
+	stream.Start()
+
+	counter := metrics.MsgStreamDeadLetterCounter.WithLabelValues("mock_channel")
+	before := testutil.ToFloat64(counter)
+	consumer.ch <- marshalConsumerMsg(t, getTsMsg(commonpb.MsgType_Insert, 1), 1)
+	consumer.ch <- garbageConsumerMsg(2)
+	consumer.ch <- marshalConsumerMsg(t, getTsMsg(commonpb.MsgType_Insert, 3), 3)
+
+	// the garbage is skipped and the following message keeps flowing
+	pack := stream.Consume()
+	assert.Equal(t, int64(1), pack.Msgs[0].ID())
+	pack = stream.Consume()
+	assert.Equal(t, int64(3), pack.Msgs[0].ID())
+
+	producer := client.producer("dead-letter-mock_channel")
+	assert.NotNil(t, producer)
+	sent := producer.sent()
+	assert.Equal(t, 1, len(sent))
+	assert.Equal(t, []byte{0xff, 0xff, 0xff}, sent[0].Payload)
+	assert.Equal(t, "value", sent[0].Properties["key"])
+	assert.Equal(t, "mock_channel", sent[0].Properties[deadLetterChannelKey])
+	assert.Equal(t, "mock_sub", sent[0].Properties[deadLetterSubscriptionKey])
+	assert.Equal(t, "3", sent[0].Properties[deadLetterAttemptsKey])
+	assert.Equal(t, base64.StdEncoding.EncodeToString((&mockMessageID{id: 2}).Serialize()), sent[0].Properties[deadLetterMsgIDKey])
+	assert.NotEmpty(t, sent[0].Properties[deadLetterErrorKey])
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+
+	stream.Close()
+	assert.True(t, producer.closed)
+}
+
+func TestMqMsgStream_DeadLetterDisabled(t *testing.T) {
+	client := &mockDeadLetterClient{producers: make(map[string]*mockRecordProducer)}
+	factory := ProtoUDFactory{}
+	stream, err := NewMqMsgStream(context.Background(), 100, 100, client, factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	stream.SetDeadLetterPolicy(DeadLetterPolicy{})
+
+	consumer := &mockChanConsumer{ch: make(chan mqclient.ConsumerMessage, 10)}
+	stream.consumers["mock_channel"] = consumer
+	stream.consumerChannels = append(stream.consumerChannels, "mock_channel")
+	stream.Start()
+
+	consumer.ch <- garbageConsumerMsg(1)
+	consumer.ch <- marshalConsumerMsg(t, getTsMsg(commonpb.MsgType_Insert, 2), 2)
+	pack := stream.Consume()
+	assert.Equal(t, int64(2), pack.Msgs[0].ID())
+	assert.Nil(t, client.producer("dead-letter-mock_channel"))
+	stream.Close()
+}
+
+func TestMqMsgStream_DeadLetterFailed(t *testing.T) {
+	client := &mockDeadLetterClient{producers: make(map[string]*mockRecordProducer), err: errors.New("mock error")}
+	factory := ProtoUDFactory{}
+	stream, err := NewMqMsgStream(context.Background(), 100, 100, client, factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	stream.SetDeadLetterPolicy(DeadLetterPolicy{MaxUnmarshalAttempts: 1, ChannelPrefix: "dead-letter-"})
+	consumer := &mockChanConsumer{}
+
+	// the error of unmarshal is returned if the dead letter can't be sent
+	tsMsg, err := stream.unmarshalConsumerMsg(consumer, garbageConsumerMsg(1))
+	assert.Error(t, err)
+	assert.Nil(t, tsMsg)
+
+	tsMsg, err = stream.unmarshalConsumerMsg(consumer, marshalConsumerMsg(t, getTsMsg(commonpb.MsgType_Insert, 2), 2))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), tsMsg.ID())
+}
+
+func TestMqTtMsgStream_DeadLetter(t *testing.T) {
+	client := &mockDeadLetterClient{producers: make(map[string]*mockRecordProducer)}
+	factory := ProtoUDFactory{}
+	stream, err := NewMqTtMsgStream(context.Background(), 100, 100, client, factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	stream.SetDeadLetterPolicy(DeadLetterPolicy{MaxUnmarshalAttempts: 3, ChannelPrefix: "dead-letter-"})
+
+	consumer := &mockChanConsumer{ch: make(chan mqclient.ConsumerMessage, 10)}
+	stream.addConsumer(consumer, "mock_channel")
+	stream.Start()
+
+	consumer.ch <- marshalConsumerMsg(t, getTsMsg(commonpb.MsgType_Insert, 1), 1)
+	consumer.ch <- garbageConsumerMsg(2)
+	consumer.ch <- marshalConsumerMsg(t, getTsMsg(commonpb.MsgType_Insert, 3), 3)
+	consumer.ch <- marshalConsumerMsg(t, getTimeTickMsg(5), 4)
+
+	pack := stream.Consume()
+	assert.Equal(t, 2, len(pack.Msgs))
+	assert.Equal(t, int64(1), pack.Msgs[0].ID())
+	assert.Equal(t, int64(3), pack.Msgs[1].ID())
+	assert.Equal(t, 1, len(client.producer("dead-letter-mock_channel").sent()))
+	stream.Close()
+}
@@ -13,6 +13,7 @@ package msgstream
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/mitchellh/mapstructure"
@@ -22,6 +23,27 @@ import (
 	rocksmqserver "github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
 )
 
+// ProducerBatchParams are the parameters of producer side batching, batching is disabled by default
+type ProducerBatchParams struct {
+	MaxMessages int
+	MaxBytes    int
+	MaxDelayMs  int64
+	Compression string
+}
+
+func (p ProducerBatchParams) config() (ProducerBatchConfig, error) {
+	compression, err := ParseCompressionType(p.Compression)
+	if err != nil {
+		return ProducerBatchConfig{}, err
+	}
+	return ProducerBatchConfig{
+		MaxMessages: p.MaxMessages,
+		MaxBytes:    p.MaxBytes,
+		MaxDelay:    time.Duration(p.MaxDelayMs) * time.Millisecond,
+		Compression: compression,
+	}, nil
+}
+
 // PmsFactory is a pulsar msgstream factory that implemented Factory interface(msgstream.go)
 type PmsFactory struct {
 	dispatcherFactory ProtoUDFactory
@@ -29,6 +51,9 @@ type PmsFactory struct {
 	PulsarAddress  string
 	ReceiveBufSize int64
 	PulsarBufSize  int64
+	ProducerBatch  ProducerBatchParams
+	// DeadLetter is disabled by default, so that the control channels of coordinators never skip messages
+	DeadLetter DeadLetterPolicy
 }
 
 // SetParams is used to set parameters for PmsFactory
@@ -46,7 +71,17 @@ func (f *PmsFactory) NewMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqMsgStream(ctx, f.ReceiveBufSize, f.PulsarBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqMsgStream(ctx, f.ReceiveBufSize, f.PulsarBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
 }
 
 // NewTtMsgStream is used to generate a new TtMsgstream object
@@ -55,7 +90,17 @@ func (f *PmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.PulsarBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.PulsarBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
 }
 
 // NewQueryMsgStream is used to generate a new QueryMsgstream object
@@ -74,11 +119,86 @@ func NewPmsFactory() Factory {
 }
 
 // RmsFactory is a rocksmq msgstream factory that implemented Factory interface(msgstream.go)
+type KmsFactory struct {
+	dispatcherFactory ProtoUDFactory
+	// the following members must be public, so that mapstructure.Decode() can access them
+	KafkaAddress   string
+	ReceiveBufSize int64
+	KafkaBufSize   int64
+	ProducerBatch  ProducerBatchParams
+	// DeadLetter is disabled by default, so that the control channels of coordinators never skip messages
+	DeadLetter DeadLetterPolicy
+}
+
+func (f *KmsFactory) SetParams(params map[string]interface{}) error {
+	err := mapstructure.Decode(params, f)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (f *KmsFactory) NewMsgStream(ctx context.Context) (MsgStream, error) {
+	kafkaClient, err := mqclient.GetKafkaClientInstance(f.KafkaAddress)
+	if err != nil {
+		return nil, err
+	}
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqMsgStream(ctx, f.ReceiveBufSize, f.KafkaBufSize, kafkaClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
+}
+
+func (f *KmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
+	kafkaClient, err := mqclient.GetKafkaClientInstance(f.KafkaAddress)
+	if err != nil {
+		return nil, err
+	}
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.KafkaBufSize, kafkaClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
+}
+
+func (f *KmsFactory) NewQueryMsgStream(ctx context.Context) (MsgStream, error) {
+	return f.NewMsgStream(ctx)
+}
+
+// NewKmsFactory returns a factory of the msgstreams on kafka, kafkaAddress is the comma separated
+// host:port list of the bootstrap brokers. Each channel is a kafka topic and its messages are kept
+// in a single partition, see mqclient.GetKafkaClientInstance.
+func NewKmsFactory(kafkaAddress string) Factory {
+	f := &KmsFactory{
+		dispatcherFactory: ProtoUDFactory{},
+		KafkaAddress:      kafkaAddress,
+		ReceiveBufSize:    64,
+		KafkaBufSize:      64,
+	}
+	return f
+}
+
 type RmsFactory struct {
 	dispatcherFactory ProtoUDFactory
 	// the following members must be public, so that mapstructure.Decode() can access them
 	ReceiveBufSize int64
 	RmqBufSize     int64
+	ProducerBatch  ProducerBatchParams
+	// DeadLetter is disabled by default, so that the control channels of coordinators never skip messages
+	DeadLetter DeadLetterPolicy
 }
 
 // SetParams is used to set parameters for RmsFactory
@@ -96,7 +216,17 @@ func (f *RmsFactory) NewMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqMsgStream(ctx, f.ReceiveBufSize, f.RmqBufSize, rmqClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqMsgStream(ctx, f.ReceiveBufSize, f.RmqBufSize, rmqClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
 }
 
 // NewTtMsgStream is used to generate a new TtMsgstream object
@@ -105,7 +235,17 @@ func (f *RmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.RmqBufSize, rmqClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.RmqBufSize, rmqClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
 }
 
 // NewQueryMsgStream is used to generate a new QueryMsgstream object
@@ -114,7 +254,17 @@ func (f *RmsFactory) NewQueryMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqMsgStream(ctx, f.ReceiveBufSize, f.RmqBufSize, rmqClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	batchConfig, err := f.ProducerBatch.config()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewMqMsgStream(ctx, f.ReceiveBufSize, f.RmqBufSize, rmqClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.SetProducerBatchConfig(batchConfig)
+	stream.SetDeadLetterPolicy(f.DeadLetter)
+	return stream, nil
 }
 
 // NewRmsFactory is used to generate a new RmsFactory object
@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/mqclient"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/trace"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 )
 
 var _ MsgStream = (*mqMsgStream)(nil)
@@ -49,6 +50,12 @@ type mqMsgStream struct {
 	bufSize          int64
 	producerLock     *sync.Mutex
 	consumerLock     *sync.Mutex
+	// seekTimestamps are the timestamps seeked to of consumers, the messages before them are skipped
+	seekTimestamps map[mqclient.Consumer]Timestamp
+	batchConfig    ProducerBatchConfig
+	batchProducers map[string]*batchProducer
+	// deadLetter is nil if the messages failing to unmarshal are dropped
+	deadLetter *deadLetterProducer
 }
 
 // NewMqMsgStream is used to generate a new mqMsgStream object
@@ -79,11 +86,27 @@ func NewMqMsgStream(ctx context.Context,
 		producerLock:     &sync.Mutex{},
 		consumerLock:     &sync.Mutex{},
 		wait:             &sync.WaitGroup{},
+		seekTimestamps:   make(map[mqclient.Consumer]Timestamp),
+		batchProducers:   make(map[string]*batchProducer),
 	}
 
 	return stream, nil
 }
 
+// SetProducerBatchConfig sets the config of producer side batching, it should be called before AsProducer
+func (ms *mqMsgStream) SetProducerBatchConfig(config ProducerBatchConfig) {
+	ms.batchConfig = config
+}
+
+// SetDeadLetterPolicy sets the policy of the consumed messages which can't be parsed, it should be called before Start
+func (ms *mqMsgStream) SetDeadLetterPolicy(policy DeadLetterPolicy) {
+	if !policy.Enabled() {
+		ms.deadLetter = nil
+		return
+	}
+	ms.deadLetter = newDeadLetterProducer(ms.client, policy)
+}
+
 // AsProducer create producer to send message to channels
 func (ms *mqMsgStream) AsProducer(channels []string) {
 	for _, channel := range channels {
@@ -104,6 +127,9 @@ func (ms *mqMsgStream) AsProducer(channels []string) {
 			defer ms.producerLock.Unlock()
 			ms.producers[channel] = pp
 			ms.producerChannels = append(ms.producerChannels, channel)
+			if ms.batchConfig.Enabled() {
+				ms.batchProducers[channel] = newBatchProducer(pp, ms.batchConfig)
+			}
 			return nil
 		}
 		err := retry.Do(context.TODO(), fn, retry.Attempts(20), retry.Sleep(time.Millisecond*200))
@@ -170,11 +196,7 @@ func (ms *mqMsgStream) Close() {
 	ms.streamCancel()
 	ms.wait.Wait()
 
-	for _, producer := range ms.producers {
-		if producer != nil {
-			producer.Close()
-		}
-	}
+	ms.closeProducers()
 	for _, consumer := range ms.consumers {
 		if consumer != nil {
 			consumer.Close()
@@ -182,6 +204,52 @@ func (ms *mqMsgStream) Close() {
 	}
 }
 
+// Unsubscribe closes the stream, and deletes the subscriptions of the consumers
+func (ms *mqMsgStream) Unsubscribe() {
+	ms.streamCancel()
+	ms.wait.Wait()
+
+	ms.closeProducers()
+	ms.unsubscribeConsumers()
+}
+
+func (ms *mqMsgStream) unsubscribeConsumers() {
+	for channel, consumer := range ms.consumers {
+		if consumer == nil {
+			continue
+		}
+		if err := consumer.Unsubscribe(); err != nil {
+			log.Warn("MsgStream unsubscribe failed", zap.String("channel", channel),
+				zap.String("subscription", consumer.Subscription()), zap.Error(err))
+		}
+	}
+}
+
+// closeProducers sends the pending batches and closes the producers
+func (ms *mqMsgStream) closeProducers() {
+	for channel, producer := range ms.producers {
+		if bp, ok := ms.batchProducers[channel]; ok {
+			bp.Close()
+		} else if producer != nil {
+			producer.Close()
+		}
+	}
+	if ms.deadLetter != nil {
+		ms.deadLetter.Close()
+	}
+}
+
+// send sends the message to the producer of channel. With batching, the message is appended to
+// a batch, which should be flushed before the message is regarded as sent and the id is got.
+func (ms *mqMsgStream) send(ctx context.Context, channel string, tsMsg TsMsg, msg *mqclient.ProducerMessage) (MessageID, *producerBatch, error) {
+	if bp, ok := ms.batchProducers[channel]; ok {
+		// a time tick seals the batch, so that it's always the last one of a batch
+		return nil, bp.add(msg, tsMsg.Type() == commonpb.MsgType_TimeTick), nil
+	}
+	id, err := ms.producers[channel].Send(ctx, msg)
+	return id, nil, err
+}
+
 func (ms *mqMsgStream) ComputeProduceChannelIndexes(tsMsgs []TsMsg) [][]int32 {
 	if len(tsMsgs) <= 0 {
 		return nil
@@ -235,6 +303,7 @@ func (ms *mqMsgStream) Produce(msgPack *MsgPack) error {
 	if err != nil {
 		return err
 	}
+	batches := make([]*producerBatch, 0)
 	for k, v := range result {
 		channel := ms.producerChannels[k]
 		for i := 0; i < len(v.Msgs); i++ {
@@ -255,20 +324,21 @@ func (ms *mqMsgStream) Produce(msgPack *MsgPack) error {
 			trace.InjectContextToPulsarMsgProperties(sp.Context(), msg.Properties)
 
 			ms.producerLock.Lock()
-			if _, err := ms.producers[channel].Send(
-				spanCtx,
-				msg,
-			); err != nil {
+			_, batch, err := ms.send(spanCtx, channel, v.Msgs[i], msg)
+			if err != nil {
 				ms.producerLock.Unlock()
 				trace.LogError(sp, err)
 				sp.Finish()
 				return err
 			}
+			if batch != nil {
+				batches = append(batches, batch)
+			}
 			sp.Finish()
 			ms.producerLock.Unlock()
 		}
 	}
-	return nil
+	return flushBatches(batches)
 }
 
 // ProduceMark send msg pack to all producers and returns corresponding msg id
@@ -301,6 +371,7 @@ func (ms *mqMsgStream) ProduceMark(msgPack *MsgPack) (map[string][]MessageID, er
 	if err != nil {
 		return ids, err
 	}
+	batched := make([]batchedMsgID, 0)
 	for k, v := range result {
 		channel := ms.producerChannels[k]
 		for i, tsMsg := range v.Msgs {
@@ -321,22 +392,22 @@ func (ms *mqMsgStream) ProduceMark(msgPack *MsgPack) (map[string][]MessageID, er
 			trace.InjectContextToPulsarMsgProperties(sp.Context(), msg.Properties)
 
 			ms.producerLock.Lock()
-			id, err := ms.producers[channel].Send(
-				spanCtx,
-				msg,
-			)
+			id, batch, err := ms.send(spanCtx, channel, tsMsg, msg)
 			if err != nil {
 				ms.producerLock.Unlock()
 				trace.LogError(sp, err)
 				sp.Finish()
 				return ids, err
 			}
+			if batch != nil {
+				batched = append(batched, batchedMsgID{channel: channel, index: len(ids[channel]), batch: batch})
+			}
 			ids[channel] = append(ids[channel], id)
 			sp.Finish()
 			ms.producerLock.Unlock()
 		}
 	}
-	return ids, nil
+	return ids, fillBatchedMsgIDs(ids, batched)
 }
 
 // Broadcast put msgPack to all producer in current msgstream
@@ -346,6 +417,7 @@ func (ms *mqMsgStream) Broadcast(msgPack *MsgPack) error {
 		log.Debug("Warning: Receive empty msgPack")
 		return nil
 	}
+	batches := make([]*producerBatch, 0)
 	for _, v := range msgPack.Msgs {
 		sp, spanCtx := MsgSpanFromCtx(v.TraceCtx(), v)
 
@@ -364,21 +436,22 @@ func (ms *mqMsgStream) Broadcast(msgPack *MsgPack) error {
 		trace.InjectContextToPulsarMsgProperties(sp.Context(), msg.Properties)
 
 		ms.producerLock.Lock()
-		for _, producer := range ms.producers {
-			if _, err := producer.Send(
-				spanCtx,
-				msg,
-			); err != nil {
+		for channel := range ms.producers {
+			_, batch, err := ms.send(spanCtx, channel, v, msg)
+			if err != nil {
 				ms.producerLock.Unlock()
 				trace.LogError(sp, err)
 				sp.Finish()
 				return err
 			}
+			if batch != nil {
+				batches = append(batches, batch)
+			}
 		}
 		ms.producerLock.Unlock()
 		sp.Finish()
 	}
-	return nil
+	return flushBatches(batches)
 }
 
 // BroadcastMark broadcast msg pack to all producers and returns corresponding msg id
@@ -388,6 +461,7 @@ func (ms *mqMsgStream) BroadcastMark(msgPack *MsgPack) (map[string][]MessageID,
 	if msgPack == nil || len(msgPack.Msgs) <= 0 {
 		return ids, errors.New("empty msgs")
 	}
+	batched := make([]batchedMsgID, 0)
 	for _, v := range msgPack.Msgs {
 		sp, spanCtx := MsgSpanFromCtx(v.TraceCtx(), v)
 
@@ -406,20 +480,23 @@ func (ms *mqMsgStream) BroadcastMark(msgPack *MsgPack) (map[string][]MessageID,
 		trace.InjectContextToPulsarMsgProperties(sp.Context(), msg.Properties)
 
 		ms.producerLock.Lock()
-		for channel, producer := range ms.producers {
-			id, err := producer.Send(spanCtx, msg)
+		for channel := range ms.producers {
+			id, batch, err := ms.send(spanCtx, channel, v, msg)
 			if err != nil {
 				ms.producerLock.Unlock()
 				trace.LogError(sp, err)
 				sp.Finish()
 				return ids, err
 			}
+			if batch != nil {
+				batched = append(batched, batchedMsgID{channel: channel, index: len(ids[channel]), batch: batch})
+			}
 			ids[channel] = append(ids[channel], id)
 		}
 		ms.producerLock.Unlock()
 		sp.Finish()
 	}
-	return ids, nil
+	return ids, fillBatchedMsgIDs(ids, batched)
 }
 
 func (ms *mqMsgStream) Consume() *MsgPack {
@@ -444,6 +521,9 @@ func (ms *mqMsgStream) getTsMsgFromConsumerMsg(msg mqclient.ConsumerMessage) (Ts
 	if err != nil {
 		return nil, fmt.Errorf("Failed to unmarshal message header, err %s", err.Error())
 	}
+	if header.Base == nil {
+		return nil, errors.New("Failed to unmarshal message header, base is nil")
+	}
 	tsMsg, err := ms.unmarshal.Unmarshal(msg.Payload(), header.Base.MsgType)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to unmarshal tsMsg, err %s", err.Error())
@@ -471,32 +551,45 @@ func (ms *mqMsgStream) receiveMsg(consumer mqclient.Consumer) {
 			}
 			consumer.Ack(msg)
 
-			tsMsg, err := ms.getTsMsgFromConsumerMsg(msg)
+			msgs, err := ms.unpackConsumerMsg(consumer, msg)
 			if err != nil {
-				log.Error("Failed to getTsMsgFromConsumerMsg", zap.Error(err))
+				log.Error("Failed to unpack consumer message", zap.Error(err))
 				continue
 			}
-			pos := tsMsg.Position()
-			tsMsg.SetPosition(&MsgPosition{
-				ChannelName: pos.ChannelName,
-				MsgID:       pos.MsgID,
-				MsgGroup:    consumer.Subscription(),
-				Timestamp:   tsMsg.BeginTs(),
-			})
+			for _, m := range msgs {
+				tsMsg, err := ms.unmarshalConsumerMsg(consumer, m)
+				if err != nil {
+					log.Error("Failed to getTsMsgFromConsumerMsg", zap.Error(err))
+					continue
+				}
+				if tsMsg == nil {
+					continue
+				}
+				if tsMsg.BeginTs() < ms.seekTimestamps[consumer] {
+					continue
+				}
+				pos := tsMsg.Position()
+				tsMsg.SetPosition(&MsgPosition{
+					ChannelName: pos.ChannelName,
+					MsgID:       pos.MsgID,
+					MsgGroup:    consumer.Subscription(),
+					Timestamp:   tsMsg.BeginTs(),
+				})
+
+				sp, ok := ExtractFromPulsarMsgProperties(tsMsg, m.Properties())
+				if ok {
+					tsMsg.SetTraceCtx(opentracing.ContextWithSpan(context.Background(), sp))
+				}
 
-			sp, ok := ExtractFromPulsarMsgProperties(tsMsg, msg.Properties())
-			if ok {
-				tsMsg.SetTraceCtx(opentracing.ContextWithSpan(context.Background(), sp))
-			}
+				msgPack := MsgPack{
+					Msgs:           []TsMsg{tsMsg},
+					StartPositions: []*internalpb.MsgPosition{tsMsg.Position()},
+					EndPositions:   []*internalpb.MsgPosition{tsMsg.Position()},
+				}
+				ms.receiveBuf <- &msgPack
 
-			msgPack := MsgPack{
-				Msgs:           []TsMsg{tsMsg},
-				StartPositions: []*internalpb.MsgPosition{tsMsg.Position()},
-				EndPositions:   []*internalpb.MsgPosition{tsMsg.Position()},
+				sp.Finish()
 			}
-			ms.receiveBuf <- &msgPack
-
-			sp.Finish()
 		}
 	}
 }
@@ -519,6 +612,9 @@ func (ms *mqMsgStream) Seek(msgPositions []*internalpb.MsgPosition) error {
 		log.Debug("MsgStream begin to seek", zap.Any("MessageID", messageID))
 		err = consumer.Seek(messageID)
 		if err != nil {
+			if errors.Is(err, ErrPositionExpired) {
+				log.Warn("MsgStream seek to an expired position", zap.String("channel", mp.ChannelName), zap.Error(err))
+			}
 			return err
 		}
 		log.Debug("MsgStream seek finished", zap.Any("MessageID", messageID))
@@ -539,6 +635,26 @@ func (ms *mqMsgStream) Seek(msgPositions []*internalpb.MsgPosition) error {
 	return nil
 }
 
+// SeekToTimestamp seeks the consumers of channels to the first messages whose timestamps are at or after ts,
+// the consumers are seeked by the publish time of messages, and the messages before ts are skipped.
+// It should be called before Start.
+func (ms *mqMsgStream) SeekToTimestamp(channels []string, ts Timestamp) error {
+	publishTime, _ := tsoutil.ParseTS(ts)
+	for _, channel := range channels {
+		consumer, ok := ms.consumers[channel]
+		if !ok {
+			return fmt.Errorf("channel %s not subscribed", channel)
+		}
+		log.Debug("MsgStream begin to seek to timestamp", zap.String("channel", channel), zap.Uint64("timestamp", ts))
+		if err := consumer.SeekByTime(publishTime); err != nil {
+			return err
+		}
+		ms.seekTimestamps[consumer] = ts
+		log.Debug("MsgStream seek to timestamp finished", zap.String("channel", channel), zap.Uint64("timestamp", ts))
+	}
+	return nil
+}
+
 var _ MsgStream = (*MqTtMsgStream)(nil)
 
 // MqTtMsgStream is a msgstream that contains timeticks
@@ -653,11 +769,7 @@ func (ms *MqTtMsgStream) Close() {
 	close(ms.syncConsumer)
 	ms.wait.Wait()
 
-	for _, producer := range ms.producers {
-		if producer != nil {
-			producer.Close()
-		}
-	}
+	ms.closeProducers()
 	for _, consumer := range ms.consumers {
 		if consumer != nil {
 			consumer.Close()
@@ -665,6 +777,16 @@ func (ms *MqTtMsgStream) Close() {
 	}
 }
 
+// Unsubscribe closes the stream, and deletes the subscriptions of the consumers
+func (ms *MqTtMsgStream) Unsubscribe() {
+	ms.streamCancel()
+	close(ms.syncConsumer)
+	ms.wait.Wait()
+
+	ms.closeProducers()
+	ms.unsubscribeConsumers()
+}
+
 func (ms *MqTtMsgStream) bufMsgPackToChannel() {
 	defer ms.wait.Done()
 	chanTtMsgSync := make(map[mqclient.Consumer]bool)
@@ -780,29 +902,39 @@ func (ms *MqTtMsgStream) consumeToTtMsg(consumer mqclient.Consumer) {
 			}
 			consumer.Ack(msg)
 
-			tsMsg, err := ms.getTsMsgFromConsumerMsg(msg)
+			msgs, err := ms.unpackConsumerMsg(consumer, msg)
 			if err != nil {
-				log.Error("Failed to getTsMsgFromConsumerMsg", zap.Error(err))
+				log.Error("Failed to unpack consumer message", zap.Error(err))
 				continue
 			}
+			for _, m := range msgs {
+				tsMsg, err := ms.unmarshalConsumerMsg(consumer, m)
+				if err != nil {
+					log.Error("Failed to getTsMsgFromConsumerMsg", zap.Error(err))
+					continue
+				}
+				if tsMsg == nil {
+					continue
+				}
 
-			sp, ok := ExtractFromPulsarMsgProperties(tsMsg, msg.Properties())
-			if ok {
-				tsMsg.SetTraceCtx(opentracing.ContextWithSpan(context.Background(), sp))
-			}
+				sp, ok := ExtractFromPulsarMsgProperties(tsMsg, m.Properties())
+				if ok {
+					tsMsg.SetTraceCtx(opentracing.ContextWithSpan(context.Background(), sp))
+				}
 
-			ms.chanMsgBufMutex.Lock()
-			ms.chanMsgBuf[consumer] = append(ms.chanMsgBuf[consumer], tsMsg)
-			ms.chanMsgBufMutex.Unlock()
+				ms.chanMsgBufMutex.Lock()
+				ms.chanMsgBuf[consumer] = append(ms.chanMsgBuf[consumer], tsMsg)
+				ms.chanMsgBufMutex.Unlock()
 
-			if tsMsg.Type() == commonpb.MsgType_TimeTick {
-				ms.chanTtMsgTimeMutex.Lock()
-				ms.chanTtMsgTime[consumer] = tsMsg.(*TimeTickMsg).Base.Timestamp
-				ms.chanTtMsgTimeMutex.Unlock()
+				if tsMsg.Type() == commonpb.MsgType_TimeTick {
+					ms.chanTtMsgTimeMutex.Lock()
+					ms.chanTtMsgTime[consumer] = tsMsg.(*TimeTickMsg).Base.Timestamp
+					ms.chanTtMsgTimeMutex.Unlock()
+					sp.Finish()
+					return
+				}
 				sp.Finish()
-				return
 			}
-			sp.Finish()
 		}
 	}
 }
@@ -838,6 +970,7 @@ func (ms *MqTtMsgStream) Seek(msgPositions []*internalpb.MsgPosition) error {
 	var consumer mqclient.Consumer
 	var mp *MsgPosition
 	var err error
+	var expiredErr error
 	fn := func() error {
 		var ok bool
 		consumer, ok = ms.consumers[mp.ChannelName]
@@ -855,6 +988,11 @@ func (ms *MqTtMsgStream) Seek(msgPositions []*internalpb.MsgPosition) error {
 		}
 		err = consumer.Seek(seekMsgID)
 		if err != nil {
+			// the messages are removed, retrying never succeeds
+			if errors.Is(err, ErrPositionExpired) {
+				expiredErr = err
+				return retry.Unrecoverable(err)
+			}
 			return err
 		}
 
@@ -870,6 +1008,10 @@ func (ms *MqTtMsgStream) Seek(msgPositions []*internalpb.MsgPosition) error {
 			return fmt.Errorf("when msgID's length equal to 0, please use AsConsumer interface")
 		}
 		if err = retry.Do(context.TODO(), fn, retry.Attempts(20), retry.Sleep(time.Millisecond*200)); err != nil {
+			if expiredErr != nil {
+				log.Warn("MqTtMsgStream seek to an expired position", zap.String("channel", mp.ChannelName), zap.Error(expiredErr))
+				return expiredErr
+			}
 			return fmt.Errorf("Failed to seek, error %s", err.Error())
 		}
 		ms.addConsumer(consumer, mp.ChannelName)
@@ -885,27 +1027,112 @@ func (ms *MqTtMsgStream) Seek(msgPositions []*internalpb.MsgPosition) error {
 				}
 				consumer.Ack(msg)
 
-				headerMsg := commonpb.MsgHeader{}
-				err := proto.Unmarshal(msg.Payload(), &headerMsg)
+				msgs, err := ms.unpackConsumerMsg(consumer, msg)
 				if err != nil {
-					return fmt.Errorf("Failed to unmarshal message header, err %s", err.Error())
+					return err
+				}
+				// a time tick is always the last one of a batch
+				for _, m := range msgs {
+					tsMsg, err := ms.unmarshalConsumerMsg(consumer, m)
+					if err != nil {
+						return err
+					}
+					if tsMsg == nil {
+						continue
+					}
+					if tsMsg.Type() == commonpb.MsgType_TimeTick && tsMsg.BeginTs() >= mp.Timestamp {
+						runLoop = false
+						break
+					} else if tsMsg.BeginTs() > mp.Timestamp {
+						tsMsg.SetPosition(&MsgPosition{
+							ChannelName: filepath.Base(m.Topic()),
+							MsgID:       m.ID().Serialize(),
+						})
+						ms.chanMsgBuf[consumer] = append(ms.chanMsgBuf[consumer], tsMsg)
+					}
 				}
-				tsMsg, err := ms.unmarshal.Unmarshal(msg.Payload(), headerMsg.Base.MsgType)
+			}
+		}
+	}
+	return nil
+}
+
+// SeekToTimestamp seeks the consumers of channels to the first messages whose timestamps are at or after ts.
+// The messages are consumed till the time tick at or after ts, and the start position of the next MsgPack
+// is the first message consumed at or after ts, so that seeking to it replays the same messages.
+func (ms *MqTtMsgStream) SeekToTimestamp(channels []string, ts Timestamp) error {
+	publishTime, _ := tsoutil.ParseTS(ts)
+
+	ms.consumerLock.Lock()
+	defer ms.consumerLock.Unlock()
+
+	for _, channel := range channels {
+		consumer, ok := ms.consumers[channel]
+		if !ok {
+			return fmt.Errorf("please subcribe the channel, channel name =%s", channel)
+		}
+		fn := func() error {
+			return consumer.SeekByTime(publishTime)
+		}
+		if err := retry.Do(context.TODO(), fn, retry.Attempts(20), retry.Sleep(time.Millisecond*200)); err != nil {
+			return fmt.Errorf("Failed to seek to timestamp, error %s", err.Error())
+		}
+
+		// Seek skips the messages at or before the timestamp of position
+		seekTs := ts
+		if seekTs > 0 {
+			seekTs--
+		}
+		var startPos *MsgPosition
+		buffer := make([]TsMsg, 0)
+		runLoop := true
+		for runLoop {
+			select {
+			case <-ms.ctx.Done():
+				return nil
+			case msg, ok := <-consumer.Chan():
+				if !ok {
+					return fmt.Errorf("consumer closed")
+				}
+				consumer.Ack(msg)
+
+				msgs, err := ms.unpackConsumerMsg(consumer, msg)
 				if err != nil {
-					return fmt.Errorf("Failed to unmarshal tsMsg, err %s", err.Error())
+					return err
 				}
-				if tsMsg.Type() == commonpb.MsgType_TimeTick && tsMsg.BeginTs() >= mp.Timestamp {
-					runLoop = false
-					break
-				} else if tsMsg.BeginTs() > mp.Timestamp {
-					tsMsg.SetPosition(&MsgPosition{
-						ChannelName: filepath.Base(msg.Topic()),
-						MsgID:       msg.ID().Serialize(),
-					})
-					ms.chanMsgBuf[consumer] = append(ms.chanMsgBuf[consumer], tsMsg)
+				// a time tick is always the last one of a batch
+				for _, m := range msgs {
+					tsMsg, err := ms.unmarshalConsumerMsg(consumer, m)
+					if err != nil {
+						return err
+					}
+					if tsMsg == nil {
+						continue
+					}
+					if tsMsg.BeginTs() < ts {
+						continue
+					}
+					if startPos == nil {
+						startPos = &MsgPosition{
+							ChannelName: tsMsg.Position().ChannelName,
+							MsgID:       tsMsg.Position().MsgID,
+							Timestamp:   seekTs,
+							MsgGroup:    consumer.Subscription(),
+						}
+					}
+					if tsMsg.Type() == commonpb.MsgType_TimeTick {
+						runLoop = false
+						break
+					}
+					buffer = append(buffer, tsMsg)
 				}
 			}
 		}
+
+		ms.chanMsgBufMutex.Lock()
+		ms.chanMsgBuf[consumer] = buffer
+		ms.chanMsgPos[consumer] = startPos
+		ms.chanMsgBufMutex.Unlock()
 	}
 	return nil
 }
@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+//go:build kafka
+// +build kafka
+
+package msgstream
+
+// The tests in this file need the kafka brokers at KAFKA_ADDRESS (localhost:9092 by default),
+// run them with scripts/run_go_kafka_unittest.sh which starts the brokers in a container.
+// Besides them, the kafka client joins the shared tests of mq_msgstream_test.go through kafkaParameters.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/mqclient"
+)
+
+func kafkaParameters(t *testing.T) []parameters {
+	return []parameters{{getKafkaClient(t)}}
+}
+
+func getKafkaClient(t *testing.T) mqclient.Client {
+	kafkaAddress, _ := Params.Load("_KafkaAddress")
+	kafkaClient, err := mqclient.GetKafkaClientInstance(kafkaAddress)
+	assert.Nil(t, err)
+	return kafkaClient
+}
+
+func getKafkaInputStream(t *testing.T, producerChannels []string) MsgStream {
+	factory := ProtoUDFactory{}
+	inputStream, err := NewMqMsgStream(context.Background(), 100, 100, getKafkaClient(t), factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	inputStream.AsProducer(producerChannels)
+	inputStream.Start()
+	return inputStream
+}
+
+func getKafkaOutputStream(t *testing.T, consumerChannels []string, consumerSubName string) MsgStream {
+	factory := ProtoUDFactory{}
+	outputStream, err := NewMqMsgStream(context.Background(), 100, 100, getKafkaClient(t), factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	outputStream.AsConsumer(consumerChannels, consumerSubName)
+	outputStream.Start()
+	return outputStream
+}
+
+func getKafkaTtOutputStream(t *testing.T, consumerChannels []string, consumerSubName string, positions []*MsgPosition) MsgStream {
+	factory := ProtoUDFactory{}
+	outputStream, err := NewMqTtMsgStream(context.Background(), 100, 100, getKafkaClient(t), factory.NewUnmarshalDispatcher())
+	assert.Nil(t, err)
+	outputStream.AsConsumer(consumerChannels, consumerSubName)
+	if len(positions) > 0 {
+		err = outputStream.Seek(positions)
+		assert.Nil(t, err)
+	}
+	outputStream.Start()
+	return outputStream
+}
+
+func TestStream_KafkaMsgStream_Insert(t *testing.T) {
+	c1, c2 := funcutil.RandomString(8), funcutil.RandomString(8)
+	producerChannels := []string{c1, c2}
+	consumerChannels := []string{c1, c2}
+	consumerSubName := funcutil.RandomString(8)
+
+	msgPack := MsgPack{}
+	msgPack.Msgs = append(msgPack.Msgs, getTsMsg(commonpb.MsgType_Insert, 1))
+	msgPack.Msgs = append(msgPack.Msgs, getTsMsg(commonpb.MsgType_Insert, 3))
+
+	inputStream := getKafkaInputStream(t, producerChannels)
+	outputStream := getKafkaOutputStream(t, consumerChannels, consumerSubName)
+
+	err := inputStream.Produce(&msgPack)
+	assert.Nil(t, err)
+
+	receiveMsg(outputStream, len(msgPack.Msgs))
+	inputStream.Close()
+	outputStream.Close()
+}
+
+// the messages of a channel are received in the order they are produced
+func TestStream_KafkaMsgStream_Order(t *testing.T) {
+	c := funcutil.RandomString(8)
+	channels := []string{c}
+
+	inputStream := getKafkaInputStream(t, channels)
+	outputStream := getKafkaOutputStream(t, channels, funcutil.RandomString(8))
+
+	msgCount := 100
+	for i := 0; i < msgCount; i++ {
+		var err error
+		if i%10 == 9 {
+			err = inputStream.Broadcast(&MsgPack{Msgs: []TsMsg{getTimeTickMsg(int64(i))}})
+		} else {
+			err = inputStream.Produce(&MsgPack{Msgs: []TsMsg{getTsMsg(commonpb.MsgType_Insert, int64(i))}})
+		}
+		assert.Nil(t, err)
+	}
+
+	received := 0
+	for received < msgCount {
+		result := outputStream.Consume()
+		for _, msg := range result.Msgs {
+			assert.Equal(t, int64(received), msg.ID())
+			received++
+		}
+	}
+	inputStream.Close()
+	outputStream.Close()
+}
+
+func TestStream_KafkaMsgStream_Seek(t *testing.T) {
+	c := funcutil.RandomString(8)
+	producerChannels := []string{c}
+	consumerChannels := []string{c}
+	consumerSubName := funcutil.RandomString(8)
+
+	msgPack := &MsgPack{}
+	inputStream := getKafkaInputStream(t, producerChannels)
+	outputStream := getKafkaOutputStream(t, consumerChannels, consumerSubName)
+
+	for i := 0; i < 10; i++ {
+		insertMsg := getTsMsg(commonpb.MsgType_Insert, int64(i))
+		msgPack.Msgs = append(msgPack.Msgs, insertMsg)
+	}
+
+	err := inputStream.Produce(msgPack)
+	assert.Nil(t, err)
+	var seekPosition *internalpb.MsgPosition
+	for i := 0; i < 10; i++ {
+		result := outputStream.Consume()
+		assert.Equal(t, result.Msgs[0].ID(), int64(i))
+		if i == 5 {
+			seekPosition = result.EndPositions[0]
+		}
+	}
+	outputStream.Close()
+
+	factory := ProtoUDFactory{}
+	outputStream2, _ := NewMqMsgStream(context.Background(), 100, 100, getKafkaClient(t), factory.NewUnmarshalDispatcher())
+	outputStream2.AsConsumer(consumerChannels, consumerSubName)
+	err = outputStream2.Seek([]*internalpb.MsgPosition{seekPosition})
+	assert.Nil(t, err)
+	outputStream2.Start()
+
+	for i := 6; i < 10; i++ {
+		result := outputStream2.Consume()
+		assert.Equal(t, result.Msgs[0].ID(), int64(i))
+	}
+	inputStream.Close()
+	outputStream2.Close()
+}
+
+func TestStream_KafkaTtMsgStream_Seek(t *testing.T) {
+	c1, c2 := funcutil.RandomString(8), funcutil.RandomString(8)
+	producerChannels := []string{c1, c2}
+	consumerChannels := []string{c1, c2}
+	consumerSubName := funcutil.RandomString(8)
+
+	msgPack0 := MsgPack{}
+	msgPack0.Msgs = append(msgPack0.Msgs, getTimeTickMsg(0))
+
+	msgPack1 := MsgPack{}
+	msgPack1.Msgs = append(msgPack1.Msgs, getTsMsg(commonpb.MsgType_Insert, 1))
+	msgPack1.Msgs = append(msgPack1.Msgs, getTsMsg(commonpb.MsgType_Insert, 19))
+
+	msgPack2 := MsgPack{}
+	msgPack2.Msgs = append(msgPack2.Msgs, getTimeTickMsg(5))
+
+	msgPack3 := MsgPack{}
+	msgPack3.Msgs = append(msgPack3.Msgs, getTsMsg(commonpb.MsgType_Insert, 14))
+	msgPack3.Msgs = append(msgPack3.Msgs, getTsMsg(commonpb.MsgType_Insert, 9))
+
+	msgPack4 := MsgPack{}
+	msgPack4.Msgs = append(msgPack4.Msgs, getTimeTickMsg(11))
+
+	msgPack5 := MsgPack{}
+	msgPack5.Msgs = append(msgPack5.Msgs, getTimeTickMsg(15))
+
+	inputStream := getKafkaInputStream(t, producerChannels)
+	outputStream := getKafkaTtOutputStream(t, consumerChannels, consumerSubName, nil)
+
+	err := inputStream.Broadcast(&msgPack0)
+	assert.Nil(t, err)
+	err = inputStream.Produce(&msgPack1)
+	assert.Nil(t, err)
+	err = inputStream.Broadcast(&msgPack2)
+	assert.Nil(t, err)
+	err = inputStream.Produce(&msgPack3)
+	assert.Nil(t, err)
+	err = inputStream.Broadcast(&msgPack4)
+	assert.Nil(t, err)
+
+	outputStream.Consume()
+	receivedMsg := outputStream.Consume()
+	outputStream.Close()
+	outputStream = getKafkaTtOutputStream(t, consumerChannels, consumerSubName, receivedMsg.EndPositions)
+
+	err = inputStream.Broadcast(&msgPack5)
+	assert.Nil(t, err)
+	seekMsg := outputStream.Consume()
+	for _, msg := range seekMsg.Msgs {
+		assert.Equal(t, msg.BeginTs(), uint64(14))
+	}
+	inputStream.Close()
+	outputStream.Close()
+}
+
+func TestStream_KafkaMsgStream_SeekToTimestamp(t *testing.T) {
+	channels := []string{funcutil.RandomString(8)}
+	inputStream := getKafkaInputStream(t, channels)
+
+	msgPack1 := &MsgPack{}
+	msgPack1.Msgs = append(msgPack1.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 1, nowTs()))
+	err := inputStream.Produce(msgPack1)
+	assert.Nil(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	seekTs := nowTs()
+	time.Sleep(20 * time.Millisecond)
+
+	msgPack2 := &MsgPack{}
+	msgPack2.Msgs = append(msgPack2.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 2, nowTs()))
+	err = inputStream.Produce(msgPack2)
+	assert.Nil(t, err)
+
+	factory := ProtoUDFactory{}
+	outputStream, _ := NewMqMsgStream(context.Background(), 100, 100, getKafkaClient(t), factory.NewUnmarshalDispatcher())
+	outputStream.AsConsumer(channels, funcutil.RandomString(8))
+
+	err = outputStream.SeekToTimestamp([]string{"unknown_channel"}, seekTs)
+	assert.Error(t, err)
+
+	err = outputStream.SeekToTimestamp(channels, seekTs)
+	assert.Nil(t, err)
+	outputStream.Start()
+
+	result := outputStream.Consume()
+	assert.Equal(t, 1, len(result.Msgs))
+	assert.Equal(t, int64(2), result.Msgs[0].ID())
+	assert.True(t, result.Msgs[0].BeginTs() >= seekTs)
+
+	inputStream.Close()
+	outputStream.Close()
+}
+
+func TestKmsFactory(t *testing.T) {
+	kafkaAddress, _ := Params.Load("_KafkaAddress")
+	kmsFactory := NewKmsFactory(kafkaAddress)
+
+	m := map[string]interface{}{
+		"ReceiveBufSize": 1024,
+		"KafkaBufSize":   1024,
+	}
+	err := kmsFactory.SetParams(m)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	_, err = kmsFactory.NewMsgStream(ctx)
+	assert.Nil(t, err)
+
+	_, err = kmsFactory.NewTtMsgStream(ctx)
+	assert.Nil(t, err)
+
+	_, err = kmsFactory.NewQueryMsgStream(ctx)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+//go:build !kafka
+// +build !kafka
+
+package msgstream
+
+import "testing"
+
+// kafkaParameters returns no client unless the tests are built with the kafka tag, see mq_msgstream_kafka_test.go
+func kafkaParameters(t *testing.T) []parameters {
+	return nil
+}
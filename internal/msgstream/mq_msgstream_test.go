@@ -34,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 	client "github.com/milvus-io/milvus/internal/util/rocksmq/client/rocksmq"
 	"github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 )
 
 var Params paramtable.BaseTable
@@ -80,6 +81,8 @@ func (f *fixture) setup() []parameters {
 	parameters := []parameters{
 		{pulsarClient}, {rmqClient},
 	}
+	// the kafka client joins the shared tests when built with the kafka tag
+	parameters = append(parameters, kafkaParameters(f.t)...)
 	return parameters
 }
 
@@ -1619,3 +1622,119 @@ func printMsgPack(msgPack *MsgPack) {
 	}
 	log.Println("================")
 }
+
+func getTsMsgWithTimestamp(msgType MsgType, reqID UniqueID, ts Timestamp) TsMsg {
+	var msg TsMsg
+	if msgType == commonpb.MsgType_TimeTick {
+		msg = getTimeTickMsg(reqID)
+		msg.(*TimeTickMsg).BeginTimestamp = ts
+		msg.(*TimeTickMsg).EndTimestamp = ts
+		msg.(*TimeTickMsg).Base.Timestamp = ts
+		return msg
+	}
+	msg = getTsMsg(msgType, reqID)
+	msg.(*InsertMsg).BeginTimestamp = ts
+	msg.(*InsertMsg).EndTimestamp = ts
+	msg.(*InsertMsg).Timestamps = []Timestamp{ts}
+	return msg
+}
+
+func nowTs() Timestamp {
+	return tsoutil.ComposeTS(time.Now().UnixNano()/int64(time.Millisecond), 0)
+}
+
+func TestStream_RmqMsgStream_SeekToTimestamp(t *testing.T) {
+	channels := []string{"seek_ts_insert"}
+	rocksdbName := "/tmp/rocksmq_seek_ts"
+	etcdKV := initRmq(rocksdbName)
+	factory := ProtoUDFactory{}
+
+	rmqClient, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+	inputStream, _ := NewMqMsgStream(context.Background(), 100, 100, rmqClient, factory.NewUnmarshalDispatcher())
+	inputStream.AsProducer(channels)
+	inputStream.Start()
+
+	msgPack1 := &MsgPack{}
+	msgPack1.Msgs = append(msgPack1.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 1, nowTs()))
+	err := inputStream.Produce(msgPack1)
+	assert.Nil(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	seekTs := nowTs()
+	time.Sleep(20 * time.Millisecond)
+
+	msgPack2 := &MsgPack{}
+	msgPack2.Msgs = append(msgPack2.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 2, nowTs()))
+	err = inputStream.Produce(msgPack2)
+	assert.Nil(t, err)
+
+	rmqClient2, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+	outputStream, _ := NewMqMsgStream(context.Background(), 100, 100, rmqClient2, factory.NewUnmarshalDispatcher())
+	outputStream.AsConsumer(channels, "seek_ts_group")
+
+	err = outputStream.SeekToTimestamp([]string{"unknown_channel"}, seekTs)
+	assert.Error(t, err)
+
+	err = outputStream.SeekToTimestamp(channels, seekTs)
+	assert.Nil(t, err)
+	outputStream.Start()
+
+	result := outputStream.Consume()
+	assert.Equal(t, 1, len(result.Msgs))
+	assert.Equal(t, int64(2), result.Msgs[0].ID())
+	assert.True(t, result.Msgs[0].BeginTs() >= seekTs)
+
+	Close(rocksdbName, inputStream, outputStream, etcdKV)
+}
+
+func TestStream_RmqTtMsgStream_SeekToTimestamp(t *testing.T) {
+	channels := []string{"seek_ts_insert_tt"}
+	rocksdbName := "/tmp/rocksmq_seek_ts_tt"
+	etcdKV := initRmq(rocksdbName)
+	factory := ProtoUDFactory{}
+
+	rmqClient, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+	inputStream, _ := NewMqMsgStream(context.Background(), 100, 100, rmqClient, factory.NewUnmarshalDispatcher())
+	inputStream.AsProducer(channels)
+	inputStream.Start()
+
+	ts1 := nowTs()
+	msgPack1 := &MsgPack{}
+	msgPack1.Msgs = append(msgPack1.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 1, ts1))
+	err := inputStream.Produce(msgPack1)
+	assert.Nil(t, err)
+	err = inputStream.Broadcast(&MsgPack{Msgs: []TsMsg{getTsMsgWithTimestamp(commonpb.MsgType_TimeTick, 1, ts1)}})
+	assert.Nil(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	seekTs := nowTs()
+	time.Sleep(20 * time.Millisecond)
+
+	ts2 := nowTs()
+	msgPack2 := &MsgPack{}
+	msgPack2.Msgs = append(msgPack2.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 2, ts2))
+	msgPack2.Msgs = append(msgPack2.Msgs, getTsMsgWithTimestamp(commonpb.MsgType_Insert, 3, ts2))
+	err = inputStream.Produce(msgPack2)
+	assert.Nil(t, err)
+	err = inputStream.Broadcast(&MsgPack{Msgs: []TsMsg{getTsMsgWithTimestamp(commonpb.MsgType_TimeTick, 2, ts2)}})
+	assert.Nil(t, err)
+	ts3 := nowTs() + 1
+	err = inputStream.Broadcast(&MsgPack{Msgs: []TsMsg{getTsMsgWithTimestamp(commonpb.MsgType_TimeTick, 3, ts3)}})
+	assert.Nil(t, err)
+
+	rmqClient2, _ := mqclient.NewRmqClient(client.ClientOptions{Server: rocksmq.Rmq})
+	outputStream, _ := NewMqTtMsgStream(context.Background(), 100, 100, rmqClient2, factory.NewUnmarshalDispatcher())
+	outputStream.AsConsumer(channels, "seek_ts_tt_group")
+	err = outputStream.SeekToTimestamp(channels, seekTs)
+	assert.Nil(t, err)
+	outputStream.Start()
+
+	result := outputStream.Consume()
+	assert.Equal(t, 2, len(result.Msgs))
+	assert.Equal(t, int64(2), result.Msgs[0].ID())
+	assert.Equal(t, int64(3), result.Msgs[1].ID())
+	assert.Equal(t, 1, len(result.StartPositions))
+	assert.Equal(t, result.Msgs[0].Position().MsgID, result.StartPositions[0].MsgID)
+
+	Close(rocksdbName, inputStream, outputStream, etcdKV)
+}
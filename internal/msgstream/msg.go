@@ -269,6 +269,11 @@ func (st *SearchMsg) TravelTs() Timestamp {
 	return st.GetTravelTimestamp()
 }
 
+// TimeoutTs returns the timestamp after which the search request is expired, 0 means no timeout
+func (st *SearchMsg) TimeoutTs() Timestamp {
+	return st.GetTimeoutTimestamp()
+}
+
 // Marshal is used to serializing a message pack to byte array
 func (st *SearchMsg) Marshal(input TsMsg) (MarshalType, error) {
 	searchTask := input.(*SearchMsg)
@@ -391,6 +396,11 @@ func (rm *RetrieveMsg) TravelTs() Timestamp {
 	return rm.GetTravelTimestamp()
 }
 
+// TimeoutTs returns 0, query requests don't carry a timeout
+func (rm *RetrieveMsg) TimeoutTs() Timestamp {
+	return 0
+}
+
 // Marshal is used to serializing a message pack to byte array
 func (rm *RetrieveMsg) Marshal(input TsMsg) (MarshalType, error) {
 	retrieveTask := input.(*RetrieveMsg)
@@ -200,6 +200,7 @@ func TestSearchMsg(t *testing.T) {
 			OutputFieldsId:     []int64{},
 			TravelTimestamp:    6,
 			GuaranteeTimestamp: 7,
+			TimeoutTimestamp:   8,
 		},
 	}
 
@@ -214,6 +215,7 @@ func TestSearchMsg(t *testing.T) {
 	assert.Equal(t, int64(3), searchMsg.SourceID())
 	assert.Equal(t, uint64(7), searchMsg.GuaranteeTs())
 	assert.Equal(t, uint64(6), searchMsg.TravelTs())
+	assert.Equal(t, uint64(8), searchMsg.TimeoutTs())
 
 	bytes, err := searchMsg.Marshal(searchMsg)
 	assert.Nil(t, err)
@@ -228,6 +230,7 @@ func TestSearchMsg(t *testing.T) {
 	assert.Equal(t, int64(3), searchMsg2.SourceID())
 	assert.Equal(t, uint64(7), searchMsg2.GuaranteeTs())
 	assert.Equal(t, uint64(6), searchMsg2.TravelTs())
+	assert.Equal(t, uint64(8), searchMsg2.TimeoutTs())
 }
 
 func TestSearchMsg_Unmarshal_IllegalParameter(t *testing.T) {
@@ -319,6 +322,7 @@ func TestRetrieveMsg(t *testing.T) {
 	assert.Equal(t, int64(3), retrieveMsg.SourceID())
 	assert.Equal(t, uint64(11), retrieveMsg.GuaranteeTs())
 	assert.Equal(t, uint64(10), retrieveMsg.TravelTs())
+	assert.Equal(t, uint64(0), retrieveMsg.TimeoutTs())
 
 	bytes, err := retrieveMsg.Marshal(retrieveMsg)
 	assert.Nil(t, err)
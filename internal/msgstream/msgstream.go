@@ -34,6 +34,10 @@ type MsgPosition = internalpb.MsgPosition
 // MessageID is an alias for short
 type MessageID = mqclient.MessageID
 
+// ErrPositionExpired is returned by Seek when the messages after the position are removed by the retention
+// of message queue, the callers should do a full recovery instead of consuming from the position
+var ErrPositionExpired = mqclient.ErrPositionExpired
+
 // MsgPack represents a batch of msg in msgstream
 type MsgPack struct {
 	BeginTs        Timestamp
@@ -50,6 +54,9 @@ type RepackFunc func(msgs []TsMsg, hashKeys [][]int32) (map[int32]*MsgPack, erro
 type MsgStream interface {
 	Start()
 	Close()
+	// Unsubscribe closes the stream like Close, and deletes the subscriptions of the consumers, it's used if the
+	// subscriptions are not shared and won't be resumed
+	Unsubscribe()
 	Chan() <-chan *MsgPack
 	AsProducer(channels []string)
 	AsConsumer(channels []string, subName string)
@@ -63,6 +70,8 @@ type MsgStream interface {
 	BroadcastMark(*MsgPack) (map[string][]MessageID, error)
 	Consume() *MsgPack
 	Seek(offset []*MsgPosition) error
+	// SeekToTimestamp seeks the consumers of channels to the first messages whose timestamps are at or after ts
+	SeekToTimestamp(channels []string, ts Timestamp) error
 }
 
 // Factory is an interface that can be used to generate a new msgstream object
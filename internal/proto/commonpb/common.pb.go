@@ -49,6 +49,8 @@ const (
 	ErrorCode_OutOfMemory           ErrorCode = 24
 	ErrorCode_IndexNotExist         ErrorCode = 25
 	ErrorCode_EmptyCollection       ErrorCode = 26
+	ErrorCode_RateLimit             ErrorCode = 27
+	ErrorCode_SegmentStatsMismatch  ErrorCode = 28
 	// internal error code.
 	ErrorCode_DDRequestRace ErrorCode = 1000
 )
@@ -80,6 +82,8 @@ var ErrorCode_name = map[int32]string{
 	24:   "OutOfMemory",
 	25:   "IndexNotExist",
 	26:   "EmptyCollection",
+	27:   "RateLimit",
+	28:   "SegmentStatsMismatch",
 	1000: "DDRequestRace",
 }
 
@@ -110,6 +114,8 @@ var ErrorCode_value = map[string]int32{
 	"OutOfMemory":           24,
 	"IndexNotExist":         25,
 	"EmptyCollection":       26,
+	"RateLimit":             27,
+	"SegmentStatsMismatch":  28,
 	"DDRequestRace":         1000,
 }
 
@@ -164,6 +170,7 @@ const (
 	SegmentState_Sealed           SegmentState = 3
 	SegmentState_Flushed          SegmentState = 4
 	SegmentState_Flushing         SegmentState = 5
+	SegmentState_Dropped          SegmentState = 6
 )
 
 var SegmentState_name = map[int32]string{
@@ -173,6 +180,7 @@ var SegmentState_name = map[int32]string{
 	3: "Sealed",
 	4: "Flushed",
 	5: "Flushing",
+	6: "Dropped",
 }
 
 var SegmentState_value = map[string]int32{
@@ -182,6 +190,7 @@ var SegmentState_value = map[string]int32{
 	"Sealed":           3,
 	"Flushed":          4,
 	"Flushing":         5,
+	"Dropped":          6,
 }
 
 func (x SegmentState) String() string {
@@ -208,6 +217,7 @@ const (
 	MsgType_CreateAlias        MsgType = 108
 	MsgType_DropAlias          MsgType = 109
 	MsgType_AlterAlias         MsgType = 110
+	MsgType_AlterCollection    MsgType = 111
 	// DEFINITION REQUESTS: PARTITION
 	MsgType_CreatePartition   MsgType = 200
 	MsgType_DropPartition     MsgType = 201
@@ -231,6 +241,7 @@ const (
 	MsgType_Insert MsgType = 400
 	MsgType_Delete MsgType = 401
 	MsgType_Flush  MsgType = 402
+	MsgType_Import MsgType = 403
 	// QUERY
 	MsgType_Search                   MsgType = 500
 	MsgType_SearchResult             MsgType = 501
@@ -272,6 +283,7 @@ var MsgType_name = map[int32]string{
 	108:  "CreateAlias",
 	109:  "DropAlias",
 	110:  "AlterAlias",
+	111:  "AlterCollection",
 	200:  "CreatePartition",
 	201:  "DropPartition",
 	202:  "HasPartition",
@@ -291,6 +303,7 @@ var MsgType_name = map[int32]string{
 	400:  "Insert",
 	401:  "Delete",
 	402:  "Flush",
+	403:  "Import",
 	500:  "Search",
 	501:  "SearchResult",
 	502:  "GetIndexState",
@@ -329,6 +342,7 @@ var MsgType_value = map[string]int32{
 	"CreateAlias":              108,
 	"DropAlias":                109,
 	"AlterAlias":               110,
+	"AlterCollection":          111,
 	"CreatePartition":          200,
 	"DropPartition":            201,
 	"HasPartition":             202,
@@ -348,6 +362,7 @@ var MsgType_value = map[string]int32{
 	"Insert":                   400,
 	"Delete":                   401,
 	"Flush":                    402,
+	"Import":                   403,
 	"Search":                   500,
 	"SearchResult":             501,
 	"GetIndexState":            502,
@@ -381,6 +396,40 @@ func (MsgType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_555bd8c177793206, []int{3}
 }
 
+type ImportState int32
+
+const (
+	ImportState_ImportPending   ImportState = 0
+	ImportState_ImportFailed    ImportState = 1
+	ImportState_ImportStarted   ImportState = 2
+	ImportState_ImportPersisted ImportState = 3
+	ImportState_ImportCompleted ImportState = 4
+)
+
+var ImportState_name = map[int32]string{
+	0: "ImportPending",
+	1: "ImportFailed",
+	2: "ImportStarted",
+	3: "ImportPersisted",
+	4: "ImportCompleted",
+}
+
+var ImportState_value = map[string]int32{
+	"ImportPending":   0,
+	"ImportFailed":    1,
+	"ImportStarted":   2,
+	"ImportPersisted": 3,
+	"ImportCompleted": 4,
+}
+
+func (x ImportState) String() string {
+	return proto.EnumName(ImportState_name, int32(x))
+}
+
+func (ImportState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{4}
+}
+
 type DslType int32
 
 const (
@@ -403,12 +452,73 @@ func (x DslType) String() string {
 }
 
 func (DslType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{4}
+	return fileDescriptor_555bd8c177793206, []int{5}
+}
+
+type ConsistencyLevel int32
+
+const (
+	ConsistencyLevel_Strong           ConsistencyLevel = 0
+	ConsistencyLevel_Session          ConsistencyLevel = 1
+	ConsistencyLevel_BoundedStaleness ConsistencyLevel = 2
+	ConsistencyLevel_Eventually       ConsistencyLevel = 3
+)
+
+var ConsistencyLevel_name = map[int32]string{
+	0: "Strong",
+	1: "Session",
+	2: "BoundedStaleness",
+	3: "Eventually",
+}
+
+var ConsistencyLevel_value = map[string]int32{
+	"Strong":           0,
+	"Session":          1,
+	"BoundedStaleness": 2,
+	"Eventually":       3,
+}
+
+func (x ConsistencyLevel) String() string {
+	return proto.EnumName(ConsistencyLevel_name, int32(x))
+}
+
+func (ConsistencyLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{6}
+}
+
+// UnindexedSegmentPolicy decides how loading handles the segments whose index is not built yet
+type UnindexedSegmentPolicy int32
+
+const (
+	UnindexedSegmentPolicy_LoadUnindexed   UnindexedSegmentPolicy = 0
+	UnindexedSegmentPolicy_WaitForIndex    UnindexedSegmentPolicy = 1
+	UnindexedSegmentPolicy_FailOnUnindexed UnindexedSegmentPolicy = 2
+)
+
+var UnindexedSegmentPolicy_name = map[int32]string{
+	0: "LoadUnindexed",
+	1: "WaitForIndex",
+	2: "FailOnUnindexed",
+}
+
+var UnindexedSegmentPolicy_value = map[string]int32{
+	"LoadUnindexed":   0,
+	"WaitForIndex":    1,
+	"FailOnUnindexed": 2,
+}
+
+func (x UnindexedSegmentPolicy) String() string {
+	return proto.EnumName(UnindexedSegmentPolicy_name, int32(x))
+}
+
+func (UnindexedSegmentPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{7}
 }
 
 type Status struct {
 	ErrorCode            ErrorCode `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3,enum=milvus.proto.common.ErrorCode" json:"error_code,omitempty"`
 	Reason               string    `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Detail               string    `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -453,6 +563,13 @@ func (m *Status) GetReason() string {
 	return ""
 }
 
+func (m *Status) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
 type KeyValuePair struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -789,7 +906,10 @@ func init() {
 	proto.RegisterEnum("milvus.proto.common.IndexState", IndexState_name, IndexState_value)
 	proto.RegisterEnum("milvus.proto.common.SegmentState", SegmentState_name, SegmentState_value)
 	proto.RegisterEnum("milvus.proto.common.MsgType", MsgType_name, MsgType_value)
+	proto.RegisterEnum("milvus.proto.common.ImportState", ImportState_name, ImportState_value)
 	proto.RegisterEnum("milvus.proto.common.DslType", DslType_name, DslType_value)
+	proto.RegisterEnum("milvus.proto.common.ConsistencyLevel", ConsistencyLevel_name, ConsistencyLevel_value)
+	proto.RegisterEnum("milvus.proto.common.UnindexedSegmentPolicy", UnindexedSegmentPolicy_name, UnindexedSegmentPolicy_value)
 	proto.RegisterType((*Status)(nil), "milvus.proto.common.Status")
 	proto.RegisterType((*KeyValuePair)(nil), "milvus.proto.common.KeyValuePair")
 	proto.RegisterType((*KeyDataPair)(nil), "milvus.proto.common.KeyDataPair")
@@ -803,91 +923,103 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 1371 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4b, 0x73, 0x1b, 0x37,
-	0x12, 0xd6, 0x70, 0x28, 0x51, 0x84, 0x28, 0x09, 0x82, 0x1e, 0x96, 0xbd, 0xda, 0x2d, 0x17, 0x4f,
-	0x2e, 0x55, 0x59, 0xda, 0x5d, 0xd7, 0x6e, 0x4e, 0x3e, 0x48, 0x1c, 0x3d, 0x58, 0xb6, 0x1e, 0x19,
-	0xca, 0x4e, 0x2a, 0x87, 0xb8, 0xa0, 0x99, 0x26, 0x89, 0x78, 0x06, 0x60, 0x00, 0x50, 0x16, 0x6f,
-	0xf9, 0x09, 0x89, 0x7f, 0x47, 0x92, 0xca, 0x3b, 0xa9, 0xfc, 0x82, 0xbc, 0x2f, 0xb9, 0xe4, 0x27,
-	0xe4, 0x07, 0xe4, 0xe9, 0x67, 0xaa, 0x31, 0x43, 0x72, 0x5c, 0x65, 0x9f, 0x72, 0x43, 0x7f, 0xdd,
-	0xf8, 0xd0, 0xfd, 0x75, 0x03, 0x33, 0xa4, 0x16, 0xa9, 0x34, 0x55, 0x72, 0xa3, 0xa7, 0x95, 0x55,
-	0x6c, 0x31, 0x15, 0xc9, 0x59, 0xdf, 0x64, 0xd6, 0x46, 0xe6, 0xaa, 0xdf, 0x21, 0x53, 0x2d, 0xcb,
-	0x6d, 0xdf, 0xb0, 0xeb, 0x84, 0x80, 0xd6, 0x4a, 0xdf, 0x89, 0x54, 0x0c, 0xab, 0xde, 0x65, 0xef,
-	0xca, 0xdc, 0x7f, 0xff, 0xb5, 0xf1, 0x9c, 0x3d, 0x1b, 0x3b, 0x18, 0xd6, 0x50, 0x31, 0x84, 0x55,
-	0x18, 0x2e, 0xd9, 0x0a, 0x99, 0xd2, 0xc0, 0x8d, 0x92, 0xab, 0xa5, 0xcb, 0xde, 0x95, 0x6a, 0x98,
-	0x5b, 0xf5, 0xff, 0x93, 0xda, 0x0d, 0x18, 0xdc, 0xe6, 0x49, 0x1f, 0x8e, 0xb9, 0xd0, 0x8c, 0x12,
-	0xff, 0x2e, 0x0c, 0x1c, 0x7f, 0x35, 0xc4, 0x25, 0x5b, 0x22, 0x93, 0x67, 0xe8, 0xce, 0x37, 0x66,
-	0x46, 0xfd, 0x1a, 0x99, 0xb9, 0x01, 0x83, 0x80, 0x5b, 0xfe, 0x82, 0x6d, 0x8c, 0x94, 0x63, 0x6e,
-	0xb9, 0xdb, 0x55, 0x0b, 0xdd, 0xba, 0xbe, 0x46, 0xca, 0xdb, 0x89, 0x3a, 0x1d, 0x53, 0x7a, 0xce,
-	0x99, 0x53, 0x5e, 0x25, 0x95, 0xad, 0x38, 0xd6, 0x60, 0x0c, 0x9b, 0x23, 0x25, 0xd1, 0xcb, 0xd9,
-	0x4a, 0xa2, 0x87, 0x64, 0x3d, 0xa5, 0xad, 0x23, 0xf3, 0x43, 0xb7, 0xae, 0xdf, 0xf7, 0x48, 0xe5,
-	0xc0, 0x74, 0xb6, 0xb9, 0x01, 0xf6, 0x12, 0x99, 0x4e, 0x4d, 0xe7, 0x8e, 0x1d, 0xf4, 0x86, 0xd2,
-	0xac, 0x3d, 0x57, 0x9a, 0x03, 0xd3, 0x39, 0x19, 0xf4, 0x20, 0xac, 0xa4, 0xd9, 0x02, 0x33, 0x49,
-	0x4d, 0xa7, 0x19, 0xe4, 0xcc, 0x99, 0xc1, 0xd6, 0x48, 0xd5, 0x8a, 0x14, 0x8c, 0xe5, 0x69, 0x6f,
-	0xd5, 0xbf, 0xec, 0x5d, 0x29, 0x87, 0x63, 0x80, 0x5d, 0x22, 0xd3, 0x46, 0xf5, 0x75, 0x04, 0xcd,
-	0x60, 0xb5, 0xec, 0xb6, 0x8d, 0xec, 0xfa, 0x75, 0x52, 0x3d, 0x30, 0x9d, 0x7d, 0xe0, 0x31, 0x68,
-	0xf6, 0x6f, 0x52, 0x3e, 0xe5, 0x26, 0xcb, 0x68, 0xe6, 0xc5, 0x19, 0x61, 0x05, 0xa1, 0x8b, 0xac,
-	0xbf, 0x4e, 0x6a, 0xc1, 0xc1, 0xcd, 0xbf, 0xc1, 0x80, 0xa9, 0x9b, 0x2e, 0xd7, 0xf1, 0x21, 0x4f,
-	0x87, 0x1d, 0x1b, 0x03, 0xeb, 0x5f, 0x96, 0x49, 0x75, 0x34, 0x1e, 0x6c, 0x86, 0x54, 0x5a, 0xfd,
-	0x28, 0x02, 0x63, 0xe8, 0x04, 0x5b, 0x24, 0xf3, 0xb7, 0x24, 0x9c, 0xf7, 0x20, 0xb2, 0x10, 0xbb,
-	0x18, 0xea, 0xb1, 0x05, 0x32, 0xdb, 0x50, 0x52, 0x42, 0x64, 0x77, 0xb9, 0x48, 0x20, 0xa6, 0x25,
-	0xb6, 0x44, 0xe8, 0x31, 0xe8, 0x54, 0x18, 0x23, 0x94, 0x0c, 0x40, 0x0a, 0x88, 0xa9, 0xcf, 0x2e,
-	0x90, 0xc5, 0x86, 0x4a, 0x12, 0x88, 0xac, 0x50, 0xf2, 0x50, 0xd9, 0x9d, 0x73, 0x61, 0xac, 0xa1,
-	0x65, 0xa4, 0x6d, 0x26, 0x09, 0x74, 0x78, 0xb2, 0xa5, 0x3b, 0xfd, 0x14, 0xa4, 0xa5, 0x93, 0xc8,
-	0x91, 0x83, 0x81, 0x48, 0x41, 0x22, 0x13, 0xad, 0x14, 0xd0, 0xa6, 0x8c, 0xe1, 0x1c, 0xfb, 0x43,
-	0xa7, 0xd9, 0x45, 0xb2, 0x9c, 0xa3, 0x85, 0x03, 0x78, 0x0a, 0xb4, 0xca, 0xe6, 0xc9, 0x4c, 0xee,
-	0x3a, 0x39, 0x3a, 0xbe, 0x41, 0x49, 0x81, 0x21, 0x54, 0xf7, 0x42, 0x88, 0x94, 0x8e, 0xe9, 0x4c,
-	0x21, 0x85, 0xdb, 0x10, 0x59, 0xa5, 0x9b, 0x01, 0xad, 0x61, 0xc2, 0x39, 0xd8, 0x02, 0xae, 0xa3,
-	0x6e, 0x08, 0xa6, 0x9f, 0x58, 0x3a, 0xcb, 0x28, 0xa9, 0xed, 0x8a, 0x04, 0x0e, 0x95, 0xdd, 0x55,
-	0x7d, 0x19, 0xd3, 0x39, 0x36, 0x47, 0xc8, 0x01, 0x58, 0x9e, 0x2b, 0x30, 0x8f, 0xc7, 0x36, 0x78,
-	0xd4, 0x85, 0x1c, 0xa0, 0x6c, 0x85, 0xb0, 0x06, 0x97, 0x52, 0xd9, 0x86, 0x06, 0x6e, 0x61, 0x57,
-	0x25, 0x31, 0x68, 0xba, 0x80, 0xe9, 0x3c, 0x83, 0x8b, 0x04, 0x28, 0x1b, 0x47, 0x07, 0x90, 0xc0,
-	0x28, 0x7a, 0x71, 0x1c, 0x9d, 0xe3, 0x18, 0xbd, 0x84, 0xc9, 0x6f, 0xf7, 0x45, 0x12, 0x3b, 0x49,
-	0xb2, 0xb6, 0x2c, 0x63, 0x8e, 0x79, 0xf2, 0x87, 0x37, 0x9b, 0xad, 0x13, 0xba, 0xc2, 0x96, 0xc9,
-	0x42, 0x8e, 0x1c, 0x80, 0xd5, 0x22, 0x72, 0xe2, 0x5d, 0xc0, 0x54, 0x8f, 0xfa, 0xf6, 0xa8, 0x7d,
-	0x00, 0xa9, 0xd2, 0x03, 0xba, 0x8a, 0x0d, 0x75, 0x4c, 0xc3, 0x16, 0xd1, 0x8b, 0x78, 0xc2, 0x4e,
-	0xda, 0xb3, 0x83, 0xb1, 0xbc, 0xf4, 0x12, 0x63, 0x64, 0x36, 0x08, 0x42, 0x78, 0xb3, 0x0f, 0xc6,
-	0x86, 0x3c, 0x02, 0xfa, 0x73, 0x65, 0xfd, 0x55, 0x42, 0xdc, 0x5e, 0x7c, 0x90, 0x80, 0x31, 0x32,
-	0x37, 0xb6, 0x0e, 0x95, 0x04, 0x3a, 0xc1, 0x6a, 0x64, 0xfa, 0x96, 0x14, 0xc6, 0xf4, 0x21, 0xa6,
-	0x1e, 0xea, 0xd6, 0x94, 0xc7, 0x5a, 0x75, 0xf0, 0x4a, 0xd3, 0x12, 0x7a, 0x77, 0x85, 0x14, 0xa6,
-	0xeb, 0x26, 0x86, 0x90, 0xa9, 0x5c, 0xc0, 0xf2, 0x7a, 0x9b, 0xd4, 0x5a, 0xd0, 0xc1, 0xe1, 0xc8,
-	0xb8, 0x97, 0x08, 0x2d, 0xda, 0x63, 0xf6, 0x51, 0xda, 0x1e, 0x0e, 0xef, 0x9e, 0x56, 0xf7, 0x84,
-	0xec, 0xd0, 0x12, 0x92, 0xb5, 0x80, 0x27, 0x8e, 0x78, 0x86, 0x54, 0x76, 0x93, 0xbe, 0x3b, 0xa5,
-	0xec, 0xce, 0x44, 0x03, 0xc3, 0x26, 0xd7, 0x7f, 0x9c, 0x76, 0x4f, 0x86, 0xbb, 0xf9, 0xb3, 0xa4,
-	0x7a, 0x4b, 0xc6, 0xd0, 0x16, 0x12, 0x62, 0x3a, 0xe1, 0xd4, 0x77, 0x5d, 0x2a, 0xc8, 0x10, 0x63,
-	0x91, 0x81, 0x56, 0xbd, 0x02, 0x06, 0x28, 0xe1, 0x3e, 0x37, 0x05, 0xa8, 0x8d, 0x2d, 0x0d, 0xc0,
-	0x44, 0x5a, 0x9c, 0x16, 0xb7, 0x77, 0x50, 0xda, 0x56, 0x57, 0xdd, 0x1b, 0x63, 0x86, 0x76, 0xf1,
-	0xa4, 0x3d, 0xb0, 0xad, 0x81, 0xb1, 0x90, 0x36, 0x94, 0x6c, 0x8b, 0x8e, 0xa1, 0x02, 0x4f, 0xba,
-	0xa9, 0x78, 0x5c, 0xd8, 0xfe, 0x06, 0x36, 0x35, 0x84, 0x04, 0xb8, 0x29, 0xb2, 0xde, 0x75, 0xf3,
-	0xe7, 0x52, 0xdd, 0x4a, 0x04, 0x37, 0x34, 0xc1, 0x52, 0x30, 0xcb, 0xcc, 0x4c, 0x51, 0xf7, 0xad,
-	0xc4, 0x82, 0xce, 0x6c, 0xc9, 0x96, 0xc8, 0x7c, 0x16, 0x7f, 0xcc, 0xb5, 0x15, 0x8e, 0xe4, 0x2b,
-	0xcf, 0x75, 0x58, 0xab, 0xde, 0x18, 0xfb, 0x1a, 0xaf, 0x7b, 0x6d, 0x9f, 0x9b, 0x31, 0xf4, 0x8d,
-	0xc7, 0x56, 0xc8, 0xc2, 0xb0, 0xb4, 0x31, 0xfe, 0xad, 0xc7, 0x16, 0xc9, 0x1c, 0x96, 0x36, 0xc2,
-	0x0c, 0xfd, 0xce, 0x81, 0x58, 0x44, 0x01, 0xfc, 0xde, 0x31, 0xe4, 0x55, 0x14, 0xf0, 0x1f, 0xdc,
-	0x61, 0xc8, 0x90, 0x37, 0xda, 0xd0, 0x07, 0x1e, 0x66, 0x3a, 0x3c, 0x2c, 0x87, 0xe9, 0x43, 0x17,
-	0x88, 0xac, 0xa3, 0xc0, 0x47, 0x2e, 0x30, 0xe7, 0x1c, 0xa1, 0x8f, 0x1d, 0xba, 0xcf, 0x65, 0xac,
-	0xda, 0xed, 0x11, 0xfa, 0xc4, 0x63, 0xab, 0x64, 0x11, 0xb7, 0x6f, 0xf3, 0x84, 0xcb, 0x68, 0x1c,
-	0xff, 0xd4, 0x63, 0x74, 0x28, 0xa4, 0x1b, 0x64, 0xfa, 0x6e, 0xc9, 0x89, 0x92, 0x27, 0x90, 0x61,
-	0xef, 0x95, 0xd8, 0x5c, 0xa6, 0x6e, 0x66, 0xbf, 0x5f, 0x62, 0x33, 0x64, 0xaa, 0x29, 0x0d, 0x68,
-	0x4b, 0xdf, 0xc6, 0x61, 0x9b, 0xca, 0xae, 0x2b, 0x7d, 0x07, 0x47, 0x7a, 0xd2, 0x0d, 0x1b, 0xbd,
-	0xef, 0x1c, 0xd9, 0xc3, 0x42, 0x7f, 0xf1, 0x5d, 0xa9, 0xc5, 0x57, 0xe6, 0x57, 0x1f, 0x4f, 0xda,
-	0x03, 0x3b, 0xbe, 0x41, 0xf4, 0x37, 0x9f, 0x5d, 0x22, 0xcb, 0x43, 0xcc, 0xdd, 0xf9, 0xd1, 0xdd,
-	0xf9, 0xdd, 0x67, 0x6b, 0xe4, 0xc2, 0x1e, 0xd8, 0xf1, 0x1c, 0xe0, 0x26, 0x61, 0xac, 0x88, 0x0c,
-	0xfd, 0xc3, 0x67, 0xff, 0x20, 0x2b, 0x7b, 0x60, 0x47, 0xfa, 0x16, 0x9c, 0x7f, 0xfa, 0x6c, 0x96,
-	0x4c, 0x87, 0xf8, 0x28, 0xc0, 0x19, 0xd0, 0x07, 0x3e, 0x36, 0x69, 0x68, 0xe6, 0xe9, 0x3c, 0xf4,
-	0x51, 0xba, 0x57, 0xb8, 0x8d, 0xba, 0x41, 0xda, 0xe8, 0x72, 0x29, 0x21, 0x31, 0xf4, 0x91, 0xcf,
-	0x96, 0x09, 0x0d, 0x21, 0x55, 0x67, 0x50, 0x80, 0x1f, 0xe3, 0x63, 0xcf, 0x5c, 0xf0, 0xcb, 0x7d,
-	0xd0, 0x83, 0x91, 0xe3, 0x89, 0x8f, 0x52, 0x67, 0xf1, 0xcf, 0x7a, 0x9e, 0xfa, 0xec, 0x9f, 0x64,
-	0x35, 0xbb, 0xa0, 0x43, 0xfd, 0xd1, 0xd9, 0x81, 0xa6, 0x6c, 0x2b, 0xfa, 0x56, 0x19, 0x3b, 0x91,
-	0x3b, 0x1c, 0xf2, 0x53, 0x19, 0x93, 0x3e, 0x11, 0x29, 0x9c, 0x88, 0xe8, 0x2e, 0xfd, 0xa0, 0x8a,
-	0x49, 0x3b, 0xce, 0x43, 0x15, 0x03, 0x56, 0x67, 0xe8, 0x87, 0x55, 0xec, 0x0c, 0x76, 0x36, 0xeb,
-	0xcc, 0x47, 0xce, 0xce, 0x9f, 0xac, 0x66, 0x40, 0x3f, 0xc6, 0xef, 0x03, 0xc9, 0xed, 0x93, 0xd6,
-	0x11, 0xfd, 0xa4, 0x8a, 0x55, 0x6e, 0x25, 0x89, 0x8a, 0xb8, 0x1d, 0xcd, 0xd7, 0xa7, 0x55, 0x1c,
-	0xd0, 0xc2, 0x6b, 0x93, 0xeb, 0xf6, 0x59, 0x15, 0xab, 0xcf, 0x71, 0xd7, 0xd5, 0x00, 0x5f, 0xa1,
-	0xcf, 0x1d, 0x2b, 0xfe, 0xf6, 0x60, 0x26, 0x27, 0x96, 0x7e, 0x51, 0x5d, 0xaf, 0x93, 0x4a, 0x60,
-	0x12, 0xf7, 0xa8, 0x54, 0x88, 0x1f, 0x98, 0x84, 0x4e, 0xe0, 0x1d, 0xdc, 0x56, 0x2a, 0xd9, 0x39,
-	0xef, 0xe9, 0xdb, 0xff, 0xa1, 0xde, 0xf6, 0xff, 0x5e, 0xbb, 0xd6, 0x11, 0xb6, 0xdb, 0x3f, 0xc5,
-	0xaf, 0xf6, 0x66, 0xf6, 0x19, 0xbf, 0x2a, 0x54, 0xbe, 0xda, 0x14, 0xd2, 0x82, 0x96, 0x3c, 0xd9,
-	0x74, 0x5f, 0xf6, 0xcd, 0xec, 0xcb, 0xde, 0x3b, 0x3d, 0x9d, 0x72, 0xf6, 0xb5, 0xbf, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x68, 0x27, 0x6c, 0xe8, 0x2a, 0x0a, 0x00, 0x00,
+	// 1556 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4b, 0x73, 0x1b, 0xb9,
+	0x11, 0x16, 0x39, 0xb4, 0x28, 0xb6, 0x28, 0x09, 0x86, 0x1e, 0xd6, 0x7a, 0x95, 0xd4, 0x96, 0x4e,
+	0x5b, 0xaa, 0x5a, 0x3b, 0x89, 0x2b, 0xc9, 0x69, 0x0f, 0x12, 0x47, 0x92, 0x59, 0x16, 0x25, 0xed,
+	0x50, 0xf6, 0xa6, 0x72, 0xc8, 0x16, 0x34, 0xd3, 0x22, 0x11, 0x63, 0x00, 0x06, 0x00, 0x65, 0xf3,
+	0x96, 0x9f, 0x90, 0x6c, 0xfe, 0x46, 0x92, 0xca, 0x3b, 0xf9, 0x09, 0x79, 0x9f, 0x73, 0xcf, 0x25,
+	0x3f, 0x20, 0x9b, 0xc7, 0x3e, 0x53, 0x8d, 0x19, 0x72, 0x66, 0xab, 0xd6, 0xa7, 0xdc, 0xd0, 0x5f,
+	0x37, 0x3e, 0xf4, 0x0b, 0x0d, 0x40, 0x37, 0x35, 0x79, 0x6e, 0xf4, 0x83, 0x89, 0x35, 0xde, 0xf0,
+	0xcd, 0x5c, 0xaa, 0xdb, 0xa9, 0x2b, 0xa4, 0x07, 0x85, 0x6a, 0xff, 0x05, 0x2c, 0x0f, 0xbd, 0xf0,
+	0x53, 0xc7, 0xdf, 0x06, 0x40, 0x6b, 0x8d, 0x7d, 0x2f, 0x35, 0x19, 0xee, 0x36, 0xde, 0x68, 0xbc,
+	0xb9, 0xfe, 0xb5, 0x2f, 0x3f, 0xf8, 0x82, 0x3d, 0x0f, 0x8e, 0xc9, 0xac, 0x67, 0x32, 0x4c, 0x3a,
+	0x38, 0x5f, 0xf2, 0x1d, 0x58, 0xb6, 0x28, 0x9c, 0xd1, 0xbb, 0xcd, 0x37, 0x1a, 0x6f, 0x76, 0x92,
+	0x52, 0x22, 0x3c, 0x43, 0x2f, 0xa4, 0xda, 0x8d, 0x0a, 0xbc, 0x90, 0xf6, 0xbf, 0x01, 0xdd, 0x27,
+	0x38, 0x7b, 0x26, 0xd4, 0x14, 0x2f, 0x85, 0xb4, 0x9c, 0x41, 0xf4, 0x1c, 0x67, 0xe1, 0xdc, 0x4e,
+	0x42, 0x4b, 0xbe, 0x05, 0x77, 0x6e, 0x49, 0x5d, 0x12, 0x16, 0xc2, 0xfe, 0x23, 0x58, 0x7d, 0x82,
+	0xb3, 0x58, 0x78, 0xf1, 0x8a, 0x6d, 0x1c, 0x5a, 0x99, 0xf0, 0x22, 0xec, 0xea, 0x26, 0x61, 0xbd,
+	0xbf, 0x07, 0xad, 0x23, 0x65, 0xae, 0x2b, 0xca, 0x46, 0x50, 0x96, 0x94, 0x6f, 0x41, 0xfb, 0x30,
+	0xcb, 0x2c, 0x3a, 0xc7, 0xd7, 0xa1, 0x29, 0x27, 0x25, 0x5b, 0x53, 0x4e, 0x88, 0x6c, 0x62, 0xac,
+	0x0f, 0x64, 0x51, 0x12, 0xd6, 0xfb, 0xef, 0x37, 0xa0, 0x3d, 0x70, 0xa3, 0x23, 0xe1, 0x90, 0x7f,
+	0x13, 0x56, 0x72, 0x37, 0x7a, 0xcf, 0xcf, 0x26, 0xf3, 0x94, 0xed, 0x7d, 0x61, 0xca, 0x06, 0x6e,
+	0x74, 0x35, 0x9b, 0x60, 0xd2, 0xce, 0x8b, 0x05, 0x79, 0x92, 0xbb, 0x51, 0x3f, 0x2e, 0x99, 0x0b,
+	0x81, 0xef, 0x41, 0xc7, 0xcb, 0x1c, 0x9d, 0x17, 0xf9, 0x24, 0xe4, 0xab, 0x95, 0x54, 0x00, 0xbf,
+	0x0f, 0x2b, 0xce, 0x4c, 0x6d, 0x8a, 0xfd, 0x78, 0xb7, 0x15, 0xb6, 0x2d, 0xe4, 0xfd, 0xb7, 0xa1,
+	0x33, 0x70, 0xa3, 0xc7, 0x28, 0x32, 0xb4, 0xfc, 0x2b, 0xd0, 0xba, 0x16, 0xae, 0xf0, 0x68, 0xf5,
+	0xd5, 0x1e, 0x51, 0x04, 0x49, 0xb0, 0xdc, 0xff, 0x0e, 0x74, 0xe3, 0xc1, 0xd9, 0xff, 0xc1, 0x40,
+	0xae, 0xbb, 0xb1, 0xb0, 0xd9, 0xb9, 0xc8, 0xe7, 0x15, 0xab, 0x80, 0x83, 0xbf, 0xb7, 0xa0, 0xb3,
+	0x68, 0x1b, 0xbe, 0x0a, 0xed, 0xe1, 0x34, 0x4d, 0xd1, 0x39, 0xb6, 0xc4, 0x37, 0x61, 0xe3, 0xa9,
+	0xc6, 0x97, 0x13, 0x4c, 0x3d, 0x66, 0xc1, 0x86, 0x35, 0xf8, 0x5d, 0x58, 0xeb, 0x19, 0xad, 0x31,
+	0xf5, 0x27, 0x42, 0x2a, 0xcc, 0x58, 0x93, 0x6f, 0x01, 0xbb, 0x44, 0x9b, 0x4b, 0xe7, 0xa4, 0xd1,
+	0x31, 0x6a, 0x89, 0x19, 0x8b, 0xf8, 0x3d, 0xd8, 0xec, 0x19, 0xa5, 0x30, 0xf5, 0xd2, 0xe8, 0x73,
+	0xe3, 0x8f, 0x5f, 0x4a, 0xe7, 0x1d, 0x6b, 0x11, 0x6d, 0x5f, 0x29, 0x1c, 0x09, 0x75, 0x68, 0x47,
+	0xd3, 0x1c, 0xb5, 0x67, 0x77, 0x88, 0xa3, 0x04, 0x63, 0x99, 0xa3, 0x26, 0x26, 0xd6, 0xae, 0xa1,
+	0x7d, 0x9d, 0xe1, 0x4b, 0xaa, 0x0f, 0x5b, 0xe1, 0xaf, 0xc1, 0x76, 0x89, 0xd6, 0x0e, 0x10, 0x39,
+	0xb2, 0x0e, 0xdf, 0x80, 0xd5, 0x52, 0x75, 0x75, 0x71, 0xf9, 0x84, 0x41, 0x8d, 0x21, 0x31, 0x2f,
+	0x12, 0x4c, 0x8d, 0xcd, 0xd8, 0x6a, 0xcd, 0x85, 0x67, 0x98, 0x7a, 0x63, 0xfb, 0x31, 0xeb, 0x92,
+	0xc3, 0x25, 0x38, 0x44, 0x61, 0xd3, 0x71, 0x82, 0x6e, 0xaa, 0x3c, 0x5b, 0xe3, 0x0c, 0xba, 0x27,
+	0x52, 0xe1, 0xb9, 0xf1, 0x27, 0x66, 0xaa, 0x33, 0xb6, 0xce, 0xd7, 0x01, 0x06, 0xe8, 0x45, 0x99,
+	0x81, 0x0d, 0x3a, 0xb6, 0x27, 0xd2, 0x31, 0x96, 0x00, 0xe3, 0x3b, 0xc0, 0x7b, 0x42, 0x6b, 0xe3,
+	0x7b, 0x16, 0x85, 0xc7, 0x13, 0xa3, 0x32, 0xb4, 0xec, 0x2e, 0xb9, 0xf3, 0x39, 0x5c, 0x2a, 0x64,
+	0xbc, 0xb2, 0x8e, 0x51, 0xe1, 0xc2, 0x7a, 0xb3, 0xb2, 0x2e, 0x71, 0xb2, 0xde, 0x22, 0xe7, 0x8f,
+	0xa6, 0x52, 0x65, 0x21, 0x25, 0x45, 0x59, 0xb6, 0xc9, 0xc7, 0xd2, 0xf9, 0xf3, 0xb3, 0xfe, 0xf0,
+	0x8a, 0xed, 0xf0, 0x6d, 0xb8, 0x5b, 0x22, 0x03, 0xf4, 0x56, 0xa6, 0x21, 0x79, 0xf7, 0xc8, 0xd5,
+	0x8b, 0xa9, 0xbf, 0xb8, 0x19, 0x60, 0x6e, 0xec, 0x8c, 0xed, 0x52, 0x41, 0x03, 0xd3, 0xbc, 0x44,
+	0xec, 0x35, 0x3a, 0xe1, 0x38, 0x9f, 0xf8, 0x59, 0x95, 0x5e, 0x76, 0x9f, 0xaf, 0x41, 0x27, 0x11,
+	0x1e, 0xcf, 0x64, 0x2e, 0x3d, 0x7b, 0x9d, 0xef, 0xc2, 0xd6, 0x10, 0x47, 0x54, 0x3d, 0x9a, 0x52,
+	0x6e, 0x20, 0x5d, 0x2e, 0x7c, 0x3a, 0x66, 0x7b, 0x9c, 0xc3, 0x5a, 0x1c, 0x27, 0xf8, 0xbd, 0x29,
+	0x3a, 0x9f, 0x88, 0x14, 0xd9, 0x3f, 0xda, 0x07, 0xdf, 0x02, 0x08, 0x87, 0x90, 0x2d, 0x72, 0x0e,
+	0xeb, 0x95, 0x74, 0x6e, 0x34, 0xb2, 0x25, 0xde, 0x85, 0x95, 0xa7, 0x5a, 0x3a, 0x37, 0xc5, 0x8c,
+	0x35, 0x28, 0xc1, 0x7d, 0x7d, 0x69, 0xcd, 0x88, 0xee, 0x3e, 0x6b, 0x92, 0xf6, 0x44, 0x6a, 0xe9,
+	0xc6, 0xa1, 0xb5, 0x00, 0x96, 0xcb, 0x4c, 0xb7, 0x0e, 0x1c, 0x74, 0x6b, 0x7e, 0xd0, 0xf5, 0x65,
+	0x75, 0xb9, 0x62, 0x5f, 0xc4, 0xd7, 0xa0, 0x2e, 0x3f, 0xb5, 0xe6, 0x85, 0xd4, 0x23, 0xd6, 0x24,
+	0xb2, 0x21, 0x0a, 0x15, 0x88, 0x57, 0xa1, 0x7d, 0xa2, 0xa6, 0xe1, 0x94, 0x56, 0x38, 0x93, 0x04,
+	0x32, 0xbb, 0x43, 0xaa, 0xd8, 0x9a, 0xc9, 0x04, 0x33, 0xb6, 0x7c, 0xf0, 0xc1, 0x4a, 0x18, 0x34,
+	0x61, 0x5e, 0xac, 0x41, 0xe7, 0xa9, 0xce, 0xf0, 0x46, 0x6a, 0xcc, 0xd8, 0x52, 0xa8, 0x59, 0xa8,
+	0x6d, 0x2d, 0x79, 0x19, 0x45, 0x4c, 0xbb, 0x6b, 0x18, 0x52, 0xe2, 0x1f, 0x0b, 0x57, 0x83, 0x6e,
+	0xa8, 0x11, 0x62, 0x74, 0xa9, 0x95, 0xd7, 0xf5, 0xed, 0x23, 0x2a, 0xc8, 0x70, 0x6c, 0x5e, 0x54,
+	0x98, 0x63, 0x63, 0x3a, 0xe9, 0x14, 0xfd, 0x70, 0xe6, 0x3c, 0xe6, 0x3d, 0xa3, 0x6f, 0xe4, 0xc8,
+	0x31, 0x49, 0x27, 0x9d, 0x19, 0x91, 0xd5, 0xb6, 0x7f, 0x97, 0x5a, 0x21, 0x41, 0x85, 0xc2, 0xd5,
+	0x59, 0x9f, 0x87, 0xae, 0x0d, 0xae, 0x1e, 0x2a, 0x29, 0x1c, 0x53, 0x14, 0x0a, 0x79, 0x59, 0x88,
+	0x39, 0x15, 0xe1, 0x50, 0x79, 0xb4, 0x85, 0xac, 0xc9, 0x8b, 0x20, 0xd7, 0x48, 0x0c, 0xdf, 0x82,
+	0x8d, 0x82, 0xe4, 0x52, 0x58, 0x2f, 0x03, 0xf8, 0xfb, 0x46, 0xe8, 0x01, 0x6b, 0x26, 0x15, 0xf6,
+	0x07, 0x9a, 0x1c, 0xdd, 0xc7, 0xc2, 0x55, 0xd0, 0x1f, 0x1b, 0x7c, 0x07, 0xee, 0xce, 0xe3, 0xad,
+	0xf0, 0x3f, 0x35, 0xf8, 0x26, 0xac, 0x53, 0xbc, 0x0b, 0xcc, 0xb1, 0x3f, 0x07, 0x90, 0x22, 0xab,
+	0x81, 0x7f, 0x09, 0x0c, 0x65, 0x68, 0x35, 0xfc, 0xaf, 0xe1, 0x30, 0x62, 0x28, 0x5b, 0xc1, 0xb1,
+	0x0f, 0x1b, 0xe4, 0xe9, 0xfc, 0xb0, 0x12, 0x66, 0x1f, 0x05, 0x43, 0x62, 0x5d, 0x18, 0x7e, 0x1c,
+	0x0c, 0x4b, 0xce, 0x05, 0xfa, 0x49, 0x40, 0x1f, 0x0b, 0x9d, 0x99, 0x9b, 0x9b, 0x05, 0xfa, 0x69,
+	0x83, 0xef, 0xc2, 0x26, 0x6d, 0x3f, 0x12, 0x4a, 0xe8, 0xb4, 0xb2, 0xff, 0xac, 0xc1, 0xd9, 0x3c,
+	0xbb, 0xa1, 0xd5, 0xd9, 0x8f, 0x9b, 0x21, 0x29, 0xa5, 0x03, 0x05, 0xf6, 0x93, 0x26, 0x5f, 0x2f,
+	0x52, 0x5e, 0xc8, 0x3f, 0x6d, 0xf2, 0x55, 0x58, 0xee, 0x6b, 0x87, 0xd6, 0xb3, 0x1f, 0x50, 0x3b,
+	0x2e, 0x17, 0x37, 0x9f, 0xfd, 0x90, 0x9a, 0xfe, 0x4e, 0x68, 0x47, 0xf6, 0x7e, 0x50, 0xf4, 0x73,
+	0x7a, 0xf2, 0xd8, 0x8f, 0x82, 0x50, 0x0c, 0x2c, 0xf6, 0xcf, 0x28, 0xc4, 0x5d, 0x9f, 0x5e, 0x1f,
+	0x44, 0x74, 0xec, 0x29, 0xfa, 0xea, 0xc2, 0xb1, 0x7f, 0x45, 0xfc, 0x3e, 0x6c, 0xcf, 0xb1, 0x30,
+	0x4b, 0x16, 0x57, 0xed, 0xdf, 0x11, 0xdf, 0x83, 0x7b, 0xa7, 0xe8, 0xab, 0x22, 0xd3, 0x26, 0xe9,
+	0xbc, 0x4c, 0x1d, 0xfb, 0x4f, 0xc4, 0x5f, 0x87, 0x9d, 0x53, 0xf4, 0x8b, 0x64, 0xd7, 0x94, 0xff,
+	0x8d, 0xf8, 0x1a, 0xac, 0x24, 0x34, 0x6c, 0xf0, 0x16, 0xd9, 0x87, 0x11, 0x55, 0x6c, 0x2e, 0x96,
+	0xee, 0x7c, 0x14, 0x51, 0x1e, 0xdf, 0xa5, 0x49, 0x11, 0xe7, 0xbd, 0xb1, 0xd0, 0x1a, 0x95, 0x63,
+	0x1f, 0x47, 0x7c, 0x1b, 0x58, 0x82, 0xb9, 0xb9, 0xc5, 0x1a, 0xfc, 0x09, 0x3d, 0x22, 0x3c, 0x18,
+	0xbf, 0x33, 0x45, 0x3b, 0x5b, 0x28, 0x3e, 0x8d, 0x28, 0xef, 0x85, 0xfd, 0xe7, 0x35, 0x9f, 0x45,
+	0xfc, 0x4b, 0xb0, 0x5b, 0xdc, 0xe7, 0x79, 0x31, 0x48, 0x39, 0xc2, 0xbe, 0xbe, 0x31, 0xec, 0xfb,
+	0x2d, 0x2a, 0x4b, 0xa9, 0x08, 0xc8, 0xdf, 0x5a, 0xe4, 0xf4, 0x95, 0xcc, 0xf1, 0x4a, 0xa6, 0xcf,
+	0xd9, 0xcf, 0x3a, 0xe4, 0x74, 0xe0, 0x3c, 0x37, 0x19, 0x86, 0xd1, 0xc6, 0x7e, 0xde, 0xa1, 0x32,
+	0x51, 0x99, 0x8b, 0x32, 0xfd, 0x22, 0xc8, 0xe5, 0x84, 0xeb, 0xc7, 0xec, 0x97, 0xf4, 0xee, 0x40,
+	0x29, 0x5f, 0x0d, 0x2f, 0xd8, 0xaf, 0x3a, 0x14, 0xe5, 0xa1, 0x52, 0x26, 0x15, 0x7e, 0xd1, 0x6c,
+	0xbf, 0xee, 0x50, 0xb7, 0xd6, 0x86, 0x53, 0x99, 0xb7, 0xdf, 0x74, 0x28, 0xfa, 0x12, 0x0f, 0x25,
+	0x8e, 0x69, 0x68, 0xfd, 0x36, 0xb0, 0xd2, 0x77, 0x8a, 0x3c, 0xb9, 0xf2, 0xec, 0x77, 0x9d, 0x03,
+	0x03, 0xab, 0x45, 0xdd, 0x8b, 0x59, 0x47, 0xa3, 0x3b, 0x88, 0x97, 0xa8, 0x33, 0x1a, 0x53, 0x4b,
+	0xe1, 0x1d, 0x08, 0x50, 0x39, 0x20, 0x1b, 0x95, 0xd1, 0xd0, 0x0b, 0xeb, 0xc3, 0x83, 0x4d, 0xcf,
+	0x5f, 0xb9, 0xcf, 0x3a, 0xe9, 0x7c, 0x98, 0x7d, 0x0b, 0xb0, 0x67, 0xf2, 0x09, 0x35, 0x1d, 0x4d,
+	0xd7, 0x7d, 0x68, 0xc7, 0x4e, 0x85, 0x39, 0xd7, 0x86, 0x28, 0x76, 0x8a, 0x2d, 0xd1, 0x58, 0x38,
+	0x32, 0x46, 0x1d, 0xbf, 0x9c, 0xd8, 0x67, 0x5f, 0x65, 0x8d, 0x83, 0x77, 0x80, 0xf5, 0x8c, 0x0e,
+	0x3c, 0x3a, 0x9d, 0x9d, 0xe1, 0x2d, 0xaa, 0x30, 0x54, 0xbd, 0x35, 0xc1, 0x25, 0xfa, 0x53, 0x60,
+	0xf8, 0x1b, 0x30, 0xba, 0x45, 0xec, 0x88, 0x1e, 0x51, 0xcc, 0x86, 0x5e, 0x28, 0xd4, 0xc5, 0x78,
+	0x5f, 0x07, 0x38, 0xbe, 0x45, 0xed, 0xa7, 0x42, 0xa9, 0x19, 0x8b, 0x0e, 0x12, 0xd8, 0x79, 0xaa,
+	0x25, 0x25, 0x7b, 0x51, 0xc6, 0x4b, 0xa3, 0x64, 0x3a, 0xa3, 0x68, 0xa8, 0x10, 0x0b, 0x6d, 0x11,
+	0xf2, 0xbb, 0x42, 0xfa, 0x13, 0x63, 0x8b, 0xf2, 0xd0, 0xa4, 0xd8, 0xa0, 0xf0, 0x2f, 0x74, 0x65,
+	0xd6, 0x3c, 0xfa, 0xfa, 0xb7, 0x1f, 0x8d, 0xa4, 0x1f, 0x4f, 0xaf, 0xe9, 0x97, 0xf4, 0xb0, 0xf8,
+	0x36, 0xbd, 0x25, 0x4d, 0xb9, 0x7a, 0x28, 0xb5, 0x47, 0xab, 0x85, 0x7a, 0x18, 0x7e, 0x52, 0x0f,
+	0x8b, 0x9f, 0xd4, 0xe4, 0xfa, 0x7a, 0x39, 0xc8, 0x8f, 0xfe, 0x37, 0x00, 0xb9, 0x3f, 0x7c, 0x9e,
+	0xb2, 0x0b, 0x00, 0x00,
 }
@@ -1296,6 +1296,7 @@ type CollectionInfo struct {
 	Schema               *schemapb.CollectionSchema `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
 	Partitions           []int64                    `protobuf:"varint,3,rep,packed,name=partitions,proto3" json:"partitions,omitempty"`
 	StartPositions       []*commonpb.KeyDataPair    `protobuf:"bytes,4,rep,name=start_positions,json=startPositions,proto3" json:"start_positions,omitempty"`
+	Properties           []*commonpb.KeyValuePair   `protobuf:"bytes,5,rep,name=properties,proto3" json:"properties,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -1354,6 +1355,13 @@ func (m *CollectionInfo) GetStartPositions() []*commonpb.KeyDataPair {
 	return nil
 }
 
+func (m *CollectionInfo) GetProperties() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 type SegmentInfo struct {
 	ID             int64                   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	CollectionID   int64                   `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
@@ -1369,6 +1377,9 @@ type SegmentInfo struct {
 	Binlogs              []*FieldBinlog  `protobuf:"bytes,11,rep,name=binlogs,proto3" json:"binlogs,omitempty"`
 	Statslogs            []*FieldBinlog  `protobuf:"bytes,12,rep,name=statslogs,proto3" json:"statslogs,omitempty"`
 	Deltalogs            []*DeltaLogInfo `protobuf:"bytes,13,rep,name=deltalogs,proto3" json:"deltalogs,omitempty"`
+	DroppedAt            uint64          `protobuf:"varint,14,opt,name=dropped_at,json=droppedAt,proto3" json:"dropped_at,omitempty"`
+	LayoutVersion        int32           `protobuf:"varint,15,opt,name=layout_version,json=layoutVersion,proto3" json:"layout_version,omitempty"`
+	BinlogSize           int64           `protobuf:"varint,16,opt,name=binlog_size,json=binlogSize,proto3" json:"binlog_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -1490,6 +1501,27 @@ func (m *SegmentInfo) GetDeltalogs() []*DeltaLogInfo {
 	return nil
 }
 
+func (m *SegmentInfo) GetDroppedAt() uint64 {
+	if m != nil {
+		return m.DroppedAt
+	}
+	return 0
+}
+
+func (m *SegmentInfo) GetLayoutVersion() int32 {
+	if m != nil {
+		return m.LayoutVersion
+	}
+	return 0
+}
+
+func (m *SegmentInfo) GetBinlogSize() int64 {
+	if m != nil {
+		return m.BinlogSize
+	}
+	return 0
+}
+
 type SegmentStartPosition struct {
 	StartPosition        *internalpb.MsgPosition `protobuf:"bytes,1,opt,name=start_position,json=startPosition,proto3" json:"start_position,omitempty"`
 	SegmentID            int64                   `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
@@ -1767,12 +1799,14 @@ func (m *DeltaLogInfo) GetDeltaLogSize() int64 {
 }
 
 type DataNodeTtMsg struct {
-	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	ChannelName          string            `protobuf:"bytes,2,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
-	Timestamp            uint64            `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Base                 *commonpb.MsgBase       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	ChannelName          string                  `protobuf:"bytes,2,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
+	Timestamp            uint64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MemoryHigh           bool                    `protobuf:"varint,4,opt,name=memory_high,json=memoryHigh,proto3" json:"memory_high,omitempty"`
+	Checkpoint           *internalpb.MsgPosition `protobuf:"bytes,5,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *DataNodeTtMsg) Reset()         { *m = DataNodeTtMsg{} }
@@ -1821,6 +1855,20 @@ func (m *DataNodeTtMsg) GetTimestamp() uint64 {
 	return 0
 }
 
+func (m *DataNodeTtMsg) GetMemoryHigh() bool {
+	if m != nil {
+		return m.MemoryHigh
+	}
+	return false
+}
+
+func (m *DataNodeTtMsg) GetCheckpoint() *internalpb.MsgPosition {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
 type ChannelStatus struct {
 	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	State                ChannelWatchState `protobuf:"varint,2,opt,name=state,proto3,enum=milvus.proto.data.ChannelWatchState" json:"state,omitempty"`
@@ -2005,6 +2053,8 @@ func (m *SegmentBinlogs) GetDeltalogs() []*DeltaLogInfo {
 type FieldBinlog struct {
 	FieldID              int64    `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
 	Binlogs              []string `protobuf:"bytes,2,rep,name=binlogs,proto3" json:"binlogs,omitempty"`
+	EntriesNums          []int64  `protobuf:"varint,3,rep,packed,name=entries_nums,json=entriesNums,proto3" json:"entries_nums,omitempty"`
+	LogSizes             []int64  `protobuf:"varint,4,rep,packed,name=log_sizes,json=logSizes,proto3" json:"log_sizes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2049,6 +2099,20 @@ func (m *FieldBinlog) GetBinlogs() []string {
 	return nil
 }
 
+func (m *FieldBinlog) GetEntriesNums() []int64 {
+	if m != nil {
+		return m.EntriesNums
+	}
+	return nil
+}
+
+func (m *FieldBinlog) GetLogSizes() []int64 {
+	if m != nil {
+		return m.LogSizes
+	}
+	return nil
+}
+
 type GetRecoveryInfoResponse struct {
 	Status               *commonpb.Status  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Channels             []*VchannelInfo   `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
@@ -2363,6 +2427,496 @@ func (m *ChannelWatchInfo) GetState() ChannelWatchState {
 	return ChannelWatchState_Uncomplete
 }
 
+type ImportTaskRequest struct {
+	Base                 *commonpb.MsgBase        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CollectionID         int64                    `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionID          int64                    `protobuf:"varint,3,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	RowBased             bool                     `protobuf:"varint,4,opt,name=row_based,json=rowBased,proto3" json:"row_based,omitempty"`
+	Files                []string                 `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	Options              []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ImportTaskRequest) Reset()         { *m = ImportTaskRequest{} }
+func (m *ImportTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportTaskRequest) ProtoMessage()    {}
+func (*ImportTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{39}
+}
+
+func (m *ImportTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTaskRequest.Unmarshal(m, b)
+}
+func (m *ImportTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTaskRequest.Merge(m, src)
+}
+func (m *ImportTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportTaskRequest.Size(m)
+}
+func (m *ImportTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTaskRequest proto.InternalMessageInfo
+
+func (m *ImportTaskRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ImportTaskRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *ImportTaskRequest) GetPartitionID() int64 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
+}
+
+func (m *ImportTaskRequest) GetRowBased() bool {
+	if m != nil {
+		return m.RowBased
+	}
+	return false
+}
+
+func (m *ImportTaskRequest) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ImportTaskRequest) GetOptions() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type ImportTaskResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	TaskID               int64            `protobuf:"varint,2,opt,name=taskID,proto3" json:"taskID,omitempty"`
+	DatanodeID           int64            `protobuf:"varint,3,opt,name=datanodeID,proto3" json:"datanodeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ImportTaskResponse) Reset()         { *m = ImportTaskResponse{} }
+func (m *ImportTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportTaskResponse) ProtoMessage()    {}
+func (*ImportTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{40}
+}
+
+func (m *ImportTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTaskResponse.Unmarshal(m, b)
+}
+func (m *ImportTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTaskResponse.Merge(m, src)
+}
+func (m *ImportTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportTaskResponse.Size(m)
+}
+func (m *ImportTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTaskResponse proto.InternalMessageInfo
+
+func (m *ImportTaskResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ImportTaskResponse) GetTaskID() int64 {
+	if m != nil {
+		return m.TaskID
+	}
+	return 0
+}
+
+func (m *ImportTaskResponse) GetDatanodeID() int64 {
+	if m != nil {
+		return m.DatanodeID
+	}
+	return 0
+}
+
+// ImportTask is the task assigned to datanode, which parses the files and writes the binlogs of segments
+type ImportTask struct {
+	Base                 *commonpb.MsgBase        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	TaskID               int64                    `protobuf:"varint,2,opt,name=taskID,proto3" json:"taskID,omitempty"`
+	CollectionID         int64                    `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionID          int64                    `protobuf:"varint,4,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	RowBased             bool                     `protobuf:"varint,5,opt,name=row_based,json=rowBased,proto3" json:"row_based,omitempty"`
+	Files                []string                 `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
+	Options              []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ImportTask) Reset()         { *m = ImportTask{} }
+func (m *ImportTask) String() string { return proto.CompactTextString(m) }
+func (*ImportTask) ProtoMessage()    {}
+func (*ImportTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{41}
+}
+
+func (m *ImportTask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTask.Unmarshal(m, b)
+}
+func (m *ImportTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTask.Marshal(b, m, deterministic)
+}
+func (m *ImportTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTask.Merge(m, src)
+}
+func (m *ImportTask) XXX_Size() int {
+	return xxx_messageInfo_ImportTask.Size(m)
+}
+func (m *ImportTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTask proto.InternalMessageInfo
+
+func (m *ImportTask) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ImportTask) GetTaskID() int64 {
+	if m != nil {
+		return m.TaskID
+	}
+	return 0
+}
+
+func (m *ImportTask) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *ImportTask) GetPartitionID() int64 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
+}
+
+func (m *ImportTask) GetRowBased() bool {
+	if m != nil {
+		return m.RowBased
+	}
+	return false
+}
+
+func (m *ImportTask) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ImportTask) GetOptions() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// ImportResult is reported by datanode when the state of import task changes
+type ImportResult struct {
+	Base   *commonpb.MsgBase    `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	TaskID int64                `protobuf:"varint,2,opt,name=taskID,proto3" json:"taskID,omitempty"`
+	State  commonpb.ImportState `protobuf:"varint,3,opt,name=state,proto3,enum=milvus.proto.common.ImportState" json:"state,omitempty"`
+	// the segments persisted, reported with state ImportPersisted
+	Segments []*SegmentInfo `protobuf:"bytes,4,rep,name=segments,proto3" json:"segments,omitempty"`
+	RowCount int64          `protobuf:"varint,5,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	// file path -> error of the failed files
+	Infos                []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=infos,proto3" json:"infos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ImportResult) Reset()         { *m = ImportResult{} }
+func (m *ImportResult) String() string { return proto.CompactTextString(m) }
+func (*ImportResult) ProtoMessage()    {}
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{42}
+}
+
+func (m *ImportResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportResult.Unmarshal(m, b)
+}
+func (m *ImportResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportResult.Marshal(b, m, deterministic)
+}
+func (m *ImportResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportResult.Merge(m, src)
+}
+func (m *ImportResult) XXX_Size() int {
+	return xxx_messageInfo_ImportResult.Size(m)
+}
+func (m *ImportResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportResult proto.InternalMessageInfo
+
+func (m *ImportResult) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ImportResult) GetTaskID() int64 {
+	if m != nil {
+		return m.TaskID
+	}
+	return 0
+}
+
+func (m *ImportResult) GetState() commonpb.ImportState {
+	if m != nil {
+		return m.State
+	}
+	return commonpb.ImportState_ImportPending
+}
+
+func (m *ImportResult) GetSegments() []*SegmentInfo {
+	if m != nil {
+		return m.Segments
+	}
+	return nil
+}
+
+func (m *ImportResult) GetRowCount() int64 {
+	if m != nil {
+		return m.RowCount
+	}
+	return 0
+}
+
+func (m *ImportResult) GetInfos() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Infos
+	}
+	return nil
+}
+
+// ImportTaskInfo is the import task kept by datacoord
+type ImportTaskInfo struct {
+	ID                   int64                    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	DatanodeID           int64                    `protobuf:"varint,2,opt,name=datanodeID,proto3" json:"datanodeID,omitempty"`
+	CollectionID         int64                    `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionID          int64                    `protobuf:"varint,4,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	RowBased             bool                     `protobuf:"varint,5,opt,name=row_based,json=rowBased,proto3" json:"row_based,omitempty"`
+	Files                []string                 `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
+	Options              []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	State                commonpb.ImportState     `protobuf:"varint,8,opt,name=state,proto3,enum=milvus.proto.common.ImportState" json:"state,omitempty"`
+	RowCount             int64                    `protobuf:"varint,9,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	SegmentIDs           []int64                  `protobuf:"varint,10,rep,packed,name=segmentIDs,proto3" json:"segmentIDs,omitempty"`
+	Infos                []*commonpb.KeyValuePair `protobuf:"bytes,11,rep,name=infos,proto3" json:"infos,omitempty"`
+	CreateTs             int64                    `protobuf:"varint,12,opt,name=create_ts,json=createTs,proto3" json:"create_ts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ImportTaskInfo) Reset()         { *m = ImportTaskInfo{} }
+func (m *ImportTaskInfo) String() string { return proto.CompactTextString(m) }
+func (*ImportTaskInfo) ProtoMessage()    {}
+func (*ImportTaskInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{43}
+}
+
+func (m *ImportTaskInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTaskInfo.Unmarshal(m, b)
+}
+func (m *ImportTaskInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTaskInfo.Marshal(b, m, deterministic)
+}
+func (m *ImportTaskInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTaskInfo.Merge(m, src)
+}
+func (m *ImportTaskInfo) XXX_Size() int {
+	return xxx_messageInfo_ImportTaskInfo.Size(m)
+}
+func (m *ImportTaskInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTaskInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTaskInfo proto.InternalMessageInfo
+
+func (m *ImportTaskInfo) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *ImportTaskInfo) GetDatanodeID() int64 {
+	if m != nil {
+		return m.DatanodeID
+	}
+	return 0
+}
+
+func (m *ImportTaskInfo) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *ImportTaskInfo) GetPartitionID() int64 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
+}
+
+func (m *ImportTaskInfo) GetRowBased() bool {
+	if m != nil {
+		return m.RowBased
+	}
+	return false
+}
+
+func (m *ImportTaskInfo) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ImportTaskInfo) GetOptions() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *ImportTaskInfo) GetState() commonpb.ImportState {
+	if m != nil {
+		return m.State
+	}
+	return commonpb.ImportState_ImportPending
+}
+
+func (m *ImportTaskInfo) GetRowCount() int64 {
+	if m != nil {
+		return m.RowCount
+	}
+	return 0
+}
+
+func (m *ImportTaskInfo) GetSegmentIDs() []int64 {
+	if m != nil {
+		return m.SegmentIDs
+	}
+	return nil
+}
+
+func (m *ImportTaskInfo) GetInfos() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Infos
+	}
+	return nil
+}
+
+func (m *ImportTaskInfo) GetCreateTs() int64 {
+	if m != nil {
+		return m.CreateTs
+	}
+	return 0
+}
+
+// DropSegmentRequest drops a segment by an operator, the loaded segment is released by QueryCoord,
+// the index builds are dropped by IndexCoord and the binlogs are garbage collected after the retention duration
+type DropSegmentRequest struct {
+	Base      *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	SegmentID int64             `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	// drop the segment even if it's still being written or flushed
+	Force                bool     `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropSegmentRequest) Reset()         { *m = DropSegmentRequest{} }
+func (m *DropSegmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DropSegmentRequest) ProtoMessage()    {}
+func (*DropSegmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{44}
+}
+
+func (m *DropSegmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropSegmentRequest.Unmarshal(m, b)
+}
+func (m *DropSegmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropSegmentRequest.Marshal(b, m, deterministic)
+}
+func (m *DropSegmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropSegmentRequest.Merge(m, src)
+}
+func (m *DropSegmentRequest) XXX_Size() int {
+	return xxx_messageInfo_DropSegmentRequest.Size(m)
+}
+func (m *DropSegmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropSegmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropSegmentRequest proto.InternalMessageInfo
+
+func (m *DropSegmentRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *DropSegmentRequest) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *DropSegmentRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type CompactionSegmentBinlogs struct {
 	SegmentID            int64           `protobuf:"varint,1,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
 	FieldBinlogs         []*FieldBinlog  `protobuf:"bytes,2,rep,name=fieldBinlogs,proto3" json:"fieldBinlogs,omitempty"`
@@ -2377,7 +2931,7 @@ func (m *CompactionSegmentBinlogs) Reset()         { *m = CompactionSegmentBinlo
 func (m *CompactionSegmentBinlogs) String() string { return proto.CompactTextString(m) }
 func (*CompactionSegmentBinlogs) ProtoMessage()    {}
 func (*CompactionSegmentBinlogs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_82cd95f524594f49, []int{39}
+	return fileDescriptor_82cd95f524594f49, []int{45}
 }
 
 func (m *CompactionSegmentBinlogs) XXX_Unmarshal(b []byte) error {
@@ -2428,6 +2982,7 @@ func (m *CompactionSegmentBinlogs) GetDeltalogs() []*DeltaLogInfo {
 
 type CompactionMergeGroup struct {
 	SegmentBinlogs       []*CompactionSegmentBinlogs `protobuf:"bytes,1,rep,name=segmentBinlogs,proto3" json:"segmentBinlogs,omitempty"`
+	TargetSegmentID      int64                       `protobuf:"varint,2,opt,name=target_segmentID,json=targetSegmentID,proto3" json:"target_segmentID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
 	XXX_unrecognized     []byte                      `json:"-"`
 	XXX_sizecache        int32                       `json:"-"`
@@ -2437,7 +2992,7 @@ func (m *CompactionMergeGroup) Reset()         { *m = CompactionMergeGroup{} }
 func (m *CompactionMergeGroup) String() string { return proto.CompactTextString(m) }
 func (*CompactionMergeGroup) ProtoMessage()    {}
 func (*CompactionMergeGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_82cd95f524594f49, []int{40}
+	return fileDescriptor_82cd95f524594f49, []int{46}
 }
 
 func (m *CompactionMergeGroup) XXX_Unmarshal(b []byte) error {
@@ -2465,6 +3020,13 @@ func (m *CompactionMergeGroup) GetSegmentBinlogs() []*CompactionSegmentBinlogs {
 	return nil
 }
 
+func (m *CompactionMergeGroup) GetTargetSegmentID() int64 {
+	if m != nil {
+		return m.TargetSegmentID
+	}
+	return 0
+}
+
 type CompactionPlan struct {
 	PlanID               int64                   `protobuf:"varint,1,opt,name=planID,proto3" json:"planID,omitempty"`
 	MergeGroup           []*CompactionMergeGroup `protobuf:"bytes,2,rep,name=mergeGroup,proto3" json:"mergeGroup,omitempty"`
@@ -2472,6 +3034,8 @@ type CompactionPlan struct {
 	TimeoutInSeconds     int32                   `protobuf:"varint,4,opt,name=timeout_in_seconds,json=timeoutInSeconds,proto3" json:"timeout_in_seconds,omitempty"`
 	Type                 CompactionType          `protobuf:"varint,5,opt,name=type,proto3,enum=milvus.proto.data.CompactionType" json:"type,omitempty"`
 	Timetravel           uint64                  `protobuf:"varint,6,opt,name=timetravel,proto3" json:"timetravel,omitempty"`
+	CollectionID         int64                   `protobuf:"varint,7,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionID          int64                   `protobuf:"varint,8,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
 	XXX_sizecache        int32                   `json:"-"`
@@ -2481,7 +3045,7 @@ func (m *CompactionPlan) Reset()         { *m = CompactionPlan{} }
 func (m *CompactionPlan) String() string { return proto.CompactTextString(m) }
 func (*CompactionPlan) ProtoMessage()    {}
 func (*CompactionPlan) Descriptor() ([]byte, []int) {
-	return fileDescriptor_82cd95f524594f49, []int{41}
+	return fileDescriptor_82cd95f524594f49, []int{47}
 }
 
 func (m *CompactionPlan) XXX_Unmarshal(b []byte) error {
@@ -2544,6 +3108,20 @@ func (m *CompactionPlan) GetTimetravel() uint64 {
 	return 0
 }
 
+func (m *CompactionPlan) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *CompactionPlan) GetPartitionID() int64 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
+}
+
 type CompactionResult struct {
 	PlanID               int64           `protobuf:"varint,1,opt,name=planID,proto3" json:"planID,omitempty"`
 	SegmentID            int64           `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
@@ -2560,7 +3138,7 @@ func (m *CompactionResult) Reset()         { *m = CompactionResult{} }
 func (m *CompactionResult) String() string { return proto.CompactTextString(m) }
 func (*CompactionResult) ProtoMessage()    {}
 func (*CompactionResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_82cd95f524594f49, []int{42}
+	return fileDescriptor_82cd95f524594f49, []int{48}
 }
 
 func (m *CompactionResult) XXX_Unmarshal(b []byte) error {
@@ -2636,7 +3214,7 @@ func (m *SegmentFieldBinlogMeta) Reset()         { *m = SegmentFieldBinlogMeta{}
 func (m *SegmentFieldBinlogMeta) String() string { return proto.CompactTextString(m) }
 func (*SegmentFieldBinlogMeta) ProtoMessage()    {}
 func (*SegmentFieldBinlogMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_82cd95f524594f49, []int{43}
+	return fileDescriptor_82cd95f524594f49, []int{49}
 }
 
 func (m *SegmentFieldBinlogMeta) XXX_Unmarshal(b []byte) error {
@@ -2713,6 +3291,12 @@ func init() {
 	proto.RegisterType((*GetFlushedSegmentsResponse)(nil), "milvus.proto.data.GetFlushedSegmentsResponse")
 	proto.RegisterType((*SegmentFlushCompletedMsg)(nil), "milvus.proto.data.SegmentFlushCompletedMsg")
 	proto.RegisterType((*ChannelWatchInfo)(nil), "milvus.proto.data.ChannelWatchInfo")
+	proto.RegisterType((*ImportTaskRequest)(nil), "milvus.proto.data.ImportTaskRequest")
+	proto.RegisterType((*ImportTaskResponse)(nil), "milvus.proto.data.ImportTaskResponse")
+	proto.RegisterType((*ImportTask)(nil), "milvus.proto.data.ImportTask")
+	proto.RegisterType((*ImportResult)(nil), "milvus.proto.data.ImportResult")
+	proto.RegisterType((*ImportTaskInfo)(nil), "milvus.proto.data.ImportTaskInfo")
+	proto.RegisterType((*DropSegmentRequest)(nil), "milvus.proto.data.DropSegmentRequest")
 	proto.RegisterType((*CompactionSegmentBinlogs)(nil), "milvus.proto.data.CompactionSegmentBinlogs")
 	proto.RegisterType((*CompactionMergeGroup)(nil), "milvus.proto.data.CompactionMergeGroup")
 	proto.RegisterType((*CompactionPlan)(nil), "milvus.proto.data.CompactionPlan")
@@ -2723,158 +3307,188 @@ func init() {
 func init() { proto.RegisterFile("data_coord.proto", fileDescriptor_82cd95f524594f49) }
 
 var fileDescriptor_82cd95f524594f49 = []byte{
-	// 2412 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0xcb, 0x6f, 0x1b, 0xc7,
-	0xf9, 0x5e, 0x3e, 0x64, 0xf1, 0xe3, 0x43, 0xf4, 0xd8, 0x3f, 0x99, 0x3f, 0xda, 0x96, 0xe5, 0x6d,
-	0x62, 0x2b, 0x4e, 0x22, 0xc5, 0x72, 0x83, 0x06, 0x75, 0xd2, 0x20, 0xb6, 0x6c, 0x96, 0xa8, 0xe4,
-	0xaa, 0x4b, 0x25, 0x01, 0x9a, 0x03, 0xb1, 0xe2, 0x8e, 0xa8, 0xad, 0xf6, 0xc1, 0xec, 0x0c, 0x65,
-	0x3b, 0x97, 0x04, 0x2e, 0x10, 0xa0, 0x41, 0xdb, 0xb4, 0x28, 0x0a, 0xf4, 0x50, 0xa0, 0x45, 0x4f,
-	0x05, 0x7a, 0xe9, 0xa5, 0x97, 0xfe, 0x05, 0x45, 0xfb, 0xff, 0xf4, 0x5c, 0xcc, 0x63, 0x67, 0x97,
-	0xcb, 0x25, 0xb9, 0x94, 0x6c, 0xeb, 0xc6, 0xf9, 0xf6, 0x7b, 0xcd, 0x37, 0xdf, 0x73, 0x86, 0x50,
-	0xb7, 0x4c, 0x6a, 0x76, 0x7b, 0xbe, 0x1f, 0x58, 0xeb, 0x83, 0xc0, 0xa7, 0x3e, 0xba, 0xe0, 0xda,
-	0xce, 0xf1, 0x90, 0x88, 0xd5, 0x3a, 0xfb, 0xdc, 0xac, 0xf4, 0x7c, 0xd7, 0xf5, 0x3d, 0x01, 0x6a,
-	0xd6, 0x6c, 0x8f, 0xe2, 0xc0, 0x33, 0x1d, 0xb9, 0xae, 0xc4, 0x09, 0x9a, 0x15, 0xd2, 0x3b, 0xc4,
-	0xae, 0x29, 0x56, 0xfa, 0x53, 0xa8, 0x3c, 0x72, 0x86, 0xe4, 0xd0, 0xc0, 0x9f, 0x0f, 0x31, 0xa1,
-	0xe8, 0x1d, 0x28, 0xec, 0x9b, 0x04, 0x37, 0xb4, 0x55, 0x6d, 0xad, 0xbc, 0x79, 0x75, 0x7d, 0x44,
-	0x96, 0x94, 0xb2, 0x43, 0xfa, 0xf7, 0x4d, 0x82, 0x0d, 0x8e, 0x89, 0x10, 0x14, 0xac, 0xfd, 0xf6,
-	0x56, 0x23, 0xb7, 0xaa, 0xad, 0xe5, 0x0d, 0xfe, 0x1b, 0xe9, 0x50, 0xe9, 0xf9, 0x8e, 0x83, 0x7b,
-	0xd4, 0xf6, 0xbd, 0xf6, 0x56, 0xa3, 0xc0, 0xbf, 0x8d, 0xc0, 0xf4, 0x3f, 0x6a, 0x50, 0x95, 0xa2,
-	0xc9, 0xc0, 0xf7, 0x08, 0x46, 0x77, 0x61, 0x81, 0x50, 0x93, 0x0e, 0x89, 0x94, 0x7e, 0x25, 0x55,
-	0x7a, 0x87, 0xa3, 0x18, 0x12, 0x35, 0x93, 0xf8, 0xfc, 0xb8, 0x78, 0xb4, 0x02, 0x40, 0x70, 0xdf,
-	0xc5, 0x1e, 0x6d, 0x6f, 0x91, 0x46, 0x61, 0x35, 0xbf, 0x96, 0x37, 0x62, 0x10, 0xfd, 0xb7, 0x1a,
-	0xd4, 0x3b, 0xe1, 0x32, 0xb4, 0xce, 0x25, 0x28, 0xf6, 0xfc, 0xa1, 0x47, 0xb9, 0x82, 0x55, 0x43,
-	0x2c, 0xd0, 0x0d, 0xa8, 0xf4, 0x0e, 0x4d, 0xcf, 0xc3, 0x4e, 0xd7, 0x33, 0x5d, 0xcc, 0x55, 0x29,
-	0x19, 0x65, 0x09, 0x7b, 0x6c, 0xba, 0x38, 0x93, 0x46, 0xab, 0x50, 0x1e, 0x98, 0x01, 0xb5, 0x47,
-	0x6c, 0x16, 0x07, 0xe9, 0x7f, 0xd6, 0x60, 0xf9, 0x23, 0x42, 0xec, 0xbe, 0x37, 0xa6, 0xd9, 0x32,
-	0x2c, 0x78, 0xbe, 0x85, 0xdb, 0x5b, 0x5c, 0xb5, 0xbc, 0x21, 0x57, 0xe8, 0x0a, 0x94, 0x06, 0x18,
-	0x07, 0xdd, 0xc0, 0x77, 0x42, 0xc5, 0x16, 0x19, 0xc0, 0xf0, 0x1d, 0x8c, 0x7e, 0x02, 0x17, 0x48,
-	0x82, 0x11, 0x69, 0xe4, 0x57, 0xf3, 0x6b, 0xe5, 0xcd, 0xef, 0xac, 0x8f, 0x79, 0xd9, 0x7a, 0x52,
-	0xa8, 0x31, 0x4e, 0xad, 0x7f, 0x95, 0x83, 0x8b, 0x0a, 0x4f, 0xe8, 0xca, 0x7e, 0x33, 0xcb, 0x11,
-	0xdc, 0x57, 0xea, 0x89, 0x45, 0x16, 0xcb, 0x29, 0x93, 0xe7, 0xe3, 0x26, 0xcf, 0xe0, 0x60, 0x49,
-	0x7b, 0x16, 0xc7, 0xec, 0x89, 0xae, 0x43, 0x19, 0x3f, 0x1d, 0xd8, 0x01, 0xee, 0x52, 0xdb, 0xc5,
-	0x8d, 0x85, 0x55, 0x6d, 0xad, 0x60, 0x80, 0x00, 0xed, 0xd9, 0x6e, 0xdc, 0x23, 0xcf, 0x67, 0xf6,
-	0x48, 0xfd, 0x2f, 0x1a, 0x5c, 0x1e, 0x3b, 0x25, 0xe9, 0xe2, 0x06, 0xd4, 0xf9, 0xce, 0x23, 0xcb,
-	0x30, 0x67, 0x67, 0x06, 0xbf, 0x39, 0xcd, 0xe0, 0x11, 0xba, 0x31, 0x46, 0x1f, 0x53, 0x32, 0x97,
-	0x5d, 0xc9, 0x23, 0xb8, 0xdc, 0xc2, 0x54, 0x0a, 0x60, 0xdf, 0x30, 0x39, 0x79, 0x0a, 0x18, 0x8d,
-	0xa5, 0xdc, 0x58, 0x2c, 0xfd, 0x3d, 0xa7, 0x62, 0x89, 0x8b, 0x6a, 0x7b, 0x07, 0x3e, 0xba, 0x0a,
-	0x25, 0x85, 0x22, 0xbd, 0x22, 0x02, 0xa0, 0xef, 0x41, 0x91, 0x69, 0x2a, 0x5c, 0xa2, 0xb6, 0x79,
-	0x23, 0x7d, 0x4f, 0x31, 0x9e, 0x86, 0xc0, 0x47, 0x6d, 0xa8, 0x11, 0x6a, 0x06, 0xb4, 0x3b, 0xf0,
-	0x09, 0x3f, 0x67, 0xee, 0x38, 0xe5, 0x4d, 0x7d, 0x94, 0x83, 0x4a, 0x91, 0x3b, 0xa4, 0xbf, 0x2b,
-	0x31, 0x8d, 0x2a, 0xa7, 0x0c, 0x97, 0xe8, 0x21, 0x54, 0xb0, 0x67, 0x45, 0x8c, 0x0a, 0x99, 0x19,
-	0x95, 0xb1, 0x67, 0x29, 0x36, 0xd1, 0xf9, 0x14, 0xb3, 0x9f, 0xcf, 0x2f, 0x35, 0x68, 0x8c, 0x1f,
-	0xd0, 0x69, 0x12, 0xe5, 0x3d, 0x41, 0x84, 0xc5, 0x01, 0x4d, 0x8d, 0x70, 0x75, 0x48, 0x86, 0x24,
-	0xd1, 0x6d, 0xf8, 0xbf, 0x48, 0x1b, 0xfe, 0xe5, 0xa5, 0x39, 0xcb, 0xcf, 0x35, 0x58, 0x4e, 0xca,
-	0x3a, 0xcd, 0xbe, 0xbf, 0x0b, 0x45, 0xdb, 0x3b, 0xf0, 0xc3, 0x6d, 0xaf, 0x4c, 0x89, 0x33, 0x26,
-	0x4b, 0x20, 0xeb, 0x2e, 0x5c, 0x69, 0x61, 0xda, 0xf6, 0x08, 0x0e, 0xe8, 0x7d, 0xdb, 0x73, 0xfc,
-	0xfe, 0xae, 0x49, 0x0f, 0x4f, 0x11, 0x23, 0x23, 0xee, 0x9e, 0x4b, 0xb8, 0xbb, 0xfe, 0x57, 0x0d,
-	0xae, 0xa6, 0xcb, 0x93, 0x5b, 0x6f, 0xc2, 0xe2, 0x81, 0x8d, 0x1d, 0x8b, 0xd9, 0x4c, 0xe3, 0x36,
-	0x53, 0x6b, 0x16, 0x2b, 0x03, 0x86, 0x2c, 0x77, 0x78, 0x63, 0x82, 0x83, 0x76, 0x68, 0x60, 0x7b,
-	0xfd, 0x6d, 0x9b, 0x50, 0x43, 0xe0, 0xc7, 0xec, 0x99, 0xcf, 0xee, 0x99, 0xdf, 0x68, 0xb0, 0xd2,
-	0xc2, 0xf4, 0x81, 0x4a, 0xb5, 0xec, 0xbb, 0x4d, 0xa8, 0xdd, 0x23, 0x2f, 0xb7, 0x89, 0x48, 0xa9,
-	0x99, 0xfa, 0xb7, 0x1a, 0x5c, 0x9f, 0xa8, 0x8c, 0x34, 0x9d, 0x4c, 0x25, 0x61, 0xa2, 0x4d, 0x4f,
-	0x25, 0x3f, 0xc2, 0xcf, 0x3e, 0x31, 0x9d, 0x21, 0xde, 0x35, 0xed, 0x40, 0xa4, 0x92, 0x13, 0x26,
-	0xd6, 0xbf, 0x69, 0x70, 0xad, 0x85, 0xe9, 0x6e, 0x58, 0x66, 0xce, 0xd0, 0x3a, 0x19, 0x3a, 0x8a,
-	0x5f, 0x8b, 0xc3, 0x4c, 0xd5, 0xf6, 0x4c, 0xcc, 0xb7, 0xc2, 0xe3, 0x20, 0x16, 0x90, 0x0f, 0x44,
-	0x2f, 0x20, 0x8d, 0xa7, 0xff, 0x23, 0x07, 0x95, 0x4f, 0x64, 0x7f, 0xc0, 0xcb, 0x48, 0xd2, 0x0e,
-	0x5a, 0xba, 0x1d, 0x62, 0x2d, 0x45, 0x5a, 0x97, 0xd1, 0x82, 0x2a, 0xc1, 0xf8, 0xe8, 0x24, 0x45,
-	0xa3, 0xc2, 0x08, 0x55, 0xb2, 0xdf, 0x86, 0x0b, 0x43, 0xef, 0x80, 0xb5, 0xb5, 0xd8, 0x92, 0xbb,
-	0x10, 0xdd, 0xe5, 0xec, 0xcc, 0x33, 0x4e, 0x88, 0x7e, 0x08, 0x4b, 0x49, 0x5e, 0xc5, 0x4c, 0xbc,
-	0x92, 0x64, 0xfa, 0x2f, 0x34, 0x58, 0xfe, 0xd4, 0xa4, 0xbd, 0xc3, 0x2d, 0x57, 0x5a, 0xf4, 0x14,
-	0xfe, 0xf8, 0x01, 0x94, 0x8e, 0xa5, 0xf5, 0xc2, 0xa4, 0x73, 0x3d, 0x45, 0xa1, 0xf8, 0x39, 0x19,
-	0x11, 0x85, 0xfe, 0x2f, 0x0d, 0x2e, 0xf1, 0xce, 0x3f, 0xd4, 0xee, 0xd5, 0x47, 0xc6, 0x8c, 0xee,
-	0x1f, 0xdd, 0x84, 0x9a, 0x6b, 0x06, 0x47, 0x9d, 0x08, 0xa7, 0xc8, 0x71, 0x12, 0x50, 0xfd, 0x29,
-	0x80, 0x5c, 0xed, 0x90, 0xfe, 0x09, 0xf4, 0x7f, 0x0f, 0xce, 0x4b, 0xa9, 0x32, 0x48, 0x66, 0x1d,
-	0x6c, 0x88, 0xae, 0xff, 0x5b, 0x83, 0x5a, 0x94, 0xf6, 0x78, 0x28, 0xd4, 0x20, 0xa7, 0x02, 0x20,
-	0xd7, 0xde, 0x42, 0x1f, 0xc0, 0x82, 0x98, 0xf5, 0x24, 0xef, 0xd7, 0x47, 0x79, 0xcb, 0x39, 0x30,
-	0x96, 0x3b, 0x39, 0xc0, 0x90, 0x44, 0xcc, 0x46, 0x2a, 0x55, 0x88, 0xb1, 0x20, 0x6f, 0xc4, 0x20,
-	0xa8, 0x0d, 0x4b, 0xa3, 0x9d, 0x56, 0xe8, 0xe8, 0xab, 0x93, 0x52, 0xc4, 0x96, 0x49, 0x4d, 0x9e,
-	0x21, 0x6a, 0x23, 0x8d, 0x16, 0xd1, 0xff, 0x5b, 0x80, 0x72, 0x6c, 0x97, 0x63, 0x3b, 0x49, 0x1e,
-	0x69, 0x6e, 0x76, 0xb2, 0xcb, 0x8f, 0xb7, 0xfb, 0xaf, 0x43, 0xcd, 0xe6, 0x05, 0xb6, 0x2b, 0x5d,
-	0x91, 0x67, 0xc4, 0x92, 0x51, 0x15, 0x50, 0x19, 0x17, 0x68, 0x05, 0xca, 0xde, 0xd0, 0xed, 0xfa,
-	0x07, 0xdd, 0xc0, 0x7f, 0x42, 0xe4, 0xdc, 0x50, 0xf2, 0x86, 0xee, 0x8f, 0x0f, 0x0c, 0xff, 0x09,
-	0x89, 0x5a, 0xd3, 0x85, 0x39, 0x5b, 0xd3, 0x15, 0x28, 0xbb, 0xe6, 0x53, 0xc6, 0xb5, 0xeb, 0x0d,
-	0x5d, 0x3e, 0x52, 0xe4, 0x8d, 0x92, 0x6b, 0x3e, 0x35, 0xfc, 0x27, 0x8f, 0x87, 0x2e, 0x5a, 0x83,
-	0xba, 0x63, 0x12, 0xda, 0x8d, 0xcf, 0x24, 0x8b, 0x7c, 0x26, 0xa9, 0x31, 0xf8, 0xc3, 0x68, 0x2e,
-	0x19, 0x6f, 0x72, 0x4b, 0xa7, 0x68, 0x72, 0x2d, 0xd7, 0x89, 0x18, 0x41, 0xf6, 0x26, 0xd7, 0x72,
-	0x1d, 0xc5, 0xe6, 0x3d, 0x38, 0xbf, 0xcf, 0xdb, 0x16, 0xd2, 0x28, 0x4f, 0xcc, 0x50, 0x8f, 0x58,
-	0xc7, 0x22, 0xba, 0x1b, 0x23, 0x44, 0x47, 0xef, 0x43, 0x89, 0xd7, 0x0b, 0x4e, 0x5b, 0xc9, 0x44,
-	0x1b, 0x11, 0xb0, 0x54, 0x64, 0x61, 0x87, 0x9a, 0x9c, 0xba, 0x3a, 0x31, 0x15, 0x6d, 0x31, 0x9c,
-	0x6d, 0xbf, 0x2f, 0x52, 0x91, 0xa2, 0xd0, 0xbf, 0x84, 0x4b, 0xd1, 0x49, 0xc5, 0xac, 0x32, 0x6e,
-	0x60, 0xed, 0xa4, 0x06, 0x9e, 0xde, 0xf8, 0xfd, 0xa1, 0x00, 0xcb, 0x1d, 0xf3, 0x18, 0xbf, 0xfc,
-	0x1e, 0x33, 0x53, 0x5e, 0xdc, 0x86, 0x0b, 0xbc, 0xad, 0xdc, 0x8c, 0xe9, 0x33, 0xa5, 0x7c, 0xc5,
-	0x0f, 0x65, 0x9c, 0x10, 0x7d, 0xc8, 0xea, 0x2e, 0xee, 0x1d, 0xed, 0xfa, 0x76, 0x54, 0xba, 0xae,
-	0xa5, 0xf0, 0x79, 0xa0, 0xb0, 0x8c, 0x38, 0x05, 0xda, 0x1d, 0x4f, 0x31, 0x0b, 0x9c, 0xc9, 0xad,
-	0xa9, 0xc3, 0x4b, 0x64, 0xfd, 0x64, 0xa6, 0x41, 0x0d, 0x38, 0x2f, 0x4b, 0x23, 0x8f, 0xbf, 0x45,
-	0x23, 0x5c, 0xa2, 0x5d, 0xb8, 0x28, 0x76, 0xd0, 0x91, 0xce, 0x25, 0x36, 0xbf, 0x98, 0x69, 0xf3,
-	0x69, 0xa4, 0xa3, 0xbe, 0x59, 0x9a, 0xdb, 0x37, 0xbf, 0xd1, 0x00, 0x22, 0xc3, 0xcc, 0x98, 0x97,
-	0x7f, 0x00, 0x8b, 0xca, 0x55, 0x73, 0x99, 0x5d, 0x55, 0xd1, 0x24, 0x93, 0x5e, 0x3e, 0x91, 0xf4,
-	0xf4, 0xff, 0x68, 0x50, 0x89, 0x2b, 0xca, 0x92, 0x69, 0x80, 0x7b, 0x7e, 0x60, 0x75, 0xb1, 0x47,
-	0x03, 0x1b, 0x8b, 0x99, 0xac, 0x60, 0x54, 0x05, 0xf4, 0xa1, 0x00, 0x32, 0x34, 0x96, 0xc7, 0x08,
-	0x35, 0xdd, 0x41, 0xf7, 0x20, 0xf0, 0x5d, 0xae, 0x5d, 0xc1, 0xa8, 0x2a, 0xe8, 0xa3, 0xc0, 0x77,
-	0xd1, 0x0d, 0xa8, 0x44, 0x68, 0xd4, 0xe7, 0xf2, 0x0b, 0x46, 0x59, 0xc1, 0xf6, 0x7c, 0xf4, 0x1a,
-	0xd4, 0xb8, 0x6d, 0xba, 0x8e, 0xdf, 0xef, 0xb2, 0xf9, 0x45, 0x66, 0xef, 0x8a, 0x25, 0xd5, 0x62,
-	0x46, 0x1f, 0xc5, 0x22, 0xf6, 0x17, 0x58, 0xe6, 0x6f, 0x85, 0xd5, 0xb1, 0xbf, 0xc0, 0xfa, 0x73,
-	0x0d, 0xaa, 0xac, 0x18, 0x3d, 0xf6, 0x2d, 0xbc, 0x77, 0xc2, 0xd2, 0x9d, 0xe1, 0xee, 0xea, 0x2a,
-	0x94, 0xd4, 0x0e, 0xe4, 0x96, 0x22, 0x00, 0x1b, 0x74, 0xab, 0xb2, 0xe6, 0x74, 0xd4, 0x5d, 0x26,
-	0x67, 0xa5, 0x71, 0x56, 0xfc, 0x37, 0xfa, 0xfe, 0xe8, 0x45, 0xc8, 0x6b, 0xa9, 0xd1, 0xc3, 0x99,
-	0xf0, 0xf6, 0x6e, 0xa4, 0xe0, 0x64, 0x99, 0xa0, 0xbe, 0x62, 0x07, 0x2b, 0x4d, 0xc1, 0x0f, 0xb6,
-	0x01, 0xe7, 0x4d, 0xcb, 0x0a, 0x30, 0x21, 0x52, 0x8f, 0x70, 0xc9, 0xbe, 0x1c, 0xe3, 0x80, 0x84,
-	0x2e, 0x96, 0x37, 0xc2, 0x25, 0x7a, 0x1f, 0x16, 0x55, 0x3f, 0x98, 0x4f, 0xeb, 0x01, 0xe2, 0x7a,
-	0xca, 0x8e, 0x5f, 0x51, 0xe8, 0xdf, 0xe6, 0xa0, 0x26, 0x83, 0xf7, 0xbe, 0x2c, 0x0a, 0xd3, 0x9d,
-	0xfd, 0x3e, 0x54, 0x0e, 0xa2, 0xe0, 0x9b, 0x36, 0xd9, 0xc7, 0x63, 0x74, 0x84, 0x66, 0x96, 0xc3,
-	0x8f, 0x96, 0xa5, 0xc2, 0xa9, 0xca, 0x52, 0x71, 0xee, 0xd0, 0xff, 0x08, 0xca, 0x31, 0xc6, 0x3c,
-	0x69, 0x89, 0x61, 0x5f, 0xda, 0x22, 0x5c, 0xb2, 0x2f, 0xfb, 0x31, 0x23, 0x94, 0x54, 0x59, 0x65,
-	0x4d, 0xf6, 0xe5, 0x16, 0xa6, 0x06, 0xee, 0xf9, 0xc7, 0x38, 0x78, 0x76, 0xfa, 0x7b, 0x94, 0x7b,
-	0xb1, 0x33, 0xce, 0xd8, 0xf3, 0x2b, 0x02, 0x74, 0x2f, 0xd2, 0x33, 0x9f, 0x36, 0x46, 0xc6, 0x13,
-	0xb8, 0x3c, 0xa1, 0x68, 0x2b, 0xbf, 0x11, 0x37, 0x42, 0xa3, 0x5b, 0x39, 0x69, 0x8d, 0x7c, 0x21,
-	0xad, 0xa4, 0xfe, 0x3b, 0x0d, 0xfe, 0xbf, 0x85, 0xe9, 0xa3, 0xd1, 0x29, 0xeb, 0xac, 0xb5, 0x72,
-	0xa1, 0x99, 0xa6, 0xd4, 0x69, 0x4e, 0xbd, 0x09, 0x8b, 0x24, 0x1c, 0x3d, 0xc5, 0x5d, 0x9d, 0x5a,
-	0xeb, 0x5f, 0x6b, 0xd0, 0x90, 0x52, 0xb8, 0xcc, 0x07, 0xbe, 0x3b, 0x70, 0x30, 0xc5, 0xd6, 0xab,
-	0x9e, 0x85, 0xfe, 0xa4, 0x41, 0x3d, 0x9e, 0x04, 0x79, 0x1e, 0x7b, 0x17, 0x8a, 0x7c, 0xe4, 0x94,
-	0x1a, 0xcc, 0x74, 0x56, 0x81, 0xcd, 0x22, 0x8a, 0xb7, 0x0c, 0x7b, 0x24, 0x4c, 0x72, 0x72, 0x19,
-	0x65, 0xe2, 0xfc, 0xdc, 0x99, 0x58, 0xff, 0x55, 0x0e, 0x1a, 0xcc, 0x3c, 0xa6, 0x18, 0xb4, 0x5e,
-	0x75, 0xb2, 0x9b, 0xd0, 0xdb, 0xe4, 0x5f, 0x50, 0x6f, 0x53, 0x98, 0x3b, 0xc1, 0x1d, 0xc1, 0xa5,
-	0xc8, 0x1c, 0x3b, 0x38, 0xe8, 0xe3, 0x56, 0xe0, 0x0f, 0x07, 0xa8, 0x03, 0x35, 0x32, 0x62, 0x1c,
-	0x79, 0xeb, 0xf4, 0x66, 0x9a, 0xb1, 0x27, 0xd8, 0xd3, 0x48, 0xb0, 0xd0, 0x7f, 0x9f, 0x63, 0xa3,
-	0x72, 0x88, 0xbc, 0xeb, 0x98, 0x1e, 0x5a, 0x86, 0x85, 0x81, 0x63, 0x46, 0xf7, 0x45, 0x72, 0x85,
-	0x5a, 0x00, 0xae, 0xd2, 0x46, 0x9a, 0xfa, 0xd6, 0x54, 0xd9, 0x91, 0xf2, 0x46, 0x8c, 0x14, 0x5d,
-	0x03, 0x10, 0x9d, 0x2b, 0x9f, 0xe2, 0x64, 0xed, 0x17, 0x9e, 0xc4, 0x06, 0xb8, 0xb7, 0x00, 0xb1,
-	0x0f, 0xfe, 0x90, 0x76, 0x6d, 0xaf, 0x4b, 0x70, 0xcf, 0xf7, 0x2c, 0xc2, 0x1b, 0x9a, 0xa2, 0x51,
-	0x97, 0x5f, 0xda, 0x5e, 0x47, 0xc0, 0xd1, 0xbb, 0x50, 0xa0, 0xcf, 0x06, 0xa2, 0x95, 0xa9, 0xa5,
-	0xa6, 0xce, 0x48, 0x9f, 0xbd, 0x67, 0x03, 0x6c, 0x70, 0x74, 0x36, 0xc0, 0x33, 0x56, 0x34, 0x30,
-	0x8f, 0xb1, 0x13, 0xbe, 0x6e, 0x45, 0x10, 0xfd, 0x9f, 0x39, 0xa8, 0x47, 0x84, 0x06, 0x26, 0x43,
-	0x87, 0x4e, 0xb4, 0xcc, 0xf4, 0xd9, 0x62, 0x56, 0x35, 0xfd, 0x10, 0xca, 0x72, 0xf4, 0x9e, 0xa3,
-	0x9e, 0x82, 0x20, 0xd9, 0x9e, 0xe2, 0xc1, 0xc5, 0x17, 0xe4, 0xc1, 0x0b, 0x73, 0x7b, 0x70, 0x07,
-	0x96, 0xc3, 0xdc, 0x17, 0x49, 0xda, 0xc1, 0xd4, 0x9c, 0x52, 0xad, 0xaf, 0x43, 0x59, 0xd4, 0x34,
-	0xd1, 0xbf, 0x8a, 0x8e, 0x11, 0xf6, 0xd5, 0xc4, 0x74, 0xfb, 0x0e, 0x5c, 0x18, 0x4b, 0x21, 0xa8,
-	0x06, 0xf0, 0xb1, 0xd7, 0x93, 0xb9, 0xb5, 0x7e, 0x0e, 0x55, 0x60, 0x31, 0xcc, 0xb4, 0x75, 0xed,
-	0x76, 0x27, 0xee, 0xdb, 0xec, 0xf0, 0xd1, 0x65, 0xb8, 0xf8, 0xb1, 0x67, 0xe1, 0x03, 0xdb, 0xc3,
-	0x56, 0xf4, 0xa9, 0x7e, 0x0e, 0x5d, 0x84, 0xa5, 0xb6, 0xe7, 0xe1, 0x20, 0x06, 0xd4, 0x18, 0x90,
-	0xbb, 0x70, 0x0c, 0x98, 0xdb, 0x7c, 0x5e, 0x85, 0x12, 0x6b, 0x0a, 0x1f, 0xf8, 0x7e, 0x60, 0xa1,
-	0x01, 0x20, 0x7e, 0xc7, 0xee, 0x0e, 0x7c, 0x4f, 0x3d, 0x46, 0xa1, 0x77, 0x26, 0xcc, 0x17, 0xe3,
-	0xa8, 0xb2, 0x2c, 0x36, 0x6f, 0x4e, 0xa0, 0x48, 0xa0, 0xeb, 0xe7, 0x90, 0xcb, 0x25, 0xb2, 0x48,
-	0xd9, 0xb3, 0x7b, 0x47, 0xe1, 0xc5, 0xcc, 0x14, 0x89, 0x09, 0xd4, 0x50, 0x62, 0xe2, 0x8d, 0x4b,
-	0x2e, 0xc4, 0x43, 0x48, 0x58, 0x17, 0xf5, 0x73, 0xe8, 0x73, 0xb8, 0xd4, 0xc2, 0x34, 0xba, 0xfb,
-	0x0e, 0x05, 0x6e, 0x4e, 0x16, 0x38, 0x86, 0x3c, 0xa7, 0xc8, 0x6d, 0x28, 0xf2, 0x9a, 0x89, 0xd2,
-	0x7c, 0x2e, 0xfe, 0x8f, 0x8c, 0xe6, 0xea, 0x64, 0x04, 0xc5, 0xed, 0x67, 0xb0, 0x94, 0x78, 0x71,
-	0x46, 0x6f, 0xa4, 0x90, 0xa5, 0xff, 0x77, 0xa0, 0x79, 0x3b, 0x0b, 0xaa, 0x92, 0xd5, 0x87, 0xda,
-	0xe8, 0x0d, 0x3d, 0x5a, 0x4b, 0xa1, 0x4f, 0x7d, 0x2d, 0x6c, 0xbe, 0x91, 0x01, 0x53, 0x09, 0x72,
-	0xa1, 0x9e, 0x7c, 0x01, 0x45, 0xb7, 0xa7, 0x32, 0x18, 0x75, 0xb7, 0x37, 0x33, 0xe1, 0x2a, 0x71,
-	0xcf, 0xb8, 0x13, 0x8c, 0xbd, 0xc0, 0xa1, 0xf5, 0x74, 0x36, 0x93, 0x9e, 0x06, 0x9b, 0x1b, 0x99,
-	0xf1, 0x95, 0xe8, 0xe7, 0xa2, 0x57, 0x4f, 0x7b, 0xc5, 0x42, 0x77, 0xd2, 0xd9, 0x4d, 0x79, 0x7e,
-	0x6b, 0x6e, 0xce, 0x43, 0xa2, 0x94, 0xf8, 0x92, 0x37, 0xd9, 0x29, 0x2f, 0x41, 0xc9, 0xb8, 0x0b,
-	0xf9, 0x4d, 0x7e, 0xe2, 0x6a, 0xde, 0x99, 0x83, 0x42, 0x29, 0xe0, 0x27, 0xdf, 0x98, 0xc3, 0x30,
-	0xdc, 0x98, 0xe9, 0x35, 0x27, 0x8b, 0xc1, 0xcf, 0x60, 0x29, 0x71, 0xf5, 0x96, 0x1a, 0x35, 0xe9,
-	0xd7, 0x73, 0xcd, 0x69, 0xed, 0xb3, 0x08, 0xc9, 0xc4, 0xcc, 0x82, 0x26, 0x78, 0x7f, 0xca, 0x5c,
-	0xd3, 0xbc, 0x9d, 0x05, 0x55, 0x6d, 0x84, 0xf0, 0x74, 0x99, 0xe8, 0xfb, 0xd1, 0x5b, 0xe9, 0x3c,
-	0xd2, 0x67, 0x96, 0xe6, 0xdb, 0x19, 0xb1, 0x95, 0xd0, 0x2e, 0x40, 0x0b, 0xd3, 0x1d, 0x4c, 0x03,
-	0xe6, 0x23, 0x37, 0x53, 0x4d, 0x1e, 0x21, 0x84, 0x62, 0x6e, 0xcd, 0xc4, 0x0b, 0x05, 0x6c, 0x7e,
-	0x5d, 0x80, 0xc5, 0xf0, 0x66, 0xe2, 0x0c, 0x6a, 0xd0, 0x19, 0x14, 0x85, 0xcf, 0x60, 0x29, 0xf1,
-	0x46, 0x97, 0xea, 0x33, 0xe9, 0xef, 0x78, 0xb3, 0x1c, 0xf2, 0x53, 0xf9, 0x77, 0x3b, 0xe5, 0x1f,
-	0xb7, 0x26, 0x15, 0x96, 0xa4, 0x6b, 0xcc, 0x60, 0xfc, 0xb2, 0x1d, 0xe1, 0xfe, 0xdd, 0x9f, 0xde,
-	0xe9, 0xdb, 0xf4, 0x70, 0xb8, 0xcf, 0x44, 0x6f, 0x08, 0xcc, 0xb7, 0x6d, 0x5f, 0xfe, 0xda, 0x08,
-	0x4f, 0x60, 0x83, 0x73, 0xda, 0x60, 0xfb, 0x18, 0xec, 0xef, 0x2f, 0xf0, 0xd5, 0xdd, 0xff, 0x05,
-	0x00, 0x00, 0xff, 0xff, 0x21, 0x93, 0x69, 0xf9, 0x40, 0x29, 0x00, 0x00,
+	// 2890 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x1b, 0x4d, 0x6f, 0x24, 0x47,
+	0x75, 0x7b, 0x3e, 0xec, 0x99, 0x37, 0xe3, 0xf1, 0x6c, 0xed, 0xe2, 0x0c, 0x93, 0xfd, 0xf0, 0x36,
+	0xc9, 0xc6, 0xd9, 0x24, 0x76, 0xd6, 0x21, 0x10, 0xe5, 0x83, 0x28, 0xb6, 0xb3, 0xce, 0x08, 0x7b,
+	0x31, 0x3d, 0x4e, 0x22, 0x91, 0xc3, 0xa8, 0x3d, 0x5d, 0x1e, 0x37, 0x9e, 0xfe, 0x48, 0x57, 0x8d,
+	0x77, 0x9d, 0x4b, 0x02, 0x48, 0x48, 0xa0, 0x40, 0x40, 0x48, 0x88, 0x03, 0x12, 0x28, 0x27, 0x24,
+	0x2e, 0x5c, 0xb8, 0x70, 0xe3, 0x86, 0x84, 0xc4, 0x1f, 0x40, 0x9c, 0x39, 0xf1, 0x0b, 0xb8, 0xa0,
+	0xfa, 0xe8, 0xef, 0x9e, 0x99, 0x1e, 0x7b, 0x77, 0x2d, 0x6e, 0x53, 0x55, 0xef, 0xd5, 0x7b, 0xf5,
+	0xbe, 0xea, 0xbd, 0xd7, 0x35, 0xd0, 0x34, 0x74, 0xaa, 0xf7, 0xfa, 0x8e, 0xe3, 0x19, 0xab, 0xae,
+	0xe7, 0x50, 0x07, 0x5d, 0xb6, 0xcc, 0xe1, 0xc9, 0x88, 0x88, 0xd1, 0x2a, 0x5b, 0x6e, 0xd7, 0xfb,
+	0x8e, 0x65, 0x39, 0xb6, 0x98, 0x6a, 0x37, 0x4c, 0x9b, 0x62, 0xcf, 0xd6, 0x87, 0x72, 0x5c, 0x8f,
+	0x22, 0xb4, 0xeb, 0xa4, 0x7f, 0x84, 0x2d, 0x5d, 0x8c, 0xd4, 0x87, 0x50, 0xbf, 0x37, 0x1c, 0x91,
+	0x23, 0x0d, 0x7f, 0x3c, 0xc2, 0x84, 0xa2, 0x97, 0xa1, 0x74, 0xa0, 0x13, 0xdc, 0x52, 0x96, 0x95,
+	0x95, 0xda, 0xfa, 0xb5, 0xd5, 0x18, 0x2d, 0x49, 0x65, 0x97, 0x0c, 0x36, 0x74, 0x82, 0x35, 0x0e,
+	0x89, 0x10, 0x94, 0x8c, 0x83, 0xce, 0x56, 0xab, 0xb0, 0xac, 0xac, 0x14, 0x35, 0xfe, 0x1b, 0xa9,
+	0x50, 0xef, 0x3b, 0xc3, 0x21, 0xee, 0x53, 0xd3, 0xb1, 0x3b, 0x5b, 0xad, 0x12, 0x5f, 0x8b, 0xcd,
+	0xa9, 0xbf, 0x55, 0x60, 0x41, 0x92, 0x26, 0xae, 0x63, 0x13, 0x8c, 0x5e, 0x81, 0x39, 0x42, 0x75,
+	0x3a, 0x22, 0x92, 0xfa, 0xd3, 0x99, 0xd4, 0xbb, 0x1c, 0x44, 0x93, 0xa0, 0xb9, 0xc8, 0x17, 0xd3,
+	0xe4, 0xd1, 0x0d, 0x00, 0x82, 0x07, 0x16, 0xb6, 0x69, 0x67, 0x8b, 0xb4, 0x4a, 0xcb, 0xc5, 0x95,
+	0xa2, 0x16, 0x99, 0x51, 0x7f, 0xa9, 0x40, 0xb3, 0xeb, 0x0f, 0x7d, 0xe9, 0x5c, 0x85, 0x72, 0xdf,
+	0x19, 0xd9, 0x94, 0x33, 0xb8, 0xa0, 0x89, 0x01, 0xba, 0x05, 0xf5, 0xfe, 0x91, 0x6e, 0xdb, 0x78,
+	0xd8, 0xb3, 0x75, 0x0b, 0x73, 0x56, 0xaa, 0x5a, 0x4d, 0xce, 0xdd, 0xd7, 0x2d, 0x9c, 0x8b, 0xa3,
+	0x65, 0xa8, 0xb9, 0xba, 0x47, 0xcd, 0x98, 0xcc, 0xa2, 0x53, 0xea, 0xef, 0x15, 0x58, 0x7a, 0x87,
+	0x10, 0x73, 0x60, 0xa7, 0x38, 0x5b, 0x82, 0x39, 0xdb, 0x31, 0x70, 0x67, 0x8b, 0xb3, 0x56, 0xd4,
+	0xe4, 0x08, 0x3d, 0x0d, 0x55, 0x17, 0x63, 0xaf, 0xe7, 0x39, 0x43, 0x9f, 0xb1, 0x0a, 0x9b, 0xd0,
+	0x9c, 0x21, 0x46, 0xdf, 0x85, 0xcb, 0x24, 0xb1, 0x11, 0x69, 0x15, 0x97, 0x8b, 0x2b, 0xb5, 0xf5,
+	0xaf, 0xad, 0xa6, 0xac, 0x6c, 0x35, 0x49, 0x54, 0x4b, 0x63, 0xab, 0x9f, 0x15, 0xe0, 0x4a, 0x00,
+	0x27, 0x78, 0x65, 0xbf, 0x99, 0xe4, 0x08, 0x1e, 0x04, 0xec, 0x89, 0x41, 0x1e, 0xc9, 0x05, 0x22,
+	0x2f, 0x46, 0x45, 0x9e, 0xc3, 0xc0, 0x92, 0xf2, 0x2c, 0xa7, 0xe4, 0x89, 0x6e, 0x42, 0x0d, 0x3f,
+	0x74, 0x4d, 0x0f, 0xf7, 0xa8, 0x69, 0xe1, 0xd6, 0xdc, 0xb2, 0xb2, 0x52, 0xd2, 0x40, 0x4c, 0xed,
+	0x9b, 0x56, 0xd4, 0x22, 0xe7, 0x73, 0x5b, 0xa4, 0xfa, 0xa5, 0x02, 0x4f, 0xa5, 0xb4, 0x24, 0x4d,
+	0x5c, 0x83, 0x26, 0x3f, 0x79, 0x28, 0x19, 0x66, 0xec, 0x4c, 0xe0, 0xb7, 0x27, 0x09, 0x3c, 0x04,
+	0xd7, 0x52, 0xf8, 0x11, 0x26, 0x0b, 0xf9, 0x99, 0x3c, 0x86, 0xa7, 0xb6, 0x31, 0x95, 0x04, 0xd8,
+	0x1a, 0x26, 0x67, 0x0f, 0x01, 0x71, 0x5f, 0x2a, 0xa4, 0x7c, 0xe9, 0x4f, 0x05, 0x68, 0x46, 0x49,
+	0x75, 0xec, 0x43, 0x07, 0x5d, 0x83, 0x6a, 0x00, 0x22, 0xad, 0x22, 0x9c, 0x40, 0xdf, 0x84, 0x32,
+	0xe3, 0x54, 0x98, 0x44, 0x63, 0xfd, 0x56, 0xf6, 0x99, 0x22, 0x7b, 0x6a, 0x02, 0x1e, 0x75, 0xa0,
+	0x41, 0xa8, 0xee, 0xd1, 0x9e, 0xeb, 0x10, 0xae, 0x67, 0x6e, 0x38, 0xb5, 0x75, 0x35, 0xbe, 0x43,
+	0x10, 0x22, 0x77, 0xc9, 0x60, 0x4f, 0x42, 0x6a, 0x0b, 0x1c, 0xd3, 0x1f, 0xa2, 0x77, 0xa1, 0x8e,
+	0x6d, 0x23, 0xdc, 0xa8, 0x94, 0x7b, 0xa3, 0x1a, 0xb6, 0x8d, 0x60, 0x9b, 0x50, 0x3f, 0xe5, 0xfc,
+	0xfa, 0xf9, 0x5c, 0x81, 0x56, 0x5a, 0x41, 0xe7, 0x09, 0x94, 0x6f, 0x08, 0x24, 0x2c, 0x14, 0x34,
+	0xd1, 0xc3, 0x03, 0x25, 0x69, 0x12, 0x45, 0x35, 0xe1, 0x2b, 0x21, 0x37, 0x7c, 0xe5, 0xb1, 0x19,
+	0xcb, 0x8f, 0x14, 0x58, 0x4a, 0xd2, 0x3a, 0xcf, 0xb9, 0xbf, 0x0e, 0x65, 0xd3, 0x3e, 0x74, 0xfc,
+	0x63, 0xdf, 0x98, 0xe0, 0x67, 0x8c, 0x96, 0x00, 0x56, 0x2d, 0x78, 0x7a, 0x1b, 0xd3, 0x8e, 0x4d,
+	0xb0, 0x47, 0x37, 0x4c, 0x7b, 0xe8, 0x0c, 0xf6, 0x74, 0x7a, 0x74, 0x0e, 0x1f, 0x89, 0x99, 0x7b,
+	0x21, 0x61, 0xee, 0xea, 0x1f, 0x14, 0xb8, 0x96, 0x4d, 0x4f, 0x1e, 0xbd, 0x0d, 0x95, 0x43, 0x13,
+	0x0f, 0x8d, 0xce, 0x96, 0x08, 0x18, 0x45, 0x2d, 0x18, 0x33, 0x5f, 0x71, 0x19, 0xb0, 0x3c, 0xe1,
+	0xad, 0x31, 0x06, 0xda, 0xa5, 0x9e, 0x69, 0x0f, 0x76, 0x4c, 0x42, 0x35, 0x01, 0x1f, 0x91, 0x67,
+	0x31, 0xbf, 0x65, 0xfe, 0x54, 0x81, 0x1b, 0xdb, 0x98, 0x6e, 0x06, 0xa1, 0x96, 0xad, 0x9b, 0x84,
+	0x9a, 0x7d, 0xf2, 0x78, 0x93, 0x88, 0x8c, 0x3b, 0x53, 0xfd, 0x42, 0x81, 0x9b, 0x63, 0x99, 0x91,
+	0xa2, 0x93, 0xa1, 0xc4, 0x0f, 0xb4, 0xd9, 0xa1, 0xe4, 0xdb, 0xf8, 0xf4, 0x03, 0x7d, 0x38, 0xc2,
+	0x7b, 0xba, 0xe9, 0x89, 0x50, 0x72, 0xc6, 0xc0, 0xfa, 0x47, 0x05, 0xae, 0x6f, 0x63, 0xba, 0xe7,
+	0x5f, 0x33, 0x17, 0x28, 0x9d, 0x1c, 0x19, 0xc5, 0xcf, 0x85, 0x32, 0x33, 0xb9, 0xbd, 0x10, 0xf1,
+	0xdd, 0xe0, 0x7e, 0x10, 0x71, 0xc8, 0x4d, 0x91, 0x0b, 0x48, 0xe1, 0xa9, 0x7f, 0x2e, 0x40, 0xfd,
+	0x03, 0x99, 0x1f, 0xb0, 0xe5, 0x94, 0x1c, 0x94, 0x6c, 0x39, 0x44, 0x52, 0x8a, 0xac, 0x2c, 0x63,
+	0x1b, 0x16, 0x08, 0xc6, 0xc7, 0x67, 0xb9, 0x34, 0xea, 0x0c, 0xd1, 0x1f, 0xa1, 0x1d, 0xb8, 0x3c,
+	0xb2, 0x0f, 0x59, 0x5a, 0x8b, 0x0d, 0x79, 0x0a, 0x91, 0x5d, 0x4e, 0x8f, 0x3c, 0x69, 0x44, 0xf4,
+	0x1e, 0x2c, 0x26, 0xf7, 0x2a, 0xe7, 0xda, 0x2b, 0x89, 0xa6, 0xfe, 0x44, 0x81, 0xa5, 0x0f, 0x75,
+	0xda, 0x3f, 0xda, 0xb2, 0xa4, 0x44, 0xcf, 0x61, 0x8f, 0x6f, 0x41, 0xf5, 0x44, 0x4a, 0xcf, 0x0f,
+	0x3a, 0x37, 0x33, 0x18, 0x8a, 0xea, 0x49, 0x0b, 0x31, 0xd4, 0xbf, 0x29, 0x70, 0x95, 0x67, 0xfe,
+	0x3e, 0x77, 0x4f, 0xde, 0x33, 0xa6, 0x64, 0xff, 0xe8, 0x36, 0x34, 0x2c, 0xdd, 0x3b, 0xee, 0x86,
+	0x30, 0x65, 0x0e, 0x93, 0x98, 0x55, 0x1f, 0x02, 0xc8, 0xd1, 0x2e, 0x19, 0x9c, 0x81, 0xff, 0xd7,
+	0x60, 0x5e, 0x52, 0x95, 0x4e, 0x32, 0x4d, 0xb1, 0x3e, 0xb8, 0xfa, 0xb3, 0x02, 0x34, 0xc2, 0xb0,
+	0xc7, 0x5d, 0xa1, 0x01, 0x85, 0xc0, 0x01, 0x0a, 0x9d, 0x2d, 0xf4, 0x16, 0xcc, 0x89, 0x5a, 0x4f,
+	0xee, 0xfd, 0x6c, 0x7c, 0x6f, 0xb1, 0xb6, 0x1a, 0x89, 0x9d, 0x7c, 0x42, 0x93, 0x48, 0x4c, 0x46,
+	0x41, 0xa8, 0x10, 0x65, 0x41, 0x51, 0x8b, 0xcc, 0xa0, 0x0e, 0x2c, 0xc6, 0x33, 0x2d, 0xdf, 0xd0,
+	0x97, 0xc7, 0x85, 0x88, 0x2d, 0x9d, 0xea, 0x3c, 0x42, 0x34, 0x62, 0x89, 0x16, 0x41, 0xef, 0x00,
+	0xb8, 0x9e, 0xe3, 0x62, 0x8f, 0x9a, 0xd8, 0x37, 0xf1, 0x1c, 0x81, 0x26, 0x82, 0xa4, 0xfe, 0xab,
+	0x0c, 0xb5, 0x88, 0xa0, 0x52, 0xc2, 0x48, 0x5a, 0x45, 0x61, 0x7a, 0xbc, 0x2c, 0xa6, 0x2b, 0x86,
+	0x67, 0xa1, 0x61, 0xf2, 0x3b, 0xba, 0x27, 0xad, 0x99, 0x07, 0xd5, 0xaa, 0xb6, 0x20, 0x66, 0xa5,
+	0x6b, 0xa1, 0x1b, 0x50, 0xb3, 0x47, 0x56, 0xcf, 0x39, 0xec, 0x79, 0xce, 0x03, 0x22, 0x4b, 0x8f,
+	0xaa, 0x3d, 0xb2, 0xbe, 0x73, 0xa8, 0x39, 0x0f, 0x48, 0x98, 0xdd, 0xce, 0xcd, 0x98, 0xdd, 0xde,
+	0x80, 0x9a, 0xa5, 0x3f, 0x64, 0xbb, 0xf6, 0xec, 0x91, 0xc5, 0xab, 0x92, 0xa2, 0x56, 0xb5, 0xf4,
+	0x87, 0x9a, 0xf3, 0xe0, 0xfe, 0xc8, 0x42, 0x2b, 0xd0, 0x1c, 0xea, 0x84, 0xf6, 0xa2, 0x65, 0x4d,
+	0x85, 0x97, 0x35, 0x0d, 0x36, 0xff, 0x6e, 0x58, 0xda, 0xa4, 0xf3, 0xe4, 0xea, 0x39, 0xf2, 0x64,
+	0xc3, 0x1a, 0x86, 0x1b, 0x41, 0xfe, 0x3c, 0xd9, 0xb0, 0x86, 0xc1, 0x36, 0xaf, 0xc1, 0xfc, 0x01,
+	0xcf, 0x7c, 0x48, 0xab, 0x36, 0x36, 0xc8, 0xdd, 0x63, 0x49, 0x8f, 0x48, 0x90, 0x34, 0x1f, 0x1c,
+	0xbd, 0x09, 0x55, 0x7e, 0xe5, 0x70, 0xdc, 0x7a, 0x2e, 0xdc, 0x10, 0x81, 0x45, 0x33, 0x03, 0x0f,
+	0xa9, 0xce, 0xb1, 0x17, 0xc6, 0x46, 0xb3, 0x2d, 0x06, 0xb3, 0xe3, 0x0c, 0x44, 0x34, 0x0b, 0x30,
+	0xd0, 0x75, 0x00, 0xc3, 0x73, 0x5c, 0x17, 0x1b, 0x3d, 0x9d, 0xb6, 0x1a, 0x5c, 0xd8, 0x55, 0x39,
+	0xf3, 0x0e, 0x65, 0x16, 0x33, 0xd4, 0x4f, 0x9d, 0x11, 0xed, 0x9d, 0x60, 0x8f, 0x30, 0xf1, 0x2c,
+	0x2e, 0x2b, 0x2b, 0x65, 0x6d, 0x41, 0xcc, 0x7e, 0x20, 0x26, 0x59, 0x29, 0x2a, 0x4e, 0xd3, 0x23,
+	0xe6, 0x27, 0xb8, 0xd5, 0xe4, 0x8a, 0x05, 0x31, 0xd5, 0x35, 0x3f, 0xc1, 0xea, 0xa7, 0x70, 0x35,
+	0x34, 0x88, 0x88, 0xf0, 0xd3, 0x7a, 0x54, 0xce, 0xaa, 0xc7, 0xc9, 0x29, 0xea, 0x6f, 0x4a, 0xb0,
+	0xd4, 0xd5, 0x4f, 0xf0, 0xe3, 0xcf, 0x86, 0x73, 0x45, 0xf0, 0x1d, 0xb8, 0xcc, 0x13, 0xe0, 0xf5,
+	0x08, 0x3f, 0xad, 0x52, 0x2e, 0xdd, 0xa7, 0x11, 0xd1, 0xdb, 0x2c, 0x43, 0xc0, 0xfd, 0xe3, 0x3d,
+	0xc7, 0x0c, 0x2f, 0xd9, 0xeb, 0x19, 0xfb, 0x6c, 0x06, 0x50, 0x5a, 0x14, 0x03, 0xed, 0xa5, 0x83,
+	0xe1, 0x1c, 0xdf, 0xe4, 0xb9, 0x89, 0x65, 0x56, 0x28, 0xfd, 0x54, 0x4c, 0x6c, 0xc1, 0xbc, 0xbc,
+	0xc4, 0xb9, 0x9b, 0x57, 0x34, 0x7f, 0x88, 0xf6, 0xe0, 0x8a, 0x38, 0x41, 0x57, 0xda, 0xb0, 0x38,
+	0x7c, 0x25, 0xd7, 0xe1, 0xb3, 0x50, 0xe3, 0x2e, 0x50, 0x9d, 0xd5, 0x05, 0x58, 0x49, 0x00, 0xa1,
+	0x60, 0xa6, 0x54, 0xf6, 0xdf, 0x82, 0x4a, 0x60, 0xaa, 0x85, 0xdc, 0xa6, 0x1a, 0xe0, 0x24, 0x63,
+	0x6b, 0x31, 0x11, 0x5b, 0xd5, 0xbf, 0x2b, 0x50, 0x8f, 0x32, 0xca, 0x3c, 0xd0, 0xc3, 0x7d, 0xc7,
+	0x33, 0x7a, 0xd8, 0xa6, 0x1e, 0xbb, 0x60, 0x14, 0xee, 0xa4, 0x0b, 0x62, 0xf6, 0x5d, 0x31, 0xc9,
+	0xc0, 0x58, 0xb8, 0x24, 0x54, 0xb7, 0xdc, 0xde, 0xa1, 0xe7, 0x58, 0x9c, 0xbb, 0x92, 0xb6, 0x10,
+	0xcc, 0xde, 0xf3, 0x1c, 0x8b, 0xb5, 0xac, 0x42, 0x30, 0xea, 0x70, 0xfa, 0x25, 0xad, 0x16, 0xcc,
+	0xed, 0x3b, 0xe8, 0x19, 0x68, 0x70, 0xd9, 0xf4, 0x98, 0x3b, 0xb3, 0x4a, 0x4b, 0x5e, 0x12, 0x75,
+	0x43, 0xb2, 0xc5, 0x84, 0x1e, 0x87, 0xe2, 0x4e, 0x2f, 0xae, 0x89, 0x00, 0x8a, 0xbb, 0xfd, 0xbf,
+	0x15, 0x58, 0x60, 0xd7, 0xe6, 0x7d, 0xc7, 0xc0, 0xfb, 0x67, 0x4c, 0x32, 0x72, 0x74, 0xd9, 0xae,
+	0x41, 0x35, 0x38, 0x81, 0x3c, 0x52, 0x38, 0xc1, 0x82, 0x93, 0x85, 0x2d, 0xc7, 0x3b, 0xed, 0x1d,
+	0x99, 0x03, 0x71, 0x9a, 0x8a, 0x06, 0x62, 0xea, 0x3d, 0x73, 0x70, 0x84, 0x36, 0x00, 0xb8, 0x33,
+	0xb8, 0x4c, 0xff, 0xad, 0x72, 0x6e, 0xad, 0x46, 0xb0, 0x58, 0xdd, 0xbf, 0x20, 0xef, 0xcf, 0x6e,
+	0xd0, 0xda, 0xe5, 0xfc, 0x2a, 0x9c, 0x5f, 0xfe, 0x1b, 0xbd, 0x1e, 0xef, 0x0b, 0x3d, 0x93, 0xe9,
+	0xa2, 0x7c, 0x13, 0x9e, 0xed, 0xc6, 0x2e, 0xcf, 0x3c, 0x05, 0xe5, 0x67, 0xcc, 0x7a, 0xa4, 0xbc,
+	0xb9, 0xf5, 0xb4, 0x60, 0x5e, 0x37, 0x0c, 0x0f, 0x13, 0x22, 0xf9, 0xf0, 0x87, 0x6c, 0xc5, 0x0f,
+	0xe9, 0x22, 0x82, 0xf9, 0x43, 0xf4, 0x26, 0x54, 0x82, 0xf4, 0xb8, 0x98, 0x95, 0x12, 0x45, 0xf9,
+	0x94, 0x05, 0x50, 0x80, 0xa1, 0x7e, 0x51, 0x80, 0x86, 0x8c, 0x10, 0x1b, 0xf2, 0x82, 0x9b, 0xec,
+	0x51, 0x1b, 0x50, 0x3f, 0x0c, 0x3d, 0x7c, 0x52, 0xa3, 0x23, 0x1a, 0x08, 0x62, 0x38, 0xd3, 0xbc,
+	0x2a, 0x7e, 0xc5, 0x96, 0xce, 0x75, 0xc5, 0x96, 0x67, 0x8e, 0x2f, 0x3f, 0x50, 0xa0, 0x16, 0xd9,
+	0x99, 0x87, 0x46, 0xd1, 0xfc, 0x90, 0xc2, 0xf0, 0x87, 0x6c, 0xe5, 0x20, 0x22, 0x85, 0x6a, 0x98,
+	0x23, 0xdc, 0x62, 0xcd, 0x3c, 0xee, 0xe9, 0x2c, 0x73, 0xf2, 0xf3, 0xd9, 0x9a, 0x9c, 0xbb, 0x3f,
+	0xb2, 0x08, 0xeb, 0x95, 0xfb, 0xbe, 0xe8, 0xd7, 0x04, 0x15, 0x79, 0xfd, 0xf2, 0xa2, 0x85, 0x75,
+	0x4c, 0x35, 0xdc, 0x77, 0x4e, 0xb0, 0x77, 0x7a, 0xfe, 0xbe, 0xd4, 0x1b, 0x11, 0x23, 0xc9, 0x59,
+	0x43, 0x05, 0x08, 0xe8, 0x8d, 0xf0, 0x9c, 0xc5, 0xac, 0x6c, 0x39, 0x7a, 0xcd, 0x48, 0x15, 0x07,
+	0xa2, 0x50, 0x7f, 0x21, 0x3a, 0x6c, 0xf1, 0xa3, 0x9c, 0xf5, 0x26, 0x7f, 0x24, 0x79, 0xb5, 0xfa,
+	0x2b, 0x05, 0xbe, 0xba, 0x8d, 0xe9, 0xbd, 0x78, 0xd5, 0x7a, 0xd1, 0x5c, 0x59, 0xd0, 0xce, 0x62,
+	0xea, 0x3c, 0x5a, 0x6f, 0x43, 0x85, 0xf8, 0xa5, 0xbc, 0xe8, 0x7d, 0x06, 0x63, 0xf5, 0xc7, 0x0a,
+	0xb4, 0x24, 0x15, 0x4e, 0x73, 0xd3, 0xb1, 0xdc, 0x21, 0xa6, 0xd8, 0x78, 0xd2, 0xb5, 0xe5, 0xef,
+	0x14, 0x68, 0x46, 0xa3, 0x28, 0x5b, 0x45, 0xaf, 0x42, 0x99, 0x97, 0xf0, 0x92, 0x83, 0xa9, 0xc6,
+	0x2a, 0xa0, 0x99, 0x47, 0xf2, 0xc4, 0x66, 0x9f, 0xf8, 0x51, 0x52, 0x0e, 0xc3, 0x50, 0x5e, 0x9c,
+	0x39, 0x94, 0xab, 0xff, 0x55, 0xe0, 0x72, 0xc7, 0x72, 0x1d, 0x8f, 0xee, 0xeb, 0xe4, 0xf8, 0x82,
+	0xed, 0x84, 0x05, 0x0e, 0x56, 0x91, 0xb1, 0x1d, 0x0d, 0x79, 0x3b, 0x56, 0x3c, 0xe7, 0x01, 0xa3,
+	0x63, 0xb0, 0x0f, 0x58, 0x87, 0xe6, 0x50, 0x96, 0xb5, 0x55, 0x4d, 0x0c, 0x98, 0x03, 0x3b, 0x6e,
+	0x34, 0x4f, 0xcc, 0x51, 0xee, 0xfa, 0x18, 0x2c, 0x1e, 0xa2, 0xe8, 0xe9, 0xcf, 0x63, 0x90, 0x4b,
+	0x30, 0x47, 0x75, 0x72, 0x1c, 0x9c, 0x5d, 0x8e, 0x58, 0xf5, 0xcf, 0x54, 0x20, 0x3f, 0x2a, 0x8a,
+	0x43, 0x47, 0x66, 0xd4, 0xcf, 0x0b, 0x00, 0x21, 0x0f, 0x67, 0x10, 0xfd, 0x38, 0xc2, 0x8f, 0xa4,
+	0xb1, 0x19, 0x57, 0x49, 0x79, 0x9c, 0x4a, 0xe6, 0xc6, 0xa8, 0x64, 0x7e, 0x66, 0x95, 0x7c, 0x59,
+	0x80, 0xba, 0x10, 0x87, 0x86, 0xc9, 0x68, 0x48, 0x1f, 0xa1, 0x40, 0xbe, 0x11, 0xf7, 0x93, 0xec,
+	0xee, 0x8a, 0xa0, 0x1d, 0x4b, 0x77, 0x5e, 0x8f, 0x84, 0x9a, 0x7c, 0x1d, 0xc8, 0x00, 0xde, 0x17,
+	0x9f, 0xf8, 0xf2, 0x2a, 0xf2, 0x52, 0x26, 0xbe, 0x4d, 0x36, 0x66, 0xdd, 0x0b, 0xf1, 0x45, 0x25,
+	0xb7, 0xe5, 0x0a, 0x78, 0xf5, 0xaf, 0x45, 0x68, 0x84, 0x36, 0x93, 0xd9, 0xa6, 0x89, 0x9b, 0x5d,
+	0x21, 0x69, 0x76, 0xff, 0x9f, 0xd6, 0x11, 0xaa, 0xb0, 0x32, 0x9b, 0x0a, 0x63, 0x6a, 0xa8, 0x26,
+	0xd4, 0x10, 0xef, 0x61, 0x42, 0xaa, 0x87, 0x19, 0xa8, 0xa9, 0x36, 0x9b, 0x9a, 0x18, 0xd5, 0xbe,
+	0x87, 0x75, 0x8a, 0x7b, 0x94, 0xb5, 0x53, 0x38, 0x55, 0x31, 0xb1, 0x4f, 0xd4, 0x4f, 0x00, 0x6d,
+	0x79, 0x8e, 0x2b, 0xcd, 0xe6, 0x71, 0x75, 0x00, 0x98, 0x0e, 0x1c, 0xaf, 0x2f, 0x6c, 0xbe, 0xa2,
+	0x89, 0x01, 0xeb, 0x79, 0xb6, 0xd8, 0xa5, 0xa8, 0x8b, 0x76, 0xe5, 0x93, 0xce, 0x91, 0xc7, 0xd4,
+	0xdd, 0xc5, 0x47, 0x54, 0x77, 0x97, 0x66, 0xce, 0x8b, 0x7f, 0xad, 0xc0, 0xd5, 0x50, 0x1e, 0xbb,
+	0xd8, 0x1b, 0xe0, 0x6d, 0xcf, 0x19, 0xb9, 0xa8, 0x0b, 0x0d, 0x12, 0x93, 0x8e, 0xfc, 0x78, 0xf3,
+	0x42, 0xd6, 0x1d, 0x3b, 0x46, 0xa0, 0x5a, 0x62, 0x0b, 0xf4, 0x3c, 0x34, 0xa9, 0xee, 0x0d, 0x30,
+	0xed, 0x25, 0x15, 0xb7, 0x28, 0xe6, 0x83, 0xbe, 0xb8, 0xfa, 0x4f, 0xde, 0x9c, 0xf6, 0xf7, 0xdd,
+	0x1b, 0xea, 0x36, 0x8b, 0x6e, 0xee, 0x50, 0x0f, 0xbf, 0xd0, 0xc8, 0x11, 0xda, 0x06, 0xb0, 0x02,
+	0xc6, 0x5b, 0x85, 0xb1, 0x3d, 0x93, 0xac, 0x73, 0x6a, 0x11, 0x54, 0xd6, 0x87, 0x13, 0x1d, 0x18,
+	0xde, 0xf4, 0x94, 0x35, 0xac, 0xc8, 0x35, 0x58, 0xbf, 0xf3, 0x45, 0x40, 0x6c, 0x81, 0x35, 0xe2,
+	0x4c, 0xbb, 0x47, 0x70, 0xdf, 0xb1, 0x0d, 0xc2, 0x63, 0x43, 0x59, 0x6b, 0xca, 0x95, 0x8e, 0xdd,
+	0x15, 0xf3, 0xe8, 0x55, 0x28, 0xd1, 0x53, 0x57, 0x94, 0xe4, 0x8d, 0xf5, 0x5b, 0x13, 0xf9, 0xd9,
+	0x3f, 0x75, 0xb1, 0xc6, 0xc1, 0x99, 0x4b, 0xb2, 0xad, 0xa8, 0xa7, 0x9f, 0xe0, 0xa1, 0xff, 0x9e,
+	0x24, 0x9c, 0x49, 0x45, 0xaf, 0xf9, 0xe9, 0xd1, 0xab, 0x92, 0x4e, 0x4b, 0xff, 0x52, 0x80, 0x66,
+	0x48, 0x5e, 0xde, 0x37, 0xe3, 0xe4, 0x3b, 0xd9, 0xcf, 0xa6, 0x95, 0x7d, 0x6f, 0x43, 0x4d, 0xf6,
+	0xbb, 0x67, 0x28, 0xfc, 0x40, 0xa0, 0xec, 0x4c, 0xf0, 0x99, 0xf2, 0x23, 0xf2, 0x99, 0xb9, 0x99,
+	0x7d, 0xa6, 0x0b, 0x4b, 0x7e, 0x8e, 0x1d, 0x52, 0xda, 0xc5, 0x54, 0x9f, 0x50, 0x55, 0x86, 0xcd,
+	0x59, 0xde, 0xcd, 0x11, 0xfd, 0x13, 0xd9, 0x9c, 0x65, 0x4c, 0xdd, 0xb9, 0x0b, 0x97, 0x53, 0xa9,
+	0x2a, 0x6a, 0x00, 0xbc, 0x6f, 0xf7, 0x65, 0x0e, 0xdf, 0xbc, 0x84, 0xea, 0x50, 0xf1, 0x33, 0xfa,
+	0xa6, 0x72, 0xa7, 0x0b, 0x8d, 0xb8, 0x09, 0xa1, 0xa7, 0xe0, 0xca, 0xfb, 0xb6, 0x81, 0x0f, 0x4d,
+	0x1b, 0x1b, 0xe1, 0x52, 0xf3, 0x12, 0xba, 0x02, 0x8b, 0x1d, 0xdb, 0xc6, 0x5e, 0x64, 0x52, 0x61,
+	0x93, 0xdc, 0x11, 0x22, 0x93, 0x85, 0xf5, 0xff, 0x2c, 0x42, 0x95, 0x75, 0x2f, 0x36, 0x1d, 0xc7,
+	0x33, 0x90, 0x0b, 0x88, 0x7f, 0x1b, 0xb7, 0x5c, 0xc7, 0x0e, 0x1e, 0x91, 0xa0, 0x97, 0xc7, 0xf4,
+	0x65, 0xd2, 0xa0, 0x32, 0xb8, 0xb7, 0x6f, 0x8f, 0xc1, 0x48, 0x80, 0xab, 0x97, 0x90, 0xc5, 0x29,
+	0x32, 0x7f, 0xdb, 0x37, 0xfb, 0xc7, 0xfe, 0xd7, 0x90, 0x09, 0x14, 0x13, 0xa0, 0x3e, 0xc5, 0xc4,
+	0xdb, 0x14, 0x39, 0x10, 0x0f, 0x18, 0xfc, 0x74, 0x57, 0xbd, 0x84, 0x3e, 0x86, 0xab, 0xec, 0x63,
+	0x71, 0xf0, 0xcd, 0xda, 0x27, 0xb8, 0x3e, 0x9e, 0x60, 0x0a, 0x78, 0x46, 0x92, 0x3b, 0x50, 0xe6,
+	0xb5, 0x19, 0xca, 0xb2, 0xb9, 0xe8, 0x4b, 0xca, 0xf6, 0xf2, 0x78, 0x80, 0x60, 0xb7, 0xef, 0xc3,
+	0x62, 0xe2, 0xa5, 0x18, 0x7a, 0x3e, 0x03, 0x2d, 0xfb, 0xcd, 0x5f, 0xfb, 0x4e, 0x1e, 0xd0, 0x80,
+	0xd6, 0x00, 0x1a, 0xf1, 0x2f, 0xeb, 0x68, 0x25, 0x03, 0x3f, 0xf3, 0x95, 0x4f, 0xfb, 0xf9, 0x1c,
+	0x90, 0x01, 0x21, 0x0b, 0x9a, 0xc9, 0x97, 0x4b, 0xe8, 0xce, 0xc4, 0x0d, 0xe2, 0xe6, 0xf6, 0x42,
+	0x2e, 0xd8, 0x80, 0xdc, 0x29, 0x5c, 0xcd, 0x7a, 0x39, 0x83, 0x56, 0xb3, 0xb7, 0x19, 0xf7, 0xa4,
+	0xa7, 0xbd, 0x96, 0x1b, 0x3e, 0x20, 0xfd, 0x43, 0xd1, 0x13, 0xca, 0x7a, 0x7d, 0x82, 0xee, 0x66,
+	0x6f, 0x37, 0xe1, 0xd9, 0x4c, 0x7b, 0x7d, 0x16, 0x94, 0x80, 0x89, 0x4f, 0x61, 0x29, 0xfb, 0x05,
+	0x07, 0x7a, 0x39, 0x7b, 0xbf, 0xf1, 0x4f, 0x53, 0xda, 0x77, 0x67, 0xc0, 0x08, 0x18, 0x70, 0x92,
+	0x6f, 0xc3, 0x7c, 0x37, 0x5c, 0x9b, 0x6a, 0x35, 0x67, 0xf3, 0xc1, 0x8f, 0x60, 0x31, 0xf1, 0x21,
+	0x2a, 0xd3, 0x6b, 0xb2, 0x3f, 0x56, 0xb5, 0x27, 0x55, 0xc5, 0xc2, 0x25, 0x13, 0xbd, 0x31, 0x34,
+	0xc6, 0xfa, 0x33, 0xfa, 0x67, 0xed, 0x3b, 0x79, 0x40, 0x83, 0x83, 0x10, 0x1e, 0x2e, 0x13, 0xfd,
+	0x25, 0xf4, 0x62, 0xf6, 0x1e, 0xd9, 0xbd, 0xb1, 0xf6, 0x4b, 0x39, 0xa1, 0x03, 0xa2, 0x3d, 0x80,
+	0x6d, 0x4c, 0x77, 0x31, 0xf5, 0x98, 0x8d, 0xdc, 0xce, 0x14, 0x79, 0x08, 0xe0, 0x93, 0x79, 0x6e,
+	0x2a, 0x5c, 0x40, 0xe0, 0x43, 0x98, 0x13, 0xa5, 0x0c, 0xca, 0x6a, 0xe9, 0xa4, 0xba, 0x36, 0xed,
+	0x67, 0xa7, 0x40, 0x05, 0x1b, 0x1f, 0xf3, 0x08, 0x16, 0x29, 0x93, 0x92, 0x61, 0x25, 0xe4, 0x2a,
+	0x02, 0x34, 0x26, 0xac, 0x8c, 0x81, 0x0d, 0x88, 0xdd, 0x87, 0xba, 0x86, 0xd9, 0x82, 0x3c, 0xcb,
+	0xcd, 0xb1, 0x5c, 0x8a, 0x04, 0x6c, 0x9a, 0x5d, 0x75, 0xa1, 0x16, 0xa9, 0x9b, 0x50, 0xd6, 0xa1,
+	0xd3, 0x75, 0xd5, 0x94, 0x4d, 0xd7, 0xff, 0x51, 0x82, 0x8a, 0xff, 0xb5, 0xe2, 0x02, 0xae, 0xfb,
+	0x0b, 0xb8, 0x7f, 0x3f, 0x82, 0xc5, 0xc4, 0x33, 0xa6, 0x4c, 0xf7, 0xcc, 0x7e, 0xea, 0x34, 0x4d,
+	0x47, 0x1f, 0xca, 0x7f, 0x24, 0x04, 0xae, 0xf8, 0xdc, 0xb8, 0x3b, 0x3c, 0xe9, 0x85, 0x53, 0x36,
+	0x7e, 0xec, 0x3e, 0x77, 0x2f, 0xf0, 0xb9, 0xeb, 0x13, 0xbd, 0x69, 0x0a, 0xa3, 0x1b, 0xaf, 0x7c,
+	0xef, 0xee, 0xc0, 0xa4, 0x47, 0xa3, 0x03, 0xb6, 0xb2, 0x26, 0x40, 0x5f, 0x32, 0x1d, 0xf9, 0x6b,
+	0xcd, 0xd7, 0xe4, 0x1a, 0xc7, 0x5e, 0x63, 0x9b, 0xbb, 0x07, 0x07, 0x73, 0x7c, 0xf4, 0xca, 0xff,
+	0x06, 0x00, 0xb6, 0x7b, 0xd3, 0xc4, 0xab, 0x32, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2905,6 +3519,10 @@ type DataCoordClient interface {
 	GetFlushedSegments(ctx context.Context, in *GetFlushedSegmentsRequest, opts ...grpc.CallOption) (*GetFlushedSegmentsResponse, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
+	Import(ctx context.Context, in *ImportTaskRequest, opts ...grpc.CallOption) (*ImportTaskResponse, error)
+	GetImportState(ctx context.Context, in *milvuspb.GetImportStateRequest, opts ...grpc.CallOption) (*milvuspb.GetImportStateResponse, error)
+	ReportImport(ctx context.Context, in *ImportResult, opts ...grpc.CallOption) (*commonpb.Status, error)
+	DropSegment(ctx context.Context, in *DropSegmentRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 }
 
 type dataCoordClient struct {
@@ -3050,6 +3668,42 @@ func (c *dataCoordClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetric
 	return out, nil
 }
 
+func (c *dataCoordClient) Import(ctx context.Context, in *ImportTaskRequest, opts ...grpc.CallOption) (*ImportTaskResponse, error) {
+	out := new(ImportTaskResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataCoord/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCoordClient) GetImportState(ctx context.Context, in *milvuspb.GetImportStateRequest, opts ...grpc.CallOption) (*milvuspb.GetImportStateResponse, error) {
+	out := new(milvuspb.GetImportStateResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataCoord/GetImportState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCoordClient) ReportImport(ctx context.Context, in *ImportResult, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataCoord/ReportImport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataCoordClient) DropSegment(ctx context.Context, in *DropSegmentRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataCoord/DropSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataCoordServer is the server API for DataCoord service.
 type DataCoordServer interface {
 	GetComponentStates(context.Context, *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error)
@@ -3068,6 +3722,10 @@ type DataCoordServer interface {
 	GetFlushedSegments(context.Context, *GetFlushedSegmentsRequest) (*GetFlushedSegmentsResponse, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
+	Import(context.Context, *ImportTaskRequest) (*ImportTaskResponse, error)
+	GetImportState(context.Context, *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error)
+	ReportImport(context.Context, *ImportResult) (*commonpb.Status, error)
+	DropSegment(context.Context, *DropSegmentRequest) (*commonpb.Status, error)
 }
 
 // UnimplementedDataCoordServer can be embedded to have forward compatible implementations.
@@ -3119,6 +3777,18 @@ func (*UnimplementedDataCoordServer) GetFlushedSegments(ctx context.Context, req
 func (*UnimplementedDataCoordServer) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (*UnimplementedDataCoordServer) Import(ctx context.Context, req *ImportTaskRequest) (*ImportTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (*UnimplementedDataCoordServer) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportState not implemented")
+}
+func (*UnimplementedDataCoordServer) ReportImport(ctx context.Context, req *ImportResult) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportImport not implemented")
+}
+func (*UnimplementedDataCoordServer) DropSegment(ctx context.Context, req *DropSegmentRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropSegment not implemented")
+}
 
 func RegisterDataCoordServer(s *grpc.Server, srv DataCoordServer) {
 	s.RegisterService(&_DataCoord_serviceDesc, srv)
@@ -3394,6 +4064,78 @@ func _DataCoord_GetMetrics_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataCoord_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataCoord/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).Import(ctx, req.(*ImportTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCoord_GetImportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(milvuspb.GetImportStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).GetImportState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataCoord/GetImportState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).GetImportState(ctx, req.(*milvuspb.GetImportStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCoord_ReportImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportResult)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).ReportImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataCoord/ReportImport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).ReportImport(ctx, req.(*ImportResult))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataCoord_DropSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).DropSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataCoord/DropSegment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).DropSegment(ctx, req.(*DropSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DataCoord_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.data.DataCoord",
 	HandlerType: (*DataCoordServer)(nil),
@@ -3458,6 +4200,22 @@ var _DataCoord_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetMetrics",
 			Handler:    _DataCoord_GetMetrics_Handler,
 		},
+		{
+			MethodName: "Import",
+			Handler:    _DataCoord_Import_Handler,
+		},
+		{
+			MethodName: "GetImportState",
+			Handler:    _DataCoord_GetImportState_Handler,
+		},
+		{
+			MethodName: "ReportImport",
+			Handler:    _DataCoord_ReportImport_Handler,
+		},
+		{
+			MethodName: "DropSegment",
+			Handler:    _DataCoord_DropSegment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "data_coord.proto",
@@ -3473,6 +4231,7 @@ type DataNodeClient interface {
 	FlushSegments(ctx context.Context, in *FlushSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
+	Import(ctx context.Context, in *ImportTask, opts ...grpc.CallOption) (*commonpb.Status, error)
 }
 
 type dataNodeClient struct {
@@ -3528,6 +4287,15 @@ func (c *dataNodeClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetrics
 	return out, nil
 }
 
+func (c *dataNodeClient) Import(ctx context.Context, in *ImportTask, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataNode/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataNodeServer is the server API for DataNode service.
 type DataNodeServer interface {
 	GetComponentStates(context.Context, *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error)
@@ -3536,6 +4304,7 @@ type DataNodeServer interface {
 	FlushSegments(context.Context, *FlushSegmentsRequest) (*commonpb.Status, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
+	Import(context.Context, *ImportTask) (*commonpb.Status, error)
 }
 
 // UnimplementedDataNodeServer can be embedded to have forward compatible implementations.
@@ -3557,6 +4326,9 @@ func (*UnimplementedDataNodeServer) FlushSegments(ctx context.Context, req *Flus
 func (*UnimplementedDataNodeServer) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (*UnimplementedDataNodeServer) Import(ctx context.Context, req *ImportTask) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 
 func RegisterDataNodeServer(s *grpc.Server, srv DataNodeServer) {
 	s.RegisterService(&_DataNode_serviceDesc, srv)
@@ -3652,6 +4424,24 @@ func _DataNode_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataNode_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTask)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataNodeServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataNode/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataNodeServer).Import(ctx, req.(*ImportTask))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DataNode_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.data.DataNode",
 	HandlerType: (*DataNodeServer)(nil),
@@ -3676,6 +4466,10 @@ var _DataNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetMetrics",
 			Handler:    _DataNode_GetMetrics_Handler,
 		},
+		{
+			MethodName: "Import",
+			Handler:    _DataNode_Import_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "data_coord.proto",
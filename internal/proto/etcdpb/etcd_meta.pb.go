@@ -254,6 +254,8 @@ type CollectionInfo struct {
 	PartitionCreatedTimestamps []uint64                   `protobuf:"varint,9,rep,packed,name=partition_created_timestamps,json=partitionCreatedTimestamps,proto3" json:"partition_created_timestamps,omitempty"`
 	ShardsNum                  int32                      `protobuf:"varint,10,opt,name=shards_num,json=shardsNum,proto3" json:"shards_num,omitempty"`
 	StartPositions             []*commonpb.KeyDataPair    `protobuf:"bytes,11,rep,name=start_positions,json=startPositions,proto3" json:"start_positions,omitempty"`
+	Properties                 []*commonpb.KeyValuePair   `protobuf:"bytes,12,rep,name=properties,proto3" json:"properties,omitempty"`
+	PropertiesVersion          uint64                     `protobuf:"varint,13,opt,name=properties_version,json=propertiesVersion,proto3" json:"properties_version,omitempty"`
 	XXX_NoUnkeyedLiteral       struct{}                   `json:"-"`
 	XXX_unrecognized           []byte                     `json:"-"`
 	XXX_sizecache              int32                      `json:"-"`
@@ -361,6 +363,20 @@ func (m *CollectionInfo) GetStartPositions() []*commonpb.KeyDataPair {
 	return nil
 }
 
+func (m *CollectionInfo) GetProperties() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *CollectionInfo) GetPropertiesVersion() uint64 {
+	if m != nil {
+		return m.PropertiesVersion
+	}
+	return 0
+}
+
 type SegmentIndexInfo struct {
 	CollectionID         int64    `protobuf:"varint,1,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
 	PartitionID          int64    `protobuf:"varint,2,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
@@ -540,52 +556,54 @@ func init() {
 func init() { proto.RegisterFile("etcd_meta.proto", fileDescriptor_975d306d62b73e88) }
 
 var fileDescriptor_975d306d62b73e88 = []byte{
-	// 745 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xcd, 0x6e, 0xe3, 0x36,
-	0x10, 0x86, 0x22, 0xc7, 0x8e, 0xc6, 0x8e, 0x9d, 0xb0, 0x3f, 0x20, 0x82, 0xb4, 0x55, 0x04, 0x24,
-	0x15, 0x50, 0xd4, 0x46, 0x93, 0xa2, 0xb7, 0x02, 0x6d, 0x23, 0x04, 0x30, 0x8a, 0x06, 0xae, 0x62,
-	0xf4, 0xd0, 0x8b, 0x40, 0x4b, 0xb4, 0x4d, 0x40, 0xa4, 0xbc, 0x22, 0x15, 0xc4, 0xb7, 0x3d, 0xef,
-	0x23, 0xec, 0x23, 0xec, 0x8b, 0xed, 0x61, 0x5f, 0x62, 0x21, 0x52, 0x92, 0xed, 0xc4, 0x39, 0xee,
-	0x4d, 0xf3, 0xcd, 0x0c, 0xf9, 0xcd, 0xc7, 0x6f, 0x04, 0x03, 0xaa, 0xe2, 0x24, 0xe2, 0x54, 0x91,
-	0xe1, 0x2a, 0xcf, 0x54, 0x86, 0x4e, 0x39, 0x4b, 0x1f, 0x0b, 0x69, 0xa2, 0x61, 0x99, 0x3d, 0xeb,
-	0xc5, 0x19, 0xe7, 0x99, 0x30, 0xd0, 0x59, 0x4f, 0xc6, 0x4b, 0xca, 0xab, 0x72, 0xef, 0xbd, 0x05,
-	0x30, 0xa5, 0x82, 0x08, 0xf5, 0x0f, 0x55, 0x04, 0xf5, 0xe1, 0x60, 0x1c, 0x60, 0xcb, 0xb5, 0x7c,
-	0x3b, 0x3c, 0x18, 0x07, 0xe8, 0x0a, 0x06, 0xa2, 0xe0, 0xd1, 0x9b, 0x82, 0xe6, 0xeb, 0x48, 0x64,
-	0x09, 0x95, 0xf8, 0x40, 0x27, 0x8f, 0x45, 0xc1, 0xff, 0x2d, 0xd1, 0xfb, 0x12, 0x44, 0x3f, 0xc1,
-	0x29, 0x13, 0x92, 0xe6, 0x2a, 0x8a, 0x97, 0x44, 0x08, 0x9a, 0x8e, 0x03, 0x89, 0x6d, 0xd7, 0xf6,
-	0x9d, 0xf0, 0xc4, 0x24, 0x6e, 0x1b, 0x1c, 0xfd, 0x08, 0x03, 0x73, 0x60, 0x53, 0x8b, 0x5b, 0xae,
-	0xe5, 0x3b, 0x61, 0x5f, 0xc3, 0x4d, 0xa5, 0xf7, 0xd6, 0x02, 0x67, 0x92, 0x67, 0x4f, 0xeb, 0xbd,
-	0xdc, 0x7e, 0x83, 0x0e, 0x49, 0x92, 0x9c, 0x4a, 0xc3, 0xa9, 0x7b, 0x7d, 0x3e, 0xdc, 0x99, 0xbd,
-	0x9a, 0xfa, 0x4f, 0x53, 0x13, 0xd6, 0xc5, 0x25, 0xd7, 0x9c, 0xca, 0x22, 0xdd, 0xc7, 0xd5, 0x24,
-	0x36, 0x5c, 0xbd, 0x77, 0x16, 0x38, 0x63, 0x91, 0xd0, 0xa7, 0xb1, 0x98, 0x67, 0xe8, 0x3b, 0x00,
-	0x56, 0x06, 0x91, 0x20, 0x9c, 0x6a, 0x2a, 0x4e, 0xe8, 0x68, 0xe4, 0x9e, 0x70, 0x8a, 0x30, 0x74,
-	0x74, 0x30, 0x0e, 0x2a, 0x95, 0xea, 0x10, 0x05, 0xd0, 0x33, 0x8d, 0x2b, 0x92, 0x13, 0x6e, 0xae,
-	0xeb, 0x5e, 0x5f, 0xec, 0x25, 0xfc, 0x37, 0x5d, 0xff, 0x47, 0xd2, 0x82, 0x4e, 0x08, 0xcb, 0xc3,
-	0xae, 0x6e, 0x9b, 0xe8, 0x2e, 0x2f, 0x80, 0xfe, 0x1d, 0xa3, 0x69, 0xb2, 0x21, 0x84, 0xa1, 0x33,
-	0x67, 0x29, 0x4d, 0x1a, 0x61, 0xea, 0xf0, 0x75, 0x2e, 0xde, 0x87, 0x16, 0xf4, 0x6f, 0xb3, 0x34,
-	0xa5, 0xb1, 0x62, 0x99, 0xd0, 0xc7, 0x3c, 0x97, 0xf6, 0x77, 0x68, 0x1b, 0x97, 0x54, 0xca, 0x5e,
-	0xee, 0x12, 0xad, 0x1c, 0xb4, 0x39, 0xe4, 0x41, 0x03, 0x61, 0xd5, 0x84, 0x7e, 0x80, 0x6e, 0x9c,
-	0x53, 0xa2, 0x68, 0xa4, 0x18, 0xa7, 0xd8, 0x76, 0x2d, 0xbf, 0x15, 0x82, 0x81, 0xa6, 0x8c, 0x53,
-	0xe4, 0x41, 0x6f, 0x45, 0x72, 0xc5, 0x34, 0x81, 0x40, 0xe2, 0x96, 0x6b, 0xfb, 0x76, 0xb8, 0x83,
-	0xa1, 0x2b, 0xe8, 0x37, 0x71, 0xa9, 0xae, 0xc4, 0x87, 0xfa, 0x8d, 0x9e, 0xa1, 0xe8, 0x0e, 0x8e,
-	0xe7, 0xa5, 0x28, 0x91, 0x9e, 0x8f, 0x4a, 0xdc, 0xde, 0xa7, 0x6d, 0xb9, 0x08, 0xc3, 0x5d, 0xf1,
-	0xc2, 0xde, 0xbc, 0x89, 0xa9, 0x44, 0xd7, 0xf0, 0xcd, 0x23, 0xcb, 0x55, 0x41, 0xd2, 0xda, 0x17,
-	0xfa, 0x95, 0x25, 0xee, 0xe8, 0x6b, 0xbf, 0xaa, 0x92, 0x95, 0x37, 0xcc, 0xdd, 0xbf, 0xc2, 0xb7,
-	0xab, 0xe5, 0x5a, 0xb2, 0xf8, 0x45, 0xd3, 0x91, 0x6e, 0xfa, 0xba, 0xce, 0xee, 0x74, 0xfd, 0x01,
-	0xe7, 0xcd, 0x0c, 0x91, 0x51, 0x25, 0xd1, 0x4a, 0x49, 0x45, 0xf8, 0x4a, 0x62, 0xc7, 0xb5, 0xfd,
-	0x56, 0x78, 0xd6, 0xd4, 0xdc, 0x9a, 0x92, 0x69, 0x53, 0x51, 0xfa, 0x50, 0x2e, 0x49, 0x9e, 0xc8,
-	0x48, 0x14, 0x1c, 0x83, 0x6b, 0xf9, 0x87, 0xa1, 0x63, 0x90, 0xfb, 0x82, 0xa3, 0x31, 0x0c, 0xa4,
-	0x22, 0xb9, 0x8a, 0x56, 0x99, 0xd4, 0x27, 0x48, 0xdc, 0xd5, 0xa2, 0xb8, 0xaf, 0x19, 0x2e, 0x20,
-	0x8a, 0x68, 0xbf, 0xf5, 0x75, 0xe3, 0xa4, 0xee, 0xf3, 0x3e, 0x5a, 0x70, 0xf2, 0x40, 0x17, 0x9c,
-	0x0a, 0xb5, 0x71, 0x9d, 0x07, 0xbd, 0x78, 0x63, 0xa0, 0xda, 0x38, 0x3b, 0x18, 0x72, 0xa1, 0xbb,
-	0xf5, 0x9c, 0x95, 0x07, 0xb7, 0x21, 0x74, 0x0e, 0x8e, 0xac, 0x4e, 0x0e, 0xb4, 0x47, 0xec, 0x70,
-	0x03, 0x18, 0x67, 0x97, 0xcf, 0x63, 0x7e, 0x0e, 0xda, 0xd9, 0x3a, 0xdc, 0x76, 0xf6, 0xe1, 0xee,
-	0x96, 0x61, 0xe8, 0xcc, 0x0a, 0xa6, 0x7b, 0xda, 0x26, 0x53, 0x85, 0xe8, 0x02, 0x7a, 0x54, 0x90,
-	0x59, 0x4a, 0x8d, 0x4b, 0x70, 0xc7, 0xb5, 0xfc, 0xa3, 0xb0, 0x6b, 0x30, 0x3d, 0x98, 0xf7, 0xc9,
-	0xda, 0x5e, 0x8b, 0xbd, 0x7f, 0x9c, 0x2f, 0xbd, 0x16, 0xdf, 0x03, 0x34, 0x02, 0xd4, 0x4b, 0xb1,
-	0x85, 0xa0, 0xcb, 0xad, 0x95, 0x88, 0x14, 0x59, 0xd4, 0x2b, 0x71, 0xdc, 0xa0, 0x53, 0xb2, 0x90,
-	0x2f, 0xb6, 0xab, 0xfd, 0x72, 0xbb, 0xfe, 0xba, 0xf9, 0xff, 0x97, 0x05, 0x53, 0xcb, 0x62, 0x56,
-	0x9a, 0x60, 0x64, 0xc6, 0xf8, 0x99, 0x65, 0xd5, 0xd7, 0x88, 0x09, 0x45, 0x73, 0x41, 0xd2, 0x91,
-	0x9e, 0x6c, 0x54, 0x6e, 0xcf, 0x6a, 0x36, 0x6b, 0xeb, 0xe8, 0xe6, 0x73, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x9e, 0xc5, 0x2c, 0x1a, 0x75, 0x06, 0x00, 0x00,
+	// 776 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x5d, 0x6b, 0xe3, 0x46,
+	0x14, 0x45, 0x51, 0x62, 0xaf, 0xae, 0x1d, 0x67, 0x33, 0xfd, 0x60, 0x08, 0x69, 0xab, 0x15, 0xec,
+	0xd6, 0x50, 0x36, 0xa1, 0xd9, 0xd2, 0xb7, 0x42, 0xb7, 0x11, 0x0b, 0xa6, 0x34, 0xa4, 0xda, 0xb0,
+	0x0f, 0x7d, 0x11, 0x63, 0xe9, 0x26, 0x1e, 0xd0, 0x8c, 0xd4, 0x99, 0x51, 0x58, 0xbf, 0xf5, 0xb9,
+	0x3f, 0xa1, 0xf4, 0xff, 0xf5, 0xa1, 0x7f, 0xa2, 0x68, 0x46, 0x1f, 0xf6, 0xc6, 0x81, 0xbe, 0xec,
+	0x9b, 0xef, 0xb9, 0x1f, 0x73, 0xef, 0xf1, 0x39, 0x82, 0x23, 0x34, 0x59, 0x9e, 0x0a, 0x34, 0xec,
+	0xac, 0x52, 0xa5, 0x29, 0xc9, 0xb1, 0xe0, 0xc5, 0x7d, 0xad, 0x5d, 0x74, 0xd6, 0x64, 0x4f, 0xa6,
+	0x59, 0x29, 0x44, 0x29, 0x1d, 0x74, 0x32, 0xd5, 0xd9, 0x0a, 0x45, 0x5b, 0x1e, 0xfd, 0xe5, 0x01,
+	0xdc, 0xa0, 0x64, 0xd2, 0xfc, 0x82, 0x86, 0x91, 0x19, 0xec, 0x2d, 0x62, 0xea, 0x85, 0xde, 0xdc,
+	0x4f, 0xf6, 0x16, 0x31, 0x79, 0x01, 0x47, 0xb2, 0x16, 0xe9, 0xef, 0x35, 0xaa, 0x75, 0x2a, 0xcb,
+	0x1c, 0x35, 0xdd, 0xb3, 0xc9, 0x43, 0x59, 0x8b, 0x5f, 0x1b, 0xf4, 0xaa, 0x01, 0xc9, 0x37, 0x70,
+	0xcc, 0xa5, 0x46, 0x65, 0xd2, 0x6c, 0xc5, 0xa4, 0xc4, 0x62, 0x11, 0x6b, 0xea, 0x87, 0xfe, 0x3c,
+	0x48, 0x9e, 0xba, 0xc4, 0x65, 0x8f, 0x93, 0xaf, 0xe1, 0xc8, 0x0d, 0xec, 0x6b, 0xe9, 0x7e, 0xe8,
+	0xcd, 0x83, 0x64, 0x66, 0xe1, 0xbe, 0x32, 0xfa, 0xc3, 0x83, 0xe0, 0x5a, 0x95, 0xef, 0xd7, 0x3b,
+	0x77, 0xfb, 0x1e, 0xc6, 0x2c, 0xcf, 0x15, 0x6a, 0xb7, 0xd3, 0xe4, 0xe2, 0xf4, 0x6c, 0xeb, 0xf6,
+	0xf6, 0xea, 0xd7, 0xae, 0x26, 0xe9, 0x8a, 0x9b, 0x5d, 0x15, 0xea, 0xba, 0xd8, 0xb5, 0xab, 0x4b,
+	0x0c, 0xbb, 0x46, 0x7f, 0x7a, 0x10, 0x2c, 0x64, 0x8e, 0xef, 0x17, 0xf2, 0xb6, 0x24, 0x5f, 0x00,
+	0xf0, 0x26, 0x48, 0x25, 0x13, 0x68, 0x57, 0x09, 0x92, 0xc0, 0x22, 0x57, 0x4c, 0x20, 0xa1, 0x30,
+	0xb6, 0xc1, 0x22, 0x6e, 0x59, 0xea, 0x42, 0x12, 0xc3, 0xd4, 0x35, 0x56, 0x4c, 0x31, 0xe1, 0x9e,
+	0x9b, 0x5c, 0x3c, 0xdb, 0xb9, 0xf0, 0xcf, 0xb8, 0x7e, 0xc7, 0x8a, 0x1a, 0xaf, 0x19, 0x57, 0xc9,
+	0xc4, 0xb6, 0x5d, 0xdb, 0xae, 0x28, 0x86, 0xd9, 0x1b, 0x8e, 0x45, 0x3e, 0x2c, 0x44, 0x61, 0x7c,
+	0xcb, 0x0b, 0xcc, 0x7b, 0x62, 0xba, 0xf0, 0xf1, 0x5d, 0xa2, 0xbf, 0x0f, 0x60, 0x76, 0x59, 0x16,
+	0x05, 0x66, 0x86, 0x97, 0xd2, 0x8e, 0xf9, 0x90, 0xda, 0x1f, 0x60, 0xe4, 0x54, 0xd2, 0x32, 0xfb,
+	0x7c, 0x7b, 0xd1, 0x56, 0x41, 0xc3, 0x90, 0xb7, 0x16, 0x48, 0xda, 0x26, 0xf2, 0x15, 0x4c, 0x32,
+	0x85, 0xcc, 0x60, 0x6a, 0xb8, 0x40, 0xea, 0x87, 0xde, 0x7c, 0x3f, 0x01, 0x07, 0xdd, 0x70, 0x81,
+	0x24, 0x82, 0x69, 0xc5, 0x94, 0xe1, 0x76, 0x81, 0x58, 0xd3, 0xfd, 0xd0, 0x9f, 0xfb, 0xc9, 0x16,
+	0x46, 0x5e, 0xc0, 0xac, 0x8f, 0x1b, 0x76, 0x35, 0x3d, 0xb0, 0xff, 0xd1, 0x07, 0x28, 0x79, 0x03,
+	0x87, 0xb7, 0x0d, 0x29, 0xa9, 0xbd, 0x0f, 0x35, 0x1d, 0xed, 0xe2, 0xb6, 0x31, 0xc2, 0xd9, 0x36,
+	0x79, 0xc9, 0xf4, 0xb6, 0x8f, 0x51, 0x93, 0x0b, 0xf8, 0xec, 0x9e, 0x2b, 0x53, 0xb3, 0xa2, 0xd3,
+	0x85, 0xfd, 0x97, 0x35, 0x1d, 0xdb, 0x67, 0x3f, 0x69, 0x93, 0xad, 0x36, 0xdc, 0xdb, 0xdf, 0xc1,
+	0xe7, 0xd5, 0x6a, 0xad, 0x79, 0xf6, 0xa0, 0xe9, 0x89, 0x6d, 0xfa, 0xb4, 0xcb, 0x6e, 0x75, 0xfd,
+	0x08, 0xa7, 0xfd, 0x0d, 0xa9, 0x63, 0x25, 0xb7, 0x4c, 0x69, 0xc3, 0x44, 0xa5, 0x69, 0x10, 0xfa,
+	0xf3, 0xfd, 0xe4, 0xa4, 0xaf, 0xb9, 0x74, 0x25, 0x37, 0x7d, 0x45, 0xa3, 0x43, 0xbd, 0x62, 0x2a,
+	0xd7, 0xa9, 0xac, 0x05, 0x85, 0xd0, 0x9b, 0x1f, 0x24, 0x81, 0x43, 0xae, 0x6a, 0x41, 0x16, 0x70,
+	0xa4, 0x0d, 0x53, 0x26, 0xad, 0x4a, 0x6d, 0x27, 0x68, 0x3a, 0xb1, 0xa4, 0x84, 0x8f, 0x09, 0x2e,
+	0x66, 0x86, 0x59, 0xbd, 0xcd, 0x6c, 0xe3, 0x75, 0xd7, 0x47, 0x5e, 0x03, 0x54, 0xaa, 0xac, 0x50,
+	0x19, 0x8e, 0x9a, 0x4e, 0xff, 0xaf, 0x6c, 0x37, 0x9a, 0xc8, 0x4b, 0x20, 0x43, 0x94, 0xde, 0xa3,
+	0xd2, 0xbc, 0x94, 0xf4, 0xd0, 0x8a, 0xe2, 0x78, 0xc8, 0xbc, 0x73, 0x89, 0xe8, 0x1f, 0x0f, 0x9e,
+	0xbe, 0xc5, 0x3b, 0x81, 0xd2, 0x0c, 0x3a, 0x8f, 0x60, 0x9a, 0x0d, 0x92, 0xed, 0xa4, 0xba, 0x85,
+	0x91, 0x10, 0x26, 0x1b, 0x02, 0x6a, 0x55, 0xbf, 0x09, 0x91, 0x53, 0x08, 0x74, 0x3b, 0x39, 0xb6,
+	0xaa, 0xf4, 0x93, 0x01, 0x70, 0x5e, 0x6a, 0x04, 0xe1, 0x3e, 0x47, 0x7e, 0xd2, 0x85, 0x9b, 0x5e,
+	0x3a, 0xd8, 0xf6, 0x35, 0x85, 0xf1, 0xb2, 0xe6, 0xb6, 0x67, 0xe4, 0x32, 0x6d, 0x48, 0x9e, 0xc1,
+	0x14, 0x25, 0x5b, 0x16, 0xe8, 0x74, 0x49, 0xc7, 0xa1, 0x37, 0x7f, 0x92, 0x4c, 0x1c, 0x66, 0x0f,
+	0x8b, 0xfe, 0xf5, 0x36, 0x8d, 0xb8, 0xf3, 0x1b, 0xf7, 0xb1, 0x8d, 0xf8, 0x25, 0x40, 0x4f, 0x40,
+	0x67, 0xc3, 0x0d, 0x84, 0x3c, 0xdf, 0x30, 0x61, 0x6a, 0xd8, 0x5d, 0x67, 0xc2, 0xc3, 0x1e, 0xbd,
+	0x61, 0x77, 0xfa, 0x81, 0x9f, 0x47, 0x0f, 0xfd, 0xfc, 0xd3, 0xab, 0xdf, 0xbe, 0xbd, 0xe3, 0x66,
+	0x55, 0x2f, 0x1b, 0xc1, 0x9c, 0xbb, 0x33, 0x5e, 0xf2, 0xb2, 0xfd, 0x75, 0xce, 0xa5, 0x41, 0x25,
+	0x59, 0x71, 0x6e, 0x2f, 0x3b, 0x6f, 0xfc, 0x5a, 0x2d, 0x97, 0x23, 0x1b, 0xbd, 0xfa, 0x6f, 0x00,
+	0x7a, 0x6d, 0xb6, 0xec, 0xe7, 0x06, 0x00, 0x00,
 }
@@ -10,6 +10,7 @@ import (
 	commonpb "github.com/milvus-io/milvus/internal/proto/commonpb"
 	internalpb "github.com/milvus-io/milvus/internal/proto/internalpb"
 	milvuspb "github.com/milvus-io/milvus/internal/proto/milvuspb"
+	schemapb "github.com/milvus-io/milvus/internal/proto/schemapb"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -27,6 +28,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// The optional features of IndexNode, each of them is a bit of GetCapabilitiesResponse.features.
+type IndexNodeFeature int32
+
+const (
+	IndexNodeFeature_FeatureNone             IndexNodeFeature = 0
+	IndexNodeFeature_FeatureCancelIndexBuild IndexNodeFeature = 1
+	IndexNodeFeature_FeatureTaskSlots        IndexNodeFeature = 2
+)
+
+var IndexNodeFeature_name = map[int32]string{
+	0: "FeatureNone",
+	1: "FeatureCancelIndexBuild",
+	2: "FeatureTaskSlots",
+}
+
+var IndexNodeFeature_value = map[string]int32{
+	"FeatureNone":             0,
+	"FeatureCancelIndexBuild": 1,
+	"FeatureTaskSlots":        2,
+}
+
+func (x IndexNodeFeature) String() string {
+	return proto.EnumName(IndexNodeFeature_name, int32(x))
+}
+
+func (IndexNodeFeature) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{0}
+}
+
 type RegisterNodeRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	Address              *commonpb.Address `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
@@ -295,6 +325,7 @@ type CreateIndexRequest struct {
 	DataPaths            []string                 `protobuf:"bytes,6,rep,name=data_paths,json=dataPaths,proto3" json:"data_paths,omitempty"`
 	TypeParams           []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
 	IndexParams          []*commonpb.KeyValuePair `protobuf:"bytes,8,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
+	NumRows              int64                    `protobuf:"varint,9,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -381,23 +412,343 @@ func (m *CreateIndexRequest) GetIndexParams() []*commonpb.KeyValuePair {
 	return nil
 }
 
+func (m *CreateIndexRequest) GetNumRows() int64 {
+	if m != nil {
+		return m.NumRows
+	}
+	return 0
+}
+
+type CancelIndexBuildRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	IndexBuildIDs        []int64           `protobuf:"varint,2,rep,packed,name=indexBuildIDs,proto3" json:"indexBuildIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CancelIndexBuildRequest) Reset()         { *m = CancelIndexBuildRequest{} }
+func (m *CancelIndexBuildRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelIndexBuildRequest) ProtoMessage()    {}
+func (*CancelIndexBuildRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{6}
+}
+
+func (m *CancelIndexBuildRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelIndexBuildRequest.Unmarshal(m, b)
+}
+func (m *CancelIndexBuildRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelIndexBuildRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelIndexBuildRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelIndexBuildRequest.Merge(m, src)
+}
+func (m *CancelIndexBuildRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelIndexBuildRequest.Size(m)
+}
+func (m *CancelIndexBuildRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelIndexBuildRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelIndexBuildRequest proto.InternalMessageInfo
+
+func (m *CancelIndexBuildRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *CancelIndexBuildRequest) GetIndexBuildIDs() []int64 {
+	if m != nil {
+		return m.IndexBuildIDs
+	}
+	return nil
+}
+
+type GetTaskSlotsRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	IndexBuildIDs        []int64           `protobuf:"varint,2,rep,packed,name=indexBuildIDs,proto3" json:"indexBuildIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetTaskSlotsRequest) Reset()         { *m = GetTaskSlotsRequest{} }
+func (m *GetTaskSlotsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTaskSlotsRequest) ProtoMessage()    {}
+func (*GetTaskSlotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{7}
+}
+
+func (m *GetTaskSlotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTaskSlotsRequest.Unmarshal(m, b)
+}
+func (m *GetTaskSlotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTaskSlotsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTaskSlotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTaskSlotsRequest.Merge(m, src)
+}
+func (m *GetTaskSlotsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTaskSlotsRequest.Size(m)
+}
+func (m *GetTaskSlotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTaskSlotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTaskSlotsRequest proto.InternalMessageInfo
+
+func (m *GetTaskSlotsRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *GetTaskSlotsRequest) GetIndexBuildIDs() []int64 {
+	if m != nil {
+		return m.IndexBuildIDs
+	}
+	return nil
+}
+
+// The state of an index building task on an IndexNode.
+type IndexTaskState struct {
+	IndexBuildID         int64               `protobuf:"varint,1,opt,name=indexBuildID,proto3" json:"indexBuildID,omitempty"`
+	State                commonpb.IndexState `protobuf:"varint,2,opt,name=state,proto3,enum=milvus.proto.common.IndexState" json:"state,omitempty"`
+	QueuePosition        int64               `protobuf:"varint,3,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *IndexTaskState) Reset()         { *m = IndexTaskState{} }
+func (m *IndexTaskState) String() string { return proto.CompactTextString(m) }
+func (*IndexTaskState) ProtoMessage()    {}
+func (*IndexTaskState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{8}
+}
+
+func (m *IndexTaskState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IndexTaskState.Unmarshal(m, b)
+}
+func (m *IndexTaskState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IndexTaskState.Marshal(b, m, deterministic)
+}
+func (m *IndexTaskState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexTaskState.Merge(m, src)
+}
+func (m *IndexTaskState) XXX_Size() int {
+	return xxx_messageInfo_IndexTaskState.Size(m)
+}
+func (m *IndexTaskState) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexTaskState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexTaskState proto.InternalMessageInfo
+
+func (m *IndexTaskState) GetIndexBuildID() int64 {
+	if m != nil {
+		return m.IndexBuildID
+	}
+	return 0
+}
+
+func (m *IndexTaskState) GetState() commonpb.IndexState {
+	if m != nil {
+		return m.State
+	}
+	return commonpb.IndexState_IndexStateNone
+}
+
+func (m *IndexTaskState) GetQueuePosition() int64 {
+	if m != nil {
+		return m.QueuePosition
+	}
+	return 0
+}
+
+type GetTaskSlotsResponse struct {
+	Status               *commonpb.Status  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Slots                int64             `protobuf:"varint,2,opt,name=slots,proto3" json:"slots,omitempty"`
+	TaskStates           []*IndexTaskState `protobuf:"bytes,3,rep,name=task_states,json=taskStates,proto3" json:"task_states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetTaskSlotsResponse) Reset()         { *m = GetTaskSlotsResponse{} }
+func (m *GetTaskSlotsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTaskSlotsResponse) ProtoMessage()    {}
+func (*GetTaskSlotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{9}
+}
+
+func (m *GetTaskSlotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTaskSlotsResponse.Unmarshal(m, b)
+}
+func (m *GetTaskSlotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTaskSlotsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTaskSlotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTaskSlotsResponse.Merge(m, src)
+}
+func (m *GetTaskSlotsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTaskSlotsResponse.Size(m)
+}
+func (m *GetTaskSlotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTaskSlotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTaskSlotsResponse proto.InternalMessageInfo
+
+func (m *GetTaskSlotsResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetTaskSlotsResponse) GetSlots() int64 {
+	if m != nil {
+		return m.Slots
+	}
+	return 0
+}
+
+func (m *GetTaskSlotsResponse) GetTaskStates() []*IndexTaskState {
+	if m != nil {
+		return m.TaskStates
+	}
+	return nil
+}
+
+type GetCapabilitiesRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetCapabilitiesRequest) Reset()         { *m = GetCapabilitiesRequest{} }
+func (m *GetCapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCapabilitiesRequest) ProtoMessage()    {}
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{10}
+}
+
+func (m *GetCapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCapabilitiesRequest.Unmarshal(m, b)
+}
+func (m *GetCapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCapabilitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCapabilitiesRequest.Merge(m, src)
+}
+func (m *GetCapabilitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCapabilitiesRequest.Size(m)
+}
+func (m *GetCapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCapabilitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCapabilitiesRequest proto.InternalMessageInfo
+
+func (m *GetCapabilitiesRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+type GetCapabilitiesResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	BuildVersion         string           `protobuf:"bytes,2,opt,name=build_version,json=buildVersion,proto3" json:"build_version,omitempty"`
+	IndexTypes           []string         `protobuf:"bytes,3,rep,name=index_types,json=indexTypes,proto3" json:"index_types,omitempty"`
+	Features             uint64           `protobuf:"varint,4,opt,name=features,proto3" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetCapabilitiesResponse) Reset()         { *m = GetCapabilitiesResponse{} }
+func (m *GetCapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCapabilitiesResponse) ProtoMessage()    {}
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{11}
+}
+
+func (m *GetCapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCapabilitiesResponse.Unmarshal(m, b)
+}
+func (m *GetCapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCapabilitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetCapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCapabilitiesResponse.Merge(m, src)
+}
+func (m *GetCapabilitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetCapabilitiesResponse.Size(m)
+}
+func (m *GetCapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCapabilitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCapabilitiesResponse proto.InternalMessageInfo
+
+func (m *GetCapabilitiesResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetCapabilitiesResponse) GetBuildVersion() string {
+	if m != nil {
+		return m.BuildVersion
+	}
+	return ""
+}
+
+func (m *GetCapabilitiesResponse) GetIndexTypes() []string {
+	if m != nil {
+		return m.IndexTypes
+	}
+	return nil
+}
+
+func (m *GetCapabilitiesResponse) GetFeatures() uint64 {
+	if m != nil {
+		return m.Features
+	}
+	return 0
+}
+
 type BuildIndexRequest struct {
-	IndexBuildID         int64                    `protobuf:"varint,1,opt,name=indexBuildID,proto3" json:"indexBuildID,omitempty"`
-	IndexName            string                   `protobuf:"bytes,2,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
-	IndexID              int64                    `protobuf:"varint,3,opt,name=indexID,proto3" json:"indexID,omitempty"`
-	DataPaths            []string                 `protobuf:"bytes,5,rep,name=data_paths,json=dataPaths,proto3" json:"data_paths,omitempty"`
-	TypeParams           []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
-	IndexParams          []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	IndexBuildID int64                    `protobuf:"varint,1,opt,name=indexBuildID,proto3" json:"indexBuildID,omitempty"`
+	IndexName    string                   `protobuf:"bytes,2,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	IndexID      int64                    `protobuf:"varint,3,opt,name=indexID,proto3" json:"indexID,omitempty"`
+	DataPaths    []string                 `protobuf:"bytes,5,rep,name=data_paths,json=dataPaths,proto3" json:"data_paths,omitempty"`
+	TypeParams   []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	IndexParams  []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
+	SegmentID    int64                    `protobuf:"varint,8,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	FieldID      int64                    `protobuf:"varint,9,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	CollectionID int64                    `protobuf:"varint,10,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	NumRows      int64                    `protobuf:"varint,11,opt,name=num_rows,json=numRows,proto3" json:"num_rows,omitempty"`
+	// the segments with fewer rows skip the build until compaction merges them, 0 for the default of IndexCoord
+	MinSegmentRows       int64    `protobuf:"varint,12,opt,name=min_segment_rows,json=minSegmentRows,proto3" json:"min_segment_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *BuildIndexRequest) Reset()         { *m = BuildIndexRequest{} }
 func (m *BuildIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*BuildIndexRequest) ProtoMessage()    {}
 func (*BuildIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{6}
+	return fileDescriptor_f9e019eb3fda53c2, []int{12}
 }
 
 func (m *BuildIndexRequest) XXX_Unmarshal(b []byte) error {
@@ -460,6 +811,41 @@ func (m *BuildIndexRequest) GetIndexParams() []*commonpb.KeyValuePair {
 	return nil
 }
 
+func (m *BuildIndexRequest) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *BuildIndexRequest) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *BuildIndexRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *BuildIndexRequest) GetNumRows() int64 {
+	if m != nil {
+		return m.NumRows
+	}
+	return 0
+}
+
+func (m *BuildIndexRequest) GetMinSegmentRows() int64 {
+	if m != nil {
+		return m.MinSegmentRows
+	}
+	return 0
+}
+
 type BuildIndexResponse struct {
 	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	IndexBuildID         int64            `protobuf:"varint,2,opt,name=indexBuildID,proto3" json:"indexBuildID,omitempty"`
@@ -472,7 +858,7 @@ func (m *BuildIndexResponse) Reset()         { *m = BuildIndexResponse{} }
 func (m *BuildIndexResponse) String() string { return proto.CompactTextString(m) }
 func (*BuildIndexResponse) ProtoMessage()    {}
 func (*BuildIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{7}
+	return fileDescriptor_f9e019eb3fda53c2, []int{13}
 }
 
 func (m *BuildIndexResponse) XXX_Unmarshal(b []byte) error {
@@ -518,7 +904,7 @@ func (m *GetIndexFilePathsRequest) Reset()         { *m = GetIndexFilePathsReque
 func (m *GetIndexFilePathsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetIndexFilePathsRequest) ProtoMessage()    {}
 func (*GetIndexFilePathsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{8}
+	return fileDescriptor_f9e019eb3fda53c2, []int{14}
 }
 
 func (m *GetIndexFilePathsRequest) XXX_Unmarshal(b []byte) error {
@@ -559,7 +945,7 @@ func (m *IndexFilePathInfo) Reset()         { *m = IndexFilePathInfo{} }
 func (m *IndexFilePathInfo) String() string { return proto.CompactTextString(m) }
 func (*IndexFilePathInfo) ProtoMessage()    {}
 func (*IndexFilePathInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{9}
+	return fileDescriptor_f9e019eb3fda53c2, []int{15}
 }
 
 func (m *IndexFilePathInfo) XXX_Unmarshal(b []byte) error {
@@ -613,7 +999,7 @@ func (m *GetIndexFilePathsResponse) Reset()         { *m = GetIndexFilePathsResp
 func (m *GetIndexFilePathsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetIndexFilePathsResponse) ProtoMessage()    {}
 func (*GetIndexFilePathsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{10}
+	return fileDescriptor_f9e019eb3fda53c2, []int{16}
 }
 
 func (m *GetIndexFilePathsResponse) XXX_Unmarshal(b []byte) error {
@@ -658,6 +1044,8 @@ type IndexMeta struct {
 	NodeID               int64               `protobuf:"varint,7,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
 	Version              int64               `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
 	Recycled             bool                `protobuf:"varint,9,opt,name=recycled,proto3" json:"recycled,omitempty"`
+	RetryCount           int64               `protobuf:"varint,10,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	AssignTime           int64               `protobuf:"varint,11,opt,name=assign_time,json=assignTime,proto3" json:"assign_time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
@@ -667,7 +1055,7 @@ func (m *IndexMeta) Reset()         { *m = IndexMeta{} }
 func (m *IndexMeta) String() string { return proto.CompactTextString(m) }
 func (*IndexMeta) ProtoMessage()    {}
 func (*IndexMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{11}
+	return fileDescriptor_f9e019eb3fda53c2, []int{17}
 }
 
 func (m *IndexMeta) XXX_Unmarshal(b []byte) error {
@@ -751,22 +1139,218 @@ func (m *IndexMeta) GetRecycled() bool {
 	return false
 }
 
-type DropIndexRequest struct {
-	IndexID              int64    `protobuf:"varint,1,opt,name=indexID,proto3" json:"indexID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *IndexMeta) GetRetryCount() int64 {
+	if m != nil {
+		return m.RetryCount
+	}
+	return 0
 }
 
-func (m *DropIndexRequest) Reset()         { *m = DropIndexRequest{} }
-func (m *DropIndexRequest) String() string { return proto.CompactTextString(m) }
-func (*DropIndexRequest) ProtoMessage()    {}
-func (*DropIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f9e019eb3fda53c2, []int{12}
+func (m *IndexMeta) GetAssignTime() int64 {
+	if m != nil {
+		return m.AssignTime
+	}
+	return 0
 }
 
-func (m *DropIndexRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropIndexRequest.Unmarshal(m, b)
+// An index created on a field of a collection, the builds of the index on segments are recorded by IndexMeta.
+type IndexDefinition struct {
+	CollectionID         int64                    `protobuf:"varint,1,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	FieldID              int64                    `protobuf:"varint,2,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	IndexName            string                   `protobuf:"bytes,3,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	IndexID              int64                    `protobuf:"varint,4,opt,name=indexID,proto3" json:"indexID,omitempty"`
+	TypeParams           []*commonpb.KeyValuePair `protobuf:"bytes,5,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	IndexParams          []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
+	FieldType            schemapb.DataType        `protobuf:"varint,7,opt,name=field_type,json=fieldType,proto3,enum=milvus.proto.schema.DataType" json:"field_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *IndexDefinition) Reset()         { *m = IndexDefinition{} }
+func (m *IndexDefinition) String() string { return proto.CompactTextString(m) }
+func (*IndexDefinition) ProtoMessage()    {}
+func (*IndexDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{18}
+}
+
+func (m *IndexDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IndexDefinition.Unmarshal(m, b)
+}
+func (m *IndexDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IndexDefinition.Marshal(b, m, deterministic)
+}
+func (m *IndexDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexDefinition.Merge(m, src)
+}
+func (m *IndexDefinition) XXX_Size() int {
+	return xxx_messageInfo_IndexDefinition.Size(m)
+}
+func (m *IndexDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexDefinition proto.InternalMessageInfo
+
+func (m *IndexDefinition) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *IndexDefinition) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *IndexDefinition) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+func (m *IndexDefinition) GetIndexID() int64 {
+	if m != nil {
+		return m.IndexID
+	}
+	return 0
+}
+
+func (m *IndexDefinition) GetTypeParams() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.TypeParams
+	}
+	return nil
+}
+
+func (m *IndexDefinition) GetIndexParams() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.IndexParams
+	}
+	return nil
+}
+
+func (m *IndexDefinition) GetFieldType() schemapb.DataType {
+	if m != nil {
+		return m.FieldType
+	}
+	return schemapb.DataType_None
+}
+
+type CreateIndexDefinitionRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Definition           *IndexDefinition  `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CreateIndexDefinitionRequest) Reset()         { *m = CreateIndexDefinitionRequest{} }
+func (m *CreateIndexDefinitionRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateIndexDefinitionRequest) ProtoMessage()    {}
+func (*CreateIndexDefinitionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{19}
+}
+
+func (m *CreateIndexDefinitionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIndexDefinitionRequest.Unmarshal(m, b)
+}
+func (m *CreateIndexDefinitionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIndexDefinitionRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateIndexDefinitionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIndexDefinitionRequest.Merge(m, src)
+}
+func (m *CreateIndexDefinitionRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateIndexDefinitionRequest.Size(m)
+}
+func (m *CreateIndexDefinitionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIndexDefinitionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIndexDefinitionRequest proto.InternalMessageInfo
+
+func (m *CreateIndexDefinitionRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *CreateIndexDefinitionRequest) GetDefinition() *IndexDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+type CreateIndexDefinitionResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	IndexID              int64            `protobuf:"varint,2,opt,name=indexID,proto3" json:"indexID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CreateIndexDefinitionResponse) Reset()         { *m = CreateIndexDefinitionResponse{} }
+func (m *CreateIndexDefinitionResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateIndexDefinitionResponse) ProtoMessage()    {}
+func (*CreateIndexDefinitionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{20}
+}
+
+func (m *CreateIndexDefinitionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIndexDefinitionResponse.Unmarshal(m, b)
+}
+func (m *CreateIndexDefinitionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIndexDefinitionResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateIndexDefinitionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIndexDefinitionResponse.Merge(m, src)
+}
+func (m *CreateIndexDefinitionResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateIndexDefinitionResponse.Size(m)
+}
+func (m *CreateIndexDefinitionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIndexDefinitionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIndexDefinitionResponse proto.InternalMessageInfo
+
+func (m *CreateIndexDefinitionResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *CreateIndexDefinitionResponse) GetIndexID() int64 {
+	if m != nil {
+		return m.IndexID
+	}
+	return 0
+}
+
+type DropIndexRequest struct {
+	IndexID              int64    `protobuf:"varint,1,opt,name=indexID,proto3" json:"indexID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropIndexRequest) Reset()         { *m = DropIndexRequest{} }
+func (m *DropIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*DropIndexRequest) ProtoMessage()    {}
+func (*DropIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{21}
+}
+
+func (m *DropIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropIndexRequest.Unmarshal(m, b)
 }
 func (m *DropIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_DropIndexRequest.Marshal(b, m, deterministic)
@@ -790,87 +1374,432 @@ func (m *DropIndexRequest) GetIndexID() int64 {
 	return 0
 }
 
+// DropSegmentIndexRequest drops the index builds of a dropped segment
+type DropSegmentIndexRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	SegmentID            int64             `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DropSegmentIndexRequest) Reset()         { *m = DropSegmentIndexRequest{} }
+func (m *DropSegmentIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*DropSegmentIndexRequest) ProtoMessage()    {}
+func (*DropSegmentIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{22}
+}
+
+func (m *DropSegmentIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropSegmentIndexRequest.Unmarshal(m, b)
+}
+func (m *DropSegmentIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropSegmentIndexRequest.Marshal(b, m, deterministic)
+}
+func (m *DropSegmentIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropSegmentIndexRequest.Merge(m, src)
+}
+func (m *DropSegmentIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_DropSegmentIndexRequest.Size(m)
+}
+func (m *DropSegmentIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropSegmentIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropSegmentIndexRequest proto.InternalMessageInfo
+
+func (m *DropSegmentIndexRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *DropSegmentIndexRequest) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+// The progress of the index of a collection field, segments too small to build index are counted as indexed.
+type GetIndexBuildProgressRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CollectionID         int64             `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	FieldID              int64             `protobuf:"varint,3,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	IndexName            string            `protobuf:"bytes,4,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetIndexBuildProgressRequest) Reset()         { *m = GetIndexBuildProgressRequest{} }
+func (m *GetIndexBuildProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*GetIndexBuildProgressRequest) ProtoMessage()    {}
+func (*GetIndexBuildProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{23}
+}
+
+func (m *GetIndexBuildProgressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexBuildProgressRequest.Unmarshal(m, b)
+}
+func (m *GetIndexBuildProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexBuildProgressRequest.Marshal(b, m, deterministic)
+}
+func (m *GetIndexBuildProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexBuildProgressRequest.Merge(m, src)
+}
+func (m *GetIndexBuildProgressRequest) XXX_Size() int {
+	return xxx_messageInfo_GetIndexBuildProgressRequest.Size(m)
+}
+func (m *GetIndexBuildProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexBuildProgressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIndexBuildProgressRequest proto.InternalMessageInfo
+
+func (m *GetIndexBuildProgressRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *GetIndexBuildProgressRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *GetIndexBuildProgressRequest) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *GetIndexBuildProgressRequest) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+type GetIndexBuildProgressResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	IndexedRows          int64            `protobuf:"varint,2,opt,name=indexed_rows,json=indexedRows,proto3" json:"indexed_rows,omitempty"`
+	TotalRows            int64            `protobuf:"varint,3,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetIndexBuildProgressResponse) Reset()         { *m = GetIndexBuildProgressResponse{} }
+func (m *GetIndexBuildProgressResponse) String() string { return proto.CompactTextString(m) }
+func (*GetIndexBuildProgressResponse) ProtoMessage()    {}
+func (*GetIndexBuildProgressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{24}
+}
+
+func (m *GetIndexBuildProgressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexBuildProgressResponse.Unmarshal(m, b)
+}
+func (m *GetIndexBuildProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexBuildProgressResponse.Marshal(b, m, deterministic)
+}
+func (m *GetIndexBuildProgressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexBuildProgressResponse.Merge(m, src)
+}
+func (m *GetIndexBuildProgressResponse) XXX_Size() int {
+	return xxx_messageInfo_GetIndexBuildProgressResponse.Size(m)
+}
+func (m *GetIndexBuildProgressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexBuildProgressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIndexBuildProgressResponse proto.InternalMessageInfo
+
+func (m *GetIndexBuildProgressResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetIndexBuildProgressResponse) GetIndexedRows() int64 {
+	if m != nil {
+		return m.IndexedRows
+	}
+	return 0
+}
+
+func (m *GetIndexBuildProgressResponse) GetTotalRows() int64 {
+	if m != nil {
+		return m.TotalRows
+	}
+	return 0
+}
+
+// The state of the index of a collection field, rolled up from the states of its builds.
+type GetIndexStateRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CollectionID         int64             `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	FieldID              int64             `protobuf:"varint,3,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	IndexName            string            `protobuf:"bytes,4,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetIndexStateRequest) Reset()         { *m = GetIndexStateRequest{} }
+func (m *GetIndexStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetIndexStateRequest) ProtoMessage()    {}
+func (*GetIndexStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{25}
+}
+
+func (m *GetIndexStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexStateRequest.Unmarshal(m, b)
+}
+func (m *GetIndexStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetIndexStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexStateRequest.Merge(m, src)
+}
+func (m *GetIndexStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetIndexStateRequest.Size(m)
+}
+func (m *GetIndexStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIndexStateRequest proto.InternalMessageInfo
+
+func (m *GetIndexStateRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *GetIndexStateRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *GetIndexStateRequest) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *GetIndexStateRequest) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+type GetIndexStateResponse struct {
+	Status               *commonpb.Status    `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	State                commonpb.IndexState `protobuf:"varint,2,opt,name=state,proto3,enum=milvus.proto.common.IndexState" json:"state,omitempty"`
+	FailReason           string              `protobuf:"bytes,3,opt,name=fail_reason,json=failReason,proto3" json:"fail_reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetIndexStateResponse) Reset()         { *m = GetIndexStateResponse{} }
+func (m *GetIndexStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetIndexStateResponse) ProtoMessage()    {}
+func (*GetIndexStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f9e019eb3fda53c2, []int{26}
+}
+
+func (m *GetIndexStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexStateResponse.Unmarshal(m, b)
+}
+func (m *GetIndexStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetIndexStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexStateResponse.Merge(m, src)
+}
+func (m *GetIndexStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetIndexStateResponse.Size(m)
+}
+func (m *GetIndexStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIndexStateResponse proto.InternalMessageInfo
+
+func (m *GetIndexStateResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetIndexStateResponse) GetState() commonpb.IndexState {
+	if m != nil {
+		return m.State
+	}
+	return commonpb.IndexState_IndexStateNone
+}
+
+func (m *GetIndexStateResponse) GetFailReason() string {
+	if m != nil {
+		return m.FailReason
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("milvus.proto.index.IndexNodeFeature", IndexNodeFeature_name, IndexNodeFeature_value)
 	proto.RegisterType((*RegisterNodeRequest)(nil), "milvus.proto.index.RegisterNodeRequest")
 	proto.RegisterType((*RegisterNodeResponse)(nil), "milvus.proto.index.RegisterNodeResponse")
 	proto.RegisterType((*GetIndexStatesRequest)(nil), "milvus.proto.index.GetIndexStatesRequest")
 	proto.RegisterType((*IndexInfo)(nil), "milvus.proto.index.IndexInfo")
 	proto.RegisterType((*GetIndexStatesResponse)(nil), "milvus.proto.index.GetIndexStatesResponse")
 	proto.RegisterType((*CreateIndexRequest)(nil), "milvus.proto.index.CreateIndexRequest")
+	proto.RegisterType((*CancelIndexBuildRequest)(nil), "milvus.proto.index.CancelIndexBuildRequest")
+	proto.RegisterType((*GetTaskSlotsRequest)(nil), "milvus.proto.index.GetTaskSlotsRequest")
+	proto.RegisterType((*IndexTaskState)(nil), "milvus.proto.index.IndexTaskState")
+	proto.RegisterType((*GetTaskSlotsResponse)(nil), "milvus.proto.index.GetTaskSlotsResponse")
+	proto.RegisterType((*GetCapabilitiesRequest)(nil), "milvus.proto.index.GetCapabilitiesRequest")
+	proto.RegisterType((*GetCapabilitiesResponse)(nil), "milvus.proto.index.GetCapabilitiesResponse")
 	proto.RegisterType((*BuildIndexRequest)(nil), "milvus.proto.index.BuildIndexRequest")
 	proto.RegisterType((*BuildIndexResponse)(nil), "milvus.proto.index.BuildIndexResponse")
 	proto.RegisterType((*GetIndexFilePathsRequest)(nil), "milvus.proto.index.GetIndexFilePathsRequest")
 	proto.RegisterType((*IndexFilePathInfo)(nil), "milvus.proto.index.IndexFilePathInfo")
 	proto.RegisterType((*GetIndexFilePathsResponse)(nil), "milvus.proto.index.GetIndexFilePathsResponse")
 	proto.RegisterType((*IndexMeta)(nil), "milvus.proto.index.IndexMeta")
+	proto.RegisterType((*IndexDefinition)(nil), "milvus.proto.index.IndexDefinition")
+	proto.RegisterType((*CreateIndexDefinitionRequest)(nil), "milvus.proto.index.CreateIndexDefinitionRequest")
+	proto.RegisterType((*CreateIndexDefinitionResponse)(nil), "milvus.proto.index.CreateIndexDefinitionResponse")
 	proto.RegisterType((*DropIndexRequest)(nil), "milvus.proto.index.DropIndexRequest")
+	proto.RegisterType((*DropSegmentIndexRequest)(nil), "milvus.proto.index.DropSegmentIndexRequest")
+	proto.RegisterType((*GetIndexBuildProgressRequest)(nil), "milvus.proto.index.GetIndexBuildProgressRequest")
+	proto.RegisterType((*GetIndexBuildProgressResponse)(nil), "milvus.proto.index.GetIndexBuildProgressResponse")
+	proto.RegisterType((*GetIndexStateRequest)(nil), "milvus.proto.index.GetIndexStateRequest")
+	proto.RegisterType((*GetIndexStateResponse)(nil), "milvus.proto.index.GetIndexStateResponse")
 }
 
 func init() { proto.RegisterFile("index_coord.proto", fileDescriptor_f9e019eb3fda53c2) }
 
 var fileDescriptor_f9e019eb3fda53c2 = []byte{
-	// 975 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xcf, 0xf9, 0x12, 0x3b, 0x1e, 0x87, 0xa8, 0x59, 0x4a, 0x75, 0xb8, 0x54, 0x75, 0x8e, 0x52,
-	0x0c, 0x6a, 0x9d, 0xca, 0xa5, 0xf0, 0x84, 0x04, 0x89, 0x45, 0x64, 0xa1, 0x54, 0xd1, 0x36, 0xe2,
-	0x01, 0x09, 0x59, 0x1b, 0xdf, 0x24, 0x59, 0xf5, 0xfe, 0xe5, 0x76, 0x5d, 0x91, 0x77, 0xde, 0x79,
-	0x2b, 0xe2, 0x93, 0xf0, 0x39, 0xfa, 0xcc, 0x97, 0x41, 0xbb, 0xb7, 0x77, 0xb9, 0x3b, 0x9f, 0x53,
-	0x87, 0x50, 0x78, 0xe1, 0xed, 0x66, 0xf6, 0x37, 0x33, 0x3b, 0xbf, 0x9d, 0xfd, 0xdd, 0xc2, 0x16,
-	0x0f, 0x3d, 0xfc, 0x79, 0x32, 0x8d, 0xa2, 0xc4, 0x1b, 0xc4, 0x49, 0x24, 0x23, 0x42, 0x02, 0xee,
-	0xbf, 0x9a, 0x89, 0xd4, 0x1a, 0xe8, 0xf5, 0xee, 0xc6, 0x34, 0x0a, 0x82, 0x28, 0x4c, 0x7d, 0xdd,
-	0x4d, 0x1e, 0x4a, 0x4c, 0x42, 0xe6, 0x1b, 0x7b, 0xa3, 0x18, 0xe1, 0xfe, 0x66, 0xc1, 0xfb, 0x14,
-	0x4f, 0xb9, 0x90, 0x98, 0x3c, 0x8f, 0x3c, 0xa4, 0x78, 0x3e, 0x43, 0x21, 0xc9, 0x13, 0x58, 0x3d,
-	0x66, 0x02, 0x1d, 0xab, 0x67, 0xf5, 0x3b, 0xc3, 0x8f, 0x06, 0xa5, 0x32, 0x26, 0xff, 0x81, 0x38,
-	0xdd, 0x65, 0x02, 0xa9, 0x46, 0x92, 0x2f, 0xa1, 0xc5, 0x3c, 0x2f, 0x41, 0x21, 0x9c, 0xc6, 0x15,
-	0x41, 0xdf, 0xa6, 0x18, 0x9a, 0x81, 0xc9, 0x1d, 0x68, 0x86, 0x91, 0x87, 0xe3, 0x91, 0x63, 0xf7,
-	0xac, 0xbe, 0x4d, 0x8d, 0xe5, 0xfe, 0x6a, 0xc1, 0xed, 0xf2, 0xce, 0x44, 0x1c, 0x85, 0x02, 0xc9,
-	0x53, 0x68, 0x0a, 0xc9, 0xe4, 0x4c, 0x98, 0xcd, 0xdd, 0xad, 0xad, 0xf3, 0x42, 0x43, 0xa8, 0x81,
-	0x92, 0x5d, 0xe8, 0xf0, 0x90, 0xcb, 0x49, 0xcc, 0x12, 0x16, 0x64, 0x3b, 0xdc, 0x1e, 0x54, 0xd8,
-	0x33, 0x44, 0x8d, 0x43, 0x2e, 0x0f, 0x35, 0x90, 0x02, 0xcf, 0xbf, 0xdd, 0xaf, 0xe1, 0x83, 0x7d,
-	0x94, 0x63, 0xc5, 0xb1, 0xca, 0x8e, 0x22, 0x23, 0xeb, 0x01, 0xbc, 0xa7, 0x99, 0xdf, 0x9d, 0x71,
-	0xdf, 0x1b, 0x8f, 0xd4, 0xc6, 0xec, 0xbe, 0x4d, 0xcb, 0x4e, 0xf7, 0x0f, 0x0b, 0xda, 0x3a, 0x78,
-	0x1c, 0x9e, 0x44, 0xe4, 0x19, 0xac, 0xa9, 0xad, 0xa5, 0x0c, 0x6f, 0x0e, 0xef, 0xd7, 0x36, 0x71,
-	0x59, 0x8b, 0xa6, 0x68, 0xe2, 0xc2, 0x46, 0x31, 0xab, 0x6e, 0xc4, 0xa6, 0x25, 0x1f, 0x71, 0xa0,
-	0xa5, 0xed, 0x9c, 0xd2, 0xcc, 0x24, 0xf7, 0x00, 0xd2, 0x11, 0x0a, 0x59, 0x80, 0xce, 0x6a, 0xcf,
-	0xea, 0xb7, 0x69, 0x5b, 0x7b, 0x9e, 0xb3, 0x00, 0xd5, 0x51, 0x24, 0xc8, 0x44, 0x14, 0x3a, 0x6b,
-	0x7a, 0xc9, 0x58, 0xee, 0x2f, 0x16, 0xdc, 0xa9, 0x76, 0x7e, 0x93, 0xc3, 0x78, 0x96, 0x06, 0xa1,
-	0x3a, 0x07, 0xbb, 0xdf, 0x19, 0xde, 0x1b, 0xcc, 0x4f, 0xf1, 0x20, 0xa7, 0x8a, 0x1a, 0xb0, 0xfb,
-	0xa6, 0x01, 0x64, 0x2f, 0x41, 0x26, 0x51, 0xaf, 0x65, 0xec, 0x57, 0x29, 0xb1, 0x6a, 0x28, 0x29,
-	0x37, 0xde, 0xa8, 0x36, 0xbe, 0x98, 0x31, 0x07, 0x5a, 0xaf, 0x30, 0x11, 0x3c, 0x0a, 0x35, 0x5d,
-	0x36, 0xcd, 0x4c, 0x72, 0x17, 0xda, 0x01, 0x4a, 0x36, 0x89, 0x99, 0x3c, 0x33, 0x7c, 0xad, 0x2b,
-	0xc7, 0x21, 0x93, 0x67, 0xaa, 0x9e, 0xc7, 0xcc, 0xa2, 0x70, 0x9a, 0x3d, 0x5b, 0xd5, 0x53, 0x1e,
-	0xb5, 0xaa, 0xa7, 0x51, 0x5e, 0xc4, 0x98, 0x4d, 0x63, 0x4b, 0xb3, 0xb0, 0x5d, 0x4b, 0xdd, 0xf7,
-	0x78, 0xf1, 0x03, 0xf3, 0x67, 0x78, 0xc8, 0x78, 0x42, 0x41, 0x45, 0xa5, 0xd3, 0x48, 0x46, 0xa6,
-	0xed, 0x2c, 0xc9, 0xfa, 0xb2, 0x49, 0x3a, 0x3a, 0xcc, 0xcc, 0xf4, 0xef, 0x0d, 0xd8, 0x4a, 0x49,
-	0xfa, 0xd7, 0x28, 0x2d, 0x73, 0xb3, 0xf6, 0x16, 0x6e, 0x9a, 0xff, 0x04, 0x37, 0xad, 0xbf, 0xc5,
-	0x4d, 0x00, 0xa4, 0x48, 0xcd, 0x4d, 0x26, 0x7e, 0x89, 0x6b, 0xeb, 0x7e, 0x03, 0x4e, 0x76, 0xc9,
-	0xbe, 0xe3, 0x3e, 0x6a, 0x36, 0xae, 0xa7, 0x30, 0xaf, 0x2d, 0xd8, 0x2a, 0xc5, 0x6b, 0xa5, 0x79,
-	0x57, 0x1b, 0x26, 0x7d, 0xb8, 0x95, 0xb2, 0x7c, 0xc2, 0x7d, 0x34, 0xc7, 0x69, 0xeb, 0xe3, 0xdc,
-	0xe4, 0xa5, 0x2e, 0xd4, 0xc6, 0x3e, 0xac, 0xe9, 0xed, 0x26, 0x8c, 0x8e, 0x00, 0x0a, 0x65, 0x53,
-	0x1d, 0xf9, 0x64, 0xa1, 0x8e, 0x14, 0x09, 0xa1, 0xed, 0x93, 0x7c, 0x63, 0x7f, 0x36, 0x8c, 0x26,
-	0x1f, 0xa0, 0x64, 0x4b, 0x8d, 0x7d, 0xae, 0xdb, 0x8d, 0x6b, 0xe9, 0xf6, 0x7d, 0xe8, 0x9c, 0x30,
-	0xee, 0x4f, 0x8c, 0xbe, 0xda, 0xfa, 0xba, 0x80, 0x72, 0x51, 0xed, 0x21, 0x5f, 0x81, 0x9d, 0xe0,
-	0xb9, 0x16, 0x99, 0x05, 0x8d, 0xcc, 0x5d, 0x53, 0xaa, 0x22, 0x6a, 0x4f, 0x61, 0xad, 0xee, 0x14,
-	0xc8, 0x36, 0x6c, 0x04, 0x2c, 0x79, 0x39, 0xf1, 0xd0, 0x47, 0x89, 0x9e, 0xd3, 0xec, 0x59, 0xfd,
-	0x75, 0xda, 0x51, 0xbe, 0x51, 0xea, 0x2a, 0xfc, 0x8c, 0x5b, 0xc5, 0x9f, 0x71, 0x51, 0x06, 0xd7,
-	0xcb, 0x32, 0xd8, 0x85, 0xf5, 0x04, 0xa7, 0x17, 0x53, 0x1f, 0x3d, 0xa7, 0xad, 0x13, 0xe6, 0xb6,
-	0xfb, 0x08, 0x6e, 0x8d, 0x92, 0x28, 0x2e, 0x49, 0x4b, 0x41, 0x17, 0xac, 0x92, 0x2e, 0x0c, 0xdf,
-	0x34, 0x01, 0x34, 0x74, 0x4f, 0xbd, 0x6f, 0x48, 0x0c, 0x64, 0x1f, 0xe5, 0x5e, 0x14, 0xc4, 0x51,
-	0x88, 0xa1, 0x4c, 0xff, 0x3b, 0xe4, 0xc9, 0x82, 0x5f, 0xf6, 0x3c, 0xd4, 0x14, 0xec, 0x3e, 0x5c,
-	0x10, 0x51, 0x81, 0xbb, 0x2b, 0x24, 0xd0, 0x15, 0x8f, 0x78, 0x80, 0x47, 0x7c, 0xfa, 0x72, 0xef,
-	0x8c, 0x85, 0x21, 0xfa, 0x57, 0x55, 0xac, 0x40, 0xb3, 0x8a, 0x1f, 0x97, 0x23, 0x8c, 0xf1, 0x42,
-	0x26, 0x3c, 0x3c, 0xcd, 0x86, 0xde, 0x5d, 0x21, 0xe7, 0x70, 0x7b, 0x1f, 0x75, 0x75, 0x2e, 0x24,
-	0x9f, 0x8a, 0xac, 0xe0, 0x70, 0x71, 0xc1, 0x39, 0xf0, 0x35, 0x4b, 0xfe, 0x04, 0x70, 0x39, 0x45,
-	0x64, 0xb9, 0x29, 0x9b, 0x27, 0xb0, 0x0a, 0xcb, 0xd3, 0x73, 0xd8, 0x2c, 0x3f, 0x13, 0xc8, 0x67,
-	0x75, 0xb1, 0xb5, 0x8f, 0xa8, 0xee, 0xe7, 0xcb, 0x40, 0xf3, 0x52, 0x09, 0x6c, 0xcd, 0x09, 0x0a,
-	0x79, 0x74, 0x55, 0x8a, 0xaa, 0xa6, 0x76, 0x1f, 0x2f, 0x89, 0xce, 0x6b, 0x1e, 0x42, 0x3b, 0x1f,
-	0x67, 0xf2, 0xa0, 0x2e, 0xba, 0x3a, 0xed, 0xdd, 0xab, 0xa4, 0xcc, 0x5d, 0x21, 0x13, 0x80, 0x7d,
-	0x94, 0x07, 0x28, 0x13, 0x3e, 0x15, 0xe4, 0x61, 0xed, 0x21, 0x5e, 0x02, 0xb2, 0xa4, 0x9f, 0xbe,
-	0x15, 0x97, 0x6d, 0x79, 0xf8, 0x7a, 0xd5, 0xe8, 0x9b, 0x7a, 0x41, 0xff, 0x7f, 0xa5, 0xde, 0xc1,
-	0x95, 0x3a, 0x82, 0x4e, 0xe1, 0x4d, 0x4a, 0x6a, 0x2f, 0xcb, 0xfc, 0xa3, 0xf5, 0xbf, 0x1e, 0x8c,
-	0xdd, 0x2f, 0x7e, 0x1c, 0x9e, 0x72, 0x79, 0x36, 0x3b, 0x56, 0xa5, 0x77, 0x52, 0xe4, 0x63, 0x1e,
-	0x99, 0xaf, 0x9d, 0x8c, 0xa1, 0x1d, 0x9d, 0x69, 0x47, 0xb7, 0x11, 0x1f, 0x1f, 0x37, 0xb5, 0xf9,
-	0xf4, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x75, 0x9d, 0x20, 0xf1, 0x89, 0x0e, 0x00, 0x00,
+	// 1665 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4b, 0x6f, 0x1b, 0xc9,
+	0x11, 0xf6, 0x70, 0x24, 0x8a, 0x2c, 0x52, 0x34, 0xd5, 0xab, 0x5d, 0x71, 0x69, 0x09, 0x96, 0x67,
+	0x77, 0xb5, 0xf4, 0x4b, 0xb2, 0xe5, 0x38, 0xb9, 0x24, 0x40, 0x22, 0x12, 0x36, 0x98, 0xc0, 0x86,
+	0x30, 0x12, 0x7c, 0x08, 0x10, 0x13, 0x2d, 0x4e, 0x53, 0x6a, 0x68, 0x1e, 0xd4, 0x74, 0xd3, 0x8e,
+	0x2e, 0x39, 0xe5, 0x1e, 0x20, 0x40, 0x12, 0xe4, 0x90, 0x5b, 0x92, 0x5b, 0x80, 0xdc, 0x02, 0xe4,
+	0x1f, 0xe4, 0x96, 0x1f, 0x92, 0x1f, 0x11, 0xf4, 0x63, 0x46, 0x33, 0xc3, 0xe1, 0x43, 0xa2, 0x15,
+	0xe4, 0xb0, 0xb7, 0xe9, 0x9a, 0xaa, 0xae, 0xae, 0xd7, 0x57, 0xd5, 0x0d, 0x6b, 0xd4, 0x77, 0xc8,
+	0x2f, 0x7b, 0xfd, 0x20, 0x08, 0x9d, 0xdd, 0x61, 0x18, 0xf0, 0x00, 0x21, 0x8f, 0xba, 0x1f, 0x46,
+	0x4c, 0xad, 0x76, 0xe5, 0xff, 0x66, 0xb5, 0x1f, 0x78, 0x5e, 0xe0, 0x2b, 0x5a, 0xb3, 0x46, 0x7d,
+	0x4e, 0x42, 0x1f, 0xbb, 0x7a, 0x5d, 0x4d, 0x4a, 0x34, 0xab, 0xac, 0x7f, 0x46, 0x3c, 0xac, 0x56,
+	0xd6, 0x1f, 0x0c, 0xf8, 0xcc, 0x26, 0xa7, 0x94, 0x71, 0x12, 0xbe, 0x0d, 0x1c, 0x62, 0x93, 0x8b,
+	0x11, 0x61, 0x1c, 0x3d, 0x83, 0xa5, 0x13, 0xcc, 0x48, 0xc3, 0xd8, 0x36, 0x5a, 0x95, 0xfd, 0xcd,
+	0xdd, 0x94, 0x52, 0xad, 0xed, 0x0d, 0x3b, 0x3d, 0xc0, 0x8c, 0xd8, 0x92, 0x13, 0x7d, 0x1f, 0x56,
+	0xb0, 0xe3, 0x84, 0x84, 0xb1, 0x46, 0x61, 0x8a, 0xd0, 0x4f, 0x14, 0x8f, 0x1d, 0x31, 0xa3, 0x2f,
+	0xa0, 0xe8, 0x07, 0x0e, 0xe9, 0x76, 0x1a, 0xe6, 0xb6, 0xd1, 0x32, 0x6d, 0xbd, 0xb2, 0x7e, 0x63,
+	0xc0, 0x7a, 0xfa, 0x64, 0x6c, 0x18, 0xf8, 0x8c, 0xa0, 0x17, 0x50, 0x64, 0x1c, 0xf3, 0x11, 0xd3,
+	0x87, 0xbb, 0x97, 0xab, 0xe7, 0x48, 0xb2, 0xd8, 0x9a, 0x15, 0x1d, 0x40, 0x85, 0xfa, 0x94, 0xf7,
+	0x86, 0x38, 0xc4, 0x5e, 0x74, 0xc2, 0x07, 0xbb, 0x19, 0x5f, 0x6a, 0xb7, 0x75, 0x7d, 0xca, 0x0f,
+	0x25, 0xa3, 0x0d, 0x34, 0xfe, 0xb6, 0x7e, 0x04, 0x9f, 0xbf, 0x26, 0xbc, 0x2b, 0x3c, 0x2e, 0x76,
+	0x27, 0x2c, 0x72, 0xd6, 0xd7, 0xb0, 0x2a, 0xe3, 0x70, 0x30, 0xa2, 0xae, 0xd3, 0xed, 0x88, 0x83,
+	0x99, 0x2d, 0xd3, 0x4e, 0x13, 0xad, 0x7f, 0x18, 0x50, 0x96, 0xc2, 0x5d, 0x7f, 0x10, 0xa0, 0x97,
+	0xb0, 0x2c, 0x8e, 0xa6, 0x3c, 0x5c, 0xdb, 0xbf, 0x9f, 0x6b, 0xc4, 0x95, 0x2e, 0x5b, 0x71, 0x23,
+	0x0b, 0xaa, 0xc9, 0x5d, 0xa5, 0x21, 0xa6, 0x9d, 0xa2, 0xa1, 0x06, 0xac, 0xc8, 0x75, 0xec, 0xd2,
+	0x68, 0x89, 0xb6, 0x00, 0x54, 0x42, 0xf9, 0xd8, 0x23, 0x8d, 0xa5, 0x6d, 0xa3, 0x55, 0xb6, 0xcb,
+	0x92, 0xf2, 0x16, 0x7b, 0x44, 0x84, 0x22, 0x24, 0x98, 0x05, 0x7e, 0x63, 0x59, 0xfe, 0xd2, 0x2b,
+	0xeb, 0xd7, 0x06, 0x7c, 0x91, 0xb5, 0x7c, 0x91, 0x60, 0xbc, 0x54, 0x42, 0x44, 0xc4, 0xc1, 0x6c,
+	0x55, 0xf6, 0xb7, 0x76, 0xc7, 0x73, 0x7a, 0x37, 0x76, 0x95, 0xad, 0x99, 0xad, 0xff, 0x14, 0x00,
+	0xb5, 0x43, 0x82, 0x39, 0x91, 0xff, 0x22, 0xef, 0x67, 0x5d, 0x62, 0xe4, 0xb8, 0x24, 0x6d, 0x78,
+	0x21, 0x6b, 0xf8, 0x64, 0x8f, 0x35, 0x60, 0xe5, 0x03, 0x09, 0x19, 0x0d, 0x7c, 0xe9, 0x2e, 0xd3,
+	0x8e, 0x96, 0xe8, 0x1e, 0x94, 0x3d, 0xc2, 0x71, 0x6f, 0x88, 0xf9, 0x99, 0xf6, 0x57, 0x49, 0x10,
+	0x0e, 0x31, 0x3f, 0x13, 0xfa, 0x1c, 0xac, 0x7f, 0xb2, 0x46, 0x71, 0xdb, 0x14, 0xfa, 0x1c, 0xac,
+	0xfe, 0xca, 0x6c, 0xe4, 0x97, 0x43, 0x12, 0x65, 0xe3, 0xca, 0xb6, 0x39, 0x9e, 0x8d, 0xda, 0x75,
+	0x3f, 0x23, 0x97, 0xef, 0xb0, 0x3b, 0x22, 0x87, 0x98, 0x86, 0x36, 0x08, 0x29, 0x95, 0x8d, 0xa8,
+	0xa3, 0xcd, 0x8e, 0x36, 0x29, 0xcd, 0xbb, 0x49, 0x45, 0x8a, 0xe9, 0x5d, 0xbe, 0x84, 0x92, 0x3f,
+	0xf2, 0x7a, 0x61, 0xf0, 0x91, 0x35, 0xca, 0xca, 0x40, 0x7f, 0xe4, 0xd9, 0xc1, 0x47, 0x66, 0x5d,
+	0xc0, 0x46, 0x1b, 0xfb, 0x7d, 0xe2, 0x76, 0x63, 0x4f, 0xde, 0x1c, 0x1d, 0xc6, 0x4a, 0xa4, 0x90,
+	0x57, 0x22, 0x1e, 0x7c, 0xf6, 0x9a, 0xf0, 0x63, 0xcc, 0xce, 0x8f, 0xdc, 0x80, 0xb3, 0xdb, 0x56,
+	0xf7, 0x5b, 0x03, 0x6a, 0xd2, 0x38, 0xa9, 0x31, 0xb7, 0xbe, 0xf2, 0x92, 0x29, 0x2e, 0xdd, 0xc2,
+	0xb5, 0x4a, 0xf7, 0x1b, 0xa8, 0x5d, 0x8c, 0xc8, 0x88, 0xf4, 0x86, 0x01, 0xa3, 0x5c, 0x64, 0x94,
+	0xca, 0xb5, 0x55, 0x49, 0x3d, 0xd4, 0x44, 0xeb, 0xaf, 0x06, 0xac, 0xa7, 0x9d, 0xb0, 0x48, 0xa9,
+	0xad, 0xc3, 0x32, 0x13, 0xbb, 0x68, 0xa0, 0x50, 0x0b, 0xd4, 0x86, 0x0a, 0xc7, 0xec, 0xbc, 0xa7,
+	0xab, 0xd0, 0x94, 0xa9, 0x63, 0x4d, 0xac, 0xc2, 0xd8, 0x3d, 0x36, 0xf0, 0xe8, 0x93, 0x59, 0x3f,
+	0x95, 0xa0, 0xd0, 0xc6, 0x43, 0x7c, 0x42, 0x5d, 0xca, 0x29, 0xb9, 0x79, 0xbc, 0xac, 0xbf, 0x1b,
+	0xb0, 0x31, 0xb6, 0xd9, 0x22, 0x76, 0x7f, 0x05, 0xab, 0x27, 0x22, 0x5c, 0xbd, 0xa8, 0x7a, 0x55,
+	0xcd, 0x57, 0x25, 0xf1, 0x9d, 0x2e, 0xe1, 0xfb, 0xa0, 0x6a, 0xa1, 0x27, 0xca, 0x4a, 0xb9, 0xa1,
+	0x6c, 0x2b, 0xa0, 0x38, 0x16, 0x14, 0xd4, 0x84, 0xd2, 0x80, 0x60, 0x3e, 0x0a, 0x09, 0x93, 0xe5,
+	0xbf, 0x64, 0xc7, 0x6b, 0xeb, 0x9f, 0x26, 0xac, 0xa9, 0x8c, 0xf8, 0x9f, 0x81, 0x51, 0x1a, 0x55,
+	0x96, 0x67, 0xa0, 0x4a, 0xf1, 0x53, 0xa0, 0xca, 0xca, 0x8d, 0x50, 0x65, 0x13, 0xca, 0x8c, 0x9c,
+	0x7a, 0xc4, 0xe7, 0xdd, 0x4e, 0xa3, 0x24, 0x8d, 0xb8, 0x22, 0x08, 0x03, 0x07, 0x94, 0x48, 0xf7,
+	0x68, 0xc8, 0xd1, 0x4b, 0xe1, 0xbd, 0x7e, 0xe0, 0xba, 0xa4, 0x2f, 0x2a, 0xa1, 0xdb, 0x69, 0x80,
+	0xf2, 0x5e, 0x92, 0x96, 0x42, 0xac, 0x4a, 0x0a, 0xb1, 0x50, 0x0b, 0xea, 0x1e, 0xf5, 0x7b, 0x5a,
+	0x93, 0x62, 0xa9, 0x4a, 0x96, 0x9a, 0x47, 0xfd, 0x23, 0x45, 0x96, 0xd8, 0xe6, 0x01, 0x4a, 0xc6,
+	0x6e, 0x91, 0x4c, 0x9b, 0xa3, 0x23, 0x5b, 0x3f, 0x86, 0x46, 0xd4, 0x3f, 0x5f, 0x51, 0x97, 0xc8,
+	0x70, 0x5d, 0x6f, 0x78, 0xf8, 0xbd, 0x01, 0x6b, 0x29, 0x79, 0x39, 0x44, 0xdc, 0xd6, 0x81, 0x85,
+	0x27, 0x55, 0x1a, 0x0c, 0xa8, 0x4b, 0x74, 0xbe, 0xa9, 0xf2, 0xa8, 0xd1, 0x94, 0x15, 0xe2, 0x60,
+	0x5f, 0xe6, 0xd8, 0xb6, 0x88, 0x47, 0x3b, 0x00, 0x09, 0xb5, 0x6a, 0x44, 0xf8, 0x66, 0x22, 0x38,
+	0x25, 0x1d, 0x62, 0x97, 0x07, 0xf1, 0xc1, 0xfe, 0x64, 0xea, 0x71, 0xeb, 0x0d, 0xe1, 0xf8, 0x36,
+	0x71, 0xfd, 0x3e, 0x54, 0x06, 0x98, 0xba, 0x3d, 0x3d, 0x3a, 0x99, 0xb2, 0x9e, 0x41, 0x90, 0x6c,
+	0x49, 0x41, 0x3f, 0x00, 0x33, 0x24, 0x17, 0x12, 0x40, 0x26, 0x18, 0x32, 0x86, 0x23, 0xb6, 0x90,
+	0xc8, 0x8d, 0xc2, 0x72, 0x5e, 0x14, 0xd0, 0x03, 0xa8, 0x7a, 0x38, 0x3c, 0xef, 0x39, 0xc4, 0x25,
+	0x9c, 0x38, 0x8d, 0xe2, 0xb6, 0xd1, 0x2a, 0xd9, 0x15, 0x41, 0xeb, 0x28, 0x52, 0x62, 0xce, 0x5e,
+	0x49, 0xce, 0xd9, 0xc9, 0x09, 0xa7, 0x94, 0x9e, 0x70, 0x9a, 0x50, 0x0a, 0x49, 0xff, 0xb2, 0xef,
+	0x12, 0x47, 0x16, 0x6a, 0xc9, 0x8e, 0xd7, 0xc2, 0xe8, 0x90, 0xf0, 0xf0, 0xb2, 0xd7, 0x0f, 0x46,
+	0x3e, 0xd7, 0x85, 0x0a, 0x92, 0xd4, 0x16, 0x14, 0xc1, 0x80, 0x19, 0xa3, 0xa7, 0x7e, 0x8f, 0x53,
+	0x8f, 0xe8, 0x4a, 0x05, 0x45, 0x3a, 0xa6, 0x1e, 0xb1, 0xfe, 0x5d, 0x80, 0xbb, 0xd2, 0xe4, 0x0e,
+	0x19, 0x50, 0x5f, 0xf6, 0xbe, 0xb1, 0xfa, 0x37, 0x72, 0xea, 0x3f, 0x81, 0x1e, 0x85, 0x34, 0x7a,
+	0xa4, 0x71, 0xd5, 0x9c, 0x82, 0xab, 0x4b, 0x69, 0x5c, 0xcd, 0x00, 0xe7, 0xf2, 0xa7, 0x00, 0xce,
+	0xe2, 0x8d, 0x80, 0xf3, 0x87, 0x22, 0xf5, 0x89, 0xeb, 0xc8, 0x8e, 0x24, 0x03, 0x55, 0xcb, 0x4e,
+	0xc7, 0xfa, 0xfa, 0xd6, 0xc1, 0x1c, 0x8b, 0x26, 0x25, 0x52, 0x9e, 0xb8, 0x8e, 0xf8, 0xb4, 0x7e,
+	0x67, 0xc0, 0x66, 0x62, 0x40, 0xbe, 0x72, 0xec, 0xcd, 0x07, 0xa9, 0x36, 0x80, 0x13, 0x6f, 0xa3,
+	0xaf, 0x4d, 0x5f, 0x4d, 0xac, 0xc5, 0x84, 0xc6, 0x84, 0x98, 0xe5, 0xc3, 0xd6, 0x84, 0x63, 0x2d,
+	0x02, 0x13, 0x89, 0x78, 0x16, 0x52, 0xf1, 0xb4, 0x9e, 0x40, 0xbd, 0x13, 0x06, 0xc3, 0x54, 0x63,
+	0x4e, 0x70, 0x1b, 0x69, 0x6e, 0x0a, 0x1b, 0x82, 0x5b, 0xb7, 0x87, 0x94, 0xd0, 0xf5, 0xfd, 0x95,
+	0xea, 0x7c, 0x85, 0x4c, 0xe7, 0xb3, 0xfe, 0x66, 0xc0, 0x66, 0x04, 0x96, 0xb2, 0xe6, 0x0f, 0xc3,
+	0xe0, 0x54, 0x5e, 0x87, 0x6f, 0xac, 0x30, 0x5b, 0x32, 0x85, 0xe9, 0x25, 0x63, 0x4e, 0x2b, 0x99,
+	0xec, 0x85, 0x50, 0x80, 0xfb, 0xd6, 0x84, 0xf3, 0x2e, 0x12, 0xb9, 0x07, 0xba, 0x56, 0x88, 0xa3,
+	0x7a, 0xb4, 0x3a, 0x73, 0x45, 0xd3, 0x64, 0x2b, 0xdf, 0x02, 0xe0, 0x01, 0xc7, 0xae, 0x62, 0x50,
+	0xa7, 0x2e, 0x4b, 0x8a, 0xec, 0xdf, 0x7f, 0x51, 0x43, 0x72, 0x02, 0x8c, 0xff, 0x3f, 0x1d, 0xf8,
+	0x67, 0x23, 0xf3, 0x66, 0xb0, 0xe8, 0xc5, 0xf9, 0x56, 0x3a, 0xd4, 0xa3, 0x77, 0x50, 0x97, 0x52,
+	0xe2, 0x9d, 0xe5, 0x95, 0x1a, 0x70, 0xd1, 0x5d, 0xa8, 0xe8, 0xcf, 0xb7, 0x81, 0x4f, 0xea, 0x77,
+	0xd0, 0x3d, 0xd8, 0xd0, 0x84, 0xec, 0xb5, 0xb0, 0x6e, 0xa0, 0x75, 0xa8, 0xeb, 0x9f, 0xf1, 0xc5,
+	0xa5, 0x5e, 0xd8, 0xff, 0x23, 0x00, 0x48, 0xb6, 0x76, 0x10, 0x84, 0x0e, 0x1a, 0x02, 0x12, 0x43,
+	0x7e, 0xe0, 0x0d, 0x03, 0x9f, 0xf8, 0x5c, 0x9e, 0x91, 0xa1, 0x67, 0x13, 0x5e, 0x61, 0xc6, 0x59,
+	0x75, 0x90, 0x9b, 0x3b, 0x13, 0x24, 0x32, 0xec, 0xd6, 0x1d, 0xe4, 0x49, 0x8d, 0xa2, 0xdf, 0x1c,
+	0xd3, 0xfe, 0x79, 0xfb, 0x0c, 0xfb, 0x3e, 0x71, 0xa7, 0x69, 0xcc, 0xb0, 0x46, 0x1a, 0x33, 0x90,
+	0xa7, 0x17, 0x47, 0x3c, 0xa4, 0xfe, 0x69, 0x14, 0x52, 0xeb, 0x0e, 0xba, 0x90, 0x59, 0x29, 0xb4,
+	0x53, 0xc6, 0x69, 0x9f, 0x45, 0x0a, 0xf7, 0x27, 0x2b, 0x1c, 0x63, 0xbe, 0xa6, 0xca, 0x5f, 0xc1,
+	0xe7, 0xb9, 0xd8, 0x8a, 0x9e, 0xe5, 0xa1, 0xf4, 0xb4, 0xee, 0xd0, 0x7c, 0x7e, 0x0d, 0x89, 0x58,
+	0xff, 0x2f, 0x00, 0xae, 0xa6, 0x17, 0x34, 0xdf, 0x74, 0xd3, 0xdc, 0x99, 0xc5, 0x16, 0x6f, 0x4f,
+	0xa1, 0x96, 0x7e, 0x79, 0x42, 0x0f, 0xf3, 0x64, 0x73, 0xdf, 0xe5, 0x9a, 0x8f, 0xe6, 0x61, 0x8d,
+	0x55, 0x85, 0xb0, 0x36, 0x36, 0xc8, 0xa2, 0x27, 0xd3, 0xb6, 0xc8, 0xce, 0xf2, 0xcd, 0xa7, 0x73,
+	0x72, 0xc7, 0x3a, 0x0f, 0xa1, 0x1c, 0x77, 0x2a, 0xf4, 0x75, 0x9e, 0x74, 0xb6, 0x91, 0x35, 0xa7,
+	0x01, 0x85, 0xca, 0x87, 0x5c, 0xc4, 0xce, 0xcf, 0x87, 0x69, 0xcd, 0xa8, 0xf9, 0xfc, 0x1a, 0x12,
+	0xb1, 0x45, 0x03, 0x58, 0x4d, 0x79, 0x18, 0xb5, 0x66, 0x06, 0x21, 0xd2, 0xf7, 0x70, 0x0e, 0xce,
+	0x58, 0xcf, 0x7b, 0xa8, 0x67, 0xbb, 0x36, 0x7a, 0x3c, 0xc9, 0x81, 0x39, 0xbd, 0x7d, 0x96, 0x1f,
+	0x7b, 0x00, 0xaf, 0x09, 0x7f, 0x43, 0x78, 0x48, 0xfb, 0x0c, 0xed, 0xe4, 0x16, 0xe3, 0x15, 0x43,
+	0xb4, 0xe9, 0xb7, 0x33, 0xf9, 0x22, 0x03, 0xf6, 0xff, 0x55, 0x84, 0x72, 0x0c, 0xba, 0xdf, 0x41,
+	0xe3, 0x2d, 0x40, 0xe3, 0x31, 0x54, 0x12, 0xe8, 0x85, 0x76, 0x66, 0xc0, 0xdb, 0x9c, 0x89, 0xf1,
+	0x1e, 0xea, 0xd9, 0xfe, 0x97, 0x9f, 0x78, 0x13, 0x1e, 0x4f, 0x67, 0xed, 0xdf, 0x87, 0x6a, 0xf2,
+	0xf9, 0x0f, 0x7d, 0x3b, 0xa1, 0x2a, 0xb2, 0xaf, 0xa4, 0xcd, 0xd6, 0x6c, 0xc6, 0xd8, 0x35, 0x2e,
+	0xdc, 0xcd, 0x3c, 0xb7, 0xa1, 0x49, 0x60, 0x99, 0xf3, 0xc0, 0xd7, 0x7c, 0x3c, 0x17, 0x6f, 0xac,
+	0xed, 0xb6, 0x6b, 0xe9, 0xe0, 0x7b, 0x3f, 0xdf, 0x3f, 0xa5, 0xfc, 0x6c, 0x74, 0x22, 0xbc, 0xb9,
+	0xa7, 0x38, 0x9f, 0xd2, 0x40, 0x7f, 0xed, 0x45, 0x49, 0xb5, 0x27, 0x77, 0xda, 0x93, 0xc7, 0x1d,
+	0x9e, 0x9c, 0x14, 0xe5, 0xf2, 0xc5, 0x7f, 0x07, 0x00, 0xc0, 0xb2, 0xca, 0x0b, 0x65, 0x1b, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -888,10 +1817,14 @@ type IndexCoordClient interface {
 	GetComponentStates(ctx context.Context, in *internalpb.GetComponentStatesRequest, opts ...grpc.CallOption) (*internalpb.ComponentStates, error)
 	GetTimeTickChannel(ctx context.Context, in *internalpb.GetTimeTickChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
 	GetStatisticsChannel(ctx context.Context, in *internalpb.GetStatisticsChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
+	CreateIndexDefinition(ctx context.Context, in *CreateIndexDefinitionRequest, opts ...grpc.CallOption) (*CreateIndexDefinitionResponse, error)
 	BuildIndex(ctx context.Context, in *BuildIndexRequest, opts ...grpc.CallOption) (*BuildIndexResponse, error)
 	GetIndexStates(ctx context.Context, in *GetIndexStatesRequest, opts ...grpc.CallOption) (*GetIndexStatesResponse, error)
 	GetIndexFilePaths(ctx context.Context, in *GetIndexFilePathsRequest, opts ...grpc.CallOption) (*GetIndexFilePathsResponse, error)
 	DropIndex(ctx context.Context, in *DropIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	GetIndexBuildProgress(ctx context.Context, in *GetIndexBuildProgressRequest, opts ...grpc.CallOption) (*GetIndexBuildProgressResponse, error)
+	GetIndexState(ctx context.Context, in *GetIndexStateRequest, opts ...grpc.CallOption) (*GetIndexStateResponse, error)
+	DropSegmentIndex(ctx context.Context, in *DropSegmentIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
 }
@@ -931,6 +1864,15 @@ func (c *indexCoordClient) GetStatisticsChannel(ctx context.Context, in *interna
 	return out, nil
 }
 
+func (c *indexCoordClient) CreateIndexDefinition(ctx context.Context, in *CreateIndexDefinitionRequest, opts ...grpc.CallOption) (*CreateIndexDefinitionResponse, error) {
+	out := new(CreateIndexDefinitionResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexCoord/CreateIndexDefinition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *indexCoordClient) BuildIndex(ctx context.Context, in *BuildIndexRequest, opts ...grpc.CallOption) (*BuildIndexResponse, error) {
 	out := new(BuildIndexResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexCoord/BuildIndex", in, out, opts...)
@@ -967,6 +1909,33 @@ func (c *indexCoordClient) DropIndex(ctx context.Context, in *DropIndexRequest,
 	return out, nil
 }
 
+func (c *indexCoordClient) GetIndexBuildProgress(ctx context.Context, in *GetIndexBuildProgressRequest, opts ...grpc.CallOption) (*GetIndexBuildProgressResponse, error) {
+	out := new(GetIndexBuildProgressResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexCoord/GetIndexBuildProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexCoordClient) GetIndexState(ctx context.Context, in *GetIndexStateRequest, opts ...grpc.CallOption) (*GetIndexStateResponse, error) {
+	out := new(GetIndexStateResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexCoord/GetIndexState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexCoordClient) DropSegmentIndex(ctx context.Context, in *DropSegmentIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexCoord/DropSegmentIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *indexCoordClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	out := new(milvuspb.GetMetricsResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexCoord/GetMetrics", in, out, opts...)
@@ -981,10 +1950,14 @@ type IndexCoordServer interface {
 	GetComponentStates(context.Context, *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error)
 	GetTimeTickChannel(context.Context, *internalpb.GetTimeTickChannelRequest) (*milvuspb.StringResponse, error)
 	GetStatisticsChannel(context.Context, *internalpb.GetStatisticsChannelRequest) (*milvuspb.StringResponse, error)
+	CreateIndexDefinition(context.Context, *CreateIndexDefinitionRequest) (*CreateIndexDefinitionResponse, error)
 	BuildIndex(context.Context, *BuildIndexRequest) (*BuildIndexResponse, error)
 	GetIndexStates(context.Context, *GetIndexStatesRequest) (*GetIndexStatesResponse, error)
 	GetIndexFilePaths(context.Context, *GetIndexFilePathsRequest) (*GetIndexFilePathsResponse, error)
 	DropIndex(context.Context, *DropIndexRequest) (*commonpb.Status, error)
+	GetIndexBuildProgress(context.Context, *GetIndexBuildProgressRequest) (*GetIndexBuildProgressResponse, error)
+	GetIndexState(context.Context, *GetIndexStateRequest) (*GetIndexStateResponse, error)
+	DropSegmentIndex(context.Context, *DropSegmentIndexRequest) (*commonpb.Status, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -1002,6 +1975,9 @@ func (*UnimplementedIndexCoordServer) GetTimeTickChannel(ctx context.Context, re
 func (*UnimplementedIndexCoordServer) GetStatisticsChannel(ctx context.Context, req *internalpb.GetStatisticsChannelRequest) (*milvuspb.StringResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatisticsChannel not implemented")
 }
+func (*UnimplementedIndexCoordServer) CreateIndexDefinition(ctx context.Context, req *CreateIndexDefinitionRequest) (*CreateIndexDefinitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIndexDefinition not implemented")
+}
 func (*UnimplementedIndexCoordServer) BuildIndex(ctx context.Context, req *BuildIndexRequest) (*BuildIndexResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BuildIndex not implemented")
 }
@@ -1014,6 +1990,15 @@ func (*UnimplementedIndexCoordServer) GetIndexFilePaths(ctx context.Context, req
 func (*UnimplementedIndexCoordServer) DropIndex(ctx context.Context, req *DropIndexRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DropIndex not implemented")
 }
+func (*UnimplementedIndexCoordServer) GetIndexBuildProgress(ctx context.Context, req *GetIndexBuildProgressRequest) (*GetIndexBuildProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIndexBuildProgress not implemented")
+}
+func (*UnimplementedIndexCoordServer) GetIndexState(ctx context.Context, req *GetIndexStateRequest) (*GetIndexStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIndexState not implemented")
+}
+func (*UnimplementedIndexCoordServer) DropSegmentIndex(ctx context.Context, req *DropSegmentIndexRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropSegmentIndex not implemented")
+}
 func (*UnimplementedIndexCoordServer) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
@@ -1076,6 +2061,24 @@ func _IndexCoord_GetStatisticsChannel_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexCoord_CreateIndexDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIndexDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexCoordServer).CreateIndexDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexCoord/CreateIndexDefinition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexCoordServer).CreateIndexDefinition(ctx, req.(*CreateIndexDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IndexCoord_BuildIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BuildIndexRequest)
 	if err := dec(in); err != nil {
@@ -1148,6 +2151,60 @@ func _IndexCoord_DropIndex_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexCoord_GetIndexBuildProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexBuildProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexCoordServer).GetIndexBuildProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexCoord/GetIndexBuildProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexCoordServer).GetIndexBuildProgress(ctx, req.(*GetIndexBuildProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexCoord_GetIndexState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexCoordServer).GetIndexState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexCoord/GetIndexState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexCoordServer).GetIndexState(ctx, req.(*GetIndexStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexCoord_DropSegmentIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropSegmentIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexCoordServer).DropSegmentIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexCoord/DropSegmentIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexCoordServer).DropSegmentIndex(ctx, req.(*DropSegmentIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IndexCoord_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(milvuspb.GetMetricsRequest)
 	if err := dec(in); err != nil {
@@ -1182,6 +2239,10 @@ var _IndexCoord_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatisticsChannel",
 			Handler:    _IndexCoord_GetStatisticsChannel_Handler,
 		},
+		{
+			MethodName: "CreateIndexDefinition",
+			Handler:    _IndexCoord_CreateIndexDefinition_Handler,
+		},
 		{
 			MethodName: "BuildIndex",
 			Handler:    _IndexCoord_BuildIndex_Handler,
@@ -1198,6 +2259,18 @@ var _IndexCoord_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DropIndex",
 			Handler:    _IndexCoord_DropIndex_Handler,
 		},
+		{
+			MethodName: "GetIndexBuildProgress",
+			Handler:    _IndexCoord_GetIndexBuildProgress_Handler,
+		},
+		{
+			MethodName: "GetIndexState",
+			Handler:    _IndexCoord_GetIndexState_Handler,
+		},
+		{
+			MethodName: "DropSegmentIndex",
+			Handler:    _IndexCoord_DropSegmentIndex_Handler,
+		},
 		{
 			MethodName: "GetMetrics",
 			Handler:    _IndexCoord_GetMetrics_Handler,
@@ -1215,6 +2288,9 @@ type IndexNodeClient interface {
 	GetTimeTickChannel(ctx context.Context, in *internalpb.GetTimeTickChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
 	GetStatisticsChannel(ctx context.Context, in *internalpb.GetStatisticsChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
 	CreateIndex(ctx context.Context, in *CreateIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	CancelIndexBuild(ctx context.Context, in *CancelIndexBuildRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	GetTaskSlots(ctx context.Context, in *GetTaskSlotsRequest, opts ...grpc.CallOption) (*GetTaskSlotsResponse, error)
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
 }
@@ -1263,6 +2339,33 @@ func (c *indexNodeClient) CreateIndex(ctx context.Context, in *CreateIndexReques
 	return out, nil
 }
 
+func (c *indexNodeClient) CancelIndexBuild(ctx context.Context, in *CancelIndexBuildRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexNode/CancelIndexBuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexNodeClient) GetTaskSlots(ctx context.Context, in *GetTaskSlotsRequest, opts ...grpc.CallOption) (*GetTaskSlotsResponse, error) {
+	out := new(GetTaskSlotsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexNode/GetTaskSlots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexNodeClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexNode/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *indexNodeClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	out := new(milvuspb.GetMetricsResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.index.IndexNode/GetMetrics", in, out, opts...)
@@ -1278,6 +2381,9 @@ type IndexNodeServer interface {
 	GetTimeTickChannel(context.Context, *internalpb.GetTimeTickChannelRequest) (*milvuspb.StringResponse, error)
 	GetStatisticsChannel(context.Context, *internalpb.GetStatisticsChannelRequest) (*milvuspb.StringResponse, error)
 	CreateIndex(context.Context, *CreateIndexRequest) (*commonpb.Status, error)
+	CancelIndexBuild(context.Context, *CancelIndexBuildRequest) (*commonpb.Status, error)
+	GetTaskSlots(context.Context, *GetTaskSlotsRequest) (*GetTaskSlotsResponse, error)
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -1298,6 +2404,15 @@ func (*UnimplementedIndexNodeServer) GetStatisticsChannel(ctx context.Context, r
 func (*UnimplementedIndexNodeServer) CreateIndex(ctx context.Context, req *CreateIndexRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateIndex not implemented")
 }
+func (*UnimplementedIndexNodeServer) CancelIndexBuild(ctx context.Context, req *CancelIndexBuildRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelIndexBuild not implemented")
+}
+func (*UnimplementedIndexNodeServer) GetTaskSlots(ctx context.Context, req *GetTaskSlotsRequest) (*GetTaskSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskSlots not implemented")
+}
+func (*UnimplementedIndexNodeServer) GetCapabilities(ctx context.Context, req *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (*UnimplementedIndexNodeServer) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
@@ -1378,6 +2493,60 @@ func _IndexNode_CreateIndex_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexNode_CancelIndexBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelIndexBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexNodeServer).CancelIndexBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexNode/CancelIndexBuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexNodeServer).CancelIndexBuild(ctx, req.(*CancelIndexBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexNode_GetTaskSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexNodeServer).GetTaskSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexNode/GetTaskSlots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexNodeServer).GetTaskSlots(ctx, req.(*GetTaskSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexNode_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexNodeServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.index.IndexNode/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexNodeServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IndexNode_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(milvuspb.GetMetricsRequest)
 	if err := dec(in); err != nil {
@@ -1416,6 +2585,18 @@ var _IndexNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateIndex",
 			Handler:    _IndexNode_CreateIndex_Handler,
 		},
+		{
+			MethodName: "CancelIndexBuild",
+			Handler:    _IndexNode_CancelIndexBuild_Handler,
+		},
+		{
+			MethodName: "GetTaskSlots",
+			Handler:    _IndexNode_GetTaskSlots_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _IndexNode_GetCapabilities_Handler,
+		},
 		{
 			MethodName: "GetMetrics",
 			Handler:    _IndexNode_GetMetrics_Handler,
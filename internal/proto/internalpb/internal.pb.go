@@ -1240,16 +1240,18 @@ type SearchRequest struct {
 	PartitionIDs    []int64           `protobuf:"varint,5,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
 	Dsl             string            `protobuf:"bytes,6,opt,name=dsl,proto3" json:"dsl,omitempty"`
 	// serialized `PlaceholderGroup`
-	PlaceholderGroup     []byte           `protobuf:"bytes,7,opt,name=placeholder_group,json=placeholderGroup,proto3" json:"placeholder_group,omitempty"`
-	DslType              commonpb.DslType `protobuf:"varint,8,opt,name=dsl_type,json=dslType,proto3,enum=milvus.proto.common.DslType" json:"dsl_type,omitempty"`
-	SerializedExprPlan   []byte           `protobuf:"bytes,9,opt,name=serialized_expr_plan,json=serializedExprPlan,proto3" json:"serialized_expr_plan,omitempty"`
-	OutputFieldsId       []int64          `protobuf:"varint,10,rep,packed,name=output_fields_id,json=outputFieldsId,proto3" json:"output_fields_id,omitempty"`
-	TravelTimestamp      uint64           `protobuf:"varint,11,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
-	GuaranteeTimestamp   uint64           `protobuf:"varint,12,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
-	SearchByIDExprPlan   []byte           `protobuf:"bytes,13,opt,name=search_byID_expr_plan,json=searchByIDExprPlan,proto3" json:"search_byID_expr_plan,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	PlaceholderGroup   []byte           `protobuf:"bytes,7,opt,name=placeholder_group,json=placeholderGroup,proto3" json:"placeholder_group,omitempty"`
+	DslType            commonpb.DslType `protobuf:"varint,8,opt,name=dsl_type,json=dslType,proto3,enum=milvus.proto.common.DslType" json:"dsl_type,omitempty"`
+	SerializedExprPlan []byte           `protobuf:"bytes,9,opt,name=serialized_expr_plan,json=serializedExprPlan,proto3" json:"serialized_expr_plan,omitempty"`
+	OutputFieldsId     []int64          `protobuf:"varint,10,rep,packed,name=output_fields_id,json=outputFieldsId,proto3" json:"output_fields_id,omitempty"`
+	TravelTimestamp    uint64           `protobuf:"varint,11,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
+	GuaranteeTimestamp uint64           `protobuf:"varint,12,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
+	SearchByIDExprPlan []byte           `protobuf:"bytes,13,opt,name=search_byID_expr_plan,json=searchByIDExprPlan,proto3" json:"search_byID_expr_plan,omitempty"`
+	// the search is dropped by query nodes once the timestamp passes, 0 means no timeout
+	TimeoutTimestamp     uint64   `protobuf:"varint,14,opt,name=timeout_timestamp,json=timeoutTimestamp,proto3" json:"timeout_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
@@ -1368,6 +1370,13 @@ func (m *SearchRequest) GetSearchByIDExprPlan() []byte {
 	return nil
 }
 
+func (m *SearchRequest) GetTimeoutTimestamp() uint64 {
+	if m != nil {
+		return m.TimeoutTimestamp
+	}
+	return 0
+}
+
 type SearchResults struct {
 	Base                     *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	Status                   *commonpb.Status  `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -1379,9 +1388,12 @@ type SearchResults struct {
 	ChannelIDsSearched       []string          `protobuf:"bytes,8,rep,name=channelIDs_searched,json=channelIDsSearched,proto3" json:"channelIDs_searched,omitempty"`
 	GlobalSealedSegmentIDs   []int64           `protobuf:"varint,9,rep,packed,name=global_sealed_segmentIDs,json=globalSealedSegmentIDs,proto3" json:"global_sealed_segmentIDs,omitempty"`
 	// schema.SearchResultsData inside
-	SlicedBlob           []byte   `protobuf:"bytes,10,opt,name=sliced_blob,json=slicedBlob,proto3" json:"sliced_blob,omitempty"`
-	SlicedNumCount       int64    `protobuf:"varint,11,opt,name=sliced_num_count,json=slicedNumCount,proto3" json:"sliced_num_count,omitempty"`
-	SlicedOffset         int64    `protobuf:"varint,12,opt,name=sliced_offset,json=slicedOffset,proto3" json:"sliced_offset,omitempty"`
+	SlicedBlob     []byte `protobuf:"bytes,10,opt,name=sliced_blob,json=slicedBlob,proto3" json:"sliced_blob,omitempty"`
+	SlicedNumCount int64  `protobuf:"varint,11,opt,name=sliced_num_count,json=slicedNumCount,proto3" json:"sliced_num_count,omitempty"`
+	SlicedOffset   int64  `protobuf:"varint,12,opt,name=sliced_offset,json=slicedOffset,proto3" json:"sliced_offset,omitempty"`
+	// rows of the segments searched and the number of them
+	RowsScanned          int64    `protobuf:"varint,13,opt,name=rows_scanned,json=rowsScanned,proto3" json:"rows_scanned,omitempty"`
+	SegmentsHit          int64    `protobuf:"varint,14,opt,name=segments_hit,json=segmentsHit,proto3" json:"segments_hit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1496,6 +1508,20 @@ func (m *SearchResults) GetSlicedOffset() int64 {
 	return 0
 }
 
+func (m *SearchResults) GetRowsScanned() int64 {
+	if m != nil {
+		return m.RowsScanned
+	}
+	return 0
+}
+
+func (m *SearchResults) GetSegmentsHit() int64 {
+	if m != nil {
+		return m.SegmentsHit
+	}
+	return 0
+}
+
 type RetrieveRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	ResultChannelID      string            `protobuf:"bytes,2,opt,name=result_channelID,json=resultChannelID,proto3" json:"result_channelID,omitempty"`
@@ -1506,6 +1532,7 @@ type RetrieveRequest struct {
 	OutputFieldsId       []int64           `protobuf:"varint,7,rep,packed,name=output_fields_id,json=outputFieldsId,proto3" json:"output_fields_id,omitempty"`
 	TravelTimestamp      uint64            `protobuf:"varint,8,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
 	GuaranteeTimestamp   uint64            `protobuf:"varint,9,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
+	Limit                int64             `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -1599,6 +1626,13 @@ func (m *RetrieveRequest) GetGuaranteeTimestamp() uint64 {
 	return 0
 }
 
+func (m *RetrieveRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 type RetrieveResults struct {
 	Base                      *commonpb.MsgBase     `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	Status                    *commonpb.Status      `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -2452,133 +2486,137 @@ func init() {
 func init() { proto.RegisterFile("internal.proto", fileDescriptor_41f4a519b878ee3b) }
 
 var fileDescriptor_41f4a519b878ee3b = []byte{
-	// 2047 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x5f, 0x6f, 0x24, 0x47,
-	0x11, 0x67, 0x76, 0x76, 0xbd, 0xbb, 0xb5, 0x6b, 0x7b, 0xdd, 0xf6, 0x25, 0x63, 0xdf, 0xe5, 0xce,
-	0x99, 0x04, 0x30, 0x39, 0x71, 0xbe, 0x38, 0x40, 0x22, 0x84, 0xb8, 0x9c, 0xbd, 0xe1, 0x58, 0x5d,
-	0x6c, 0xcc, 0xf8, 0x12, 0x09, 0x5e, 0x46, 0xbd, 0x3b, 0xed, 0xf5, 0x70, 0xf3, 0x2f, 0xd3, 0x3d,
-	0xbe, 0xdb, 0x3c, 0x21, 0xc4, 0x13, 0x08, 0x24, 0x90, 0x78, 0x84, 0x2f, 0x80, 0xc4, 0x2b, 0x4f,
-	0xfc, 0x11, 0x4f, 0x7c, 0x05, 0x3e, 0x00, 0x5f, 0x82, 0x27, 0xd4, 0xd5, 0x3d, 0x7f, 0x76, 0xbd,
-	0xf6, 0xf9, 0x7c, 0x0a, 0x39, 0xa4, 0xbc, 0x6d, 0x57, 0x55, 0xf7, 0x54, 0xfd, 0xea, 0x57, 0xdd,
-	0xd5, 0xbd, 0xb0, 0xe4, 0x47, 0x82, 0xa5, 0x11, 0x0d, 0xee, 0x24, 0x69, 0x2c, 0x62, 0x72, 0x2d,
-	0xf4, 0x83, 0xd3, 0x8c, 0xab, 0xd1, 0x9d, 0x5c, 0xb9, 0xd1, 0x1d, 0xc5, 0x61, 0x18, 0x47, 0x4a,
-	0xbc, 0xd1, 0xe5, 0xa3, 0x13, 0x16, 0x52, 0x35, 0xb2, 0xff, 0x6a, 0xc0, 0xe2, 0x5e, 0x1c, 0x26,
-	0x71, 0xc4, 0x22, 0x31, 0x88, 0x8e, 0x63, 0xf2, 0x0a, 0x2c, 0x44, 0xb1, 0xc7, 0x06, 0x7d, 0xcb,
-	0xd8, 0x34, 0xb6, 0x4c, 0x47, 0x8f, 0x08, 0x81, 0x7a, 0x1a, 0x07, 0xcc, 0xaa, 0x6d, 0x1a, 0x5b,
-	0x6d, 0x07, 0x7f, 0x93, 0x7b, 0x00, 0x5c, 0x50, 0xc1, 0xdc, 0x51, 0xec, 0x31, 0xcb, 0xdc, 0x34,
-	0xb6, 0x96, 0x76, 0x36, 0xef, 0xcc, 0xf5, 0xe2, 0xce, 0x91, 0x34, 0xdc, 0x8b, 0x3d, 0xe6, 0xb4,
-	0x79, 0xfe, 0x93, 0xbc, 0x0f, 0xc0, 0x9e, 0x8a, 0x94, 0xba, 0x7e, 0x74, 0x1c, 0x5b, 0xf5, 0x4d,
-	0x73, 0xab, 0xb3, 0xf3, 0xfa, 0xf4, 0x02, 0xda, 0xf9, 0x87, 0x6c, 0xf2, 0x31, 0x0d, 0x32, 0x76,
-	0x48, 0xfd, 0xd4, 0x69, 0xe3, 0x24, 0xe9, 0xae, 0xfd, 0x2f, 0x03, 0x96, 0x8b, 0x00, 0xf0, 0x1b,
-	0x9c, 0x7c, 0x1b, 0x1a, 0xf8, 0x09, 0x8c, 0xa0, 0xb3, 0xf3, 0xe6, 0x39, 0x1e, 0x4d, 0xc5, 0xed,
-	0xa8, 0x29, 0xe4, 0x23, 0x58, 0xe5, 0xd9, 0x70, 0x94, 0xab, 0x5c, 0x94, 0x72, 0xab, 0x86, 0xae,
-	0x5d, 0x6e, 0x25, 0x52, 0x5d, 0x40, 0xbb, 0xf4, 0x0e, 0x2c, 0xc8, 0x95, 0x32, 0x8e, 0x28, 0x75,
-	0x76, 0xae, 0xcf, 0x0d, 0xf2, 0x08, 0x4d, 0x1c, 0x6d, 0x6a, 0x5f, 0x87, 0xf5, 0x07, 0x4c, 0xcc,
-	0x44, 0xe7, 0xb0, 0x4f, 0x32, 0xc6, 0x85, 0x56, 0x3e, 0xf2, 0x43, 0xf6, 0xc8, 0x1f, 0x3d, 0xde,
-	0x3b, 0xa1, 0x51, 0xc4, 0x82, 0x5c, 0xf9, 0x1a, 0x5c, 0x7f, 0xc0, 0x70, 0x82, 0xcf, 0x85, 0x3f,
-	0xe2, 0x33, 0xea, 0x6b, 0xb0, 0xfa, 0x80, 0x89, 0xbe, 0x37, 0x23, 0xfe, 0x18, 0x5a, 0x07, 0x32,
-	0xd9, 0x92, 0x06, 0xdf, 0x82, 0x26, 0xf5, 0xbc, 0x94, 0x71, 0xae, 0x51, 0xbc, 0x31, 0xd7, 0xe3,
-	0xfb, 0xca, 0xc6, 0xc9, 0x8d, 0xe7, 0xd1, 0xc4, 0xfe, 0x09, 0xc0, 0x20, 0xf2, 0xc5, 0x21, 0x4d,
-	0x69, 0xc8, 0xcf, 0x25, 0x58, 0x1f, 0xba, 0x5c, 0xd0, 0x54, 0xb8, 0x09, 0xda, 0x69, 0xc8, 0x2f,
-	0xc1, 0x86, 0x0e, 0x4e, 0x53, 0xab, 0xdb, 0x3f, 0x02, 0x38, 0x12, 0xa9, 0x1f, 0x8d, 0x3f, 0xf4,
-	0xb9, 0x90, 0xdf, 0x3a, 0x95, 0x76, 0x32, 0x08, 0x73, 0xab, 0xed, 0xe8, 0x51, 0x25, 0x1d, 0xb5,
-	0xcb, 0xa7, 0xe3, 0x1e, 0x74, 0x72, 0xb8, 0xf7, 0xf9, 0x98, 0xdc, 0x85, 0xfa, 0x90, 0x72, 0x76,
-	0x21, 0x3c, 0xfb, 0x7c, 0xbc, 0x4b, 0x39, 0x73, 0xd0, 0xd2, 0xfe, 0x85, 0x09, 0xaf, 0xee, 0xa5,
-	0x0c, 0xc9, 0x1f, 0x04, 0x6c, 0x24, 0xfc, 0x38, 0xd2, 0xd8, 0x3f, 0xff, 0x6a, 0xe4, 0x55, 0x68,
-	0x7a, 0x43, 0x37, 0xa2, 0x61, 0x0e, 0xf6, 0x82, 0x37, 0x3c, 0xa0, 0x21, 0x23, 0x5f, 0x81, 0xa5,
-	0x51, 0xb1, 0xbe, 0x94, 0x20, 0xe7, 0xda, 0xce, 0x8c, 0x94, 0xbc, 0x09, 0x8b, 0x09, 0x4d, 0x85,
-	0x5f, 0x98, 0xd5, 0xd1, 0x6c, 0x5a, 0x28, 0x13, 0xea, 0x0d, 0x07, 0x7d, 0xab, 0x81, 0xc9, 0xc2,
-	0xdf, 0xc4, 0x86, 0x6e, 0xb9, 0xd6, 0xa0, 0x6f, 0x2d, 0xa0, 0x6e, 0x4a, 0x46, 0x36, 0xa1, 0x53,
-	0x2c, 0x34, 0xe8, 0x5b, 0x4d, 0x34, 0xa9, 0x8a, 0x64, 0x72, 0xd4, 0x5e, 0x64, 0xb5, 0x36, 0x8d,
-	0xad, 0xae, 0xa3, 0x47, 0xe4, 0x2e, 0xac, 0x9e, 0xfa, 0xa9, 0xc8, 0x68, 0xa0, 0xf9, 0x29, 0xfd,
-	0xe0, 0x56, 0x1b, 0x33, 0x38, 0x4f, 0x45, 0x76, 0x60, 0x2d, 0x39, 0x99, 0x70, 0x7f, 0x34, 0x33,
-	0x05, 0x70, 0xca, 0x5c, 0x9d, 0xfd, 0x0f, 0x03, 0xae, 0xf5, 0xd3, 0x38, 0x79, 0x29, 0x52, 0x91,
-	0x83, 0x5c, 0xbf, 0x00, 0xe4, 0xc6, 0x59, 0x90, 0xed, 0x5f, 0xd5, 0xe0, 0x15, 0xc5, 0xa8, 0xc3,
-	0x1c, 0xd8, 0xcf, 0x20, 0x8a, 0xaf, 0xc2, 0x72, 0xf9, 0x55, 0x65, 0x30, 0x3f, 0x8c, 0x2f, 0xc3,
-	0x52, 0x91, 0x60, 0x65, 0xf7, 0xbf, 0xa5, 0x94, 0xfd, 0xcb, 0x1a, 0xac, 0xc9, 0xa4, 0x7e, 0x81,
-	0x86, 0x44, 0xe3, 0x0f, 0x06, 0x10, 0xc5, 0x8e, 0xfb, 0x81, 0x4f, 0xf9, 0xe7, 0x89, 0xc5, 0x1a,
-	0x34, 0xa8, 0xf4, 0x41, 0x43, 0xa0, 0x06, 0x36, 0x87, 0x9e, 0xcc, 0xd6, 0x67, 0xe5, 0x5d, 0xf1,
-	0x51, 0xb3, 0xfa, 0xd1, 0xdf, 0x1b, 0xb0, 0x72, 0x3f, 0x10, 0x2c, 0x7d, 0x49, 0x41, 0xf9, 0x5b,
-	0x2d, 0xcf, 0xda, 0x20, 0xf2, 0xd8, 0xd3, 0xcf, 0xd3, 0xc1, 0xd7, 0x00, 0x8e, 0x7d, 0x16, 0x78,
-	0x55, 0xf6, 0xb6, 0x51, 0xf2, 0x42, 0xcc, 0xb5, 0xa0, 0x89, 0x8b, 0x14, 0xac, 0xcd, 0x87, 0xb2,
-	0x07, 0x50, 0xfd, 0xa0, 0xee, 0x01, 0x5a, 0x97, 0xee, 0x01, 0x70, 0x9a, 0xee, 0x01, 0xfe, 0x64,
-	0xc2, 0xe2, 0x20, 0xe2, 0x2c, 0x15, 0x57, 0x07, 0xef, 0x06, 0xb4, 0xf9, 0x09, 0x4d, 0x31, 0x50,
-	0x0d, 0x5f, 0x29, 0xa8, 0x42, 0x6b, 0x3e, 0x0b, 0xda, 0xfa, 0x25, 0x37, 0x87, 0xc6, 0x45, 0x9b,
-	0xc3, 0xc2, 0x05, 0x10, 0x37, 0x9f, 0xbd, 0x39, 0xb4, 0xce, 0x9e, 0xbe, 0x32, 0x40, 0x36, 0x0e,
-	0x65, 0xd3, 0xda, 0xb7, 0xda, 0xa8, 0x2f, 0x05, 0xe4, 0x26, 0x80, 0xf0, 0x43, 0xc6, 0x05, 0x0d,
-	0x13, 0x75, 0x8e, 0xd6, 0x9d, 0x8a, 0x44, 0x9e, 0xdd, 0x69, 0xfc, 0x64, 0xd0, 0xe7, 0x56, 0x67,
-	0xd3, 0x94, 0x4d, 0x9c, 0x1a, 0x91, 0x6f, 0x40, 0x2b, 0x8d, 0x9f, 0xb8, 0x1e, 0x15, 0xd4, 0xea,
-	0x62, 0xf2, 0xd6, 0xe7, 0x82, 0xbd, 0x1b, 0xc4, 0x43, 0xa7, 0x99, 0xc6, 0x4f, 0xfa, 0x54, 0x50,
-	0xfb, 0x8f, 0x75, 0x58, 0x3c, 0x62, 0x34, 0x1d, 0x9d, 0x5c, 0x3d, 0x61, 0x5f, 0x83, 0x5e, 0xca,
-	0x78, 0x16, 0x08, 0x77, 0xa4, 0x8e, 0xf9, 0x41, 0x5f, 0xe7, 0x6d, 0x59, 0xc9, 0xf7, 0x72, 0x71,
-	0x01, 0xaa, 0x79, 0x01, 0xa8, 0xf5, 0x39, 0xa0, 0xda, 0xd0, 0xad, 0x20, 0xc8, 0xad, 0x06, 0x86,
-	0x3e, 0x25, 0x23, 0x3d, 0x30, 0x3d, 0x1e, 0x60, 0xbe, 0xda, 0x8e, 0xfc, 0x49, 0x6e, 0xc3, 0x4a,
-	0x12, 0xd0, 0x11, 0x3b, 0x89, 0x03, 0x8f, 0xa5, 0xee, 0x38, 0x8d, 0xb3, 0x04, 0x73, 0xd6, 0x75,
-	0x7a, 0x15, 0xc5, 0x03, 0x29, 0x27, 0xef, 0x42, 0xcb, 0xe3, 0x81, 0x2b, 0x26, 0x09, 0xc3, 0xa4,
-	0x2d, 0x9d, 0x13, 0x7b, 0x9f, 0x07, 0x8f, 0x26, 0x09, 0x73, 0x9a, 0x9e, 0xfa, 0x41, 0xee, 0xc2,
-	0x1a, 0x67, 0xa9, 0x4f, 0x03, 0xff, 0x53, 0xe6, 0xb9, 0xec, 0x69, 0x92, 0xba, 0x49, 0x40, 0x23,
-	0xcc, 0x6c, 0xd7, 0x21, 0xa5, 0xee, 0x83, 0xa7, 0x49, 0x7a, 0x18, 0xd0, 0x88, 0x6c, 0x41, 0x2f,
-	0xce, 0x44, 0x92, 0x09, 0x17, 0xab, 0x8f, 0xbb, 0xbe, 0x87, 0x89, 0x36, 0x9d, 0x25, 0x25, 0xff,
-	0x1e, 0x8a, 0x07, 0x9e, 0x84, 0x56, 0xa4, 0xf4, 0x94, 0x05, 0x6e, 0xc1, 0x00, 0xab, 0xb3, 0x69,
-	0x6c, 0xd5, 0x9d, 0x65, 0x25, 0x7f, 0x94, 0x8b, 0xc9, 0x36, 0xac, 0x8e, 0x33, 0x9a, 0xd2, 0x48,
-	0x30, 0x56, 0xb1, 0xee, 0xa2, 0x35, 0x29, 0x54, 0xe5, 0x84, 0xb7, 0xe1, 0x1a, 0xc7, 0xcc, 0xbb,
-	0xc3, 0xc9, 0xa0, 0x5f, 0x71, 0x7c, 0x31, 0x77, 0x5c, 0x2a, 0x77, 0x27, 0x83, 0x7e, 0xee, 0xb8,
-	0xfd, 0x9b, 0x0a, 0x5b, 0x64, 0x62, 0xf9, 0x15, 0xd8, 0x72, 0x95, 0x0b, 0xc0, 0x5c, 0x8a, 0x99,
-	0xf3, 0x29, 0x76, 0x0b, 0x3a, 0x21, 0x13, 0xa9, 0x3f, 0x52, 0xa9, 0x54, 0x7b, 0x00, 0x28, 0x11,
-	0xe6, 0xeb, 0x16, 0x74, 0xa2, 0x2c, 0x74, 0x3f, 0xc9, 0x58, 0xea, 0x33, 0xae, 0xb7, 0x50, 0x88,
-	0xb2, 0xf0, 0x87, 0x4a, 0x42, 0x56, 0xa1, 0x21, 0xe2, 0xc4, 0x7d, 0x9c, 0x97, 0xbe, 0x88, 0x93,
-	0x87, 0xe4, 0x3b, 0xb0, 0xc1, 0x19, 0x0d, 0x98, 0xe7, 0x16, 0xa5, 0xca, 0x5d, 0x05, 0x11, 0xf3,
-	0xac, 0x26, 0x66, 0xcf, 0x52, 0x16, 0x47, 0x85, 0xc1, 0x91, 0xd6, 0xcb, 0xe4, 0x14, 0x8e, 0x57,
-	0xa6, 0xb5, 0xb0, 0x4b, 0x26, 0xa5, 0xaa, 0x98, 0xf0, 0x1e, 0x58, 0xe3, 0x20, 0x1e, 0xd2, 0xc0,
-	0x3d, 0xf3, 0x55, 0x6c, 0xc7, 0x4d, 0xe7, 0x15, 0xa5, 0x3f, 0x9a, 0xf9, 0xa4, 0x0c, 0x8f, 0x07,
-	0xfe, 0x88, 0x79, 0xee, 0x30, 0x88, 0x87, 0x16, 0x60, 0x32, 0x41, 0x89, 0x64, 0xed, 0x4b, 0xf6,
-	0x69, 0x03, 0x09, 0xc3, 0x28, 0xce, 0x22, 0x81, 0x9c, 0x32, 0x9d, 0x25, 0x25, 0x3f, 0xc8, 0xc2,
-	0x3d, 0x29, 0x25, 0x6f, 0xc0, 0xa2, 0xb6, 0x8c, 0x8f, 0x8f, 0x39, 0x13, 0x48, 0x26, 0xd3, 0xe9,
-	0x2a, 0xe1, 0x0f, 0x50, 0x66, 0xff, 0xcc, 0x84, 0x65, 0x47, 0xa2, 0xcb, 0x4e, 0xd9, 0xff, 0xfd,
-	0x1e, 0x72, 0x5e, 0x2d, 0x2f, 0x3c, 0x57, 0x2d, 0x37, 0x2f, 0x5d, 0xcb, 0xad, 0xe7, 0xaa, 0xe5,
-	0xf6, 0x79, 0xb5, 0x6c, 0xff, 0x65, 0x2a, 0x09, 0x2f, 0x6b, 0x69, 0xbe, 0x05, 0xa6, 0xef, 0xa9,
-	0x9e, 0xab, 0xb3, 0x63, 0x4d, 0x2f, 0xae, 0xdf, 0xc6, 0x06, 0x7d, 0xee, 0x48, 0x23, 0x72, 0x0f,
-	0x3a, 0x1a, 0x50, 0x3c, 0xd1, 0x1a, 0x78, 0xa2, 0xdd, 0x9c, 0x3b, 0x07, 0x11, 0x96, 0xa7, 0x99,
-	0xa3, 0x7a, 0x26, 0x2e, 0x7f, 0x93, 0xef, 0xc2, 0xf5, 0xb3, 0x05, 0x9b, 0x6a, 0x8c, 0x3c, 0x6b,
-	0x01, 0x73, 0xb4, 0x3e, 0x5b, 0xb1, 0x39, 0x88, 0x1e, 0x79, 0x1b, 0xd6, 0x2a, 0x25, 0x5b, 0x4e,
-	0x6c, 0xaa, 0xcb, 0x70, 0xa9, 0x2b, 0xa7, 0x5c, 0x54, 0xb4, 0xad, 0x8b, 0x8a, 0xd6, 0xfe, 0x77,
-	0x0d, 0x16, 0xfb, 0x2c, 0x60, 0x82, 0x7d, 0xd1, 0x37, 0x9d, 0xdb, 0x37, 0xbd, 0x0e, 0xdd, 0x24,
-	0xf5, 0x43, 0x9a, 0x4e, 0xdc, 0xc7, 0x6c, 0x92, 0xef, 0x83, 0x1d, 0x2d, 0x7b, 0xc8, 0x26, 0xfc,
-	0x59, 0xcd, 0x93, 0x1d, 0xc1, 0xc6, 0x87, 0x31, 0xf5, 0x76, 0x69, 0x40, 0xa3, 0x11, 0xd3, 0x09,
-	0x78, 0x81, 0x9b, 0xc8, 0x4d, 0x80, 0x4a, 0x8e, 0x6b, 0xe8, 0x50, 0x45, 0x62, 0xff, 0xc7, 0x80,
-	0xb6, 0xfc, 0x20, 0xde, 0x27, 0xae, 0x98, 0xd3, 0xa2, 0x55, 0xac, 0xcd, 0xb6, 0x8a, 0x37, 0xa0,
-	0xbc, 0x12, 0xe8, 0xac, 0x56, 0xee, 0x08, 0x95, 0x5e, 0xbf, 0x3e, 0xdd, 0xeb, 0xdf, 0x82, 0x8e,
-	0x2f, 0x1d, 0x72, 0x13, 0x2a, 0x4e, 0xd4, 0x46, 0xd8, 0x76, 0x00, 0x45, 0x87, 0x52, 0x22, 0x2f,
-	0x03, 0xb9, 0x01, 0x5e, 0x06, 0x16, 0x2e, 0x7d, 0x19, 0xd0, 0x8b, 0xe0, 0x65, 0xe0, 0xef, 0x35,
-	0xb0, 0x34, 0xc4, 0xe5, 0x7b, 0xe8, 0x47, 0x89, 0x87, 0xcf, 0xb2, 0x37, 0xa0, 0x5d, 0xf0, 0x5f,
-	0x3f, 0x47, 0x96, 0x02, 0x89, 0xeb, 0x3e, 0x0b, 0xe3, 0x74, 0x72, 0xe4, 0x7f, 0xca, 0x74, 0xe0,
-	0x15, 0x89, 0x8c, 0xed, 0x20, 0x0b, 0x9d, 0xf8, 0x09, 0xd7, 0xc7, 0x40, 0x3e, 0x94, 0xb1, 0x8d,
-	0xf0, 0x0a, 0x87, 0xfb, 0x26, 0x46, 0x5e, 0x77, 0x40, 0x89, 0xe4, 0x7e, 0x49, 0xd6, 0xa1, 0xc5,
-	0x22, 0x4f, 0x69, 0x1b, 0xa8, 0x6d, 0xb2, 0xc8, 0x43, 0xd5, 0x00, 0x96, 0xf4, 0x3b, 0x68, 0xcc,
-	0x91, 0x74, 0x48, 0xe2, 0xce, 0x8e, 0x7d, 0xce, 0xe3, 0xf3, 0x3e, 0x1f, 0x1f, 0x6a, 0x4b, 0x67,
-	0x51, 0x3d, 0x85, 0xea, 0x21, 0xf9, 0x00, 0xba, 0xf2, 0x2b, 0xc5, 0x42, 0xcd, 0x4b, 0x2f, 0xd4,
-	0x61, 0x91, 0x97, 0x0f, 0xec, 0xdf, 0x1a, 0xb0, 0x72, 0x06, 0xc2, 0x2b, 0xf0, 0xe8, 0x21, 0xb4,
-	0x8e, 0xd8, 0x58, 0x2e, 0x91, 0xbf, 0xee, 0x6e, 0x9f, 0xf7, 0x67, 0xc1, 0x39, 0x09, 0x73, 0x8a,
-	0x05, 0xec, 0x9f, 0x1b, 0x00, 0x48, 0x68, 0x1c, 0x9e, 0x21, 0x8b, 0x71, 0x15, 0xb2, 0xc8, 0x93,
-	0x57, 0xb6, 0x23, 0x29, 0x0b, 0xa8, 0x28, 0x77, 0x4e, 0xae, 0x73, 0x4f, 0xa2, 0x2c, 0x74, 0x94,
-	0x2a, 0x2f, 0x5a, 0xfb, 0xd7, 0x06, 0x00, 0x6e, 0xfd, 0xca, 0x8d, 0xd9, 0x3d, 0xc6, 0xb8, 0xf8,
-	0xfa, 0x5b, 0x9b, 0x2e, 0x89, 0xdd, 0xbc, 0x24, 0x38, 0x62, 0x64, 0xce, 0x8b, 0xa1, 0xc0, 0xa8,
-	0x0c, 0x5e, 0x57, 0x8d, 0xc2, 0xe5, 0x77, 0x06, 0x74, 0x2b, 0xf0, 0xf1, 0xe9, 0xea, 0x35, 0x66,
-	0xab, 0x17, 0x1b, 0x55, 0xc9, 0x68, 0x97, 0x57, 0x48, 0x1e, 0x96, 0x24, 0x5f, 0x87, 0x16, 0x42,
-	0x52, 0x61, 0x79, 0xa4, 0x59, 0x7e, 0x1b, 0x56, 0x52, 0x36, 0x62, 0x91, 0x08, 0x26, 0x6e, 0x18,
-	0x7b, 0xfe, 0xb1, 0xcf, 0x3c, 0xe4, 0x7a, 0xcb, 0xe9, 0xe5, 0x8a, 0x7d, 0x2d, 0xb7, 0xff, 0x69,
-	0xc0, 0x92, 0xec, 0x6d, 0x27, 0x07, 0xb1, 0xc7, 0x94, 0x67, 0xcf, 0xcf, 0xa0, 0xf7, 0x31, 0x16,
-	0x0d, 0x8f, 0xa2, 0xd0, 0x1b, 0xcf, 0xa6, 0x10, 0x77, 0x5a, 0x5c, 0xd3, 0x46, 0x42, 0xac, 0x9e,
-	0x34, 0x2e, 0x03, 0x71, 0x99, 0x58, 0x7d, 0xa8, 0x2b, 0x88, 0x7f, 0x6a, 0x40, 0xa7, 0x52, 0x2c,
-	0xf2, 0x48, 0xd0, 0x07, 0xb1, 0x3a, 0x91, 0x0c, 0xdc, 0x04, 0x3b, 0xa3, 0xf2, 0xb9, 0x99, 0xac,
-	0x41, 0x23, 0xe4, 0x63, 0x9d, 0xf1, 0xae, 0xa3, 0x06, 0x64, 0x03, 0x5a, 0x21, 0x1f, 0xe3, 0xcd,
-	0x4f, 0xef, 0x9c, 0xc5, 0x58, 0xa6, 0xad, 0xec, 0xb9, 0xd4, 0x06, 0x52, 0x0a, 0xec, 0x3f, 0x1b,
-	0x40, 0x74, 0x4b, 0xf3, 0x42, 0xff, 0x49, 0x20, 0x61, 0xab, 0x4f, 0xe6, 0x35, 0xdc, 0x86, 0xa7,
-	0x64, 0x33, 0xe7, 0x99, 0x79, 0xe6, 0x31, 0xe0, 0x36, 0xac, 0x78, 0xec, 0x98, 0xca, 0xee, 0x6b,
-	0xd6, 0xe5, 0x9e, 0x56, 0x14, 0x4d, 0xe2, 0x5b, 0xef, 0x41, 0xbb, 0xf8, 0x2b, 0x90, 0xf4, 0xa0,
-	0x3b, 0x88, 0x7c, 0x81, 0xed, 0xac, 0x1f, 0x8d, 0x7b, 0x5f, 0x22, 0x1d, 0x68, 0x7e, 0x9f, 0xd1,
-	0x40, 0x9c, 0x4c, 0x7a, 0x06, 0xe9, 0x42, 0xeb, 0xfe, 0x30, 0x8a, 0xd3, 0x90, 0x06, 0xbd, 0xda,
-	0xee, 0xbb, 0x3f, 0xfe, 0xe6, 0xd8, 0x17, 0x27, 0xd9, 0x50, 0x46, 0xb2, 0xad, 0x42, 0xfb, 0xba,
-	0x1f, 0xeb, 0x5f, 0xdb, 0x79, 0xd6, 0xb6, 0x31, 0xda, 0x62, 0x98, 0x0c, 0x87, 0x0b, 0x28, 0x79,
-	0xe7, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x84, 0x42, 0xe6, 0x5f, 0x30, 0x1d, 0x00, 0x00,
+	// 2107 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x5b, 0x93, 0x1b, 0x47,
+	0xf5, 0xff, 0x8f, 0x46, 0x5a, 0x49, 0x67, 0xb4, 0xb2, 0xb6, 0xbd, 0x76, 0xc6, 0x97, 0xd8, 0xf2,
+	0x24, 0x7f, 0x58, 0xe2, 0xc2, 0x76, 0x36, 0x40, 0x52, 0x14, 0x85, 0xe3, 0x5d, 0x05, 0x47, 0xe5,
+	0xec, 0xb2, 0x8c, 0x9c, 0x54, 0xc1, 0xcb, 0x54, 0x6b, 0xa6, 0x57, 0x1a, 0x3c, 0xb7, 0x4c, 0xf7,
+	0xec, 0xae, 0xf2, 0xc4, 0x03, 0x4f, 0x50, 0xf0, 0x40, 0x15, 0x55, 0xbc, 0xc0, 0x47, 0xe0, 0x95,
+	0x27, 0x2e, 0xc5, 0x13, 0xdf, 0x00, 0xf8, 0x00, 0x7c, 0x09, 0x9e, 0xa8, 0xbe, 0xcc, 0x45, 0x5a,
+	0x69, 0xbd, 0x5e, 0x57, 0x88, 0xa9, 0xca, 0xdb, 0xf4, 0x39, 0xa7, 0x2f, 0xe7, 0x77, 0x7e, 0x7d,
+	0xfa, 0x74, 0x0f, 0x74, 0xfd, 0x88, 0x91, 0x34, 0xc2, 0xc1, 0xbd, 0x24, 0x8d, 0x59, 0x8c, 0xae,
+	0x84, 0x7e, 0x70, 0x94, 0x51, 0xd9, 0xba, 0x97, 0x2b, 0xaf, 0x77, 0xdc, 0x38, 0x0c, 0xe3, 0x48,
+	0x8a, 0xaf, 0x77, 0xa8, 0x3b, 0x25, 0x21, 0x96, 0x2d, 0xeb, 0x4f, 0x1a, 0xac, 0xef, 0xc6, 0x61,
+	0x12, 0x47, 0x24, 0x62, 0xc3, 0xe8, 0x30, 0x46, 0x57, 0x61, 0x2d, 0x8a, 0x3d, 0x32, 0x1c, 0x98,
+	0x5a, 0x5f, 0xdb, 0xd2, 0x6d, 0xd5, 0x42, 0x08, 0xea, 0x69, 0x1c, 0x10, 0xb3, 0xd6, 0xd7, 0xb6,
+	0xda, 0xb6, 0xf8, 0x46, 0x0f, 0x01, 0x28, 0xc3, 0x8c, 0x38, 0x6e, 0xec, 0x11, 0x53, 0xef, 0x6b,
+	0x5b, 0xdd, 0xed, 0xfe, 0xbd, 0xa5, 0xab, 0xb8, 0x37, 0xe2, 0x86, 0xbb, 0xb1, 0x47, 0xec, 0x36,
+	0xcd, 0x3f, 0xd1, 0xfb, 0x00, 0xe4, 0x84, 0xa5, 0xd8, 0xf1, 0xa3, 0xc3, 0xd8, 0xac, 0xf7, 0xf5,
+	0x2d, 0x63, 0xfb, 0xce, 0xfc, 0x00, 0x6a, 0xf1, 0x4f, 0xc8, 0xec, 0x13, 0x1c, 0x64, 0xe4, 0x00,
+	0xfb, 0xa9, 0xdd, 0x16, 0x9d, 0xf8, 0x72, 0xad, 0x7f, 0x6a, 0x70, 0xa9, 0x70, 0x40, 0xcc, 0x41,
+	0xd1, 0xb7, 0xa1, 0x21, 0xa6, 0x10, 0x1e, 0x18, 0xdb, 0x6f, 0xae, 0x58, 0xd1, 0x9c, 0xdf, 0xb6,
+	0xec, 0x82, 0x3e, 0x86, 0xcb, 0x34, 0x1b, 0xbb, 0xb9, 0xca, 0x11, 0x52, 0x6a, 0xd6, 0xfa, 0xfa,
+	0xb9, 0x47, 0x42, 0xd5, 0x01, 0xd4, 0x92, 0xde, 0x81, 0x35, 0x3e, 0x52, 0x46, 0x05, 0x4a, 0xc6,
+	0xf6, 0x8d, 0xa5, 0x4e, 0x8e, 0x84, 0x89, 0xad, 0x4c, 0xad, 0x1b, 0x70, 0xed, 0x31, 0x61, 0x0b,
+	0xde, 0xd9, 0xe4, 0xd3, 0x8c, 0x50, 0xa6, 0x94, 0x4f, 0xfd, 0x90, 0x3c, 0xf5, 0xdd, 0x67, 0xbb,
+	0x53, 0x1c, 0x45, 0x24, 0xc8, 0x95, 0xaf, 0xc3, 0x8d, 0xc7, 0x44, 0x74, 0xf0, 0x29, 0xf3, 0x5d,
+	0xba, 0xa0, 0xbe, 0x02, 0x97, 0x1f, 0x13, 0x36, 0xf0, 0x16, 0xc4, 0x9f, 0x40, 0x6b, 0x9f, 0x07,
+	0x9b, 0xd3, 0xe0, 0x5b, 0xd0, 0xc4, 0x9e, 0x97, 0x12, 0x4a, 0x15, 0x8a, 0x37, 0x97, 0xae, 0xf8,
+	0x91, 0xb4, 0xb1, 0x73, 0xe3, 0x65, 0x34, 0xb1, 0x7e, 0x0c, 0x30, 0x8c, 0x7c, 0x76, 0x80, 0x53,
+	0x1c, 0xd2, 0x95, 0x04, 0x1b, 0x40, 0x87, 0x32, 0x9c, 0x32, 0x27, 0x11, 0x76, 0x66, 0xed, 0xbc,
+	0x6c, 0x30, 0x44, 0x37, 0x39, 0xba, 0xf5, 0x43, 0x80, 0x11, 0x4b, 0xfd, 0x68, 0xf2, 0x91, 0x4f,
+	0x19, 0x9f, 0xeb, 0x88, 0xdb, 0x71, 0x27, 0xf4, 0xad, 0xb6, 0xad, 0x5a, 0x95, 0x70, 0xd4, 0xce,
+	0x1f, 0x8e, 0x87, 0x60, 0xe4, 0x70, 0xef, 0xd1, 0x09, 0x7a, 0x00, 0xf5, 0x31, 0xa6, 0xe4, 0x4c,
+	0x78, 0xf6, 0xe8, 0x64, 0x07, 0x53, 0x62, 0x0b, 0x4b, 0xeb, 0x67, 0x3a, 0xbc, 0xb6, 0x9b, 0x12,
+	0x41, 0xfe, 0x20, 0x20, 0x2e, 0xf3, 0xe3, 0x48, 0x61, 0xff, 0xe2, 0xa3, 0xa1, 0xd7, 0xa0, 0xe9,
+	0x8d, 0x9d, 0x08, 0x87, 0x39, 0xd8, 0x6b, 0xde, 0x78, 0x1f, 0x87, 0x04, 0x7d, 0x05, 0xba, 0x6e,
+	0x31, 0x3e, 0x97, 0x08, 0xce, 0xb5, 0xed, 0x05, 0x29, 0x7a, 0x13, 0xd6, 0x13, 0x9c, 0x32, 0xbf,
+	0x30, 0xab, 0x0b, 0xb3, 0x79, 0x21, 0x0f, 0xa8, 0x37, 0x1e, 0x0e, 0xcc, 0x86, 0x08, 0x96, 0xf8,
+	0x46, 0x16, 0x74, 0xca, 0xb1, 0x86, 0x03, 0x73, 0x4d, 0xe8, 0xe6, 0x64, 0xa8, 0x0f, 0x46, 0x31,
+	0xd0, 0x70, 0x60, 0x36, 0x85, 0x49, 0x55, 0xc4, 0x83, 0x23, 0x73, 0x91, 0xd9, 0xea, 0x6b, 0x5b,
+	0x1d, 0x5b, 0xb5, 0xd0, 0x03, 0xb8, 0x7c, 0xe4, 0xa7, 0x2c, 0xc3, 0x81, 0xe2, 0x27, 0x5f, 0x07,
+	0x35, 0xdb, 0x22, 0x82, 0xcb, 0x54, 0x68, 0x1b, 0x36, 0x93, 0xe9, 0x8c, 0xfa, 0xee, 0x42, 0x17,
+	0x10, 0x5d, 0x96, 0xea, 0xac, 0xbf, 0x6a, 0x70, 0x65, 0x90, 0xc6, 0xc9, 0x2b, 0x11, 0x8a, 0x1c,
+	0xe4, 0xfa, 0x19, 0x20, 0x37, 0x4e, 0x83, 0x6c, 0xfd, 0xa2, 0x06, 0x57, 0x25, 0xa3, 0x0e, 0x72,
+	0x60, 0x3f, 0x07, 0x2f, 0xbe, 0x0a, 0x97, 0xca, 0x59, 0x9d, 0x68, 0xb5, 0x1b, 0xff, 0x0f, 0xdd,
+	0x22, 0xc0, 0xd2, 0xee, 0xbf, 0x4b, 0x29, 0xeb, 0xe7, 0x35, 0xd8, 0xe4, 0x41, 0xfd, 0x12, 0x0d,
+	0x8e, 0xc6, 0xef, 0x34, 0x40, 0x92, 0x1d, 0x8f, 0x02, 0x1f, 0xd3, 0x2f, 0x12, 0x8b, 0x4d, 0x68,
+	0x60, 0xbe, 0x06, 0x05, 0x81, 0x6c, 0x58, 0x14, 0x7a, 0x3c, 0x5a, 0x9f, 0xd7, 0xea, 0x8a, 0x49,
+	0xf5, 0xea, 0xa4, 0xbf, 0xd5, 0x60, 0xe3, 0x51, 0xc0, 0x48, 0xfa, 0x8a, 0x82, 0xf2, 0xe7, 0x5a,
+	0x1e, 0xb5, 0x61, 0xe4, 0x91, 0x93, 0x2f, 0x72, 0x81, 0xaf, 0x03, 0x1c, 0xfa, 0x24, 0xf0, 0xaa,
+	0xec, 0x6d, 0x0b, 0xc9, 0x4b, 0x31, 0xd7, 0x84, 0xa6, 0x18, 0xa4, 0x60, 0x6d, 0xde, 0xe4, 0x35,
+	0x80, 0xac, 0x07, 0x55, 0x0d, 0xd0, 0x3a, 0x77, 0x0d, 0x20, 0xba, 0xa9, 0x1a, 0xe0, 0xf7, 0x3a,
+	0xac, 0x0f, 0x23, 0x4a, 0x52, 0x76, 0x71, 0xf0, 0x6e, 0x42, 0x9b, 0x4e, 0x71, 0xea, 0xed, 0x97,
+	0xf0, 0x95, 0x82, 0x2a, 0xb4, 0xfa, 0xf3, 0xa0, 0xad, 0x9f, 0x33, 0x39, 0x34, 0xce, 0x4a, 0x0e,
+	0x6b, 0x67, 0x40, 0xdc, 0x7c, 0x7e, 0x72, 0x68, 0x9d, 0x3e, 0x7d, 0xb9, 0x83, 0x64, 0x12, 0xf2,
+	0xa2, 0x75, 0x60, 0xb6, 0x85, 0xbe, 0x14, 0xa0, 0x5b, 0x00, 0xcc, 0x0f, 0x09, 0x65, 0x38, 0x4c,
+	0xe4, 0x39, 0x5a, 0xb7, 0x2b, 0x12, 0x7e, 0x76, 0xa7, 0xf1, 0xf1, 0x70, 0x40, 0x4d, 0xa3, 0xaf,
+	0xf3, 0x22, 0x4e, 0xb6, 0xd0, 0x37, 0xa0, 0x95, 0xc6, 0xc7, 0x8e, 0x87, 0x19, 0x36, 0x3b, 0x22,
+	0x78, 0xd7, 0x96, 0x82, 0xbd, 0x13, 0xc4, 0x63, 0xbb, 0x99, 0xc6, 0xc7, 0x03, 0xcc, 0xb0, 0xf5,
+	0x8f, 0x3a, 0xac, 0x8f, 0x08, 0x4e, 0xdd, 0xe9, 0xc5, 0x03, 0xf6, 0x35, 0xe8, 0xa5, 0x84, 0x66,
+	0x01, 0x73, 0x5c, 0x79, 0xcc, 0x0f, 0x07, 0x2a, 0x6e, 0x97, 0xa4, 0x7c, 0x37, 0x17, 0x17, 0xa0,
+	0xea, 0x67, 0x80, 0x5a, 0x5f, 0x02, 0xaa, 0x05, 0x9d, 0x0a, 0x82, 0xd4, 0x6c, 0x08, 0xd7, 0xe7,
+	0x64, 0xa8, 0x07, 0xba, 0x47, 0x03, 0x11, 0xaf, 0xb6, 0xcd, 0x3f, 0xd1, 0x5d, 0xd8, 0x48, 0x02,
+	0xec, 0x92, 0x69, 0x1c, 0x78, 0x24, 0x75, 0x26, 0x69, 0x9c, 0x25, 0x22, 0x66, 0x1d, 0xbb, 0x57,
+	0x51, 0x3c, 0xe6, 0x72, 0xf4, 0x2e, 0xb4, 0x3c, 0x1a, 0x38, 0x6c, 0x96, 0x10, 0x11, 0xb4, 0xee,
+	0x0a, 0xdf, 0x07, 0x34, 0x78, 0x3a, 0x4b, 0x88, 0xdd, 0xf4, 0xe4, 0x07, 0x7a, 0x00, 0x9b, 0x94,
+	0xa4, 0x3e, 0x0e, 0xfc, 0xcf, 0x88, 0xe7, 0x90, 0x93, 0x24, 0x75, 0x92, 0x00, 0x47, 0x22, 0xb2,
+	0x1d, 0x1b, 0x95, 0xba, 0x0f, 0x4e, 0x92, 0xf4, 0x20, 0xc0, 0x11, 0xda, 0x82, 0x5e, 0x9c, 0xb1,
+	0x24, 0x63, 0x8e, 0xd8, 0x7d, 0xd4, 0xf1, 0x3d, 0x11, 0x68, 0xdd, 0xee, 0x4a, 0xf9, 0xf7, 0x84,
+	0x78, 0xe8, 0x71, 0x68, 0x59, 0x8a, 0x8f, 0x48, 0xe0, 0x14, 0x0c, 0x30, 0x8d, 0xbe, 0xb6, 0x55,
+	0xb7, 0x2f, 0x49, 0xf9, 0xd3, 0x5c, 0x8c, 0xee, 0xc3, 0xe5, 0x49, 0x86, 0x53, 0x1c, 0x31, 0x42,
+	0x2a, 0xd6, 0x1d, 0x61, 0x8d, 0x0a, 0x55, 0xd9, 0xe1, 0x6d, 0xb8, 0x42, 0x45, 0xe4, 0x9d, 0xf1,
+	0x6c, 0x38, 0xa8, 0x2c, 0x7c, 0x3d, 0x5f, 0x38, 0x57, 0xee, 0xcc, 0x86, 0x83, 0x62, 0xe1, 0x77,
+	0x61, 0x83, 0x8f, 0x1c, 0x67, 0xac, 0x32, 0x43, 0x57, 0xcc, 0xd0, 0x53, 0x8a, 0x62, 0x7c, 0xeb,
+	0xef, 0x15, 0x6a, 0x71, 0x16, 0xd0, 0x0b, 0x50, 0xeb, 0x22, 0xb7, 0x85, 0xa5, 0x7c, 0xd4, 0x97,
+	0xf3, 0xf1, 0x36, 0x18, 0x21, 0x61, 0xa9, 0xef, 0xca, 0xb8, 0xcb, 0x84, 0x01, 0x52, 0x24, 0x82,
+	0x7b, 0x1b, 0x8c, 0x28, 0x0b, 0x9d, 0x4f, 0x33, 0x92, 0xfa, 0x84, 0xaa, 0x7c, 0x0b, 0x51, 0x16,
+	0xfe, 0x40, 0x4a, 0xd0, 0x65, 0x68, 0xb0, 0x38, 0x71, 0x9e, 0xe5, 0x79, 0x82, 0xc5, 0xc9, 0x13,
+	0xf4, 0x1d, 0xb8, 0x4e, 0x09, 0x0e, 0x88, 0xe7, 0x14, 0xfb, 0x9a, 0x3a, 0x12, 0x4f, 0xe2, 0x99,
+	0x4d, 0x11, 0x6a, 0x53, 0x5a, 0x8c, 0x0a, 0x83, 0x91, 0xd2, 0xf3, 0x48, 0x16, 0x0b, 0xaf, 0x74,
+	0x6b, 0x89, 0x92, 0x1a, 0x95, 0xaa, 0xa2, 0xc3, 0x7b, 0x60, 0x4e, 0x82, 0x78, 0x8c, 0x03, 0xe7,
+	0xd4, 0xac, 0xa2, 0x76, 0xd7, 0xed, 0xab, 0x52, 0x3f, 0x5a, 0x98, 0x92, 0xbb, 0x47, 0x03, 0xdf,
+	0x25, 0x9e, 0x33, 0x0e, 0xe2, 0xb1, 0x09, 0x22, 0xf2, 0x20, 0x45, 0x3c, 0x51, 0x70, 0xaa, 0x2a,
+	0x03, 0x0e, 0x83, 0x1b, 0x67, 0x11, 0x13, 0x04, 0xd4, 0xed, 0xae, 0x94, 0xef, 0x67, 0xe1, 0x2e,
+	0x97, 0xa2, 0x37, 0x60, 0x5d, 0x59, 0xc6, 0x87, 0x87, 0x94, 0x30, 0xc1, 0x3c, 0xdd, 0xee, 0x48,
+	0xe1, 0xf7, 0x85, 0x0c, 0xdd, 0x81, 0x4e, 0x1a, 0x1f, 0x53, 0x87, 0xba, 0xdc, 0x09, 0x4f, 0x50,
+	0x4d, 0xb7, 0x0d, 0x2e, 0x1b, 0x49, 0x11, 0x37, 0x51, 0xcb, 0xa7, 0xce, 0xd4, 0x67, 0x82, 0x5e,
+	0xba, 0x6d, 0xe4, 0xb2, 0x0f, 0x7d, 0x66, 0xfd, 0x46, 0x87, 0x4b, 0x36, 0x8f, 0x11, 0x39, 0x22,
+	0xff, 0xf3, 0x69, 0x6b, 0x55, 0xfa, 0x58, 0x7b, 0xa1, 0xf4, 0xd1, 0x3c, 0x77, 0xfa, 0x68, 0xbd,
+	0x50, 0xfa, 0x68, 0xaf, 0x4c, 0x1f, 0x9b, 0xd0, 0x08, 0xfc, 0xd0, 0x67, 0x82, 0x34, 0xba, 0x2d,
+	0x1b, 0xd6, 0x1f, 0xe7, 0x42, 0xf3, 0xaa, 0x6e, 0xfb, 0xb7, 0x40, 0xf7, 0x3d, 0x59, 0xfc, 0x19,
+	0xdb, 0xe6, 0xfc, 0xe0, 0xea, 0x91, 0x6e, 0x38, 0xa0, 0x36, 0x37, 0x42, 0x0f, 0xc1, 0x50, 0x30,
+	0x8b, 0xa3, 0xb5, 0x21, 0x8e, 0xd6, 0x5b, 0x4b, 0xfb, 0x08, 0xdc, 0xf9, 0xb1, 0x6a, 0xcb, 0xe2,
+	0x8d, 0xf2, 0x6f, 0xf4, 0x5d, 0xb8, 0x71, 0x3a, 0x19, 0xa4, 0x0a, 0x23, 0xcf, 0x5c, 0x13, 0x91,
+	0xbb, 0xb6, 0x98, 0x0d, 0x72, 0x10, 0x3d, 0xf4, 0x36, 0x6c, 0x56, 0xd2, 0x41, 0xd9, 0xb1, 0x29,
+	0x6f, 0xe5, 0xa5, 0xae, 0xec, 0x72, 0x56, 0x42, 0x68, 0x9d, 0x95, 0x10, 0xac, 0x7f, 0xd5, 0x60,
+	0x7d, 0x40, 0x02, 0xc2, 0xc8, 0x97, 0x05, 0xdc, 0xca, 0x02, 0xee, 0x0e, 0x74, 0x92, 0xd4, 0x0f,
+	0x71, 0x3a, 0x73, 0x9e, 0x91, 0x59, 0x9e, 0x63, 0x0d, 0x25, 0x7b, 0x42, 0x66, 0xf4, 0x79, 0x55,
+	0x9c, 0x15, 0xc1, 0xf5, 0x8f, 0x62, 0xec, 0xed, 0xe0, 0x00, 0x47, 0x2e, 0x51, 0x01, 0x78, 0x89,
+	0x2b, 0xd1, 0x2d, 0x80, 0x4a, 0x8c, 0x6b, 0x62, 0x41, 0x15, 0x89, 0xf5, 0x6f, 0x0d, 0xda, 0x7c,
+	0x42, 0x71, 0xb1, 0xb9, 0x60, 0x4c, 0x8b, 0x9a, 0xb5, 0xb6, 0x58, 0xb3, 0xde, 0x84, 0xf2, 0x6e,
+	0xa2, 0xa2, 0x5a, 0x0a, 0xaa, 0x97, 0x8e, 0xfa, 0xfc, 0xa5, 0xe3, 0x36, 0x18, 0x3e, 0x5f, 0x90,
+	0x93, 0x60, 0x36, 0x95, 0xe9, 0xb1, 0x6d, 0x83, 0x10, 0x1d, 0x70, 0x09, 0xbf, 0x95, 0xe4, 0x06,
+	0xe2, 0x56, 0xb2, 0x76, 0xee, 0x5b, 0x89, 0x1a, 0x44, 0xdc, 0x4a, 0xfe, 0x52, 0x03, 0x53, 0x41,
+	0x5c, 0x3e, 0xcc, 0x7e, 0x9c, 0x78, 0xe2, 0x7d, 0xf8, 0x26, 0xb4, 0x0b, 0xfe, 0xab, 0x77, 0xd1,
+	0x52, 0xc0, 0x71, 0xdd, 0x23, 0x61, 0x9c, 0xce, 0x46, 0xfe, 0x67, 0x44, 0x39, 0x5e, 0x91, 0x70,
+	0xdf, 0xf6, 0xb3, 0xd0, 0x8e, 0x8f, 0xa9, 0x3a, 0x1c, 0xf2, 0x26, 0xf7, 0xcd, 0x15, 0x77, 0x49,
+	0x91, 0x4d, 0x85, 0xe7, 0x75, 0x1b, 0xa4, 0x88, 0x67, 0x51, 0x74, 0x0d, 0x5a, 0x24, 0xf2, 0xa4,
+	0xb6, 0x21, 0xb4, 0x4d, 0x12, 0x79, 0x42, 0x35, 0x84, 0xae, 0x7a, 0x90, 0x8d, 0xa9, 0x20, 0x9d,
+	0x20, 0xb1, 0xb1, 0x6d, 0xad, 0x78, 0x05, 0xdf, 0xa3, 0x93, 0x03, 0x65, 0x69, 0xaf, 0xcb, 0x37,
+	0x59, 0xd5, 0x44, 0x1f, 0x40, 0x87, 0xcf, 0x52, 0x0c, 0xd4, 0x3c, 0xf7, 0x40, 0x06, 0x89, 0xbc,
+	0xbc, 0x61, 0xfd, 0x4a, 0x83, 0x8d, 0x53, 0x10, 0x5e, 0x80, 0x47, 0x4f, 0xa0, 0x35, 0x22, 0x13,
+	0x3e, 0x44, 0xfe, 0xcc, 0x7c, 0x7f, 0xd5, 0x5f, 0x8b, 0x15, 0x01, 0xb3, 0x8b, 0x01, 0xac, 0x9f,
+	0x6a, 0xfc, 0x79, 0xdb, 0x23, 0x27, 0xa2, 0x79, 0x8a, 0x2c, 0xda, 0x45, 0xc8, 0xc2, 0xcf, 0x63,
+	0x5e, 0xea, 0xa4, 0x24, 0xc0, 0xac, 0xcc, 0x9c, 0x54, 0xc5, 0x1e, 0x45, 0x59, 0x68, 0x4b, 0x55,
+	0xbe, 0x69, 0xad, 0x5f, 0x6a, 0x00, 0x22, 0xf5, 0xcb, 0x65, 0x2c, 0xe6, 0x18, 0xed, 0xec, 0x7b,
+	0x78, 0x6d, 0x7e, 0x4b, 0xec, 0xe4, 0x5b, 0x82, 0x0a, 0x8c, 0xf4, 0x65, 0x3e, 0x14, 0x18, 0x95,
+	0xce, 0xab, 0x5d, 0x23, 0x71, 0xf9, 0xb5, 0x06, 0x9d, 0x0a, 0x7c, 0x74, 0x7e, 0xf7, 0x6a, 0x8b,
+	0xbb, 0x57, 0x14, 0xc1, 0x9c, 0xd1, 0x0e, 0xad, 0x90, 0x3c, 0x2c, 0x49, 0x7e, 0x0d, 0x5a, 0x02,
+	0x92, 0x0a, 0xcb, 0x23, 0xc5, 0xf2, 0xbb, 0xb0, 0x91, 0x12, 0x97, 0x44, 0x2c, 0x98, 0x39, 0x61,
+	0xec, 0xf9, 0x87, 0x3e, 0xf1, 0x04, 0xd7, 0x5b, 0x76, 0x2f, 0x57, 0xec, 0x29, 0xb9, 0xf5, 0x37,
+	0x0d, 0xba, 0xbc, 0x6e, 0x9e, 0xf1, 0x7f, 0x1d, 0x72, 0x65, 0x2f, 0xce, 0xa0, 0xf7, 0x85, 0x2f,
+	0x0e, 0xad, 0x50, 0xe8, 0x8d, 0xe7, 0x53, 0x88, 0xda, 0x2d, 0xaa, 0x68, 0xc3, 0x21, 0x96, 0x6f,
+	0x2b, 0xe7, 0x81, 0xb8, 0x0c, 0xac, 0x3a, 0xd4, 0x25, 0xc4, 0x3f, 0xd1, 0xc0, 0xa8, 0x6c, 0x16,
+	0x7e, 0x24, 0xa8, 0x83, 0x58, 0x9e, 0x48, 0x9a, 0x48, 0x82, 0x86, 0x5b, 0xbe, 0x7b, 0xf3, 0x82,
+	0x29, 0xa4, 0x13, 0x15, 0xf1, 0x8e, 0x2d, 0x1b, 0xe8, 0x3a, 0xb4, 0x42, 0x3a, 0x11, 0x57, 0x50,
+	0x95, 0x39, 0x8b, 0x36, 0x0f, 0x5b, 0x59, 0x89, 0xc9, 0x04, 0x52, 0x0a, 0xac, 0x3f, 0xf0, 0x37,
+	0x46, 0x39, 0xfe, 0x4b, 0xfd, 0x1c, 0x11, 0x84, 0xad, 0xbe, 0xdd, 0xd7, 0x44, 0x1a, 0x9e, 0x93,
+	0x2d, 0x9c, 0x67, 0xfa, 0xa9, 0x57, 0x89, 0xbb, 0xb0, 0xe1, 0x91, 0x43, 0xcc, 0xab, 0xaf, 0xc5,
+	0x25, 0xf7, 0x94, 0xa2, 0x28, 0x1d, 0xdf, 0x7a, 0x0f, 0xda, 0xc5, 0x3f, 0x49, 0xd4, 0x83, 0x0e,
+	0xff, 0x45, 0x25, 0x8a, 0x5c, 0x3f, 0x9a, 0xf4, 0xfe, 0x0f, 0x19, 0xd0, 0xfc, 0x90, 0xe0, 0x80,
+	0x4d, 0x67, 0x3d, 0x0d, 0x75, 0xa0, 0xf5, 0x68, 0x1c, 0xc5, 0x69, 0x88, 0x83, 0x5e, 0x6d, 0xe7,
+	0xdd, 0x1f, 0x7d, 0x73, 0xe2, 0xb3, 0x69, 0x36, 0xe6, 0x9e, 0xdc, 0x97, 0xae, 0x7d, 0xdd, 0x8f,
+	0xd5, 0xd7, 0xfd, 0x3c, 0x6a, 0xf7, 0x85, 0xb7, 0x45, 0x33, 0x19, 0x8f, 0xd7, 0x84, 0xe4, 0x9d,
+	0xff, 0x0c, 0x00, 0xb1, 0x36, 0x87, 0x42, 0xb9, 0x1d, 0x00, 0x00,
 }
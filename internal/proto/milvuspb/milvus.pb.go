@@ -263,6 +263,70 @@ func (m *AlterAliasRequest) GetAlias() string {
 	}
 	return ""
 }
+// Alter the properties of a collection, an empty value removes the property
+type AlterCollectionRequest struct {
+	Base                 *commonpb.MsgBase        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbName               string                   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName       string                   `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Properties           []*commonpb.KeyValuePair `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *AlterCollectionRequest) Reset()         { *m = AlterCollectionRequest{} }
+func (m *AlterCollectionRequest) String() string { return proto.CompactTextString(m) }
+func (*AlterCollectionRequest) ProtoMessage()    {}
+func (*AlterCollectionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{3}
+}
+
+func (m *AlterCollectionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlterCollectionRequest.Unmarshal(m, b)
+}
+func (m *AlterCollectionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlterCollectionRequest.Marshal(b, m, deterministic)
+}
+func (m *AlterCollectionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlterCollectionRequest.Merge(m, src)
+}
+func (m *AlterCollectionRequest) XXX_Size() int {
+	return xxx_messageInfo_AlterCollectionRequest.Size(m)
+}
+func (m *AlterCollectionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlterCollectionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlterCollectionRequest proto.InternalMessageInfo
+
+func (m *AlterCollectionRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *AlterCollectionRequest) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *AlterCollectionRequest) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *AlterCollectionRequest) GetProperties() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 
 //*
 // Create collection in milvus
@@ -277,7 +341,9 @@ type CreateCollectionRequest struct {
 	Schema []byte `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
 	// Once set, no modification is allowed (Optional)
 	// https://github.com/milvus-io/milvus/issues/6690
-	ShardsNum            int32    `protobuf:"varint,5,opt,name=shards_num,json=shardsNum,proto3" json:"shards_num,omitempty"`
+	ShardsNum int32 `protobuf:"varint,5,opt,name=shards_num,json=shardsNum,proto3" json:"shards_num,omitempty"`
+	// The number of physical partitions of a collection with partition key (Optional)
+	NumPartitions        int64    `protobuf:"varint,6,opt,name=num_partitions,json=numPartitions,proto3" json:"num_partitions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -287,7 +353,7 @@ func (m *CreateCollectionRequest) Reset()         { *m = CreateCollectionRequest
 func (m *CreateCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateCollectionRequest) ProtoMessage()    {}
 func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{3}
+	return fileDescriptor_02345ba45cc0e303, []int{4}
 }
 
 func (m *CreateCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -343,6 +409,13 @@ func (m *CreateCollectionRequest) GetShardsNum() int32 {
 	return 0
 }
 
+func (m *CreateCollectionRequest) GetNumPartitions() int64 {
+	if m != nil {
+		return m.NumPartitions
+	}
+	return 0
+}
+
 //*
 // Drop collection in milvus, also will drop data in collection.
 type DropCollectionRequest struct {
@@ -361,7 +434,7 @@ func (m *DropCollectionRequest) Reset()         { *m = DropCollectionRequest{} }
 func (m *DropCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*DropCollectionRequest) ProtoMessage()    {}
 func (*DropCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{4}
+	return fileDescriptor_02345ba45cc0e303, []int{5}
 }
 
 func (m *DropCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -423,7 +496,7 @@ func (m *HasCollectionRequest) Reset()         { *m = HasCollectionRequest{} }
 func (m *HasCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*HasCollectionRequest) ProtoMessage()    {}
 func (*HasCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{5}
+	return fileDescriptor_02345ba45cc0e303, []int{6}
 }
 
 func (m *HasCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -484,7 +557,7 @@ func (m *BoolResponse) Reset()         { *m = BoolResponse{} }
 func (m *BoolResponse) String() string { return proto.CompactTextString(m) }
 func (*BoolResponse) ProtoMessage()    {}
 func (*BoolResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{6}
+	return fileDescriptor_02345ba45cc0e303, []int{7}
 }
 
 func (m *BoolResponse) XXX_Unmarshal(b []byte) error {
@@ -531,7 +604,7 @@ func (m *StringResponse) Reset()         { *m = StringResponse{} }
 func (m *StringResponse) String() string { return proto.CompactTextString(m) }
 func (*StringResponse) ProtoMessage()    {}
 func (*StringResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{7}
+	return fileDescriptor_02345ba45cc0e303, []int{8}
 }
 
 func (m *StringResponse) XXX_Unmarshal(b []byte) error {
@@ -588,7 +661,7 @@ func (m *DescribeCollectionRequest) Reset()         { *m = DescribeCollectionReq
 func (m *DescribeCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*DescribeCollectionRequest) ProtoMessage()    {}
 func (*DescribeCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{8}
+	return fileDescriptor_02345ba45cc0e303, []int{9}
 }
 
 func (m *DescribeCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -666,17 +739,19 @@ type DescribeCollectionResponse struct {
 	// The aliases of this collection
 	Aliases []string `protobuf:"bytes,9,rep,name=aliases,proto3" json:"aliases,omitempty"`
 	// The message ID/posititon when collection is created
-	StartPositions       []*commonpb.KeyDataPair `protobuf:"bytes,10,rep,name=start_positions,json=startPositions,proto3" json:"start_positions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	StartPositions []*commonpb.KeyDataPair `protobuf:"bytes,10,rep,name=start_positions,json=startPositions,proto3" json:"start_positions,omitempty"`
+	// The collection level properties, set by AlterCollection
+	Properties           []*commonpb.KeyValuePair `protobuf:"bytes,11,rep,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *DescribeCollectionResponse) Reset()         { *m = DescribeCollectionResponse{} }
 func (m *DescribeCollectionResponse) String() string { return proto.CompactTextString(m) }
 func (*DescribeCollectionResponse) ProtoMessage()    {}
 func (*DescribeCollectionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{9}
+	return fileDescriptor_02345ba45cc0e303, []int{10}
 }
 
 func (m *DescribeCollectionResponse) XXX_Unmarshal(b []byte) error {
@@ -767,6 +842,13 @@ func (m *DescribeCollectionResponse) GetStartPositions() []*commonpb.KeyDataPair
 	return nil
 }
 
+func (m *DescribeCollectionResponse) GetProperties() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 //*
 // Load collection data into query nodes, then you can do vector search on this collection.
 type LoadCollectionRequest struct {
@@ -775,17 +857,19 @@ type LoadCollectionRequest struct {
 	// Not useful for now
 	DbName string `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
 	// The collection name you want to load
-	CollectionName       string   `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CollectionName string `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// How to handle the segments whose index is not built yet
+	UnindexedSegmentPolicy commonpb.UnindexedSegmentPolicy `protobuf:"varint,4,opt,name=unindexed_segment_policy,json=unindexedSegmentPolicy,proto3,enum=milvus.proto.common.UnindexedSegmentPolicy" json:"unindexed_segment_policy,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                        `json:"-"`
+	XXX_unrecognized       []byte                          `json:"-"`
+	XXX_sizecache          int32                           `json:"-"`
 }
 
 func (m *LoadCollectionRequest) Reset()         { *m = LoadCollectionRequest{} }
 func (m *LoadCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadCollectionRequest) ProtoMessage()    {}
 func (*LoadCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{10}
+	return fileDescriptor_02345ba45cc0e303, []int{11}
 }
 
 func (m *LoadCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -827,6 +911,13 @@ func (m *LoadCollectionRequest) GetCollectionName() string {
 	return ""
 }
 
+func (m *LoadCollectionRequest) GetUnindexedSegmentPolicy() commonpb.UnindexedSegmentPolicy {
+	if m != nil {
+		return m.UnindexedSegmentPolicy
+	}
+	return commonpb.UnindexedSegmentPolicy_LoadUnindexed
+}
+
 //*
 // Release collection data from query nodes, then you can't do vector search on this collection.
 type ReleaseCollectionRequest struct {
@@ -845,7 +936,7 @@ func (m *ReleaseCollectionRequest) Reset()         { *m = ReleaseCollectionReque
 func (m *ReleaseCollectionRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseCollectionRequest) ProtoMessage()    {}
 func (*ReleaseCollectionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{11}
+	return fileDescriptor_02345ba45cc0e303, []int{12}
 }
 
 func (m *ReleaseCollectionRequest) XXX_Unmarshal(b []byte) error {
@@ -905,7 +996,7 @@ func (m *GetCollectionStatisticsRequest) Reset()         { *m = GetCollectionSta
 func (m *GetCollectionStatisticsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCollectionStatisticsRequest) ProtoMessage()    {}
 func (*GetCollectionStatisticsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{12}
+	return fileDescriptor_02345ba45cc0e303, []int{13}
 }
 
 func (m *GetCollectionStatisticsRequest) XXX_Unmarshal(b []byte) error {
@@ -963,7 +1054,7 @@ func (m *GetCollectionStatisticsResponse) Reset()         { *m = GetCollectionSt
 func (m *GetCollectionStatisticsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetCollectionStatisticsResponse) ProtoMessage()    {}
 func (*GetCollectionStatisticsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{13}
+	return fileDescriptor_02345ba45cc0e303, []int{14}
 }
 
 func (m *GetCollectionStatisticsResponse) XXX_Unmarshal(b []byte) error {
@@ -1020,7 +1111,7 @@ func (m *ShowCollectionsRequest) Reset()         { *m = ShowCollectionsRequest{}
 func (m *ShowCollectionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ShowCollectionsRequest) ProtoMessage()    {}
 func (*ShowCollectionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{14}
+	return fileDescriptor_02345ba45cc0e303, []int{15}
 }
 
 func (m *ShowCollectionsRequest) XXX_Unmarshal(b []byte) error {
@@ -1100,7 +1191,7 @@ func (m *ShowCollectionsResponse) Reset()         { *m = ShowCollectionsResponse
 func (m *ShowCollectionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ShowCollectionsResponse) ProtoMessage()    {}
 func (*ShowCollectionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{15}
+	return fileDescriptor_02345ba45cc0e303, []int{16}
 }
 
 func (m *ShowCollectionsResponse) XXX_Unmarshal(b []byte) error {
@@ -1183,7 +1274,7 @@ func (m *CreatePartitionRequest) Reset()         { *m = CreatePartitionRequest{}
 func (m *CreatePartitionRequest) String() string { return proto.CompactTextString(m) }
 func (*CreatePartitionRequest) ProtoMessage()    {}
 func (*CreatePartitionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{16}
+	return fileDescriptor_02345ba45cc0e303, []int{17}
 }
 
 func (m *CreatePartitionRequest) XXX_Unmarshal(b []byte) error {
@@ -1252,7 +1343,7 @@ func (m *DropPartitionRequest) Reset()         { *m = DropPartitionRequest{} }
 func (m *DropPartitionRequest) String() string { return proto.CompactTextString(m) }
 func (*DropPartitionRequest) ProtoMessage()    {}
 func (*DropPartitionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{17}
+	return fileDescriptor_02345ba45cc0e303, []int{18}
 }
 
 func (m *DropPartitionRequest) XXX_Unmarshal(b []byte) error {
@@ -1321,7 +1412,7 @@ func (m *HasPartitionRequest) Reset()         { *m = HasPartitionRequest{} }
 func (m *HasPartitionRequest) String() string { return proto.CompactTextString(m) }
 func (*HasPartitionRequest) ProtoMessage()    {}
 func (*HasPartitionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{18}
+	return fileDescriptor_02345ba45cc0e303, []int{19}
 }
 
 func (m *HasPartitionRequest) XXX_Unmarshal(b []byte) error {
@@ -1381,17 +1472,19 @@ type LoadPartitionsRequest struct {
 	// The collection name in milvus
 	CollectionName string `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
 	// The partition names you want to load
-	PartitionNames       []string `protobuf:"bytes,4,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PartitionNames []string `protobuf:"bytes,4,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
+	// How to handle the segments whose index is not built yet
+	UnindexedSegmentPolicy commonpb.UnindexedSegmentPolicy `protobuf:"varint,5,opt,name=unindexed_segment_policy,json=unindexedSegmentPolicy,proto3,enum=milvus.proto.common.UnindexedSegmentPolicy" json:"unindexed_segment_policy,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                        `json:"-"`
+	XXX_unrecognized       []byte                          `json:"-"`
+	XXX_sizecache          int32                           `json:"-"`
 }
 
 func (m *LoadPartitionsRequest) Reset()         { *m = LoadPartitionsRequest{} }
 func (m *LoadPartitionsRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadPartitionsRequest) ProtoMessage()    {}
 func (*LoadPartitionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{19}
+	return fileDescriptor_02345ba45cc0e303, []int{20}
 }
 
 func (m *LoadPartitionsRequest) XXX_Unmarshal(b []byte) error {
@@ -1440,6 +1533,13 @@ func (m *LoadPartitionsRequest) GetPartitionNames() []string {
 	return nil
 }
 
+func (m *LoadPartitionsRequest) GetUnindexedSegmentPolicy() commonpb.UnindexedSegmentPolicy {
+	if m != nil {
+		return m.UnindexedSegmentPolicy
+	}
+	return commonpb.UnindexedSegmentPolicy_LoadUnindexed
+}
+
 //
 // Release specific partitions data of one collection from query nodes.
 // Then you can not get these data as result when you do vector search on this collection.
@@ -1461,7 +1561,7 @@ func (m *ReleasePartitionsRequest) Reset()         { *m = ReleasePartitionsReque
 func (m *ReleasePartitionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleasePartitionsRequest) ProtoMessage()    {}
 func (*ReleasePartitionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{20}
+	return fileDescriptor_02345ba45cc0e303, []int{21}
 }
 
 func (m *ReleasePartitionsRequest) XXX_Unmarshal(b []byte) error {
@@ -1530,7 +1630,7 @@ func (m *GetPartitionStatisticsRequest) Reset()         { *m = GetPartitionStati
 func (m *GetPartitionStatisticsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetPartitionStatisticsRequest) ProtoMessage()    {}
 func (*GetPartitionStatisticsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{21}
+	return fileDescriptor_02345ba45cc0e303, []int{22}
 }
 
 func (m *GetPartitionStatisticsRequest) XXX_Unmarshal(b []byte) error {
@@ -1591,7 +1691,7 @@ func (m *GetPartitionStatisticsResponse) Reset()         { *m = GetPartitionStat
 func (m *GetPartitionStatisticsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPartitionStatisticsResponse) ProtoMessage()    {}
 func (*GetPartitionStatisticsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{22}
+	return fileDescriptor_02345ba45cc0e303, []int{23}
 }
 
 func (m *GetPartitionStatisticsResponse) XXX_Unmarshal(b []byte) error {
@@ -1650,7 +1750,7 @@ func (m *ShowPartitionsRequest) Reset()         { *m = ShowPartitionsRequest{} }
 func (m *ShowPartitionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ShowPartitionsRequest) ProtoMessage()    {}
 func (*ShowPartitionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{23}
+	return fileDescriptor_02345ba45cc0e303, []int{24}
 }
 
 func (m *ShowPartitionsRequest) XXX_Unmarshal(b []byte) error {
@@ -1738,7 +1838,7 @@ func (m *ShowPartitionsResponse) Reset()         { *m = ShowPartitionsResponse{}
 func (m *ShowPartitionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ShowPartitionsResponse) ProtoMessage()    {}
 func (*ShowPartitionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{24}
+	return fileDescriptor_02345ba45cc0e303, []int{25}
 }
 
 func (m *ShowPartitionsResponse) XXX_Unmarshal(b []byte) error {
@@ -1814,7 +1914,7 @@ func (m *DescribeSegmentRequest) Reset()         { *m = DescribeSegmentRequest{}
 func (m *DescribeSegmentRequest) String() string { return proto.CompactTextString(m) }
 func (*DescribeSegmentRequest) ProtoMessage()    {}
 func (*DescribeSegmentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{25}
+	return fileDescriptor_02345ba45cc0e303, []int{26}
 }
 
 func (m *DescribeSegmentRequest) XXX_Unmarshal(b []byte) error {
@@ -1870,7 +1970,7 @@ func (m *DescribeSegmentResponse) Reset()         { *m = DescribeSegmentResponse
 func (m *DescribeSegmentResponse) String() string { return proto.CompactTextString(m) }
 func (*DescribeSegmentResponse) ProtoMessage()    {}
 func (*DescribeSegmentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{26}
+	return fileDescriptor_02345ba45cc0e303, []int{27}
 }
 
 func (m *DescribeSegmentResponse) XXX_Unmarshal(b []byte) error {
@@ -1932,7 +2032,7 @@ func (m *ShowSegmentsRequest) Reset()         { *m = ShowSegmentsRequest{} }
 func (m *ShowSegmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ShowSegmentsRequest) ProtoMessage()    {}
 func (*ShowSegmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{27}
+	return fileDescriptor_02345ba45cc0e303, []int{28}
 }
 
 func (m *ShowSegmentsRequest) XXX_Unmarshal(b []byte) error {
@@ -1986,7 +2086,7 @@ func (m *ShowSegmentsResponse) Reset()         { *m = ShowSegmentsResponse{} }
 func (m *ShowSegmentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ShowSegmentsResponse) ProtoMessage()    {}
 func (*ShowSegmentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{28}
+	return fileDescriptor_02345ba45cc0e303, []int{29}
 }
 
 func (m *ShowSegmentsResponse) XXX_Unmarshal(b []byte) error {
@@ -2043,7 +2143,7 @@ func (m *CreateIndexRequest) Reset()         { *m = CreateIndexRequest{} }
 func (m *CreateIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateIndexRequest) ProtoMessage()    {}
 func (*CreateIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{29}
+	return fileDescriptor_02345ba45cc0e303, []int{30}
 }
 
 func (m *CreateIndexRequest) XXX_Unmarshal(b []byte) error {
@@ -2122,7 +2222,7 @@ func (m *DescribeIndexRequest) Reset()         { *m = DescribeIndexRequest{} }
 func (m *DescribeIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*DescribeIndexRequest) ProtoMessage()    {}
 func (*DescribeIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{30}
+	return fileDescriptor_02345ba45cc0e303, []int{31}
 }
 
 func (m *DescribeIndexRequest) XXX_Unmarshal(b []byte) error {
@@ -2198,7 +2298,7 @@ func (m *IndexDescription) Reset()         { *m = IndexDescription{} }
 func (m *IndexDescription) String() string { return proto.CompactTextString(m) }
 func (*IndexDescription) ProtoMessage()    {}
 func (*IndexDescription) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{31}
+	return fileDescriptor_02345ba45cc0e303, []int{32}
 }
 
 func (m *IndexDescription) XXX_Unmarshal(b []byte) error {
@@ -2263,7 +2363,7 @@ func (m *DescribeIndexResponse) Reset()         { *m = DescribeIndexResponse{} }
 func (m *DescribeIndexResponse) String() string { return proto.CompactTextString(m) }
 func (*DescribeIndexResponse) ProtoMessage()    {}
 func (*DescribeIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{32}
+	return fileDescriptor_02345ba45cc0e303, []int{33}
 }
 
 func (m *DescribeIndexResponse) XXX_Unmarshal(b []byte) error {
@@ -2313,7 +2413,7 @@ func (m *GetIndexBuildProgressRequest) Reset()         { *m = GetIndexBuildProgr
 func (m *GetIndexBuildProgressRequest) String() string { return proto.CompactTextString(m) }
 func (*GetIndexBuildProgressRequest) ProtoMessage()    {}
 func (*GetIndexBuildProgressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{33}
+	return fileDescriptor_02345ba45cc0e303, []int{34}
 }
 
 func (m *GetIndexBuildProgressRequest) XXX_Unmarshal(b []byte) error {
@@ -2382,7 +2482,7 @@ func (m *GetIndexBuildProgressResponse) Reset()         { *m = GetIndexBuildProg
 func (m *GetIndexBuildProgressResponse) String() string { return proto.CompactTextString(m) }
 func (*GetIndexBuildProgressResponse) ProtoMessage()    {}
 func (*GetIndexBuildProgressResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{34}
+	return fileDescriptor_02345ba45cc0e303, []int{35}
 }
 
 func (m *GetIndexBuildProgressResponse) XXX_Unmarshal(b []byte) error {
@@ -2439,7 +2539,7 @@ func (m *GetIndexStateRequest) Reset()         { *m = GetIndexStateRequest{} }
 func (m *GetIndexStateRequest) String() string { return proto.CompactTextString(m) }
 func (*GetIndexStateRequest) ProtoMessage()    {}
 func (*GetIndexStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{35}
+	return fileDescriptor_02345ba45cc0e303, []int{36}
 }
 
 func (m *GetIndexStateRequest) XXX_Unmarshal(b []byte) error {
@@ -2508,7 +2608,7 @@ func (m *GetIndexStateResponse) Reset()         { *m = GetIndexStateResponse{} }
 func (m *GetIndexStateResponse) String() string { return proto.CompactTextString(m) }
 func (*GetIndexStateResponse) ProtoMessage()    {}
 func (*GetIndexStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{36}
+	return fileDescriptor_02345ba45cc0e303, []int{37}
 }
 
 func (m *GetIndexStateResponse) XXX_Unmarshal(b []byte) error {
@@ -2565,7 +2665,7 @@ func (m *DropIndexRequest) Reset()         { *m = DropIndexRequest{} }
 func (m *DropIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*DropIndexRequest) ProtoMessage()    {}
 func (*DropIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{37}
+	return fileDescriptor_02345ba45cc0e303, []int{38}
 }
 
 func (m *DropIndexRequest) XXX_Unmarshal(b []byte) error {
@@ -2638,7 +2738,7 @@ func (m *InsertRequest) Reset()         { *m = InsertRequest{} }
 func (m *InsertRequest) String() string { return proto.CompactTextString(m) }
 func (*InsertRequest) ProtoMessage()    {}
 func (*InsertRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{38}
+	return fileDescriptor_02345ba45cc0e303, []int{39}
 }
 
 func (m *InsertRequest) XXX_Unmarshal(b []byte) error {
@@ -2727,7 +2827,7 @@ func (m *MutationResult) Reset()         { *m = MutationResult{} }
 func (m *MutationResult) String() string { return proto.CompactTextString(m) }
 func (*MutationResult) ProtoMessage()    {}
 func (*MutationResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{39}
+	return fileDescriptor_02345ba45cc0e303, []int{40}
 }
 
 func (m *MutationResult) XXX_Unmarshal(b []byte) error {
@@ -2827,7 +2927,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{40}
+	return fileDescriptor_02345ba45cc0e303, []int{41}
 }
 
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -2904,7 +3004,7 @@ func (m *PlaceholderValue) Reset()         { *m = PlaceholderValue{} }
 func (m *PlaceholderValue) String() string { return proto.CompactTextString(m) }
 func (*PlaceholderValue) ProtoMessage()    {}
 func (*PlaceholderValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{41}
+	return fileDescriptor_02345ba45cc0e303, []int{42}
 }
 
 func (m *PlaceholderValue) XXX_Unmarshal(b []byte) error {
@@ -2957,7 +3057,7 @@ func (m *PlaceholderGroup) Reset()         { *m = PlaceholderGroup{} }
 func (m *PlaceholderGroup) String() string { return proto.CompactTextString(m) }
 func (*PlaceholderGroup) ProtoMessage()    {}
 func (*PlaceholderGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{42}
+	return fileDescriptor_02345ba45cc0e303, []int{43}
 }
 
 func (m *PlaceholderGroup) XXX_Unmarshal(b []byte) error {
@@ -2992,23 +3092,24 @@ type SearchRequest struct {
 	PartitionNames []string          `protobuf:"bytes,4,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
 	Dsl            string            `protobuf:"bytes,5,opt,name=dsl,proto3" json:"dsl,omitempty"`
 	// serialized `PlaceholderGroup`
-	PlaceholderGroup     []byte                   `protobuf:"bytes,6,opt,name=placeholder_group,json=placeholderGroup,proto3" json:"placeholder_group,omitempty"`
-	DslType              commonpb.DslType         `protobuf:"varint,7,opt,name=dsl_type,json=dslType,proto3,enum=milvus.proto.common.DslType" json:"dsl_type,omitempty"`
-	OutputFields         []string                 `protobuf:"bytes,8,rep,name=output_fields,json=outputFields,proto3" json:"output_fields,omitempty"`
-	SearchParams         []*commonpb.KeyValuePair `protobuf:"bytes,9,rep,name=search_params,json=searchParams,proto3" json:"search_params,omitempty"`
-	TravelTimestamp      uint64                   `protobuf:"varint,10,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
-	GuaranteeTimestamp   uint64                   `protobuf:"varint,11,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
-	SearchIDs            *schemapb.IDs            `protobuf:"bytes,12,opt,name=searchIDs,proto3" json:"searchIDs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	PlaceholderGroup     []byte                    `protobuf:"bytes,6,opt,name=placeholder_group,json=placeholderGroup,proto3" json:"placeholder_group,omitempty"`
+	DslType              commonpb.DslType          `protobuf:"varint,7,opt,name=dsl_type,json=dslType,proto3,enum=milvus.proto.common.DslType" json:"dsl_type,omitempty"`
+	OutputFields         []string                  `protobuf:"bytes,8,rep,name=output_fields,json=outputFields,proto3" json:"output_fields,omitempty"`
+	SearchParams         []*commonpb.KeyValuePair  `protobuf:"bytes,9,rep,name=search_params,json=searchParams,proto3" json:"search_params,omitempty"`
+	TravelTimestamp      uint64                    `protobuf:"varint,10,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
+	GuaranteeTimestamp   uint64                    `protobuf:"varint,11,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
+	SearchIDs            *schemapb.IDs             `protobuf:"bytes,12,opt,name=searchIDs,proto3" json:"searchIDs,omitempty"`
+	ConsistencyLevel     commonpb.ConsistencyLevel `protobuf:"varint,13,opt,name=consistency_level,json=consistencyLevel,proto3,enum=milvus.proto.common.ConsistencyLevel" json:"consistency_level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
 func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
 func (*SearchRequest) ProtoMessage()    {}
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{43}
+	return fileDescriptor_02345ba45cc0e303, []int{44}
 }
 
 func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
@@ -3113,6 +3214,13 @@ func (m *SearchRequest) GetSearchIDs() *schemapb.IDs {
 	return nil
 }
 
+func (m *SearchRequest) GetConsistencyLevel() commonpb.ConsistencyLevel {
+	if m != nil {
+		return m.ConsistencyLevel
+	}
+	return commonpb.ConsistencyLevel_Strong
+}
+
 type Hits struct {
 	IDs                  []int64   `protobuf:"varint,1,rep,packed,name=IDs,proto3" json:"IDs,omitempty"`
 	RowData              [][]byte  `protobuf:"bytes,2,rep,name=row_data,json=rowData,proto3" json:"row_data,omitempty"`
@@ -3126,7 +3234,7 @@ func (m *Hits) Reset()         { *m = Hits{} }
 func (m *Hits) String() string { return proto.CompactTextString(m) }
 func (*Hits) ProtoMessage()    {}
 func (*Hits) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{44}
+	return fileDescriptor_02345ba45cc0e303, []int{45}
 }
 
 func (m *Hits) XXX_Unmarshal(b []byte) error {
@@ -3169,18 +3277,25 @@ func (m *Hits) GetScores() []float32 {
 }
 
 type SearchResults struct {
-	Status               *commonpb.Status           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Results              *schemapb.SearchResultData `protobuf:"bytes,2,opt,name=results,proto3" json:"results,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Status  *commonpb.Status           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Results *schemapb.SearchResultData `protobuf:"bytes,2,opt,name=results,proto3" json:"results,omitempty"`
+	// set if some shards didn't reply in time and the results are reduced without them,
+	// only happens if partial results are allowed by the search
+	PartialResult bool     `protobuf:"varint,3,opt,name=partial_result,json=partialResult,proto3" json:"partial_result,omitempty"`
+	MissingShards []string `protobuf:"bytes,4,rep,name=missing_shards,json=missingShards,proto3" json:"missing_shards,omitempty"`
+	// the search breakdown of the shards searched through their shard leaders,
+	// only returned if asked by the search param shard_stats
+	ShardStats           []*ShardSearchStats `protobuf:"bytes,5,rep,name=shard_stats,json=shardStats,proto3" json:"shard_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *SearchResults) Reset()         { *m = SearchResults{} }
 func (m *SearchResults) String() string { return proto.CompactTextString(m) }
 func (*SearchResults) ProtoMessage()    {}
 func (*SearchResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{45}
+	return fileDescriptor_02345ba45cc0e303, []int{46}
 }
 
 func (m *SearchResults) XXX_Unmarshal(b []byte) error {
@@ -3215,6 +3330,118 @@ func (m *SearchResults) GetResults() *schemapb.SearchResultData {
 	return nil
 }
 
+func (m *SearchResults) GetPartialResult() bool {
+	if m != nil {
+		return m.PartialResult
+	}
+	return false
+}
+
+func (m *SearchResults) GetMissingShards() []string {
+	if m != nil {
+		return m.MissingShards
+	}
+	return nil
+}
+
+func (m *SearchResults) GetShardStats() []*ShardSearchStats {
+	if m != nil {
+		return m.ShardStats
+	}
+	return nil
+}
+
+type ShardSearchStats struct {
+	ChannelName string `protobuf:"bytes,1,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
+	// the shard leader serving the search
+	NodeID int64 `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	// rows of the segments searched, including the ones searched on other query nodes
+	RowsScanned int64 `protobuf:"varint,3,opt,name=rows_scanned,json=rowsScanned,proto3" json:"rows_scanned,omitempty"`
+	SegmentsHit int64 `protobuf:"varint,4,opt,name=segments_hit,json=segmentsHit,proto3" json:"segments_hit,omitempty"`
+	// number of the reduced results coming from the shard
+	Hits int64 `protobuf:"varint,5,opt,name=hits,proto3" json:"hits,omitempty"`
+	// time spent by the shard leader, and the rest of the round trip of the proxy in microseconds
+	NodeLatencyUs        int64    `protobuf:"varint,6,opt,name=node_latency_us,json=nodeLatencyUs,proto3" json:"node_latency_us,omitempty"`
+	TransportLatencyUs   int64    `protobuf:"varint,7,opt,name=transport_latency_us,json=transportLatencyUs,proto3" json:"transport_latency_us,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShardSearchStats) Reset()         { *m = ShardSearchStats{} }
+func (m *ShardSearchStats) String() string { return proto.CompactTextString(m) }
+func (*ShardSearchStats) ProtoMessage()    {}
+func (*ShardSearchStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{47}
+}
+
+func (m *ShardSearchStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShardSearchStats.Unmarshal(m, b)
+}
+func (m *ShardSearchStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShardSearchStats.Marshal(b, m, deterministic)
+}
+func (m *ShardSearchStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardSearchStats.Merge(m, src)
+}
+func (m *ShardSearchStats) XXX_Size() int {
+	return xxx_messageInfo_ShardSearchStats.Size(m)
+}
+func (m *ShardSearchStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardSearchStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardSearchStats proto.InternalMessageInfo
+
+func (m *ShardSearchStats) GetChannelName() string {
+	if m != nil {
+		return m.ChannelName
+	}
+	return ""
+}
+
+func (m *ShardSearchStats) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *ShardSearchStats) GetRowsScanned() int64 {
+	if m != nil {
+		return m.RowsScanned
+	}
+	return 0
+}
+
+func (m *ShardSearchStats) GetSegmentsHit() int64 {
+	if m != nil {
+		return m.SegmentsHit
+	}
+	return 0
+}
+
+func (m *ShardSearchStats) GetHits() int64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *ShardSearchStats) GetNodeLatencyUs() int64 {
+	if m != nil {
+		return m.NodeLatencyUs
+	}
+	return 0
+}
+
+func (m *ShardSearchStats) GetTransportLatencyUs() int64 {
+	if m != nil {
+		return m.TransportLatencyUs
+	}
+	return 0
+}
+
 type FlushRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbName               string            `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
@@ -3228,7 +3455,7 @@ func (m *FlushRequest) Reset()         { *m = FlushRequest{} }
 func (m *FlushRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushRequest) ProtoMessage()    {}
 func (*FlushRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{46}
+	return fileDescriptor_02345ba45cc0e303, []int{48}
 }
 
 func (m *FlushRequest) XXX_Unmarshal(b []byte) error {
@@ -3283,7 +3510,7 @@ func (m *FlushResponse) Reset()         { *m = FlushResponse{} }
 func (m *FlushResponse) String() string { return proto.CompactTextString(m) }
 func (*FlushResponse) ProtoMessage()    {}
 func (*FlushResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{47}
+	return fileDescriptor_02345ba45cc0e303, []int{49}
 }
 
 func (m *FlushResponse) XXX_Unmarshal(b []byte) error {
@@ -3326,24 +3553,27 @@ func (m *FlushResponse) GetCollSegIDs() map[string]*schemapb.LongArray {
 }
 
 type QueryRequest struct {
-	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DbName               string            `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	CollectionName       string            `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Expr                 string            `protobuf:"bytes,4,opt,name=expr,proto3" json:"expr,omitempty"`
-	OutputFields         []string          `protobuf:"bytes,5,rep,name=output_fields,json=outputFields,proto3" json:"output_fields,omitempty"`
-	PartitionNames       []string          `protobuf:"bytes,6,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
-	TravelTimestamp      uint64            `protobuf:"varint,7,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
-	GuaranteeTimestamp   uint64            `protobuf:"varint,8,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Base                 *commonpb.MsgBase         `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbName               string                    `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName       string                    `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Expr                 string                    `protobuf:"bytes,4,opt,name=expr,proto3" json:"expr,omitempty"`
+	OutputFields         []string                  `protobuf:"bytes,5,rep,name=output_fields,json=outputFields,proto3" json:"output_fields,omitempty"`
+	PartitionNames       []string                  `protobuf:"bytes,6,rep,name=partition_names,json=partitionNames,proto3" json:"partition_names,omitempty"`
+	TravelTimestamp      uint64                    `protobuf:"varint,7,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
+	GuaranteeTimestamp   uint64                    `protobuf:"varint,8,opt,name=guarantee_timestamp,json=guaranteeTimestamp,proto3" json:"guarantee_timestamp,omitempty"`
+	ConsistencyLevel     commonpb.ConsistencyLevel `protobuf:"varint,9,opt,name=consistency_level,json=consistencyLevel,proto3,enum=milvus.proto.common.ConsistencyLevel" json:"consistency_level,omitempty"`
+	Offset               int64                     `protobuf:"varint,10,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit                int64                     `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
 func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryRequest) ProtoMessage()    {}
 func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{48}
+	return fileDescriptor_02345ba45cc0e303, []int{50}
 }
 
 func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
@@ -3420,6 +3650,27 @@ func (m *QueryRequest) GetGuaranteeTimestamp() uint64 {
 	return 0
 }
 
+func (m *QueryRequest) GetConsistencyLevel() commonpb.ConsistencyLevel {
+	if m != nil {
+		return m.ConsistencyLevel
+	}
+	return commonpb.ConsistencyLevel_Strong
+}
+
+func (m *QueryRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 type QueryResults struct {
 	Status               *commonpb.Status      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	FieldsData           []*schemapb.FieldData `protobuf:"bytes,2,rep,name=fields_data,json=fieldsData,proto3" json:"fields_data,omitempty"`
@@ -3432,7 +3683,7 @@ func (m *QueryResults) Reset()         { *m = QueryResults{} }
 func (m *QueryResults) String() string { return proto.CompactTextString(m) }
 func (*QueryResults) ProtoMessage()    {}
 func (*QueryResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{49}
+	return fileDescriptor_02345ba45cc0e303, []int{51}
 }
 
 func (m *QueryResults) XXX_Unmarshal(b []byte) error {
@@ -3481,7 +3732,7 @@ func (m *VectorIDs) Reset()         { *m = VectorIDs{} }
 func (m *VectorIDs) String() string { return proto.CompactTextString(m) }
 func (*VectorIDs) ProtoMessage()    {}
 func (*VectorIDs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{50}
+	return fileDescriptor_02345ba45cc0e303, []int{52}
 }
 
 func (m *VectorIDs) XXX_Unmarshal(b []byte) error {
@@ -3544,7 +3795,7 @@ func (m *VectorsArray) Reset()         { *m = VectorsArray{} }
 func (m *VectorsArray) String() string { return proto.CompactTextString(m) }
 func (*VectorsArray) ProtoMessage()    {}
 func (*VectorsArray) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{51}
+	return fileDescriptor_02345ba45cc0e303, []int{53}
 }
 
 func (m *VectorsArray) XXX_Unmarshal(b []byte) error {
@@ -3624,7 +3875,7 @@ func (m *CalcDistanceRequest) Reset()         { *m = CalcDistanceRequest{} }
 func (m *CalcDistanceRequest) String() string { return proto.CompactTextString(m) }
 func (*CalcDistanceRequest) ProtoMessage()    {}
 func (*CalcDistanceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{52}
+	return fileDescriptor_02345ba45cc0e303, []int{54}
 }
 
 func (m *CalcDistanceRequest) XXX_Unmarshal(b []byte) error {
@@ -3690,7 +3941,7 @@ func (m *CalcDistanceResults) Reset()         { *m = CalcDistanceResults{} }
 func (m *CalcDistanceResults) String() string { return proto.CompactTextString(m) }
 func (*CalcDistanceResults) ProtoMessage()    {}
 func (*CalcDistanceResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{53}
+	return fileDescriptor_02345ba45cc0e303, []int{55}
 }
 
 func (m *CalcDistanceResults) XXX_Unmarshal(b []byte) error {
@@ -3778,7 +4029,7 @@ func (m *PersistentSegmentInfo) Reset()         { *m = PersistentSegmentInfo{} }
 func (m *PersistentSegmentInfo) String() string { return proto.CompactTextString(m) }
 func (*PersistentSegmentInfo) ProtoMessage()    {}
 func (*PersistentSegmentInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{54}
+	return fileDescriptor_02345ba45cc0e303, []int{56}
 }
 
 func (m *PersistentSegmentInfo) XXX_Unmarshal(b []byte) error {
@@ -3847,7 +4098,7 @@ func (m *GetPersistentSegmentInfoRequest) Reset()         { *m = GetPersistentSe
 func (m *GetPersistentSegmentInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*GetPersistentSegmentInfoRequest) ProtoMessage()    {}
 func (*GetPersistentSegmentInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{55}
+	return fileDescriptor_02345ba45cc0e303, []int{57}
 }
 
 func (m *GetPersistentSegmentInfoRequest) XXX_Unmarshal(b []byte) error {
@@ -3901,7 +4152,7 @@ func (m *GetPersistentSegmentInfoResponse) Reset()         { *m = GetPersistentS
 func (m *GetPersistentSegmentInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPersistentSegmentInfoResponse) ProtoMessage()    {}
 func (*GetPersistentSegmentInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{56}
+	return fileDescriptor_02345ba45cc0e303, []int{58}
 }
 
 func (m *GetPersistentSegmentInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -3953,7 +4204,7 @@ func (m *QuerySegmentInfo) Reset()         { *m = QuerySegmentInfo{} }
 func (m *QuerySegmentInfo) String() string { return proto.CompactTextString(m) }
 func (*QuerySegmentInfo) ProtoMessage()    {}
 func (*QuerySegmentInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{57}
+	return fileDescriptor_02345ba45cc0e303, []int{59}
 }
 
 func (m *QuerySegmentInfo) XXX_Unmarshal(b []byte) error {
@@ -4036,7 +4287,7 @@ func (m *GetQuerySegmentInfoRequest) Reset()         { *m = GetQuerySegmentInfoR
 func (m *GetQuerySegmentInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*GetQuerySegmentInfoRequest) ProtoMessage()    {}
 func (*GetQuerySegmentInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{58}
+	return fileDescriptor_02345ba45cc0e303, []int{60}
 }
 
 func (m *GetQuerySegmentInfoRequest) XXX_Unmarshal(b []byte) error {
@@ -4090,7 +4341,7 @@ func (m *GetQuerySegmentInfoResponse) Reset()         { *m = GetQuerySegmentInfo
 func (m *GetQuerySegmentInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*GetQuerySegmentInfoResponse) ProtoMessage()    {}
 func (*GetQuerySegmentInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{59}
+	return fileDescriptor_02345ba45cc0e303, []int{61}
 }
 
 func (m *GetQuerySegmentInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -4125,6 +4376,313 @@ func (m *GetQuerySegmentInfoResponse) GetInfos() []*QuerySegmentInfo {
 	return nil
 }
 
+type LoadBalanceRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	SrcNodeID            int64             `protobuf:"varint,2,opt,name=src_nodeID,json=srcNodeID,proto3" json:"src_nodeID,omitempty"`
+	DstNodeIDs           []int64           `protobuf:"varint,3,rep,packed,name=dst_nodeIDs,json=dstNodeIDs,proto3" json:"dst_nodeIDs,omitempty"`
+	SealedSegmentIDs     []int64           `protobuf:"varint,4,rep,packed,name=sealed_segmentIDs,json=sealedSegmentIDs,proto3" json:"sealed_segmentIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LoadBalanceRequest) Reset()         { *m = LoadBalanceRequest{} }
+func (m *LoadBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadBalanceRequest) ProtoMessage()    {}
+func (*LoadBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{62}
+}
+
+func (m *LoadBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadBalanceRequest.Unmarshal(m, b)
+}
+func (m *LoadBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *LoadBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadBalanceRequest.Merge(m, src)
+}
+func (m *LoadBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadBalanceRequest.Size(m)
+}
+func (m *LoadBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadBalanceRequest proto.InternalMessageInfo
+
+func (m *LoadBalanceRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *LoadBalanceRequest) GetSrcNodeID() int64 {
+	if m != nil {
+		return m.SrcNodeID
+	}
+	return 0
+}
+
+func (m *LoadBalanceRequest) GetDstNodeIDs() []int64 {
+	if m != nil {
+		return m.DstNodeIDs
+	}
+	return nil
+}
+
+func (m *LoadBalanceRequest) GetSealedSegmentIDs() []int64 {
+	if m != nil {
+		return m.SealedSegmentIDs
+	}
+	return nil
+}
+
+type ImportRequest struct {
+	Base                 *commonpb.MsgBase        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CollectionName       string                   `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	PartitionName        string                   `protobuf:"bytes,3,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	RowBased             bool                     `protobuf:"varint,4,opt,name=row_based,json=rowBased,proto3" json:"row_based,omitempty"`
+	Files                []string                 `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	Options              []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
+func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportRequest) ProtoMessage()    {}
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{63}
+}
+
+func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
+}
+func (m *ImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportRequest.Merge(m, src)
+}
+func (m *ImportRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportRequest.Size(m)
+}
+func (m *ImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportRequest proto.InternalMessageInfo
+
+func (m *ImportRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ImportRequest) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *ImportRequest) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *ImportRequest) GetRowBased() bool {
+	if m != nil {
+		return m.RowBased
+	}
+	return false
+}
+
+func (m *ImportRequest) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ImportRequest) GetOptions() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type ImportResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Tasks                []int64          `protobuf:"varint,2,rep,packed,name=tasks,proto3" json:"tasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ImportResponse) Reset()         { *m = ImportResponse{} }
+func (m *ImportResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportResponse) ProtoMessage()    {}
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{64}
+}
+
+func (m *ImportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportResponse.Unmarshal(m, b)
+}
+func (m *ImportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportResponse.Merge(m, src)
+}
+func (m *ImportResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportResponse.Size(m)
+}
+func (m *ImportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportResponse proto.InternalMessageInfo
+
+func (m *ImportResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ImportResponse) GetTasks() []int64 {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+type GetImportStateRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Task                 int64             `protobuf:"varint,2,opt,name=task,proto3" json:"task,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetImportStateRequest) Reset()         { *m = GetImportStateRequest{} }
+func (m *GetImportStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetImportStateRequest) ProtoMessage()    {}
+func (*GetImportStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{65}
+}
+
+func (m *GetImportStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetImportStateRequest.Unmarshal(m, b)
+}
+func (m *GetImportStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetImportStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetImportStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetImportStateRequest.Merge(m, src)
+}
+func (m *GetImportStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetImportStateRequest.Size(m)
+}
+func (m *GetImportStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetImportStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetImportStateRequest proto.InternalMessageInfo
+
+func (m *GetImportStateRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *GetImportStateRequest) GetTask() int64 {
+	if m != nil {
+		return m.Task
+	}
+	return 0
+}
+
+type GetImportStateResponse struct {
+	Status               *commonpb.Status         `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	State                commonpb.ImportState     `protobuf:"varint,2,opt,name=state,proto3,enum=milvus.proto.common.ImportState" json:"state,omitempty"`
+	RowCount             int64                    `protobuf:"varint,3,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	SegmentIds           []int64                  `protobuf:"varint,4,rep,packed,name=segment_ids,json=segmentIds,proto3" json:"segment_ids,omitempty"`
+	Infos                []*commonpb.KeyValuePair `protobuf:"bytes,5,rep,name=infos,proto3" json:"infos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetImportStateResponse) Reset()         { *m = GetImportStateResponse{} }
+func (m *GetImportStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetImportStateResponse) ProtoMessage()    {}
+func (*GetImportStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02345ba45cc0e303, []int{66}
+}
+
+func (m *GetImportStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetImportStateResponse.Unmarshal(m, b)
+}
+func (m *GetImportStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetImportStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetImportStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetImportStateResponse.Merge(m, src)
+}
+func (m *GetImportStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetImportStateResponse.Size(m)
+}
+func (m *GetImportStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetImportStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetImportStateResponse proto.InternalMessageInfo
+
+func (m *GetImportStateResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetImportStateResponse) GetState() commonpb.ImportState {
+	if m != nil {
+		return m.State
+	}
+	return commonpb.ImportState_ImportPending
+}
+
+func (m *GetImportStateResponse) GetRowCount() int64 {
+	if m != nil {
+		return m.RowCount
+	}
+	return 0
+}
+
+func (m *GetImportStateResponse) GetSegmentIds() []int64 {
+	if m != nil {
+		return m.SegmentIds
+	}
+	return nil
+}
+
+func (m *GetImportStateResponse) GetInfos() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Infos
+	}
+	return nil
+}
+
 type DummyRequest struct {
 	RequestType          string   `protobuf:"bytes,1,opt,name=request_type,json=requestType,proto3" json:"request_type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -4136,7 +4694,7 @@ func (m *DummyRequest) Reset()         { *m = DummyRequest{} }
 func (m *DummyRequest) String() string { return proto.CompactTextString(m) }
 func (*DummyRequest) ProtoMessage()    {}
 func (*DummyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{60}
+	return fileDescriptor_02345ba45cc0e303, []int{67}
 }
 
 func (m *DummyRequest) XXX_Unmarshal(b []byte) error {
@@ -4175,7 +4733,7 @@ func (m *DummyResponse) Reset()         { *m = DummyResponse{} }
 func (m *DummyResponse) String() string { return proto.CompactTextString(m) }
 func (*DummyResponse) ProtoMessage()    {}
 func (*DummyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{61}
+	return fileDescriptor_02345ba45cc0e303, []int{68}
 }
 
 func (m *DummyResponse) XXX_Unmarshal(b []byte) error {
@@ -4213,7 +4771,7 @@ func (m *RegisterLinkRequest) Reset()         { *m = RegisterLinkRequest{} }
 func (m *RegisterLinkRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterLinkRequest) ProtoMessage()    {}
 func (*RegisterLinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{62}
+	return fileDescriptor_02345ba45cc0e303, []int{69}
 }
 
 func (m *RegisterLinkRequest) XXX_Unmarshal(b []byte) error {
@@ -4246,7 +4804,7 @@ func (m *RegisterLinkResponse) Reset()         { *m = RegisterLinkResponse{} }
 func (m *RegisterLinkResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterLinkResponse) ProtoMessage()    {}
 func (*RegisterLinkResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{63}
+	return fileDescriptor_02345ba45cc0e303, []int{70}
 }
 
 func (m *RegisterLinkResponse) XXX_Unmarshal(b []byte) error {
@@ -4293,7 +4851,7 @@ func (m *GetMetricsRequest) Reset()         { *m = GetMetricsRequest{} }
 func (m *GetMetricsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetMetricsRequest) ProtoMessage()    {}
 func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{64}
+	return fileDescriptor_02345ba45cc0e303, []int{71}
 }
 
 func (m *GetMetricsRequest) XXX_Unmarshal(b []byte) error {
@@ -4341,7 +4899,7 @@ func (m *GetMetricsResponse) Reset()         { *m = GetMetricsResponse{} }
 func (m *GetMetricsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetMetricsResponse) ProtoMessage()    {}
 func (*GetMetricsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02345ba45cc0e303, []int{65}
+	return fileDescriptor_02345ba45cc0e303, []int{72}
 }
 
 func (m *GetMetricsResponse) XXX_Unmarshal(b []byte) error {
@@ -4389,6 +4947,7 @@ func init() {
 	proto.RegisterType((*CreateAliasRequest)(nil), "milvus.proto.milvus.CreateAliasRequest")
 	proto.RegisterType((*DropAliasRequest)(nil), "milvus.proto.milvus.DropAliasRequest")
 	proto.RegisterType((*AlterAliasRequest)(nil), "milvus.proto.milvus.AlterAliasRequest")
+	proto.RegisterType((*AlterCollectionRequest)(nil), "milvus.proto.milvus.AlterCollectionRequest")
 	proto.RegisterType((*CreateCollectionRequest)(nil), "milvus.proto.milvus.CreateCollectionRequest")
 	proto.RegisterType((*DropCollectionRequest)(nil), "milvus.proto.milvus.DropCollectionRequest")
 	proto.RegisterType((*HasCollectionRequest)(nil), "milvus.proto.milvus.HasCollectionRequest")
@@ -4432,6 +4991,7 @@ func init() {
 	proto.RegisterType((*SearchRequest)(nil), "milvus.proto.milvus.SearchRequest")
 	proto.RegisterType((*Hits)(nil), "milvus.proto.milvus.Hits")
 	proto.RegisterType((*SearchResults)(nil), "milvus.proto.milvus.SearchResults")
+	proto.RegisterType((*ShardSearchStats)(nil), "milvus.proto.milvus.ShardSearchStats")
 	proto.RegisterType((*FlushRequest)(nil), "milvus.proto.milvus.FlushRequest")
 	proto.RegisterType((*FlushResponse)(nil), "milvus.proto.milvus.FlushResponse")
 	proto.RegisterMapType((map[string]*schemapb.LongArray)(nil), "milvus.proto.milvus.FlushResponse.CollSegIDsEntry")
@@ -4447,6 +5007,11 @@ func init() {
 	proto.RegisterType((*QuerySegmentInfo)(nil), "milvus.proto.milvus.QuerySegmentInfo")
 	proto.RegisterType((*GetQuerySegmentInfoRequest)(nil), "milvus.proto.milvus.GetQuerySegmentInfoRequest")
 	proto.RegisterType((*GetQuerySegmentInfoResponse)(nil), "milvus.proto.milvus.GetQuerySegmentInfoResponse")
+	proto.RegisterType((*LoadBalanceRequest)(nil), "milvus.proto.milvus.LoadBalanceRequest")
+	proto.RegisterType((*ImportRequest)(nil), "milvus.proto.milvus.ImportRequest")
+	proto.RegisterType((*ImportResponse)(nil), "milvus.proto.milvus.ImportResponse")
+	proto.RegisterType((*GetImportStateRequest)(nil), "milvus.proto.milvus.GetImportStateRequest")
+	proto.RegisterType((*GetImportStateResponse)(nil), "milvus.proto.milvus.GetImportStateResponse")
 	proto.RegisterType((*DummyRequest)(nil), "milvus.proto.milvus.DummyRequest")
 	proto.RegisterType((*DummyResponse)(nil), "milvus.proto.milvus.DummyResponse")
 	proto.RegisterType((*RegisterLinkRequest)(nil), "milvus.proto.milvus.RegisterLinkRequest")
@@ -4458,205 +5023,240 @@ func init() {
 func init() { proto.RegisterFile("milvus.proto", fileDescriptor_02345ba45cc0e303) }
 
 var fileDescriptor_02345ba45cc0e303 = []byte{
-	// 3167 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5b, 0xcd, 0x6f, 0x1c, 0xc7,
-	0xb1, 0xe7, 0xec, 0x72, 0xbf, 0x6a, 0x67, 0xc9, 0x65, 0xf3, 0x43, 0xeb, 0xb5, 0x64, 0x91, 0xe3,
-	0x27, 0x8b, 0x92, 0x6c, 0xc9, 0xa2, 0xfc, 0xf5, 0xec, 0xf7, 0x9e, 0x2d, 0x89, 0xcf, 0x12, 0x61,
-	0xc9, 0xa1, 0x87, 0xb6, 0x01, 0xc7, 0x30, 0x06, 0xc3, 0xdd, 0xe6, 0x72, 0xc0, 0xd9, 0x99, 0xcd,
-	0x74, 0xaf, 0xa8, 0xf5, 0x29, 0x80, 0x93, 0x00, 0x81, 0x1d, 0x1b, 0x41, 0x82, 0x04, 0x39, 0x24,
-	0x87, 0x24, 0x3e, 0xe4, 0x16, 0x27, 0x01, 0x12, 0xe4, 0x94, 0x83, 0x0f, 0x39, 0x04, 0xc8, 0x07,
-	0x90, 0x53, 0x2e, 0xb9, 0xe4, 0x98, 0xff, 0x20, 0x87, 0xa0, 0x3f, 0x66, 0x76, 0x66, 0xb7, 0x67,
-	0xb9, 0xd4, 0x5a, 0x21, 0x79, 0xdb, 0xa9, 0xae, 0xaa, 0xae, 0xae, 0xfe, 0x75, 0x75, 0x77, 0x75,
-	0x2d, 0xe8, 0x6d, 0xc7, 0xbd, 0xd7, 0x25, 0x97, 0x3b, 0x81, 0x4f, 0x7d, 0x34, 0x1f, 0xff, 0xba,
-	0x2c, 0x3e, 0xea, 0x7a, 0xc3, 0x6f, 0xb7, 0x7d, 0x4f, 0x10, 0xeb, 0x3a, 0x69, 0xec, 0xe2, 0xb6,
-	0x2d, 0xbe, 0x8c, 0x1f, 0x69, 0x80, 0x6e, 0x06, 0xd8, 0xa6, 0xf8, 0xba, 0xeb, 0xd8, 0xc4, 0xc4,
-	0x5f, 0xe9, 0x62, 0x42, 0xd1, 0xd3, 0x30, 0xbd, 0x6d, 0x13, 0x5c, 0xd3, 0x96, 0xb5, 0xd5, 0xf2,
-	0xda, 0xe9, 0xcb, 0x09, 0xb5, 0x52, 0xdd, 0x5d, 0xd2, 0xba, 0x61, 0x13, 0x6c, 0x72, 0x4e, 0x74,
-	0x0a, 0x0a, 0xcd, 0x6d, 0xcb, 0xb3, 0xdb, 0xb8, 0x96, 0x59, 0xd6, 0x56, 0x4b, 0x66, 0xbe, 0xb9,
-	0xfd, 0xba, 0xdd, 0xc6, 0xe8, 0x3c, 0xcc, 0x36, 0x7c, 0xd7, 0xc5, 0x0d, 0xea, 0xf8, 0x9e, 0x60,
-	0xc8, 0x72, 0x86, 0x99, 0x3e, 0x99, 0x33, 0x2e, 0x40, 0xce, 0x66, 0x36, 0xd4, 0xa6, 0x79, 0xb3,
-	0xf8, 0x30, 0x08, 0x54, 0xd7, 0x03, 0xbf, 0xf3, 0xb0, 0xac, 0x8b, 0x3a, 0xcd, 0xc6, 0x3b, 0xfd,
-	0xa1, 0x06, 0x73, 0xd7, 0x5d, 0x8a, 0x83, 0x63, 0xea, 0x94, 0xcf, 0x35, 0x38, 0x25, 0x66, 0xed,
-	0x66, 0xc4, 0x7e, 0x94, 0x56, 0x2e, 0x41, 0x5e, 0xa0, 0x8a, 0x9b, 0xa9, 0x9b, 0xf2, 0x0b, 0x9d,
-	0x01, 0x20, 0xbb, 0x76, 0xd0, 0x24, 0x96, 0xd7, 0x6d, 0xd7, 0x72, 0xcb, 0xda, 0x6a, 0xce, 0x2c,
-	0x09, 0xca, 0xeb, 0xdd, 0xb6, 0xf1, 0xa1, 0x06, 0x8b, 0x6c, 0x72, 0x8f, 0xc5, 0x20, 0x8c, 0x9f,
-	0x69, 0xb0, 0x70, 0xdb, 0x26, 0xc7, 0xc3, 0xa3, 0x67, 0x00, 0xa8, 0xd3, 0xc6, 0x16, 0xa1, 0x76,
-	0xbb, 0xc3, 0xbd, 0x3a, 0x6d, 0x96, 0x18, 0x65, 0x8b, 0x11, 0x8c, 0x77, 0x40, 0xbf, 0xe1, 0xfb,
-	0xae, 0x89, 0x49, 0xc7, 0xf7, 0x08, 0x46, 0xd7, 0x20, 0x4f, 0xa8, 0x4d, 0xbb, 0x44, 0x1a, 0xf9,
-	0xa8, 0xd2, 0xc8, 0x2d, 0xce, 0x62, 0x4a, 0x56, 0x86, 0xad, 0x7b, 0xb6, 0xdb, 0x15, 0x36, 0x16,
-	0x4d, 0xf1, 0x61, 0xbc, 0x0b, 0x33, 0x5b, 0x34, 0x70, 0xbc, 0xd6, 0x17, 0xa8, 0xbc, 0x14, 0x2a,
-	0xff, 0x8b, 0x06, 0x8f, 0xac, 0x63, 0xd2, 0x08, 0x9c, 0xed, 0x63, 0x02, 0x5d, 0x03, 0xf4, 0x3e,
-	0x65, 0x63, 0x9d, 0xbb, 0x3a, 0x6b, 0x26, 0x68, 0x03, 0x93, 0x91, 0x1b, 0x9c, 0x8c, 0x0f, 0xa6,
-	0xa1, 0xae, 0x1a, 0xd4, 0x24, 0xee, 0xfb, 0xdf, 0x68, 0x45, 0x65, 0xb8, 0xd0, 0xb9, 0xa4, 0x90,
-	0x8c, 0xe1, 0xfd, 0xde, 0xb6, 0x38, 0x21, 0x5a, 0x78, 0x83, 0xa3, 0xca, 0x2a, 0x46, 0xb5, 0x06,
-	0x8b, 0xf7, 0x9c, 0x80, 0x76, 0x6d, 0xd7, 0x6a, 0xec, 0xda, 0x9e, 0x87, 0x5d, 0xee, 0x27, 0x16,
-	0x6a, 0xb2, 0xab, 0x25, 0x73, 0x5e, 0x36, 0xde, 0x14, 0x6d, 0xcc, 0x59, 0x04, 0x3d, 0x03, 0x4b,
-	0x9d, 0xdd, 0x1e, 0x71, 0x1a, 0x43, 0x42, 0x39, 0x2e, 0xb4, 0x10, 0xb6, 0x26, 0xa4, 0x2e, 0xc1,
-	0x5c, 0x83, 0x47, 0xab, 0xa6, 0xc5, 0xbc, 0x26, 0xdc, 0x98, 0xe7, 0x6e, 0xac, 0xca, 0x86, 0x37,
-	0x43, 0x3a, 0x33, 0x2b, 0x64, 0xee, 0xd2, 0x46, 0x4c, 0xa0, 0xc0, 0x05, 0xe6, 0x65, 0xe3, 0x5b,
-	0xb4, 0xd1, 0x97, 0x49, 0xc6, 0x99, 0xe2, 0x40, 0x9c, 0x41, 0x35, 0x28, 0xf0, 0xb8, 0x89, 0x49,
-	0xad, 0xc4, 0xcd, 0x0c, 0x3f, 0xd1, 0x06, 0xcc, 0x12, 0x6a, 0x07, 0xd4, 0xea, 0xf8, 0xc4, 0x61,
-	0x7e, 0x21, 0x35, 0x58, 0xce, 0xae, 0x96, 0xd7, 0x96, 0x95, 0x93, 0xf4, 0x1a, 0xee, 0xad, 0xdb,
-	0xd4, 0xde, 0xb4, 0x9d, 0xc0, 0x9c, 0xe1, 0x82, 0x9b, 0xa1, 0x1c, 0x0f, 0x66, 0x77, 0x7c, 0xbb,
-	0x79, 0x3c, 0x82, 0xd9, 0xc7, 0x1a, 0xd4, 0x4c, 0xec, 0x62, 0x9b, 0x1c, 0x8f, 0x75, 0x66, 0x7c,
-	0x57, 0x83, 0xc7, 0x6e, 0x61, 0x1a, 0x43, 0x2c, 0xb5, 0xa9, 0x43, 0xa8, 0xd3, 0x38, 0xca, 0xfd,
-	0xd5, 0xf8, 0x44, 0x83, 0xb3, 0xa9, 0x66, 0x4d, 0xb2, 0x80, 0x9f, 0x87, 0x1c, 0xfb, 0x45, 0x6a,
-	0x19, 0x8e, 0xa7, 0x95, 0x34, 0x3c, 0xbd, 0xcd, 0xe2, 0x22, 0x07, 0x94, 0xe0, 0x37, 0xfe, 0xae,
-	0xc1, 0xd2, 0xd6, 0xae, 0xbf, 0xdf, 0x37, 0xe9, 0x61, 0x38, 0x28, 0x19, 0xd2, 0xb2, 0x03, 0x21,
-	0x0d, 0x5d, 0x85, 0x69, 0xda, 0xeb, 0x60, 0x1e, 0x0d, 0x67, 0xd6, 0xce, 0x5c, 0x56, 0x1c, 0x2b,
-	0x2f, 0x33, 0x23, 0xdf, 0xec, 0x75, 0xb0, 0xc9, 0x59, 0xd1, 0x05, 0xa8, 0x0e, 0xb8, 0x3c, 0x0c,
-	0x0a, 0xb3, 0x49, 0x9f, 0x13, 0xe3, 0x37, 0x19, 0x38, 0x35, 0x34, 0xc4, 0x49, 0x9c, 0xad, 0xea,
-	0x3b, 0xa3, 0xec, 0x1b, 0x9d, 0x83, 0x18, 0x04, 0x2c, 0xa7, 0xc9, 0x4e, 0x7e, 0xd9, 0xd5, 0xac,
-	0x59, 0x89, 0xc5, 0xc6, 0x26, 0x41, 0x4f, 0x01, 0x1a, 0x0a, 0x59, 0x22, 0x32, 0x4e, 0x9b, 0x73,
-	0x83, 0x31, 0x8b, 0xc7, 0x45, 0x65, 0xd0, 0x12, 0x2e, 0x98, 0x36, 0x17, 0x14, 0x51, 0x8b, 0xa0,
-	0xab, 0xb0, 0xe0, 0x78, 0x77, 0x71, 0xdb, 0x0f, 0x7a, 0x56, 0x07, 0x07, 0x0d, 0xec, 0x51, 0xbb,
-	0x85, 0x49, 0x2d, 0xcf, 0x2d, 0x9a, 0x0f, 0xdb, 0x36, 0xfb, 0x4d, 0xc6, 0x2f, 0x35, 0x58, 0x12,
-	0x27, 0xbf, 0x4d, 0x3b, 0xa0, 0xce, 0x51, 0xef, 0x9e, 0xe7, 0x60, 0xa6, 0x13, 0xda, 0x21, 0xf8,
-	0xc4, 0x39, 0xb5, 0x12, 0x51, 0xf9, 0x2a, 0xfb, 0x4c, 0x83, 0x05, 0x76, 0xd0, 0x3b, 0x49, 0x36,
-	0xff, 0x5c, 0x83, 0xf9, 0xdb, 0x36, 0x39, 0x49, 0x26, 0xff, 0x4a, 0x6e, 0x41, 0x91, 0xcd, 0x47,
-	0x7a, 0x75, 0x39, 0x0f, 0xb3, 0x49, 0xa3, 0xc3, 0x93, 0xc5, 0x4c, 0xc2, 0x6a, 0x62, 0xfc, 0xba,
-	0xbf, 0x57, 0x9d, 0x30, 0xcb, 0x7f, 0xab, 0xc1, 0x99, 0x5b, 0x98, 0x46, 0x56, 0x1f, 0x8b, 0x3d,
-	0x6d, 0x5c, 0xb4, 0x7c, 0x2c, 0x76, 0x64, 0xa5, 0xf1, 0x47, 0xb2, 0xf3, 0x7d, 0x98, 0x81, 0x45,
-	0xb6, 0x2d, 0x1c, 0x0f, 0x10, 0x8c, 0x73, 0x31, 0x50, 0x00, 0x25, 0xa7, 0x02, 0x4a, 0xb4, 0x9f,
-	0xe6, 0xc7, 0xde, 0x4f, 0x8d, 0x5f, 0x64, 0xc4, 0x39, 0x20, 0xee, 0x8d, 0x49, 0xa6, 0x45, 0x61,
-	0x6b, 0x46, 0x69, 0xab, 0x01, 0x7a, 0x44, 0xd9, 0x58, 0x0f, 0xf7, 0xc7, 0x04, 0xed, 0xd8, 0x6e,
-	0x8f, 0x1f, 0x69, 0xb0, 0x14, 0x5e, 0xc5, 0xb6, 0x70, 0xab, 0x8d, 0x3d, 0xfa, 0xe0, 0x18, 0x1a,
-	0x44, 0x40, 0x46, 0x81, 0x80, 0xd3, 0x50, 0x22, 0xa2, 0x9f, 0xe8, 0x96, 0xd5, 0x27, 0x18, 0x9f,
-	0x6a, 0x70, 0x6a, 0xc8, 0x9c, 0x49, 0x26, 0xb1, 0x06, 0x05, 0xc7, 0x6b, 0xe2, 0xfb, 0x91, 0x35,
-	0xe1, 0x27, 0x6b, 0xd9, 0xee, 0x3a, 0x6e, 0x33, 0x32, 0x23, 0xfc, 0x44, 0x2b, 0xa0, 0x63, 0xcf,
-	0xde, 0x76, 0xb1, 0xc5, 0x79, 0x39, 0x90, 0x8b, 0x66, 0x59, 0xd0, 0x36, 0x18, 0xc9, 0xf8, 0x96,
-	0x06, 0xf3, 0x0c, 0x6b, 0xd2, 0x46, 0xf2, 0x70, 0x7d, 0xb6, 0x0c, 0xe5, 0x18, 0x98, 0xa4, 0xb9,
-	0x71, 0x92, 0xb1, 0x07, 0x0b, 0x49, 0x73, 0x26, 0xf1, 0xd9, 0x63, 0x00, 0xd1, 0x8c, 0x08, 0xcc,
-	0x67, 0xcd, 0x18, 0xc5, 0xf8, 0x67, 0x94, 0x02, 0xe5, 0xce, 0x38, 0xe2, 0xac, 0xcf, 0x8e, 0x83,
-	0xdd, 0x66, 0x3c, 0x6a, 0x97, 0x38, 0x85, 0x37, 0xaf, 0x83, 0x8e, 0xef, 0xd3, 0xc0, 0xb6, 0x3a,
-	0x76, 0x60, 0xb7, 0xc5, 0xe2, 0x19, 0x2b, 0xc0, 0x96, 0xb9, 0xd8, 0x26, 0x97, 0x32, 0x7e, 0xcf,
-	0x0e, 0x63, 0x12, 0x94, 0xc7, 0x7d, 0xc4, 0x67, 0x00, 0x38, 0x68, 0x45, 0x73, 0x4e, 0x34, 0x73,
-	0x0a, 0xdf, 0xc2, 0x3e, 0xd5, 0xa0, 0xca, 0x87, 0x20, 0xc6, 0xd3, 0x61, 0x6a, 0x07, 0x64, 0xb4,
-	0x01, 0x99, 0x11, 0x4b, 0xe8, 0xbf, 0x21, 0x2f, 0x1d, 0x9b, 0x1d, 0xd7, 0xb1, 0x52, 0xe0, 0x80,
-	0x61, 0x18, 0x3f, 0xd6, 0x60, 0x71, 0xc0, 0xe5, 0x93, 0x20, 0xfa, 0x4d, 0x40, 0x62, 0x84, 0xcd,
-	0xfe, 0xb0, 0xc3, 0xed, 0xf6, 0x9c, 0x72, 0x6f, 0x19, 0x74, 0x92, 0x39, 0xe7, 0x0c, 0x50, 0x88,
-	0xf1, 0x27, 0x0d, 0x4e, 0xdf, 0xc2, 0x94, 0xb3, 0xde, 0x60, 0xb1, 0x63, 0x33, 0xf0, 0x5b, 0x01,
-	0x26, 0xe4, 0xe4, 0xe2, 0xe3, 0x7b, 0xe2, 0x7c, 0xa6, 0x1a, 0xd2, 0x24, 0xfe, 0x5f, 0x01, 0x9d,
-	0xf7, 0x81, 0x9b, 0x56, 0xe0, 0xef, 0x13, 0x89, 0xa3, 0xb2, 0xa4, 0x99, 0xfe, 0x3e, 0x07, 0x04,
-	0xf5, 0xa9, 0xed, 0x0a, 0x06, 0xb9, 0x31, 0x70, 0x0a, 0x6b, 0xe6, 0x6b, 0x30, 0x34, 0x8c, 0x29,
-	0xc7, 0x27, 0xd7, 0xc7, 0x3f, 0xd5, 0x60, 0x71, 0x60, 0x28, 0x93, 0xf8, 0xf6, 0x59, 0x71, 0x7a,
-	0x14, 0x83, 0x99, 0x59, 0x3b, 0xab, 0x94, 0x89, 0x75, 0x26, 0xb8, 0xd1, 0x59, 0x28, 0xef, 0xd8,
-	0x8e, 0x6b, 0x05, 0xd8, 0x26, 0xbe, 0x27, 0x07, 0x0a, 0x8c, 0x64, 0x72, 0x8a, 0xf1, 0xb9, 0x26,
-	0x1e, 0x92, 0x4e, 0x78, 0xc4, 0xfb, 0x49, 0x06, 0x2a, 0x1b, 0x1e, 0xc1, 0x01, 0x3d, 0xfe, 0x37,
-	0x0c, 0xf4, 0x32, 0x94, 0xf9, 0xc0, 0x88, 0xd5, 0xb4, 0xa9, 0x2d, 0xb7, 0xab, 0xc7, 0x94, 0x99,
-	0xec, 0x57, 0x19, 0xdf, 0xba, 0x4d, 0x6d, 0x53, 0x78, 0x87, 0xb0, 0xdf, 0xe8, 0x51, 0x28, 0xed,
-	0xda, 0x64, 0xd7, 0xda, 0xc3, 0x3d, 0x71, 0xec, 0xab, 0x98, 0x45, 0x46, 0x78, 0x0d, 0xf7, 0x08,
-	0x7a, 0x04, 0x8a, 0x5e, 0xb7, 0x2d, 0x16, 0x58, 0x61, 0x59, 0x5b, 0xad, 0x98, 0x05, 0xaf, 0xdb,
-	0xe6, 0xcb, 0xeb, 0x0f, 0x19, 0x98, 0xb9, 0xdb, 0x65, 0xf7, 0x19, 0x9e, 0x87, 0xef, 0xba, 0xf4,
-	0xc1, 0xc0, 0x78, 0x11, 0xb2, 0xe2, 0xcc, 0xc0, 0x24, 0x6a, 0x4a, 0xc3, 0x37, 0xd6, 0x89, 0xc9,
-	0x98, 0x78, 0x0e, 0xba, 0xdb, 0x68, 0xc8, 0x43, 0x56, 0x96, 0x1b, 0x5b, 0x62, 0x14, 0x8e, 0x38,
-	0x36, 0x14, 0x1c, 0x04, 0xd1, 0x11, 0x8c, 0x0f, 0x05, 0x07, 0x81, 0x68, 0x34, 0x40, 0xb7, 0x1b,
-	0x7b, 0x9e, 0xbf, 0xef, 0xe2, 0x66, 0x0b, 0x37, 0xf9, 0xb4, 0x17, 0xcd, 0x04, 0x4d, 0x00, 0x83,
-	0x4d, 0xbc, 0xd5, 0xf0, 0x28, 0xbf, 0x48, 0x64, 0x19, 0x30, 0x18, 0xe5, 0xa6, 0x47, 0x59, 0x73,
-	0x13, 0xbb, 0x98, 0x62, 0xde, 0x5c, 0x10, 0xcd, 0x82, 0x22, 0x9b, 0xbb, 0x9d, 0x48, 0xba, 0x28,
-	0x9a, 0x05, 0x85, 0x35, 0x9f, 0x86, 0x52, 0x3f, 0xd1, 0x5e, 0xea, 0x67, 0x03, 0x39, 0xc1, 0xf8,
-	0x9b, 0x06, 0x95, 0x75, 0xae, 0xea, 0x04, 0x80, 0x0e, 0xc1, 0x34, 0xbe, 0xdf, 0x09, 0xe4, 0xd2,
-	0xe1, 0xbf, 0x47, 0xe2, 0xc8, 0xb8, 0x07, 0xd5, 0x4d, 0xd7, 0x6e, 0xe0, 0x5d, 0xdf, 0x6d, 0xe2,
-	0x80, 0xef, 0xed, 0xa8, 0x0a, 0x59, 0x6a, 0xb7, 0xe4, 0xe1, 0x81, 0xfd, 0x44, 0x2f, 0xc8, 0x1b,
-	0x9c, 0x08, 0x4b, 0xff, 0xa5, 0xdc, 0x65, 0x63, 0x6a, 0x62, 0x89, 0xd1, 0x25, 0xc8, 0xf3, 0xc7,
-	0x2f, 0x71, 0xac, 0xd0, 0x4d, 0xf9, 0x65, 0xbc, 0x97, 0xe8, 0xf7, 0x56, 0xe0, 0x77, 0x3b, 0x68,
-	0x03, 0xf4, 0x4e, 0x9f, 0xc6, 0xb0, 0x9a, 0xbe, 0xa7, 0x0f, 0x1a, 0x6d, 0x26, 0x44, 0x8d, 0x4f,
-	0xa7, 0xa1, 0xb2, 0x85, 0xed, 0xa0, 0xb1, 0x7b, 0x12, 0x52, 0x29, 0xcc, 0xe3, 0x4d, 0xe2, 0xca,
-	0x59, 0x63, 0x3f, 0xd1, 0x25, 0x98, 0x8b, 0x0d, 0xc8, 0x6a, 0x31, 0x07, 0x71, 0xdc, 0xeb, 0x66,
-	0xb5, 0x33, 0xe8, 0xb8, 0xe7, 0xa1, 0xd8, 0x24, 0xae, 0xc5, 0xa7, 0xa8, 0xc0, 0xa7, 0x48, 0x3d,
-	0xbe, 0x75, 0xe2, 0xf2, 0xa9, 0x29, 0x34, 0xc5, 0x0f, 0xf4, 0x38, 0x54, 0xfc, 0x2e, 0xed, 0x74,
-	0xa9, 0x25, 0xe2, 0x4e, 0xad, 0xc8, 0xcd, 0xd3, 0x05, 0x91, 0x87, 0x25, 0x82, 0x5e, 0x85, 0x0a,
-	0xe1, 0xae, 0x0c, 0x4f, 0xde, 0xa5, 0x71, 0x0f, 0x88, 0xba, 0x90, 0x13, 0x47, 0x6f, 0x74, 0x01,
-	0xaa, 0x34, 0xb0, 0xef, 0x61, 0x37, 0xf6, 0xac, 0x05, 0x7c, 0xb5, 0xcd, 0x0a, 0x7a, 0xff, 0x49,
-	0xeb, 0x0a, 0xcc, 0xb7, 0xba, 0x76, 0x60, 0x7b, 0x14, 0xe3, 0x18, 0x77, 0x99, 0x73, 0xa3, 0xa8,
-	0xa9, 0x2f, 0xf0, 0x1c, 0xbb, 0x89, 0xb2, 0xbe, 0x58, 0xc4, 0xd2, 0x0f, 0x88, 0x58, 0x7d, 0x56,
-	0xe3, 0x35, 0x98, 0xbe, 0xed, 0x50, 0x3e, 0x01, 0x4c, 0x52, 0xe3, 0xf7, 0x23, 0x1e, 0xd1, 0x1e,
-	0x81, 0x62, 0xe0, 0xef, 0x8b, 0xd8, 0x9d, 0xe1, 0xd0, 0x2d, 0x04, 0xfe, 0x3e, 0x0f, 0xcc, 0xfc,
-	0xc1, 0xdf, 0x0f, 0x24, 0xa6, 0x33, 0xa6, 0xfc, 0x32, 0xbe, 0xae, 0xf5, 0x41, 0xc7, 0xc2, 0x2e,
-	0x79, 0xb0, 0xb8, 0xfb, 0x32, 0x14, 0x02, 0x21, 0x3f, 0xf2, 0xf9, 0x33, 0xde, 0x13, 0xdf, 0x3b,
-	0x42, 0x29, 0xe3, 0x6b, 0x1a, 0xe8, 0xaf, 0xba, 0x5d, 0xf2, 0x30, 0xb0, 0xaf, 0x7a, 0x6c, 0xc8,
-	0xaa, 0x1f, 0x3a, 0xbe, 0x9d, 0x81, 0x8a, 0x34, 0x63, 0x92, 0x33, 0x51, 0xaa, 0x29, 0x5b, 0x50,
-	0x66, 0x5d, 0x5a, 0x04, 0xb7, 0xc2, 0x4c, 0x4d, 0x79, 0x6d, 0x4d, 0x19, 0x2d, 0x12, 0x66, 0xf0,
-	0x87, 0xe3, 0x2d, 0x2e, 0xf4, 0xff, 0x1e, 0x0d, 0x7a, 0x26, 0x34, 0x22, 0x42, 0xfd, 0x3d, 0x98,
-	0x1d, 0x68, 0x66, 0xd8, 0xd8, 0xc3, 0xbd, 0x30, 0x1c, 0xee, 0xe1, 0x1e, 0x7a, 0x26, 0xfe, 0xbc,
-	0x9f, 0xb6, 0xa9, 0xdf, 0xf1, 0xbd, 0xd6, 0xf5, 0x20, 0xb0, 0x7b, 0xf2, 0xf9, 0xff, 0xc5, 0xcc,
-	0x0b, 0x9a, 0xf1, 0xbb, 0x0c, 0xe8, 0x6f, 0x74, 0x71, 0xd0, 0x3b, 0xca, 0xb0, 0x14, 0x6e, 0x12,
-	0xd3, 0xb1, 0x4d, 0x62, 0x28, 0x12, 0xe4, 0x14, 0x91, 0x40, 0x11, 0xcf, 0xf2, 0xca, 0x78, 0xa6,
-	0x5a, 0xea, 0x85, 0x43, 0x2d, 0xf5, 0x62, 0xda, 0x52, 0xe7, 0xe8, 0x96, 0x2e, 0x9c, 0x68, 0x91,
-	0x25, 0x4e, 0x67, 0x99, 0xc3, 0x9e, 0xce, 0x8c, 0xcf, 0x34, 0x28, 0xbd, 0x8d, 0x1b, 0xd4, 0x0f,
-	0x58, 0xb4, 0x50, 0xf8, 0x5e, 0x1b, 0xe3, 0x00, 0x9c, 0x19, 0x3c, 0x00, 0x5f, 0x83, 0xa2, 0xd3,
-	0xb4, 0x6c, 0x06, 0x1b, 0x3e, 0x79, 0xa3, 0xc2, 0x58, 0xc1, 0x69, 0x72, 0x7c, 0x8d, 0x9f, 0xb1,
-	0xff, 0xbe, 0x06, 0xba, 0xb0, 0x99, 0x08, 0xc9, 0x97, 0x62, 0xdd, 0x69, 0x2a, 0x2c, 0xcb, 0x8f,
-	0x68, 0xa0, 0xb7, 0xa7, 0xfa, 0xdd, 0x5e, 0x07, 0x60, 0xbe, 0x93, 0xe2, 0x62, 0x29, 0x2c, 0x2b,
-	0xad, 0x15, 0xe2, 0xdc, 0x8f, 0xb7, 0xa7, 0xcc, 0x12, 0x93, 0xe2, 0x2a, 0x6e, 0x14, 0x20, 0xc7,
-	0xa5, 0x8d, 0x7f, 0x69, 0x30, 0x7f, 0xd3, 0x76, 0x1b, 0xeb, 0x0e, 0xa1, 0xb6, 0xd7, 0x98, 0xe0,
-	0xa8, 0xf5, 0x22, 0x14, 0xfc, 0x8e, 0xe5, 0xe2, 0x1d, 0x2a, 0x4d, 0x5a, 0x19, 0x31, 0x22, 0xe1,
-	0x06, 0x33, 0xef, 0x77, 0xee, 0xe0, 0x1d, 0x8a, 0xfe, 0x07, 0x8a, 0x7e, 0xc7, 0x0a, 0x9c, 0xd6,
-	0x2e, 0x95, 0xde, 0x1f, 0x43, 0xb8, 0xe0, 0x77, 0x4c, 0x26, 0x11, 0xcb, 0xa0, 0x4c, 0x1f, 0x32,
-	0x83, 0x62, 0xfc, 0x79, 0x68, 0xf8, 0x13, 0x40, 0xfb, 0x45, 0x28, 0x3a, 0x1e, 0xb5, 0x9a, 0x0e,
-	0x09, 0x5d, 0x70, 0x46, 0x8d, 0x21, 0x8f, 0xf2, 0x11, 0xf0, 0x39, 0xf5, 0x28, 0xeb, 0x1b, 0xbd,
-	0x02, 0xb0, 0xe3, 0xfa, 0xb6, 0x94, 0x16, 0x3e, 0x38, 0xab, 0x5e, 0x15, 0x8c, 0x2d, 0x94, 0x2f,
-	0x71, 0x21, 0xa6, 0xa1, 0x3f, 0xa5, 0x7f, 0xd4, 0x60, 0x71, 0x13, 0x07, 0xc4, 0x21, 0x14, 0x7b,
-	0x54, 0x66, 0x33, 0x37, 0xbc, 0x1d, 0x3f, 0x99, 0x36, 0xd6, 0x06, 0xd2, 0xc6, 0x5f, 0x4c, 0x12,
-	0x35, 0x71, 0x3f, 0x12, 0x8f, 0x17, 0xe1, 0xfd, 0x28, 0x7c, 0xa2, 0x11, 0xf7, 0xcb, 0x99, 0x94,
-	0x69, 0x92, 0xf6, 0xc6, 0xaf, 0xd9, 0xc6, 0x77, 0x44, 0xb9, 0x84, 0x72, 0x50, 0x0f, 0x0e, 0xd8,
-	0x25, 0x90, 0x01, 0x7c, 0x20, 0x9c, 0x3f, 0x01, 0x03, 0xb1, 0x23, 0xa5, 0x88, 0xe3, 0x07, 0x1a,
-	0x2c, 0xa7, 0x5b, 0x35, 0xc9, 0xce, 0xfb, 0x0a, 0xe4, 0x1c, 0x6f, 0xc7, 0x0f, 0x93, 0x6b, 0x17,
-	0xd5, 0x07, 0x71, 0x65, 0xbf, 0x42, 0xd0, 0xf8, 0x87, 0x06, 0x55, 0x1e, 0xab, 0x8f, 0x60, 0xfa,
-	0xdb, 0xb8, 0x6d, 0x11, 0xe7, 0x7d, 0x1c, 0x4e, 0x7f, 0x1b, 0xb7, 0xb7, 0x9c, 0xf7, 0x71, 0x02,
-	0x19, 0xb9, 0x24, 0x32, 0x92, 0xe9, 0x87, 0xfc, 0x88, 0xe4, 0x69, 0x21, 0x91, 0x3c, 0x35, 0x3e,
-	0xd6, 0xa0, 0x7e, 0x0b, 0xd3, 0xc1, 0xa1, 0x1e, 0x1d, 0x28, 0x3e, 0xd1, 0xe0, 0x51, 0xa5, 0x41,
-	0x93, 0xe0, 0xe1, 0xa5, 0x24, 0x1e, 0xd4, 0x17, 0xb3, 0xa1, 0x2e, 0x25, 0x14, 0xae, 0x82, 0xbe,
-	0xde, 0x6d, 0xb7, 0xa3, 0x83, 0xcf, 0x0a, 0xe8, 0x81, 0xf8, 0x29, 0xee, 0x2d, 0x62, 0xbb, 0x2c,
-	0x4b, 0x1a, 0xbb, 0x9d, 0x18, 0x97, 0xa0, 0x22, 0x45, 0xa4, 0xd5, 0x75, 0x28, 0x06, 0xf2, 0xb7,
-	0xe4, 0x8f, 0xbe, 0x8d, 0x45, 0x98, 0x37, 0x71, 0x8b, 0x21, 0x31, 0xb8, 0xe3, 0x78, 0x7b, 0xb2,
-	0x1b, 0xe3, 0x03, 0x0d, 0x16, 0x92, 0x74, 0xa9, 0xeb, 0x39, 0x28, 0xd8, 0xcd, 0x66, 0x80, 0x09,
-	0x19, 0x39, 0x2d, 0xd7, 0x05, 0x8f, 0x19, 0x32, 0xc7, 0x3c, 0x97, 0x19, 0xdb, 0x73, 0x86, 0x05,
-	0x73, 0xb7, 0x30, 0xbd, 0x8b, 0x69, 0x30, 0xd1, 0xeb, 0x78, 0x8d, 0xdd, 0x0c, 0xb8, 0xb0, 0x84,
-	0x45, 0xf8, 0x69, 0x7c, 0xa4, 0x01, 0x8a, 0xf7, 0x30, 0xc9, 0x34, 0xc7, 0xbd, 0x9c, 0x49, 0x7a,
-	0x59, 0x14, 0x10, 0xb5, 0x3b, 0xbe, 0x87, 0x3d, 0x1a, 0x3f, 0x62, 0x56, 0x22, 0x2a, 0x83, 0xdf,
-	0xc5, 0x15, 0x28, 0x86, 0x0f, 0xba, 0xa8, 0x00, 0xd9, 0xeb, 0xae, 0x5b, 0x9d, 0x42, 0x3a, 0x14,
-	0x37, 0xe4, 0xab, 0x65, 0x55, 0xbb, 0xf8, 0x7f, 0x30, 0x3b, 0x90, 0x31, 0x40, 0x45, 0x98, 0x7e,
-	0xdd, 0xf7, 0x70, 0x75, 0x0a, 0x55, 0x41, 0xbf, 0xe1, 0x78, 0x76, 0xd0, 0x13, 0x3b, 0x6d, 0xb5,
-	0x89, 0x66, 0xa1, 0xcc, 0x77, 0x1c, 0x49, 0xc0, 0x6b, 0x7f, 0xad, 0x43, 0xe5, 0x2e, 0x1f, 0xcc,
-	0x16, 0x0e, 0xee, 0x39, 0x0d, 0x8c, 0x2c, 0xa8, 0x0e, 0x96, 0x85, 0xa3, 0x27, 0x95, 0x18, 0x4d,
-	0xa9, 0x1e, 0xaf, 0x8f, 0x72, 0x8f, 0x31, 0x85, 0xde, 0x85, 0x99, 0x64, 0xc1, 0x36, 0x52, 0x87,
-	0x44, 0x65, 0x55, 0xf7, 0x41, 0xca, 0x2d, 0xa8, 0x24, 0xea, 0xaf, 0xd1, 0x05, 0xa5, 0x6e, 0x55,
-	0x8d, 0x76, 0x5d, 0x7d, 0x4a, 0x89, 0xd7, 0x48, 0x0b, 0xeb, 0x93, 0x15, 0x9a, 0x29, 0xd6, 0x2b,
-	0xcb, 0x38, 0x0f, 0xb2, 0xde, 0x86, 0xb9, 0xa1, 0x82, 0x4b, 0xf4, 0x94, 0x52, 0x7f, 0x5a, 0x61,
-	0xe6, 0x41, 0x5d, 0xec, 0x03, 0x1a, 0xae, 0x33, 0x46, 0x97, 0xd5, 0x33, 0x90, 0x56, 0x65, 0x5d,
-	0xbf, 0x32, 0x36, 0x7f, 0xe4, 0xb8, 0x6f, 0x68, 0x70, 0x2a, 0xa5, 0x4a, 0x12, 0x5d, 0x53, 0xaa,
-	0x1b, 0x5d, 0xea, 0x59, 0x7f, 0xe6, 0x70, 0x42, 0x91, 0x21, 0x1e, 0xcc, 0x0e, 0x14, 0x0e, 0xa2,
-	0x4b, 0xa9, 0xc5, 0x14, 0xc3, 0x15, 0x94, 0xf5, 0x27, 0xc7, 0x63, 0x8e, 0xfa, 0x63, 0x77, 0xe1,
-	0x64, 0xb5, 0x5d, 0x4a, 0x7f, 0xea, 0x9a, 0xbc, 0x83, 0x26, 0xf4, 0x1d, 0xa8, 0x24, 0xca, 0xe2,
-	0x52, 0x10, 0xaf, 0x2a, 0x9d, 0x3b, 0x48, 0xf5, 0x7b, 0xa0, 0xc7, 0xab, 0xd7, 0xd0, 0x6a, 0xda,
-	0x5a, 0x1a, 0x52, 0x7c, 0x98, 0xa5, 0xd4, 0x2f, 0x4e, 0x19, 0xb1, 0x94, 0x86, 0xea, 0x79, 0xc6,
-	0x5f, 0x4a, 0x31, 0xfd, 0x23, 0x97, 0xd2, 0xa1, 0xbb, 0xf8, 0x40, 0x83, 0x25, 0x75, 0xf1, 0x13,
-	0x5a, 0x4b, 0xc3, 0x66, 0x7a, 0x99, 0x57, 0xfd, 0xda, 0xa1, 0x64, 0x22, 0x2f, 0xee, 0xc1, 0x4c,
-	0xb2, 0xc4, 0x27, 0xc5, 0x8b, 0xca, 0xaa, 0xa8, 0xfa, 0xa5, 0xb1, 0x78, 0xa3, 0xce, 0xde, 0x82,
-	0x72, 0xec, 0x9f, 0x5e, 0xe8, 0xfc, 0x08, 0x1c, 0xc7, 0xff, 0xf6, 0x74, 0x90, 0x27, 0xdf, 0x80,
-	0x52, 0xf4, 0x07, 0x2d, 0x74, 0x2e, 0x15, 0xbf, 0x87, 0x51, 0xb9, 0x05, 0xd0, 0xff, 0xf7, 0x15,
-	0x7a, 0x42, 0xa9, 0x73, 0xe8, 0xef, 0x59, 0x07, 0x29, 0x8d, 0x86, 0x2f, 0x9e, 0x5c, 0x46, 0x0d,
-	0x3f, 0xfe, 0x46, 0x78, 0x90, 0xda, 0x5d, 0xa8, 0x24, 0x5e, 0xf6, 0xd3, 0x96, 0xb0, 0xa2, 0xe0,
-	0xa2, 0x7e, 0x71, 0x1c, 0xd6, 0x68, 0xfe, 0x76, 0xa1, 0x92, 0x78, 0x67, 0x4d, 0xe9, 0x49, 0xf5,
-	0xac, 0x9c, 0xd2, 0x93, 0xf2, 0xd9, 0xd6, 0x98, 0x42, 0x5f, 0x8d, 0x3d, 0xe9, 0x26, 0x9e, 0xcd,
-	0xd1, 0xd5, 0x91, 0x7a, 0x54, 0x55, 0x03, 0xf5, 0xb5, 0xc3, 0x88, 0x44, 0x26, 0x48, 0x54, 0x09,
-	0x97, 0xa6, 0xa3, 0xea, 0x30, 0x33, 0xb5, 0x05, 0x79, 0xf1, 0x72, 0x8a, 0x8c, 0x94, 0x1a, 0x89,
-	0xd8, 0xb3, 0x6a, 0xfd, 0x71, 0x25, 0x4f, 0xf2, 0x51, 0x51, 0x28, 0x15, 0x2f, 0x63, 0x29, 0x4a,
-	0x13, 0xcf, 0x66, 0xe3, 0x2a, 0x35, 0x21, 0x2f, 0x52, 0xdb, 0x29, 0x4a, 0x13, 0xcf, 0x3a, 0xf5,
-	0xd1, 0x3c, 0x22, 0x1f, 0x3e, 0x85, 0x36, 0x21, 0xc7, 0x53, 0xc0, 0x68, 0x65, 0x54, 0x7a, 0x78,
-	0x94, 0xc6, 0x44, 0x06, 0xd9, 0x98, 0x42, 0x5f, 0x82, 0x1c, 0xbf, 0xe9, 0xa4, 0x68, 0x8c, 0xe7,
-	0x78, 0xeb, 0x23, 0x59, 0x42, 0x13, 0x9b, 0xa0, 0xc7, 0x33, 0x40, 0x29, 0x5b, 0x96, 0x22, 0x47,
-	0x56, 0x1f, 0x87, 0x33, 0xec, 0xe5, 0x9b, 0x1a, 0xd4, 0xd2, 0x92, 0x05, 0x28, 0xf5, 0x5c, 0x32,
-	0x2a, 0xe3, 0x51, 0x7f, 0xf6, 0x90, 0x52, 0x91, 0x0b, 0xdf, 0x87, 0x79, 0xc5, 0x15, 0x15, 0x5d,
-	0x49, 0xd3, 0x97, 0x72, 0xbb, 0xae, 0x3f, 0x3d, 0xbe, 0x40, 0xd4, 0xf7, 0x26, 0xe4, 0xf8, 0xd5,
-	0x32, 0x65, 0xfa, 0xe2, 0x37, 0xd5, 0x14, 0x40, 0x24, 0x6e, 0xa6, 0xc6, 0x14, 0xc2, 0xa0, 0xc7,
-	0xef, 0x99, 0x29, 0xf3, 0xa7, 0xb8, 0xa2, 0xd6, 0x2f, 0x8c, 0xc1, 0x19, 0x75, 0x63, 0x01, 0xf4,
-	0xef, 0x79, 0x29, 0xbb, 0xc3, 0xd0, 0x55, 0xb3, 0x7e, 0xfe, 0x40, 0xbe, 0xb0, 0x83, 0xb5, 0x2e,
-	0xe8, 0x9b, 0x81, 0x7f, 0xbf, 0x17, 0xde, 0xaa, 0xfe, 0x33, 0xe3, 0xba, 0xf1, 0xec, 0x97, 0xaf,
-	0xb5, 0x1c, 0xba, 0xdb, 0xdd, 0x66, 0x91, 0xeb, 0x8a, 0xe0, 0x7d, 0xca, 0xf1, 0xe5, 0xaf, 0x2b,
-	0x8e, 0x47, 0x71, 0xe0, 0xd9, 0xee, 0x15, 0xae, 0x4b, 0x52, 0x3b, 0xdb, 0xdb, 0x79, 0xfe, 0x7d,
-	0xed, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x1a, 0xa9, 0xcd, 0x14, 0x09, 0x3e, 0x00, 0x00,
+	// 3719 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x1b, 0x4b, 0x8c, 0x1c, 0x47,
+	0xd5, 0x3d, 0xb3, 0xb3, 0x33, 0xf3, 0x66, 0x66, 0x77, 0x5c, 0xbb, 0x5e, 0x4f, 0xc6, 0x76, 0xbc,
+	0xee, 0xe0, 0xf8, 0x97, 0xd8, 0xf1, 0x3a, 0x3f, 0x12, 0x20, 0xb1, 0xbd, 0xd8, 0x5e, 0xc5, 0x36,
+	0x9b, 0x9e, 0x38, 0x52, 0x88, 0xac, 0x56, 0x6f, 0x77, 0xed, 0x6e, 0x6b, 0x7b, 0xba, 0x87, 0xae,
+	0x1a, 0xdb, 0x93, 0x13, 0x28, 0x08, 0x09, 0x05, 0x12, 0x21, 0x10, 0x08, 0x21, 0x38, 0x00, 0x39,
+	0x70, 0x03, 0x72, 0x00, 0x71, 0x44, 0x08, 0x71, 0x00, 0x05, 0xb8, 0x72, 0xe1, 0xc2, 0x09, 0x71,
+	0xe1, 0x86, 0xc4, 0x01, 0xd5, 0xa7, 0x7b, 0xba, 0x67, 0xaa, 0x67, 0x67, 0x3d, 0x31, 0xbb, 0x7b,
+	0xeb, 0x7a, 0xf5, 0x5e, 0xd5, 0xab, 0x57, 0xaf, 0xde, 0x7b, 0xf5, 0xea, 0x35, 0x54, 0xdb, 0xae,
+	0x77, 0xaf, 0x4b, 0xce, 0x77, 0xc2, 0x80, 0x06, 0x68, 0x2e, 0xd9, 0x3a, 0x2f, 0x1a, 0xcd, 0xaa,
+	0x1d, 0xb4, 0xdb, 0x81, 0x2f, 0x80, 0xcd, 0x2a, 0xb1, 0x37, 0x71, 0xdb, 0x12, 0x2d, 0xfd, 0x47,
+	0x1a, 0xa0, 0xab, 0x21, 0xb6, 0x28, 0xbe, 0xec, 0xb9, 0x16, 0x31, 0xf0, 0x97, 0xba, 0x98, 0x50,
+	0xf4, 0x0c, 0x4c, 0xad, 0x59, 0x04, 0x37, 0xb4, 0x45, 0xed, 0x74, 0x65, 0xe9, 0xe8, 0xf9, 0xd4,
+	0xb0, 0x72, 0xb8, 0x5b, 0x64, 0xe3, 0x8a, 0x45, 0xb0, 0xc1, 0x31, 0xd1, 0x61, 0x28, 0x3a, 0x6b,
+	0xa6, 0x6f, 0xb5, 0x71, 0x23, 0xb7, 0xa8, 0x9d, 0x2e, 0x1b, 0xd3, 0xce, 0xda, 0x6d, 0xab, 0x8d,
+	0xd1, 0x29, 0x98, 0xb5, 0x03, 0xcf, 0xc3, 0x36, 0x75, 0x03, 0x5f, 0x20, 0xe4, 0x39, 0xc2, 0x4c,
+	0x1f, 0xcc, 0x11, 0xe7, 0xa1, 0x60, 0x31, 0x1e, 0x1a, 0x53, 0xbc, 0x5b, 0x34, 0x74, 0x02, 0xf5,
+	0xe5, 0x30, 0xe8, 0x3c, 0x2a, 0xee, 0xe2, 0x49, 0xf3, 0xc9, 0x49, 0x7f, 0xa8, 0xc1, 0xc1, 0xcb,
+	0x1e, 0xc5, 0xe1, 0x1e, 0x15, 0xca, 0xc7, 0x1a, 0x2c, 0x70, 0xfe, 0xae, 0xc6, 0xd8, 0xbb, 0xc9,
+	0xe4, 0x65, 0x80, 0x4e, 0x18, 0x74, 0x70, 0x48, 0x5d, 0xcc, 0x38, 0xcd, 0x9f, 0xae, 0x2c, 0x9d,
+	0x50, 0xce, 0xfc, 0x1a, 0xee, 0xbd, 0x69, 0x79, 0x5d, 0xbc, 0x6a, 0xb9, 0xa1, 0x91, 0x20, 0xd2,
+	0xff, 0xa9, 0xc1, 0x61, 0xa1, 0x87, 0x7b, 0x63, 0x49, 0x0b, 0x30, 0x2d, 0xce, 0x09, 0x17, 0x7c,
+	0xd5, 0x90, 0x2d, 0x74, 0x0c, 0x80, 0x6c, 0x5a, 0xa1, 0x43, 0x4c, 0xbf, 0xdb, 0x6e, 0x14, 0x16,
+	0xb5, 0xd3, 0x05, 0xa3, 0x2c, 0x20, 0xb7, 0xbb, 0x6d, 0x74, 0x12, 0x66, 0xfc, 0x6e, 0xdb, 0xec,
+	0x58, 0x21, 0x75, 0xd9, 0x58, 0xa4, 0x31, 0xbd, 0xa8, 0x9d, 0xce, 0x1b, 0x35, 0xbf, 0xdb, 0x5e,
+	0x8d, 0x81, 0xfa, 0x7b, 0x1a, 0x1c, 0x62, 0x5a, 0xbd, 0x27, 0xd6, 0xaa, 0xff, 0x4c, 0x83, 0xf9,
+	0x1b, 0x16, 0xd9, 0x1b, 0x82, 0x3f, 0x06, 0x40, 0xdd, 0x36, 0x36, 0x09, 0xb5, 0xda, 0x1d, 0x2e,
+	0xfc, 0x29, 0xa3, 0xcc, 0x20, 0x2d, 0x06, 0xd0, 0xdf, 0x82, 0xea, 0x95, 0x20, 0xf0, 0x0c, 0x4c,
+	0x3a, 0x81, 0x4f, 0x30, 0xba, 0x04, 0xd3, 0x84, 0x5a, 0xb4, 0x4b, 0x24, 0x93, 0x47, 0x94, 0x4c,
+	0xb6, 0x38, 0x8a, 0x21, 0x51, 0xd9, 0xa1, 0xba, 0xc7, 0xb4, 0x90, 0xf3, 0x58, 0x32, 0x44, 0x43,
+	0x7f, 0x1b, 0x66, 0x5a, 0x34, 0x74, 0xfd, 0x8d, 0x4f, 0x70, 0xf0, 0x72, 0x34, 0xf8, 0x5f, 0x35,
+	0x78, 0x6c, 0x19, 0x13, 0x3b, 0x74, 0xd7, 0xf6, 0x88, 0x86, 0xeb, 0x50, 0xed, 0x43, 0x56, 0x96,
+	0xb9, 0xa8, 0xf3, 0x46, 0x0a, 0x36, 0xb0, 0x19, 0x85, 0xc1, 0xcd, 0xf8, 0xfd, 0x14, 0x34, 0x55,
+	0x8b, 0x9a, 0x44, 0x7c, 0x9f, 0x8d, 0x0f, 0x5e, 0x8e, 0x13, 0x9d, 0x4c, 0x13, 0x89, 0xbe, 0xf3,
+	0xfd, 0xd9, 0x5a, 0x1c, 0x10, 0x9f, 0xcf, 0xc1, 0x55, 0xe5, 0x15, 0xab, 0x5a, 0x82, 0x43, 0xf7,
+	0xdc, 0x90, 0x76, 0x2d, 0xcf, 0xb4, 0x37, 0x2d, 0xdf, 0xc7, 0x1e, 0x97, 0x93, 0xb0, 0x5c, 0x65,
+	0x63, 0x4e, 0x76, 0x5e, 0x15, 0x7d, 0x4c, 0x58, 0x04, 0x3d, 0x0b, 0x0b, 0x9d, 0xcd, 0x1e, 0x71,
+	0xed, 0x21, 0xa2, 0x02, 0x27, 0x9a, 0x8f, 0x7a, 0x53, 0x54, 0xe7, 0xe0, 0xa0, 0xcd, 0x8d, 0x9a,
+	0x63, 0x32, 0xa9, 0x09, 0x31, 0x4e, 0x73, 0x31, 0xd6, 0x65, 0xc7, 0x1b, 0x11, 0x9c, 0xb1, 0x15,
+	0x21, 0x77, 0xa9, 0x9d, 0x20, 0x28, 0x72, 0x82, 0x39, 0xd9, 0x79, 0x87, 0xda, 0x7d, 0x9a, 0xb4,
+	0x39, 0x2a, 0x0d, 0x9a, 0xa3, 0x06, 0x14, 0xb9, 0xc3, 0xc0, 0xa4, 0x51, 0xe6, 0x6c, 0x46, 0x4d,
+	0xb4, 0x02, 0xb3, 0x84, 0x5a, 0x21, 0x35, 0x3b, 0x01, 0x91, 0x96, 0x0a, 0xb8, 0xdd, 0x5e, 0xcc,
+	0xb2, 0xdb, 0xcb, 0x16, 0xb5, 0xb8, 0xd9, 0x9e, 0xe1, 0x84, 0xab, 0x11, 0xdd, 0x80, 0xf5, 0xaf,
+	0x3c, 0x8c, 0xf5, 0xff, 0xb7, 0x06, 0x87, 0x6e, 0x06, 0x96, 0xb3, 0x37, 0x4e, 0x06, 0x86, 0x46,
+	0xd7, 0x77, 0x7d, 0x07, 0x3f, 0xc0, 0x8e, 0x49, 0xf0, 0x46, 0x1b, 0xfb, 0x4c, 0x4e, 0x9e, 0x6b,
+	0xf7, 0xf8, 0x29, 0x99, 0x59, 0x3a, 0xa7, 0xe4, 0xe3, 0x4e, 0x44, 0xd4, 0x12, 0x34, 0xab, 0x9c,
+	0xc4, 0x58, 0xe8, 0x2a, 0xe1, 0xfa, 0xfb, 0x1a, 0x34, 0x0c, 0xec, 0x61, 0x8b, 0xec, 0x0d, 0x8b,
+	0xa0, 0x7f, 0x47, 0x83, 0xc7, 0xaf, 0x63, 0x9a, 0x38, 0x5b, 0xd4, 0xa2, 0x2e, 0xa1, 0xae, 0xbd,
+	0x9b, 0x21, 0x90, 0xfe, 0x81, 0x06, 0xc7, 0x33, 0xd9, 0x9a, 0xc4, 0xd4, 0xbc, 0x00, 0x05, 0xf6,
+	0x45, 0x1a, 0xb9, 0x71, 0x75, 0x56, 0xe0, 0xeb, 0x7f, 0xd7, 0x60, 0xa1, 0xb5, 0x19, 0xdc, 0xef,
+	0xb3, 0xf4, 0x28, 0x04, 0x94, 0x36, 0xbe, 0xf9, 0x01, 0xe3, 0x8b, 0x2e, 0xc2, 0x14, 0xed, 0x75,
+	0xb0, 0xd4, 0xc8, 0x63, 0xe7, 0x15, 0x91, 0xff, 0x79, 0xc6, 0xe4, 0x1b, 0xbd, 0x0e, 0x36, 0x38,
+	0x2a, 0x3a, 0x03, 0xf5, 0x01, 0x91, 0x47, 0xe6, 0x6b, 0x36, 0x2d, 0x73, 0xa2, 0xff, 0x3a, 0x07,
+	0x87, 0x87, 0x96, 0x38, 0x89, 0xb0, 0x55, 0x73, 0xe7, 0x94, 0x73, 0xb3, 0x20, 0x2a, 0x81, 0xea,
+	0x3a, 0x2c, 0x38, 0xcf, 0xb3, 0x20, 0xaa, 0x0f, 0x5d, 0x71, 0x08, 0x7a, 0x1a, 0xd0, 0x90, 0x71,
+	0x15, 0x36, 0x7c, 0xca, 0x38, 0x38, 0x68, 0x5d, 0xb9, 0x05, 0x57, 0x9a, 0x57, 0x21, 0x82, 0x29,
+	0x63, 0x5e, 0x61, 0x5f, 0x09, 0xba, 0x08, 0xf3, 0xae, 0x7f, 0x0b, 0xb7, 0x83, 0xb0, 0x67, 0x76,
+	0x70, 0x68, 0x63, 0x9f, 0x5a, 0x1b, 0x98, 0x85, 0x75, 0x8c, 0xa3, 0xb9, 0xa8, 0x6f, 0xb5, 0xdf,
+	0xa5, 0x7f, 0xa4, 0xc1, 0x82, 0x08, 0x65, 0xe3, 0x88, 0x6f, 0x37, 0xad, 0xd9, 0x49, 0x98, 0x89,
+	0xc3, 0x51, 0x81, 0x27, 0xae, 0x12, 0xb5, 0x18, 0xca, 0x4f, 0xd9, 0x2f, 0x34, 0x98, 0x67, 0x21,
+	0xe9, 0x7e, 0xe2, 0xf9, 0xe7, 0x1a, 0xcc, 0xdd, 0xb0, 0xc8, 0x7e, 0x62, 0xf9, 0x07, 0x39, 0xe1,
+	0xe9, 0x62, 0x9e, 0x77, 0xf5, 0x76, 0x79, 0x0a, 0x66, 0xd3, 0x4c, 0x47, 0x31, 0xd0, 0x4c, 0x8a,
+	0x6b, 0x32, 0xd2, 0x25, 0x16, 0x3e, 0x39, 0x97, 0xf8, 0xab, 0xbe, 0x4b, 0xdc, 0x5f, 0x02, 0xd2,
+	0x7f, 0xa3, 0xc1, 0xb1, 0xeb, 0x98, 0xc6, 0x5c, 0xef, 0x09, 0xd7, 0x39, 0xae, 0x52, 0xbe, 0x2f,
+	0x1c, 0xbf, 0x92, 0xf9, 0x5d, 0x71, 0xb0, 0xef, 0xe5, 0xe0, 0x10, 0xf3, 0x3e, 0x7b, 0x43, 0x09,
+	0xc6, 0xb9, 0x29, 0x29, 0x14, 0xa5, 0xa0, 0x3c, 0x49, 0x91, 0xdb, 0x9e, 0x1e, 0xdb, 0x6d, 0xeb,
+	0xbf, 0xcc, 0xc1, 0xc2, 0xa0, 0x34, 0x26, 0xd9, 0x16, 0x05, 0xaf, 0x39, 0x25, 0xaf, 0x3a, 0x54,
+	0x63, 0xc8, 0xca, 0x72, 0xe4, 0x86, 0x53, 0xb0, 0x3d, 0xeb, 0x85, 0xbf, 0xa1, 0xc1, 0x42, 0x74,
+	0x37, 0x95, 0x46, 0xe6, 0xe1, 0x75, 0x68, 0x50, 0x03, 0x72, 0x0a, 0x0d, 0x38, 0x0a, 0x65, 0x69,
+	0x18, 0xe3, 0x6b, 0x67, 0x1f, 0xa0, 0x7f, 0xa8, 0xc1, 0xe1, 0x21, 0x76, 0x26, 0xd9, 0xc4, 0x06,
+	0x14, 0xb9, 0x09, 0x8d, 0xb9, 0x89, 0x9a, 0xac, 0x67, 0xad, 0xeb, 0x7a, 0x4e, 0xcc, 0x46, 0xd4,
+	0x44, 0x27, 0xa0, 0x8a, 0x7d, 0x6b, 0xcd, 0xc3, 0x26, 0xc7, 0xe5, 0x8a, 0x5c, 0x32, 0x2a, 0x02,
+	0xb6, 0xc2, 0x40, 0xfa, 0x37, 0x35, 0x98, 0x63, 0xba, 0x26, 0x79, 0x24, 0x8f, 0x56, 0x66, 0x8b,
+	0x50, 0x49, 0x28, 0x93, 0x64, 0x37, 0x09, 0xd2, 0xb7, 0x60, 0x3e, 0xcd, 0xce, 0x24, 0x32, 0x7b,
+	0x1c, 0x20, 0xde, 0x11, 0xa1, 0xf3, 0x79, 0x23, 0x01, 0xd1, 0xff, 0x15, 0x27, 0xc3, 0xb9, 0x30,
+	0x76, 0x39, 0x0d, 0xb6, 0xee, 0x62, 0xcf, 0x49, 0x5a, 0xed, 0x32, 0x87, 0xf0, 0xee, 0x65, 0xa8,
+	0xe2, 0x07, 0x34, 0xb4, 0x58, 0xa6, 0xd1, 0x6a, 0x8b, 0xc3, 0x33, 0x96, 0x81, 0xad, 0x70, 0xb2,
+	0x55, 0x4e, 0xa5, 0xff, 0x81, 0xc5, 0x7c, 0x52, 0x29, 0xf7, 0xfa, 0x8a, 0x8f, 0x01, 0x70, 0xa5,
+	0x15, 0xdd, 0x05, 0xd1, 0xcd, 0x21, 0xdc, 0x85, 0x7d, 0xa8, 0x41, 0x9d, 0x2f, 0x41, 0xac, 0xa7,
+	0xc3, 0x86, 0x1d, 0xa0, 0xd1, 0x06, 0x68, 0x46, 0x1c, 0xa1, 0x4f, 0xc3, 0xb4, 0x14, 0x6c, 0x7e,
+	0x5c, 0xc1, 0x4a, 0x82, 0x6d, 0x96, 0xa1, 0xff, 0x98, 0x65, 0x7e, 0xd3, 0x22, 0x9f, 0x44, 0xa3,
+	0xdf, 0x00, 0x24, 0x56, 0xe8, 0xf4, 0x97, 0x1d, 0xb9, 0xdb, 0x93, 0x4a, 0xdf, 0x32, 0x28, 0x24,
+	0xe3, 0xa0, 0x3b, 0x00, 0x21, 0xfa, 0x9f, 0x35, 0x38, 0x7a, 0x1d, 0x53, 0x8e, 0x7a, 0x85, 0xd9,
+	0x8e, 0xd5, 0x30, 0xd8, 0x08, 0x31, 0x21, 0xfb, 0x57, 0x3f, 0xbe, 0x2b, 0xe2, 0x33, 0xd5, 0x92,
+	0x26, 0x91, 0xff, 0x09, 0xa8, 0x46, 0x51, 0x71, 0x18, 0xdc, 0x27, 0x52, 0x8f, 0x2a, 0x12, 0x66,
+	0x04, 0xf7, 0xb9, 0x42, 0xd0, 0x80, 0x5a, 0x9e, 0x40, 0x90, 0x8e, 0x81, 0x43, 0x58, 0x37, 0x3f,
+	0x83, 0x11, 0x63, 0x6c, 0x70, 0xbc, 0x7f, 0x65, 0xfc, 0x53, 0x0d, 0x0e, 0x0d, 0x2c, 0x65, 0x12,
+	0xd9, 0x3e, 0x27, 0xa2, 0x47, 0xb1, 0x98, 0x99, 0xa5, 0xe3, 0x4a, 0x9a, 0xc4, 0x64, 0x02, 0x1b,
+	0x1d, 0x87, 0xca, 0xba, 0xe5, 0x7a, 0x66, 0x88, 0x2d, 0x12, 0xf8, 0x72, 0xa1, 0xc0, 0x40, 0x06,
+	0x87, 0xe8, 0xbf, 0xd3, 0xc4, 0x93, 0xe2, 0x3e, 0xb7, 0x78, 0x3f, 0xc9, 0x41, 0x6d, 0xc5, 0x27,
+	0x38, 0xa4, 0x7b, 0xff, 0x86, 0x81, 0x5e, 0x81, 0x0a, 0x5f, 0x18, 0x31, 0x1d, 0x8b, 0x5a, 0xd2,
+	0x5d, 0x3d, 0xae, 0x4c, 0xed, 0x5f, 0x63, 0x78, 0x2c, 0xd9, 0x6c, 0x08, 0xe9, 0x10, 0xf6, 0x8d,
+	0x8e, 0x40, 0x79, 0xd3, 0x22, 0x9b, 0xe6, 0x16, 0xee, 0x89, 0xb0, 0xaf, 0x66, 0x94, 0x18, 0xe0,
+	0x35, 0xdc, 0x23, 0xe8, 0x31, 0x28, 0xb1, 0x57, 0x37, 0x7e, 0xc0, 0x58, 0xb2, 0xbc, 0x66, 0x14,
+	0xfd, 0x6e, 0x9b, 0x1f, 0xaf, 0x3f, 0xe6, 0x60, 0xe6, 0x56, 0x97, 0x5a, 0xf2, 0x61, 0xa2, 0xeb,
+	0xd1, 0x87, 0x53, 0xc6, 0xb3, 0x90, 0x17, 0x31, 0x03, 0xa3, 0x68, 0x28, 0x19, 0x5f, 0x59, 0x26,
+	0x06, 0x43, 0x62, 0x1b, 0x47, 0xba, 0xb6, 0x2d, 0x83, 0xac, 0x3c, 0x67, 0xb6, 0xcc, 0x20, 0x5c,
+	0xe3, 0xd8, 0x52, 0x70, 0x18, 0xc6, 0x21, 0x18, 0x5f, 0x0a, 0x0e, 0x43, 0xd1, 0xa9, 0x43, 0xd5,
+	0xb2, 0xb7, 0xfc, 0xe0, 0xbe, 0x87, 0x9d, 0x0d, 0xec, 0xf0, 0x6d, 0x2f, 0x19, 0x29, 0x98, 0x50,
+	0x0c, 0xb6, 0xf1, 0xa6, 0xed, 0x53, 0xf9, 0xc0, 0x58, 0x16, 0x90, 0xab, 0x3e, 0x65, 0xdd, 0x0e,
+	0xf6, 0x30, 0xc5, 0xbc, 0xbb, 0x28, 0xba, 0x05, 0x44, 0x76, 0x77, 0x3b, 0x31, 0x75, 0x49, 0x74,
+	0x0b, 0x08, 0xeb, 0x3e, 0x0a, 0xe5, 0xfe, 0xcb, 0x43, 0xb9, 0x9f, 0x74, 0xe4, 0x00, 0xfd, 0x6f,
+	0x1a, 0xd4, 0x96, 0xf9, 0x50, 0xfb, 0x40, 0xe9, 0x10, 0x4c, 0xe1, 0x07, 0x9d, 0x50, 0x1e, 0x1d,
+	0xfe, 0x3d, 0x52, 0x8f, 0xf4, 0x7b, 0x50, 0x5f, 0xf5, 0x2c, 0x1b, 0x6f, 0x06, 0x9e, 0x83, 0x43,
+	0xee, 0xdb, 0x51, 0x1d, 0xf2, 0xd4, 0xda, 0x90, 0xc1, 0x03, 0xfb, 0x44, 0x2f, 0xca, 0x1b, 0x9c,
+	0x30, 0x4b, 0x9f, 0x52, 0x7a, 0xd9, 0xc4, 0x30, 0x89, 0xfc, 0xeb, 0x02, 0x4c, 0xf3, 0xd7, 0x40,
+	0x11, 0x56, 0x54, 0x0d, 0xd9, 0xd2, 0xef, 0xa6, 0xe6, 0xbd, 0x1e, 0x06, 0xdd, 0x0e, 0x5a, 0x81,
+	0x6a, 0xa7, 0x0f, 0x63, 0xba, 0x9a, 0xed, 0xd3, 0x07, 0x99, 0x36, 0x52, 0xa4, 0xfa, 0x7f, 0xa6,
+	0xa0, 0xd6, 0xc2, 0x56, 0x68, 0x6f, 0xee, 0x8b, 0x5c, 0x53, 0x1d, 0xf2, 0x0e, 0xf1, 0xe4, 0xae,
+	0xb1, 0x4f, 0xf6, 0x8c, 0x96, 0x58, 0x90, 0xb9, 0xc1, 0x04, 0xc4, 0xf5, 0xbe, 0x6a, 0xd4, 0x3b,
+	0x83, 0x82, 0x7b, 0x01, 0x4a, 0x0e, 0xf1, 0x4c, 0xbe, 0x45, 0x45, 0xbe, 0x45, 0xea, 0xf5, 0x2d,
+	0x13, 0x8f, 0x6f, 0x4d, 0xd1, 0x11, 0x1f, 0xe8, 0x09, 0xa8, 0x05, 0x5d, 0xda, 0xe9, 0x52, 0x53,
+	0xd8, 0x9d, 0x46, 0x89, 0xb3, 0x57, 0x15, 0x40, 0x6e, 0x96, 0x08, 0xba, 0x06, 0x35, 0xc2, 0x45,
+	0x19, 0x45, 0xde, 0xe5, 0x71, 0x03, 0xc4, 0xaa, 0xa0, 0x13, 0xa1, 0x37, 0x4b, 0x87, 0xd3, 0xd0,
+	0xba, 0x87, 0xbd, 0xc4, 0x3b, 0x1f, 0xf0, 0xd3, 0x36, 0x2b, 0xe0, 0xfd, 0x37, 0xbe, 0x0b, 0x30,
+	0xb7, 0xd1, 0xb5, 0x42, 0xcb, 0xa7, 0x18, 0x27, 0xb0, 0x2b, 0x1c, 0x1b, 0xc5, 0x5d, 0x7d, 0x82,
+	0xe7, 0xa1, 0x2c, 0xe6, 0x62, 0x16, 0xab, 0xba, 0x8d, 0xc5, 0xea, 0xa3, 0x22, 0x03, 0x0e, 0xda,
+	0x81, 0x4f, 0x5c, 0x42, 0xb1, 0x6f, 0xf7, 0x4c, 0x0f, 0xdf, 0xc3, 0x5e, 0xa3, 0xc6, 0x45, 0x78,
+	0x52, 0xb9, 0xbe, 0xab, 0x7d, 0xec, 0x9b, 0x0c, 0xd9, 0xa8, 0xdb, 0x03, 0x10, 0xfd, 0x35, 0x98,
+	0xba, 0xe1, 0x52, 0xbe, 0xa9, 0x2b, 0xcb, 0x42, 0x8b, 0xf3, 0xc2, 0x4a, 0x3e, 0x06, 0xa5, 0x30,
+	0xb8, 0x2f, 0xfc, 0x41, 0x8e, 0x1f, 0x87, 0x62, 0x18, 0xdc, 0xe7, 0xc6, 0x9e, 0x17, 0x5f, 0x04,
+	0xa1, 0x3c, 0x27, 0x39, 0x43, 0xb6, 0x58, 0xf2, 0x34, 0x56, 0x64, 0x66, 0xca, 0xc9, 0xc3, 0xd9,
+	0xf2, 0x57, 0xa0, 0x18, 0x0a, 0xfa, 0x91, 0x6f, 0xcc, 0xc9, 0x99, 0xb8, 0x3f, 0x8a, 0xa8, 0x62,
+	0xfb, 0xc3, 0x82, 0x3a, 0x0e, 0xe2, 0x2a, 0x5f, 0x92, 0xf6, 0xc7, 0xf2, 0xa4, 0xa3, 0x39, 0x09,
+	0x33, 0x6d, 0x97, 0x10, 0xd7, 0xdf, 0x30, 0xc5, 0x93, 0xac, 0x54, 0xf8, 0x9a, 0x84, 0xb6, 0x38,
+	0x10, 0x5d, 0x83, 0x0a, 0xef, 0x36, 0x45, 0xae, 0xac, 0x30, 0xe2, 0xa0, 0x73, 0x0a, 0xc1, 0x17,
+	0x5b, 0x13, 0x31, 0xc4, 0xeb, 0x2f, 0xff, 0xd6, 0xbf, 0x92, 0x83, 0xfa, 0x20, 0x02, 0x0b, 0x50,
+	0x93, 0xcf, 0xd5, 0xd2, 0x8e, 0x55, 0xec, 0xfe, 0x2b, 0x35, 0x93, 0xb6, 0x1f, 0x38, 0x38, 0xbe,
+	0x05, 0xc9, 0x16, 0x23, 0x65, 0x1e, 0xd5, 0x24, 0x36, 0xc3, 0x75, 0xa2, 0xdb, 0x39, 0x83, 0xb5,
+	0x04, 0x88, 0xa1, 0xc8, 0xeb, 0x33, 0x31, 0x37, 0x5d, 0x2a, 0x33, 0x63, 0x95, 0x08, 0x76, 0xc3,
+	0xa5, 0xcc, 0x08, 0x6f, 0xba, 0x7c, 0x59, 0xac, 0x8b, 0x7f, 0xa3, 0x27, 0x61, 0x96, 0xcd, 0x61,
+	0x7a, 0x96, 0xd0, 0xb4, 0x6e, 0xbf, 0x4c, 0x26, 0x70, 0xf0, 0x4d, 0x01, 0xbd, 0x43, 0xd0, 0x33,
+	0x30, 0x4f, 0x43, 0xcb, 0x27, 0x9d, 0x20, 0xa4, 0x49, 0x64, 0xe1, 0xd3, 0x50, 0xdc, 0x17, 0x53,
+	0xe8, 0x5f, 0xd5, 0xa0, 0x7a, 0xcd, 0xeb, 0x92, 0x47, 0x61, 0xe9, 0x54, 0x2f, 0x58, 0x79, 0xf5,
+	0xeb, 0xd9, 0xb7, 0x72, 0x50, 0x93, 0x6c, 0x4c, 0x12, 0x01, 0x67, 0xb2, 0xd2, 0x82, 0x0a, 0x9b,
+	0x92, 0x65, 0xe2, 0xa3, 0xbc, 0x5c, 0x65, 0x69, 0x49, 0xa9, 0x32, 0x29, 0x36, 0x78, 0xdd, 0x44,
+	0x8b, 0x13, 0x7d, 0xde, 0xa7, 0x61, 0xcf, 0x00, 0x3b, 0x06, 0x34, 0xef, 0xc2, 0xec, 0x40, 0x37,
+	0x3b, 0xb5, 0x5b, 0xb8, 0x17, 0x39, 0xbf, 0x2d, 0xdc, 0x43, 0xcf, 0x26, 0xab, 0x5b, 0xb2, 0x42,
+	0xb8, 0x9b, 0x81, 0xbf, 0x71, 0x39, 0x0c, 0xad, 0x9e, 0xac, 0x7e, 0x79, 0x29, 0xf7, 0xa2, 0xa6,
+	0xff, 0x36, 0x0f, 0xd5, 0xd7, 0xbb, 0x38, 0xec, 0xed, 0xa6, 0x13, 0x8a, 0x42, 0x82, 0xa9, 0x44,
+	0x48, 0x30, 0x64, 0xf7, 0x0b, 0x0a, 0xbb, 0xaf, 0xf0, 0x5e, 0xd3, 0x4a, 0xef, 0xa5, 0x32, 0xec,
+	0xc5, 0x1d, 0x19, 0xf6, 0x52, 0xa6, 0x61, 0x57, 0x1a, 0xe8, 0xf2, 0x44, 0x06, 0x9a, 0x9d, 0xfe,
+	0x60, 0x7d, 0x9d, 0x60, 0xca, 0xdd, 0x4f, 0xde, 0x90, 0x2d, 0x56, 0xc6, 0xe4, 0xb9, 0x6d, 0x97,
+	0x72, 0x3f, 0x93, 0x37, 0x44, 0x83, 0x9f, 0x2f, 0xb9, 0x89, 0x13, 0x19, 0xe0, 0xd4, 0x6d, 0x20,
+	0xb7, 0xd3, 0xdb, 0x00, 0x7b, 0xac, 0x2c, 0xbf, 0x89, 0x6d, 0x1a, 0x84, 0xcc, 0x93, 0x28, 0x76,
+	0x5f, 0x1b, 0xe3, 0xc2, 0x95, 0x1b, 0xbc, 0x70, 0x5d, 0x82, 0x92, 0xeb, 0x98, 0x16, 0x53, 0xdc,
+	0x46, 0x7e, 0x1b, 0xb7, 0x59, 0x74, 0x1d, 0xae, 0xe1, 0xe3, 0xbf, 0x10, 0x7d, 0x4f, 0x83, 0xaa,
+	0xe0, 0x99, 0x08, 0xca, 0x97, 0x13, 0xd3, 0x69, 0xaa, 0xd3, 0x24, 0x1b, 0xf1, 0x42, 0x6f, 0x1c,
+	0xe8, 0x4f, 0x7b, 0x19, 0x80, 0xc9, 0x4e, 0x92, 0x8b, 0xc3, 0xb8, 0xa8, 0xe4, 0x56, 0x90, 0x73,
+	0x39, 0xde, 0x38, 0x60, 0x94, 0x19, 0x15, 0x1f, 0xe2, 0x4a, 0x11, 0x0a, 0x9c, 0x5a, 0xff, 0xaf,
+	0x06, 0x73, 0x57, 0x2d, 0xcf, 0x5e, 0x76, 0x09, 0xb5, 0x7c, 0x7b, 0x82, 0xd0, 0xfe, 0x25, 0x28,
+	0x06, 0x1d, 0xd3, 0xc3, 0xeb, 0x54, 0xb2, 0x74, 0x62, 0xc4, 0x8a, 0x84, 0x18, 0x8c, 0xe9, 0xa0,
+	0x73, 0x13, 0xaf, 0x53, 0xf4, 0x19, 0x28, 0x05, 0x1d, 0x33, 0x74, 0x37, 0x36, 0x69, 0x23, 0x3f,
+	0x2e, 0x71, 0x31, 0xe8, 0x18, 0x8c, 0x22, 0x91, 0xb1, 0x9b, 0xda, 0x61, 0xc6, 0x4e, 0xff, 0xcb,
+	0xd0, 0xf2, 0x27, 0x50, 0xed, 0x97, 0xa0, 0xe4, 0xfa, 0xd4, 0x74, 0x5c, 0x12, 0x89, 0xe0, 0x98,
+	0x5a, 0x87, 0x7c, 0xca, 0x57, 0xc0, 0xf7, 0xd4, 0xa7, 0x6c, 0x6e, 0xf4, 0x2a, 0xc0, 0xba, 0x17,
+	0x58, 0x92, 0x5a, 0xc8, 0xe0, 0xb8, 0xfa, 0x54, 0x30, 0xb4, 0x88, 0xbe, 0xcc, 0x89, 0xd8, 0x08,
+	0xfd, 0x2d, 0xfd, 0x58, 0x83, 0x43, 0xab, 0x38, 0x14, 0x47, 0x9d, 0xca, 0xec, 0xf9, 0x8a, 0xbf,
+	0x1e, 0xa4, 0x9f, 0x29, 0xb4, 0x81, 0x67, 0x8a, 0x4f, 0x26, 0x69, 0x9f, 0xba, 0x8f, 0x8b, 0x90,
+	0x20, 0xba, 0x8f, 0x47, 0x4f, 0x82, 0x58, 0xbe, 0x1a, 0xab, 0xb7, 0x49, 0xf2, 0x9b, 0x4c, 0xeb,
+	0xe8, 0xdf, 0x16, 0x55, 0x40, 0xca, 0x45, 0x3d, 0xbc, 0xc2, 0x2e, 0x80, 0x74, 0x21, 0x03, 0x0e,
+	0xe5, 0x49, 0x18, 0xb0, 0x1d, 0x19, 0xb5, 0x49, 0xdf, 0xd7, 0x60, 0x31, 0x9b, 0xab, 0x49, 0x7c,
+	0xff, 0xab, 0x50, 0x70, 0xfd, 0xf5, 0x20, 0x4a, 0xe6, 0x9e, 0x55, 0x5f, 0xfc, 0x94, 0xf3, 0x0a,
+	0x42, 0xfd, 0x1f, 0x1a, 0xd4, 0xb9, 0xad, 0xde, 0x85, 0xed, 0x6f, 0xe3, 0xb6, 0x49, 0xdc, 0x77,
+	0x70, 0xb4, 0xfd, 0x6d, 0xdc, 0x6e, 0xb9, 0xef, 0xe0, 0x94, 0x66, 0x14, 0xd2, 0x9a, 0x91, 0x4e,
+	0x77, 0x4d, 0x8f, 0x48, 0xd6, 0x17, 0x53, 0xc9, 0x7a, 0xf6, 0x7a, 0xdd, 0xbc, 0x8e, 0xe9, 0xe0,
+	0x52, 0x77, 0x4f, 0x29, 0x3e, 0xd0, 0xe0, 0x88, 0x92, 0xa1, 0x49, 0xf4, 0xe1, 0xe5, 0xb4, 0x3e,
+	0xa8, 0xef, 0x07, 0x43, 0x53, 0x4a, 0x55, 0xf8, 0x48, 0x03, 0xc4, 0xaa, 0x4e, 0xae, 0x58, 0xde,
+	0x64, 0x06, 0x9e, 0xa5, 0xb6, 0x42, 0xdb, 0x4c, 0xdd, 0x17, 0xca, 0x24, 0xb4, 0x6f, 0x73, 0x00,
+	0xcb, 0xbd, 0x3a, 0x84, 0xca, 0xee, 0xe8, 0xbd, 0x18, 0x1c, 0x42, 0x45, 0x3f, 0x2f, 0x88, 0x25,
+	0xd8, 0xf2, 0xfa, 0x45, 0x24, 0x2b, 0xcb, 0xc2, 0x62, 0xe7, 0x8d, 0xba, 0xe8, 0x68, 0xc5, 0x70,
+	0x76, 0xa1, 0xa9, 0xad, 0xb4, 0x59, 0x80, 0xff, 0xf0, 0x0c, 0x2b, 0x62, 0x83, 0xdc, 0x98, 0x39,
+	0xa5, 0xbc, 0x2a, 0xa7, 0x74, 0x04, 0xca, 0xec, 0xd6, 0xca, 0xc6, 0x76, 0xe4, 0xfb, 0x29, 0xbb,
+	0xc6, 0xb2, 0x19, 0x1d, 0x16, 0x33, 0xad, 0xbb, 0x5e, 0xfc, 0xf4, 0x2f, 0x1a, 0xe8, 0x65, 0xe6,
+	0x14, 0xa3, 0x9f, 0x01, 0xc6, 0xf4, 0x4d, 0x11, 0x05, 0x2b, 0x4a, 0x8f, 0x44, 0x30, 0x61, 0x51,
+	0x3a, 0xb5, 0xc8, 0x56, 0xf4, 0xe8, 0x29, 0x1a, 0xfa, 0x5d, 0x91, 0xaf, 0xe7, 0xe3, 0x4f, 0xf8,
+	0xf6, 0x80, 0x60, 0x8a, 0x8d, 0x29, 0x55, 0x82, 0x7f, 0xb3, 0xb8, 0x62, 0x61, 0x70, 0xfc, 0x49,
+	0x16, 0xf1, 0x7c, 0xfa, 0x41, 0x40, 0x5d, 0xa9, 0x9c, 0x9c, 0x4d, 0xa0, 0x47, 0x7b, 0x66, 0x07,
+	0x5d, 0x9f, 0x4a, 0x7b, 0xc5, 0xf6, 0xec, 0x2a, 0x6b, 0x33, 0x95, 0x8d, 0xea, 0x99, 0x5c, 0x27,
+	0xd2, 0xc5, 0xf8, 0x51, 0xd8, 0xe1, 0x1e, 0x4b, 0x1c, 0xbc, 0xb1, 0xdf, 0x58, 0xe5, 0xa1, 0xbb,
+	0x08, 0xd5, 0xe5, 0x6e, 0xbb, 0x1d, 0xdf, 0x77, 0xd8, 0x7d, 0x5a, 0x7c, 0x8a, 0xe4, 0x94, 0xbc,
+	0x8a, 0x4b, 0x18, 0x4b, 0x41, 0xe9, 0xe7, 0xa0, 0x26, 0x49, 0xa4, 0x9c, 0x9a, 0x50, 0x0a, 0xe5,
+	0xb7, 0xc4, 0x8f, 0xdb, 0xfa, 0x21, 0x98, 0x33, 0xf0, 0x06, 0x33, 0xff, 0xe1, 0x4d, 0xd7, 0xdf,
+	0x92, 0xd3, 0xe8, 0xef, 0x6a, 0x30, 0x9f, 0x86, 0xcb, 0xb1, 0x9e, 0x87, 0xa2, 0xe5, 0x38, 0x21,
+	0x26, 0x64, 0xe4, 0xbe, 0x5e, 0x16, 0x38, 0x46, 0x84, 0x9c, 0xd8, 0xab, 0xdc, 0xd8, 0x7b, 0xa5,
+	0x9b, 0x70, 0xf0, 0x3a, 0xa6, 0xb7, 0x30, 0x0d, 0x27, 0x2a, 0x81, 0x6a, 0xb0, 0x54, 0x0d, 0x27,
+	0x96, 0xc7, 0x36, 0x6a, 0xb2, 0xfa, 0x0e, 0x94, 0x9c, 0x61, 0x12, 0xc5, 0x4a, 0x4a, 0x39, 0x97,
+	0x96, 0xb2, 0x28, 0x46, 0x6d, 0x77, 0x02, 0x9f, 0x69, 0x48, 0xd2, 0x2e, 0xc4, 0x50, 0x66, 0x17,
+	0xce, 0x9e, 0x80, 0x52, 0x54, 0xb5, 0x83, 0x8a, 0x90, 0xbf, 0xec, 0x79, 0xf5, 0x03, 0xa8, 0x0a,
+	0xa5, 0x15, 0x59, 0x9a, 0x52, 0xd7, 0xce, 0x7e, 0x0e, 0x66, 0x07, 0xd2, 0xc2, 0xa8, 0x04, 0x53,
+	0xb7, 0x03, 0x1f, 0xd7, 0x0f, 0xa0, 0x3a, 0x54, 0xaf, 0xb8, 0xbe, 0x15, 0xf6, 0x44, 0x78, 0x5b,
+	0x77, 0xd0, 0x2c, 0x54, 0x78, 0x98, 0x27, 0x01, 0x78, 0xe9, 0x4f, 0xc7, 0xa0, 0x76, 0x8b, 0x2f,
+	0xa6, 0x85, 0xc3, 0x7b, 0xae, 0x8d, 0x91, 0x09, 0xf5, 0xc1, 0x7f, 0xa6, 0xd0, 0x53, 0x4a, 0xc7,
+	0x90, 0xf1, 0x6b, 0x55, 0x73, 0x94, 0x78, 0xf4, 0x03, 0xe8, 0x6d, 0x98, 0x49, 0xff, 0xa6, 0x84,
+	0xd4, 0x71, 0x88, 0xf2, 0x5f, 0xa6, 0xed, 0x06, 0x37, 0xa1, 0x96, 0xfa, 0xeb, 0x08, 0x9d, 0x51,
+	0x8e, 0xad, 0xfa, 0x33, 0xa9, 0xa9, 0xbe, 0x1a, 0x24, 0xff, 0x0c, 0x12, 0xdc, 0xa7, 0x7f, 0x2a,
+	0xc8, 0xe0, 0x5e, 0xf9, 0xe7, 0xc1, 0x76, 0xdc, 0x5b, 0x70, 0x70, 0xa8, 0x78, 0x1f, 0x3d, 0xad,
+	0x1c, 0x3f, 0xab, 0xc8, 0x7f, 0xbb, 0x29, 0xee, 0x03, 0x1a, 0xfe, 0xbb, 0x06, 0x9d, 0x57, 0xef,
+	0x40, 0xd6, 0xbf, 0x45, 0xcd, 0x0b, 0x63, 0xe3, 0xc7, 0x82, 0xfb, 0x9a, 0x06, 0x87, 0x33, 0x2a,
+	0xee, 0xd1, 0x25, 0xe5, 0x70, 0xa3, 0x7f, 0x1b, 0x68, 0x3e, 0xbb, 0x33, 0xa2, 0x98, 0x11, 0x1f,
+	0x66, 0x07, 0x8a, 0xd0, 0xd1, 0xb9, 0xcc, 0x8a, 0xb9, 0xe1, 0x6a, 0xfc, 0xe6, 0x53, 0xe3, 0x21,
+	0xc7, 0xf3, 0xb1, 0x14, 0x58, 0xba, 0x72, 0x3b, 0x63, 0x3e, 0x75, 0x7d, 0xf7, 0x76, 0x1b, 0xfa,
+	0x16, 0xd4, 0x52, 0x25, 0xd6, 0x19, 0x1a, 0xaf, 0x2a, 0xc3, 0xde, 0x6e, 0xe8, 0xbb, 0x50, 0x4d,
+	0x56, 0x42, 0xa3, 0xd3, 0x59, 0x67, 0x69, 0x68, 0xe0, 0x9d, 0x1c, 0xa5, 0x98, 0x98, 0x8c, 0x38,
+	0x4a, 0x43, 0x45, 0x9b, 0xe3, 0x1f, 0xa5, 0xc4, 0xf8, 0x23, 0x8f, 0xd2, 0x8e, 0xa7, 0x78, 0x57,
+	0x84, 0x22, 0x8a, 0x0a, 0x57, 0xb4, 0x94, 0xa5, 0x9b, 0xd9, 0xb5, 0xbc, 0xcd, 0x4b, 0x3b, 0xa2,
+	0x89, 0xa5, 0xb8, 0x05, 0x33, 0xe9, 0x3a, 0xce, 0x0c, 0x29, 0x2a, 0x4b, 0x5f, 0x9b, 0xe7, 0xc6,
+	0xc2, 0x8d, 0x27, 0xbb, 0x03, 0x95, 0xc4, 0x8f, 0xdd, 0xe8, 0xd4, 0x08, 0x3d, 0x4e, 0xfe, 0xe5,
+	0xbc, 0x9d, 0x24, 0x5f, 0x87, 0x72, 0xfc, 0x3f, 0x36, 0x3a, 0x99, 0xa9, 0xbf, 0x3b, 0x19, 0xb2,
+	0x05, 0xd0, 0xff, 0xd9, 0x1a, 0x3d, 0xa9, 0x1c, 0x73, 0xe8, 0x6f, 0xec, 0xed, 0x0f, 0xc4, 0xec,
+	0xc0, 0x1f, 0xd2, 0x19, 0x47, 0x59, 0xfd, 0x1f, 0xf5, 0x76, 0xc3, 0xc7, 0xd2, 0x15, 0xcf, 0xf6,
+	0xa3, 0xa4, 0x9b, 0xac, 0x33, 0xd9, 0x6e, 0xd8, 0x4d, 0xa8, 0x45, 0x96, 0x59, 0x0c, 0x7c, 0x66,
+	0xa4, 0xf5, 0x4e, 0x0d, 0x7d, 0x76, 0x1c, 0xd4, 0x58, 0x3d, 0x36, 0xa1, 0x96, 0xaa, 0xd5, 0xc9,
+	0x98, 0x49, 0x55, 0x9a, 0xd4, 0x3c, 0x3b, 0x0e, 0x6a, 0x3c, 0xd3, 0x97, 0x13, 0x65, 0x41, 0xa9,
+	0xd2, 0x2b, 0x74, 0x71, 0xe4, 0x38, 0xaa, 0xca, 0xb3, 0xe6, 0xd2, 0x4e, 0x48, 0x62, 0x16, 0xa4,
+	0xd2, 0x0a, 0x91, 0x66, 0x2b, 0xed, 0x4e, 0x76, 0xaa, 0x05, 0xd3, 0xa2, 0xfa, 0x06, 0xe9, 0x19,
+	0x75, 0x76, 0x89, 0xd2, 0x9c, 0xe6, 0x13, 0x4a, 0x9c, 0x74, 0x61, 0x8a, 0x18, 0x54, 0x54, 0x57,
+	0x64, 0x0c, 0x9a, 0x2a, 0xbd, 0x18, 0x77, 0x50, 0x03, 0xa6, 0xc5, 0x8b, 0x60, 0xc6, 0xa0, 0xa9,
+	0xd2, 0x80, 0xe6, 0x68, 0x1c, 0x36, 0x24, 0x5b, 0xfd, 0x2a, 0x14, 0xf8, 0xc3, 0x12, 0x3a, 0x31,
+	0xea, 0xd1, 0x69, 0xd4, 0x88, 0xa9, 0x77, 0x29, 0xfd, 0x00, 0xfa, 0x02, 0x14, 0x78, 0xf6, 0x22,
+	0x63, 0xc4, 0xe4, 0xcb, 0x51, 0x73, 0x24, 0x4a, 0xc4, 0xa2, 0x03, 0xd5, 0x64, 0x56, 0x37, 0xc3,
+	0x23, 0x2a, 0xf2, 0xde, 0xcd, 0x71, 0x30, 0xa3, 0x59, 0xbe, 0xae, 0x41, 0x23, 0x2b, 0x01, 0x88,
+	0x32, 0xc3, 0x9e, 0x51, 0x59, 0xcc, 0xe6, 0x73, 0x3b, 0xa4, 0x8a, 0x45, 0xf8, 0x0e, 0xcc, 0x29,
+	0xd2, 0x4e, 0xe8, 0x42, 0xd6, 0x78, 0x19, 0x19, 0xb3, 0xe6, 0x33, 0xe3, 0x13, 0x24, 0xbd, 0x4d,
+	0x22, 0xc1, 0x94, 0x61, 0x0f, 0x87, 0x53, 0x50, 0xe3, 0x9c, 0x32, 0x7e, 0xa1, 0xcf, 0x3a, 0x65,
+	0xc9, 0xf4, 0x50, 0xf3, 0x89, 0x91, 0x38, 0x49, 0x37, 0x9c, 0x4e, 0x4b, 0xa0, 0x6c, 0x83, 0x36,
+	0x94, 0x1b, 0x69, 0x9e, 0x1b, 0x0b, 0x37, 0x9e, 0x6c, 0x15, 0x0a, 0xfc, 0x4a, 0x9f, 0xa1, 0xd7,
+	0xc9, 0x0c, 0x41, 0x53, 0x1f, 0x85, 0x12, 0x8f, 0x88, 0xa1, 0x9a, 0xbc, 0xdf, 0x67, 0x28, 0xb6,
+	0x22, 0x35, 0xd0, 0x3c, 0x33, 0x06, 0x66, 0x3c, 0x8d, 0x09, 0xd0, 0xbf, 0x5f, 0x67, 0x78, 0xe5,
+	0xa1, 0x2b, 0x7e, 0xf3, 0xd4, 0xb6, 0x78, 0xd1, 0x04, 0x4b, 0x5d, 0xa8, 0xae, 0x86, 0xc1, 0x83,
+	0x5e, 0x74, 0x9b, 0xfd, 0xff, 0xac, 0xeb, 0xca, 0x73, 0x5f, 0xbc, 0xb4, 0xe1, 0xd2, 0xcd, 0xee,
+	0x1a, 0x53, 0xb6, 0x0b, 0x02, 0xf7, 0x69, 0x37, 0x90, 0x5f, 0x17, 0x5c, 0x9f, 0xe2, 0xd0, 0xb7,
+	0xbc, 0x0b, 0x7c, 0x2c, 0x09, 0xed, 0xac, 0xad, 0x4d, 0xf3, 0xf6, 0xa5, 0xff, 0x0d, 0x00, 0x61,
+	0xea, 0x45, 0xa7, 0x70, 0x47, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4689,6 +5289,7 @@ type MilvusServiceClient interface {
 	CreateAlias(ctx context.Context, in *CreateAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	DropAlias(ctx context.Context, in *DropAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	AlterAlias(ctx context.Context, in *AlterAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	AlterCollection(ctx context.Context, in *AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	CreateIndex(ctx context.Context, in *CreateIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	DescribeIndex(ctx context.Context, in *DescribeIndexRequest, opts ...grpc.CallOption) (*DescribeIndexResponse, error)
 	GetIndexState(ctx context.Context, in *GetIndexStateRequest, opts ...grpc.CallOption) (*GetIndexStateResponse, error)
@@ -4702,6 +5303,9 @@ type MilvusServiceClient interface {
 	CalcDistance(ctx context.Context, in *CalcDistanceRequest, opts ...grpc.CallOption) (*CalcDistanceResults, error)
 	GetPersistentSegmentInfo(ctx context.Context, in *GetPersistentSegmentInfoRequest, opts ...grpc.CallOption) (*GetPersistentSegmentInfoResponse, error)
 	GetQuerySegmentInfo(ctx context.Context, in *GetQuerySegmentInfoRequest, opts ...grpc.CallOption) (*GetQuerySegmentInfoResponse, error)
+	LoadBalance(ctx context.Context, in *LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error)
+	GetImportState(ctx context.Context, in *GetImportStateRequest, opts ...grpc.CallOption) (*GetImportStateResponse, error)
 	Dummy(ctx context.Context, in *DummyRequest, opts ...grpc.CallOption) (*DummyResponse, error)
 	// TODO: remove
 	RegisterLink(ctx context.Context, in *RegisterLinkRequest, opts ...grpc.CallOption) (*RegisterLinkResponse, error)
@@ -4879,6 +5483,15 @@ func (c *milvusServiceClient) AlterAlias(ctx context.Context, in *AlterAliasRequ
 	return out, nil
 }
 
+func (c *milvusServiceClient) AlterCollection(ctx context.Context, in *AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.milvus.MilvusService/AlterCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *milvusServiceClient) CreateIndex(ctx context.Context, in *CreateIndexRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	out := new(commonpb.Status)
 	err := c.cc.Invoke(ctx, "/milvus.proto.milvus.MilvusService/CreateIndex", in, out, opts...)
@@ -4996,6 +5609,33 @@ func (c *milvusServiceClient) GetQuerySegmentInfo(ctx context.Context, in *GetQu
 	return out, nil
 }
 
+func (c *milvusServiceClient) LoadBalance(ctx context.Context, in *LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.milvus.MilvusService/LoadBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusServiceClient) Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.milvus.MilvusService/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusServiceClient) GetImportState(ctx context.Context, in *GetImportStateRequest, opts ...grpc.CallOption) (*GetImportStateResponse, error) {
+	out := new(GetImportStateResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.milvus.MilvusService/GetImportState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *milvusServiceClient) Dummy(ctx context.Context, in *DummyRequest, opts ...grpc.CallOption) (*DummyResponse, error) {
 	out := new(DummyResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.milvus.MilvusService/Dummy", in, out, opts...)
@@ -5043,6 +5683,7 @@ type MilvusServiceServer interface {
 	CreateAlias(context.Context, *CreateAliasRequest) (*commonpb.Status, error)
 	DropAlias(context.Context, *DropAliasRequest) (*commonpb.Status, error)
 	AlterAlias(context.Context, *AlterAliasRequest) (*commonpb.Status, error)
+	AlterCollection(context.Context, *AlterCollectionRequest) (*commonpb.Status, error)
 	CreateIndex(context.Context, *CreateIndexRequest) (*commonpb.Status, error)
 	DescribeIndex(context.Context, *DescribeIndexRequest) (*DescribeIndexResponse, error)
 	GetIndexState(context.Context, *GetIndexStateRequest) (*GetIndexStateResponse, error)
@@ -5056,6 +5697,9 @@ type MilvusServiceServer interface {
 	CalcDistance(context.Context, *CalcDistanceRequest) (*CalcDistanceResults, error)
 	GetPersistentSegmentInfo(context.Context, *GetPersistentSegmentInfoRequest) (*GetPersistentSegmentInfoResponse, error)
 	GetQuerySegmentInfo(context.Context, *GetQuerySegmentInfoRequest) (*GetQuerySegmentInfoResponse, error)
+	LoadBalance(context.Context, *LoadBalanceRequest) (*commonpb.Status, error)
+	Import(context.Context, *ImportRequest) (*ImportResponse, error)
+	GetImportState(context.Context, *GetImportStateRequest) (*GetImportStateResponse, error)
 	Dummy(context.Context, *DummyRequest) (*DummyResponse, error)
 	// TODO: remove
 	RegisterLink(context.Context, *RegisterLinkRequest) (*RegisterLinkResponse, error)
@@ -5121,6 +5765,9 @@ func (*UnimplementedMilvusServiceServer) DropAlias(ctx context.Context, req *Dro
 func (*UnimplementedMilvusServiceServer) AlterAlias(ctx context.Context, req *AlterAliasRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AlterAlias not implemented")
 }
+func (*UnimplementedMilvusServiceServer) AlterCollection(ctx context.Context, req *AlterCollectionRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AlterCollection not implemented")
+}
 func (*UnimplementedMilvusServiceServer) CreateIndex(ctx context.Context, req *CreateIndexRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateIndex not implemented")
 }
@@ -5160,6 +5807,15 @@ func (*UnimplementedMilvusServiceServer) GetPersistentSegmentInfo(ctx context.Co
 func (*UnimplementedMilvusServiceServer) GetQuerySegmentInfo(ctx context.Context, req *GetQuerySegmentInfoRequest) (*GetQuerySegmentInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetQuerySegmentInfo not implemented")
 }
+func (*UnimplementedMilvusServiceServer) LoadBalance(ctx context.Context, req *LoadBalanceRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadBalance not implemented")
+}
+func (*UnimplementedMilvusServiceServer) Import(ctx context.Context, req *ImportRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (*UnimplementedMilvusServiceServer) GetImportState(ctx context.Context, req *GetImportStateRequest) (*GetImportStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportState not implemented")
+}
 func (*UnimplementedMilvusServiceServer) Dummy(ctx context.Context, req *DummyRequest) (*DummyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Dummy not implemented")
 }
@@ -5498,6 +6154,24 @@ func _MilvusService_AlterAlias_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MilvusService_AlterCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusServiceServer).AlterCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.milvus.MilvusService/AlterCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusServiceServer).AlterCollection(ctx, req.(*AlterCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MilvusService_CreateIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateIndexRequest)
 	if err := dec(in); err != nil {
@@ -5732,6 +6406,60 @@ func _MilvusService_GetQuerySegmentInfo_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MilvusService_LoadBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusServiceServer).LoadBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.milvus.MilvusService/LoadBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusServiceServer).LoadBalance(ctx, req.(*LoadBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusService_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusServiceServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.milvus.MilvusService/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusServiceServer).Import(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusService_GetImportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusServiceServer).GetImportState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.milvus.MilvusService/GetImportState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusServiceServer).GetImportState(ctx, req.(*GetImportStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MilvusService_Dummy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DummyRequest)
 	if err := dec(in); err != nil {
@@ -5862,6 +6590,10 @@ var _MilvusService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AlterAlias",
 			Handler:    _MilvusService_AlterAlias_Handler,
 		},
+		{
+			MethodName: "AlterCollection",
+			Handler:    _MilvusService_AlterCollection_Handler,
+		},
 		{
 			MethodName: "CreateIndex",
 			Handler:    _MilvusService_CreateIndex_Handler,
@@ -5914,6 +6646,18 @@ var _MilvusService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetQuerySegmentInfo",
 			Handler:    _MilvusService_GetQuerySegmentInfo_Handler,
 		},
+		{
+			MethodName: "LoadBalance",
+			Handler:    _MilvusService_LoadBalance_Handler,
+		},
+		{
+			MethodName: "Import",
+			Handler:    _MilvusService_Import_Handler,
+		},
+		{
+			MethodName: "GetImportState",
+			Handler:    _MilvusService_GetImportState_Handler,
+		},
 		{
 			MethodName: "Dummy",
 			Handler:    _MilvusService_Dummy_Handler,
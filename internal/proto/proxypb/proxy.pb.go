@@ -27,13 +27,74 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// CollectionMetaType is the kind of collection meta cached in proxy
+type CollectionMetaType int32
+
+const (
+	CollectionMetaType_All        CollectionMetaType = 0
+	CollectionMetaType_Schema     CollectionMetaType = 1
+	CollectionMetaType_Shards     CollectionMetaType = 2
+	CollectionMetaType_Partitions CollectionMetaType = 3
+)
+
+var CollectionMetaType_name = map[int32]string{
+	0: "All",
+	1: "Schema",
+	2: "Shards",
+	3: "Partitions",
+}
+
+var CollectionMetaType_value = map[string]int32{
+	"All":        0,
+	"Schema":     1,
+	"Shards":     2,
+	"Partitions": 3,
+}
+
+func (x CollectionMetaType) String() string {
+	return proto.EnumName(CollectionMetaType_name, int32(x))
+}
+
+func (CollectionMetaType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{0}
+}
+
+type RateType int32
+
+const (
+	RateType_DMLRows     RateType = 0
+	RateType_DMLBytes    RateType = 1
+	RateType_DQLRequests RateType = 2
+)
+
+var RateType_name = map[int32]string{
+	0: "DMLRows",
+	1: "DMLBytes",
+	2: "DQLRequests",
+}
+
+var RateType_value = map[string]int32{
+	"DMLRows":     0,
+	"DMLBytes":    1,
+	"DQLRequests": 2,
+}
+
+func (x RateType) String() string {
+	return proto.EnumName(RateType_name, int32(x))
+}
+
+func (RateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{1}
+}
+
 type InvalidateCollMetaCacheRequest struct {
-	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DbName               string            `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	CollectionName       string            `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Base                 *commonpb.MsgBase  `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbName               string             `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName       string             `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	MetaType             CollectionMetaType `protobuf:"varint,4,opt,name=meta_type,json=metaType,proto3,enum=milvus.proto.proxy.CollectionMetaType" json:"meta_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *InvalidateCollMetaCacheRequest) Reset()         { *m = InvalidateCollMetaCacheRequest{} }
@@ -82,6 +143,13 @@ func (m *InvalidateCollMetaCacheRequest) GetCollectionName() string {
 	return ""
 }
 
+func (m *InvalidateCollMetaCacheRequest) GetMetaType() CollectionMetaType {
+	if m != nil {
+		return m.MetaType
+	}
+	return CollectionMetaType_All
+}
+
 type ReleaseDQLMessageStreamRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbID                 int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
@@ -137,41 +205,365 @@ func (m *ReleaseDQLMessageStreamRequest) GetCollectionID() int64 {
 	return 0
 }
 
+type RateLimit struct {
+	RateType RateType `protobuf:"varint,1,opt,name=rate_type,json=rateType,proto3,enum=milvus.proto.proxy.RateType" json:"rate_type,omitempty"`
+	// per second of every collection, not limited if not positive
+	Limit                float64  `protobuf:"fixed64,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{2}
+}
+
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RateLimit.Unmarshal(m, b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+}
+func (m *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(m, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return xxx_messageInfo_RateLimit.Size(m)
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetRateType() RateType {
+	if m != nil {
+		return m.RateType
+	}
+	return RateType_DMLRows
+}
+
+func (m *RateLimit) GetLimit() float64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SetRateLimitRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Limits               []*RateLimit      `protobuf:"bytes,2,rep,name=limits,proto3" json:"limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetRateLimitRequest) Reset()         { *m = SetRateLimitRequest{} }
+func (m *SetRateLimitRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRateLimitRequest) ProtoMessage()    {}
+func (*SetRateLimitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{3}
+}
+
+func (m *SetRateLimitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRateLimitRequest.Unmarshal(m, b)
+}
+func (m *SetRateLimitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRateLimitRequest.Marshal(b, m, deterministic)
+}
+func (m *SetRateLimitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRateLimitRequest.Merge(m, src)
+}
+func (m *SetRateLimitRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRateLimitRequest.Size(m)
+}
+func (m *SetRateLimitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRateLimitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRateLimitRequest proto.InternalMessageInfo
+
+func (m *SetRateLimitRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *SetRateLimitRequest) GetLimits() []*RateLimit {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+type UpdateConfigRequest struct {
+	Base                 *commonpb.MsgBase        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Configs              []*commonpb.KeyValuePair `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *UpdateConfigRequest) Reset()         { *m = UpdateConfigRequest{} }
+func (m *UpdateConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateConfigRequest) ProtoMessage()    {}
+func (*UpdateConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{4}
+}
+
+func (m *UpdateConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateConfigRequest.Unmarshal(m, b)
+}
+func (m *UpdateConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateConfigRequest.Merge(m, src)
+}
+func (m *UpdateConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateConfigRequest.Size(m)
+}
+func (m *UpdateConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateConfigRequest proto.InternalMessageInfo
+
+func (m *UpdateConfigRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *UpdateConfigRequest) GetConfigs() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
+}
+
+type UpdateConfigResponse struct {
+	Status               *commonpb.Status         `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Configs              []*commonpb.KeyValuePair `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *UpdateConfigResponse) Reset()         { *m = UpdateConfigResponse{} }
+func (m *UpdateConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateConfigResponse) ProtoMessage()    {}
+func (*UpdateConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{5}
+}
+
+func (m *UpdateConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateConfigResponse.Unmarshal(m, b)
+}
+func (m *UpdateConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateConfigResponse.Merge(m, src)
+}
+func (m *UpdateConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateConfigResponse.Size(m)
+}
+func (m *UpdateConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateConfigResponse proto.InternalMessageInfo
+
+func (m *UpdateConfigResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *UpdateConfigResponse) GetConfigs() []*commonpb.KeyValuePair {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
+}
+
+type SetLogLevelRequest struct {
+	Base *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// name of the logger to set, the global level is set if it's empty
+	Logger string `protobuf:"bytes,2,opt,name=logger,proto3" json:"logger,omitempty"`
+	// an empty level removes the level of the logger
+	Level                string   `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelRequest) Reset()         { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()    {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{6}
+}
+
+func (m *SetLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelRequest.Unmarshal(m, b)
+}
+func (m *SetLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelRequest.Merge(m, src)
+}
+func (m *SetLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelRequest.Size(m)
+}
+func (m *SetLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelRequest proto.InternalMessageInfo
+
+func (m *SetLogLevelRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *SetLogLevelRequest) GetLogger() string {
+	if m != nil {
+		return m.Logger
+	}
+	return ""
+}
+
+func (m *SetLogLevelRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
+	Status *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// json of the log levels of the components
+	Response             string   `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelResponse) Reset()         { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()    {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700b50b08ed8dbaf, []int{7}
+}
+
+func (m *SetLogLevelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelResponse.Unmarshal(m, b)
+}
+func (m *SetLogLevelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelResponse.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelResponse.Merge(m, src)
+}
+func (m *SetLogLevelResponse) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelResponse.Size(m)
+}
+func (m *SetLogLevelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelResponse proto.InternalMessageInfo
+
+func (m *SetLogLevelResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *SetLogLevelResponse) GetResponse() string {
+	if m != nil {
+		return m.Response
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("milvus.proto.proxy.CollectionMetaType", CollectionMetaType_name, CollectionMetaType_value)
+	proto.RegisterEnum("milvus.proto.proxy.RateType", RateType_name, RateType_value)
 	proto.RegisterType((*InvalidateCollMetaCacheRequest)(nil), "milvus.proto.proxy.InvalidateCollMetaCacheRequest")
 	proto.RegisterType((*ReleaseDQLMessageStreamRequest)(nil), "milvus.proto.proxy.ReleaseDQLMessageStreamRequest")
+	proto.RegisterType((*RateLimit)(nil), "milvus.proto.proxy.RateLimit")
+	proto.RegisterType((*SetRateLimitRequest)(nil), "milvus.proto.proxy.SetRateLimitRequest")
+	proto.RegisterType((*UpdateConfigRequest)(nil), "milvus.proto.proxy.UpdateConfigRequest")
+	proto.RegisterType((*UpdateConfigResponse)(nil), "milvus.proto.proxy.UpdateConfigResponse")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "milvus.proto.proxy.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "milvus.proto.proxy.SetLogLevelResponse")
 }
 
 func init() { proto.RegisterFile("proxy.proto", fileDescriptor_700b50b08ed8dbaf) }
 
 var fileDescriptor_700b50b08ed8dbaf = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0xd1, 0x0a, 0xd3, 0x30,
-	0x14, 0x5d, 0xdd, 0x9c, 0x98, 0x95, 0x09, 0x41, 0xd8, 0xa8, 0x3a, 0x46, 0x05, 0x1d, 0x82, 0xeb,
-	0xa8, 0x7e, 0xc1, 0x5a, 0x18, 0x03, 0x27, 0xda, 0xbd, 0xf9, 0x22, 0x69, 0x7b, 0xe9, 0x02, 0x69,
-	0xd2, 0x35, 0xe9, 0xd0, 0x5f, 0xf0, 0xd9, 0x1f, 0xf1, 0x0f, 0xa5, 0x69, 0xb7, 0xd9, 0x6d, 0x9d,
-	0xe8, 0x5b, 0xcf, 0xcd, 0xb9, 0x9c, 0x73, 0x6e, 0x0f, 0x1a, 0x64, 0xb9, 0xf8, 0xf6, 0x7d, 0x9e,
-	0xe5, 0x42, 0x09, 0x8c, 0x53, 0xca, 0x0e, 0x85, 0xac, 0xd0, 0x5c, 0xbf, 0x58, 0x66, 0x24, 0xd2,
-	0x54, 0xf0, 0x6a, 0x66, 0x0d, 0x29, 0x57, 0x90, 0x73, 0xc2, 0x6a, 0x6c, 0xfe, 0xb9, 0x61, 0xff,
-	0x34, 0xd0, 0x64, 0xcd, 0x0f, 0x84, 0xd1, 0x98, 0x28, 0xf0, 0x04, 0x63, 0x1b, 0x50, 0xc4, 0x23,
-	0xd1, 0x0e, 0x02, 0xd8, 0x17, 0x20, 0x15, 0x5e, 0xa0, 0x5e, 0x48, 0x24, 0x8c, 0x8d, 0xa9, 0x31,
-	0x1b, 0xb8, 0xcf, 0xe7, 0x0d, 0xc5, 0x5a, 0x6a, 0x23, 0x93, 0x25, 0x91, 0x10, 0x68, 0x26, 0x1e,
-	0xa1, 0x47, 0x71, 0xf8, 0x95, 0x93, 0x14, 0xc6, 0x0f, 0xa6, 0xc6, 0xec, 0x71, 0xd0, 0x8f, 0xc3,
-	0x8f, 0x24, 0x05, 0xfc, 0x1a, 0x3d, 0x89, 0x04, 0x63, 0x10, 0x29, 0x2a, 0x78, 0x45, 0xe8, 0x6a,
-	0xc2, 0xf0, 0x3c, 0x2e, 0x89, 0xf6, 0x0f, 0x03, 0x4d, 0x02, 0x60, 0x40, 0x24, 0xf8, 0x9f, 0x3f,
-	0x6c, 0x40, 0x4a, 0x92, 0xc0, 0x56, 0xe5, 0x40, 0xd2, 0xff, 0xb7, 0x85, 0x51, 0x2f, 0x0e, 0xd7,
-	0xbe, 0xf6, 0xd4, 0x0d, 0xf4, 0x37, 0xb6, 0x91, 0x79, 0x96, 0x5e, 0xfb, 0xda, 0x4e, 0x37, 0x68,
-	0xcc, 0xdc, 0x5f, 0x3d, 0xf4, 0xf0, 0x53, 0x79, 0x59, 0x9c, 0x21, 0xbc, 0x02, 0xe5, 0x89, 0x34,
-	0x13, 0x1c, 0xb8, 0xda, 0x2a, 0xa2, 0x40, 0xe2, 0x45, 0x53, 0xfb, 0x74, 0xef, 0x6b, 0x6a, 0xed,
-	0xdd, 0x7a, 0xd5, 0xb2, 0x71, 0x41, 0xb7, 0x3b, 0x78, 0x8f, 0x9e, 0xae, 0x40, 0x43, 0x2a, 0x15,
-	0x8d, 0xa4, 0xb7, 0x23, 0x9c, 0x03, 0xc3, 0x6e, 0xbb, 0xe6, 0x15, 0xf9, 0xa8, 0xfa, 0xb2, 0xb9,
-	0x53, 0x83, 0xad, 0xca, 0x29, 0x4f, 0x02, 0x90, 0x99, 0xe0, 0x12, 0xec, 0x0e, 0xce, 0xd1, 0x8b,
-	0x66, 0x23, 0xaa, 0x43, 0x9c, 0x7a, 0x71, 0xa9, 0x5d, 0xd5, 0xf1, 0x7e, 0x89, 0xac, 0x67, 0x37,
-	0xff, 0x4f, 0x69, 0xb5, 0x28, 0x63, 0x12, 0x64, 0xae, 0x40, 0xf9, 0xf1, 0x31, 0xde, 0x9b, 0xf6,
-	0x78, 0x27, 0xd2, 0x3f, 0xc6, 0x62, 0x68, 0xd4, 0xd2, 0xa8, 0xdb, 0x81, 0xee, 0xd7, 0xef, 0x2f,
-	0x81, 0x96, 0xef, 0xbf, 0xb8, 0x09, 0x55, 0xbb, 0x22, 0x2c, 0x5f, 0x9c, 0x8a, 0xfa, 0x96, 0x8a,
-	0xfa, 0xcb, 0x39, 0x06, 0x72, 0xf4, 0xb6, 0xa3, 0x15, 0xb3, 0x30, 0xec, 0x6b, 0xf8, 0xee, 0x77,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x46, 0xe1, 0x7c, 0xd9, 0xe3, 0x03, 0x00, 0x00,
+	// 759 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x5d, 0x6f, 0xda, 0x48,
+	0x14, 0xc5, 0x90, 0xf0, 0x71, 0x41, 0x04, 0x4d, 0xa2, 0x0d, 0x62, 0x93, 0x88, 0xf5, 0x4a, 0x09,
+	0x8a, 0xb4, 0x10, 0x91, 0xdd, 0x95, 0x56, 0xfb, 0xd4, 0x40, 0x15, 0x45, 0x85, 0x2a, 0x31, 0x6d,
+	0x1e, 0xaa, 0x4a, 0xe9, 0x60, 0x6e, 0xc0, 0xd2, 0xd8, 0xe3, 0x78, 0x86, 0xb4, 0xbc, 0xf4, 0xa9,
+	0x0f, 0x55, 0x7f, 0x5d, 0x5f, 0xfb, 0x6f, 0x2a, 0x8f, 0x6d, 0x02, 0xc1, 0x04, 0x95, 0xbc, 0xcd,
+	0x35, 0xe7, 0x9e, 0x7b, 0xce, 0x70, 0xe7, 0x40, 0xde, 0xf5, 0xf8, 0xa7, 0x49, 0xdd, 0xf5, 0xb8,
+	0xe4, 0x84, 0xd8, 0x16, 0xbb, 0x1f, 0x8b, 0xa0, 0xaa, 0xab, 0x5f, 0x2a, 0x05, 0x93, 0xdb, 0x36,
+	0x77, 0x82, 0x6f, 0x95, 0xa2, 0xe5, 0x48, 0xf4, 0x1c, 0xca, 0xc2, 0xba, 0x30, 0xdb, 0xa1, 0xff,
+	0xd0, 0xe0, 0xe0, 0xc2, 0xb9, 0xa7, 0xcc, 0x1a, 0x50, 0x89, 0x2d, 0xce, 0x58, 0x17, 0x25, 0x6d,
+	0x51, 0x73, 0x84, 0x06, 0xde, 0x8d, 0x51, 0x48, 0x72, 0x02, 0x1b, 0x7d, 0x2a, 0xb0, 0xac, 0x55,
+	0xb5, 0x5a, 0xbe, 0xb9, 0x57, 0x9f, 0x9b, 0x18, 0x8e, 0xea, 0x8a, 0xe1, 0x19, 0x15, 0x68, 0x28,
+	0x24, 0xd9, 0x85, 0xcc, 0xa0, 0x7f, 0xe3, 0x50, 0x1b, 0xcb, 0xc9, 0xaa, 0x56, 0xcb, 0x19, 0xe9,
+	0x41, 0xff, 0x35, 0xb5, 0x91, 0x1c, 0xc1, 0x96, 0xc9, 0x19, 0x43, 0x53, 0x5a, 0xdc, 0x09, 0x00,
+	0x29, 0x05, 0x28, 0x3e, 0x7c, 0x56, 0xc0, 0x16, 0xe4, 0x6c, 0x94, 0xf4, 0x46, 0x4e, 0x5c, 0x2c,
+	0x6f, 0x54, 0xb5, 0x5a, 0xb1, 0x79, 0x58, 0x5f, 0xb4, 0x5a, 0x6f, 0x4d, 0xdb, 0x7c, 0xd9, 0x6f,
+	0x26, 0x2e, 0x1a, 0x59, 0x3b, 0x3c, 0xe9, 0xdf, 0x34, 0x38, 0x30, 0x90, 0x21, 0x15, 0xd8, 0xbe,
+	0xea, 0x74, 0x51, 0x08, 0x3a, 0xc4, 0x9e, 0xf4, 0x90, 0xda, 0xeb, 0x7b, 0x23, 0xb0, 0x31, 0xe8,
+	0x5f, 0xb4, 0x95, 0xb1, 0x94, 0xa1, 0xce, 0x44, 0x87, 0xc2, 0x83, 0xfe, 0x8b, 0xb6, 0xf2, 0x94,
+	0x32, 0xe6, 0xbe, 0xe9, 0xef, 0x21, 0x67, 0x50, 0x89, 0x1d, 0xcb, 0xb6, 0x24, 0xf9, 0x0f, 0x72,
+	0x1e, 0x95, 0x18, 0xd8, 0xd3, 0x94, 0xbd, 0xbd, 0x38, 0x7b, 0x7e, 0x47, 0x60, 0xca, 0x0b, 0x4f,
+	0x64, 0x07, 0x36, 0x99, 0xcf, 0xa1, 0x04, 0x68, 0x46, 0x50, 0xe8, 0x9f, 0x61, 0xbb, 0x87, 0x72,
+	0x3a, 0x60, 0x7d, 0x7b, 0xff, 0x40, 0x5a, 0x31, 0x8a, 0x72, 0xb2, 0x9a, 0xaa, 0xe5, 0x9b, 0xfb,
+	0xcb, 0x64, 0x05, 0x73, 0x42, 0xb0, 0xfe, 0x45, 0x83, 0xed, 0xb7, 0x6e, 0xb0, 0x42, 0xce, 0xad,
+	0x35, 0x5c, 0x5f, 0xc0, 0xff, 0x90, 0x31, 0x15, 0x45, 0xa4, 0xe0, 0x8f, 0xd8, 0xa6, 0x57, 0x38,
+	0xb9, 0xa6, 0x6c, 0x8c, 0x97, 0xd4, 0xf2, 0x8c, 0xa8, 0x43, 0xff, 0xaa, 0xc1, 0xce, 0xbc, 0x0c,
+	0xe1, 0x72, 0x47, 0x20, 0x39, 0x85, 0xb4, 0x90, 0x54, 0x8e, 0x45, 0xa8, 0xe4, 0xf7, 0x58, 0xd2,
+	0x9e, 0x82, 0x18, 0x21, 0xf4, 0x79, 0x52, 0x24, 0x90, 0x1e, 0xca, 0x0e, 0x1f, 0x76, 0xf0, 0x1e,
+	0xd9, 0xfa, 0xf7, 0xf1, 0x1b, 0xa4, 0x19, 0x1f, 0x0e, 0xd1, 0x8b, 0x9e, 0x52, 0x50, 0xa9, 0x3d,
+	0xf0, 0x99, 0xc3, 0x07, 0x14, 0x14, 0xfa, 0x2d, 0x6c, 0xcf, 0x4d, 0x7d, 0x8e, 0xfd, 0x0a, 0x64,
+	0xbd, 0x90, 0x20, 0x9c, 0x3d, 0xad, 0x8f, 0x5f, 0x02, 0x59, 0x7c, 0x7a, 0x24, 0x03, 0xa9, 0x17,
+	0x8c, 0x95, 0x12, 0x04, 0x20, 0xdd, 0x33, 0x47, 0x68, 0xd3, 0x92, 0xa6, 0xce, 0x23, 0xea, 0x0d,
+	0x44, 0x29, 0x49, 0x8a, 0x00, 0x97, 0xd4, 0x93, 0x96, 0xdf, 0x25, 0x4a, 0xa9, 0xe3, 0x7f, 0x21,
+	0x1b, 0xad, 0x38, 0xc9, 0x43, 0xa6, 0xdd, 0xed, 0x18, 0xfc, 0xa3, 0x28, 0x25, 0x48, 0x01, 0xb2,
+	0xed, 0x6e, 0xe7, 0x6c, 0x22, 0x51, 0x94, 0x34, 0xb2, 0x05, 0xf9, 0xf6, 0x55, 0x27, 0xbc, 0x43,
+	0x51, 0x4a, 0x36, 0xbf, 0xa7, 0x61, 0xf3, 0xd2, 0x5f, 0x45, 0xe2, 0x02, 0x39, 0x47, 0xd9, 0xe2,
+	0xb6, 0xcb, 0x1d, 0x74, 0xa4, 0x6f, 0x01, 0x05, 0x39, 0x99, 0xf7, 0x37, 0x4d, 0xc0, 0x45, 0x68,
+	0x48, 0x5a, 0x39, 0x5c, 0xd2, 0xf1, 0x08, 0xae, 0x27, 0xc8, 0x1d, 0xec, 0x9c, 0xa3, 0x2a, 0x2d,
+	0x21, 0x2d, 0x53, 0xb4, 0x46, 0xd4, 0x71, 0x90, 0x91, 0xe6, 0xf2, 0x99, 0x0b, 0xe0, 0x68, 0xea,
+	0x9f, 0xf3, 0x3d, 0x61, 0xd1, 0x93, 0x9e, 0xe5, 0x4c, 0x77, 0x57, 0x4f, 0x10, 0x0f, 0xf6, 0xe7,
+	0x33, 0xfa, 0xe1, 0xde, 0x55, 0x52, 0x93, 0x66, 0xdc, 0x2b, 0x7d, 0x3a, 0xd6, 0x2b, 0x4f, 0xed,
+	0x80, 0x9e, 0x20, 0x14, 0x0a, 0xe7, 0x28, 0xdb, 0x83, 0xc8, 0xde, 0xf1, 0x72, 0x7b, 0x53, 0xd0,
+	0x2f, 0xda, 0x62, 0xb0, 0xbb, 0x24, 0x9e, 0xe3, 0x0d, 0x3d, 0x9d, 0xe5, 0xab, 0x0c, 0x5d, 0x43,
+	0x61, 0x36, 0x22, 0xc9, 0x51, 0xdc, 0x88, 0x98, 0x10, 0x5d, 0xc5, 0x6b, 0x42, 0x61, 0x36, 0x72,
+	0xe2, 0x79, 0x63, 0xb2, 0xb1, 0x52, 0x5b, 0x0d, 0x9c, 0x5e, 0xd5, 0x07, 0xc8, 0xcf, 0xbc, 0x6b,
+	0x72, 0xb8, 0x44, 0xfb, 0xa3, 0xb8, 0xa9, 0x1c, 0xad, 0xc4, 0x45, 0x13, 0xce, 0xfe, 0x7e, 0xd7,
+	0x1c, 0x5a, 0x72, 0x34, 0xee, 0xfb, 0x06, 0x1b, 0x41, 0xdb, 0x5f, 0x16, 0x0f, 0x4f, 0x8d, 0xe8,
+	0xff, 0x6e, 0x28, 0xa6, 0x86, 0x62, 0x72, 0xfb, 0xfd, 0xb4, 0x2a, 0x4f, 0x7f, 0x0e, 0x00, 0xd8,
+	0xd8, 0xc8, 0x91, 0x94, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -191,6 +583,9 @@ type ProxyClient interface {
 	InvalidateCollectionMetaCache(ctx context.Context, in *InvalidateCollMetaCacheRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	GetDdChannel(ctx context.Context, in *internalpb.GetDdChannelRequest, opts ...grpc.CallOption) (*milvuspb.StringResponse, error)
 	ReleaseDQLMessageStream(ctx context.Context, in *ReleaseDQLMessageStreamRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	SetRateLimit(ctx context.Context, in *SetRateLimitRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
 }
 
 type proxyClient struct {
@@ -246,6 +641,33 @@ func (c *proxyClient) ReleaseDQLMessageStream(ctx context.Context, in *ReleaseDQ
 	return out, nil
 }
 
+func (c *proxyClient) SetRateLimit(ctx context.Context, in *SetRateLimitRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.proxy.Proxy/SetRateLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error) {
+	out := new(UpdateConfigResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.proxy.Proxy/UpdateConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.proxy.Proxy/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProxyServer is the server API for Proxy service.
 type ProxyServer interface {
 	GetComponentStates(context.Context, *internalpb.GetComponentStatesRequest) (*internalpb.ComponentStates, error)
@@ -253,6 +675,9 @@ type ProxyServer interface {
 	InvalidateCollectionMetaCache(context.Context, *InvalidateCollMetaCacheRequest) (*commonpb.Status, error)
 	GetDdChannel(context.Context, *internalpb.GetDdChannelRequest) (*milvuspb.StringResponse, error)
 	ReleaseDQLMessageStream(context.Context, *ReleaseDQLMessageStreamRequest) (*commonpb.Status, error)
+	SetRateLimit(context.Context, *SetRateLimitRequest) (*commonpb.Status, error)
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error)
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
 }
 
 // UnimplementedProxyServer can be embedded to have forward compatible implementations.
@@ -274,6 +699,15 @@ func (*UnimplementedProxyServer) GetDdChannel(ctx context.Context, req *internal
 func (*UnimplementedProxyServer) ReleaseDQLMessageStream(ctx context.Context, req *ReleaseDQLMessageStreamRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDQLMessageStream not implemented")
 }
+func (*UnimplementedProxyServer) SetRateLimit(ctx context.Context, req *SetRateLimitRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRateLimit not implemented")
+}
+func (*UnimplementedProxyServer) UpdateConfig(ctx context.Context, req *UpdateConfigRequest) (*UpdateConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (*UnimplementedProxyServer) SetLogLevel(ctx context.Context, req *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
 
 func RegisterProxyServer(s *grpc.Server, srv ProxyServer) {
 	s.RegisterService(&_Proxy_serviceDesc, srv)
@@ -369,6 +803,60 @@ func _Proxy_ReleaseDQLMessageStream_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Proxy_SetRateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).SetRateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.proxy.Proxy/SetRateLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).SetRateLimit(ctx, req.(*SetRateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.proxy.Proxy/UpdateConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.proxy.Proxy/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Proxy_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.proxy.Proxy",
 	HandlerType: (*ProxyServer)(nil),
@@ -393,6 +881,18 @@ var _Proxy_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseDQLMessageStream",
 			Handler:    _Proxy_ReleaseDQLMessageStream_Handler,
 		},
+		{
+			MethodName: "SetRateLimit",
+			Handler:    _Proxy_SetRateLimit_Handler,
+		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _Proxy_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Proxy_SetLogLevel_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proxy.proto",
@@ -391,13 +391,14 @@ func (m *ShowPartitionsResponse) GetInMemoryPercentages() []int64 {
 }
 
 type LoadCollectionRequest struct {
-	Base                 *commonpb.MsgBase          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DbID                 int64                      `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionID         int64                      `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
-	Schema               *schemapb.CollectionSchema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Base                   *commonpb.MsgBase               `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbID                   int64                           `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionID           int64                           `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	Schema                 *schemapb.CollectionSchema      `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	UnindexedSegmentPolicy commonpb.UnindexedSegmentPolicy `protobuf:"varint,5,opt,name=unindexed_segment_policy,json=unindexedSegmentPolicy,proto3,enum=milvus.proto.common.UnindexedSegmentPolicy" json:"unindexed_segment_policy,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                        `json:"-"`
+	XXX_unrecognized       []byte                          `json:"-"`
+	XXX_sizecache          int32                           `json:"-"`
 }
 
 func (m *LoadCollectionRequest) Reset()         { *m = LoadCollectionRequest{} }
@@ -453,6 +454,13 @@ func (m *LoadCollectionRequest) GetSchema() *schemapb.CollectionSchema {
 	return nil
 }
 
+func (m *LoadCollectionRequest) GetUnindexedSegmentPolicy() commonpb.UnindexedSegmentPolicy {
+	if m != nil {
+		return m.UnindexedSegmentPolicy
+	}
+	return commonpb.UnindexedSegmentPolicy_LoadUnindexed
+}
+
 type ReleaseCollectionRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbID                 int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
@@ -517,14 +525,15 @@ func (m *ReleaseCollectionRequest) GetNodeID() int64 {
 }
 
 type LoadPartitionsRequest struct {
-	Base                 *commonpb.MsgBase          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DbID                 int64                      `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionID         int64                      `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
-	PartitionIDs         []int64                    `protobuf:"varint,4,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
-	Schema               *schemapb.CollectionSchema `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Base                   *commonpb.MsgBase               `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbID                   int64                           `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionID           int64                           `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionIDs           []int64                         `protobuf:"varint,4,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
+	Schema                 *schemapb.CollectionSchema      `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
+	UnindexedSegmentPolicy commonpb.UnindexedSegmentPolicy `protobuf:"varint,6,opt,name=unindexed_segment_policy,json=unindexedSegmentPolicy,proto3,enum=milvus.proto.common.UnindexedSegmentPolicy" json:"unindexed_segment_policy,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                        `json:"-"`
+	XXX_unrecognized       []byte                          `json:"-"`
+	XXX_sizecache          int32                           `json:"-"`
 }
 
 func (m *LoadPartitionsRequest) Reset()         { *m = LoadPartitionsRequest{} }
@@ -587,6 +596,13 @@ func (m *LoadPartitionsRequest) GetSchema() *schemapb.CollectionSchema {
 	return nil
 }
 
+func (m *LoadPartitionsRequest) GetUnindexedSegmentPolicy() commonpb.UnindexedSegmentPolicy {
+	if m != nil {
+		return m.UnindexedSegmentPolicy
+	}
+	return commonpb.UnindexedSegmentPolicy_LoadUnindexed
+}
+
 type ReleasePartitionsRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	DbID                 int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
@@ -993,6 +1009,8 @@ type SegmentInfo struct {
 	SegmentState         SegmentState `protobuf:"varint,10,opt,name=segment_state,json=segmentState,proto3,enum=milvus.proto.query.SegmentState" json:"segment_state,omitempty"`
 	CompactionFrom       []int64      `protobuf:"varint,11,rep,packed,name=compactionFrom,proto3" json:"compactionFrom,omitempty"`
 	CreatedByCompaction  bool         `protobuf:"varint,12,opt,name=createdByCompaction,proto3" json:"createdByCompaction,omitempty"`
+	Version              int64        `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	UnindexedFieldIDs    []int64      `protobuf:"varint,14,rep,packed,name=unindexed_fieldIDs,json=unindexedFieldIDs,proto3" json:"unindexed_fieldIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -1107,6 +1125,20 @@ func (m *SegmentInfo) GetCreatedByCompaction() bool {
 	return false
 }
 
+func (m *SegmentInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SegmentInfo) GetUnindexedFieldIDs() []int64 {
+	if m != nil {
+		return m.UnindexedFieldIDs
+	}
+	return nil
+}
+
 type GetSegmentInfoResponse struct {
 	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Infos                []*SegmentInfo   `protobuf:"bytes,2,rep,name=infos,proto3" json:"infos,omitempty"`
@@ -1154,6 +1186,219 @@ func (m *GetSegmentInfoResponse) GetInfos() []*SegmentInfo {
 	return nil
 }
 
+type GetShardLeadersRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CollectionID         int64             `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetShardLeadersRequest) Reset()         { *m = GetShardLeadersRequest{} }
+func (m *GetShardLeadersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShardLeadersRequest) ProtoMessage()    {}
+func (*GetShardLeadersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{16}
+}
+
+func (m *GetShardLeadersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShardLeadersRequest.Unmarshal(m, b)
+}
+func (m *GetShardLeadersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShardLeadersRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShardLeadersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShardLeadersRequest.Merge(m, src)
+}
+func (m *GetShardLeadersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShardLeadersRequest.Size(m)
+}
+func (m *GetShardLeadersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShardLeadersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShardLeadersRequest proto.InternalMessageInfo
+
+func (m *GetShardLeadersRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *GetShardLeadersRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+type SegmentsOnNode struct {
+	NodeID               int64    `protobuf:"varint,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	NodeAddress          string   `protobuf:"bytes,2,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	SegmentIDs           []int64  `protobuf:"varint,3,rep,packed,name=segmentIDs,proto3" json:"segmentIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentsOnNode) Reset()         { *m = SegmentsOnNode{} }
+func (m *SegmentsOnNode) String() string { return proto.CompactTextString(m) }
+func (*SegmentsOnNode) ProtoMessage()    {}
+func (*SegmentsOnNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{17}
+}
+
+func (m *SegmentsOnNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentsOnNode.Unmarshal(m, b)
+}
+func (m *SegmentsOnNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentsOnNode.Marshal(b, m, deterministic)
+}
+func (m *SegmentsOnNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentsOnNode.Merge(m, src)
+}
+func (m *SegmentsOnNode) XXX_Size() int {
+	return xxx_messageInfo_SegmentsOnNode.Size(m)
+}
+func (m *SegmentsOnNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentsOnNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentsOnNode proto.InternalMessageInfo
+
+func (m *SegmentsOnNode) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *SegmentsOnNode) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+func (m *SegmentsOnNode) GetSegmentIDs() []int64 {
+	if m != nil {
+		return m.SegmentIDs
+	}
+	return nil
+}
+
+type ShardLeader struct {
+	ChannelName string `protobuf:"bytes,1,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
+	NodeID      int64  `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Address     string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// sealed segments searched through this shard leader, grouped by the query nodes holding them
+	SealedSegments       []*SegmentsOnNode `protobuf:"bytes,4,rep,name=sealed_segments,json=sealedSegments,proto3" json:"sealed_segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ShardLeader) Reset()         { *m = ShardLeader{} }
+func (m *ShardLeader) String() string { return proto.CompactTextString(m) }
+func (*ShardLeader) ProtoMessage()    {}
+func (*ShardLeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{18}
+}
+
+func (m *ShardLeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShardLeader.Unmarshal(m, b)
+}
+func (m *ShardLeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShardLeader.Marshal(b, m, deterministic)
+}
+func (m *ShardLeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardLeader.Merge(m, src)
+}
+func (m *ShardLeader) XXX_Size() int {
+	return xxx_messageInfo_ShardLeader.Size(m)
+}
+func (m *ShardLeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardLeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardLeader proto.InternalMessageInfo
+
+func (m *ShardLeader) GetChannelName() string {
+	if m != nil {
+		return m.ChannelName
+	}
+	return ""
+}
+
+func (m *ShardLeader) GetNodeID() int64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *ShardLeader) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ShardLeader) GetSealedSegments() []*SegmentsOnNode {
+	if m != nil {
+		return m.SealedSegments
+	}
+	return nil
+}
+
+type GetShardLeadersResponse struct {
+	Status               *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Shards               []*ShardLeader   `protobuf:"bytes,2,rep,name=shards,proto3" json:"shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetShardLeadersResponse) Reset()         { *m = GetShardLeadersResponse{} }
+func (m *GetShardLeadersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetShardLeadersResponse) ProtoMessage()    {}
+func (*GetShardLeadersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{19}
+}
+
+func (m *GetShardLeadersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShardLeadersResponse.Unmarshal(m, b)
+}
+func (m *GetShardLeadersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShardLeadersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetShardLeadersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShardLeadersResponse.Merge(m, src)
+}
+func (m *GetShardLeadersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetShardLeadersResponse.Size(m)
+}
+func (m *GetShardLeadersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShardLeadersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShardLeadersResponse proto.InternalMessageInfo
+
+func (m *GetShardLeadersResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetShardLeadersResponse) GetShards() []*ShardLeader {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
 //-----------------query node proto----------------
 type AddQueryChannelRequest struct {
 	Base                  *commonpb.MsgBase       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
@@ -1173,7 +1418,7 @@ func (m *AddQueryChannelRequest) Reset()         { *m = AddQueryChannelRequest{}
 func (m *AddQueryChannelRequest) String() string { return proto.CompactTextString(m) }
 func (*AddQueryChannelRequest) ProtoMessage()    {}
 func (*AddQueryChannelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{16}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{20}
 }
 
 func (m *AddQueryChannelRequest) XXX_Unmarshal(b []byte) error {
@@ -1265,7 +1510,7 @@ func (m *RemoveQueryChannelRequest) Reset()         { *m = RemoveQueryChannelReq
 func (m *RemoveQueryChannelRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveQueryChannelRequest) ProtoMessage()    {}
 func (*RemoveQueryChannelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{17}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{21}
 }
 
 func (m *RemoveQueryChannelRequest) XXX_Unmarshal(b []byte) error {
@@ -1338,7 +1583,7 @@ func (m *WatchDmChannelsRequest) Reset()         { *m = WatchDmChannelsRequest{}
 func (m *WatchDmChannelsRequest) String() string { return proto.CompactTextString(m) }
 func (*WatchDmChannelsRequest) ProtoMessage()    {}
 func (*WatchDmChannelsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{18}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{22}
 }
 
 func (m *WatchDmChannelsRequest) XXX_Unmarshal(b []byte) error {
@@ -1419,6 +1664,7 @@ type SegmentLoadInfo struct {
 	NumOfRows            int64                  `protobuf:"varint,7,opt,name=num_of_rows,json=numOfRows,proto3" json:"num_of_rows,omitempty"`
 	Statslogs            []*datapb.FieldBinlog  `protobuf:"bytes,8,rep,name=statslogs,proto3" json:"statslogs,omitempty"`
 	Deltalogs            []*datapb.DeltaLogInfo `protobuf:"bytes,9,rep,name=deltalogs,proto3" json:"deltalogs,omitempty"`
+	Version              int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
@@ -1428,7 +1674,7 @@ func (m *SegmentLoadInfo) Reset()         { *m = SegmentLoadInfo{} }
 func (m *SegmentLoadInfo) String() string { return proto.CompactTextString(m) }
 func (*SegmentLoadInfo) ProtoMessage()    {}
 func (*SegmentLoadInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{19}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{23}
 }
 
 func (m *SegmentLoadInfo) XXX_Unmarshal(b []byte) error {
@@ -1512,24 +1758,33 @@ func (m *SegmentLoadInfo) GetDeltalogs() []*datapb.DeltaLogInfo {
 	return nil
 }
 
+func (m *SegmentLoadInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type LoadSegmentsRequest struct {
-	Base                 *commonpb.MsgBase          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DstNodeID            int64                      `protobuf:"varint,2,opt,name=dst_nodeID,json=dstNodeID,proto3" json:"dst_nodeID,omitempty"`
-	Infos                []*SegmentLoadInfo         `protobuf:"bytes,3,rep,name=infos,proto3" json:"infos,omitempty"`
-	Schema               *schemapb.CollectionSchema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
-	LoadCondition        TriggerCondition           `protobuf:"varint,5,opt,name=load_condition,json=loadCondition,proto3,enum=milvus.proto.query.TriggerCondition" json:"load_condition,omitempty"`
-	SourceNodeID         int64                      `protobuf:"varint,6,opt,name=source_nodeID,json=sourceNodeID,proto3" json:"source_nodeID,omitempty"`
-	CollectionID         int64                      `protobuf:"varint,7,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Base          *commonpb.MsgBase          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DstNodeID     int64                      `protobuf:"varint,2,opt,name=dst_nodeID,json=dstNodeID,proto3" json:"dst_nodeID,omitempty"`
+	Infos         []*SegmentLoadInfo         `protobuf:"bytes,3,rep,name=infos,proto3" json:"infos,omitempty"`
+	Schema        *schemapb.CollectionSchema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	LoadCondition TriggerCondition           `protobuf:"varint,5,opt,name=load_condition,json=loadCondition,proto3,enum=milvus.proto.query.TriggerCondition" json:"load_condition,omitempty"`
+	SourceNodeID  int64                      `protobuf:"varint,6,opt,name=source_nodeID,json=sourceNodeID,proto3" json:"source_nodeID,omitempty"`
+	CollectionID  int64                      `protobuf:"varint,7,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	// address of the online query node holding the segments, the binlogs are fetched from it before the object storage
+	SourceAddress        string   `protobuf:"bytes,8,opt,name=source_address,json=sourceAddress,proto3" json:"source_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LoadSegmentsRequest) Reset()         { *m = LoadSegmentsRequest{} }
 func (m *LoadSegmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadSegmentsRequest) ProtoMessage()    {}
 func (*LoadSegmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{20}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{24}
 }
 
 func (m *LoadSegmentsRequest) XXX_Unmarshal(b []byte) error {
@@ -1599,6 +1854,135 @@ func (m *LoadSegmentsRequest) GetCollectionID() int64 {
 	return 0
 }
 
+func (m *LoadSegmentsRequest) GetSourceAddress() string {
+	if m != nil {
+		return m.SourceAddress
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	Req *internalpb.SearchRequest `protobuf:"bytes,1,opt,name=req,proto3" json:"req,omitempty"`
+	// dm channel whose growing segments are searched, empty to search sealed segments only
+	DmlChannel string `protobuf:"bytes,2,opt,name=dml_channel,json=dmlChannel,proto3" json:"dml_channel,omitempty"`
+	// sealed segments searched on the receiving query node
+	SegmentIDs []int64 `protobuf:"varint,3,rep,packed,name=segmentIDs,proto3" json:"segmentIDs,omitempty"`
+	// sealed segments held by other query nodes, the shard leader forwards the search to them
+	RemoteSegments       []*SegmentsOnNode `protobuf:"bytes,4,rep,name=remote_segments,json=remoteSegments,proto3" json:"remote_segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{25}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetReq() *internalpb.SearchRequest {
+	if m != nil {
+		return m.Req
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetDmlChannel() string {
+	if m != nil {
+		return m.DmlChannel
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetSegmentIDs() []int64 {
+	if m != nil {
+		return m.SegmentIDs
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetRemoteSegments() []*SegmentsOnNode {
+	if m != nil {
+		return m.RemoteSegments
+	}
+	return nil
+}
+
+type SearchResponse struct {
+	Status  *commonpb.Status            `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Results []*internalpb.SearchResults `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	// time spent by the query node serving the search in microseconds
+	LatencyUs            int64    `protobuf:"varint,3,opt,name=latency_us,json=latencyUs,proto3" json:"latency_us,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{26}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetResults() []*internalpb.SearchResults {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetLatencyUs() int64 {
+	if m != nil {
+		return m.LatencyUs
+	}
+	return 0
+}
+
 type ReleaseSegmentsRequest struct {
 	Base   *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	NodeID int64             `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
@@ -1616,7 +2000,7 @@ func (m *ReleaseSegmentsRequest) Reset()         { *m = ReleaseSegmentsRequest{}
 func (m *ReleaseSegmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseSegmentsRequest) ProtoMessage()    {}
 func (*ReleaseSegmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{21}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{27}
 }
 
 func (m *ReleaseSegmentsRequest) XXX_Unmarshal(b []byte) error {
@@ -1691,7 +2075,7 @@ func (m *DmChannelInfo) Reset()         { *m = DmChannelInfo{} }
 func (m *DmChannelInfo) String() string { return proto.CompactTextString(m) }
 func (*DmChannelInfo) ProtoMessage()    {}
 func (*DmChannelInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{22}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{28}
 }
 
 func (m *DmChannelInfo) XXX_Unmarshal(b []byte) error {
@@ -1741,7 +2125,7 @@ func (m *QueryChannelInfo) Reset()         { *m = QueryChannelInfo{} }
 func (m *QueryChannelInfo) String() string { return proto.CompactTextString(m) }
 func (*QueryChannelInfo) ProtoMessage()    {}
 func (*QueryChannelInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{23}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{29}
 }
 
 func (m *QueryChannelInfo) XXX_Unmarshal(b []byte) error {
@@ -1815,7 +2199,7 @@ func (m *CollectionInfo) Reset()         { *m = CollectionInfo{} }
 func (m *CollectionInfo) String() string { return proto.CompactTextString(m) }
 func (*CollectionInfo) ProtoMessage()    {}
 func (*CollectionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{24}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{30}
 }
 
 func (m *CollectionInfo) XXX_Unmarshal(b []byte) error {
@@ -1910,7 +2294,7 @@ func (m *LoadBalanceSegmentInfo) Reset()         { *m = LoadBalanceSegmentInfo{}
 func (m *LoadBalanceSegmentInfo) String() string { return proto.CompactTextString(m) }
 func (*LoadBalanceSegmentInfo) ProtoMessage()    {}
 func (*LoadBalanceSegmentInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{25}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{31}
 }
 
 func (m *LoadBalanceSegmentInfo) XXX_Unmarshal(b []byte) error {
@@ -1999,7 +2383,7 @@ func (m *HandoffSegmentsRequest) Reset()         { *m = HandoffSegmentsRequest{}
 func (m *HandoffSegmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*HandoffSegmentsRequest) ProtoMessage()    {}
 func (*HandoffSegmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{26}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{32}
 }
 
 func (m *HandoffSegmentsRequest) XXX_Unmarshal(b []byte) error {
@@ -2038,6 +2422,8 @@ type LoadBalanceRequest struct {
 	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	SourceNodeIDs        []int64           `protobuf:"varint,2,rep,packed,name=source_nodeIDs,json=sourceNodeIDs,proto3" json:"source_nodeIDs,omitempty"`
 	BalanceReason        TriggerCondition  `protobuf:"varint,3,opt,name=balance_reason,json=balanceReason,proto3,enum=milvus.proto.query.TriggerCondition" json:"balance_reason,omitempty"`
+	DstNodeIDs           []int64           `protobuf:"varint,4,rep,packed,name=dst_nodeIDs,json=dstNodeIDs,proto3" json:"dst_nodeIDs,omitempty"`
+	SealedSegmentIDs     []int64           `protobuf:"varint,5,rep,packed,name=sealed_segmentIDs,json=sealedSegmentIDs,proto3" json:"sealed_segmentIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -2047,7 +2433,7 @@ func (m *LoadBalanceRequest) Reset()         { *m = LoadBalanceRequest{} }
 func (m *LoadBalanceRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadBalanceRequest) ProtoMessage()    {}
 func (*LoadBalanceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{27}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{33}
 }
 
 func (m *LoadBalanceRequest) XXX_Unmarshal(b []byte) error {
@@ -2089,6 +2475,20 @@ func (m *LoadBalanceRequest) GetBalanceReason() TriggerCondition {
 	return TriggerCondition_handoff
 }
 
+func (m *LoadBalanceRequest) GetDstNodeIDs() []int64 {
+	if m != nil {
+		return m.DstNodeIDs
+	}
+	return nil
+}
+
+func (m *LoadBalanceRequest) GetSealedSegmentIDs() []int64 {
+	if m != nil {
+		return m.SealedSegmentIDs
+	}
+	return nil
+}
+
 //---------------- common query proto -----------------
 type SegmentChangeInfo struct {
 	OnlineNodeID         int64          `protobuf:"varint,1,opt,name=online_nodeID,json=onlineNodeID,proto3" json:"online_nodeID,omitempty"`
@@ -2104,7 +2504,7 @@ func (m *SegmentChangeInfo) Reset()         { *m = SegmentChangeInfo{} }
 func (m *SegmentChangeInfo) String() string { return proto.CompactTextString(m) }
 func (*SegmentChangeInfo) ProtoMessage()    {}
 func (*SegmentChangeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{28}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{34}
 }
 
 func (m *SegmentChangeInfo) XXX_Unmarshal(b []byte) error {
@@ -2165,7 +2565,7 @@ func (m *SealedSegmentsChangeInfo) Reset()         { *m = SealedSegmentsChangeIn
 func (m *SealedSegmentsChangeInfo) String() string { return proto.CompactTextString(m) }
 func (*SealedSegmentsChangeInfo) ProtoMessage()    {}
 func (*SealedSegmentsChangeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aab7cc9a69ed26e8, []int{29}
+	return fileDescriptor_aab7cc9a69ed26e8, []int{35}
 }
 
 func (m *SealedSegmentsChangeInfo) XXX_Unmarshal(b []byte) error {
@@ -2200,6 +2600,135 @@ func (m *SealedSegmentsChangeInfo) GetInfos() []*SegmentChangeInfo {
 	return nil
 }
 
+type FetchSegmentBinlogsRequest struct {
+	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	SegmentID            int64             `protobuf:"varint,2,opt,name=segmentID,proto3" json:"segmentID,omitempty"`
+	Paths                []string          `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *FetchSegmentBinlogsRequest) Reset()         { *m = FetchSegmentBinlogsRequest{} }
+func (m *FetchSegmentBinlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchSegmentBinlogsRequest) ProtoMessage()    {}
+func (*FetchSegmentBinlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{36}
+}
+
+func (m *FetchSegmentBinlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchSegmentBinlogsRequest.Unmarshal(m, b)
+}
+func (m *FetchSegmentBinlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchSegmentBinlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchSegmentBinlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchSegmentBinlogsRequest.Merge(m, src)
+}
+func (m *FetchSegmentBinlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchSegmentBinlogsRequest.Size(m)
+}
+func (m *FetchSegmentBinlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchSegmentBinlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchSegmentBinlogsRequest proto.InternalMessageInfo
+
+func (m *FetchSegmentBinlogsRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *FetchSegmentBinlogsRequest) GetSegmentID() int64 {
+	if m != nil {
+		return m.SegmentID
+	}
+	return 0
+}
+
+func (m *FetchSegmentBinlogsRequest) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+// SegmentBinlogChunk is a piece of a binlog, the pieces of a binlog are streamed in order
+type SegmentBinlogChunk struct {
+	Status *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Path   string           `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Data   []byte           `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// set on the last piece of the binlog
+	Last bool `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`
+	// CRC-32C of the whole binlog, set on the last piece
+	Checksum             uint32   `protobuf:"varint,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentBinlogChunk) Reset()         { *m = SegmentBinlogChunk{} }
+func (m *SegmentBinlogChunk) String() string { return proto.CompactTextString(m) }
+func (*SegmentBinlogChunk) ProtoMessage()    {}
+func (*SegmentBinlogChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aab7cc9a69ed26e8, []int{37}
+}
+
+func (m *SegmentBinlogChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentBinlogChunk.Unmarshal(m, b)
+}
+func (m *SegmentBinlogChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentBinlogChunk.Marshal(b, m, deterministic)
+}
+func (m *SegmentBinlogChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentBinlogChunk.Merge(m, src)
+}
+func (m *SegmentBinlogChunk) XXX_Size() int {
+	return xxx_messageInfo_SegmentBinlogChunk.Size(m)
+}
+func (m *SegmentBinlogChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentBinlogChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentBinlogChunk proto.InternalMessageInfo
+
+func (m *SegmentBinlogChunk) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *SegmentBinlogChunk) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SegmentBinlogChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SegmentBinlogChunk) GetLast() bool {
+	if m != nil {
+		return m.Last
+	}
+	return false
+}
+
+func (m *SegmentBinlogChunk) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("milvus.proto.query.PartitionState", PartitionState_name, PartitionState_value)
 	proto.RegisterEnum("milvus.proto.query.TriggerCondition", TriggerCondition_name, TriggerCondition_value)
@@ -2221,11 +2750,17 @@ func init() {
 	proto.RegisterType((*GetSegmentInfoRequest)(nil), "milvus.proto.query.GetSegmentInfoRequest")
 	proto.RegisterType((*SegmentInfo)(nil), "milvus.proto.query.SegmentInfo")
 	proto.RegisterType((*GetSegmentInfoResponse)(nil), "milvus.proto.query.GetSegmentInfoResponse")
+	proto.RegisterType((*GetShardLeadersRequest)(nil), "milvus.proto.query.GetShardLeadersRequest")
+	proto.RegisterType((*SegmentsOnNode)(nil), "milvus.proto.query.SegmentsOnNode")
+	proto.RegisterType((*ShardLeader)(nil), "milvus.proto.query.ShardLeader")
+	proto.RegisterType((*GetShardLeadersResponse)(nil), "milvus.proto.query.GetShardLeadersResponse")
 	proto.RegisterType((*AddQueryChannelRequest)(nil), "milvus.proto.query.AddQueryChannelRequest")
 	proto.RegisterType((*RemoveQueryChannelRequest)(nil), "milvus.proto.query.RemoveQueryChannelRequest")
 	proto.RegisterType((*WatchDmChannelsRequest)(nil), "milvus.proto.query.WatchDmChannelsRequest")
 	proto.RegisterType((*SegmentLoadInfo)(nil), "milvus.proto.query.SegmentLoadInfo")
 	proto.RegisterType((*LoadSegmentsRequest)(nil), "milvus.proto.query.LoadSegmentsRequest")
+	proto.RegisterType((*SearchRequest)(nil), "milvus.proto.query.SearchRequest")
+	proto.RegisterType((*SearchResponse)(nil), "milvus.proto.query.SearchResponse")
 	proto.RegisterType((*ReleaseSegmentsRequest)(nil), "milvus.proto.query.ReleaseSegmentsRequest")
 	proto.RegisterType((*DmChannelInfo)(nil), "milvus.proto.query.DmChannelInfo")
 	proto.RegisterType((*QueryChannelInfo)(nil), "milvus.proto.query.QueryChannelInfo")
@@ -2235,154 +2770,187 @@ func init() {
 	proto.RegisterType((*LoadBalanceRequest)(nil), "milvus.proto.query.LoadBalanceRequest")
 	proto.RegisterType((*SegmentChangeInfo)(nil), "milvus.proto.query.SegmentChangeInfo")
 	proto.RegisterType((*SealedSegmentsChangeInfo)(nil), "milvus.proto.query.SealedSegmentsChangeInfo")
+	proto.RegisterType((*FetchSegmentBinlogsRequest)(nil), "milvus.proto.query.FetchSegmentBinlogsRequest")
+	proto.RegisterType((*SegmentBinlogChunk)(nil), "milvus.proto.query.SegmentBinlogChunk")
 }
 
 func init() { proto.RegisterFile("query_coord.proto", fileDescriptor_aab7cc9a69ed26e8) }
 
 var fileDescriptor_aab7cc9a69ed26e8 = []byte{
-	// 2261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xcd, 0x6f, 0x1c, 0x49,
-	0x15, 0x77, 0xcf, 0x87, 0x67, 0xe6, 0xcd, 0x57, 0xa7, 0x12, 0xcf, 0x4e, 0x86, 0x24, 0x6b, 0x3a,
-	0x9b, 0x8f, 0xf5, 0xb2, 0x4e, 0xd6, 0x59, 0x10, 0x2b, 0xd8, 0xc3, 0xc6, 0xb3, 0xf1, 0xce, 0x92,
-	0x38, 0xa6, 0x9d, 0x5d, 0x44, 0x14, 0xa9, 0xe9, 0x99, 0x2e, 0x8f, 0x5b, 0xe9, 0xee, 0x9a, 0x74,
-	0xf5, 0x24, 0x71, 0xce, 0x1c, 0xe0, 0x80, 0xf8, 0x03, 0x40, 0x48, 0x48, 0x20, 0xc4, 0x81, 0x23,
-	0x20, 0x71, 0xda, 0x0b, 0x77, 0xfe, 0x02, 0x24, 0x04, 0x77, 0x4e, 0x70, 0x46, 0x5d, 0x55, 0xdd,
-	0xd3, 0x1f, 0x35, 0xf6, 0xd8, 0x26, 0x24, 0x5a, 0x71, 0xeb, 0x7e, 0xf5, 0xea, 0xbd, 0x57, 0xef,
-	0xe3, 0xf7, 0xea, 0x03, 0xce, 0x3c, 0x99, 0x62, 0xff, 0xc0, 0x18, 0x11, 0xe2, 0x5b, 0xeb, 0x13,
-	0x9f, 0x04, 0x04, 0x21, 0xd7, 0x76, 0x9e, 0x4e, 0x29, 0xff, 0x5b, 0x67, 0xe3, 0xbd, 0xc6, 0x88,
-	0xb8, 0x2e, 0xf1, 0x38, 0xad, 0xd7, 0x48, 0x72, 0xf4, 0x5a, 0xb6, 0x17, 0x60, 0xdf, 0x33, 0x9d,
-	0x68, 0x94, 0x8e, 0xf6, 0xb1, 0x6b, 0x8a, 0x3f, 0xd5, 0x32, 0x03, 0x33, 0x29, 0x5f, 0xfb, 0xa1,
-	0x02, 0x9d, 0xdd, 0x7d, 0xf2, 0x6c, 0x93, 0x38, 0x0e, 0x1e, 0x05, 0x36, 0xf1, 0xa8, 0x8e, 0x9f,
-	0x4c, 0x31, 0x0d, 0xd0, 0x4d, 0x28, 0x0d, 0x4d, 0x8a, 0xbb, 0xca, 0xaa, 0x72, 0xbd, 0xbe, 0x71,
-	0x61, 0x3d, 0x65, 0x89, 0x30, 0xe1, 0x1e, 0x1d, 0xdf, 0x36, 0x29, 0xd6, 0x19, 0x27, 0x42, 0x50,
-	0xb2, 0x86, 0x83, 0x7e, 0xb7, 0xb0, 0xaa, 0x5c, 0x2f, 0xea, 0xec, 0x1b, 0xbd, 0x05, 0xcd, 0x51,
-	0x2c, 0x7b, 0xd0, 0xa7, 0xdd, 0xe2, 0x6a, 0xf1, 0x7a, 0x51, 0x4f, 0x13, 0xb5, 0xdf, 0x28, 0xf0,
-	0x46, 0xce, 0x0c, 0x3a, 0x21, 0x1e, 0xc5, 0xe8, 0x16, 0x2c, 0xd3, 0xc0, 0x0c, 0xa6, 0x54, 0x58,
-	0xf2, 0x15, 0xa9, 0x25, 0xbb, 0x8c, 0x45, 0x17, 0xac, 0x79, 0xb5, 0x05, 0x89, 0x5a, 0xf4, 0x1e,
-	0x9c, 0xb3, 0xbd, 0x7b, 0xd8, 0x25, 0xfe, 0x81, 0x31, 0xc1, 0xfe, 0x08, 0x7b, 0x81, 0x39, 0xc6,
-	0x91, 0x8d, 0x67, 0xa3, 0xb1, 0x9d, 0xd9, 0x90, 0xf6, 0x6b, 0x05, 0x56, 0x42, 0x4b, 0x77, 0x4c,
-	0x3f, 0xb0, 0x5f, 0x82, 0xbf, 0x34, 0x68, 0x24, 0x6d, 0xec, 0x16, 0xd9, 0x58, 0x8a, 0x16, 0xf2,
-	0x4c, 0x22, 0xf5, 0xe1, 0xda, 0x4a, 0xcc, 0xdc, 0x14, 0x4d, 0xfb, 0x95, 0x08, 0x6c, 0xd2, 0xce,
-	0xd3, 0x38, 0x34, 0xab, 0xb3, 0x90, 0xd7, 0x79, 0x12, 0x77, 0x7e, 0xa1, 0xc0, 0xca, 0x5d, 0x62,
-	0x5a, 0xb3, 0xc0, 0xff, 0xef, 0xdd, 0xf9, 0x21, 0x2c, 0xf3, 0x2a, 0xe9, 0x96, 0x98, 0xae, 0x2b,
-	0x69, 0x5d, 0xa2, 0x82, 0x66, 0x16, 0xee, 0x32, 0x82, 0x2e, 0x26, 0x69, 0x3f, 0x57, 0xa0, 0xab,
-	0x63, 0x07, 0x9b, 0x14, 0xbf, 0xca, 0x55, 0x74, 0x60, 0xd9, 0x23, 0x16, 0x1e, 0xf4, 0xd9, 0x2a,
-	0x8a, 0xba, 0xf8, 0xd3, 0xfe, 0x21, 0x3c, 0xfc, 0x9a, 0x27, 0x6c, 0x22, 0x0a, 0xe5, 0x93, 0x44,
-	0xe1, 0x8b, 0x59, 0x14, 0x5e, 0xf7, 0x95, 0xce, 0x22, 0x55, 0x4e, 0x45, 0xea, 0xfb, 0x70, 0x7e,
-	0xd3, 0xc7, 0x66, 0x80, 0xbf, 0x1b, 0xc2, 0xfc, 0xe6, 0xbe, 0xe9, 0x79, 0xd8, 0x89, 0x96, 0x90,
-	0x55, 0xae, 0x48, 0x94, 0x77, 0xa1, 0x32, 0xf1, 0xc9, 0xf3, 0x83, 0xd8, 0xee, 0xe8, 0x57, 0xfb,
-	0xa5, 0x02, 0x3d, 0x99, 0xec, 0xd3, 0x20, 0xc2, 0x35, 0x68, 0xfb, 0xdc, 0x38, 0x63, 0xc4, 0xe5,
-	0x31, 0xad, 0x35, 0xbd, 0x25, 0xc8, 0x42, 0x0b, 0xba, 0x02, 0x2d, 0x1f, 0xd3, 0xa9, 0x33, 0xe3,
-	0x2b, 0x32, 0xbe, 0x26, 0xa7, 0x0a, 0x36, 0xed, 0xb7, 0x0a, 0x9c, 0xdf, 0xc2, 0x41, 0x1c, 0xbd,
-	0x50, 0x1d, 0x7e, 0x4d, 0xd1, 0xf5, 0x17, 0x0a, 0xb4, 0x33, 0x86, 0xa2, 0x55, 0xa8, 0x27, 0x78,
-	0x44, 0x80, 0x92, 0x24, 0xf4, 0x4d, 0x28, 0x87, 0xbe, 0xc3, 0xcc, 0xa4, 0xd6, 0x86, 0xb6, 0x9e,
-	0x6f, 0xee, 0xeb, 0x69, 0xa9, 0x3a, 0x9f, 0x80, 0x6e, 0xc0, 0x59, 0x09, 0xb2, 0x0a, 0xf3, 0x51,
-	0x1e, 0x58, 0xb5, 0xdf, 0x29, 0xd0, 0x93, 0x39, 0xf3, 0x34, 0x01, 0x7f, 0x08, 0x9d, 0x78, 0x35,
-	0x86, 0x85, 0xe9, 0xc8, 0xb7, 0x27, 0xac, 0xcc, 0x58, 0x33, 0xa8, 0x6f, 0x5c, 0x3e, 0x7a, 0x3d,
-	0x54, 0x5f, 0x89, 0x45, 0xf4, 0x13, 0x12, 0xb4, 0x9f, 0x28, 0xb0, 0xb2, 0x85, 0x83, 0x5d, 0x3c,
-	0x76, 0xb1, 0x17, 0x0c, 0xbc, 0x3d, 0x72, 0xf2, 0xc0, 0x5f, 0x02, 0xa0, 0x42, 0x4e, 0xdc, 0xa8,
-	0x12, 0x94, 0x45, 0x92, 0x40, 0xfb, 0x63, 0x11, 0xea, 0x09, 0x63, 0xd0, 0x05, 0xa8, 0xc5, 0x12,
-	0x44, 0x68, 0x67, 0x84, 0x9c, 0xc4, 0x82, 0x24, 0xad, 0x32, 0xe9, 0x51, 0xcc, 0xa7, 0xc7, 0x1c,
-	0x04, 0x47, 0xe7, 0xa1, 0xea, 0x62, 0xd7, 0xa0, 0xf6, 0x0b, 0x2c, 0x10, 0xa3, 0xe2, 0x62, 0x77,
-	0xd7, 0x7e, 0x81, 0xc3, 0x21, 0x6f, 0xea, 0x1a, 0x3e, 0x79, 0x46, 0xbb, 0xcb, 0x7c, 0xc8, 0x9b,
-	0xba, 0x3a, 0x79, 0x46, 0xd1, 0x45, 0x00, 0xdb, 0xb3, 0xf0, 0x73, 0xc3, 0x33, 0x5d, 0xdc, 0xad,
-	0xb0, 0x8a, 0xab, 0x31, 0xca, 0xb6, 0xe9, 0xe2, 0x10, 0x2b, 0xd8, 0xcf, 0xa0, 0xdf, 0xad, 0xf2,
-	0x89, 0xe2, 0x37, 0x5c, 0xaa, 0xa8, 0xd3, 0x41, 0xbf, 0x5b, 0xe3, 0xf3, 0x62, 0x02, 0xfa, 0x18,
-	0x9a, 0x62, 0xdd, 0x06, 0xcf, 0x65, 0x60, 0xb9, 0xbc, 0x2a, 0x8b, 0xbd, 0x70, 0x20, 0xcf, 0xe4,
-	0x06, 0x4d, 0xfc, 0xa1, 0xab, 0xd0, 0x1a, 0x11, 0x77, 0x62, 0x32, 0xef, 0xdc, 0xf1, 0x89, 0xdb,
-	0xad, 0xb3, 0x38, 0x65, 0xa8, 0xe8, 0x26, 0x9c, 0x1d, 0x31, 0xdc, 0xb2, 0x6e, 0x1f, 0x6c, 0xc6,
-	0x43, 0xdd, 0xc6, 0xaa, 0x72, 0xbd, 0xaa, 0xcb, 0x86, 0xd8, 0x8e, 0x36, 0x9b, 0x49, 0xa7, 0xc9,
-	0xfa, 0xaf, 0x43, 0xd9, 0xf6, 0xf6, 0x48, 0x94, 0xe4, 0x6f, 0x1e, 0xb2, 0x50, 0xa6, 0x8c, 0x73,
-	0x6b, 0x7f, 0x28, 0x42, 0xe7, 0x23, 0xcb, 0x92, 0x41, 0xf9, 0xf1, 0x33, 0x7a, 0x96, 0x19, 0x85,
-	0x54, 0x66, 0x2c, 0x02, 0x67, 0xef, 0xc0, 0x99, 0x0c, 0x4c, 0x8b, 0x04, 0xab, 0xe9, 0x6a, 0x1a,
-	0xa8, 0x07, 0x7d, 0xf4, 0x36, 0xa8, 0x69, 0xa8, 0x16, 0x4d, 0xaa, 0xa6, 0xb7, 0x53, 0x60, 0x3d,
-	0xe8, 0xa3, 0x6f, 0xc0, 0x1b, 0x63, 0x87, 0x0c, 0x4d, 0xc7, 0xa0, 0xd8, 0x74, 0xb0, 0x65, 0xcc,
-	0xea, 0x63, 0x99, 0x85, 0x72, 0x85, 0x0f, 0xef, 0xb2, 0xd1, 0xdd, 0xb8, 0x56, 0xb6, 0xc2, 0x04,
-	0xc2, 0x8f, 0x8d, 0x09, 0xa1, 0x2c, 0xf1, 0x59, 0x6a, 0xd6, 0xb3, 0x60, 0x18, 0x1f, 0x63, 0xee,
-	0xd1, 0xf1, 0x8e, 0xe0, 0x0c, 0x53, 0x08, 0x3f, 0x8e, 0xfe, 0xd0, 0x67, 0xd0, 0x91, 0x1a, 0x40,
-	0xbb, 0xd5, 0xc5, 0x22, 0x75, 0x4e, 0x62, 0x20, 0xd5, 0xfe, 0xa6, 0xc0, 0x79, 0x1d, 0xbb, 0xe4,
-	0x29, 0xfe, 0xd2, 0xc6, 0x4e, 0xfb, 0x7b, 0x01, 0x3a, 0xdf, 0x33, 0x83, 0xd1, 0x7e, 0xdf, 0x15,
-	0x44, 0xfa, 0x6a, 0x16, 0x98, 0x01, 0xc5, 0x52, 0x1e, 0x14, 0xe3, 0xf2, 0x2b, 0xcb, 0x82, 0x1a,
-	0x9e, 0x67, 0xd7, 0x3f, 0x8f, 0xd6, 0x3b, 0x2b, 0xbf, 0xc4, 0x6e, 0x72, 0xf9, 0x04, 0xbb, 0x49,
-	0xb4, 0x09, 0x4d, 0xfc, 0x7c, 0xe4, 0x4c, 0x2d, 0x6c, 0x70, 0xed, 0x15, 0xa6, 0xfd, 0x92, 0x44,
-	0x7b, 0x32, 0xa3, 0x1a, 0x62, 0xd2, 0x80, 0x41, 0xc0, 0x8f, 0x8a, 0xd0, 0x16, 0xa3, 0xe1, 0x06,
-	0x7c, 0x81, 0x3e, 0x92, 0x71, 0x47, 0x21, 0xef, 0x8e, 0x45, 0x9c, 0x1a, 0x6d, 0x7c, 0x4a, 0x89,
-	0x8d, 0xcf, 0x45, 0x80, 0x3d, 0x67, 0x4a, 0xf7, 0x8d, 0xc0, 0x76, 0xa3, 0x2e, 0x52, 0x63, 0x94,
-	0x07, 0xb6, 0x8b, 0xd1, 0x47, 0xd0, 0x18, 0xda, 0x9e, 0x43, 0xc6, 0xc6, 0xc4, 0x0c, 0xf6, 0x29,
-	0xab, 0x60, 0xf9, 0x72, 0xef, 0xd8, 0xd8, 0xb1, 0x6e, 0x33, 0x5e, 0xbd, 0xce, 0xe7, 0xec, 0x84,
-	0x53, 0xd0, 0x25, 0xa8, 0x87, 0xad, 0x88, 0xec, 0xf1, 0x6e, 0x54, 0xe1, 0x2a, 0xbc, 0xa9, 0x7b,
-	0x7f, 0x8f, 0xf5, 0xa3, 0x6f, 0x43, 0x2d, 0x44, 0x54, 0xea, 0x90, 0x71, 0x54, 0xa1, 0x47, 0xc9,
-	0x9f, 0x4d, 0x40, 0x1f, 0x42, 0xcd, 0xc2, 0x4e, 0x60, 0xb2, 0xd9, 0xb5, 0xb9, 0xa9, 0xd0, 0x0f,
-	0x79, 0xee, 0x92, 0x31, 0x8b, 0xc6, 0x6c, 0x86, 0xf6, 0xef, 0x02, 0x9c, 0x0d, 0x63, 0x10, 0x55,
-	0xf9, 0xc9, 0xb3, 0xfd, 0x22, 0x80, 0x45, 0x03, 0x23, 0x95, 0xf1, 0x35, 0x8b, 0x06, 0xdb, 0x3c,
-	0xe9, 0x3f, 0x88, 0xd2, 0xb5, 0x38, 0x7f, 0x4b, 0x94, 0xc9, 0x89, 0x7c, 0xca, 0x9e, 0xe4, 0x18,
-	0x8a, 0xbe, 0x03, 0x2d, 0x87, 0x98, 0x96, 0x31, 0x22, 0x9e, 0xc5, 0x81, 0xb5, 0xcc, 0x3a, 0xf3,
-	0x5b, 0x32, 0x13, 0x1e, 0xf8, 0xf6, 0x78, 0x8c, 0xfd, 0xcd, 0x88, 0x57, 0x6f, 0x3a, 0xec, 0x10,
-	0x2e, 0x7e, 0xd1, 0x65, 0x68, 0x52, 0x32, 0xf5, 0x47, 0x38, 0x5a, 0x28, 0xdf, 0x5c, 0x34, 0x38,
-	0x71, 0x5b, 0x5e, 0xe0, 0x15, 0xc9, 0x3e, 0xea, 0xaf, 0x0a, 0x74, 0xc4, 0xb1, 0xec, 0xf4, 0xbe,
-	0x9f, 0x87, 0x34, 0x51, 0xc2, 0x17, 0x0f, 0xd9, 0xe9, 0x97, 0x16, 0xd8, 0xe9, 0x97, 0x25, 0x87,
-	0xb5, 0xf4, 0x66, 0x72, 0x39, 0xbb, 0x99, 0xd4, 0x1e, 0x40, 0x33, 0x06, 0x51, 0x56, 0xe1, 0x97,
-	0xa1, 0xc9, 0xcd, 0x32, 0x42, 0x97, 0x62, 0x2b, 0x3a, 0xa9, 0x71, 0xe2, 0x5d, 0x46, 0x0b, 0xa5,
-	0xc6, 0x20, 0xcd, 0x77, 0x16, 0x35, 0x3d, 0x41, 0xd1, 0x7e, 0x5f, 0x00, 0x35, 0xd9, 0x7e, 0x98,
-	0xe4, 0x45, 0x8e, 0x80, 0xd7, 0xa0, 0x2d, 0x2e, 0x11, 0xe3, 0x1e, 0x20, 0x0e, 0x65, 0x4f, 0x92,
-	0xe2, 0xfa, 0xe8, 0x7d, 0xe8, 0x70, 0xc6, 0x5c, 0xcf, 0xe0, 0x87, 0xb3, 0x73, 0x6c, 0x54, 0xcf,
-	0x34, 0xfd, 0xf9, 0x3d, 0xb7, 0x74, 0x8a, 0x9e, 0x9b, 0xdf, 0x13, 0x94, 0x4f, 0xb6, 0x27, 0xd0,
-	0xfe, 0x52, 0x84, 0xd6, 0xac, 0x42, 0x16, 0xf6, 0xda, 0x22, 0x97, 0x5b, 0xdb, 0xa0, 0xce, 0x4e,
-	0x3f, 0x6c, 0xeb, 0x7b, 0x68, 0x91, 0x67, 0xcf, 0x3d, 0xed, 0x49, 0xe6, 0xb8, 0x78, 0x07, 0x9a,
-	0xc2, 0xe7, 0xa2, 0xc5, 0x70, 0x0f, 0x7e, 0x55, 0x26, 0x2c, 0x95, 0x61, 0x7a, 0x23, 0xd1, 0xef,
-	0x28, 0xfa, 0x00, 0x6a, 0xac, 0xee, 0x83, 0x83, 0x09, 0x16, 0x25, 0x7f, 0x41, 0x26, 0x23, 0xcc,
-	0xbc, 0x07, 0x07, 0x13, 0xac, 0x57, 0x1d, 0xf1, 0x75, 0xda, 0x26, 0x79, 0x0b, 0x56, 0x7c, 0x5e,
-	0xda, 0x96, 0x91, 0x72, 0x5f, 0x85, 0xb9, 0xef, 0x5c, 0x34, 0xb8, 0x93, 0x74, 0xe3, 0x9c, 0x93,
-	0x6c, 0x75, 0xee, 0x49, 0xf6, 0x67, 0x05, 0xe8, 0x84, 0xb6, 0xdf, 0x36, 0x1d, 0xd3, 0x1b, 0xe1,
-	0xc5, 0x0f, 0x65, 0xff, 0x9d, 0x66, 0x9a, 0x43, 0xc2, 0x92, 0x04, 0x09, 0xd3, 0x4d, 0xa1, 0x9c,
-	0x6d, 0x0a, 0x6f, 0x42, 0x5d, 0xc8, 0xb0, 0x88, 0x87, 0x99, 0xb3, 0xab, 0x3a, 0x70, 0x52, 0x9f,
-	0x78, 0xec, 0x18, 0x17, 0xce, 0x67, 0xa3, 0x15, 0x36, 0x5a, 0xb1, 0x68, 0xc0, 0x86, 0x2e, 0x02,
-	0x3c, 0x35, 0x1d, 0xdb, 0x62, 0x49, 0xc2, 0xdc, 0x54, 0xd5, 0x6b, 0x8c, 0x12, 0xba, 0x40, 0xfb,
-	0xa9, 0x02, 0x9d, 0x4f, 0x4c, 0xcf, 0x22, 0x7b, 0x7b, 0xa7, 0xc7, 0xd7, 0x4d, 0x88, 0x0e, 0x69,
-	0x83, 0xe3, 0x9c, 0x78, 0x52, 0x93, 0xb4, 0x3f, 0x29, 0x80, 0x12, 0xf1, 0x3a, 0xb9, 0x35, 0x57,
-	0xa0, 0x95, 0xf2, 0x7c, 0x7c, 0x87, 0x9f, 0x74, 0x3d, 0x0d, 0xfb, 0xde, 0x90, 0xab, 0x32, 0x7c,
-	0x6c, 0x52, 0xe2, 0xb1, 0x30, 0x2e, 0xdc, 0xf7, 0x86, 0x91, 0x99, 0xe1, 0x54, 0xed, 0x5f, 0x0a,
-	0x9c, 0x11, 0x4b, 0x0b, 0x2b, 0x6e, 0x8c, 0x23, 0x48, 0x27, 0x9e, 0x63, 0x7b, 0x71, 0x0e, 0x08,
-	0x0c, 0xe1, 0x44, 0x11, 0xe4, 0x4f, 0xa0, 0x2d, 0x98, 0x62, 0x4c, 0x5c, 0xd0, 0x7f, 0x2d, 0x3e,
-	0x2f, 0x46, 0xc3, 0x2b, 0xd0, 0x22, 0x7b, 0x7b, 0x49, 0x7d, 0x3c, 0x31, 0x9b, 0x82, 0x2a, 0x14,
-	0x7e, 0x0a, 0x6a, 0xc4, 0x76, 0x5c, 0x14, 0x6e, 0x8b, 0x89, 0xf1, 0xa1, 0xe7, 0xc7, 0x0a, 0x74,
-	0xd3, 0x98, 0x9c, 0x58, 0xfe, 0xf1, 0x43, 0xf7, 0xad, 0xf4, 0x99, 0xf9, 0xca, 0x21, 0xf6, 0xcc,
-	0xf4, 0x88, 0x7d, 0xd0, 0xda, 0x0b, 0x68, 0xa5, 0xc1, 0x13, 0x35, 0xa0, 0xba, 0x4d, 0x82, 0x8f,
-	0x9f, 0xdb, 0x34, 0x50, 0x97, 0x50, 0x0b, 0x60, 0x9b, 0x04, 0x3b, 0x3e, 0xa6, 0xd8, 0x0b, 0x54,
-	0x05, 0x01, 0x2c, 0xdf, 0xf7, 0xfa, 0x36, 0x7d, 0xac, 0x16, 0xd0, 0x59, 0x71, 0x2d, 0x67, 0x3a,
-	0x03, 0x81, 0x24, 0x6a, 0x31, 0x9c, 0x1e, 0xff, 0x95, 0x90, 0x0a, 0x8d, 0x98, 0x65, 0x6b, 0xe7,
-	0x33, 0xb5, 0x8c, 0x6a, 0x50, 0xe6, 0x9f, 0xcb, 0x6b, 0xf7, 0x41, 0xcd, 0xa6, 0x08, 0xaa, 0x43,
-	0x65, 0x9f, 0x57, 0x98, 0xba, 0x84, 0xda, 0x50, 0x77, 0x66, 0xc9, 0xad, 0x2a, 0x21, 0x61, 0xec,
-	0x4f, 0x46, 0x22, 0xcd, 0xd5, 0x42, 0xa8, 0x2d, 0x8c, 0x5a, 0x9f, 0x3c, 0xf3, 0xd4, 0xe2, 0xda,
-	0xa7, 0xd0, 0x48, 0xde, 0x82, 0xa0, 0x2a, 0x94, 0xb6, 0x89, 0x87, 0xd5, 0xa5, 0x50, 0xec, 0x96,
-	0x4f, 0x9e, 0xd9, 0xde, 0x98, 0xaf, 0xe1, 0x8e, 0x4f, 0x5e, 0x60, 0x4f, 0x2d, 0x84, 0x03, 0x61,
-	0x73, 0x0d, 0x07, 0x8a, 0xe1, 0x00, 0xef, 0xb4, 0x6a, 0x69, 0xed, 0x3d, 0xa8, 0x46, 0x20, 0x8e,
-	0xce, 0x40, 0x33, 0x75, 0xa9, 0xaf, 0x2e, 0x21, 0xc4, 0x37, 0x80, 0x33, 0xb8, 0x56, 0x95, 0x8d,
-	0x7f, 0x02, 0x00, 0xdf, 0x47, 0x10, 0xe2, 0x5b, 0x68, 0x02, 0x68, 0x0b, 0x07, 0x9b, 0xc4, 0x9d,
-	0x10, 0x2f, 0x32, 0x89, 0xa2, 0x9b, 0x73, 0xda, 0x6c, 0x9e, 0x55, 0xac, 0xb2, 0x77, 0x75, 0xce,
-	0x8c, 0x0c, 0xbb, 0xb6, 0x84, 0x5c, 0xa6, 0x31, 0x3c, 0x63, 0x3c, 0xb0, 0x47, 0x8f, 0xa3, 0x1b,
-	0xe1, 0x43, 0x34, 0x66, 0x58, 0x23, 0x8d, 0x99, 0x1e, 0x2b, 0x7e, 0x76, 0x03, 0xdf, 0xf6, 0xc6,
-	0xd1, 0xfd, 0x8e, 0xb6, 0x84, 0x9e, 0xc0, 0xb9, 0x2d, 0xcc, 0xb4, 0xdb, 0x34, 0xb0, 0x47, 0x34,
-	0x52, 0xb8, 0x31, 0x5f, 0x61, 0x8e, 0xf9, 0x98, 0x2a, 0x1d, 0x68, 0x67, 0x5e, 0x2e, 0xd1, 0x9a,
-	0x34, 0xdf, 0xa5, 0xaf, 0xac, 0xbd, 0x77, 0x16, 0xe2, 0x8d, 0xb5, 0xd9, 0xd0, 0x4a, 0xbf, 0xea,
-	0xa1, 0xb7, 0xe7, 0x09, 0xc8, 0x3d, 0x83, 0xf4, 0xd6, 0x16, 0x61, 0x8d, 0x55, 0x3d, 0x84, 0x56,
-	0xfa, 0xdd, 0x48, 0xae, 0x4a, 0xfa, 0xb6, 0xd4, 0x3b, 0xec, 0x6a, 0x4d, 0x5b, 0x42, 0x3f, 0x80,
-	0x33, 0xb9, 0xc7, 0x1a, 0xf4, 0x35, 0x99, 0xf8, 0x79, 0x6f, 0x3a, 0x47, 0x69, 0x10, 0xd6, 0xcf,
-	0xbc, 0x38, 0xdf, 0xfa, 0xdc, 0xab, 0xdd, 0xe2, 0xd6, 0x27, 0xc4, 0x1f, 0x66, 0xfd, 0xb1, 0x35,
-	0x4c, 0x01, 0xe5, 0x9f, 0x6b, 0xd0, 0xbb, 0x32, 0x15, 0x73, 0x9f, 0x8c, 0x7a, 0xeb, 0x8b, 0xb2,
-	0xc7, 0x21, 0x9f, 0xb2, 0x6a, 0xcd, 0x3e, 0x6c, 0x48, 0xd5, 0xce, 0x7d, 0xa9, 0x91, 0xab, 0x9d,
-	0xff, 0x16, 0xc1, 0x93, 0x3a, 0x7d, 0x63, 0x2b, 0x8f, 0x95, 0xf4, 0x7d, 0x40, 0x9e, 0xd4, 0xf2,
-	0x0b, 0x60, 0x6d, 0x09, 0x19, 0x00, 0x5b, 0x38, 0xb8, 0x87, 0x03, 0xdf, 0x1e, 0x51, 0x74, 0x55,
-	0x5a, 0xe2, 0x33, 0x86, 0x48, 0xc7, 0xb5, 0x23, 0xf9, 0x22, 0x05, 0x1b, 0x7f, 0xae, 0x41, 0x8d,
-	0x79, 0x37, 0xec, 0xd2, 0xff, 0x07, 0xdc, 0x97, 0x00, 0xb8, 0x8f, 0xa0, 0x9d, 0xb9, 0x58, 0x97,
-	0x03, 0xae, 0xfc, 0xf6, 0xfd, 0xa8, 0xca, 0x1b, 0x02, 0xca, 0xdf, 0xfe, 0xca, 0x4b, 0x60, 0xee,
-	0x2d, 0xf1, 0x51, 0x3a, 0x1e, 0x41, 0x3b, 0x73, 0xfb, 0x2a, 0x5f, 0x81, 0xfc, 0x8a, 0xf6, 0x28,
-	0xe9, 0x9f, 0x43, 0x23, 0x79, 0xd5, 0x85, 0xae, 0xcd, 0xc3, 0xbd, 0xcc, 0x81, 0xe1, 0xd5, 0xa3,
-	0xde, 0xcb, 0xef, 0x0a, 0x8f, 0xa0, 0x9d, 0xb9, 0x8d, 0x92, 0x7b, 0x5e, 0x7e, 0x65, 0x75, 0x94,
-	0xf4, 0x2f, 0x11, 0x8e, 0xdd, 0x7e, 0xff, 0xe1, 0xc6, 0xd8, 0x0e, 0xf6, 0xa7, 0xc3, 0x70, 0x95,
-	0x37, 0x38, 0xe7, 0xbb, 0x36, 0x11, 0x5f, 0x37, 0xa2, 0x82, 0xbe, 0xc1, 0x24, 0xdd, 0x60, 0xd6,
-	0x4e, 0x86, 0xc3, 0x65, 0xf6, 0x7b, 0xeb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x61, 0x1b, 0x06,
-	0x95, 0xca, 0x26, 0x00, 0x00,
+	// 2761 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x1a, 0x5d, 0x8f, 0x1c, 0x47,
+	0xf1, 0x66, 0x77, 0x6f, 0x3f, 0x6a, 0x3f, 0xdd, 0xb6, 0x2f, 0xeb, 0x25, 0x4e, 0x2e, 0x93, 0xf8,
+	0x23, 0x67, 0x72, 0x76, 0x2e, 0x21, 0x10, 0x41, 0x90, 0xe2, 0xdb, 0xf8, 0xb2, 0x89, 0x7d, 0xbe,
+	0xcc, 0x9d, 0x83, 0x88, 0x22, 0x2d, 0x73, 0x33, 0x7d, 0xbb, 0xa3, 0x9b, 0x99, 0x5e, 0x4f, 0xcf,
+	0xda, 0x3e, 0x3f, 0x21, 0x84, 0x04, 0x08, 0x21, 0xc4, 0x33, 0x1f, 0x12, 0x28, 0x28, 0xe2, 0x01,
+	0xf1, 0x04, 0xcf, 0xbc, 0xf3, 0xc2, 0x0b, 0xaf, 0x48, 0x88, 0xdf, 0xc0, 0x3b, 0xea, 0x8f, 0x99,
+	0x9d, 0xcf, 0xbb, 0xbd, 0x3b, 0x3b, 0xb1, 0x10, 0x6f, 0xd3, 0xd5, 0xd5, 0x55, 0xd5, 0x55, 0xd5,
+	0x55, 0xd5, 0xd5, 0x03, 0x67, 0xee, 0x4f, 0xb1, 0x77, 0x30, 0x34, 0x08, 0xf1, 0xcc, 0xd5, 0x89,
+	0x47, 0x7c, 0x82, 0x90, 0x63, 0xd9, 0x0f, 0xa6, 0x54, 0x8c, 0x56, 0xf9, 0x7c, 0xaf, 0x61, 0x10,
+	0xc7, 0x21, 0xae, 0x80, 0xf5, 0x1a, 0x51, 0x8c, 0x5e, 0xcb, 0x72, 0x7d, 0xec, 0xb9, 0xba, 0x1d,
+	0xcc, 0x52, 0x63, 0x8c, 0x1d, 0x5d, 0x8e, 0x3a, 0xa6, 0xee, 0xeb, 0x51, 0xfa, 0xea, 0x0f, 0x15,
+	0x58, 0xda, 0x1e, 0x93, 0x87, 0xeb, 0xc4, 0xb6, 0xb1, 0xe1, 0x5b, 0xc4, 0xa5, 0x1a, 0xbe, 0x3f,
+	0xc5, 0xd4, 0x47, 0x37, 0xa0, 0xb4, 0xab, 0x53, 0xdc, 0x55, 0x96, 0x95, 0xab, 0xf5, 0xb5, 0xe7,
+	0x57, 0x63, 0x92, 0x48, 0x11, 0xee, 0xd0, 0xd1, 0x4d, 0x9d, 0x62, 0x8d, 0x63, 0x22, 0x04, 0x25,
+	0x73, 0x77, 0xd0, 0xef, 0x16, 0x96, 0x95, 0xab, 0x45, 0x8d, 0x7f, 0xa3, 0x57, 0xa0, 0x69, 0x84,
+	0xb4, 0x07, 0x7d, 0xda, 0x2d, 0x2e, 0x17, 0xaf, 0x16, 0xb5, 0x38, 0x50, 0xfd, 0x5c, 0x81, 0xe7,
+	0x52, 0x62, 0xd0, 0x09, 0x71, 0x29, 0x46, 0x6f, 0x40, 0x99, 0xfa, 0xba, 0x3f, 0xa5, 0x52, 0x92,
+	0xaf, 0x64, 0x4a, 0xb2, 0xcd, 0x51, 0x34, 0x89, 0x9a, 0x66, 0x5b, 0xc8, 0x60, 0x8b, 0x5e, 0x87,
+	0x73, 0x96, 0x7b, 0x07, 0x3b, 0xc4, 0x3b, 0x18, 0x4e, 0xb0, 0x67, 0x60, 0xd7, 0xd7, 0x47, 0x38,
+	0x90, 0xf1, 0x6c, 0x30, 0xb7, 0x35, 0x9b, 0x52, 0x7f, 0xaf, 0xc0, 0x79, 0x26, 0xe9, 0x96, 0xee,
+	0xf9, 0xd6, 0x53, 0xd0, 0x97, 0x0a, 0x8d, 0xa8, 0x8c, 0xdd, 0x22, 0x9f, 0x8b, 0xc1, 0x18, 0xce,
+	0x24, 0x60, 0xcf, 0xf6, 0x56, 0xe2, 0xe2, 0xc6, 0x60, 0xea, 0x67, 0xd2, 0xb0, 0x51, 0x39, 0x4f,
+	0xa3, 0xd0, 0x24, 0xcf, 0x42, 0x9a, 0xe7, 0x49, 0xd4, 0xf9, 0x79, 0x01, 0xce, 0xdf, 0x26, 0xba,
+	0x39, 0x33, 0xfc, 0x17, 0xaf, 0xce, 0x77, 0xa0, 0x2c, 0x4e, 0x49, 0xb7, 0xc4, 0x79, 0x5d, 0x8a,
+	0xf3, 0x12, 0x73, 0xab, 0x33, 0x09, 0xb7, 0x39, 0x40, 0x93, 0x8b, 0x10, 0x86, 0xee, 0xd4, 0xb5,
+	0x5c, 0x13, 0x3f, 0xc2, 0xe6, 0x90, 0xe2, 0x91, 0x83, 0x5d, 0x7f, 0x38, 0x21, 0xb6, 0x65, 0x1c,
+	0x74, 0x17, 0x97, 0x95, 0xab, 0xad, 0xb5, 0x6b, 0x99, 0xc2, 0xdf, 0x0b, 0x16, 0x6d, 0x8b, 0x35,
+	0x5b, 0x7c, 0x89, 0xb6, 0x34, 0xcd, 0x84, 0xab, 0xbf, 0x52, 0xa0, 0xab, 0x61, 0x1b, 0xeb, 0x14,
+	0x7f, 0x99, 0xca, 0x5a, 0x82, 0xb2, 0x4b, 0x4c, 0x3c, 0xe8, 0x73, 0x65, 0x15, 0x35, 0x39, 0x52,
+	0xff, 0x26, 0x0d, 0xf9, 0x8c, 0x9f, 0x8b, 0x88, 0xb1, 0x17, 0x9f, 0xb4, 0xb1, 0xcb, 0x4f, 0xce,
+	0xd8, 0x7f, 0x9d, 0x19, 0xfb, 0x59, 0x57, 0xe8, 0xcc, 0x21, 0x16, 0x63, 0x0e, 0xf1, 0x5d, 0xb8,
+	0xb0, 0xee, 0x61, 0xdd, 0xc7, 0x1f, 0xb1, 0xa4, 0xb5, 0x3e, 0xd6, 0x5d, 0x17, 0xdb, 0xc1, 0x16,
+	0x92, 0xcc, 0x95, 0x0c, 0xe6, 0x5d, 0xa8, 0x4c, 0x3c, 0xf2, 0xe8, 0x20, 0x94, 0x3b, 0x18, 0xaa,
+	0xbf, 0x55, 0xa0, 0x97, 0x45, 0xfb, 0x34, 0xf1, 0xed, 0x0a, 0xb4, 0x3d, 0x21, 0xdc, 0xd0, 0x10,
+	0xf4, 0x38, 0xd7, 0x9a, 0xd6, 0x92, 0x60, 0xc9, 0x05, 0x5d, 0x82, 0x96, 0x87, 0xe9, 0xd4, 0x9e,
+	0xe1, 0x15, 0x39, 0x5e, 0x53, 0x40, 0x25, 0x9a, 0xfa, 0x07, 0x05, 0x2e, 0x6c, 0x60, 0x3f, 0xb4,
+	0x1e, 0x63, 0x87, 0x9f, 0xd1, 0x5c, 0xf1, 0x1b, 0x05, 0xda, 0x09, 0x41, 0xd1, 0x32, 0xd4, 0x23,
+	0x38, 0xd2, 0x40, 0x51, 0x10, 0xfa, 0x06, 0x2c, 0x32, 0xdd, 0x61, 0x2e, 0x52, 0x6b, 0x4d, 0x5d,
+	0x4d, 0x97, 0x2a, 0xab, 0x71, 0xaa, 0x9a, 0x58, 0x80, 0xae, 0xc3, 0xd9, 0x8c, 0x3c, 0x21, 0xc5,
+	0x47, 0xe9, 0x34, 0xa1, 0xfe, 0x51, 0x81, 0x5e, 0x96, 0x32, 0x4f, 0x63, 0xf0, 0x4f, 0x60, 0x29,
+	0xdc, 0xcd, 0xd0, 0xc4, 0xd4, 0xf0, 0xac, 0x09, 0xfb, 0x16, 0xa9, 0xad, 0xbe, 0xf6, 0xf2, 0xd1,
+	0xfb, 0xa1, 0xda, 0xf9, 0x90, 0x44, 0x3f, 0x42, 0x41, 0xfd, 0x99, 0x02, 0xe7, 0x37, 0xb0, 0x2f,
+	0xcf, 0xf4, 0xc0, 0xdd, 0x23, 0x27, 0x37, 0xfc, 0x0b, 0x00, 0x32, 0xce, 0xcc, 0xd2, 0x6e, 0x04,
+	0x32, 0x8f, 0x13, 0xa8, 0xdf, 0x2f, 0x41, 0x3d, 0x22, 0x0c, 0x7a, 0x1e, 0x6a, 0x21, 0x05, 0x69,
+	0xda, 0x19, 0x20, 0x45, 0xb1, 0x90, 0xe1, 0x56, 0x09, 0xf7, 0x28, 0xa6, 0xdd, 0x23, 0x27, 0x51,
+	0xa0, 0x0b, 0x50, 0x75, 0xb0, 0x33, 0xa4, 0xd6, 0x63, 0x2c, 0x23, 0x46, 0xc5, 0xc1, 0xce, 0xb6,
+	0xf5, 0x18, 0xb3, 0x29, 0x77, 0xea, 0x0c, 0x3d, 0xf2, 0x90, 0xf2, 0x60, 0x5a, 0xd4, 0x2a, 0xee,
+	0xd4, 0xd1, 0xc8, 0x43, 0x8a, 0x2e, 0x02, 0xf0, 0x40, 0x39, 0x74, 0x75, 0x07, 0x77, 0x2b, 0xfc,
+	0xc4, 0xd5, 0x38, 0x64, 0x53, 0x77, 0x30, 0x8b, 0x15, 0x7c, 0x30, 0xe8, 0x77, 0xab, 0x62, 0xa1,
+	0x1c, 0xb2, 0xad, 0xca, 0x73, 0x3a, 0xe8, 0x77, 0x6b, 0x62, 0x5d, 0x08, 0x40, 0xef, 0x41, 0x33,
+	0x08, 0xe2, 0xc2, 0x97, 0x81, 0xfb, 0xf2, 0x72, 0x96, 0xed, 0xa5, 0x02, 0x85, 0x27, 0x37, 0x68,
+	0x64, 0x84, 0x2e, 0x43, 0xcb, 0x20, 0xce, 0x44, 0xe7, 0xda, 0xb9, 0xe5, 0x11, 0xa7, 0x5b, 0xe7,
+	0x76, 0x4a, 0x40, 0xd1, 0x0d, 0x38, 0x6b, 0xf0, 0xb8, 0x65, 0xde, 0x3c, 0x58, 0x0f, 0xa7, 0xba,
+	0x8d, 0x65, 0xe5, 0x6a, 0x55, 0xcb, 0x9a, 0x62, 0x1b, 0x7b, 0x80, 0x3d, 0xca, 0xb0, 0x9a, 0x62,
+	0x63, 0x72, 0x88, 0x5e, 0x03, 0x34, 0xcb, 0x44, 0x7b, 0x16, 0xb6, 0x4d, 0xe6, 0x1f, 0x2d, 0xce,
+	0xf7, 0x4c, 0x38, 0x73, 0x4b, 0x4e, 0xf0, 0x42, 0x3f, 0xe9, 0x92, 0xa7, 0x39, 0x3e, 0x5f, 0x83,
+	0x45, 0xcb, 0xdd, 0x23, 0xc1, 0x69, 0x79, 0xf1, 0x10, 0x8d, 0x71, 0x66, 0x02, 0x5b, 0x75, 0x85,
+	0x14, 0x63, 0xdd, 0x33, 0x6f, 0x63, 0xdd, 0xc4, 0xde, 0x29, 0x42, 0xe2, 0x1c, 0x7e, 0xaa, 0xee,
+	0x43, 0x4b, 0x4a, 0x41, 0xef, 0xba, 0x9b, 0xc4, 0xc4, 0x11, 0xbf, 0x54, 0x62, 0x7e, 0xf9, 0x12,
+	0x34, 0xd8, 0xd7, 0x50, 0x37, 0x4d, 0x0f, 0x53, 0x2a, 0xa3, 0x7f, 0x9d, 0xc1, 0xde, 0x15, 0xa0,
+	0xc4, 0x51, 0x2c, 0x26, 0x8f, 0xa2, 0xfa, 0x27, 0x05, 0xea, 0x91, 0xad, 0x31, 0x92, 0xd2, 0xd5,
+	0x84, 0xdb, 0x2a, 0x82, 0xa4, 0x84, 0x71, 0xc7, 0x9d, 0x49, 0x53, 0x88, 0x49, 0xd3, 0x85, 0x4a,
+	0x20, 0x88, 0x48, 0x2f, 0xc1, 0x10, 0x7d, 0x08, 0x6d, 0x8a, 0x75, 0x7b, 0x56, 0x7e, 0x88, 0x98,
+	0x5e, 0xcf, 0x0e, 0xc0, 0xf1, 0xcd, 0x6b, 0x2d, 0xb1, 0x34, 0x80, 0xaa, 0x3f, 0x52, 0xe0, 0xb9,
+	0x94, 0x3d, 0x4e, 0xe3, 0x16, 0x5f, 0x87, 0x32, 0x65, 0xc4, 0x0e, 0xf7, 0x8b, 0x19, 0x3b, 0x4d,
+	0xa2, 0xab, 0x7f, 0x29, 0xc2, 0xd2, 0xbb, 0xa6, 0x99, 0x55, 0x2c, 0x1c, 0xdf, 0x33, 0xf2, 0xb4,
+	0x3a, 0x4f, 0xc2, 0xbc, 0x06, 0x67, 0x12, 0x85, 0x80, 0x0c, 0x61, 0x35, 0xad, 0x13, 0x2f, 0x05,
+	0x06, 0x7d, 0xf4, 0x2a, 0x74, 0xe2, 0xc5, 0x80, 0x2c, 0x83, 0x6a, 0x5a, 0x3b, 0x56, 0x0e, 0x0c,
+	0xfa, 0xe8, 0x2d, 0x78, 0x6e, 0x64, 0x93, 0x5d, 0xdd, 0x1e, 0xc6, 0xcd, 0x37, 0xe8, 0x77, 0xcb,
+	0xdc, 0x93, 0xce, 0x8b, 0xe9, 0xed, 0xa8, 0x85, 0x06, 0x7d, 0xb4, 0xc1, 0x42, 0x14, 0xde, 0x1f,
+	0x4e, 0x08, 0xe5, 0xa1, 0x95, 0x07, 0xbf, 0x94, 0xb5, 0xc3, 0x6b, 0xff, 0x1d, 0x3a, 0xda, 0x92,
+	0x98, 0x2c, 0x48, 0xe1, 0xfd, 0x60, 0x84, 0xee, 0xc1, 0x52, 0xa6, 0x00, 0xb4, 0x5b, 0x9d, 0xef,
+	0x08, 0x9f, 0xcb, 0x10, 0x90, 0xaa, 0xff, 0x52, 0xe0, 0x82, 0x86, 0x1d, 0xf2, 0x00, 0xff, 0xcf,
+	0xda, 0x4e, 0xfd, 0x77, 0x01, 0x96, 0xbe, 0xa3, 0xfb, 0xc6, 0xb8, 0xef, 0x48, 0x20, 0xfd, 0x72,
+	0x36, 0x98, 0x48, 0xbb, 0xa5, 0x74, 0xda, 0x0d, 0xe3, 0xf2, 0x62, 0x96, 0x51, 0x59, 0xff, 0x67,
+	0xf5, 0xe3, 0x60, 0xbf, 0xb3, 0xb8, 0x1c, 0xb9, 0x16, 0x95, 0x4f, 0x72, 0x2d, 0x5a, 0x87, 0x26,
+	0x7e, 0x64, 0xd8, 0x53, 0x13, 0x0f, 0x05, 0xf7, 0x0a, 0xe7, 0xfe, 0x42, 0x06, 0xf7, 0xa8, 0x47,
+	0x35, 0xe4, 0xa2, 0x01, 0xcf, 0x0d, 0xbf, 0x2b, 0x42, 0x5b, 0xce, 0xb2, 0x9b, 0xe4, 0x1c, 0x95,
+	0x4a, 0x42, 0x1d, 0x85, 0xb4, 0x3a, 0xe6, 0x51, 0x6a, 0x50, 0x5a, 0x97, 0x22, 0xa5, 0xf5, 0x45,
+	0x80, 0x3d, 0x7b, 0x4a, 0xc7, 0x43, 0xdf, 0x72, 0x82, 0x3a, 0xa5, 0xc6, 0x21, 0x3b, 0x96, 0x83,
+	0xd1, 0xbb, 0xd0, 0xd8, 0xb5, 0x5c, 0x9b, 0x8c, 0x86, 0x13, 0xdd, 0x1f, 0xd3, 0x6e, 0x39, 0x77,
+	0xbb, 0x3c, 0x01, 0xdf, 0xe4, 0xb8, 0x5a, 0x5d, 0xac, 0xd9, 0x62, 0x4b, 0xd0, 0x0b, 0x50, 0x67,
+	0xc5, 0x0e, 0xd9, 0x13, 0xf5, 0x4e, 0x45, 0xb0, 0x70, 0xa7, 0xce, 0xdd, 0x3d, 0x5e, 0xf1, 0x7c,
+	0x0b, 0x6a, 0x2c, 0xa6, 0x52, 0x9b, 0x8c, 0x82, 0x13, 0x7a, 0x14, 0xfd, 0xd9, 0x02, 0xf4, 0x0e,
+	0xd4, 0x4c, 0x6c, 0xfb, 0x3a, 0x5f, 0x5d, 0xcb, 0x75, 0x85, 0x3e, 0xc3, 0xb9, 0x4d, 0x46, 0xdc,
+	0x1a, 0xb3, 0x15, 0xd1, 0xb2, 0x03, 0x62, 0x65, 0x87, 0xfa, 0xeb, 0x22, 0x9c, 0x65, 0xd6, 0x09,
+	0xce, 0xff, 0xc9, 0xcf, 0xc1, 0x45, 0x00, 0x93, 0xfa, 0xc3, 0xd8, 0x59, 0xa8, 0x99, 0xd4, 0xdf,
+	0xe4, 0x00, 0xf4, 0x76, 0xe0, 0xc8, 0xc5, 0xfc, 0x72, 0x3c, 0xe1, 0x2d, 0x69, 0x67, 0x3e, 0x51,
+	0x43, 0xe7, 0x43, 0x68, 0xd9, 0x44, 0x37, 0x87, 0x06, 0x71, 0x4d, 0x11, 0x72, 0x45, 0x1b, 0xe7,
+	0x95, 0x2c, 0x11, 0x76, 0x3c, 0x6b, 0x34, 0xc2, 0xde, 0x7a, 0x80, 0xab, 0x35, 0x6d, 0xde, 0xce,
+	0x92, 0x43, 0xf4, 0x32, 0x34, 0x29, 0x99, 0x7a, 0x06, 0x0e, 0x36, 0x2a, 0x0a, 0xdb, 0x86, 0x00,
+	0x6e, 0x66, 0x1f, 0xfd, 0x4a, 0x86, 0x97, 0x5e, 0x82, 0x96, 0x24, 0x14, 0x14, 0x06, 0x55, 0x71,
+	0xef, 0x14, 0x50, 0x59, 0xa3, 0xa8, 0xff, 0x50, 0xa0, 0xb9, 0x8d, 0x75, 0xcf, 0x18, 0x07, 0x96,
+	0x79, 0x0b, 0x8a, 0x1e, 0xbe, 0x2f, 0x0d, 0xf3, 0x4a, 0x4e, 0xda, 0x88, 0x2d, 0xd1, 0xd8, 0x02,
+	0xf4, 0x22, 0xd4, 0x4d, 0xc7, 0x4e, 0xdc, 0x86, 0xc1, 0x74, 0xec, 0xe0, 0x26, 0x7c, 0x44, 0x39,
+	0xc4, 0x2a, 0x15, 0x0f, 0x3b, 0xc4, 0xc7, 0x27, 0xaa, 0x54, 0xc4, 0xd2, 0x30, 0xcd, 0x7c, 0xa6,
+	0x40, 0x2b, 0x10, 0xf2, 0x34, 0x05, 0xca, 0xb7, 0xa1, 0x22, 0xa2, 0x7b, 0x50, 0xa1, 0x1c, 0xa5,
+	0x11, 0x8e, 0xab, 0x05, 0x8b, 0x98, 0xd7, 0xda, 0xba, 0x8f, 0x5d, 0xe3, 0x60, 0x38, 0xa5, 0x32,
+	0x9c, 0xd4, 0x24, 0xe4, 0x1e, 0x55, 0xff, 0xa9, 0xc0, 0x92, 0x6c, 0xdc, 0x9c, 0xfe, 0x84, 0xe4,
+	0x65, 0x8a, 0x20, 0x60, 0x15, 0x0f, 0xe9, 0x05, 0x94, 0xe6, 0xe8, 0x05, 0x2c, 0x66, 0xb4, 0x73,
+	0xe2, 0x46, 0x2d, 0xa7, 0x6a, 0xdc, 0x1d, 0x68, 0x86, 0x49, 0x90, 0x47, 0xe8, 0x97, 0xa1, 0x29,
+	0xc4, 0x1a, 0x32, 0xc7, 0xc7, 0x66, 0xd0, 0xcb, 0x11, 0xc0, 0xdb, 0x1c, 0xc6, 0xa8, 0x86, 0x49,
+	0x56, 0x28, 0xbe, 0xa6, 0x45, 0x20, 0xea, 0x9f, 0x0b, 0xd0, 0x89, 0x96, 0x0f, 0x9c, 0xf2, 0x3c,
+	0x4d, 0xa2, 0x2b, 0xd0, 0x96, 0x8f, 0x26, 0x61, 0x0e, 0x97, 0x6d, 0x9b, 0xfb, 0x51, 0x72, 0x7d,
+	0xf4, 0x26, 0x2c, 0x09, 0xc4, 0x54, 0xce, 0x17, 0xf5, 0xf5, 0x39, 0x3e, 0xab, 0x25, 0x8a, 0xb6,
+	0xfc, 0x9a, 0xa9, 0x74, 0x8a, 0x9a, 0x29, 0x5d, 0xd3, 0x2d, 0x9e, 0xac, 0xa6, 0x53, 0xff, 0x5e,
+	0x84, 0xd6, 0x2c, 0x8e, 0xcd, 0xad, 0xb5, 0x79, 0x9a, 0xf9, 0x9b, 0xd0, 0x09, 0xc7, 0xe2, 0x72,
+	0x7c, 0x68, 0x28, 0x4e, 0x76, 0x46, 0xda, 0x93, 0x38, 0x00, 0xdd, 0x82, 0x66, 0x70, 0x19, 0x12,
+	0x71, 0x5d, 0x68, 0xf0, 0xa5, 0x2c, 0x62, 0x31, 0x0f, 0xd3, 0x1a, 0x91, 0x7a, 0x85, 0xa2, 0xb7,
+	0xa1, 0xc6, 0xa3, 0xb3, 0x7f, 0x30, 0xc1, 0x32, 0x30, 0x3f, 0x9f, 0x45, 0x83, 0x79, 0xde, 0xce,
+	0xc1, 0x04, 0x6b, 0x55, 0x5b, 0x7e, 0x9d, 0xb6, 0xc8, 0x79, 0x03, 0xce, 0x7b, 0xe2, 0x68, 0x9b,
+	0xc3, 0x98, 0xfa, 0x2a, 0x5c, 0x7d, 0xe7, 0x82, 0xc9, 0xad, 0xa8, 0x1a, 0x73, 0x7a, 0x5d, 0xd5,
+	0xdc, 0x5e, 0xd7, 0x2f, 0x0b, 0xb0, 0xc4, 0x64, 0xbf, 0xa9, 0xdb, 0xba, 0x6b, 0xe0, 0xf9, 0xdb,
+	0x36, 0x4f, 0xa6, 0x18, 0x4a, 0xe5, 0xab, 0x52, 0x46, 0xbe, 0x8a, 0xa7, 0xee, 0xc5, 0x64, 0xea,
+	0x7e, 0x11, 0xea, 0x92, 0x86, 0x49, 0x5c, 0xcc, 0x95, 0x5d, 0xd5, 0x40, 0x80, 0xfa, 0xc4, 0xe5,
+	0x8d, 0x1e, 0xb6, 0x9e, 0xcf, 0x56, 0xf8, 0x6c, 0xc5, 0xa4, 0x3e, 0x9f, 0xba, 0x08, 0xf0, 0x40,
+	0xb7, 0x2d, 0x93, 0x3b, 0x09, 0x57, 0x53, 0x55, 0xab, 0x71, 0x08, 0x53, 0x81, 0xfa, 0x73, 0x05,
+	0x96, 0xde, 0xd7, 0x5d, 0x93, 0xec, 0xed, 0x9d, 0x3e, 0xbe, 0xae, 0x43, 0xd0, 0xc6, 0x19, 0x1c,
+	0xa7, 0x95, 0x11, 0x5b, 0xa4, 0xfe, 0xb8, 0x00, 0x28, 0x62, 0xaf, 0x93, 0x4b, 0x33, 0x4b, 0xf0,
+	0x42, 0xaf, 0xe1, 0x9b, 0x65, 0x54, 0xf5, 0x2c, 0xab, 0xb6, 0x76, 0x05, 0xab, 0xa1, 0x87, 0x75,
+	0x4a, 0xdc, 0x6e, 0xf1, 0x38, 0xd5, 0xc9, 0x6e, 0x20, 0x26, 0x5b, 0xca, 0x73, 0x7c, 0x68, 0xc8,
+	0xa0, 0x39, 0x0c, 0xa1, 0x25, 0x29, 0xbb, 0x51, 0x25, 0xaf, 0xab, 0x41, 0xde, 0xe8, 0xd0, 0xf8,
+	0x4d, 0x95, 0xaa, 0xff, 0x51, 0xe0, 0x8c, 0x1c, 0xb2, 0xf3, 0x3b, 0xc2, 0x41, 0x82, 0x20, 0xae,
+	0x6d, 0xb9, 0xa1, 0x47, 0xc9, 0x88, 0x24, 0x80, 0xd2, 0x65, 0xde, 0x87, 0xb6, 0x44, 0x0a, 0x23,
+	0xec, 0x9c, 0xd6, 0x68, 0x89, 0x75, 0x61, 0x6c, 0xbd, 0x04, 0x2d, 0xb2, 0xb7, 0x17, 0xe5, 0x27,
+	0xdc, 0xbc, 0x29, 0xa1, 0x92, 0xe1, 0x07, 0xd0, 0x09, 0xd0, 0x8e, 0x1b, 0xd3, 0xdb, 0x72, 0x61,
+	0x58, 0x9b, 0xfc, 0x44, 0x81, 0x6e, 0x3c, 0xc2, 0x47, 0xb6, 0x7f, 0x7c, 0x47, 0xf8, 0x66, 0xbc,
+	0xb5, 0x76, 0xe9, 0x10, 0x79, 0x66, 0x7c, 0x82, 0x06, 0xdb, 0x0f, 0x14, 0xe8, 0xdd, 0xc2, 0xbe,
+	0x31, 0x96, 0x18, 0xe2, 0x6a, 0x70, 0x8a, 0x43, 0x12, 0x0b, 0x3a, 0x85, 0x64, 0xd0, 0x39, 0x07,
+	0x8b, 0xe2, 0x06, 0x54, 0xe4, 0x39, 0x5d, 0x0c, 0xd8, 0x03, 0x0d, 0x8a, 0xf1, 0x5f, 0x1f, 0x4f,
+	0xdd, 0xfd, 0x93, 0x15, 0x6c, 0x08, 0x4a, 0x8c, 0xa8, 0x4c, 0xeb, 0xfc, 0x9b, 0xc1, 0xd8, 0xf5,
+	0x85, 0x5b, 0xb6, 0xa1, 0xf1, 0x6f, 0x06, 0xb3, 0x75, 0xea, 0xf3, 0x78, 0x55, 0xd5, 0xf8, 0x37,
+	0xea, 0x41, 0xd5, 0x18, 0x63, 0x63, 0x9f, 0x4e, 0x1d, 0x1e, 0xa5, 0x9a, 0x5a, 0x38, 0x5e, 0x79,
+	0x0c, 0xad, 0x78, 0xce, 0x42, 0x0d, 0xa8, 0x6e, 0x12, 0xff, 0xbd, 0x47, 0x16, 0xf5, 0x3b, 0x0b,
+	0xa8, 0x05, 0xb0, 0x49, 0xfc, 0x2d, 0x0f, 0x53, 0xec, 0xfa, 0x1d, 0x05, 0x01, 0x94, 0xef, 0xba,
+	0x7d, 0x8b, 0xee, 0x77, 0x0a, 0xe8, 0xac, 0x7c, 0x2f, 0xd1, 0xed, 0x81, 0x0c, 0xe0, 0x9d, 0x22,
+	0x5b, 0x1e, 0x8e, 0x4a, 0xa8, 0x03, 0x8d, 0x10, 0x65, 0x63, 0xeb, 0x5e, 0x67, 0x11, 0xd5, 0x60,
+	0x51, 0x7c, 0x96, 0x57, 0xee, 0x42, 0x27, 0x79, 0x32, 0x51, 0x1d, 0x2a, 0x63, 0x11, 0xd8, 0x3a,
+	0x0b, 0xa8, 0x0d, 0x75, 0x7b, 0x16, 0x53, 0x3a, 0x0a, 0x03, 0x8c, 0xbc, 0x89, 0x21, 0xcd, 0xd8,
+	0x29, 0x30, 0x6e, 0xcc, 0xbd, 0xfb, 0xe4, 0xa1, 0xdb, 0x29, 0xae, 0x7c, 0x00, 0x8d, 0x68, 0x7b,
+	0x1a, 0x55, 0xa1, 0xb4, 0x49, 0x5c, 0xdc, 0x59, 0x60, 0x64, 0x37, 0x3c, 0xf2, 0xd0, 0x72, 0x47,
+	0x62, 0x0f, 0xb7, 0x3c, 0xf2, 0x18, 0xbb, 0x9d, 0x02, 0x9b, 0x60, 0x07, 0x98, 0x4d, 0x14, 0xd9,
+	0x84, 0x38, 0xcd, 0x9d, 0xd2, 0xca, 0xeb, 0x50, 0x0d, 0x72, 0x27, 0x3a, 0x03, 0xcd, 0xd8, 0xa3,
+	0x6e, 0x67, 0x01, 0x21, 0x71, 0x3b, 0x9a, 0x65, 0xc9, 0x8e, 0xb2, 0xf6, 0x8b, 0x26, 0x80, 0x28,
+	0xdf, 0x08, 0xf1, 0x4c, 0x34, 0x01, 0xb4, 0x81, 0x7d, 0xd6, 0xc5, 0x26, 0x6e, 0x20, 0x12, 0x45,
+	0x37, 0x72, 0xaa, 0x9b, 0x34, 0xaa, 0xdc, 0x65, 0xef, 0x72, 0xce, 0x8a, 0x04, 0xba, 0xba, 0x80,
+	0x1c, 0xce, 0x91, 0x5d, 0xcd, 0x77, 0x2c, 0x63, 0x3f, 0xb8, 0xa0, 0x1c, 0xc2, 0x31, 0x81, 0x1a,
+	0x70, 0x4c, 0x94, 0x36, 0x72, 0xb0, 0xed, 0x7b, 0x96, 0x3b, 0x0a, 0xee, 0x1d, 0xea, 0x02, 0xba,
+	0x0f, 0xe7, 0x58, 0xd7, 0xd4, 0xd7, 0x7d, 0x8b, 0xfa, 0x96, 0x41, 0x03, 0x86, 0x6b, 0xf9, 0x0c,
+	0x53, 0xc8, 0xc7, 0x64, 0x69, 0x43, 0x3b, 0xf1, 0x83, 0x0c, 0x5a, 0xc9, 0xee, 0xad, 0x66, 0xfd,
+	0xcc, 0xd3, 0xbb, 0x36, 0x17, 0x6e, 0xc8, 0xcd, 0x82, 0x56, 0xfc, 0xe7, 0x11, 0xf4, 0x6a, 0x1e,
+	0x81, 0xd4, 0xfb, 0x74, 0x6f, 0x65, 0x1e, 0xd4, 0x90, 0xd5, 0x27, 0xd0, 0x8a, 0xb9, 0x58, 0x0e,
+	0xab, 0xcc, 0x7f, 0x0b, 0x7a, 0x87, 0x45, 0x10, 0x75, 0x01, 0x7d, 0x0f, 0xce, 0xa4, 0x5e, 0xd1,
+	0xd1, 0x57, 0xb3, 0xc8, 0xe7, 0x3d, 0xb6, 0x1f, 0xc5, 0x41, 0x4a, 0x3f, 0xd3, 0x62, 0xbe, 0xf4,
+	0xa9, 0xbf, 0x36, 0xe6, 0x97, 0x3e, 0x42, 0xfe, 0x30, 0xe9, 0x8f, 0xcd, 0x61, 0x0a, 0x28, 0xfd,
+	0x8e, 0x8e, 0x5e, 0xcb, 0x62, 0x91, 0xfb, 0x96, 0xdf, 0x5b, 0x9d, 0x17, 0x3d, 0x34, 0xf9, 0x94,
+	0x9f, 0xd6, 0xe4, 0x8b, 0x73, 0x26, 0xdb, 0xdc, 0x27, 0xf4, 0xde, 0xea, 0xbc, 0xe8, 0x51, 0xa7,
+	0x8e, 0xbf, 0x80, 0x65, 0xdb, 0x2a, 0xf3, 0xe1, 0xb6, 0xb7, 0x32, 0x0f, 0x6a, 0xf4, 0xb4, 0x26,
+	0x9e, 0x55, 0x50, 0x2e, 0x81, 0xf4, 0x5b, 0x58, 0xef, 0xda, 0x5c, 0xb8, 0x21, 0xb7, 0x1d, 0xa8,
+	0x47, 0x2a, 0x50, 0x74, 0x39, 0xcf, 0x03, 0xe3, 0x25, 0xea, 0x51, 0xce, 0xf1, 0x29, 0xb4, 0x13,
+	0x9d, 0x8c, 0xec, 0x3d, 0x64, 0xb7, 0x3b, 0x8e, 0xa2, 0x3e, 0x04, 0xd8, 0xc0, 0xfe, 0x1d, 0xec,
+	0x7b, 0x96, 0x41, 0x93, 0x22, 0xcb, 0xc1, 0x0c, 0x21, 0x20, 0x7a, 0xe5, 0x48, 0xbc, 0x40, 0x29,
+	0x6b, 0x3f, 0xad, 0x43, 0x8d, 0xfb, 0x1f, 0x7f, 0xf5, 0xfb, 0x7f, 0x4a, 0x7a, 0xf2, 0x29, 0xe9,
+	0x53, 0x68, 0x27, 0x5e, 0xec, 0xb2, 0x1d, 0x24, 0xfb, 0x59, 0xef, 0x28, 0x07, 0xd9, 0x05, 0x94,
+	0x7e, 0x56, 0xca, 0x0e, 0x12, 0xb9, 0xcf, 0x4f, 0x73, 0xb8, 0x78, 0xe2, 0x59, 0x27, 0x7b, 0x07,
+	0xd9, 0x6f, 0x3f, 0x47, 0x51, 0xff, 0x18, 0x1a, 0xd1, 0x4e, 0x39, 0xba, 0x92, 0x77, 0x2e, 0x8f,
+	0x79, 0x74, 0x9e, 0x7e, 0x5e, 0x78, 0xfa, 0x79, 0xf3, 0xe9, 0x06, 0x97, 0x2f, 0x30, 0xd2, 0x7f,
+	0x04, 0x65, 0xd1, 0x29, 0x46, 0x2f, 0x65, 0xdf, 0xd3, 0x22, 0x7d, 0xf5, 0x9e, 0x7a, 0x18, 0x4a,
+	0xe4, 0x28, 0x9f, 0xcd, 0xb8, 0xc1, 0xa1, 0xcc, 0x84, 0x97, 0x7f, 0xd5, 0xeb, 0x5d, 0x3e, 0xe4,
+	0xde, 0x18, 0xb9, 0x95, 0xa9, 0x0b, 0x37, 0x94, 0xa7, 0x1e, 0x8d, 0x6f, 0xbe, 0xf9, 0xc9, 0xda,
+	0xc8, 0xf2, 0xc7, 0xd3, 0x5d, 0x66, 0xab, 0xeb, 0x02, 0xf3, 0x35, 0x8b, 0xc8, 0xaf, 0xeb, 0x41,
+	0x58, 0xba, 0xce, 0x29, 0x5d, 0xe7, 0xb2, 0x4e, 0x76, 0x77, 0xcb, 0x7c, 0xf8, 0xc6, 0x7f, 0x07,
+	0x00, 0x7f, 0xe4, 0x7d, 0x09, 0x19, 0x2f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2409,6 +2977,9 @@ type QueryCoordClient interface {
 	CreateQueryChannel(ctx context.Context, in *CreateQueryChannelRequest, opts ...grpc.CallOption) (*CreateQueryChannelResponse, error)
 	GetPartitionStates(ctx context.Context, in *GetPartitionStatesRequest, opts ...grpc.CallOption) (*GetPartitionStatesResponse, error)
 	GetSegmentInfo(ctx context.Context, in *GetSegmentInfoRequest, opts ...grpc.CallOption) (*GetSegmentInfoResponse, error)
+	GetShardLeaders(ctx context.Context, in *GetShardLeadersRequest, opts ...grpc.CallOption) (*GetShardLeadersResponse, error)
+	LoadBalance(ctx context.Context, in *LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	ReleaseSegments(ctx context.Context, in *ReleaseSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
 }
@@ -2529,6 +3100,33 @@ func (c *queryCoordClient) GetSegmentInfo(ctx context.Context, in *GetSegmentInf
 	return out, nil
 }
 
+func (c *queryCoordClient) GetShardLeaders(ctx context.Context, in *GetShardLeadersRequest, opts ...grpc.CallOption) (*GetShardLeadersResponse, error) {
+	out := new(GetShardLeadersResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryCoord/GetShardLeaders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryCoordClient) LoadBalance(ctx context.Context, in *LoadBalanceRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryCoord/LoadBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryCoordClient) ReleaseSegments(ctx context.Context, in *ReleaseSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryCoord/ReleaseSegments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryCoordClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	out := new(milvuspb.GetMetricsResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryCoord/GetMetrics", in, out, opts...)
@@ -2552,6 +3150,9 @@ type QueryCoordServer interface {
 	CreateQueryChannel(context.Context, *CreateQueryChannelRequest) (*CreateQueryChannelResponse, error)
 	GetPartitionStates(context.Context, *GetPartitionStatesRequest) (*GetPartitionStatesResponse, error)
 	GetSegmentInfo(context.Context, *GetSegmentInfoRequest) (*GetSegmentInfoResponse, error)
+	GetShardLeaders(context.Context, *GetShardLeadersRequest) (*GetShardLeadersResponse, error)
+	LoadBalance(context.Context, *LoadBalanceRequest) (*commonpb.Status, error)
+	ReleaseSegments(context.Context, *ReleaseSegmentsRequest) (*commonpb.Status, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -2596,6 +3197,15 @@ func (*UnimplementedQueryCoordServer) GetPartitionStates(ctx context.Context, re
 func (*UnimplementedQueryCoordServer) GetSegmentInfo(ctx context.Context, req *GetSegmentInfoRequest) (*GetSegmentInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSegmentInfo not implemented")
 }
+func (*UnimplementedQueryCoordServer) GetShardLeaders(ctx context.Context, req *GetShardLeadersRequest) (*GetShardLeadersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShardLeaders not implemented")
+}
+func (*UnimplementedQueryCoordServer) LoadBalance(ctx context.Context, req *LoadBalanceRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadBalance not implemented")
+}
+func (*UnimplementedQueryCoordServer) ReleaseSegments(ctx context.Context, req *ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSegments not implemented")
+}
 func (*UnimplementedQueryCoordServer) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
@@ -2820,6 +3430,60 @@ func _QueryCoord_GetSegmentInfo_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryCoord_GetShardLeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShardLeadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryCoordServer).GetShardLeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryCoord/GetShardLeaders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryCoordServer).GetShardLeaders(ctx, req.(*GetShardLeadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryCoord_LoadBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryCoordServer).LoadBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryCoord/LoadBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryCoordServer).LoadBalance(ctx, req.(*LoadBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryCoord_ReleaseSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryCoordServer).ReleaseSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryCoord/ReleaseSegments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryCoordServer).ReleaseSegments(ctx, req.(*ReleaseSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QueryCoord_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(milvuspb.GetMetricsRequest)
 	if err := dec(in); err != nil {
@@ -2890,6 +3554,18 @@ var _QueryCoord_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSegmentInfo",
 			Handler:    _QueryCoord_GetSegmentInfo_Handler,
 		},
+		{
+			MethodName: "GetShardLeaders",
+			Handler:    _QueryCoord_GetShardLeaders_Handler,
+		},
+		{
+			MethodName: "LoadBalance",
+			Handler:    _QueryCoord_LoadBalance_Handler,
+		},
+		{
+			MethodName: "ReleaseSegments",
+			Handler:    _QueryCoord_ReleaseSegments_Handler,
+		},
 		{
 			MethodName: "GetMetrics",
 			Handler:    _QueryCoord_GetMetrics_Handler,
@@ -2914,6 +3590,9 @@ type QueryNodeClient interface {
 	ReleasePartitions(ctx context.Context, in *ReleasePartitionsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	ReleaseSegments(ctx context.Context, in *ReleaseSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	GetSegmentInfo(ctx context.Context, in *GetSegmentInfoRequest, opts ...grpc.CallOption) (*GetSegmentInfoResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// FetchSegmentBinlogs streams the binlogs of a segment cached on the query node to the query node loading it
+	FetchSegmentBinlogs(ctx context.Context, in *FetchSegmentBinlogsRequest, opts ...grpc.CallOption) (QueryNode_FetchSegmentBinlogsClient, error)
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
 }
@@ -3025,6 +3704,47 @@ func (c *queryNodeClient) GetSegmentInfo(ctx context.Context, in *GetSegmentInfo
 	return out, nil
 }
 
+func (c *queryNodeClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryNodeClient) FetchSegmentBinlogs(ctx context.Context, in *FetchSegmentBinlogsRequest, opts ...grpc.CallOption) (QueryNode_FetchSegmentBinlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_QueryNode_serviceDesc.Streams[0], "/milvus.proto.query.QueryNode/FetchSegmentBinlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryNodeFetchSegmentBinlogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryNode_FetchSegmentBinlogsClient interface {
+	Recv() (*SegmentBinlogChunk, error)
+	grpc.ClientStream
+}
+
+type queryNodeFetchSegmentBinlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryNodeFetchSegmentBinlogsClient) Recv() (*SegmentBinlogChunk, error) {
+	m := new(SegmentBinlogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *queryNodeClient) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
 	out := new(milvuspb.GetMetricsResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.query.QueryNode/GetMetrics", in, out, opts...)
@@ -3047,6 +3767,9 @@ type QueryNodeServer interface {
 	ReleasePartitions(context.Context, *ReleasePartitionsRequest) (*commonpb.Status, error)
 	ReleaseSegments(context.Context, *ReleaseSegmentsRequest) (*commonpb.Status, error)
 	GetSegmentInfo(context.Context, *GetSegmentInfoRequest) (*GetSegmentInfoResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// FetchSegmentBinlogs streams the binlogs of a segment cached on the query node to the query node loading it
+	FetchSegmentBinlogs(*FetchSegmentBinlogsRequest, QueryNode_FetchSegmentBinlogsServer) error
 	// https://wiki.lfaidata.foundation/display/MIL/MEP+8+--+Add+metrics+for+proxy
 	GetMetrics(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -3088,6 +3811,12 @@ func (*UnimplementedQueryNodeServer) ReleaseSegments(ctx context.Context, req *R
 func (*UnimplementedQueryNodeServer) GetSegmentInfo(ctx context.Context, req *GetSegmentInfoRequest) (*GetSegmentInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSegmentInfo not implemented")
 }
+func (*UnimplementedQueryNodeServer) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedQueryNodeServer) FetchSegmentBinlogs(req *FetchSegmentBinlogsRequest, srv QueryNode_FetchSegmentBinlogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method FetchSegmentBinlogs not implemented")
+}
 func (*UnimplementedQueryNodeServer) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
@@ -3294,6 +4023,45 @@ func _QueryNode_GetSegmentInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryNode_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryNodeServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.query.QueryNode/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryNodeServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryNode_FetchSegmentBinlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchSegmentBinlogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryNodeServer).FetchSegmentBinlogs(m, &queryNodeFetchSegmentBinlogsServer{stream})
+}
+
+type QueryNode_FetchSegmentBinlogsServer interface {
+	Send(*SegmentBinlogChunk) error
+	grpc.ServerStream
+}
+
+type queryNodeFetchSegmentBinlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryNodeFetchSegmentBinlogsServer) Send(m *SegmentBinlogChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _QueryNode_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(milvuspb.GetMetricsRequest)
 	if err := dec(in); err != nil {
@@ -3360,11 +4128,21 @@ var _QueryNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSegmentInfo",
 			Handler:    _QueryNode_GetSegmentInfo_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _QueryNode_Search_Handler,
+		},
 		{
 			MethodName: "GetMetrics",
 			Handler:    _QueryNode_GetMetrics_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchSegmentBinlogs",
+			Handler:       _QueryNode_FetchSegmentBinlogs_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "query_coord.proto",
 }
@@ -243,59 +243,59 @@ func init() {
 func init() { proto.RegisterFile("root_coord.proto", fileDescriptor_4513485a144f6b06) }
 
 var fileDescriptor_4513485a144f6b06 = []byte{
-	// 826 bytes of a gzipped FileDescriptorProto
+	// 829 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0xdb, 0x4f, 0xe3, 0x46,
-	0x14, 0xc6, 0x49, 0xa0, 0x54, 0x1c, 0x72, 0x41, 0x23, 0xa0, 0x28, 0xe5, 0x81, 0xa6, 0x2a, 0x24,
-	0x5c, 0x1c, 0x04, 0x52, 0xd5, 0x57, 0x48, 0x54, 0x88, 0xd4, 0x48, 0xc5, 0x01, 0x69, 0x6f, 0x28,
-	0x9a, 0x38, 0x47, 0x89, 0x85, 0xed, 0x31, 0x9e, 0xc9, 0xc2, 0x3e, 0xee, 0x5f, 0xb6, 0xff, 0xda,
-	0xca, 0x97, 0x71, 0x6c, 0xc7, 0x0e, 0x8e, 0x76, 0xdf, 0xe2, 0xf8, 0x37, 0xdf, 0x37, 0xe7, 0x3b,
-	0x1e, 0xfb, 0xc0, 0x96, 0xc3, 0x98, 0x18, 0x68, 0x8c, 0x39, 0x23, 0xc5, 0x76, 0x98, 0x60, 0x64,
-	0xd7, 0xd4, 0x8d, 0xcf, 0x53, 0xee, 0x5f, 0x29, 0xee, 0x6d, 0xef, 0x6e, 0xad, 0xa4, 0x31, 0xd3,
-	0x64, 0x96, 0xff, 0x7f, 0xad, 0x14, 0xa5, 0x6a, 0x15, 0xdd, 0x12, 0xe8, 0x58, 0xd4, 0x08, 0xae,
-	0x37, 0x6d, 0x87, 0xbd, 0x7e, 0x09, 0x2e, 0xb6, 0x46, 0x54, 0xd0, 0xa8, 0x45, 0x7d, 0x00, 0x3b,
-	0x57, 0x86, 0xc1, 0xb4, 0x7b, 0xdd, 0x44, 0x2e, 0xa8, 0x69, 0xab, 0xf8, 0x3c, 0x45, 0x2e, 0xc8,
-	0x39, 0xac, 0x0d, 0x29, 0xc7, 0xbd, 0xc2, 0x41, 0xa1, 0xb1, 0x79, 0xb1, 0xaf, 0xc4, 0xb6, 0x12,
-	0xf8, 0xf7, 0xf8, 0xf8, 0x9a, 0x72, 0x54, 0x3d, 0x92, 0x6c, 0xc3, 0x2f, 0x1a, 0x9b, 0x5a, 0x62,
-	0x6f, 0xf5, 0xa0, 0xd0, 0x28, 0xab, 0xfe, 0x45, 0xfd, 0x6b, 0x01, 0x76, 0x93, 0x0e, 0xdc, 0x66,
-	0x16, 0x47, 0x72, 0x09, 0xeb, 0x5c, 0x50, 0x31, 0xe5, 0x81, 0xc9, 0xef, 0xa9, 0x26, 0x7d, 0x0f,
-	0x51, 0x03, 0x94, 0xec, 0xc3, 0x86, 0x90, 0x4a, 0x7b, 0xc5, 0x83, 0x42, 0x63, 0x4d, 0x9d, 0xfd,
-	0x91, 0xb1, 0x87, 0x77, 0x50, 0xf1, 0xb6, 0xd0, 0xed, 0xfc, 0x84, 0xea, 0x8a, 0x51, 0x65, 0x03,
-	0xaa, 0xa1, 0xf2, 0x8f, 0x54, 0x55, 0x81, 0x62, 0xb7, 0xe3, 0x49, 0xaf, 0xaa, 0xc5, 0x6e, 0x27,
-	0xbd, 0x8e, 0x8b, 0x6f, 0xdb, 0xb0, 0xa1, 0x32, 0x26, 0xda, 0x6e, 0x03, 0x89, 0x0d, 0xe4, 0x06,
-	0x45, 0x9b, 0x99, 0x36, 0xb3, 0xd0, 0x12, 0xae, 0x22, 0x72, 0x72, 0x1e, 0xb7, 0x0b, 0x9f, 0x86,
-	0x79, 0x34, 0xc8, 0xa2, 0x76, 0x98, 0xb1, 0x22, 0x81, 0xd7, 0x57, 0x88, 0xe9, 0x39, 0xba, 0x8d,
-	0xbc, 0xd7, 0xb5, 0xa7, 0xf6, 0x84, 0x5a, 0x16, 0x1a, 0x8b, 0x1c, 0x13, 0xa8, 0x74, 0xfc, 0x33,
-	0xbe, 0x22, 0xb8, 0xe8, 0x0b, 0x47, 0xb7, 0xc6, 0x32, 0xc7, 0xfa, 0x0a, 0x79, 0x86, 0xed, 0x1b,
-	0xf4, 0xdc, 0x75, 0x2e, 0x74, 0x8d, 0x4b, 0xc3, 0x8b, 0x6c, 0xc3, 0x39, 0x78, 0x49, 0xcb, 0x01,
-	0x6c, 0xb5, 0x1d, 0xa4, 0x02, 0xdb, 0xcc, 0x30, 0x50, 0x13, 0x3a, 0xb3, 0xc8, 0x69, 0xea, 0xd2,
-	0x24, 0x26, 0x8d, 0x16, 0xb5, 0xbb, 0xbe, 0x42, 0x3e, 0x42, 0xa5, 0xe3, 0x30, 0x3b, 0x22, 0x7f,
-	0x9c, 0x2a, 0x1f, 0x87, 0x72, 0x8a, 0x0f, 0xa0, 0x7c, 0x4b, 0x79, 0x44, 0xbb, 0x99, 0xaa, 0x1d,
-	0x63, 0xa4, 0xf4, 0x1f, 0xa9, 0xe8, 0x35, 0x63, 0x46, 0x24, 0x9e, 0x17, 0x20, 0x1d, 0xe4, 0x9a,
-	0xa3, 0x0f, 0xa3, 0x01, 0x29, 0xe9, 0x15, 0xcc, 0x81, 0xd2, 0xaa, 0x95, 0x9b, 0x0f, 0x8d, 0x1f,
-	0x60, 0xd3, 0x0f, 0xfc, 0xca, 0xd0, 0x29, 0x27, 0x47, 0x0b, 0x5a, 0xe2, 0x11, 0x39, 0x03, 0xbb,
-	0x83, 0x0d, 0x37, 0x68, 0x5f, 0xf4, 0xaf, 0xcc, 0x46, 0x2c, 0x23, 0xd9, 0x07, 0xb8, 0x32, 0x04,
-	0x3a, 0xbe, 0xe6, 0x61, 0xaa, 0xe6, 0x0c, 0xc8, 0x29, 0x6a, 0x41, 0xb5, 0x3f, 0x61, 0x2f, 0xb3,
-	0x68, 0x38, 0x39, 0x49, 0x7f, 0xa0, 0xe3, 0x94, 0x94, 0x3f, 0xcd, 0x07, 0x87, 0x71, 0x3f, 0x42,
-	0xd5, 0x0f, 0xf3, 0x7f, 0xea, 0x08, 0xdd, 0x6b, 0xf2, 0xc9, 0x82, 0xc8, 0x43, 0x2a, 0x67, 0x39,
-	0xef, 0xa1, 0xec, 0xc6, 0x3a, 0x13, 0x6f, 0x66, 0x46, 0xbf, 0xac, 0xf4, 0x23, 0x94, 0x6e, 0x29,
-	0x9f, 0x29, 0x37, 0xb2, 0x4e, 0xc0, 0x9c, 0x70, 0xae, 0x03, 0xf0, 0x04, 0x15, 0x37, 0xb5, 0x70,
-	0x31, 0xcf, 0x38, 0xbe, 0x71, 0x48, 0x5a, 0x9c, 0xe4, 0x62, 0x43, 0x33, 0x0b, 0xaa, 0xf2, 0x50,
-	0xf4, 0x71, 0x6c, 0xa2, 0x25, 0x32, 0xba, 0x90, 0xa0, 0x16, 0x77, 0x7d, 0x0e, 0x0e, 0xfd, 0x10,
-	0x4a, 0xee, 0x5e, 0x82, 0x1b, 0x3c, 0x23, 0xbb, 0x28, 0x22, 0x9d, 0x9a, 0x39, 0xc8, 0xf9, 0xb3,
-	0xdc, 0xb5, 0x46, 0xf8, 0xba, 0xf0, 0x2c, 0x7b, 0x44, 0xce, 0xce, 0x4f, 0xa0, 0x2c, 0x4b, 0xf3,
-	0x85, 0x9b, 0x0b, 0xcb, 0x8f, 0x49, 0x1f, 0xe7, 0x41, 0xc3, 0x02, 0x82, 0xb7, 0x86, 0xef, 0x92,
-	0xfd, 0xd6, 0x58, 0x66, 0xf3, 0xcf, 0xc1, 0x84, 0x12, 0x0e, 0x49, 0xe4, 0x4c, 0x49, 0x1f, 0xfe,
-	0x94, 0xd4, 0x71, 0xad, 0xa6, 0xe4, 0xc5, 0xc3, 0x2a, 0x3e, 0xc1, 0xaf, 0xc1, 0xe8, 0x92, 0x7c,
-	0x4b, 0x25, 0x16, 0x87, 0x53, 0x53, 0xed, 0xe8, 0x4d, 0x2e, 0x54, 0xa7, 0xb0, 0xf3, 0x60, 0x8f,
-	0xdc, 0x2f, 0xa4, 0xff, 0x1d, 0x96, 0x93, 0x40, 0xb2, 0x2b, 0xb3, 0x69, 0x23, 0xce, 0xf5, 0xf8,
-	0xf8, 0xad, 0xcc, 0x0c, 0xf8, 0x4d, 0x45, 0x03, 0x29, 0xc7, 0xce, 0xdd, 0x7f, 0x3d, 0xe4, 0x9c,
-	0x8e, 0xb1, 0x2f, 0x1c, 0xa4, 0x66, 0x72, 0x42, 0xf0, 0x47, 0xe0, 0x0c, 0x38, 0x67, 0x87, 0x34,
-	0xd8, 0x09, 0x9e, 0xe5, 0x7f, 0x8d, 0x29, 0x9f, 0xb8, 0xc3, 0x91, 0x81, 0x02, 0x47, 0xc9, 0x23,
-	0xe9, 0x4e, 0xd8, 0x4a, 0x2a, 0x99, 0xa3, 0xa4, 0x01, 0xc0, 0x0d, 0x8a, 0x1e, 0x0a, 0x47, 0xd7,
-	0xb2, 0x3e, 0x1e, 0x33, 0x20, 0xa3, 0x2d, 0x29, 0x9c, 0x6c, 0xcb, 0xf5, 0x3f, 0x1f, 0xfe, 0x1e,
-	0xeb, 0x62, 0x32, 0x1d, 0xba, 0xd6, 0x2d, 0x9f, 0x3c, 0xd3, 0x59, 0xf0, 0xab, 0x25, 0xbb, 0xd1,
-	0xf2, 0x94, 0x5a, 0x61, 0x83, 0xed, 0xe1, 0x70, 0xdd, 0xfb, 0xeb, 0xf2, 0x7b, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x27, 0xad, 0x32, 0xbe, 0xa6, 0x0c, 0x00, 0x00,
+	0x14, 0xc6, 0x49, 0xa0, 0x54, 0x1c, 0x72, 0x41, 0x23, 0x42, 0x51, 0xca, 0x03, 0x4d, 0x55, 0x48,
+	0xb8, 0x38, 0x08, 0xa4, 0xaa, 0xaf, 0x90, 0xa8, 0x10, 0xa9, 0x91, 0x8a, 0x03, 0x52, 0x2f, 0x8b,
+	0xa2, 0x89, 0x73, 0x94, 0x58, 0xd8, 0x1e, 0xe3, 0x99, 0x2c, 0xec, 0xe3, 0xfe, 0x23, 0xfb, 0xb7,
+	0xae, 0x7c, 0x8d, 0xe3, 0x78, 0x8c, 0xa3, 0xdd, 0xb7, 0x38, 0xfe, 0xcd, 0xf7, 0xcd, 0x39, 0x9f,
+	0x3d, 0x3e, 0xb0, 0xe3, 0x30, 0x26, 0x86, 0x1a, 0x63, 0xce, 0x58, 0xb1, 0x1d, 0x26, 0x18, 0xd9,
+	0x33, 0x75, 0xe3, 0xe3, 0x8c, 0xfb, 0x57, 0x8a, 0x7b, 0xdb, 0xbb, 0x5b, 0x2f, 0x69, 0xcc, 0x34,
+	0x99, 0xe5, 0xff, 0x5f, 0x2f, 0xc5, 0xa9, 0x7a, 0x45, 0xb7, 0x04, 0x3a, 0x16, 0x35, 0x82, 0xeb,
+	0x6d, 0xdb, 0x61, 0x6f, 0x9f, 0x82, 0x8b, 0x9d, 0x31, 0x15, 0x34, 0x6e, 0xd1, 0x18, 0x42, 0xed,
+	0xda, 0x30, 0x98, 0xf6, 0xa0, 0x9b, 0xc8, 0x05, 0x35, 0x6d, 0x15, 0x5f, 0x66, 0xc8, 0x05, 0xb9,
+	0x80, 0x8d, 0x11, 0xe5, 0xb8, 0x5f, 0x38, 0x2c, 0x34, 0xb7, 0x2f, 0x0f, 0x94, 0x85, 0xad, 0x04,
+	0xfe, 0x7d, 0x3e, 0xb9, 0xa1, 0x1c, 0x55, 0x8f, 0x24, 0xbb, 0xf0, 0x83, 0xc6, 0x66, 0x96, 0xd8,
+	0x5f, 0x3f, 0x2c, 0x34, 0xcb, 0xaa, 0x7f, 0xd1, 0xf8, 0x5c, 0x80, 0xbd, 0xa4, 0x03, 0xb7, 0x99,
+	0xc5, 0x91, 0x5c, 0xc1, 0x26, 0x17, 0x54, 0xcc, 0x78, 0x60, 0xf2, 0x73, 0xaa, 0xc9, 0xc0, 0x43,
+	0xd4, 0x00, 0x25, 0x07, 0xb0, 0x25, 0x42, 0xa5, 0xfd, 0xe2, 0x61, 0xa1, 0xb9, 0xa1, 0xce, 0xff,
+	0x90, 0xec, 0xe1, 0x1f, 0xa8, 0x78, 0x5b, 0xe8, 0x75, 0xbf, 0x43, 0x75, 0xc5, 0xb8, 0xb2, 0x01,
+	0xd5, 0x48, 0xf9, 0x5b, 0xaa, 0xaa, 0x40, 0xb1, 0xd7, 0xf5, 0xa4, 0xd7, 0xd5, 0x62, 0xaf, 0x9b,
+	0x5e, 0xc7, 0xe5, 0x97, 0x1a, 0x6c, 0xa9, 0x8c, 0x89, 0x8e, 0x1b, 0x20, 0xb1, 0x81, 0xdc, 0xa2,
+	0xe8, 0x30, 0xd3, 0x66, 0x16, 0x5a, 0xc2, 0x55, 0x44, 0x4e, 0x2e, 0x16, 0xed, 0xa2, 0xa7, 0x61,
+	0x19, 0x0d, 0x7a, 0x51, 0x3f, 0x92, 0xac, 0x48, 0xe0, 0x8d, 0x35, 0x62, 0x7a, 0x8e, 0x6e, 0x90,
+	0x0f, 0xba, 0xf6, 0xdc, 0x99, 0x52, 0xcb, 0x42, 0x23, 0xcb, 0x31, 0x81, 0x86, 0x8e, 0xbf, 0x2e,
+	0xae, 0x08, 0x2e, 0x06, 0xc2, 0xd1, 0xad, 0x49, 0xd8, 0xc7, 0xc6, 0x1a, 0x79, 0x81, 0xdd, 0x5b,
+	0xf4, 0xdc, 0x75, 0x2e, 0x74, 0x8d, 0x87, 0x86, 0x97, 0x72, 0xc3, 0x25, 0x78, 0x45, 0xcb, 0x21,
+	0xec, 0x74, 0x1c, 0xa4, 0x02, 0x3b, 0xcc, 0x30, 0x50, 0x13, 0x3a, 0xb3, 0xc8, 0x59, 0xea, 0xd2,
+	0x24, 0x16, 0x1a, 0x65, 0xc5, 0xdd, 0x58, 0x23, 0xff, 0x43, 0xa5, 0xeb, 0x30, 0x3b, 0x26, 0x7f,
+	0x92, 0x2a, 0xbf, 0x08, 0xe5, 0x14, 0x1f, 0x42, 0xf9, 0x8e, 0xf2, 0x98, 0x76, 0x2b, 0x55, 0x7b,
+	0x81, 0x09, 0xa5, 0x7f, 0x49, 0x45, 0x6f, 0x18, 0x33, 0x62, 0xed, 0x79, 0x05, 0xd2, 0x45, 0xae,
+	0x39, 0xfa, 0x28, 0xde, 0x20, 0x25, 0xbd, 0x82, 0x25, 0x30, 0xb4, 0x6a, 0xe7, 0xe6, 0x23, 0xe3,
+	0x47, 0xd8, 0xf6, 0x1b, 0x7e, 0x6d, 0xe8, 0x94, 0x93, 0xe3, 0x8c, 0x48, 0x3c, 0x22, 0x67, 0xc3,
+	0xee, 0x61, 0xcb, 0x6d, 0xb4, 0x2f, 0xfa, 0x9b, 0x34, 0x88, 0x55, 0x24, 0x07, 0x00, 0xd7, 0x86,
+	0x40, 0xc7, 0xd7, 0x3c, 0x4a, 0xd5, 0x9c, 0x03, 0x39, 0x45, 0x9f, 0xdc, 0x63, 0x46, 0xa0, 0x13,
+	0x6b, 0xfa, 0xa9, 0x5c, 0x79, 0xe5, 0xe7, 0xc6, 0x82, 0xea, 0x60, 0xca, 0x5e, 0xe7, 0xeb, 0xb8,
+	0x44, 0x3e, 0x41, 0x85, 0xf2, 0x67, 0xf9, 0xe0, 0x28, 0xcd, 0x27, 0xa8, 0xfa, 0x59, 0xfd, 0x4d,
+	0x1d, 0xa1, 0x67, 0x94, 0x93, 0xa0, 0x72, 0x96, 0xf3, 0x2f, 0x94, 0xdd, 0xd4, 0xe6, 0xe2, 0x2d,
+	0x69, 0xb2, 0xab, 0x4a, 0x3f, 0x41, 0xe9, 0x8e, 0xf2, 0xb9, 0x72, 0x53, 0xf6, 0x82, 0x2d, 0x09,
+	0xe7, 0x7a, 0xbf, 0x9e, 0xa1, 0xe2, 0x76, 0x2d, 0x5a, 0xcc, 0x25, 0xa7, 0xc3, 0x22, 0x14, 0x5a,
+	0x9c, 0xe6, 0x62, 0x23, 0x33, 0x0b, 0xaa, 0xe1, 0x3b, 0x37, 0xc0, 0x89, 0x89, 0x96, 0x90, 0xa4,
+	0x90, 0xa0, 0xb2, 0x53, 0x5f, 0x82, 0x23, 0x3f, 0x84, 0x92, 0xbb, 0x97, 0xe0, 0x06, 0x97, 0xf4,
+	0x2e, 0x8e, 0x84, 0x4e, 0xad, 0x1c, 0xe4, 0xf2, 0x51, 0xd1, 0xb3, 0xc6, 0xf8, 0x96, 0x79, 0x54,
+	0x78, 0x44, 0xce, 0xe4, 0xa7, 0x50, 0x0e, 0x4b, 0xf3, 0x85, 0x5b, 0x99, 0xe5, 0x2f, 0x48, 0x9f,
+	0xe4, 0x41, 0xa3, 0x02, 0x82, 0x43, 0xc9, 0x77, 0x91, 0x1f, 0x4a, 0xab, 0x6c, 0xfe, 0x25, 0x18,
+	0x80, 0xa2, 0x19, 0x8c, 0x9c, 0x2b, 0xe9, 0xb3, 0xa5, 0x92, 0x3a, 0x0d, 0xd6, 0x95, 0xbc, 0x78,
+	0x54, 0xc5, 0x07, 0xf8, 0x31, 0x98, 0x8c, 0xc8, 0x51, 0xe6, 0xe2, 0x68, 0x28, 0xab, 0x1f, 0xbf,
+	0xcb, 0x45, 0xea, 0x14, 0x6a, 0x8f, 0xf6, 0xd8, 0xfd, 0x00, 0xfb, 0x9f, 0xf9, 0x70, 0xd0, 0x20,
+	0x2d, 0xc9, 0x6c, 0x90, 0xe0, 0xfa, 0x7c, 0xf2, 0x5e, 0xcf, 0x0c, 0xf8, 0x49, 0x45, 0x03, 0x29,
+	0xc7, 0xee, 0xfd, 0x5f, 0x7d, 0xe4, 0x9c, 0x4e, 0x70, 0x20, 0x1c, 0xa4, 0x66, 0x72, 0x00, 0xf1,
+	0x27, 0x6c, 0x09, 0x9c, 0x33, 0x21, 0x0d, 0x6a, 0xc1, 0xb3, 0xfc, 0xa7, 0x31, 0xe3, 0x53, 0x77,
+	0xf6, 0x32, 0x50, 0xe0, 0x38, 0xf9, 0x4a, 0xba, 0x03, 0xbc, 0x92, 0x4a, 0xe6, 0x28, 0x69, 0x08,
+	0x70, 0x8b, 0xa2, 0x8f, 0xc2, 0xd1, 0x35, 0xd9, 0xb7, 0x69, 0x0e, 0x48, 0x62, 0x49, 0xe1, 0xc2,
+	0x58, 0x6e, 0xfe, 0xf8, 0xef, 0xf7, 0x89, 0x2e, 0xa6, 0xb3, 0x91, 0x6b, 0xdd, 0xf6, 0xc9, 0x73,
+	0x9d, 0x05, 0xbf, 0xda, 0x61, 0x1a, 0x6d, 0x4f, 0xa9, 0x1d, 0x05, 0x6c, 0x8f, 0x46, 0x9b, 0xde,
+	0x5f, 0x57, 0x5f, 0x07, 0x00, 0x3e, 0x10, 0x48, 0xec, 0x05, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -344,6 +344,7 @@ type RootCoordClient interface {
 	CreateAlias(ctx context.Context, in *milvuspb.CreateAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	DropAlias(ctx context.Context, in *milvuspb.DropAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	AlterAlias(ctx context.Context, in *milvuspb.AlterAliasRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
+	AlterCollection(ctx context.Context, in *milvuspb.AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	//*
 	// @brief This method is used to list all collections.
 	//
@@ -483,6 +484,15 @@ func (c *rootCoordClient) AlterAlias(ctx context.Context, in *milvuspb.AlterAlia
 	return out, nil
 }
 
+func (c *rootCoordClient) AlterCollection(ctx context.Context, in *milvuspb.AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
+	out := new(commonpb.Status)
+	err := c.cc.Invoke(ctx, "/milvus.proto.rootcoord.RootCoord/AlterCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rootCoordClient) ShowCollections(ctx context.Context, in *milvuspb.ShowCollectionsRequest, opts ...grpc.CallOption) (*milvuspb.ShowCollectionsResponse, error) {
 	out := new(milvuspb.ShowCollectionsResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.rootcoord.RootCoord/ShowCollections", in, out, opts...)
@@ -663,6 +673,7 @@ type RootCoordServer interface {
 	CreateAlias(context.Context, *milvuspb.CreateAliasRequest) (*commonpb.Status, error)
 	DropAlias(context.Context, *milvuspb.DropAliasRequest) (*commonpb.Status, error)
 	AlterAlias(context.Context, *milvuspb.AlterAliasRequest) (*commonpb.Status, error)
+	AlterCollection(context.Context, *milvuspb.AlterCollectionRequest) (*commonpb.Status, error)
 	//*
 	// @brief This method is used to list all collections.
 	//
@@ -738,6 +749,9 @@ func (*UnimplementedRootCoordServer) DropAlias(ctx context.Context, req *milvusp
 func (*UnimplementedRootCoordServer) AlterAlias(ctx context.Context, req *milvuspb.AlterAliasRequest) (*commonpb.Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AlterAlias not implemented")
 }
+func (*UnimplementedRootCoordServer) AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AlterCollection not implemented")
+}
 func (*UnimplementedRootCoordServer) ShowCollections(ctx context.Context, req *milvuspb.ShowCollectionsRequest) (*milvuspb.ShowCollectionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShowCollections not implemented")
 }
@@ -971,6 +985,24 @@ func _RootCoord_AlterAlias_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RootCoord_AlterCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(milvuspb.AlterCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RootCoordServer).AlterCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.rootcoord.RootCoord/AlterCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RootCoordServer).AlterCollection(ctx, req.(*milvuspb.AlterCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RootCoord_ShowCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(milvuspb.ShowCollectionsRequest)
 	if err := dec(in); err != nil {
@@ -1303,6 +1335,10 @@ var _RootCoord_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AlterAlias",
 			Handler:    _RootCoord_AlterAlias_Handler,
 		},
+		{
+			MethodName: "AlterCollection",
+			Handler:    _RootCoord_AlterCollection_Handler,
+		},
 		{
 			MethodName: "ShowCollections",
 			Handler:    _RootCoord_ShowCollections_Handler,
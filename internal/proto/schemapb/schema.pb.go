@@ -86,6 +86,7 @@ type FieldSchema struct {
 	TypeParams           []*commonpb.KeyValuePair `protobuf:"bytes,6,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
 	IndexParams          []*commonpb.KeyValuePair `protobuf:"bytes,7,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
 	AutoID               bool                     `protobuf:"varint,8,opt,name=autoID,proto3" json:"autoID,omitempty"`
+	IsPartitionKey       bool                     `protobuf:"varint,9,opt,name=is_partition_key,json=isPartitionKey,proto3" json:"is_partition_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -172,6 +173,13 @@ func (m *FieldSchema) GetAutoID() bool {
 	return false
 }
 
+func (m *FieldSchema) GetIsPartitionKey() bool {
+	if m != nil {
+		return m.IsPartitionKey
+	}
+	return false
+}
+
 //*
 // @brief Collection schema
 type CollectionSchema struct {
@@ -1038,66 +1046,67 @@ func init() {
 func init() { proto.RegisterFile("schema.proto", fileDescriptor_1c5fb4d8cc22d66a) }
 
 var fileDescriptor_1c5fb4d8cc22d66a = []byte{
-	// 963 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x8e, 0xe3, 0xfc, 0xd8, 0xc7, 0xa1, 0x58, 0xd3, 0x0a, 0x19, 0xa4, 0x76, 0xd3, 0x08, 0xa4,
-	0xa8, 0x12, 0xbb, 0xea, 0x2e, 0x94, 0x52, 0x51, 0x01, 0x69, 0xb4, 0x4a, 0xb4, 0xa8, 0x5a, 0xbc,
-	0xa8, 0x17, 0xdc, 0x44, 0x93, 0x78, 0xba, 0x3b, 0x5a, 0x7b, 0x26, 0x78, 0x26, 0x15, 0x79, 0x00,
-	0xae, 0xb9, 0xe1, 0x8a, 0x77, 0xe3, 0x02, 0xf1, 0x1c, 0x48, 0x68, 0xce, 0x4c, 0x12, 0xd3, 0x78,
-	0x57, 0x7b, 0x77, 0x66, 0x7c, 0xbe, 0x6f, 0xce, 0xf9, 0xce, 0x8f, 0xa1, 0xa7, 0x16, 0x57, 0xac,
-	0xa0, 0x87, 0xcb, 0x52, 0x6a, 0x49, 0xee, 0x17, 0x3c, 0x7f, 0xb7, 0x52, 0xf6, 0x74, 0x68, 0x3f,
-	0x7d, 0xd2, 0x5b, 0xc8, 0xa2, 0x90, 0xc2, 0x5e, 0x0e, 0xfe, 0x6e, 0x42, 0x74, 0xca, 0x59, 0x9e,
-	0x5d, 0xe0, 0x57, 0x92, 0x40, 0xf7, 0xad, 0x39, 0x4e, 0xc7, 0x89, 0xd7, 0xf7, 0x86, 0x7e, 0xba,
-	0x39, 0x12, 0x02, 0x2d, 0x41, 0x0b, 0x96, 0x34, 0xfb, 0xde, 0x30, 0x4c, 0xd1, 0x26, 0x9f, 0xc2,
-	0x3d, 0xae, 0x66, 0xcb, 0x92, 0x17, 0xb4, 0x5c, 0xcf, 0xae, 0xd9, 0x3a, 0xf1, 0xfb, 0xde, 0x30,
-	0x48, 0x7b, 0x5c, 0x9d, 0xdb, 0xcb, 0x33, 0xb6, 0x26, 0x7d, 0x88, 0x32, 0xa6, 0x16, 0x25, 0x5f,
-	0x6a, 0x2e, 0x45, 0xd2, 0x42, 0x82, 0xea, 0x15, 0x79, 0x01, 0x61, 0x46, 0x35, 0x9d, 0xe9, 0xf5,
-	0x92, 0x25, 0xed, 0xbe, 0x37, 0xbc, 0x77, 0xfc, 0xf0, 0xb0, 0x26, 0xf8, 0xc3, 0x31, 0xd5, 0xf4,
-	0xa7, 0xf5, 0x92, 0xa5, 0x41, 0xe6, 0x2c, 0x32, 0x82, 0xc8, 0xc0, 0x66, 0x4b, 0x5a, 0xd2, 0x42,
-	0x25, 0x9d, 0xbe, 0x3f, 0x8c, 0x8e, 0x1f, 0xff, 0x1f, 0xed, 0x52, 0x3e, 0x63, 0xeb, 0x37, 0x34,
-	0x5f, 0xb1, 0x73, 0xca, 0xcb, 0x14, 0x0c, 0xea, 0x1c, 0x41, 0x64, 0x0c, 0x3d, 0x2e, 0x32, 0xf6,
-	0xeb, 0x86, 0xa4, 0x7b, 0x57, 0x92, 0x08, 0x61, 0x8e, 0xe5, 0x23, 0xe8, 0xd0, 0x95, 0x96, 0xd3,
-	0x71, 0x12, 0xa0, 0x0a, 0xee, 0x34, 0xf8, 0xd3, 0x83, 0xf8, 0x95, 0xcc, 0x73, 0xb6, 0x30, 0xc9,
-	0x3a, 0xa1, 0x37, 0x72, 0x7a, 0x15, 0x39, 0xdf, 0x13, 0xaa, 0xb9, 0x2f, 0xd4, 0xee, 0x09, 0xbf,
-	0xfa, 0x04, 0x79, 0x0e, 0x1d, 0xac, 0x93, 0x4a, 0x5a, 0x18, 0x7a, 0xbf, 0x56, 0xbd, 0x4a, 0xa1,
-	0x53, 0xe7, 0x3f, 0x38, 0x80, 0x70, 0x24, 0x65, 0xfe, 0x7d, 0x59, 0xd2, 0xb5, 0x09, 0xca, 0xe8,
-	0x9a, 0x78, 0x7d, 0x7f, 0x18, 0xa4, 0x68, 0x0f, 0x1e, 0x41, 0x30, 0x15, 0x7a, 0xff, 0x7b, 0xdb,
-	0x7d, 0x3f, 0x80, 0xf0, 0x07, 0x29, 0x2e, 0xf7, 0x1d, 0x7c, 0xe7, 0xd0, 0x07, 0x38, 0xcd, 0x25,
-	0xad, 0xa1, 0x68, 0x3a, 0x8f, 0xc7, 0x10, 0x8d, 0xe5, 0x6a, 0x9e, 0xb3, 0x7d, 0x17, 0x6f, 0x47,
-	0x32, 0x5a, 0x6b, 0xa6, 0xf6, 0x3d, 0x7a, 0x3b, 0x92, 0x0b, 0x5d, 0xf2, 0xba, 0x48, 0x42, 0xe7,
-	0xf2, 0x97, 0x0f, 0xd1, 0xc5, 0x82, 0xe6, 0xb4, 0x44, 0x25, 0xc8, 0x4b, 0x08, 0xe7, 0x52, 0xe6,
-	0x33, 0xe7, 0xe8, 0x0d, 0xa3, 0xe3, 0x47, 0xb5, 0xc2, 0x6d, 0x15, 0x9a, 0x34, 0xd2, 0xc0, 0x40,
-	0x4c, 0x1f, 0x92, 0x17, 0x10, 0x70, 0xa1, 0x2d, 0xba, 0x89, 0xe8, 0xfa, 0xa6, 0xdd, 0xc8, 0x37,
-	0x69, 0xa4, 0x5d, 0x2e, 0x34, 0x62, 0x5f, 0x42, 0x98, 0x4b, 0x71, 0x69, 0xc1, 0xfe, 0x2d, 0x4f,
-	0x6f, 0xb5, 0x35, 0x4f, 0x1b, 0x08, 0xc2, 0xbf, 0x03, 0x78, 0x6b, 0x34, 0xb5, 0xf8, 0x16, 0xe2,
-	0x0f, 0xea, 0x6b, 0xbe, 0x95, 0x7e, 0xd2, 0x48, 0x43, 0x04, 0x21, 0xc3, 0x2b, 0x88, 0x32, 0xd4,
-	0xdc, 0x52, 0xb4, 0x91, 0xa2, 0xbe, 0x6d, 0x2a, 0xb5, 0x99, 0x34, 0x52, 0xb0, 0xb0, 0x0d, 0x89,
-	0x42, 0xcd, 0x2d, 0x49, 0xe7, 0x16, 0x92, 0x4a, 0x6d, 0x0c, 0x89, 0x85, 0x6d, 0x72, 0x99, 0x9b,
-	0xd2, 0x5a, 0x8e, 0xee, 0x2d, 0xb9, 0xec, 0x3a, 0xc0, 0xe4, 0x82, 0x20, 0xc3, 0x30, 0xea, 0xd8,
-	0x5a, 0x0f, 0xfe, 0xf0, 0x20, 0x7a, 0xc3, 0x16, 0x5a, 0xba, 0xfa, 0xc6, 0xe0, 0x67, 0xbc, 0x70,
-	0x8b, 0xcc, 0x98, 0x66, 0xd0, 0xad, 0x6e, 0xef, 0xd0, 0xcd, 0x95, 0xed, 0x0e, 0xca, 0x45, 0x08,
-	0xb3, 0xe4, 0xe4, 0x33, 0xf8, 0x60, 0xce, 0x85, 0x59, 0x79, 0x8e, 0xc6, 0x14, 0xb0, 0x37, 0x69,
-	0xa4, 0x3d, 0x7b, 0x6d, 0xdd, 0xb6, 0x61, 0xfd, 0xeb, 0x41, 0x88, 0x01, 0x61, 0xba, 0x4f, 0xa1,
-	0x85, 0x6b, 0xce, 0xbb, 0xcb, 0x9a, 0x43, 0x57, 0xf2, 0x10, 0x00, 0xa7, 0x75, 0x56, 0x59, 0xc0,
-	0x21, 0xde, 0xbc, 0x36, 0x6b, 0xe3, 0x1b, 0xe8, 0x2a, 0xec, 0x6a, 0xe5, 0x3a, 0xe9, 0x86, 0x0a,
-	0xec, 0x3a, 0xdf, 0x74, 0xa2, 0x83, 0x18, 0xb4, 0xcd, 0x42, 0xb9, 0x3e, 0xaa, 0x47, 0x57, 0x74,
-	0x35, 0x68, 0x07, 0x21, 0x1f, 0x43, 0x60, 0x43, 0xe3, 0x19, 0xf6, 0xd0, 0xf6, 0x87, 0x91, 0x8d,
-	0xba, 0xd0, 0x46, 0x73, 0xf0, 0x9b, 0x07, 0xfe, 0x74, 0xac, 0xc8, 0x57, 0xd0, 0x31, 0xf3, 0xc2,
-	0xb3, 0x5b, 0x67, 0xad, 0xda, 0xf0, 0x6d, 0x2e, 0xf4, 0x34, 0x23, 0x5f, 0x43, 0x47, 0xe9, 0xd2,
-	0x00, 0x9b, 0x77, 0xee, 0xb0, 0xb6, 0xd2, 0xe5, 0x34, 0x1b, 0x01, 0x04, 0x3c, 0x9b, 0xd9, 0x38,
-	0xfe, 0xf1, 0x20, 0xbe, 0x60, 0xb4, 0x5c, 0x5c, 0xa5, 0x4c, 0xad, 0x72, 0x3b, 0x07, 0x07, 0x10,
-	0x89, 0x55, 0x31, 0xfb, 0x65, 0xc5, 0x4a, 0xce, 0x94, 0xeb, 0x15, 0x10, 0xab, 0xe2, 0x47, 0x7b,
-	0x43, 0xee, 0x43, 0x5b, 0xcb, 0xe5, 0xec, 0x1a, 0xdf, 0xf6, 0xd3, 0x96, 0x96, 0xcb, 0x33, 0xf2,
-	0x2d, 0x44, 0x76, 0x7f, 0x6e, 0x06, 0xd8, 0xbf, 0x31, 0x9f, 0x6d, 0xe5, 0x53, 0x5b, 0x44, 0x6c,
-	0x59, 0xb3, 0xc8, 0xd5, 0x42, 0x96, 0xcc, 0x2e, 0xec, 0x66, 0xea, 0x4e, 0xe4, 0x09, 0xf8, 0x3c,
-	0x53, 0x6e, 0x1c, 0x93, 0xfa, 0x75, 0x32, 0x56, 0xa9, 0x71, 0x22, 0x0f, 0x30, 0xb2, 0x6b, 0xfb,
-	0xcf, 0xf3, 0x53, 0x7b, 0x78, 0xf2, 0xbb, 0x07, 0xc1, 0xa6, 0x7f, 0x48, 0x00, 0xad, 0xd7, 0x52,
-	0xb0, 0xb8, 0x61, 0x2c, 0xb3, 0xc5, 0x62, 0xcf, 0x58, 0x53, 0xa1, 0x9f, 0xc7, 0x4d, 0x12, 0x42,
-	0x7b, 0x2a, 0xf4, 0xd3, 0x67, 0xb1, 0xef, 0xcc, 0x93, 0xe3, 0xb8, 0xe5, 0xcc, 0x67, 0x5f, 0xc4,
-	0x6d, 0x63, 0xe2, 0x14, 0xc4, 0x40, 0x00, 0x3a, 0x76, 0x0f, 0xc4, 0x91, 0xb1, 0xad, 0xd8, 0xf1,
-	0x03, 0x12, 0x43, 0x6f, 0x54, 0x69, 0xfa, 0x38, 0x23, 0x1f, 0x42, 0x74, 0xba, 0x1b, 0x96, 0x98,
-	0x8d, 0xbe, 0xfc, 0xf9, 0xe4, 0x92, 0xeb, 0xab, 0xd5, 0xdc, 0xfc, 0x42, 0x8f, 0x6c, 0x4a, 0x9f,
-	0x73, 0xe9, 0xac, 0x23, 0x2e, 0x34, 0x2b, 0x05, 0xcd, 0x8f, 0x30, 0xcb, 0x23, 0x9b, 0xe5, 0x72,
-	0x3e, 0xef, 0xe0, 0xf9, 0xe4, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x88, 0x3c, 0xbb, 0x94, 0xd4,
-	0x08, 0x00, 0x00,
+	// 981 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0xcf, 0xc6, 0x71, 0x62, 0x8f, 0x43, 0xb1, 0xb6, 0x15, 0x32, 0x48, 0xed, 0xb9, 0x11, 0x48,
+	0x51, 0x25, 0xee, 0xd4, 0x3b, 0x28, 0xa5, 0xa2, 0x02, 0xd2, 0xe8, 0x74, 0xd1, 0xa1, 0xea, 0xf0,
+	0xa1, 0x3e, 0xf0, 0x12, 0x6d, 0xe2, 0xed, 0xdd, 0xea, 0x6c, 0x6f, 0xf0, 0x6e, 0x2a, 0xf2, 0x01,
+	0x78, 0xe2, 0x81, 0x17, 0x9e, 0xf8, 0x6e, 0x3c, 0xf1, 0x39, 0x90, 0xd0, 0xce, 0x6e, 0xfe, 0x1c,
+	0x49, 0xa3, 0x7b, 0x9b, 0x1d, 0xcf, 0xef, 0xb7, 0x33, 0xbf, 0x99, 0x1d, 0x43, 0x57, 0x4d, 0xaf,
+	0x79, 0xc9, 0x0e, 0x67, 0xb5, 0xd4, 0x92, 0xde, 0x2f, 0x45, 0xf1, 0x6e, 0xae, 0xec, 0xe9, 0xd0,
+	0x7e, 0xfa, 0xa4, 0x3b, 0x95, 0x65, 0x29, 0x2b, 0xeb, 0xec, 0xfd, 0xee, 0x41, 0x74, 0x2a, 0x78,
+	0x91, 0x5f, 0xe2, 0x57, 0x9a, 0x40, 0xe7, 0xad, 0x39, 0x8e, 0x86, 0x09, 0x49, 0x49, 0xdf, 0xcb,
+	0x96, 0x47, 0x4a, 0xa1, 0x55, 0xb1, 0x92, 0x27, 0xcd, 0x94, 0xf4, 0xc3, 0x0c, 0x6d, 0xfa, 0x29,
+	0xdc, 0x13, 0x6a, 0x3c, 0xab, 0x45, 0xc9, 0xea, 0xc5, 0xf8, 0x86, 0x2f, 0x12, 0x2f, 0x25, 0xfd,
+	0x20, 0xeb, 0x0a, 0x75, 0x61, 0x9d, 0xe7, 0x7c, 0x41, 0x53, 0x88, 0x72, 0xae, 0xa6, 0xb5, 0x98,
+	0x69, 0x21, 0xab, 0xa4, 0x85, 0x04, 0x9b, 0x2e, 0xfa, 0x02, 0xc2, 0x9c, 0x69, 0x36, 0xd6, 0x8b,
+	0x19, 0x4f, 0xfc, 0x94, 0xf4, 0xef, 0x1d, 0x3f, 0x3c, 0xdc, 0x91, 0xfc, 0xe1, 0x90, 0x69, 0xf6,
+	0xd3, 0x62, 0xc6, 0xb3, 0x20, 0x77, 0x16, 0x1d, 0x40, 0x64, 0x60, 0xe3, 0x19, 0xab, 0x59, 0xa9,
+	0x92, 0x76, 0xea, 0xf5, 0xa3, 0xe3, 0xc7, 0xb7, 0xd1, 0xae, 0xe4, 0x73, 0xbe, 0x78, 0xc3, 0x8a,
+	0x39, 0xbf, 0x60, 0xa2, 0xce, 0xc0, 0xa0, 0x2e, 0x10, 0x44, 0x87, 0xd0, 0x15, 0x55, 0xce, 0x7f,
+	0x5d, 0x92, 0x74, 0xee, 0x4a, 0x12, 0x21, 0xcc, 0xb1, 0x7c, 0x04, 0x6d, 0x36, 0xd7, 0x72, 0x34,
+	0x4c, 0x02, 0x54, 0xc1, 0x9d, 0x68, 0x1f, 0x62, 0xa3, 0x12, 0xab, 0xb5, 0x30, 0xd5, 0xa2, 0x4e,
+	0x21, 0x46, 0xdc, 0x13, 0xea, 0x62, 0xe9, 0x3e, 0xe7, 0x8b, 0xde, 0x5f, 0x04, 0xe2, 0x57, 0xb2,
+	0x28, 0xf8, 0xd4, 0x78, 0x5c, 0x4b, 0x96, 0xc2, 0x93, 0x0d, 0xe1, 0xff, 0x27, 0x69, 0x73, 0x5b,
+	0xd2, 0x75, 0x32, 0xde, 0xad, 0x64, 0x9e, 0x43, 0x1b, 0x3b, 0xaa, 0x92, 0x16, 0x16, 0x99, 0xee,
+	0xd4, 0x79, 0x63, 0x24, 0x32, 0x17, 0xdf, 0x3b, 0x80, 0x70, 0x20, 0x65, 0xf1, 0x7d, 0x5d, 0xb3,
+	0x85, 0x49, 0xca, 0x74, 0x20, 0x21, 0xa9, 0xd7, 0x0f, 0x32, 0xb4, 0x7b, 0x8f, 0x20, 0x18, 0x55,
+	0x7a, 0xfb, 0xbb, 0xef, 0xbe, 0x1f, 0x40, 0xf8, 0x83, 0xac, 0xae, 0xb6, 0x03, 0x3c, 0x17, 0x90,
+	0x02, 0x9c, 0x16, 0x92, 0xed, 0xa0, 0x68, 0xba, 0x88, 0xc7, 0x10, 0x0d, 0xe5, 0x7c, 0x52, 0xf0,
+	0xed, 0x10, 0xb2, 0x26, 0x19, 0x2c, 0x34, 0x57, 0xdb, 0x11, 0xdd, 0x35, 0xc9, 0xa5, 0xae, 0xc5,
+	0xae, 0x4c, 0x42, 0x17, 0xf2, 0xb7, 0x07, 0xd1, 0xe5, 0x94, 0x15, 0xac, 0x46, 0x25, 0xe8, 0x4b,
+	0x08, 0x27, 0x52, 0x16, 0x63, 0x17, 0x48, 0xfa, 0xd1, 0xf1, 0xa3, 0x9d, 0xc2, 0xad, 0x14, 0x3a,
+	0x6b, 0x64, 0x81, 0x81, 0x98, 0x89, 0xa5, 0x2f, 0x20, 0x10, 0x95, 0xb6, 0xe8, 0x26, 0xa2, 0x77,
+	0x8f, 0xf7, 0x52, 0xbe, 0xb3, 0x46, 0xd6, 0x11, 0x95, 0x46, 0xec, 0x4b, 0x08, 0x0b, 0x59, 0x5d,
+	0x59, 0xb0, 0xb7, 0xe7, 0xea, 0x95, 0xb6, 0xe6, 0x6a, 0x03, 0x41, 0xf8, 0x77, 0x00, 0x6f, 0x8d,
+	0xa6, 0x16, 0xdf, 0x42, 0xfc, 0xc1, 0xee, 0x9e, 0xaf, 0xa4, 0x3f, 0x6b, 0x64, 0x21, 0x82, 0x90,
+	0xe1, 0x15, 0x44, 0x39, 0x6a, 0x6e, 0x29, 0xfc, 0x94, 0xbc, 0x77, 0x6c, 0x36, 0x7a, 0x73, 0xd6,
+	0xc8, 0xc0, 0xc2, 0x96, 0x24, 0x0a, 0x35, 0xb7, 0x24, 0xed, 0x3d, 0x24, 0x1b, 0xbd, 0x31, 0x24,
+	0x16, 0xb6, 0xac, 0x65, 0x62, 0x5a, 0x6b, 0x39, 0x3a, 0x7b, 0x6a, 0x59, 0x4f, 0x80, 0xa9, 0x05,
+	0x41, 0x86, 0x61, 0xd0, 0xb6, 0xbd, 0xee, 0xfd, 0x49, 0x20, 0x7a, 0xc3, 0xa7, 0x5a, 0xba, 0xfe,
+	0xc6, 0xe0, 0xe5, 0xa2, 0x74, 0x2b, 0xcf, 0x98, 0x66, 0x25, 0x58, 0xdd, 0xde, 0x61, 0x58, 0xd2,
+	0xdc, 0x73, 0xdb, 0x2d, 0xe5, 0x22, 0x84, 0x59, 0x72, 0xfa, 0x19, 0x7c, 0x30, 0x11, 0x95, 0x59,
+	0x8e, 0x8e, 0xc6, 0x34, 0xb0, 0x7b, 0xd6, 0xc8, 0xba, 0xd6, 0x6d, 0xc3, 0x56, 0x69, 0xfd, 0x4b,
+	0x20, 0xc4, 0x84, 0xb0, 0xdc, 0xa7, 0xd0, 0xc2, 0x85, 0x48, 0xee, 0xb2, 0x10, 0x31, 0x94, 0x3e,
+	0x04, 0xc0, 0xd7, 0x3a, 0xde, 0x58, 0xd5, 0x21, 0x7a, 0x5e, 0x9b, 0xb5, 0xf1, 0x0d, 0x74, 0x14,
+	0x4e, 0xb5, 0x4a, 0xbc, 0x7d, 0x1d, 0x58, 0x4f, 0xbe, 0x99, 0x44, 0x07, 0x31, 0x68, 0x5b, 0x85,
+	0x4a, 0x5a, 0x7b, 0xd0, 0x1b, 0xba, 0x1a, 0xb4, 0x83, 0xd0, 0x8f, 0x21, 0xb0, 0xa9, 0x89, 0x3c,
+	0xf1, 0x37, 0x7f, 0x2d, 0xf9, 0xa0, 0x03, 0x3e, 0x9a, 0xbd, 0xdf, 0x08, 0x78, 0xa3, 0xa1, 0xa2,
+	0x5f, 0x41, 0xdb, 0xbc, 0x17, 0x91, 0x27, 0xe4, 0x8e, 0x03, 0xef, 0x8b, 0x4a, 0x8f, 0x72, 0xfa,
+	0x35, 0xb4, 0x95, 0xae, 0x0d, 0xb0, 0x79, 0xe7, 0x09, 0xf3, 0x95, 0xae, 0x47, 0xf9, 0x00, 0x20,
+	0x10, 0xf9, 0xd8, 0xe6, 0xf1, 0x0f, 0x81, 0xf8, 0x92, 0xb3, 0x7a, 0x7a, 0x9d, 0x71, 0x35, 0x2f,
+	0xec, 0x3b, 0x38, 0x80, 0xa8, 0x9a, 0x97, 0xe3, 0x5f, 0xe6, 0xbc, 0x16, 0x5c, 0xb9, 0x59, 0x81,
+	0x6a, 0x5e, 0xfe, 0x68, 0x3d, 0xf4, 0x3e, 0xf8, 0x5a, 0xce, 0xc6, 0x37, 0x78, 0xb7, 0x97, 0xb5,
+	0xb4, 0x9c, 0x9d, 0xd3, 0x6f, 0x21, 0xb2, 0xfb, 0x73, 0xf9, 0x80, 0xbd, 0xf7, 0xd6, 0xb3, 0xea,
+	0x7c, 0x66, 0x9b, 0x88, 0x23, 0x6b, 0x16, 0xb9, 0x9a, 0xca, 0x9a, 0xdb, 0x85, 0xdd, 0xcc, 0xdc,
+	0x89, 0x3e, 0x01, 0x4f, 0xe4, 0xca, 0x3d, 0xc7, 0x64, 0xf7, 0x3a, 0x19, 0xaa, 0xcc, 0x04, 0xd1,
+	0x07, 0x98, 0xd9, 0x8d, 0xfd, 0x3b, 0x7a, 0x99, 0x3d, 0x3c, 0xf9, 0x83, 0x40, 0xb0, 0x9c, 0x1f,
+	0x1a, 0x40, 0xeb, 0xb5, 0xac, 0x78, 0xdc, 0x30, 0x96, 0xd9, 0x62, 0x31, 0x31, 0xd6, 0xa8, 0xd2,
+	0xcf, 0xe3, 0x26, 0x0d, 0xc1, 0x1f, 0x55, 0xfa, 0xe9, 0xb3, 0xd8, 0x73, 0xe6, 0xc9, 0x71, 0xdc,
+	0x72, 0xe6, 0xb3, 0x2f, 0x62, 0xdf, 0x98, 0xf8, 0x0a, 0x62, 0xa0, 0x00, 0x6d, 0xbb, 0x07, 0xe2,
+	0xc8, 0xd8, 0x56, 0xec, 0xf8, 0x01, 0x8d, 0xa1, 0x3b, 0xd8, 0x18, 0xfa, 0x38, 0xa7, 0x1f, 0x42,
+	0x74, 0xba, 0x7e, 0x2c, 0x31, 0x1f, 0x7c, 0xf9, 0xf3, 0xc9, 0x95, 0xd0, 0xd7, 0xf3, 0x89, 0xf9,
+	0xd9, 0x1e, 0xd9, 0x92, 0x3e, 0x17, 0xd2, 0x59, 0x47, 0xa2, 0xd2, 0xbc, 0xae, 0x58, 0x71, 0x84,
+	0x55, 0x1e, 0xd9, 0x2a, 0x67, 0x93, 0x49, 0x1b, 0xcf, 0x27, 0xff, 0x0d, 0x00, 0x0b, 0x45, 0xe7,
+	0x82, 0xfe, 0x08, 0x00, 0x00,
 }
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+)
+
+// Entry is a line of the access log
+type Entry struct {
+	Time       time.Time
+	Method     string
+	Collection string
+	User       string
+	Client     string
+	// Rows is the number of queries of search or the number of rows of insert and delete
+	Rows   int64
+	Status commonpb.ErrorCode
+	Error  string
+	Total  time.Duration
+	Stages [stageNum]time.Duration
+}
+
+type collectionNameGetter interface {
+	GetCollectionName() string
+}
+
+type statusGetter interface {
+	GetStatus() *commonpb.Status
+}
+
+// getCollectionName returns the collection name of a request, empty if it has none
+func getCollectionName(req interface{}) string {
+	if r, ok := req.(collectionNameGetter); ok {
+		return r.GetCollectionName()
+	}
+	return ""
+}
+
+// getRows returns the number of queries or rows of a request and its response
+func getRows(req interface{}, resp interface{}) int64 {
+	switch r := req.(type) {
+	case *milvuspb.InsertRequest:
+		return int64(r.GetNumRows())
+	case *milvuspb.SearchRequest:
+		if res, ok := resp.(*milvuspb.SearchResults); ok {
+			return res.GetResults().GetNumQueries()
+		}
+	case *milvuspb.DeleteRequest:
+		if res, ok := resp.(*milvuspb.MutationResult); ok {
+			return res.GetDeleteCnt()
+		}
+	}
+	return 0
+}
+
+// getStatus returns the status of a response, the response of some methods is a status itself
+func getStatus(resp interface{}) *commonpb.Status {
+	switch r := resp.(type) {
+	case *commonpb.Status:
+		return r
+	case statusGetter:
+		return r.GetStatus()
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatText is the name of the text formatter
+	FormatText = "text"
+	// FormatJSON is the name of the json formatter
+	FormatJSON = "json"
+
+	timeLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// Formatter formats an entry into a line of the access log, the returned line ends with a newline
+type Formatter interface {
+	Format(entry *Entry) []byte
+}
+
+// NewFormatter returns the formatter of name
+func NewFormatter(name string) (Formatter, error) {
+	switch name {
+	case FormatText, "":
+		return TextFormatter{}, nil
+	case FormatJSON:
+		return JSONFormatter{}, nil
+	}
+	return nil, fmt.Errorf("unknown access log format %s", name)
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// TextFormatter formats an entry into space separated key=value pairs, the values containing spaces are quoted
+type TextFormatter struct{}
+
+// Format implements Formatter
+func (TextFormatter) Format(entry *Entry) []byte {
+	var buf bytes.Buffer
+	write := func(key string, value string) {
+		if buf.Len() > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		if value == "" || strings.ContainsAny(value, " \"=\n") {
+			value = strconv.Quote(value)
+		}
+		buf.WriteString(value)
+	}
+	writeMs := func(key string, d time.Duration) {
+		write(key, strconv.FormatFloat(durationMs(d), 'f', 3, 64)+"ms")
+	}
+
+	write("time", entry.Time.Format(timeLayout))
+	write("method", entry.Method)
+	write("collection", entry.Collection)
+	write("user", entry.User)
+	write("client", entry.Client)
+	write("rows", strconv.FormatInt(entry.Rows, 10))
+	write("status", entry.Status.String())
+	write("error", entry.Error)
+	writeMs("total", entry.Total)
+	for stage := Stage(0); stage < stageNum; stage++ {
+		writeMs(stage.String(), entry.Stages[stage])
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// JSONFormatter formats an entry into a json object, the latencies are in milliseconds
+type JSONFormatter struct{}
+
+type jsonEntry struct {
+	Time       string  `json:"time"`
+	Method     string  `json:"method"`
+	Collection string  `json:"collection"`
+	User       string  `json:"user"`
+	Client     string  `json:"client"`
+	Rows       int64   `json:"rows"`
+	Status     string  `json:"status"`
+	Error      string  `json:"error"`
+	Total      float64 `json:"total_ms"`
+	Queue      float64 `json:"queue_ms"`
+	Coord      float64 `json:"coord_ms"`
+	Publish    float64 `json:"publish_ms"`
+	Result     float64 `json:"result_ms"`
+}
+
+// Format implements Formatter
+func (JSONFormatter) Format(entry *Entry) []byte {
+	line, _ := json.Marshal(&jsonEntry{
+		Time:       entry.Time.Format(timeLayout),
+		Method:     entry.Method,
+		Collection: entry.Collection,
+		User:       entry.User,
+		Client:     entry.Client,
+		Rows:       entry.Rows,
+		Status:     entry.Status.String(),
+		Error:      entry.Error,
+		Total:      durationMs(entry.Total),
+		Queue:      durationMs(entry.Stages[StageQueue]),
+		Coord:      durationMs(entry.Stages[StageCoord]),
+		Publish:    durationMs(entry.Stages[StagePublish]),
+		Result:     durationMs(entry.Stages[StageResult]),
+	})
+	return append(line, '\n')
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+func newTestEntry() *Entry {
+	entry := &Entry{
+		Time:       time.Date(2021, 9, 1, 8, 0, 0, 0, time.UTC),
+		Method:     "Search",
+		Collection: "coll",
+		User:       "root",
+		Client:     "127.0.0.1:12345",
+		Rows:       10,
+		Status:     commonpb.ErrorCode_UnexpectedError,
+		Error:      "search failed",
+		Total:      10 * time.Millisecond,
+	}
+	entry.Stages[StageQueue] = time.Millisecond
+	entry.Stages[StageCoord] = 2 * time.Millisecond
+	entry.Stages[StagePublish] = 1500 * time.Microsecond
+	entry.Stages[StageResult] = 5 * time.Millisecond
+	return entry
+}
+
+func TestNewFormatter(t *testing.T) {
+	f, err := NewFormatter(FormatText)
+	assert.NoError(t, err)
+	assert.IsType(t, TextFormatter{}, f)
+
+	f, err = NewFormatter("")
+	assert.NoError(t, err)
+	assert.IsType(t, TextFormatter{}, f)
+
+	f, err = NewFormatter(FormatJSON)
+	assert.NoError(t, err)
+	assert.IsType(t, JSONFormatter{}, f)
+
+	_, err = NewFormatter("xml")
+	assert.Error(t, err)
+}
+
+func TestTextFormatter(t *testing.T) {
+	line := string(TextFormatter{}.Format(newTestEntry()))
+	assert.True(t, strings.HasSuffix(line, "\n"))
+	assert.Equal(t, 1, strings.Count(line, "\n"))
+	assert.Equal(t, `time=2021-09-01T08:00:00.000Z method=Search collection=coll user=root client=127.0.0.1:12345 `+
+		`rows=10 status=UnexpectedError error="search failed" total=10.000ms queue=1.000ms coord=2.000ms `+
+		`publish=1.500ms result=5.000ms`+"\n", line)
+
+	t.Run("empty and multi-line values", func(t *testing.T) {
+		line := string(TextFormatter{}.Format(&Entry{Method: "Insert", Error: "line1\nline2"}))
+		assert.Equal(t, 1, strings.Count(line, "\n"))
+		assert.Contains(t, line, `collection="" user="" client=""`)
+		assert.Contains(t, line, `status=Success error="line1\nline2"`)
+	})
+}
+
+func TestJSONFormatter(t *testing.T) {
+	line := JSONFormatter{}.Format(newTestEntry())
+	assert.Equal(t, byte('\n'), line[len(line)-1])
+
+	fields := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(line, &fields))
+	assert.Equal(t, map[string]interface{}{
+		"time":       "2021-09-01T08:00:00.000Z",
+		"method":     "Search",
+		"collection": "coll",
+		"user":       "root",
+		"client":     "127.0.0.1:12345",
+		"rows":       float64(10),
+		"status":     "UnexpectedError",
+		"error":      "search failed",
+		"total_ms":   float64(10),
+		"queue_ms":   float64(1),
+		"coord_ms":   float64(2),
+		"publish_ms": 1.5,
+		"result_ms":  float64(5),
+	}, fields)
+}
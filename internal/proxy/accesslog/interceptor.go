@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+const (
+	// milvusServicePrefix is the prefix of the methods of the MilvusService, the methods of other services are not logged
+	milvusServicePrefix = "/milvus.proto.milvus.MilvusService/"
+	// UserKey is the key of the user name in the grpc metadata
+	UserKey = "user"
+)
+
+func getUser(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if users := md.Get(UserKey); len(users) > 0 {
+		return users[0]
+	}
+	return ""
+}
+
+func getClient(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// UnaryServerInterceptor returns an interceptor logging the requests of the MilvusService to logger. A Record is
+// attached to the context of the request, the latency of the stages is recorded by Begin and End of the handler.
+func UnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, milvusServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		record := &Record{}
+		resp, err := handler(WithRecord(ctx, record), req)
+
+		entry := &Entry{
+			Time:       start,
+			Method:     path.Base(info.FullMethod),
+			Collection: getCollectionName(req),
+			User:       getUser(ctx),
+			Client:     getClient(ctx),
+			Rows:       getRows(req, resp),
+			Total:      time.Since(start),
+		}
+		for stage := Stage(0); stage < stageNum; stage++ {
+			entry.Stages[stage] = record.Elapsed(stage)
+		}
+		if err != nil {
+			entry.Status = commonpb.ErrorCode_UnexpectedError
+			entry.Error = err.Error()
+		} else if status := getStatus(resp); status != nil {
+			entry.Status = status.ErrorCode
+			entry.Error = status.Reason
+		}
+		logger.Log(entry)
+		return resp, err
+	}
+}
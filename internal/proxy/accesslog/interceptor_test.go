@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+type entryWriter struct {
+	entries []*Entry
+}
+
+func (w *entryWriter) Format(entry *Entry) []byte {
+	w.entries = append(w.entries, entry)
+	return nil
+}
+
+func (w *entryWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *entryWriter) Close() error {
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	var nilRecord *Record
+	nilRecord.Begin(StageQueue)
+	nilRecord.End(StageQueue)
+	assert.Equal(t, time.Duration(0), nilRecord.Elapsed(StageQueue))
+
+	// no record in context
+	Begin(context.Background(), StageCoord)
+	End(context.Background(), StageCoord)
+	assert.Nil(t, FromContext(context.Background()))
+
+	record := &Record{}
+	ctx := WithRecord(context.Background(), record)
+	assert.Equal(t, record, FromContext(ctx))
+
+	// end without begin is ignored
+	End(ctx, StageCoord)
+	assert.Equal(t, time.Duration(0), record.Elapsed(StageCoord))
+
+	// the elapsed time of a stage is accumulated
+	for i := 0; i < 2; i++ {
+		Begin(ctx, StageCoord)
+		time.Sleep(5 * time.Millisecond)
+		End(ctx, StageCoord)
+	}
+	assert.GreaterOrEqual(t, int64(record.Elapsed(StageCoord)), int64(10*time.Millisecond))
+	assert.Equal(t, time.Duration(0), record.Elapsed(StageResult))
+
+	assert.Equal(t, "queue", StageQueue.String())
+	assert.Equal(t, "result", StageResult.String())
+	assert.Equal(t, "unknown", stageNum.String())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	w := &entryWriter{}
+	logger := newLogger(w, w, 16)
+	interceptor := UnaryServerInterceptor(logger)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserKey, "root"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 12345}})
+
+	searchInfo := &grpc.UnaryServerInfo{FullMethod: milvusServicePrefix + "Search"}
+	_, err := interceptor(ctx, &milvuspb.SearchRequest{CollectionName: "coll"}, searchInfo,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			for stage := Stage(0); stage < stageNum; stage++ {
+				Begin(ctx, stage)
+				time.Sleep(time.Millisecond)
+				End(ctx, stage)
+			}
+			return &milvuspb.SearchResults{
+				Status:  &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				Results: &schemapb.SearchResultData{NumQueries: 3},
+			}, nil
+		})
+	assert.NoError(t, err)
+
+	insertInfo := &grpc.UnaryServerInfo{FullMethod: milvusServicePrefix + "Insert"}
+	_, err = interceptor(ctx, &milvuspb.InsertRequest{CollectionName: "coll", NumRows: 100}, insertInfo,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &milvuspb.MutationResult{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_CollectionNotExists, Reason: "not exist"},
+			}, nil
+		})
+	assert.NoError(t, err)
+
+	dropInfo := &grpc.UnaryServerInfo{FullMethod: milvusServicePrefix + "DropCollection"}
+	_, err = interceptor(context.Background(), &milvuspb.DropCollectionRequest{CollectionName: "coll"}, dropInfo,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+		})
+	assert.NoError(t, err)
+
+	_, err = interceptor(context.Background(), &milvuspb.DeleteRequest{CollectionName: "coll"},
+		&grpc.UnaryServerInfo{FullMethod: milvusServicePrefix + "Delete"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("canceled")
+		})
+	assert.Error(t, err)
+
+	// the methods of other services are not logged
+	_, err = interceptor(ctx, &milvuspb.SearchRequest{}, &grpc.UnaryServerInfo{FullMethod: "/milvus.proto.proxy.Proxy/GetComponentStates"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Nil(t, FromContext(ctx))
+			return nil, nil
+		})
+	assert.NoError(t, err)
+
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, 4, len(w.entries))
+
+	search := w.entries[0]
+	assert.Equal(t, "Search", search.Method)
+	assert.Equal(t, "coll", search.Collection)
+	assert.Equal(t, "root", search.User)
+	assert.Equal(t, "127.0.0.1:12345", search.Client)
+	assert.Equal(t, int64(3), search.Rows)
+	assert.Equal(t, commonpb.ErrorCode_Success, search.Status)
+	assert.Empty(t, search.Error)
+	var sum time.Duration
+	for stage := Stage(0); stage < stageNum; stage++ {
+		assert.Greater(t, int64(search.Stages[stage]), int64(0))
+		sum += search.Stages[stage]
+	}
+	assert.GreaterOrEqual(t, int64(search.Total), int64(sum))
+	assert.False(t, search.Time.IsZero())
+
+	insert := w.entries[1]
+	assert.Equal(t, "Insert", insert.Method)
+	assert.Equal(t, int64(100), insert.Rows)
+	assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, insert.Status)
+	assert.Equal(t, "not exist", insert.Error)
+
+	drop := w.entries[2]
+	assert.Equal(t, "DropCollection", drop.Method)
+	assert.Empty(t, drop.User)
+	assert.Empty(t, drop.Client)
+	assert.Equal(t, commonpb.ErrorCode_Success, drop.Status)
+
+	del := w.entries[3]
+	assert.Equal(t, "Delete", del.Method)
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, del.Status)
+	assert.Equal(t, "canceled", del.Error)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+const defaultBufferSize = 1024
+
+// Config is the config of the access log
+type Config struct {
+	Filename string
+	// MaxSize is the size in megabytes of the log file before it gets rotated
+	MaxSize int
+	// MaxBackups is the number of rotated log files to retain, 0 means retaining all
+	MaxBackups int
+	// Format is the name of the formatter, text or json
+	Format string
+	// BufferSize is the number of entries buffered before they are written, the entries are dropped if the buffer is full
+	BufferSize int
+}
+
+// Logger writes the access log in a background goroutine, so that logging doesn't block the requests
+type Logger struct {
+	formatter Formatter
+	writer    io.WriteCloser
+
+	entries chan *Entry
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+	dropped int64
+}
+
+// NewLogger returns a Logger writing to the rotated file of cfg
+func NewLogger(cfg Config) (*Logger, error) {
+	if cfg.Filename == "" {
+		return nil, errors.New("access log file name is empty")
+	}
+	formatter, err := NewFormatter(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+	return newLogger(formatter, &lumberjack.Logger{
+		Filename:   cfg.Filename,
+		MaxSize:    cfg.MaxSize,
+		MaxBackups: cfg.MaxBackups,
+		LocalTime:  true,
+	}, cfg.BufferSize), nil
+}
+
+func newLogger(formatter Formatter, writer io.WriteCloser, bufferSize int) *Logger {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	l := &Logger{
+		formatter: formatter,
+		writer:    writer,
+		entries:   make(chan *Entry, bufferSize),
+		closeCh:   make(chan struct{}),
+	}
+	l.wg.Add(1)
+	go l.loop()
+	return l
+}
+
+// Log enqueues entry to be written, entry is dropped if the buffer is full or the logger is closed
+func (l *Logger) Log(entry *Entry) {
+	select {
+	case <-l.closeCh:
+		atomic.AddInt64(&l.dropped, 1)
+		return
+	default:
+	}
+	select {
+	case l.entries <- entry:
+	default:
+		atomic.AddInt64(&l.dropped, 1)
+	}
+}
+
+// Dropped returns the number of entries dropped
+func (l *Logger) Dropped() int64 {
+	return atomic.LoadInt64(&l.dropped)
+}
+
+func (l *Logger) write(entry *Entry) {
+	if _, err := l.writer.Write(l.formatter.Format(entry)); err != nil {
+		log.Warn("failed to write access log", zap.Error(err))
+	}
+}
+
+func (l *Logger) loop() {
+	defer l.wg.Done()
+	for {
+		select {
+		case entry := <-l.entries:
+			l.write(entry)
+		case <-l.closeCh:
+			for {
+				select {
+				case entry := <-l.entries:
+					l.write(entry)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close writes the buffered entries and closes the log file
+func (l *Logger) Close() error {
+	var err error
+	l.once.Do(func() {
+		close(l.closeCh)
+		l.wg.Wait()
+		err = l.writer.Close()
+		if dropped := l.Dropped(); dropped > 0 {
+			log.Warn("access log entries dropped", zap.Int64("dropped", dropped))
+		}
+	})
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type blockingWriter struct {
+	mu      sync.Mutex
+	lines   []string
+	block   chan struct{}
+	started chan struct{}
+	once    sync.Once
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.started) })
+	<-w.block
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lines = append(w.lines, string(p))
+	return len(p), nil
+}
+
+func (w *blockingWriter) Close() error {
+	return nil
+}
+
+func TestNewLogger(t *testing.T) {
+	_, err := NewLogger(Config{})
+	assert.Error(t, err)
+
+	_, err = NewLogger(Config{Filename: "access.log", Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestLogger_Rotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "access_log")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := path.Join(dir, "access.log")
+	logger, err := NewLogger(Config{
+		Filename:   filename,
+		MaxSize:    1,
+		MaxBackups: 2,
+		Format:     FormatText,
+		BufferSize: 1,
+	})
+	assert.NoError(t, err)
+
+	// write about 3MB, the log should be rotated at every 1MB
+	reason := strings.Repeat("x", 1024)
+	total := 3 * 1024
+	for i := 0; i < total; i++ {
+		entry := &Entry{Time: time.Now(), Method: "Insert", Error: reason}
+		// retry until the entry is enqueued, so that no entry is dropped
+		for dropped := logger.Dropped(); ; dropped = logger.Dropped() {
+			logger.Log(entry)
+			if logger.Dropped() == dropped {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	assert.NoError(t, logger.Close())
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	// the current file and MaxBackups rotated files
+	assert.Equal(t, 3, len(files))
+	for _, f := range files {
+		assert.LessOrEqual(t, f.Size(), int64(1024*1024))
+	}
+
+	// every line of the current file is complete
+	f, err := os.Open(filename)
+	assert.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 4096), 4096)
+	lines := 0
+	for scanner.Scan() {
+		assert.True(t, strings.HasPrefix(scanner.Text(), "time="))
+		assert.True(t, strings.HasSuffix(scanner.Text(), "result=0.000ms"))
+		lines++
+	}
+	assert.NoError(t, scanner.Err())
+	assert.Greater(t, lines, 0)
+}
+
+func TestLogger_DropAndClose(t *testing.T) {
+	writer := &blockingWriter{block: make(chan struct{}), started: make(chan struct{})}
+	logger := newLogger(TextFormatter{}, writer, 2)
+
+	// the first entry blocks the writer, the next two fill the buffer
+	logger.Log(&Entry{Method: "m0"})
+	<-writer.started
+	logger.Log(&Entry{Method: "m1"})
+	logger.Log(&Entry{Method: "m2"})
+	logger.Log(&Entry{Method: "m3"})
+	assert.Equal(t, int64(1), logger.Dropped())
+
+	close(writer.block)
+	assert.NoError(t, logger.Close())
+	// closing twice is ok
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, 3, len(writer.lines))
+	for i, line := range writer.lines {
+		assert.Contains(t, line, "method=m"+string(rune('0'+i)))
+	}
+
+	logger.Log(&Entry{Method: "m4"})
+	assert.Equal(t, int64(2), logger.Dropped())
+	assert.Equal(t, 3, len(writer.lines))
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Stage is a stage of a request whose latency is recorded in the access log
+type Stage int
+
+const (
+	// StageQueue is the time waiting in the task queue
+	StageQueue Stage = iota
+	// StageCoord is the time calling coordinators
+	StageCoord
+	// StagePublish is the time publishing messages to message stream
+	StagePublish
+	// StageResult is the time waiting for the results from query nodes
+	StageResult
+
+	stageNum
+)
+
+var stageNames = [stageNum]string{"queue", "coord", "publish", "result"}
+
+func (s Stage) String() string {
+	if s < 0 || s >= stageNum {
+		return "unknown"
+	}
+	return stageNames[s]
+}
+
+// Record records the latency of the stages of a request. The timestamps are recorded atomically since the tasks may
+// still run after the request is returned, and all the methods are no-op on a nil Record.
+type Record struct {
+	begins  [stageNum]int64
+	elapsed [stageNum]int64
+}
+
+// Begin records the beginning of stage
+func (r *Record) Begin(stage Stage) {
+	if r == nil {
+		return
+	}
+	atomic.StoreInt64(&r.begins[stage], time.Now().UnixNano())
+}
+
+// End records the end of stage, the elapsed time of a stage is accumulated if it happens more than once
+func (r *Record) End(stage Stage) {
+	if r == nil {
+		return
+	}
+	begin := atomic.LoadInt64(&r.begins[stage])
+	if begin == 0 {
+		return
+	}
+	atomic.AddInt64(&r.elapsed[stage], time.Now().UnixNano()-begin)
+}
+
+// Elapsed returns the accumulated elapsed time of stage
+func (r *Record) Elapsed(stage Stage) time.Duration {
+	if r == nil {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&r.elapsed[stage]))
+}
+
+type recordKey struct{}
+
+// WithRecord returns a copy of ctx carrying r
+func WithRecord(ctx context.Context, r *Record) context.Context {
+	return context.WithValue(ctx, recordKey{}, r)
+}
+
+// FromContext returns the Record carried by ctx, nil if there is none
+func FromContext(ctx context.Context) *Record {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(recordKey{}).(*Record)
+	return r
+}
+
+// Begin records the beginning of stage in the Record carried by ctx
+func Begin(ctx context.Context, stage Stage) {
+	FromContext(ctx).Begin(stage)
+}
+
+// End records the end of stage in the Record carried by ctx
+func End(ctx context.Context, stage Stage) {
+	FromContext(ctx).End(stage)
+}
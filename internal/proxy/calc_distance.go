@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+)
+
+// validateVectorIDs checks the ids referencing the vectors of a collection in CalcDistance
+func validateVectorIDs(ids *milvuspb.VectorIDs) error {
+	if err := validateCollectionName(ids.GetCollectionName()); err != nil {
+		return err
+	}
+	if ids.GetFieldName() == "" {
+		return errors.New("vector field name is empty")
+	}
+	if ids.GetIdArray().GetStrId() != nil {
+		return errors.New("only int64 ids are supported")
+	}
+	if len(ids.GetIdArray().GetIntId().GetData()) == 0 {
+		return errors.New("vector ids are empty")
+	}
+	return nil
+}
+
+// arrangeVectors returns the vectors of field fieldName retrieved by ids, in the order of ids
+func arrangeVectors(ids []int64, fieldName string, fieldsData []*schemapb.FieldData) (*schemapb.VectorField, error) {
+	var retrievedIds []int64
+	var retrievedVectors *schemapb.VectorField
+	for _, fieldData := range fieldsData {
+		if fieldData.FieldName == fieldName {
+			retrievedVectors = fieldData.GetVectors()
+		} else if fieldData.Type == schemapb.DataType_Int64 {
+			retrievedIds = fieldData.GetScalars().GetLongData().GetData()
+		}
+	}
+	if retrievedIds == nil || retrievedVectors == nil {
+		return nil, fmt.Errorf("failed to fetch vectors of field %s", fieldName)
+	}
+
+	dict := make(map[int64]int64, len(retrievedIds))
+	for index, id := range retrievedIds {
+		dict[id] = int64(index)
+	}
+	dim := retrievedVectors.GetDim()
+
+	switch vectors := retrievedVectors.Data.(type) {
+	case *schemapb.VectorField_FloatVector:
+		data := vectors.FloatVector.GetData()
+		result := make([]float32, 0, int64(len(ids))*dim)
+		for _, id := range ids {
+			index, ok := dict[id]
+			if !ok || (index+1)*dim > int64(len(data)) {
+				return nil, fmt.Errorf("failed to fetch vector by id %d", id)
+			}
+			result = append(result, data[index*dim:(index+1)*dim]...)
+		}
+		return &schemapb.VectorField{
+			Dim: dim,
+			Data: &schemapb.VectorField_FloatVector{
+				FloatVector: &schemapb.FloatArray{Data: result},
+			},
+		}, nil
+	case *schemapb.VectorField_BinaryVector:
+		data := vectors.BinaryVector
+		bytesPerVector := distance.SingleBitLen(dim) / 8
+		result := make([]byte, 0, int64(len(ids))*bytesPerVector)
+		for _, id := range ids {
+			index, ok := dict[id]
+			if !ok || (index+1)*bytesPerVector > int64(len(data)) {
+				return nil, fmt.Errorf("failed to fetch vector by id %d", id)
+			}
+			result = append(result, data[index*bytesPerVector:(index+1)*bytesPerVector]...)
+		}
+		return &schemapb.VectorField{
+			Dim: dim,
+			Data: &schemapb.VectorField_BinaryVector{
+				BinaryVector: result,
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("field %s is not a vector field", fieldName)
+}
+
+// calcVectorsDistance returns the distances between every vector of left and every vector of right, num(left)*num(right)
+// distances in the order of left vectors. L2 and IP are supported by float vectors, HAMMING and TANIMOTO are supported
+// by binary vectors.
+func calcVectorsDistance(left *schemapb.VectorField, right *schemapb.VectorField, metric string) (*milvuspb.CalcDistanceResults, error) {
+	if left.GetDim() <= 0 || right.GetDim() <= 0 {
+		return nil, fmt.Errorf("invalid dimension, left: %d, right: %d", left.GetDim(), right.GetDim())
+	}
+	if left.GetDim() != right.GetDim() {
+		return nil, fmt.Errorf("dimension mismatch, left: %d, right: %d", left.GetDim(), right.GetDim())
+	}
+	dim := left.GetDim()
+
+	leftFloat, rightFloat := left.GetFloatVector(), right.GetFloatVector()
+	leftBinary, rightBinary := left.GetBinaryVector(), right.GetBinaryVector()
+
+	switch {
+	case leftFloat != nil && rightFloat != nil:
+		if metric != distance.L2 && metric != distance.IP {
+			return nil, fmt.Errorf("metric type %s is not supported by float vectors, supported: %s, %s", metric, distance.L2, distance.IP)
+		}
+		if err := distance.ValidateFloatArrayLength(dim, len(leftFloat.GetData())); err != nil {
+			return nil, fmt.Errorf("left vectors: %w, dimension: %d", err, dim)
+		}
+		if err := distance.ValidateFloatArrayLength(dim, len(rightFloat.GetData())); err != nil {
+			return nil, fmt.Errorf("right vectors: %w, dimension: %d", err, dim)
+		}
+		distances, err := distance.CalcFloatDistance(dim, leftFloat.GetData(), rightFloat.GetData(), metric)
+		if err != nil {
+			return nil, err
+		}
+		return &milvuspb.CalcDistanceResults{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			Array: &milvuspb.CalcDistanceResults_FloatDist{
+				FloatDist: &schemapb.FloatArray{Data: distances},
+			},
+		}, nil
+
+	case leftBinary != nil && rightBinary != nil:
+		if metric != distance.HAMMING && metric != distance.TANIMOTO {
+			return nil, fmt.Errorf("metric type %s is not supported by binary vectors, supported: %s, %s", metric, distance.HAMMING, distance.TANIMOTO)
+		}
+		if err := distance.ValidateBinaryArrayLength(dim, len(leftBinary)); err != nil {
+			return nil, fmt.Errorf("left vectors: %w, dimension: %d", err, dim)
+		}
+		if err := distance.ValidateBinaryArrayLength(dim, len(rightBinary)); err != nil {
+			return nil, fmt.Errorf("right vectors: %w, dimension: %d", err, dim)
+		}
+		hamming, err := distance.CalcHammingDistance(dim, leftBinary, rightBinary)
+		if err != nil {
+			return nil, err
+		}
+		if metric == distance.HAMMING {
+			return &milvuspb.CalcDistanceResults{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				Array: &milvuspb.CalcDistanceResults_IntDist{
+					IntDist: &schemapb.IntArray{Data: hamming},
+				},
+			}, nil
+		}
+		tanimoto, err := distance.CalcTanimotoCoefficient(dim, hamming)
+		if err != nil {
+			return nil, err
+		}
+		return &milvuspb.CalcDistanceResults{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			Array: &milvuspb.CalcDistanceResults_FloatDist{
+				FloatDist: &schemapb.FloatArray{Data: tanimoto},
+			},
+		}, nil
+
+	case (leftFloat != nil || leftBinary != nil) && (rightFloat != nil || rightBinary != nil):
+		return nil, errors.New("cannot calculate distance between binary vectors and float vectors")
+	}
+	return nil, errors.New("vectors are empty")
+}
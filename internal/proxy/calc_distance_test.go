@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/distance"
+)
+
+func newFloatVectorField(dim int64, data []float32) *schemapb.VectorField {
+	return &schemapb.VectorField{
+		Dim: dim,
+		Data: &schemapb.VectorField_FloatVector{
+			FloatVector: &schemapb.FloatArray{Data: data},
+		},
+	}
+}
+
+func newBinaryVectorField(dim int64, data []byte) *schemapb.VectorField {
+	return &schemapb.VectorField{
+		Dim: dim,
+		Data: &schemapb.VectorField_BinaryVector{
+			BinaryVector: data,
+		},
+	}
+}
+
+func TestCalcVectorsDistance(t *testing.T) {
+	// [1, 2], [3, 4] and [0, 0], [1, 1], [-1, 2]
+	floatLeft := newFloatVectorField(2, []float32{1, 2, 3, 4})
+	floatRight := newFloatVectorField(2, []float32{0, 0, 1, 1, -1, 2})
+	// 11110000, 00000000 and 11110000, 00001111, 11000000, 10101010 of dim 8
+	binaryLeft := newBinaryVectorField(8, []byte{0xF0, 0x00})
+	binaryRight := newBinaryVectorField(8, []byte{0xF0, 0x0F, 0xC0, 0xAA})
+
+	t.Run("L2", func(t *testing.T) {
+		result, err := calcVectorsDistance(floatLeft, floatRight, distance.L2)
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float32{5, 1, 4, 25, 13, 20}, result.GetFloatDist().GetData(), 1e-6)
+	})
+
+	t.Run("IP", func(t *testing.T) {
+		result, err := calcVectorsDistance(floatLeft, floatRight, distance.IP)
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float32{0, 3, 3, 0, 7, 5}, result.GetFloatDist().GetData(), 1e-6)
+	})
+
+	t.Run("HAMMING", func(t *testing.T) {
+		result, err := calcVectorsDistance(binaryLeft, binaryRight, distance.HAMMING)
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{0, 8, 2, 4, 4, 4, 2, 4}, result.GetIntDist().GetData())
+	})
+
+	t.Run("TANIMOTO", func(t *testing.T) {
+		result, err := calcVectorsDistance(binaryLeft, binaryRight, distance.TANIMOTO)
+		assert.NoError(t, err)
+		// equal bits / (2 * dim - equal bits)
+		assert.InDeltaSlice(t, []float32{1, 0, 0.6, 0.333333, 0.333333, 0.333333, 0.6, 0.333333}, result.GetFloatDist().GetData(), 1e-6)
+	})
+
+	t.Run("binary vectors not aligned to bytes", func(t *testing.T) {
+		// dim 4, only the high 4 bits of every byte are used
+		left := newBinaryVectorField(4, []byte{0xFF})
+		right := newBinaryVectorField(4, []byte{0xF0, 0x80})
+		result, err := calcVectorsDistance(left, right, distance.HAMMING)
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{0, 3}, result.GetIntDist().GetData())
+	})
+
+	t.Run("metric not supported by vector type", func(t *testing.T) {
+		_, err := calcVectorsDistance(floatLeft, floatRight, distance.HAMMING)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not supported by float vectors")
+
+		_, err = calcVectorsDistance(floatLeft, floatRight, distance.TANIMOTO)
+		assert.Error(t, err)
+
+		_, err = calcVectorsDistance(binaryLeft, binaryRight, distance.L2)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not supported by binary vectors")
+
+		_, err = calcVectorsDistance(binaryLeft, binaryRight, distance.IP)
+		assert.Error(t, err)
+	})
+
+	t.Run("dimension mismatch", func(t *testing.T) {
+		_, err := calcVectorsDistance(floatLeft, newFloatVectorField(3, []float32{1, 2, 3}), distance.L2)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "dimension mismatch")
+
+		_, err = calcVectorsDistance(binaryLeft, newBinaryVectorField(16, []byte{0xFF, 0xFF}), distance.HAMMING)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "dimension mismatch")
+
+		_, err = calcVectorsDistance(newFloatVectorField(0, []float32{1}), newFloatVectorField(0, []float32{1}), distance.L2)
+		assert.Error(t, err)
+	})
+
+	t.Run("length not matching dimension", func(t *testing.T) {
+		_, err := calcVectorsDistance(floatLeft, newFloatVectorField(2, []float32{1, 2, 3}), distance.L2)
+		assert.Error(t, err)
+
+		_, err = calcVectorsDistance(newFloatVectorField(2, []float32{}), floatRight, distance.IP)
+		assert.Error(t, err)
+
+		_, err = calcVectorsDistance(newBinaryVectorField(16, []byte{0xFF}), newBinaryVectorField(16, []byte{0xFF, 0xFF}), distance.HAMMING)
+		assert.Error(t, err)
+	})
+
+	t.Run("mixed vector types", func(t *testing.T) {
+		_, err := calcVectorsDistance(newFloatVectorField(8, make([]float32, 8)), binaryLeft, distance.L2)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "binary vectors and float vectors")
+	})
+
+	t.Run("empty vectors", func(t *testing.T) {
+		_, err := calcVectorsDistance(&schemapb.VectorField{Dim: 2}, floatRight, distance.L2)
+		assert.Error(t, err)
+	})
+}
+
+func TestArrangeVectors(t *testing.T) {
+	pkField := &schemapb.FieldData{
+		Type:      schemapb.DataType_Int64,
+		FieldName: "pk",
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{
+					LongData: &schemapb.LongArray{Data: []int64{3, 1, 2}},
+				},
+			},
+		},
+	}
+
+	t.Run("float vectors", func(t *testing.T) {
+		fieldsData := []*schemapb.FieldData{
+			pkField,
+			{
+				Type:      schemapb.DataType_FloatVector,
+				FieldName: "fvec",
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: newFloatVectorField(2, []float32{3, 3, 1, 1, 2, 2}),
+				},
+			},
+		}
+		vectors, err := arrangeVectors([]int64{1, 2, 3, 1}, "fvec", fieldsData)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), vectors.GetDim())
+		assert.Equal(t, []float32{1, 1, 2, 2, 3, 3, 1, 1}, vectors.GetFloatVector().GetData())
+
+		_, err = arrangeVectors([]int64{4}, "fvec", fieldsData)
+		assert.Error(t, err)
+
+		_, err = arrangeVectors([]int64{1}, "bvec", fieldsData)
+		assert.Error(t, err)
+	})
+
+	t.Run("binary vectors", func(t *testing.T) {
+		fieldsData := []*schemapb.FieldData{
+			pkField,
+			{
+				Type:      schemapb.DataType_BinaryVector,
+				FieldName: "bvec",
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: newBinaryVectorField(16, []byte{3, 3, 1, 1, 2, 2}),
+				},
+			},
+		}
+		vectors, err := arrangeVectors([]int64{2, 3, 1}, "bvec", fieldsData)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(16), vectors.GetDim())
+		assert.Equal(t, []byte{2, 2, 3, 3, 1, 1}, vectors.GetBinaryVector())
+
+		_, err = arrangeVectors([]int64{4}, "bvec", fieldsData)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateVectorIDs(t *testing.T) {
+	ids := &milvuspb.VectorIDs{
+		CollectionName: "coll",
+		FieldName:      "fvec",
+		IdArray: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}},
+		},
+	}
+	assert.NoError(t, validateVectorIDs(ids))
+
+	assert.Error(t, validateVectorIDs(&milvuspb.VectorIDs{CollectionName: "", FieldName: "fvec", IdArray: ids.IdArray}))
+	assert.Error(t, validateVectorIDs(&milvuspb.VectorIDs{CollectionName: "coll", IdArray: ids.IdArray}))
+	assert.Error(t, validateVectorIDs(&milvuspb.VectorIDs{CollectionName: "coll", FieldName: "fvec"}))
+	assert.Error(t, validateVectorIDs(&milvuspb.VectorIDs{
+		CollectionName: "coll",
+		FieldName:      "fvec",
+		IdArray: &schemapb.IDs{
+			IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a"}}},
+		},
+	}))
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// sessionTsTracker tracks the timestamp of the last write of every client session,
+// which is used as the guarantee timestamp of search and query of Session consistency level.
+type sessionTsTracker struct {
+	mu          sync.RWMutex
+	lastWriteTs map[string]Timestamp
+}
+
+func newSessionTsTracker() *sessionTsTracker {
+	return &sessionTsTracker{
+		lastWriteTs: make(map[string]Timestamp),
+	}
+}
+
+// update records ts as the last write timestamp of session if ts is newer
+func (t *sessionTsTracker) update(session string, ts Timestamp) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ts > t.lastWriteTs[session] {
+		t.lastWriteTs[session] = ts
+	}
+}
+
+// get returns the last write timestamp of session, 0 is returned if the session never writes
+func (t *sessionTsTracker) get(session string) Timestamp {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lastWriteTs[session]
+}
+
+// getClientSession returns the identity of the client session which sends the request,
+// the requests from the same grpc connection belong to the same session.
+func getClientSession(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
+
+// computeGuaranteeTs returns the guarantee timestamp of search or query according to the consistency level,
+// query node would wait until its serviceable time reaches the guarantee timestamp before searching.
+// The guarantee timestamp specified by the request is always respected.
+func computeGuaranteeTs(level commonpb.ConsistencyLevel, requestTs, beginTs, sessionTs Timestamp) Timestamp {
+	if requestTs != 0 {
+		return requestTs
+	}
+	switch level {
+	case commonpb.ConsistencyLevel_Session:
+		return sessionTs
+	case commonpb.ConsistencyLevel_BoundedStaleness:
+		physical, logical := tsoutil.ParseHybridTs(beginTs)
+		staleness := uint64(Params.BoundedStaleness / time.Millisecond)
+		if physical <= staleness {
+			return 0
+		}
+		return tsoutil.ComposeTS(int64(physical-staleness), int64(logical))
+	case commonpb.ConsistencyLevel_Eventually:
+		return 0
+	default:
+		return beginTs
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestSessionTsTracker(t *testing.T) {
+	tracker := newSessionTsTracker()
+	assert.Equal(t, Timestamp(0), tracker.get("client"))
+
+	tracker.update("client", 100)
+	assert.Equal(t, Timestamp(100), tracker.get("client"))
+
+	// older timestamp is ignored
+	tracker.update("client", 50)
+	assert.Equal(t, Timestamp(100), tracker.get("client"))
+
+	assert.Equal(t, Timestamp(0), tracker.get("another"))
+}
+
+func TestGetClientSession(t *testing.T) {
+	assert.Equal(t, "", getClientSession(context.Background()))
+
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 12345}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	assert.Equal(t, addr.String(), getClientSession(ctx))
+}
+
+func TestComputeGuaranteeTs(t *testing.T) {
+	Params.Init()
+
+	now := time.Now()
+	beginTs := tsoutil.ComposeTS(now.UnixNano()/int64(time.Millisecond), 3)
+	sessionTs := tsoutil.ComposeTS(now.Add(-time.Second).UnixNano()/int64(time.Millisecond), 0)
+
+	// strong consistency by default
+	assert.Equal(t, beginTs, computeGuaranteeTs(commonpb.ConsistencyLevel_Strong, 0, beginTs, sessionTs))
+
+	// the specified guarantee timestamp is respected
+	assert.Equal(t, Timestamp(100), computeGuaranteeTs(commonpb.ConsistencyLevel_Eventually, 100, beginTs, sessionTs))
+
+	assert.Equal(t, sessionTs, computeGuaranteeTs(commonpb.ConsistencyLevel_Session, 0, beginTs, sessionTs))
+	assert.Equal(t, Timestamp(0), computeGuaranteeTs(commonpb.ConsistencyLevel_Session, 0, beginTs, 0))
+
+	assert.Equal(t, Timestamp(0), computeGuaranteeTs(commonpb.ConsistencyLevel_Eventually, 0, beginTs, sessionTs))
+
+	bounded := computeGuaranteeTs(commonpb.ConsistencyLevel_BoundedStaleness, 0, beginTs, sessionTs)
+	boundedTime, logical := tsoutil.ParseTS(bounded)
+	beginTime, _ := tsoutil.ParseTS(beginTs)
+	assert.Equal(t, Params.BoundedStaleness, beginTime.Sub(boundedTime))
+	assert.Equal(t, uint64(3), logical)
+
+	// the physical time is less than the staleness
+	assert.Equal(t, Timestamp(0), computeGuaranteeTs(commonpb.ConsistencyLevel_BoundedStaleness, 0, tsoutil.ComposeTS(1, 0), sessionTs))
+}
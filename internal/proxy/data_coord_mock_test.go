@@ -140,6 +140,22 @@ func (coord *DataCoordMock) GetFlushedSegments(ctx context.Context, req *datapb.
 	panic("implement me")
 }
 
+func (coord *DataCoordMock) Import(ctx context.Context, req *datapb.ImportTaskRequest) (*datapb.ImportTaskResponse, error) {
+	panic("implement me")
+}
+
+func (coord *DataCoordMock) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	panic("implement me")
+}
+
+func (coord *DataCoordMock) ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
+func (coord *DataCoordMock) DropSegment(ctx context.Context, req *datapb.DropSegmentRequest) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
 func (coord *DataCoordMock) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	if !coord.healthy() {
 		return &milvuspb.GetMetricsResponse{
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ant_ast "github.com/antonmedv/expr/ast"
+	ant_parser "github.com/antonmedv/expr/parser"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// identifierCollector collects the identifiers in an expression
+type identifierCollector struct {
+	identifiers []string
+}
+
+func (*identifierCollector) Enter(*ant_ast.Node) {}
+
+func (c *identifierCollector) Exit(node *ant_ast.Node) {
+	if identifier, ok := (*node).(*ant_ast.IdentifierNode); ok {
+		c.identifiers = append(c.identifiers, identifier.Value)
+	}
+}
+
+// parseDeleteExpr parses the expression of a delete request. The primary keys are returned directly if expr is
+// a term expression on the primary key, otherwise isPkExpr is false and the primary keys of the matched entities
+// should be retrieved from query nodes.
+func parseDeleteExpr(schema *schemapb.CollectionSchema, expr string) (pks []int64, isPkExpr bool, err error) {
+	if expr == "" {
+		return nil, false, errors.New("delete expression is empty")
+	}
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return nil, false, err
+	}
+	pkField, err := helper.GetPrimaryKeyField()
+	if err != nil {
+		return nil, false, fmt.Errorf("can't delete from collection %s without primary key", schema.Name)
+	}
+
+	// the plan parser only reports type mismatch on vector fields
+	if tree, err := ant_parser.Parse(expr); err == nil {
+		collector := &identifierCollector{}
+		ant_ast.Walk(&tree.Node, collector)
+		for _, name := range collector.identifiers {
+			field, err := helper.GetFieldFromName(name)
+			if err == nil && typeutil.IsVectorType(field.DataType) {
+				return nil, false, fmt.Errorf("delete expression can't reference vector field %s", field.Name)
+			}
+		}
+	}
+
+	plan, err := createExprPlan(schema, expr)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create expr plan, expr = %s, error = %w", expr, err)
+	}
+	predicates := plan.GetPredicates()
+	if predicates == nil {
+		return nil, false, fmt.Errorf("invalid delete expression: %s", expr)
+	}
+
+	termExpr, ok := predicates.Expr.(*planpb.Expr_TermExpr)
+	if !ok || termExpr.TermExpr.GetColumnInfo().GetFieldId() != pkField.FieldID {
+		return nil, false, nil
+	}
+	pks = make([]int64, 0, len(termExpr.TermExpr.Values))
+	for _, v := range termExpr.TermExpr.Values {
+		pks = append(pks, v.GetInt64Val())
+	}
+	return pks, true, nil
+}
+
+// getDeletePrimaryKeys returns the primary keys of the entities to be deleted by request, the entities matching
+// an expression other than the term expression on the primary key are retrieved from query nodes.
+func (node *Proxy) getDeletePrimaryKeys(ctx context.Context, request *milvuspb.DeleteRequest) ([]int64, error) {
+	if err := validateCollectionName(request.CollectionName); err != nil {
+		return nil, err
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, request.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+	if getPartitionKeyField(schema) != nil && request.PartitionName != "" {
+		return nil, errPartitionKeyWithPartitionNames(request.CollectionName)
+	}
+	pks, isPkExpr, err := parseDeleteExpr(schema, request.Expr)
+	if err != nil {
+		return nil, err
+	}
+	if isPkExpr {
+		return pks, nil
+	}
+
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return nil, err
+	}
+	pkField, err := helper.GetPrimaryKeyField()
+	if err != nil {
+		return nil, err
+	}
+	return node.queryPrimaryKeys(ctx, request, pkField)
+}
+
+// queryPrimaryKeys retrieves the primary keys of the entities matching the delete expression in the partition of request
+func (node *Proxy) queryPrimaryKeys(ctx context.Context, request *milvuspb.DeleteRequest, pkField *schemapb.FieldSchema) ([]int64, error) {
+	queryRequest := &milvuspb.QueryRequest{
+		DbName:         request.DbName,
+		CollectionName: request.CollectionName,
+		Expr:           request.Expr,
+		OutputFields:   []string{pkField.Name},
+		// the entities inserted before the delete request should be visible
+		ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+	}
+	if request.PartitionName != "" {
+		queryRequest.PartitionNames = []string{request.PartitionName}
+	}
+
+	qt := &queryTask{
+		ctx:       ctx,
+		Condition: NewTaskCondition(ctx),
+		RetrieveRequest: &internalpb.RetrieveRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:  commonpb.MsgType_Retrieve,
+				SourceID: Params.ProxyID,
+			},
+		},
+		resultBuf: make(chan []*internalpb.RetrieveResults),
+		query:     queryRequest,
+		chMgr:     node.chMgr,
+		qc:        node.queryCoord,
+	}
+	if err := node.sched.dqQueue.Enqueue(qt); err != nil {
+		return nil, err
+	}
+	if err := qt.WaitToFinish(); err != nil {
+		return nil, err
+	}
+
+	switch qt.result.Status.ErrorCode {
+	case commonpb.ErrorCode_Success:
+	case commonpb.ErrorCode_EmptyCollection:
+		// nothing matches the expression
+		return []int64{}, nil
+	default:
+		return nil, errors.New(qt.result.Status.Reason)
+	}
+	for _, fieldData := range qt.result.FieldsData {
+		if fieldData.FieldId == pkField.FieldID {
+			pks := fieldData.GetScalars().GetLongData().GetData()
+			log.Debug("retrieve primary keys to delete",
+				zap.String("collection", request.CollectionName),
+				zap.String("expr", request.Expr),
+				zap.Int("count", len(pks)))
+			return pks, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to retrieve primary key field %s of the entities to delete", pkField.Name)
+}
+
+// splitPrimaryKeys splits pks into batches of at most batchSize keys
+func splitPrimaryKeys(pks []int64, batchSize int) [][]int64 {
+	if batchSize <= 0 {
+		batchSize = len(pks)
+	}
+	batches := make([][]int64, 0)
+	for len(pks) > 0 {
+		n := batchSize
+		if n > len(pks) {
+			n = len(pks)
+		}
+		batches = append(batches, pks[:n])
+		pks = pks[n:]
+	}
+	return batches
+}
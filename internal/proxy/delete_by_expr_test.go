@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func newDeleteTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test_delete_expr",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "category", DataType: schemapb.DataType_Int64},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Double},
+			{
+				FieldID:    103,
+				Name:       "fvec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "8"}},
+			},
+		},
+	}
+}
+
+func TestParseDeleteExpr(t *testing.T) {
+	schema := newDeleteTestSchema()
+
+	cases := []struct {
+		name     string
+		expr     string
+		pks      []int64
+		isPkExpr bool
+		errMsg   string
+	}{
+		{
+			name:     "primary keys",
+			expr:     "pk in [1, 2, 3]",
+			pks:      []int64{1, 2, 3},
+			isPkExpr: true,
+		},
+		{
+			name: "term on other field",
+			expr: "category in [1, 2]",
+		},
+		{
+			name: "compare",
+			expr: "category == 3",
+		},
+		{
+			name: "range on primary key",
+			expr: "pk > 100",
+		},
+		{
+			name: "logical",
+			expr: "category == 3 && price < 10.0 || not (pk in [1])",
+		},
+		{
+			name:   "empty",
+			expr:   "",
+			errMsg: "delete expression is empty",
+		},
+		{
+			name:   "invalid",
+			expr:   "category ==",
+			errMsg: "failed to create expr plan",
+		},
+		{
+			name:   "unknown field",
+			expr:   "unknown == 1",
+			errMsg: "failed to create expr plan",
+		},
+		{
+			name:   "vector field",
+			expr:   "fvec in [1]",
+			errMsg: "vector field fvec",
+		},
+		{
+			name:   "vector field in logical expression",
+			expr:   "category == 3 && fvec > 1",
+			errMsg: "vector field fvec",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pks, isPkExpr, err := parseDeleteExpr(schema, c.expr)
+			if c.errMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), c.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.isPkExpr, isPkExpr)
+			assert.Equal(t, c.pks, pks)
+		})
+	}
+
+	t.Run("no primary key", func(t *testing.T) {
+		schema := newDeleteTestSchema()
+		schema.Fields[0].IsPrimaryKey = false
+		_, _, err := parseDeleteExpr(schema, "pk in [1]")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "without primary key")
+	})
+
+	t.Run("getPrimaryKeysFromExpr", func(t *testing.T) {
+		pks, err := getPrimaryKeysFromExpr(schema, "pk in [1, 2]")
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, pks)
+
+		_, err = getPrimaryKeysFromExpr(schema, "category == 3")
+		assert.Error(t, err)
+	})
+}
+
+func TestSplitPrimaryKeys(t *testing.T) {
+	pks := []int64{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int64{{1, 2}, {3, 4}, {5}}, splitPrimaryKeys(pks, 2))
+	assert.Equal(t, [][]int64{{1, 2, 3, 4, 5}}, splitPrimaryKeys(pks, 5))
+	assert.Equal(t, [][]int64{{1, 2, 3, 4, 5}}, splitPrimaryKeys(pks, 0))
+	assert.Equal(t, 0, len(splitPrimaryKeys([]int64{}, 2)))
+}
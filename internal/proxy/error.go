@@ -14,6 +14,7 @@ package proxy
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 )
@@ -34,6 +35,9 @@ func errNumRowsOfFieldDataMismatchPassed(idx int, fieldNumRows, passedNumRows ui
 
 var errEmptyFieldData = errors.New("empty field data")
 
+// errDataNodeMemoryHigh means the inserts are throttled since the memory of DataNode is high, the client should retry later
+var errDataNodeMemoryHigh = errors.New("memory of DataNode is high")
+
 func errFieldsLessThanNeeded(fieldsNum, needed int) error {
 	return fmt.Errorf("the length(%d) of passed fields is less than needed(%d)", fieldsNum, needed)
 }
@@ -58,6 +62,28 @@ func errDimShouldDivide8(dim int) error {
 	return fmt.Errorf("dim(%d) should divide 8", dim)
 }
 
+// errPayloadTooLarge means the serialized size of a request or result exceeds the limit, suggestion tells the client how
+// to make it smaller
+func errPayloadTooLarge(payload string, size int, limit int64, suggestion string) error {
+	return fmt.Errorf("the size of the %s(%d bytes) exceeds the limit(%d bytes), %s", payload, size, limit, suggestion)
+}
+
+// errTravelTimestampBeyondRetention means the travel timestamp is older than the retention duration
+func errTravelTimestampBeyondRetention(travelTs Timestamp, retention time.Duration) error {
+	return fmt.Errorf("travel timestamp %d is beyond retention duration %v, the history before it may have been garbage collected", travelTs, retention)
+}
+
+// maxReportedDuplicatePKs is the max number of duplicate primary keys named in the error
+const maxReportedDuplicatePKs = 10
+
+// errDuplicatePrimaryKeys means the insert batch contains the rows of the same primary keys
+func errDuplicatePrimaryKeys(pks []int64) error {
+	if len(pks) > maxReportedDuplicatePKs {
+		return fmt.Errorf("duplicate primary keys %v and %d more in the insert batch", pks[:maxReportedDuplicatePKs], len(pks)-maxReportedDuplicatePKs)
+	}
+	return fmt.Errorf("duplicate primary keys %v in the insert batch", pks)
+}
+
 func msgProxyIsUnhealthy(id UniqueID) string {
 	return fmt.Sprintf("proxy %d is unhealthy", id)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
 
@@ -145,3 +146,12 @@ func Test_errProxyIsUnhealthy(t *testing.T) {
 			zap.Error(errProxyIsUnhealthy(id)))
 	}
 }
+
+func Test_errDuplicatePrimaryKeys(t *testing.T) {
+	err := errDuplicatePrimaryKeys([]int64{1, 2})
+	assert.Equal(t, "duplicate primary keys [1 2] in the insert batch", err.Error())
+
+	pks := make([]int64, maxReportedDuplicatePKs+2)
+	err = errDuplicatePrimaryKeys(pks)
+	assert.Contains(t, err.Error(), "and 2 more")
+}
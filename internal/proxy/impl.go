@@ -20,10 +20,12 @@ import (
 
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/trace"
 
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 
+	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
@@ -68,6 +70,32 @@ func (node *Proxy) GetComponentStates(ctx context.Context) (*internalpb.Componen
 	return stats, nil
 }
 
+// CheckReady checks whether the proxy is healthy, its session is registered in etcd,
+// and the coordinators it forwards the requests to are healthy
+func (node *Proxy) CheckReady(ctx context.Context) error {
+	if err := healthz.CheckComponentReady(ctx, node, node.session); err != nil {
+		return err
+	}
+	coords := []struct {
+		role      string
+		component healthz.StateComponent
+	}{
+		{typeutil.RootCoordRole, node.rootCoord},
+		{typeutil.DataCoordRole, node.dataCoord},
+		{typeutil.QueryCoordRole, node.queryCoord},
+		{typeutil.IndexCoordRole, node.indexCoord},
+	}
+	for _, coord := range coords {
+		if coord.component == nil {
+			return fmt.Errorf("client of %s is not initialized", coord.role)
+		}
+		if err := healthz.CheckComponentState(ctx, coord.component); err != nil {
+			return fmt.Errorf("%s is not healthy: %w", coord.role, err)
+		}
+	}
+	return nil
+}
+
 func (node *Proxy) GetStatisticsChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
 	return &milvuspb.StringResponse{
 		Status: &commonpb.Status{
@@ -82,16 +110,18 @@ func (node *Proxy) InvalidateCollectionMetaCache(ctx context.Context, request *p
 	log.Debug("InvalidateCollectionMetaCache",
 		zap.String("role", Params.RoleName),
 		zap.String("db", request.DbName),
-		zap.String("collection", request.CollectionName))
+		zap.String("collection", request.CollectionName),
+		zap.String("metaType", request.MetaType.String()))
 
 	collectionName := request.CollectionName
 	if globalMetaCache != nil {
-		globalMetaCache.RemoveCollection(ctx, collectionName) // no need to return error, though collection may be not cached
+		globalMetaCache.InvalidateCollectionMeta(ctx, collectionName, request.MetaType) // no need to return error, though collection may be not cached
 	}
 	log.Debug("InvalidateCollectionMetaCache Done",
 		zap.String("role", Params.RoleName),
 		zap.String("db", request.DbName),
-		zap.String("collection", request.CollectionName))
+		zap.String("collection", request.CollectionName),
+		zap.String("metaType", request.MetaType.String()))
 
 	return &commonpb.Status{
 		ErrorCode: commonpb.ErrorCode_Success,
@@ -122,6 +152,162 @@ func (node *Proxy) ReleaseDQLMessageStream(ctx context.Context, request *proxypb
 	}, nil
 }
 
+// SetRateLimit adjusts the DML and DQL rate limits of every collection at runtime
+func (node *Proxy) SetRateLimit(ctx context.Context, request *proxypb.SetRateLimitRequest) (*commonpb.Status, error) {
+	log.Debug("SetRateLimit",
+		zap.String("role", Params.RoleName),
+		zap.Any("limits", request.Limits))
+
+	if !node.checkHealthy() {
+		return unhealthyStatus(), nil
+	}
+
+	for _, limit := range request.Limits {
+		if _, ok := proxypb.RateType_name[int32(limit.RateType)]; !ok {
+			return &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_IllegalArgument,
+				Reason:    fmt.Sprintf("unknown rate type %d", limit.RateType),
+			}, nil
+		}
+	}
+	for _, limit := range request.Limits {
+		node.rateLimiter.setLimit(limit.RateType, limit.Limit)
+	}
+
+	log.Debug("SetRateLimit Done",
+		zap.String("role", Params.RoleName),
+		zap.Any("limits", request.Limits))
+
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+		Reason:    "",
+	}, nil
+}
+
+// UpdateConfig updates the whitelisted configs at runtime, either all or none of the configs take effect
+func (node *Proxy) UpdateConfig(ctx context.Context, request *proxypb.UpdateConfigRequest) (*proxypb.UpdateConfigResponse, error) {
+	log.Debug("UpdateConfig",
+		zap.String("role", Params.RoleName),
+		zap.Any("configs", request.Configs))
+
+	if !node.checkHealthy() {
+		return &proxypb.UpdateConfigResponse{
+			Status: unhealthyStatus(),
+		}, nil
+	}
+
+	configs, err := RepeatedKeyValToMap(request.Configs)
+	if err == nil {
+		configs, err = node.configUpdater.Update(configs)
+	}
+	if err != nil {
+		log.Warn("UpdateConfig failed",
+			zap.String("role", Params.RoleName),
+			zap.Error(err))
+		return &proxypb.UpdateConfigResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_IllegalArgument,
+				Reason:    err.Error(),
+			},
+		}, nil
+	}
+
+	log.Debug("UpdateConfig Done",
+		zap.String("role", Params.RoleName),
+		zap.Any("configs", configs))
+
+	effective := make([]*commonpb.KeyValuePair, 0, len(configs))
+	for _, kv := range request.Configs {
+		effective = append(effective, &commonpb.KeyValuePair{Key: kv.Key, Value: configs[kv.Key]})
+	}
+	return &proxypb.UpdateConfigResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+			Reason:    "",
+		},
+		Configs: effective,
+	}, nil
+}
+
+// SetLogLevel sets the log level of proxy, then of all the coordinators if the request is valid
+func (node *Proxy) SetLogLevel(ctx context.Context, request *proxypb.SetLogLevelRequest) (*proxypb.SetLogLevelResponse, error) {
+	log.Debug("SetLogLevel",
+		zap.String("role", Params.RoleName),
+		zap.String("logger", request.Logger),
+		zap.String("level", request.Level))
+
+	if !node.checkHealthy() {
+		return &proxypb.SetLogLevelResponse{
+			Status: unhealthyStatus(),
+		}, nil
+	}
+
+	req, err := metricsinfo.ConstructSetLogLevelRequest(request.Logger, request.Level)
+	if err != nil {
+		return &proxypb.SetLogLevelResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    err.Error(),
+			},
+		}, nil
+	}
+
+	self := metricsinfo.SetLogLevel(req, metricsinfo.ConstructComponentName(typeutil.ProxyRole, Params.ProxyID))
+	if self.HasError {
+		log.Warn("SetLogLevel failed",
+			zap.String("role", Params.RoleName),
+			zap.String("reason", self.ErrorReason))
+		return &proxypb.SetLogLevelResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_IllegalArgument,
+				Reason:    self.ErrorReason,
+			},
+		}, nil
+	}
+
+	levels := []metricsinfo.ComponentLogLevels{self}
+	var coords []func(context.Context, *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
+	if node.rootCoord != nil {
+		coords = append(coords, node.rootCoord.GetMetrics)
+	}
+	if node.dataCoord != nil {
+		coords = append(coords, node.dataCoord.GetMetrics)
+	}
+	if node.queryCoord != nil {
+		coords = append(coords, node.queryCoord.GetMetrics)
+	}
+	if node.indexCoord != nil {
+		coords = append(coords, node.indexCoord.GetMetrics)
+	}
+	for _, getMetrics := range coords {
+		levels = append(levels, metricsinfo.CollectLogLevels(getMetrics(ctx, req))...)
+	}
+
+	resp, err := metricsinfo.MarshalLogLevels(levels)
+	status := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+		Reason:    "",
+	}
+	if err != nil {
+		log.Warn("SetLogLevel failed on some components",
+			zap.String("role", Params.RoleName),
+			zap.Error(err))
+		status = &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    err.Error(),
+		}
+	}
+
+	log.Debug("SetLogLevel Done",
+		zap.String("role", Params.RoleName),
+		zap.String("levels", resp))
+
+	return &proxypb.SetLogLevelResponse{
+		Status:   status,
+		Response: resp,
+	}, nil
+}
+
 func (node *Proxy) CreateCollection(ctx context.Context, request *milvuspb.CreateCollectionRequest) (*commonpb.Status, error) {
 	if !node.checkHealthy() {
 		return unhealthyStatus(), nil
@@ -1125,7 +1311,6 @@ func (node *Proxy) GetIndexBuildProgress(ctx context.Context, request *milvuspb.
 		GetIndexBuildProgressRequest: request,
 		indexCoord:                   node.indexCoord,
 		rootCoord:                    node.rootCoord,
-		dataCoord:                    node.dataCoord,
 	}
 
 	log.Debug("GetIndexBuildProgress enqueue",
@@ -1248,6 +1433,13 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 			Status: unhealthyStatus(),
 		}, nil
 	}
+	if status := node.checkRateLimit(ctx, request.CollectionName,
+		rateCost{rateType: proxypb.RateType_DMLRows, cost: float64(request.NumRows)},
+		rateCost{rateType: proxypb.RateType_DMLBytes, cost: float64(proto.Size(request))}); status != nil {
+		return &milvuspb.MutationResult{
+			Status: status,
+		}, nil
+	}
 	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "Proxy-Insert")
 	defer sp.Finish()
 	it := &insertTask{
@@ -1332,6 +1524,10 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	err = it.WaitToFinish()
 	if err != nil {
 		result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		if errors.Is(err, errDataNodeMemoryHigh) {
+			// the client may retry after the buffers of DataNode drain
+			result.Status.ErrorCode = commonpb.ErrorCode_RateLimit
+		}
 		result.Status.Reason = err.Error()
 		numRows := it.req.NumRows
 		errIndex := make([]uint32, numRows)
@@ -1350,6 +1546,7 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 		it.result.ErrIndex = errIndex
 	}
 	it.result.InsertCnt = int64(it.req.NumRows)
+	node.sessionTsTracker.update(getClientSession(ctx), it.EndTs())
 	return it.result, nil
 }
 
@@ -1366,43 +1563,17 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 		}, nil
 	}
 
-	deleteReq := &milvuspb.DeleteRequest{
-		DbName:         request.DbName,
-		CollectionName: request.CollectionName,
-		PartitionName:  request.PartitionName,
-		Expr:           request.Expr,
-	}
-
-	dt := &deleteTask{
-		ctx:       ctx,
-		Condition: NewTaskCondition(ctx),
-		req:       deleteReq,
-		BaseDeleteTask: BaseDeleteTask{
-			BaseMsg: msgstream.BaseMsg{},
-			DeleteRequest: internalpb.DeleteRequest{
-				Base: &commonpb.MsgBase{
-					MsgType: commonpb.MsgType_Delete,
-					MsgID:   0,
-				},
-				CollectionName: request.CollectionName,
-				PartitionName:  request.PartitionName,
-				// RowData: transfer column based request to this
-			},
-		},
-		chMgr:    node.chMgr,
-		chTicker: node.chTicker,
+	if status := node.checkRateLimit(ctx, request.CollectionName,
+		rateCost{rateType: proxypb.RateType_DMLRows, cost: float64(node.getDeleteRowNum(ctx, request))},
+		rateCost{rateType: proxypb.RateType_DMLBytes, cost: float64(proto.Size(request))}); status != nil {
+		return &milvuspb.MutationResult{
+			Status: status,
+		}, nil
 	}
 
-	log.Debug("Delete request enqueue",
-		zap.String("role", Params.RoleName),
-		zap.String("db", request.DbName),
-		zap.String("collection", request.CollectionName),
-		zap.String("partition", request.PartitionName),
-		zap.String("expr", request.Expr))
-
-	// MsgID will be set by Enqueue()
-	if err := node.sched.dmQueue.Enqueue(dt); err != nil {
-		log.Error("Failed to enqueue delete task: "+err.Error(), zap.String("traceID", traceID))
+	primaryKeys, err := node.getDeletePrimaryKeys(ctx, request)
+	if err != nil {
+		log.Error("Failed to get primary keys to delete: "+err.Error(), zap.String("traceID", traceID))
 		return &milvuspb.MutationResult{
 			Status: &commonpb.Status{
 				ErrorCode: commonpb.ErrorCode_UnexpectedError,
@@ -1411,26 +1582,86 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 		}, nil
 	}
 
-	log.Debug("Delete request detail",
-		zap.String("role", Params.RoleName),
-		zap.Int64("msgID", dt.Base.MsgID),
-		zap.Uint64("timestamp", dt.Base.Timestamp),
-		zap.String("db", request.DbName),
-		zap.String("collection", request.CollectionName),
-		zap.String("partition", request.PartitionName),
-		zap.String("expr", request.Expr))
-
-	if err := dt.WaitToFinish(); err != nil {
-		log.Error("Failed to execute delete task in task scheduler: "+err.Error(), zap.String("traceID", traceID))
-		return &milvuspb.MutationResult{
-			Status: &commonpb.Status{
-				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    err.Error(),
+	result := &milvuspb.MutationResult{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		IDs: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{
+				IntId: &schemapb.LongArray{
+					Data: make([]int64, 0, len(primaryKeys)),
+				},
 			},
-		}, nil
+		},
 	}
+	// the keys are deleted in batches, so that a huge number of keys doesn't produce a huge message
+	for _, batch := range splitPrimaryKeys(primaryKeys, Params.DeleteBatchSize) {
+		dt := &deleteTask{
+			ctx:       ctx,
+			Condition: NewTaskCondition(ctx),
+			req: &milvuspb.DeleteRequest{
+				DbName:         request.DbName,
+				CollectionName: request.CollectionName,
+				PartitionName:  request.PartitionName,
+				Expr:           request.Expr,
+			},
+			BaseDeleteTask: BaseDeleteTask{
+				BaseMsg: msgstream.BaseMsg{},
+				DeleteRequest: internalpb.DeleteRequest{
+					Base: &commonpb.MsgBase{
+						MsgType: commonpb.MsgType_Delete,
+						MsgID:   0,
+					},
+					CollectionName: request.CollectionName,
+					PartitionName:  request.PartitionName,
+					// RowData: transfer column based request to this
+				},
+			},
+			chMgr:       node.chMgr,
+			chTicker:    node.chTicker,
+			primaryKeys: batch,
+		}
 
-	return dt.result, nil
+		log.Debug("Delete request enqueue",
+			zap.String("role", Params.RoleName),
+			zap.String("db", request.DbName),
+			zap.String("collection", request.CollectionName),
+			zap.String("partition", request.PartitionName),
+			zap.String("expr", request.Expr),
+			zap.Int("keys", len(batch)))
+
+		// MsgID will be set by Enqueue()
+		if err := node.sched.dmQueue.Enqueue(dt); err != nil {
+			log.Error("Failed to enqueue delete task: "+err.Error(), zap.String("traceID", traceID))
+			result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+			result.Status.Reason = err.Error()
+			return result, nil
+		}
+
+		log.Debug("Delete request detail",
+			zap.String("role", Params.RoleName),
+			zap.Int64("msgID", dt.Base.MsgID),
+			zap.Uint64("timestamp", dt.Base.Timestamp),
+			zap.String("db", request.DbName),
+			zap.String("collection", request.CollectionName),
+			zap.String("partition", request.PartitionName),
+			zap.String("expr", request.Expr))
+
+		if err := dt.WaitToFinish(); err != nil {
+			log.Error("Failed to execute delete task in task scheduler: "+err.Error(), zap.String("traceID", traceID))
+			// the keys deleted by the previous batches are still reported
+			result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+			result.Status.Reason = err.Error()
+			return result, nil
+		}
+		node.sessionTsTracker.update(getClientSession(ctx), dt.EndTs())
+
+		result.IDs.GetIntId().Data = append(result.IDs.GetIntId().Data, batch...)
+		result.DeleteCnt += dt.result.DeleteCnt
+		result.Timestamp = dt.result.Timestamp
+	}
+
+	return result, nil
 }
 
 func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
@@ -1439,6 +1670,12 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 			Status: unhealthyStatus(),
 		}, nil
 	}
+	if status := node.checkRateLimit(ctx, request.CollectionName,
+		rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1}); status != nil {
+		return &milvuspb.SearchResults{
+			Status: status,
+		}, nil
+	}
 	sp, ctx := trace.StartSpanFromContextWithOperationName(ctx, "Proxy-Search")
 	defer sp.Finish()
 	qt := &searchTask{
@@ -1451,10 +1688,13 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 			},
 			ResultChannelID: strconv.FormatInt(Params.ProxyID, 10),
 		},
-		resultBuf: make(chan []*internalpb.SearchResults),
-		query:     request,
-		chMgr:     node.chMgr,
-		qc:        node.queryCoord,
+		resultBuf:    make(chan []*internalpb.SearchResults),
+		query:        request,
+		chMgr:        node.chMgr,
+		qc:           node.queryCoord,
+		rc:           node.rootCoord,
+		shardClients: node.shardClients,
+		sessionTs:    node.sessionTsTracker.get(getClientSession(ctx)),
 	}
 
 	log.Debug("Search enqueue",
@@ -1583,13 +1823,20 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 			Status: unhealthyStatus(),
 		}, nil
 	}
+	if status := node.checkRateLimit(ctx, request.CollectionName,
+		rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1}); status != nil {
+		return &milvuspb.QueryResults{
+			Status: status,
+		}, nil
+	}
 
 	queryRequest := &milvuspb.QueryRequest{
-		DbName:         request.DbName,
-		CollectionName: request.CollectionName,
-		PartitionNames: request.PartitionNames,
-		Expr:           request.Expr,
-		OutputFields:   request.OutputFields,
+		DbName:           request.DbName,
+		CollectionName:   request.CollectionName,
+		PartitionNames:   request.PartitionNames,
+		Expr:             request.Expr,
+		OutputFields:     request.OutputFields,
+		ConsistencyLevel: request.ConsistencyLevel,
 	}
 
 	qt := &queryTask{
@@ -1606,6 +1853,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		query:     queryRequest,
 		chMgr:     node.chMgr,
 		qc:        node.queryCoord,
+		sessionTs: node.sessionTsTracker.get(getClientSession(ctx)),
 	}
 
 	log.Debug("Query enqueue",
@@ -1793,8 +2041,57 @@ func (node *Proxy) AlterAlias(ctx context.Context, request *milvuspb.AlterAliasR
 	return aat.result, nil
 }
 
+// AlterCollection alters the properties of a collection
+func (node *Proxy) AlterCollection(ctx context.Context, request *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	if !node.checkHealthy() {
+		return unhealthyStatus(), nil
+	}
+	aat := &alterCollectionTask{
+		ctx:                    ctx,
+		Condition:              NewTaskCondition(ctx),
+		AlterCollectionRequest: request,
+		rootCoord:              node.rootCoord,
+	}
+
+	err := node.sched.ddQueue.Enqueue(aat)
+	if err != nil {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    err.Error(),
+		}, nil
+	}
+
+	log.Debug("AlterCollection",
+		zap.String("role", Params.RoleName),
+		zap.Int64("msgID", request.Base.MsgID),
+		zap.Uint64("timestamp", request.Base.Timestamp),
+		zap.String("collection", request.CollectionName))
+	defer func() {
+		log.Debug("AlterCollection Done",
+			zap.Error(err),
+			zap.String("role", Params.RoleName),
+			zap.Int64("msgID", request.Base.MsgID),
+			zap.Uint64("timestamp", request.Base.Timestamp),
+			zap.String("collection", request.CollectionName))
+	}()
+
+	err = aat.WaitToFinish()
+	if err != nil {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    err.Error(),
+		}, nil
+	}
+
+	return aat.result, nil
+}
+
 func (node *Proxy) CalcDistance(ctx context.Context, request *milvuspb.CalcDistanceRequest) (*milvuspb.CalcDistanceResults, error) {
-	param, _ := funcutil.GetAttrByKeyFromRepeatedKV("metric", request.GetParams())
+	// the metric type is specified by "metric", or "metric_type" as search does
+	param, err := funcutil.GetAttrByKeyFromRepeatedKV("metric", request.GetParams())
+	if err != nil {
+		param, _ = funcutil.GetAttrByKeyFromRepeatedKV(MetricTypeKey, request.GetParams())
+	}
 	metric, err := distance.ValidateMetricType(param)
 	if err != nil {
 		return &milvuspb.CalcDistanceResults{
@@ -1858,94 +2155,25 @@ func (node *Proxy) CalcDistance(ctx context.Context, request *milvuspb.CalcDista
 		}, nil
 	}
 
-	// the vectors retrieved are random order, we need re-arrange the vectors by the order of input ids
-	arrangeFunc := func(ids *milvuspb.VectorIDs, retrievedFields []*schemapb.FieldData) (*schemapb.VectorField, error) {
-		var retrievedIds *schemapb.ScalarField
-		var retrievedVectors *schemapb.VectorField
-		for _, fieldData := range retrievedFields {
-			if fieldData.FieldName == ids.FieldName {
-				retrievedVectors = fieldData.GetVectors()
-			}
-			if fieldData.Type == schemapb.DataType_Int64 {
-				retrievedIds = fieldData.GetScalars()
-			}
-		}
-
-		if retrievedIds == nil || retrievedVectors == nil {
-			return nil, errors.New("Failed to fetch vectors")
-		}
-
-		dict := make(map[int64]int)
-		for index, id := range retrievedIds.GetLongData().Data {
-			dict[id] = index
+	// fetchVectors retrieves the vectors referenced by ids, the vectors are re-arranged by the order of ids since
+	// they are retrieved in random order
+	fetchVectors := func(ids *milvuspb.VectorIDs) (*schemapb.VectorField, error) {
+		if err := validateVectorIDs(ids); err != nil {
+			return nil, err
 		}
-
-		inputIds := ids.IdArray.GetIntId().Data
-		if retrievedVectors.GetFloatVector() != nil {
-			floatArr := retrievedVectors.GetFloatVector().Data
-			element := retrievedVectors.GetDim()
-			result := make([]float32, 0, int64(len(inputIds))*element)
-			for _, id := range inputIds {
-				index, ok := dict[id]
-				if !ok {
-					log.Error("id not found in CalcDistance", zap.Int64("id", id))
-					return nil, errors.New("Failed to fetch vectors by id: " + fmt.Sprintln(id))
-				}
-				result = append(result, floatArr[int64(index)*element:int64(index+1)*element]...)
-			}
-
-			return &schemapb.VectorField{
-				Dim: element,
-				Data: &schemapb.VectorField_FloatVector{
-					FloatVector: &schemapb.FloatArray{
-						Data: result,
-					},
-				},
-			}, nil
+		result, err := query(ids)
+		if err != nil {
+			return nil, err
 		}
-
-		if retrievedVectors.GetBinaryVector() != nil {
-			binaryArr := retrievedVectors.GetBinaryVector()
-			element := retrievedVectors.GetDim()
-			if element%8 != 0 {
-				element = element + 8 - element%8
-			}
-
-			result := make([]byte, 0, int64(len(inputIds))*element)
-			for _, id := range inputIds {
-				index, ok := dict[id]
-				if !ok {
-					log.Error("id not found in CalcDistance", zap.Int64("id", id))
-					return nil, errors.New("Failed to fetch vectors by id: " + fmt.Sprintln(id))
-				}
-				result = append(result, binaryArr[int64(index)*element:int64(index+1)*element]...)
-			}
-
-			return &schemapb.VectorField{
-				Dim: element * 8,
-				Data: &schemapb.VectorField_BinaryVector{
-					BinaryVector: result,
-				},
-			}, nil
+		if result.Status.ErrorCode != commonpb.ErrorCode_Success {
+			return nil, errors.New(result.Status.Reason)
 		}
-
-		return nil, errors.New("Failed to fetch vectors")
+		return arrangeVectors(ids.IdArray.GetIntId().GetData(), ids.FieldName, result.FieldsData)
 	}
 
 	vectorsLeft := request.GetOpLeft().GetDataArray()
-	opLeft := request.GetOpLeft().GetIdArray()
-	if opLeft != nil {
-		result, err := query(opLeft)
-		if err != nil {
-			return &milvuspb.CalcDistanceResults{
-				Status: &commonpb.Status{
-					ErrorCode: commonpb.ErrorCode_UnexpectedError,
-					Reason:    err.Error(),
-				},
-			}, nil
-		}
-
-		vectorsLeft, err = arrangeFunc(opLeft, result.FieldsData)
+	if opLeft := request.GetOpLeft().GetIdArray(); opLeft != nil {
+		vectorsLeft, err = fetchVectors(opLeft)
 		if err != nil {
 			return &milvuspb.CalcDistanceResults{
 				Status: &commonpb.Status{
@@ -1966,19 +2194,8 @@ func (node *Proxy) CalcDistance(ctx context.Context, request *milvuspb.CalcDista
 	}
 
 	vectorsRight := request.GetOpRight().GetDataArray()
-	opRight := request.GetOpRight().GetIdArray()
-	if opRight != nil {
-		result, err := query(opRight)
-		if err != nil {
-			return &milvuspb.CalcDistanceResults{
-				Status: &commonpb.Status{
-					ErrorCode: commonpb.ErrorCode_UnexpectedError,
-					Reason:    err.Error(),
-				},
-			}, nil
-		}
-
-		vectorsRight, err = arrangeFunc(opRight, result.FieldsData)
+	if opRight := request.GetOpRight().GetIdArray(); opRight != nil {
+		vectorsRight, err = fetchVectors(opRight)
 		if err != nil {
 			return &milvuspb.CalcDistanceResults{
 				Status: &commonpb.Status{
@@ -1998,91 +2215,16 @@ func (node *Proxy) CalcDistance(ctx context.Context, request *milvuspb.CalcDista
 		}, nil
 	}
 
-	if vectorsLeft.Dim != vectorsRight.Dim {
+	result, err := calcVectorsDistance(vectorsLeft, vectorsRight, metric)
+	if err != nil {
 		return &milvuspb.CalcDistanceResults{
 			Status: &commonpb.Status{
 				ErrorCode: commonpb.ErrorCode_UnexpectedError,
-				Reason:    "Vectors dimension is not equal",
-			},
-		}, nil
-	}
-
-	if vectorsLeft.GetFloatVector() != nil && vectorsRight.GetFloatVector() != nil {
-		distances, err := distance.CalcFloatDistance(vectorsLeft.Dim, vectorsLeft.GetFloatVector().Data, vectorsRight.GetFloatVector().Data, metric)
-		if err != nil {
-			return &milvuspb.CalcDistanceResults{
-				Status: &commonpb.Status{
-					ErrorCode: commonpb.ErrorCode_UnexpectedError,
-					Reason:    err.Error(),
-				},
-			}, nil
-		}
-
-		return &milvuspb.CalcDistanceResults{
-			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success, Reason: ""},
-			Array: &milvuspb.CalcDistanceResults_FloatDist{
-				FloatDist: &schemapb.FloatArray{
-					Data: distances,
-				},
+				Reason:    err.Error(),
 			},
 		}, nil
 	}
-
-	if vectorsLeft.GetBinaryVector() != nil && vectorsRight.GetBinaryVector() != nil {
-		hamming, err := distance.CalcHammingDistance(vectorsLeft.Dim, vectorsLeft.GetBinaryVector(), vectorsRight.GetBinaryVector())
-		if err != nil {
-			return &milvuspb.CalcDistanceResults{
-				Status: &commonpb.Status{
-					ErrorCode: commonpb.ErrorCode_UnexpectedError,
-					Reason:    err.Error(),
-				},
-			}, nil
-		}
-
-		if metric == distance.HAMMING {
-			return &milvuspb.CalcDistanceResults{
-				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success, Reason: ""},
-				Array: &milvuspb.CalcDistanceResults_IntDist{
-					IntDist: &schemapb.IntArray{
-						Data: hamming,
-					},
-				},
-			}, nil
-		}
-
-		if metric == distance.TANIMOTO {
-			tanimoto, err := distance.CalcTanimotoCoefficient(vectorsLeft.Dim, hamming)
-			if err != nil {
-				return &milvuspb.CalcDistanceResults{
-					Status: &commonpb.Status{
-						ErrorCode: commonpb.ErrorCode_UnexpectedError,
-						Reason:    err.Error(),
-					},
-				}, nil
-			}
-
-			return &milvuspb.CalcDistanceResults{
-				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success, Reason: ""},
-				Array: &milvuspb.CalcDistanceResults_FloatDist{
-					FloatDist: &schemapb.FloatArray{
-						Data: tanimoto,
-					},
-				},
-			}, nil
-		}
-	}
-
-	err = errors.New("Unexpected error")
-	if (vectorsLeft.GetBinaryVector() != nil && vectorsRight.GetFloatVector() != nil) || (vectorsLeft.GetFloatVector() != nil && vectorsRight.GetBinaryVector() != nil) {
-		err = errors.New("Cannot calculate distance between binary vectors and float vectors")
-	}
-
-	return &milvuspb.CalcDistanceResults{
-		Status: &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-			Reason:    err.Error(),
-		},
-	}, nil
+	return result, nil
 }
 
 func (node *Proxy) GetDdChannel(ctx context.Context, request *internalpb.GetDdChannelRequest) (*milvuspb.StringResponse, error) {
@@ -2206,6 +2348,141 @@ func (node *Proxy) GetQuerySegmentInfo(ctx context.Context, req *milvuspb.GetQue
 	return resp, nil
 }
 
+// LoadBalance moves the sealed segments off the source query node, it returns after query coord finishes the balance
+func (node *Proxy) LoadBalance(ctx context.Context, req *milvuspb.LoadBalanceRequest) (*commonpb.Status, error) {
+	log.Debug("LoadBalance",
+		zap.String("role", Params.RoleName),
+		zap.Int64("srcNodeID", req.GetSrcNodeID()),
+		zap.Int64s("dstNodeIDs", req.GetDstNodeIDs()),
+		zap.Int64s("sealedSegmentIDs", req.GetSealedSegmentIDs()))
+
+	if !node.checkHealthy() {
+		return unhealthyStatus(), nil
+	}
+	status := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_UnexpectedError,
+	}
+	resp, err := node.queryCoord.LoadBalance(ctx, &querypb.LoadBalanceRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_LoadBalanceSegments,
+			MsgID:     0,
+			Timestamp: 0,
+			SourceID:  Params.ProxyID,
+		},
+		SourceNodeIDs:    []int64{req.GetSrcNodeID()},
+		DstNodeIDs:       req.GetDstNodeIDs(),
+		BalanceReason:    querypb.TriggerCondition_grpcRequest,
+		SealedSegmentIDs: req.GetSealedSegmentIDs(),
+	})
+	if err != nil {
+		log.Error("Failed to LoadBalance from Query Coordinator",
+			zap.Any("req", req), zap.Error(err))
+		status.Reason = err.Error()
+		return status, nil
+	}
+	if resp.ErrorCode != commonpb.ErrorCode_Success {
+		log.Error("Failed to LoadBalance", zap.String("errMsg", resp.Reason))
+		return resp, nil
+	}
+	log.Debug("LoadBalance Done", zap.Any("req", req), zap.Any("status", resp))
+	status.ErrorCode = commonpb.ErrorCode_Success
+	return status, nil
+}
+
+// Import imports the files into a partition of the collection, a row based file is imported by an import task,
+// and the column based files are imported together by an import task
+func (node *Proxy) Import(ctx context.Context, req *milvuspb.ImportRequest) (*milvuspb.ImportResponse, error) {
+	log.Debug("Import",
+		zap.String("role", Params.RoleName),
+		zap.String("collection", req.GetCollectionName()),
+		zap.String("partition", req.GetPartitionName()),
+		zap.Bool("rowBased", req.GetRowBased()),
+		zap.Strings("files", req.GetFiles()))
+
+	resp := &milvuspb.ImportResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if !node.checkHealthy() {
+		resp.Status = unhealthyStatus()
+		return resp, nil
+	}
+	if len(req.GetFiles()) == 0 {
+		resp.Status.Reason = "no file to import"
+		return resp, nil
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, req.GetCollectionName())
+	if err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+	partitionName := req.GetPartitionName()
+	if partitionName == "" {
+		partitionName = Params.DefaultPartitionName
+	}
+	partitionID, err := globalMetaCache.GetPartitionID(ctx, req.GetCollectionName(), partitionName)
+	if err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+
+	fileGroups := [][]string{req.GetFiles()}
+	if req.GetRowBased() {
+		fileGroups = make([][]string, 0, len(req.GetFiles()))
+		for _, file := range req.GetFiles() {
+			fileGroups = append(fileGroups, []string{file})
+		}
+	}
+	for _, files := range fileGroups {
+		taskResp, err := node.dataCoord.Import(ctx, &datapb.ImportTaskRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:  commonpb.MsgType_Import,
+				SourceID: Params.ProxyID,
+			},
+			CollectionID: collectionID,
+			PartitionID:  partitionID,
+			RowBased:     req.GetRowBased(),
+			Files:        files,
+			Options:      req.GetOptions(),
+		})
+		if err != nil {
+			resp.Status.Reason = fmt.Errorf("dataCoord:Import, err:%w", err).Error()
+			return resp, nil
+		}
+		if taskResp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			resp.Status.Reason = taskResp.GetStatus().GetReason()
+			return resp, nil
+		}
+		resp.Tasks = append(resp.Tasks, taskResp.GetTaskID())
+	}
+	resp.Status.ErrorCode = commonpb.ErrorCode_Success
+	return resp, nil
+}
+
+// GetImportState gets the state of an import task
+func (node *Proxy) GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error) {
+	log.Debug("GetImportState",
+		zap.String("role", Params.RoleName),
+		zap.Int64("task", req.GetTask()))
+
+	if !node.checkHealthy() {
+		return &milvuspb.GetImportStateResponse{
+			Status: unhealthyStatus(),
+		}, nil
+	}
+	resp, err := node.dataCoord.GetImportState(ctx, req)
+	if err != nil {
+		return &milvuspb.GetImportStateResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    fmt.Errorf("dataCoord:GetImportState, err:%w", err).Error(),
+			},
+		}, nil
+	}
+	return resp, nil
+}
+
 func (node *Proxy) getSegmentsOfCollection(ctx context.Context, dbName string, collectionName string) ([]UniqueID, error) {
 	describeCollectionResponse, err := node.rootCoord.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
 		Base: &commonpb.MsgBase{
@@ -2392,6 +2669,44 @@ func (node *Proxy) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsReque
 		return metrics, err
 	}
 
+	if metricType == metricsinfo.SegmentStatisticsMetrics {
+		metrics, err := getSegmentStatisticsMetrics(ctx, req, node)
+
+		log.Debug("Proxy.GetMetrics",
+			zap.Int64("node_id", Params.ProxyID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Error(err))
+
+		return metrics, err
+	}
+
+	if metricType == metricsinfo.UpdateConfigMetrics {
+		metrics := node.configUpdater.UpdateByMetricsRequest(req,
+			metricsinfo.ConstructComponentName(typeutil.ProxyRole, Params.ProxyID))
+
+		log.Debug("Proxy.GetMetrics",
+			zap.Int64("node_id", Params.ProxyID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		name := metricsinfo.ConstructComponentName(typeutil.ProxyRole, Params.ProxyID)
+		metrics := metricsinfo.LogLevelsResponse([]metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}, name)
+
+		log.Debug("Proxy.GetMetrics",
+			zap.Int64("node_id", Params.ProxyID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Debug("Proxy.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", Params.ProxyID),
 		zap.String("req", req.Request),
@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// showCollectionsLoadProgress asks querycoord for the in-memory percentage of the given collections.
+// The result holds one entry per requested collection, in request order, and collections which are
+// not loaded at all report 0. Older querycoords reject requests naming collections that are not loaded,
+// in which case the progress is derived from the full list of loaded collections instead.
+func showCollectionsLoadProgress(ctx context.Context, queryCoord types.QueryCoord, base *commonpb.MsgBase, collectionIDs []UniqueID) (*querypb.ShowCollectionsResponse, error) {
+	resp, err := queryCoord.ShowCollections(ctx, &querypb.ShowCollectionsRequest{
+		Base:          base,
+		CollectionIDs: collectionIDs,
+	})
+	if len(collectionIDs) == 0 {
+		return resp, checkShowCollectionsResponse(resp, err)
+	}
+	if checkShowCollectionsResponse(resp, err) == nil && len(resp.InMemoryPercentages) == len(collectionIDs) {
+		return resp, nil
+	}
+
+	log.Debug("failed to show load progress of collections, fall back to loaded collections",
+		zap.Int64s("collectionIDs", collectionIDs), zap.Error(err), zap.Any("status", resp.GetStatus()))
+	loaded, err := queryCoord.ShowCollections(ctx, &querypb.ShowCollectionsRequest{
+		Base: base,
+	})
+	if err = checkShowCollectionsResponse(loaded, err); err != nil {
+		return nil, err
+	}
+	percentages := make(map[UniqueID]int64, len(loaded.CollectionIDs))
+	for offset, id := range loaded.CollectionIDs {
+		if offset < len(loaded.InMemoryPercentages) {
+			percentages[id] = loaded.InMemoryPercentages[offset]
+		}
+	}
+	resp = &querypb.ShowCollectionsResponse{
+		Status:              loaded.Status,
+		CollectionIDs:       collectionIDs,
+		InMemoryPercentages: make([]int64, 0, len(collectionIDs)),
+	}
+	for _, id := range collectionIDs {
+		resp.InMemoryPercentages = append(resp.InMemoryPercentages, percentages[id])
+	}
+	return resp, nil
+}
+
+// showPartitionsLoadProgress is the partition counterpart of showCollectionsLoadProgress.
+func showPartitionsLoadProgress(ctx context.Context, queryCoord types.QueryCoord, base *commonpb.MsgBase, collectionID UniqueID, partitionIDs []UniqueID) (*querypb.ShowPartitionsResponse, error) {
+	resp, err := queryCoord.ShowPartitions(ctx, &querypb.ShowPartitionsRequest{
+		Base:         base,
+		CollectionID: collectionID,
+		PartitionIDs: partitionIDs,
+	})
+	if len(partitionIDs) == 0 {
+		return resp, checkShowPartitionsResponse(resp, err)
+	}
+	if checkShowPartitionsResponse(resp, err) == nil && len(resp.InMemoryPercentages) == len(partitionIDs) {
+		return resp, nil
+	}
+
+	log.Debug("failed to show load progress of partitions, fall back to loaded partitions",
+		zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", partitionIDs),
+		zap.Error(err), zap.Any("status", resp.GetStatus()))
+	loaded, err := queryCoord.ShowPartitions(ctx, &querypb.ShowPartitionsRequest{
+		Base:         base,
+		CollectionID: collectionID,
+	})
+	percentages := make(map[UniqueID]int64)
+	if loadedErr := checkShowPartitionsResponse(loaded, err); loadedErr == nil {
+		for offset, id := range loaded.PartitionIDs {
+			if offset < len(loaded.InMemoryPercentages) {
+				percentages[id] = loaded.InMemoryPercentages[offset]
+			}
+		}
+	} else {
+		// older querycoords also fail to show partitions of a collection which is not loaded
+		collections, err := showCollectionsLoadProgress(ctx, queryCoord, base, []UniqueID{collectionID})
+		if err != nil {
+			return nil, err
+		}
+		if collections.InMemoryPercentages[0] != 0 {
+			return nil, loadedErr
+		}
+	}
+	resp = &querypb.ShowPartitionsResponse{
+		Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		PartitionIDs:        partitionIDs,
+		InMemoryPercentages: make([]int64, 0, len(partitionIDs)),
+	}
+	for _, id := range partitionIDs {
+		resp.InMemoryPercentages = append(resp.InMemoryPercentages, percentages[id])
+	}
+	return resp, nil
+}
+
+func checkShowCollectionsResponse(resp *querypb.ShowCollectionsResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("failed to show collections")
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return errors.New(resp.Status.Reason)
+	}
+	return nil
+}
+
+func checkShowPartitionsResponse(resp *querypb.ShowPartitionsResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("failed to show partitions")
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return errors.New(resp.Status.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func newLoadProgressQueryCoord() *QueryCoordMock {
+	qc := NewQueryCoordMock()
+	qc.updateState(internalpb.StateCode_Healthy)
+	return qc
+}
+
+func TestShowCollectionsLoadProgress(t *testing.T) {
+	ctx := context.Background()
+	base := &commonpb.MsgBase{MsgType: commonpb.MsgType_ShowCollections}
+
+	t.Run("mid load", func(t *testing.T) {
+		qc := newLoadProgressQueryCoord()
+		qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+			assert.Equal(t, []int64{1, 2}, req.CollectionIDs)
+			return &querypb.ShowCollectionsResponse{
+				Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				CollectionIDs:       []int64{1, 2},
+				InMemoryPercentages: []int64{50, 0},
+			}, nil
+		})
+		resp, err := showCollectionsLoadProgress(ctx, qc, base, []UniqueID{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, resp.CollectionIDs)
+		assert.Equal(t, []int64{50, 0}, resp.InMemoryPercentages)
+	})
+
+	t.Run("fall back to loaded collections", func(t *testing.T) {
+		qc := newLoadProgressQueryCoord()
+		qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+			if len(req.CollectionIDs) > 0 {
+				return &querypb.ShowCollectionsResponse{
+					Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "collection not loaded"},
+				}, nil
+			}
+			return &querypb.ShowCollectionsResponse{
+				Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				CollectionIDs:       []int64{1},
+				InMemoryPercentages: []int64{50},
+			}, nil
+		})
+		resp, err := showCollectionsLoadProgress(ctx, qc, base, []UniqueID{2, 1})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2, 1}, resp.CollectionIDs)
+		assert.Equal(t, []int64{0, 50}, resp.InMemoryPercentages)
+	})
+
+	t.Run("querycoord unavailable", func(t *testing.T) {
+		qc := newLoadProgressQueryCoord()
+		qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+			return nil, errors.New("mock")
+		})
+		_, err := showCollectionsLoadProgress(ctx, qc, base, []UniqueID{1})
+		assert.Error(t, err)
+		_, err = showCollectionsLoadProgress(ctx, qc, base, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestShowPartitionsLoadProgress(t *testing.T) {
+	ctx := context.Background()
+	base := &commonpb.MsgBase{MsgType: commonpb.MsgType_ShowPartitions}
+
+	t.Run("mid load", func(t *testing.T) {
+		qc := newLoadProgressQueryCoord()
+		qc.SetShowPartitionsFunc(func(ctx context.Context, req *querypb.ShowPartitionsRequest) (*querypb.ShowPartitionsResponse, error) {
+			return &querypb.ShowPartitionsResponse{
+				Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				PartitionIDs:        req.PartitionIDs,
+				InMemoryPercentages: []int64{50},
+			}, nil
+		})
+		resp, err := showPartitionsLoadProgress(ctx, qc, base, 1, []UniqueID{10})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{50}, resp.InMemoryPercentages)
+	})
+
+	t.Run("fall back to loaded partitions", func(t *testing.T) {
+		qc := newLoadProgressQueryCoord()
+		qc.SetShowPartitionsFunc(func(ctx context.Context, req *querypb.ShowPartitionsRequest) (*querypb.ShowPartitionsResponse, error) {
+			if len(req.PartitionIDs) > 0 {
+				return &querypb.ShowPartitionsResponse{
+					Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "partition not loaded"},
+				}, nil
+			}
+			return &querypb.ShowPartitionsResponse{
+				Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				PartitionIDs:        []int64{10},
+				InMemoryPercentages: []int64{50},
+			}, nil
+		})
+		resp, err := showPartitionsLoadProgress(ctx, qc, base, 1, []UniqueID{10, 11})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10, 11}, resp.PartitionIDs)
+		assert.Equal(t, []int64{50, 0}, resp.InMemoryPercentages)
+	})
+
+	t.Run("collection not loaded", func(t *testing.T) {
+		qc := newLoadProgressQueryCoord()
+		qc.SetShowPartitionsFunc(func(ctx context.Context, req *querypb.ShowPartitionsRequest) (*querypb.ShowPartitionsResponse, error) {
+			return &querypb.ShowPartitionsResponse{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "collection not loaded"},
+			}, nil
+		})
+		resp, err := showPartitionsLoadProgress(ctx, qc, base, 1, []UniqueID{10})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{0}, resp.InMemoryPercentages)
+
+		qc.SetShowCollectionsFunc(func(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
+			return &querypb.ShowCollectionsResponse{
+				Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				CollectionIDs:       []int64{1},
+				InMemoryPercentages: []int64{100},
+			}, nil
+		})
+		_, err = showPartitionsLoadProgress(ctx, qc, base, 1, []UniqueID{10})
+		assert.Error(t, err)
+	})
+}
@@ -23,8 +23,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
@@ -39,16 +41,31 @@ type Cache interface {
 	GetPartitions(ctx context.Context, collectionName string) (map[string]typeutil.UniqueID, error)
 	GetPartitionInfo(ctx context.Context, collectionName string, partitionName string) (*partitionInfo, error)
 	GetCollectionSchema(ctx context.Context, collectionName string) (*schemapb.CollectionSchema, error)
+	// GetShards get the shards of collection, which map the virtual channels to the physical channels.
+	GetShards(ctx context.Context, collectionName string) (map[vChan]pChan, error)
 	RemoveCollection(ctx context.Context, collectionName string)
 	RemovePartition(ctx context.Context, collectionName string, partitionName string)
+	// InvalidateCollectionMeta removes the specific kind of meta of collection from cache.
+	InvalidateCollectionMeta(ctx context.Context, collectionName string, metaType proxypb.CollectionMetaType)
+	// RefreshExpiredShards refetches the shards cached longer than ttl.
+	RefreshExpiredShards(ctx context.Context, ttl time.Duration)
 }
 
 type collectionInfo struct {
 	collID              typeutil.UniqueID
 	schema              *schemapb.CollectionSchema
 	partInfo            map[string]*partitionInfo
+	shards              map[vChan]pChan
+	shardsUpdateTime    time.Time
 	createdTimestamp    uint64
 	createdUtcTimestamp uint64
+	properties          common.CollectionProperties
+
+	// the versions are increased on every invalidation of the meta,
+	// the meta fetched from rootcoord is not cached if the version changes during fetching
+	schemaVersion     uint64
+	shardsVersion     uint64
+	partitionsVersion uint64
 }
 
 type partitionInfo struct {
@@ -57,11 +74,67 @@ type partitionInfo struct {
 	createdUtcTimestamp uint64
 }
 
+const (
+	metaCacheSchemaLabel     = "schema"
+	metaCacheShardsLabel     = "shards"
+	metaCachePartitionsLabel = "partitions"
+
+	metaCacheHitLabel     = "hit"
+	metaCacheMissLabel    = "miss"
+	metaCacheRefreshLabel = "refresh"
+)
+
+// metaCall is an in-flight request to rootcoord, whose result is shared by the concurrent misses of the same collection
+type metaCall struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+// metaCallGroup deduplicates the concurrent requests with the same key
+type metaCallGroup struct {
+	mu    sync.Mutex
+	calls map[string]*metaCall
+}
+
+// do executes fn once for the concurrent callers with the same key, and returns the result to all of them
+func (g *metaCallGroup) do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+	call := &metaCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	return call.val, call.err
+}
+
+// forget makes the later callers with key issue a new request rather than wait for the in-flight one
+func (g *metaCallGroup) forget(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
+
 type MetaCache struct {
 	client types.RootCoord
 
 	collInfo map[string]*collectionInfo
 	mu       sync.RWMutex
+
+	calls metaCallGroup
 }
 
 var globalMetaCache Cache
@@ -79,149 +152,287 @@ func NewMetaCache(client types.RootCoord) (*MetaCache, error) {
 	return &MetaCache{
 		client:   client,
 		collInfo: map[string]*collectionInfo{},
+		calls: metaCallGroup{
+			calls: map[string]*metaCall{},
+		},
 	}, nil
 }
 
-func (m *MetaCache) GetCollectionID(ctx context.Context, collectionName string) (typeutil.UniqueID, error) {
-	m.mu.RLock()
-	collInfo, ok := m.collInfo[collectionName]
-
-	if !ok {
-		m.mu.RUnlock()
-		coll, err := m.describeCollection(ctx, collectionName)
-		if err != nil {
-			return 0, err
-		}
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		m.updateCollection(coll, collectionName)
-		collInfo = m.collInfo[collectionName]
-		return collInfo.collID, nil
-	}
-	defer m.mu.RUnlock()
+func describeCollectionKey(collectionName string) string {
+	return "describe-" + collectionName
+}
 
-	return collInfo.collID, nil
+func showPartitionsKey(collectionName string) string {
+	return "partitions-" + collectionName
 }
 
-func (m *MetaCache) GetCollectionInfo(ctx context.Context, collectionName string) (*collectionInfo, error) {
-	m.mu.RLock()
-	var collInfo *collectionInfo
+// getOrCreateCollectionInfo must be called with the write lock held
+func (m *MetaCache) getOrCreateCollectionInfo(collectionName string) *collectionInfo {
 	collInfo, ok := m.collInfo[collectionName]
-	m.mu.RUnlock()
-
 	if !ok {
-		coll, err := m.describeCollection(ctx, collectionName)
-		if err != nil {
-			return nil, err
-		}
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		m.updateCollection(coll, collectionName)
-		collInfo = m.collInfo[collectionName]
+		collInfo = &collectionInfo{}
+		m.collInfo[collectionName] = collInfo
 	}
+	return collInfo
+}
 
-	return &collectionInfo{
-		collID:              collInfo.collID,
-		schema:              collInfo.schema,
-		partInfo:            collInfo.partInfo,
-		createdTimestamp:    collInfo.createdTimestamp,
-		createdUtcTimestamp: collInfo.createdUtcTimestamp,
-	}, nil
+// removeEmptyCollectionInfo removes collInfo created for fetching if nothing is cached in it,
+// it must be called with the write lock held
+func (m *MetaCache) removeEmptyCollectionInfo(collectionName string, collInfo *collectionInfo) {
+	if m.collInfo[collectionName] == collInfo && collInfo.schema == nil && collInfo.shards == nil && collInfo.partInfo == nil {
+		delete(m.collInfo, collectionName)
+	}
 }
 
-func (m *MetaCache) GetCollectionSchema(ctx context.Context, collectionName string) (*schemapb.CollectionSchema, error) {
-	m.mu.RLock()
-	collInfo, ok := m.collInfo[collectionName]
+// loadCollection describes collection from rootcoord, and caches the schema and the shards unless they are
+// invalidated during describing. The concurrent loads of the same collection share one DescribeCollection.
+func (m *MetaCache) loadCollection(ctx context.Context, collectionName string) (*milvuspb.DescribeCollectionResponse, error) {
+	val, err := m.calls.do(describeCollectionKey(collectionName), func() (interface{}, error) {
+		m.mu.Lock()
+		collInfo := m.getOrCreateCollectionInfo(collectionName)
+		schemaVersion, shardsVersion := collInfo.schemaVersion, collInfo.shardsVersion
+		m.mu.Unlock()
 
-	if !ok {
 		t0 := time.Now()
-		m.mu.RUnlock()
 		coll, err := m.describeCollection(ctx, collectionName)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
 		if err != nil {
+			m.removeEmptyCollectionInfo(collectionName, collInfo)
 			log.Warn("Failed to load collection from rootcoord ",
 				zap.String("collection name ", collectionName),
 				zap.Error(err))
 			return nil, err
 		}
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		m.updateCollection(coll, collectionName)
-		collInfo = m.collInfo[collectionName]
+		// the collection info is replaced if the collection is removed during describing
+		if m.collInfo[collectionName] == collInfo {
+			if collInfo.schemaVersion == schemaVersion {
+				m.updateCollection(coll, collectionName)
+			}
+			if collInfo.shardsVersion == shardsVersion {
+				m.updateShards(coll, collectionName)
+			}
+		}
 		log.Debug("Reload collection from rootcoord ",
 			zap.String("collection name ", collectionName),
 			zap.Any("time take ", time.Since(t0)))
-		return collInfo.schema, nil
+		return coll, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	defer m.mu.RUnlock()
-
-	return collInfo.schema, nil
+	return val.(*milvuspb.DescribeCollectionResponse), nil
 }
 
-func (m *MetaCache) updateCollection(coll *milvuspb.DescribeCollectionResponse, collectionName string) {
-	_, ok := m.collInfo[collectionName]
-	if !ok {
-		m.collInfo[collectionName] = &collectionInfo{}
+// getCachedCollection returns the cached collection info whose schema is valid
+func (m *MetaCache) getCachedCollection(collectionName string) (*collectionInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	collInfo, ok := m.collInfo[collectionName]
+	if !ok || collInfo.schema == nil {
+		metrics.ProxyMetaCacheCounter.WithLabelValues(metaCacheSchemaLabel, metaCacheMissLabel).Inc()
+		return nil, false
 	}
-	m.collInfo[collectionName].schema = coll.Schema
-	m.collInfo[collectionName].collID = coll.CollectionID
-	m.collInfo[collectionName].createdTimestamp = coll.CreatedTimestamp
-	m.collInfo[collectionName].createdUtcTimestamp = coll.CreatedUtcTimestamp
+	metrics.ProxyMetaCacheCounter.WithLabelValues(metaCacheSchemaLabel, metaCacheHitLabel).Inc()
+	return &collectionInfo{
+		collID:              collInfo.collID,
+		schema:              collInfo.schema,
+		partInfo:            collInfo.partInfo,
+		createdTimestamp:    collInfo.createdTimestamp,
+		createdUtcTimestamp: collInfo.createdUtcTimestamp,
+		properties:          collInfo.properties,
+	}, true
 }
 
-func (m *MetaCache) GetPartitionID(ctx context.Context, collectionName string, partitionName string) (typeutil.UniqueID, error) {
-	partInfo, err := m.GetPartitionInfo(ctx, collectionName, partitionName)
+func (m *MetaCache) GetCollectionID(ctx context.Context, collectionName string) (typeutil.UniqueID, error) {
+	if collInfo, ok := m.getCachedCollection(collectionName); ok {
+		return collInfo.collID, nil
+	}
+	coll, err := m.loadCollection(ctx, collectionName)
 	if err != nil {
 		return 0, err
 	}
-	return partInfo.partitionID, nil
+	return coll.CollectionID, nil
 }
 
-func (m *MetaCache) GetPartitions(ctx context.Context, collectionName string) (map[string]typeutil.UniqueID, error) {
-	_, err := m.GetCollectionID(ctx, collectionName)
+func (m *MetaCache) GetCollectionInfo(ctx context.Context, collectionName string) (*collectionInfo, error) {
+	if collInfo, ok := m.getCachedCollection(collectionName); ok {
+		return collInfo, nil
+	}
+	coll, err := m.loadCollection(ctx, collectionName)
 	if err != nil {
 		return nil, err
 	}
 
 	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var partInfo map[string]*partitionInfo
+	if collInfo, ok := m.collInfo[collectionName]; ok {
+		partInfo = collInfo.partInfo
+	}
+	return &collectionInfo{
+		collID:              coll.CollectionID,
+		schema:              coll.Schema,
+		partInfo:            partInfo,
+		createdTimestamp:    coll.CreatedTimestamp,
+		createdUtcTimestamp: coll.CreatedUtcTimestamp,
+		properties:          common.NewCollectionProperties(coll.Properties),
+	}, nil
+}
+
+func (m *MetaCache) GetCollectionSchema(ctx context.Context, collectionName string) (*schemapb.CollectionSchema, error) {
+	if collInfo, ok := m.getCachedCollection(collectionName); ok {
+		return collInfo.schema, nil
+	}
+	coll, err := m.loadCollection(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	return coll.Schema, nil
+}
 
+func (m *MetaCache) GetShards(ctx context.Context, collectionName string) (map[vChan]pChan, error) {
+	m.mu.RLock()
 	collInfo, ok := m.collInfo[collectionName]
-	if !ok {
+	if ok && collInfo.shards != nil {
+		shards := copyShards(collInfo.shards)
 		m.mu.RUnlock()
-		return nil, fmt.Errorf("can't find collection name:%s", collectionName)
+		metrics.ProxyMetaCacheCounter.WithLabelValues(metaCacheShardsLabel, metaCacheHitLabel).Inc()
+		return shards, nil
 	}
+	m.mu.RUnlock()
+	metrics.ProxyMetaCacheCounter.WithLabelValues(metaCacheShardsLabel, metaCacheMissLabel).Inc()
 
-	if collInfo.partInfo == nil || len(collInfo.partInfo) == 0 {
-		m.mu.RUnlock()
+	coll, err := m.loadCollection(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	return getShards(coll)
+}
+
+func (m *MetaCache) updateCollection(coll *milvuspb.DescribeCollectionResponse, collectionName string) {
+	collInfo := m.getOrCreateCollectionInfo(collectionName)
+	collInfo.schema = coll.Schema
+	collInfo.collID = coll.CollectionID
+	collInfo.createdTimestamp = coll.CreatedTimestamp
+	collInfo.createdUtcTimestamp = coll.CreatedUtcTimestamp
+	collInfo.properties = common.NewCollectionProperties(coll.Properties)
+}
+
+func (m *MetaCache) updateShards(coll *milvuspb.DescribeCollectionResponse, collectionName string) {
+	shards, err := getShards(coll)
+	if err != nil {
+		log.Warn("Failed to update shards of collection",
+			zap.String("collection name", collectionName),
+			zap.Error(err))
+		return
+	}
+	collInfo := m.getOrCreateCollectionInfo(collectionName)
+	collInfo.shards = shards
+	collInfo.shardsUpdateTime = time.Now()
+}
+
+// getShards returns the map from the virtual channels to the physical channels of the described collection
+func getShards(coll *milvuspb.DescribeCollectionResponse) (map[vChan]pChan, error) {
+	if len(coll.VirtualChannelNames) != len(coll.PhysicalChannelNames) {
+		return nil, fmt.Errorf("len(VirtualChannelNames): %v, len(PhysicalChannelNames): %v",
+			len(coll.VirtualChannelNames), len(coll.PhysicalChannelNames))
+	}
+	shards := make(map[vChan]pChan, len(coll.VirtualChannelNames))
+	for i, vchan := range coll.VirtualChannelNames {
+		shards[vchan] = coll.PhysicalChannelNames[i]
+	}
+	return shards, nil
+}
+
+func copyShards(shards map[vChan]pChan) map[vChan]pChan {
+	ret := make(map[vChan]pChan, len(shards))
+	for vchan, pchan := range shards {
+		ret[vchan] = pchan
+	}
+	return ret
+}
+
+// loadPartitions shows the partitions of collection from rootcoord, and caches them unless they are
+// invalidated during showing. The concurrent loads of the same collection share one ShowPartitions.
+func (m *MetaCache) loadPartitions(ctx context.Context, collectionName string) (map[string]*partitionInfo, error) {
+	val, err := m.calls.do(showPartitionsKey(collectionName), func() (interface{}, error) {
+		m.mu.Lock()
+		collInfo := m.getOrCreateCollectionInfo(collectionName)
+		partitionsVersion := collInfo.partitionsVersion
+		m.mu.Unlock()
 
 		partitions, err := m.showPartitions(ctx, collectionName)
-		if err != nil {
-			return nil, err
+		if err == nil {
+			err = checkPartitions(partitions)
 		}
 
 		m.mu.Lock()
 		defer m.mu.Unlock()
-
-		err = m.updatePartitions(partitions, collectionName)
 		if err != nil {
+			m.removeEmptyCollectionInfo(collectionName, collInfo)
 			return nil, err
 		}
-		log.Debug("proxy", zap.Any("GetPartitions:partitions after update", partitions), zap.Any("collectionName", collectionName))
-		ret := make(map[string]typeutil.UniqueID)
-		partInfo := m.collInfo[collectionName].partInfo
-		for k, v := range partInfo {
-			ret[k] = v.partitionID
+		partInfo := make(map[string]*partitionInfo, len(partitions.PartitionIDs))
+		for i := 0; i < len(partitions.PartitionIDs); i++ {
+			partInfo[partitions.PartitionNames[i]] = &partitionInfo{
+				partitionID:         partitions.PartitionIDs[i],
+				createdTimestamp:    partitions.CreatedTimestamps[i],
+				createdUtcTimestamp: partitions.CreatedUtcTimestamps[i],
+			}
+		}
+		if m.collInfo[collectionName] == collInfo && collInfo.partitionsVersion == partitionsVersion {
+			collInfo.partInfo = partInfo
 		}
-		return ret, nil
-
+		log.Debug("proxy", zap.Any("partitions after update", partitions), zap.Any("collectionName", collectionName))
+		return partInfo, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return val.(map[string]*partitionInfo), nil
+}
+
+// getCachedPartitions returns the cached partitions of collection, nil is returned if they are not cached
+func (m *MetaCache) getCachedPartitions(collectionName string) map[string]*partitionInfo {
+	m.mu.RLock()
 	defer m.mu.RUnlock()
+	collInfo, ok := m.collInfo[collectionName]
+	if !ok || len(collInfo.partInfo) == 0 {
+		metrics.ProxyMetaCacheCounter.WithLabelValues(metaCachePartitionsLabel, metaCacheMissLabel).Inc()
+		return nil
+	}
+	metrics.ProxyMetaCacheCounter.WithLabelValues(metaCachePartitionsLabel, metaCacheHitLabel).Inc()
+	return collInfo.partInfo
+}
+
+func (m *MetaCache) GetPartitionID(ctx context.Context, collectionName string, partitionName string) (typeutil.UniqueID, error) {
+	partInfo, err := m.GetPartitionInfo(ctx, collectionName, partitionName)
+	if err != nil {
+		return 0, err
+	}
+	return partInfo.partitionID, nil
+}
+
+func (m *MetaCache) GetPartitions(ctx context.Context, collectionName string) (map[string]typeutil.UniqueID, error) {
+	_, err := m.GetCollectionID(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	partInfo := m.getCachedPartitions(collectionName)
+	if partInfo == nil {
+		partInfo, err = m.loadPartitions(ctx, collectionName)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	ret := make(map[string]typeutil.UniqueID)
-	partInfo := m.collInfo[collectionName].partInfo
 	for k, v := range partInfo {
 		ret[k] = v.partitionID
 	}
-
 	return ret, nil
 }
 
@@ -231,33 +442,13 @@ func (m *MetaCache) GetPartitionInfo(ctx context.Context, collectionName string,
 		return nil, err
 	}
 
-	m.mu.RLock()
-
-	collInfo, ok := m.collInfo[collectionName]
-	if !ok {
-		m.mu.RUnlock()
-		return nil, fmt.Errorf("can't find collection name:%s", collectionName)
-	}
-
-	var partInfo *partitionInfo
-	partInfo, ok = collInfo.partInfo[partitionName]
-	m.mu.RUnlock()
-
+	partInfo, ok := m.getCachedPartitions(collectionName)[partitionName]
 	if !ok {
-		partitions, err := m.showPartitions(ctx, collectionName)
-		if err != nil {
-			return nil, err
-		}
-
-		m.mu.Lock()
-		defer m.mu.Unlock()
-		err = m.updatePartitions(partitions, collectionName)
+		partitions, err := m.loadPartitions(ctx, collectionName)
 		if err != nil {
 			return nil, err
 		}
-		log.Debug("proxy", zap.Any("GetPartitionID:partitions after update", partitions), zap.Any("collectionName", collectionName))
-
-		partInfo, ok = m.collInfo[collectionName].partInfo[partitionName]
+		partInfo, ok = partitions[partitionName]
 		if !ok {
 			return nil, fmt.Errorf("partitionID of partitionName:%s can not be find", partitionName)
 		}
@@ -329,52 +520,87 @@ func (m *MetaCache) showPartitions(ctx context.Context, collectionName string) (
 	return partitions, nil
 }
 
-func (m *MetaCache) updatePartitions(partitions *milvuspb.ShowPartitionsResponse, collectionName string) error {
-	_, ok := m.collInfo[collectionName]
-	if !ok {
-		m.collInfo[collectionName] = &collectionInfo{
-			partInfo: map[string]*partitionInfo{},
-		}
-	}
-	partInfo := m.collInfo[collectionName].partInfo
-	if partInfo == nil {
-		partInfo = map[string]*partitionInfo{}
-	}
-
-	// check partitionID, createdTimestamp and utcstamp has sam element numbers
+// checkPartitions checks that the partition ids, names and timestamps have the same element numbers
+func checkPartitions(partitions *milvuspb.ShowPartitionsResponse) error {
 	if len(partitions.PartitionNames) != len(partitions.CreatedTimestamps) || len(partitions.PartitionNames) != len(partitions.CreatedUtcTimestamps) {
 		return errors.New("partition names and timestamps number is not aligned, response " + partitions.String())
 	}
-
-	for i := 0; i < len(partitions.PartitionIDs); i++ {
-		if _, ok := partInfo[partitions.PartitionNames[i]]; !ok {
-			partInfo[partitions.PartitionNames[i]] = &partitionInfo{
-				partitionID:         partitions.PartitionIDs[i],
-				createdTimestamp:    partitions.CreatedTimestamps[i],
-				createdUtcTimestamp: partitions.CreatedUtcTimestamps[i],
-			}
-		}
-	}
-	m.collInfo[collectionName].partInfo = partInfo
 	return nil
 }
 
 func (m *MetaCache) RemoveCollection(ctx context.Context, collectionName string) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	delete(m.collInfo, collectionName)
+	m.InvalidateCollectionMeta(ctx, collectionName, proxypb.CollectionMetaType_All)
 }
 
 func (m *MetaCache) RemovePartition(ctx context.Context, collectionName, partitionName string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	_, ok := m.collInfo[collectionName]
+	collInfo, ok := m.collInfo[collectionName]
 	if !ok {
 		return
 	}
-	partInfo := m.collInfo[collectionName].partInfo
-	if partInfo == nil {
+	// the partitions being shown may still contain the removed one
+	collInfo.partitionsVersion++
+	m.calls.forget(showPartitionsKey(collectionName))
+	if collInfo.partInfo == nil {
 		return
 	}
-	delete(partInfo, partitionName)
+	partInfo := make(map[string]*partitionInfo, len(collInfo.partInfo))
+	for name, info := range collInfo.partInfo {
+		if name != partitionName {
+			partInfo[name] = info
+		}
+	}
+	collInfo.partInfo = partInfo
+}
+
+func (m *MetaCache) InvalidateCollectionMeta(ctx context.Context, collectionName string, metaType proxypb.CollectionMetaType) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	collInfo, ok := m.collInfo[collectionName]
+	switch metaType {
+	case proxypb.CollectionMetaType_Schema:
+		m.calls.forget(describeCollectionKey(collectionName))
+		if ok {
+			collInfo.schema = nil
+			collInfo.schemaVersion++
+		}
+	case proxypb.CollectionMetaType_Shards:
+		m.calls.forget(describeCollectionKey(collectionName))
+		if ok {
+			collInfo.shards = nil
+			collInfo.shardsVersion++
+		}
+	case proxypb.CollectionMetaType_Partitions:
+		m.calls.forget(showPartitionsKey(collectionName))
+		if ok {
+			collInfo.partInfo = nil
+			collInfo.partitionsVersion++
+		}
+	default:
+		// the in-flight loads don't cache their results since the collection info is replaced
+		m.calls.forget(describeCollectionKey(collectionName))
+		m.calls.forget(showPartitionsKey(collectionName))
+		delete(m.collInfo, collectionName)
+	}
+}
+
+func (m *MetaCache) RefreshExpiredShards(ctx context.Context, ttl time.Duration) {
+	var expired []string
+	m.mu.RLock()
+	for collectionName, collInfo := range m.collInfo {
+		if collInfo.shards != nil && time.Since(collInfo.shardsUpdateTime) >= ttl {
+			expired = append(expired, collectionName)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, collectionName := range expired {
+		metrics.ProxyMetaCacheCounter.WithLabelValues(metaCacheShardsLabel, metaCacheRefreshLabel).Inc()
+		if _, err := m.loadCollection(ctx, collectionName); err != nil {
+			log.Warn("Failed to refresh shards of collection",
+				zap.String("collection name", collectionName),
+				zap.Error(err))
+		}
+	}
 }
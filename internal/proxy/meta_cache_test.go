@@ -15,11 +15,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
@@ -268,3 +272,289 @@ func TestMetaCache_GetPartitionError(t *testing.T) {
 	log.Debug(err.Error())
 	assert.Equal(t, id, typeutil.UniqueID(0))
 }
+
+// mockMetaRootCoord counts the requests, and blocks them until unblocked if block is set
+type mockMetaRootCoord struct {
+	types.RootCoord
+	describeCount int32
+	showCount     int32
+	shardsNum     int32
+	block         chan struct{}
+	entered       chan struct{}
+}
+
+func newMockMetaRootCoord() *mockMetaRootCoord {
+	return &mockMetaRootCoord{shardsNum: 2}
+}
+
+func (m *mockMetaRootCoord) wait() {
+	if m.block != nil {
+		m.entered <- struct{}{}
+		<-m.block
+	}
+}
+
+func (m *mockMetaRootCoord) DescribeCollection(ctx context.Context, in *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error) {
+	atomic.AddInt32(&m.describeCount, 1)
+	m.wait()
+	resp := &milvuspb.DescribeCollectionResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		CollectionID: typeutil.UniqueID(1),
+		Schema: &schemapb.CollectionSchema{
+			Name: in.CollectionName,
+		},
+	}
+	for i := 0; i < int(atomic.LoadInt32(&m.shardsNum)); i++ {
+		resp.VirtualChannelNames = append(resp.VirtualChannelNames, fmt.Sprintf("%s_v%d", in.CollectionName, i))
+		resp.PhysicalChannelNames = append(resp.PhysicalChannelNames, fmt.Sprintf("p%d", i))
+	}
+	return resp, nil
+}
+
+func (m *mockMetaRootCoord) ShowPartitions(ctx context.Context, in *milvuspb.ShowPartitionsRequest) (*milvuspb.ShowPartitionsResponse, error) {
+	atomic.AddInt32(&m.showCount, 1)
+	m.wait()
+	return &milvuspb.ShowPartitionsResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		PartitionIDs:         []typeutil.UniqueID{1, 2},
+		CreatedTimestamps:    []uint64{100, 200},
+		CreatedUtcTimestamps: []uint64{100, 200},
+		PartitionNames:       []string{"par1", "par2"},
+	}, nil
+}
+
+func TestMetaCache_GetShards(t *testing.T) {
+	ctx := context.Background()
+	client := newMockMetaRootCoord()
+	cache, err := NewMetaCache(client)
+	assert.NoError(t, err)
+
+	shards, err := cache.GetShards(ctx, "collection")
+	assert.NoError(t, err)
+	assert.Equal(t, map[vChan]pChan{"collection_v0": "p0", "collection_v1": "p1"}, shards)
+	// the schema is cached together with the shards
+	_, err = cache.GetCollectionSchema(ctx, "collection")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), client.describeCount)
+
+	// the returned shards are a copy
+	shards["collection_v2"] = "p2"
+	shards, err = cache.GetShards(ctx, "collection")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(shards))
+	assert.Equal(t, int32(1), client.describeCount)
+}
+
+func TestMetaCache_InvalidateCollectionMeta(t *testing.T) {
+	ctx := context.Background()
+	client := newMockMetaRootCoord()
+	cache, err := NewMetaCache(client)
+	assert.NoError(t, err)
+
+	load := func() {
+		_, err := cache.GetCollectionSchema(ctx, "collection")
+		assert.NoError(t, err)
+		_, err = cache.GetShards(ctx, "collection")
+		assert.NoError(t, err)
+		_, err = cache.GetPartitions(ctx, "collection")
+		assert.NoError(t, err)
+	}
+	load()
+	assert.Equal(t, int32(1), client.describeCount)
+	assert.Equal(t, int32(1), client.showCount)
+
+	t.Run("schema only", func(t *testing.T) {
+		cache.InvalidateCollectionMeta(ctx, "collection", proxypb.CollectionMetaType_Schema)
+		_, err := cache.GetShards(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), client.describeCount)
+		load()
+		assert.Equal(t, int32(2), client.describeCount)
+		assert.Equal(t, int32(1), client.showCount)
+	})
+
+	t.Run("shards only", func(t *testing.T) {
+		cache.InvalidateCollectionMeta(ctx, "collection", proxypb.CollectionMetaType_Shards)
+		_, err := cache.GetCollectionSchema(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), client.describeCount)
+		load()
+		assert.Equal(t, int32(3), client.describeCount)
+		assert.Equal(t, int32(1), client.showCount)
+	})
+
+	t.Run("partitions only", func(t *testing.T) {
+		cache.InvalidateCollectionMeta(ctx, "collection", proxypb.CollectionMetaType_Partitions)
+		load()
+		assert.Equal(t, int32(3), client.describeCount)
+		assert.Equal(t, int32(2), client.showCount)
+	})
+
+	t.Run("remove partition", func(t *testing.T) {
+		cache.RemovePartition(ctx, "collection", "par1")
+		partitions, err := cache.GetPartitions(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]typeutil.UniqueID{"par2": 2}, partitions)
+		assert.Equal(t, int32(2), client.showCount)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		cache.InvalidateCollectionMeta(ctx, "collection", proxypb.CollectionMetaType_All)
+		load()
+		assert.Equal(t, int32(4), client.describeCount)
+		assert.Equal(t, int32(3), client.showCount)
+
+		cache.RemoveCollection(ctx, "collection")
+		load()
+		assert.Equal(t, int32(5), client.describeCount)
+		assert.Equal(t, int32(4), client.showCount)
+	})
+}
+
+func TestMetaCache_SingleFlight(t *testing.T) {
+	ctx := context.Background()
+	client := newMockMetaRootCoord()
+	client.block = make(chan struct{})
+	client.entered = make(chan struct{}, 1)
+	cache, err := NewMetaCache(client)
+	assert.NoError(t, err)
+
+	n := 10
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			schema, err := cache.GetCollectionSchema(ctx, "collection")
+			assert.NoError(t, err)
+			assert.Equal(t, "collection", schema.Name)
+		}()
+	}
+	<-client.entered
+	// wait for the other misses to join the in-flight request
+	time.Sleep(100 * time.Millisecond)
+	close(client.block)
+	wg.Wait()
+	assert.Equal(t, int32(1), client.describeCount)
+}
+
+func TestMetaCache_InvalidateDuringLoad(t *testing.T) {
+	ctx := context.Background()
+
+	loadAndInvalidate := func(t *testing.T, metaType proxypb.CollectionMetaType) (*MetaCache, *mockMetaRootCoord) {
+		client := newMockMetaRootCoord()
+		client.block = make(chan struct{})
+		client.entered = make(chan struct{}, 1)
+		cache, err := NewMetaCache(client)
+		assert.NoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err := cache.GetShards(ctx, "collection")
+			assert.NoError(t, err)
+		}()
+		<-client.entered
+		cache.InvalidateCollectionMeta(ctx, "collection", metaType)
+		close(client.block)
+		<-done
+		client.block = nil
+		return cache, client
+	}
+
+	t.Run("all", func(t *testing.T) {
+		cache, client := loadAndInvalidate(t, proxypb.CollectionMetaType_All)
+		// the meta described before the invalidation is not cached
+		_, err := cache.GetCollectionSchema(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), client.describeCount)
+	})
+
+	t.Run("shards", func(t *testing.T) {
+		cache, client := loadAndInvalidate(t, proxypb.CollectionMetaType_Shards)
+		_, err := cache.GetCollectionSchema(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), client.describeCount)
+		_, err = cache.GetShards(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), client.describeCount)
+	})
+
+	t.Run("partitions", func(t *testing.T) {
+		cache, client := loadAndInvalidate(t, proxypb.CollectionMetaType_Partitions)
+		_, err := cache.GetShards(ctx, "collection")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), client.describeCount)
+	})
+}
+
+func TestMetaCache_ConcurrentInvalidate(t *testing.T) {
+	ctx := context.Background()
+	client := newMockMetaRootCoord()
+	cache, err := NewMetaCache(client)
+	assert.NoError(t, err)
+
+	metaTypes := []proxypb.CollectionMetaType{
+		proxypb.CollectionMetaType_All,
+		proxypb.CollectionMetaType_Schema,
+		proxypb.CollectionMetaType_Shards,
+		proxypb.CollectionMetaType_Partitions,
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				schema, err := cache.GetCollectionSchema(ctx, "collection")
+				assert.NoError(t, err)
+				assert.Equal(t, "collection", schema.Name)
+				shards, err := cache.GetShards(ctx, "collection")
+				assert.NoError(t, err)
+				assert.Equal(t, 2, len(shards))
+				partitionID, err := cache.GetPartitionID(ctx, "collection", "par2")
+				assert.NoError(t, err)
+				assert.Equal(t, typeutil.UniqueID(2), partitionID)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cache.InvalidateCollectionMeta(ctx, "collection", metaTypes[(i+j)%len(metaTypes)])
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestMetaCache_RefreshExpiredShards(t *testing.T) {
+	ctx := context.Background()
+	client := newMockMetaRootCoord()
+	cache, err := NewMetaCache(client)
+	assert.NoError(t, err)
+
+	_, err = cache.GetShards(ctx, "collection")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), client.describeCount)
+
+	// not expired yet
+	cache.RefreshExpiredShards(ctx, time.Hour)
+	assert.Equal(t, int32(1), client.describeCount)
+
+	atomic.StoreInt32(&client.shardsNum, 3)
+	cache.RefreshExpiredShards(ctx, 0)
+	assert.Equal(t, int32(2), client.describeCount)
+	shards, err := cache.GetShards(ctx, "collection")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(shards))
+	assert.Equal(t, int32(2), client.describeCount)
+
+	// the collections without cached shards are not refreshed
+	cache.InvalidateCollectionMeta(ctx, "collection", proxypb.CollectionMetaType_Shards)
+	cache.RefreshExpiredShards(ctx, 0)
+	assert.Equal(t, int32(2), client.describeCount)
+}
@@ -14,17 +14,22 @@ package proxy
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 
 	"github.com/milvus-io/milvus/internal/util/uniquegenerator"
 
+	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 )
@@ -278,8 +283,215 @@ func TestProxy_metrics(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 
+	// the responses of coordinators in the shapes without version are understood as well
+	systemTopology, err := metricsinfo.UnmarshalSystemTopology(resp.Response)
+	assert.NoError(t, err)
+	assert.Equal(t, metricsinfo.TopologyVersion, systemTopology.Version)
+	roles := make(map[string]bool)
+	for _, node := range systemTopology.NodesInfo {
+		roles[node.Infos.(*metricsinfo.ComponentInfo).Type] = true
+	}
+	for _, role := range []string{typeutil.ProxyRole, typeutil.RootCoordRole, typeutil.QueryCoordRole, typeutil.DataCoordRole, typeutil.IndexCoordRole} {
+		assert.True(t, roles[role], role)
+	}
+
 	rc.getMetricsFunc = nil
 	qc.getMetricsFunc = nil
 	dc.getMetricsFunc = nil
 	ic.getMetricsFunc = nil
 }
+
+func TestProxy_metricsQueryCoordNodeTasks(t *testing.T) {
+	ctx := context.Background()
+
+	rc := NewRootCoordMock()
+	rc.Start()
+	defer rc.Stop()
+
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+
+	dc := NewDataCoordMock()
+	dc.Start()
+	defer dc.Stop()
+
+	ic := NewIndexCoordMock()
+	ic.Start()
+	defer ic.Stop()
+
+	proxy := &Proxy{
+		rootCoord:  rc,
+		queryCoord: qc,
+		dataCoord:  dc,
+		indexCoord: ic,
+		session:    &sessionutil.Session{Address: funcutil.GenRandomStr()},
+	}
+
+	nodeTasks := []metricsinfo.QueryNodeTaskInfos{{
+		NodeID:          1,
+		LoadingSegments: []metricsinfo.LoadingSegmentInfo{{TaskID: 11, SegmentID: 100, Phase: "executing"}},
+		DispatchQueue:   []metricsinfo.TaskInfo{},
+		FailedTasks:     []metricsinfo.FailedTaskInfo{{TaskID: 10, NodeID: 1, Reason: "mocked load failure"}},
+	}}
+	qc.getMetricsFunc = func(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		self := metricsinfo.NewComponentInfo(typeutil.QueryCoordRole, 2, "", time.Now(), time.Now(), nil)
+		self.NodeTasks = nodeTasks
+		return metricsinfo.ComponentTopologyResponse(metricsinfo.NewComponentTopology(self)), nil
+	}
+	defer func() {
+		qc.getMetricsFunc = nil
+	}()
+
+	req, _ := metricsinfo.ConstructRequestByMetricType(metricsinfo.SystemInfoMetrics)
+	resp, err := getSystemInfoMetrics(ctx, req, proxy)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	for _, field := range []string{`"node_tasks"`, `"loading_segments"`, `"failed_tasks"`, `"reason":"mocked load failure"`} {
+		assert.Contains(t, resp.Response, field)
+	}
+
+	systemTopology, err := metricsinfo.UnmarshalSystemTopology(resp.Response)
+	assert.NoError(t, err)
+	var found bool
+	for _, node := range systemTopology.NodesInfo {
+		infos := node.Infos.(*metricsinfo.ComponentInfo)
+		if infos.Type == typeutil.QueryCoordRole {
+			found = true
+			assert.Equal(t, nodeTasks, infos.NodeTasks)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestProxy_segmentStatisticsMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+
+	proxy := &Proxy{
+		queryCoord: qc,
+		session:    &sessionutil.Session{Address: funcutil.GenRandomStr()},
+	}
+
+	qc.getMetricsFunc = func(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		clusterStatistics := metricsinfo.QueryClusterSegmentStatistics{
+			Nodes: []metricsinfo.QueryNodeSegmentStatistics{
+				{
+					ID: 1,
+					Segments: []metricsinfo.SegmentStatistics{
+						{SegmentID: 1, CollectionID: 1, SearchCount: 1},
+						{SegmentID: 2, CollectionID: 2, SearchCount: 2},
+					},
+				},
+				{
+					ID: 2,
+					Segments: []metricsinfo.SegmentStatistics{
+						{SegmentID: 3, CollectionID: 1, SearchCount: 3},
+					},
+				},
+			},
+		}
+		resp, _ := metricsinfo.MarshalComponentInfos(clusterStatistics)
+		return &milvuspb.GetMetricsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_Success,
+			},
+			Response: resp,
+		}, nil
+	}
+
+	req, _ := metricsinfo.ConstructRequestByMetricType(metricsinfo.SegmentStatisticsMetrics)
+	resp, err := getSegmentStatisticsMetrics(ctx, req, proxy)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+
+	collections := make([]metricsinfo.CollectionSegmentStatistics, 0)
+	err = metricsinfo.UnmarshalComponentInfos(resp.Response, &collections)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(collections))
+	assert.Equal(t, int64(1), collections[0].CollectionID)
+	assert.Equal(t, int64(4), collections[0].SearchCount)
+	assert.Equal(t, 2, len(collections[0].Segments))
+	assert.Equal(t, int64(2), collections[1].SearchCount)
+
+	qc.getMetricsFunc = func(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		return &milvuspb.GetMetricsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    "mock",
+			},
+		}, nil
+	}
+	resp, err = getSegmentStatisticsMetrics(ctx, req, proxy)
+	assert.NoError(t, err)
+	assert.NotEqual(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+
+	qc.getMetricsFunc = nil
+}
+
+func TestProxy_SetLogLevel(t *testing.T) {
+	ctx := context.Background()
+	level := log.GetLevel()
+	defer log.SetLevel(level)
+	log.SetLevel(zap.InfoLevel)
+	defer log.ResetNamedLevel("querycoord.scheduler")
+
+	rc := NewRootCoordMock()
+	rc.Start()
+	defer rc.Stop()
+	rc.getMetricsFunc = func(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		return metricsinfo.LogLevelsResponse([]metricsinfo.ComponentLogLevels{{Name: "rootcoord", Level: "info"}}, "rootcoord"), nil
+	}
+
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+
+	node := &Proxy{rootCoord: rc}
+	node.UpdateStateCode(internalpb.StateCode_Healthy)
+
+	debugEnabled := func() bool {
+		return log.Named("querycoord.scheduler").Check(zap.DebugLevel, "debug line") != nil
+	}
+	assert.False(t, debugEnabled())
+
+	resp, err := node.SetLogLevel(ctx, &proxypb.SetLogLevelRequest{Logger: "querycoord.scheduler", Level: "debug"})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	assert.True(t, debugEnabled())
+	levels := metricsinfo.CollectLogLevels(&milvuspb.GetMetricsResponse{Response: resp.Response}, nil)
+	assert.Equal(t, 2, len(levels))
+	assert.Equal(t, map[string]string{"querycoord.scheduler": "debug"}, levels[0].Loggers)
+	assert.Equal(t, "rootcoord", levels[1].Name)
+
+	// the failure of a coordinator is reported, but the level of the others is set
+	node.queryCoord = qc
+	resp, err = node.SetLogLevel(ctx, &proxypb.SetLogLevelRequest{Logger: "querycoord.scheduler"})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	assert.False(t, debugEnabled())
+	levels = metricsinfo.CollectLogLevels(&milvuspb.GetMetricsResponse{Response: resp.Response}, nil)
+	assert.Equal(t, 3, len(levels))
+	assert.True(t, levels[2].HasError)
+
+	// an invalid level isn't passed on
+	resp, err = node.SetLogLevel(ctx, &proxypb.SetLogLevelRequest{Level: "verbose"})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, resp.Status.ErrorCode)
+	assert.Equal(t, zap.InfoLevel, log.GetLevel())
+
+	req, err := metricsinfo.ConstructSetLogLevelRequest("", "warn")
+	assert.NoError(t, err)
+	metrics, err := node.GetMetrics(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, metrics.Status.ErrorCode)
+	assert.Equal(t, zap.WarnLevel, log.GetLevel())
+
+	node.UpdateStateCode(internalpb.StateCode_Abnormal)
+	resp, err = node.SetLogLevel(ctx, &proxypb.SetLogLevelRequest{Level: "info"})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+}
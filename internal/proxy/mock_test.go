@@ -262,6 +262,9 @@ func (ms *simpleMockMsgStream) Start() {
 func (ms *simpleMockMsgStream) Close() {
 }
 
+func (ms *simpleMockMsgStream) Unsubscribe() {
+}
+
 func (ms *simpleMockMsgStream) Chan() <-chan *msgstream.MsgPack {
 	return ms.msgChan
 }
@@ -357,6 +360,10 @@ func (ms *simpleMockMsgStream) Seek(offset []*msgstream.MsgPosition) error {
 	return nil
 }
 
+func (ms *simpleMockMsgStream) SeekToTimestamp(channels []string, ts Timestamp) error {
+	return nil
+}
+
 func newSimpleMockMsgStream() *simpleMockMsgStream {
 	return &simpleMockMsgStream{
 		msgChan:  make(chan *msgstream.MsgPack, 1024),
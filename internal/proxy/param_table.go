@@ -18,6 +18,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 )
 
@@ -67,6 +70,39 @@ type ParamTable struct {
 
 	MaxTaskNum int64
 
+	// the staleness that search and query of BoundedStaleness consistency level can tolerate
+	BoundedStaleness time.Duration
+
+	// rate limits of every collection, per second, not limited if not positive
+	DMLRowsRateLimit     float64
+	DMLBytesRateLimit    float64
+	DQLRequestsRateLimit float64
+
+	InsertRejectNaN bool
+	// the max serialized sizes of the requests and results in bytes, 0 means no limit
+	InsertMaxRequestSize int64
+	SearchMaxRequestSize int64
+	SearchMaxResultSize  int64
+	DeleteBatchSize int
+
+	// the shards cached longer than MetaCacheShardsTTL are refreshed in background, 0 disables the refresh
+	MetaCacheShardsTTL time.Duration
+
+	DefaultPartitionKeyPartitionNum int64
+	MaxPartitionKeyPartitionNum     int64
+
+	// the max offset+limit of query pagination
+	QueryMaxWindow int64
+
+	// the travel timestamps older than RetentionDuration are rejected since the history may be garbage collected
+	RetentionDuration time.Duration
+
+	AccessLogEnable bool
+	AccessLog       accesslog.Config
+
+	// the batching and compression of the messages produced by proxy
+	ProducerBatch msgstream.ProducerBatchParams
+
 	PulsarMaxMessageSize int
 
 	CreatedTime time.Time
@@ -114,6 +150,17 @@ func (pt *ParamTable) Init() {
 	pt.initRoleName()
 
 	pt.initMaxTaskNum()
+	pt.initBoundedStaleness()
+	pt.initRateLimit()
+	pt.initInsertRejectNaN()
+	pt.initPayloadSizeLimits()
+	pt.initDeleteBatchSize()
+	pt.initMetaCacheShardsTTL()
+	pt.initPartitionKeyPartitionNum()
+	pt.initQueryMaxWindow()
+	pt.initRetentionDuration()
+	pt.initAccessLog()
+	pt.initProducerBatch()
 
 	pt.initRoleName()
 }
@@ -156,6 +203,9 @@ func (pt *ParamTable) initClusterMsgChannelPrefix() {
 	if err != nil {
 		panic(err)
 	}
+	if err = channelutil.ValidatePrefix(config); err != nil {
+		panic(err)
+	}
 	pt.ClusterChannelPrefix = config
 }
 
@@ -164,8 +214,7 @@ func (pt *ParamTable) initProxySubName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{pt.ClusterChannelPrefix, config, strconv.FormatInt(pt.ProxyID, 10)}
-	pt.ProxySubName = strings.Join(s, "-")
+	pt.ProxySubName = channelutil.JoinName(pt.ClusterChannelPrefix, config, strconv.FormatInt(pt.ProxyID, 10))
 }
 
 func (pt *ParamTable) initProxyTimeTickChannelNames() {
@@ -173,9 +222,7 @@ func (pt *ParamTable) initProxyTimeTickChannelNames() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{pt.ClusterChannelPrefix, config, "0"}
-	prefix := strings.Join(s, "-")
-	pt.ProxyTimeTickChannelNames = []string{prefix}
+	pt.ProxyTimeTickChannelNames = []string{channelutil.JoinName(pt.ClusterChannelPrefix, config, "0")}
 }
 
 func (pt *ParamTable) initMsgStreamTimeTickBufSize() {
@@ -317,3 +364,87 @@ func (pt *ParamTable) initMaxTaskNum() {
 	}
 	pt.MaxTaskNum = maxTaskNum
 }
+
+func (pt *ParamTable) initBoundedStaleness() {
+	str, err := pt.Load("proxy.boundedStaleness")
+	if err != nil {
+		panic(err)
+	}
+	staleness, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	pt.BoundedStaleness = time.Duration(staleness) * time.Millisecond
+}
+
+func (pt *ParamTable) initRateLimit() {
+	pt.DMLRowsRateLimit = pt.ParseFloat("proxy.rateLimit.dmlRows")
+	pt.DMLBytesRateLimit = pt.ParseFloat("proxy.rateLimit.dmlBytes")
+	pt.DQLRequestsRateLimit = pt.ParseFloat("proxy.rateLimit.dqlRequests")
+}
+
+func (pt *ParamTable) initInsertRejectNaN() {
+	pt.InsertRejectNaN = pt.ParseBool("proxy.insert.rejectNaN", true)
+}
+
+func (pt *ParamTable) initPayloadSizeLimits() {
+	pt.InsertMaxRequestSize = pt.ParseInt64("proxy.insert.maxRequestSize")
+	pt.SearchMaxRequestSize = pt.ParseInt64("proxy.search.maxRequestSize")
+	pt.SearchMaxResultSize = pt.ParseInt64("proxy.search.maxResultSize")
+}
+
+func (pt *ParamTable) initRetentionDuration() {
+	pt.RetentionDuration = time.Duration(pt.ParseInt64("common.retentionDuration")) * time.Second
+}
+
+func (pt *ParamTable) initDeleteBatchSize() {
+	pt.DeleteBatchSize = pt.ParseInt("proxy.delete.batchSize")
+}
+
+func (pt *ParamTable) initMetaCacheShardsTTL() {
+	pt.MetaCacheShardsTTL = time.Duration(pt.ParseInt64("proxy.metaCache.shardsTTL")) * time.Second
+}
+
+func (pt *ParamTable) initPartitionKeyPartitionNum() {
+	pt.DefaultPartitionKeyPartitionNum = pt.ParseInt64("proxy.partitionKey.defaultPartitionNum")
+	pt.MaxPartitionKeyPartitionNum = pt.ParseInt64("proxy.partitionKey.maxPartitionNum")
+}
+
+func (pt *ParamTable) initQueryMaxWindow() {
+	pt.QueryMaxWindow = pt.ParseInt64("proxy.query.maxWindow")
+}
+
+func (pt *ParamTable) initAccessLog() {
+	pt.AccessLogEnable = pt.ParseBool("proxy.accessLog.enable", false)
+	filename, err := pt.Load("proxy.accessLog.filename")
+	if err != nil {
+		panic(err)
+	}
+	format, err := pt.LoadWithDefault("proxy.accessLog.format", accesslog.FormatText)
+	if err != nil {
+		panic(err)
+	}
+	pt.AccessLog = accesslog.Config{
+		Filename:   filename,
+		MaxSize:    pt.ParseInt("proxy.accessLog.maxSize"),
+		MaxBackups: pt.ParseInt("proxy.accessLog.maxBackups"),
+		Format:     format,
+		BufferSize: pt.ParseInt("proxy.accessLog.bufferSize"),
+	}
+}
+
+func (pt *ParamTable) initProducerBatch() {
+	compression, err := pt.LoadWithDefault("proxy.msgStream.producer.compression", string(msgstream.CompressionNone))
+	if err != nil {
+		panic(err)
+	}
+	if _, err := msgstream.ParseCompressionType(compression); err != nil {
+		panic(err)
+	}
+	pt.ProducerBatch = msgstream.ProducerBatchParams{
+		MaxMessages: pt.ParseInt("proxy.msgStream.producer.batch.maxMessages"),
+		MaxBytes:    pt.ParseInt("proxy.msgStream.producer.batch.maxBytes"),
+		MaxDelayMs:  pt.ParseInt64("proxy.msgStream.producer.batch.maxDelay"),
+		Compression: compression,
+	}
+}
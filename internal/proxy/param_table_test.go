@@ -13,6 +13,7 @@ package proxy
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -89,6 +90,50 @@ func TestParamTable_Normal(t *testing.T) {
 	t.Run("MaxTaskNum", func(t *testing.T) {
 		t.Logf("MaxTaskNum: %d", Params.MaxTaskNum)
 	})
+
+	t.Run("BoundedStaleness", func(t *testing.T) {
+		t.Logf("BoundedStaleness: %v", Params.BoundedStaleness)
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		t.Logf("DMLRowsRateLimit: %v", Params.DMLRowsRateLimit)
+		t.Logf("DMLBytesRateLimit: %v", Params.DMLBytesRateLimit)
+		t.Logf("DQLRequestsRateLimit: %v", Params.DQLRequestsRateLimit)
+	})
+
+	t.Run("InsertRejectNaN", func(t *testing.T) {
+		t.Logf("InsertRejectNaN: %v", Params.InsertRejectNaN)
+	})
+
+	t.Run("DeleteBatchSize", func(t *testing.T) {
+		t.Logf("DeleteBatchSize: %v", Params.DeleteBatchSize)
+	})
+
+	t.Run("MetaCacheShardsTTL", func(t *testing.T) {
+		t.Logf("MetaCacheShardsTTL: %v", Params.MetaCacheShardsTTL)
+	})
+
+	t.Run("PartitionKeyPartitionNum", func(t *testing.T) {
+		t.Logf("DefaultPartitionKeyPartitionNum: %d", Params.DefaultPartitionKeyPartitionNum)
+		t.Logf("MaxPartitionKeyPartitionNum: %d", Params.MaxPartitionKeyPartitionNum)
+	})
+
+	t.Run("QueryMaxWindow", func(t *testing.T) {
+		t.Logf("QueryMaxWindow: %d", Params.QueryMaxWindow)
+	})
+
+	t.Run("RetentionDuration", func(t *testing.T) {
+		assert.Equal(t, 5*24*time.Hour, Params.RetentionDuration)
+	})
+
+	t.Run("AccessLog", func(t *testing.T) {
+		t.Logf("AccessLogEnable: %v", Params.AccessLogEnable)
+		t.Logf("AccessLog: %+v", Params.AccessLog)
+	})
+
+	t.Run("ProducerBatch", func(t *testing.T) {
+		t.Logf("ProducerBatch: %+v", Params.ProducerBatch)
+	})
 }
 
 func shouldPanic(t *testing.T, name string, f func()) {
@@ -167,4 +212,54 @@ func TestParamTable_Panics(t *testing.T) {
 		Params.Save("proxy.maxTaskNum", "-asdf")
 		Params.initMaxTaskNum()
 	})
+
+	shouldPanic(t, "proxy.boundedStaleness", func() {
+		Params.Save("proxy.boundedStaleness", "-asdf")
+		Params.initBoundedStaleness()
+	})
+
+	shouldPanic(t, "proxy.rateLimit.dmlRows", func() {
+		Params.Save("proxy.rateLimit.dmlRows", "-asdf")
+		Params.initRateLimit()
+	})
+
+	shouldPanic(t, "proxy.delete.batchSize", func() {
+		Params.Save("proxy.delete.batchSize", "-asdf")
+		Params.initDeleteBatchSize()
+	})
+
+	shouldPanic(t, "proxy.metaCache.shardsTTL", func() {
+		Params.Save("proxy.metaCache.shardsTTL", "-asdf")
+		Params.initMetaCacheShardsTTL()
+	})
+
+	shouldPanic(t, "proxy.partitionKey.defaultPartitionNum", func() {
+		Params.Save("proxy.partitionKey.defaultPartitionNum", "-asdf")
+		Params.initPartitionKeyPartitionNum()
+	})
+
+	shouldPanic(t, "proxy.insert.maxRequestSize", func() {
+		Params.Save("proxy.insert.maxRequestSize", "-asdf")
+		Params.initPayloadSizeLimits()
+	})
+
+	shouldPanic(t, "proxy.query.maxWindow", func() {
+		Params.Save("proxy.query.maxWindow", "-asdf")
+		Params.initQueryMaxWindow()
+	})
+
+	shouldPanic(t, "proxy.accessLog.maxSize", func() {
+		Params.Save("proxy.accessLog.maxSize", "-asdf")
+		Params.initAccessLog()
+	})
+
+	shouldPanic(t, "proxy.msgStream.producer.batch.maxMessages", func() {
+		Params.Save("proxy.msgStream.producer.batch.maxMessages", "-asdf")
+		Params.initProducerBatch()
+	})
+
+	shouldPanic(t, "proxy.msgStream.producer.compression", func() {
+		Params.Save("proxy.msgStream.producer.compression", "snappy")
+		Params.initProducerBatch()
+	})
 }
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// errPartitionKeyWithPartitionNames is returned if a request on a collection with partition key specifies partitions
+func errPartitionKeyWithPartitionNames(collectionName string) error {
+	return fmt.Errorf("not support manually specifying the partition names of collection %s which has partition key", collectionName)
+}
+
+// getPartitionKeyField returns the partition key field of schema, nil if there is no partition key
+func getPartitionKeyField(schema *schemapb.CollectionSchema) *schemapb.FieldSchema {
+	for _, field := range schema.GetFields() {
+		if field.IsPartitionKey {
+			return field
+		}
+	}
+	return nil
+}
+
+// partitionKeyPartitionName returns the name of the i-th physical partition of a collection with partition key
+func partitionKeyPartitionName(i int64) string {
+	return fmt.Sprintf("%s_%d", Params.DefaultPartitionName, i)
+}
+
+// getPartitionKeyPartitions returns the names and ids of the physical partitions of a collection with partition key,
+// the i-th element is the partition of the keys hashed to i
+func getPartitionKeyPartitions(ctx context.Context, collectionName string) ([]string, []UniqueID, error) {
+	partitions, err := globalMetaCache.GetPartitions(ctx, collectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(partitions))
+	ids := make([]UniqueID, 0, len(partitions))
+	for i := int64(0); ; i++ {
+		name := partitionKeyPartitionName(i)
+		id, ok := partitions[name]
+		if !ok {
+			break
+		}
+		names = append(names, name)
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, nil, fmt.Errorf("no partition of partition key found in collection %s", collectionName)
+	}
+	return names, ids, nil
+}
+
+// hashPartitionKey returns the index of the physical partition which the partition key belongs to
+func hashPartitionKey(key int64, partitionNum int) int {
+	hash, _ := typeutil.Hash32Int64(key)
+	return int(hash % uint32(partitionNum))
+}
+
+// getPartitionKeyValues returns the values of the partition key field which expr can be true for.
+// ok is false if expr can be true for any value, i.e. expr doesn't restrict the partition key by
+// equality or term predicates joined by logical and/or.
+func getPartitionKeyValues(expr *planpb.Expr, fieldID UniqueID) (values []int64, ok bool) {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetColumnInfo().GetFieldId() != fieldID {
+			return nil, false
+		}
+		values = make([]int64, 0, len(e.TermExpr.Values))
+		for _, v := range e.TermExpr.Values {
+			values = append(values, v.GetInt64Val())
+		}
+		return values, true
+	case *planpb.Expr_UnaryRangeExpr:
+		if e.UnaryRangeExpr.GetColumnInfo().GetFieldId() != fieldID || e.UnaryRangeExpr.Op != planpb.OpType_Equal {
+			return nil, false
+		}
+		return []int64{e.UnaryRangeExpr.GetValue().GetInt64Val()}, true
+	case *planpb.Expr_BinaryExpr:
+		left, leftOk := getPartitionKeyValues(e.BinaryExpr.Left, fieldID)
+		right, rightOk := getPartitionKeyValues(e.BinaryExpr.Right, fieldID)
+		switch e.BinaryExpr.Op {
+		case planpb.BinaryExpr_LogicalAnd:
+			if leftOk && rightOk {
+				return intersectInt64s(left, right), true
+			}
+			if leftOk {
+				return left, true
+			}
+			return right, rightOk
+		case planpb.BinaryExpr_LogicalOr:
+			if leftOk && rightOk {
+				return append(left, right...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func intersectInt64s(a, b []int64) []int64 {
+	set := make(map[int64]struct{}, len(a))
+	for _, v := range a {
+		set[v] = struct{}{}
+	}
+	ret := make([]int64, 0)
+	for _, v := range b {
+		if _, ok := set[v]; ok {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// getPartitionKeyPartitionIDs returns the ids of the physical partitions which the entities matching expr can be in,
+// nil means all the partitions should be searched
+func getPartitionKeyPartitionIDs(expr *planpb.Expr, fieldID UniqueID, partitionIDs []UniqueID) []UniqueID {
+	values, ok := getPartitionKeyValues(expr, fieldID)
+	// no entity can match expr if values is empty, keep searching all partitions then
+	if !ok || len(values) == 0 {
+		return nil
+	}
+	hit := make(map[UniqueID]struct{})
+	ret := make([]UniqueID, 0)
+	for _, v := range values {
+		id := partitionIDs[hashPartitionKey(v, len(partitionIDs))]
+		if _, ok := hit[id]; !ok {
+			hit[id] = struct{}{}
+			ret = append(ret, id)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// getSearchPartitionKeyPartitionIDs returns the ids of the physical partitions to search or query by expr in a
+// collection with partition key, nil means all the partitions
+func getSearchPartitionKeyPartitionIDs(ctx context.Context, collectionName string, partitionKeyField *schemapb.FieldSchema, expr *planpb.Expr) ([]UniqueID, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	_, partitionIDs, err := getPartitionKeyPartitions(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	return getPartitionKeyPartitionIDs(expr, partitionKeyField.FieldID, partitionIDs), nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func newPartitionKeyTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test_partition_key",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "tenant", IsPartitionKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Double},
+			{
+				FieldID:    103,
+				Name:       "fvec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "8"}},
+			},
+		},
+	}
+}
+
+func TestGetPartitionKeyField(t *testing.T) {
+	schema := newPartitionKeyTestSchema()
+	assert.Equal(t, "tenant", getPartitionKeyField(schema).Name)
+
+	schema.Fields[1].IsPartitionKey = false
+	assert.Nil(t, getPartitionKeyField(schema))
+}
+
+func TestHashPartitionKey(t *testing.T) {
+	hit := make(map[int]bool)
+	for key := int64(0); key < 1000; key++ {
+		idx := hashPartitionKey(key, 16)
+		assert.True(t, idx >= 0 && idx < 16)
+		assert.Equal(t, idx, hashPartitionKey(key, 16))
+		hit[idx] = true
+	}
+	assert.Equal(t, 16, len(hit))
+	assert.Equal(t, 0, hashPartitionKey(-1, 1))
+}
+
+func TestGetPartitionKeyValues(t *testing.T) {
+	schema := newPartitionKeyTestSchema()
+	cases := []struct {
+		expr   string
+		values []int64
+		ok     bool
+	}{
+		{expr: "tenant == 1", values: []int64{1}, ok: true},
+		{expr: "tenant in [1, 2, 3]", values: []int64{1, 2, 3}, ok: true},
+		{expr: "tenant in [1, 2] && price > 1.0", values: []int64{1, 2}, ok: true},
+		{expr: "price > 1.0 && tenant == 3", values: []int64{3}, ok: true},
+		{expr: "tenant in [1, 2] && tenant in [2, 3]", values: []int64{2}, ok: true},
+		{expr: "tenant in [1, 2] && tenant == 3", values: []int64{}, ok: true},
+		{expr: "tenant == 1 || tenant in [2, 3]", values: []int64{1, 2, 3}, ok: true},
+		{expr: "tenant == 1 || price > 1.0", ok: false},
+		{expr: "tenant > 1", ok: false},
+		{expr: "tenant != 1", ok: false},
+		{expr: "not (tenant == 1)", ok: false},
+		{expr: "pk in [1, 2]", ok: false},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			plan, err := createExprPlan(schema, c.expr)
+			assert.NoError(t, err)
+			values, ok := getPartitionKeyValues(plan.GetPredicates(), 101)
+			assert.Equal(t, c.ok, ok)
+			if c.ok {
+				sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+				assert.Equal(t, c.values, values)
+			}
+		})
+	}
+}
+
+func TestGetPartitionKeyPartitionIDs(t *testing.T) {
+	schema := newPartitionKeyTestSchema()
+	partitionIDs := []UniqueID{1000, 1001, 1002, 1003}
+
+	plan, err := createExprPlan(schema, "tenant in [5, 6, 7, 8, 5]")
+	assert.NoError(t, err)
+	ids := getPartitionKeyPartitionIDs(plan.GetPredicates(), 101, partitionIDs)
+	expected := make(map[UniqueID]bool)
+	for _, key := range []int64{5, 6, 7, 8} {
+		expected[partitionIDs[hashPartitionKey(key, len(partitionIDs))]] = true
+	}
+	assert.Equal(t, len(expected), len(ids))
+	for _, id := range ids {
+		assert.True(t, expected[id])
+	}
+	assert.True(t, sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i] < ids[j] }))
+
+	plan, err = createExprPlan(schema, "price > 1.0")
+	assert.NoError(t, err)
+	assert.Nil(t, getPartitionKeyPartitionIDs(plan.GetPredicates(), 101, partitionIDs))
+
+	plan, err = createExprPlan(schema, "tenant == 1 && tenant == 2")
+	assert.NoError(t, err)
+	assert.Nil(t, getPartitionKeyPartitionIDs(plan.GetPredicates(), 101, partitionIDs))
+}
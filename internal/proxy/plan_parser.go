@@ -325,7 +325,7 @@ func (pc *parserContext) createCmpExpr(left, right ant_ast.Node, operator string
 		return nil, err
 	}
 
-	val, err := pc.handleLeafValue(valueNode, field.DataType)
+	val, err := pc.handleLeafValue(valueNode, field)
 	if err != nil {
 		return nil, err
 	}
@@ -334,6 +334,10 @@ func (pc *parserContext) createCmpExpr(left, right ant_ast.Node, operator string
 	if op == planpb.OpType_Invalid {
 		return nil, fmt.Errorf("invalid binary operator(%s)", operator)
 	}
+	// bool fields have no order, only equality comparisons make sense on them
+	if typeutil.IsBoolType(field.DataType) && op != planpb.OpType_Equal && op != planpb.OpType_NotEqual {
+		return nil, fmt.Errorf("bool field %s only supports == and != comparisons, got %s", field.Name, operator)
+	}
 
 	expr := &planpb.Expr{
 		Expr: &planpb.Expr_UnaryRangeExpr{
@@ -379,16 +383,20 @@ func (pc *parserContext) handleLogicalExpr(node *ant_ast.BinaryNode) (*planpb.Ex
 	return expr, nil
 }
 
-func (pc *parserContext) handleArrayExpr(node *ant_ast.Node, dataType schemapb.DataType) ([]*planpb.GenericValue, error) {
+func (pc *parserContext) handleArrayExpr(node *ant_ast.Node, field *schemapb.FieldSchema) ([]*planpb.GenericValue, error) {
 	arrayNode, ok2 := (*node).(*ant_ast.ArrayNode)
 	if !ok2 {
 		return nil, fmt.Errorf("right operand of the InExpr must be array")
 	}
 	var arr []*planpb.GenericValue
 	for _, element := range arrayNode.Nodes {
+		// `True` and `False` inside the array are parsed as identifiers
+		if boolNode := parseBoolNode(&element); boolNode != nil {
+			element = boolNode
+		}
 		// use value inside
 		// #nosec G601
-		val, err := pc.handleLeafValue(&element, dataType)
+		val, err := pc.handleLeafValue(&element, field)
 		if err != nil {
 			return nil, err
 		}
@@ -409,7 +417,7 @@ func (pc *parserContext) handleInExpr(node *ant_ast.BinaryNode) (*planpb.Expr, e
 	if err != nil {
 		return nil, err
 	}
-	arrayData, err := pc.handleArrayExpr(&node.Right, field.DataType)
+	arrayData, err := pc.handleArrayExpr(&node.Right, field)
 	if err != nil {
 		return nil, err
 	}
@@ -537,7 +545,8 @@ func (pc *parserContext) createNotExpr(childExpr *planpb.Expr) (*planpb.Expr, er
 	return expr, nil
 }
 
-func (pc *parserContext) handleLeafValue(nodeRaw *ant_ast.Node, dataType schemapb.DataType) (gv *planpb.GenericValue, err error) {
+func (pc *parserContext) handleLeafValue(nodeRaw *ant_ast.Node, field *schemapb.FieldSchema) (gv *planpb.GenericValue, err error) {
+	dataType := field.DataType
 	switch node := (*nodeRaw).(type) {
 	case *ant_ast.FloatNode:
 		if typeutil.IsFloatingType(dataType) {
@@ -547,7 +556,7 @@ func (pc *parserContext) handleLeafValue(nodeRaw *ant_ast.Node, dataType schemap
 				},
 			}
 		} else {
-			return nil, fmt.Errorf("type mismatch")
+			return nil, typeMismatchError(field, "float")
 		}
 	case *ant_ast.IntegerNode:
 		if typeutil.IsFloatingType(dataType) {
@@ -572,7 +581,7 @@ func (pc *parserContext) handleLeafValue(nodeRaw *ant_ast.Node, dataType schemap
 				gv.Val.(*planpb.GenericValue_BoolVal).BoolVal = false
 			}
 		} else {
-			return nil, fmt.Errorf("type mismatch")
+			return nil, typeMismatchError(field, "integer")
 		}
 	case *ant_ast.BoolNode:
 		if typeutil.IsBoolType(dataType) {
@@ -582,8 +591,15 @@ func (pc *parserContext) handleLeafValue(nodeRaw *ant_ast.Node, dataType schemap
 				},
 			}
 		} else {
-			return nil, fmt.Errorf("type mismatch")
+			return nil, typeMismatchError(field, "bool")
+		}
+	case *ant_ast.StringNode:
+		// GenericValue carries no string value and segcore keeps no string columns,
+		// so string literals can only be reported here
+		if dataType == schemapb.DataType_String {
+			return nil, fmt.Errorf("field %s of type String is not supported in expressions yet", field.Name)
 		}
+		return nil, typeMismatchError(field, "string")
 	default:
 		return nil, fmt.Errorf("unsupported leaf node")
 	}
@@ -591,6 +607,11 @@ func (pc *parserContext) handleLeafValue(nodeRaw *ant_ast.Node, dataType schemap
 	return gv, nil
 }
 
+func typeMismatchError(field *schemapb.FieldSchema, literalType string) error {
+	return fmt.Errorf("type mismatch: field %s of type %s cannot be compared with a %s value",
+		field.Name, field.DataType.String(), literalType)
+}
+
 func (pc *parserContext) handleIdentifier(node *ant_ast.IdentifierNode) (*schemapb.FieldSchema, error) {
 	fieldName := node.Value
 	field, err := pc.schema.GetFieldFromName(fieldName)
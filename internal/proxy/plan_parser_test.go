@@ -280,6 +280,88 @@ func TestExprMultiRange_Str(t *testing.T) {
 	}
 }
 
+func TestExprTermAndBool_Str(t *testing.T) {
+	fields := []*schemapb.FieldSchema{
+		{FieldID: 100, Name: "fakevec", DataType: schemapb.DataType_FloatVector},
+		{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int64},
+		{FieldID: 102, Name: "FloatN", DataType: schemapb.DataType_Float},
+		{FieldID: 103, Name: "BoolN", DataType: schemapb.DataType_Bool},
+		{FieldID: 104, Name: "StrN", DataType: schemapb.DataType_String},
+	}
+
+	schema := &schemapb.CollectionSchema{
+		Name:        "default-collection",
+		Description: "",
+		AutoID:      true,
+		Fields:      fields,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		exprStrs := []string{
+			"age in [1, 2, 3]",
+			"age not in [1, 2, 3]",
+			"FloatN in [1.0, 2, -3.5]",
+			"FloatN not in [1.0]",
+			"BoolN in [True]",
+			"BoolN in [true, False]",
+			"BoolN not in [false]",
+			"BoolN == True",
+			"BoolN != false",
+			"not (BoolN == true)",
+			"age in [] and BoolN == False",
+		}
+		for _, exprStr := range exprStrs {
+			planProto, err := createExprPlan(schema, exprStr)
+			assert.Nil(t, err, exprStr)
+			assert.NotNil(t, planProto.GetPredicates(), exprStr)
+		}
+	})
+
+	t.Run("term values", func(t *testing.T) {
+		planProto, err := createExprPlan(schema, "BoolN not in [True, false]")
+		assert.Nil(t, err)
+		unaryExpr := planProto.GetPredicates().GetUnaryExpr()
+		assert.Equal(t, planpb.UnaryExpr_Not, unaryExpr.GetOp())
+		termExpr := unaryExpr.GetChild().GetTermExpr()
+		assert.Equal(t, int64(103), termExpr.GetColumnInfo().GetFieldId())
+		assert.Equal(t, 2, len(termExpr.GetValues()))
+		assert.True(t, termExpr.GetValues()[0].GetBoolVal())
+		assert.False(t, termExpr.GetValues()[1].GetBoolVal())
+
+		planProto, err = createExprPlan(schema, "FloatN in [1, 2.5]")
+		assert.Nil(t, err)
+		termExpr = planProto.GetPredicates().GetTermExpr()
+		assert.Equal(t, 1.0, termExpr.GetValues()[0].GetFloatVal())
+		assert.Equal(t, 2.5, termExpr.GetValues()[1].GetFloatVal())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			expr   string
+			errMsg string
+		}{
+			{"age in [1.5]", "field age"},
+			{"age in [1, \"a\"]", "field age"},
+			{"age in [True]", "field age"},
+			{"FloatN not in [\"a\"]", "field FloatN"},
+			{"BoolN in [1.0]", "field BoolN"},
+			{"BoolN in [\"true\"]", "field BoolN"},
+			{"BoolN > True", "field BoolN"},
+			{"0 <= BoolN", "field BoolN"},
+			{"StrN in [\"a\", \"b\"]", "field StrN"},
+			{"StrN == \"a\"", "field StrN"},
+			{"age in 1", "must be array"},
+			{"age in [notExist]", "unsupported leaf node"},
+		}
+		for _, c := range cases {
+			planProto, err := createExprPlan(schema, c.expr)
+			assert.Error(t, err, c.expr)
+			assert.Nil(t, planProto, c.expr)
+			assert.Contains(t, err.Error(), c.errMsg, c.expr)
+		}
+	})
+}
+
 func TestExprFieldCompare_Str(t *testing.T) {
 	exprStrs := []string{
 		"age1 < age2",
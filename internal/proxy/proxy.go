@@ -33,7 +33,9 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
@@ -62,6 +64,9 @@ type Proxy struct {
 	dataCoord  types.DataCoord
 	queryCoord types.QueryCoord
 
+	// clients of the shard leaders, which the searches are sent to directly
+	shardClients *shardclient.Manager
+
 	chMgr channelsMgr
 
 	sched *taskScheduler
@@ -74,6 +79,11 @@ type Proxy struct {
 
 	metricsCacheManager *metricsinfo.MetricsCacheManager
 
+	sessionTsTracker *sessionTsTracker
+
+	rateLimiter   *rateLimiter
+	configUpdater *paramtable.ConfigUpdater
+
 	session *sessionutil.Session
 
 	msFactory msgstream.Factory
@@ -88,9 +98,10 @@ func NewProxy(ctx context.Context, factory msgstream.Factory) (*Proxy, error) {
 	rand.Seed(time.Now().UnixNano())
 	ctx1, cancel := context.WithCancel(ctx)
 	node := &Proxy{
-		ctx:       ctx1,
-		cancel:    cancel,
-		msFactory: factory,
+		ctx:              ctx1,
+		cancel:           cancel,
+		msFactory:        factory,
+		sessionTsTracker: newSessionTsTracker(),
 	}
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 	log.Debug("Proxy", zap.Any("State", node.stateCode.Load()))
@@ -114,35 +125,29 @@ func (node *Proxy) Register() error {
 func (node *Proxy) Init() error {
 	// wait for datacoord state changed to Healthy
 	if node.dataCoord != nil {
-		log.Debug("Proxy wait for dataCoord ready")
 		err := funcutil.WaitForComponentHealthy(node.ctx, node.dataCoord, "DataCoord", 1000000, time.Millisecond*200)
 		if err != nil {
 			log.Debug("Proxy wait for dataCoord ready failed", zap.Error(err))
 			return err
 		}
-		log.Debug("Proxy dataCoord is ready")
 	}
 
 	// wait for queryCoord state changed to Healthy
 	if node.queryCoord != nil {
-		log.Debug("Proxy wait for queryCoord ready")
 		err := funcutil.WaitForComponentHealthy(node.ctx, node.queryCoord, "QueryCoord", 1000000, time.Millisecond*200)
 		if err != nil {
 			log.Debug("Proxy wait for queryCoord ready failed", zap.Error(err))
 			return err
 		}
-		log.Debug("Proxy queryCoord is ready")
 	}
 
 	// wait for indexcoord state changed to Healthy
 	if node.indexCoord != nil {
-		log.Debug("Proxy wait for indexCoord ready")
 		err := funcutil.WaitForComponentHealthy(node.ctx, node.indexCoord, "IndexCoord", 1000000, time.Millisecond*200)
 		if err != nil {
 			log.Debug("Proxy wait for indexCoord ready failed", zap.Error(err))
 			return err
 		}
-		log.Debug("Proxy indexCoord is ready")
 	}
 
 	if node.queryCoord != nil {
@@ -168,7 +173,8 @@ func (node *Proxy) Init() error {
 
 	m := map[string]interface{}{
 		"PulsarAddress": Params.PulsarAddress,
-		"PulsarBufSize": 1024}
+		"PulsarBufSize": 1024,
+		"ProducerBatch": Params.ProducerBatch}
 	err := node.msFactory.SetParams(m)
 	if err != nil {
 		return err
@@ -208,6 +214,11 @@ func (node *Proxy) Init() error {
 
 	node.metricsCacheManager = metricsinfo.NewMetricsCacheManager()
 
+	node.rateLimiter = newRateLimiter()
+
+	node.configUpdater = paramtable.NewConfigUpdater(&Params.BaseTable)
+	node.rateLimiter.registerConfigs(node.configUpdater)
+
 	return nil
 }
 
@@ -283,6 +294,30 @@ func (node *Proxy) sendChannelsTimeTickLoop() {
 	}()
 }
 
+// refreshMetaCacheLoop starts a goroutine that refreshes the expired shards in the meta cache.
+func (node *Proxy) refreshMetaCacheLoop() {
+	ttl := Params.MetaCacheShardsTTL
+	if ttl <= 0 {
+		return
+	}
+	node.wg.Add(1)
+	go func() {
+		defer node.wg.Done()
+
+		ticker := time.NewTicker(ttl / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-node.ctx.Done():
+				return
+			case <-ticker.C:
+				globalMetaCache.RefreshExpiredShards(node.ctx, ttl)
+			}
+		}
+	}()
+}
+
 // Start starts a proxy node.
 func (node *Proxy) Start() error {
 	err := InitMetaCache(node.rootCoord)
@@ -313,6 +348,7 @@ func (node *Proxy) Start() error {
 	log.Debug("start channelsTimeTicker")
 
 	node.sendChannelsTimeTickLoop()
+	node.refreshMetaCacheLoop()
 
 	// Start callbacks
 	for _, cb := range node.startCallbacks {
@@ -347,6 +383,9 @@ func (node *Proxy) Stop() error {
 			return err
 		}
 	}
+	if node.shardClients != nil {
+		node.shardClients.Close()
+	}
 
 	node.wg.Wait()
 
@@ -387,3 +426,9 @@ func (node *Proxy) SetDataCoordClient(cli types.DataCoord) {
 func (node *Proxy) SetQueryCoordClient(cli types.QueryCoord) {
 	node.queryCoord = cli
 }
+
+// SetQueryNodeCreator sets the function creating the clients of the shard leaders,
+// searches are sent to the query channel only if it's not set.
+func (node *Proxy) SetQueryNodeCreator(creator func(ctx context.Context, addr string) (types.QueryNode, error)) {
+	node.shardClients = shardclient.NewManager(node.ctx, creator)
+}
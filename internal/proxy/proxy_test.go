@@ -1000,6 +1000,83 @@ func TestProxy(t *testing.T) {
 		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	wg.Add(1)
+	t.Run("partition key", func(t *testing.T) {
+		defer wg.Done()
+		partitionKeyCollectionName := collectionName + "_partition_key_" + funcutil.GenRandomStr()
+		partitionKeyField := "tenant"
+		partitionNum := int64(4)
+
+		partitionKeySchema := constructCollectionSchema()
+		partitionKeySchema.Name = partitionKeyCollectionName
+		partitionKeySchema.Fields = append(partitionKeySchema.Fields, &schemapb.FieldSchema{
+			Name:           partitionKeyField,
+			DataType:       schemapb.DataType_Int64,
+			IsPartitionKey: true,
+		})
+		bs, err := proto.Marshal(partitionKeySchema)
+		assert.NoError(t, err)
+
+		resp, err := proxy.CreateCollection(ctx, &milvuspb.CreateCollectionRequest{
+			DbName:         dbName,
+			CollectionName: partitionKeyCollectionName,
+			Schema:         bs,
+			ShardsNum:      shardsNum,
+			NumPartitions:  partitionNum,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+
+		showResp, err := proxy.ShowPartitions(ctx, &milvuspb.ShowPartitionsRequest{
+			DbName:         dbName,
+			CollectionName: partitionKeyCollectionName,
+			Type:           milvuspb.ShowType_All,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, showResp.Status.ErrorCode)
+		// default partition and the partitions of partition key
+		assert.Equal(t, int(partitionNum)+1, len(showResp.PartitionNames))
+		for i := int64(0); i < partitionNum; i++ {
+			assert.Contains(t, showResp.PartitionNames, partitionKeyPartitionName(i))
+		}
+
+		// the partitions are managed by proxy
+		resp, err = proxy.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{
+			DbName:         dbName,
+			CollectionName: partitionKeyCollectionName,
+			PartitionName:  partitionName,
+		})
+		assert.NoError(t, err)
+		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+
+		fVecColumn := newFloatVectorFieldData(floatVecField, rowNum, dim)
+		keyColumn := newScalarFieldData(schemapb.DataType_Int64, partitionKeyField, rowNum)
+		insertReq := &milvuspb.InsertRequest{
+			DbName:         dbName,
+			CollectionName: partitionKeyCollectionName,
+			FieldsData:     []*schemapb.FieldData{fVecColumn, keyColumn},
+			HashKeys:       generateHashKeys(rowNum),
+			NumRows:        uint32(rowNum),
+		}
+		insertResp, err := proxy.Insert(ctx, insertReq)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, insertResp.Status.ErrorCode)
+		assert.Equal(t, int64(rowNum), insertResp.InsertCnt)
+
+		// partition name can't be specified with partition key
+		insertReq.PartitionName = partitionKeyPartitionName(0)
+		insertResp, err = proxy.Insert(ctx, insertReq)
+		assert.NoError(t, err)
+		assert.NotEqual(t, commonpb.ErrorCode_Success, insertResp.Status.ErrorCode)
+
+		resp, err = proxy.DropCollection(ctx, &milvuspb.DropCollectionRequest{
+			DbName:         dbName,
+			CollectionName: partitionKeyCollectionName,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	wg.Add(1)
 	t.Run("insert", func(t *testing.T) {
 		defer wg.Done()
@@ -1205,7 +1282,7 @@ func TestProxy(t *testing.T) {
 		assert.Equal(t, 1, len(resp.CollectionNames))
 		assert.Equal(t, 1, len(resp.InMemoryPercentages))
 
-		// get in-memory percentage of  not loaded collection -> fail
+		// get in-memory percentage of not loaded collection -> 0
 		resp, err = proxy.ShowCollections(ctx, &milvuspb.ShowCollectionsRequest{
 			Base:            nil,
 			DbName:          dbName,
@@ -1214,7 +1291,8 @@ func TestProxy(t *testing.T) {
 			CollectionNames: []string{otherCollectionName},
 		})
 		assert.NoError(t, err)
-		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, []int64{0}, resp.InMemoryPercentages)
 	})
 
 	if loaded {
@@ -1281,8 +1359,7 @@ func TestProxy(t *testing.T) {
 			},
 		}
 
-		//resp, err := proxy.CalcDistance(ctx, &milvuspb.CalcDistanceRequest{
-		_, err := proxy.CalcDistance(ctx, &milvuspb.CalcDistanceRequest{
+		resp, err := proxy.CalcDistance(ctx, &milvuspb.CalcDistanceRequest{
 			Base:    nil,
 			OpLeft:  opLeft,
 			OpRight: opRight,
@@ -1294,8 +1371,8 @@ func TestProxy(t *testing.T) {
 			},
 		})
 		assert.NoError(t, err)
-		// assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
-		// TODO(dragondriver): compare distance
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, nq*nq, len(resp.GetFloatDist().GetData()))
 
 		// TODO(dragondriver): use primary key to calculate distance
 	})
@@ -1462,7 +1539,7 @@ func TestProxy(t *testing.T) {
 		// default partition?
 		assert.Equal(t, 1, len(resp.PartitionNames))
 
-		// show partition not in-memory -> fail
+		// show partition not in-memory -> 0
 		resp, err = proxy.ShowPartitions(ctx, &milvuspb.ShowPartitionsRequest{
 			Base:           nil,
 			DbName:         dbName,
@@ -1472,7 +1549,8 @@ func TestProxy(t *testing.T) {
 			Type:           milvuspb.ShowType_InMemory,
 		})
 		assert.NoError(t, err)
-		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, []int64{0}, resp.InMemoryPercentages)
 
 		// non-exist collection -> fail
 		resp, err = proxy.ShowPartitions(ctx, &milvuspb.ShowPartitionsRequest{
@@ -1528,7 +1606,8 @@ func TestProxy(t *testing.T) {
 			Type:           milvuspb.ShowType_InMemory,
 		})
 		assert.NoError(t, err)
-		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, []int64{0}, resp.InMemoryPercentages)
 	})
 
 	wg.Add(1)
@@ -1940,6 +2019,14 @@ func TestProxy(t *testing.T) {
 		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 	})
 
+	wg.Add(1)
+	t.Run("LoadBalance fail, unhealthy", func(t *testing.T) {
+		defer wg.Done()
+		resp, err := proxy.LoadBalance(ctx, &milvuspb.LoadBalanceRequest{})
+		assert.NoError(t, err)
+		assert.NotEqual(t, commonpb.ErrorCode_Success, resp.ErrorCode)
+	})
+
 	wg.Add(1)
 	t.Run("RegisterLink fail, unhealthy", func(t *testing.T) {
 		defer wg.Done()
@@ -26,6 +26,10 @@ func SetQueryCoordShowCollectionsFunc(f queryCoordShowCollectionsFuncType) Query
 	}
 }
 
+type queryCoordShowPartitionsFuncType func(ctx context.Context, request *querypb.ShowPartitionsRequest) (*querypb.ShowPartitionsResponse, error)
+
+type queryCoordGetShardLeadersFuncType func(ctx context.Context, request *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error)
+
 type QueryCoordMock struct {
 	nodeID  typeutil.UniqueID
 	address string
@@ -37,6 +41,8 @@ type QueryCoordMock struct {
 	colMtx              sync.RWMutex
 
 	showCollectionsFunc queryCoordShowCollectionsFuncType
+	showPartitionsFunc  queryCoordShowPartitionsFuncType
+	getShardLeadersFunc queryCoordGetShardLeadersFuncType
 	getMetricsFunc      getMetricsFuncType
 
 	statisticsChannel string
@@ -120,6 +126,14 @@ func (coord *QueryCoordMock) SetShowCollectionsFunc(f queryCoordShowCollectionsF
 	coord.showCollectionsFunc = f
 }
 
+func (coord *QueryCoordMock) SetShowPartitionsFunc(f queryCoordShowPartitionsFuncType) {
+	coord.showPartitionsFunc = f
+}
+
+func (coord *QueryCoordMock) SetGetShardLeadersFunc(f queryCoordGetShardLeadersFuncType) {
+	coord.getShardLeadersFunc = f
+}
+
 func (coord *QueryCoordMock) ShowCollections(ctx context.Context, req *querypb.ShowCollectionsRequest) (*querypb.ShowCollectionsResponse, error) {
 	if !coord.healthy() {
 		return &querypb.ShowCollectionsResponse{
@@ -217,6 +231,10 @@ func (coord *QueryCoordMock) ShowPartitions(ctx context.Context, req *querypb.Sh
 		}, nil
 	}
 
+	if coord.showPartitionsFunc != nil {
+		return coord.showPartitionsFunc(ctx, req)
+	}
+
 	panic("implement me")
 }
 
@@ -281,6 +299,54 @@ func (coord *QueryCoordMock) GetSegmentInfo(ctx context.Context, req *querypb.Ge
 	panic("implement me")
 }
 
+func (coord *QueryCoordMock) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+	if !coord.healthy() {
+		return &querypb.GetShardLeadersResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    "unhealthy",
+			},
+		}, nil
+	}
+
+	if coord.getShardLeadersFunc != nil {
+		return coord.getShardLeadersFunc(ctx, req)
+	}
+
+	return &querypb.GetShardLeadersResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    "not implemented",
+		},
+	}, nil
+}
+
+func (coord *QueryCoordMock) LoadBalance(ctx context.Context, req *querypb.LoadBalanceRequest) (*commonpb.Status, error) {
+	if !coord.healthy() {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    "unhealthy",
+		}, nil
+	}
+
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
+func (coord *QueryCoordMock) ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	if !coord.healthy() {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    "unhealthy",
+		}, nil
+	}
+
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}, nil
+}
+
 func (coord *QueryCoordMock) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	if !coord.healthy() {
 		return &milvuspb.GetMetricsResponse{
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// retryAfterKey is the grpc header which tells the client how long to wait before retrying a rate limited request
+const retryAfterKey = "retry-after-ms"
+
+// tokenBucket is a token bucket refilled at limit tokens per second, which holds at most one second of tokens.
+type tokenBucket struct {
+	limit  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: limit,
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.limit, b.tokens+elapsed.Seconds()*b.limit)
+		b.last = now
+	}
+}
+
+// wait returns how long to wait until n tokens are available, 0 is returned if they are available now.
+// A request larger than the bucket is allowed once the bucket is full, the bucket goes into debt then.
+func (b *tokenBucket) wait(n float64) time.Duration {
+	if b.tokens >= n || b.tokens >= b.limit {
+		return 0
+	}
+	lack := math.Min(n, b.limit) - b.tokens
+	return time.Duration(math.Ceil(lack / b.limit * float64(time.Second)))
+}
+
+func (b *tokenBucket) setLimit(limit float64) {
+	b.limit = limit
+	if b.tokens > limit {
+		b.tokens = limit
+	}
+}
+
+// rateCost is the cost of a request on one kind of rate
+type rateCost struct {
+	rateType proxypb.RateType
+	cost     float64
+}
+
+// rateLimiter limits the DML and DQL rates of every collection with token buckets.
+// The requests exceeding the limits are rejected rather than queued.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limits  map[proxypb.RateType]float64
+	buckets map[proxypb.RateType]map[string]*tokenBucket // rate type -> collection name -> bucket
+	now     func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	rl := &rateLimiter{
+		limits:  make(map[proxypb.RateType]float64),
+		buckets: make(map[proxypb.RateType]map[string]*tokenBucket),
+		now:     time.Now,
+	}
+	rl.setLimit(proxypb.RateType_DMLRows, Params.DMLRowsRateLimit)
+	rl.setLimit(proxypb.RateType_DMLBytes, Params.DMLBytesRateLimit)
+	rl.setLimit(proxypb.RateType_DQLRequests, Params.DQLRequestsRateLimit)
+	return rl
+}
+
+// setLimit sets the limit of rateType per second, the rate is not limited if limit is not positive
+func (rl *rateLimiter) setLimit(rateType proxypb.RateType, limit float64) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if limit <= 0 {
+		delete(rl.limits, rateType)
+		delete(rl.buckets, rateType)
+		return
+	}
+	rl.limits[rateType] = limit
+	if rl.buckets[rateType] == nil {
+		rl.buckets[rateType] = make(map[string]*tokenBucket)
+	}
+	for _, bucket := range rl.buckets[rateType] {
+		bucket.setLimit(limit)
+	}
+}
+
+// getLimit returns the limit of rateType per second, 0 is returned if the rate is not limited
+func (rl *rateLimiter) getLimit(rateType proxypb.RateType) float64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.limits[rateType]
+}
+
+// allow checks the costs of a request on collection against the limits. The costs are consumed only if all of
+// them are within the limits, otherwise the duration to wait before retrying is returned.
+func (rl *rateLimiter) allow(collection string, costs ...rateCost) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := rl.now()
+	var retryAfter time.Duration
+	for _, c := range costs {
+		limit, ok := rl.limits[c.rateType]
+		if !ok {
+			continue
+		}
+		bucket, ok := rl.buckets[c.rateType][collection]
+		if !ok {
+			bucket = newTokenBucket(limit, now)
+			rl.buckets[c.rateType][collection] = bucket
+		}
+		bucket.refill(now)
+		if wait := bucket.wait(c.cost); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		return false, retryAfter
+	}
+	for _, c := range costs {
+		if bucket, ok := rl.buckets[c.rateType][collection]; ok {
+			bucket.tokens -= c.cost
+		}
+	}
+	return true, 0
+}
+
+// rateLimitConfigKeys are the keys of the rate limits which can be updated at runtime
+var rateLimitConfigKeys = map[string]proxypb.RateType{
+	"proxy.rateLimit.dmlRows":     proxypb.RateType_DMLRows,
+	"proxy.rateLimit.dmlBytes":    proxypb.RateType_DMLBytes,
+	"proxy.rateLimit.dqlRequests": proxypb.RateType_DQLRequests,
+}
+
+// registerConfigs whitelists the rate limits in updater, a limit should be a non-negative number and 0 means no limit
+func (rl *rateLimiter) registerConfigs(updater *paramtable.ConfigUpdater) {
+	for key, rateType := range rateLimitConfigKeys {
+		rateType := rateType
+		updater.Register(key, paramtable.ConfigHandler{
+			Validate: func(value string) error {
+				limit, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return err
+				}
+				if limit < 0 || math.IsNaN(limit) || math.IsInf(limit, 0) {
+					return fmt.Errorf("rate limit should be a non-negative number")
+				}
+				return nil
+			},
+			Apply: func(value string) {
+				limit, _ := strconv.ParseFloat(value, 64)
+				rl.setLimit(rateType, limit)
+			},
+			Get: func() string {
+				return strconv.FormatFloat(rl.getLimit(rateType), 'f', -1, 64)
+			},
+		})
+	}
+}
+
+// checkRateLimit returns the RateLimit status if the request on collection exceeds the rate limits,
+// nil is returned if the request is allowed.
+func (node *Proxy) checkRateLimit(ctx context.Context, collection string, costs ...rateCost) *commonpb.Status {
+	ok, retryAfter := node.rateLimiter.allow(collection, costs...)
+	if ok {
+		return nil
+	}
+	retryAfterMs := retryAfter.Milliseconds()
+	if retryAfterMs == 0 {
+		retryAfterMs = 1
+	}
+	// the header is best effort, it fails if the request doesn't come from grpc
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterKey, strconv.FormatInt(retryAfterMs, 10)))
+	log.Debug("request is rate limited",
+		zap.String("collection", collection),
+		zap.Int64("retryAfterMs", retryAfterMs))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_RateLimit,
+		Reason:    fmt.Sprintf("rate limit exceeded on collection %s, retry after %dms", collection, retryAfterMs),
+	}
+}
+
+// getDeleteRowNum returns the number of rows deleted by request. The expression is parsed only if the DML rows
+// are limited, and the request is counted as one row if the expression is not a term expression on the primary key,
+// whose matched rows are unknown before retrieval.
+func (node *Proxy) getDeleteRowNum(ctx context.Context, request *milvuspb.DeleteRequest) int {
+	if node.rateLimiter.getLimit(proxypb.RateType_DMLRows) <= 0 || globalMetaCache == nil {
+		return 0
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, request.CollectionName)
+	if err != nil {
+		return 1
+	}
+	pks, err := getPrimaryKeysFromExpr(schema, request.Expr)
+	if err != nil || len(pks) == 0 {
+		return 1
+	}
+	return len(pks)
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/proxypb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// newTestRateLimiter returns a rate limiter without any limit whose clock only moves by the returned function
+func newTestRateLimiter() (*rateLimiter, func(time.Duration)) {
+	now := time.Now()
+	rl := &rateLimiter{
+		limits:  make(map[proxypb.RateType]float64),
+		buckets: make(map[proxypb.RateType]map[string]*tokenBucket),
+		now:     func() time.Time { return now },
+	}
+	return rl, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, now)
+	assert.Equal(t, time.Duration(0), b.wait(10))
+	b.tokens -= 10
+	assert.Equal(t, 100*time.Millisecond, b.wait(1))
+
+	b.refill(now.Add(500 * time.Millisecond))
+	assert.Equal(t, float64(5), b.tokens)
+
+	// at most one second of tokens
+	b.refill(now.Add(10 * time.Second))
+	assert.Equal(t, float64(10), b.tokens)
+
+	// a request larger than the bucket passes once the bucket is full
+	assert.Equal(t, time.Duration(0), b.wait(100))
+	b.tokens = 5
+	assert.Equal(t, 500*time.Millisecond, b.wait(100))
+
+	b.setLimit(2)
+	assert.Equal(t, float64(2), b.tokens)
+}
+
+func TestRateLimiter_allow(t *testing.T) {
+	rl, advance := newTestRateLimiter()
+
+	t.Run("not limited", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			ok, _ := rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("below the limit", func(t *testing.T) {
+		rl.setLimit(proxypb.RateType_DQLRequests, 10)
+		for i := 0; i < 100; i++ {
+			ok, _ := rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+			assert.True(t, ok)
+			advance(100 * time.Millisecond)
+		}
+	})
+
+	t.Run("above the limit", func(t *testing.T) {
+		rl.setLimit(proxypb.RateType_DQLRequests, 10)
+		advance(time.Second)
+		allowed := 0
+		for i := 0; i < 100; i++ {
+			ok, retryAfter := rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+			if ok {
+				allowed++
+			} else {
+				assert.Equal(t, 100*time.Millisecond, retryAfter)
+			}
+		}
+		assert.Equal(t, 10, allowed)
+
+		// the limit is per collection
+		ok, _ := rl.allow("another", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+		assert.True(t, ok)
+
+		advance(100 * time.Millisecond)
+		ok, _ = rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+		assert.True(t, ok)
+	})
+
+	t.Run("costs are consumed only if all are allowed", func(t *testing.T) {
+		rl.setLimit(proxypb.RateType_DMLRows, 100)
+		rl.setLimit(proxypb.RateType_DMLBytes, 1000)
+		ok, retryAfter := rl.allow("dml",
+			rateCost{rateType: proxypb.RateType_DMLRows, cost: 10},
+			rateCost{rateType: proxypb.RateType_DMLBytes, cost: 1000})
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), retryAfter)
+
+		ok, retryAfter = rl.allow("dml",
+			rateCost{rateType: proxypb.RateType_DMLRows, cost: 10},
+			rateCost{rateType: proxypb.RateType_DMLBytes, cost: 500})
+		assert.False(t, ok)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+		assert.Equal(t, float64(90), rl.buckets[proxypb.RateType_DMLRows]["dml"].tokens)
+	})
+
+	t.Run("adjust limit", func(t *testing.T) {
+		rl.setLimit(proxypb.RateType_DQLRequests, 1)
+		advance(time.Second)
+		ok, _ := rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+		assert.True(t, ok)
+		ok, _ = rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+		assert.False(t, ok)
+
+		rl.setLimit(proxypb.RateType_DQLRequests, 0)
+		assert.Equal(t, float64(0), rl.getLimit(proxypb.RateType_DQLRequests))
+		ok, _ = rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+		assert.True(t, ok)
+	})
+}
+
+func TestProxy_rateLimit(t *testing.T) {
+	ctx := context.Background()
+	rl, _ := newTestRateLimiter()
+	node := &Proxy{rateLimiter: rl}
+	node.UpdateStateCode(internalpb.StateCode_Healthy)
+
+	status, err := node.SetRateLimit(ctx, &proxypb.SetRateLimitRequest{
+		Limits: []*proxypb.RateLimit{{RateType: proxypb.RateType(100), Limit: 1}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, status.ErrorCode)
+
+	status, err = node.SetRateLimit(ctx, &proxypb.SetRateLimitRequest{
+		Limits: []*proxypb.RateLimit{
+			{RateType: proxypb.RateType_DMLRows, Limit: 10},
+			{RateType: proxypb.RateType_DMLBytes, Limit: 1024 * 1024},
+			{RateType: proxypb.RateType_DQLRequests, Limit: 1},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+	assert.Equal(t, float64(10), rl.getLimit(proxypb.RateType_DMLRows))
+	assert.Equal(t, float64(1024*1024), rl.getLimit(proxypb.RateType_DMLBytes))
+	assert.Equal(t, float64(1), rl.getLimit(proxypb.RateType_DQLRequests))
+
+	// exhaust the buckets, the requests are rejected before they are enqueued
+	assert.Nil(t, node.checkRateLimit(ctx, "collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1}))
+	assert.Nil(t, node.checkRateLimit(ctx, "collection", rateCost{rateType: proxypb.RateType_DMLRows, cost: 10}))
+
+	searchResult, err := node.Search(ctx, &milvuspb.SearchRequest{CollectionName: "collection"})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, searchResult.Status.ErrorCode)
+	assert.Contains(t, searchResult.Status.Reason, "retry after 1000ms")
+
+	queryResult, err := node.Query(ctx, &milvuspb.QueryRequest{CollectionName: "collection"})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, queryResult.Status.ErrorCode)
+
+	insertResult, err := node.Insert(ctx, &milvuspb.InsertRequest{CollectionName: "collection", NumRows: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, insertResult.Status.ErrorCode)
+
+	// the limits are per collection
+	assert.Nil(t, node.checkRateLimit(ctx, "another", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1}))
+
+	node.UpdateStateCode(internalpb.StateCode_Abnormal)
+	status, err = node.SetRateLimit(ctx, &proxypb.SetRateLimitRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+}
+
+func TestProxy_UpdateConfig(t *testing.T) {
+	ctx := context.Background()
+	level := log.GetLevel()
+	defer log.SetLevel(level)
+	log.SetLevel(zap.InfoLevel)
+
+	rl, _ := newTestRateLimiter()
+	node := &Proxy{
+		rateLimiter:   rl,
+		configUpdater: paramtable.NewConfigUpdater(nil),
+	}
+	rl.registerConfigs(node.configUpdater)
+	node.UpdateStateCode(internalpb.StateCode_Healthy)
+
+	resp, err := node.UpdateConfig(ctx, &proxypb.UpdateConfigRequest{
+		Configs: []*commonpb.KeyValuePair{
+			{Key: "log.level", Value: "debug"},
+			{Key: "proxy.rateLimit.dqlRequests", Value: "1"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	assert.Equal(t, []*commonpb.KeyValuePair{
+		{Key: "log.level", Value: "debug"},
+		{Key: "proxy.rateLimit.dqlRequests", Value: "1"},
+	}, resp.Configs)
+	assert.Equal(t, zap.DebugLevel, log.GetLevel())
+	ok, _ := rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+	assert.True(t, ok)
+	ok, _ = rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+	assert.False(t, ok)
+
+	// nothing is applied if any config is not whitelisted or invalid
+	for _, configs := range [][]*commonpb.KeyValuePair{
+		{{Key: "log.level", Value: "info"}, {Key: "proxy.timeTickInterval", Value: "100"}},
+		{{Key: "log.level", Value: "info"}, {Key: "proxy.rateLimit.dqlRequests", Value: "-1"}},
+		{{Key: "log.level", Value: "info"}, {Key: "proxy.rateLimit.dqlRequests", Value: "many"}},
+		{{Key: "log.level", Value: "info"}, {Key: "log.level", Value: "warn"}},
+	} {
+		resp, err = node.UpdateConfig(ctx, &proxypb.UpdateConfigRequest{Configs: configs})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, resp.Status.ErrorCode)
+		assert.Equal(t, zap.DebugLevel, log.GetLevel())
+		assert.Equal(t, float64(1), rl.getLimit(proxypb.RateType_DQLRequests))
+	}
+
+	// 0 removes the limit
+	req, err := metricsinfo.ConstructUpdateConfigRequest(map[string]string{"proxy.rateLimit.dqlRequests": "0"})
+	assert.NoError(t, err)
+	metrics, err := node.GetMetrics(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, metrics.Status.ErrorCode)
+	assert.JSONEq(t, `{"proxy.rateLimit.dqlRequests":"0"}`, metrics.Response)
+	ok, _ = rl.allow("collection", rateCost{rateType: proxypb.RateType_DQLRequests, cost: 1})
+	assert.True(t, ok)
+
+	node.UpdateStateCode(internalpb.StateCode_Abnormal)
+	resp, err = node.UpdateConfig(ctx, &proxypb.UpdateConfigRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+}
@@ -49,6 +49,7 @@ type collectionMeta struct {
 	physicalChannelNames []string
 	createdTimestamp     uint64
 	createdUtcTimestamp  uint64
+	properties           []*commonpb.KeyValuePair
 }
 
 type partitionMeta struct {
@@ -70,6 +71,8 @@ type describeCollectionFuncType func(ctx context.Context, request *milvuspb.Desc
 
 type showPartitionsFuncType func(ctx context.Context, request *milvuspb.ShowPartitionsRequest) (*milvuspb.ShowPartitionsResponse, error)
 
+type describeIndexFuncType func(ctx context.Context, request *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error)
+
 type RootCoordMock struct {
 	nodeID  typeutil.UniqueID
 	address string
@@ -91,6 +94,7 @@ type RootCoordMock struct {
 
 	describeCollectionFunc describeCollectionFuncType
 	showPartitionsFunc     showPartitionsFuncType
+	describeIndexFunc      describeIndexFuncType
 	getMetricsFunc         getMetricsFuncType
 
 	// TODO(dragondriver): index-related
@@ -195,6 +199,39 @@ func (coord *RootCoordMock) AlterAlias(ctx context.Context, req *milvuspb.AlterA
 	}, nil
 }
 
+func (coord *RootCoordMock) AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	code := coord.state.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    fmt.Sprintf("state code = %s", internalpb.StateCode_name[int32(code)]),
+		}, nil
+	}
+	if err := common.ValidateCollectionProperties(req.Properties); err != nil {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_IllegalArgument,
+			Reason:    err.Error(),
+		}, nil
+	}
+	coord.collMtx.Lock()
+	defer coord.collMtx.Unlock()
+
+	collID, exist := coord.collName2ID[req.CollectionName]
+	if !exist {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_CollectionNotExists,
+			Reason:    milvuserrors.MsgCollectionNotExist(req.CollectionName),
+		}, nil
+	}
+	meta := coord.collID2Meta[collID]
+	meta.properties = common.MergeCollectionProperties(meta.properties, req.Properties)
+	coord.collID2Meta[collID] = meta
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+		Reason:    "",
+	}, nil
+}
+
 func (coord *RootCoordMock) updateState(state internalpb.StateCode) {
 	coord.state.Store(state)
 }
@@ -483,6 +520,7 @@ func (coord *RootCoordMock) DescribeCollection(ctx context.Context, req *milvusp
 		PhysicalChannelNames: meta.physicalChannelNames,
 		CreatedTimestamp:     meta.createdUtcTimestamp,
 		CreatedUtcTimestamp:  meta.createdUtcTimestamp,
+		Properties:           meta.properties,
 	}, nil
 }
 
@@ -736,6 +774,9 @@ func (coord *RootCoordMock) DescribeIndex(ctx context.Context, req *milvuspb.Des
 			IndexDescriptions: nil,
 		}, nil
 	}
+	if coord.describeIndexFunc != nil {
+		return coord.describeIndexFunc(ctx, req)
+	}
 	return &milvuspb.DescribeIndexResponse{
 		Status: &commonpb.Status{
 			ErrorCode: commonpb.ErrorCode_Success,
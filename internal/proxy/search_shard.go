@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// searchShardsAttempts is the number of attempts to search through the shard leaders, the shard leaders are
+// fetched again for every attempt, since a shard leader may go down after they are returned by query coordinator.
+const searchShardsAttempts = 2
+
+// partialResultsReserveDivisor decides the time reserved to reduce the partial results, the proxy stops waiting
+// for the shards once 1/partialResultsReserveDivisor of the time before the deadline is left.
+const partialResultsReserveDivisor = 10
+
+// searchShards searches all the shards of the collection through their shard leaders directly, the missing shards
+// are the ones not replied in time, they are only allowed if the search allows partial results.
+func (st *searchTask) searchShards(ctx context.Context) ([]*internalpb.SearchResults, []string, error) {
+	var err error
+	for i := 0; i < searchShardsAttempts; i++ {
+		var shards []*querypb.ShardLeader
+		shards, err = st.getShardLeaders(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		var results []*internalpb.SearchResults
+		var missingShards []string
+		results, missingShards, err = st.searchShardLeaders(ctx, shards)
+		if err == nil {
+			return results, missingShards, nil
+		}
+		log.Debug("search shard leaders failed",
+			zap.Int64("collectionID", st.CollectionID),
+			zap.Int64("msgID", st.ID()),
+			zap.Int("attempt", i+1),
+			zap.Error(err))
+	}
+	return nil, nil, err
+}
+
+func (st *searchTask) getShardLeaders(ctx context.Context) ([]*querypb.ShardLeader, error) {
+	resp, err := st.qc.GetShardLeaders(ctx, &querypb.GetShardLeadersRequest{
+		Base: &commonpb.MsgBase{
+			MsgID:     st.Base.MsgID,
+			Timestamp: st.Base.Timestamp,
+			SourceID:  st.Base.SourceID,
+		},
+		CollectionID: st.CollectionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return nil, errors.New(resp.Status.Reason)
+	}
+	if len(resp.Shards) == 0 {
+		return nil, fmt.Errorf("no shard leader of collection %d", st.CollectionID)
+	}
+	return resp.Shards, nil
+}
+
+// searchShardLeaders sends the search to every shard leader, the sealed segments held by other query nodes
+// are searched through the shard leader as well. If the search allows partial results, the shards not replied
+// before the partial deadline are returned as missing instead of failing the search.
+func (st *searchTask) searchShardLeaders(ctx context.Context, shards []*querypb.ShardLeader) ([]*internalpb.SearchResults, []string, error) {
+	shardCtx, cancel := st.shardSearchContext(ctx)
+	defer cancel()
+
+	type shardReply struct {
+		idx     int
+		results []*internalpb.SearchResults
+		stats   *milvuspb.ShardSearchStats
+		err     error
+	}
+	// buffered so that the shards replying after the partial deadline don't block
+	replies := make(chan shardReply, len(shards))
+	for i, shard := range shards {
+		go func(i int, shard *querypb.ShardLeader) {
+			results, stats, err := st.searchShardLeader(shardCtx, shard)
+			replies <- shardReply{idx: i, results: results, stats: stats, err: err}
+		}(i, shard)
+	}
+
+	shardResults := make([][]*internalpb.SearchResults, len(shards))
+	shardStats := make([]*milvuspb.ShardSearchStats, len(shards))
+	replied := make([]bool, len(shards))
+	expired := false
+	for n := 0; n < len(shards) && !expired; n++ {
+		select {
+		case reply := <-replies:
+			shard := shards[reply.idx]
+			if reply.err != nil && !(st.allowPartial && shardCtx.Err() != nil) {
+				return nil, nil, fmt.Errorf("search shard %s through query node %d failed, %s", shard.ChannelName, shard.NodeID, reply.err.Error())
+			}
+			if reply.err == nil {
+				shardResults[reply.idx] = reply.results
+				shardStats[reply.idx] = reply.stats
+				replied[reply.idx] = true
+			}
+		case <-shardCtx.Done():
+			expired = true
+		}
+	}
+	if expired && !st.allowPartial {
+		return nil, nil, fmt.Errorf("search shards failed, %s", shardCtx.Err())
+	}
+
+	results := make([]*internalpb.SearchResults, 0)
+	var missingShards []string
+	var stats []*milvuspb.ShardSearchStats
+	resultShards := make(map[*internalpb.SearchResults]int)
+	for i, shard := range shards {
+		if !replied[i] {
+			missingShards = append(missingShards, shard.ChannelName)
+			continue
+		}
+		for _, result := range shardResults[i] {
+			resultShards[result] = len(stats)
+		}
+		stats = append(stats, shardStats[i])
+		results = append(results, shardResults[i]...)
+	}
+	if len(missingShards) == len(shards) {
+		return nil, nil, fmt.Errorf("no shard replied before the deadline, %s", shardCtx.Err())
+	}
+	if st.withShardStats {
+		st.shardStats, st.resultShards = stats, resultShards
+	}
+	if len(missingShards) > 0 {
+		log.Debug("some shards didn't reply before the deadline",
+			zap.Int64("collectionID", st.CollectionID),
+			zap.Int64("msgID", st.ID()),
+			zap.Strings("missingShards", missingShards))
+	}
+	return results, missingShards, nil
+}
+
+// shardSearchContext returns the context to search the shards, it expires a bit earlier than ctx if the search
+// allows partial results, so that the results of the replied shards could be reduced before ctx expires.
+func (st *searchTask) shardSearchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !st.allowPartial || !ok {
+		return context.WithCancel(ctx)
+	}
+	reserve := time.Until(deadline) / partialResultsReserveDivisor
+	return context.WithDeadline(ctx, deadline.Add(-reserve))
+}
+
+// searchShardLeader returns the results of the shard along with its search stats, the hits of the stats are
+// counted once the results are reduced
+func (st *searchTask) searchShardLeader(ctx context.Context, shard *querypb.ShardLeader) ([]*internalpb.SearchResults, *milvuspb.ShardSearchStats, error) {
+	req := &querypb.SearchRequest{
+		Req:        st.SearchRequest,
+		DmlChannel: shard.ChannelName,
+	}
+	for _, segments := range shard.SealedSegments {
+		if segments.NodeID == shard.NodeID {
+			req.SegmentIDs = append(req.SegmentIDs, segments.SegmentIDs...)
+		} else {
+			req.RemoteSegments = append(req.RemoteSegments, segments)
+		}
+	}
+
+	client, err := st.shardClients.GetClient(shard.NodeID, shard.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	resp, err := client.Search(ctx, req)
+	if err != nil {
+		// reconnect next time, the shard leader may have been restarted
+		st.shardClients.RemoveClient(shard.NodeID)
+		return nil, nil, err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return nil, nil, errors.New(resp.Status.Reason)
+	}
+	return resp.Results, newShardSearchStats(shard, resp, time.Since(start)), nil
+}
+
+func newShardSearchStats(shard *querypb.ShardLeader, resp *querypb.SearchResponse, roundTrip time.Duration) *milvuspb.ShardSearchStats {
+	stats := &milvuspb.ShardSearchStats{
+		ChannelName:   shard.ChannelName,
+		NodeID:        shard.NodeID,
+		NodeLatencyUs: resp.LatencyUs,
+	}
+	for _, result := range resp.Results {
+		stats.RowsScanned += result.RowsScanned
+		stats.SegmentsHit += result.SegmentsHit
+	}
+	// the clocks of the proxy and the query node are not compared, only the durations
+	if transport := roundTrip.Microseconds() - resp.LatencyUs; transport > 0 {
+		stats.TransportLatencyUs = transport
+	}
+	return stats
+}
+
+// fillShardStats sets the shard stats of the result if they are asked, decoded are the search results
+// reduced into the result in order and hits the numbers of the reduced results taken from each of them
+func (st *searchTask) fillShardStats(decoded []*internalpb.SearchResults, hits []int64) {
+	if !st.withShardStats || len(st.shardStats) == 0 {
+		return
+	}
+	for _, stats := range st.shardStats {
+		stats.Hits = 0
+	}
+	for i, result := range decoded {
+		if shard, ok := st.resultShards[result]; ok {
+			st.shardStats[shard].Hits += hits[i]
+		}
+	}
+	st.result.ShardStats = st.shardStats
+}
+
+// sendShardResults hands the results of the shard leaders over to PostExecute
+func (st *searchTask) sendShardResults(results []*internalpb.SearchResults) {
+	select {
+	case st.resultBuf <- results:
+	case <-st.TraceCtx().Done():
+	}
+}
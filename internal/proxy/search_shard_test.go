@@ -0,0 +1,413 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
+)
+
+type shardSearchQueryNode struct {
+	types.QueryNode
+	nodeID   int64
+	mu       sync.Mutex
+	requests []*querypb.SearchRequest
+	err      error
+	status   commonpb.ErrorCode
+	// block makes the node artificially slow, Search doesn't return until it's closed even if ctx expires
+	block chan struct{}
+	// results are returned instead of an empty result of the dm channel if set
+	results   []*internalpb.SearchResults
+	latencyUs int64
+}
+
+func (node *shardSearchQueryNode) Init() error  { return nil }
+func (node *shardSearchQueryNode) Start() error { return nil }
+func (node *shardSearchQueryNode) Stop() error  { return nil }
+
+func (node *shardSearchQueryNode) Search(ctx context.Context, req *querypb.SearchRequest) (*querypb.SearchResponse, error) {
+	node.mu.Lock()
+	node.requests = append(node.requests, req)
+	node.mu.Unlock()
+	if node.block != nil {
+		<-node.block
+	}
+	if node.err != nil {
+		return nil, node.err
+	}
+	if node.status != commonpb.ErrorCode_Success {
+		return &querypb.SearchResponse{
+			Status: &commonpb.Status{ErrorCode: node.status, Reason: "search failed"},
+		}, nil
+	}
+	if node.results != nil {
+		return &querypb.SearchResponse{
+			Status:    &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			Results:   node.results,
+			LatencyUs: node.latencyUs,
+		}, nil
+	}
+	return &querypb.SearchResponse{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Results: []*internalpb.SearchResults{
+			{
+				Status:             &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				ChannelIDsSearched: []string{req.DmlChannel},
+			},
+		},
+	}, nil
+}
+
+func newShardSearchTask(t *testing.T, qc types.QueryCoord, nodes map[string]*shardSearchQueryNode) *searchTask {
+	creator := func(ctx context.Context, addr string) (types.QueryNode, error) {
+		node, ok := nodes[addr]
+		if !ok {
+			return nil, errors.New("unknown address")
+		}
+		return node, nil
+	}
+	return &searchTask{
+		ctx:       context.Background(),
+		Condition: NewTaskCondition(context.Background()),
+		SearchRequest: &internalpb.SearchRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_Search,
+			},
+			CollectionID: 1,
+		},
+		resultBuf:    make(chan []*internalpb.SearchResults),
+		qc:           qc,
+		shardClients: shardclient.NewManager(context.Background(), creator),
+	}
+}
+
+func shardLeadersResponse(shards ...*querypb.ShardLeader) *querypb.GetShardLeadersResponse {
+	return &querypb.GetShardLeadersResponse{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Shards: shards,
+	}
+}
+
+func TestSearchTask_searchShards(t *testing.T) {
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+
+	t.Run("search through shard leaders", func(t *testing.T) {
+		node1 := &shardSearchQueryNode{nodeID: 1}
+		node2 := &shardSearchQueryNode{nodeID: 2}
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			assert.Equal(t, int64(1), req.CollectionID)
+			return shardLeadersResponse(
+				&querypb.ShardLeader{
+					ChannelName: "dml-0",
+					NodeID:      1,
+					Address:     "node1",
+					SealedSegments: []*querypb.SegmentsOnNode{
+						{NodeID: 1, NodeAddress: "node1", SegmentIDs: []int64{10}},
+						{NodeID: 3, NodeAddress: "node3", SegmentIDs: []int64{30}},
+					},
+				},
+				&querypb.ShardLeader{
+					ChannelName: "dml-1",
+					NodeID:      2,
+					Address:     "node2",
+				},
+			), nil
+		})
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": node1, "node2": node2})
+		defer st.shardClients.Close()
+
+		results, _, err := st.searchShards(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(results))
+
+		assert.Equal(t, 1, len(node1.requests))
+		req := node1.requests[0]
+		assert.Equal(t, "dml-0", req.DmlChannel)
+		assert.Equal(t, []int64{10}, req.SegmentIDs)
+		assert.Equal(t, 1, len(req.RemoteSegments))
+		assert.Equal(t, int64(3), req.RemoteSegments[0].NodeID)
+		assert.Equal(t, []int64{30}, req.RemoteSegments[0].SegmentIDs)
+
+		assert.Equal(t, 1, len(node2.requests))
+		assert.Equal(t, "dml-1", node2.requests[0].DmlChannel)
+		assert.Empty(t, node2.requests[0].SegmentIDs)
+		assert.Empty(t, node2.requests[0].RemoteSegments)
+	})
+
+	t.Run("shard leader down after get shard leaders", func(t *testing.T) {
+		down := &shardSearchQueryNode{nodeID: 1, err: errors.New("connection refused")}
+		standby := &shardSearchQueryNode{nodeID: 2}
+		attempt := 0
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			attempt++
+			if attempt == 1 {
+				return shardLeadersResponse(&querypb.ShardLeader{ChannelName: "dml-0", NodeID: 1, Address: "node1"}), nil
+			}
+			return shardLeadersResponse(&querypb.ShardLeader{ChannelName: "dml-0", NodeID: 2, Address: "node2"}), nil
+		})
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": down, "node2": standby})
+		defer st.shardClients.Close()
+
+		results, _, err := st.searchShards(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, 2, attempt)
+		assert.Equal(t, 1, len(down.requests))
+		assert.Equal(t, 1, len(standby.requests))
+	})
+
+	t.Run("all attempts failed", func(t *testing.T) {
+		failed := &shardSearchQueryNode{nodeID: 1, status: commonpb.ErrorCode_UnexpectedError}
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			return shardLeadersResponse(&querypb.ShardLeader{ChannelName: "dml-0", NodeID: 1, Address: "node1"}), nil
+		})
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": failed})
+		defer st.shardClients.Close()
+
+		_, _, err := st.searchShards(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, searchShardsAttempts, len(failed.requests))
+	})
+
+	t.Run("get shard leaders failed", func(t *testing.T) {
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			return nil, errors.New("mock")
+		})
+		st := newShardSearchTask(t, qc, nil)
+		_, _, err := st.searchShards(context.Background())
+		assert.Error(t, err)
+
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			return &querypb.GetShardLeadersResponse{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "not loaded"},
+			}, nil
+		})
+		_, _, err = st.searchShards(context.Background())
+		assert.Error(t, err)
+
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			return shardLeadersResponse(), nil
+		})
+		_, _, err = st.searchShards(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown shard leader address", func(t *testing.T) {
+		qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+			return shardLeadersResponse(&querypb.ShardLeader{ChannelName: "dml-0", NodeID: 1, Address: "node1"}), nil
+		})
+		st := newShardSearchTask(t, qc, nil)
+		_, _, err := st.searchShards(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchTask_searchShardsTimeout(t *testing.T) {
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+	qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+		return shardLeadersResponse(
+			&querypb.ShardLeader{ChannelName: "dml-0", NodeID: 1, Address: "node1"},
+			&querypb.ShardLeader{ChannelName: "dml-1", NodeID: 2, Address: "node2"},
+		), nil
+	})
+	const timeout = 200 * time.Millisecond
+
+	t.Run("partial results", func(t *testing.T) {
+		fast := &shardSearchQueryNode{nodeID: 1}
+		slow := &shardSearchQueryNode{nodeID: 2, block: make(chan struct{})}
+		defer close(slow.block)
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": fast, "node2": slow})
+		defer st.shardClients.Close()
+		st.allowPartial = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		results, missingShards, err := st.searchShards(ctx)
+		assert.NoError(t, err)
+		// returns before the deadline, so that the results could be reduced in time
+		assert.NoError(t, ctx.Err())
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, []string{"dml-0"}, results[0].ChannelIDsSearched)
+		assert.Equal(t, []string{"dml-1"}, missingShards)
+
+		st.result = &milvuspb.SearchResults{}
+		st.missingShards = missingShards
+		st.markPartialResult()
+		assert.True(t, st.result.PartialResult)
+		assert.Equal(t, []string{"dml-1"}, st.result.MissingShards)
+	})
+
+	t.Run("all or timeout by default", func(t *testing.T) {
+		fast := &shardSearchQueryNode{nodeID: 1}
+		slow := &shardSearchQueryNode{nodeID: 2, block: make(chan struct{})}
+		defer close(slow.block)
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": fast, "node2": slow})
+		defer st.shardClients.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_, _, err := st.searchShards(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("no shard replied", func(t *testing.T) {
+		slow1 := &shardSearchQueryNode{nodeID: 1, block: make(chan struct{})}
+		slow2 := &shardSearchQueryNode{nodeID: 2, block: make(chan struct{})}
+		defer close(slow1.block)
+		defer close(slow2.block)
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": slow1, "node2": slow2})
+		defer st.shardClients.Close()
+		st.allowPartial = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_, _, err := st.searchShards(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed shard is not missing", func(t *testing.T) {
+		fast := &shardSearchQueryNode{nodeID: 1}
+		failed := &shardSearchQueryNode{nodeID: 2, status: commonpb.ErrorCode_UnexpectedError}
+		st := newShardSearchTask(t, qc, map[string]*shardSearchQueryNode{"node1": fast, "node2": failed})
+		defer st.shardClients.Close()
+		st.allowPartial = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_, _, err := st.searchShards(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchTask_shardStats(t *testing.T) {
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+	qc.SetGetShardLeadersFunc(func(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+		return shardLeadersResponse(
+			&querypb.ShardLeader{ChannelName: "dml-0", NodeID: 1, Address: "node1"},
+			&querypb.ShardLeader{ChannelName: "dml-1", NodeID: 2, Address: "node2"},
+		), nil
+	})
+	const topk = 3
+	result := func(rows, segments int64, ids []int64, scores []float32) *internalpb.SearchResults {
+		blob, err := proto.Marshal(genSearchResultData(1, topk, ids, scores))
+		require.NoError(t, err)
+		return &internalpb.SearchResults{
+			Status:      &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			NumQueries:  1,
+			TopK:        topk,
+			MetricType:  "L2",
+			SlicedBlob:  blob,
+			RowsScanned: rows,
+			SegmentsHit: segments,
+		}
+	}
+	newNodes := func() map[string]*shardSearchQueryNode {
+		return map[string]*shardSearchQueryNode{
+			// the shard leader of dml-0 returns the result of the sealed segments on another query node as well
+			"node1": {nodeID: 1, latencyUs: 100, results: []*internalpb.SearchResults{
+				result(1000, 2, []int64{1, 2, 3}, []float32{-1, -3, -5}),
+				result(500, 1, []int64{4, 5, 6}, []float32{-2, -6, -7}),
+			}},
+			"node2": {nodeID: 2, latencyUs: 50, results: []*internalpb.SearchResults{
+				result(300, 1, []int64{7, 8, 9}, []float32{-1.5, -8, -9}),
+			}},
+		}
+	}
+	reduce := func(st *searchTask) {
+		results, _, err := st.searchShards(context.Background())
+		require.NoError(t, err)
+		data, err := decodeSearchResults(results)
+		require.NoError(t, err)
+		var hits []int64
+		st.result, hits, err = reduceSearchResultDataWithHits(data, 1, topk, "L2", -1)
+		require.NoError(t, err)
+		st.fillShardStats(results, hits)
+	}
+
+	t.Run("breakdown", func(t *testing.T) {
+		st := newShardSearchTask(t, qc, newNodes())
+		defer st.shardClients.Close()
+		st.withShardStats = true
+		reduce(st)
+
+		assert.Equal(t, []int64{1, 7, 4}, st.result.Results.Ids.GetIntId().Data)
+		stats := st.result.ShardStats
+		require.Equal(t, 2, len(stats))
+		assert.Equal(t, "dml-0", stats[0].ChannelName)
+		assert.Equal(t, int64(1), stats[0].NodeID)
+		assert.Equal(t, int64(1500), stats[0].RowsScanned)
+		assert.Equal(t, int64(3), stats[0].SegmentsHit)
+		assert.Equal(t, int64(2), stats[0].Hits)
+		assert.Equal(t, int64(100), stats[0].NodeLatencyUs)
+		assert.Equal(t, "dml-1", stats[1].ChannelName)
+		assert.Equal(t, int64(2), stats[1].NodeID)
+		assert.Equal(t, int64(1), stats[1].Hits)
+		assert.Equal(t, int64(50), stats[1].NodeLatencyUs)
+
+		// the breakdown sums up to the merged counts
+		var hits, topks, rows, segments int64
+		for _, s := range stats {
+			hits += s.Hits
+			rows += s.RowsScanned
+			segments += s.SegmentsHit
+			assert.True(t, s.TransportLatencyUs >= 0)
+		}
+		for _, k := range st.result.Results.Topks {
+			topks += k
+		}
+		assert.Equal(t, int64(len(st.result.Results.Ids.GetIntId().Data)), hits)
+		assert.Equal(t, topks, hits)
+		assert.Equal(t, int64(1800), rows)
+		assert.Equal(t, int64(4), segments)
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		st := newShardSearchTask(t, qc, newNodes())
+		defer st.shardClients.Close()
+		reduce(st)
+		assert.Equal(t, 3, len(st.result.Results.Ids.GetIntId().Data))
+		assert.Nil(t, st.result.ShardStats)
+	})
+}
+
+func TestSearchTask_sendShardResults(t *testing.T) {
+	st := newShardSearchTask(t, nil, nil)
+	results := []*internalpb.SearchResults{{}}
+	go st.sendShardResults(results)
+	assert.Equal(t, results, <-st.resultBuf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	st.ctx = ctx
+	cancel()
+	// returns without a receiver once the task is canceled
+	st.sendShardResults(results)
+}
@@ -323,12 +323,16 @@ func (sa *segIDAssigner) syncSegments() (bool, error) {
 	var errMsg string
 	now := time.Now()
 	success := true
+	rateLimited := false
 	for _, info := range resp.SegIDAssignments {
 		if info.Status.GetErrorCode() != commonpb.ErrorCode_Success {
 			log.Debug("proxy", zap.String("SyncSegment Error", info.Status.Reason))
 			errMsg += info.Status.Reason
 			errMsg += "\n"
 			success = false
+			if info.Status.GetErrorCode() == commonpb.ErrorCode_RateLimit {
+				rateLimited = true
+			}
 			continue
 		}
 		assign, err := sa.getAssign(info.CollectionID, info.PartitionID, info.ChannelName)
@@ -358,6 +362,9 @@ func (sa *segIDAssigner) syncSegments() (bool, error) {
 		}
 		assign.lastInsertTime = now
 	}
+	if rateLimited {
+		return false, fmt.Errorf("%w: %s", errDataNodeMemoryHigh, errMsg)
+	}
 	if !success {
 		return false, fmt.Errorf(errMsg)
 	}
@@ -386,7 +393,7 @@ func (sa *segIDAssigner) GetSegmentID(collID UniqueID, partitionID UniqueID, cha
 	}
 	sa.Reqs <- req
 	if err := req.Wait(); err != nil {
-		return nil, fmt.Errorf("GetSegmentID failed: %s", err)
+		return nil, fmt.Errorf("GetSegmentID failed: %w", err)
 	}
 
 	return req.segInfo, nil
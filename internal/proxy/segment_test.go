@@ -13,6 +13,7 @@ package proxy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -270,6 +271,63 @@ func TestSegmentAllocator5(t *testing.T) {
 	wg.Wait()
 }
 
+// mockDataCoordMemoryHigh rejects the assignments with RateLimit while the memory of DataNode is high
+type mockDataCoordMemoryHigh struct {
+	mockDataCoord
+	mu         sync.Mutex
+	memoryHigh bool
+}
+
+func (mockD *mockDataCoordMemoryHigh) setMemoryHigh(high bool) {
+	mockD.mu.Lock()
+	defer mockD.mu.Unlock()
+	mockD.memoryHigh = high
+}
+
+func (mockD *mockDataCoordMemoryHigh) AssignSegmentID(ctx context.Context, req *datapb.AssignSegmentIDRequest) (*datapb.AssignSegmentIDResponse, error) {
+	mockD.mu.Lock()
+	defer mockD.mu.Unlock()
+	if !mockD.memoryHigh {
+		return mockD.mockDataCoord.AssignSegmentID(ctx, req)
+	}
+	assigns := make([]*datapb.SegmentIDAssignment, 0, len(req.SegmentIDRequests))
+	for _, r := range req.SegmentIDRequests {
+		assigns = append(assigns, &datapb.SegmentIDAssignment{
+			ChannelName:  r.ChannelName,
+			CollectionID: r.CollectionID,
+			PartitionID:  r.PartitionID,
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_RateLimit,
+				Reason:    "memory of DataNode is high",
+			},
+		})
+	}
+	return &datapb.AssignSegmentIDResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		SegIDAssignments: assigns,
+	}, nil
+}
+
+func TestSegmentAllocatorMemoryHigh(t *testing.T) {
+	ctx := context.Background()
+	dataCoord := &mockDataCoordMemoryHigh{memoryHigh: true}
+	dataCoord.expireTime = Timestamp(500)
+	segAllocator, err := newSegIDAssigner(ctx, dataCoord, getLastTick2)
+	assert.Nil(t, err)
+	segAllocator.Start()
+	defer segAllocator.Close()
+
+	_, err = segAllocator.GetSegmentID(1, 1, "abc", 10, 100)
+	assert.True(t, errors.Is(err, errDataNodeMemoryHigh))
+
+	dataCoord.setMemoryHigh(false)
+	segInfo, err := segAllocator.GetSegmentID(1, 1, "abc", 10, 100)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 10, segInfo[1])
+}
+
 func TestSegmentAllocator6(t *testing.T) {
 	ctx := context.Background()
 	dataCoord := &mockDataCoord{}
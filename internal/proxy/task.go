@@ -13,6 +13,7 @@ package proxy
 
 import (
 	"bytes"
+	"container/heap"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -23,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 
 	"go.uber.org/zap"
@@ -40,11 +42,14 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
 	"github.com/milvus-io/milvus/internal/util/timerecord"
 	"github.com/milvus-io/milvus/internal/util/trace"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
@@ -60,6 +65,8 @@ const (
 	MetricTypeKey                   = "metric_type"
 	SearchParamsKey                 = "params"
 	RoundDecimalKey                 = "round_decimal"
+	PartialResultsKey               = "partial_results"
+	ShardStatsKey                   = "shard_stats"
 	HasCollectionTaskName           = "HasCollectionTask"
 	DescribeCollectionTaskName      = "DescribeCollectionTask"
 	GetCollectionStatisticsTaskName = "GetCollectionStatisticsTask"
@@ -83,6 +90,7 @@ const (
 	CreateAliasTaskName             = "CreateAliasTask"
 	DropAliasTaskName               = "DropAliasTask"
 	AlterAliasTaskName              = "AlterAliasTask"
+	AlterCollectionTaskName         = "AlterCollectionTask"
 
 	minFloat32 = -1 * float32(math.MaxFloat32)
 )
@@ -126,6 +134,8 @@ type insertTask struct {
 	vChannels      []vChan
 	pChannels      []pChan
 	schema         *schemapb.CollectionSchema
+	// keptRowOffsets are the offsets of the rows in the original request kept by deduplication
+	keptRowOffsets []int
 }
 
 func (it *insertTask) TraceCtx() context.Context {
@@ -654,10 +664,10 @@ func (it *insertTask) checkFieldAutoIDAndHashPK() error {
 		}
 	}
 
-	var rowIDBegin UniqueID
-	var rowIDEnd UniqueID
-
-	rowIDBegin, rowIDEnd, _ = it.rowIDAllocator.Alloc(rowNums)
+	rowIDBegin, rowIDEnd, err := it.rowIDAllocator.Alloc(rowNums)
+	if err != nil {
+		return err
+	}
 
 	it.BaseInsertTask.RowIDs = make([]UniqueID, rowNums)
 	for i := rowIDBegin; i < rowIDEnd; i++ {
@@ -716,6 +726,56 @@ func (it *insertTask) checkFieldAutoIDAndHashPK() error {
 	return nil
 }
 
+// dedupPrimaryKeys handles the duplicate primary keys within the batch by the insert dedup mode of the collection.
+// The collections with AutoID are skipped since their primary keys are allocated by the proxy.
+func (it *insertTask) dedupPrimaryKeys(ctx context.Context) error {
+	collInfo, err := globalMetaCache.GetCollectionInfo(ctx, it.CollectionName)
+	if err != nil {
+		return err
+	}
+	mode, ok := collInfo.properties.InsertDedupMode()
+	if !ok || mode == common.InsertDedupNone {
+		return nil
+	}
+
+	primaryField, err := typeutil.GetPrimaryFieldSchema(it.schema)
+	if err != nil {
+		return err
+	}
+	if primaryField.AutoID {
+		return nil
+	}
+	primaryFieldData, err := typeutil.GetPrimaryFieldData(it.req.FieldsData, primaryField)
+	if err != nil {
+		return err
+	}
+	pks, err := typeutil.GetInt64PrimaryKeys(primaryFieldData)
+	if err != nil {
+		return err
+	}
+
+	lastOccurrences, duplicates := typeutil.FindDuplicatePrimaryKeys(pks)
+	if len(duplicates) == 0 {
+		return nil
+	}
+	if mode == common.InsertDedupReject {
+		return errDuplicatePrimaryKeys(duplicates)
+	}
+
+	fieldsData, err := typeutil.SelectRows(it.req.FieldsData, lastOccurrences)
+	if err != nil {
+		return err
+	}
+	log.Debug("Proxy Insert drops the rows of duplicate primary keys",
+		zap.String("collection", it.CollectionName),
+		zap.Int("duplicate primary keys", len(duplicates)),
+		zap.Int("dropped rows", len(pks)-len(lastOccurrences)))
+	it.req.FieldsData = fieldsData
+	it.req.NumRows = uint32(len(lastOccurrences))
+	it.keptRowOffsets = lastOccurrences
+	return nil
+}
+
 func (it *insertTask) PreExecute(ctx context.Context) error {
 	sp, ctx := trace.StartSpanFromContextWithOperationName(it.ctx, "Proxy-Insert-PreExecute")
 	defer sp.Finish()
@@ -732,6 +792,12 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		Timestamp: it.BeginTs(),
 	}
 
+	// reject the oversized request before it's converted into messages
+	if err := validatePayloadSize("insert request", proto.Size(it.req), Params.InsertMaxRequestSize,
+		"please split the rows into smaller batches"); err != nil {
+		return err
+	}
+
 	collectionName := it.BaseInsertTask.CollectionName
 	if err := validateCollectionName(collectionName); err != nil {
 		return err
@@ -749,15 +815,35 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	}
 	it.schema = collSchema
 
+	if getPartitionKeyField(collSchema) != nil && len(partitionTag) > 0 {
+		return errPartitionKeyWithPartitionNames(collectionName)
+	}
+
+	err = validateInsertFieldsData(collSchema, it.req.FieldsData, it.req.NumRows, Params.InsertRejectNaN)
+	if err != nil {
+		return err
+	}
+
 	err = it.checkRowNums()
 	if err != nil {
 		return err
 	}
 
+	err = it.dedupPrimaryKeys(ctx)
+	if err != nil {
+		return err
+	}
+
 	err = it.checkFieldAutoIDAndHashPK()
 	if err != nil {
 		return err
 	}
+	// the succeeded rows are reported by their offsets in the original request
+	if it.keptRowOffsets != nil {
+		for i, offset := range it.keptRowOffsets {
+			it.result.SuccIndex[i] = uint32(offset)
+		}
+	}
 
 	err = it.transferColumnBasedRequestToRowBasedData()
 	if err != nil {
@@ -773,6 +859,134 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
+// splitByPartitionKey splits the rows of insert request into messages of the physical partitions by the partition key
+func (it *insertTask) splitByPartitionKey(ctx context.Context, partitionKeyField *schemapb.FieldSchema) ([]*msgstream.InsertMsg, error) {
+	var keys []int64
+	for _, fieldData := range it.req.FieldsData {
+		if fieldData.FieldName == partitionKeyField.Name {
+			keys = fieldData.GetScalars().GetLongData().GetData()
+			break
+		}
+	}
+	if len(keys) != len(it.RowData) {
+		return nil, fmt.Errorf("the number of partition keys(%d) mismatch with rows(%d)", len(keys), len(it.RowData))
+	}
+	partitionNames, partitionIDs, err := getPartitionKeyPartitions(ctx, it.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*msgstream.InsertMsg, len(partitionIDs))
+	for row, key := range keys {
+		idx := hashPartitionKey(key, len(partitionIDs))
+		if msgs[idx] == nil {
+			msgs[idx] = &msgstream.InsertMsg{
+				BaseMsg: msgstream.BaseMsg{
+					Ctx:            ctx,
+					BeginTimestamp: it.BeginTimestamp,
+					EndTimestamp:   it.EndTimestamp,
+				},
+				InsertRequest: internalpb.InsertRequest{
+					Base:           it.Base,
+					DbName:         it.DbName,
+					CollectionName: it.CollectionName,
+					CollectionID:   it.CollectionID,
+					PartitionName:  partitionNames[idx],
+					PartitionID:    partitionIDs[idx],
+				},
+			}
+		}
+		msg := msgs[idx]
+		msg.HashValues = append(msg.HashValues, it.HashValues[row])
+		msg.Timestamps = append(msg.Timestamps, it.Timestamps[row])
+		msg.RowIDs = append(msg.RowIDs, it.RowIDs[row])
+		msg.RowData = append(msg.RowData, it.RowData[row])
+	}
+
+	ret := make([]*msgstream.InsertMsg, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg != nil {
+			ret = append(ret, msg)
+		}
+	}
+	return ret, nil
+}
+
+// insertMsgBatcher packs the rows of every channel into InsertMsgs, a new InsertMsg is started before the size of the
+// current one exceeds maxSize, so that a large insert request is split into the messages the message stream accepts.
+// A row larger than maxSize is sent in an InsertMsg alone.
+type insertMsgBatcher struct {
+	maxSize int
+	msgs    map[int32]*msgstream.InsertMsg
+	sizes   map[int32]int
+	full    []msgstream.TsMsg
+}
+
+func newInsertMsgBatcher(maxSize int) *insertMsgBatcher {
+	return &insertMsgBatcher{
+		maxSize: maxSize,
+		msgs:    make(map[int32]*msgstream.InsertMsg),
+		sizes:   make(map[int32]int),
+	}
+}
+
+// fixedSizeOfInsertMsg estimates the size of an InsertMsg without any row, it's not accurate
+/* #nosec G103 */
+func fixedSizeOfInsertMsg(msg *msgstream.InsertMsg) int {
+	size := 0
+
+	size += int(unsafe.Sizeof(*msg.Base))
+	size += int(unsafe.Sizeof(msg.DbName))
+	size += int(unsafe.Sizeof(msg.CollectionName))
+	size += int(unsafe.Sizeof(msg.PartitionName))
+	size += int(unsafe.Sizeof(msg.DbID))
+	size += int(unsafe.Sizeof(msg.CollectionID))
+	size += int(unsafe.Sizeof(msg.PartitionID))
+	size += int(unsafe.Sizeof(msg.SegmentID))
+	size += int(unsafe.Sizeof(msg.ShardName))
+	size += int(unsafe.Sizeof(msg.Timestamps))
+	size += int(unsafe.Sizeof(msg.RowIDs))
+	return size
+}
+
+// sizeOfInsertRow estimates the size a row takes in an InsertMsg, including its hash value, timestamp and row ID
+/* #nosec G103 */
+func sizeOfInsertRow(row *commonpb.Blob) int {
+	return 4 + 8 + int(unsafe.Sizeof(row.Value)) + len(row.Value)
+}
+
+// add appends the row to the InsertMsg of the channel key, newMsg creates the InsertMsg if there is none.
+func (b *insertMsgBatcher) add(key int32, hashValue uint32, ts Timestamp, rowID UniqueID, row *commonpb.Blob, newMsg func() *msgstream.InsertMsg) {
+	rowSize := sizeOfInsertRow(row)
+	if msg, ok := b.msgs[key]; ok && len(msg.RowData) > 0 && b.sizes[key]+rowSize > b.maxSize {
+		b.full = append(b.full, msg)
+		delete(b.msgs, key)
+	}
+	msg, ok := b.msgs[key]
+	if !ok {
+		msg = newMsg()
+		b.msgs[key] = msg
+		b.sizes[key] = fixedSizeOfInsertMsg(msg)
+	}
+	msg.HashValues = append(msg.HashValues, hashValue)
+	msg.Timestamps = append(msg.Timestamps, ts)
+	msg.RowIDs = append(msg.RowIDs, rowID)
+	msg.RowData = append(msg.RowData, row)
+	b.sizes[key] += rowSize
+}
+
+// flush returns all the InsertMsgs, the full ones first.
+func (b *insertMsgBatcher) flush() []msgstream.TsMsg {
+	ret := b.full
+	for _, msg := range b.msgs {
+		ret = append(ret, msg)
+	}
+	b.full = nil
+	b.msgs = make(map[int32]*msgstream.InsertMsg)
+	b.sizes = make(map[int32]int)
+	return ret
+}
+
 func (it *insertTask) _assignSegmentID(stream msgstream.MsgStream, pack *msgstream.MsgPack) (*msgstream.MsgPack, error) {
 	newPack := &msgstream.MsgPack{
 		BeginTs:        pack.BeginTs,
@@ -792,6 +1006,8 @@ func (it *insertTask) _assignSegmentID(stream msgstream.MsgStream, pack *msgstre
 	}
 	log.Debug("_assignSemgentID, produceChannels:", zap.Any("Channels", channelNames))
 
+	// all the messages of pack belong to the same partition
+	partitionID := it.PartitionID
 	for i, request := range tsMsgs {
 		if request.Type() != commonpb.MsgType_Insert {
 			return nil, fmt.Errorf("msg's must be Insert")
@@ -800,6 +1016,7 @@ func (it *insertTask) _assignSegmentID(stream msgstream.MsgStream, pack *msgstre
 		if !ok {
 			return nil, fmt.Errorf("msg's must be Insert")
 		}
+		partitionID = insertRequest.PartitionID
 
 		keys := hashKeys[i]
 		timestampLen := len(insertRequest.Timestamps)
@@ -833,7 +1050,9 @@ func (it *insertTask) _assignSegmentID(stream msgstream.MsgStream, pack *msgstre
 		if channelName == "" {
 			return nil, fmt.Errorf("Proxy, repack_func, can not found channelName")
 		}
-		mapInfo, err := it.segIDAssigner.GetSegmentID(it.CollectionID, it.PartitionID, channelName, count, ts)
+		accesslog.Begin(it.TraceCtx(), accesslog.StageCoord)
+		mapInfo, err := it.segIDAssigner.GetSegmentID(it.CollectionID, partitionID, channelName, count, ts)
+		accesslog.End(it.TraceCtx(), accesslog.StageCoord)
 		if err != nil {
 			log.Debug("insertTask.go", zap.Any("MapInfo", mapInfo),
 				zap.Error(err))
@@ -891,30 +1110,8 @@ func (it *insertTask) _assignSegmentID(stream msgstream.MsgStream, pack *msgstre
 		return 0
 	}
 
-	threshold := Params.PulsarMaxMessageSize
-	log.Debug("Proxy", zap.Int("threshold of message size: ", threshold))
-	// not accurate
-	/* #nosec G103 */
-	getFixedSizeOfInsertMsg := func(msg *msgstream.InsertMsg) int {
-		size := 0
-
-		size += int(unsafe.Sizeof(*msg.Base))
-		size += int(unsafe.Sizeof(msg.DbName))
-		size += int(unsafe.Sizeof(msg.CollectionName))
-		size += int(unsafe.Sizeof(msg.PartitionName))
-		size += int(unsafe.Sizeof(msg.DbID))
-		size += int(unsafe.Sizeof(msg.CollectionID))
-		size += int(unsafe.Sizeof(msg.PartitionID))
-		size += int(unsafe.Sizeof(msg.SegmentID))
-		size += int(unsafe.Sizeof(msg.ShardName))
-		size += int(unsafe.Sizeof(msg.Timestamps))
-		size += int(unsafe.Sizeof(msg.RowIDs))
-		return size
-	}
-
-	result := make(map[int32]msgstream.TsMsg)
-	curMsgSizeMap := make(map[int32]int)
-
+	batcher := newInsertMsgBatcher(Params.PulsarMaxMessageSize)
+	log.Debug("Proxy", zap.Int("threshold of message size: ", batcher.maxSize))
 	for i, request := range tsMsgs {
 		insertRequest := request.(*msgstream.InsertMsg)
 		keys := hashKeys[i]
@@ -925,61 +1122,35 @@ func (it *insertTask) _assignSegmentID(stream msgstream.MsgStream, pack *msgstre
 		proxyID := insertRequest.Base.SourceID
 		for index, key := range keys {
 			ts := insertRequest.Timestamps[index]
-			rowID := insertRequest.RowIDs[index]
-			row := insertRequest.RowData[index]
 			segmentID := getSegmentID(key)
 			if segmentID == 0 {
 				return nil, fmt.Errorf("get SegmentID failed, segmentID is zero")
 			}
-			_, ok := result[key]
-			if !ok {
-				sliceRequest := internalpb.InsertRequest{
-					Base: &commonpb.MsgBase{
-						MsgType:   commonpb.MsgType_Insert,
-						MsgID:     reqID,
-						Timestamp: ts,
-						SourceID:  proxyID,
-					},
-					CollectionID:   collectionID,
-					PartitionID:    partitionID,
-					CollectionName: collectionName,
-					PartitionName:  partitionName,
-					SegmentID:      segmentID,
-					ShardName:      channelNames[key],
-				}
-				insertMsg := &msgstream.InsertMsg{
-					BaseMsg: msgstream.BaseMsg{
-						Ctx: request.TraceCtx(),
-					},
-					InsertRequest: sliceRequest,
-				}
-				result[key] = insertMsg
-				curMsgSizeMap[key] = getFixedSizeOfInsertMsg(insertMsg)
-			}
-			curMsg := result[key].(*msgstream.InsertMsg)
-			curMsgSize := curMsgSizeMap[key]
-			curMsg.HashValues = append(curMsg.HashValues, insertRequest.HashValues[index])
-			curMsg.Timestamps = append(curMsg.Timestamps, ts)
-			curMsg.RowIDs = append(curMsg.RowIDs, rowID)
-			curMsg.RowData = append(curMsg.RowData, row)
-			/* #nosec G103 */
-			curMsgSize += 4 + 8 + int(unsafe.Sizeof(row.Value))
-			curMsgSize += len(row.Value)
-
-			if curMsgSize >= threshold {
-				newPack.Msgs = append(newPack.Msgs, curMsg)
-				delete(result, key)
-				curMsgSize = 0
-			}
-
-			curMsgSizeMap[key] = curMsgSize
-		}
-	}
-	for _, msg := range result {
-		if msg != nil {
-			newPack.Msgs = append(newPack.Msgs, msg)
+			batcher.add(key, insertRequest.HashValues[index], ts, insertRequest.RowIDs[index], insertRequest.RowData[index],
+				func() *msgstream.InsertMsg {
+					return &msgstream.InsertMsg{
+						BaseMsg: msgstream.BaseMsg{
+							Ctx: request.TraceCtx(),
+						},
+						InsertRequest: internalpb.InsertRequest{
+							Base: &commonpb.MsgBase{
+								MsgType:   commonpb.MsgType_Insert,
+								MsgID:     reqID,
+								Timestamp: ts,
+								SourceID:  proxyID,
+							},
+							CollectionID:   collectionID,
+							PartitionID:    partitionID,
+							CollectionName: collectionName,
+							PartitionName:  partitionName,
+							SegmentID:      segmentID,
+							ShardName:      channelNames[key],
+						},
+					}
+				})
 		}
 	}
+	newPack.Msgs = batcher.flush()
 
 	return newPack, nil
 }
@@ -993,29 +1164,31 @@ func (it *insertTask) Execute(ctx context.Context) error {
 		return err
 	}
 	it.CollectionID = collID
-	var partitionID UniqueID
-	if len(it.PartitionName) > 0 {
-		partitionID, err = globalMetaCache.GetPartitionID(ctx, collectionName, it.PartitionName)
+	it.BaseMsg.Ctx = ctx
+
+	// the messages of different partitions are assigned segments separately
+	var partitionMsgs []*msgstream.InsertMsg
+	if partitionKeyField := getPartitionKeyField(it.schema); partitionKeyField != nil {
+		partitionMsgs, err = it.splitByPartitionKey(ctx, partitionKeyField)
 		if err != nil {
 			return err
 		}
 	} else {
-		partitionID, err = globalMetaCache.GetPartitionID(ctx, collectionName, Params.DefaultPartitionName)
-		if err != nil {
-			return err
+		var partitionID UniqueID
+		if len(it.PartitionName) > 0 {
+			partitionID, err = globalMetaCache.GetPartitionID(ctx, collectionName, it.PartitionName)
+			if err != nil {
+				return err
+			}
+		} else {
+			partitionID, err = globalMetaCache.GetPartitionID(ctx, collectionName, Params.DefaultPartitionName)
+			if err != nil {
+				return err
+			}
 		}
+		it.PartitionID = partitionID
+		partitionMsgs = []*msgstream.InsertMsg{&it.BaseInsertTask}
 	}
-	it.PartitionID = partitionID
-
-	var tsMsg msgstream.TsMsg = &it.BaseInsertTask
-	it.BaseMsg.Ctx = ctx
-	msgPack := msgstream.MsgPack{
-		BeginTs: it.BeginTs(),
-		EndTs:   it.EndTs(),
-		Msgs:    make([]msgstream.TsMsg, 1),
-	}
-
-	msgPack.Msgs[0] = tsMsg
 
 	stream, err := it.chMgr.getDMLStream(collID)
 	if err != nil {
@@ -1045,13 +1218,26 @@ func (it *insertTask) Execute(ctx context.Context) error {
 	}
 
 	// Assign SegmentID
-	var pack *msgstream.MsgPack
-	pack, err = it._assignSegmentID(stream, &msgPack)
-	if err != nil {
-		return err
+	pack := &msgstream.MsgPack{
+		BeginTs: it.BeginTs(),
+		EndTs:   it.EndTs(),
+	}
+	for _, msg := range partitionMsgs {
+		msgPack := msgstream.MsgPack{
+			BeginTs: it.BeginTs(),
+			EndTs:   it.EndTs(),
+			Msgs:    []msgstream.TsMsg{msg},
+		}
+		partitionPack, err := it._assignSegmentID(stream, &msgPack)
+		if err != nil {
+			return err
+		}
+		pack.Msgs = append(pack.Msgs, partitionPack.Msgs...)
 	}
 
+	accesslog.Begin(ctx, accesslog.StagePublish)
 	err = stream.Produce(pack)
+	accesslog.End(ctx, accesslog.StagePublish)
 	if err != nil {
 		it.result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
 		it.result.Status.Reason = err.Error()
@@ -1153,6 +1339,20 @@ func (cct *createCollectionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err := validatePartitionKey(cct.schema); err != nil {
+		return err
+	}
+	if getPartitionKeyField(cct.schema) != nil {
+		if cct.NumPartitions <= 0 {
+			cct.NumPartitions = Params.DefaultPartitionKeyPartitionNum
+		}
+		if cct.NumPartitions > Params.MaxPartitionKeyPartitionNum {
+			return fmt.Errorf("maximum partitions' number of partition key should be limited to %d", Params.MaxPartitionKeyPartitionNum)
+		}
+	} else if cct.NumPartitions > 0 {
+		return errors.New("num_partitions can only be specified for the collection with partition key")
+	}
+
 	// validate field name
 	for _, field := range cct.schema.Fields {
 		if err := validateFieldName(field.Name); err != nil {
@@ -1193,7 +1393,54 @@ func (cct *createCollectionTask) PreExecute(ctx context.Context) error {
 func (cct *createCollectionTask) Execute(ctx context.Context) error {
 	var err error
 	cct.result, err = cct.rootCoord.CreateCollection(ctx, cct.CreateCollectionRequest)
-	return err
+	if err != nil || cct.result.ErrorCode != commonpb.ErrorCode_Success || getPartitionKeyField(cct.schema) == nil {
+		return err
+	}
+
+	if err = cct.createPartitionKeyPartitions(ctx); err != nil {
+		log.Warn("failed to create partitions of partition key, drop the collection",
+			zap.String("collection", cct.CollectionName), zap.Error(err))
+		status, dropErr := cct.rootCoord.DropCollection(ctx, &milvuspb.DropCollectionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:   commonpb.MsgType_DropCollection,
+				MsgID:     cct.Base.MsgID,
+				Timestamp: cct.Base.Timestamp,
+				SourceID:  Params.ProxyID,
+			},
+			DbName:         cct.DbName,
+			CollectionName: cct.CollectionName,
+		})
+		if dropErr != nil || status.ErrorCode != commonpb.ErrorCode_Success {
+			log.Warn("failed to drop the collection", zap.String("collection", cct.CollectionName),
+				zap.Error(dropErr), zap.Any("status", status))
+		}
+		return err
+	}
+	return nil
+}
+
+// createPartitionKeyPartitions creates the physical partitions of the collection with partition key
+func (cct *createCollectionTask) createPartitionKeyPartitions(ctx context.Context) error {
+	for i := int64(0); i < cct.NumPartitions; i++ {
+		status, err := cct.rootCoord.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:   commonpb.MsgType_CreatePartition,
+				MsgID:     cct.Base.MsgID,
+				Timestamp: cct.Base.Timestamp,
+				SourceID:  Params.ProxyID,
+			},
+			DbName:         cct.DbName,
+			CollectionName: cct.CollectionName,
+			PartitionName:  partitionKeyPartitionName(i),
+		})
+		if err != nil {
+			return err
+		}
+		if status.ErrorCode != commonpb.ErrorCode_Success {
+			return errors.New(status.Reason)
+		}
+	}
+	return nil
 }
 
 func (cct *createCollectionTask) PostExecute(ctx context.Context) error {
@@ -1336,6 +1583,48 @@ func translateOutputFields(outputFields []string, schema *schemapb.CollectionSch
 	return resultFieldNames, nil
 }
 
+// translateToOutputFieldIDs translates the output field names to field ids, the primary key field is always
+// in the result. All the scalar fields are returned if no output field is specified.
+func translateToOutputFieldIDs(outputFields []string, schema *schemapb.CollectionSchema) ([]UniqueID, error) {
+	outputFieldIDs := make([]UniqueID, 0, len(outputFields)+1)
+	if len(outputFields) == 0 {
+		for _, field := range schema.Fields {
+			if field.FieldID >= common.StartOfUserFieldID && field.DataType != schemapb.DataType_FloatVector && field.DataType != schemapb.DataType_BinaryVector {
+				outputFieldIDs = append(outputFieldIDs, field.FieldID)
+			}
+		}
+		return outputFieldIDs, nil
+	}
+
+	var pkFieldID UniqueID = -1
+	for _, field := range schema.Fields {
+		if field.IsPrimaryKey {
+			pkFieldID = field.FieldID
+		}
+	}
+	pkFound := false
+	for _, reqField := range outputFields {
+		fieldFound := false
+		for _, field := range schema.Fields {
+			if reqField == field.Name {
+				outputFieldIDs = append(outputFieldIDs, field.FieldID)
+				fieldFound = true
+				if field.FieldID == pkFieldID {
+					pkFound = true
+				}
+				break
+			}
+		}
+		if !fieldFound {
+			return nil, fmt.Errorf("field %s not exist in collection %s", reqField, schema.Name)
+		}
+	}
+	if !pkFound && pkFieldID != -1 {
+		outputFieldIDs = append(outputFieldIDs, pkFieldID)
+	}
+	return outputFieldIDs, nil
+}
+
 type searchTask struct {
 	Condition
 	*internalpb.SearchRequest
@@ -1345,6 +1634,22 @@ type searchTask struct {
 	query     *milvuspb.SearchRequest
 	chMgr     channelsMgr
 	qc        types.QueryCoord
+	rc        types.RootCoord
+	sessionTs Timestamp // the last write timestamp of the client session
+
+	// clients of the shard leaders, the search is sent to the query channel if it's nil
+	shardClients *shardclient.Manager
+
+	// allowPartial is set by the search param partial_results, the results of the shards replied in time are
+	// returned once the deadline is near, missingShards are the shards not replied
+	allowPartial  bool
+	missingShards []string
+
+	// withShardStats is set by the search param shard_stats, shardStats are the search breakdown of the shards
+	// searched through the shard leaders, and resultShards maps their results to the index of the shard
+	withShardStats bool
+	shardStats     []*milvuspb.ShardSearchStats
+	resultShards   map[*internalpb.SearchResults]int
 }
 
 func (st *searchTask) TraceCtx() context.Context {
@@ -1424,12 +1729,68 @@ func (st *searchTask) getVChannels() ([]vChan, error) {
 	return channels, nil
 }
 
+// checkSearchParams checks the search params by the rule of the index built on annsField before any message is
+// published, the vectors are searched by brute force if there is no index
+func (st *searchTask) checkSearchParams(ctx context.Context, schema *schemapb.CollectionSchema, annsField, metricType, searchParams string, topK int64) error {
+	var indexType indexparamcheck.IndexType = indexparamcheck.IndexFaissIDMap
+	for _, field := range schema.Fields {
+		if field.Name == annsField && field.DataType == schemapb.DataType_BinaryVector {
+			indexType = indexparamcheck.IndexFaissBinIDMap
+		}
+	}
+
+	accesslog.Begin(ctx, accesslog.StageCoord)
+	defer accesslog.End(ctx, accesslog.StageCoord)
+	resp, err := st.rc.DescribeIndex(ctx, &milvuspb.DescribeIndexRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_DescribeIndex,
+			MsgID:     st.Base.MsgID,
+			Timestamp: st.Base.Timestamp,
+			SourceID:  Params.ProxyID,
+		},
+		DbName:         st.query.DbName,
+		CollectionName: st.query.CollectionName,
+		FieldName:      annsField,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success && resp.Status.ErrorCode != commonpb.ErrorCode_IndexNotExist {
+		return errors.New(resp.Status.Reason)
+	}
+
+	var indexParams map[string]string
+	for _, desc := range resp.IndexDescriptions {
+		if desc.FieldName == annsField {
+			indexParams = parseIndexParams(desc.Params)
+			indexType = indexparamcheck.IndexFaissIvfPQ // IVF_PQ is the default index type
+			if t, ok := indexParams[indexparamcheck.IndexTypeKey]; ok {
+				indexType = t
+			}
+			break
+		}
+	}
+
+	if err := indexparamcheck.CheckSearchParams(indexType, indexParams, metricType, searchParams, topK); err != nil {
+		log.Debug("invalid search params", zap.String("collection", st.query.CollectionName),
+			zap.String("anns field", annsField), zap.Any("index params", indexParams),
+			zap.String("search params", searchParams), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (st *searchTask) PreExecute(ctx context.Context) error {
 	sp, ctx := trace.StartSpanFromContextWithOperationName(st.TraceCtx(), "Proxy-Search-PreExecute")
 	defer sp.Finish()
 	st.Base.MsgType = commonpb.MsgType_Search
 	st.Base.SourceID = Params.ProxyID
 
+	if err := validatePayloadSize("search request", proto.Size(st.query), Params.SearchMaxRequestSize,
+		"please search with fewer vectors in a request"); err != nil {
+		return err
+	}
+
 	collectionName := st.query.CollectionName
 	collID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
 	if err != nil { // err is not nil if collection not exists
@@ -1447,6 +1808,7 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 	}
 
 	// check if collection was already loaded into query node
+	accesslog.Begin(ctx, accesslog.StageCoord)
 	showResp, err := st.qc.ShowCollections(st.ctx, &querypb.ShowCollectionsRequest{
 		Base: &commonpb.MsgBase{
 			MsgType:   commonpb.MsgType_ShowCollections,
@@ -1456,6 +1818,7 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 		},
 		DbID: 0, // TODO(dragondriver)
 	})
+	accesslog.End(ctx, accesslog.StageCoord)
 	if err != nil {
 		return err
 	}
@@ -1491,6 +1854,12 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 	log.Debug("translate output fields", zap.Any("OutputFields", outputFields))
 	st.query.OutputFields = outputFields
 
+	partitionKeyField := getPartitionKeyField(schema)
+	if partitionKeyField != nil && len(st.query.PartitionNames) > 0 {
+		return errPartitionKeyWithPartitionNames(collectionName)
+	}
+	var searchExpr *planpb.Expr
+
 	if st.query.GetDslType() == commonpb.DslType_BoolExprV1 {
 		annsField, err := funcutil.GetAttrByKeyFromRepeatedKV(AnnsFieldKey, st.query.SearchParams)
 		if err != nil {
@@ -1510,6 +1879,8 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 		if err != nil {
 			return errors.New(MetricTypeKey + " not found in search_params")
 		}
+		// segcore takes the metric types case insensitively, they are checked and reduced in upper case
+		metricType = strings.ToUpper(metricType)
 
 		searchParams, err := funcutil.GetAttrByKeyFromRepeatedKV(SearchParamsKey, st.query.SearchParams)
 		if err != nil {
@@ -1528,11 +1899,26 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 			return errors.New(RoundDecimalKey + " " + roundDecimalStr + " is not invalid")
 		}
 
+		if partialStr, err := funcutil.GetAttrByKeyFromRepeatedKV(PartialResultsKey, st.query.SearchParams); err == nil {
+			st.allowPartial, err = strconv.ParseBool(partialStr)
+			if err != nil {
+				return errors.New(PartialResultsKey + " " + partialStr + " is invalid")
+			}
+		}
+		if statsStr, err := funcutil.GetAttrByKeyFromRepeatedKV(ShardStatsKey, st.query.SearchParams); err == nil {
+			st.withShardStats, err = strconv.ParseBool(statsStr)
+			if err != nil {
+				return errors.New(ShardStatsKey + " " + statsStr + " is invalid")
+			}
+		}
+
+		// the distances are rounded by the proxy after the results of all the shards are reduced,
+		// so the QueryNodes are asked not to round them
 		queryInfo := &planpb.QueryInfo{
 			Topk:         int64(topK),
 			MetricType:   metricType,
 			SearchParams: searchParams,
-			RoundDecimal: int64(roundDecimal),
+			RoundDecimal: -1,
 		}
 
 		log.Debug("create query plan",
@@ -1552,6 +1938,11 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 
 			return fmt.Errorf("failed to create query plan: %v", err)
 		}
+		searchExpr = plan.GetVectorAnns().GetPredicates()
+
+		if err := st.checkSearchParams(ctx, schema, annsField, metricType, searchParams, int64(topK)); err != nil {
+			return err
+		}
 		for _, name := range st.query.OutputFields {
 			hitField := false
 			for _, field := range schema.Fields {
@@ -1583,11 +1974,10 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 	travelTimestamp := st.query.TravelTimestamp
 	if travelTimestamp == 0 {
 		travelTimestamp = st.BeginTs()
+	} else if err := validateTravelTimestamp(travelTimestamp, st.BeginTs()); err != nil {
+		return err
 	}
-	guaranteeTimestamp := st.query.GuaranteeTimestamp
-	if guaranteeTimestamp == 0 {
-		guaranteeTimestamp = st.BeginTs()
-	}
+	guaranteeTimestamp := computeGuaranteeTs(st.query.ConsistencyLevel, st.query.GuaranteeTimestamp, st.BeginTs(), st.sessionTs)
 	st.SearchRequest.TravelTimestamp = travelTimestamp
 	st.SearchRequest.GuaranteeTimestamp = guaranteeTimestamp
 
@@ -1624,8 +2014,24 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 		}
 	}
 
+	if partitionKeyField != nil {
+		partitionIDs, err := getSearchPartitionKeyPartitionIDs(ctx, collectionName, partitionKeyField, searchExpr)
+		if err != nil {
+			return err
+		}
+		if len(partitionIDs) > 0 {
+			st.PartitionIDs = partitionIDs
+		}
+		log.Debug("search partitions of partition key", zap.String("collection", collectionName),
+			zap.Int64s("partitionIDs", st.PartitionIDs))
+	}
+
 	st.SearchRequest.Dsl = st.query.Dsl
 	st.SearchRequest.PlaceholderGroup = st.query.PlaceholderGroup
+	// query nodes drop the search once nobody waits for it
+	if deadline, ok := st.TraceCtx().Deadline(); ok {
+		st.SearchRequest.TimeoutTimestamp = tsoutil.ComposeTS(deadline.UnixNano()/int64(time.Millisecond), 0)
+	}
 
 	searchIDs := st.query.GetSearchIDs().GetIntId().GetData()
 	if len(st.SearchRequest.PlaceholderGroup) == 0 && len(searchIDs) > 0 {
@@ -1662,6 +2068,20 @@ func (st *searchTask) PreExecute(ctx context.Context) error {
 func (st *searchTask) Execute(ctx context.Context) error {
 	sp, ctx := trace.StartSpanFromContextWithOperationName(st.TraceCtx(), "Proxy-Search-Execute")
 	defer sp.Finish()
+
+	if st.shardClients != nil {
+		results, missingShards, err := st.searchShards(ctx)
+		if err == nil {
+			st.missingShards = missingShards
+			go st.sendShardResults(results)
+			return nil
+		}
+		log.Warn("search through shard leaders failed, fall back to query channel",
+			zap.Int64("collectionID", st.CollectionID),
+			zap.Int64("msgID", st.ID()),
+			zap.Error(err))
+	}
+
 	var tsMsg msgstream.TsMsg = &msgstream.SearchMsg{
 		SearchRequest: *st.SearchRequest,
 		BaseMsg: msgstream.BaseMsg{
@@ -1707,7 +2127,9 @@ func (st *searchTask) Execute(ctx context.Context) error {
 			return err
 		}
 	}
+	accesslog.Begin(ctx, accesslog.StagePublish)
 	err = stream.Produce(&msgPack)
+	accesslog.End(ctx, accesslog.StagePublish)
 	if err != nil {
 		log.Debug("proxy", zap.String("send search request failed", err.Error()))
 	}
@@ -1762,145 +2184,85 @@ func checkSearchResultData(data *schemapb.SearchResultData, nq int64, topk int64
 	return nil
 }
 
-func selectSearchResultData(dataArray []*schemapb.SearchResultData, offsets []int64, topk int64, qi int64) int {
-	sel := -1
-	maxDistance := minFloat32
-	for i, offset := range offsets { // query num, the number of ways to merge
-		if offset >= topk {
-			continue
-		}
-		idx := qi*topk + offset
-		id := dataArray[i].Ids.GetIntId().Data[idx]
-		if id != -1 {
-			distance := dataArray[i].Scores[idx]
-			if distance > maxDistance {
-				sel = i
-				maxDistance = distance
-			}
-		}
+// searchResultHeap merges the sorted results of a query from all the shards,
+// the shard with the highest score at its current offset is on the top.
+// Ties are broken by primary key then by shard index, so that the merged order is deterministic.
+type searchResultHeap struct {
+	data    []*schemapb.SearchResultData
+	offsets []int64 // offset of the next result of every shard
+	shards  []int   // shards which still have valid results
+	qi      int64
+	topk    int64
+}
+
+func newSearchResultHeap(data []*schemapb.SearchResultData, topk int64) *searchResultHeap {
+	return &searchResultHeap{
+		data:    data,
+		offsets: make([]int64, len(data)),
+		shards:  make([]int, 0, len(data)),
+		topk:    topk,
 	}
-	return sel
 }
 
-func copySearchResultData(dst *schemapb.SearchResultData, src *schemapb.SearchResultData, idx int64) error {
-	for i, fieldData := range src.FieldsData {
-		switch fieldType := fieldData.Field.(type) {
-		case *schemapb.FieldData_Scalars:
-			if dst.FieldsData[i] == nil || dst.FieldsData[i].GetScalars() == nil {
-				dst.FieldsData[i] = &schemapb.FieldData{
-					FieldName: fieldData.FieldName,
-					FieldId:   fieldData.FieldId,
-					Field: &schemapb.FieldData_Scalars{
-						Scalars: &schemapb.ScalarField{},
-					},
-				}
-			}
-			switch scalarType := fieldType.Scalars.Data.(type) {
-			case *schemapb.ScalarField_BoolData:
-				if dst.FieldsData[i].GetScalars().GetBoolData() == nil {
-					dst.FieldsData[i].Field.(*schemapb.FieldData_Scalars).Scalars = &schemapb.ScalarField{
-						Data: &schemapb.ScalarField_BoolData{
-							BoolData: &schemapb.BoolArray{
-								Data: []bool{scalarType.BoolData.Data[idx]},
-							},
-						},
-					}
-				} else {
-					dst.FieldsData[i].GetScalars().GetBoolData().Data = append(dst.FieldsData[i].GetScalars().GetBoolData().Data, scalarType.BoolData.Data[idx])
-				}
-			case *schemapb.ScalarField_IntData:
-				if dst.FieldsData[i].GetScalars().GetIntData() == nil {
-					dst.FieldsData[i].Field.(*schemapb.FieldData_Scalars).Scalars = &schemapb.ScalarField{
-						Data: &schemapb.ScalarField_IntData{
-							IntData: &schemapb.IntArray{
-								Data: []int32{scalarType.IntData.Data[idx]},
-							},
-						},
-					}
-				} else {
-					dst.FieldsData[i].GetScalars().GetIntData().Data = append(dst.FieldsData[i].GetScalars().GetIntData().Data, scalarType.IntData.Data[idx])
-				}
-			case *schemapb.ScalarField_LongData:
-				if dst.FieldsData[i].GetScalars().GetLongData() == nil {
-					dst.FieldsData[i].Field.(*schemapb.FieldData_Scalars).Scalars = &schemapb.ScalarField{
-						Data: &schemapb.ScalarField_LongData{
-							LongData: &schemapb.LongArray{
-								Data: []int64{scalarType.LongData.Data[idx]},
-							},
-						},
-					}
-				} else {
-					dst.FieldsData[i].GetScalars().GetLongData().Data = append(dst.FieldsData[i].GetScalars().GetLongData().Data, scalarType.LongData.Data[idx])
-				}
-			case *schemapb.ScalarField_FloatData:
-				if dst.FieldsData[i].GetScalars().GetFloatData() == nil {
-					dst.FieldsData[i].Field.(*schemapb.FieldData_Scalars).Scalars = &schemapb.ScalarField{
-						Data: &schemapb.ScalarField_FloatData{
-							FloatData: &schemapb.FloatArray{
-								Data: []float32{scalarType.FloatData.Data[idx]},
-							},
-						},
-					}
-				} else {
-					dst.FieldsData[i].GetScalars().GetFloatData().Data = append(dst.FieldsData[i].GetScalars().GetFloatData().Data, scalarType.FloatData.Data[idx])
-				}
-			case *schemapb.ScalarField_DoubleData:
-				if dst.FieldsData[i].GetScalars().GetDoubleData() == nil {
-					dst.FieldsData[i].Field.(*schemapb.FieldData_Scalars).Scalars = &schemapb.ScalarField{
-						Data: &schemapb.ScalarField_DoubleData{
-							DoubleData: &schemapb.DoubleArray{
-								Data: []float64{scalarType.DoubleData.Data[idx]},
-							},
-						},
-					}
-				} else {
-					dst.FieldsData[i].GetScalars().GetDoubleData().Data = append(dst.FieldsData[i].GetScalars().GetDoubleData().Data, scalarType.DoubleData.Data[idx])
-				}
-			default:
-				log.Debug("Not supported field type", zap.String("field type", fieldData.Type.String()))
-				return fmt.Errorf("not supported field type: %s", fieldData.Type.String())
-			}
-		case *schemapb.FieldData_Vectors:
-			dim := fieldType.Vectors.Dim
-			if dst.FieldsData[i] == nil || dst.FieldsData[i].GetVectors() == nil {
-				dst.FieldsData[i] = &schemapb.FieldData{
-					FieldName: fieldData.FieldName,
-					FieldId:   fieldData.FieldId,
-					Field: &schemapb.FieldData_Vectors{
-						Vectors: &schemapb.VectorField{
-							Dim: dim,
-						},
-					},
-				}
-			}
-			switch vectorType := fieldType.Vectors.Data.(type) {
-			case *schemapb.VectorField_BinaryVector:
-				if dst.FieldsData[i].GetVectors().GetBinaryVector() == nil {
-					bvec := &schemapb.VectorField_BinaryVector{
-						BinaryVector: vectorType.BinaryVector[idx*(dim/8) : (idx+1)*(dim/8)],
-					}
-					dst.FieldsData[i].GetVectors().Data = bvec
-				} else {
-					dst.FieldsData[i].GetVectors().Data.(*schemapb.VectorField_BinaryVector).BinaryVector = append(dst.FieldsData[i].GetVectors().Data.(*schemapb.VectorField_BinaryVector).BinaryVector, vectorType.BinaryVector[idx*(dim/8):(idx+1)*(dim/8)]...)
-				}
-			case *schemapb.VectorField_FloatVector:
-				if dst.FieldsData[i].GetVectors().GetFloatVector() == nil {
-					fvec := &schemapb.VectorField_FloatVector{
-						FloatVector: &schemapb.FloatArray{
-							Data: vectorType.FloatVector.Data[idx*dim : (idx+1)*dim],
-						},
-					}
-					dst.FieldsData[i].GetVectors().Data = fvec
-				} else {
-					dst.FieldsData[i].GetVectors().GetFloatVector().Data = append(dst.FieldsData[i].GetVectors().GetFloatVector().Data, vectorType.FloatVector.Data[idx*dim:(idx+1)*dim]...)
-				}
-			default:
-				log.Debug("Not supported field type", zap.String("field type", fieldData.Type.String()))
-				return fmt.Errorf("not supported field type: %s", fieldData.Type.String())
-			}
+// reset prepares the heap for merging the results of query qi
+func (h *searchResultHeap) reset(qi int64) {
+	h.qi = qi
+	h.shards = h.shards[:0]
+	for i := range h.offsets {
+		h.offsets[i] = 0
+		if h.valid(i) {
+			h.shards = append(h.shards, i)
 		}
 	}
-	return nil
+	heap.Init(h)
+}
+
+// valid returns whether the current result of shard is valid, -1 id means no more results
+func (h *searchResultHeap) valid(shard int) bool {
+	return h.offsets[shard] < h.topk && h.data[shard].Ids.GetIntId().Data[h.index(shard)] != -1
+}
+
+// index returns the index of the current result of shard in the search result data
+func (h *searchResultHeap) index(shard int) int64 {
+	return h.qi*h.topk + h.offsets[shard]
+}
+
+func (h *searchResultHeap) Len() int { return len(h.shards) }
+
+func (h *searchResultHeap) Less(i, j int) bool {
+	si, sj := h.shards[i], h.shards[j]
+	idxI, idxJ := h.index(si), h.index(sj)
+	scoreI, scoreJ := h.data[si].Scores[idxI], h.data[sj].Scores[idxJ]
+	if scoreI != scoreJ {
+		return scoreI > scoreJ
+	}
+	idI, idJ := h.data[si].Ids.GetIntId().Data[idxI], h.data[sj].Ids.GetIntId().Data[idxJ]
+	if idI != idJ {
+		return idI < idJ
+	}
+	return si < sj
+}
+
+func (h *searchResultHeap) Swap(i, j int) { h.shards[i], h.shards[j] = h.shards[j], h.shards[i] }
+
+func (h *searchResultHeap) Push(x interface{}) { h.shards = append(h.shards, x.(int)) }
+
+func (h *searchResultHeap) Pop() interface{} {
+	n := len(h.shards)
+	x := h.shards[n-1]
+	h.shards = h.shards[:n-1]
+	return x
+}
+
+// advance moves the top shard to its next result
+func (h *searchResultHeap) advance() {
+	top := h.shards[0]
+	h.offsets[top]++
+	if h.valid(top) {
+		heap.Fix(h, 0)
+	} else {
+		heap.Pop(h)
+	}
 }
 
 //func printSearchResultData(data *schemapb.SearchResultData, header string) {
@@ -1915,7 +2277,53 @@ func copySearchResultData(dst *schemapb.SearchResultData, src *schemapb.SearchRe
 //	}
 //}
 
-func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq int64, topk int64, metricType string) (*milvuspb.SearchResults, error) {
+// searchHit is a result selected by the k-way merge, index is its index in the search result data of shard
+type searchHit struct {
+	shard int
+	index int64
+	id    int64
+	score float32
+}
+
+// postProcessSearchHits turns the scores of the selected results of a query into the distances returned to the user,
+// and rounds them to roundDecimal digits if it's not -1. The rounding is only done here, after the results of all
+// the shards are merged, so the distances don't depend on which node served the results. The results whose distances
+// become equal after rounding are ordered by primary key, which keeps the order of the near-ties stable.
+func postProcessSearchHits(hits []searchHit, metricType string, roundDecimal int64) {
+	// the scores of the distances other than IP are negated, so that the larger score is always the better one
+	isDistance := metricType != "IP"
+	if isDistance {
+		for k := range hits {
+			hits[k].score *= -1
+		}
+	}
+	if roundDecimal == -1 {
+		// the merge has ordered the results by score and then by primary key
+		return
+	}
+	multiplier := math.Pow(10.0, float64(roundDecimal))
+	for k := range hits {
+		hits[k].score = float32(math.Floor(float64(hits[k].score)*multiplier+0.5) / multiplier)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return (hits[i].score < hits[j].score) == isDistance
+		}
+		return hits[i].id < hits[j].id
+	})
+}
+
+// reduceSearchResultData merges the search results of all the shards into the final topk results of every query.
+// Every shard result is sorted by score, so the merge is done by a k-way heap merge, and the fields data of
+// the selected results is appended into the preallocated output after the distances are post-processed.
+func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq int64, topk int64, metricType string, roundDecimal int64) (*milvuspb.SearchResults, error) {
+	ret, _, err := reduceSearchResultDataWithHits(searchResultData, nq, topk, metricType, roundDecimal)
+	return ret, err
+}
+
+// reduceSearchResultDataWithHits reduces the search results like reduceSearchResultData,
+// hits are the numbers of the reduced results taken from every search result
+func reduceSearchResultDataWithHits(searchResultData []*schemapb.SearchResultData, nq int64, topk int64, metricType string, roundDecimal int64) (*milvuspb.SearchResults, []int64, error) {
 
 	tr := timerecord.NewTimeRecorder("reduceSearchResultData")
 	defer func() {
@@ -1932,16 +2340,16 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 		Results: &schemapb.SearchResultData{
 			NumQueries: nq,
 			TopK:       topk,
-			FieldsData: make([]*schemapb.FieldData, len(searchResultData[0].FieldsData)),
-			Scores:     make([]float32, 0),
+			FieldsData: typeutil.PrepareResultFieldData(searchResultData[0].FieldsData, nq*topk),
+			Scores:     make([]float32, 0, nq*topk),
 			Ids: &schemapb.IDs{
 				IdField: &schemapb.IDs_IntId{
 					IntId: &schemapb.LongArray{
-						Data: make([]int64, 0),
+						Data: make([]int64, 0, nq*topk),
 					},
 				},
 			},
-			Topks: make([]int64, 0),
+			Topks: make([]int64, 0, nq),
 		},
 	}
 
@@ -1952,60 +2360,61 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 			zap.Int64("topk", sData.TopK),
 			zap.Any("len(FieldsData)", len(sData.FieldsData)))
 		if err := checkSearchResultData(sData, nq, topk); err != nil {
-			return ret, err
+			return ret, nil, err
 		}
 		//printSearchResultData(sData, strconv.FormatInt(int64(i), 10))
 	}
 
+	h := newSearchResultHeap(searchResultData, topk)
+	hits := make([]int64, len(searchResultData))
+	// ids selected with the same score as prevScore
+	prevIDSet := make(map[int64]struct{})
+	selected := make([]searchHit, 0, topk)
 	var realTopK int64 = -1
 	for i := int64(0); i < nq; i++ {
-		offsets := make([]int64, len(searchResultData))
-
-		var prevIDSet = make(map[int64]struct{})
+		h.reset(i)
+		selected = selected[:0]
 		var prevScore float32 = math.MaxFloat32
 		var j int64
-		for j = 0; j < topk; {
-			sel := selectSearchResultData(searchResultData, offsets, topk, i)
-			if sel == -1 {
-				break
-			}
-			idx := i*topk + offsets[sel]
-
+		for j < topk && h.Len() > 0 {
+			sel := h.shards[0]
+			idx := h.index(sel)
 			id := searchResultData[sel].Ids.GetIntId().Data[idx]
 			score := searchResultData[sel].Scores[idx]
-			// ignore invalid search result
-			if id == -1 {
-				continue
-			}
 
 			// remove duplicates
 			if math.Abs(float64(score)-float64(prevScore)) > 0.00001 {
-				copySearchResultData(ret.Results, searchResultData[sel], idx)
-				ret.Results.Ids.GetIntId().Data = append(ret.Results.Ids.GetIntId().Data, id)
-				ret.Results.Scores = append(ret.Results.Scores, score)
+				for k := range prevIDSet {
+					delete(prevIDSet, k)
+				}
 				prevScore = score
-				prevIDSet = map[int64]struct{}{id: {}}
+			}
+			// To handle this case:
+			//    e1: [100, 0.99]
+			//    e2: [101, 0.99]   ==> not duplicated, should keep
+			//    e3: [100, 0.99]   ==> duplicated, should remove
+			if _, ok := prevIDSet[id]; !ok {
+				selected = append(selected, searchHit{shard: sel, index: idx, id: id, score: score})
+				prevIDSet[id] = struct{}{}
+				hits[sel]++
 				j++
 			} else {
-				// To handle this case:
-				//    e1: [100, 0.99]
-				//    e2: [101, 0.99]   ==> not duplicated, should keep
-				//    e3: [100, 0.99]   ==> duplicated, should remove
-				if _, ok := prevIDSet[id]; !ok {
-					copySearchResultData(ret.Results, searchResultData[sel], idx)
-					ret.Results.Ids.GetIntId().Data = append(ret.Results.Ids.GetIntId().Data, id)
-					ret.Results.Scores = append(ret.Results.Scores, score)
-					prevIDSet[id] = struct{}{}
-					j++
-				} else {
-					// entity with same id and same score must be duplicated
-					log.Debug("skip duplicated search result",
-						zap.Int64("id", id),
-						zap.Float32("score", score),
-						zap.Float32("prevScore", prevScore))
-				}
+				// entity with same id and same score must be duplicated
+				log.Debug("skip duplicated search result",
+					zap.Int64("id", id),
+					zap.Float32("score", score),
+					zap.Float32("prevScore", prevScore))
+			}
+			h.advance()
+		}
+
+		postProcessSearchHits(selected, metricType, roundDecimal)
+		for _, hit := range selected {
+			if err := typeutil.AppendFieldData(ret.Results.FieldsData, searchResultData[hit.shard].FieldsData, hit.index); err != nil {
+				return ret, nil, err
 			}
-			offsets[sel]++
+			ret.Results.Ids.GetIntId().Data = append(ret.Results.Ids.GetIntId().Data, hit.id)
+			ret.Results.Scores = append(ret.Results.Scores, hit.score)
 		}
 		if realTopK != -1 && realTopK != j {
 			log.Warn("Proxy Reduce Search Result", zap.Error(errors.New("the length (topk) between all result of query is different")))
@@ -2017,13 +2426,7 @@ func reduceSearchResultData(searchResultData []*schemapb.SearchResultData, nq in
 
 	ret.Results.TopK = realTopK
 
-	if metricType != "IP" {
-		for k := range ret.Results.Scores {
-			ret.Results.Scores[k] *= -1
-		}
-	}
-
-	return ret, nil
+	return ret, hits, nil
 }
 
 //func printSearchResult(partialSearchResult *internalpb.SearchResults) {
@@ -2045,12 +2448,15 @@ func (st *searchTask) PostExecute(ctx context.Context) error {
 	defer func() {
 		tr.Elapse("done")
 	}()
+	accesslog.Begin(ctx, accesslog.StageResult)
 	for {
 		select {
 		case <-st.TraceCtx().Done():
+			accesslog.End(ctx, accesslog.StageResult)
 			log.Debug("Proxy", zap.Int64("searchTask PostExecute Loop exit caused by ctx.Done", st.ID()))
 			return fmt.Errorf("searchTask:wait to finish failed, timeout: %d", st.ID())
 		case searchResults := <-st.resultBuf:
+			accesslog.End(ctx, accesslog.StageResult)
 			// fmt.Println("searchResults: ", searchResults)
 			filterSearchResults := make([]*internalpb.SearchResults, 0)
 			var filterReason string
@@ -2099,10 +2505,20 @@ func (st *searchTask) PostExecute(ctx context.Context) error {
 						Topks:      make([]int64, searchResults[0].NumQueries),
 					},
 				}
+				st.markPartialResult()
+				st.fillShardStats(nil, nil)
 				return nil
 			}
 
-			st.result, err = reduceSearchResultData(validSearchResults, searchResults[0].NumQueries, searchResults[0].TopK, searchResults[0].MetricType)
+			// round_decimal has been validated in PreExecute
+			roundDecimal := int64(-1)
+			if roundDecimalStr, err := funcutil.GetAttrByKeyFromRepeatedKV(RoundDecimalKey, st.query.SearchParams); err == nil {
+				if rd, err := strconv.Atoi(roundDecimalStr); err == nil {
+					roundDecimal = int64(rd)
+				}
+			}
+			var hits []int64
+			st.result, hits, err = reduceSearchResultDataWithHits(validSearchResults, searchResults[0].NumQueries, searchResults[0].TopK, searchResults[0].MetricType, roundDecimal)
 			if err != nil {
 				return err
 			}
@@ -2122,11 +2538,36 @@ func (st *searchTask) PostExecute(ctx context.Context) error {
 					}
 				}
 			}
+			if err := validatePayloadSize("search result", proto.Size(st.result), Params.SearchMaxResultSize,
+				"please reduce the nq, topk or output fields of the search"); err != nil {
+				st.result = nil
+				return err
+			}
+			st.markPartialResult()
+			// the search results are decoded in order, skipping the empty ones
+			decoded := make([]*internalpb.SearchResults, 0, len(filterSearchResults))
+			for _, result := range filterSearchResults {
+				if result.SlicedBlob != nil {
+					decoded = append(decoded, result)
+				}
+			}
+			st.fillShardStats(decoded, hits)
 			return nil
 		}
 	}
 }
 
+// markPartialResult flags the result if some shards are missing from it
+func (st *searchTask) markPartialResult() {
+	if len(st.missingShards) == 0 {
+		return
+	}
+	st.result.PartialResult = true
+	st.result.MissingShards = st.missingShards
+	log.Warn("search returns partial results", zap.Int64("msgID", st.ID()),
+		zap.Int64("collectionID", st.CollectionID), zap.Strings("missingShards", st.missingShards))
+}
+
 type queryTask struct {
 	Condition
 	*internalpb.RetrieveRequest
@@ -2137,6 +2578,7 @@ type queryTask struct {
 	chMgr     channelsMgr
 	qc        types.QueryCoord
 	ids       *schemapb.IDs
+	sessionTs Timestamp // the last write timestamp of the client session
 }
 
 func (qt *queryTask) TraceCtx() context.Context {
@@ -2252,7 +2694,12 @@ func (qt *queryTask) PreExecute(ctx context.Context) error {
 	log.Info("Validate partition names.",
 		zap.Any("requestID", qt.Base.MsgID), zap.Any("requestType", "query"))
 
+	if err := validateQueryWindow(qt.query.Offset, qt.query.Limit); err != nil {
+		return err
+	}
+
 	// check if collection was already loaded into query node
+	accesslog.Begin(ctx, accesslog.StageCoord)
 	showResp, err := qt.qc.ShowCollections(qt.ctx, &querypb.ShowCollectionsRequest{
 		Base: &commonpb.MsgBase{
 			MsgType:   commonpb.MsgType_ShowCollections,
@@ -2262,6 +2709,7 @@ func (qt *queryTask) PreExecute(ctx context.Context) error {
 		},
 		DbID: 0, // TODO(dragondriver)
 	})
+	accesslog.End(ctx, accesslog.StageCoord)
 	if err != nil {
 		return err
 	}
@@ -2285,6 +2733,11 @@ func (qt *queryTask) PreExecute(ctx context.Context) error {
 
 	schema, _ := globalMetaCache.GetCollectionSchema(ctx, qt.query.CollectionName)
 
+	partitionKeyField := getPartitionKeyField(schema)
+	if partitionKeyField != nil && len(qt.query.PartitionNames) > 0 {
+		return errPartitionKeyWithPartitionNames(collectionName)
+	}
+
 	if qt.ids != nil {
 		pkField := ""
 		for _, field := range schema.Fields {
@@ -2309,38 +2762,12 @@ func (qt *queryTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 	log.Debug("translate output fields", zap.Any("OutputFields", qt.query.OutputFields))
-	if len(qt.query.OutputFields) == 0 {
-		for _, field := range schema.Fields {
-			if field.FieldID >= 100 && field.DataType != schemapb.DataType_FloatVector && field.DataType != schemapb.DataType_BinaryVector {
-				qt.OutputFieldsId = append(qt.OutputFieldsId, field.FieldID)
-			}
-		}
-	} else {
-		addPrimaryKey := false
-		for _, reqField := range qt.query.OutputFields {
-			findField := false
-			for _, field := range schema.Fields {
-				if reqField == field.Name {
-					if field.IsPrimaryKey {
-						addPrimaryKey = true
-					}
-					findField = true
-					qt.OutputFieldsId = append(qt.OutputFieldsId, field.FieldID)
-					plan.OutputFieldIds = append(plan.OutputFieldIds, field.FieldID)
-				} else {
-					if field.IsPrimaryKey && !addPrimaryKey {
-						qt.OutputFieldsId = append(qt.OutputFieldsId, field.FieldID)
-						plan.OutputFieldIds = append(plan.OutputFieldIds, field.FieldID)
-						addPrimaryKey = true
-					}
-				}
-			}
-			if !findField {
-				errMsg := "Field " + reqField + " not exist"
-				return errors.New(errMsg)
-			}
-		}
+	qt.OutputFieldsId, err = translateToOutputFieldIDs(qt.query.OutputFields, schema)
+	if err != nil {
+		return err
 	}
+	// segcore only reads the columns listed in the plan, keep them the same as the result fields
+	plan.OutputFieldIds = qt.OutputFieldsId
 	log.Debug("translate output fields to field ids", zap.Any("OutputFieldsID", qt.OutputFieldsId))
 
 	qt.RetrieveRequest.SerializedExprPlan, err = proto.Marshal(plan)
@@ -2351,13 +2778,17 @@ func (qt *queryTask) PreExecute(ctx context.Context) error {
 	travelTimestamp := qt.query.TravelTimestamp
 	if travelTimestamp == 0 {
 		travelTimestamp = qt.BeginTs()
+	} else if err := validateTravelTimestamp(travelTimestamp, qt.BeginTs()); err != nil {
+		return err
 	}
-	guaranteeTimestamp := qt.query.GuaranteeTimestamp
-	if guaranteeTimestamp == 0 {
-		guaranteeTimestamp = qt.BeginTs()
-	}
+	guaranteeTimestamp := computeGuaranteeTs(qt.query.ConsistencyLevel, qt.query.GuaranteeTimestamp, qt.BeginTs(), qt.sessionTs)
 	qt.TravelTimestamp = travelTimestamp
 	qt.GuaranteeTimestamp = guaranteeTimestamp
+	// every query node returns the first offset+limit entities sorted by primary key, the final page is sliced in
+	// PostExecute. The pages are consistent if they are queried at the same travel timestamp.
+	if qt.query.Limit > 0 {
+		qt.RetrieveRequest.Limit = qt.query.Offset + qt.query.Limit
+	}
 
 	qt.ResultChannelID = Params.RetrieveResultChannelNames[0]
 	qt.DbID = 0 // todo(yukun)
@@ -2400,6 +2831,18 @@ func (qt *queryTask) PreExecute(ctx context.Context) error {
 		}
 	}
 
+	if partitionKeyField != nil {
+		partitionIDs, err := getSearchPartitionKeyPartitionIDs(ctx, collectionName, partitionKeyField, plan.GetPredicates())
+		if err != nil {
+			return err
+		}
+		if len(partitionIDs) > 0 {
+			qt.PartitionIDs = partitionIDs
+		}
+		log.Debug("query partitions of partition key", zap.String("collection", collectionName),
+			zap.Int64s("partitionIDs", qt.PartitionIDs))
+	}
+
 	log.Info("Query PreExecute done.",
 		zap.Any("requestID", qt.Base.MsgID), zap.Any("requestType", "query"))
 	return nil
@@ -2437,7 +2880,9 @@ func (qt *queryTask) Execute(ctx context.Context) error {
 			return err
 		}
 	}
+	accesslog.Begin(ctx, accesslog.StagePublish)
 	err = stream.Produce(&msgPack)
+	accesslog.End(ctx, accesslog.StagePublish)
 	log.Debug("proxy", zap.Int("length of retrieveMsg", len(msgPack.Msgs)))
 	if err != nil {
 		log.Debug("Failed to send retrieve request.",
@@ -2454,11 +2899,14 @@ func (qt *queryTask) PostExecute(ctx context.Context) error {
 	defer func() {
 		tr.Elapse("done")
 	}()
+	accesslog.Begin(ctx, accesslog.StageResult)
 	select {
 	case <-qt.TraceCtx().Done():
+		accesslog.End(ctx, accesslog.StageResult)
 		log.Debug("proxy", zap.Int64("Query: wait to finish failed, timeout!, taskID:", qt.ID()))
 		return fmt.Errorf("queryTask:wait to finish failed, timeout : %d", qt.ID())
 	case retrieveResults := <-qt.resultBuf:
+		accesslog.End(ctx, accesslog.StageResult)
 		retrieveResult := make([]*internalpb.RetrieveResults, 0)
 		var reason string
 		for _, partialRetrieveResult := range retrieveResults {
@@ -2482,6 +2930,7 @@ func (qt *queryTask) PostExecute(ctx context.Context) error {
 		}
 
 		availableQueryNodeNum := 0
+		ids := make([]int64, 0)
 		qt.result = &milvuspb.QueryResults{
 			Status: &commonpb.Status{
 				ErrorCode: commonpb.ErrorCode_Success,
@@ -2494,6 +2943,7 @@ func (qt *queryTask) PostExecute(ctx context.Context) error {
 				reason += "ids is nil\n"
 				continue
 			} else {
+				ids = append(ids, partialRetrieveResult.Ids.GetIntId().GetData()...)
 				// handles initialization, cannot use idx==0 since first result may be empty
 				if len(qt.result.FieldsData) == 0 {
 					qt.result.FieldsData = append(qt.result.FieldsData, partialRetrieveResult.FieldsData...)
@@ -2553,6 +3003,14 @@ func (qt *queryTask) PostExecute(ctx context.Context) error {
 			return nil
 		}
 
+		if qt.query.Limit > 0 {
+			_, fieldsData, err := typeutil.SliceRowsByPrimaryKey(ids, qt.result.FieldsData, qt.query.Offset, qt.query.Limit)
+			if err != nil {
+				return err
+			}
+			qt.result.FieldsData = fieldsData
+		}
+
 		schema, err := globalMetaCache.GetCollectionSchema(ctx, qt.query.CollectionName)
 		if err != nil {
 			return err
@@ -2735,6 +3193,7 @@ func (dct *describeCollectionTask) Execute(ctx context.Context) error {
 		dct.result.CreatedTimestamp = result.CreatedTimestamp
 		dct.result.CreatedUtcTimestamp = result.CreatedUtcTimestamp
 		dct.result.ShardsNum = result.ShardsNum
+		dct.result.Properties = result.Properties
 		for _, field := range result.Schema.Fields {
 			if field.FieldID >= common.StartOfUserFieldID {
 				dct.result.Schema.Fields = append(dct.result.Schema.Fields, &schemapb.FieldSchema{
@@ -3029,29 +3488,16 @@ func (sct *showCollectionsTask) Execute(ctx context.Context) error {
 			IDs2Names[collectionID] = collectionName
 		}
 
-		resp, err := sct.queryCoord.ShowCollections(ctx, &querypb.ShowCollectionsRequest{
-			Base: &commonpb.MsgBase{
-				MsgType:   commonpb.MsgType_ShowCollections,
-				MsgID:     sct.Base.MsgID,
-				Timestamp: sct.Base.Timestamp,
-				SourceID:  sct.Base.SourceID,
-			},
-			//DbID: sct.ShowCollectionsRequest.DbName,
-			CollectionIDs: collectionIDs,
-		})
-
+		resp, err := showCollectionsLoadProgress(ctx, sct.queryCoord, &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_ShowCollections,
+			MsgID:     sct.Base.MsgID,
+			Timestamp: sct.Base.Timestamp,
+			SourceID:  sct.Base.SourceID,
+		}, collectionIDs)
 		if err != nil {
 			return err
 		}
 
-		if resp == nil {
-			return errors.New("failed to show collections")
-		}
-
-		if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
-			return errors.New(resp.Status.Reason)
-		}
-
 		sct.result = &milvuspb.ShowCollectionsResponse{
 			Status:               resp.Status,
 			CollectionNames:      make([]string, 0, len(resp.CollectionIDs)),
@@ -3150,6 +3596,15 @@ func (cpt *createPartitionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, collName)
+	if err != nil {
+		return err
+	}
+	// the partitions of partition key are managed by proxy
+	if getPartitionKeyField(schema) != nil {
+		return fmt.Errorf("can't create partition of collection %s which has partition key", collName)
+	}
+
 	return nil
 }
 
@@ -3227,6 +3682,15 @@ func (dpt *dropPartitionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, collName)
+	if err != nil {
+		return err
+	}
+	// the partitions of partition key are managed by proxy
+	if getPartitionKeyField(schema) != nil {
+		return fmt.Errorf("can't drop partition of collection %s which has partition key", collName)
+	}
+
 	return nil
 }
 
@@ -3424,29 +3888,16 @@ func (spt *showPartitionsTask) Execute(ctx context.Context) error {
 			partitionIDs = append(partitionIDs, partitionID)
 			IDs2Names[partitionID] = partitionName
 		}
-		resp, err := spt.queryCoord.ShowPartitions(ctx, &querypb.ShowPartitionsRequest{
-			Base: &commonpb.MsgBase{
-				MsgType:   commonpb.MsgType_ShowCollections,
-				MsgID:     spt.Base.MsgID,
-				Timestamp: spt.Base.Timestamp,
-				SourceID:  spt.Base.SourceID,
-			},
-			CollectionID: collectionID,
-			PartitionIDs: partitionIDs,
-		})
-
+		resp, err := showPartitionsLoadProgress(ctx, spt.queryCoord, &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_ShowPartitions,
+			MsgID:     spt.Base.MsgID,
+			Timestamp: spt.Base.Timestamp,
+			SourceID:  spt.Base.SourceID,
+		}, collectionID, partitionIDs)
 		if err != nil {
 			return err
 		}
 
-		if resp == nil {
-			return errors.New("failed to show partitions")
-		}
-
-		if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
-			return errors.New(resp.Status.Reason)
-		}
-
 		spt.result = &milvuspb.ShowPartitionsResponse{
 			Status:               resp.Status,
 			PartitionNames:       make([]string, 0, len(resp.PartitionIDs)),
@@ -3526,6 +3977,28 @@ func (cit *createIndexTask) SetTs(ts Timestamp) {
 	cit.Base.Timestamp = ts
 }
 
+// parseIndexParams converts the index params to map, the params in json of key `params` are expanded
+func parseIndexParams(kvs []*commonpb.KeyValuePair) map[string]string {
+	indexParams := make(map[string]string)
+	for _, kv := range kvs {
+		if kv.Key == "params" { // TODO(dragondriver): change `params` to const variable
+			params, err := funcutil.ParseIndexParamsMap(kv.Value)
+			if err != nil {
+				log.Warn("Failed to parse index params",
+					zap.String("params", kv.Value),
+					zap.Error(err))
+				continue
+			}
+			for k, v := range params {
+				indexParams[k] = v
+			}
+		} else {
+			indexParams[kv.Key] = kv.Value
+		}
+	}
+	return indexParams
+}
+
 func (cit *createIndexTask) OnEnqueue() error {
 	cit.Base = &commonpb.MsgBase{}
 	return nil
@@ -3546,39 +4019,31 @@ func (cit *createIndexTask) PreExecute(ctx context.Context) error {
 	}
 
 	// check index param, not accurate, only some static rules
-	indexParams := make(map[string]string)
-	for _, kv := range cit.CreateIndexRequest.ExtraParams {
-		if kv.Key == "params" { // TODO(dragondriver): change `params` to const variable
-			params, err := funcutil.ParseIndexParamsMap(kv.Value)
-			if err != nil {
-				log.Warn("Failed to parse index params",
-					zap.String("params", kv.Value),
-					zap.Error(err))
-				continue
-			}
-			for k, v := range params {
-				indexParams[k] = v
-			}
-		} else {
-			indexParams[kv.Key] = kv.Value
-		}
-	}
+	indexParams := parseIndexParams(cit.CreateIndexRequest.ExtraParams)
 
-	indexType, exist := indexParams["index_type"] // TODO(dragondriver): change `index_type` to const variable
+	indexType, exist := indexParams[indexparamcheck.IndexTypeKey]
 	if !exist {
 		indexType = indexparamcheck.IndexFaissIvfPQ // IVF_PQ is the default index type
 	}
 
-	adapter, err := indexparamcheck.GetConfAdapterMgrInstance().GetAdapter(indexType)
-	if err != nil {
-		log.Warn("Failed to get conf adapter", zap.String("index_type", indexType))
-		return fmt.Errorf("invalid index type: %s", indexType)
+	// the type and the dim of the field are checked with the index params if the collection is found,
+	// otherwise the collection and the field are validated by rootcoord
+	dataType := schemapb.DataType_None
+	if schema, err := globalMetaCache.GetCollectionSchema(ctx, collName); err == nil {
+		for _, field := range schema.Fields {
+			if field.Name == fieldName {
+				dataType = field.DataType
+				for _, kv := range field.TypeParams {
+					indexParams[kv.Key] = kv.Value
+				}
+				break
+			}
+		}
 	}
 
-	ok := adapter.CheckTrain(indexParams)
-	if !ok {
-		log.Warn("Create index with invalid params", zap.Any("index_params", indexParams))
-		return fmt.Errorf("invalid index params: %v", cit.CreateIndexRequest.ExtraParams)
+	if err := indexparamcheck.CheckIndexParams(indexType, dataType, indexParams); err != nil {
+		log.Warn("Create index with invalid params", zap.Any("index_params", indexParams), zap.Error(err))
+		return fmt.Errorf("invalid index params: %w", err)
 	}
 
 	return nil
@@ -3765,7 +4230,6 @@ type getIndexBuildProgressTask struct {
 	ctx        context.Context
 	indexCoord types.IndexCoord
 	rootCoord  types.RootCoord
-	dataCoord  types.DataCoord
 	result     *milvuspb.GetIndexBuildProgressResponse
 }
 
@@ -3818,167 +4282,87 @@ func (gibpt *getIndexBuildProgressTask) PreExecute(ctx context.Context) error {
 }
 
 func (gibpt *getIndexBuildProgressTask) Execute(ctx context.Context) error {
-	collectionName := gibpt.CollectionName
-	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
-	if err != nil { // err is not nil if collection not exists
+	if gibpt.IndexName == "" {
+		gibpt.IndexName = Params.DefaultIndexName
+	}
+	collectionID, fieldID, err := getIndexedField(ctx, gibpt.rootCoord, gibpt.Base, gibpt.DbName, gibpt.CollectionName, gibpt.IndexName)
+	if err != nil {
 		return err
 	}
 
-	showPartitionRequest := &milvuspb.ShowPartitionsRequest{
+	resp, err := gibpt.indexCoord.GetIndexBuildProgress(ctx, &indexpb.GetIndexBuildProgressRequest{
 		Base: &commonpb.MsgBase{
-			MsgType:   commonpb.MsgType_ShowPartitions,
+			MsgType:   commonpb.MsgType_GetIndexBuildProgress,
 			MsgID:     gibpt.Base.MsgID,
 			Timestamp: gibpt.Base.Timestamp,
 			SourceID:  Params.ProxyID,
 		},
-		DbName:         gibpt.DbName,
-		CollectionName: collectionName,
-		CollectionID:   collectionID,
-	}
-	partitions, err := gibpt.rootCoord.ShowPartitions(ctx, showPartitionRequest)
+		CollectionID: collectionID,
+		FieldID:      fieldID,
+		IndexName:    gibpt.IndexName,
+	})
 	if err != nil {
 		return err
 	}
 
-	if gibpt.IndexName == "" {
-		gibpt.IndexName = Params.DefaultIndexName
+	gibpt.result = &milvuspb.GetIndexBuildProgressResponse{
+		Status:      resp.Status,
+		TotalRows:   resp.TotalRows,
+		IndexedRows: resp.IndexedRows,
+	}
+
+	return nil
+}
+
+func (gibpt *getIndexBuildProgressTask) PostExecute(ctx context.Context) error {
+	return nil
+}
+
+// getIndexedField returns the ids of the collection and the field which the index named indexName is created on.
+func getIndexedField(ctx context.Context, rootCoord types.RootCoord, base *commonpb.MsgBase, dbName, collectionName, indexName string) (UniqueID, UniqueID, error) {
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
+	if err != nil { // err is not nil if collection not exists
+		return 0, 0, err
 	}
 
-	describeIndexReq := milvuspb.DescribeIndexRequest{
+	describeIndexReq := &milvuspb.DescribeIndexRequest{
 		Base: &commonpb.MsgBase{
 			MsgType:   commonpb.MsgType_DescribeIndex,
-			MsgID:     gibpt.Base.MsgID,
-			Timestamp: gibpt.Base.Timestamp,
+			MsgID:     base.MsgID,
+			Timestamp: base.Timestamp,
 			SourceID:  Params.ProxyID,
 		},
-		DbName:         gibpt.DbName,
-		CollectionName: gibpt.CollectionName,
-		//		IndexName:      gibpt.IndexName,
+		DbName:         dbName,
+		CollectionName: collectionName,
 	}
-
-	indexDescriptionResp, err2 := gibpt.rootCoord.DescribeIndex(ctx, &describeIndexReq)
-	if err2 != nil {
-		return err2
+	indexDescriptionResp, err := rootCoord.DescribeIndex(ctx, describeIndexReq)
+	if err != nil {
+		return 0, 0, err
 	}
 
-	matchIndexID := int64(-1)
-	foundIndexID := false
+	fieldName := ""
+	foundIndex := false
 	for _, desc := range indexDescriptionResp.IndexDescriptions {
-		if desc.IndexName == gibpt.IndexName {
-			matchIndexID = desc.IndexID
-			foundIndexID = true
+		if desc.IndexName == indexName {
+			fieldName = desc.FieldName
+			foundIndex = true
 			break
 		}
 	}
-	if !foundIndexID {
-		return fmt.Errorf("no index is created")
-	}
-
-	var allSegmentIDs []UniqueID
-	for _, partitionID := range partitions.PartitionIDs {
-		showSegmentsRequest := &milvuspb.ShowSegmentsRequest{
-			Base: &commonpb.MsgBase{
-				MsgType:   commonpb.MsgType_ShowSegments,
-				MsgID:     gibpt.Base.MsgID,
-				Timestamp: gibpt.Base.Timestamp,
-				SourceID:  Params.ProxyID,
-			},
-			CollectionID: collectionID,
-			PartitionID:  partitionID,
-		}
-		segments, err := gibpt.rootCoord.ShowSegments(ctx, showSegmentsRequest)
-		if err != nil {
-			return err
-		}
-		if segments.Status.ErrorCode != commonpb.ErrorCode_Success {
-			return errors.New(segments.Status.Reason)
-		}
-		allSegmentIDs = append(allSegmentIDs, segments.SegmentIDs...)
-	}
-
-	getIndexStatesRequest := &indexpb.GetIndexStatesRequest{
-		IndexBuildIDs: make([]UniqueID, 0),
-	}
-
-	buildIndexMap := make(map[int64]int64)
-	for _, segmentID := range allSegmentIDs {
-		describeSegmentRequest := &milvuspb.DescribeSegmentRequest{
-			Base: &commonpb.MsgBase{
-				MsgType:   commonpb.MsgType_DescribeSegment,
-				MsgID:     gibpt.Base.MsgID,
-				Timestamp: gibpt.Base.Timestamp,
-				SourceID:  Params.ProxyID,
-			},
-			CollectionID: collectionID,
-			SegmentID:    segmentID,
-		}
-		segmentDesc, err := gibpt.rootCoord.DescribeSegment(ctx, describeSegmentRequest)
-		if err != nil {
-			return err
-		}
-		if segmentDesc.IndexID == matchIndexID {
-			if segmentDesc.EnableIndex {
-				getIndexStatesRequest.IndexBuildIDs = append(getIndexStatesRequest.IndexBuildIDs, segmentDesc.BuildID)
-				buildIndexMap[segmentID] = segmentDesc.BuildID
-			}
-		}
-	}
-
-	states, err := gibpt.indexCoord.GetIndexStates(ctx, getIndexStatesRequest)
-	if err != nil {
-		return err
-	}
-
-	if states.Status.ErrorCode != commonpb.ErrorCode_Success {
-		gibpt.result = &milvuspb.GetIndexBuildProgressResponse{
-			Status: states.Status,
-		}
-	}
-
-	buildFinishMap := make(map[int64]bool)
-	for _, state := range states.States {
-		if state.State == commonpb.IndexState_Finished {
-			buildFinishMap[state.IndexBuildID] = true
-		}
+	if !foundIndex {
+		return 0, 0, fmt.Errorf("no index is created")
 	}
 
-	infoResp, err := gibpt.dataCoord.GetSegmentInfo(ctx, &datapb.GetSegmentInfoRequest{
-		Base: &commonpb.MsgBase{
-			MsgType:   commonpb.MsgType_SegmentInfo,
-			MsgID:     0,
-			Timestamp: 0,
-			SourceID:  Params.ProxyID,
-		},
-		SegmentIDs: allSegmentIDs,
-	})
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, collectionName)
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
-
-	total := int64(0)
-	indexed := int64(0)
-
-	for _, info := range infoResp.Infos {
-		total += info.NumOfRows
-		if buildFinishMap[buildIndexMap[info.ID]] {
-			indexed += info.NumOfRows
+	for _, field := range schema.Fields {
+		if field.Name == fieldName {
+			return collectionID, field.FieldID, nil
 		}
 	}
-
-	gibpt.result = &milvuspb.GetIndexBuildProgressResponse{
-		Status: &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_Success,
-			Reason:    "",
-		},
-		TotalRows:   total,
-		IndexedRows: indexed,
-	}
-
-	return nil
-}
-
-func (gibpt *getIndexBuildProgressTask) PostExecute(ctx context.Context) error {
-	return nil
+	return 0, 0, fmt.Errorf("field %s of index %s not found in collection %s", fieldName, indexName, collectionName)
 }
 
 type getIndexStateTask struct {
@@ -4039,147 +4423,36 @@ func (gist *getIndexStateTask) PreExecute(ctx context.Context) error {
 }
 
 func (gist *getIndexStateTask) Execute(ctx context.Context) error {
-	collectionName := gist.CollectionName
-	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
-	if err != nil { // err is not nil if collection not exists
-		return err
-	}
-
-	showPartitionRequest := &milvuspb.ShowPartitionsRequest{
-		Base: &commonpb.MsgBase{
-			MsgType:   commonpb.MsgType_ShowPartitions,
-			MsgID:     gist.Base.MsgID,
-			Timestamp: gist.Base.Timestamp,
-			SourceID:  Params.ProxyID,
-		},
-		DbName:         gist.DbName,
-		CollectionName: collectionName,
-		CollectionID:   collectionID,
+	if gist.IndexName == "" {
+		gist.IndexName = Params.DefaultIndexName
 	}
-	partitions, err := gist.rootCoord.ShowPartitions(ctx, showPartitionRequest)
+	collectionID, fieldID, err := getIndexedField(ctx, gist.rootCoord, gist.Base, gist.DbName, gist.CollectionName, gist.IndexName)
 	if err != nil {
 		return err
 	}
 
-	if gist.IndexName == "" {
-		gist.IndexName = Params.DefaultIndexName
-	}
-
-	describeIndexReq := milvuspb.DescribeIndexRequest{
+	resp, err := gist.indexCoord.GetIndexState(ctx, &indexpb.GetIndexStateRequest{
 		Base: &commonpb.MsgBase{
-			MsgType:   commonpb.MsgType_DescribeIndex,
+			MsgType:   commonpb.MsgType_GetIndexState,
 			MsgID:     gist.Base.MsgID,
 			Timestamp: gist.Base.Timestamp,
 			SourceID:  Params.ProxyID,
 		},
-		DbName:         gist.DbName,
-		CollectionName: gist.CollectionName,
-		IndexName:      gist.IndexName,
-	}
-
-	indexDescriptionResp, err2 := gist.rootCoord.DescribeIndex(ctx, &describeIndexReq)
-	if err2 != nil {
-		return err2
-	}
-
-	matchIndexID := int64(-1)
-	foundIndexID := false
-	for _, desc := range indexDescriptionResp.IndexDescriptions {
-		if desc.IndexName == gist.IndexName {
-			matchIndexID = desc.IndexID
-			foundIndexID = true
-			break
-		}
-	}
-	if !foundIndexID {
-		return fmt.Errorf("no index is created")
-	}
-
-	var allSegmentIDs []UniqueID
-	for _, partitionID := range partitions.PartitionIDs {
-		showSegmentsRequest := &milvuspb.ShowSegmentsRequest{
-			Base: &commonpb.MsgBase{
-				MsgType:   commonpb.MsgType_ShowSegments,
-				MsgID:     gist.Base.MsgID,
-				Timestamp: gist.Base.Timestamp,
-				SourceID:  Params.ProxyID,
-			},
-			CollectionID: collectionID,
-			PartitionID:  partitionID,
-		}
-		segments, err := gist.rootCoord.ShowSegments(ctx, showSegmentsRequest)
-		if err != nil {
-			return err
-		}
-		if segments.Status.ErrorCode != commonpb.ErrorCode_Success {
-			return errors.New(segments.Status.Reason)
-		}
-		allSegmentIDs = append(allSegmentIDs, segments.SegmentIDs...)
-	}
-
-	getIndexStatesRequest := &indexpb.GetIndexStatesRequest{
-		IndexBuildIDs: make([]UniqueID, 0),
-	}
-
-	for _, segmentID := range allSegmentIDs {
-		describeSegmentRequest := &milvuspb.DescribeSegmentRequest{
-			Base: &commonpb.MsgBase{
-				MsgType:   commonpb.MsgType_DescribeSegment,
-				MsgID:     gist.Base.MsgID,
-				Timestamp: gist.Base.Timestamp,
-				SourceID:  Params.ProxyID,
-			},
-			CollectionID: collectionID,
-			SegmentID:    segmentID,
-		}
-		segmentDesc, err := gist.rootCoord.DescribeSegment(ctx, describeSegmentRequest)
-		if err != nil {
-			return err
-		}
-		if segmentDesc.IndexID == matchIndexID {
-			if segmentDesc.EnableIndex {
-				getIndexStatesRequest.IndexBuildIDs = append(getIndexStatesRequest.IndexBuildIDs, segmentDesc.BuildID)
-			}
-		}
-	}
-
-	gist.result = &milvuspb.GetIndexStateResponse{
-		Status: &commonpb.Status{
-			ErrorCode: commonpb.ErrorCode_Success,
-			Reason:    "",
-		},
-		State:      commonpb.IndexState_Finished,
-		FailReason: "",
-	}
-
-	log.Debug("Proxy GetIndexState", zap.Int("IndexBuildIDs", len(getIndexStatesRequest.IndexBuildIDs)), zap.Error(err))
-
-	if len(getIndexStatesRequest.IndexBuildIDs) == 0 {
-		return nil
-	}
-	states, err := gist.indexCoord.GetIndexStates(ctx, getIndexStatesRequest)
+		CollectionID: collectionID,
+		FieldID:      fieldID,
+		IndexName:    gist.IndexName,
+	})
 	if err != nil {
 		return err
 	}
 
-	if states.Status.ErrorCode != commonpb.ErrorCode_Success {
-		gist.result = &milvuspb.GetIndexStateResponse{
-			Status: states.Status,
-			State:  commonpb.IndexState_Failed,
-		}
-		return nil
-	}
-
-	for _, state := range states.States {
-		if state.State != commonpb.IndexState_Finished {
-			gist.result = &milvuspb.GetIndexStateResponse{
-				Status:     states.Status,
-				State:      state.State,
-				FailReason: state.Reason,
-			}
-			return nil
-		}
+	gist.result = &milvuspb.GetIndexStateResponse{
+		Status:     resp.Status,
+		State:      resp.State,
+		FailReason: resp.FailReason,
 	}
+	log.Debug("Proxy GetIndexState", zap.String("collection", gist.CollectionName), zap.String("index", gist.IndexName),
+		zap.String("state", resp.State.String()), zap.String("reason", resp.FailReason))
 
 	return nil
 }
@@ -4357,19 +4630,29 @@ func (lct *loadCollectionTask) Execute(ctx context.Context) (err error) {
 			Timestamp: lct.Base.Timestamp,
 			SourceID:  lct.Base.SourceID,
 		},
-		DbID:         0,
-		CollectionID: collID,
-		Schema:       collSchema,
+		DbID:                   0,
+		CollectionID:           collID,
+		Schema:                 collSchema,
+		UnindexedSegmentPolicy: lct.UnindexedSegmentPolicy,
 	}
 	log.Debug("send LoadCollectionRequest to query coordinator", zap.String("role", Params.RoleName), zap.Int64("msgID", request.Base.MsgID), zap.Int64("collectionID", request.CollectionID),
 		zap.Any("schema", request.Schema))
 	lct.result, err = lct.queryCoord.LoadCollection(ctx, request)
-	if err != nil {
+	if err = queryCoordError(lct.result, err); err != nil {
 		return fmt.Errorf("call query coordinator LoadCollection: %s", err)
 	}
 	return nil
 }
 
+// queryCoordError returns the error of a query coord request, nil if the failure is reported by the status,
+// which is then returned to the client unchanged, keeping its error code and detail
+func queryCoordError(status *commonpb.Status, err error) error {
+	if err != nil && status != nil && status.ErrorCode != commonpb.ErrorCode_Success {
+		return nil
+	}
+	return err
+}
+
 func (lct *loadCollectionTask) PostExecute(ctx context.Context) error {
 	log.Debug("loadCollectionTask PostExecute", zap.String("role", Params.RoleName), zap.Int64("msgID", lct.Base.MsgID))
 	return nil
@@ -4454,7 +4737,7 @@ func (rct *releaseCollectionTask) Execute(ctx context.Context) (err error) {
 
 	_ = rct.chMgr.removeDQLStream(collID)
 
-	return err
+	return queryCoordError(rct.result, err)
 }
 
 func (rct *releaseCollectionTask) PostExecute(ctx context.Context) error {
@@ -4543,13 +4826,14 @@ func (lpt *loadPartitionsTask) Execute(ctx context.Context) error {
 			Timestamp: lpt.Base.Timestamp,
 			SourceID:  lpt.Base.SourceID,
 		},
-		DbID:         0,
-		CollectionID: collID,
-		PartitionIDs: partitionIDs,
-		Schema:       collSchema,
+		DbID:                   0,
+		CollectionID:           collID,
+		PartitionIDs:           partitionIDs,
+		Schema:                 collSchema,
+		UnindexedSegmentPolicy: lpt.UnindexedSegmentPolicy,
 	}
 	lpt.result, err = lpt.queryCoord.LoadPartitions(ctx, request)
-	return err
+	return queryCoordError(lpt.result, err)
 }
 
 func (lpt *loadPartitionsTask) PostExecute(ctx context.Context) error {
@@ -4639,7 +4923,7 @@ func (rpt *releasePartitionsTask) Execute(ctx context.Context) (err error) {
 		PartitionIDs: partitionIDs,
 	}
 	rpt.result, err = rpt.queryCoord.ReleasePartitions(ctx, request)
-	return err
+	return queryCoordError(rpt.result, err)
 }
 
 func (rpt *releasePartitionsTask) PostExecute(ctx context.Context) error {
@@ -4658,6 +4942,9 @@ type deleteTask struct {
 	chTicker  channelsTimeTicker
 	vChannels []vChan
 	pChannels []pChan
+
+	// primaryKeys are the keys to delete, the keys are parsed from the expression of req if they are nil
+	primaryKeys []int64
 }
 
 func (dt *deleteTask) TraceCtx() context.Context {
@@ -4698,27 +4985,15 @@ func (dt *deleteTask) OnEnqueue() error {
 }
 
 func getPrimaryKeysFromExpr(schema *schemapb.CollectionSchema, expr string) (res []int64, err error) {
-	if len(expr) == 0 {
-		log.Warn("empty expr")
-		return
-	}
-
-	plan, err := createExprPlan(schema, expr)
+	pks, isPkExpr, err := parseDeleteExpr(schema, expr)
 	if err != nil {
-		return res, fmt.Errorf("failed to create expr plan, expr = %s", expr)
+		return res, err
 	}
-
-	// delete request only support expr "id in [a, b]"
-	termExpr, ok := plan.Node.(*planpb.PlanNode_Predicates).Predicates.Expr.(*planpb.Expr_TermExpr)
-	if !ok {
+	// the delete task only supports expr "id in [a, b]", other expressions are resolved by retrieval before
+	if !isPkExpr {
 		return res, fmt.Errorf("invalid plan node type")
 	}
-
-	for _, v := range termExpr.TermExpr.Values {
-		res = append(res, v.GetInt64Val())
-	}
-
-	return res, nil
+	return pks, nil
 }
 
 func (dt *deleteTask) PreExecute(ctx context.Context) error {
@@ -4770,10 +5045,13 @@ func (dt *deleteTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
-	primaryKeys, err := getPrimaryKeysFromExpr(schema, dt.req.Expr)
-	if err != nil {
-		log.Error("Failed to get primary keys from expr", zap.Error(err))
-		return err
+	primaryKeys := dt.primaryKeys
+	if primaryKeys == nil {
+		primaryKeys, err = getPrimaryKeysFromExpr(schema, dt.req.Expr)
+		if err != nil {
+			log.Error("Failed to get primary keys from expr", zap.Error(err))
+			return err
+		}
 	}
 	log.Debug("get primary keys from expr", zap.Any("primary keys", primaryKeys))
 	dt.DeleteRequest.PrimaryKeys = primaryKeys
@@ -4836,6 +5114,12 @@ func (dt *deleteTask) Execute(ctx context.Context) (err error) {
 			return err
 		}
 	}
+	vchannels, err := dt.chMgr.getVChannels(collID)
+	if err != nil {
+		dt.result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		dt.result.Status.Reason = err.Error()
+		return err
+	}
 	result := make(map[int32]msgstream.TsMsg)
 	hashKeys := stream.ComputeProduceChannelIndexes(msgPack.Msgs)
 	// For each msg, assign PK to different message buckets by hash value of PK.
@@ -4863,6 +5147,7 @@ func (dt *deleteTask) Execute(ctx context.Context) (err error) {
 					PartitionID:    partitionID,
 					CollectionName: collectionName,
 					PartitionName:  partitionName,
+					ShardName:      vchannels[key],
 				}
 				deleteMsg := &msgstream.DeleteMsg{
 					BaseMsg: msgstream.BaseMsg{
@@ -4893,7 +5178,9 @@ func (dt *deleteTask) Execute(ctx context.Context) (err error) {
 		}
 	}
 
+	accesslog.Begin(ctx, accesslog.StagePublish)
 	err = stream.Produce(newPack)
+	accesslog.End(ctx, accesslog.StagePublish)
 	if err != nil {
 		dt.result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
 		dt.result.Status.Reason = err.Error()
@@ -5123,3 +5410,69 @@ func (a *AlterAliasTask) Execute(ctx context.Context) error {
 func (a *AlterAliasTask) PostExecute(ctx context.Context) error {
 	return nil
 }
+
+type alterCollectionTask struct {
+	Condition
+	*milvuspb.AlterCollectionRequest
+	ctx       context.Context
+	rootCoord types.RootCoord
+	result    *commonpb.Status
+}
+
+func (a *alterCollectionTask) TraceCtx() context.Context {
+	return a.ctx
+}
+
+func (a *alterCollectionTask) ID() UniqueID {
+	return a.Base.MsgID
+}
+
+func (a *alterCollectionTask) SetID(uid UniqueID) {
+	a.Base.MsgID = uid
+}
+
+func (a *alterCollectionTask) Name() string {
+	return AlterCollectionTaskName
+}
+
+func (a *alterCollectionTask) Type() commonpb.MsgType {
+	return a.Base.MsgType
+}
+
+func (a *alterCollectionTask) BeginTs() Timestamp {
+	return a.Base.Timestamp
+}
+
+func (a *alterCollectionTask) EndTs() Timestamp {
+	return a.Base.Timestamp
+}
+
+func (a *alterCollectionTask) SetTs(ts Timestamp) {
+	a.Base.Timestamp = ts
+}
+
+func (a *alterCollectionTask) OnEnqueue() error {
+	a.Base = &commonpb.MsgBase{}
+	return nil
+}
+
+func (a *alterCollectionTask) PreExecute(ctx context.Context) error {
+	a.Base.MsgType = commonpb.MsgType_AlterCollection
+	a.Base.SourceID = Params.ProxyID
+
+	if err := validateCollectionName(a.CollectionName); err != nil {
+		return err
+	}
+	// rootcoord validates the properties again, checking here only saves a round trip
+	return common.ValidateCollectionProperties(a.Properties)
+}
+
+func (a *alterCollectionTask) Execute(ctx context.Context) error {
+	var err error
+	a.result, err = a.rootCoord.AlterCollection(ctx, a.AlterCollectionRequest)
+	return err
+}
+
+func (a *alterCollectionTask) PostExecute(ctx context.Context) error {
+	return nil
+}
@@ -27,11 +27,15 @@ import (
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/opentracing/opentracing-go"
 	oplog "github.com/opentracing/opentracing-go/log"
 )
 
+// schedulerLogger is the name of the logger of the task queues, whose level can be set apart at runtime
+const schedulerLogger = "proxy.scheduler"
+
 type taskQueue interface {
 	utChan() <-chan int
 	utEmpty() bool
@@ -120,7 +124,7 @@ func (queue *baseTaskQueue) AddActiveTask(t task) {
 	tID := t.ID()
 	_, ok := queue.activeTasks[tID]
 	if ok {
-		log.Debug("Proxy task with tID already in active task list!", zap.Any("ID", tID))
+		log.Ctx(t.TraceCtx()).Named(schedulerLogger).Debug("Proxy task with tID already in active task list!", zap.Any("ID", tID))
 	}
 
 	queue.activeTasks[tID] = t
@@ -177,6 +181,7 @@ func (queue *baseTaskQueue) Enqueue(t task) error {
 	}
 	t.SetID(reqID)
 
+	accesslog.Begin(t.TraceCtx(), accesslog.StageQueue)
 	return queue.addUnissuedTask(t)
 }
 
@@ -244,7 +249,7 @@ func (queue *dmTaskQueue) PopActiveTask(tID UniqueID) task {
 	t, ok := queue.activeTasks[tID]
 	if ok {
 		delete(queue.activeTasks, tID)
-		log.Debug("Proxy dmTaskQueue popPChanStats", zap.Any("tID", t.ID()))
+		log.Ctx(t.TraceCtx()).Named(schedulerLogger).Debug("Proxy dmTaskQueue popPChanStats", zap.Any("tID", t.ID()))
 		queue.popPChanStats(t)
 	} else {
 		log.Debug("Proxy task not in active task list!", zap.Any("tID", tID))
@@ -256,7 +261,7 @@ func (queue *dmTaskQueue) addPChanStats(t task) error {
 	if dmT, ok := t.(dmlTask); ok {
 		stats, err := dmT.getPChanStats()
 		if err != nil {
-			log.Debug("Proxy dmTaskQueue addPChanStats", zap.Any("tID", t.ID()),
+			log.Ctx(t.TraceCtx()).Named(schedulerLogger).Debug("Proxy dmTaskQueue addPChanStats", zap.Any("tID", t.ID()),
 				zap.Any("stats", stats), zap.Error(err))
 			return err
 		}
@@ -417,13 +422,13 @@ func (sched *taskScheduler) getTaskByReqID(collMeta UniqueID) task {
 }
 
 func (sched *taskScheduler) processTask(t task, q taskQueue) {
+	accesslog.End(t.TraceCtx(), accesslog.StageQueue)
 	span, ctx := trace.StartSpanFromContext(t.TraceCtx(),
 		opentracing.Tags{
 			"Type": t.Name(),
 			"ID":   t.ID(),
 		})
 	defer span.Finish()
-	traceID, _, _ := trace.InfoFromSpan(span)
 
 	span.LogFields(oplog.Int64("scheduler process AddActiveTask", t.ID()))
 	q.AddActiveTask(t)
@@ -441,17 +446,23 @@ func (sched *taskScheduler) processTask(t task, q taskQueue) {
 	}()
 	if err != nil {
 		trace.LogError(span, err)
-		log.Error("Failed to pre-execute task: "+err.Error(),
-			zap.String("traceID", traceID))
+		log.Ctx(ctx).Named(schedulerLogger).Error("Failed to pre-execute task: "+err.Error())
 		return
 	}
 
 	span.LogFields(oplog.Int64("scheduler process Execute", t.ID()))
+	// the definition tasks are executed by the coordinators
+	isDdTask := q == sched.ddQueue
+	if isDdTask {
+		accesslog.Begin(ctx, accesslog.StageCoord)
+	}
 	err = t.Execute(ctx)
+	if isDdTask {
+		accesslog.End(ctx, accesslog.StageCoord)
+	}
 	if err != nil {
 		trace.LogError(span, err)
-		log.Error("Failed to execute task: "+err.Error(),
-			zap.String("traceID", traceID))
+		log.Ctx(ctx).Named(schedulerLogger).Error("Failed to execute task: "+err.Error())
 		return
 	}
 
@@ -460,8 +471,7 @@ func (sched *taskScheduler) processTask(t task, q taskQueue) {
 
 	if err != nil {
 		trace.LogError(span, err)
-		log.Error("Failed to post-execute task: "+err.Error(),
-			zap.String("traceID", traceID))
+		log.Ctx(ctx).Named(schedulerLogger).Error("Failed to post-execute task: "+err.Error())
 		return
 	}
 }
@@ -508,7 +518,7 @@ func (sched *taskScheduler) queryLoop() {
 				t := sched.scheduleDqTask()
 				go sched.processTask(t, sched.dqQueue)
 			} else {
-				log.Debug("query queue is empty ...")
+				log.Ctx(sched.ctx).Named(schedulerLogger).WithRateLimit("proxy.scheduler.queryLoop", 1, 60).Debug("query queue is empty ...")
 			}
 		}
 	}
@@ -7,7 +7,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 	"strconv"
 	"sync"
 	"testing"
@@ -26,6 +28,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/util/distance"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/milvus-io/milvus/internal/util/uniquegenerator"
 	"github.com/stretchr/testify/assert"
 )
@@ -758,6 +762,47 @@ func TestTranslateOutputFields(t *testing.T) {
 	assert.ElementsMatch(t, []string{idFieldName, floatVectorFieldName, binaryVectorFieldName}, outputFields)
 }
 
+func TestTranslateToOutputFieldIDs(t *testing.T) {
+	const fieldNum = 20
+	schema := &schemapb.CollectionSchema{
+		Name:   "TestTranslateToOutputFieldIDs",
+		AutoID: false,
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	for i := 0; len(schema.Fields) < fieldNum+2; i++ {
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			FieldID:  int64(102 + i),
+			Name:     fmt.Sprintf("field_%d", 102+i),
+			DataType: schemapb.DataType_Int64,
+		})
+	}
+
+	// all the scalar fields by default
+	ids, err := translateToOutputFieldIDs(nil, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, fieldNum-1, len(ids))
+	assert.NotContains(t, ids, int64(101))
+
+	// primary key is appended
+	ids, err = translateToOutputFieldIDs([]string{"field_102", "vec"}, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, []UniqueID{102, 101, 100}, ids)
+
+	// primary key is not duplicated
+	ids, err = translateToOutputFieldIDs([]string{"pk", "field_103"}, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, []UniqueID{100, 103}, ids)
+
+	_, err = translateToOutputFieldIDs([]string{"field_102", "not_exist"}, schema)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not_exist")
+}
+
 func TestSearchTask(t *testing.T) {
 	ctx := context.Background()
 	ctxCancel, cancel := context.WithCancel(ctx)
@@ -1455,6 +1500,7 @@ func TestCreatePartitionTask(t *testing.T) {
 	rc := NewRootCoordMock()
 	rc.Start()
 	defer rc.Stop()
+	InitMetaCache(rc)
 	ctx := context.Background()
 	prefix := "TestCreatePartitionTask"
 	dbName := ""
@@ -1502,6 +1548,7 @@ func TestDropPartitionTask(t *testing.T) {
 	rc := NewRootCoordMock()
 	rc.Start()
 	defer rc.Stop()
+	InitMetaCache(rc)
 	ctx := context.Background()
 	prefix := "TestDropPartitionTask"
 	dbName := ""
@@ -1766,6 +1813,7 @@ func TestSearchTask_all(t *testing.T) {
 		query:     req,
 		chMgr:     chMgr,
 		qc:        qc,
+		rc:        rc,
 	}
 
 	// simple mock for query node
@@ -2112,6 +2160,7 @@ func TestSearchTaskWithInvalidRoundDecimal(t *testing.T) {
 		query:     req,
 		chMgr:     chMgr,
 		qc:        qc,
+		rc:        rc,
 	}
 
 	// simple mock for query node
@@ -2452,6 +2501,7 @@ func TestSearchTask_7803_reduce(t *testing.T) {
 		query:     req,
 		chMgr:     chMgr,
 		qc:        qc,
+		rc:        rc,
 	}
 
 	// simple mock for query node
@@ -2734,6 +2784,7 @@ func TestSearchTask_PreExecute(t *testing.T) {
 		},
 		chMgr: chMgr,
 		qc:    qc,
+		rc:    rc,
 	}
 	assert.NoError(t, task.OnEnqueue())
 
@@ -2978,10 +3029,331 @@ func TestSearchTask_PreExecute(t *testing.T) {
 		},
 	}
 	assert.NoError(t, task.PreExecute(ctx))
+	assert.Zero(t, task.TimeoutTimestamp)
+
+	// travel timestamp beyond the retention duration
+	now := time.Now()
+	task.SetTs(tsoutil.ComposeTS(now.UnixNano()/int64(time.Millisecond), 0))
+	task.query.TravelTimestamp = tsoutil.ComposeTS(now.Add(-Params.RetentionDuration-time.Minute).UnixNano()/int64(time.Millisecond), 0)
+	err = task.PreExecute(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "beyond retention")
+	task.query.TravelTimestamp = tsoutil.ComposeTS(now.Add(-time.Minute).UnixNano()/int64(time.Millisecond), 0)
+	assert.NoError(t, task.PreExecute(ctx))
+	assert.Equal(t, task.query.TravelTimestamp, task.SearchRequest.TravelTimestamp)
+	task.query.TravelTimestamp = 0
+	task.SetTs(0)
+
+	// partial results and timeout
+	task.query.SearchParams = append(task.query.SearchParams, &commonpb.KeyValuePair{
+		Key:   PartialResultsKey,
+		Value: "invalid",
+	})
+	assert.Error(t, task.PreExecute(ctx))
+	task.query.SearchParams[len(task.query.SearchParams)-1].Value = "true"
+	deadline := time.Now().Add(time.Minute)
+	deadlineCtx, deadlineCancel := context.WithDeadline(ctx, deadline)
+	defer deadlineCancel()
+	task.ctx = deadlineCtx
+	assert.NoError(t, task.PreExecute(deadlineCtx))
+	assert.True(t, task.allowPartial)
+	assert.Equal(t, tsoutil.ComposeTS(deadline.UnixNano()/int64(time.Millisecond), 0), task.TimeoutTimestamp)
+	task.ctx = ctx
+
 	task.query.PlaceholderGroup = tmpPlaceHolder
 	task.query.SearchIDs = nil
 }
 
+func TestPartitionKey(t *testing.T) {
+	var err error
+
+	Params.Init()
+	Params.SearchResultChannelNames = []string{funcutil.GenRandomStr()}
+
+	rc := NewRootCoordMock()
+	rc.Start()
+	defer rc.Stop()
+
+	qc := NewQueryCoordMock()
+	qc.Start()
+	defer qc.Stop()
+
+	ctx := context.Background()
+
+	err = InitMetaCache(rc)
+	assert.NoError(t, err)
+
+	prefix := "TestPartitionKey"
+	collectionName := prefix + funcutil.GenRandomStr()
+	int64Field := "int64"
+	floatVecField := "fvec"
+	partitionKeyField := "category"
+	dim := 128
+	partitionNum := int64(4)
+
+	schema := constructCollectionSchema(int64Field, floatVecField, dim, collectionName)
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		Name:           partitionKeyField,
+		DataType:       schemapb.DataType_Int64,
+		IsPartitionKey: true,
+	})
+	marshaledSchema, err := proto.Marshal(schema)
+	assert.NoError(t, err)
+
+	createColT := &createCollectionTask{
+		Condition: NewTaskCondition(ctx),
+		CreateCollectionRequest: &milvuspb.CreateCollectionRequest{
+			CollectionName: collectionName,
+			Schema:         marshaledSchema,
+			ShardsNum:      2,
+			NumPartitions:  Params.MaxPartitionKeyPartitionNum + 1,
+		},
+		ctx:       ctx,
+		rootCoord: rc,
+	}
+	assert.NoError(t, createColT.OnEnqueue())
+	// too many partitions
+	assert.Error(t, createColT.PreExecute(ctx))
+	createColT.NumPartitions = partitionNum
+	assert.NoError(t, createColT.PreExecute(ctx))
+	assert.NoError(t, createColT.Execute(ctx))
+	assert.NoError(t, createColT.PostExecute(ctx))
+
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, collectionName)
+	assert.NoError(t, err)
+	partitionNames, partitionIDs, err := getPartitionKeyPartitions(ctx, collectionName)
+	assert.NoError(t, err)
+	assert.Equal(t, int(partitionNum), len(partitionIDs))
+	for i, name := range partitionNames {
+		assert.Equal(t, partitionKeyPartitionName(int64(i)), name)
+	}
+
+	t.Run("create and drop partition", func(t *testing.T) {
+		cpt := &createPartitionTask{
+			Condition: NewTaskCondition(ctx),
+			CreatePartitionRequest: &milvuspb.CreatePartitionRequest{
+				Base:           &commonpb.MsgBase{},
+				CollectionName: collectionName,
+				PartitionName:  "p1",
+			},
+			ctx:       ctx,
+			rootCoord: rc,
+		}
+		assert.Error(t, cpt.PreExecute(ctx))
+
+		dpt := &dropPartitionTask{
+			Condition: NewTaskCondition(ctx),
+			DropPartitionRequest: &milvuspb.DropPartitionRequest{
+				Base:           &commonpb.MsgBase{},
+				CollectionName: collectionName,
+				PartitionName:  partitionNames[0],
+			},
+			ctx:       ctx,
+			rootCoord: rc,
+		}
+		assert.Error(t, dpt.PreExecute(ctx))
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		keys := []int64{1, 2, 3, 4, 5, 6, 7, 8, 1, 2}
+		rowNum := len(keys)
+		it := &insertTask{
+			BaseInsertTask: msgstream.InsertMsg{
+				BaseMsg: msgstream.BaseMsg{
+					HashValues: make([]uint32, rowNum),
+				},
+				InsertRequest: internalpb.InsertRequest{
+					Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_Insert},
+					CollectionName: collectionName,
+					CollectionID:   collectionID,
+					Timestamps:     make([]uint64, rowNum),
+					RowIDs:         make([]int64, rowNum),
+					RowData:        make([]*commonpb.Blob, rowNum),
+				},
+			},
+			req: &milvuspb.InsertRequest{
+				FieldsData: []*schemapb.FieldData{
+					newScalarFieldData(schemapb.DataType_Int64, partitionKeyField, rowNum),
+				},
+			},
+		}
+		it.req.FieldsData[0].GetScalars().GetLongData().Data = keys
+		for i := range keys {
+			it.RowIDs[i] = int64(i)
+		}
+
+		msgs, err := it.splitByPartitionKey(ctx, schema.Fields[2])
+		assert.NoError(t, err)
+		rows := 0
+		for _, msg := range msgs {
+			for _, rowID := range msg.RowIDs {
+				idx := hashPartitionKey(keys[rowID], len(partitionIDs))
+				assert.Equal(t, partitionIDs[idx], msg.PartitionID)
+				assert.Equal(t, partitionNames[idx], msg.PartitionName)
+			}
+			assert.Equal(t, len(msg.RowIDs), len(msg.HashValues))
+			assert.Equal(t, len(msg.RowIDs), len(msg.Timestamps))
+			assert.Equal(t, len(msg.RowIDs), len(msg.RowData))
+			rows += len(msg.RowIDs)
+		}
+		assert.Equal(t, rowNum, rows)
+
+		// the number of partition keys mismatch with rows
+		it.req.FieldsData[0].GetScalars().GetLongData().Data = keys[1:]
+		_, err = it.splitByPartitionKey(ctx, schema.Fields[2])
+		assert.Error(t, err)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		_, _ = qc.LoadCollection(ctx, &querypb.LoadCollectionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadCollection,
+			},
+			CollectionID: collectionID,
+		})
+
+		task := &searchTask{
+			ctx:           ctx,
+			SearchRequest: &internalpb.SearchRequest{},
+			query: &milvuspb.SearchRequest{
+				CollectionName: collectionName,
+				DslType:        commonpb.DslType_BoolExprV1,
+				SearchParams: []*commonpb.KeyValuePair{
+					{Key: AnnsFieldKey, Value: floatVecField},
+					{Key: TopKKey, Value: "10"},
+					{Key: MetricTypeKey, Value: distance.L2},
+					{Key: SearchParamsKey, Value: `{"nprobe": 10}`},
+				},
+			},
+			qc: qc,
+			rc: rc,
+		}
+		assert.NoError(t, task.OnEnqueue())
+
+		expectedPartitions := func(keys ...int64) []UniqueID {
+			set := make(map[UniqueID]struct{})
+			for _, key := range keys {
+				set[partitionIDs[hashPartitionKey(key, len(partitionIDs))]] = struct{}{}
+			}
+			ids := make([]UniqueID, 0, len(set))
+			for id := range set {
+				ids = append(ids, id)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			return ids
+		}
+
+		task.query.Dsl = partitionKeyField + " in [1, 2]"
+		assert.NoError(t, task.PreExecute(ctx))
+		assert.Equal(t, expectedPartitions(1, 2), task.PartitionIDs)
+
+		task.query.Dsl = partitionKeyField + " == 3 && " + int64Field + " > 10"
+		assert.NoError(t, task.PreExecute(ctx))
+		assert.Equal(t, expectedPartitions(3), task.PartitionIDs)
+
+		// all the partitions are searched if partition key is not restricted
+		task.query.Dsl = partitionKeyField + " == 3 || " + int64Field + " > 10"
+		assert.NoError(t, task.PreExecute(ctx))
+		assert.Equal(t, 0, len(task.PartitionIDs))
+
+		// partition names can't be specified
+		task.query.Dsl = partitionKeyField + " in [1, 2]"
+		task.query.PartitionNames = []string{partitionNames[0]}
+		assert.Error(t, task.PreExecute(ctx))
+	})
+}
+
+func TestSearchTask_CheckSearchParams(t *testing.T) {
+	Params.Init()
+
+	rc := NewRootCoordMock()
+	rc.Start()
+	defer rc.Stop()
+
+	ctx := context.Background()
+	collectionName := "TestSearchTask_CheckSearchParams" + funcutil.GenRandomStr()
+	int64Field := "int64"
+	floatVecField := "fvec"
+	binaryVecField := "bvec"
+
+	schema := constructCollectionSchema(int64Field, floatVecField, 128, collectionName)
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		Name:       binaryVecField,
+		DataType:   schemapb.DataType_BinaryVector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "128"}},
+	})
+
+	task := &searchTask{
+		ctx: ctx,
+		SearchRequest: &internalpb.SearchRequest{
+			Base: &commonpb.MsgBase{},
+		},
+		query: &milvuspb.SearchRequest{
+			CollectionName: collectionName,
+		},
+		rc: rc,
+	}
+
+	// no index, searched by brute force
+	assert.NoError(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, `{"nprobe": 10}`, 10))
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, `{"nprobe": 10}`, 0))
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, indexparamcheck.JACCARD, "", 10))
+	assert.NoError(t, task.checkSearchParams(ctx, schema, binaryVecField, indexparamcheck.JACCARD, "", 10))
+	assert.Error(t, task.checkSearchParams(ctx, schema, binaryVecField, distance.L2, "", 10))
+
+	rc.describeIndexFunc = func(ctx context.Context, request *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error) {
+		return &milvuspb.DescribeIndexResponse{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_IndexNotExist},
+		}, nil
+	}
+	assert.NoError(t, task.checkSearchParams(ctx, schema, floatVecField, distance.IP, "", 10))
+
+	rc.describeIndexFunc = func(ctx context.Context, request *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error) {
+		return &milvuspb.DescribeIndexResponse{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			IndexDescriptions: []*milvuspb.IndexDescription{
+				{
+					FieldName: floatVecField,
+					Params: []*commonpb.KeyValuePair{
+						{Key: "index_type", Value: "IVF_FLAT"},
+						{Key: "params", Value: `{"nlist": 128}`},
+						{Key: MetricTypeKey, Value: distance.L2},
+					},
+				},
+			},
+		}, nil
+	}
+	assert.NoError(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, `{"nprobe": 128}`, 10))
+	// nprobe > nlist
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, `{"nprobe": 129}`, 10))
+	// metric type mismatch with index
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, distance.IP, `{"nprobe": 10}`, 10))
+
+	// IVF_PQ is the default index type
+	rc.describeIndexFunc = func(ctx context.Context, request *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error) {
+		return &milvuspb.DescribeIndexResponse{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			IndexDescriptions: []*milvuspb.IndexDescription{
+				{FieldName: floatVecField, Params: []*commonpb.KeyValuePair{{Key: "nlist", Value: "16"}}},
+			},
+		}, nil
+	}
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, "", 10))
+	assert.NoError(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, `{"nprobe": 16}`, 10))
+
+	rc.describeIndexFunc = func(ctx context.Context, request *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error) {
+		return &milvuspb.DescribeIndexResponse{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "mock"},
+		}, nil
+	}
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, "", 10))
+
+	rc.describeIndexFunc = func(ctx context.Context, request *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error) {
+		return nil, errors.New("mock")
+	}
+	assert.Error(t, task.checkSearchParams(ctx, schema, floatVecField, distance.L2, "", 10))
+}
+
 func TestSearchTask_Execute(t *testing.T) {
 	var err error
 
@@ -3106,7 +3478,7 @@ func TestSearchTask_Reduce(t *testing.T) {
 		dataArray := make([]*schemapb.SearchResultData, 0)
 		dataArray = append(dataArray, data1)
 		dataArray = append(dataArray, data2)
-		res, err := reduceSearchResultData(dataArray, nq, topk, metricType)
+		res, err := reduceSearchResultData(dataArray, nq, topk, metricType, -1)
 		assert.Nil(t, err)
 		assert.Equal(t, ids, res.Results.Ids.GetIntId().Data)
 		assert.Equal(t, []float32{1.0, 2.0, 3.0, 4.0}, res.Results.Scores)
@@ -3121,12 +3493,334 @@ func TestSearchTask_Reduce(t *testing.T) {
 		dataArray := make([]*schemapb.SearchResultData, 0)
 		dataArray = append(dataArray, data1)
 		dataArray = append(dataArray, data2)
-		res, err := reduceSearchResultData(dataArray, nq, topk, metricType)
+		res, err := reduceSearchResultData(dataArray, nq, topk, metricType, -1)
 		assert.Nil(t, err)
 		assert.ElementsMatch(t, []int64{1, 5, 2, 3}, res.Results.Ids.GetIntId().Data)
 	})
 }
 
+func TestSearchTask_ReduceTies(t *testing.T) {
+	// ties on score are broken by primary key, duplicated entities are removed
+	data1 := genSearchResultData(1, 2, []int64{5, 2}, []float32{-1.0, -2.0})
+	data2 := genSearchResultData(1, 2, []int64{4, 1}, []float32{-1.0, -2.0})
+	data3 := genSearchResultData(1, 2, []int64{4, -1}, []float32{-1.0, minFloat32})
+	res, err := reduceSearchResultData([]*schemapb.SearchResultData{data1, data2, data3}, 1, 2, "L2", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4, 5}, res.Results.Ids.GetIntId().Data)
+	assert.Equal(t, []float32{1.0, 1.0}, res.Results.Scores)
+	assert.Equal(t, []int64{2}, res.Results.Topks)
+
+	data1 = genSearchResultData(1, 4, []int64{5, 2, 7, 8}, []float32{-1.0, -2.0, -3.0, -4.0})
+	data2 = genSearchResultData(1, 4, []int64{4, 1, 2, -1}, []float32{-1.0, -2.0, -2.0, minFloat32})
+	res, err = reduceSearchResultData([]*schemapb.SearchResultData{data2, data1}, 1, 4, "L2", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4, 5, 1, 2}, res.Results.Ids.GetIntId().Data)
+	assert.Equal(t, []float32{1.0, 1.0, 2.0, 2.0}, res.Results.Scores)
+}
+
+func TestSearchTask_ReduceRoundDecimal(t *testing.T) {
+	data := genSearchResultData(1, 2, []int64{1, 2}, []float32{-1.23456, -2.34567})
+	res, err := reduceSearchResultData([]*schemapb.SearchResultData{data}, 1, 2, "L2", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1.23, 2.35}, res.Results.Scores)
+
+	data = genSearchResultData(1, 2, []int64{1, 2}, []float32{2.34567, 1.23456})
+	res, err = reduceSearchResultData([]*schemapb.SearchResultData{data}, 1, 2, "IP", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{2, 1}, res.Results.Scores)
+}
+
+func TestSearchTask_ReduceRoundDecimalTies(t *testing.T) {
+	// the distances of 3, 1 and 2 are equal after rounding to 2 digits, and so are the ones of 6 and 4
+	newShards := func() []*schemapb.SearchResultData {
+		return []*schemapb.SearchResultData{
+			genSearchResultData(1, 5, []int64{3, 2, 6, -1, -1}, []float32{-1.2301, -1.2349, -2.0001, minFloat32, minFloat32}),
+			genSearchResultData(1, 5, []int64{1, 4, 5, -1, -1}, []float32{-1.2302, -2.0002, -3.0, minFloat32, minFloat32}),
+		}
+	}
+	expectedIDs := []int64{1, 2, 3, 4, 6}
+	expectedScores := []float32{1.23, 1.23, 1.23, 2, 2}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 10; i++ {
+		shards := newShards()
+		r.Shuffle(len(shards), func(i, j int) { shards[i], shards[j] = shards[j], shards[i] })
+		res, err := reduceSearchResultData(shards, 1, 5, "L2", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedIDs, res.Results.Ids.GetIntId().Data)
+		assert.Equal(t, expectedScores, res.Results.Scores)
+	}
+
+	// the larger IP is the better one
+	shards := []*schemapb.SearchResultData{
+		genSearchResultData(1, 4, []int64{7, 9, -1, -1}, []float32{0.91, 0.5, minFloat32, minFloat32}),
+		genSearchResultData(1, 4, []int64{8, 2, -1, -1}, []float32{0.94, 0.49, minFloat32, minFloat32}),
+	}
+	res, err := reduceSearchResultData(shards, 1, 4, "IP", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7, 8, 2, 9}, res.Results.Ids.GetIntId().Data)
+	assert.Equal(t, []float32{0.9, 0.9, 0.5, 0.5}, res.Results.Scores)
+}
+
+// genShardedSearchResultData generates the search results of shardNum shards, the scores of all the results
+// of a query are distinct, and shard i returns i%3 invalid results at the tail of every query.
+func genShardedSearchResultData(nq, topk int64, shardNum int, dim int64, r *rand.Rand) []*schemapb.SearchResultData {
+	ret := make([]*schemapb.SearchResultData, shardNum)
+	for s := range ret {
+		ids := make([]int64, 0, nq*topk)
+		scores := make([]float32, 0, nq*topk)
+		ret[s] = genSearchResultData(nq, topk, nil, nil)
+		ret[s].FieldsData = []*schemapb.FieldData{
+			{
+				Type:      schemapb.DataType_Int64,
+				FieldName: "int64",
+				FieldId:   100,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: make([]int64, 0, nq*topk)}},
+					},
+				},
+			},
+		}
+		if dim > 0 {
+			ret[s].FieldsData = append(ret[s].FieldsData, &schemapb.FieldData{
+				Type:      schemapb.DataType_FloatVector,
+				FieldName: "fvec",
+				FieldId:   101,
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: &schemapb.VectorField{
+						Dim:  dim,
+						Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: make([]float32, 0, nq*topk*dim)}},
+					},
+				},
+			})
+		}
+		ret[s].Ids.GetIntId().Data = ids
+		ret[s].Scores = scores
+	}
+	for i := int64(0); i < nq; i++ {
+		perm := r.Perm(shardNum * int(topk))
+		for s := 0; s < shardNum; s++ {
+			ranks := perm[s*int(topk) : (s+1)*int(topk)]
+			sort.Ints(ranks)
+			valid := len(ranks) - s%3
+			for k, rank := range ranks {
+				id, score := int64(rank), -float32(rank)
+				if k >= valid {
+					id, score = -1, minFloat32
+				}
+				ret[s].Ids.GetIntId().Data = append(ret[s].Ids.GetIntId().Data, id)
+				ret[s].Scores = append(ret[s].Scores, score)
+				longData := ret[s].FieldsData[0].GetScalars().GetLongData()
+				longData.Data = append(longData.Data, id*10)
+				if dim > 0 {
+					floatVector := ret[s].FieldsData[1].GetVectors().GetFloatVector()
+					for d := int64(0); d < dim; d++ {
+						floatVector.Data = append(floatVector.Data, float32(id+d))
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func TestSearchTask_ReduceGolden(t *testing.T) {
+	const (
+		nq       = 10
+		topk     = 50
+		shardNum = 8
+		dim      = 4
+	)
+	r := rand.New(rand.NewSource(0))
+	data := genShardedSearchResultData(nq, topk, shardNum, dim, r)
+	expected, err := reduceSearchResultDataLegacy(data, nq, topk, "L2")
+	assert.NoError(t, err)
+
+	res, err := reduceSearchResultData(data, nq, topk, "L2", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Results.Ids.GetIntId().Data, res.Results.Ids.GetIntId().Data)
+	assert.Equal(t, expected.Results.Scores, res.Results.Scores)
+	assert.Equal(t, expected.Results.Topks, res.Results.Topks)
+	assert.Equal(t, expected.Results.TopK, res.Results.TopK)
+
+	// the fields data are appended in the order of the results
+	ids := res.Results.Ids.GetIntId().Data
+	longData := res.Results.FieldsData[0].GetScalars().GetLongData().Data
+	floatVector := res.Results.FieldsData[1].GetVectors().GetFloatVector().Data
+	assert.Equal(t, len(ids), len(longData))
+	assert.Equal(t, len(ids)*dim, len(floatVector))
+	for k, id := range ids {
+		assert.Equal(t, id*10, longData[k])
+		for d := 0; d < dim; d++ {
+			assert.Equal(t, float32(id+int64(d)), floatVector[k*dim+d])
+		}
+	}
+	assert.Equal(t, schemapb.DataType_Int64, res.Results.FieldsData[0].Type)
+	assert.Equal(t, int64(dim), res.Results.FieldsData[1].GetVectors().Dim)
+}
+
+func benchmarkReduceSearchResultData(b *testing.B, reduce func([]*schemapb.SearchResultData, int64, int64) error) {
+	const (
+		nq       = 100
+		topk     = 1000
+		shardNum = 16
+	)
+	r := rand.New(rand.NewSource(0))
+	data := genShardedSearchResultData(nq, topk, shardNum, 0, r)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := reduce(data, nq, topk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReduceSearchResultData(b *testing.B) {
+	benchmarkReduceSearchResultData(b, func(data []*schemapb.SearchResultData, nq, topk int64) error {
+		_, err := reduceSearchResultData(data, nq, topk, "L2", -1)
+		return err
+	})
+}
+
+func BenchmarkReduceSearchResultDataLegacy(b *testing.B) {
+	benchmarkReduceSearchResultData(b, func(data []*schemapb.SearchResultData, nq, topk int64) error {
+		_, err := reduceSearchResultDataLegacy(data, nq, topk, "L2")
+		return err
+	})
+}
+
+// reduceSearchResultDataLegacy is the reduction of search results before the k-way heap merge,
+// it's kept as the reference of the golden tests and the benchmarks.
+func reduceSearchResultDataLegacy(searchResultData []*schemapb.SearchResultData, nq int64, topk int64, metricType string) (*milvuspb.SearchResults, error) {
+	ret := &milvuspb.SearchResults{
+		Status: &commonpb.Status{
+			ErrorCode: 0,
+		},
+		Results: &schemapb.SearchResultData{
+			NumQueries: nq,
+			TopK:       topk,
+			FieldsData: make([]*schemapb.FieldData, len(searchResultData[0].FieldsData)),
+			Scores:     make([]float32, 0),
+			Ids: &schemapb.IDs{
+				IdField: &schemapb.IDs_IntId{
+					IntId: &schemapb.LongArray{
+						Data: make([]int64, 0),
+					},
+				},
+			},
+			Topks: make([]int64, 0),
+		},
+	}
+
+	for _, sData := range searchResultData {
+		if err := checkSearchResultData(sData, nq, topk); err != nil {
+			return ret, err
+		}
+	}
+
+	var realTopK int64 = -1
+	for i := int64(0); i < nq; i++ {
+		offsets := make([]int64, len(searchResultData))
+
+		var prevIDSet = make(map[int64]struct{})
+		var prevScore float32 = math.MaxFloat32
+		var j int64
+		for j = 0; j < topk; {
+			sel := selectSearchResultDataLegacy(searchResultData, offsets, topk, i)
+			if sel == -1 {
+				break
+			}
+			idx := i*topk + offsets[sel]
+
+			id := searchResultData[sel].Ids.GetIntId().Data[idx]
+			score := searchResultData[sel].Scores[idx]
+			// ignore invalid search result
+			if id == -1 {
+				continue
+			}
+
+			// remove duplicates
+			if math.Abs(float64(score)-float64(prevScore)) > 0.00001 {
+				copySearchResultDataLegacy(ret.Results, searchResultData[sel], idx)
+				ret.Results.Ids.GetIntId().Data = append(ret.Results.Ids.GetIntId().Data, id)
+				ret.Results.Scores = append(ret.Results.Scores, score)
+				prevScore = score
+				prevIDSet = map[int64]struct{}{id: {}}
+				j++
+			} else if _, ok := prevIDSet[id]; !ok {
+				copySearchResultDataLegacy(ret.Results, searchResultData[sel], idx)
+				ret.Results.Ids.GetIntId().Data = append(ret.Results.Ids.GetIntId().Data, id)
+				ret.Results.Scores = append(ret.Results.Scores, score)
+				prevIDSet[id] = struct{}{}
+				j++
+			}
+			offsets[sel]++
+		}
+		realTopK = j
+		ret.Results.Topks = append(ret.Results.Topks, realTopK)
+	}
+
+	ret.Results.TopK = realTopK
+
+	if metricType != "IP" {
+		for k := range ret.Results.Scores {
+			ret.Results.Scores[k] *= -1
+		}
+	}
+
+	return ret, nil
+}
+
+func selectSearchResultDataLegacy(dataArray []*schemapb.SearchResultData, offsets []int64, topk int64, qi int64) int {
+	sel := -1
+	maxDistance := minFloat32
+	for i, offset := range offsets { // query num, the number of ways to merge
+		if offset >= topk {
+			continue
+		}
+		idx := qi*topk + offset
+		id := dataArray[i].Ids.GetIntId().Data[idx]
+		if id != -1 {
+			distance := dataArray[i].Scores[idx]
+			if distance > maxDistance {
+				sel = i
+				maxDistance = distance
+			}
+		}
+	}
+	return sel
+}
+
+// copySearchResultDataLegacy only copies the scalar fields, the vector fields of the legacy reduction share
+// the memory with the source results.
+func copySearchResultDataLegacy(dst *schemapb.SearchResultData, src *schemapb.SearchResultData, idx int64) {
+	for i, fieldData := range src.FieldsData {
+		scalars := fieldData.GetScalars()
+		if scalars == nil || scalars.GetLongData() == nil {
+			continue
+		}
+		if dst.FieldsData[i] == nil {
+			dst.FieldsData[i] = &schemapb.FieldData{
+				FieldName: fieldData.FieldName,
+				FieldId:   fieldData.FieldId,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{
+							LongData: &schemapb.LongArray{
+								Data: []int64{scalars.GetLongData().Data[idx]},
+							},
+						},
+					},
+				},
+			}
+		} else {
+			dst.FieldsData[i].GetScalars().GetLongData().Data = append(dst.FieldsData[i].GetScalars().GetLongData().Data, scalars.GetLongData().Data[idx])
+		}
+	}
+}
+
 func TestQueryTask_all(t *testing.T) {
 	var err error
 
@@ -3889,3 +4583,244 @@ func TestAlterAlias_all(t *testing.T) {
 	assert.NoError(t, task.Execute(ctx))
 	assert.NoError(t, task.PostExecute(ctx))
 }
+
+func TestAlterCollectionTask(t *testing.T) {
+	Params.Init()
+	rc := NewRootCoordMock()
+	rc.Start()
+	defer rc.Stop()
+	ctx := context.Background()
+	newTask := func(properties ...*commonpb.KeyValuePair) *alterCollectionTask {
+		task := &alterCollectionTask{
+			Condition: NewTaskCondition(ctx),
+			AlterCollectionRequest: &milvuspb.AlterCollectionRequest{
+				CollectionName: "TestAlterCollectionTask" + funcutil.GenRandomStr(),
+				Properties:     properties,
+			},
+			ctx:       ctx,
+			rootCoord: rc,
+		}
+		assert.NoError(t, task.OnEnqueue())
+		return task
+	}
+
+	task := newTask(&commonpb.KeyValuePair{Key: common.CollectionTTLKey, Value: "60"})
+	assert.NotNil(t, task.TraceCtx())
+	id := UniqueID(uniquegenerator.GetUniqueIntGeneratorIns().GetInt())
+	task.SetID(id)
+	assert.Equal(t, id, task.ID())
+	assert.Equal(t, AlterCollectionTaskName, task.Name())
+	ts := Timestamp(time.Now().UnixNano())
+	task.SetTs(ts)
+	assert.Equal(t, ts, task.BeginTs())
+	assert.Equal(t, ts, task.EndTs())
+
+	assert.NoError(t, task.PreExecute(ctx))
+	assert.Equal(t, commonpb.MsgType_AlterCollection, task.Type())
+	assert.NoError(t, task.Execute(ctx))
+	// the collection does not exist in the mock
+	assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, task.result.ErrorCode)
+	assert.NoError(t, task.PostExecute(ctx))
+
+	assert.Error(t, newTask(&commonpb.KeyValuePair{Key: common.CollectionReplicaNumberKey, Value: "0"}).PreExecute(ctx))
+	assert.Error(t, newTask(&commonpb.KeyValuePair{Key: "unknown", Value: "1"}).PreExecute(ctx))
+}
+
+func TestQueryCoordError(t *testing.T) {
+	failed := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_OutOfMemory,
+		Detail:    "OutOfMemory",
+	}
+	// the failure reported by the status is surfaced unchanged
+	assert.Nil(t, queryCoordError(failed, errors.New("mock")))
+	assert.NotNil(t, queryCoordError(nil, errors.New("mock")))
+	assert.NotNil(t, queryCoordError(&commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, errors.New("mock")))
+	assert.Nil(t, queryCoordError(&commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil))
+}
+
+func TestInsertTask_PreExecute_payloadSize(t *testing.T) {
+	maxSize := Params.InsertMaxRequestSize
+	defer func() { Params.InsertMaxRequestSize = maxSize }()
+
+	newTask := func() *insertTask {
+		return &insertTask{
+			ctx: context.Background(),
+			req: &milvuspb.InsertRequest{
+				CollectionName: "",
+				FieldsData: []*schemapb.FieldData{
+					newFloatVectorFieldData("vec", 100, 16),
+				},
+				NumRows: 100,
+			},
+			BaseInsertTask: BaseInsertTask{
+				InsertRequest: internalpb.InsertRequest{
+					Base: &commonpb.MsgBase{},
+				},
+			},
+		}
+	}
+	size := int64(proto.Size(newTask().req))
+
+	// the request exactly at the limit passes the size check, and fails for the empty collection name
+	Params.InsertMaxRequestSize = size
+	err := newTask().PreExecute(context.Background())
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "exceeds the limit")
+
+	Params.InsertMaxRequestSize = size - 1
+	err = newTask().PreExecute(context.Background())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("insert request(%d bytes) exceeds the limit(%d bytes)", size, size-1))
+	assert.Contains(t, err.Error(), "smaller batches")
+}
+
+func TestInsertTask_dedupPrimaryKeys(t *testing.T) {
+	const collectionName = "dedup_collection"
+	schema := &schemapb.CollectionSchema{
+		Name: collectionName,
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "value", DataType: schemapb.DataType_Int64},
+		},
+	}
+	oldCache := globalMetaCache
+	defer func() { globalMetaCache = oldCache }()
+	setMode := func(schema *schemapb.CollectionSchema, mode string) {
+		cache, err := NewMetaCache(nil)
+		assert.Nil(t, err)
+		cache.collInfo[collectionName] = &collectionInfo{
+			schema: schema,
+			properties: common.NewCollectionProperties([]*commonpb.KeyValuePair{
+				{Key: common.CollectionInsertDedupKey, Value: mode},
+			}),
+		}
+		globalMetaCache = cache
+	}
+
+	pks := []int64{math.MaxInt64, 0, math.MinInt64, -1, math.MaxInt64, math.MinInt64, 1, 0}
+	newTask := func(pks []int64) *insertTask {
+		values := make([]int64, len(pks))
+		for i := range values {
+			values[i] = int64(i)
+		}
+		return &insertTask{
+			ctx:    context.Background(),
+			schema: schema,
+			req: &milvuspb.InsertRequest{
+				CollectionName: collectionName,
+				FieldsData: []*schemapb.FieldData{
+					{
+						Type:      schemapb.DataType_Int64,
+						FieldName: "pk",
+						Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+							Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+						}},
+					},
+					{
+						Type:      schemapb.DataType_Int64,
+						FieldName: "value",
+						Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+							Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: values}},
+						}},
+					},
+				},
+				NumRows: uint32(len(pks)),
+			},
+			BaseInsertTask: BaseInsertTask{
+				InsertRequest: internalpb.InsertRequest{
+					CollectionName: collectionName,
+				},
+			},
+		}
+	}
+
+	t.Run("none", func(t *testing.T) {
+		setMode(schema, common.InsertDedupNone)
+		it := newTask(pks)
+		assert.Nil(t, it.dedupPrimaryKeys(context.Background()))
+		assert.Equal(t, uint32(len(pks)), it.req.NumRows)
+		assert.Nil(t, it.keptRowOffsets)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		setMode(schema, common.InsertDedupReject)
+		err := newTask(pks).dedupPrimaryKeys(context.Background())
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("%v", []int64{math.MaxInt64, math.MinInt64, 0}))
+
+		// no duplicates
+		it := newTask([]int64{math.MinInt64, -1, 0, 1, math.MaxInt64})
+		assert.Nil(t, it.dedupPrimaryKeys(context.Background()))
+		assert.Equal(t, uint32(5), it.req.NumRows)
+	})
+
+	t.Run("keep last", func(t *testing.T) {
+		setMode(schema, common.InsertDedupKeepLast)
+		it := newTask(pks)
+		assert.Nil(t, it.dedupPrimaryKeys(context.Background()))
+		assert.Equal(t, uint32(5), it.req.NumRows)
+		assert.Equal(t, []int{3, 4, 5, 6, 7}, it.keptRowOffsets)
+		assert.Equal(t, []int64{-1, math.MaxInt64, math.MinInt64, 1, 0}, it.req.FieldsData[0].GetScalars().GetLongData().Data)
+		assert.Equal(t, []int64{3, 4, 5, 6, 7}, it.req.FieldsData[1].GetScalars().GetLongData().Data)
+	})
+
+	t.Run("auto id", func(t *testing.T) {
+		autoIDSchema := proto.Clone(schema).(*schemapb.CollectionSchema)
+		autoIDSchema.Fields[0].AutoID = true
+		setMode(autoIDSchema, common.InsertDedupReject)
+		it := newTask(pks)
+		it.schema = autoIDSchema
+		assert.Nil(t, it.dedupPrimaryKeys(context.Background()))
+		assert.Equal(t, uint32(len(pks)), it.req.NumRows)
+	})
+}
+
+func TestInsertMsgBatcher(t *testing.T) {
+	rowSize := sizeOfInsertRow(&commonpb.Blob{Value: make([]byte, 100)})
+	newMsg := func() *msgstream.InsertMsg {
+		return &msgstream.InsertMsg{
+			InsertRequest: internalpb.InsertRequest{
+				Base: &commonpb.MsgBase{},
+			},
+		}
+	}
+	fixedSize := fixedSizeOfInsertMsg(newMsg())
+	maxSize := fixedSize + 3*rowSize
+	batcher := newInsertMsgBatcher(maxSize)
+
+	// 10 rows of channel 0, 4 rows of channel 1 and a row of channel 2 larger than maxSize
+	rowNums := map[int32]int{0: 10, 1: 4}
+	rowID := UniqueID(0)
+	for key, num := range rowNums {
+		for i := 0; i < num; i++ {
+			batcher.add(key, uint32(key), Timestamp(rowID), rowID, &commonpb.Blob{Value: make([]byte, 100)}, newMsg)
+			rowID++
+		}
+	}
+	batcher.add(2, 2, Timestamp(rowID), rowID, &commonpb.Blob{Value: make([]byte, maxSize)}, newMsg)
+	rowNums[2] = 1
+
+	msgs := batcher.flush()
+	// channel 0 is split into 4 messages, channel 1 into 2 messages
+	assert.Equal(t, 4+2+1, len(msgs))
+	gotRows := make(map[int32]int)
+	rowIDs := make(map[UniqueID]bool)
+	for _, tsMsg := range msgs {
+		msg := tsMsg.(*msgstream.InsertMsg)
+		key := int32(msg.HashValues[0])
+		gotRows[key] += len(msg.RowData)
+		assert.Equal(t, len(msg.RowData), len(msg.RowIDs))
+		assert.Equal(t, len(msg.RowData), len(msg.Timestamps))
+		assert.Equal(t, len(msg.RowData), len(msg.HashValues))
+		if key != 2 {
+			assert.LessOrEqual(t, fixedSize+len(msg.RowData)*rowSize, maxSize)
+		}
+		for _, id := range msg.RowIDs {
+			assert.False(t, rowIDs[id])
+			rowIDs[id] = true
+		}
+	}
+	assert.Equal(t, rowNums, gotRows)
+	assert.Equal(t, int(rowID)+1, len(rowIDs))
+	assert.Empty(t, batcher.flush())
+}
@@ -14,11 +14,15 @@ package proxy
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 func isAlpha(c uint8) bool {
@@ -210,6 +214,26 @@ func validatePrimaryKey(coll *schemapb.CollectionSchema) error {
 	return nil
 }
 
+// validatePartitionKey checks there is at most one partition key field, and it is an int64 field other than the primary key
+func validatePartitionKey(coll *schemapb.CollectionSchema) error {
+	idx := -1
+	for i, field := range coll.Fields {
+		if field.IsPartitionKey {
+			if idx != -1 {
+				return fmt.Errorf("there are more than one partition key, field name = %s, %s", coll.Fields[idx].Name, field.Name)
+			}
+			if field.IsPrimaryKey {
+				return fmt.Errorf("the primary key can't be the partition key, field name = %s", field.Name)
+			}
+			if field.DataType != schemapb.DataType_Int64 {
+				return errors.New("the data type of partition key should be int64")
+			}
+			idx = i
+		}
+	}
+	return nil
+}
+
 func RepeatedKeyValToMap(kvPairs []*commonpb.KeyValuePair) (map[string]string, error) {
 	resMap := make(map[string]string)
 	for _, kv := range kvPairs {
@@ -244,7 +268,7 @@ func validateMetricType(dataType schemapb.DataType, metricTypeStrRaw string) err
 		if dataType == schemapb.DataType_FloatVector {
 			return nil
 		}
-	case "JACCARD", "HAMMING", "TANIMOTO", "SUBSTRUCTURE", "SUBPERSTURCTURE":
+	case "JACCARD", "HAMMING", "TANIMOTO", "SUBSTRUCTURE", "SUPERSTRUCTURE":
 		if dataType == schemapb.DataType_BinaryVector {
 			return nil
 		}
@@ -293,15 +317,22 @@ func validateSchema(coll *schemapb.CollectionSchema) error {
 			return err3
 		}
 
+		typeKv, err2 := RepeatedKeyValToMap(field.TypeParams)
+		if err2 != nil {
+			return err2
+		}
+		// compression of binlogs can be set for any field
+		if compression, ok := typeKv[storage.CompressionKey]; ok {
+			if _, err := storage.ParseCompressionType(compression); err != nil {
+				return fmt.Errorf("invalid compression of field %s(%d): %s", field.Name, field.FieldID, compression)
+			}
+		}
+
 		if isVec {
 			indexKv, err1 := RepeatedKeyValToMap(field.IndexParams)
 			if err1 != nil {
 				return err1
 			}
-			typeKv, err2 := RepeatedKeyValToMap(field.TypeParams)
-			if err2 != nil {
-				return err2
-			}
 			dimStr, ok := typeKv["dim"]
 			if !ok {
 				return fmt.Errorf("dim not found in type_params for vector field %s(%d)", field.Name, field.FieldID)
@@ -325,8 +356,10 @@ func validateSchema(coll *schemapb.CollectionSchema) error {
 			if len(field.IndexParams) != 0 {
 				return fmt.Errorf("index params is not empty for scalar field: %s(%d)", field.Name, field.FieldID)
 			}
-			if len(field.TypeParams) != 0 {
-				return fmt.Errorf("type params is not empty for scalar field: %s(%d)", field.Name, field.FieldID)
+			for key := range typeKv {
+				if key != storage.CompressionKey {
+					return fmt.Errorf("type params is not empty for scalar field: %s(%d)", field.Name, field.FieldID)
+				}
 			}
 		}
 	}
@@ -337,3 +370,208 @@ func validateSchema(coll *schemapb.CollectionSchema) error {
 
 	return nil
 }
+
+// validateInsertFieldsData checks the fields data of an insert request against the collection schema:
+// every field except the autoID one is passed exactly once with the data type of schema, all fields have numRows rows,
+// and the vectors have the dim of schema. NaN and Inf are rejected in float fields if rejectNaN is true.
+func validateInsertFieldsData(schema *schemapb.CollectionSchema, fieldsData []*schemapb.FieldData, numRows uint32, rejectNaN bool) error {
+	if numRows <= 0 {
+		return errNumRowsLessThanOrEqualToZero(numRows)
+	}
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return err
+	}
+
+	passed := make(map[string]bool)
+	for _, fieldData := range fieldsData {
+		field, err := helper.GetFieldFromName(fieldData.FieldName)
+		if err != nil {
+			return fmt.Errorf("field %s does not exist in collection %s", fieldData.FieldName, schema.Name)
+		}
+		if field.AutoID {
+			return fmt.Errorf("autoID field (%v) does not require data", field.Name)
+		}
+		if passed[field.Name] {
+			return fmt.Errorf("field %s is passed more than once", field.Name)
+		}
+		passed[field.Name] = true
+
+		if fieldData.Type != field.DataType {
+			return fmt.Errorf("the data type(%s) of field %s mismatch with schema(%s)", fieldData.Type, field.Name, field.DataType)
+		}
+		if err := validateFieldData(helper, field, fieldData, numRows, rejectNaN); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range schema.Fields {
+		if !field.AutoID && !passed[field.Name] {
+			return fmt.Errorf("field %s is not passed", field.Name)
+		}
+	}
+	return nil
+}
+
+func validateFieldData(helper *typeutil.SchemaHelper, field *schemapb.FieldSchema, fieldData *schemapb.FieldData, numRows uint32, rejectNaN bool) error {
+	mismatch := fmt.Errorf("the data of field %s mismatch with data type %s", field.Name, field.DataType)
+	badRow := -1
+	var rows int
+	switch field.DataType {
+	case schemapb.DataType_Bool:
+		data, ok := fieldData.GetScalars().GetData().(*schemapb.ScalarField_BoolData)
+		if !ok {
+			return mismatch
+		}
+		rows = len(data.BoolData.GetData())
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		data, ok := fieldData.GetScalars().GetData().(*schemapb.ScalarField_IntData)
+		if !ok {
+			return mismatch
+		}
+		rows = len(data.IntData.GetData())
+	case schemapb.DataType_Int64:
+		data, ok := fieldData.GetScalars().GetData().(*schemapb.ScalarField_LongData)
+		if !ok {
+			return mismatch
+		}
+		rows = len(data.LongData.GetData())
+	case schemapb.DataType_Float:
+		data, ok := fieldData.GetScalars().GetData().(*schemapb.ScalarField_FloatData)
+		if !ok {
+			return mismatch
+		}
+		rows = len(data.FloatData.GetData())
+		if rejectNaN {
+			badRow = findNaNOrInfFloat32(data.FloatData.GetData(), 1)
+		}
+	case schemapb.DataType_Double:
+		data, ok := fieldData.GetScalars().GetData().(*schemapb.ScalarField_DoubleData)
+		if !ok {
+			return mismatch
+		}
+		rows = len(data.DoubleData.GetData())
+		if rejectNaN {
+			for i, v := range data.DoubleData.GetData() {
+				if math.IsNaN(v) || math.IsInf(v, 0) {
+					badRow = i
+					break
+				}
+			}
+		}
+	case schemapb.DataType_FloatVector:
+		data, ok := fieldData.GetVectors().GetData().(*schemapb.VectorField_FloatVector)
+		if !ok {
+			return mismatch
+		}
+		dim, err := validateFieldDataDim(helper, field, fieldData)
+		if err != nil {
+			return err
+		}
+		l := len(data.FloatVector.GetData())
+		if l%dim != 0 {
+			return fmt.Errorf("the length(%d) of field %s should divide the dim(%d), first offending row: %d", l, field.Name, dim, l/dim)
+		}
+		rows = l / dim
+		if rejectNaN {
+			badRow = findNaNOrInfFloat32(data.FloatVector.GetData(), dim)
+		}
+	case schemapb.DataType_BinaryVector:
+		data, ok := fieldData.GetVectors().GetData().(*schemapb.VectorField_BinaryVector)
+		if !ok {
+			return mismatch
+		}
+		dim, err := validateFieldDataDim(helper, field, fieldData)
+		if err != nil {
+			return err
+		}
+		if dim%8 != 0 {
+			return errDimShouldDivide8(dim)
+		}
+		bytesPerRow := dim / 8
+		l := len(data.BinaryVector)
+		if l%bytesPerRow != 0 {
+			return fmt.Errorf("the length(%d) of field %s should divide dim/8(%d), first offending row: %d", l, field.Name, bytesPerRow, l/bytesPerRow)
+		}
+		rows = l / bytesPerRow
+	default:
+		return errUnsupportedDataType(field.DataType)
+	}
+
+	if uint32(rows) != numRows {
+		firstRow := rows
+		if uint32(rows) > numRows {
+			firstRow = int(numRows)
+		}
+		return fmt.Errorf("the num_rows(%d) of field %s is not equal to passed NumRows(%d), first offending row: %d", rows, field.Name, numRows, firstRow)
+	}
+	if badRow >= 0 {
+		return fmt.Errorf("field %s contains NaN or Inf, first offending row: %d", field.Name, badRow)
+	}
+	return nil
+}
+
+// validateFieldDataDim returns the dim of vector field, the dim passed in fieldData should be the same as in schema
+func validateFieldDataDim(helper *typeutil.SchemaHelper, field *schemapb.FieldSchema, fieldData *schemapb.FieldData) (int, error) {
+	dim, err := helper.GetVectorDimFromID(field.FieldID)
+	if err != nil {
+		return 0, err
+	}
+	if dim <= 0 {
+		return 0, errDimLessThanOrEqualToZero(dim)
+	}
+	if passedDim := fieldData.GetVectors().GetDim(); passedDim != int64(dim) {
+		return 0, fmt.Errorf("the dim(%d) of field %s is not equal to the dim(%d) in schema, first offending row: 0", passedDim, field.Name, dim)
+	}
+	return dim, nil
+}
+
+// findNaNOrInfFloat32 returns the first row containing NaN or Inf in data of rows with dim values, -1 is returned if there is none
+func findNaNOrInfFloat32(data []float32, dim int) int {
+	for i, v := range data {
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return i / dim
+		}
+	}
+	return -1
+}
+
+// validateQueryWindow checks the offset and limit of query, offset+limit shouldn't exceed the max query window
+func validateQueryWindow(offset int64, limit int64) error {
+	if offset < 0 {
+		return fmt.Errorf("query offset(%d) should be non-negative", offset)
+	}
+	if limit < 0 {
+		return fmt.Errorf("query limit(%d) should be non-negative", limit)
+	}
+	if offset > 0 && limit == 0 {
+		return errors.New("query limit is required if offset is specified")
+	}
+	if offset > Params.QueryMaxWindow || limit > Params.QueryMaxWindow || offset+limit > Params.QueryMaxWindow {
+		return fmt.Errorf("query offset(%d) + limit(%d) should be in range [0, %d]", offset, limit, Params.QueryMaxWindow)
+	}
+	return nil
+}
+
+// validatePayloadSize checks the serialized size of a request or result against the limit, 0 means no limit
+func validatePayloadSize(payload string, size int, limit int64, suggestion string) error {
+	if limit > 0 && int64(size) > limit {
+		return errPayloadTooLarge(payload, size, limit, suggestion)
+	}
+	return nil
+}
+
+// validateTravelTimestamp rejects the travel timestamp older than the retention duration before tMax, since the history
+// before it may have been garbage collected and the results would be wrong, a non-positive retention means no limit
+func validateTravelTimestamp(travelTs, tMax Timestamp) error {
+	if Params.RetentionDuration <= 0 {
+		return nil
+	}
+	travelTime, _ := tsoutil.ParseTS(travelTs)
+	maxTime, _ := tsoutil.ParseTS(tMax)
+	if travelTime.Before(maxTime.Add(-Params.RetentionDuration)) {
+		return errTravelTimestampBeyondRetention(travelTs, Params.RetentionDuration)
+	}
+	return nil
+}
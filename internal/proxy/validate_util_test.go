@@ -12,10 +12,14 @@
 package proxy
 
 import (
+	"math"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -133,6 +137,18 @@ func TestValidateVectorFieldMetricType(t *testing.T) {
 	assert.Nil(t, validateVectorFieldMetricType(field1))
 }
 
+func TestValidateMetricType(t *testing.T) {
+	for _, metric := range []string{"HAMMING", "JACCARD", "TANIMOTO", "SUBSTRUCTURE", "SUPERSTRUCTURE", "jaccard"} {
+		assert.NoError(t, validateMetricType(schemapb.DataType_BinaryVector, metric), metric)
+		assert.Error(t, validateMetricType(schemapb.DataType_FloatVector, metric), metric)
+	}
+	for _, metric := range []string{"L2", "IP", "ip"} {
+		assert.NoError(t, validateMetricType(schemapb.DataType_FloatVector, metric), metric)
+		assert.Error(t, validateMetricType(schemapb.DataType_BinaryVector, metric), metric)
+	}
+	assert.Error(t, validateMetricType(schemapb.DataType_BinaryVector, "SUBPERSTURCTURE"))
+}
+
 func TestValidateDuplicatedFieldName(t *testing.T) {
 	fields := []*schemapb.FieldSchema{
 		{Name: "abc"},
@@ -145,6 +161,34 @@ func TestValidateDuplicatedFieldName(t *testing.T) {
 	assert.NotNil(t, validateDuplicatedFieldName(fields))
 }
 
+func TestValidatePartitionKey(t *testing.T) {
+	coll := &schemapb.CollectionSchema{
+		Name: "coll1",
+		Fields: []*schemapb.FieldSchema{
+			{Name: "pk", FieldID: 100, IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{Name: "f1", FieldID: 101, DataType: schemapb.DataType_Int64},
+			{Name: "f2", FieldID: 102, DataType: schemapb.DataType_Float},
+		},
+	}
+	assert.Nil(t, validatePartitionKey(coll))
+
+	coll.Fields[1].IsPartitionKey = true
+	assert.Nil(t, validatePartitionKey(coll))
+
+	// more than one partition key
+	coll.Fields[2].IsPartitionKey = true
+	assert.NotNil(t, validatePartitionKey(coll))
+
+	// partition key isn't int64
+	coll.Fields[1].IsPartitionKey = false
+	assert.NotNil(t, validatePartitionKey(coll))
+
+	// primary key as partition key
+	coll.Fields[2].IsPartitionKey = false
+	coll.Fields[0].IsPartitionKey = true
+	assert.NotNil(t, validatePartitionKey(coll))
+}
+
 func TestValidatePrimaryKey(t *testing.T) {
 	coll := schemapb.CollectionSchema{
 		Name:        "coll1",
@@ -366,4 +410,291 @@ func TestValidateSchema(t *testing.T) {
 
 	pf3.IndexParams = ip3Good
 	assert.Nil(t, validateSchema(coll))
+
+	// compression is the only type param of scalar fields
+	pf2.TypeParams = []*commonpb.KeyValuePair{{Key: "compression", Value: "zstd"}}
+	assert.Nil(t, validateSchema(coll))
+
+	pf2.TypeParams = []*commonpb.KeyValuePair{{Key: "compression", Value: "lz4"}}
+	assert.NotNil(t, validateSchema(coll))
+
+	pf2.TypeParams = []*commonpb.KeyValuePair{{Key: "compression", Value: "snappy"}, {Key: "dim", Value: "128"}}
+	assert.NotNil(t, validateSchema(coll))
+
+	pf2.TypeParams = nil
+	pf3.TypeParams = append(tp3Good, &commonpb.KeyValuePair{Key: "compression", Value: "snappy"})
+	assert.Nil(t, validateSchema(coll))
+
+	pf3.TypeParams = append(tp3Good, &commonpb.KeyValuePair{Key: "compression", Value: "gzip"})
+	assert.NotNil(t, validateSchema(coll))
+}
+
+func TestValidateInsertFieldsData(t *testing.T) {
+	const (
+		numRows = 10
+		dim     = 16
+	)
+	schema := &schemapb.CollectionSchema{
+		Name: "test_validate_insert",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64, AutoID: true},
+			{FieldID: 101, Name: "bool", DataType: schemapb.DataType_Bool},
+			{FieldID: 102, Name: "int32", DataType: schemapb.DataType_Int32},
+			{FieldID: 103, Name: "float", DataType: schemapb.DataType_Float},
+			{FieldID: 104, Name: "double", DataType: schemapb.DataType_Double},
+			{
+				FieldID:    105,
+				Name:       "fvec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(dim)}},
+			},
+			{
+				FieldID:    106,
+				Name:       "bvec",
+				DataType:   schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: strconv.Itoa(dim)}},
+			},
+		},
+	}
+	newFieldsData := func() []*schemapb.FieldData {
+		return []*schemapb.FieldData{
+			newScalarFieldData(schemapb.DataType_Bool, "bool", numRows),
+			newScalarFieldData(schemapb.DataType_Int32, "int32", numRows),
+			newScalarFieldData(schemapb.DataType_Float, "float", numRows),
+			newScalarFieldData(schemapb.DataType_Double, "double", numRows),
+			newFloatVectorFieldData("fvec", numRows, dim),
+			newBinaryVectorFieldData("bvec", numRows, dim),
+		}
+	}
+
+	cases := []struct {
+		name      string
+		numRows   uint32
+		rejectNaN bool
+		modify    func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData
+		errMsg    string
+	}{
+		{
+			name:    "valid",
+			numRows: numRows,
+		},
+		{
+			name:    "zero num rows",
+			numRows: 0,
+			errMsg:  "num_rows(0) should be greater than 0",
+		},
+		{
+			name:    "unknown field",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return append(fieldsData, newScalarFieldData(schemapb.DataType_Int64, "unknown", numRows))
+			},
+			errMsg: "field unknown does not exist",
+		},
+		{
+			name:    "autoID field",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return append(fieldsData, newScalarFieldData(schemapb.DataType_Int64, "pk", numRows))
+			},
+			errMsg: "autoID field (pk) does not require data",
+		},
+		{
+			name:    "duplicated field",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return append(fieldsData, newScalarFieldData(schemapb.DataType_Bool, "bool", numRows))
+			},
+			errMsg: "field bool is passed more than once",
+		},
+		{
+			name:    "missing field",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				return fieldsData[1:]
+			},
+			errMsg: "field bool is not passed",
+		},
+		{
+			name:    "data type mismatch",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[1] = newScalarFieldData(schemapb.DataType_Int64, "int32", numRows)
+				return fieldsData
+			},
+			errMsg: "the data type(Int64) of field int32 mismatch with schema(Int32)",
+		},
+		{
+			name:    "data mismatch with type",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[2] = newScalarFieldData(schemapb.DataType_Double, "float", numRows)
+				fieldsData[2].Type = schemapb.DataType_Float
+				return fieldsData
+			},
+			errMsg: "the data of field float mismatch with data type Float",
+		},
+		{
+			name:    "less rows",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[1] = newScalarFieldData(schemapb.DataType_Int32, "int32", numRows-3)
+				return fieldsData
+			},
+			errMsg: "the num_rows(7) of field int32 is not equal to passed NumRows(10), first offending row: 7",
+		},
+		{
+			name:    "more rows",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[4] = newFloatVectorFieldData("fvec", numRows+1, dim)
+				return fieldsData
+			},
+			errMsg: "the num_rows(11) of field fvec is not equal to passed NumRows(10), first offending row: 10",
+		},
+		{
+			name:    "float vector dim mismatch",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[4] = newFloatVectorFieldData("fvec", numRows, dim*2)
+				return fieldsData
+			},
+			errMsg: "the dim(32) of field fvec is not equal to the dim(16) in schema, first offending row: 0",
+		},
+		{
+			name:    "float vector length mismatch",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				data := fieldsData[4].GetVectors().GetFloatVector()
+				data.Data = data.Data[:len(data.Data)-1]
+				return fieldsData
+			},
+			errMsg: "the length(159) of field fvec should divide the dim(16), first offending row: 9",
+		},
+		{
+			name:    "binary vector dim mismatch",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[5] = newBinaryVectorFieldData("bvec", numRows, dim/2)
+				return fieldsData
+			},
+			errMsg: "the dim(8) of field bvec is not equal to the dim(16) in schema, first offending row: 0",
+		},
+		{
+			name:    "binary vector length mismatch",
+			numRows: numRows,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				data := fieldsData[5].GetVectors().Data.(*schemapb.VectorField_BinaryVector)
+				data.BinaryVector = data.BinaryVector[:len(data.BinaryVector)-1]
+				return fieldsData
+			},
+			errMsg: "the length(19) of field bvec should divide dim/8(2), first offending row: 9",
+		},
+		{
+			name:      "NaN in float field",
+			numRows:   numRows,
+			rejectNaN: true,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[2].GetScalars().GetFloatData().Data[3] = float32(math.NaN())
+				return fieldsData
+			},
+			errMsg: "field float contains NaN or Inf, first offending row: 3",
+		},
+		{
+			name:      "Inf in double field",
+			numRows:   numRows,
+			rejectNaN: true,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[3].GetScalars().GetDoubleData().Data[5] = math.Inf(-1)
+				return fieldsData
+			},
+			errMsg: "field double contains NaN or Inf, first offending row: 5",
+		},
+		{
+			name:      "Inf in float vector field",
+			numRows:   numRows,
+			rejectNaN: true,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[4].GetVectors().GetFloatVector().Data[2*dim+1] = float32(math.Inf(1))
+				return fieldsData
+			},
+			errMsg: "field fvec contains NaN or Inf, first offending row: 2",
+		},
+		{
+			name:      "NaN allowed",
+			numRows:   numRows,
+			rejectNaN: false,
+			modify: func(fieldsData []*schemapb.FieldData) []*schemapb.FieldData {
+				fieldsData[2].GetScalars().GetFloatData().Data[3] = float32(math.NaN())
+				return fieldsData
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fieldsData := newFieldsData()
+			if c.modify != nil {
+				fieldsData = c.modify(fieldsData)
+			}
+			err := validateInsertFieldsData(schema, fieldsData, c.numRows, c.rejectNaN)
+			if c.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), c.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateQueryWindow(t *testing.T) {
+	maxWindow := Params.QueryMaxWindow
+	defer func() { Params.QueryMaxWindow = maxWindow }()
+	Params.QueryMaxWindow = 100
+
+	assert.Nil(t, validateQueryWindow(0, 0))
+	assert.Nil(t, validateQueryWindow(0, 100))
+	assert.Nil(t, validateQueryWindow(90, 10))
+
+	assert.NotNil(t, validateQueryWindow(-1, 10))
+	assert.NotNil(t, validateQueryWindow(0, -1))
+	assert.NotNil(t, validateQueryWindow(10, 0))
+	assert.NotNil(t, validateQueryWindow(0, 101))
+	assert.NotNil(t, validateQueryWindow(91, 10))
+	assert.NotNil(t, validateQueryWindow(math.MaxInt64, 1))
+}
+
+func TestValidatePayloadSize(t *testing.T) {
+	assert.Nil(t, validatePayloadSize("insert request", 100, 0, "batch"))
+	assert.Nil(t, validatePayloadSize("insert request", 100, 100, "batch"))
+
+	err := validatePayloadSize("insert request", 101, 100, "please split the rows into smaller batches")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "insert request(101 bytes)")
+	assert.Contains(t, err.Error(), "limit(100 bytes)")
+	assert.Contains(t, err.Error(), "smaller batches")
+}
+
+func TestValidateTravelTimestamp(t *testing.T) {
+	retention := Params.RetentionDuration
+	defer func() { Params.RetentionDuration = retention }()
+	Params.RetentionDuration = time.Minute
+
+	now := time.Now()
+	tMax := tsoutil.ComposeTS(now.UnixNano()/int64(time.Millisecond), 0)
+	travelTs := func(d time.Duration) Timestamp {
+		return tsoutil.ComposeTS(now.Add(-d).UnixNano()/int64(time.Millisecond), 0)
+	}
+	assert.Nil(t, validateTravelTimestamp(tMax, tMax))
+	assert.Nil(t, validateTravelTimestamp(travelTs(30*time.Second), tMax))
+	assert.Nil(t, validateTravelTimestamp(travelTs(time.Minute), tMax))
+
+	err := validateTravelTimestamp(travelTs(2*time.Minute), tMax)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "beyond retention")
+
+	// no limit
+	Params.RetentionDuration = 0
+	assert.Nil(t, validateTravelTimestamp(travelTs(24*time.Hour), tMax))
 }
@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	nodeclient "github.com/milvus-io/milvus/internal/distributed/querynode/client"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+type newQueryNodeClientFn func(ctx context.Context, address string) (types.QueryNode, error)
+
+// newQueryNodeClient creates a grpc client of the queryNode at address
+func newQueryNodeClient(ctx context.Context, address string) (types.QueryNode, error) {
+	return nodeclient.NewClient(ctx, address)
+}
+
+// cachedQueryNodeClient is a client in the queryNodeClientCache, ready is closed once the construction is done
+type cachedQueryNodeClient struct {
+	address  string
+	client   types.QueryNode
+	err      error
+	ready    chan struct{}
+	lastUsed time.Time
+}
+
+func (c *cachedQueryNodeClient) isReady() bool {
+	select {
+	case <-c.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// queryNodeClientCache caches the clients of queryNodes by nodeID,
+// concurrent requests for a client which doesn't exist share a single construction,
+// and the least recently used client is closed when the number of clients reaches maxClients
+type queryNodeClientCache struct {
+	ctx        context.Context
+	maxClients int
+
+	sync.Mutex
+	clients map[int64]*cachedQueryNodeClient
+}
+
+func newQueryNodeClientCache(ctx context.Context, maxClients int) *queryNodeClientCache {
+	return &queryNodeClientCache{
+		ctx:        ctx,
+		maxClients: maxClients,
+		clients:    make(map[int64]*cachedQueryNodeClient),
+	}
+}
+
+// get returns the initialized client of the queryNode, the client is created by newClientFn if it is not cached
+func (cc *queryNodeClientCache) get(ctx context.Context, nodeID int64, address string, newClientFn newQueryNodeClientFn) (types.QueryNode, error) {
+	cc.Lock()
+	cached, ok := cc.clients[nodeID]
+	if ok && cached.address != address {
+		// the queryNode has restarted at another address
+		cc.removeLocked(nodeID)
+		ok = false
+	}
+	if ok {
+		cached.lastUsed = time.Now()
+		cc.Unlock()
+		select {
+		case <-cached.ready:
+			return cached.client, cached.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if len(cc.clients) >= cc.maxClients && !cc.evictLocked() {
+		cc.Unlock()
+		return nil, fmt.Errorf("queryNodeClientCache: too many queryNode clients, limit = %d", cc.maxClients)
+	}
+	cached = &cachedQueryNodeClient{
+		address:  address,
+		ready:    make(chan struct{}),
+		lastUsed: time.Now(),
+	}
+	cc.clients[nodeID] = cached
+	cc.Unlock()
+
+	// the construction is shared by all callers, so it is not bound to the context of this caller
+	cached.client, cached.err = cc.connect(address, newClientFn)
+	if cached.err != nil {
+		cc.Lock()
+		if cc.clients[nodeID] == cached {
+			delete(cc.clients, nodeID)
+		}
+		cc.Unlock()
+		log.Warn("queryNodeClientCache: connect to queryNode failed", zap.Int64("nodeID", nodeID), zap.String("address", address), zap.Error(cached.err))
+	}
+	close(cached.ready)
+	return cached.client, cached.err
+}
+
+func (cc *queryNodeClientCache) connect(address string, newClientFn newQueryNodeClientFn) (types.QueryNode, error) {
+	client, err := newClientFn(cc.ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Init(); err != nil {
+		client.Stop()
+		return nil, err
+	}
+	if err = client.Start(); err != nil {
+		client.Stop()
+		return nil, err
+	}
+	return client, nil
+}
+
+// evictLocked closes the least recently used client which has been constructed,
+// false is returned if all the clients are still in construction
+func (cc *queryNodeClientCache) evictLocked() bool {
+	var lruNodeID int64
+	var lru *cachedQueryNodeClient
+	for nodeID, cached := range cc.clients {
+		if !cached.isReady() {
+			continue
+		}
+		if lru == nil || cached.lastUsed.Before(lru.lastUsed) {
+			lruNodeID, lru = nodeID, cached
+		}
+	}
+	if lru == nil {
+		return false
+	}
+	log.Debug("queryNodeClientCache: evict queryNode client", zap.Int64("nodeID", lruNodeID), zap.String("address", lru.address))
+	cc.removeLocked(lruNodeID)
+	return true
+}
+
+// remove closes and removes the client of the queryNode, it is called when the queryNode goes offline
+func (cc *queryNodeClientCache) remove(nodeID int64) {
+	cc.Lock()
+	defer cc.Unlock()
+	cc.removeLocked(nodeID)
+}
+
+func (cc *queryNodeClientCache) removeLocked(nodeID int64) {
+	cached, ok := cc.clients[nodeID]
+	if !ok {
+		return
+	}
+	delete(cc.clients, nodeID)
+	go func() {
+		<-cached.ready
+		if cached.client != nil {
+			if err := cached.client.Stop(); err != nil {
+				log.Warn("queryNodeClientCache: close queryNode client failed", zap.Int64("nodeID", nodeID), zap.Error(err))
+			}
+		}
+	}()
+}
+
+// close closes all the cached clients
+func (cc *queryNodeClientCache) close() {
+	cc.Lock()
+	defer cc.Unlock()
+	for nodeID := range cc.clients {
+		cc.removeLocked(nodeID)
+	}
+}
+
+func (cc *queryNodeClientCache) size() int {
+	cc.Lock()
+	defer cc.Unlock()
+	return len(cc.clients)
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+type fakeQueryNodeClient struct {
+	types.QueryNode
+	stopped int32
+}
+
+func (client *fakeQueryNodeClient) Init() error {
+	return nil
+}
+
+func (client *fakeQueryNodeClient) Start() error {
+	return nil
+}
+
+func (client *fakeQueryNodeClient) Stop() error {
+	atomic.StoreInt32(&client.stopped, 1)
+	return nil
+}
+
+func (client *fakeQueryNodeClient) ReleaseSegments(ctx context.Context, in *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, nil
+}
+
+// fakeQueryNodeDialer counts the connections it establishes
+type fakeQueryNodeDialer struct {
+	sync.Mutex
+	connections int
+	clients     []*fakeQueryNodeClient
+	err         error
+}
+
+func (d *fakeQueryNodeDialer) newClient(ctx context.Context, address string) (types.QueryNode, error) {
+	// a slow dial lets concurrent callers pile up on the same construction
+	time.Sleep(50 * time.Millisecond)
+	d.Lock()
+	defer d.Unlock()
+	d.connections++
+	if d.err != nil {
+		return nil, d.err
+	}
+	client := &fakeQueryNodeClient{}
+	d.clients = append(d.clients, client)
+	return client, nil
+}
+
+func TestQueryNodeClientCache_SingleConnection(t *testing.T) {
+	ctx := context.Background()
+	dialer := &fakeQueryNodeDialer{}
+	clients := newQueryNodeClientCache(ctx, 16)
+	node := &queryNode{
+		ctx:         ctx,
+		cancel:      func() {},
+		id:          1,
+		address:     "fake",
+		clients:     clients,
+		newClientFn: dialer.newClient,
+		state:       online,
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- node.releaseSegments(ctx, &querypb.ReleaseSegmentsRequest{})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, dialer.connections)
+	assert.Equal(t, 1, clients.size())
+
+	// the client is closed once the node is offline
+	node.stop()
+	assert.Equal(t, 0, clients.size())
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&dialer.clients[0].stopped) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestQueryNodeClientCache_Evict(t *testing.T) {
+	ctx := context.Background()
+	dialer := &fakeQueryNodeDialer{}
+	clients := newQueryNodeClientCache(ctx, 2)
+
+	for nodeID := int64(1); nodeID <= 3; nodeID++ {
+		_, err := clients.get(ctx, nodeID, "fake", dialer.newClient)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 3, dialer.connections)
+	assert.Equal(t, 2, clients.size())
+	// the least recently used client of node 1 is evicted and closed
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&dialer.clients[0].stopped) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// a new address of the node replaces the cached client
+	_, err := clients.get(ctx, 3, "restarted", dialer.newClient)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, dialer.connections)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&dialer.clients[2].stopped) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	clients.close()
+	assert.Equal(t, 0, clients.size())
+}
+
+func TestQueryNodeClientCache_ConnectFailed(t *testing.T) {
+	ctx := context.Background()
+	dialer := &fakeQueryNodeDialer{err: errors.New("dial failed")}
+	clients := newQueryNodeClientCache(ctx, 2)
+
+	_, err := clients.get(ctx, 1, "fake", dialer.newClient)
+	assert.Error(t, err)
+	// failed clients are not cached, the next call connects again
+	assert.Equal(t, 0, clients.size())
+	dialer.err = nil
+	_, err = clients.get(ctx, 1, "fake", dialer.newClient)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, dialer.connections)
+}
@@ -57,6 +57,7 @@ type Cluster interface {
 	releaseCollection(ctx context.Context, nodeID int64, in *querypb.ReleaseCollectionRequest) error
 	releasePartitions(ctx context.Context, nodeID int64, in *querypb.ReleasePartitionsRequest) error
 	getSegmentInfo(ctx context.Context, in *querypb.GetSegmentInfoRequest) ([]*querypb.SegmentInfo, error)
+	getSegmentInfoByNode(ctx context.Context, nodeID int64, in *querypb.GetSegmentInfoRequest) ([]*querypb.SegmentInfo, error)
 
 	registerNode(ctx context.Context, session *sessionutil.Session, id UniqueID, state nodeState) error
 	getNodeByID(nodeID int64) (Node, error)
@@ -69,9 +70,11 @@ type Cluster interface {
 	getSessionVersion() int64
 
 	getMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest) []queryNodeGetMetricsResponse
+
+	close()
 }
 
-type newQueryNodeFn func(ctx context.Context, address string, id UniqueID, kv *etcdkv.EtcdKV) (Node, error)
+type newQueryNodeFn func(ctx context.Context, address string, id UniqueID, kv *etcdkv.EtcdKV, clients *queryNodeClientCache) (Node, error)
 
 type nodeState int
 
@@ -93,6 +96,8 @@ type queryNodeCluster struct {
 	clusterMeta Meta
 	nodes       map[int64]Node
 	newNodeFn   newQueryNodeFn
+	// clients caches the grpc clients of queryNodes, all requests to queryNodes get their clients from it
+	clients *queryNodeClientCache
 }
 
 func newQueryNodeCluster(ctx context.Context, clusterMeta Meta, kv *etcdkv.EtcdKV, newNodeFn newQueryNodeFn, session *sessionutil.Session) (*queryNodeCluster, error) {
@@ -106,6 +111,7 @@ func newQueryNodeCluster(ctx context.Context, clusterMeta Meta, kv *etcdkv.EtcdK
 		clusterMeta: clusterMeta,
 		nodes:       nodes,
 		newNodeFn:   newNodeFn,
+		clients:     newQueryNodeClientCache(childCtx, Params.MaxQueryNodeClients),
 	}
 	err := c.reloadFromKV()
 	if err != nil {
@@ -231,7 +237,7 @@ func (c *queryNodeCluster) releaseSegments(ctx context.Context, nodeID int64, in
 
 	if node, ok := c.nodes[nodeID]; ok {
 		if !node.isOnline() {
-			return errors.New("node offline")
+			return errQueryNodeOffline("ReleaseSegments")
 		}
 
 		err := node.releaseSegments(ctx, in)
@@ -376,6 +382,24 @@ func (c *queryNodeCluster) getSegmentInfo(ctx context.Context, in *querypb.GetSe
 	return segmentInfos, nil
 }
 
+func (c *queryNodeCluster) getSegmentInfoByNode(ctx context.Context, nodeID int64, in *querypb.GetSegmentInfoRequest) ([]*querypb.SegmentInfo, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if node, ok := c.nodes[nodeID]; ok {
+		res, err := node.getSegmentInfo(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return nil, fmt.Errorf("getSegmentInfoByNode: query node %d failed to report segment infos", nodeID)
+		}
+		return res.Infos, nil
+	}
+
+	return nil, fmt.Errorf("getSegmentInfoByNode: can't find query node by nodeID %d", nodeID)
+}
+
 type queryNodeGetMetricsResponse struct {
 	resp *milvuspb.GetMetricsResponse
 	err  error
@@ -455,7 +479,7 @@ func (c *queryNodeCluster) registerNode(ctx context.Context, session *sessionuti
 		if err != nil {
 			return err
 		}
-		node, err := c.newNodeFn(ctx, session.Address, id, c.client)
+		node, err := c.newNodeFn(ctx, session.Address, id, c.client, c.clients)
 		if err != nil {
 			log.Debug("RegisterNode: create a new query node failed", zap.Int64("nodeID", id), zap.Error(err))
 			return err
@@ -504,6 +528,11 @@ func (c *queryNodeCluster) removeNodeInfo(nodeID int64) error {
 	return nil
 }
 
+// close closes the cached clients of all queryNodes
+func (c *queryNodeCluster) close() {
+	c.clients.close()
+}
+
 func (c *queryNodeCluster) stopNode(nodeID int64) {
 	c.Lock()
 	defer c.Unlock()
@@ -45,6 +45,7 @@ func TestReloadClusterFromKV(t *testing.T) {
 			nodes:     make(map[int64]Node),
 			newNodeFn: newQueryNodeTest,
 			session:   clusterSession,
+			clients:   newQueryNodeClientCache(baseCtx, Params.MaxQueryNodeClients),
 		}
 
 		queryNode, err := startQueryNodeServer(baseCtx)
@@ -75,6 +76,7 @@ func TestReloadClusterFromKV(t *testing.T) {
 			nodes:     make(map[int64]Node),
 			newNodeFn: newQueryNodeTest,
 			session:   clusterSession,
+			clients:   newQueryNodeClientCache(context.Background(), Params.MaxQueryNodeClients),
 		}
 
 		kvs := make(map[string]string)
@@ -126,6 +128,7 @@ func TestGrpcRequest(t *testing.T) {
 		nodes:       make(map[int64]Node),
 		newNodeFn:   newQueryNodeTest,
 		session:     clusterSession,
+		clients:     newQueryNodeClientCache(baseCtx, Params.MaxQueryNodeClients),
 	}
 
 	node, err := startQueryNodeServer(baseCtx)
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+const (
+	schedulerComponentRole = "QueryCoordScheduler"
+	clusterComponentRole   = "QueryCoordCluster"
+	metaComponentRole      = "QueryCoordMeta"
+)
+
+// kvStats is a snapshot of the health of the kv client used by meta
+type kvStats struct {
+	lastLatency  time.Duration
+	errorCount   int64
+	failingSince time.Time // zero if the last operation succeeded
+}
+
+// monitoredKv wraps a kv.MetaKv and records the latency and the failures of the operations meta issues
+type monitoredKv struct {
+	kv.MetaKv
+
+	mu    sync.Mutex
+	stats kvStats
+}
+
+func newMonitoredKv(client kv.MetaKv) *monitoredKv {
+	if mkv, ok := client.(*monitoredKv); ok {
+		return mkv
+	}
+	return &monitoredKv{MetaKv: client}
+}
+
+func (mkv *monitoredKv) record(start time.Time, err error) {
+	now := time.Now()
+	mkv.mu.Lock()
+	defer mkv.mu.Unlock()
+	mkv.stats.lastLatency = now.Sub(start)
+	if err == nil {
+		mkv.stats.failingSince = time.Time{}
+		return
+	}
+	mkv.stats.errorCount++
+	if mkv.stats.failingSince.IsZero() {
+		mkv.stats.failingSince = now
+	}
+}
+
+func (mkv *monitoredKv) getStats() kvStats {
+	mkv.mu.Lock()
+	defer mkv.mu.Unlock()
+	return mkv.stats
+}
+
+func (mkv *monitoredKv) Load(key string) (string, error) {
+	start := time.Now()
+	value, err := mkv.MetaKv.Load(key)
+	mkv.record(start, err)
+	return value, err
+}
+
+func (mkv *monitoredKv) MultiLoad(keys []string) ([]string, error) {
+	start := time.Now()
+	values, err := mkv.MetaKv.MultiLoad(keys)
+	mkv.record(start, err)
+	return values, err
+}
+
+func (mkv *monitoredKv) LoadWithPrefix(key string) ([]string, []string, error) {
+	start := time.Now()
+	keys, values, err := mkv.MetaKv.LoadWithPrefix(key)
+	mkv.record(start, err)
+	return keys, values, err
+}
+
+func (mkv *monitoredKv) Save(key, value string) error {
+	start := time.Now()
+	err := mkv.MetaKv.Save(key, value)
+	mkv.record(start, err)
+	return err
+}
+
+func (mkv *monitoredKv) MultiSave(kvs map[string]string) error {
+	start := time.Now()
+	err := mkv.MetaKv.MultiSave(kvs)
+	mkv.record(start, err)
+	return err
+}
+
+func (mkv *monitoredKv) Remove(key string) error {
+	start := time.Now()
+	err := mkv.MetaKv.Remove(key)
+	mkv.record(start, err)
+	return err
+}
+
+func (mkv *monitoredKv) MultiRemove(keys []string) error {
+	start := time.Now()
+	err := mkv.MetaKv.MultiRemove(keys)
+	mkv.record(start, err)
+	return err
+}
+
+func (mkv *monitoredKv) RemoveWithPrefix(key string) error {
+	start := time.Now()
+	err := mkv.MetaKv.RemoveWithPrefix(key)
+	mkv.record(start, err)
+	return err
+}
+
+func (mkv *monitoredKv) MultiSaveAndRemove(saves map[string]string, removals []string) error {
+	start := time.Now()
+	err := mkv.MetaKv.MultiSaveAndRemove(saves, removals)
+	mkv.record(start, err)
+	return err
+}
+
+// componentStateProber remembers the state of the subcomponents between two probes
+type componentStateProber struct {
+	mu           sync.Mutex
+	offlineSince time.Time // zero if there was no offline node at the last probe
+}
+
+// markProgress records that the scheduler has just made progress
+func (scheduler *TaskScheduler) markProgress() {
+	atomic.StoreInt64(&scheduler.lastProgress, time.Now().UnixNano())
+}
+
+func (scheduler *TaskScheduler) lastProgressTime() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&scheduler.lastProgress))
+}
+
+func (scheduler *TaskScheduler) pendingTaskNum() int {
+	scheduler.triggerTasksMu.RLock()
+	defer scheduler.triggerTasksMu.RUnlock()
+	return len(scheduler.triggerTasks)
+}
+
+// getSchedulerState reports the scheduler abnormal if it has trigger tasks but made no progress for longer than threshold
+func getSchedulerState(scheduler *TaskScheduler, now time.Time, threshold time.Duration) *internalpb.ComponentInfo {
+	pending := scheduler.pendingTaskNum()
+	lastProgress := scheduler.lastProgressTime()
+	stateCode := internalpb.StateCode_Healthy
+	if pending > 0 && now.Sub(lastProgress) > threshold {
+		stateCode = internalpb.StateCode_Abnormal
+	}
+	return &internalpb.ComponentInfo{
+		NodeID:    Params.QueryCoordID,
+		Role:      schedulerComponentRole,
+		StateCode: stateCode,
+		ExtraInfo: []*commonpb.KeyValuePair{
+			{Key: "queue_length", Value: strconv.Itoa(pending)},
+			{Key: "last_progress_time", Value: lastProgress.Format(time.RFC3339Nano)},
+		},
+	}
+}
+
+// getClusterState reports the cluster abnormal if some query nodes have stayed offline for longer than threshold
+func (p *componentStateProber) getClusterState(cluster Cluster, now time.Time, threshold time.Duration) *internalpb.ComponentInfo {
+	// an error means there is no node
+	onlineNodes, _ := cluster.onlineNodes()
+	offlineNodes, _ := cluster.offlineNodes()
+
+	p.mu.Lock()
+	if len(offlineNodes) == 0 {
+		p.offlineSince = time.Time{}
+	} else if p.offlineSince.IsZero() {
+		p.offlineSince = now
+	}
+	offlineSince := p.offlineSince
+	p.mu.Unlock()
+
+	stateCode := internalpb.StateCode_Healthy
+	if !offlineSince.IsZero() && now.Sub(offlineSince) > threshold {
+		stateCode = internalpb.StateCode_Abnormal
+	}
+	return &internalpb.ComponentInfo{
+		NodeID:    Params.QueryCoordID,
+		Role:      clusterComponentRole,
+		StateCode: stateCode,
+		ExtraInfo: []*commonpb.KeyValuePair{
+			{Key: "online_nodes", Value: strconv.Itoa(len(onlineNodes))},
+			{Key: "offline_nodes", Value: strconv.Itoa(len(offlineNodes))},
+		},
+	}
+}
+
+// getMetaState reports meta abnormal if its kv operations have kept failing for longer than threshold
+func getMetaState(meta Meta, now time.Time, threshold time.Duration) *internalpb.ComponentInfo {
+	stats := meta.getKvStats()
+	stateCode := internalpb.StateCode_Healthy
+	if !stats.failingSince.IsZero() && now.Sub(stats.failingSince) > threshold {
+		stateCode = internalpb.StateCode_Abnormal
+	}
+	return &internalpb.ComponentInfo{
+		NodeID:    Params.QueryCoordID,
+		Role:      metaComponentRole,
+		StateCode: stateCode,
+		ExtraInfo: []*commonpb.KeyValuePair{
+			{Key: "last_op_latency", Value: stats.lastLatency.String()},
+			{Key: "error_count", Value: strconv.FormatInt(stats.errorCount, 10)},
+		},
+	}
+}
+
+// getSubcomponentStates probes the scheduler, the cluster and meta, it only reads in-memory state so it is cheap to call often
+func (qc *QueryCoord) getSubcomponentStates() []*internalpb.ComponentInfo {
+	now := time.Now()
+	return []*internalpb.ComponentInfo{
+		getSchedulerState(qc.scheduler, now, Params.StallThreshold),
+		qc.stateProber.getClusterState(qc.cluster, now, Params.StallThreshold),
+		getMetaState(qc.meta, now, Params.StallThreshold),
+	}
+}
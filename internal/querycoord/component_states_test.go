@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+type offlineCluster struct {
+	Cluster
+	online  map[int64]Node
+	offline map[int64]Node
+}
+
+func (c *offlineCluster) onlineNodes() (map[int64]Node, error) {
+	if len(c.online) == 0 {
+		return nil, errors.New("no online node")
+	}
+	return c.online, nil
+}
+
+func (c *offlineCluster) offlineNodes() (map[int64]Node, error) {
+	if len(c.offline) == 0 {
+		return nil, errors.New("no offline node")
+	}
+	return c.offline, nil
+}
+
+func stallScheduler(scheduler *TaskScheduler, since time.Time) {
+	ctx := context.Background()
+	t := &testTask{
+		baseTask: baseTask{
+			ctx:              ctx,
+			condition:        newTaskCondition(ctx),
+			triggerCondition: querypb.TriggerCondition_grpcRequest,
+			taskID:           1,
+		},
+	}
+	scheduler.addTriggerTask(t)
+	atomic.StoreInt64(&scheduler.lastProgress, since.UnixNano())
+}
+
+func TestGetSchedulerState(t *testing.T) {
+	threshold := time.Minute
+	scheduler := &TaskScheduler{
+		triggerTasks: make(map[UniqueID]task),
+	}
+
+	// an idle scheduler is never stalled
+	atomic.StoreInt64(&scheduler.lastProgress, time.Now().Add(-time.Hour).UnixNano())
+	info := getSchedulerState(scheduler, time.Now(), threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+	assert.Equal(t, schedulerComponentRole, info.Role)
+
+	stallScheduler(scheduler, time.Now().Add(-time.Hour))
+	info = getSchedulerState(scheduler, time.Now(), threshold)
+	assert.Equal(t, internalpb.StateCode_Abnormal, info.StateCode)
+	assert.Equal(t, "queue_length", info.ExtraInfo[0].Key)
+	assert.Equal(t, "1", info.ExtraInfo[0].Value)
+
+	scheduler.markProgress()
+	info = getSchedulerState(scheduler, time.Now(), threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+}
+
+func TestGetClusterState(t *testing.T) {
+	threshold := time.Minute
+	prober := &componentStateProber{}
+	cluster := &offlineCluster{
+		online:  map[int64]Node{1: nil},
+		offline: map[int64]Node{2: nil},
+	}
+
+	now := time.Now()
+	info := prober.getClusterState(cluster, now, threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+	assert.Equal(t, "1", info.ExtraInfo[0].Value)
+	assert.Equal(t, "1", info.ExtraInfo[1].Value)
+
+	info = prober.getClusterState(cluster, now.Add(2*threshold), threshold)
+	assert.Equal(t, internalpb.StateCode_Abnormal, info.StateCode)
+
+	cluster.offline = nil
+	info = prober.getClusterState(cluster, now.Add(3*threshold), threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+	assert.Equal(t, "0", info.ExtraInfo[1].Value)
+}
+
+func TestGetMetaState(t *testing.T) {
+	threshold := time.Minute
+	kv := &testKv{
+		returnFn: failedResult,
+	}
+	meta, err := newMeta(context.Background(), kv, nil, nil)
+	assert.Nil(t, err)
+
+	info := getMetaState(meta, time.Now(), threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+
+	err = meta.(*MetaReplica).client.Save("key", "value")
+	assert.NotNil(t, err)
+	stats := meta.getKvStats()
+	assert.Equal(t, int64(1), stats.errorCount)
+	assert.False(t, stats.failingSince.IsZero())
+
+	info = getMetaState(meta, time.Now(), threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+	info = getMetaState(meta, time.Now().Add(2*threshold), threshold)
+	assert.Equal(t, internalpb.StateCode_Abnormal, info.StateCode)
+	assert.Equal(t, "1", info.ExtraInfo[1].Value)
+
+	kv.returnFn = func() error { return nil }
+	err = meta.(*MetaReplica).client.Save("key", "value")
+	assert.Nil(t, err)
+	info = getMetaState(meta, time.Now().Add(2*threshold), threshold)
+	assert.Equal(t, internalpb.StateCode_Healthy, info.StateCode)
+	assert.Equal(t, "1", info.ExtraInfo[1].Value)
+}
+
+func TestGetComponentStates_stalledScheduler(t *testing.T) {
+	refreshParams()
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+	defer queryCoord.Stop()
+
+	states, err := queryCoord.GetComponentStates(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, internalpb.StateCode_Healthy, states.State.StateCode)
+	assert.Equal(t, 3, len(states.SubcomponentStates))
+
+	stallScheduler(queryCoord.scheduler, time.Now().Add(-2*Params.StallThreshold))
+	states, err = queryCoord.GetComponentStates(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, internalpb.StateCode_Abnormal, states.State.StateCode)
+	assert.Equal(t, schedulerComponentRole, states.SubcomponentStates[0].Role)
+	assert.Equal(t, internalpb.StateCode_Abnormal, states.SubcomponentStates[0].StateCode)
+
+	// the scheduler recovers once the stalled task is done
+	queryCoord.scheduler.triggerTasksMu.RLock()
+	stalled := queryCoord.scheduler.triggerTasks[1]
+	queryCoord.scheduler.triggerTasksMu.RUnlock()
+	queryCoord.scheduler.removeTriggerTask(stalled)
+	states, err = queryCoord.GetComponentStates(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, internalpb.StateCode_Healthy, states.State.StateCode)
+}
@@ -14,10 +14,152 @@ package querycoord
 import (
 	"errors"
 	"fmt"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+)
+
+// the kinds of task failures, returned to the client in the detail of the status so that it can tell them apart
+const (
+	errKindCollectionNotFound = "CollectionNotFound"
+	errKindPartitionNotFound  = "PartitionNotFound"
+	errKindSegmentsNotIndexed = "SegmentsNotIndexed"
+	errKindNodeOffline        = "NodeOffline"
+	errKindNoAvailableNode    = "NoAvailableNode"
+	errKindOutOfMemory        = "OutOfMemory"
+	errKindSegmentNotFound    = "SegmentNotFound"
 )
 
+// taskError is an error of task carrying the error code and the kind returned to the client
+type taskError struct {
+	code commonpb.ErrorCode
+	kind string
+	msg  string
+}
+
+func (e *taskError) Error() string {
+	return e.msg
+}
+
+func errCollectionNotFound(collectionID UniqueID, reason string) error {
+	return &taskError{
+		code: commonpb.ErrorCode_CollectionNotExists,
+		kind: errKindCollectionNotFound,
+		msg:  fmt.Sprintf("collection %d not found, %s", collectionID, reason),
+	}
+}
+
+func errPartitionNotFound(collectionID UniqueID, partitionIDs []UniqueID) error {
+	return &taskError{
+		code: commonpb.ErrorCode_IllegalArgument,
+		kind: errKindPartitionNotFound,
+		msg:  fmt.Sprintf("partitions %v not found in collection %d", partitionIDs, collectionID),
+	}
+}
+
+func errSegmentsNotIndexed(collectionID UniqueID, segmentIDs []UniqueID) error {
+	return &taskError{
+		code: commonpb.ErrorCode_IndexNotExist,
+		kind: errKindSegmentsNotIndexed,
+		msg:  fmt.Sprintf("index of segments %v in collection %d is not built", segmentIDs, collectionID),
+	}
+}
+
+func errSegmentNotFound(segmentID UniqueID, nodeIDs []int64) error {
+	return &taskError{
+		code: commonpb.ErrorCode_IllegalArgument,
+		kind: errKindSegmentNotFound,
+		msg:  fmt.Sprintf("segment %d not found on query nodes %v", segmentID, nodeIDs),
+	}
+}
+
+func errIllegalLoadBalance(reason string) error {
+	return &taskError{
+		code: commonpb.ErrorCode_IllegalArgument,
+		msg:  fmt.Sprintf("illegal load balance request, %s", reason),
+	}
+}
+
+func errQueryNodeOffline(op string) error {
+	return &taskError{
+		code: commonpb.ErrorCode_ConnectFailed,
+		kind: errKindNodeOffline,
+		msg:  fmt.Sprintf("%s: queryNode is offline", op),
+	}
+}
+
+func errNoAvailableQueryNode() error {
+	return &taskError{
+		code: commonpb.ErrorCode_UnexpectedError,
+		kind: errKindNoAvailableNode,
+		msg:  "no queryNode to allocate",
+	}
+}
+
+// errFromStatus converts the failed status returned by a query node, keeping its error code
+func errFromStatus(status *commonpb.Status) error {
+	te := &taskError{
+		code: status.ErrorCode,
+		kind: status.Detail,
+		msg:  status.Reason,
+	}
+	if te.kind == "" {
+		switch status.ErrorCode {
+		case commonpb.ErrorCode_OutOfMemory:
+			te.kind = errKindOutOfMemory
+		case commonpb.ErrorCode_CollectionNotExists:
+			te.kind = errKindCollectionNotFound
+		}
+	}
+	return te
+}
+
+// errRescheduleFailed reports a failed child task which can't be rescheduled. The child failure is the root cause,
+// e.g. the query nodes are out of memory rather than unavailable, so its kind is reported to the client if it has one
+func errRescheduleFailed(childErr error, err error) error {
+	var te *taskError
+	if !errors.As(childErr, &te) {
+		return err
+	}
+	return &taskError{
+		code: te.code,
+		kind: te.kind,
+		msg:  fmt.Sprintf("%s, reschedule failed: %s", childErr.Error(), err.Error()),
+	}
+}
+
+// errorCodeOf returns the error code of err returned to the client, UnexpectedError if err doesn't carry one
+func errorCodeOf(err error) commonpb.ErrorCode {
+	var te *taskError
+	if errors.As(err, &te) {
+		return te.code
+	}
+	return commonpb.ErrorCode_UnexpectedError
+}
+
+// errorKindOf returns the kind of err returned to the client, empty if err doesn't carry one
+func errorKindOf(err error) string {
+	var te *taskError
+	if errors.As(err, &te) {
+		return te.kind
+	}
+	return ""
+}
+
+// failedTaskErrorCode returns the error code of the failed task returned to the client
+func failedTaskErrorCode(t task) commonpb.ErrorCode {
+	code := t.getResultInfo().ErrorCode
+	if code == commonpb.ErrorCode_Success {
+		return commonpb.ErrorCode_UnexpectedError
+	}
+	return code
+}
+
 func errQueryNodeIsNotOnService(id UniqueID) error {
-	return fmt.Errorf("query node %d is not on service", id)
+	return &taskError{
+		code: commonpb.ErrorCode_ConnectFailed,
+		kind: errKindNodeOffline,
+		msg:  fmt.Sprintf("query node %d is not on service", id),
+	}
 }
 
 func msgQueryCoordIsUnhealthy(coordID UniqueID) string {
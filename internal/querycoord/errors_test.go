@@ -12,10 +12,17 @@
 package querycoord
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
-	"github.com/milvus-io/milvus/internal/log"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
 )
 
 func TestErrQueryNodeIsNotOnService(t *testing.T) {
@@ -42,3 +49,68 @@ func TestErrQueryCoordIsUnhealthy(t *testing.T) {
 		log.Info("TestErrQueryCoordIsUnhealthy", zap.Error(errQueryCoordIsUnhealthy(nodeID)))
 	}
 }
+
+func TestErrorCodeOf(t *testing.T) {
+	assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, errorCodeOf(errCollectionNotFound(1, "dropped")))
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, errorCodeOf(errPartitionNotFound(1, []UniqueID{2})))
+	assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, errorCodeOf(fmt.Errorf("wrapped: %w", errCollectionNotFound(1, "dropped"))))
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, errorCodeOf(errors.New("mock")))
+
+	loadTask := &loadCollectionTask{
+		baseTask: newBaseTask(context.Background(), querypb.TriggerCondition_grpcRequest),
+	}
+	loadTask.setResultInfo(nil)
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, failedTaskErrorCode(loadTask))
+	loadTask.setResultInfo(errCollectionNotFound(1, "dropped"))
+	assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, failedTaskErrorCode(loadTask))
+}
+
+func TestErrorKindOf(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code commonpb.ErrorCode
+		kind string
+	}{
+		{"collection dropped", errCollectionNotFound(1, "dropped"), commonpb.ErrorCode_CollectionNotExists, errKindCollectionNotFound},
+		{"partition not found", errPartitionNotFound(1, []UniqueID{2}), commonpb.ErrorCode_IllegalArgument, errKindPartitionNotFound},
+		{"segments not indexed", errSegmentsNotIndexed(1, []UniqueID{2}), commonpb.ErrorCode_IndexNotExist, errKindSegmentsNotIndexed},
+		{"node offline", errQueryNodeOffline("LoadSegments"), commonpb.ErrorCode_ConnectFailed, errKindNodeOffline},
+		{"node not on service", errQueryNodeIsNotOnService(1), commonpb.ErrorCode_ConnectFailed, errKindNodeOffline},
+		{"no available node", errNoAvailableQueryNode(), commonpb.ErrorCode_UnexpectedError, errKindNoAvailableNode},
+		{"out of memory", errFromStatus(&commonpb.Status{ErrorCode: commonpb.ErrorCode_OutOfMemory, Reason: "OOM"}), commonpb.ErrorCode_OutOfMemory, errKindOutOfMemory},
+		{"unclassified status", errFromStatus(&commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError}), commonpb.ErrorCode_UnexpectedError, ""},
+		{"reschedule after out of memory", errRescheduleFailed(errFromStatus(&commonpb.Status{ErrorCode: commonpb.ErrorCode_OutOfMemory}), errNoAvailableQueryNode()), commonpb.ErrorCode_OutOfMemory, errKindOutOfMemory},
+		{"reschedule after unclassified", errRescheduleFailed(errors.New("mock"), errNoAvailableQueryNode()), commonpb.ErrorCode_UnexpectedError, errKindNoAvailableNode},
+		{"wrapped", fmt.Errorf("wrapped: %w", errQueryNodeOffline("WatchDmChannels")), commonpb.ErrorCode_ConnectFailed, errKindNodeOffline},
+		{"plain", errors.New("mock"), commonpb.ErrorCode_UnexpectedError, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.code, errorCodeOf(c.err))
+			assert.Equal(t, c.kind, errorKindOf(c.err))
+
+			loadTask := &loadCollectionTask{
+				baseTask: newBaseTask(context.Background(), querypb.TriggerCondition_grpcRequest),
+			}
+			loadTask.setResultInfo(c.err)
+			result := loadTask.getResultInfo()
+			assert.Equal(t, c.code, failedTaskErrorCode(loadTask))
+			assert.Equal(t, c.kind, result.Detail)
+
+			loadTask.setResultInfo(nil)
+			assert.Equal(t, "", loadTask.getResultInfo().Detail)
+		})
+	}
+}
+
+func TestQueryNodeOfflineError(t *testing.T) {
+	node := &queryNode{
+		id:    1,
+		state: offline,
+	}
+	err := node.loadSegments(context.Background(), &querypb.LoadSegmentsRequest{})
+	assert.Equal(t, errKindNodeOffline, errorKindOf(err))
+	err = node.watchDmChannels(context.Background(), &querypb.WatchDmChannelsRequest{})
+	assert.Equal(t, commonpb.ErrorCode_ConnectFailed, errorCodeOf(err))
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+// idleReleaseChecker releases the loaded collections which are not searched or queried for longer than their
+// load.idle.release.seconds property, the collections without the property are never released.
+//
+// The last activity of a collection is the latest access of its segments reported by the query nodes, a collection
+// found idle is marked pending first and released at the next check if it's still idle.
+type idleReleaseChecker struct {
+	meta      Meta
+	cluster   Cluster
+	rootCoord types.RootCoord
+	release   func(ctx context.Context, collectionID UniqueID) error
+	now       func() time.Time
+
+	lastActive map[UniqueID]time.Time // the last activity of the loaded collections, in the clock of query coord
+	pending    map[UniqueID]time.Time // the collections to release at the next check, with the time they're marked
+}
+
+func newIdleReleaseChecker(meta Meta, cluster Cluster, rootCoord types.RootCoord, release func(ctx context.Context, collectionID UniqueID) error) *idleReleaseChecker {
+	return &idleReleaseChecker{
+		meta:       meta,
+		cluster:    cluster,
+		rootCoord:  rootCoord,
+		release:    release,
+		now:        time.Now,
+		lastActive: make(map[UniqueID]time.Time),
+		pending:    make(map[UniqueID]time.Time),
+	}
+}
+
+// getLastActivities returns the last activity of the collections served by the query nodes, complete is false
+// if some query node failed to report, in which case the activities may be missed.
+func (c *idleReleaseChecker) getLastActivities(ctx context.Context, now time.Time) (activities map[UniqueID]time.Time, complete bool) {
+	activities = make(map[UniqueID]time.Time)
+	complete = true
+
+	req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.SegmentStatisticsMetrics)
+	if err != nil {
+		log.Warn("idleReleaseChecker failed to construct segment statistics request", zap.Error(err))
+		return activities, false
+	}
+	for _, nodeMetrics := range c.cluster.getMetrics(ctx, req) {
+		if nodeMetrics.err != nil || nodeMetrics.resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			complete = false
+			continue
+		}
+		nodeStatistics := metricsinfo.QueryNodeSegmentStatistics{}
+		if err := metricsinfo.UnmarshalComponentInfos(nodeMetrics.resp.Response, &nodeStatistics); err != nil {
+			complete = false
+			continue
+		}
+		for _, segment := range nodeStatistics.Segments {
+			// the idle time measured by query node avoids the clock skew between the nodes
+			active := now.Add(-time.Duration(segment.IdleInMs) * time.Millisecond)
+			if active.After(activities[segment.CollectionID]) {
+				activities[segment.CollectionID] = active
+			}
+		}
+	}
+	return activities, complete
+}
+
+// getIdleRelease returns the load.idle.release.seconds property of the collection
+func (c *idleReleaseChecker) getIdleRelease(ctx context.Context, collectionID UniqueID) (time.Duration, bool, error) {
+	req := &milvuspb.DescribeCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_DescribeCollection,
+		},
+		CollectionID: collectionID,
+	}
+	resp, err := c.rootCoord.DescribeCollection(ctx, req)
+	if err != nil {
+		return 0, false, err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return 0, false, errors.New(resp.Status.Reason)
+	}
+	idle, ok := common.NewCollectionProperties(resp.GetProperties()).LoadIdleRelease()
+	return idle, ok, nil
+}
+
+func (c *idleReleaseChecker) clearPending(collectionID UniqueID) {
+	if _, ok := c.pending[collectionID]; ok {
+		delete(c.pending, collectionID)
+		metrics.QueryCoordIdleReleasePendingCollections.DeleteLabelValues(strconv.FormatInt(collectionID, 10))
+	}
+}
+
+// check updates the last activities of the loaded collections, and releases the collections idle for too long
+func (c *idleReleaseChecker) check(ctx context.Context) {
+	now := c.now()
+	activities, complete := c.getLastActivities(ctx, now)
+
+	loaded := make(map[UniqueID]bool)
+	for _, info := range c.meta.showCollections() {
+		collectionID := info.CollectionID
+		loaded[collectionID] = true
+
+		// the collection is taken as active when it's seen the first time
+		lastActive, ok := c.lastActive[collectionID]
+		if !ok {
+			lastActive = now
+		}
+		if active, ok := activities[collectionID]; ok && active.After(lastActive) {
+			lastActive = active
+		}
+		c.lastActive[collectionID] = lastActive
+
+		threshold, ok, err := c.getIdleRelease(ctx, collectionID)
+		if err != nil {
+			log.Warn("idleReleaseChecker failed to get collection properties", zap.Int64("collectionID", collectionID), zap.Error(err))
+			c.clearPending(collectionID)
+			continue
+		}
+		idle := now.Sub(lastActive)
+		if !ok || idle < threshold {
+			if _, pending := c.pending[collectionID]; pending {
+				log.Info("idleReleaseChecker cancels the release of collection", zap.Int64("collectionID", collectionID), zap.Duration("idle", idle))
+			}
+			c.clearPending(collectionID)
+			continue
+		}
+		// a query node failed to report may be serving the collection
+		if !complete {
+			continue
+		}
+
+		if _, pending := c.pending[collectionID]; !pending {
+			log.Warn("idleReleaseChecker will release the idle collection at next check",
+				zap.Int64("collectionID", collectionID),
+				zap.Duration("idle", idle),
+				zap.Duration("threshold", threshold))
+			c.pending[collectionID] = now
+			metrics.QueryCoordIdleReleasePendingCollections.WithLabelValues(strconv.FormatInt(collectionID, 10)).Set(1)
+			continue
+		}
+
+		log.Info("idleReleaseChecker releases the idle collection",
+			zap.Int64("collectionID", collectionID),
+			zap.Duration("idle", idle),
+			zap.Duration("threshold", threshold))
+		if err := c.release(ctx, collectionID); err != nil {
+			log.Warn("idleReleaseChecker failed to release the idle collection", zap.Int64("collectionID", collectionID), zap.Error(err))
+			continue
+		}
+		metrics.QueryCoordIdleReleasedCollectionCounter.Inc()
+		c.clearPending(collectionID)
+		delete(c.lastActive, collectionID)
+	}
+
+	// forget the collections released by others
+	for collectionID := range c.lastActive {
+		if !loaded[collectionID] {
+			delete(c.lastActive, collectionID)
+			c.clearPending(collectionID)
+		}
+	}
+}
+
+// releaseIdleCollection releases the collection by a releaseCollectionTask as ReleaseCollection does
+func (qc *QueryCoord) releaseIdleCollection(ctx context.Context, collectionID UniqueID) error {
+	req := &querypb.ReleaseCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_ReleaseCollection,
+		},
+		CollectionID: collectionID,
+	}
+	baseTask := newBaseTask(qc.taskCtx(ctx), querypb.TriggerCondition_grpcRequest)
+	releaseCollectionTask := &releaseCollectionTask{
+		baseTask:                 baseTask,
+		ReleaseCollectionRequest: req,
+		cluster:                  qc.cluster,
+		meta:                     qc.meta,
+		rootCoord:                qc.rootCoordClient,
+	}
+	if err := qc.scheduler.Enqueue(releaseCollectionTask); err != nil {
+		return err
+	}
+	return releaseCollectionTask.waitToFinish()
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+type idleReleaseMeta struct {
+	Meta
+	collectionIDs []UniqueID
+}
+
+func (m *idleReleaseMeta) showCollections() []*querypb.CollectionInfo {
+	infos := make([]*querypb.CollectionInfo, 0, len(m.collectionIDs))
+	for _, id := range m.collectionIDs {
+		infos = append(infos, &querypb.CollectionInfo{CollectionID: id})
+	}
+	return infos
+}
+
+// idleReleaseCluster reports the segments of the query nodes, a nil segment list fails the query node
+type idleReleaseCluster struct {
+	Cluster
+	nodes [][]metricsinfo.SegmentStatistics
+}
+
+func (c *idleReleaseCluster) getMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest) []queryNodeGetMetricsResponse {
+	ret := make([]queryNodeGetMetricsResponse, 0, len(c.nodes))
+	for _, segments := range c.nodes {
+		if segments == nil {
+			ret = append(ret, queryNodeGetMetricsResponse{err: errors.New("query node is down")})
+			continue
+		}
+		resp, err := metricsinfo.MarshalComponentInfos(metricsinfo.QueryNodeSegmentStatistics{Segments: segments})
+		if err != nil {
+			panic(err)
+		}
+		ret = append(ret, queryNodeGetMetricsResponse{
+			resp: &milvuspb.GetMetricsResponse{
+				Status:   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				Response: resp,
+			},
+		})
+	}
+	return ret
+}
+
+type idleReleaseRootCoord struct {
+	types.RootCoord
+	properties map[UniqueID][]*commonpb.KeyValuePair
+}
+
+func (rc *idleReleaseRootCoord) DescribeCollection(ctx context.Context, req *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error) {
+	return &milvuspb.DescribeCollectionResponse{
+		Status:       &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		CollectionID: req.CollectionID,
+		Properties:   rc.properties[req.CollectionID],
+	}, nil
+}
+
+func TestIdleReleaseChecker(t *testing.T) {
+	const (
+		idleID   = UniqueID(1) // idle with the property
+		activeID = UniqueID(2) // active with the property
+		unsetID  = UniqueID(3) // idle without the property
+	)
+	idleRelease := []*commonpb.KeyValuePair{{Key: common.CollectionLoadIdleReleaseKey, Value: "3600"}}
+
+	meta := &idleReleaseMeta{collectionIDs: []UniqueID{idleID, activeID, unsetID}}
+	cluster := &idleReleaseCluster{}
+	rootCoord := &idleReleaseRootCoord{
+		properties: map[UniqueID][]*commonpb.KeyValuePair{
+			idleID:   idleRelease,
+			activeID: idleRelease,
+		},
+	}
+	released := make([]UniqueID, 0)
+	release := func(ctx context.Context, collectionID UniqueID) error {
+		released = append(released, collectionID)
+		for i, id := range meta.collectionIDs {
+			if id == collectionID {
+				meta.collectionIDs = append(meta.collectionIDs[:i], meta.collectionIDs[i+1:]...)
+				break
+			}
+		}
+		return nil
+	}
+	checker := newIdleReleaseChecker(meta, cluster, rootCoord, release)
+	clock := time.Now()
+	checker.now = func() time.Time { return clock }
+
+	// activeID is searched just now on the second node, idleID hasn't been searched since loaded
+	reportActive := func() {
+		cluster.nodes = [][]metricsinfo.SegmentStatistics{
+			{
+				{SegmentID: 1, CollectionID: idleID, IdleInMs: (2 * time.Hour).Milliseconds()},
+				{SegmentID: 2, CollectionID: activeID, IdleInMs: (2 * time.Hour).Milliseconds()},
+			},
+			{
+				{SegmentID: 3, CollectionID: activeID, IdleInMs: 0},
+				{SegmentID: 4, CollectionID: unsetID, IdleInMs: (2 * time.Hour).Milliseconds()},
+			},
+		}
+	}
+	reportActive()
+	checker.check(context.Background())
+	assert.Empty(t, released)
+	assert.Empty(t, checker.pending)
+
+	clock = clock.Add(30 * time.Minute)
+	reportActive()
+	checker.check(context.Background())
+	assert.Empty(t, released)
+	assert.Empty(t, checker.pending)
+
+	// idleID is marked pending and released at the next check
+	clock = clock.Add(31 * time.Minute)
+	reportActive()
+	checker.check(context.Background())
+	assert.Empty(t, released)
+	assert.Contains(t, checker.pending, idleID)
+	assert.Equal(t, 1, len(checker.pending))
+
+	clock = clock.Add(time.Minute)
+	reportActive()
+	checker.check(context.Background())
+	assert.Equal(t, []UniqueID{idleID}, released)
+	assert.Empty(t, checker.pending)
+	assert.NotContains(t, checker.lastActive, idleID)
+
+	// the collection without the property is never released
+	for i := 0; i < 3; i++ {
+		clock = clock.Add(24 * time.Hour)
+		cluster.nodes = [][]metricsinfo.SegmentStatistics{
+			{{SegmentID: 4, CollectionID: unsetID, IdleInMs: (48 * time.Hour).Milliseconds()}},
+		}
+		checker.check(context.Background())
+	}
+	assert.Equal(t, []UniqueID{idleID, activeID}, released)
+	assert.Equal(t, []UniqueID{unsetID}, meta.collectionIDs)
+}
+
+func TestIdleReleaseChecker_cancel(t *testing.T) {
+	collectionID := UniqueID(1)
+	meta := &idleReleaseMeta{collectionIDs: []UniqueID{collectionID}}
+	cluster := &idleReleaseCluster{}
+	rootCoord := &idleReleaseRootCoord{
+		properties: map[UniqueID][]*commonpb.KeyValuePair{
+			collectionID: {{Key: common.CollectionLoadIdleReleaseKey, Value: "60"}},
+		},
+	}
+	released := 0
+	checker := newIdleReleaseChecker(meta, cluster, rootCoord, func(ctx context.Context, collectionID UniqueID) error {
+		released++
+		return nil
+	})
+	clock := time.Now()
+	checker.now = func() time.Time { return clock }
+	report := func(idle time.Duration) {
+		cluster.nodes = [][]metricsinfo.SegmentStatistics{
+			{{SegmentID: 1, CollectionID: collectionID, IdleInMs: idle.Milliseconds()}},
+		}
+	}
+
+	report(time.Hour)
+	checker.check(context.Background())
+	clock = clock.Add(2 * time.Minute)
+	report(time.Hour)
+	checker.check(context.Background())
+	assert.Contains(t, checker.pending, collectionID)
+
+	// searched before the release
+	clock = clock.Add(time.Minute)
+	report(time.Second)
+	checker.check(context.Background())
+	assert.Empty(t, checker.pending)
+	assert.Equal(t, 0, released)
+
+	// a query node fails to report, which may be serving the collection
+	clock = clock.Add(2 * time.Minute)
+	report(time.Hour)
+	cluster.nodes = append(cluster.nodes, nil)
+	checker.check(context.Background())
+	checker.check(context.Background())
+	assert.Empty(t, checker.pending)
+	assert.Equal(t, 0, released)
+
+	// the property is removed
+	rootCoord.properties = nil
+	report(time.Hour)
+	checker.check(context.Background())
+	checker.check(context.Background())
+	assert.Empty(t, checker.pending)
+	assert.Equal(t, 0, released)
+
+	// released by others
+	meta.collectionIDs = nil
+	checker.check(context.Background())
+	assert.Empty(t, checker.lastActive)
+}
@@ -15,6 +15,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/opentracing/opentracing-go"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
@@ -22,34 +23,43 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 // GetComponentStates return information about whether the coord is healthy
 func (qc *QueryCoord) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
 	serviceComponentInfo := &internalpb.ComponentInfo{
 		NodeID:    Params.QueryCoordID,
+		Role:      typeutil.QueryCoordRole,
 		StateCode: qc.stateCode.Load().(internalpb.StateCode),
 	}
 
-	//subComponentInfos, err := qs.cluster.GetComponentInfos(ctx)
-	//if err != nil {
-	//	return &internalpb.ComponentStates{
-	//		Status: &commonpb.Status{
-	//			ErrorCode: commonpb.ErrorCode_UnexpectedError,
-	//			Reason:    err.Error(),
-	//		},
-	//	}, err
-	//}
+	// the subcomponents are only probed once the coord has started, and a stalled one degrades the whole coord
+	var subComponentInfos []*internalpb.ComponentInfo
+	if serviceComponentInfo.StateCode == internalpb.StateCode_Healthy {
+		subComponentInfos = qc.getSubcomponentStates()
+		for _, info := range subComponentInfos {
+			if info.StateCode == internalpb.StateCode_Abnormal {
+				serviceComponentInfo.StateCode = internalpb.StateCode_Abnormal
+			}
+		}
+	}
 	return &internalpb.ComponentStates{
 		Status: &commonpb.Status{
 			ErrorCode: commonpb.ErrorCode_Success,
 		},
-		State: serviceComponentInfo,
-		//SubcomponentStates: subComponentInfos,
+		State:              serviceComponentInfo,
+		SubcomponentStates: subComponentInfos,
 	}, nil
 }
 
+// CheckReady checks whether QueryCoord is healthy and its session is registered in etcd
+func (qc *QueryCoord) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, qc, qc.session)
+}
+
 // GetTimeTickChannel returns the time tick channel
 // TimeTickChannel contains many time tick messages, which has been sent by query nodes
 func (qc *QueryCoord) GetTimeTickChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
@@ -109,16 +119,9 @@ func (qc *QueryCoord) ShowCollections(ctx context.Context, req *querypb.ShowColl
 			InMemoryPercentages: inMemoryPercentages,
 		}, nil
 	}
+	// the collections not loaded are reported with 0 percentage
 	for _, id := range req.CollectionIDs {
-		if _, ok := ID2collectionInfo[id]; !ok {
-			status.ErrorCode = commonpb.ErrorCode_UnexpectedError
-			err := errors.New("collection has not been loaded to memory or load failed")
-			status.Reason = err.Error()
-			return &querypb.ShowCollectionsResponse{
-				Status: status,
-			}, err
-		}
-		inMemoryPercentages = append(inMemoryPercentages, ID2collectionInfo[id].InMemoryPercentage)
+		inMemoryPercentages = append(inMemoryPercentages, ID2collectionInfo[id].GetInMemoryPercentage())
 	}
 	log.Debug("show collection end", zap.Int64s("collections", req.CollectionIDs), zap.Int64s("inMemoryPercentage", inMemoryPercentages))
 	return &querypb.ShowCollectionsResponse{
@@ -145,12 +148,13 @@ func (qc *QueryCoord) LoadCollection(ctx context.Context, req *querypb.LoadColle
 		return status, err
 	}
 
-	baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_grpcRequest)
+	baseTask := newBaseTask(qc.taskCtx(ctx), querypb.TriggerCondition_grpcRequest)
 	loadCollectionTask := &loadCollectionTask{
 		baseTask:              baseTask,
 		LoadCollectionRequest: req,
 		rootCoord:             qc.rootCoordClient,
 		dataCoord:             qc.dataCoordClient,
+		indexCoord:            qc.indexCoordClient,
 		cluster:               qc.cluster,
 		meta:                  qc.meta,
 	}
@@ -163,7 +167,8 @@ func (qc *QueryCoord) LoadCollection(ctx context.Context, req *querypb.LoadColle
 
 	err = loadCollectionTask.waitToFinish()
 	if err != nil {
-		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		status.ErrorCode = failedTaskErrorCode(loadCollectionTask)
+		status.Detail = loadCollectionTask.getResultInfo().Detail
 		status.Reason = err.Error()
 		return status, err
 	}
@@ -194,7 +199,7 @@ func (qc *QueryCoord) ReleaseCollection(ctx context.Context, req *querypb.Releas
 		return status, nil
 	}
 
-	baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_grpcRequest)
+	baseTask := newBaseTask(qc.taskCtx(ctx), querypb.TriggerCondition_grpcRequest)
 	releaseCollectionTask := &releaseCollectionTask{
 		baseTask:                 baseTask,
 		ReleaseCollectionRequest: req,
@@ -211,7 +216,8 @@ func (qc *QueryCoord) ReleaseCollection(ctx context.Context, req *querypb.Releas
 
 	err = releaseCollectionTask.waitToFinish()
 	if err != nil {
-		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		status.ErrorCode = failedTaskErrorCode(releaseCollectionTask)
+		status.Detail = releaseCollectionTask.getResultInfo().Detail
 		status.Reason = err.Error()
 		return status, err
 	}
@@ -222,6 +228,132 @@ func (qc *QueryCoord) ReleaseCollection(ctx context.Context, req *querypb.Releas
 	return status, nil
 }
 
+// LoadBalance moves the sealed segments off the source query nodes, the request is rejected before enqueue
+// if the nodes or the segments don't match the cluster and the meta
+func (qc *QueryCoord) LoadBalance(ctx context.Context, req *querypb.LoadBalanceRequest) (*commonpb.Status, error) {
+	log.Debug("LoadBalanceRequest received",
+		zap.String("role", Params.RoleName),
+		zap.Int64("msgID", req.Base.MsgID),
+		zap.Int64s("sourceNodeIDs", req.SourceNodeIDs),
+		zap.Int64s("dstNodeIDs", req.DstNodeIDs),
+		zap.Int64s("sealedSegmentIDs", req.SealedSegmentIDs))
+	status := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}
+	if qc.stateCode.Load() != internalpb.StateCode_Healthy {
+		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		err := errors.New("query coordinator is not healthy")
+		status.Reason = err.Error()
+		log.Debug("load balance end with query coordinator not healthy")
+		return status, err
+	}
+
+	err := checkLoadBalanceRequest(req, qc.cluster, qc.meta)
+	if err != nil {
+		status.ErrorCode = errorCodeOf(err)
+		status.Detail = errorKindOf(err)
+		status.Reason = err.Error()
+		log.Warn("load balance end with illegal request", zap.Int64("msgID", req.Base.MsgID), zap.Error(err))
+		return status, err
+	}
+
+	req.BalanceReason = querypb.TriggerCondition_grpcRequest
+	baseTask := newBaseTask(qc.taskCtx(ctx), querypb.TriggerCondition_grpcRequest)
+	loadBalanceTask := &loadBalanceTask{
+		baseTask:           baseTask,
+		LoadBalanceRequest: req,
+		rootCoord:          qc.rootCoordClient,
+		dataCoord:          qc.dataCoordClient,
+		cluster:            qc.cluster,
+		meta:               qc.meta,
+	}
+	err = qc.scheduler.Enqueue(loadBalanceTask)
+	if err != nil {
+		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		status.Reason = err.Error()
+		return status, err
+	}
+
+	err = loadBalanceTask.waitToFinish()
+	if err != nil {
+		status.ErrorCode = failedTaskErrorCode(loadBalanceTask)
+		status.Detail = loadBalanceTask.getResultInfo().Detail
+		status.Reason = err.Error()
+		return status, err
+	}
+
+	log.Debug("LoadBalanceRequest completed", zap.String("role", Params.RoleName), zap.Int64("msgID", req.Base.MsgID))
+	return status, nil
+}
+
+// ReleaseSegments releases the sealed segments dropped by DataCoord, the segments are removed from the meta and the
+// query channels first so no more requests are routed to them, then released from the query nodes loading them.
+// The segments not loaded are ignored.
+func (qc *QueryCoord) ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error) {
+	log.Debug("ReleaseSegmentsRequest received",
+		zap.String("role", Params.RoleName),
+		zap.Int64("msgID", req.GetBase().GetMsgID()),
+		zap.Int64s("segmentIDs", req.SegmentIDs))
+	status := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}
+	if qc.stateCode.Load() != internalpb.StateCode_Healthy {
+		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		err := errors.New("query coordinator is not healthy")
+		status.Reason = err.Error()
+		log.Debug("release segments end with query coordinator not healthy")
+		return status, err
+	}
+
+	col2Segments := make(map[UniqueID][]*querypb.SegmentInfo)
+	for _, segmentID := range req.SegmentIDs {
+		info, err := qc.meta.getSegmentInfoByID(segmentID)
+		if err != nil {
+			continue
+		}
+		col2Segments[info.CollectionID] = append(col2Segments[info.CollectionID], info)
+	}
+
+	var releaseErr error
+	for collectionID, infos := range col2Segments {
+		segmentIDs := make([]UniqueID, 0, len(infos))
+		for _, info := range infos {
+			segmentIDs = append(segmentIDs, info.SegmentID)
+		}
+		if _, err := qc.meta.removeSealedSegInfosByID(collectionID, segmentIDs); err != nil {
+			status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+			status.Reason = err.Error()
+			log.Warn("release segments end with remove segment infos failed",
+				zap.Int64("collectionID", collectionID), zap.Int64s("segmentIDs", segmentIDs), zap.Error(err))
+			return status, err
+		}
+
+		for _, info := range infos {
+			err := qc.cluster.releaseSegments(ctx, info.NodeID, &querypb.ReleaseSegmentsRequest{
+				Base:         req.Base,
+				NodeID:       info.NodeID,
+				CollectionID: info.CollectionID,
+				PartitionIDs: []UniqueID{info.PartitionID},
+				SegmentIDs:   []UniqueID{info.SegmentID},
+			})
+			if err != nil {
+				// the segment is no longer searched, the memory is freed when the node goes offline at the latest
+				log.Warn("release segments failed to release segment from query node",
+					zap.Int64("segmentID", info.SegmentID), zap.Int64("nodeID", info.NodeID), zap.Error(err))
+				releaseErr = err
+			}
+		}
+	}
+	if releaseErr != nil {
+		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		status.Reason = releaseErr.Error()
+		return status, releaseErr
+	}
+
+	log.Debug("ReleaseSegmentsRequest completed", zap.String("role", Params.RoleName), zap.Int64("msgID", req.GetBase().GetMsgID()))
+	return status, nil
+}
+
 // ShowPartitions return all the partitions that have been loaded
 func (qc *QueryCoord) ShowPartitions(ctx context.Context, req *querypb.ShowPartitionsRequest) (*querypb.ShowPartitionsResponse, error) {
 	collectionID := req.CollectionID
@@ -240,6 +372,10 @@ func (qc *QueryCoord) ShowPartitions(ctx context.Context, req *querypb.ShowParti
 	}
 
 	partitionStates, err := qc.meta.showPartitions(collectionID)
+	if err != nil && len(req.PartitionIDs) > 0 {
+		// the partitions of a collection not loaded are reported with 0 percentage
+		partitionStates, err = nil, nil
+	}
 	if err != nil {
 		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
 		status.Reason = err.Error()
@@ -265,16 +401,9 @@ func (qc *QueryCoord) ShowPartitions(ctx context.Context, req *querypb.ShowParti
 			InMemoryPercentages: inMemoryPercentages,
 		}, nil
 	}
+	// the partitions not loaded are reported with 0 percentage
 	for _, id := range req.PartitionIDs {
-		if _, ok := ID2PartitionState[id]; !ok {
-			status.ErrorCode = commonpb.ErrorCode_UnexpectedError
-			err := errors.New("partition has not been loaded to memory or load failed")
-			status.Reason = err.Error()
-			return &querypb.ShowPartitionsResponse{
-				Status: status,
-			}, err
-		}
-		inMemoryPercentages = append(inMemoryPercentages, ID2PartitionState[id].InMemoryPercentage)
+		inMemoryPercentages = append(inMemoryPercentages, ID2PartitionState[id].GetInMemoryPercentage())
 	}
 
 	log.Debug("show partitions end", zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", req.PartitionIDs), zap.Int64s("inMemoryPercentage", inMemoryPercentages))
@@ -337,11 +466,13 @@ func (qc *QueryCoord) LoadPartitions(ctx context.Context, req *querypb.LoadParti
 		req.PartitionIDs = partitionIDsToLoad
 	}
 
-	baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_grpcRequest)
+	baseTask := newBaseTask(qc.taskCtx(ctx), querypb.TriggerCondition_grpcRequest)
 	loadPartitionTask := &loadPartitionTask{
 		baseTask:              baseTask,
 		LoadPartitionsRequest: req,
+		rootCoord:             qc.rootCoordClient,
 		dataCoord:             qc.dataCoordClient,
+		indexCoord:            qc.indexCoordClient,
 		cluster:               qc.cluster,
 		meta:                  qc.meta,
 	}
@@ -354,7 +485,8 @@ func (qc *QueryCoord) LoadPartitions(ctx context.Context, req *querypb.LoadParti
 
 	err = loadPartitionTask.waitToFinish()
 	if err != nil {
-		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		status.ErrorCode = failedTaskErrorCode(loadPartitionTask)
+		status.Detail = loadPartitionTask.getResultInfo().Detail
 		status.Reason = err.Error()
 		log.Debug("LoadPartitionRequest completed", zap.String("role", Params.RoleName), zap.Int64("msgID", req.Base.MsgID), zap.Int64("collectionID", req.CollectionID))
 		return status, err
@@ -408,7 +540,7 @@ func (qc *QueryCoord) ReleasePartitions(ctx context.Context, req *querypb.Releas
 	}
 
 	req.PartitionIDs = toReleasedPartitions
-	baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_grpcRequest)
+	baseTask := newBaseTask(qc.taskCtx(ctx), querypb.TriggerCondition_grpcRequest)
 	releasePartitionTask := &releasePartitionTask{
 		baseTask:                 baseTask,
 		ReleasePartitionsRequest: req,
@@ -423,7 +555,8 @@ func (qc *QueryCoord) ReleasePartitions(ctx context.Context, req *querypb.Releas
 
 	err = releasePartitionTask.waitToFinish()
 	if err != nil {
-		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		status.ErrorCode = failedTaskErrorCode(releasePartitionTask)
+		status.Detail = releasePartitionTask.getResultInfo().Detail
 		status.Reason = err.Error()
 		return status, err
 	}
@@ -549,6 +682,38 @@ func (qc *QueryCoord) isHealthy() bool {
 	return code == internalpb.StateCode_Healthy
 }
 
+// GetShardLeaders returns the shard leaders of a loaded collection, the proxy searches the shards through them directly
+func (qc *QueryCoord) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error) {
+	status := &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+	}
+	if qc.stateCode.Load() != internalpb.StateCode_Healthy {
+		status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		err := errors.New("query coordinator is not healthy")
+		status.Reason = err.Error()
+		log.Debug("getShardLeaders end with query coordinator not healthy")
+		return &querypb.GetShardLeadersResponse{
+			Status: status,
+		}, err
+	}
+
+	shards, err := getShardLeaders(qc.meta, qc.cluster, req.CollectionID)
+	if err != nil {
+		status.ErrorCode = errorCodeOf(err)
+		status.Detail = errorKindOf(err)
+		status.Reason = err.Error()
+		log.Debug("getShardLeaders end with error", zap.Int64("collectionID", req.CollectionID), zap.Error(err))
+		return &querypb.GetShardLeadersResponse{
+			Status: status,
+		}, err
+	}
+
+	return &querypb.GetShardLeadersResponse{
+		Status: status,
+		Shards: shards,
+	}, nil
+}
+
 // GetMetrics returns all the queryCoord's metrics
 func (qc *QueryCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	log.Debug("QueryCoord.GetMetrics",
@@ -610,6 +775,54 @@ func (qc *QueryCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRe
 
 		return metrics, err
 	}
+
+	if metricType == metricsinfo.SegmentStatisticsMetrics {
+		metrics, err := getSegmentStatisticsMetrics(ctx, req, qc)
+
+		log.Debug("QueryCoord.GetMetrics",
+			zap.Int64("node_id", Params.QueryCoordID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Error(err))
+
+		return metrics, err
+	}
+	if metricType == metricsinfo.TaskInfoMetrics {
+		metrics := getTaskInfoMetrics(qc)
+
+		log.Debug("QueryCoord.GetMetrics",
+			zap.Int64("node_id", Params.QueryCoordID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType))
+
+		return metrics, nil
+	}
+
+	if metricType == metricsinfo.UpdateConfigMetrics {
+		metrics := qc.configUpdater.UpdateByMetricsRequest(req,
+			metricsinfo.ConstructComponentName(typeutil.QueryCoordRole, Params.QueryCoordID))
+
+		log.Debug("QueryCoord.GetMetrics",
+			zap.Int64("node_id", Params.QueryCoordID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		metrics := setLogLevel(ctx, req, qc)
+
+		log.Debug("QueryCoord.GetMetrics",
+			zap.Int64("node_id", Params.QueryCoordID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	err = errors.New(metricsinfo.MsgUnimplementedMetric)
 	log.Debug("QueryCoord.GetMetrics failed",
 		zap.Int64("node_id", Params.QueryCoordID),
@@ -625,3 +838,12 @@ func (qc *QueryCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRe
 		Response: "",
 	}, err
 }
+
+// taskCtx returns the context of the tasks triggered by a request, the tasks outlive the request,
+// but carry its span so that the task spans and logs join the trace of the request
+func (qc *QueryCoord) taskCtx(ctx context.Context) context.Context {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		return opentracing.ContextWithSpan(qc.loopCtx, span)
+	}
+	return qc.loopCtx
+}
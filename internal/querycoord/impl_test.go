@@ -45,8 +45,9 @@ func TestGrpcTask(t *testing.T) {
 			CollectionID: defaultCollectionID,
 			PartitionIDs: []UniqueID{defaultPartitionID},
 		})
-		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, res.Status.ErrorCode)
-		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{0}, res.InMemoryPercentages)
 	})
 
 	t.Run("Test LoadEmptyPartition", func(t *testing.T) {
@@ -94,8 +95,9 @@ func TestGrpcTask(t *testing.T) {
 			CollectionID: defaultCollectionID,
 			PartitionIDs: []UniqueID{-1},
 		})
-		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, res.Status.ErrorCode)
-		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{0}, res.InMemoryPercentages)
 	})
 
 	t.Run("Test ShowAllPartitions", func(t *testing.T) {
@@ -164,8 +166,9 @@ func TestGrpcTask(t *testing.T) {
 			},
 			CollectionIDs: []UniqueID{-1},
 		})
-		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, res.Status.ErrorCode)
-		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{0}, res.InMemoryPercentages)
 	})
 
 	t.Run("Test ShowAllCollections", func(t *testing.T) {
@@ -297,6 +300,19 @@ func TestGrpcTask(t *testing.T) {
 		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
 	})
 
+	t.Run("Test GetTaskInfoMetrics", func(t *testing.T) {
+		req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.TaskInfoMetrics)
+		assert.Nil(t, err)
+		res, err := queryCoord.GetMetrics(ctx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
+
+		infos := metricsinfo.TaskInfos{}
+		err = metricsinfo.UnmarshalComponentInfos(res.Response, &infos)
+		assert.Nil(t, err)
+		assert.Equal(t, res.ComponentName, infos.Name)
+	})
+
 	t.Run("Test InvalidMetricType", func(t *testing.T) {
 		metricReq := make(map[string]string)
 		metricReq["invalidKey"] = "invalidValue"
@@ -483,6 +499,198 @@ func TestLoadBalanceTask(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGrpcLoadBalance(t *testing.T) {
+	refreshParams()
+	baseCtx := context.Background()
+
+	queryCoord, err := startQueryCoord(baseCtx)
+	assert.Nil(t, err)
+
+	queryNode1, err := startQueryNodeServer(baseCtx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, queryNode1.queryNodeID)
+
+	res, err := queryCoord.LoadCollection(baseCtx, &querypb.LoadCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadCollection,
+		},
+		CollectionID: defaultCollectionID,
+		Schema:       genCollectionSchema(defaultCollectionID, false),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, res.ErrorCode)
+	for {
+		collectionInfo := queryCoord.meta.showCollections()
+		if collectionInfo[0].InMemoryPercentage == 100 {
+			break
+		}
+	}
+
+	queryNode2, err := startQueryNodeServer(baseCtx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, queryNode2.queryNodeID)
+
+	segmentInfos := queryCoord.meta.getSegmentInfosByNode(queryNode1.queryNodeID)
+	assert.Equal(t, defaultChannelNum, len(segmentInfos))
+	segmentIDs := make([]UniqueID, 0)
+	for _, info := range segmentInfos {
+		segmentIDs = append(segmentIDs, info.SegmentID)
+	}
+
+	genLoadBalanceRequest := func(sourceNodeID int64, dstNodeIDs []int64, segmentIDs []UniqueID) *querypb.LoadBalanceRequest {
+		return &querypb.LoadBalanceRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadBalanceSegments,
+			},
+			SourceNodeIDs:    []int64{sourceNodeID},
+			DstNodeIDs:       dstNodeIDs,
+			SealedSegmentIDs: segmentIDs,
+		}
+	}
+
+	t.Run("Test segment not on source", func(t *testing.T) {
+		status, err := queryCoord.LoadBalance(baseCtx, genLoadBalanceRequest(queryNode2.queryNodeID, []int64{queryNode1.queryNodeID}, segmentIDs))
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, status.ErrorCode)
+		assert.Equal(t, errKindSegmentNotFound, status.Detail)
+	})
+
+	t.Run("Test unknown segment", func(t *testing.T) {
+		status, err := queryCoord.LoadBalance(baseCtx, genLoadBalanceRequest(queryNode1.queryNodeID, nil, []UniqueID{-1}))
+		assert.NotNil(t, err)
+		assert.Equal(t, errKindSegmentNotFound, status.Detail)
+	})
+
+	t.Run("Test destination offline", func(t *testing.T) {
+		status, err := queryCoord.LoadBalance(baseCtx, genLoadBalanceRequest(queryNode1.queryNodeID, []int64{-1}, segmentIDs))
+		assert.NotNil(t, err)
+		assert.Equal(t, errKindNodeOffline, status.Detail)
+	})
+
+	t.Run("Test destination is source", func(t *testing.T) {
+		status, err := queryCoord.LoadBalance(baseCtx, genLoadBalanceRequest(queryNode1.queryNodeID, []int64{queryNode1.queryNodeID}, segmentIDs))
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, status.ErrorCode)
+	})
+
+	t.Run("Test move named segments", func(t *testing.T) {
+		status, err := queryCoord.LoadBalance(baseCtx, genLoadBalanceRequest(queryNode1.queryNodeID, []int64{queryNode2.queryNodeID}, segmentIDs))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+
+		for _, segmentID := range segmentIDs {
+			info, err := queryCoord.meta.getSegmentInfoByID(segmentID)
+			assert.Nil(t, err)
+			assert.Equal(t, queryNode2.queryNodeID, info.NodeID)
+		}
+		assert.Equal(t, 0, len(queryCoord.meta.getSegmentInfosByNode(queryNode1.queryNodeID)))
+		online, err := queryCoord.cluster.isOnline(queryNode1.queryNodeID)
+		assert.Nil(t, err)
+		assert.True(t, online)
+
+		// the binlogs are fetched from the source node since it's online
+		queryNode2.segmentMu.Lock()
+		defer queryNode2.segmentMu.Unlock()
+		assert.NotEqual(t, 0, len(queryNode2.loadSegmentsReqs))
+		for _, req := range queryNode2.loadSegmentsReqs {
+			assert.Equal(t, queryNode1.queryNodeID, req.SourceNodeID)
+			assert.Equal(t, queryNode1.session.Address, req.SourceAddress)
+		}
+	})
+
+	t.Run("Test no segment on source", func(t *testing.T) {
+		status, err := queryCoord.LoadBalance(baseCtx, genLoadBalanceRequest(queryNode1.queryNodeID, nil, nil))
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, status.ErrorCode)
+	})
+
+	queryNode1.stop()
+	queryNode2.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
+func TestGrpcReleaseSegments(t *testing.T) {
+	refreshParams()
+	baseCtx := context.Background()
+
+	queryCoord, err := startQueryCoord(baseCtx)
+	assert.Nil(t, err)
+
+	queryNode, err := startQueryNodeServer(baseCtx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, queryNode.queryNodeID)
+
+	res, err := queryCoord.LoadCollection(baseCtx, &querypb.LoadCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadCollection,
+		},
+		CollectionID: defaultCollectionID,
+		Schema:       genCollectionSchema(defaultCollectionID, false),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, res.ErrorCode)
+	for {
+		collectionInfo := queryCoord.meta.showCollections()
+		if collectionInfo[0].InMemoryPercentage == 100 {
+			break
+		}
+	}
+
+	segmentInfos := queryCoord.meta.getSegmentInfosByNode(queryNode.queryNodeID)
+	assert.Equal(t, defaultChannelNum, len(segmentInfos))
+	droppedID, keptID := segmentInfos[0].SegmentID, segmentInfos[1].SegmentID
+
+	genReleaseSegmentsRequest := func(segmentIDs ...UniqueID) *querypb.ReleaseSegmentsRequest {
+		return &querypb.ReleaseSegmentsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_ReleaseSegments,
+			},
+			SegmentIDs: segmentIDs,
+		}
+	}
+
+	t.Run("Test segment not loaded", func(t *testing.T) {
+		status, err := queryCoord.ReleaseSegments(baseCtx, genReleaseSegmentsRequest(-1))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		assert.Equal(t, defaultChannelNum, len(queryCoord.meta.getSegmentInfosByNode(queryNode.queryNodeID)))
+	})
+
+	t.Run("Test release dropped segment", func(t *testing.T) {
+		status, err := queryCoord.ReleaseSegments(baseCtx, genReleaseSegmentsRequest(droppedID, -1))
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+
+		_, err = queryCoord.meta.getSegmentInfoByID(droppedID)
+		assert.NotNil(t, err)
+		_, err = queryCoord.meta.getSegmentInfoByID(keptID)
+		assert.Nil(t, err)
+		queryChannelInfo, err := queryCoord.meta.getQueryChannelInfoByID(defaultCollectionID)
+		assert.Nil(t, err)
+		for _, info := range queryChannelInfo.GlobalSealedSegments {
+			assert.NotEqual(t, droppedID, info.SegmentID)
+		}
+	})
+
+	t.Run("Test release from query node failed", func(t *testing.T) {
+		queryNode.releaseSegments = returnFailedResult
+		status, err := queryCoord.ReleaseSegments(baseCtx, genReleaseSegmentsRequest(keptID))
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.ErrorCode)
+		// the segment is no longer routed to even if the query node failed to release it
+		_, err = queryCoord.meta.getSegmentInfoByID(keptID)
+		assert.NotNil(t, err)
+		queryNode.releaseSegments = returnSuccessResult
+	})
+
+	queryNode.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
 func TestGrpcTaskBeforeHealthy(t *testing.T) {
 	refreshParams()
 	ctx := context.Background()
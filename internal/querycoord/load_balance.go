@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// checkLoadBalanceRequest rejects a balance requested by users before it is enqueued,
+// the source and destination nodes must be online and the segments must be on the source nodes
+func checkLoadBalanceRequest(req *querypb.LoadBalanceRequest, cluster Cluster, meta Meta) error {
+	if len(req.SourceNodeIDs) == 0 {
+		return errIllegalLoadBalance("no source node to balance")
+	}
+	sourceNodes := make(map[int64]struct{})
+	for _, nodeID := range req.SourceNodeIDs {
+		online, err := cluster.isOnline(nodeID)
+		if err != nil || !online {
+			return errQueryNodeIsNotOnService(nodeID)
+		}
+		sourceNodes[nodeID] = struct{}{}
+	}
+
+	for _, nodeID := range req.DstNodeIDs {
+		if _, ok := sourceNodes[nodeID]; ok {
+			return errIllegalLoadBalance(fmt.Sprintf("query node %d is both the source and the destination", nodeID))
+		}
+		online, err := cluster.isOnline(nodeID)
+		if err != nil || !online {
+			return errQueryNodeIsNotOnService(nodeID)
+		}
+	}
+	if len(req.DstNodeIDs) == 0 {
+		onlineNodes, err := cluster.onlineNodes()
+		if err != nil {
+			return errNoAvailableQueryNode()
+		}
+		for nodeID := range sourceNodes {
+			delete(onlineNodes, nodeID)
+		}
+		if len(onlineNodes) == 0 {
+			return errNoAvailableQueryNode()
+		}
+	}
+
+	for _, segmentID := range req.SealedSegmentIDs {
+		info, err := meta.getSegmentInfoByID(segmentID)
+		if err != nil {
+			return errSegmentNotFound(segmentID, req.SourceNodeIDs)
+		}
+		if _, ok := sourceNodes[info.NodeID]; !ok {
+			return errSegmentNotFound(segmentID, req.SourceNodeIDs)
+		}
+	}
+	if len(req.SealedSegmentIDs) == 0 && len(balancedSegmentInfos(req, meta)) == 0 {
+		return errIllegalLoadBalance(fmt.Sprintf("no sealed segment on query nodes %v", req.SourceNodeIDs))
+	}
+	return nil
+}
+
+// balancedSegmentInfos returns the segments to move, all the segments on the source nodes if the request doesn't name them
+func balancedSegmentInfos(req *querypb.LoadBalanceRequest, meta Meta) []*querypb.SegmentInfo {
+	sourceNodes := make(map[int64]struct{})
+	for _, nodeID := range req.SourceNodeIDs {
+		sourceNodes[nodeID] = struct{}{}
+	}
+
+	segmentInfos := make([]*querypb.SegmentInfo, 0)
+	if len(req.SealedSegmentIDs) == 0 {
+		for nodeID := range sourceNodes {
+			segmentInfos = append(segmentInfos, meta.getSegmentInfosByNode(nodeID)...)
+		}
+		return segmentInfos
+	}
+	for _, segmentID := range req.SealedSegmentIDs {
+		info, err := meta.getSegmentInfoByID(segmentID)
+		if err != nil {
+			// released or compacted after the request is checked
+			continue
+		}
+		if _, ok := sourceNodes[info.NodeID]; ok {
+			segmentInfos = append(segmentInfos, info)
+		}
+	}
+	return segmentInfos
+}
+
+// balanceExcludeNodeIDs returns the nodes the segments must not be moved to,
+// the source nodes and the online nodes other than the destination nodes if the request names them
+func (lbt *loadBalanceTask) balanceExcludeNodeIDs() ([]int64, error) {
+	excludeNodeIDs := append([]int64{}, lbt.SourceNodeIDs...)
+	if len(lbt.DstNodeIDs) == 0 {
+		return excludeNodeIDs, nil
+	}
+
+	onlineNodes, err := lbt.cluster.onlineNodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeID := range lbt.DstNodeIDs {
+		delete(onlineNodes, nodeID)
+	}
+	for nodeID := range onlineNodes {
+		excludeNodeIDs = append(excludeNodeIDs, nodeID)
+	}
+	return excludeNodeIDs, nil
+}
+
+// sourceNodeAddress returns the address of the source node of the balanced segments if it's online, so that the
+// destination node fetches the binlogs cached on it instead of downloading them from the storage
+func sourceNodeAddress(cluster Cluster, nodeID int64) string {
+	online, err := cluster.isOnline(nodeID)
+	if err != nil || !online {
+		return ""
+	}
+	node, err := cluster.getNodeByID(nodeID)
+	if err != nil {
+		return ""
+	}
+	return node.getAddress()
+}
+
+// balanceSealedSegments assigns the load segment tasks moving the sealed segments off the source nodes,
+// the source nodes release their copies once the meta is updated with the new copies
+func (lbt *loadBalanceTask) balanceSealedSegments(ctx context.Context) error {
+	excludeNodeIDs, err := lbt.balanceExcludeNodeIDs()
+	if err != nil {
+		return err
+	}
+
+	col2PartitionIDs := make(map[UniqueID][]UniqueID)
+	par2Segments := make(map[UniqueID][]*querypb.SegmentInfo)
+	for _, info := range balancedSegmentInfos(lbt.LoadBalanceRequest, lbt.meta) {
+		if _, ok := par2Segments[info.PartitionID]; !ok {
+			col2PartitionIDs[info.CollectionID] = append(col2PartitionIDs[info.CollectionID], info.PartitionID)
+		}
+		par2Segments[info.PartitionID] = append(par2Segments[info.PartitionID], info)
+	}
+	if len(par2Segments) == 0 {
+		return errors.New("loadBalanceTask: no sealed segment to balance")
+	}
+
+	sourceAddresses := make(map[int64]string)
+	for _, nodeID := range lbt.SourceNodeIDs {
+		sourceAddresses[nodeID] = sourceNodeAddress(lbt.cluster, nodeID)
+	}
+
+	for collectionID, partitionIDs := range col2PartitionIDs {
+		collectionInfo, err := lbt.meta.getCollectionInfoByID(collectionID)
+		if err != nil {
+			log.Warn("loadBalanceTask: getCollectionInfoByID occur error", zap.Int64("collectionID", collectionID), zap.Error(err))
+			return err
+		}
+
+		loadSegmentReqs := make([]*querypb.LoadSegmentsRequest, 0)
+		for _, partitionID := range partitionIDs {
+			getRecoveryInfo := &datapb.GetRecoveryInfoRequest{
+				Base: &commonpb.MsgBase{
+					MsgType: commonpb.MsgType_LoadBalanceSegments,
+				},
+				CollectionID: collectionID,
+				PartitionID:  partitionID,
+			}
+			recoveryInfo, err := lbt.dataCoord.GetRecoveryInfo(ctx, getRecoveryInfo)
+			if err != nil {
+				return err
+			}
+			segmentID2Binlog := make(map[UniqueID]*datapb.SegmentBinlogs)
+			for _, segmentBinlog := range recoveryInfo.Binlogs {
+				segmentID2Binlog[segmentBinlog.SegmentID] = segmentBinlog
+			}
+
+			for _, info := range par2Segments[partitionID] {
+				segmentBinlog, ok := segmentID2Binlog[info.SegmentID]
+				if !ok {
+					log.Warn("loadBalanceTask: can't find binlog of segment to balance, may be compacted", zap.Int64("segmentID", info.SegmentID))
+					continue
+				}
+				segmentLoadInfo := &querypb.SegmentLoadInfo{
+					SegmentID:    info.SegmentID,
+					PartitionID:  partitionID,
+					CollectionID: collectionID,
+					BinlogPaths:  segmentBinlog.FieldBinlogs,
+					NumOfRows:    segmentBinlog.NumOfRows,
+					Statslogs:    segmentBinlog.Statslogs,
+					Deltalogs:    segmentBinlog.Deltalogs,
+				}
+
+				msgBase := proto.Clone(lbt.Base).(*commonpb.MsgBase)
+				msgBase.MsgType = commonpb.MsgType_LoadSegments
+				loadSegmentReq := &querypb.LoadSegmentsRequest{
+					Base:          msgBase,
+					Infos:         []*querypb.SegmentLoadInfo{segmentLoadInfo},
+					Schema:        collectionInfo.Schema,
+					LoadCondition: querypb.TriggerCondition_grpcRequest,
+					SourceNodeID:  info.NodeID,
+					SourceAddress: sourceAddresses[info.NodeID],
+				}
+				loadSegmentReqs = append(loadSegmentReqs, loadSegmentReq)
+			}
+		}
+
+		err = assignInternalTask(ctx, collectionID, lbt, lbt.meta, lbt.cluster, loadSegmentReqs, nil, false, excludeNodeIDs)
+		if err != nil {
+			log.Warn("loadBalanceTask: assign child task failed", zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", partitionIDs))
+			return err
+		}
+		log.Debug("loadBalanceTask: assign child task done", zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", partitionIDs))
+	}
+	return nil
+}
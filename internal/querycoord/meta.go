@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/mqclient"
 )
 
@@ -46,6 +47,7 @@ type col2SealedSegmentChangeInfos = map[UniqueID]*querypb.SealedSegmentsChangeIn
 type Meta interface {
 	reloadFromKV() error
 	setKvClient(kv kv.MetaKv)
+	getKvStats() kvStats
 
 	showCollections() []*querypb.CollectionInfo
 	hasCollection(collectionID UniqueID) bool
@@ -63,6 +65,7 @@ type Meta interface {
 	setSegmentInfos(segmentInfos map[UniqueID]*querypb.SegmentInfo) error
 	showSegmentInfos(collectionID UniqueID, partitionIDs []UniqueID) []*querypb.SegmentInfo
 	getSegmentInfoByID(segmentID UniqueID) (*querypb.SegmentInfo, error)
+	getSegmentInfosByNode(nodeID int64) []*querypb.SegmentInfo
 
 	getPartitionStatesByID(collectionID UniqueID, partitionID UniqueID) (*querypb.PartitionStates, error)
 
@@ -79,6 +82,7 @@ type Meta interface {
 	//printMeta()
 	saveGlobalSealedSegInfos(saves col2SegmentInfos) (col2SealedSegmentChangeInfos, error)
 	removeGlobalSealedSegInfos(collectionID UniqueID, partitionIDs []UniqueID) (col2SealedSegmentChangeInfos, error)
+	removeSealedSegInfosByID(collectionID UniqueID, segmentIDs []UniqueID) (col2SealedSegmentChangeInfos, error)
 	sendSealedSegmentChangeInfos(collectionID UniqueID, changeInfos *querypb.SealedSegmentsChangeInfo) (*querypb.QueryChannelInfo, map[string][]mqclient.MessageID, error)
 }
 
@@ -90,10 +94,11 @@ type MetaReplica struct {
 	msFactory   msgstream.Factory
 	idAllocator func() (UniqueID, error)
 
-	//sync.RWMutex
-	collectionInfos   map[UniqueID]*querypb.CollectionInfo
+	// collectionMu and segmentMu only guard the maps, the meta of each collection is guarded by its own locks
+	collections       map[UniqueID]*collectionMeta
 	collectionMu      sync.RWMutex
-	segmentInfos      map[UniqueID]*querypb.SegmentInfo
+	segments          map[UniqueID]*segmentShard // collectionID -> segment infos of the collection
+	segmentIndex      map[UniqueID]UniqueID      // segmentID -> collectionID
 	segmentMu         sync.RWMutex
 	queryChannelInfos map[UniqueID]*querypb.QueryChannelInfo
 	channelMu         sync.RWMutex
@@ -103,25 +108,64 @@ type MetaReplica struct {
 	//partitionStates map[UniqueID]*querypb.PartitionStates
 }
 
-func newMeta(ctx context.Context, kv kv.MetaKv, factory msgstream.Factory, idAllocator func() (UniqueID, error)) (Meta, error) {
-	childCtx, cancel := context.WithCancel(ctx)
-	collectionInfos := make(map[UniqueID]*querypb.CollectionInfo)
-	segmentInfos := make(map[UniqueID]*querypb.SegmentInfo)
-	queryChannelInfos := make(map[UniqueID]*querypb.QueryChannelInfo)
-	queryMsgStream := make(map[UniqueID]msgstream.MsgStream)
+// collectionMeta holds the info of one loaded collection, including its dm channels.
+// The info is never modified in place: an update persists a modified copy to etcd and then swaps it in,
+// so readers only hold mu for a pointer read and updates of other collections never wait on it
+type collectionMeta struct {
+	updateMu sync.Mutex // serializes the read-modify-persist updates, held while writing etcd
+	mu       sync.RWMutex
+	info     *querypb.CollectionInfo
+	released bool
+}
+
+func newCollectionMeta(info *querypb.CollectionInfo) *collectionMeta {
+	return &collectionMeta{
+		info: info,
+	}
+}
 
-	m := &MetaReplica{
-		ctx:         childCtx,
-		cancel:      cancel,
-		client:      kv,
-		msFactory:   factory,
-		idAllocator: idAllocator,
+// snapshot returns the current info, which must not be modified by the caller
+func (c *collectionMeta) snapshot() *querypb.CollectionInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.info
+}
+
+func (c *collectionMeta) set(info *querypb.CollectionInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.info = info
+}
+
+// segmentShard holds the segment infos of one collection, the infos are never modified in place
+type segmentShard struct {
+	mu       sync.RWMutex
+	segments map[UniqueID]*querypb.SegmentInfo
+}
+
+func newSegmentShard() *segmentShard {
+	return &segmentShard{
+		segments: make(map[UniqueID]*querypb.SegmentInfo),
+	}
+}
 
-		collectionInfos:   collectionInfos,
-		segmentInfos:      segmentInfos,
-		queryChannelInfos: queryChannelInfos,
-		queryStreams:      queryMsgStream,
+// snapshot returns the segment infos of the shard, which must not be modified by the caller
+func (s *segmentShard) snapshot() []*querypb.SegmentInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	infos := make([]*querypb.SegmentInfo, 0, len(s.segments))
+	for _, info := range s.segments {
+		infos = append(infos, info)
 	}
+	return infos
+}
+
+func newMeta(ctx context.Context, kv kv.MetaKv, factory msgstream.Factory, idAllocator func() (UniqueID, error)) (Meta, error) {
+	childCtx, cancel := context.WithCancel(ctx)
+	m := newMetaReplica(childCtx, kv)
+	m.cancel = cancel
+	m.msFactory = factory
+	m.idAllocator = idAllocator
 
 	err := m.reloadFromKV()
 	if err != nil {
@@ -131,6 +175,19 @@ func newMeta(ctx context.Context, kv kv.MetaKv, factory msgstream.Factory, idAll
 	return m, nil
 }
 
+func newMetaReplica(ctx context.Context, kv kv.MetaKv) *MetaReplica {
+	return &MetaReplica{
+		ctx:    ctx,
+		client: newMonitoredKv(kv),
+
+		collections:       make(map[UniqueID]*collectionMeta),
+		segments:          make(map[UniqueID]*segmentShard),
+		segmentIndex:      make(map[UniqueID]UniqueID),
+		queryChannelInfos: make(map[UniqueID]*querypb.QueryChannelInfo),
+		queryStreams:      make(map[UniqueID]msgstream.MsgStream),
+	}
+}
+
 func (m *MetaReplica) reloadFromKV() error {
 	collectionKeys, collectionValues, err := m.client.LoadWithPrefix(collectionMetaPrefix)
 	if err != nil {
@@ -146,13 +203,14 @@ func (m *MetaReplica) reloadFromKV() error {
 		if err != nil {
 			return err
 		}
-		m.collectionInfos[collectionID] = collectionInfo
+		m.collections[collectionID] = newCollectionMeta(collectionInfo)
 	}
 
 	segmentKeys, segmentValues, err := m.client.LoadWithPrefix(segmentMetaPrefix)
 	if err != nil {
 		return err
 	}
+	segmentInfos := make(map[UniqueID]*querypb.SegmentInfo)
 	for index := range segmentKeys {
 		segmentID, err := strconv.ParseInt(filepath.Base(segmentKeys[index]), 10, 64)
 		if err != nil {
@@ -163,12 +221,13 @@ func (m *MetaReplica) reloadFromKV() error {
 		if err != nil {
 			return err
 		}
-		m.segmentInfos[segmentID] = segmentInfo
+		segmentInfos[segmentID] = segmentInfo
 	}
+	m.putSegmentInfos(segmentInfos)
 
 	queryChannelKeys, queryChannelValues, err := m.client.LoadWithPrefix(queryChannelMetaPrefix)
 	if err != nil {
-		return nil
+		return err
 	}
 	for index := range queryChannelKeys {
 		collectionID, err := strconv.ParseInt(filepath.Base(queryChannelKeys[index]), 10, 64)
@@ -180,6 +239,8 @@ func (m *MetaReplica) reloadFromKV() error {
 		if err != nil {
 			return err
 		}
+		// channel infos written by older versions were saved before the collectionID was set
+		queryChannelInfo.CollectionID = collectionID
 		m.queryChannelInfos[collectionID] = queryChannelInfo
 	}
 	//TODO::update partition states
@@ -188,28 +249,72 @@ func (m *MetaReplica) reloadFromKV() error {
 }
 
 func (m *MetaReplica) setKvClient(kv kv.MetaKv) {
-	m.client = kv
+	m.client = newMonitoredKv(kv)
 }
 
-func (m *MetaReplica) showCollections() []*querypb.CollectionInfo {
+func (m *MetaReplica) getKvStats() kvStats {
+	if mkv, ok := m.client.(*monitoredKv); ok {
+		return mkv.getStats()
+	}
+	return kvStats{}
+}
+
+func (m *MetaReplica) getCollectionMeta(collectionID UniqueID) (*collectionMeta, bool) {
 	m.collectionMu.RLock()
 	defer m.collectionMu.RUnlock()
+	c, ok := m.collections[collectionID]
+	return c, ok
+}
 
-	collections := make([]*querypb.CollectionInfo, 0)
-	for _, info := range m.collectionInfos {
-		collections = append(collections, proto.Clone(info).(*querypb.CollectionInfo))
+// updateCollectionInfo applies update to a copy of the collection info, persists the copy if update asks to
+// and makes it visible afterwards. Updates of one collection are serialized, updates of different collections run in parallel.
+// It returns false if the collection is not loaded
+func (m *MetaReplica) updateCollectionInfo(collectionID UniqueID, update func(info *querypb.CollectionInfo) (bool, error)) (bool, error) {
+	c, ok := m.getCollectionMeta(collectionID)
+	if !ok {
+		return false, nil
 	}
-	return collections
+
+	c.updateMu.Lock()
+	defer c.updateMu.Unlock()
+	if c.released {
+		return false, nil
+	}
+
+	info := proto.Clone(c.snapshot()).(*querypb.CollectionInfo)
+	save, err := update(info)
+	if err != nil || !save {
+		return true, err
+	}
+	err = saveGlobalCollectionInfo(collectionID, info, m.client)
+	if err != nil {
+		log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
+		return true, err
+	}
+	c.set(info)
+	return true, nil
 }
 
-func (m *MetaReplica) showPartitions(collectionID UniqueID) ([]*querypb.PartitionStates, error) {
+func (m *MetaReplica) showCollections() []*querypb.CollectionInfo {
 	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
+	metas := make([]*collectionMeta, 0, len(m.collections))
+	for _, c := range m.collections {
+		metas = append(metas, c)
+	}
+	m.collectionMu.RUnlock()
 
+	collections := make([]*querypb.CollectionInfo, 0, len(metas))
+	for _, c := range metas {
+		collections = append(collections, proto.Clone(c.snapshot()).(*querypb.CollectionInfo))
+	}
+	return collections
+}
+
+func (m *MetaReplica) showPartitions(collectionID UniqueID) ([]*querypb.PartitionStates, error) {
 	//TODO::should update after load collection
 	results := make([]*querypb.PartitionStates, 0)
-	if info, ok := m.collectionInfos[collectionID]; ok {
-		for _, state := range info.PartitionStates {
+	if c, ok := m.getCollectionMeta(collectionID); ok {
+		for _, state := range c.snapshot().PartitionStates {
 			results = append(results, proto.Clone(state).(*querypb.PartitionStates))
 		}
 		return results, nil
@@ -219,22 +324,13 @@ func (m *MetaReplica) showPartitions(collectionID UniqueID) ([]*querypb.Partitio
 }
 
 func (m *MetaReplica) hasCollection(collectionID UniqueID) bool {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
-
-	if _, ok := m.collectionInfos[collectionID]; ok {
-		return true
-	}
-
-	return false
+	_, ok := m.getCollectionMeta(collectionID)
+	return ok
 }
 
 func (m *MetaReplica) hasPartition(collectionID UniqueID, partitionID UniqueID) bool {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
-
-	if info, ok := m.collectionInfos[collectionID]; ok {
-		for _, id := range info.PartitionIDs {
+	if c, ok := m.getCollectionMeta(collectionID); ok {
+		for _, id := range c.snapshot().PartitionIDs {
 			if partitionID == id {
 				return true
 			}
@@ -245,11 +341,8 @@ func (m *MetaReplica) hasPartition(collectionID UniqueID, partitionID UniqueID)
 }
 
 func (m *MetaReplica) hasReleasePartition(collectionID UniqueID, partitionID UniqueID) bool {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
-
-	if info, ok := m.collectionInfos[collectionID]; ok {
-		for _, id := range info.ReleasedPartitionIDs {
+	if c, ok := m.getCollectionMeta(collectionID); ok {
+		for _, id := range c.snapshot().ReleasedPartitionIDs {
 			if partitionID == id {
 				return true
 			}
@@ -278,7 +371,9 @@ func (m *MetaReplica) addCollection(collectionID UniqueID, schema *schemapb.Coll
 			return err
 		}
 		m.collectionMu.Lock()
-		m.collectionInfos[collectionID] = newCollection
+		if _, ok := m.collections[collectionID]; !ok {
+			m.collections[collectionID] = newCollectionMeta(newCollection)
+		}
 		m.collectionMu.Unlock()
 	}
 
@@ -286,15 +381,11 @@ func (m *MetaReplica) addCollection(collectionID UniqueID, schema *schemapb.Coll
 }
 
 func (m *MetaReplica) addPartition(collectionID UniqueID, partitionID UniqueID) error {
-	m.collectionMu.Lock()
-	defer m.collectionMu.Unlock()
-
-	if info, ok := m.collectionInfos[collectionID]; ok {
-		col := proto.Clone(info).(*querypb.CollectionInfo)
+	found, err := m.updateCollectionInfo(collectionID, func(col *querypb.CollectionInfo) (bool, error) {
 		log.Debug("add a  partition to MetaReplica...", zap.Int64s("partitionIDs", col.PartitionIDs))
 		for _, id := range col.PartitionIDs {
 			if id == partitionID {
-				return nil
+				return false, nil
 			}
 		}
 		col.PartitionIDs = append(col.PartitionIDs, partitionID)
@@ -311,25 +402,90 @@ func (m *MetaReplica) addPartition(collectionID UniqueID, partitionID UniqueID)
 		})
 
 		log.Debug("add a  partition to MetaReplica", zap.Int64s("partitionIDs", col.PartitionIDs))
-		err := saveGlobalCollectionInfo(collectionID, col, m.client)
-		if err != nil {
-			log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
-			return err
+		return true, nil
+	})
+	if !found {
+		return errors.New("addPartition: can't find collection when add partition")
+	}
+	return err
+}
+
+func (m *MetaReplica) getSegmentShard(collectionID UniqueID) (*segmentShard, bool) {
+	m.segmentMu.RLock()
+	defer m.segmentMu.RUnlock()
+	shard, ok := m.segments[collectionID]
+	return shard, ok
+}
+
+// putSegmentInfos updates the segment infos in memory, they must have been persisted by the caller
+func (m *MetaReplica) putSegmentInfos(segmentInfos map[UniqueID]*querypb.SegmentInfo) {
+	col2Infos := make(map[UniqueID]map[UniqueID]*querypb.SegmentInfo)
+	m.segmentMu.Lock()
+	for segmentID, info := range segmentInfos {
+		if oldCollectionID, ok := m.segmentIndex[segmentID]; ok && oldCollectionID != info.CollectionID {
+			shard := m.segments[oldCollectionID]
+			shard.mu.Lock()
+			delete(shard.segments, segmentID)
+			shard.mu.Unlock()
+		}
+		m.segmentIndex[segmentID] = info.CollectionID
+		if _, ok := m.segments[info.CollectionID]; !ok {
+			m.segments[info.CollectionID] = newSegmentShard()
 		}
-		m.collectionInfos[collectionID] = col
-		return nil
+		if _, ok := col2Infos[info.CollectionID]; !ok {
+			col2Infos[info.CollectionID] = make(map[UniqueID]*querypb.SegmentInfo)
+		}
+		col2Infos[info.CollectionID][segmentID] = info
+	}
+	shards := make(map[UniqueID]*segmentShard, len(col2Infos))
+	for collectionID := range col2Infos {
+		shards[collectionID] = m.segments[collectionID]
+	}
+	m.segmentMu.Unlock()
+
+	for collectionID, infos := range col2Infos {
+		shard := shards[collectionID]
+		shard.mu.Lock()
+		for segmentID, info := range infos {
+			shard.segments[segmentID] = info
+		}
+		shard.mu.Unlock()
 	}
-	return errors.New("addPartition: can't find collection when add partition")
 }
 
-func (m *MetaReplica) deleteSegmentInfoByNodeID(nodeID UniqueID) error {
+// removeSegmentInfos removes the segment infos from memory if keep returns false for them
+func (m *MetaReplica) removeSegmentInfos(segmentIDs []UniqueID, keep func(info *querypb.SegmentInfo) bool) {
 	m.segmentMu.Lock()
 	defer m.segmentMu.Unlock()
+	for _, segmentID := range segmentIDs {
+		collectionID, ok := m.segmentIndex[segmentID]
+		if !ok {
+			continue
+		}
+		shard := m.segments[collectionID]
+		shard.mu.Lock()
+		if info, ok := shard.segments[segmentID]; ok && (keep == nil || !keep(info)) {
+			delete(shard.segments, segmentID)
+			delete(m.segmentIndex, segmentID)
+		}
+		shard.mu.Unlock()
+	}
+}
+
+func (m *MetaReplica) deleteSegmentInfoByNodeID(nodeID UniqueID) error {
+	m.segmentMu.RLock()
+	shards := make([]*segmentShard, 0, len(m.segments))
+	for _, shard := range m.segments {
+		shards = append(shards, shard)
+	}
+	m.segmentMu.RUnlock()
 
 	segmentIDsToRemove := make([]UniqueID, 0)
-	for segmentID, info := range m.segmentInfos {
-		if info.NodeID == nodeID {
-			segmentIDsToRemove = append(segmentIDsToRemove, segmentID)
+	for _, shard := range shards {
+		for _, info := range shard.snapshot() {
+			if info.NodeID == nodeID {
+				segmentIDsToRemove = append(segmentIDsToRemove, info.SegmentID)
+			}
 		}
 	}
 
@@ -338,27 +494,22 @@ func (m *MetaReplica) deleteSegmentInfoByNodeID(nodeID UniqueID) error {
 		log.Error("remove segmentInfo from etcd error", zap.Any("error", err.Error()), zap.Int64s("segmentIDs", segmentIDsToRemove))
 		return err
 	}
-	for _, segmentID := range segmentIDsToRemove {
-		delete(m.segmentInfos, segmentID)
-	}
+	// a segment moved to another node in the meantime is kept
+	m.removeSegmentInfos(segmentIDsToRemove, func(info *querypb.SegmentInfo) bool {
+		return info.NodeID != nodeID
+	})
 
 	return nil
 }
 
 func (m *MetaReplica) setSegmentInfos(segmentInfos map[UniqueID]*querypb.SegmentInfo) error {
-	m.segmentMu.Lock()
-	defer m.segmentMu.Unlock()
-
 	err := multiSaveSegmentInfos(segmentInfos, m.client)
 	if err != nil {
 		log.Error("save segmentInfos error", zap.Any("segmentInfos", segmentInfos), zap.Error(err))
 		return err
 	}
 
-	for segmentID, info := range segmentInfos {
-		m.segmentInfos[segmentID] = info
-	}
-
+	m.putSegmentInfos(segmentInfos)
 	return nil
 }
 
@@ -479,14 +630,13 @@ func (m *MetaReplica) saveGlobalSealedSegInfos(saves col2SegmentInfos) (col2Seal
 		log.Error("updateGlobalSealedSegmentInfos: save info to etcd error", zap.Error(err))
 		return col2SegmentChangeInfos, err
 	}
-	m.segmentMu.Lock()
-	for _, segmentInfos := range saves {
-		for _, info := range segmentInfos {
-			segmentID := info.SegmentID
-			m.segmentInfos[segmentID] = info
+	segmentInfos := make(map[UniqueID]*querypb.SegmentInfo)
+	for _, infos := range saves {
+		for _, info := range infos {
+			segmentInfos[info.SegmentID] = info
 		}
 	}
-	m.segmentMu.Unlock()
+	m.putSegmentInfos(segmentInfos)
 
 	m.channelMu.Lock()
 	for collectionID, channelInfo := range queryChannelInfosMap {
@@ -498,7 +648,24 @@ func (m *MetaReplica) saveGlobalSealedSegInfos(saves col2SegmentInfos) (col2Seal
 }
 
 func (m *MetaReplica) removeGlobalSealedSegInfos(collectionID UniqueID, partitionIDs []UniqueID) (col2SealedSegmentChangeInfos, error) {
-	removes := m.showSegmentInfos(collectionID, partitionIDs)
+	return m.removeSealedSegInfos(collectionID, m.showSegmentInfos(collectionID, partitionIDs))
+}
+
+// removeSealedSegInfosByID removes the sealed segments of the collection dropped by DataCoord, the query nodes and
+// proxies are notified by the segment change info sent to the query channel, the segments not loaded are ignored
+func (m *MetaReplica) removeSealedSegInfosByID(collectionID UniqueID, segmentIDs []UniqueID) (col2SealedSegmentChangeInfos, error) {
+	removes := make([]*querypb.SegmentInfo, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		info, err := m.getSegmentInfoByID(segmentID)
+		if err != nil || info.CollectionID != collectionID {
+			continue
+		}
+		removes = append(removes, info)
+	}
+	return m.removeSealedSegInfos(collectionID, removes)
+}
+
+func (m *MetaReplica) removeSealedSegInfos(collectionID UniqueID, removes []*querypb.SegmentInfo) (col2SealedSegmentChangeInfos, error) {
 	if len(removes) == 0 {
 		return nil, nil
 	}
@@ -586,11 +753,11 @@ func (m *MetaReplica) removeGlobalSealedSegInfos(collectionID UniqueID, partitio
 		log.Error("updateGlobalSealedSegmentInfos: save info to etcd error", zap.Error(err))
 		return col2SealedSegmentChangeInfos{collectionID: segmentChangeInfos}, err
 	}
-	m.segmentMu.Lock()
+	segmentIDs := make([]UniqueID, 0, len(removes))
 	for _, info := range removes {
-		delete(m.segmentInfos, info.SegmentID)
+		segmentIDs = append(segmentIDs, info.SegmentID)
 	}
-	m.segmentMu.Unlock()
+	m.removeSegmentInfos(segmentIDs, nil)
 
 	m.channelMu.Lock()
 	m.queryChannelInfos[collectionID] = queryChannelInfo
@@ -641,23 +808,21 @@ func (m *MetaReplica) sendSealedSegmentChangeInfos(collectionID UniqueID, change
 }
 
 func (m *MetaReplica) showSegmentInfos(collectionID UniqueID, partitionIDs []UniqueID) []*querypb.SegmentInfo {
-	m.segmentMu.RLock()
-	defer m.segmentMu.RUnlock()
-
 	results := make([]*querypb.SegmentInfo, 0)
-	segmentInfos := make([]*querypb.SegmentInfo, 0)
-	for _, info := range m.segmentInfos {
-		if info.CollectionID == collectionID {
-			segmentInfos = append(segmentInfos, proto.Clone(info).(*querypb.SegmentInfo))
-		}
-	}
-	if len(partitionIDs) == 0 {
-		return segmentInfos
+	shard, ok := m.getSegmentShard(collectionID)
+	if !ok {
+		return results
 	}
-	for _, info := range segmentInfos {
+
+	// clone outside the lock of the shard, a big scan never blocks the updates of the collection
+	for _, info := range shard.snapshot() {
+		if len(partitionIDs) == 0 {
+			results = append(results, proto.Clone(info).(*querypb.SegmentInfo))
+			continue
+		}
 		for _, partitionID := range partitionIDs {
 			if info.PartitionID == partitionID {
-				results = append(results, info)
+				results = append(results, proto.Clone(info).(*querypb.SegmentInfo))
 			}
 		}
 	}
@@ -666,31 +831,52 @@ func (m *MetaReplica) showSegmentInfos(collectionID UniqueID, partitionIDs []Uni
 
 func (m *MetaReplica) getSegmentInfoByID(segmentID UniqueID) (*querypb.SegmentInfo, error) {
 	m.segmentMu.RLock()
-	defer m.segmentMu.RUnlock()
-
-	if info, ok := m.segmentInfos[segmentID]; ok {
-		return proto.Clone(info).(*querypb.SegmentInfo), nil
+	collectionID, ok := m.segmentIndex[segmentID]
+	shard := m.segments[collectionID]
+	m.segmentMu.RUnlock()
+
+	if ok {
+		shard.mu.RLock()
+		info, ok := shard.segments[segmentID]
+		shard.mu.RUnlock()
+		if ok {
+			return proto.Clone(info).(*querypb.SegmentInfo), nil
+		}
 	}
 
 	return nil, errors.New("getSegmentInfoByID: can't find segmentID in segmentInfos")
 }
 
-func (m *MetaReplica) getCollectionInfoByID(collectionID UniqueID) (*querypb.CollectionInfo, error) {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
+func (m *MetaReplica) getSegmentInfosByNode(nodeID int64) []*querypb.SegmentInfo {
+	m.segmentMu.RLock()
+	shards := make([]*segmentShard, 0, len(m.segments))
+	for _, shard := range m.segments {
+		shards = append(shards, shard)
+	}
+	m.segmentMu.RUnlock()
 
-	if info, ok := m.collectionInfos[collectionID]; ok {
-		return proto.Clone(info).(*querypb.CollectionInfo), nil
+	results := make([]*querypb.SegmentInfo, 0)
+	for _, shard := range shards {
+		for _, info := range shard.snapshot() {
+			if info.NodeID == nodeID {
+				results = append(results, proto.Clone(info).(*querypb.SegmentInfo))
+			}
+		}
+	}
+	return results
+}
+
+func (m *MetaReplica) getCollectionInfoByID(collectionID UniqueID) (*querypb.CollectionInfo, error) {
+	if c, ok := m.getCollectionMeta(collectionID); ok {
+		return proto.Clone(c.snapshot()).(*querypb.CollectionInfo), nil
 	}
 
 	return nil, errors.New("getCollectionInfoByID: can't find collectionID in collectionInfo")
 }
 
 func (m *MetaReplica) getPartitionStatesByID(collectionID UniqueID, partitionID UniqueID) (*querypb.PartitionStates, error) {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
-
-	if info, ok := m.collectionInfos[collectionID]; ok {
+	if c, ok := m.getCollectionMeta(collectionID); ok {
+		info := c.snapshot()
 		for offset, id := range info.PartitionIDs {
 			if id == partitionID {
 				return proto.Clone(info.PartitionStates[offset]).(*querypb.PartitionStates), nil
@@ -703,22 +889,31 @@ func (m *MetaReplica) getPartitionStatesByID(collectionID UniqueID, partitionID
 }
 
 func (m *MetaReplica) releaseCollection(collectionID UniqueID) error {
+	c, ok := m.getCollectionMeta(collectionID)
+	if ok {
+		// wait for the ongoing update, so that it can't save the info back after the removal
+		c.updateMu.Lock()
+		defer c.updateMu.Unlock()
+	}
+
 	err := removeGlobalCollectionInfo(collectionID, m.client)
 	if err != nil {
 		log.Warn("remove collectionInfo from etcd failed", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
 		return err
 	}
 
-	m.collectionMu.Lock()
-	delete(m.collectionInfos, collectionID)
-	m.collectionMu.Unlock()
+	if ok {
+		c.released = true
+		m.collectionMu.Lock()
+		delete(m.collections, collectionID)
+		m.collectionMu.Unlock()
+	}
 
 	return nil
 }
 
 func (m *MetaReplica) releasePartition(collectionID UniqueID, partitionID UniqueID) error {
-	info, err := m.getCollectionInfoByID(collectionID)
-	if err == nil {
+	found, err := m.updateCollectionInfo(collectionID, func(info *querypb.CollectionInfo) (bool, error) {
 		newPartitionIDs := make([]UniqueID, 0)
 		newPartitionStates := make([]*querypb.PartitionStates, 0)
 		for offset, id := range info.PartitionIDs {
@@ -745,30 +940,18 @@ func (m *MetaReplica) releasePartition(collectionID UniqueID, partitionID Unique
 		// the inMemoryPercentage in ShowCollection response is still the old value -- 100.
 		// So if releasing partition, inMemoryPercentage should be set to 0.
 		info.InMemoryPercentage = 0
-
-		err = saveGlobalCollectionInfo(collectionID, info, m.client)
-		if err != nil {
-			log.Error("releasePartition: save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID), zap.Int64("partitionID", partitionID))
-			return err
-		}
-
-		m.collectionMu.Lock()
-		m.collectionInfos[collectionID] = info
-		m.collectionMu.Unlock()
-
-		return nil
+		return true, nil
+	})
+	if !found {
+		return errors.New("getCollectionInfoByID: can't find collectionID in collectionInfo")
 	}
-
 	return err
 }
 
 func (m *MetaReplica) getDmChannelsByNodeID(collectionID UniqueID, nodeID int64) ([]string, error) {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
-
-	if info, ok := m.collectionInfos[collectionID]; ok {
+	if c, ok := m.getCollectionMeta(collectionID); ok {
 		channels := make([]string, 0)
-		for _, channelInfo := range info.ChannelInfos {
+		for _, channelInfo := range c.snapshot().ChannelInfos {
 			if channelInfo.NodeIDLoaded == nodeID {
 				channels = append(channels, channelInfo.ChannelIDs...)
 			}
@@ -781,8 +964,7 @@ func (m *MetaReplica) getDmChannelsByNodeID(collectionID UniqueID, nodeID int64)
 
 func (m *MetaReplica) addDmChannel(collectionID UniqueID, nodeID int64, channels []string) error {
 	//before add channel, should ensure toAddedChannels not in MetaReplica
-	info, err := m.getCollectionInfoByID(collectionID)
-	if err == nil {
+	found, err := m.updateCollectionInfo(collectionID, func(info *querypb.CollectionInfo) (bool, error) {
 		findNodeID := false
 		for _, channelInfo := range info.ChannelInfos {
 			if channelInfo.NodeIDLoaded == nodeID {
@@ -797,24 +979,16 @@ func (m *MetaReplica) addDmChannel(collectionID UniqueID, nodeID int64, channels
 			}
 			info.ChannelInfos = append(info.ChannelInfos, newChannelInfo)
 		}
-
-		err = saveGlobalCollectionInfo(collectionID, info, m.client)
-		if err != nil {
-			log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
-			return err
-		}
-		m.collectionMu.Lock()
-		m.collectionInfos[collectionID] = info
-		m.collectionMu.Unlock()
-		return nil
+		return true, nil
+	})
+	if !found {
+		return errors.New("addDmChannels: can't find collection in collectionInfos")
 	}
-
-	return errors.New("addDmChannels: can't find collection in collectionInfos")
+	return err
 }
 
 func (m *MetaReplica) removeDmChannel(collectionID UniqueID, nodeID int64, channels []string) error {
-	info, err := m.getCollectionInfoByID(collectionID)
-	if err == nil {
+	found, err := m.updateCollectionInfo(collectionID, func(info *querypb.CollectionInfo) (bool, error) {
 		for _, channelInfo := range info.ChannelInfos {
 			if channelInfo.NodeIDLoaded == nodeID {
 				newChannelIDs := make([]string, 0)
@@ -832,28 +1006,19 @@ func (m *MetaReplica) removeDmChannel(collectionID UniqueID, nodeID int64, chann
 				channelInfo.ChannelIDs = newChannelIDs
 			}
 		}
-
-		err := saveGlobalCollectionInfo(collectionID, info, m.client)
-		if err != nil {
-			log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
-			return err
-		}
-
-		m.collectionMu.Lock()
-		m.collectionInfos[collectionID] = info
-		m.collectionMu.Unlock()
-
-		return nil
+		return true, nil
+	})
+	if !found {
+		return errors.New("addDmChannels: can't find collection in collectionInfos")
 	}
-
-	return errors.New("addDmChannels: can't find collection in collectionInfos")
+	return err
 }
 
 func createQueryChannel(collectionID UniqueID) *querypb.QueryChannelInfo {
 	searchPrefix := Params.SearchChannelPrefix
 	searchResultPrefix := Params.SearchResultChannelPrefix
-	allocatedQueryChannel := searchPrefix + "-" + strconv.FormatInt(collectionID, 10)
-	allocatedQueryResultChannel := searchResultPrefix + "-" + strconv.FormatInt(collectionID, 10)
+	allocatedQueryChannel := channelutil.QueryChannelName(searchPrefix, collectionID)
+	allocatedQueryResultChannel := channelutil.QueryChannelName(searchResultPrefix, collectionID)
 	log.Debug("query coordinator create query channel", zap.String("queryChannelName", allocatedQueryChannel), zap.String("queryResultChannelName", allocatedQueryResultChannel))
 
 	seekPosition := &internalpb.MsgPosition{
@@ -870,6 +1035,9 @@ func createQueryChannel(collectionID UniqueID) *querypb.QueryChannelInfo {
 	return info
 }
 
+// getQueryChannelInfoByID returns the query channel pair assigned to the collection.
+// The pair is created and persisted on first access and restored by reloadFromKV afterwards,
+// so the same channel names are returned across querycoord restarts
 func (m *MetaReplica) getQueryChannelInfoByID(collectionID UniqueID) (*querypb.QueryChannelInfo, error) {
 	m.channelMu.Lock()
 	defer m.channelMu.Unlock()
@@ -882,13 +1050,13 @@ func (m *MetaReplica) getQueryChannelInfoByID(collectionID UniqueID) (*querypb.Q
 	// all collection use the same query channel
 	colIDForAssignChannel := UniqueID(0)
 	info := createQueryChannel(colIDForAssignChannel)
+	// set info.collectionID from 0 to realID
+	info.CollectionID = collectionID
 	err := saveQueryChannelInfo(collectionID, info, m.client)
 	if err != nil {
 		log.Error("getQueryChannel: save channel to etcd error", zap.Error(err))
 		return nil, err
 	}
-	// set info.collectionID from 0 to realID
-	info.CollectionID = collectionID
 	m.queryChannelInfos[collectionID] = info
 	return proto.Clone(info).(*querypb.QueryChannelInfo), nil
 }
@@ -920,84 +1088,56 @@ func (m *MetaReplica) getQueryStreamByID(collectionID UniqueID) (msgstream.MsgSt
 }
 
 func (m *MetaReplica) setLoadType(collectionID UniqueID, loadType querypb.LoadType) error {
-	info, err := m.getCollectionInfoByID(collectionID)
-	if err == nil {
+	found, err := m.updateCollectionInfo(collectionID, func(info *querypb.CollectionInfo) (bool, error) {
 		info.LoadType = loadType
-		err := saveGlobalCollectionInfo(collectionID, info, m.client)
-		if err != nil {
-			log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
-			return err
-		}
-		m.collectionMu.Lock()
-		m.collectionInfos[collectionID] = info
-		m.collectionMu.Unlock()
-
-		return nil
+		return true, nil
+	})
+	if !found {
+		return errors.New("setLoadType: can't find collection in collectionInfos")
 	}
-
-	return errors.New("setLoadType: can't find collection in collectionInfos")
+	return err
 }
 
 func (m *MetaReplica) getLoadType(collectionID UniqueID) (querypb.LoadType, error) {
-	m.collectionMu.RLock()
-	defer m.collectionMu.RUnlock()
-
-	if info, ok := m.collectionInfos[collectionID]; ok {
-		return info.LoadType, nil
+	if c, ok := m.getCollectionMeta(collectionID); ok {
+		return c.snapshot().LoadType, nil
 	}
 
 	return 0, errors.New("getLoadType: can't find collection in collectionInfos")
 }
 
 func (m *MetaReplica) setLoadPercentage(collectionID UniqueID, partitionID UniqueID, percentage int64, loadType querypb.LoadType) error {
-	info, err := m.getCollectionInfoByID(collectionID)
-	if err != nil {
-		return errors.New("setLoadPercentage: can't find collection in collectionInfos")
-	}
-
-	if loadType == querypb.LoadType_loadCollection {
-		info.InMemoryPercentage = percentage
-		for _, partitionState := range info.PartitionStates {
-			if percentage >= 100 {
-				partitionState.State = querypb.PartitionState_InMemory
-			} else {
-				partitionState.State = querypb.PartitionState_PartialInMemory
+	found, err := m.updateCollectionInfo(collectionID, func(info *querypb.CollectionInfo) (bool, error) {
+		if loadType == querypb.LoadType_loadCollection {
+			info.InMemoryPercentage = percentage
+			for _, partitionState := range info.PartitionStates {
+				if percentage >= 100 {
+					partitionState.State = querypb.PartitionState_InMemory
+				} else {
+					partitionState.State = querypb.PartitionState_PartialInMemory
+				}
+				partitionState.InMemoryPercentage = percentage
 			}
-			partitionState.InMemoryPercentage = percentage
+			return true, nil
 		}
-		err := saveGlobalCollectionInfo(collectionID, info, m.client)
-		if err != nil {
-			log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
-			return err
-		}
-	} else {
-		findPartition := false
+
 		for _, partitionState := range info.PartitionStates {
 			if partitionState.PartitionID == partitionID {
-				findPartition = true
 				if percentage >= 100 {
 					partitionState.State = querypb.PartitionState_InMemory
 				} else {
 					partitionState.State = querypb.PartitionState_PartialInMemory
 				}
 				partitionState.InMemoryPercentage = percentage
-				err := saveGlobalCollectionInfo(collectionID, info, m.client)
-				if err != nil {
-					log.Error("save collectionInfo error", zap.Any("error", err.Error()), zap.Int64("collectionID", collectionID))
-					return err
-				}
+				return true, nil
 			}
 		}
-		if !findPartition {
-			return errors.New("setLoadPercentage: can't find partitionID in collectionInfos")
-		}
+		return false, errors.New("setLoadPercentage: can't find partitionID in collectionInfos")
+	})
+	if !found {
+		return errors.New("setLoadPercentage: can't find collection in collectionInfos")
 	}
-
-	m.collectionMu.Lock()
-	m.collectionInfos[collectionID] = info
-	m.collectionMu.Unlock()
-
-	return nil
+	return err
 }
 
 //func (m *MetaReplica) printMeta() {
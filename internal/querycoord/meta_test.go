@@ -15,7 +15,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +53,18 @@ func (tk *testKv) LoadWithPrefix(key string) ([]string, []string, error) {
 	return nil, nil, nil
 }
 
+// queryChannelErrKv fails only when loading the query channel infos
+type queryChannelErrKv struct {
+	*testKv
+}
+
+func (kv *queryChannelErrKv) LoadWithPrefix(key string) ([]string, []string, error) {
+	if key == queryChannelMetaPrefix {
+		return nil, nil, failedResult()
+	}
+	return kv.testKv.LoadWithPrefix(key)
+}
+
 func TestReplica_Release(t *testing.T) {
 	refreshParams()
 	etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
@@ -84,12 +99,7 @@ func TestMetaFunc(t *testing.T) {
 	refreshParams()
 	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
 	assert.Nil(t, err)
-	meta := &MetaReplica{
-		client:            kv,
-		collectionInfos:   map[UniqueID]*querypb.CollectionInfo{},
-		segmentInfos:      map[UniqueID]*querypb.SegmentInfo{},
-		queryChannelInfos: map[UniqueID]*querypb.QueryChannelInfo{},
-	}
+	meta := newMetaReplica(context.Background(), kv)
 
 	nodeID := int64(100)
 	dmChannels := []string{"testDm1", "testDm2"}
@@ -247,6 +257,13 @@ func TestMetaFunc(t *testing.T) {
 		assert.Equal(t, defaultSegmentID, info.SegmentID)
 	})
 
+	t.Run("Test GetSegmentInfosByNode", func(t *testing.T) {
+		infos := meta.getSegmentInfosByNode(nodeID)
+		assert.Equal(t, 1, len(infos))
+		assert.Equal(t, defaultSegmentID, infos[0].SegmentID)
+		assert.Equal(t, 0, len(meta.getSegmentInfosByNode(nodeID+1)))
+	})
+
 	t.Run("Test SetLoadType", func(t *testing.T) {
 		err := meta.setLoadType(defaultCollectionID, querypb.LoadType_loadCollection)
 		assert.Nil(t, err)
@@ -295,12 +312,7 @@ func TestReloadMetaFromKV(t *testing.T) {
 	refreshParams()
 	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
 	assert.Nil(t, err)
-	meta := &MetaReplica{
-		client:            kv,
-		collectionInfos:   map[UniqueID]*querypb.CollectionInfo{},
-		segmentInfos:      map[UniqueID]*querypb.SegmentInfo{},
-		queryChannelInfos: map[UniqueID]*querypb.QueryChannelInfo{},
-	}
+	meta := newMetaReplica(context.Background(), kv)
 
 	kvs := make(map[string]string)
 	collectionInfo := &querypb.CollectionInfo{
@@ -333,13 +345,259 @@ func TestReloadMetaFromKV(t *testing.T) {
 	err = meta.reloadFromKV()
 	assert.Nil(t, err)
 
-	assert.Equal(t, 1, len(meta.collectionInfos))
-	assert.Equal(t, 1, len(meta.segmentInfos))
+	assert.Equal(t, 1, len(meta.collections))
+	assert.Equal(t, 1, len(meta.segmentIndex))
 	assert.Equal(t, 1, len(meta.queryChannelInfos))
-	_, ok := meta.collectionInfos[defaultCollectionID]
+	_, ok := meta.collections[defaultCollectionID]
 	assert.Equal(t, true, ok)
-	_, ok = meta.segmentInfos[defaultSegmentID]
+	_, ok = meta.segmentIndex[defaultSegmentID]
 	assert.Equal(t, true, ok)
 	_, ok = meta.queryChannelInfos[defaultCollectionID]
 	assert.Equal(t, true, ok)
 }
+
+func TestQueryChannelInfoPersistence(t *testing.T) {
+	refreshParams()
+	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	queryChannelKey := fmt.Sprintf("%s/%d", queryChannelMetaPrefix, defaultCollectionID)
+	err = kv.Remove(queryChannelKey)
+	assert.Nil(t, err)
+
+	meta, err := newMeta(context.Background(), kv, nil, nil)
+	assert.Nil(t, err)
+	info, err := meta.getQueryChannelInfoByID(defaultCollectionID)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultCollectionID, info.CollectionID)
+
+	t.Run("Test idempotent", func(t *testing.T) {
+		again, err := meta.getQueryChannelInfoByID(defaultCollectionID)
+		assert.Nil(t, err)
+		assert.Equal(t, info.QueryChannelID, again.QueryChannelID)
+		assert.Equal(t, info.QueryResultChannelID, again.QueryResultChannelID)
+	})
+
+	t.Run("Test reload after restart", func(t *testing.T) {
+		restarted, err := newMeta(context.Background(), kv, nil, nil)
+		assert.Nil(t, err)
+		reloaded, err := restarted.getQueryChannelInfoByID(defaultCollectionID)
+		assert.Nil(t, err)
+		assert.Equal(t, defaultCollectionID, reloaded.CollectionID)
+		assert.Equal(t, info.QueryChannelID, reloaded.QueryChannelID)
+		assert.Equal(t, info.QueryResultChannelID, reloaded.QueryResultChannelID)
+	})
+
+	t.Run("Test reload legacy info", func(t *testing.T) {
+		legacyInfo := &querypb.QueryChannelInfo{
+			CollectionID:         0,
+			QueryChannelID:       "legacy-query-channel",
+			QueryResultChannelID: "legacy-query-result-channel",
+		}
+		err := saveQueryChannelInfo(defaultCollectionID, legacyInfo, kv)
+		assert.Nil(t, err)
+
+		restarted, err := newMeta(context.Background(), kv, nil, nil)
+		assert.Nil(t, err)
+		reloaded, err := restarted.getQueryChannelInfoByID(defaultCollectionID)
+		assert.Nil(t, err)
+		assert.Equal(t, defaultCollectionID, reloaded.CollectionID)
+		assert.Equal(t, legacyInfo.QueryChannelID, reloaded.QueryChannelID)
+		assert.Equal(t, legacyInfo.QueryResultChannelID, reloaded.QueryResultChannelID)
+	})
+
+	t.Run("Test missing key", func(t *testing.T) {
+		err := kv.Remove(queryChannelKey)
+		assert.Nil(t, err)
+
+		restarted, err := newMeta(context.Background(), kv, nil, nil)
+		assert.Nil(t, err)
+		created, err := restarted.getQueryChannelInfoByID(defaultCollectionID)
+		assert.Nil(t, err)
+		assert.Equal(t, info.QueryChannelID, created.QueryChannelID)
+
+		value, err := kv.Load(queryChannelKey)
+		assert.Nil(t, err)
+		persisted := &querypb.QueryChannelInfo{}
+		err = proto.Unmarshal([]byte(value), persisted)
+		assert.Nil(t, err)
+		assert.Equal(t, defaultCollectionID, persisted.CollectionID)
+		assert.Equal(t, created.QueryChannelID, persisted.QueryChannelID)
+		assert.Equal(t, created.QueryResultChannelID, persisted.QueryResultChannelID)
+	})
+
+	t.Run("Test reload error", func(t *testing.T) {
+		errMeta := newMetaReplica(context.Background(), &queryChannelErrKv{testKv: &testKv{returnFn: failedResult}})
+		err := errMeta.reloadFromKV()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestMetaReplica_concurrentCollections(t *testing.T) {
+	meta := newMetaReplica(context.Background(), &testKv{returnFn: successResult})
+	collectionNum := 8
+	rounds := 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < collectionNum; i++ {
+		collectionID := UniqueID(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < rounds; round++ {
+				partitionID := UniqueID(round)
+				segmentID := collectionID*1000 + UniqueID(round)
+				assert.Nil(t, meta.addCollection(collectionID, nil))
+				assert.Nil(t, meta.addPartition(collectionID, partitionID))
+				assert.Nil(t, meta.setLoadPercentage(collectionID, partitionID, 100, querypb.LoadType_LoadPartition))
+				assert.Nil(t, meta.setSegmentInfos(map[UniqueID]*querypb.SegmentInfo{
+					segmentID: {SegmentID: segmentID, CollectionID: collectionID, PartitionID: partitionID},
+				}))
+				info, err := meta.getSegmentInfoByID(segmentID)
+				assert.Nil(t, err)
+				assert.Equal(t, collectionID, info.CollectionID)
+				assert.Equal(t, 1, len(meta.showSegmentInfos(collectionID, []UniqueID{partitionID})))
+				assert.Nil(t, meta.releasePartition(collectionID, partitionID))
+				if round%5 == 4 {
+					assert.Nil(t, meta.releaseCollection(collectionID))
+				}
+			}
+		}()
+
+		// readers scanning all the collections
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < rounds; round++ {
+				for _, info := range meta.showCollections() {
+					meta.showSegmentInfos(info.CollectionID, nil)
+					meta.hasPartition(info.CollectionID, UniqueID(round))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < collectionNum; i++ {
+		assert.False(t, meta.hasCollection(UniqueID(i)))
+		assert.Equal(t, rounds, len(meta.showSegmentInfos(UniqueID(i), nil)))
+	}
+}
+
+func TestMetaReplica_concurrentUpdatesOfCollection(t *testing.T) {
+	meta := newMetaReplica(context.Background(), &testKv{returnFn: successResult})
+	err := meta.addCollection(defaultCollectionID, nil)
+	assert.Nil(t, err)
+
+	// every update is based on the result of the previous one, none is lost
+	nodeNum := 32
+	var wg sync.WaitGroup
+	for i := 0; i < nodeNum; i++ {
+		nodeID := int64(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := meta.addDmChannel(defaultCollectionID, nodeID, []string{fmt.Sprintf("dml-%d", nodeID)})
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	info, err := meta.getCollectionInfoByID(defaultCollectionID)
+	assert.Nil(t, err)
+	assert.Equal(t, nodeNum, len(info.ChannelInfos))
+
+	// an update racing with the release never brings the collection back
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		meta.setLoadType(defaultCollectionID, querypb.LoadType_loadCollection)
+	}()
+	go func() {
+		defer wg.Done()
+		assert.Nil(t, meta.releaseCollection(defaultCollectionID))
+	}()
+	wg.Wait()
+	assert.False(t, meta.hasCollection(defaultCollectionID))
+	err = meta.setLoadType(defaultCollectionID, querypb.LoadType_loadCollection)
+	assert.NotNil(t, err)
+}
+
+func TestMetaReplica_deleteSegmentInfoByNodeID(t *testing.T) {
+	meta := newMetaReplica(context.Background(), &multiRemoveKv{testKv: &testKv{returnFn: successResult}})
+	meta.putSegmentInfos(map[UniqueID]*querypb.SegmentInfo{
+		1: {SegmentID: 1, CollectionID: 1, NodeID: 1},
+		2: {SegmentID: 2, CollectionID: 2, NodeID: 1},
+		3: {SegmentID: 3, CollectionID: 2, NodeID: 2},
+	})
+
+	err := meta.deleteSegmentInfoByNodeID(1)
+	assert.Nil(t, err)
+	_, err = meta.getSegmentInfoByID(1)
+	assert.NotNil(t, err)
+	_, err = meta.getSegmentInfoByID(2)
+	assert.NotNil(t, err)
+	info, err := meta.getSegmentInfoByID(3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), info.NodeID)
+	assert.Equal(t, 0, len(meta.showSegmentInfos(1, nil)))
+	assert.Equal(t, 1, len(meta.showSegmentInfos(2, nil)))
+}
+
+type multiRemoveKv struct {
+	*testKv
+}
+
+func (kv *multiRemoveKv) MultiRemove(keys []string) error {
+	return kv.returnFn()
+}
+
+// BenchmarkMetaReplica_scanDuringUpdates scans the segments of one collection while the other collections are updated,
+// the globalLock case emulates the single mutex meta used to hold across the etcd writes
+func BenchmarkMetaReplica_scanDuringUpdates(b *testing.B) {
+	etcdLatency := func() error {
+		time.Sleep(100 * time.Microsecond)
+		return nil
+	}
+	newBenchMeta := func() *MetaReplica {
+		meta := newMetaReplica(context.Background(), &testKv{returnFn: etcdLatency})
+		segmentInfos := make(map[UniqueID]*querypb.SegmentInfo)
+		for segmentID := UniqueID(0); segmentID < 10000; segmentID++ {
+			segmentInfos[segmentID] = &querypb.SegmentInfo{SegmentID: segmentID, CollectionID: defaultCollectionID}
+		}
+		meta.putSegmentInfos(segmentInfos)
+		return meta
+	}
+
+	run := func(b *testing.B, lock sync.Locker) {
+		meta := newBenchMeta()
+		var counter int64
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				n := atomic.AddInt64(&counter, 1)
+				lock.Lock()
+				if n%2 == 0 {
+					meta.showSegmentInfos(defaultCollectionID, nil)
+				} else {
+					collectionID := defaultCollectionID + 1 + n%8
+					meta.setSegmentInfos(map[UniqueID]*querypb.SegmentInfo{
+						100000 + n: {SegmentID: 100000 + n, CollectionID: collectionID},
+					})
+				}
+				lock.Unlock()
+			}
+		})
+	}
+
+	b.Run("sharded", func(b *testing.B) {
+		run(b, noopLocker{})
+	})
+	b.Run("globalLock", func(b *testing.B) {
+		run(b, &sync.Mutex{})
+	})
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
@@ -13,9 +13,6 @@ package querycoord
 
 import (
 	"context"
-	"os"
-
-	"github.com/milvus-io/milvus/internal/util/uniquegenerator"
 
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 
@@ -35,96 +32,65 @@ func getSystemInfoMetrics(
 	qc *QueryCoord,
 ) (*milvuspb.GetMetricsResponse, error) {
 
-	clusterTopology := metricsinfo.QueryClusterTopology{
-		Self: metricsinfo.QueryCoordInfos{
-			BaseComponentInfos: metricsinfo.BaseComponentInfos{
-				Name: metricsinfo.ConstructComponentName(typeutil.QueryCoordRole, Params.QueryCoordID),
-				HardwareInfos: metricsinfo.HardwareMetrics{
-					IP:           qc.session.Address,
-					CPUCoreCount: metricsinfo.GetCPUCoreCount(false),
-					CPUCoreUsage: metricsinfo.GetCPUUsage(),
-					Memory:       metricsinfo.GetMemoryCount(),
-					MemoryUsage:  metricsinfo.GetUsedMemoryCount(),
-					Disk:         metricsinfo.GetDiskCount(),
-					DiskUsage:    metricsinfo.GetDiskUsage(),
-				},
-				SystemInfo: metricsinfo.DeployMetrics{
-					SystemVersion: os.Getenv(metricsinfo.GitCommitEnvKey),
-					DeployMode:    os.Getenv(metricsinfo.DeployModeEnvKey),
-				},
-				CreatedTime: Params.CreatedTime.String(),
-				UpdatedTime: Params.UpdatedTime.String(),
-				Type:        typeutil.QueryCoordRole,
-				ID:          qc.session.ServerID,
-			},
-			SystemConfigurations: metricsinfo.QueryCoordConfiguration{
-				SearchChannelPrefix:       Params.SearchChannelPrefix,
-				SearchResultChannelPrefix: Params.SearchResultChannelPrefix,
-			},
-		},
-		ConnectedNodes: make([]metricsinfo.QueryNodeInfos, 0),
+	self := metricsinfo.NewComponentInfo(typeutil.QueryCoordRole, qc.session.ServerID, qc.session.Address,
+		Params.CreatedTime, Params.UpdatedTime,
+		metricsinfo.QueryCoordConfiguration{
+			SearchChannelPrefix:       Params.SearchChannelPrefix,
+			SearchResultChannelPrefix: Params.SearchResultChannelPrefix,
+		})
+	self.Tasks = qc.scheduler.getTaskInfos()
+	self.NodeTasks = qc.scheduler.getNodeTaskInfos()
+	topology := metricsinfo.NewComponentTopology(self, typeutil.RootCoordRole, typeutil.DataCoordRole)
+
+	nodesMetrics := qc.cluster.getMetrics(ctx, req)
+	for _, nodeMetrics := range nodesMetrics {
+		if err := topology.AddNode(nodeMetrics.resp, nodeMetrics.err); err != nil {
+			log.Warn("invalid metrics of query node was found",
+				zap.Error(err))
+		}
+	}
+
+	return metricsinfo.ComponentTopologyResponse(topology), nil
+}
+
+// getSegmentStatisticsMetrics collects the segment statistics from all the query nodes,
+// query nodes which failed to respond are skipped.
+func getSegmentStatisticsMetrics(
+	ctx context.Context,
+	req *milvuspb.GetMetricsRequest,
+	qc *QueryCoord,
+) (*milvuspb.GetMetricsResponse, error) {
+
+	clusterStatistics := metricsinfo.QueryClusterSegmentStatistics{
+		Nodes: make([]metricsinfo.QueryNodeSegmentStatistics, 0),
 	}
 
 	nodesMetrics := qc.cluster.getMetrics(ctx, req)
 	for _, nodeMetrics := range nodesMetrics {
 		if nodeMetrics.err != nil {
-			log.Warn("invalid metrics of query node was found",
+			log.Warn("invalid segment statistics of query node was found",
 				zap.Error(nodeMetrics.err))
-			clusterTopology.ConnectedNodes = append(clusterTopology.ConnectedNodes, metricsinfo.QueryNodeInfos{
-				BaseComponentInfos: metricsinfo.BaseComponentInfos{
-					HasError:    true,
-					ErrorReason: nodeMetrics.err.Error(),
-					// Name doesn't matter here cause we can't get it when error occurs, using address as the Name?
-					Name: "",
-					ID:   int64(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
-				},
-			})
 			continue
 		}
 
 		if nodeMetrics.resp.Status.ErrorCode != commonpb.ErrorCode_Success {
-			log.Warn("invalid metrics of query node was found",
+			log.Warn("invalid segment statistics of query node was found",
 				zap.Any("error_code", nodeMetrics.resp.Status.ErrorCode),
 				zap.Any("error_reason", nodeMetrics.resp.Status.Reason))
-			clusterTopology.ConnectedNodes = append(clusterTopology.ConnectedNodes, metricsinfo.QueryNodeInfos{
-				BaseComponentInfos: metricsinfo.BaseComponentInfos{
-					HasError:    true,
-					ErrorReason: nodeMetrics.resp.Status.Reason,
-					Name:        nodeMetrics.resp.ComponentName,
-					ID:          int64(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
-				},
-			})
 			continue
 		}
 
-		infos := metricsinfo.QueryNodeInfos{}
-		err := metricsinfo.UnmarshalComponentInfos(nodeMetrics.resp.Response, &infos)
+		nodeStatistics := metricsinfo.QueryNodeSegmentStatistics{}
+		err := metricsinfo.UnmarshalComponentInfos(nodeMetrics.resp.Response, &nodeStatistics)
 		if err != nil {
-			log.Warn("invalid metrics of query node was found",
+			log.Warn("invalid segment statistics of query node was found",
 				zap.Error(err))
-			clusterTopology.ConnectedNodes = append(clusterTopology.ConnectedNodes, metricsinfo.QueryNodeInfos{
-				BaseComponentInfos: metricsinfo.BaseComponentInfos{
-					HasError:    true,
-					ErrorReason: err.Error(),
-					Name:        nodeMetrics.resp.ComponentName,
-					ID:          int64(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
-				},
-			})
 			continue
 		}
-		clusterTopology.ConnectedNodes = append(clusterTopology.ConnectedNodes, infos)
-	}
-
-	coordTopology := metricsinfo.QueryCoordTopology{
-		Cluster: clusterTopology,
-		Connections: metricsinfo.ConnTopology{
-			Name: metricsinfo.ConstructComponentName(typeutil.QueryCoordRole, Params.QueryCoordID),
-			// TODO(dragondriver): fill ConnectedComponents if necessary
-			ConnectedComponents: []metricsinfo.ConnectionInfo{},
-		},
+		clusterStatistics.Nodes = append(clusterStatistics.Nodes, nodeStatistics)
 	}
 
-	resp, err := metricsinfo.MarshalTopology(coordTopology)
+	resp, err := metricsinfo.MarshalComponentInfos(clusterStatistics)
 	if err != nil {
 		return &milvuspb.GetMetricsResponse{
 			Status: &commonpb.Status{
@@ -145,3 +111,44 @@ func getSystemInfoMetrics(
 		ComponentName: metricsinfo.ConstructComponentName(typeutil.QueryCoordRole, Params.QueryCoordID),
 	}, nil
 }
+
+// getTaskInfoMetrics returns the progress of the tasks queued or executing in the scheduler,
+// which is read from memory only.
+func getTaskInfoMetrics(qc *QueryCoord) *milvuspb.GetMetricsResponse {
+	name := metricsinfo.ConstructComponentName(typeutil.QueryCoordRole, Params.QueryCoordID)
+	resp, err := metricsinfo.MarshalComponentInfos(metricsinfo.TaskInfos{
+		Name:  name,
+		Tasks: qc.scheduler.getTaskInfos(),
+	})
+	if err != nil {
+		return &milvuspb.GetMetricsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    err.Error(),
+			},
+			Response:      "",
+			ComponentName: name,
+		}
+	}
+
+	return &milvuspb.GetMetricsResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+			Reason:    "",
+		},
+		Response:      resp,
+		ComponentName: name,
+	}
+}
+
+// setLogLevel sets the log level of query coord, then of all the query nodes if the request is valid
+func setLogLevel(ctx context.Context, req *milvuspb.GetMetricsRequest, qc *QueryCoord) *milvuspb.GetMetricsResponse {
+	name := metricsinfo.ConstructComponentName(typeutil.QueryCoordRole, Params.QueryCoordID)
+	levels := []metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}
+	if !levels[0].HasError {
+		for _, nodeMetrics := range qc.cluster.getMetrics(ctx, req) {
+			levels = append(levels, metricsinfo.CollectLogLevels(nodeMetrics.resp, nodeMetrics.err)...)
+		}
+	}
+	return metricsinfo.LogLevelsResponse(levels, name)
+}
@@ -19,6 +19,7 @@ import (
 	"path"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
@@ -196,6 +197,8 @@ type rootCoordMock struct {
 	types.RootCoord
 	CollectionIDs []UniqueID
 	Col2partition map[UniqueID][]UniqueID
+	dropped       map[UniqueID]bool
+	enableIndex   bool
 	sync.RWMutex
 }
 
@@ -206,9 +209,50 @@ func newRootCoordMock() *rootCoordMock {
 	return &rootCoordMock{
 		CollectionIDs: collectionIDs,
 		Col2partition: col2partition,
+		dropped:       make(map[UniqueID]bool),
 	}
 }
 
+func (rc *rootCoordMock) dropCollection(collectionID UniqueID) {
+	rc.Lock()
+	defer rc.Unlock()
+
+	delete(rc.Col2partition, collectionID)
+	for i, id := range rc.CollectionIDs {
+		if id == collectionID {
+			rc.CollectionIDs = append(rc.CollectionIDs[:i], rc.CollectionIDs[i+1:]...)
+			break
+		}
+	}
+	rc.dropped[collectionID] = true
+}
+
+func (rc *rootCoordMock) isDropped(collectionID UniqueID) bool {
+	rc.RLock()
+	defer rc.RUnlock()
+	return rc.dropped[collectionID]
+}
+
+func (rc *rootCoordMock) DescribeCollection(ctx context.Context, req *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error) {
+	if rc.isDropped(req.CollectionID) {
+		return &milvuspb.DescribeCollectionResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    "collection not exist",
+			},
+		}, nil
+	}
+
+	rc.createCollection(req.CollectionID)
+	return &milvuspb.DescribeCollectionResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		Schema:       genCollectionSchema(req.CollectionID, false),
+		CollectionID: req.CollectionID,
+	}, nil
+}
+
 func (rc *rootCoordMock) createCollection(collectionID UniqueID) {
 	rc.Lock()
 	defer rc.Unlock()
@@ -251,6 +295,14 @@ func (rc *rootCoordMock) CreatePartition(ctx context.Context, req *milvuspb.Crea
 
 func (rc *rootCoordMock) ShowPartitions(ctx context.Context, in *milvuspb.ShowPartitionsRequest) (*milvuspb.ShowPartitionsResponse, error) {
 	collectionID := in.CollectionID
+	if rc.isDropped(collectionID) {
+		return &milvuspb.ShowPartitionsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    "collection not exist",
+			},
+		}, nil
+	}
 	status := &commonpb.Status{
 		ErrorCode: commonpb.ErrorCode_Success,
 	}
@@ -277,8 +329,31 @@ func (rc *rootCoordMock) ReleaseDQLMessageStream(ctx context.Context, in *proxyp
 	}, nil
 }
 
+// setEnableIndex makes all the segments have an index built by indexCoordMock, whose buildID is the segmentID
+func (rc *rootCoordMock) setEnableIndex(enable bool) {
+	rc.Lock()
+	defer rc.Unlock()
+	rc.enableIndex = enable
+}
+
 func (rc *rootCoordMock) DescribeSegment(ctx context.Context, req *milvuspb.DescribeSegmentRequest) (*milvuspb.DescribeSegmentResponse, error) {
-	return nil, errors.New("describeSegment fail")
+	rc.RLock()
+	defer rc.RUnlock()
+	if !rc.enableIndex {
+		return &milvuspb.DescribeSegmentResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    fmt.Sprintf("segment %d has no index", req.SegmentID),
+			},
+		}, nil
+	}
+	return &milvuspb.DescribeSegmentResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		BuildID:     req.SegmentID,
+		EnableIndex: true,
+	}, nil
 }
 
 type dataCoordMock struct {
@@ -391,12 +466,43 @@ func (data *dataCoordMock) GetRecoveryInfo(ctx context.Context, req *datapb.GetR
 
 type indexCoordMock struct {
 	types.IndexCoord
+	indexReadyTime time.Time
+	sync.RWMutex
 }
 
 func newIndexCoordMock() *indexCoordMock {
 	return &indexCoordMock{}
 }
 
+// delayIndexBuild makes the index of all the segments finish building after delay
+func (c *indexCoordMock) delayIndexBuild(delay time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.indexReadyTime = time.Now().Add(delay)
+}
+
+func (c *indexCoordMock) GetIndexStates(ctx context.Context, req *indexpb.GetIndexStatesRequest) (*indexpb.GetIndexStatesResponse, error) {
+	c.RLock()
+	defer c.RUnlock()
+	state := commonpb.IndexState_Finished
+	if time.Now().Before(c.indexReadyTime) {
+		state = commonpb.IndexState_InProgress
+	}
+	states := make([]*indexpb.IndexInfo, 0, len(req.IndexBuildIDs))
+	for _, buildID := range req.IndexBuildIDs {
+		states = append(states, &indexpb.IndexInfo{
+			State:        state,
+			IndexBuildID: buildID,
+		})
+	}
+	return &indexpb.GetIndexStatesResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		States: states,
+	}, nil
+}
+
 func (c *indexCoordMock) GetIndexFilePaths(ctx context.Context, req *indexpb.GetIndexFilePathsRequest) (*indexpb.GetIndexFilePathsResponse, error) {
 	return nil, errors.New("get index file path fail")
 }
@@ -13,6 +13,7 @@ package querycoord
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"google.golang.org/grpc"
@@ -23,6 +24,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
 )
 
 type queryNodeClientMock struct {
@@ -35,21 +37,17 @@ type queryNodeClientMock struct {
 	addr string
 }
 
-func newQueryNodeTest(ctx context.Context, address string, id UniqueID, kv *etcdkv.EtcdKV) (Node, error) {
+func newQueryNodeTest(ctx context.Context, address string, id UniqueID, kv *etcdkv.EtcdKV, clients *queryNodeClientCache) (Node, error) {
 	collectionInfo := make(map[UniqueID]*querypb.CollectionInfo)
 	watchedChannels := make(map[UniqueID]*querypb.QueryChannelInfo)
 	childCtx, cancel := context.WithCancel(ctx)
-	client, err := newQueryNodeClientMock(childCtx, address)
-	if err != nil {
-		cancel()
-		return nil, err
-	}
 	node := &queryNode{
 		ctx:                  childCtx,
 		cancel:               cancel,
 		id:                   id,
 		address:              address,
-		client:               client,
+		clients:              clients,
+		newClientFn:          newQueryNodeClientMockFn,
 		kvClient:             kv,
 		collectionInfos:      collectionInfo,
 		watchedQueryChannels: watchedChannels,
@@ -58,6 +56,10 @@ func newQueryNodeTest(ctx context.Context, address string, id UniqueID, kv *etcd
 	return node, nil
 }
 
+func newQueryNodeClientMockFn(ctx context.Context, addr string) (types.QueryNode, error) {
+	return newQueryNodeClientMock(ctx, addr)
+}
+
 func newQueryNodeClientMock(ctx context.Context, addr string) (*queryNodeClientMock, error) {
 	if addr == "" {
 		return nil, fmt.Errorf("addr is empty")
@@ -143,6 +145,29 @@ func (client *queryNodeClientMock) GetSegmentInfo(ctx context.Context, req *quer
 	return client.grpcClient.GetSegmentInfo(ctx, req)
 }
 
+func (client *queryNodeClientMock) Search(ctx context.Context, req *querypb.SearchRequest) (*querypb.SearchResponse, error) {
+	return client.grpcClient.Search(ctx, req)
+}
+
+func (client *queryNodeClientMock) FetchSegmentBinlogs(ctx context.Context, req *querypb.FetchSegmentBinlogsRequest, recv func(*querypb.SegmentBinlogChunk) error) error {
+	stream, err := client.grpcClient.FetchSegmentBinlogs(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := recv(chunk); err != nil {
+			return err
+		}
+	}
+}
+
 func (client *queryNodeClientMock) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	return client.grpcClient.GetMetrics(ctx, req)
 }
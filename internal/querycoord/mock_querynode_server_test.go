@@ -15,6 +15,7 @@ import (
 	"errors"
 	"net"
 	"strconv"
+	"sync"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -49,14 +50,20 @@ type queryNodeServerMock struct {
 	releaseCollection   func() (*commonpb.Status, error)
 	releasePartition    func() (*commonpb.Status, error)
 	releaseSegments     func() (*commonpb.Status, error)
+	getSegmentInfos     func(segmentIDs []UniqueID) (*querypb.GetSegmentInfoResponse, error)
+
+	segmentMu        sync.Mutex
+	segmentInfos     map[UniqueID]*querypb.SegmentInfo
+	loadSegmentsReqs []*querypb.LoadSegmentsRequest
 }
 
 func newQueryNodeServerMock(ctx context.Context) *queryNodeServerMock {
 	ctx1, cancel := context.WithCancel(ctx)
-	return &queryNodeServerMock{
-		ctx:         ctx1,
-		cancel:      cancel,
-		grpcErrChan: make(chan error),
+	qs := &queryNodeServerMock{
+		ctx:          ctx1,
+		cancel:       cancel,
+		grpcErrChan:  make(chan error),
+		segmentInfos: make(map[UniqueID]*querypb.SegmentInfo),
 
 		addQueryChannels:    returnSuccessResult,
 		removeQueryChannels: returnSuccessResult,
@@ -66,6 +73,8 @@ func newQueryNodeServerMock(ctx context.Context) *queryNodeServerMock {
 		releasePartition:    returnSuccessResult,
 		releaseSegments:     returnSuccessResult,
 	}
+	qs.getSegmentInfos = qs.returnLoadedSegmentInfos
+	return qs
 }
 
 func (qs *queryNodeServerMock) Register() error {
@@ -167,7 +176,24 @@ func (qs *queryNodeServerMock) WatchDmChannels(ctx context.Context, req *querypb
 }
 
 func (qs *queryNodeServerMock) LoadSegments(ctx context.Context, req *querypb.LoadSegmentsRequest) (*commonpb.Status, error) {
-	return qs.loadSegment()
+	status, err := qs.loadSegment()
+	if err == nil && status.ErrorCode == commonpb.ErrorCode_Success {
+		qs.segmentMu.Lock()
+		qs.loadSegmentsReqs = append(qs.loadSegmentsReqs, req)
+		for _, info := range req.Infos {
+			qs.segmentInfos[info.SegmentID] = &querypb.SegmentInfo{
+				SegmentID:    info.SegmentID,
+				CollectionID: info.CollectionID,
+				PartitionID:  info.PartitionID,
+				NodeID:       qs.queryNodeID,
+				NumRows:      info.NumOfRows,
+				SegmentState: querypb.SegmentState_sealed,
+				Version:      info.Version,
+			}
+		}
+		qs.segmentMu.Unlock()
+	}
+	return status, err
 }
 
 func (qs *queryNodeServerMock) ReleaseCollection(ctx context.Context, req *querypb.ReleaseCollectionRequest) (*commonpb.Status, error) {
@@ -182,11 +208,25 @@ func (qs *queryNodeServerMock) ReleaseSegments(ctx context.Context, req *querypb
 	return qs.releaseSegments()
 }
 
-func (qs *queryNodeServerMock) GetSegmentInfo(context.Context, *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error) {
+func (qs *queryNodeServerMock) GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error) {
+	return qs.getSegmentInfos(req.SegmentIDs)
+}
+
+func (qs *queryNodeServerMock) returnLoadedSegmentInfos(segmentIDs []UniqueID) (*querypb.GetSegmentInfoResponse, error) {
+	qs.segmentMu.Lock()
+	defer qs.segmentMu.Unlock()
+
+	infos := make([]*querypb.SegmentInfo, 0)
+	for _, segmentID := range segmentIDs {
+		if info, ok := qs.segmentInfos[segmentID]; ok {
+			infos = append(infos, info)
+		}
+	}
 	return &querypb.GetSegmentInfoResponse{
 		Status: &commonpb.Status{
 			ErrorCode: commonpb.ErrorCode_Success,
 		},
+		Infos: infos,
 	}, nil
 }
 
@@ -214,6 +254,15 @@ func returnSuccessResult() (*commonpb.Status, error) {
 	}, nil
 }
 
+// returnEmptySegmentInfos acts as a query node which acked the load request but failed to load the segments
+func returnEmptySegmentInfos(segmentIDs []UniqueID) (*querypb.GetSegmentInfoResponse, error) {
+	return &querypb.GetSegmentInfoResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+	}, nil
+}
+
 func returnFailedResult() (*commonpb.Status, error) {
 	return &commonpb.Status{
 		ErrorCode: commonpb.ErrorCode_UnexpectedError,
@@ -13,12 +13,12 @@ package querycoord
 
 import (
 	"path"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
@@ -67,6 +67,23 @@ type ParamTable struct {
 
 	//---- Handoff ---
 	AutoHandoff bool
+
+	//---- Load ---
+	VerifyLoadedSegments        bool
+	VerifyLoadedSegmentsTimeout time.Duration
+	WaitForIndexTimeout         time.Duration
+
+	// --- Session ---
+	SessionReregisterGrace time.Duration
+
+	// --- Component states ---
+	StallThreshold time.Duration
+
+	// --- Cluster ---
+	MaxQueryNodeClients int
+
+	// --- Idle release ---
+	IdleReleaseCheckInterval time.Duration
 }
 
 // Params are variables of the ParamTable type
@@ -120,6 +137,23 @@ func (p *ParamTable) Init() {
 
 	//---- Handoff ---
 	p.initAutoHandoff()
+
+	//---- Load ---
+	p.initVerifyLoadedSegments()
+	p.initVerifyLoadedSegmentsTimeout()
+	p.initWaitForIndexTimeout()
+
+	// --- Session ---
+	p.initSessionReregisterGrace()
+
+	// --- Component states ---
+	p.initStallThreshold()
+
+	// --- Cluster ---
+	p.initMaxQueryNodeClients()
+
+	// --- Idle release ---
+	p.initIdleReleaseCheckInterval()
 }
 
 func (p *ParamTable) initQueryCoordAddress() {
@@ -135,6 +169,9 @@ func (p *ParamTable) initClusterMsgChannelPrefix() {
 	if err != nil {
 		panic(err)
 	}
+	if err = channelutil.ValidatePrefix(config); err != nil {
+		panic(err)
+	}
 	p.ClusterChannelPrefix = config
 }
 
@@ -144,8 +181,7 @@ func (p *ParamTable) initSearchChannelPrefix() {
 		log.Error(err.Error())
 	}
 
-	s := []string{p.ClusterChannelPrefix, config}
-	p.SearchChannelPrefix = strings.Join(s, "-")
+	p.SearchChannelPrefix = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initSearchResultChannelPrefix() {
@@ -153,8 +189,7 @@ func (p *ParamTable) initSearchResultChannelPrefix() {
 	if err != nil {
 		log.Error(err.Error())
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.SearchResultChannelPrefix = strings.Join(s, "-")
+	p.SearchResultChannelPrefix = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initStatsChannelName() {
@@ -162,8 +197,7 @@ func (p *ParamTable) initStatsChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.StatsChannelName = strings.Join(s, "-")
+	p.StatsChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initTimeTickChannelName() {
@@ -171,8 +205,7 @@ func (p *ParamTable) initTimeTickChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.TimeTickChannelName = strings.Join(s, "-")
+	p.TimeTickChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initEtcdEndpoints() {
@@ -232,11 +265,7 @@ func (p *ParamTable) initMinioSecretAccessKey() {
 }
 
 func (p *ParamTable) initMinioUseSSLStr() {
-	ssl, err := p.Load("minio.useSSL")
-	if err != nil {
-		panic(err)
-	}
-	sslBoolean, err := strconv.ParseBool(ssl)
+	sslBoolean, err := p.TryParseBool("minio.useSSL", false)
 	if err != nil {
 		panic(err)
 	}
@@ -264,12 +293,65 @@ func (p *ParamTable) initPulsarAddress() {
 }
 
 func (p *ParamTable) initAutoHandoff() {
-	handoff, err := p.Load("queryCoord.autoHandoff")
+	handoff, err := p.TryParseBool("queryCoord.autoHandoff", false)
+	if err != nil {
+		panic(err)
+	}
+	p.AutoHandoff = handoff
+}
+
+func (p *ParamTable) initVerifyLoadedSegments() {
+	verify, err := p.TryParseBool("queryCoord.verifyLoadedSegments", false)
 	if err != nil {
 		panic(err)
 	}
-	p.AutoHandoff, err = strconv.ParseBool(handoff)
+	p.VerifyLoadedSegments = verify
+}
+
+func (p *ParamTable) initVerifyLoadedSegmentsTimeout() {
+	timeout, err := p.ParseDuration("queryCoord.verifyLoadedSegmentsTimeout", 10*time.Second, time.Second)
+	if err != nil {
+		panic(err)
+	}
+	p.VerifyLoadedSegmentsTimeout = timeout
+}
+
+func (p *ParamTable) initWaitForIndexTimeout() {
+	timeout, err := p.ParseDuration("queryCoord.waitForIndexTimeout", 600*time.Second, time.Second)
+	if err != nil {
+		panic(err)
+	}
+	p.WaitForIndexTimeout = timeout
+}
+
+func (p *ParamTable) initSessionReregisterGrace() {
+	grace, err := p.ParseDuration("common.session.reregisterGrace", 30*time.Second, time.Second)
+	if err != nil {
+		panic(err)
+	}
+	p.SessionReregisterGrace = grace
+}
+
+func (p *ParamTable) initStallThreshold() {
+	threshold, err := p.ParseDuration("queryCoord.stallThreshold", 300*time.Second, time.Second)
+	if err != nil {
+		panic(err)
+	}
+	p.StallThreshold = threshold
+}
+
+func (p *ParamTable) initMaxQueryNodeClients() {
+	maxClients, err := p.ParseInt64WithBounds("queryCoord.maxQueryNodeClients", 1024, 1, 65536)
+	if err != nil {
+		panic(err)
+	}
+	p.MaxQueryNodeClients = int(maxClients)
+}
+
+func (p *ParamTable) initIdleReleaseCheckInterval() {
+	interval, err := p.ParseDuration("queryCoord.idleReleaseCheckInterval", 60*time.Second, time.Second)
 	if err != nil {
 		panic(err)
 	}
+	p.IdleReleaseCheckInterval = interval
 }
@@ -17,7 +17,6 @@ import (
 
 	"fmt"
 	"math/rand"
-	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -34,7 +33,9 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
@@ -69,16 +70,20 @@ type QueryCoord struct {
 	scheduler    *TaskScheduler
 	idAllocator  func() (UniqueID, error)
 
+	idleReleaseChecker  *idleReleaseChecker
 	metricsCacheManager *metricsinfo.MetricsCacheManager
+	configUpdater       *paramtable.ConfigUpdater
 
-	dataCoordClient types.DataCoord
-	rootCoordClient types.RootCoord
+	dataCoordClient  types.DataCoord
+	rootCoordClient  types.RootCoord
+	indexCoordClient types.IndexCoord
 
 	session   *sessionutil.Session
 	eventChan <-chan *sessionutil.SessionEvent
 
-	stateCode  atomic.Value
-	enableGrpc bool
+	stateCode   atomic.Value
+	stateProber componentStateProber
+	enableGrpc  bool
 
 	msFactory msgstream.Factory
 }
@@ -146,13 +151,16 @@ func (qc *QueryCoord) Init() error {
 		}
 
 		// init task scheduler
-		qc.scheduler, initError = NewTaskScheduler(qc.loopCtx, qc.meta, qc.cluster, qc.kvClient, qc.rootCoordClient, qc.dataCoordClient, qc.idAllocator)
+		qc.scheduler, initError = NewTaskScheduler(qc.loopCtx, qc.meta, qc.cluster, qc.kvClient, qc.rootCoordClient, qc.dataCoordClient, qc.indexCoordClient, qc.idAllocator)
 		if initError != nil {
 			log.Error("query coordinator init task scheduler failed", zap.Error(initError))
 			return
 		}
 
+		qc.idleReleaseChecker = newIdleReleaseChecker(qc.meta, qc.cluster, qc.rootCoordClient, qc.releaseIdleCollection)
+
 		qc.metricsCacheManager = metricsinfo.NewMetricsCacheManager()
+		qc.configUpdater = paramtable.NewConfigUpdater(&Params.BaseTable)
 	})
 
 	return initError
@@ -182,6 +190,9 @@ func (qc *QueryCoord) Start() error {
 	qc.loopWg.Add(1)
 	go qc.watchHandoffSegmentLoop()
 
+	qc.loopWg.Add(1)
+	go qc.idleReleaseLoop()
+
 	go qc.session.LivenessCheck(qc.loopCtx, func() {
 		qc.Stop()
 	})
@@ -194,6 +205,9 @@ func (qc *QueryCoord) Stop() error {
 	qc.scheduler.Close()
 	log.Debug("close scheduler ...")
 	qc.loopCancel()
+	if qc.cluster != nil {
+		qc.cluster.close()
+	}
 	qc.UpdateStateCode(internalpb.StateCode_Abnormal)
 
 	qc.loopWg.Wait()
@@ -212,8 +226,8 @@ func NewQueryCoord(ctx context.Context, factory msgstream.Factory) (*QueryCoord,
 	channelID := len(queryChannels)
 	searchPrefix := Params.SearchChannelPrefix
 	searchResultPrefix := Params.SearchResultChannelPrefix
-	allocatedQueryChannel := searchPrefix + "-" + strconv.FormatInt(int64(channelID), 10)
-	allocatedQueryResultChannel := searchResultPrefix + "-" + strconv.FormatInt(int64(channelID), 10)
+	allocatedQueryChannel := channelutil.QueryChannelName(searchPrefix, int64(channelID))
+	allocatedQueryResultChannel := channelutil.QueryChannelName(searchResultPrefix, int64(channelID))
 
 	queryChannels = append(queryChannels, &queryChannelInfo{
 		requestChannel:  allocatedQueryChannel,
@@ -253,6 +267,16 @@ func (qc *QueryCoord) SetDataCoord(dataCoord types.DataCoord) error {
 	return nil
 }
 
+// SetIndexCoord sets index coordinator's client
+func (qc *QueryCoord) SetIndexCoord(indexCoord types.IndexCoord) error {
+	if indexCoord == nil {
+		return errors.New("null index coordinator interface")
+	}
+
+	qc.indexCoordClient = indexCoord
+	return nil
+}
+
 func (qc *QueryCoord) watchNodeLoop() {
 	ctx, cancel := context.WithCancel(qc.loopCtx)
 	defer cancel()
@@ -271,6 +295,7 @@ func (qc *QueryCoord) watchNodeLoop() {
 				SourceID: qc.session.ServerID,
 			},
 			SourceNodeIDs: offlineNodeIDs,
+			BalanceReason: querypb.TriggerCondition_nodeDown,
 		}
 
 		baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_nodeDown)
@@ -287,7 +312,7 @@ func (qc *QueryCoord) watchNodeLoop() {
 		log.Debug("start a loadBalance task", zap.Any("task", loadBalanceTask))
 	}
 
-	qc.eventChan = qc.session.WatchServices(typeutil.QueryNodeRole, qc.cluster.getSessionVersion()+1)
+	qc.eventChan = qc.session.WatchServices(typeutil.QueryNodeRole, qc.cluster.getSessionVersion()+1, sessionutil.WithReregisterGrace(Params.SessionReregisterGrace))
 	for {
 		select {
 		case <-ctx.Done():
@@ -304,42 +329,134 @@ func (qc *QueryCoord) watchNodeLoop() {
 				if err != nil {
 					log.Error("query node failed to register", zap.Int64("nodeID", serverID), zap.String("error info", err.Error()))
 				}
+				// the node may be re-registered with the same ServerID after a restart
+				qc.recoverQueryChannels(serverID)
 				qc.metricsCacheManager.InvalidateSystemInfoMetrics()
 			case sessionutil.SessionDelEvent:
 				serverID := event.Session.ServerID
 				log.Debug("get a del event after queryNode down", zap.Int64("nodeID", serverID))
-				_, err := qc.cluster.getNodeByID(serverID)
+				online, err := qc.cluster.isOnline(serverID)
 				if err != nil {
 					log.Error("queryNode not exist", zap.Int64("nodeID", serverID))
 					continue
 				}
-
-				qc.cluster.stopNode(serverID)
-				loadBalanceSegment := &querypb.LoadBalanceRequest{
-					Base: &commonpb.MsgBase{
-						MsgType:  commonpb.MsgType_LoadBalanceSegments,
-						SourceID: qc.session.ServerID,
-					},
-					SourceNodeIDs: []int64{serverID},
-					BalanceReason: querypb.TriggerCondition_nodeDown,
+				if !online {
+					// segments have been moved away when the queryNode was marked as stopping
+					log.Debug("queryNode has been offline", zap.Int64("nodeID", serverID))
+					continue
+				}
+				qc.offlineQueryNode(serverID)
+			case sessionutil.SessionUpdateEvent:
+				serverID := event.Session.ServerID
+				if !event.Session.Stopping {
+					qc.recoverQueryChannels(serverID)
+					continue
 				}
+				log.Debug("get a stopping event of queryNode", zap.Int64("nodeID", serverID))
+				online, err := qc.cluster.isOnline(serverID)
+				if err != nil || !online {
+					continue
+				}
+				qc.offlineQueryNode(serverID)
+			}
+		}
+	}
+}
+
+// recoverQueryChannels enqueues a watchQueryChannelTask for every collection the node holds segments or dm channels of
+// but has not watched the query channel of, e.g. the node restarted between watchDmChannels and watchQueryChannel
+func (qc *QueryCoord) recoverQueryChannels(nodeID int64) {
+	online, err := qc.cluster.isOnline(nodeID)
+	if err != nil || !online {
+		return
+	}
+
+	for _, collectionID := range collectionsOnNode(qc.meta, nodeID) {
+		if qc.cluster.hasWatchedQueryChannel(qc.loopCtx, nodeID, collectionID) {
+			continue
+		}
+		queryChannelInfo, err := qc.meta.getQueryChannelInfoByID(collectionID)
+		if err != nil {
+			log.Warn("recoverQueryChannels: get query channel info failed", zap.Int64("nodeID", nodeID), zap.Int64("collectionID", collectionID), zap.Error(err))
+			continue
+		}
+		addQueryChannelRequest := &querypb.AddQueryChannelRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:  commonpb.MsgType_WatchQueryChannels,
+				SourceID: qc.session.ServerID,
+			},
+			NodeID:               nodeID,
+			CollectionID:         collectionID,
+			RequestChannelID:     queryChannelInfo.QueryChannelID,
+			ResultChannelID:      queryChannelInfo.QueryResultChannelID,
+			GlobalSealedSegments: queryChannelInfo.GlobalSealedSegments,
+			SeekPosition:         queryChannelInfo.SeekPosition,
+		}
+		watchQueryChannelTask := &watchQueryChannelTask{
+			baseTask:               newBaseTask(qc.loopCtx, querypb.TriggerCondition_nodeDown),
+			AddQueryChannelRequest: addQueryChannelRequest,
+			cluster:                qc.cluster,
+		}
+		err = qc.scheduler.Enqueue(watchQueryChannelTask)
+		if err != nil {
+			log.Error("recoverQueryChannels: enqueue watchQueryChannelTask failed", zap.Int64("nodeID", nodeID), zap.Int64("collectionID", collectionID), zap.Error(err))
+			continue
+		}
+		log.Debug("recoverQueryChannels: start a watchQueryChannel task", zap.Int64("nodeID", nodeID), zap.Int64("collectionID", collectionID))
+	}
+}
 
-				baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_nodeDown)
-				loadBalanceTask := &loadBalanceTask{
-					baseTask:           baseTask,
-					LoadBalanceRequest: loadBalanceSegment,
-					rootCoord:          qc.rootCoordClient,
-					dataCoord:          qc.dataCoordClient,
-					cluster:            qc.cluster,
-					meta:               qc.meta,
+// collectionsOnNode returns the loaded collections which have segments or dm channels on the node
+func collectionsOnNode(meta Meta, nodeID int64) []UniqueID {
+	collectionIDs := make([]UniqueID, 0)
+	for _, info := range meta.showCollections() {
+		onNode := false
+		for _, channelInfo := range info.ChannelInfos {
+			if channelInfo.NodeIDLoaded == nodeID {
+				onNode = true
+				break
+			}
+		}
+		if !onNode {
+			for _, segmentInfo := range meta.showSegmentInfos(info.CollectionID, nil) {
+				if segmentInfo.NodeID == nodeID {
+					onNode = true
+					break
 				}
-				qc.metricsCacheManager.InvalidateSystemInfoMetrics()
-				//TODO:: deal enqueue error
-				qc.scheduler.Enqueue(loadBalanceTask)
-				log.Debug("start a loadBalance task", zap.Any("task", loadBalanceTask))
 			}
 		}
+		if onNode {
+			collectionIDs = append(collectionIDs, info.CollectionID)
+		}
 	}
+	return collectionIDs
+}
+
+// offlineQueryNode stops the queryNode in cluster and moves its segments and channels to other queryNodes
+func (qc *QueryCoord) offlineQueryNode(nodeID int64) {
+	qc.cluster.stopNode(nodeID)
+	loadBalanceSegment := &querypb.LoadBalanceRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_LoadBalanceSegments,
+			SourceID: qc.session.ServerID,
+		},
+		SourceNodeIDs: []int64{nodeID},
+		BalanceReason: querypb.TriggerCondition_nodeDown,
+	}
+
+	baseTask := newBaseTask(qc.loopCtx, querypb.TriggerCondition_nodeDown)
+	loadBalanceTask := &loadBalanceTask{
+		baseTask:           baseTask,
+		LoadBalanceRequest: loadBalanceSegment,
+		rootCoord:          qc.rootCoordClient,
+		dataCoord:          qc.dataCoordClient,
+		cluster:            qc.cluster,
+		meta:               qc.meta,
+	}
+	qc.metricsCacheManager.InvalidateSystemInfoMetrics()
+	//TODO:: deal enqueue error
+	qc.scheduler.Enqueue(loadBalanceTask)
+	log.Debug("start a loadBalance task", zap.Any("task", loadBalanceTask))
 }
 
 func (qc *QueryCoord) watchHandoffSegmentLoop() {
@@ -424,3 +541,24 @@ func (qc *QueryCoord) watchHandoffSegmentLoop() {
 	}
 
 }
+
+// idleReleaseLoop checks the loaded collections idle for too long periodically
+func (qc *QueryCoord) idleReleaseLoop() {
+	defer qc.loopWg.Done()
+	if Params.IdleReleaseCheckInterval <= 0 {
+		log.Debug("query coordinator idle release is disabled")
+		return
+	}
+	log.Debug("query coordinator start idle release loop", zap.Duration("interval", Params.IdleReleaseCheckInterval))
+
+	ticker := time.NewTicker(Params.IdleReleaseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-qc.loopCtx.Done():
+			return
+		case <-ticker.C:
+			qc.idleReleaseChecker.check(qc.loopCtx)
+		}
+	}
+}
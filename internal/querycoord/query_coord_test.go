@@ -41,6 +41,8 @@ func refreshParams() {
 	Params.StatsChannelName = Params.StatsChannelName + suffix
 	Params.TimeTickChannelName = Params.TimeTickChannelName + suffix
 	Params.MetaRootPath = Params.MetaRootPath + suffix
+	// sessions are removed manually in tests, report offline nodes at once
+	Params.SessionReregisterGrace = 0
 }
 
 func TestMain(m *testing.M) {
@@ -78,6 +80,7 @@ func startQueryCoord(ctx context.Context) (*QueryCoord, error) {
 
 	coord.SetRootCoord(rootCoord)
 	coord.SetDataCoord(dataCoord)
+	coord.SetIndexCoord(newIndexCoordMock())
 
 	err = coord.Register()
 	if err != nil {
@@ -118,6 +121,7 @@ func startUnHealthyQueryCoord(ctx context.Context) (*QueryCoord, error) {
 
 	coord.SetRootCoord(rootCoord)
 	coord.SetDataCoord(dataCoord)
+	coord.SetIndexCoord(newIndexCoordMock())
 
 	err = coord.Register()
 	if err != nil {
@@ -411,3 +415,40 @@ func TestHandoffSegmentLoop(t *testing.T) {
 	err = removeAllSession()
 	assert.Nil(t, err)
 }
+
+func TestQueryChannelStableAfterRestart(t *testing.T) {
+	refreshParams()
+	baseCtx := context.Background()
+
+	queryCoord, err := startQueryCoord(baseCtx)
+	assert.Nil(t, err)
+	queryNode, err := startQueryNodeServer(baseCtx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, queryNode.queryNodeID)
+
+	req := &querypb.CreateQueryChannelRequest{
+		CollectionID: defaultCollectionID,
+	}
+	res, err := queryCoord.CreateQueryChannel(baseCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
+
+	// stop querycoord while the collection is still being loaded
+	loadCollectionTask := genLoadCollectionTask(baseCtx, queryCoord)
+	err = queryCoord.scheduler.Enqueue(loadCollectionTask)
+	assert.Nil(t, err)
+	queryCoord.Stop()
+
+	restarted, err := startQueryCoord(baseCtx)
+	assert.Nil(t, err)
+	restartedRes, err := restarted.CreateQueryChannel(baseCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, restartedRes.Status.ErrorCode)
+	assert.Equal(t, res.RequestChannel, restartedRes.RequestChannel)
+	assert.Equal(t, res.ResultChannel, restartedRes.ResultChannel)
+
+	restarted.Stop()
+	queryNode.stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
@@ -20,7 +20,6 @@ import (
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
-	nodeclient "github.com/milvus-io/milvus/internal/distributed/querynode/client"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -62,17 +61,19 @@ type Node interface {
 	loadSegments(ctx context.Context, in *querypb.LoadSegmentsRequest) error
 	releaseSegments(ctx context.Context, in *querypb.ReleaseSegmentsRequest) error
 	getComponentInfo(ctx context.Context) *internalpb.ComponentInfo
+	getAddress() string
 
 	getMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
 
 type queryNode struct {
-	ctx      context.Context
-	cancel   context.CancelFunc
-	id       int64
-	address  string
-	client   types.QueryNode
-	kvClient *etcdkv.EtcdKV
+	ctx         context.Context
+	cancel      context.CancelFunc
+	id          int64
+	address     string
+	clients     *queryNodeClientCache
+	newClientFn newQueryNodeClientFn
+	kvClient    *etcdkv.EtcdKV
 
 	sync.RWMutex
 	collectionInfos      map[UniqueID]*querypb.CollectionInfo
@@ -81,21 +82,17 @@ type queryNode struct {
 	stateLock            sync.RWMutex
 }
 
-func newQueryNode(ctx context.Context, address string, id UniqueID, kv *etcdkv.EtcdKV) (Node, error) {
+func newQueryNode(ctx context.Context, address string, id UniqueID, kv *etcdkv.EtcdKV, clients *queryNodeClientCache) (Node, error) {
 	collectionInfo := make(map[UniqueID]*querypb.CollectionInfo)
 	watchedChannels := make(map[UniqueID]*querypb.QueryChannelInfo)
 	childCtx, cancel := context.WithCancel(ctx)
-	client, err := nodeclient.NewClient(childCtx, address)
-	if err != nil {
-		cancel()
-		return nil, err
-	}
 	node := &queryNode{
 		ctx:                  childCtx,
 		cancel:               cancel,
 		id:                   id,
 		address:              address,
-		client:               client,
+		clients:              clients,
+		newClientFn:          newQueryNodeClient,
 		kvClient:             kv,
 		collectionInfos:      collectionInfo,
 		watchedQueryChannels: watchedChannels,
@@ -105,13 +102,14 @@ func newQueryNode(ctx context.Context, address string, id UniqueID, kv *etcdkv.E
 	return node, nil
 }
 
+// getClient returns the client of the queryNode from the client cache of the cluster
+func (qn *queryNode) getClient(ctx context.Context) (types.QueryNode, error) {
+	return qn.clients.get(ctx, qn.id, qn.address, qn.newClientFn)
+}
+
 func (qn *queryNode) start() error {
-	if err := qn.client.Init(); err != nil {
-		log.Error("Start: init queryNode client failed", zap.Int64("nodeID", qn.id), zap.String("error", err.Error()))
-		return err
-	}
-	if err := qn.client.Start(); err != nil {
-		log.Error("Start: start queryNode client failed", zap.Int64("nodeID", qn.id), zap.String("error", err.Error()))
+	if _, err := qn.getClient(qn.ctx); err != nil {
+		log.Error("Start: connect to queryNode failed", zap.Int64("nodeID", qn.id), zap.String("error", err.Error()))
 		return err
 	}
 
@@ -128,9 +126,7 @@ func (qn *queryNode) stop() {
 	qn.stateLock.Lock()
 	defer qn.stateLock.Unlock()
 	qn.state = offline
-	if qn.client != nil {
-		qn.client.Stop()
-	}
+	qn.clients.remove(qn.id)
 	qn.cancel()
 }
 
@@ -391,15 +387,19 @@ func (qn *queryNode) isOffline() bool {
 //***********************grpc req*************************//
 func (qn *queryNode) watchDmChannels(ctx context.Context, in *querypb.WatchDmChannelsRequest) error {
 	if !qn.isOnline() {
-		return errors.New("WatchDmChannels: queryNode is offline")
+		return errQueryNodeOffline("WatchDmChannels")
 	}
 
-	status, err := qn.client.WatchDmChannels(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.WatchDmChannels(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 	channels := make([]string, 0)
 	for _, info := range in.Infos {
@@ -415,15 +415,19 @@ func (qn *queryNode) watchDmChannels(ctx context.Context, in *querypb.WatchDmCha
 
 func (qn *queryNode) addQueryChannel(ctx context.Context, in *querypb.AddQueryChannelRequest) error {
 	if !qn.isOnline() {
-		return errors.New("AddQueryChannel: queryNode is offline")
+		return errQueryNodeOffline("AddQueryChannel")
 	}
 
-	status, err := qn.client.AddQueryChannel(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.AddQueryChannel(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 
 	queryChannelInfo := &querypb.QueryChannelInfo{
@@ -440,12 +444,16 @@ func (qn *queryNode) removeQueryChannel(ctx context.Context, in *querypb.RemoveQ
 		return nil
 	}
 
-	status, err := qn.client.RemoveQueryChannel(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.RemoveQueryChannel(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 
 	qn.removeQueryChannelInfo(in.CollectionID)
@@ -458,12 +466,16 @@ func (qn *queryNode) releaseCollection(ctx context.Context, in *querypb.ReleaseC
 		return nil
 	}
 
-	status, err := qn.client.ReleaseCollection(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.ReleaseCollection(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 
 	err = qn.releaseCollectionInfo(in.CollectionID)
@@ -479,12 +491,16 @@ func (qn *queryNode) releasePartitions(ctx context.Context, in *querypb.ReleaseP
 		return nil
 	}
 
-	status, err := qn.client.ReleasePartitions(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.ReleasePartitions(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 	err = qn.releasePartitionsInfo(in.CollectionID, in.PartitionIDs)
 	if err != nil {
@@ -499,7 +515,11 @@ func (qn *queryNode) getSegmentInfo(ctx context.Context, in *querypb.GetSegmentI
 		return nil, nil
 	}
 
-	res, err := qn.client.GetSegmentInfo(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	res, err := client.GetSegmentInfo(ctx, in)
 	if err == nil && res.Status.ErrorCode == commonpb.ErrorCode_Success {
 		return res, nil
 	}
@@ -507,6 +527,10 @@ func (qn *queryNode) getSegmentInfo(ctx context.Context, in *querypb.GetSegmentI
 	return nil, nil
 }
 
+func (qn *queryNode) getAddress() string {
+	return qn.address
+}
+
 func (qn *queryNode) getComponentInfo(ctx context.Context) *internalpb.ComponentInfo {
 	if !qn.isOnline() {
 		return &internalpb.ComponentInfo{
@@ -515,7 +539,14 @@ func (qn *queryNode) getComponentInfo(ctx context.Context) *internalpb.Component
 		}
 	}
 
-	res, err := qn.client.GetComponentStates(ctx)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return &internalpb.ComponentInfo{
+			NodeID:    qn.id,
+			StateCode: internalpb.StateCode_Abnormal,
+		}
+	}
+	res, err := client.GetComponentStates(ctx)
 	if err != nil || res.Status.ErrorCode != commonpb.ErrorCode_Success {
 		return &internalpb.ComponentInfo{
 			NodeID:    qn.id,
@@ -531,20 +562,28 @@ func (qn *queryNode) getMetrics(ctx context.Context, in *milvuspb.GetMetricsRequ
 		return nil, errQueryNodeIsNotOnService(qn.id)
 	}
 
-	return qn.client.GetMetrics(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetMetrics(ctx, in)
 }
 
 func (qn *queryNode) loadSegments(ctx context.Context, in *querypb.LoadSegmentsRequest) error {
 	if !qn.isOnline() {
-		return errors.New("LoadSegments: queryNode is offline")
+		return errQueryNodeOffline("LoadSegments")
 	}
 
-	status, err := qn.client.LoadSegments(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.LoadSegments(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 
 	for _, info := range in.Infos {
@@ -562,15 +601,19 @@ func (qn *queryNode) loadSegments(ctx context.Context, in *querypb.LoadSegmentsR
 
 func (qn *queryNode) releaseSegments(ctx context.Context, in *querypb.ReleaseSegmentsRequest) error {
 	if !qn.isOnline() {
-		return errors.New("ReleaseSegments: queryNode is offline")
+		return errQueryNodeOffline("ReleaseSegments")
 	}
 
-	status, err := qn.client.ReleaseSegments(ctx, in)
+	client, err := qn.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := client.ReleaseSegments(ctx, in)
 	if err != nil {
 		return err
 	}
 	if status.ErrorCode != commonpb.ErrorCode_Success {
-		return errors.New(status.Reason)
+		return errFromStatus(status)
 	}
 
 	return nil
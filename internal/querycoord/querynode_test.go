@@ -195,7 +195,7 @@ func TestNewQueryNode(t *testing.T) {
 
 	addr := queryNode1.session.Address
 	nodeID := queryNode1.queryNodeID
-	node, err := newQueryNode(baseCtx, addr, nodeID, kv)
+	node, err := newQueryNode(baseCtx, addr, nodeID, kv, newQueryNodeClientCache(baseCtx, Params.MaxQueryNodeClients))
 	assert.Nil(t, err)
 
 	err = node.start()
@@ -214,7 +214,7 @@ func TestReleaseCollectionOnOfflineNode(t *testing.T) {
 	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
 	assert.Nil(t, err)
 
-	node, err := newQueryNode(baseCtx, "test", 100, kv)
+	node, err := newQueryNode(baseCtx, "test", 100, kv, newQueryNodeClientCache(baseCtx, Params.MaxQueryNodeClients))
 	assert.Nil(t, err)
 
 	node.setState(offline)
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// waitForIndexInterval is the interval to check the index states of the segments when waiting for index
+var waitForIndexInterval = time.Second
+
+// segmentIndexChecker checks whether the segments to load are indexed before a load dispatches loadSegmentTasks
+type segmentIndexChecker struct {
+	rootCoord  types.RootCoord
+	dataCoord  types.DataCoord
+	indexCoord types.IndexCoord
+}
+
+// getSegmentIDs returns the sealed segments of the partitions, which are the segments to load
+func (c *segmentIndexChecker) getSegmentIDs(ctx context.Context, base *commonpb.MsgBase, collectionID UniqueID, partitionIDs []UniqueID) ([]UniqueID, error) {
+	segmentIDs := make([]UniqueID, 0)
+	for _, partitionID := range partitionIDs {
+		req := &datapb.GetRecoveryInfoRequest{
+			Base:         base,
+			CollectionID: collectionID,
+			PartitionID:  partitionID,
+		}
+		resp, err := c.dataCoord.GetRecoveryInfo(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+			return nil, errors.New(resp.Status.Reason)
+		}
+		for _, binlog := range resp.Binlogs {
+			segmentIDs = append(segmentIDs, binlog.SegmentID)
+		}
+	}
+	return segmentIDs, nil
+}
+
+// getUnindexedSegments returns the segments whose index is not enabled or not finished building
+func (c *segmentIndexChecker) getUnindexedSegments(ctx context.Context, collectionID UniqueID, segmentIDs []UniqueID) ([]UniqueID, error) {
+	unindexed := make([]UniqueID, 0)
+	buildID2SegmentID := make(map[UniqueID]UniqueID)
+	buildIDs := make([]UniqueID, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		req := &milvuspb.DescribeSegmentRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_DescribeSegment,
+			},
+			CollectionID: collectionID,
+			SegmentID:    segmentID,
+		}
+		resp, err := c.rootCoord.DescribeSegment(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		// root coordinator fails to describe the segment which has no index info yet
+		if resp.Status.ErrorCode != commonpb.ErrorCode_Success || !resp.EnableIndex {
+			unindexed = append(unindexed, segmentID)
+			continue
+		}
+		buildID2SegmentID[resp.BuildID] = segmentID
+		buildIDs = append(buildIDs, resp.BuildID)
+	}
+	if len(buildIDs) == 0 {
+		return unindexed, nil
+	}
+
+	resp, err := c.indexCoord.GetIndexStates(ctx, &indexpb.GetIndexStatesRequest{
+		IndexBuildIDs: buildIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return nil, errors.New(resp.Status.Reason)
+	}
+	finished := make(map[UniqueID]bool, len(resp.States))
+	for _, state := range resp.States {
+		finished[state.IndexBuildID] = state.State == commonpb.IndexState_Finished
+	}
+	for _, buildID := range buildIDs {
+		if !finished[buildID] {
+			unindexed = append(unindexed, buildID2SegmentID[buildID])
+		}
+	}
+	return unindexed, nil
+}
+
+// check applies the policy to the segments of the partitions to load,
+// it waits until all the segments are indexed for WaitForIndex, and fails with the unindexed segments for FailOnUnindexed.
+func (c *segmentIndexChecker) check(ctx context.Context, t task, policy commonpb.UnindexedSegmentPolicy, collectionID UniqueID, partitionIDs []UniqueID) error {
+	if policy == commonpb.UnindexedSegmentPolicy_LoadUnindexed {
+		return nil
+	}
+	if c.indexCoord == nil {
+		return errors.New("index coordinator is not available to check the index of the segments")
+	}
+	segmentIDs, err := c.getSegmentIDs(ctx, t.msgBase(), collectionID, partitionIDs)
+	if err != nil {
+		return err
+	}
+
+	t.setPhase(taskWaitingIndex)
+	defer t.setPhase(taskPreExecuting)
+	waitCtx, cancel := context.WithTimeout(ctx, Params.WaitForIndexTimeout)
+	defer cancel()
+	ticker := time.NewTicker(waitForIndexInterval)
+	defer ticker.Stop()
+	for {
+		unindexed, err := c.getUnindexedSegments(ctx, collectionID, segmentIDs)
+		if err != nil {
+			return err
+		}
+		if len(unindexed) == 0 {
+			return nil
+		}
+		if policy == commonpb.UnindexedSegmentPolicy_FailOnUnindexed {
+			return errSegmentsNotIndexed(collectionID, unindexed)
+		}
+		log.Debug("wait for the index of segments to load",
+			zap.Int64("taskID", t.getTaskID()),
+			zap.Int64("collectionID", collectionID),
+			zap.Int("indexed", len(segmentIDs)-len(unindexed)),
+			zap.Int("total", len(segmentIDs)),
+			zap.Int64s("unindexed", unindexed))
+		select {
+		case <-waitCtx.Done():
+			return errSegmentsNotIndexed(collectionID, unindexed)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// recoveryInfoDataCoord returns the same segments for all the partitions
+type recoveryInfoDataCoord struct {
+	types.DataCoord
+	segmentIDs []UniqueID
+}
+
+func (data *recoveryInfoDataCoord) GetRecoveryInfo(ctx context.Context, req *datapb.GetRecoveryInfoRequest) (*datapb.GetRecoveryInfoResponse, error) {
+	binlogs := make([]*datapb.SegmentBinlogs, 0, len(data.segmentIDs))
+	for _, segmentID := range data.segmentIDs {
+		binlogs = append(binlogs, &datapb.SegmentBinlogs{SegmentID: segmentID})
+	}
+	return &datapb.GetRecoveryInfoResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		Binlogs: binlogs,
+	}, nil
+}
+
+func TestSegmentIndexChecker(t *testing.T) {
+	interval, timeout := waitForIndexInterval, Params.WaitForIndexTimeout
+	waitForIndexInterval = 10 * time.Millisecond
+	Params.WaitForIndexTimeout = 10 * time.Second
+	defer func() {
+		waitForIndexInterval, Params.WaitForIndexTimeout = interval, timeout
+	}()
+
+	ctx := context.Background()
+	rootCoord := newRootCoordMock()
+	indexCoord := newIndexCoordMock()
+	checker := &segmentIndexChecker{
+		rootCoord:  rootCoord,
+		dataCoord:  &recoveryInfoDataCoord{segmentIDs: []UniqueID{1, 2, 3}},
+		indexCoord: indexCoord,
+	}
+	genTask := func() task {
+		return &loadCollectionTask{
+			baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+			LoadCollectionRequest: &querypb.LoadCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgType: commonpb.MsgType_LoadCollection,
+				},
+				CollectionID: defaultCollectionID,
+			},
+		}
+	}
+	partitionIDs := []UniqueID{defaultPartitionID}
+
+	t.Run("Test load unindexed", func(t *testing.T) {
+		rootCoord.setEnableIndex(false)
+		err := checker.check(ctx, genTask(), commonpb.UnindexedSegmentPolicy_LoadUnindexed, defaultCollectionID, partitionIDs)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Test fail on unindexed", func(t *testing.T) {
+		rootCoord.setEnableIndex(false)
+		err := checker.check(ctx, genTask(), commonpb.UnindexedSegmentPolicy_FailOnUnindexed, defaultCollectionID, partitionIDs)
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IndexNotExist, errorCodeOf(err))
+		assert.Contains(t, err.Error(), "[1 2 3]")
+	})
+
+	t.Run("Test fail on index in progress", func(t *testing.T) {
+		rootCoord.setEnableIndex(true)
+		indexCoord.delayIndexBuild(time.Hour)
+		err := checker.check(ctx, genTask(), commonpb.UnindexedSegmentPolicy_FailOnUnindexed, defaultCollectionID, partitionIDs)
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IndexNotExist, errorCodeOf(err))
+	})
+
+	t.Run("Test wait for index", func(t *testing.T) {
+		rootCoord.setEnableIndex(true)
+		delay := 200 * time.Millisecond
+		indexCoord.delayIndexBuild(delay)
+		start := time.Now()
+		loadTask := genTask()
+		err := checker.check(ctx, loadTask, commonpb.UnindexedSegmentPolicy_WaitForIndex, defaultCollectionID, partitionIDs)
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) >= delay)
+		phase, _ := loadTask.getPhase()
+		assert.Equal(t, taskPreExecuting, phase)
+	})
+
+	t.Run("Test wait for index timeout", func(t *testing.T) {
+		Params.WaitForIndexTimeout = 100 * time.Millisecond
+		defer func() {
+			Params.WaitForIndexTimeout = 10 * time.Second
+		}()
+		rootCoord.setEnableIndex(true)
+		indexCoord.delayIndexBuild(time.Hour)
+		err := checker.check(ctx, genTask(), commonpb.UnindexedSegmentPolicy_WaitForIndex, defaultCollectionID, partitionIDs)
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IndexNotExist, errorCodeOf(err))
+	})
+
+	t.Run("Test without index coordinator", func(t *testing.T) {
+		checker := &segmentIndexChecker{
+			rootCoord: rootCoord,
+			dataCoord: checker.dataCoord,
+		}
+		err := checker.check(ctx, genTask(), commonpb.UnindexedSegmentPolicy_WaitForIndex, defaultCollectionID, partitionIDs)
+		assert.Error(t, err)
+	})
+}
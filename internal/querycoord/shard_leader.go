@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// getShardLeaders returns a shard leader for every dm channel of the collection, which is the query node watching the channel.
+// Every sealed segment of the collection is assigned to exactly one shard, a segment held by a shard leader is assigned
+// to the shard of its holder, the others are assigned to the shards in turn, so that each segment is searched only once.
+func getShardLeaders(m Meta, cluster Cluster, collectionID UniqueID) ([]*querypb.ShardLeader, error) {
+	info, err := m.getCollectionInfoByID(collectionID)
+	if err != nil {
+		return nil, errCollectionNotFound(collectionID, err.Error())
+	}
+
+	getOnlineNode := func(nodeID int64) (Node, error) {
+		node, err := cluster.getNodeByID(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		if !node.isOnline() {
+			return nil, errQueryNodeIsNotOnService(nodeID)
+		}
+		return node, nil
+	}
+
+	shards := make([]*querypb.ShardLeader, 0)
+	for _, channelInfo := range info.ChannelInfos {
+		for _, channel := range channelInfo.ChannelIDs {
+			shards = append(shards, &querypb.ShardLeader{
+				ChannelName: channel,
+				NodeID:      channelInfo.NodeIDLoaded,
+			})
+		}
+	}
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("no dm channel of collection %d is watched", collectionID)
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].ChannelName < shards[j].ChannelName
+	})
+
+	// the first shard led by a node searches the segments held by the node
+	shardOfLeader := make(map[int64]*querypb.ShardLeader)
+	for _, shard := range shards {
+		node, err := getOnlineNode(shard.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("shard leader of channel %s is not available, %s", shard.ChannelName, err.Error())
+		}
+		shard.Address = node.getAddress()
+		if _, ok := shardOfLeader[shard.NodeID]; !ok {
+			shardOfLeader[shard.NodeID] = shard
+		}
+	}
+
+	segmentInfos := m.showSegmentInfos(collectionID, nil)
+	sort.Slice(segmentInfos, func(i, j int) bool {
+		return segmentInfos[i].SegmentID < segmentInfos[j].SegmentID
+	})
+	next := 0
+	for _, segmentInfo := range segmentInfos {
+		shard, ok := shardOfLeader[segmentInfo.NodeID]
+		if !ok {
+			shard = shards[next%len(shards)]
+			next++
+		}
+		var segments *querypb.SegmentsOnNode
+		for _, s := range shard.SealedSegments {
+			if s.NodeID == segmentInfo.NodeID {
+				segments = s
+				break
+			}
+		}
+		if segments == nil {
+			node, err := getOnlineNode(segmentInfo.NodeID)
+			if err != nil {
+				return nil, fmt.Errorf("query node of segment %d is not available, %s", segmentInfo.SegmentID, err.Error())
+			}
+			segments = &querypb.SegmentsOnNode{
+				NodeID:      segmentInfo.NodeID,
+				NodeAddress: node.getAddress(),
+			}
+			shard.SealedSegments = append(shard.SealedSegments, segments)
+		}
+		segments.SegmentIDs = append(segments.SegmentIDs, segmentInfo.SegmentID)
+	}
+
+	return shards, nil
+}
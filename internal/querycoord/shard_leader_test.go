@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querycoord
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+type shardLeaderTestNode struct {
+	Node
+	address string
+	online  bool
+}
+
+func (n *shardLeaderTestNode) isOnline() bool {
+	return n.online
+}
+
+func (n *shardLeaderTestNode) getAddress() string {
+	return n.address
+}
+
+type shardLeaderTestCluster struct {
+	Cluster
+	nodes map[int64]*shardLeaderTestNode
+}
+
+func (c *shardLeaderTestCluster) getNodeByID(nodeID int64) (Node, error) {
+	if node, ok := c.nodes[nodeID]; ok {
+		return node, nil
+	}
+	return nil, fmt.Errorf("query node %d not exist", nodeID)
+}
+
+func TestGetShardLeaders(t *testing.T) {
+	newMeta := func() *MetaReplica {
+		meta := newMetaReplica(context.Background(), nil)
+		meta.collections[defaultCollectionID] = newCollectionMeta(&querypb.CollectionInfo{
+			CollectionID: defaultCollectionID,
+			ChannelInfos: []*querypb.DmChannelInfo{
+				{NodeIDLoaded: 1, ChannelIDs: []string{"dml-1"}},
+				{NodeIDLoaded: 2, ChannelIDs: []string{"dml-0"}},
+			},
+		})
+		meta.putSegmentInfos(map[UniqueID]*querypb.SegmentInfo{
+			10: {SegmentID: 10, CollectionID: defaultCollectionID, NodeID: 1},
+			11: {SegmentID: 11, CollectionID: defaultCollectionID, NodeID: 3},
+			12: {SegmentID: 12, CollectionID: defaultCollectionID, NodeID: 2},
+			13: {SegmentID: 13, CollectionID: defaultCollectionID, NodeID: 3},
+		})
+		return meta
+	}
+	newCluster := func() *shardLeaderTestCluster {
+		return &shardLeaderTestCluster{
+			nodes: map[int64]*shardLeaderTestNode{
+				1: {address: "node1", online: true},
+				2: {address: "node2", online: true},
+				3: {address: "node3", online: true},
+			},
+		}
+	}
+
+	t.Run("assign segments", func(t *testing.T) {
+		shards, err := getShardLeaders(newMeta(), newCluster(), defaultCollectionID)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(shards))
+
+		assert.Equal(t, "dml-0", shards[0].ChannelName)
+		assert.Equal(t, int64(2), shards[0].NodeID)
+		assert.Equal(t, "node2", shards[0].Address)
+		assert.Equal(t, []*querypb.SegmentsOnNode{
+			{NodeID: 3, NodeAddress: "node3", SegmentIDs: []int64{11}},
+			{NodeID: 2, NodeAddress: "node2", SegmentIDs: []int64{12}},
+		}, shards[0].SealedSegments)
+
+		assert.Equal(t, "dml-1", shards[1].ChannelName)
+		assert.Equal(t, int64(1), shards[1].NodeID)
+		assert.Equal(t, "node1", shards[1].Address)
+		assert.Equal(t, []*querypb.SegmentsOnNode{
+			{NodeID: 1, NodeAddress: "node1", SegmentIDs: []int64{10}},
+			{NodeID: 3, NodeAddress: "node3", SegmentIDs: []int64{13}},
+		}, shards[1].SealedSegments)
+	})
+
+	t.Run("collection not loaded", func(t *testing.T) {
+		_, err := getShardLeaders(newMeta(), newCluster(), defaultCollectionID+1)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("leader offline", func(t *testing.T) {
+		cluster := newCluster()
+		cluster.nodes[1].online = false
+		_, err := getShardLeaders(newMeta(), cluster, defaultCollectionID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("segment holder not exist", func(t *testing.T) {
+		cluster := newCluster()
+		delete(cluster.nodes, 3)
+		_, err := getShardLeaders(newMeta(), cluster, defaultCollectionID)
+		assert.NotNil(t, err)
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	"github.com/opentracing/opentracing-go"
@@ -57,6 +58,57 @@ const (
 	taskFailed  taskState = 5
 )
 
+func (state taskState) String() string {
+	switch state {
+	case taskUndo:
+		return "undo"
+	case taskDoing:
+		return "doing"
+	case taskDone:
+		return "done"
+	case taskExpired:
+		return "expired"
+	case taskFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("taskState(%d)", int(state))
+	}
+}
+
+// taskPhase tells which step of the scheduling the task is in, the time spent in each phase is exposed for debugging
+type taskPhase int
+
+const (
+	// taskEnqueued means the task is waiting in the queue to be executed
+	taskEnqueued taskPhase = iota
+	taskPreExecuting
+	// taskWaitingIndex means the load task is waiting for the index of its segments to be built
+	taskWaitingIndex
+	taskExecuting
+	// taskWaitingChildren means the trigger task is waiting for its child tasks to be done
+	taskWaitingChildren
+	taskPostExecuting
+)
+
+func (phase taskPhase) String() string {
+	switch phase {
+	case taskEnqueued:
+		return "enqueued"
+	case taskPreExecuting:
+		return "preExecuting"
+	case taskWaitingIndex:
+		return "waitingIndex"
+	case taskExecuting:
+		return "executing"
+	case taskWaitingChildren:
+		return "waitingChildren"
+	case taskPostExecuting:
+		return "postExecuting"
+	default:
+		return fmt.Sprintf("taskPhase(%d)", int(phase))
+	}
+}
+
 type task interface {
 	traceCtx() context.Context
 	getTaskID() UniqueID // return ReqId
@@ -86,6 +138,9 @@ type task interface {
 	setResultInfo(err error)
 	getResultInfo() *commonpb.Status
 	updateTaskProcess()
+	setPhase(phase taskPhase)
+	getPhase() (taskPhase, time.Time)
+	getEnqueueTime() time.Time
 }
 
 type baseTask struct {
@@ -99,6 +154,11 @@ type baseTask struct {
 	retryCount int
 	//sync.RWMutex
 
+	phase       taskPhase
+	phaseTime   time.Time
+	enqueueTime time.Time
+	phaseMu     sync.RWMutex
+
 	taskID           UniqueID
 	triggerCondition querypb.TriggerCondition
 	parentTask       task
@@ -109,6 +169,7 @@ type baseTask struct {
 func newBaseTask(ctx context.Context, triggerType querypb.TriggerCondition) *baseTask {
 	childCtx, cancel := context.WithCancel(ctx)
 	condition := newTaskCondition(childCtx)
+	now := time.Now()
 
 	baseTask := &baseTask{
 		ctx:              childCtx,
@@ -118,6 +179,9 @@ func newBaseTask(ctx context.Context, triggerType querypb.TriggerCondition) *bas
 		retryCount:       MaxRetryNum,
 		triggerCondition: triggerType,
 		childTasks:       []task{},
+		phase:            taskEnqueued,
+		phaseTime:        now,
+		enqueueTime:      now,
 	}
 
 	return baseTask
@@ -203,6 +267,25 @@ func (bt *baseTask) setState(state taskState) {
 	bt.state = state
 }
 
+// setPhase records the phase the task enters and when
+func (bt *baseTask) setPhase(phase taskPhase) {
+	bt.phaseMu.Lock()
+	defer bt.phaseMu.Unlock()
+	bt.phase = phase
+	bt.phaseTime = time.Now()
+}
+
+// getPhase returns the phase of the task and when it was entered
+func (bt *baseTask) getPhase() (taskPhase, time.Time) {
+	bt.phaseMu.RLock()
+	defer bt.phaseMu.RUnlock()
+	return bt.phase, bt.phaseTime
+}
+
+func (bt *baseTask) getEnqueueTime() time.Time {
+	return bt.enqueueTime
+}
+
 func (bt *baseTask) isRetryable() bool {
 	return bt.retryCount > 0
 }
@@ -217,10 +300,12 @@ func (bt *baseTask) setResultInfo(err error) {
 	if err == nil {
 		bt.result.ErrorCode = commonpb.ErrorCode_Success
 		bt.result.Reason = ""
+		bt.result.Detail = ""
 		return
 	}
 
-	bt.result.ErrorCode = commonpb.ErrorCode_UnexpectedError
+	bt.result.ErrorCode = errorCodeOf(err)
+	bt.result.Detail = errorKindOf(err)
 	bt.result.Reason = bt.result.Reason + ", " + err.Error()
 }
 
@@ -242,10 +327,11 @@ func (bt *baseTask) rollBack(ctx context.Context) []task {
 type loadCollectionTask struct {
 	*baseTask
 	*querypb.LoadCollectionRequest
-	rootCoord types.RootCoord
-	dataCoord types.DataCoord
-	cluster   Cluster
-	meta      Meta
+	rootCoord  types.RootCoord
+	dataCoord  types.DataCoord
+	indexCoord types.IndexCoord
+	cluster    Cluster
+	meta       Meta
 }
 
 func (lct *loadCollectionTask) msgBase() *commonpb.MsgBase {
@@ -264,28 +350,66 @@ func (lct *loadCollectionTask) timestamp() Timestamp {
 	return lct.Base.Timestamp
 }
 
-func (lct *loadCollectionTask) updateTaskProcess() {
-	collectionID := lct.CollectionID
-	childTasks := lct.getChildTask()
-	allDone := true
+// childTasksProgress returns the percentage of the done child tasks,
+// 100 is returned only if all of them are done
+func childTasksProgress(childTasks []task) int64 {
+	if len(childTasks) == 0 {
+		return 100
+	}
+	done := 0
 	for _, t := range childTasks {
-		if t.getState() != taskDone {
-			allDone = false
+		if t.getState() == taskDone {
+			done++
 		}
 	}
-	if allDone {
-		err := lct.meta.setLoadPercentage(collectionID, 0, 100, querypb.LoadType_loadCollection)
-		if err != nil {
-			log.Error("loadCollectionTask: set load percentage to meta's collectionInfo", zap.Int64("collectionID", collectionID))
-			lct.setResultInfo(err)
-		}
+	return int64(done * 100 / len(childTasks))
+}
+
+func (lct *loadCollectionTask) updateTaskProcess() {
+	collectionID := lct.CollectionID
+	percentage := childTasksProgress(lct.getChildTask())
+	err := lct.meta.setLoadPercentage(collectionID, 0, percentage, querypb.LoadType_loadCollection)
+	if err != nil {
+		log.Error("loadCollectionTask: set load percentage to meta's collectionInfo", zap.Int64("collectionID", collectionID))
+		lct.setResultInfo(err)
 	}
 }
 
 func (lct *loadCollectionTask) preExecute(ctx context.Context) error {
 	collectionID := lct.CollectionID
-	schema := lct.Schema
 	lct.setResultInfo(nil)
+	schema, err := describeCollection(ctx, lct.rootCoord, collectionID, lct.Base.Timestamp)
+	if err != nil {
+		return err
+	}
+	// all the child tasks carry the schema of root coordinator
+	lct.Schema = schema
+
+	if lct.UnindexedSegmentPolicy != commonpb.UnindexedSegmentPolicy_LoadUnindexed {
+		showPartitionRequest := &milvuspb.ShowPartitionsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:   commonpb.MsgType_ShowPartitions,
+				Timestamp: lct.Base.Timestamp,
+			},
+			CollectionID: collectionID,
+		}
+		showPartitionResponse, err := lct.rootCoord.ShowPartitions(ctx, showPartitionRequest)
+		if err != nil {
+			return err
+		}
+		if showPartitionResponse.Status.ErrorCode != commonpb.ErrorCode_Success {
+			return errors.New(showPartitionResponse.Status.Reason)
+		}
+		checker := &segmentIndexChecker{
+			rootCoord:  lct.rootCoord,
+			dataCoord:  lct.dataCoord,
+			indexCoord: lct.indexCoord,
+		}
+		err = checker.check(ctx, lct, lct.UnindexedSegmentPolicy, collectionID, showPartitionResponse.PartitionIDs)
+		if err != nil {
+			return err
+		}
+	}
 	log.Debug("start do loadCollectionTask",
 		zap.Int64("msgID", lct.getTaskID()),
 		zap.Int64("collectionID", collectionID),
@@ -427,7 +551,7 @@ func (lct *loadCollectionTask) execute(ctx context.Context) error {
 		}
 	}
 
-	err = assignInternalTask(ctx, collectionID, lct, lct.meta, lct.cluster, loadSegmentReqs, watchDmChannelReqs, false)
+	err = assignInternalTask(ctx, collectionID, lct, lct.meta, lct.cluster, loadSegmentReqs, watchDmChannelReqs, false, nil)
 	if err != nil {
 		log.Warn("loadCollectionTask: assign child task failed", zap.Int64("collectionID", collectionID))
 		lct.setResultInfo(err)
@@ -606,10 +730,12 @@ func (rct *releaseCollectionTask) rollBack(ctx context.Context) []task {
 type loadPartitionTask struct {
 	*baseTask
 	*querypb.LoadPartitionsRequest
-	dataCoord types.DataCoord
-	cluster   Cluster
-	meta      Meta
-	addCol    bool
+	rootCoord  types.RootCoord
+	dataCoord  types.DataCoord
+	indexCoord types.IndexCoord
+	cluster    Cluster
+	meta       Meta
+	addCol     bool
 }
 
 func (lpt *loadPartitionTask) msgBase() *commonpb.MsgBase {
@@ -631,27 +757,64 @@ func (lpt *loadPartitionTask) timestamp() Timestamp {
 func (lpt *loadPartitionTask) updateTaskProcess() {
 	collectionID := lpt.CollectionID
 	partitionIDs := lpt.PartitionIDs
-	childTasks := lpt.getChildTask()
-	allDone := true
-	for _, t := range childTasks {
-		if t.getState() != taskDone {
-			allDone = false
-		}
-	}
-	if allDone {
-		for _, id := range partitionIDs {
-			err := lpt.meta.setLoadPercentage(collectionID, id, 100, querypb.LoadType_LoadPartition)
-			if err != nil {
-				log.Error("loadPartitionTask: set load percentage to meta's collectionInfo", zap.Int64("collectionID", collectionID), zap.Int64("partitionID", id))
-				lpt.setResultInfo(err)
-			}
+	percentage := childTasksProgress(lpt.getChildTask())
+	for _, id := range partitionIDs {
+		err := lpt.meta.setLoadPercentage(collectionID, id, percentage, querypb.LoadType_LoadPartition)
+		if err != nil {
+			log.Error("loadPartitionTask: set load percentage to meta's collectionInfo", zap.Int64("collectionID", collectionID), zap.Int64("partitionID", id))
+			lpt.setResultInfo(err)
 		}
 	}
 }
 
-func (lpt *loadPartitionTask) preExecute(context.Context) error {
+func (lpt *loadPartitionTask) preExecute(ctx context.Context) error {
 	collectionID := lpt.CollectionID
 	lpt.setResultInfo(nil)
+	schema, err := describeCollection(ctx, lpt.rootCoord, collectionID, lpt.Base.Timestamp)
+	if err != nil {
+		return err
+	}
+	// all the child tasks carry the schema of root coordinator
+	lpt.Schema = schema
+
+	showPartitionRequest := &milvuspb.ShowPartitionsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_ShowPartitions,
+			Timestamp: lpt.Base.Timestamp,
+		},
+		CollectionID: collectionID,
+	}
+	showPartitionResponse, err := lpt.rootCoord.ShowPartitions(ctx, showPartitionRequest)
+	if err != nil {
+		return err
+	}
+	if showPartitionResponse.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return errors.New(showPartitionResponse.Status.Reason)
+	}
+	existPartitions := make(map[UniqueID]struct{}, len(showPartitionResponse.PartitionIDs))
+	for _, partitionID := range showPartitionResponse.PartitionIDs {
+		existPartitions[partitionID] = struct{}{}
+	}
+	notFoundPartitionIDs := make([]UniqueID, 0)
+	for _, partitionID := range lpt.PartitionIDs {
+		if _, ok := existPartitions[partitionID]; !ok {
+			notFoundPartitionIDs = append(notFoundPartitionIDs, partitionID)
+		}
+	}
+	if len(notFoundPartitionIDs) > 0 {
+		return errPartitionNotFound(collectionID, notFoundPartitionIDs)
+	}
+
+	checker := &segmentIndexChecker{
+		rootCoord:  lpt.rootCoord,
+		dataCoord:  lpt.dataCoord,
+		indexCoord: lpt.indexCoord,
+	}
+	err = checker.check(ctx, lpt, lpt.UnindexedSegmentPolicy, collectionID, lpt.PartitionIDs)
+	if err != nil {
+		return err
+	}
+
 	log.Debug("start do loadPartitionTask",
 		zap.Int64("msgID", lpt.getTaskID()),
 		zap.Int64("collectionID", collectionID))
@@ -729,7 +892,7 @@ func (lpt *loadPartitionTask) execute(ctx context.Context) error {
 			log.Debug("loadPartitionTask: set watchDmChannelsRequests", zap.Any("request", watchDmRequest), zap.Int64("collectionID", collectionID))
 		}
 	}
-	err := assignInternalTask(ctx, collectionID, lpt, lpt.meta, lpt.cluster, loadSegmentReqs, watchDmReqs, false)
+	err := assignInternalTask(ctx, collectionID, lpt, lpt.meta, lpt.cluster, loadSegmentReqs, watchDmReqs, false, nil)
 	if err != nil {
 		log.Warn("loadPartitionTask: assign child task failed", zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", partitionIDs))
 		lpt.setResultInfo(err)
@@ -985,6 +1148,15 @@ func (lst *loadSegmentTask) execute(ctx context.Context) error {
 		lst.retryCount--
 	}()
 
+	// the task ID is the version of this assignment, it grows on every reassignment and never repeats,
+	// query nodes only serve the copy whose version is the one saved into the meta
+	for _, info := range lst.Infos {
+		info.Version = lst.getTaskID()
+	}
+
+	// query node responds after the segments are loaded, and warmed up if warm-up is enabled,
+	// so the child task is done only when the segments are ready to serve queries
+	start := time.Now()
 	err := lst.cluster.loadSegments(ctx, lst.DstNodeID, lst.LoadSegmentsRequest)
 	if err != nil {
 		log.Warn("loadSegmentTask: loadSegment occur error", zap.Int64("taskID", lst.getTaskID()))
@@ -992,8 +1164,79 @@ func (lst *loadSegmentTask) execute(ctx context.Context) error {
 		return err
 	}
 
+	if Params.VerifyLoadedSegments {
+		err = lst.verifyLoadedSegments(ctx)
+		if err != nil {
+			log.Warn("loadSegmentTask: verify loaded segments failed",
+				zap.Int64("taskID", lst.getTaskID()),
+				zap.Int64("nodeID", lst.DstNodeID),
+				zap.Error(err))
+			// the query node acked the request but failed to load the segments,
+			// load them on another node instead of retrying on the same one
+			lst.retryCount = 0
+			lst.setResultInfo(err)
+			return err
+		}
+	}
+
 	log.Debug("loadSegmentTask Execute done",
-		zap.Int64("taskID", lst.getTaskID()))
+		zap.Int64("taskID", lst.getTaskID()),
+		zap.Duration("timeCost", time.Since(start)))
+	return nil
+}
+
+// verifyLoadedSegments waits until the query node reports all the segments loaded with the expected row count,
+// it fails if the segments are still missing or mismatched after Params.VerifyLoadedSegmentsTimeout
+func (lst *loadSegmentTask) verifyLoadedSegments(ctx context.Context) error {
+	collectionID := lst.Infos[0].CollectionID
+	segmentIDs := make([]UniqueID, 0, len(lst.Infos))
+	for _, info := range lst.Infos {
+		segmentIDs = append(segmentIDs, info.SegmentID)
+	}
+	req := &querypb.GetSegmentInfoRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_SegmentInfo,
+		},
+		SegmentIDs:   segmentIDs,
+		CollectionID: collectionID,
+	}
+
+	verifyCtx, cancel := context.WithTimeout(ctx, Params.VerifyLoadedSegmentsTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		err := lst.checkLoadedSegments(verifyCtx, req)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-verifyCtx.Done():
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+func (lst *loadSegmentTask) checkLoadedSegments(ctx context.Context, req *querypb.GetSegmentInfoRequest) error {
+	infos, err := lst.cluster.getSegmentInfoByNode(ctx, lst.DstNodeID, req)
+	if err != nil {
+		return err
+	}
+	loaded := make(map[UniqueID]*querypb.SegmentInfo, len(infos))
+	for _, info := range infos {
+		loaded[info.SegmentID] = info
+	}
+	for _, info := range lst.Infos {
+		segmentInfo, ok := loaded[info.SegmentID]
+		if !ok {
+			return fmt.Errorf("segment %d is not loaded on query node %d", info.SegmentID, lst.DstNodeID)
+		}
+		if segmentInfo.NumRows != info.NumOfRows {
+			return fmt.Errorf("segment %d is loaded on query node %d with %d rows, expected %d",
+				info.SegmentID, lst.DstNodeID, segmentInfo.NumRows, info.NumOfRows)
+		}
+	}
 	return nil
 }
 
@@ -1332,7 +1575,7 @@ func (wqt *watchQueryChannelTask) timestamp() Timestamp {
 func (wqt *watchQueryChannelTask) updateTaskProcess() {
 	parentTask := wqt.getParentTask()
 	if parentTask == nil {
-		log.Warn("watchQueryChannelTask: parentTask should not be nil")
+		// enqueued as a trigger task when recovering the query channels of a re-registered node
 		return
 	}
 	parentTask.updateTaskProcess()
@@ -1487,7 +1730,7 @@ func (ht *handoffTask) execute(ctx context.Context) error {
 				ht.setResultInfo(err)
 				return err
 			}
-			err = assignInternalTask(ctx, collectionID, ht, ht.meta, ht.cluster, []*querypb.LoadSegmentsRequest{loadSegmentReq}, nil, true)
+			err = assignInternalTask(ctx, collectionID, ht, ht.meta, ht.cluster, []*querypb.LoadSegmentsRequest{loadSegmentReq}, nil, true, nil)
 			if err != nil {
 				log.Error("handoffTask: assign child task failed", zap.Any("segmentInfo", segmentInfo))
 				ht.setResultInfo(err)
@@ -1680,7 +1923,7 @@ func (lbt *loadBalanceTask) execute(ctx context.Context) error {
 						}
 					}
 				}
-				err = assignInternalTask(ctx, collectionID, lbt, lbt.meta, lbt.cluster, loadSegmentReqs, watchDmChannelReqs, true)
+				err = assignInternalTask(ctx, collectionID, lbt, lbt.meta, lbt.cluster, loadSegmentReqs, watchDmChannelReqs, true, nil)
 				if err != nil {
 					log.Warn("loadBalanceTask: assign child task failed", zap.Int64("collectionID", collectionID), zap.Int64s("partitionIDs", partitionIDs))
 					lbt.setResultInfo(err)
@@ -1691,6 +1934,14 @@ func (lbt *loadBalanceTask) execute(ctx context.Context) error {
 		}
 	}
 
+	if lbt.triggerCondition == querypb.TriggerCondition_grpcRequest {
+		err := lbt.balanceSealedSegments(ctx)
+		if err != nil {
+			lbt.setResultInfo(err)
+			return err
+		}
+	}
+
 	//TODO::
 	//if lbt.triggerCondition == querypb.TriggerCondition_loadBalance {
 	//	return nil
@@ -1704,15 +1955,17 @@ func (lbt *loadBalanceTask) execute(ctx context.Context) error {
 }
 
 func (lbt *loadBalanceTask) postExecute(context.Context) error {
-	if lbt.result.ErrorCode == commonpb.ErrorCode_Success {
+	if lbt.result.ErrorCode != commonpb.ErrorCode_Success {
+		lbt.childTasks = []task{}
+	} else if lbt.triggerCondition == querypb.TriggerCondition_nodeDown {
+		// the source nodes of a balance requested by users keep serving,
+		// their copies of the moved segments are released once the new copies are online
 		for _, id := range lbt.SourceNodeIDs {
 			err := lbt.cluster.removeNodeInfo(id)
 			if err != nil {
 				log.Error("loadBalanceTask: occur error when removing node info from cluster", zap.Int64("nodeID", id))
 			}
 		}
-	} else {
-		lbt.childTasks = []task{}
 	}
 
 	log.Debug("loadBalanceTask postExecute done",
@@ -1722,6 +1975,27 @@ func (lbt *loadBalanceTask) postExecute(context.Context) error {
 	return nil
 }
 
+// describeCollection returns the schema of the collection at ts from root coordinator,
+// the collection not found error is returned if root coordinator doesn't have it
+func describeCollection(ctx context.Context, rootCoord types.RootCoord, collectionID UniqueID, ts Timestamp) (*schemapb.CollectionSchema, error) {
+	req := &milvuspb.DescribeCollectionRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:   commonpb.MsgType_DescribeCollection,
+			Timestamp: ts,
+		},
+		CollectionID: collectionID,
+		TimeStamp:    ts,
+	}
+	resp, err := rootCoord.DescribeCollection(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return nil, errCollectionNotFound(collectionID, resp.Status.Reason)
+	}
+	return resp.Schema, nil
+}
+
 func shuffleChannelsToQueryNode(dmChannels []string, cluster Cluster, wait bool, excludeNodeIDs []int64) ([]int64, error) {
 	maxNumChannels := 0
 	nodes := make(map[int64]Node)
@@ -1743,7 +2017,7 @@ func shuffleChannelsToQueryNode(dmChannels []string, cluster Cluster, wait bool,
 			break
 		}
 		if !wait {
-			return nil, errors.New("no queryNode to allocate")
+			return nil, errNoAvailableQueryNode()
 		}
 	}
 
@@ -1813,7 +2087,7 @@ func shuffleSegmentsToQueryNode(segmentIDs []UniqueID, cluster Cluster, wait boo
 			break
 		}
 		if !wait {
-			return nil, errors.New("no queryNode to allocate")
+			return nil, errNoAvailableQueryNode()
 		}
 	}
 	for nodeID := range nodes {
@@ -1898,7 +2172,8 @@ func assignInternalTask(ctx context.Context,
 	cluster Cluster,
 	loadSegmentRequests []*querypb.LoadSegmentsRequest,
 	watchDmChannelRequests []*querypb.WatchDmChannelsRequest,
-	wait bool) error {
+	wait bool,
+	excludeNodeIDs []int64) error {
 	sp, _ := trace.StartSpanFromContext(ctx)
 	defer sp.Finish()
 	segmentsToLoad := make([]UniqueID, 0)
@@ -1909,13 +2184,13 @@ func assignInternalTask(ctx context.Context,
 	for _, req := range watchDmChannelRequests {
 		channelsToWatch = append(channelsToWatch, req.Infos[0].ChannelName)
 	}
-	segment2Nodes, err := shuffleSegmentsToQueryNode(segmentsToLoad, cluster, wait, nil)
+	segment2Nodes, err := shuffleSegmentsToQueryNode(segmentsToLoad, cluster, wait, excludeNodeIDs)
 	if err != nil {
 		log.Error("assignInternalTask: segment to node failed", zap.Any("segments map", segment2Nodes), zap.Int64("collectionID", collectionID))
 		return err
 	}
 	log.Debug("assignInternalTask: segment to node", zap.Any("segments map", segment2Nodes), zap.Int64("collectionID", collectionID))
-	watchRequest2Nodes, err := shuffleChannelsToQueryNode(channelsToWatch, cluster, wait, nil)
+	watchRequest2Nodes, err := shuffleChannelsToQueryNode(channelsToWatch, cluster, wait, excludeNodeIDs)
 	if err != nil {
 		log.Error("assignInternalTask: watch request to node failed", zap.Any("request map", watchRequest2Nodes), zap.Int64("collectionID", collectionID))
 		return err
@@ -1932,11 +2207,12 @@ func assignInternalTask(ctx context.Context,
 			node2Segments[nodeID] = append(node2Segments[nodeID], loadSegmentRequests[index])
 			sizeCounts[nodeID] = sizeOfReq
 		} else {
-			if sizeCounts[nodeID]+sizeOfReq > 2097152 {
+			lastReq := node2Segments[nodeID][len(node2Segments[nodeID])-1]
+			// the segments from different source nodes are not merged, since their binlogs are fetched from the source node
+			if sizeCounts[nodeID]+sizeOfReq > 2097152 || lastReq.SourceNodeID != loadSegmentRequests[index].SourceNodeID {
 				node2Segments[nodeID] = append(node2Segments[nodeID], loadSegmentRequests[index])
 				sizeCounts[nodeID] = sizeOfReq
 			} else {
-				lastReq := node2Segments[nodeID][len(node2Segments[nodeID])-1]
 				lastReq.Infos = append(lastReq.Infos, loadSegmentRequests[index].Infos...)
 				sizeCounts[nodeID] += sizeOfReq
 			}
@@ -1967,7 +2243,7 @@ func assignInternalTask(ctx context.Context,
 				LoadSegmentsRequest: req,
 				meta:                meta,
 				cluster:             cluster,
-				excludeNodeIDs:      []int64{},
+				excludeNodeIDs:      append([]int64{}, excludeNodeIDs...),
 			}
 			parentTask.addChildTask(loadSegmentTask)
 			log.Debug("assignInternalTask: add a loadSegmentTask childTask", zap.Any("task", loadSegmentTask))
@@ -1985,7 +2261,7 @@ func assignInternalTask(ctx context.Context,
 			WatchDmChannelsRequest: watchDmChannelReq,
 			meta:                   meta,
 			cluster:                cluster,
-			excludeNodeIDs:         []int64{},
+			excludeNodeIDs:         append([]int64{}, excludeNodeIDs...),
 		}
 		parentTask.addChildTask(watchDmChannelTask)
 		log.Debug("assignInternalTask: add a watchDmChannelTask childTask", zap.Any("task", watchDmChannelTask))
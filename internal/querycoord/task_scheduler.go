@@ -14,11 +14,15 @@ package querycoord
 import (
 	"container/list"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/opentracing/opentracing-go"
@@ -29,11 +33,15 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/trace"
 	oplog "github.com/opentracing/opentracing-go/log"
 )
 
+// schedulerLogger is the name of the logger of the task scheduler, whose level can be set apart at runtime
+const schedulerLogger = "querycoord.scheduler"
+
 // TaskQueue is used to cache triggerTasks
 type TaskQueue struct {
 	tasks *list.List
@@ -100,7 +108,7 @@ func (queue *TaskQueue) popTask() task {
 	defer queue.Unlock()
 
 	if queue.tasks.Len() <= 0 {
-		log.Warn("sorry, but the unissued task list is empty!")
+		log.Named(schedulerLogger).Warn("sorry, but the unissued task list is empty!")
 		return nil
 	}
 
@@ -119,9 +127,108 @@ func NewTaskQueue() *TaskQueue {
 	}
 }
 
+// collectionTask is implemented by the tasks working on a single collection
+type collectionTask interface {
+	GetCollectionID() UniqueID
+}
+
+// taskCollectionID returns the collection the task works on, false if the task may touch several collections
+func taskCollectionID(t task) (UniqueID, bool) {
+	if ct, ok := t.(collectionTask); ok {
+		return ct.GetCollectionID(), true
+	}
+	return 0, false
+}
+
+// collectionTaskSerializer runs the trigger tasks of the same collection one by one in their enqueue order,
+// while the trigger tasks of different collections run concurrently
+type collectionTaskSerializer struct {
+	mu      sync.Mutex
+	pending map[UniqueID][]task // the executing task comes first
+	wg      sync.WaitGroup
+}
+
+func newCollectionTaskSerializer() *collectionTaskSerializer {
+	return &collectionTaskSerializer{
+		pending: make(map[UniqueID][]task),
+	}
+}
+
+// run executes the task with process after the earlier tasks of the collection are done
+func (s *collectionTaskSerializer) run(collectionID UniqueID, t task, process func(t task)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[collectionID] = append(s.pending[collectionID], t)
+	if len(s.pending[collectionID]) > 1 {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			process(t)
+
+			s.mu.Lock()
+			s.pending[collectionID] = s.pending[collectionID][1:]
+			if len(s.pending[collectionID]) == 0 {
+				delete(s.pending, collectionID)
+				s.mu.Unlock()
+				return
+			}
+			t = s.pending[collectionID][0]
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// wait blocks until all the tasks passed to run are done
+func (s *collectionTaskSerializer) wait() {
+	s.wg.Wait()
+}
+
+// maxRecentFailedTasks is the number of the failed child tasks kept for the metrics
+const maxRecentFailedTasks = 50
+
+// failedTaskRing keeps the last maxRecentFailedTasks failed child tasks, the oldest one is overwritten when it's full
+type failedTaskRing struct {
+	mu    sync.Mutex
+	tasks []metricsinfo.FailedTaskInfo
+	next  int
+}
+
+func (r *failedTaskRing) add(info metricsinfo.FailedTaskInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tasks == nil {
+		r.tasks = make([]metricsinfo.FailedTaskInfo, 0, maxRecentFailedTasks)
+	}
+	if len(r.tasks) < maxRecentFailedTasks {
+		r.tasks = append(r.tasks, info)
+		return
+	}
+	r.tasks[r.next] = info
+	r.next = (r.next + 1) % maxRecentFailedTasks
+}
+
+// list returns the failed tasks from the oldest to the latest
+func (r *failedTaskRing) list() []metricsinfo.FailedTaskInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	infos := make([]metricsinfo.FailedTaskInfo, 0, len(r.tasks))
+	infos = append(infos, r.tasks[r.next:]...)
+	infos = append(infos, r.tasks[:r.next]...)
+	return infos
+}
+
 // TaskScheduler controls the scheduling of trigger tasks and internal tasks
 type TaskScheduler struct {
 	triggerTaskQueue         *TaskQueue
+	collectionSerializer     *collectionTaskSerializer
+	triggerTasks             map[UniqueID]task // the trigger tasks queued or executing
+	triggerTasksMu           sync.RWMutex
+	lastProgress             int64 // unix nanoseconds of the last time a task was popped or finished
+	failedTasks              failedTaskRing
 	activateTaskChan         chan task
 	meta                     Meta
 	cluster                  Cluster
@@ -129,8 +236,9 @@ type TaskScheduler struct {
 	client                   *etcdkv.EtcdKV
 	stopActivateTaskLoopChan chan int
 
-	rootCoord types.RootCoord
-	dataCoord types.DataCoord
+	rootCoord  types.RootCoord
+	dataCoord  types.DataCoord
+	indexCoord types.IndexCoord
 
 	wg     sync.WaitGroup
 	ctx    context.Context
@@ -144,6 +252,7 @@ func NewTaskScheduler(ctx context.Context,
 	kv *etcdkv.EtcdKV,
 	rootCoord types.RootCoord,
 	dataCoord types.DataCoord,
+	indexCoord types.IndexCoord,
 	idAllocator func() (UniqueID, error)) (*TaskScheduler, error) {
 	ctx1, cancel := context.WithCancel(ctx)
 	taskChan := make(chan task, 1024)
@@ -159,12 +268,16 @@ func NewTaskScheduler(ctx context.Context,
 		stopActivateTaskLoopChan: stopTaskLoopChan,
 		rootCoord:                rootCoord,
 		dataCoord:                dataCoord,
+		indexCoord:               indexCoord,
 	}
 	s.triggerTaskQueue = NewTaskQueue()
+	s.collectionSerializer = newCollectionTaskSerializer()
+	s.triggerTasks = make(map[UniqueID]task)
+	s.markProgress()
 
 	err := s.reloadFromKV()
 	if err != nil {
-		log.Error("reload task from kv failed", zap.Error(err))
+		log.Ctx(ctx).Named(schedulerLogger).Error("reload task from kv failed", zap.Error(err))
 		return nil, err
 	}
 
@@ -224,36 +337,98 @@ func (scheduler *TaskScheduler) reloadFromKV() error {
 		state := taskState(value)
 		taskInfos[taskID] = state
 		if _, ok := triggerTasks[taskID]; !ok {
-			log.Error("reloadFromKV: taskStateInfo and triggerTaskInfo are inconsistent")
+			log.Ctx(scheduler.ctx).Named(schedulerLogger).Error("reloadFromKV: taskStateInfo and triggerTaskInfo are inconsistent")
 			continue
 		}
 		triggerTasks[taskID].setState(state)
 	}
 
-	var doneTriggerTask task = nil
+	// restore the enqueue order, so that the tasks of the same collection are executed in the order they were requested
+	sortedTriggerTasks := make([]task, 0, len(triggerTasks))
 	for _, t := range triggerTasks {
+		sortedTriggerTasks = append(sortedTriggerTasks, t)
+	}
+	sort.Slice(sortedTriggerTasks, func(i, j int) bool {
+		if sortedTriggerTasks[i].timestamp() != sortedTriggerTasks[j].timestamp() {
+			return sortedTriggerTasks[i].timestamp() < sortedTriggerTasks[j].timestamp()
+		}
+		return sortedTriggerTasks[i].getTaskID() < sortedTriggerTasks[j].getTaskID()
+	})
+
+	doneTriggerTasks := make([]task, 0)
+	for _, t := range sortedTriggerTasks {
+		scheduler.addTriggerTask(t)
 		if t.getState() == taskDone {
-			doneTriggerTask = t
-			for _, childTask := range activeTasks {
-				childTask.setParentTask(t) //replace child task after reScheduler
-				t.addChildTask(childTask)
-			}
+			doneTriggerTasks = append(doneTriggerTasks, t)
 			t.setResultInfo(nil)
 			continue
 		}
 		scheduler.triggerTaskQueue.addTask(t)
 	}
 
-	if doneTriggerTask != nil {
-		scheduler.triggerTaskQueue.addTaskToFront(doneTriggerTask)
+	for _, childTask := range activeTasks {
+		parentTask := findParentTask(doneTriggerTasks, childTask)
+		if parentTask == nil {
+			log.Ctx(scheduler.ctx).Named(schedulerLogger).Error("reloadFromKV: no executing trigger task for the active task", zap.Int64("taskID", childTask.getTaskID()))
+			continue
+		}
+		childTask.setParentTask(parentTask) //replace child task after reScheduler
+		parentTask.addChildTask(childTask)
+	}
+
+	for i := len(doneTriggerTasks) - 1; i >= 0; i-- {
+		scheduler.triggerTaskQueue.addTaskToFront(doneTriggerTasks[i])
 	}
 
 	return nil
 }
 
-func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task, error) {
+// findParentTask returns the executing trigger task which the active task belongs to,
+// at most one trigger task is executing per collection, and the trigger tasks working on several collections execute alone
+func findParentTask(doneTriggerTasks []task, childTask task) task {
+	if len(doneTriggerTasks) == 1 {
+		return doneTriggerTasks[0]
+	}
+	collectionID, ok := taskCollectionID(childTask)
+	if !ok {
+		return nil
+	}
+	for _, t := range doneTriggerTasks {
+		if id, ok := taskCollectionID(t); ok && id == collectionID {
+			return t
+		}
+	}
+	return nil
+}
+
+// taskValuePrefix prefixes the task values saved to etcd, followed by the marshaled task in base64,
+// so that the values are binary-safe and printable. The values without it are the raw binary saved by the older versions.
+const taskValuePrefix = "queryCoord-task-v1:"
+
+// marshalTaskValue returns the value of the task to save to etcd
+func marshalTaskValue(t task) (string, error) {
+	blobs, err := t.marshal()
+	if err != nil {
+		return "", err
+	}
+	return taskValuePrefix + base64.StdEncoding.EncodeToString(blobs), nil
+}
+
+// decodeTaskValue returns the marshaled task of the value saved to etcd, in either the current or the old format
+func decodeTaskValue(value string) ([]byte, error) {
+	if strings.HasPrefix(value, taskValuePrefix) {
+		return base64.StdEncoding.DecodeString(strings.TrimPrefix(value, taskValuePrefix))
+	}
+	return []byte(value), nil
+}
+
+func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, value string) (task, error) {
+	t, err := decodeTaskValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode task value, err %s ", err.Error())
+	}
 	header := commonpb.MsgHeader{}
-	err := proto.Unmarshal([]byte(t), &header)
+	err = proto.Unmarshal(t, &header)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to unmarshal message header, err %s ", err.Error())
 	}
@@ -262,7 +437,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 	switch header.Base.MsgType {
 	case commonpb.MsgType_LoadCollection:
 		loadReq := querypb.LoadCollectionRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -271,27 +446,30 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 			LoadCollectionRequest: &loadReq,
 			rootCoord:             scheduler.rootCoord,
 			dataCoord:             scheduler.dataCoord,
+			indexCoord:            scheduler.indexCoord,
 			cluster:               scheduler.cluster,
 			meta:                  scheduler.meta,
 		}
 		newTask = loadCollectionTask
 	case commonpb.MsgType_LoadPartitions:
 		loadReq := querypb.LoadPartitionsRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
 		loadPartitionTask := &loadPartitionTask{
 			baseTask:              baseTask,
 			LoadPartitionsRequest: &loadReq,
+			rootCoord:             scheduler.rootCoord,
 			dataCoord:             scheduler.dataCoord,
+			indexCoord:            scheduler.indexCoord,
 			cluster:               scheduler.cluster,
 			meta:                  scheduler.meta,
 		}
 		newTask = loadPartitionTask
 	case commonpb.MsgType_ReleaseCollection:
 		loadReq := querypb.ReleaseCollectionRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -305,7 +483,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 		newTask = releaseCollectionTask
 	case commonpb.MsgType_ReleasePartitions:
 		loadReq := querypb.ReleasePartitionsRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -318,7 +496,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 	case commonpb.MsgType_LoadSegments:
 		//TODO::trigger condition may be different
 		loadReq := querypb.LoadSegmentsRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -333,7 +511,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 	case commonpb.MsgType_ReleaseSegments:
 		//TODO::trigger condition may be different
 		loadReq := querypb.ReleaseSegmentsRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -346,7 +524,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 	case commonpb.MsgType_WatchDmChannels:
 		//TODO::trigger condition may be different
 		loadReq := querypb.WatchDmChannelsRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -361,7 +539,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 	case commonpb.MsgType_WatchQueryChannels:
 		//TODO::trigger condition may be different
 		loadReq := querypb.AddQueryChannelRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
@@ -372,12 +550,15 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 		}
 		newTask = watchQueryChannelTask
 	case commonpb.MsgType_LoadBalanceSegments:
-		//TODO::trigger condition may be different
 		loadReq := querypb.LoadBalanceRequest{}
-		err = proto.Unmarshal([]byte(t), &loadReq)
+		err = proto.Unmarshal(t, &loadReq)
 		if err != nil {
 			return nil, err
 		}
+		// only the balance requested by users keeps the source nodes, the others are for the nodes down
+		if loadReq.BalanceReason != querypb.TriggerCondition_grpcRequest {
+			baseTask.triggerCondition = querypb.TriggerCondition_nodeDown
+		}
 		loadBalanceTask := &loadBalanceTask{
 			baseTask:           baseTask,
 			LoadBalanceRequest: &loadReq,
@@ -389,7 +570,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 		newTask = loadBalanceTask
 	case commonpb.MsgType_HandoffSegments:
 		handoffReq := querypb.HandoffSegmentsRequest{}
-		err = proto.Unmarshal([]byte(t), &handoffReq)
+		err = proto.Unmarshal(t, &handoffReq)
 		if err != nil {
 			return nil, err
 		}
@@ -403,7 +584,7 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 		newTask = handoffTask
 	default:
 		err = errors.New("inValid msg type when unMarshal task")
-		log.Error(err.Error())
+		log.Ctx(scheduler.ctx).Named(schedulerLogger).Error(err.Error())
 		return nil, err
 	}
 
@@ -415,29 +596,30 @@ func (scheduler *TaskScheduler) unmarshalTask(taskID UniqueID, t string) (task,
 func (scheduler *TaskScheduler) Enqueue(t task) error {
 	id, err := scheduler.taskIDAllocator()
 	if err != nil {
-		log.Error("allocator trigger taskID failed", zap.Error(err))
+		log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("allocator trigger taskID failed", zap.Error(err))
 		return err
 	}
 	t.setTaskID(id)
 	kvs := make(map[string]string)
 	taskKey := fmt.Sprintf("%s/%d", triggerTaskPrefix, t.getTaskID())
-	blobs, err := t.marshal()
+	value, err := marshalTaskValue(t)
 	if err != nil {
-		log.Error("error when save marshal task", zap.Int64("taskID", t.getTaskID()), zap.Error(err))
+		log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("error when save marshal task", zap.Int64("taskID", t.getTaskID()), zap.Error(err))
 		return err
 	}
-	kvs[taskKey] = string(blobs)
+	kvs[taskKey] = value
 	stateKey := fmt.Sprintf("%s/%d", taskInfoPrefix, t.getTaskID())
 	kvs[stateKey] = strconv.Itoa(int(taskUndo))
 	err = scheduler.client.MultiSave(kvs)
 	if err != nil {
 		//TODO::clean etcd meta
-		log.Error("error when save trigger task to etcd", zap.Int64("taskID", t.getTaskID()), zap.Error(err))
+		log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("error when save trigger task to etcd", zap.Int64("taskID", t.getTaskID()), zap.Error(err))
 		return err
 	}
 	t.setState(taskUndo)
+	scheduler.addTriggerTask(t)
 	scheduler.triggerTaskQueue.addTask(t)
-	log.Debug("EnQueue a triggerTask and save to etcd", zap.Int64("taskID", t.getTaskID()))
+	log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("EnQueue a triggerTask and save to etcd", zap.Int64("taskID", t.getTaskID()))
 
 	return nil
 }
@@ -457,11 +639,11 @@ func (scheduler *TaskScheduler) processTask(t task) error {
 			}
 			childTask.setTaskID(id)
 			childTaskKey := fmt.Sprintf("%s/%d", activeTaskPrefix, childTask.getTaskID())
-			blobs, err := childTask.marshal()
+			value, err := marshalTaskValue(childTask)
 			if err != nil {
 				return err
 			}
-			kvs[childTaskKey] = string(blobs)
+			kvs[childTaskKey] = value
 			stateKey := fmt.Sprintf("%s/%d", taskInfoPrefix, childTask.getTaskID())
 			kvs[stateKey] = strconv.Itoa(int(taskUndo))
 			err = scheduler.client.MultiSave(kvs)
@@ -487,15 +669,22 @@ func (scheduler *TaskScheduler) processTask(t task) error {
 	var err error
 	defer span.Finish()
 
+	// task preExecute, nothing is done if it fails
+	span.LogFields(oplog.Int64("processTask: scheduler process PreExecute", t.getTaskID()))
+	t.setPhase(taskPreExecuting)
+	err = t.preExecute(ctx)
+	if err != nil {
+		trace.LogError(span, err)
+		t.setResultInfo(err)
+		return err
+	}
+
 	defer func() {
 		//task postExecute
 		span.LogFields(oplog.Int64("processTask: scheduler process PostExecute", t.getTaskID()))
+		t.setPhase(taskPostExecuting)
 		t.postExecute(ctx)
 	}()
-
-	// task preExecute
-	span.LogFields(oplog.Int64("processTask: scheduler process PreExecute", t.getTaskID()))
-	t.preExecute(ctx)
 	taskInfoKey = fmt.Sprintf("%s/%d", taskInfoPrefix, t.getTaskID())
 	err = scheduler.client.Save(taskInfoKey, strconv.Itoa(int(taskDoing)))
 	if err != nil {
@@ -507,6 +696,7 @@ func (scheduler *TaskScheduler) processTask(t task) error {
 
 	// task execute
 	span.LogFields(oplog.Int64("processTask: scheduler process Execute", t.getTaskID()))
+	t.setPhase(taskExecuting)
 	err = t.execute(ctx)
 	if err != nil {
 		trace.LogError(span, err)
@@ -518,7 +708,7 @@ func (scheduler *TaskScheduler) processTask(t task) error {
 		t.setResultInfo(err)
 		return err
 	}
-	log.Debug("processTask: update etcd success", zap.Int64("parent taskID", t.getTaskID()))
+	log.Ctx(ctx).Named(schedulerLogger).Debug("processTask: update etcd success", zap.Int64("parent taskID", t.getTaskID()))
 	if t.msgType() == commonpb.MsgType_LoadCollection || t.msgType() == commonpb.MsgType_LoadPartitions {
 		t.notify(nil)
 	}
@@ -529,16 +719,18 @@ func (scheduler *TaskScheduler) processTask(t task) error {
 	return nil
 }
 
-func (scheduler *TaskScheduler) scheduleLoop() {
-	defer scheduler.wg.Done()
+// processTriggerTask executes the trigger task and its child tasks, and rolls back if failed
+func (scheduler *TaskScheduler) processTriggerTask(triggerTask task) {
+	defer scheduler.removeTriggerTask(triggerTask)
 	activeTaskWg := &sync.WaitGroup{}
-	var triggerTask task
 
 	processInternalTaskFn := func(activateTasks []task, triggerTask task) {
-		log.Debug("scheduleLoop: num of child task", zap.Int("num child task", len(activateTasks)))
+		log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Debug("scheduleLoop: num of child task", zap.Int("num child task", len(activateTasks)))
+		triggerTask.setPhase(taskWaitingChildren)
 		for _, childTask := range activateTasks {
 			if childTask != nil {
-				log.Debug("scheduleLoop: add a activate task to activateChan", zap.Int64("taskID", childTask.getTaskID()))
+				log.Ctx(childTask.traceCtx()).Named(schedulerLogger).Debug("scheduleLoop: add a activate task to activateChan", zap.Int64("taskID", childTask.getTaskID()))
+				childTask.setPhase(taskEnqueued)
 				scheduler.activateTaskChan <- childTask
 				activeTaskWg.Add(1)
 				go scheduler.waitActivateTaskDone(activeTaskWg, childTask, triggerTask)
@@ -566,11 +758,11 @@ func (scheduler *TaskScheduler) scheduleLoop() {
 			}
 			t.setTaskID(id)
 			taskKey := fmt.Sprintf("%s/%d", activeTaskPrefix, t.getTaskID())
-			blobs, err := t.marshal()
+			value, err := marshalTaskValue(t)
 			if err != nil {
 				return err
 			}
-			saves[taskKey] = string(blobs)
+			saves[taskKey] = value
 			stateKey := fmt.Sprintf("%s/%d", taskInfoPrefix, t.getTaskID())
 			saves[stateKey] = strconv.Itoa(int(taskUndo))
 		}
@@ -609,81 +801,95 @@ func (scheduler *TaskScheduler) scheduleLoop() {
 		return nil
 	}
 
-	for {
-		var err error
-		select {
-		case <-scheduler.ctx.Done():
-			scheduler.stopActivateTaskLoopChan <- 1
-			return
-		case <-scheduler.triggerTaskQueue.Chan():
-			triggerTask = scheduler.triggerTaskQueue.popTask()
-			log.Debug("scheduleLoop: pop a triggerTask from triggerTaskQueue", zap.Int64("triggerTaskID", triggerTask.getTaskID()))
-			alreadyNotify := true
-			if triggerTask.getState() == taskUndo || triggerTask.getState() == taskDoing {
-				err = scheduler.processTask(triggerTask)
-				if err != nil {
-					log.Debug("scheduleLoop: process triggerTask failed", zap.Int64("triggerTaskID", triggerTask.getTaskID()), zap.Error(err))
-					alreadyNotify = false
-				}
-			}
-			if triggerTask.msgType() != commonpb.MsgType_LoadCollection && triggerTask.msgType() != commonpb.MsgType_LoadPartitions {
-				alreadyNotify = false
-			}
-
-			childTasks := triggerTask.getChildTask()
-			if len(childTasks) != 0 {
-				activateTasks := make([]task, len(childTasks))
-				copy(activateTasks, childTasks)
-				processInternalTaskFn(activateTasks, triggerTask)
-				if triggerTask.getResultInfo().ErrorCode == commonpb.ErrorCode_Success {
-					err = updateSegmentInfoFromTask(scheduler.ctx, triggerTask, scheduler.meta)
-					if err != nil {
-						triggerTask.setResultInfo(err)
-					}
-				}
-				resultInfo := triggerTask.getResultInfo()
-				if resultInfo.ErrorCode != commonpb.ErrorCode_Success {
-					if !alreadyNotify {
-						triggerTask.notify(errors.New(resultInfo.Reason))
-						alreadyNotify = true
-					}
-					rollBackTasks := triggerTask.rollBack(scheduler.ctx)
-					log.Debug("scheduleLoop: start rollBack after triggerTask failed",
-						zap.Int64("triggerTaskID", triggerTask.getTaskID()),
-						zap.Any("rollBackTasks", rollBackTasks))
-					err = rollBackInterTaskFn(triggerTask, childTasks, rollBackTasks)
-					if err != nil {
-						log.Error("scheduleLoop: rollBackInternalTask error",
-							zap.Int64("triggerTaskID", triggerTask.getTaskID()),
-							zap.Error(err))
-
-					} else {
-						processInternalTaskFn(rollBackTasks, triggerTask)
-					}
-				}
-			}
+	var err error
+	alreadyNotify := true
+	if triggerTask.getState() == taskUndo || triggerTask.getState() == taskDoing {
+		err = scheduler.processTask(triggerTask)
+		if err != nil {
+			log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Debug("scheduleLoop: process triggerTask failed", zap.Int64("triggerTaskID", triggerTask.getTaskID()), zap.Error(err))
+			alreadyNotify = false
+		}
+	}
+	if triggerTask.msgType() != commonpb.MsgType_LoadCollection && triggerTask.msgType() != commonpb.MsgType_LoadPartitions {
+		alreadyNotify = false
+	}
 
-			err = removeTaskFromKVFn(triggerTask)
+	childTasks := triggerTask.getChildTask()
+	if len(childTasks) != 0 {
+		activateTasks := make([]task, len(childTasks))
+		copy(activateTasks, childTasks)
+		processInternalTaskFn(activateTasks, triggerTask)
+		if triggerTask.getResultInfo().ErrorCode == commonpb.ErrorCode_Success {
+			err = updateSegmentInfoFromTask(scheduler.ctx, triggerTask, scheduler.meta)
 			if err != nil {
-				log.Error("scheduleLoop: error when remove trigger and internal tasks from etcd", zap.Int64("triggerTaskID", triggerTask.getTaskID()), zap.Error(err))
 				triggerTask.setResultInfo(err)
-			} else {
-				log.Debug("scheduleLoop: trigger task done and delete from etcd", zap.Int64("triggerTaskID", triggerTask.getTaskID()))
 			}
+		}
+		resultInfo := triggerTask.getResultInfo()
+		if resultInfo.ErrorCode != commonpb.ErrorCode_Success {
+			if !alreadyNotify {
+				triggerTask.notify(errors.New(resultInfo.Reason))
+				alreadyNotify = true
+			}
+			rollBackTasks := triggerTask.rollBack(scheduler.ctx)
+			log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Debug("scheduleLoop: start rollBack after triggerTask failed",
+				zap.Int64("triggerTaskID", triggerTask.getTaskID()),
+				zap.Any("rollBackTasks", rollBackTasks))
+			err = rollBackInterTaskFn(triggerTask, childTasks, rollBackTasks)
+			if err != nil {
+				log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Error("scheduleLoop: rollBackInternalTask error",
+					zap.Int64("triggerTaskID", triggerTask.getTaskID()),
+					zap.Error(err))
 
-			resultStatus := triggerTask.getResultInfo()
-			if resultStatus.ErrorCode != commonpb.ErrorCode_Success {
-				triggerTask.setState(taskFailed)
-				if !alreadyNotify {
-					triggerTask.notify(errors.New(resultStatus.Reason))
-				}
 			} else {
-				triggerTask.updateTaskProcess()
-				triggerTask.setState(taskExpired)
-				if !alreadyNotify {
-					triggerTask.notify(nil)
-				}
+				processInternalTaskFn(rollBackTasks, triggerTask)
+			}
+		}
+	}
+
+	err = removeTaskFromKVFn(triggerTask)
+	if err != nil {
+		log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Error("scheduleLoop: error when remove trigger and internal tasks from etcd", zap.Int64("triggerTaskID", triggerTask.getTaskID()), zap.Error(err))
+		triggerTask.setResultInfo(err)
+	} else {
+		log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Debug("scheduleLoop: trigger task done and delete from etcd", zap.Int64("triggerTaskID", triggerTask.getTaskID()))
+	}
+
+	resultStatus := triggerTask.getResultInfo()
+	if resultStatus.ErrorCode != commonpb.ErrorCode_Success {
+		triggerTask.setState(taskFailed)
+		if !alreadyNotify {
+			triggerTask.notify(errors.New(resultStatus.Reason))
+		}
+	} else {
+		triggerTask.updateTaskProcess()
+		triggerTask.setState(taskExpired)
+		if !alreadyNotify {
+			triggerTask.notify(nil)
+		}
+	}
+}
+
+func (scheduler *TaskScheduler) scheduleLoop() {
+	defer scheduler.wg.Done()
+	for {
+		select {
+		case <-scheduler.ctx.Done():
+			scheduler.collectionSerializer.wait()
+			scheduler.stopActivateTaskLoopChan <- 1
+			return
+		case <-scheduler.triggerTaskQueue.Chan():
+			triggerTask := scheduler.triggerTaskQueue.popTask()
+			scheduler.markProgress()
+			log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Debug("scheduleLoop: pop a triggerTask from triggerTaskQueue", zap.Int64("triggerTaskID", triggerTask.getTaskID()))
+			collectionID, ok := taskCollectionID(triggerTask)
+			if !ok {
+				// the task may touch any collection, so it is executed after all the collection tasks are done
+				scheduler.collectionSerializer.wait()
+				scheduler.processTriggerTask(triggerTask)
+				continue
 			}
+			scheduler.collectionSerializer.run(collectionID, triggerTask, scheduler.processTriggerTask)
 		}
 	}
 }
@@ -692,18 +898,18 @@ func (scheduler *TaskScheduler) scheduleLoop() {
 func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task, triggerTask task) {
 	defer wg.Done()
 	var err error
-	redoFunc1 := func() {
+	redoFunc1 := func(childErr error) {
 		if !t.isValid() || !t.isRetryable() {
-			log.Debug("waitActivateTaskDone: reSchedule the activate task",
+			log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: reSchedule the activate task",
 				zap.Int64("taskID", t.getTaskID()),
 				zap.Int64("triggerTaskID", triggerTask.getTaskID()))
 			reScheduledTasks, err := t.reschedule(scheduler.ctx)
 			if err != nil {
-				log.Error("waitActivateTaskDone: reschedule task error",
+				log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("waitActivateTaskDone: reschedule task error",
 					zap.Int64("taskID", t.getTaskID()),
 					zap.Int64("triggerTaskID", triggerTask.getTaskID()),
 					zap.Error(err))
-				triggerTask.setResultInfo(err)
+				triggerTask.setResultInfo(errRescheduleFailed(childErr, err))
 				return
 			}
 			removes := make([]string, 0)
@@ -717,24 +923,24 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 				if rt != nil {
 					id, err := scheduler.taskIDAllocator()
 					if err != nil {
-						log.Error("waitActivateTaskDone: allocate id error",
+						log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("waitActivateTaskDone: allocate id error",
 							zap.Int64("triggerTaskID", triggerTask.getTaskID()),
 							zap.Error(err))
 						triggerTask.setResultInfo(err)
 						return
 					}
 					rt.setTaskID(id)
-					log.Debug("waitActivateTaskDone: reScheduler set id", zap.Int64("id", rt.getTaskID()))
+					log.Ctx(rt.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: reScheduler set id", zap.Int64("id", rt.getTaskID()))
 					taskKey := fmt.Sprintf("%s/%d", activeTaskPrefix, rt.getTaskID())
-					blobs, err := rt.marshal()
+					value, err := marshalTaskValue(rt)
 					if err != nil {
-						log.Error("waitActivateTaskDone: error when marshal active task",
+						log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("waitActivateTaskDone: error when marshal active task",
 							zap.Int64("triggerTaskID", triggerTask.getTaskID()),
 							zap.Error(err))
 						triggerTask.setResultInfo(err)
 						return
 					}
-					saves[taskKey] = string(blobs)
+					saves[taskKey] = value
 					stateKey := fmt.Sprintf("%s/%d", taskInfoPrefix, rt.getTaskID())
 					saves[stateKey] = strconv.Itoa(int(taskUndo))
 				}
@@ -742,12 +948,12 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 			//TODO::queryNode auto watch queryChannel, then update etcd use same id directly
 			err = scheduler.client.MultiSaveAndRemove(saves, removes)
 			if err != nil {
-				log.Error("waitActivateTaskDone: error when save and remove task from etcd", zap.Int64("triggerTaskID", triggerTask.getTaskID()))
+				log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("waitActivateTaskDone: error when save and remove task from etcd", zap.Int64("triggerTaskID", triggerTask.getTaskID()))
 				triggerTask.setResultInfo(err)
 				return
 			}
 			triggerTask.removeChildTaskByID(t.getTaskID())
-			log.Debug("waitActivateTaskDone: delete failed active task and save reScheduled task to etcd",
+			log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: delete failed active task and save reScheduled task to etcd",
 				zap.Int64("triggerTaskID", triggerTask.getTaskID()),
 				zap.Int64("failed taskID", t.getTaskID()),
 				zap.Any("reScheduled tasks", reScheduledTasks))
@@ -755,7 +961,8 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 			for _, rt := range reScheduledTasks {
 				if rt != nil {
 					triggerTask.addChildTask(rt)
-					log.Debug("waitActivateTaskDone: add a reScheduled active task to activateChan", zap.Int64("taskID", rt.getTaskID()))
+					log.Ctx(rt.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: add a reScheduled active task to activateChan", zap.Int64("taskID", rt.getTaskID()))
+					rt.setPhase(taskEnqueued)
 					scheduler.activateTaskChan <- rt
 					wg.Add(1)
 					go scheduler.waitActivateTaskDone(wg, rt, triggerTask)
@@ -763,9 +970,10 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 			}
 			//delete task from etcd
 		} else {
-			log.Debug("waitActivateTaskDone: retry the active task",
+			log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: retry the active task",
 				zap.Int64("taskID", t.getTaskID()),
 				zap.Int64("triggerTaskID", triggerTask.getTaskID()))
+			t.setPhase(taskEnqueued)
 			scheduler.activateTaskChan <- t
 			wg.Add(1)
 			go scheduler.waitActivateTaskDone(wg, t, triggerTask)
@@ -775,15 +983,16 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 	redoFunc2 := func(err error) {
 		if t.isValid() {
 			if !t.isRetryable() {
-				log.Error("waitActivateTaskDone: activate task failed after retry",
+				log.Ctx(t.traceCtx()).Named(schedulerLogger).Error("waitActivateTaskDone: activate task failed after retry",
 					zap.Int64("taskID", t.getTaskID()),
 					zap.Int64("triggerTaskID", triggerTask.getTaskID()))
 				triggerTask.setResultInfo(err)
 				return
 			}
-			log.Debug("waitActivateTaskDone: retry the active task",
+			log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: retry the active task",
 				zap.Int64("taskID", t.getTaskID()),
 				zap.Int64("triggerTaskID", triggerTask.getTaskID()))
+			t.setPhase(taskEnqueued)
 			scheduler.activateTaskChan <- t
 			wg.Add(1)
 			go scheduler.waitActivateTaskDone(wg, t, triggerTask)
@@ -791,16 +1000,17 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 	}
 	err = t.waitToFinish()
 	if err != nil {
-		log.Debug("waitActivateTaskDone: activate task return err",
+		log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: activate task return err",
 			zap.Int64("taskID", t.getTaskID()),
 			zap.Int64("triggerTaskID", triggerTask.getTaskID()),
 			zap.Error(err))
+		scheduler.recordFailedTask(t, triggerTask, err)
 
 		switch t.msgType() {
 		case commonpb.MsgType_LoadSegments:
-			redoFunc1()
+			redoFunc1(err)
 		case commonpb.MsgType_WatchDmChannels:
-			redoFunc1()
+			redoFunc1(err)
 		case commonpb.MsgType_WatchQueryChannels:
 			redoFunc2(err)
 		case commonpb.MsgType_ReleaseSegments:
@@ -813,7 +1023,7 @@ func (scheduler *TaskScheduler) waitActivateTaskDone(wg *sync.WaitGroup, t task,
 			//TODO:: case commonpb.MsgType_RemoveDmChannels:
 		}
 	} else {
-		log.Debug("waitActivateTaskDone: one activate task done",
+		log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("waitActivateTaskDone: one activate task done",
 			zap.Int64("taskID", t.getTaskID()),
 			zap.Int64("triggerTaskID", triggerTask.getTaskID()))
 	}
@@ -825,19 +1035,20 @@ func (scheduler *TaskScheduler) processActivateTaskLoop() {
 	for {
 		select {
 		case <-scheduler.stopActivateTaskLoopChan:
-			log.Debug("processActivateTaskLoop, ctx done")
+			log.Ctx(scheduler.ctx).Named(schedulerLogger).Debug("processActivateTaskLoop, ctx done")
 			return
 
 		case t := <-scheduler.activateTaskChan:
 			if t == nil {
-				log.Error("processActivateTaskLoop: pop a nil active task", zap.Int64("taskID", t.getTaskID()))
+				log.Ctx(scheduler.ctx).Named(schedulerLogger).Error("processActivateTaskLoop: pop a nil active task", zap.Int64("taskID", t.getTaskID()))
 				continue
 			}
 
 			if t.getState() != taskDone {
-				log.Debug("processActivateTaskLoop: pop a active task from activateChan", zap.Int64("taskID", t.getTaskID()))
+				log.Ctx(t.traceCtx()).Named(schedulerLogger).Debug("processActivateTaskLoop: pop a active task from activateChan", zap.Int64("taskID", t.getTaskID()))
 				go func() {
 					err := scheduler.processTask(t)
+					scheduler.markProgress()
 					t.notify(err)
 				}()
 			}
@@ -845,6 +1056,203 @@ func (scheduler *TaskScheduler) processActivateTaskLoop() {
 	}
 }
 
+func (scheduler *TaskScheduler) addTriggerTask(t task) {
+	scheduler.triggerTasksMu.Lock()
+	defer scheduler.triggerTasksMu.Unlock()
+	scheduler.triggerTasks[t.getTaskID()] = t
+}
+
+func (scheduler *TaskScheduler) removeTriggerTask(t task) {
+	scheduler.triggerTasksMu.Lock()
+	defer scheduler.triggerTasksMu.Unlock()
+	delete(scheduler.triggerTasks, t.getTaskID())
+	scheduler.markProgress()
+}
+
+// taskNodeID returns the query node which the child task is sent to
+func taskNodeID(t task) (int64, bool) {
+	switch t := t.(type) {
+	case *loadSegmentTask:
+		return t.DstNodeID, true
+	case interface{ GetNodeID() int64 }:
+		nodeID := t.GetNodeID()
+		return nodeID, nodeID != 0
+	default:
+		return 0, false
+	}
+}
+
+func newTaskInfo(t task, now time.Time) metricsinfo.TaskInfo {
+	phase, phaseTime := t.getPhase()
+	info := metricsinfo.TaskInfo{
+		TaskID:         t.getTaskID(),
+		Type:           t.msgType().String(),
+		State:          t.getState().String(),
+		Phase:          phase.String(),
+		PhaseElapsedMs: now.Sub(phaseTime).Milliseconds(),
+		ElapsedMs:      now.Sub(t.getEnqueueTime()).Milliseconds(),
+		NodeIDs:        make([]int64, 0),
+	}
+	if collectionID, ok := taskCollectionID(t); ok {
+		info.CollectionID = collectionID
+	}
+	if nodeID, ok := taskNodeID(t); ok {
+		info.NodeIDs = append(info.NodeIDs, nodeID)
+	}
+	return info
+}
+
+// getTaskInfos returns the progress of the trigger tasks queued or executing and their child tasks,
+// it only reads the memory, the tasks are sorted by task id.
+func (scheduler *TaskScheduler) getTaskInfos() []metricsinfo.TaskInfo {
+	scheduler.triggerTasksMu.RLock()
+	triggerTasks := make([]task, 0, len(scheduler.triggerTasks))
+	for _, t := range scheduler.triggerTasks {
+		triggerTasks = append(triggerTasks, t)
+	}
+	scheduler.triggerTasksMu.RUnlock()
+	sort.Slice(triggerTasks, func(i, j int) bool {
+		return triggerTasks[i].getTaskID() < triggerTasks[j].getTaskID()
+	})
+
+	now := time.Now()
+	infos := make([]metricsinfo.TaskInfo, 0, len(triggerTasks))
+	for _, t := range triggerTasks {
+		info := newTaskInfo(t, now)
+		info.ChildTaskStates = make(map[string]int)
+		info.ChildTasks = make([]metricsinfo.TaskInfo, 0)
+		nodeIDs := make(map[int64]struct{})
+		for _, childTask := range t.getChildTask() {
+			childInfo := newTaskInfo(childTask, now)
+			info.ChildTaskStates[childInfo.State]++
+			info.ChildTasks = append(info.ChildTasks, childInfo)
+			for _, nodeID := range childInfo.NodeIDs {
+				if _, ok := nodeIDs[nodeID]; !ok {
+					nodeIDs[nodeID] = struct{}{}
+					info.NodeIDs = append(info.NodeIDs, nodeID)
+				}
+			}
+		}
+		sort.Slice(info.NodeIDs, func(i, j int) bool {
+			return info.NodeIDs[i] < info.NodeIDs[j]
+		})
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// taskSegmentIDs returns the segments loaded by the child task
+func taskSegmentIDs(t task) []int64 {
+	loadSegment, ok := t.(*loadSegmentTask)
+	if !ok {
+		return nil
+	}
+	segmentIDs := make([]int64, 0, len(loadSegment.Infos))
+	for _, info := range loadSegment.Infos {
+		segmentIDs = append(segmentIDs, info.GetSegmentID())
+	}
+	return segmentIDs
+}
+
+// recordFailedTask keeps the failed child task of triggerTask for the metrics
+func (scheduler *TaskScheduler) recordFailedTask(t task, triggerTask task, err error) {
+	info := metricsinfo.FailedTaskInfo{
+		TaskID:        t.getTaskID(),
+		TriggerTaskID: triggerTask.getTaskID(),
+		Type:          t.msgType().String(),
+		SegmentIDs:    taskSegmentIDs(t),
+		Reason:        err.Error(),
+		FailedTime:    time.Now().String(),
+	}
+	if collectionID, ok := taskCollectionID(t); ok {
+		info.CollectionID = collectionID
+	}
+	if nodeID, ok := taskNodeID(t); ok {
+		info.NodeID = nodeID
+	}
+	scheduler.failedTasks.add(info)
+}
+
+// getNodeTaskInfos returns the child tasks of every query node: the segments being loaded, the child tasks
+// waiting to be dispatched and the ones failed recently. It only reads the memory, the nodes are sorted by id.
+func (scheduler *TaskScheduler) getNodeTaskInfos() []metricsinfo.QueryNodeTaskInfos {
+	scheduler.triggerTasksMu.RLock()
+	triggerTasks := make([]task, 0, len(scheduler.triggerTasks))
+	for _, t := range scheduler.triggerTasks {
+		triggerTasks = append(triggerTasks, t)
+	}
+	scheduler.triggerTasksMu.RUnlock()
+
+	nodes := make(map[int64]*metricsinfo.QueryNodeTaskInfos)
+	getNode := func(nodeID int64) *metricsinfo.QueryNodeTaskInfos {
+		node, ok := nodes[nodeID]
+		if !ok {
+			node = &metricsinfo.QueryNodeTaskInfos{
+				NodeID:          nodeID,
+				LoadingSegments: make([]metricsinfo.LoadingSegmentInfo, 0),
+				DispatchQueue:   make([]metricsinfo.TaskInfo, 0),
+				FailedTasks:     make([]metricsinfo.FailedTaskInfo, 0),
+			}
+			nodes[nodeID] = node
+		}
+		return node
+	}
+
+	now := time.Now()
+	for _, triggerTask := range triggerTasks {
+		for _, childTask := range triggerTask.getChildTask() {
+			state := childTask.getState()
+			if state == taskDone || state == taskExpired || state == taskFailed {
+				continue
+			}
+			nodeID, ok := taskNodeID(childTask)
+			if !ok {
+				continue
+			}
+			node := getNode(nodeID)
+			phase, _ := childTask.getPhase()
+			if phase == taskEnqueued {
+				node.DispatchQueue = append(node.DispatchQueue, newTaskInfo(childTask, now))
+			}
+			if loadSegment, ok := childTask.(*loadSegmentTask); ok {
+				enqueueTime := loadSegment.getEnqueueTime()
+				for _, segmentID := range taskSegmentIDs(loadSegment) {
+					node.LoadingSegments = append(node.LoadingSegments, metricsinfo.LoadingSegmentInfo{
+						TaskID:       loadSegment.getTaskID(),
+						CollectionID: loadSegment.GetCollectionID(),
+						SegmentID:    segmentID,
+						Phase:        phase.String(),
+						EnqueueTime:  enqueueTime.String(),
+						ElapsedMs:    now.Sub(enqueueTime).Milliseconds(),
+					})
+				}
+			}
+		}
+	}
+	for _, failed := range scheduler.failedTasks.list() {
+		node := getNode(failed.NodeID)
+		node.FailedTasks = append(node.FailedTasks, failed)
+	}
+
+	infos := make([]metricsinfo.QueryNodeTaskInfos, 0, len(nodes))
+	for _, node := range nodes {
+		sort.Slice(node.LoadingSegments, func(i, j int) bool {
+			if node.LoadingSegments[i].TaskID != node.LoadingSegments[j].TaskID {
+				return node.LoadingSegments[i].TaskID < node.LoadingSegments[j].TaskID
+			}
+			return node.LoadingSegments[i].SegmentID < node.LoadingSegments[j].SegmentID
+		})
+		sort.Slice(node.DispatchQueue, func(i, j int) bool {
+			return node.DispatchQueue[i].TaskID < node.DispatchQueue[j].TaskID
+		})
+		infos = append(infos, *node)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].NodeID < infos[j].NodeID
+	})
+	return infos
+}
+
 // Start function start two goroutines to process trigger tasks and internal tasks
 func (scheduler *TaskScheduler) Start() error {
 	scheduler.wg.Add(2)
@@ -900,6 +1308,7 @@ func updateSegmentInfoFromTask(ctx context.Context, triggerTask task, meta Meta)
 						PartitionID:  loadInfo.PartitionID,
 						NodeID:       dstNodeID,
 						SegmentState: querypb.SegmentState_sealed,
+						Version:      loadInfo.Version,
 					}
 					if _, ok := segmentInfosToSave[collectionID]; !ok {
 						segmentInfosToSave[collectionID] = make([]*querypb.SegmentInfo, 0)
@@ -923,7 +1332,7 @@ func updateSegmentInfoFromTask(ctx context.Context, triggerTask task, meta Meta)
 			return nil
 		}, retry.Attempts(20))
 		if rollBackSegmentChangeInfoErr != nil {
-			log.Error("scheduleLoop: Restore the information of global sealed segments in query node failed", zap.Error(rollBackSegmentChangeInfoErr))
+			log.Ctx(triggerTask.traceCtx()).Named(schedulerLogger).Error("scheduleLoop: Restore the information of global sealed segments in query node failed", zap.Error(rollBackSegmentChangeInfoErr))
 		}
 		return err
 	}
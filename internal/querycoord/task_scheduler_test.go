@@ -12,16 +12,24 @@ package querycoord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -198,6 +206,45 @@ func TestWatchQueryChannel_ClearEtcdInfoAfterAssignedNodeDown(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestWatchQueryChannel_RecoverAfterAssignedNodeRestart(t *testing.T) {
+	refreshParams()
+	baseCtx := context.Background()
+	queryCoord, err := startQueryCoord(baseCtx)
+	assert.Nil(t, err)
+	queryNode, err := startQueryNodeServer(baseCtx)
+	assert.Nil(t, err)
+	queryNode.addQueryChannels = returnFailedResult
+
+	nodeID := queryNode.queryNodeID
+	waitQueryNodeOnline(queryCoord.cluster, nodeID)
+
+	// the node has watched the dm channel, but went down before watching the query channel
+	err = queryCoord.meta.addCollection(defaultCollectionID, genCollectionSchema(defaultCollectionID, false))
+	assert.Nil(t, err)
+	err = queryCoord.meta.addDmChannel(defaultCollectionID, nodeID, []string{"test-dml-channel"})
+	assert.Nil(t, err)
+	assert.False(t, queryCoord.cluster.hasWatchedQueryChannel(baseCtx, nodeID, defaultCollectionID))
+
+	// restart the node with the same ServerID
+	queryNode.addQueryChannels = returnSuccessResult
+	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
+	assert.Nil(t, err)
+	sessionKey := fmt.Sprintf("session/"+typeutil.QueryNodeRole+"-%d", nodeID)
+	sessionValue, err := kv.Load(sessionKey)
+	assert.Nil(t, err)
+	err = kv.Save(sessionKey, sessionValue)
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return queryCoord.cluster.hasWatchedQueryChannel(baseCtx, nodeID, defaultCollectionID)
+	}, 10*time.Second, 100*time.Millisecond)
+
+	queryNode.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
 func TestUnMarshalTask(t *testing.T) {
 	refreshParams()
 	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
@@ -423,6 +470,112 @@ func TestUnMarshalTask(t *testing.T) {
 	taskScheduler.Close()
 }
 
+func TestTaskValueRoundTrip(t *testing.T) {
+	scheduler := &TaskScheduler{
+		ctx: context.Background(),
+	}
+	genBase := func(msgType commonpb.MsgType) *commonpb.MsgBase {
+		return &commonpb.MsgBase{
+			MsgType:   msgType,
+			MsgID:     1,
+			Timestamp: 2,
+		}
+	}
+	// the message id of the position is binary, which is not valid utf8
+	position := &internalpb.MsgPosition{
+		ChannelName: "dml-channel",
+		MsgID:       []byte{0xff, 0xfe, 0x00, 0x80, 0xc3},
+		Timestamp:   3,
+	}
+
+	tasks := []task{
+		&loadCollectionTask{LoadCollectionRequest: &querypb.LoadCollectionRequest{
+			Base:         genBase(commonpb.MsgType_LoadCollection),
+			CollectionID: defaultCollectionID,
+			Schema:       genCollectionSchema(defaultCollectionID, false),
+		}},
+		&loadPartitionTask{LoadPartitionsRequest: &querypb.LoadPartitionsRequest{
+			Base:         genBase(commonpb.MsgType_LoadPartitions),
+			CollectionID: defaultCollectionID,
+			PartitionIDs: []UniqueID{defaultPartitionID},
+		}},
+		&releaseCollectionTask{ReleaseCollectionRequest: &querypb.ReleaseCollectionRequest{
+			Base:         genBase(commonpb.MsgType_ReleaseCollection),
+			CollectionID: defaultCollectionID,
+			NodeID:       defaultQueryNodeID,
+		}},
+		&releasePartitionTask{ReleasePartitionsRequest: &querypb.ReleasePartitionsRequest{
+			Base:         genBase(commonpb.MsgType_ReleasePartitions),
+			CollectionID: defaultCollectionID,
+			PartitionIDs: []UniqueID{defaultPartitionID},
+		}},
+		&loadSegmentTask{LoadSegmentsRequest: &querypb.LoadSegmentsRequest{
+			Base:      genBase(commonpb.MsgType_LoadSegments),
+			DstNodeID: defaultQueryNodeID,
+			Infos: []*querypb.SegmentLoadInfo{{
+				SegmentID:    defaultSegmentID,
+				CollectionID: defaultCollectionID,
+				PartitionID:  defaultPartitionID,
+			}},
+		}},
+		&releaseSegmentTask{ReleaseSegmentsRequest: &querypb.ReleaseSegmentsRequest{
+			Base:       genBase(commonpb.MsgType_ReleaseSegments),
+			NodeID:     defaultQueryNodeID,
+			SegmentIDs: []UniqueID{defaultSegmentID},
+		}},
+		&watchDmChannelTask{WatchDmChannelsRequest: &querypb.WatchDmChannelsRequest{
+			Base:         genBase(commonpb.MsgType_WatchDmChannels),
+			CollectionID: defaultCollectionID,
+			Infos: []*datapb.VchannelInfo{{
+				CollectionID: defaultCollectionID,
+				ChannelName:  position.ChannelName,
+				SeekPosition: position,
+			}},
+		}},
+		&watchQueryChannelTask{AddQueryChannelRequest: &querypb.AddQueryChannelRequest{
+			Base:             genBase(commonpb.MsgType_WatchQueryChannels),
+			CollectionID:     defaultCollectionID,
+			SeekPosition:     position,
+			RequestChannelID: "query-channel",
+		}},
+		&loadBalanceTask{LoadBalanceRequest: &querypb.LoadBalanceRequest{
+			Base:          genBase(commonpb.MsgType_LoadBalanceSegments),
+			SourceNodeIDs: []int64{defaultQueryNodeID},
+		}},
+		&handoffTask{HandoffSegmentsRequest: &querypb.HandoffSegmentsRequest{
+			Base: genBase(commonpb.MsgType_HandoffSegments),
+			SegmentInfos: []*querypb.SegmentInfo{{
+				SegmentID:    defaultSegmentID,
+				CollectionID: defaultCollectionID,
+			}},
+		}},
+	}
+
+	for _, originTask := range tasks {
+		blobs, err := originTask.marshal()
+		assert.Nil(t, err)
+
+		value, err := marshalTaskValue(originTask)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(value, taskValuePrefix))
+		assert.True(t, utf8.ValidString(value))
+
+		// the raw binary saved by the older versions is still understood
+		for _, v := range []string{value, string(blobs)} {
+			newTask, err := scheduler.unmarshalTask(1000, v)
+			assert.Nil(t, err)
+			assert.Equal(t, originTask.msgType(), newTask.msgType())
+			assert.Equal(t, UniqueID(1000), newTask.getTaskID())
+			newBlobs, err := newTask.marshal()
+			assert.Nil(t, err)
+			assert.Equal(t, blobs, newBlobs)
+		}
+	}
+
+	_, err := scheduler.unmarshalTask(1000, taskValuePrefix+"!invalid base64")
+	assert.NotNil(t, err)
+}
+
 func TestReloadTaskFromKV(t *testing.T) {
 	refreshParams()
 	kv, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
@@ -433,6 +586,7 @@ func TestReloadTaskFromKV(t *testing.T) {
 		cancel:           cancel,
 		client:           kv,
 		triggerTaskQueue: NewTaskQueue(),
+		triggerTasks:     make(map[UniqueID]task),
 	}
 
 	kvs := make(map[string]string)
@@ -457,10 +611,11 @@ func TestReloadTaskFromKV(t *testing.T) {
 			},
 		},
 	}
-	activeBlobs, err := activeTask.marshal()
+	// the values saved by the older versions and the current one are both reloaded
+	activeValue, err := marshalTaskValue(activeTask)
 	assert.Nil(t, err)
 	activeTaskKey := fmt.Sprintf("%s/%d", activeTaskPrefix, 101)
-	kvs[activeTaskKey] = string(activeBlobs)
+	kvs[activeTaskKey] = activeValue
 
 	stateKey := fmt.Sprintf("%s/%d", taskInfoPrefix, 100)
 	kvs[stateKey] = strconv.Itoa(int(taskDone))
@@ -474,6 +629,250 @@ func TestReloadTaskFromKV(t *testing.T) {
 	assert.Equal(t, 1, len(task.getChildTask()))
 }
 
+func TestCollectionTaskSerializer(t *testing.T) {
+	ctx := context.Background()
+	genTask := func(collectionID UniqueID) task {
+		return &loadCollectionTask{
+			baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+			LoadCollectionRequest: &querypb.LoadCollectionRequest{
+				CollectionID: collectionID,
+			},
+		}
+	}
+
+	var mu sync.Mutex
+	executed := make(map[UniqueID][]task)
+	block := make(chan struct{})
+	process := func(triggerTask task) {
+		collectionID, ok := taskCollectionID(triggerTask)
+		assert.True(t, ok)
+		if collectionID == 1 {
+			<-block
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		executed[collectionID] = append(executed[collectionID], triggerTask)
+	}
+
+	serializer := newCollectionTaskSerializer()
+	tasks1 := []task{genTask(1), genTask(1), genTask(1)}
+	for _, triggerTask := range tasks1 {
+		serializer.run(1, triggerTask, process)
+	}
+	tasks2 := []task{genTask(2), genTask(2)}
+	for _, triggerTask := range tasks2 {
+		serializer.run(2, triggerTask, process)
+	}
+
+	// the tasks of collection 2 are not blocked by collection 1
+	for {
+		mu.Lock()
+		done := len(executed[2]) == len(tasks2)
+		mu.Unlock()
+		if done {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	mu.Lock()
+	assert.Equal(t, 0, len(executed[1]))
+	mu.Unlock()
+
+	close(block)
+	serializer.wait()
+	assert.Equal(t, tasks1, executed[1])
+	assert.Equal(t, tasks2, executed[2])
+	assert.Equal(t, 0, len(serializer.pending))
+
+	_, ok := taskCollectionID(&loadBalanceTask{})
+	assert.False(t, ok)
+}
+
+func TestGetTaskInfos(t *testing.T) {
+	ctx := context.Background()
+	scheduler := &TaskScheduler{
+		triggerTasks: make(map[UniqueID]task),
+	}
+
+	loadTask := &loadCollectionTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+		LoadCollectionRequest: &querypb.LoadCollectionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadCollection,
+			},
+			CollectionID: defaultCollectionID,
+		},
+	}
+	loadTask.setTaskID(100)
+	loadTask.setState(taskDone)
+	loadTask.setPhase(taskWaitingChildren)
+
+	loadSegment := &loadSegmentTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+		LoadSegmentsRequest: &querypb.LoadSegmentsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadSegments,
+			},
+			DstNodeID:    2,
+			CollectionID: defaultCollectionID,
+		},
+	}
+	loadSegment.setTaskID(101)
+	loadSegment.setState(taskDoing)
+	loadSegment.setPhase(taskExecuting)
+	loadTask.addChildTask(loadSegment)
+
+	watchDmChannel := &watchDmChannelTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+		WatchDmChannelsRequest: &querypb.WatchDmChannelsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_WatchDmChannels,
+			},
+			NodeID:       1,
+			CollectionID: defaultCollectionID,
+		},
+	}
+	watchDmChannel.setTaskID(102)
+	watchDmChannel.setState(taskDone)
+	loadTask.addChildTask(watchDmChannel)
+	scheduler.addTriggerTask(loadTask)
+
+	releaseTask := &releaseCollectionTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+		ReleaseCollectionRequest: &querypb.ReleaseCollectionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_ReleaseCollection,
+			},
+			CollectionID: defaultCollectionID,
+		},
+	}
+	releaseTask.setTaskID(99)
+	scheduler.addTriggerTask(releaseTask)
+
+	infos := scheduler.getTaskInfos()
+	assert.Equal(t, 2, len(infos))
+
+	assert.Equal(t, int64(99), infos[0].TaskID)
+	assert.Equal(t, commonpb.MsgType_ReleaseCollection.String(), infos[0].Type)
+	assert.Equal(t, "undo", infos[0].State)
+	assert.Equal(t, "enqueued", infos[0].Phase)
+	assert.Equal(t, 0, len(infos[0].NodeIDs))
+	assert.Equal(t, 0, len(infos[0].ChildTasks))
+
+	assert.Equal(t, int64(100), infos[1].TaskID)
+	assert.Equal(t, defaultCollectionID, infos[1].CollectionID)
+	assert.Equal(t, "done", infos[1].State)
+	assert.Equal(t, "waitingChildren", infos[1].Phase)
+	assert.GreaterOrEqual(t, infos[1].ElapsedMs, infos[1].PhaseElapsedMs)
+	assert.Equal(t, []int64{1, 2}, infos[1].NodeIDs)
+	assert.Equal(t, map[string]int{"doing": 1, "done": 1}, infos[1].ChildTaskStates)
+	assert.Equal(t, 2, len(infos[1].ChildTasks))
+	assert.Equal(t, "executing", infos[1].ChildTasks[0].Phase)
+	assert.Equal(t, []int64{2}, infos[1].ChildTasks[0].NodeIDs)
+
+	scheduler.removeTriggerTask(releaseTask)
+	infos = scheduler.getTaskInfos()
+	assert.Equal(t, 1, len(infos))
+	assert.Equal(t, int64(100), infos[0].TaskID)
+}
+
+func TestGetNodeTaskInfos(t *testing.T) {
+	ctx := context.Background()
+	scheduler := &TaskScheduler{
+		triggerTasks: make(map[UniqueID]task),
+	}
+
+	loadTask := &loadCollectionTask{
+		baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+		LoadCollectionRequest: &querypb.LoadCollectionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadCollection,
+			},
+			CollectionID: defaultCollectionID,
+		},
+	}
+	loadTask.setTaskID(100)
+	loadTask.setPhase(taskWaitingChildren)
+
+	newLoadSegment := func(taskID UniqueID, nodeID int64, segmentIDs ...UniqueID) *loadSegmentTask {
+		infos := make([]*querypb.SegmentLoadInfo, 0, len(segmentIDs))
+		for _, segmentID := range segmentIDs {
+			infos = append(infos, &querypb.SegmentLoadInfo{SegmentID: segmentID})
+		}
+		loadSegment := &loadSegmentTask{
+			baseTask: newBaseTask(ctx, querypb.TriggerCondition_grpcRequest),
+			LoadSegmentsRequest: &querypb.LoadSegmentsRequest{
+				Base: &commonpb.MsgBase{
+					MsgType: commonpb.MsgType_LoadSegments,
+				},
+				DstNodeID:    nodeID,
+				CollectionID: defaultCollectionID,
+				Infos:        infos,
+			},
+		}
+		loadSegment.setTaskID(taskID)
+		return loadSegment
+	}
+	executing := newLoadSegment(102, 2, 1001, 1000)
+	executing.setState(taskDoing)
+	executing.setPhase(taskExecuting)
+	loadTask.addChildTask(executing)
+	queued := newLoadSegment(101, 2, 1002)
+	loadTask.addChildTask(queued)
+	done := newLoadSegment(103, 2, 1003)
+	done.setState(taskDone)
+	loadTask.addChildTask(done)
+	scheduler.addTriggerTask(loadTask)
+
+	// a child task failed on another node
+	failed := newLoadSegment(104, 3, 1004)
+	scheduler.recordFailedTask(failed, loadTask, errors.New("mocked load failure"))
+
+	infos := scheduler.getNodeTaskInfos()
+	assert.Equal(t, 2, len(infos))
+
+	assert.Equal(t, int64(2), infos[0].NodeID)
+	assert.Equal(t, 3, len(infos[0].LoadingSegments))
+	assert.Equal(t, int64(1002), infos[0].LoadingSegments[0].SegmentID)
+	assert.Equal(t, "enqueued", infos[0].LoadingSegments[0].Phase)
+	assert.Equal(t, int64(1000), infos[0].LoadingSegments[1].SegmentID)
+	assert.Equal(t, int64(1001), infos[0].LoadingSegments[2].SegmentID)
+	assert.Equal(t, "executing", infos[0].LoadingSegments[2].Phase)
+	assert.Equal(t, 1, len(infos[0].DispatchQueue))
+	assert.Equal(t, int64(101), infos[0].DispatchQueue[0].TaskID)
+	assert.Equal(t, 0, len(infos[0].FailedTasks))
+
+	assert.Equal(t, int64(3), infos[1].NodeID)
+	assert.Equal(t, 0, len(infos[1].LoadingSegments))
+	assert.Equal(t, 1, len(infos[1].FailedTasks))
+	assert.Equal(t, int64(104), infos[1].FailedTasks[0].TaskID)
+	assert.Equal(t, int64(100), infos[1].FailedTasks[0].TriggerTaskID)
+	assert.Equal(t, []int64{1004}, infos[1].FailedTasks[0].SegmentIDs)
+	assert.Equal(t, "mocked load failure", infos[1].FailedTasks[0].Reason)
+
+	self := metricsinfo.ComponentInfo{NodeTasks: infos}
+	resp, err := metricsinfo.MarshalComponentInfos(self)
+	assert.NoError(t, err)
+	for _, field := range []string{`"node_tasks"`, `"loading_segments"`, `"enqueue_time"`, `"elapsed_ms"`,
+		`"dispatch_queue"`, `"failed_tasks"`, `"reason":"mocked load failure"`} {
+		assert.Contains(t, resp, field)
+	}
+}
+
+func TestFailedTaskRing(t *testing.T) {
+	ring := failedTaskRing{}
+	assert.Equal(t, 0, len(ring.list()))
+	for i := 0; i < maxRecentFailedTasks+10; i++ {
+		ring.add(metricsinfo.FailedTaskInfo{TaskID: int64(i)})
+	}
+	infos := ring.list()
+	assert.Equal(t, maxRecentFailedTasks, len(infos))
+	assert.Equal(t, maxRecentFailedTasks, cap(ring.tasks))
+	for i, info := range infos {
+		assert.Equal(t, int64(i+10), info.TaskID)
+	}
+}
+
 func Test_saveInternalTaskToEtcd(t *testing.T) {
 	refreshParams()
 	ctx := context.Background()
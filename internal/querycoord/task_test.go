@@ -13,7 +13,9 @@ package querycoord
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -36,6 +38,7 @@ func genLoadCollectionTask(ctx context.Context, queryCoord *QueryCoord) *loadCol
 		LoadCollectionRequest: req,
 		rootCoord:             queryCoord.rootCoordClient,
 		dataCoord:             queryCoord.dataCoordClient,
+		indexCoord:            queryCoord.indexCoordClient,
 		cluster:               queryCoord.cluster,
 		meta:                  queryCoord.meta,
 	}
@@ -55,7 +58,9 @@ func genLoadPartitionTask(ctx context.Context, queryCoord *QueryCoord) *loadPart
 	loadPartitionTask := &loadPartitionTask{
 		baseTask:              baseTask,
 		LoadPartitionsRequest: req,
+		rootCoord:             queryCoord.rootCoordClient,
 		dataCoord:             queryCoord.dataCoordClient,
+		indexCoord:            queryCoord.indexCoordClient,
 		cluster:               queryCoord.cluster,
 		meta:                  queryCoord.meta,
 	}
@@ -336,6 +341,230 @@ func Test_RepeatLoadCollection(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_LoadCollectionAfterReleaseCollection(t *testing.T) {
+	refreshParams()
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	node, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, node.queryNodeID)
+
+	loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+	err = queryCoord.scheduler.Enqueue(loadCollectionTask)
+	assert.Nil(t, err)
+	waitTaskFinalState(loadCollectionTask, taskExpired)
+
+	// release and load again back to back, the load must wait until the release is done
+	releaseCollectionTask := genReleaseCollectionTask(ctx, queryCoord)
+	err = queryCoord.scheduler.Enqueue(releaseCollectionTask)
+	assert.Nil(t, err)
+	loadCollectionTask = genLoadCollectionTask(ctx, queryCoord)
+	err = queryCoord.scheduler.Enqueue(loadCollectionTask)
+	assert.Nil(t, err)
+
+	waitTaskFinalState(releaseCollectionTask, taskExpired)
+	waitTaskFinalState(loadCollectionTask, taskExpired)
+
+	info, err := queryCoord.meta.getCollectionInfoByID(defaultCollectionID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), info.InMemoryPercentage)
+
+	node.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
+func Test_LoadCollectionValidateWithRootCoord(t *testing.T) {
+	refreshParams()
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	node, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, node.queryNodeID)
+
+	t.Run("Test SchemaFromRootCoord", func(t *testing.T) {
+		loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+		loadCollectionTask.Schema = nil
+		err = queryCoord.scheduler.Enqueue(loadCollectionTask)
+		assert.Nil(t, err)
+		waitTaskFinalState(loadCollectionTask, taskExpired)
+
+		schema := genCollectionSchema(defaultCollectionID, false)
+		assert.True(t, proto.Equal(schema, loadCollectionTask.Schema))
+		for _, childTask := range loadCollectionTask.getChildTask() {
+			if loadSegmentTask, ok := childTask.(*loadSegmentTask); ok {
+				assert.True(t, proto.Equal(schema, loadSegmentTask.Schema))
+			}
+		}
+
+		releaseCollectionTask := genReleaseCollectionTask(ctx, queryCoord)
+		err = queryCoord.scheduler.Enqueue(releaseCollectionTask)
+		assert.Nil(t, err)
+		waitTaskFinalState(releaseCollectionTask, taskExpired)
+	})
+
+	t.Run("Test PartitionNotFound", func(t *testing.T) {
+		loadPartitionTask := genLoadPartitionTask(ctx, queryCoord)
+		loadPartitionTask.PartitionIDs = []UniqueID{defaultPartitionID, -1}
+		err = queryCoord.scheduler.Enqueue(loadPartitionTask)
+		assert.Nil(t, err)
+
+		err = loadPartitionTask.waitToFinish()
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IllegalArgument, failedTaskErrorCode(loadPartitionTask))
+		assert.False(t, queryCoord.meta.hasCollection(defaultCollectionID))
+	})
+
+	t.Run("Test CollectionDroppedBeforeExecute", func(t *testing.T) {
+		// the scheduler is started after the collection is dropped, so that the task is executed after that
+		rootCoord := queryCoord.rootCoordClient.(*rootCoordMock)
+		scheduler, err := NewTaskScheduler(ctx, queryCoord.meta, queryCoord.cluster, queryCoord.kvClient,
+			rootCoord, queryCoord.dataCoordClient, queryCoord.indexCoordClient, queryCoord.idAllocator)
+		assert.Nil(t, err)
+
+		loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+		err = scheduler.Enqueue(loadCollectionTask)
+		assert.Nil(t, err)
+		rootCoord.dropCollection(defaultCollectionID)
+		err = scheduler.Start()
+		assert.Nil(t, err)
+
+		err = loadCollectionTask.waitToFinish()
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, failedTaskErrorCode(loadCollectionTask))
+		assert.Equal(t, 0, len(loadCollectionTask.getChildTask()))
+		assert.False(t, queryCoord.meta.hasCollection(defaultCollectionID))
+		scheduler.Close()
+
+		status, err := queryCoord.LoadCollection(ctx, genLoadCollectionTask(ctx, queryCoord).LoadCollectionRequest)
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_CollectionNotExists, status.ErrorCode)
+	})
+
+	node.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
+func Test_LoadWithUnindexedSegmentPolicy(t *testing.T) {
+	refreshParams()
+	interval := waitForIndexInterval
+	waitForIndexInterval = 10 * time.Millisecond
+	defer func() {
+		waitForIndexInterval = interval
+	}()
+
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	node, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, node.queryNodeID)
+
+	rootCoord := queryCoord.rootCoordClient.(*rootCoordMock)
+	indexCoord := queryCoord.indexCoordClient.(*indexCoordMock)
+
+	t.Run("Test FailOnUnindexed", func(t *testing.T) {
+		rootCoord.setEnableIndex(false)
+		loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+		loadCollectionTask.UnindexedSegmentPolicy = commonpb.UnindexedSegmentPolicy_FailOnUnindexed
+		err = queryCoord.scheduler.Enqueue(loadCollectionTask)
+		assert.Nil(t, err)
+
+		err = loadCollectionTask.waitToFinish()
+		assert.NotNil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_IndexNotExist, failedTaskErrorCode(loadCollectionTask))
+		assert.Equal(t, 0, len(loadCollectionTask.getChildTask()))
+		assert.False(t, queryCoord.meta.hasCollection(defaultCollectionID))
+	})
+
+	t.Run("Test WaitForIndex", func(t *testing.T) {
+		rootCoord.setEnableIndex(true)
+		delay := 500 * time.Millisecond
+		indexCoord.delayIndexBuild(delay)
+		start := time.Now()
+		loadPartitionTask := genLoadPartitionTask(ctx, queryCoord)
+		loadPartitionTask.UnindexedSegmentPolicy = commonpb.UnindexedSegmentPolicy_WaitForIndex
+		err = queryCoord.scheduler.Enqueue(loadPartitionTask)
+		assert.Nil(t, err)
+
+		err = loadPartitionTask.waitToFinish()
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) >= delay)
+		waitTaskFinalState(loadPartitionTask, taskExpired)
+		assert.True(t, queryCoord.meta.hasPartition(defaultCollectionID, defaultPartitionID))
+
+		releaseCollectionTask := genReleaseCollectionTask(ctx, queryCoord)
+		err = queryCoord.scheduler.Enqueue(releaseCollectionTask)
+		assert.Nil(t, err)
+		waitTaskFinalState(releaseCollectionTask, taskExpired)
+	})
+
+	node.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
+func Test_LoadCollectionProgress(t *testing.T) {
+	refreshParams()
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+	err = queryCoord.meta.addCollection(defaultCollectionID, loadCollectionTask.Schema)
+	assert.Nil(t, err)
+	err = queryCoord.meta.addPartition(defaultCollectionID, defaultPartitionID)
+	assert.Nil(t, err)
+
+	childTasks := make([]task, 0)
+	for i := 0; i < 4; i++ {
+		childTask := genLoadSegmentTask(ctx, queryCoord, 1)
+		childTask.setParentTask(loadCollectionTask)
+		loadCollectionTask.addChildTask(childTask)
+		childTasks = append(childTasks, childTask)
+	}
+	showCollection := func() int64 {
+		res, err := queryCoord.ShowCollections(ctx, &querypb.ShowCollectionsRequest{
+			Base:          &commonpb.MsgBase{MsgType: commonpb.MsgType_ShowCollections},
+			CollectionIDs: []UniqueID{defaultCollectionID},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, res.Status.ErrorCode)
+		return res.InMemoryPercentages[0]
+	}
+
+	// half of the child tasks are done
+	childTasks[0].setState(taskDone)
+	childTasks[1].setState(taskDone)
+	childTasks[1].updateTaskProcess()
+	assert.EqualValues(t, 50, showCollection())
+	res, err := queryCoord.ShowPartitions(ctx, &querypb.ShowPartitionsRequest{
+		Base:         &commonpb.MsgBase{MsgType: commonpb.MsgType_ShowPartitions},
+		CollectionID: defaultCollectionID,
+		PartitionIDs: []UniqueID{defaultPartitionID},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{50}, res.InMemoryPercentages)
+
+	childTasks[2].setState(taskDone)
+	childTasks[3].setState(taskDone)
+	childTasks[3].updateTaskProcess()
+	assert.EqualValues(t, 100, showCollection())
+
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
 func Test_LoadCollectionAssignTaskFail(t *testing.T) {
 	refreshParams()
 	ctx := context.Background()
@@ -474,6 +703,54 @@ func Test_ReleaseCollectionExecuteFail(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_ReleaseTaskNumPerNode(t *testing.T) {
+	refreshParams()
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	nodes := make([]*queryNodeServerMock, 0)
+	for i := 0; i < 3; i++ {
+		node, err := startQueryNodeServer(ctx)
+		assert.Nil(t, err)
+		waitQueryNodeOnline(queryCoord.cluster, node.queryNodeID)
+		nodes = append(nodes, node)
+	}
+
+	// the segments are released by collection or partitions in a single request per node,
+	// so the number of child tasks doesn't grow with the number of segments
+	numSegments := 500
+	segmentInfos := make(map[UniqueID]*querypb.SegmentInfo)
+	for i := 0; i < numSegments; i++ {
+		segmentID := defaultSegmentID + UniqueID(i)
+		segmentInfos[segmentID] = &querypb.SegmentInfo{
+			SegmentID:    segmentID,
+			CollectionID: defaultCollectionID,
+			PartitionID:  defaultPartitionID,
+			NodeID:       nodes[i%len(nodes)].queryNodeID,
+		}
+	}
+	err = queryCoord.meta.setSegmentInfos(segmentInfos)
+	assert.Nil(t, err)
+
+	releasePartitionTask := genReleasePartitionTask(ctx, queryCoord)
+	err = queryCoord.scheduler.processTask(releasePartitionTask)
+	assert.Nil(t, err)
+	assert.Equal(t, len(nodes), len(releasePartitionTask.getChildTask()))
+
+	releaseCollectionTask := genReleaseCollectionTask(ctx, queryCoord)
+	err = queryCoord.scheduler.processTask(releaseCollectionTask)
+	assert.Nil(t, err)
+	assert.Equal(t, len(nodes), len(releaseCollectionTask.getChildTask()))
+
+	for _, node := range nodes {
+		node.stop()
+	}
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
 func Test_LoadSegmentReschedule(t *testing.T) {
 	refreshParams()
 	ctx := context.Background()
@@ -503,6 +780,109 @@ func Test_LoadSegmentReschedule(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_LoadSegmentVerify(t *testing.T) {
+	refreshParams()
+	verify, verifyTimeout := Params.VerifyLoadedSegments, Params.VerifyLoadedSegmentsTimeout
+	Params.VerifyLoadedSegments = true
+	Params.VerifyLoadedSegmentsTimeout = time.Second
+	defer func() {
+		Params.VerifyLoadedSegments, Params.VerifyLoadedSegmentsTimeout = verify, verifyTimeout
+	}()
+
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	node1, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+	node1.getSegmentInfos = returnEmptySegmentInfos
+
+	node2, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+
+	waitQueryNodeOnline(queryCoord.cluster, node1.queryNodeID)
+	waitQueryNodeOnline(queryCoord.cluster, node2.queryNodeID)
+
+	t.Run("Test segments not loaded", func(t *testing.T) {
+		loadSegmentTask := genLoadSegmentTask(ctx, queryCoord, node1.queryNodeID)
+		err := loadSegmentTask.execute(ctx)
+		assert.NotNil(t, err)
+		assert.False(t, loadSegmentTask.isRetryable())
+	})
+
+	t.Run("Test segments loaded", func(t *testing.T) {
+		loadSegmentTask := genLoadSegmentTask(ctx, queryCoord, node2.queryNodeID)
+		err := loadSegmentTask.execute(ctx)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Test reschedule to another node", func(t *testing.T) {
+		loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+		err := queryCoord.scheduler.Enqueue(loadCollectionTask)
+		assert.Nil(t, err)
+
+		waitTaskFinalState(loadCollectionTask, taskExpired)
+		assert.Equal(t, commonpb.ErrorCode_Success, loadCollectionTask.getResultInfo().ErrorCode)
+	})
+
+	node1.stop()
+	node2.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
+func Test_LoadSegmentVersion(t *testing.T) {
+	refreshParams()
+	ctx := context.Background()
+	queryCoord, err := startQueryCoord(ctx)
+	assert.Nil(t, err)
+
+	node1, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+	node2, err := startQueryNodeServer(ctx)
+	assert.Nil(t, err)
+	waitQueryNodeOnline(queryCoord.cluster, node1.queryNodeID)
+	waitQueryNodeOnline(queryCoord.cluster, node2.queryNodeID)
+
+	loadCollectionTask := genLoadCollectionTask(ctx, queryCoord)
+	err = queryCoord.scheduler.Enqueue(loadCollectionTask)
+	assert.Nil(t, err)
+	waitTaskFinalState(loadCollectionTask, taskExpired)
+
+	loadSegment := func(node *queryNodeServerMock, taskID UniqueID) {
+		loadSegmentTask := genLoadSegmentTask(ctx, queryCoord, node.queryNodeID)
+		loadSegmentTask.setTaskID(taskID)
+		err := loadSegmentTask.execute(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, taskID, loadSegmentTask.Infos[0].Version)
+
+		err = updateSegmentInfoFromTask(ctx, loadSegmentTask.parentTask, queryCoord.meta)
+		assert.Nil(t, err)
+
+		// the meta and the query node holding the segment agree on the version of the assignment
+		info, err := queryCoord.meta.getSegmentInfoByID(defaultSegmentID)
+		assert.Nil(t, err)
+		assert.Equal(t, node.queryNodeID, info.NodeID)
+		assert.Equal(t, taskID, info.Version)
+
+		node.segmentMu.Lock()
+		assert.Equal(t, taskID, node.segmentInfos[defaultSegmentID].Version)
+		node.segmentMu.Unlock()
+	}
+
+	// every reassignment gets a new version
+	loadSegment(node1, 1000)
+	loadSegment(node2, 1001)
+	loadSegment(node1, 1002)
+
+	node1.stop()
+	node2.stop()
+	queryCoord.Stop()
+	err = removeAllSession()
+	assert.Nil(t, err)
+}
+
 func Test_WatchDmChannelReschedule(t *testing.T) {
 	refreshParams()
 	ctx := context.Background()
@@ -694,7 +1074,7 @@ func Test_AssignInternalTask(t *testing.T) {
 		loadSegmentRequests = append(loadSegmentRequests, req)
 	}
 
-	err = assignInternalTask(queryCoord.loopCtx, defaultCollectionID, loadCollectionTask, queryCoord.meta, queryCoord.cluster, loadSegmentRequests, nil, false)
+	err = assignInternalTask(queryCoord.loopCtx, defaultCollectionID, loadCollectionTask, queryCoord.meta, queryCoord.cluster, loadSegmentRequests, nil, false, nil)
 	assert.Nil(t, err)
 
 	assert.NotEqual(t, 1, len(loadCollectionTask.getChildTask()))
@@ -50,6 +50,8 @@ type Collection struct {
 	releaseMu          sync.RWMutex // guards release
 	releasedPartitions map[UniqueID]struct{}
 	releaseTime        Timestamp
+
+	deleteBuffer *deleteBuffer
 }
 
 // ID returns collection id
@@ -219,6 +221,7 @@ func newCollection(collectionID UniqueID, schema *schemapb.CollectionSchema) *Co
 		vChannels:          make([]Channel, 0),
 		pChannels:          make([]Channel, 0),
 		releasedPartitions: make(map[UniqueID]struct{}),
+		deleteBuffer:       newDeleteBuffer(int(Params.DeleteBufferSize)),
 	}
 	C.free(unsafe.Pointer(cSchemaBlob))
 
@@ -71,6 +71,7 @@ type ReplicaInterface interface {
 	getSegmentByID(segmentID UniqueID) (*Segment, error)
 	hasSegment(segmentID UniqueID) bool
 	getSegmentNum() int
+	getSegments() []*Segment
 	getSegmentStatistics() []*internalpb.SegmentStats
 
 	// excluded segments
@@ -509,6 +510,18 @@ func (colReplica *collectionReplica) getSegmentNum() int {
 	return len(colReplica.segments)
 }
 
+// getSegments returns all the segments in collectionReplica
+func (colReplica *collectionReplica) getSegments() []*Segment {
+	colReplica.mu.RLock()
+	defer colReplica.mu.RUnlock()
+
+	segments := make([]*Segment, 0, len(colReplica.segments))
+	for _, segment := range colReplica.segments {
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
 //  getSegmentStatistics returns the statistics of segments in collectionReplica
 func (colReplica *collectionReplica) getSegmentStatistics() []*internalpb.SegmentStats {
 	colReplica.mu.RLock()
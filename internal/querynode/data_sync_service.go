@@ -37,6 +37,9 @@ type dataSyncService struct {
 	mu                   sync.Mutex                                   // guards FlowGraphs
 	collectionFlowGraphs map[UniqueID]map[Channel]*queryNodeFlowGraph // map[collectionID]flowGraphs
 	partitionFlowGraphs  map[UniqueID]map[Channel]*queryNodeFlowGraph // map[partitionID]flowGraphs
+	// flowGraphRef is the number of flow graphs created and not closed yet, it must equal to the number
+	// of flow graphs in collectionFlowGraphs and partitionFlowGraphs, otherwise some flow graph is leaked
+	flowGraphRef int
 
 	streamingReplica  ReplicaInterface
 	historicalReplica ReplicaInterface
@@ -65,11 +68,20 @@ func (dsService *dataSyncService) addCollectionFlowGraph(collectionID UniqueID,
 			dsService.tSafeReplica,
 			vChannel,
 			dsService.msFactory)
+		dsService.flowGraphRef++
+		// each flow graph holds a reference of the tSafe of its channel
+		dsService.tSafeReplica.addTSafe(vChannel)
+		if oldFlowGraph, ok := dsService.collectionFlowGraphs[collectionID][vChannel]; ok {
+			// the channel is watched again, the replaced flow graph would never be reached
+			dsService.closeFlowGraph(oldFlowGraph)
+			dsService.releaseTSafe(vChannel)
+		}
 		dsService.collectionFlowGraphs[collectionID][vChannel] = newFlowGraph
 		log.Debug("add collection flow graph",
 			zap.Any("collectionID", collectionID),
 			zap.Any("channel", vChannel))
 	}
+	dsService.checkFlowGraphRef()
 }
 
 func (dsService *dataSyncService) getCollectionFlowGraphs(collectionID UniqueID, vChannels []string) (map[Channel]*queryNodeFlowGraph, error) {
@@ -112,13 +124,21 @@ func (dsService *dataSyncService) removeCollectionFlowGraph(collectionID UniqueI
 	defer dsService.mu.Unlock()
 
 	if _, ok := dsService.collectionFlowGraphs[collectionID]; ok {
-		for _, nodeFG := range dsService.collectionFlowGraphs[collectionID] {
+		for channel, nodeFG := range dsService.collectionFlowGraphs[collectionID] {
 			// close flow graph
-			nodeFG.close()
+			dsService.closeFlowGraph(nodeFG)
+			// remove tSafe record
+			// no tSafe in tSafeReplica, don't return error
+			err := dsService.tSafeReplica.removeRecord(channel, collectionID)
+			if err != nil {
+				log.Warn(err.Error())
+			}
+			dsService.releaseTSafe(channel)
 		}
 		dsService.collectionFlowGraphs[collectionID] = nil
 	}
 	delete(dsService.collectionFlowGraphs, collectionID)
+	dsService.checkFlowGraphRef()
 }
 
 // partition flow graph
@@ -139,8 +159,17 @@ func (dsService *dataSyncService) addPartitionFlowGraph(collectionID UniqueID, p
 			dsService.tSafeReplica,
 			vChannel,
 			dsService.msFactory)
+		dsService.flowGraphRef++
+		// each flow graph holds a reference of the tSafe of its channel
+		dsService.tSafeReplica.addTSafe(vChannel)
+		if oldFlowGraph, ok := dsService.partitionFlowGraphs[partitionID][vChannel]; ok {
+			// the channel is watched again, the replaced flow graph would never be reached
+			dsService.closeFlowGraph(oldFlowGraph)
+			dsService.releaseTSafe(vChannel)
+		}
 		dsService.partitionFlowGraphs[partitionID][vChannel] = newFlowGraph
 	}
+	dsService.checkFlowGraphRef()
 }
 
 func (dsService *dataSyncService) getPartitionFlowGraphs(partitionID UniqueID, vChannels []string) (map[Channel]*queryNodeFlowGraph, error) {
@@ -185,17 +214,62 @@ func (dsService *dataSyncService) removePartitionFlowGraph(partitionID UniqueID)
 	if _, ok := dsService.partitionFlowGraphs[partitionID]; ok {
 		for channel, nodeFG := range dsService.partitionFlowGraphs[partitionID] {
 			// close flow graph
-			nodeFG.close()
+			dsService.closeFlowGraph(nodeFG)
 			// remove tSafe record
 			// no tSafe in tSafeReplica, don't return error
 			err := dsService.tSafeReplica.removeRecord(channel, partitionID)
 			if err != nil {
 				log.Warn(err.Error())
 			}
+			dsService.releaseTSafe(channel)
 		}
 		dsService.partitionFlowGraphs[partitionID] = nil
 	}
 	delete(dsService.partitionFlowGraphs, partitionID)
+	dsService.checkFlowGraphRef()
+}
+
+// closeFlowGraph closes the flow graph and releases its reference, the caller must hold mu
+func (dsService *dataSyncService) closeFlowGraph(nodeFG *queryNodeFlowGraph) {
+	if nodeFG == nil {
+		return
+	}
+	nodeFG.close()
+	dsService.flowGraphRef--
+}
+
+// releaseTSafe releases the tSafe reference of a closed flow graph, the tSafe and its watchers are removed
+// after the last flow graph of the channel is released, the caller must hold mu
+func (dsService *dataSyncService) releaseTSafe(channel Channel) {
+	// no tSafe in tSafeReplica, don't return error
+	if err := dsService.tSafeReplica.removeTSafe(channel); err != nil {
+		log.Warn(err.Error())
+	}
+}
+
+// getFlowGraphNum returns the number of flow graphs which are not closed
+func (dsService *dataSyncService) getFlowGraphNum() int {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+	return dsService.flowGraphRef
+}
+
+// checkFlowGraphRef panics if flowGraphRef mismatches the registered flow graphs, it only works in debug builds,
+// the caller must hold mu
+func (dsService *dataSyncService) checkFlowGraphRef() {
+	if !debugAssertEnabled {
+		return
+	}
+	num := 0
+	for _, nodeFGs := range dsService.collectionFlowGraphs {
+		num += len(nodeFGs)
+	}
+	for _, nodeFGs := range dsService.partitionFlowGraphs {
+		num += len(nodeFGs)
+	}
+	if num != dsService.flowGraphRef {
+		panic(fmt.Sprintf("flow graph reference count mismatch, registered = %d, referenced = %d", num, dsService.flowGraphRef))
+	}
 }
 
 // newDataSyncService returns a new dataSyncService
@@ -217,22 +291,22 @@ func newDataSyncService(ctx context.Context,
 }
 
 func (dsService *dataSyncService) close() {
+	dsService.mu.Lock()
+	defer dsService.mu.Unlock()
+
 	// close collection flow graphs
 	for _, nodeFGs := range dsService.collectionFlowGraphs {
 		for _, nodeFG := range nodeFGs {
-			if nodeFG != nil {
-				nodeFG.flowGraph.Close()
-			}
+			dsService.closeFlowGraph(nodeFG)
 		}
 	}
 	// close partition flow graphs
 	for _, nodeFGs := range dsService.partitionFlowGraphs {
 		for _, nodeFG := range nodeFGs {
-			if nodeFG != nil {
-				nodeFG.flowGraph.Close()
-			}
+			dsService.closeFlowGraph(nodeFG)
 		}
 	}
 	dsService.collectionFlowGraphs = make(map[UniqueID]map[Channel]*queryNodeFlowGraph)
 	dsService.partitionFlowGraphs = make(map[UniqueID]map[Channel]*queryNodeFlowGraph)
+	dsService.checkFlowGraphRef()
 }
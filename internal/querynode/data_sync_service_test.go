@@ -15,9 +15,12 @@ import (
 	"context"
 	"encoding/binary"
 	"math"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
 
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -244,3 +247,152 @@ func TestDataSyncService_removePartitionFlowGraphs(t *testing.T) {
 		dataSyncService.removePartitionFlowGraph(defaultPartitionID)
 	})
 }
+
+func TestDataSyncService_flowGraphsHoldTSafe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streaming, err := genSimpleStreaming(ctx)
+	assert.NoError(t, err)
+
+	historicalReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	fac, err := genFactory()
+	assert.NoError(t, err)
+
+	tSafeReplica := newTSafeReplica()
+	dataSyncService := newDataSyncService(ctx, streaming.replica, historicalReplica, tSafeReplica, fac)
+
+	// the partition flow graphs of a channel share its tSafe
+	otherPartitionID := defaultPartitionID + 1
+	dataSyncService.addPartitionFlowGraph(defaultCollectionID, defaultPartitionID, []Channel{defaultVChannel})
+	dataSyncService.addPartitionFlowGraph(defaultCollectionID, otherPartitionID, []Channel{defaultVChannel})
+	// watching the channel again replaces the flow graph and keeps the tSafe
+	dataSyncService.addPartitionFlowGraph(defaultCollectionID, otherPartitionID, []Channel{defaultVChannel})
+
+	watcher := newTSafeWatcher()
+	assert.NoError(t, tSafeReplica.registerTSafeWatcher(defaultVChannel, watcher))
+
+	dataSyncService.removePartitionFlowGraph(defaultPartitionID)
+	_, err = tSafeReplica.getTSafe(defaultVChannel)
+	assert.NoError(t, err)
+
+	// the tSafe and its watchers are removed with the last flow graph
+	dataSyncService.removePartitionFlowGraph(otherPartitionID)
+	_, err = tSafeReplica.getTSafe(defaultVChannel)
+	assert.Error(t, err)
+	_, ok := <-watcher.watcherChan()
+	assert.False(t, ok)
+	assert.Equal(t, 0, dataSyncService.getFlowGraphNum())
+}
+
+// countingMsgStreamFactory counts the subscriptions of the msgstreams it creates which are not deleted
+type countingMsgStreamFactory struct {
+	msgstream.Factory
+	mu            sync.Mutex
+	subscriptions int
+}
+
+func (f *countingMsgStreamFactory) NewTtMsgStream(ctx context.Context) (msgstream.MsgStream, error) {
+	stream, err := f.Factory.NewTtMsgStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &countingMsgStream{MsgStream: stream, factory: f}, nil
+}
+
+func (f *countingMsgStreamFactory) getSubscriptions() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.subscriptions
+}
+
+type countingMsgStream struct {
+	msgstream.MsgStream
+	factory    *countingMsgStreamFactory
+	subscribed int
+}
+
+func (s *countingMsgStream) AsConsumer(channels []string, subName string) {
+	s.MsgStream.AsConsumer(channels, subName)
+	s.factory.mu.Lock()
+	defer s.factory.mu.Unlock()
+	s.subscribed += len(channels)
+	s.factory.subscriptions += len(channels)
+}
+
+func (s *countingMsgStream) Unsubscribe() {
+	s.MsgStream.Unsubscribe()
+	s.factory.mu.Lock()
+	defer s.factory.mu.Unlock()
+	s.factory.subscriptions -= s.subscribed
+	s.subscribed = 0
+}
+
+func TestDataSyncService_releaseReclaimsResources(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streaming, err := genSimpleStreaming(ctx)
+	assert.NoError(t, err)
+
+	historicalReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	fac, err := genFactory()
+	assert.NoError(t, err)
+	countingFac := &countingMsgStreamFactory{Factory: fac}
+
+	dataSyncService := newDataSyncService(ctx, streaming.replica, historicalReplica, streaming.tSafeReplica, countingFac)
+
+	// goroutines started from now on must all quit after the flow graphs are released
+	baseline := goleak.IgnoreCurrent()
+
+	const loadTimes = 50
+	for i := 0; i < loadTimes; i++ {
+		dataSyncService.tSafeReplica.addTSafe(defaultVChannel)
+		dataSyncService.addCollectionFlowGraph(defaultCollectionID, []Channel{defaultVChannel})
+		fgs, err := dataSyncService.getCollectionFlowGraphs(defaultCollectionID, []Channel{defaultVChannel})
+		assert.NoError(t, err)
+		for _, fg := range fgs {
+			err = fg.consumerFlowGraph(defaultVChannel, defaultSubName+"-release-"+strconv.Itoa(i))
+			assert.NoError(t, err)
+		}
+		err = dataSyncService.startCollectionFlowGraph(defaultCollectionID, []Channel{defaultVChannel})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dataSyncService.getFlowGraphNum())
+		assert.Equal(t, 1, countingFac.getSubscriptions())
+
+		dataSyncService.removeCollectionFlowGraph(defaultCollectionID)
+		err = dataSyncService.tSafeReplica.removeTSafe(defaultVChannel)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, dataSyncService.getFlowGraphNum())
+		assert.Equal(t, 0, countingFac.getSubscriptions())
+	}
+
+	goleak.VerifyNone(t, baseline)
+}
+
+func TestDataSyncService_replaceFlowGraph(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streaming, err := genSimpleStreaming(ctx)
+	assert.NoError(t, err)
+
+	historicalReplica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	fac, err := genFactory()
+	assert.NoError(t, err)
+
+	dataSyncService := newDataSyncService(ctx, streaming.replica, historicalReplica, streaming.tSafeReplica, fac)
+
+	dataSyncService.addPartitionFlowGraph(defaultCollectionID, defaultPartitionID, []Channel{defaultVChannel})
+	dataSyncService.addPartitionFlowGraph(defaultCollectionID, defaultPartitionID, []Channel{defaultVChannel})
+	assert.Equal(t, 1, dataSyncService.getFlowGraphNum())
+
+	dataSyncService.close()
+	assert.Equal(t, 0, dataSyncService.getFlowGraphNum())
+}
@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+//go:build debug
+// +build debug
+
+package querynode
+
+// debugAssertEnabled enables the internal consistency assertions, which panic once a resource is leaked,
+// build with `-tags debug` to enable them
+const debugAssertEnabled = true
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"sync"
+)
+
+// deleteRecord is a delete of a primary key consumed from the dml channels
+type deleteRecord struct {
+	partitionID UniqueID // -1 means all the partitions of the collection
+	pk          int64
+	ts          Timestamp
+}
+
+// deleteBuffer keeps the latest delete records of a collection consumed from the dml channels.
+// The deletes of a sealed segment consumed after its deltalogs were dumped but before the segment is set into
+// the historical replica are not applied by the insert node, the segment loader applies them from the buffer.
+type deleteBuffer struct {
+	mu       sync.Mutex
+	records  []deleteRecord
+	capacity int
+}
+
+func newDeleteBuffer(capacity int) *deleteBuffer {
+	return &deleteBuffer{
+		records:  make([]deleteRecord, 0),
+		capacity: capacity,
+	}
+}
+
+// add buffers the deletes of a delete message, the oldest records are evicted when the buffer is full
+func (b *deleteBuffer) add(partitionID UniqueID, pks []int64, tss []Timestamp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.capacity <= 0 {
+		return
+	}
+	for i := range pks {
+		b.records = append(b.records, deleteRecord{partitionID: partitionID, pk: pks[i], ts: tss[i]})
+	}
+	if overflow := len(b.records) - b.capacity; overflow > 0 {
+		b.records = append(make([]deleteRecord, 0, b.capacity), b.records[overflow:]...)
+	}
+}
+
+// applyThen calls fn with the buffered deletes of the partition, no delete is buffered until fn returns.
+// fn is expected to apply the deletes to a segment and set it into the replica, so that the deletes consumed
+// afterwards are applied to the segment by the insert node.
+func (b *deleteBuffer) applyThen(partitionID UniqueID, fn func(pks []int64, tss []Timestamp) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pks := make([]int64, 0)
+	tss := make([]Timestamp, 0)
+	for _, r := range b.records {
+		if r.partitionID == -1 || r.partitionID == partitionID {
+			pks = append(pks, r.pk)
+			tss = append(tss, r.ts)
+		}
+	}
+	return fn(pks, tss)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteBuffer(t *testing.T) {
+	t.Run("apply by partition", func(t *testing.T) {
+		buffer := newDeleteBuffer(10)
+		buffer.add(1, []int64{1, 2}, []Timestamp{10, 11})
+		buffer.add(2, []int64{3}, []Timestamp{12})
+		buffer.add(-1, []int64{4}, []Timestamp{13})
+
+		err := buffer.applyThen(1, func(pks []int64, tss []Timestamp) error {
+			assert.Equal(t, []int64{1, 2, 4}, pks)
+			assert.Equal(t, []Timestamp{10, 11, 13}, tss)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		err = buffer.applyThen(3, func(pks []int64, tss []Timestamp) error {
+			assert.Equal(t, []int64{4}, pks)
+			return errors.New("mock error")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("evict the oldest", func(t *testing.T) {
+		buffer := newDeleteBuffer(3)
+		buffer.add(1, []int64{1, 2}, []Timestamp{10, 11})
+		buffer.add(1, []int64{3, 4}, []Timestamp{12, 13})
+
+		err := buffer.applyThen(1, func(pks []int64, tss []Timestamp) error {
+			assert.Equal(t, []int64{2, 3, 4}, pks)
+			assert.Equal(t, []Timestamp{11, 12, 13}, tss)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		buffer := newDeleteBuffer(0)
+		buffer.add(1, []int64{1}, []Timestamp{10})
+
+		err := buffer.applyThen(1, func(pks []int64, tss []Timestamp) error {
+			assert.Empty(t, pks)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -16,6 +16,14 @@ import (
 	"fmt"
 )
 
+// errOutOfMemory is returned if loading the segments would use up the memory of the query node
+var errOutOfMemory = errors.New("load segment failed, OOM if load")
+
+// errRetrieveResultTooLarge is returned if the estimated results of a retrieve request exceed the per request limit
+func errRetrieveResultTooLarge(size, limit int64) error {
+	return fmt.Errorf("result too large, add a limit to the query, estimated size = %d bytes, max size = %d bytes", size, limit)
+}
+
 // error msg of unhealthy query node
 func msgQueryNodeIsUnhealthy(nodeID UniqueID) string {
 	return fmt.Sprintf("query node %d is not ready", nodeID)
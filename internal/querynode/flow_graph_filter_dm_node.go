@@ -29,6 +29,7 @@ type filterDmNode struct {
 	loadType     loadType // load collection or load partition
 	collectionID UniqueID
 	partitionID  UniqueID
+	channel      Channel
 	replica      ReplicaInterface
 }
 
@@ -119,6 +120,11 @@ func (fdmNode *filterDmNode) filterInvalidDeleteMessage(msg *msgstream.DeleteMsg
 		return nil
 	}
 
+	// several vchannels share one physical channel, only keep the messages of this flow graph's vchannel
+	if !fdmNode.isOwnShard(msg.ShardName) {
+		return nil
+	}
+
 	// if the flow graph type is partition, check if the partition is target partition
 	if fdmNode.loadType == loadTypePartition && msg.PartitionID != fdmNode.partitionID {
 		log.Debug("filter invalid delete message, partition is not the target partition",
@@ -183,6 +189,11 @@ func (fdmNode *filterDmNode) filterInvalidInsertMessage(msg *msgstream.InsertMsg
 		return nil
 	}
 
+	// several vchannels share one physical channel, only keep the messages of this flow graph's vchannel
+	if !fdmNode.isOwnShard(msg.ShardName) {
+		return nil
+	}
+
 	// if the flow graph type is partition, check if the partition is target partition
 	if fdmNode.loadType == loadTypePartition && msg.PartitionID != fdmNode.partitionID {
 		log.Debug("filter invalid insert message, partition is not the target partition",
@@ -237,10 +248,17 @@ func (fdmNode *filterDmNode) filterInvalidInsertMessage(msg *msgstream.InsertMsg
 	return msg
 }
 
+// isOwnShard checks whether a message carrying shardName belongs to the vchannel of this flow graph,
+// messages without a shard name come from older proxies and are kept
+func (fdmNode *filterDmNode) isOwnShard(shardName string) bool {
+	return shardName == "" || fdmNode.channel == "" || shardName == fdmNode.channel
+}
+
 func newFilteredDmNode(replica ReplicaInterface,
 	loadType loadType,
 	collectionID UniqueID,
-	partitionID UniqueID) *filterDmNode {
+	partitionID UniqueID,
+	channel Channel) *filterDmNode {
 
 	maxQueueLength := Params.FlowGraphMaxQueueLength
 	maxParallelism := Params.FlowGraphMaxParallelism
@@ -260,6 +278,7 @@ func newFilteredDmNode(replica ReplicaInterface,
 		loadType:     loadType,
 		collectionID: collectionID,
 		partitionID:  partitionID,
+		channel:      channel,
 		replica:      replica,
 	}
 }
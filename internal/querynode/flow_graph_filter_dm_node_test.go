@@ -31,7 +31,7 @@ func getFilterDMNode(ctx context.Context) (*filterDmNode, error) {
 	}
 
 	streaming.replica.addExcludedSegments(defaultCollectionID, nil)
-	return newFilteredDmNode(streaming.replica, loadTypeCollection, defaultCollectionID, defaultPartitionID), nil
+	return newFilteredDmNode(streaming.replica, loadTypeCollection, defaultCollectionID, defaultPartitionID, defaultVChannel), nil
 }
 
 func TestFlowGraphFilterDmNode_filterDmNode(t *testing.T) {
@@ -44,7 +44,7 @@ func TestFlowGraphFilterDmNode_filterDmNode(t *testing.T) {
 
 func TestFlowGraphFilterDmNode_invalidLoadType(t *testing.T) {
 	const invalidLoadType = -1
-	fg := newFilteredDmNode(nil, invalidLoadType, defaultCollectionID, defaultPartitionID)
+	fg := newFilteredDmNode(nil, invalidLoadType, defaultCollectionID, defaultPartitionID, defaultVChannel)
 	assert.Nil(t, fg)
 }
 
@@ -92,6 +92,20 @@ func TestFlowGraphFilterDmNode_filterInvalidInsertMessage(t *testing.T) {
 		assert.Nil(t, res)
 	})
 
+	t.Run("test not target vchannel", func(t *testing.T) {
+		msg, err := genSimpleInsertMsg()
+		assert.NoError(t, err)
+		fg, err := getFilterDMNode(ctx)
+		assert.NoError(t, err)
+		msg.ShardName = defaultVChannel + "-other"
+		res := fg.filterInvalidInsertMessage(msg)
+		assert.Nil(t, res)
+
+		msg.ShardName = ""
+		res = fg.filterInvalidInsertMessage(msg)
+		assert.NotNil(t, res)
+	})
+
 	t.Run("test not target partition", func(t *testing.T) {
 		msg, err := genSimpleInsertMsg()
 		assert.NoError(t, err)
@@ -211,6 +225,16 @@ func TestFlowGraphFilterDmNode_filterInvalidDeleteMessage(t *testing.T) {
 		assert.Nil(t, res)
 	})
 
+	t.Run("test delete not target vchannel", func(t *testing.T) {
+		msg, err := genSimpleDeleteMsg()
+		assert.NoError(t, err)
+		fg, err := getFilterDMNode(ctx)
+		assert.NoError(t, err)
+		msg.ShardName = defaultVChannel + "-other"
+		res := fg.filterInvalidDeleteMessage(msg)
+		assert.Nil(t, res)
+	})
+
 	t.Run("test delete not target partition", func(t *testing.T) {
 		msg, err := genSimpleDeleteMsg()
 		assert.NoError(t, err)
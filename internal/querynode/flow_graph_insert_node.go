@@ -147,6 +147,11 @@ func (iNode *insertNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 	}
 	// 1. filter segment by bloom filter
 	for _, delMsg := range iMsg.deleteMessages {
+		// buffer the deletes before looking for the historical segments, so that a sealed segment being loaded
+		// either gets them from the buffer or is found here
+		if collection, err := iNode.historicalReplica.getCollectionByID(delMsg.CollectionID); err == nil {
+			collection.deleteBuffer.add(delMsg.PartitionID, delMsg.PrimaryKeys, delMsg.Timestamps)
+		}
 		if iNode.streamingReplica.getSegmentNum() != 0 {
 			processDeleteMessages(iNode.streamingReplica, delMsg, delData)
 		}
@@ -206,37 +211,42 @@ func processDeleteMessages(replica ReplicaInterface, msg *msgstream.DeleteMsg, d
 			log.Warn(err.Error())
 			continue
 		}
-		pks, err := filterSegmentsByPKs(msg.PrimaryKeys, segment)
+		pks, timestamps, err := filterSegmentsByPKs(msg.PrimaryKeys, msg.Timestamps, segment)
 		if err != nil {
 			log.Warn(err.Error())
 			continue
 		}
 		if len(pks) > 0 {
 			delData.deleteIDs[segmentID] = append(delData.deleteIDs[segmentID], pks...)
-			// TODO(yukun) get offset of pks
-			delData.deleteTimestamps[segmentID] = append(delData.deleteTimestamps[segmentID], msg.Timestamps[:len(pks)]...)
+			delData.deleteTimestamps[segmentID] = append(delData.deleteTimestamps[segmentID], timestamps...)
 		}
 	}
 }
 
-func filterSegmentsByPKs(pks []int64, segment *Segment) ([]int64, error) {
+// filterSegmentsByPKs returns the pks which may be inside the segment by pk statistics of the segment,
+// along with the timestamps of the pks. Since the bloom filter has false positives, the pks which are
+// not inside the segment may be returned, they would be ignored by segcore.
+func filterSegmentsByPKs(pks []int64, timestamps []Timestamp, segment *Segment) ([]int64, []Timestamp, error) {
 	if pks == nil {
-		return nil, fmt.Errorf("pks is nil when getSegmentsByPKs")
+		return nil, nil, fmt.Errorf("pks is nil when getSegmentsByPKs")
 	}
 	if segment == nil {
-		return nil, fmt.Errorf("segments is nil when getSegmentsByPKs")
+		return nil, nil, fmt.Errorf("segments is nil when getSegmentsByPKs")
+	}
+	if len(pks) != len(timestamps) {
+		return nil, nil, fmt.Errorf("length of pks %d not equal to length of timestamps %d when getSegmentsByPKs", len(pks), len(timestamps))
 	}
 	buf := make([]byte, 8)
-	res := make([]int64, 0)
-	for _, pk := range pks {
-		binary.LittleEndian.PutUint64(buf, uint64(pk))
-		exist := segment.pkFilter.Test(buf)
-		if exist {
-			res = append(res, pk)
+	resPKs := make([]int64, 0)
+	resTss := make([]Timestamp, 0)
+	for i, pk := range pks {
+		if segment.mayContainPK(pk, buf) {
+			resPKs = append(resPKs, pk)
+			resTss = append(resTss, timestamps[i])
 		}
 	}
-	log.Debug("In filterSegmentsByPKs", zap.Any("pk len", len(res)), zap.Any("segment", segment.segmentID))
-	return res, nil
+	log.Debug("In filterSegmentsByPKs", zap.Any("pk len", len(resPKs)), zap.Any("segment", segment.segmentID))
+	return resPKs, resTss, nil
 }
 
 func (iNode *insertNode) insert(iData *insertData, segmentID UniqueID, wg *sync.WaitGroup) {
@@ -289,6 +299,7 @@ func (iNode *insertNode) delete(deleteData *deleteData, segmentID UniqueID, wg *
 		log.Warn("QueryNode: targetSegmentDelete failed", zap.Error(err))
 		return
 	}
+	targetSegment.statistics.recordDelete(int64(len(ids)))
 
 	log.Debug("Do delete done", zap.Int("len", len(deleteData.deleteIDs[segmentID])), zap.Int64("segmentID", segmentID))
 }
@@ -267,7 +267,7 @@ func TestFlowGraphInsertNode_operate(t *testing.T) {
 		assert.Nil(t, err)
 		buf := make([]byte, 8)
 		for i := 0; i < defaultMsgLength; i++ {
-			binary.BigEndian.PutUint64(buf, uint64(i))
+			binary.LittleEndian.PutUint64(buf, uint64(i))
 			assert.True(t, s.pkFilter.Test(buf))
 		}
 
@@ -392,22 +392,128 @@ func TestGetSegmentsByPKs(t *testing.T) {
 	buf := make([]byte, 8)
 	filter := bloom.NewWithEstimates(1000000, 0.01)
 	for i := 0; i < 3; i++ {
-		binary.BigEndian.PutUint64(buf, uint64(i))
+		binary.LittleEndian.PutUint64(buf, uint64(i))
 		filter.Add(buf)
 	}
 	segment := &Segment{
 		segmentID: 1,
 		pkFilter:  filter,
+		minPK:     0,
+		maxPK:     2,
 	}
-	pks, err := filterSegmentsByPKs([]int64{0, 1, 2, 3, 4}, segment)
+	pks, tss, err := filterSegmentsByPKs([]int64{0, 1, 2, 3, 4}, []Timestamp{10, 11, 12, 13, 14}, segment)
 	assert.Nil(t, err)
-	assert.Equal(t, len(pks), 1)
+	assert.Equal(t, []int64{0, 1, 2}, pks)
+	assert.Equal(t, []Timestamp{10, 11, 12}, tss)
 
-	pks, err = filterSegmentsByPKs([]int64{}, segment)
+	pks, tss, err = filterSegmentsByPKs([]int64{}, []Timestamp{}, segment)
 	assert.Nil(t, err)
 	assert.Equal(t, len(pks), 0)
-	_, err = filterSegmentsByPKs(nil, segment)
+	assert.Equal(t, len(tss), 0)
+	_, _, err = filterSegmentsByPKs(nil, nil, segment)
 	assert.NotNil(t, err)
-	_, err = filterSegmentsByPKs([]int64{0, 1, 2, 3, 4}, nil)
+	_, _, err = filterSegmentsByPKs([]int64{0, 1, 2, 3, 4}, []Timestamp{10, 11, 12, 13, 14}, nil)
 	assert.NotNil(t, err)
+	_, _, err = filterSegmentsByPKs([]int64{0, 1, 2, 3, 4}, []Timestamp{10}, segment)
+	assert.NotNil(t, err)
+
+	// pk statistics of the segment is missing, all the pks are delete candidates
+	segment.pkStatsMissing = true
+	pks, tss, err = filterSegmentsByPKs([]int64{0, 1, 2, 3, 4}, []Timestamp{10, 11, 12, 13, 14}, segment)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4}, pks)
+	assert.Equal(t, []Timestamp{10, 11, 12, 13, 14}, tss)
+}
+
+func TestProcessDeleteMessages(t *testing.T) {
+	replica, err := genSimpleReplica()
+	assert.NoError(t, err)
+
+	const anotherSegmentID = defaultSegmentID + 1
+	for _, segmentID := range []UniqueID{defaultSegmentID, anotherSegmentID} {
+		err = replica.addSegment(segmentID,
+			defaultPartitionID,
+			defaultCollectionID,
+			defaultVChannel,
+			segmentTypeGrowing,
+			true)
+		assert.NoError(t, err)
+	}
+	segment, err := replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	segment.updateBloomFilter([]int64{0, 1, 2, 3, 4})
+	anotherSegment, err := replica.getSegmentByID(anotherSegmentID)
+	assert.NoError(t, err)
+	anotherSegment.updateBloomFilter([]int64{5, 6, 7, 8, 9})
+
+	t.Run("keys split across segments", func(t *testing.T) {
+		msg, err := genSimpleDeleteMsg()
+		assert.NoError(t, err)
+		msg.PrimaryKeys = []int64{7, 1, 8, 3}
+		msg.Timestamps = []Timestamp{100, 101, 102, 103}
+
+		delData := &deleteData{
+			deleteIDs:        make(map[UniqueID][]int64),
+			deleteTimestamps: make(map[UniqueID][]Timestamp),
+			deleteOffset:     make(map[UniqueID]int64),
+		}
+		processDeleteMessages(replica, msg, delData)
+		assert.Equal(t, []int64{1, 3}, delData.deleteIDs[defaultSegmentID])
+		assert.Equal(t, []Timestamp{101, 103}, delData.deleteTimestamps[defaultSegmentID])
+		assert.Equal(t, []int64{7, 8}, delData.deleteIDs[anotherSegmentID])
+		assert.Equal(t, []Timestamp{100, 102}, delData.deleteTimestamps[anotherSegmentID])
+	})
+
+	t.Run("keys don't exist", func(t *testing.T) {
+		msg, err := genSimpleDeleteMsg()
+		assert.NoError(t, err)
+		msg.PrimaryKeys = []int64{-1, 10, 1000}
+		msg.Timestamps = []Timestamp{100, 101, 102}
+
+		delData := &deleteData{
+			deleteIDs:        make(map[UniqueID][]int64),
+			deleteTimestamps: make(map[UniqueID][]Timestamp),
+			deleteOffset:     make(map[UniqueID]int64),
+		}
+		processDeleteMessages(replica, msg, delData)
+		assert.Equal(t, 0, len(delData.deleteIDs))
+		assert.Equal(t, 0, len(delData.deleteTimestamps))
+	})
+}
+
+func TestFlowGraphInsertNode_deleteCount(t *testing.T) {
+	streaming, err := genSimpleReplica()
+	assert.NoError(t, err)
+	historical, err := genSimpleReplica()
+	assert.NoError(t, err)
+	insertNode := newInsertNode(streaming, historical)
+
+	err = streaming.addSegment(defaultSegmentID,
+		defaultPartitionID,
+		defaultCollectionID,
+		defaultVChannel,
+		segmentTypeGrowing,
+		true)
+	assert.NoError(t, err)
+
+	msgInsertMsg, err := genSimpleInsertMsg()
+	assert.NoError(t, err)
+	msgDeleteMsg, err := genSimpleDeleteMsg()
+	assert.NoError(t, err)
+	// the first pk exists in the segment while the others don't
+	msgDeleteMsg.PrimaryKeys = []int64{1, defaultMsgLength, defaultMsgLength + 1}
+	msgDeleteMsg.Timestamps = []Timestamp{defaultMsgLength, defaultMsgLength, defaultMsgLength}
+	iMsg := insertMsg{
+		insertMessages: []*msgstream.InsertMsg{
+			msgInsertMsg,
+		},
+		deleteMessages: []*msgstream.DeleteMsg{
+			msgDeleteMsg,
+		},
+	}
+	insertNode.Operate([]flowgraph.Msg{&iMsg})
+
+	segment, err := streaming.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), segment.statistics.snapshot().DeleteCount)
 }
@@ -14,6 +14,7 @@ package querynode
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"go.uber.org/zap"
 
@@ -32,6 +33,7 @@ type queryNodeFlowGraph struct {
 	channel      Channel
 	flowGraph    *flowgraph.TimeTickedFlowGraph
 	dmlStream    msgstream.MsgStream
+	closeOnce    sync.Once
 }
 
 func newQueryNodeFlowGraph(ctx context.Context,
@@ -55,8 +57,12 @@ func newQueryNodeFlowGraph(ctx context.Context,
 		flowGraph:    flowgraph.NewTimeTickedFlowGraph(ctx1),
 	}
 
+	if Params.FlowGraphProfileEnabled {
+		q.flowGraph.EnableProfiling("queryNode-"+channel, Params.FlowGraphStallThreshold)
+	}
+
 	var dmStreamNode node = q.newDmInputNode(ctx1, factory)
-	var filterDmNode node = newFilteredDmNode(streamingReplica, loadType, collectionID, partitionID)
+	var filterDmNode node = newFilteredDmNode(streamingReplica, loadType, collectionID, partitionID, channel)
 	var insertNode node = newInsertNode(streamingReplica, historicalReplica)
 	var serviceTimeNode node = newServiceTimeNode(ctx1, tSafeReplica, loadType, collectionID, partitionID, channel, factory)
 
@@ -116,6 +122,8 @@ func (q *queryNodeFlowGraph) newDmInputNode(ctx context.Context, factory msgstre
 	maxParallelism := Params.FlowGraphMaxParallelism
 
 	node := flowgraph.NewInputNode(insertStream, "dmlInputNode", maxQueueLength, maxParallelism)
+	// the subscription name is unique per load, drop the subscription when the flow graph is closed
+	node.SetUnsubscribeOnClose(true)
 	return node
 }
 
@@ -135,6 +143,14 @@ func (q *queryNodeFlowGraph) consumerFlowGraph(channel Channel, subName ConsumeS
 func (q *queryNodeFlowGraph) seekQueryNodeFlowGraph(position *internalpb.MsgPosition) error {
 	q.dmlStream.AsConsumer([]string{position.ChannelName}, position.MsgGroup)
 	err := q.dmlStream.Seek([]*internalpb.MsgPosition{position})
+	if errors.Is(err, msgstream.ErrPositionExpired) {
+		// The messages after the position are removed by retention, replay from the earliest retained one
+		log.Warn("query node flow graph seek position expired, recover from the earliest message",
+			zap.Any("collectionID", q.collectionID),
+			zap.Any("channel", position.ChannelName),
+			zap.Error(err))
+		err = q.dmlStream.SeekToTimestamp([]string{position.ChannelName}, 0)
+	}
 	log.Debug("query node flow graph seeks from pChannel",
 		zap.Any("collectionID", q.collectionID),
 		zap.Any("channel", position.ChannelName),
@@ -142,12 +158,15 @@ func (q *queryNodeFlowGraph) seekQueryNodeFlowGraph(position *internalpb.MsgPosi
 	return err
 }
 
+// close stops the flow graph, it returns after the node goroutines of the flow graph quit
 func (q *queryNodeFlowGraph) close() {
-	q.cancel()
-	q.flowGraph.Close()
-	log.Debug("stop query node flow graph",
-		zap.Any("collectionID", q.collectionID),
-		zap.Any("partitionID", q.partitionID),
-		zap.Any("channel", q.channel),
-	)
+	q.closeOnce.Do(func() {
+		q.cancel()
+		q.flowGraph.Close()
+		log.Debug("stop query node flow graph",
+			zap.Any("collectionID", q.collectionID),
+			zap.Any("partitionID", q.partitionID),
+			zap.Any("channel", q.channel),
+		)
+	})
 }
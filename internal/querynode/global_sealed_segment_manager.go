@@ -75,12 +75,33 @@ func (g *globalSealedSegmentManager) hasGlobalSegment(segmentID UniqueID) bool {
 	return ok
 }
 
+// isServing tells whether the copy of the segment loaded with the version should serve queries,
+// only the copy of the latest published assignment serves, an older copy is being released
+// and a newer one has not been published yet
+func (g *globalSealedSegmentManager) isServing(segmentID UniqueID, version int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	info, ok := g.globalSealedSegments[segmentID]
+	return ok && info.Version == version
+}
+
 func (g *globalSealedSegmentManager) removeGlobalSegmentInfo(segmentID UniqueID) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	delete(g.globalSealedSegments, segmentID)
 }
 
+// removeOfflineSegmentInfo removes the global info of the offline segment,
+// unless the segment has been reassigned with another version, e.g. by load balance
+func (g *globalSealedSegmentManager) removeOfflineSegmentInfo(segmentInfo *querypb.SegmentInfo) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	info, ok := g.globalSealedSegments[segmentInfo.SegmentID]
+	if ok && info.Version == segmentInfo.Version {
+		delete(g.globalSealedSegments, segmentInfo.SegmentID)
+	}
+}
+
 func (g *globalSealedSegmentManager) removeGlobalSegmentIDsByPartitionIds(partitionIDs []UniqueID) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -67,3 +67,38 @@ func TestGlobalSealedSegmentManager(t *testing.T) {
 	ids = manager.getGlobalSegmentIDs()
 	assert.Len(t, ids, 0)
 }
+
+func TestGlobalSealedSegmentManager_version(t *testing.T) {
+	manager := newGlobalSealedSegmentManager(defaultCollectionID)
+
+	genSegmentInfo := func(nodeID UniqueID, version int64) *querypb.SegmentInfo {
+		return &querypb.SegmentInfo{
+			SegmentID:    defaultSegmentID,
+			CollectionID: defaultCollectionID,
+			PartitionID:  defaultPartitionID,
+			NodeID:       nodeID,
+			Version:      version,
+		}
+	}
+
+	// not published yet
+	assert.False(t, manager.isServing(defaultSegmentID, 1))
+
+	err := manager.addGlobalSegmentInfo(genSegmentInfo(1, 1))
+	assert.NoError(t, err)
+	assert.True(t, manager.isServing(defaultSegmentID, 1))
+	assert.False(t, manager.isServing(defaultSegmentID, 2))
+
+	// load balance, the online segment is added before the offline one is removed
+	err = manager.addGlobalSegmentInfo(genSegmentInfo(2, 2))
+	assert.NoError(t, err)
+	manager.removeOfflineSegmentInfo(genSegmentInfo(1, 1))
+	assert.True(t, manager.hasGlobalSegment(defaultSegmentID))
+	assert.False(t, manager.isServing(defaultSegmentID, 1))
+	assert.True(t, manager.isServing(defaultSegmentID, 2))
+
+	// release
+	manager.removeOfflineSegmentInfo(genSegmentInfo(2, 2))
+	assert.False(t, manager.hasGlobalSegment(defaultSegmentID))
+	assert.False(t, manager.isServing(defaultSegmentID, 2))
+}
@@ -181,7 +181,7 @@ func (h *historical) removeGlobalSegmentIDsByPartitionIds(partitionIDs []UniqueI
 	}
 }
 
-func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID, vcm storage.ChunkManager,
+func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID,
 	plan *RetrievePlan) ([]*segcorepb.RetrieveResults, []UniqueID, error) {
 
 	retrieveResults := make([]*segcorepb.RetrieveResults, 0)
@@ -218,10 +218,6 @@ func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID, vcm storage.C
 			if err != nil {
 				return retrieveResults, retrieveSegmentIDs, err
 			}
-
-			if err = seg.fillVectorFieldsData(collID, vcm, result); err != nil {
-				return retrieveResults, retrieveSegmentIDs, err
-			}
 			retrieveResults = append(retrieveResults, result)
 			retrieveSegmentIDs = append(retrieveSegmentIDs, segID)
 		}
@@ -229,9 +225,29 @@ func (h *historical) retrieve(collID UniqueID, partIDs []UniqueID, vcm storage.C
 	return retrieveResults, retrieveSegmentIDs, nil
 }
 
+// fillVectorFieldsData fills the vectors of the retrieve results of the segments, which are read from vcm
+// if the vectors are not in memory.
+func (h *historical) fillVectorFieldsData(collID UniqueID, segIDs []UniqueID, vcm storage.ChunkManager,
+	results []*segcorepb.RetrieveResults) error {
+
+	if len(segIDs) != len(results) {
+		return fmt.Errorf("mismatch segments and retrieve results, segments = %d, results = %d", len(segIDs), len(results))
+	}
+	for i, segID := range segIDs {
+		seg, err := h.replica.getSegmentByID(segID)
+		if err != nil {
+			return err
+		}
+		if err = seg.fillVectorFieldsData(collID, vcm, results[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // search will search all the target segments in historical
 func (h *historical) search(searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, plan *SearchPlan,
-	searchTs Timestamp) ([]*SearchResult, []UniqueID, error) {
+	searchTs Timestamp, filter segmentFilter) ([]*SearchResult, []UniqueID, error) {
 
 	searchResults := make([]*SearchResult, 0)
 	searchSegmentIDs := make([]UniqueID, 0)
@@ -298,6 +314,9 @@ func (h *historical) search(searchReqs []*searchRequest, collID UniqueID, partID
 			if !seg.getOnService() {
 				continue
 			}
+			if filter != nil && !filter(seg) {
+				continue
+			}
 			searchResult, err := seg.search(plan, searchReqs, []Timestamp{searchTs})
 			if err != nil {
 				return searchResults, searchSegmentIDs, err
@@ -309,3 +328,48 @@ func (h *historical) search(searchReqs []*searchRequest, collID UniqueID, partID
 
 	return searchResults, searchSegmentIDs, nil
 }
+
+// searchSegments searches the given sealed segments in historical, the segments not in the partitions are skipped
+func (h *historical) searchSegments(searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, segIDs []UniqueID, plan *SearchPlan,
+	searchTs Timestamp) ([]*SearchResult, []UniqueID, error) {
+
+	searchResults := make([]*SearchResult, 0)
+	searchSegmentIDs := make([]UniqueID, 0)
+
+	inPartitions := func(partID UniqueID) bool {
+		if len(partIDs) == 0 {
+			return true
+		}
+		for _, id := range partIDs {
+			if id == partID {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, segID := range segIDs {
+		seg, err := h.replica.getSegmentByID(segID)
+		if err != nil {
+			return searchResults, searchSegmentIDs, err
+		}
+		if seg.collectionID != collID {
+			return searchResults, searchSegmentIDs, fmt.Errorf("segment %d doesn't belong to collection %d", segID, collID)
+		}
+		if !inPartitions(seg.partitionID) {
+			continue
+		}
+		// the segment is being released, the caller should retry with the latest segment distribution
+		if !seg.getOnService() {
+			return searchResults, searchSegmentIDs, fmt.Errorf("segment %d is not on service", segID)
+		}
+		searchResult, err := seg.search(plan, searchReqs, []Timestamp{searchTs})
+		if err != nil {
+			return searchResults, searchSegmentIDs, err
+		}
+		searchResults = append(searchResults, searchResult)
+		searchSegmentIDs = append(searchSegmentIDs, seg.segmentID)
+	}
+
+	return searchResults, searchSegmentIDs, nil
+}
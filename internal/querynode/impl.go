@@ -18,7 +18,10 @@ import (
 	"math/rand"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 
 	"go.uber.org/zap"
@@ -55,6 +58,11 @@ func (node *QueryNode) GetComponentStates(ctx context.Context) (*internalpb.Comp
 	return stats, nil
 }
 
+// CheckReady checks whether QueryNode is healthy and its session is registered in etcd
+func (node *QueryNode) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, node, node.session)
+}
+
 func (node *QueryNode) GetTimeTickChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
 	return &milvuspb.StringResponse{
 		Status: &commonpb.Status{
@@ -306,6 +314,14 @@ func (node *QueryNode) LoadSegments(ctx context.Context, in *queryPb.LoadSegment
 
 	waitFunc := func() (*commonpb.Status, error) {
 		err = dct.WaitToFinish()
+		if errors.Is(err, errOutOfMemory) {
+			// returned without error so that the code reaches querycoord through grpc, and the load is not retried
+			log.Warn(err.Error())
+			return &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_OutOfMemory,
+				Reason:    err.Error(),
+			}, nil
+		}
 		if err != nil {
 			status := &commonpb.Status{
 				ErrorCode: commonpb.ErrorCode_UnexpectedError,
@@ -488,6 +504,8 @@ func (node *QueryNode) GetSegmentInfo(ctx context.Context, in *queryPb.GetSegmen
 			IndexID:      indexID,
 			ChannelID:    segment.vChannelID,
 			SegmentState: getSegmentStateBySegmentType(segment.segmentType),
+
+			UnindexedFieldIDs: segment.getUnindexedFieldIDs(),
 		}
 		return info
 	}
@@ -579,6 +597,116 @@ func (node *QueryNode) GetSegmentInfo(ctx context.Context, in *queryPb.GetSegmen
 	}, nil
 }
 
+// Search searches a shard of the collection, the search of the remote sealed segments is forwarded to the query nodes holding them
+func (node *QueryNode) Search(ctx context.Context, in *queryPb.SearchRequest) (*queryPb.SearchResponse, error) {
+	start := time.Now()
+	failResponse := func(err error) (*queryPb.SearchResponse, error) {
+		return &queryPb.SearchResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    err.Error(),
+			},
+		}, err
+	}
+
+	code := node.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		return failResponse(fmt.Errorf("query node %d is not ready", Params.QueryNodeID))
+	}
+	if in.GetReq().GetBase() == nil {
+		return failResponse(errors.New("invalid search request, base is nil"))
+	}
+	qc, err := node.queryService.getQueryCollection(in.Req.CollectionID)
+	if err != nil {
+		return failResponse(err)
+	}
+
+	remoteResults := make([][]*internalpb.SearchResults, len(in.RemoteSegments))
+	remoteErrs := make([]error, len(in.RemoteSegments))
+	var wg sync.WaitGroup
+	for i, segments := range in.RemoteSegments {
+		wg.Add(1)
+		go func(i int, segments *queryPb.SegmentsOnNode) {
+			defer wg.Done()
+			remoteResults[i], remoteErrs[i] = node.searchRemoteSegments(ctx, in.Req, segments)
+		}(i, segments)
+	}
+	results, err := qc.searchShard(ctx, in)
+	wg.Wait()
+	if err != nil {
+		log.Warn("search shard failed", zap.Int64("collectionID", in.Req.CollectionID), zap.String("channel", in.DmlChannel), zap.Error(err))
+		return failResponse(err)
+	}
+	for i, segments := range in.RemoteSegments {
+		if remoteErrs[i] != nil {
+			log.Warn("search remote segments failed", zap.Int64("nodeID", segments.NodeID), zap.Int64s("segmentIDs", segments.SegmentIDs), zap.Error(remoteErrs[i]))
+			return failResponse(fmt.Errorf("search segments on query node %d failed, %s", segments.NodeID, remoteErrs[i].Error()))
+		}
+		results = append(results, remoteResults[i]...)
+	}
+
+	return &queryPb.SearchResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+		},
+		Results:   results,
+		LatencyUs: time.Since(start).Microseconds(),
+	}, nil
+}
+
+// searchRemoteSegments searches the sealed segments held by another query node
+func (node *QueryNode) searchRemoteSegments(ctx context.Context, req *internalpb.SearchRequest, segments *queryPb.SegmentsOnNode) ([]*internalpb.SearchResults, error) {
+	client, err := node.shardClients.GetClient(segments.NodeID, segments.NodeAddress)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Search(ctx, &queryPb.SearchRequest{
+		Req:        req,
+		SegmentIDs: segments.SegmentIDs,
+	})
+	if err != nil {
+		// reconnect next time, the query node may have been restarted
+		node.shardClients.RemoveClient(segments.NodeID)
+		return nil, err
+	}
+	if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return nil, errors.New(resp.Status.Reason)
+	}
+	return resp.Results, nil
+}
+
+// FetchSegmentBinlogs streams the binlogs of a segment cached in the chunk cache to the query node loading it,
+// the binlogs not cached are skipped so that they're loaded from the storage.
+func (node *QueryNode) FetchSegmentBinlogs(ctx context.Context, in *queryPb.FetchSegmentBinlogsRequest, send func(*queryPb.SegmentBinlogChunk) error) error {
+	fail := func(err error) error {
+		log.Warn("fetch segment binlogs failed", zap.Int64("segmentID", in.SegmentID), zap.Error(err))
+		sendErr := send(&queryPb.SegmentBinlogChunk{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    err.Error(),
+			},
+		})
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	if !node.isHealthy() {
+		return fail(errQueryNodeIsUnhealthy(Params.QueryNodeID))
+	}
+	start := time.Now()
+	err := node.historical.loader.sendCachedBinlogs(ctx, in.Paths, send)
+	if err != nil {
+		return fail(err)
+	}
+	log.Debug("fetch segment binlogs done",
+		zap.Int64("segmentID", in.SegmentID),
+		zap.Int64("targetNodeID", in.GetBase().GetSourceID()),
+		zap.Duration("cost", time.Since(start)))
+	return nil
+}
+
 func (node *QueryNode) isHealthy() bool {
 	code := node.stateCode.Load().(internalpb.StateCode)
 	return code == internalpb.StateCode_Healthy
@@ -637,6 +765,31 @@ func (node *QueryNode) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsR
 		return metrics, err
 	}
 
+	if metricType == metricsinfo.SegmentStatisticsMetrics {
+		metrics, err := getSegmentStatisticsMetrics(ctx, req, node)
+
+		log.Debug("QueryNode.GetMetrics",
+			zap.Int64("node_id", Params.QueryNodeID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Error(err))
+
+		return metrics, err
+	}
+
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		name := metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, Params.QueryNodeID)
+		metrics := metricsinfo.LogLevelsResponse([]metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}, name)
+
+		log.Debug("QueryNode.GetMetrics",
+			zap.Int64("node_id", Params.QueryNodeID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Debug("QueryNode.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", Params.QueryNodeID),
 		zap.String("req", req.Request),
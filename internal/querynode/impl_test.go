@@ -14,6 +14,7 @@ package querynode
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math/rand"
 	"testing"
 
@@ -23,8 +24,10 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
 )
 
 func TestImpl_GetComponentStates(t *testing.T) {
@@ -669,3 +672,144 @@ func TestImpl_ReleaseSegments(t *testing.T) {
 		assert.NotEqual(t, commonpb.ErrorCode_Success, status.ErrorCode)
 	})
 }
+
+type shardSearchMockQueryNode struct {
+	types.QueryNode
+	search func(req *queryPb.SearchRequest) (*queryPb.SearchResponse, error)
+}
+
+func (m *shardSearchMockQueryNode) Init() error {
+	return nil
+}
+
+func (m *shardSearchMockQueryNode) Start() error {
+	return nil
+}
+
+func (m *shardSearchMockQueryNode) Stop() error {
+	return nil
+}
+
+func (m *shardSearchMockQueryNode) Search(ctx context.Context, req *queryPb.SearchRequest) (*queryPb.SearchResponse, error) {
+	return m.search(req)
+}
+
+func TestImpl_Search(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genNode := func() *QueryNode {
+		node, err := genSimpleQueryNode(ctx)
+		assert.NoError(t, err)
+		err = node.queryService.addQueryCollection(defaultCollectionID)
+		assert.NoError(t, err)
+		return node
+	}
+	genRequest := func() *queryPb.SearchRequest {
+		req, err := genSimpleSearchRequest()
+		assert.NoError(t, err)
+		return &queryPb.SearchRequest{
+			Req:        req,
+			SegmentIDs: []UniqueID{defaultSegmentID},
+		}
+	}
+	setRemoteNode := func(node *QueryNode, remote *shardSearchMockQueryNode) {
+		node.shardClients = shardclient.NewManager(ctx, func(ctx context.Context, address string) (types.QueryNode, error) {
+			return remote, nil
+		})
+	}
+
+	t.Run("test search", func(t *testing.T) {
+		node := genNode()
+		resp, err := node.Search(ctx, genRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, 1, len(resp.Results))
+	})
+
+	t.Run("test forward to remote segments", func(t *testing.T) {
+		node := genNode()
+		remoteSegmentID := defaultSegmentID + 1
+		setRemoteNode(node, &shardSearchMockQueryNode{
+			search: func(req *queryPb.SearchRequest) (*queryPb.SearchResponse, error) {
+				assert.Equal(t, []UniqueID{remoteSegmentID}, req.SegmentIDs)
+				assert.Equal(t, "", req.DmlChannel)
+				assert.Equal(t, 0, len(req.RemoteSegments))
+				return &queryPb.SearchResponse{
+					Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+					Results: []*internalpb.SearchResults{
+						{SealedSegmentIDsSearched: []UniqueID{remoteSegmentID}},
+					},
+				}, nil
+			},
+		})
+
+		req := genRequest()
+		req.RemoteSegments = []*queryPb.SegmentsOnNode{
+			{NodeID: Params.QueryNodeID + 1, NodeAddress: "remote", SegmentIDs: []UniqueID{remoteSegmentID}},
+		}
+		resp, err := node.Search(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, 2, len(resp.Results))
+	})
+
+	t.Run("test remote query node down", func(t *testing.T) {
+		node := genNode()
+		setRemoteNode(node, &shardSearchMockQueryNode{
+			search: func(req *queryPb.SearchRequest) (*queryPb.SearchResponse, error) {
+				return nil, errors.New("connection refused")
+			},
+		})
+
+		req := genRequest()
+		req.RemoteSegments = []*queryPb.SegmentsOnNode{
+			{NodeID: Params.QueryNodeID + 1, NodeAddress: "remote", SegmentIDs: []UniqueID{defaultSegmentID + 1}},
+		}
+		resp, err := node.Search(ctx, req)
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("test remote search failed", func(t *testing.T) {
+		node := genNode()
+		setRemoteNode(node, &shardSearchMockQueryNode{
+			search: func(req *queryPb.SearchRequest) (*queryPb.SearchResponse, error) {
+				return &queryPb.SearchResponse{
+					Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "segment not found"},
+				}, nil
+			},
+		})
+
+		req := genRequest()
+		req.RemoteSegments = []*queryPb.SegmentsOnNode{
+			{NodeID: Params.QueryNodeID + 1, NodeAddress: "remote", SegmentIDs: []UniqueID{defaultSegmentID + 1}},
+		}
+		resp, err := node.Search(ctx, req)
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("test node is abnormal", func(t *testing.T) {
+		node := genNode()
+		node.UpdateStateCode(internalpb.StateCode_Abnormal)
+		resp, err := node.Search(ctx, genRequest())
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("test invalid request", func(t *testing.T) {
+		node := genNode()
+		resp, err := node.Search(ctx, &queryPb.SearchRequest{})
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+
+	t.Run("test collection not loaded", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		assert.NoError(t, err)
+		resp, err := node.Search(ctx, genRequest())
+		assert.Error(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+}
@@ -21,7 +21,6 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/kv"
-	minioKV "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
@@ -34,6 +33,9 @@ import (
 
 type indexParam = map[string]string
 
+// errSegmentNotIndexed is returned by setIndexInfo if no index has been built on the segment yet
+var errSegmentNotIndexed = errors.New("there are no indexes on this segment")
+
 // indexLoader is in charge of loading index in query node
 type indexLoader struct {
 	replica ReplicaInterface
@@ -119,7 +121,7 @@ func (loader *indexLoader) getIndexBinlog(indexPath []string) ([][]byte, indexPa
 		if path.Base(p) == storage.IndexParamsKey {
 			_, indexParams, indexName, _, err = indexCodec.Deserialize([]*storage.Blob{
 				{
-					Key:   storage.IndexParamsKey,
+					Key:   p, // key is the path reported by the checksum errors
 					Value: []byte(indexPiece),
 				},
 			})
@@ -129,7 +131,7 @@ func (loader *indexLoader) getIndexBinlog(indexPath []string) ([][]byte, indexPa
 		} else {
 			data, _, _, _, err := indexCodec.Deserialize([]*storage.Blob{
 				{
-					Key:   path.Base(p), // though key is not important here
+					Key:   p, // key is the path reported by the checksum errors
 					Value: []byte(indexPiece),
 				},
 			})
@@ -191,16 +193,19 @@ func (loader *indexLoader) setIndexInfo(collectionID UniqueID, segment *Segment,
 	}
 
 	if !response.EnableIndex {
-		return errors.New("there are no indexes on this segment")
+		return errSegmentNotIndexed
 	}
 
 	indexFilePathRequest := &indexpb.GetIndexFilePathsRequest{
 		IndexBuildIDs: []UniqueID{response.BuildID},
 	}
 	pathResponse, err := loader.indexCoord.GetIndexFilePaths(ctx, indexFilePathRequest)
-	if err != nil || pathResponse.Status.ErrorCode != commonpb.ErrorCode_Success {
+	if err != nil {
 		return err
 	}
+	if pathResponse.Status.ErrorCode != commonpb.ErrorCode_Success {
+		return fmt.Errorf("failed to get index file paths, buildID = %d, reason = %s", response.BuildID, pathResponse.Status.Reason)
+	}
 
 	if len(pathResponse.FilePaths) <= 0 {
 		return errors.New("illegal index file paths")
@@ -221,21 +226,7 @@ func (loader *indexLoader) setIndexInfo(collectionID UniqueID, segment *Segment,
 	return nil
 }
 
-func newIndexLoader(ctx context.Context, rootCoord types.RootCoord, indexCoord types.IndexCoord, replica ReplicaInterface) *indexLoader {
-	option := &minioKV.Option{
-		Address:           Params.MinioEndPoint,
-		AccessKeyID:       Params.MinioAccessKeyID,
-		SecretAccessKeyID: Params.MinioSecretAccessKey,
-		UseSSL:            Params.MinioUseSSLStr,
-		CreateBucket:      true,
-		BucketName:        Params.MinioBucketName,
-	}
-
-	client, err := minioKV.NewMinIOKV(ctx, option)
-	if err != nil {
-		panic(err)
-	}
-
+func newIndexLoader(ctx context.Context, rootCoord types.RootCoord, indexCoord types.IndexCoord, replica ReplicaInterface, client kv.DataKV) *indexLoader {
 	return &indexLoader{
 		replica: replica,
 
@@ -48,6 +48,39 @@ func TestIndexLoader_setIndexInfo(t *testing.T) {
 		err = historical.loader.indexLoader.setIndexInfo(defaultCollectionID, segment, rowIDFieldID)
 		assert.NoError(t, err)
 	})
+
+	t.Run("test segment not indexed", func(t *testing.T) {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		rc := newMockRootCoord()
+		rc.disableIndex = true
+		historical.loader.indexLoader.rootCoord = rc
+		historical.loader.indexLoader.indexCoord = newMockIndexCoord()
+
+		err = historical.loader.indexLoader.setIndexInfo(defaultCollectionID, segment, simpleVecField.id)
+		assert.Equal(t, errSegmentNotIndexed, err)
+	})
+
+	t.Run("test get index file paths failed", func(t *testing.T) {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		ic := newMockIndexCoord()
+		ic.statusFailed = true
+		historical.loader.indexLoader.rootCoord = newMockRootCoord()
+		historical.loader.indexLoader.indexCoord = ic
+
+		err = historical.loader.indexLoader.setIndexInfo(defaultCollectionID, segment, simpleVecField.id)
+		assert.Error(t, err)
+		assert.False(t, segment.checkIndexReady(simpleVecField.id))
+	})
 }
 
 func TestIndexLoader_getIndexBinlog(t *testing.T) {
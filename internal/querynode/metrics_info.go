@@ -13,7 +13,6 @@ package querynode
 
 import (
 	"context"
-	"os"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
@@ -30,39 +29,42 @@ func getSystemInfoMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest,
 	if err != nil {
 		return nil, err
 	}
-	nodeInfos := metricsinfo.QueryNodeInfos{
-		BaseComponentInfos: metricsinfo.BaseComponentInfos{
-			Name: metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, Params.QueryNodeID),
-			HardwareInfos: metricsinfo.HardwareMetrics{
-				IP:           node.session.Address,
-				CPUCoreCount: metricsinfo.GetCPUCoreCount(false),
-				CPUCoreUsage: metricsinfo.GetCPUUsage(),
-				Memory:       totalMem,
-				MemoryUsage:  usedMem,
-				Disk:         metricsinfo.GetDiskCount(),
-				DiskUsage:    metricsinfo.GetDiskUsage(),
-			},
-			SystemInfo: metricsinfo.DeployMetrics{
-				SystemVersion: os.Getenv(metricsinfo.GitCommitEnvKey),
-				DeployMode:    os.Getenv(metricsinfo.DeployModeEnvKey),
-			},
-			CreatedTime: Params.CreatedTime.String(),
-			UpdatedTime: Params.UpdatedTime.String(),
-			Type:        typeutil.QueryNodeRole,
-			ID:          node.session.ServerID,
-		},
-		SystemConfigurations: metricsinfo.QueryNodeConfiguration{
+	self := metricsinfo.NewComponentInfo(typeutil.QueryNodeRole, node.session.ServerID, node.session.Address,
+		Params.CreatedTime, Params.UpdatedTime,
+		metricsinfo.QueryNodeConfiguration{
 			SearchReceiveBufSize:         Params.SearchReceiveBufSize,
 			SearchPulsarBufSize:          Params.SearchPulsarBufSize,
 			SearchResultReceiveBufSize:   Params.SearchResultReceiveBufSize,
 			RetrieveReceiveBufSize:       Params.RetrieveReceiveBufSize,
 			RetrievePulsarBufSize:        Params.RetrievePulsarBufSize,
 			RetrieveResultReceiveBufSize: Params.RetrieveResultReceiveBufSize,
+			RetrieveMaxResultSize:        Params.RetrieveMaxResultSize,
+			RetrieveMemoryBudget:         Params.RetrieveMemoryBudget,
 
 			SimdType: Params.SimdType,
-		},
+		})
+	// the memory of the container limits query node rather than the one of the host
+	self.HardwareInfos.Memory = totalMem
+	self.HardwareInfos.MemoryUsage = usedMem
+	return metricsinfo.ComponentTopologyResponse(metricsinfo.NewComponentTopology(self)), nil
+}
+
+// getSegmentStatisticsMetrics returns the search/query statistics of all the segments in query node.
+func getSegmentStatisticsMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest, node *QueryNode) (*milvuspb.GetMetricsResponse, error) {
+	reset := metricsinfo.ParseResetFlag(req.Request)
+	nodeStatistics := metricsinfo.QueryNodeSegmentStatistics{
+		Name:     metricsinfo.ConstructComponentName(typeutil.QueryNodeRole, Params.QueryNodeID),
+		ID:       Params.QueryNodeID,
+		Segments: make([]metricsinfo.SegmentStatistics, 0),
+	}
+	if node.historical != nil {
+		nodeStatistics.Segments = append(nodeStatistics.Segments, getSegmentStatistics(node.historical.replica, reset)...)
 	}
-	resp, err := metricsinfo.MarshalComponentInfos(nodeInfos)
+	if node.streaming != nil {
+		nodeStatistics.Segments = append(nodeStatistics.Segments, getSegmentStatistics(node.streaming.replica, reset)...)
+	}
+
+	resp, err := metricsinfo.MarshalComponentInfos(nodeStatistics)
 	if err != nil {
 		return &milvuspb.GetMetricsResponse{
 			Status: &commonpb.Status{
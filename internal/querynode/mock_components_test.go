@@ -27,8 +27,9 @@ import (
 // TODO: move to mock_test
 // TODO: getMockFrom common package
 type mockRootCoord struct {
-	state       internalpb.StateCode
-	returnError bool // TODO: add error tests
+	state        internalpb.StateCode
+	returnError  bool // TODO: add error tests
+	disableIndex bool // segments are described as not indexed
 }
 
 func (m *mockRootCoord) CreateAlias(ctx context.Context, req *milvuspb.CreateAliasRequest) (*commonpb.Status, error) {
@@ -43,6 +44,10 @@ func (m *mockRootCoord) AlterAlias(ctx context.Context, req *milvuspb.AlterAlias
 	panic("implement me")
 }
 
+func (m *mockRootCoord) AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	panic("implement me")
+}
+
 func newMockRootCoord() *mockRootCoord {
 	return &mockRootCoord{
 		state: internalpb.StateCode_Healthy,
@@ -141,7 +146,7 @@ func (m *mockRootCoord) DescribeSegment(ctx context.Context, req *milvuspb.Descr
 		},
 		IndexID:     indexID,
 		BuildID:     buildID,
-		EnableIndex: true,
+		EnableIndex: !m.disableIndex,
 	}, nil
 }
 
@@ -177,6 +182,9 @@ func (m *mockRootCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetrics
 type mockIndexCoord struct {
 	types.Component
 	types.TimeTickProvider
+
+	statusFailed bool     // GetIndexFilePaths returns a failed status
+	filePaths    []string // overrides the generated index file paths if set
 }
 
 func newMockIndexCoord() *mockIndexCoord {
@@ -196,6 +204,30 @@ func (m *mockIndexCoord) GetIndexStates(ctx context.Context, req *indexpb.GetInd
 }
 
 func (m *mockIndexCoord) GetIndexFilePaths(ctx context.Context, req *indexpb.GetIndexFilePathsRequest) (*indexpb.GetIndexFilePathsResponse, error) {
+	if m.statusFailed {
+		return &indexpb.GetIndexFilePathsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    "index building failed",
+			},
+		}, nil
+	}
+	if m.filePaths != nil {
+		return &indexpb.GetIndexFilePathsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_Success,
+			},
+			FilePaths: []*indexpb.IndexFilePathInfo{
+				{
+					Status: &commonpb.Status{
+						ErrorCode: commonpb.ErrorCode_Success,
+					},
+					IndexBuildID:   buildID,
+					IndexFilePaths: m.filePaths,
+				},
+			},
+		}, nil
+	}
 	paths, err := generateIndex(defaultSegmentID)
 	if err != nil {
 		return &indexpb.GetIndexFilePathsResponse{
@@ -220,6 +252,22 @@ func (m *mockIndexCoord) GetIndexFilePaths(ctx context.Context, req *indexpb.Get
 	}, nil
 }
 
+func (m *mockIndexCoord) CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (m *mockIndexCoord) GetIndexBuildProgress(ctx context.Context, req *indexpb.GetIndexBuildProgressRequest) (*indexpb.GetIndexBuildProgressResponse, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (m *mockIndexCoord) GetIndexState(ctx context.Context, req *indexpb.GetIndexStateRequest) (*indexpb.GetIndexStateResponse, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (m *mockIndexCoord) DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 func (m *mockIndexCoord) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
 	panic("not implemented") // TODO: Implement
 }
@@ -1142,7 +1142,7 @@ func genSimpleRetrieveRequest() (*internalpb.RetrieveRequest, error) {
 		},
 		CollectionID:       defaultCollectionID,
 		PartitionIDs:       []UniqueID{defaultPartitionID},
-		OutputFieldsId:     []int64{1, 2, 3},
+		OutputFieldsId:     []int64{simpleVecField.id},
 		TravelTimestamp:    Timestamp(1000),
 		SerializedExprPlan: expr,
 	}, nil
@@ -12,6 +12,7 @@
 package querynode
 
 import (
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -21,6 +22,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 )
@@ -38,6 +41,8 @@ type ParamTable struct {
 	QueryNodeIP   string
 	QueryNodePort int64
 	QueryNodeID   UniqueID
+	// MetricsPort is the port serving the metrics of the current QueryNode, 0 means not serving
+	MetricsPort int
 	// TODO: remove cacheSize
 	CacheSize   int64 // deprecated
 	InContainer bool
@@ -50,6 +55,11 @@ type ParamTable struct {
 
 	FlowGraphMaxQueueLength int32
 	FlowGraphMaxParallelism int32
+	DeadLetter              msgstream.DeadLetterPolicy
+	// FlowGraphProfileEnabled enables the metrics of the flowgraph nodes, the nodes stalled for
+	// FlowGraphStallThreshold are logged, 0 means not watching for the stalls
+	FlowGraphProfileEnabled bool
+	FlowGraphStallThreshold time.Duration
 
 	// minio
 	MinioEndPoint        string
@@ -58,6 +68,11 @@ type ParamTable struct {
 	MinioUseSSLStr       bool
 	MinioBucketName      string
 
+	StorageType             string
+	LocalStoragePath        string
+	LocalStorageSyncOnWrite bool
+	StorageChecksumWarnOnly bool
+
 	// search
 	SearchChannelNames         []string
 	SearchResultChannelNames   []string
@@ -71,6 +86,11 @@ type ParamTable struct {
 	RetrieveReceiveBufSize       int64
 	RetrievePulsarBufSize        int64
 	RetrieveResultReceiveBufSize int64
+	// RetrieveMaxResultSize is the max estimated size of the results of a retrieve request in bytes, 0 means unlimited
+	RetrieveMaxResultSize int64
+	// RetrieveMemoryBudget is the max total estimated size of the results of the retrieve requests executed
+	// concurrently in bytes, the other requests wait. 0 means unlimited
+	RetrieveMemoryBudget int64
 
 	// stats
 	StatsPublishInterval int
@@ -78,10 +98,29 @@ type ParamTable struct {
 	GracefulTime int64
 	SliceIndex   int
 
+	// GracefulStopTimeout is the max duration to wait for in-flight queries when stopping, in seconds
+	GracefulStopTimeout int64
+
 	// segcore
 	ChunkRows int64
 	SimdType  string
 
+	// warm up sealed segments after loading
+	WarmupEnabled bool
+	WarmupTimeout time.Duration
+
+	// fail the load instead of falling back to brute force search when the index of a segment can't be loaded
+	LoadIndexStrict bool
+
+	// DeleteBufferSize is the max number of the latest delete records buffered per collection, which are applied
+	// to the sealed segments loaded after the deletes were consumed
+	DeleteBufferSize int64
+
+	// cache the binlogs and index files on local disk
+	ChunkCacheEnabled bool
+	ChunkCachePath    string
+	ChunkCacheMaxSize int64
+
 	CreatedTime time.Time
 	UpdatedTime time.Time
 }
@@ -120,21 +159,39 @@ func (p *ParamTable) Init() {
 	p.initMinioSecretAccessKey()
 	p.initMinioUseSSLStr()
 	p.initMinioBucketName()
+	p.initStorageType()
+	p.initLocalStoragePath()
+	p.initLocalStorageSyncOnWrite()
+	p.initStorageChecksumWarnOnly()
 
 	p.initPulsarAddress()
 	p.initRocksmqPath()
 	p.initEtcdEndpoints()
 	p.initMetaRootPath()
 
+	p.initMetricsPort()
 	p.initGracefulTime()
+	p.initGracefulStopTimeout()
+	p.initWarmupEnabled()
+	p.initWarmupTimeout()
+	p.initLoadIndexStrict()
+	p.initDeleteBufferSize()
+	p.initChunkCacheEnabled()
+	p.initChunkCachePath()
+	p.initChunkCacheMaxSize()
 
 	p.initFlowGraphMaxQueueLength()
 	p.initFlowGraphMaxParallelism()
+	p.initFlowGraphProfile()
+	p.initDeadLetter()
 
 	p.initSearchReceiveBufSize()
 	p.initSearchPulsarBufSize()
 	p.initSearchResultReceiveBufSize()
 
+	p.initRetrieveMaxResultSize()
+	p.initRetrieveMemoryBudget()
+
 	// Has to init global msgchannel prefix before other channel names
 	p.initClusterMsgChannelPrefix()
 	p.initQueryTimeTickChannelName()
@@ -224,6 +281,48 @@ func (p *ParamTable) initMinioBucketName() {
 	p.MinioBucketName = bucketName
 }
 
+// initStorageType initializes the type of the storage of binlogs and index files, minio or local.
+func (p *ParamTable) initStorageType() {
+	ret, err := p.LoadWithDefault("storage.type", "minio")
+	if err != nil {
+		panic(err)
+	}
+	p.StorageType = ret
+}
+
+// initLocalStoragePath initializes the root path of the files when the storage type is local.
+func (p *ParamTable) initLocalStoragePath() {
+	ret, err := p.LoadWithDefault("storage.path", "/var/lib/milvus/storage")
+	if err != nil {
+		panic(err)
+	}
+	p.LocalStoragePath = ret
+}
+
+// initLocalStorageSyncOnWrite initializes whether to fsync the files before the writes return when the storage type is local.
+func (p *ParamTable) initLocalStorageSyncOnWrite() {
+	ret, err := p.LoadWithDefault("storage.syncOnWrite", "false")
+	if err != nil {
+		panic(err)
+	}
+	p.LocalStorageSyncOnWrite, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// initStorageChecksumWarnOnly initializes whether the checksum mismatches of the files read are warnings only.
+func (p *ParamTable) initStorageChecksumWarnOnly() {
+	ret, err := p.LoadWithDefault("storage.checksumWarnOnly", "false")
+	if err != nil {
+		panic(err)
+	}
+	p.StorageChecksumWarnOnly, err = strconv.ParseBool(ret)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (p *ParamTable) initPulsarAddress() {
 	url, err := p.Load("_PulsarAddress")
 	if err != nil {
@@ -255,6 +354,36 @@ func (p *ParamTable) initFlowGraphMaxParallelism() {
 	p.FlowGraphMaxParallelism = p.ParseInt32("queryNode.dataSync.flowGraph.maxParallelism")
 }
 
+func (p *ParamTable) initFlowGraphProfile() {
+	p.FlowGraphProfileEnabled = p.ParseBool("queryNode.dataSync.flowGraph.profile.enabled", false)
+	threshold, err := p.LoadWithDefault("queryNode.dataSync.flowGraph.profile.stallThreshold", "60")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(threshold, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.FlowGraphStallThreshold = time.Duration(seconds) * time.Second
+}
+
+// initDeadLetter initializes the policy of the messages failing to unmarshal in dml and query channels,
+// disabled if not configured.
+func (p *ParamTable) initDeadLetter() {
+	attempts, err := p.LoadWithDefault("queryNode.dataSync.deadLetter.maxUnmarshalAttempts", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.DeadLetter.MaxUnmarshalAttempts, err = strconv.Atoi(attempts)
+	if err != nil {
+		panic(err)
+	}
+	p.DeadLetter.ChannelPrefix, err = p.LoadWithDefault("queryNode.dataSync.deadLetter.channelPrefix", "dead-letter-")
+	if err != nil {
+		panic(err)
+	}
+}
+
 // msgStream
 func (p *ParamTable) initSearchReceiveBufSize() {
 	p.SearchReceiveBufSize = p.ParseInt64("queryNode.msgStream.search.recvBufSize")
@@ -268,12 +397,43 @@ func (p *ParamTable) initSearchResultReceiveBufSize() {
 	p.SearchResultReceiveBufSize = p.ParseInt64("queryNode.msgStream.searchResult.recvBufSize")
 }
 
+// retrieve
+// initRetrieveMaxResultSize initializes the max estimated size of the results of a retrieve request, it's configured in MB
+func (p *ParamTable) initRetrieveMaxResultSize() {
+	size, err := p.LoadWithDefault("queryNode.retrieve.maxResultSize", "1024")
+	if err != nil {
+		panic(err)
+	}
+	value, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.RetrieveMaxResultSize = value * 1024 * 1024
+}
+
+// initRetrieveMemoryBudget initializes the estimated memory of the node for the results of the retrieve requests,
+// it's configured in MB
+func (p *ParamTable) initRetrieveMemoryBudget() {
+	size, err := p.LoadWithDefault("queryNode.retrieve.memoryBudget", "4096")
+	if err != nil {
+		panic(err)
+	}
+	value, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.RetrieveMemoryBudget = value * 1024 * 1024
+}
+
 // ------------------------  channel names
 func (p *ParamTable) initClusterMsgChannelPrefix() {
 	name, err := p.Load("msgChannel.chanNamePrefix.cluster")
 	if err != nil {
 		panic(err)
 	}
+	if err = channelutil.ValidatePrefix(name); err != nil {
+		panic(err)
+	}
 	p.ClusterChannelPrefix = name
 }
 
@@ -282,8 +442,7 @@ func (p *ParamTable) initQueryTimeTickChannelName() {
 	if err != nil {
 		log.Warn(err.Error())
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.QueryTimeTickChannelName = strings.Join(s, "-")
+	p.QueryTimeTickChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initMsgChannelSubName() {
@@ -292,8 +451,7 @@ func (p *ParamTable) initMsgChannelSubName() {
 		log.Warn(err.Error())
 	}
 
-	s := []string{p.ClusterChannelPrefix, namePrefix, strconv.FormatInt(p.QueryNodeID, 10)}
-	p.MsgChannelSubName = strings.Join(s, "-")
+	p.MsgChannelSubName = channelutil.JoinName(p.ClusterChannelPrefix, namePrefix, strconv.FormatInt(p.QueryNodeID, 10))
 }
 
 func (p *ParamTable) initStatsChannelName() {
@@ -301,8 +459,7 @@ func (p *ParamTable) initStatsChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.StatsChannelName = strings.Join(s, "-")
+	p.StatsChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 // ETCD configs
@@ -330,6 +487,47 @@ func (p *ParamTable) initGracefulTime() {
 	p.GracefulTime = p.ParseInt64("queryNode.gracefulTime")
 }
 
+func (p *ParamTable) initGracefulStopTimeout() {
+	p.GracefulStopTimeout = p.ParseInt64("queryNode.gracefulStopTimeout")
+}
+
+func (p *ParamTable) initWarmupEnabled() {
+	p.WarmupEnabled = p.ParseBool("queryNode.warmup.enabled", false)
+}
+
+func (p *ParamTable) initWarmupTimeout() {
+	p.WarmupTimeout = time.Duration(p.ParseInt64("queryNode.warmup.timeout")) * time.Millisecond
+}
+
+func (p *ParamTable) initLoadIndexStrict() {
+	p.LoadIndexStrict = p.ParseBool("queryNode.loadIndex.strict", false)
+}
+
+func (p *ParamTable) initDeleteBufferSize() {
+	size, err := p.ParseInt64WithBounds("queryNode.deleteBuffer.size", 100000, 0, math.MaxInt32)
+	if err != nil {
+		panic(err)
+	}
+	p.DeleteBufferSize = size
+}
+
+func (p *ParamTable) initChunkCacheEnabled() {
+	p.ChunkCacheEnabled = p.ParseBool("queryNode.chunkCache.enabled", false)
+}
+
+func (p *ParamTable) initChunkCachePath() {
+	path, err := p.LoadWithDefault("queryNode.chunkCache.path", "/var/lib/milvus/chunk_cache")
+	if err != nil {
+		panic(err)
+	}
+	p.ChunkCachePath = path
+}
+
+// initChunkCacheMaxSize initializes the max size of the chunk cache, it's configured in MB
+func (p *ParamTable) initChunkCacheMaxSize() {
+	p.ChunkCacheMaxSize = p.ParseInt64("queryNode.chunkCache.maxSize") * 1024 * 1024
+}
+
 func (p *ParamTable) initSegcoreChunkRows() {
 	p.ChunkRows = p.ParseInt64("queryNode.segcore.chunkRows")
 }
@@ -345,3 +543,16 @@ func (p *ParamTable) initKnowhereSimdType() {
 func (p *ParamTable) initRoleName() {
 	p.RoleName = "querynode"
 }
+
+// initMetricsPort initializes the port serving the metrics of the node, 0 means the metrics are only served on the
+// metrics port of the process which nodes sharing a host can't listen on together.
+func (p *ParamTable) initMetricsPort() {
+	port, err := p.LoadWithDefault("queryNode.metricsPort", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.MetricsPort, err = strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+}
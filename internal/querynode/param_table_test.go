@@ -16,6 +16,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -61,6 +62,12 @@ func TestParamTable_minio(t *testing.T) {
 		useSSL := Params.MinioUseSSLStr
 		assert.Equal(t, useSSL, false)
 	})
+
+	t.Run("Test storageType", func(t *testing.T) {
+		assert.Equal(t, "minio", Params.StorageType)
+		assert.False(t, Params.LocalStorageSyncOnWrite)
+		assert.False(t, Params.StorageChecksumWarnOnly)
+	})
 }
 
 func TestParamTable_statsServiceTimeInterval(t *testing.T) {
@@ -93,6 +100,16 @@ func TestParamTable_flowGraphMaxParallelism(t *testing.T) {
 	assert.Equal(t, int32(1024), maxParallelism)
 }
 
+func TestParamTable_flowGraphProfile(t *testing.T) {
+	assert.False(t, Params.FlowGraphProfileEnabled)
+	assert.Equal(t, 60*time.Second, Params.FlowGraphStallThreshold)
+}
+
+func TestParamTable_deadLetter(t *testing.T) {
+	assert.Equal(t, 3, Params.DeadLetter.MaxUnmarshalAttempts)
+	assert.Equal(t, "dead-letter-", Params.DeadLetter.ChannelPrefix)
+}
+
 func TestParamTable_msgChannelSubName(t *testing.T) {
 	Params.QueryNodeID = 3
 	Params.initMsgChannelSubName()
@@ -116,3 +133,27 @@ func TestParamTable_metaRootPath(t *testing.T) {
 	path := Params.MetaRootPath
 	fmt.Println(path)
 }
+
+func TestParamTable_warmup(t *testing.T) {
+	assert.False(t, Params.WarmupEnabled)
+	assert.Equal(t, 3*time.Second, Params.WarmupTimeout)
+}
+
+func TestParamTable_loadIndexStrict(t *testing.T) {
+	assert.False(t, Params.LoadIndexStrict)
+}
+
+func TestParamTable_deleteBufferSize(t *testing.T) {
+	assert.Equal(t, int64(100000), Params.DeleteBufferSize)
+}
+
+func TestParamTable_chunkCache(t *testing.T) {
+	assert.False(t, Params.ChunkCacheEnabled)
+	assert.Equal(t, "/var/lib/milvus/chunk_cache", Params.ChunkCachePath)
+	assert.Equal(t, int64(10240*1024*1024), Params.ChunkCacheMaxSize)
+}
+
+func TestParamTable_retrieve(t *testing.T) {
+	assert.Equal(t, int64(1024*1024*1024), Params.RetrieveMaxResultSize)
+	assert.Equal(t, int64(4096*1024*1024), Params.RetrieveMemoryBudget)
+}
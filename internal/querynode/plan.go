@@ -24,6 +24,10 @@ import (
 	"errors"
 	"fmt"
 	"unsafe"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 )
 
 // SearchPlan is a wrapper of the underlying C-structure C.CSearchPlan
@@ -50,10 +54,30 @@ func createSearchPlan(col *Collection, dsl string) (*SearchPlan, error) {
 	return newPlan, nil
 }
 
+// disableRoundDecimal clears the round_decimal of a serialized search plan. The distances are rounded by the proxy
+// after the results of all the QueryNodes are reduced, rounding them in the segments makes them depend on the node
+// serving the results, and changes the order of the near-ties.
+func disableRoundDecimal(expr []byte) ([]byte, error) {
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return nil, err
+	}
+	queryInfo := planNode.GetVectorAnns().GetQueryInfo()
+	if queryInfo == nil || queryInfo.RoundDecimal == -1 {
+		return expr, nil
+	}
+	queryInfo.RoundDecimal = -1
+	return proto.Marshal(planNode)
+}
+
 func createSearchPlanByExpr(col *Collection, expr []byte) (*SearchPlan, error) {
 	if col.collectionPtr == nil {
 		return nil, errors.New("nil collection ptr, collectionID = " + fmt.Sprintln(col.id))
 	}
+	expr, err := disableRoundDecimal(expr)
+	if err != nil {
+		return nil, err
+	}
 
 	var cPlan C.CSearchPlan
 	status := C.CreateSearchPlanByExpr(col.collectionPtr, (*C.char)(unsafe.Pointer(&expr[0])), (C.int64_t)(len(expr)), &cPlan)
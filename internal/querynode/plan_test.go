@@ -63,6 +63,33 @@ func TestPlan_createSearchPlanByExpr(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestPlan_disableRoundDecimal(t *testing.T) {
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{
+				QueryInfo: &planpb.QueryInfo{Topk: 10, MetricType: "L2", RoundDecimal: 3},
+			},
+		},
+	}
+	expr, err := proto.Marshal(planNode)
+	assert.NoError(t, err)
+
+	disabled, err := disableRoundDecimal(expr)
+	assert.NoError(t, err)
+	result := &planpb.PlanNode{}
+	assert.NoError(t, proto.Unmarshal(disabled, result))
+	assert.Equal(t, int64(-1), result.GetVectorAnns().GetQueryInfo().GetRoundDecimal())
+	assert.Equal(t, int64(10), result.GetVectorAnns().GetQueryInfo().GetTopk())
+
+	// the plan without rounding is kept as it is
+	again, err := disableRoundDecimal(disabled)
+	assert.NoError(t, err)
+	assert.Equal(t, disabled, again)
+
+	_, err = disableRoundDecimal([]byte("invalid"))
+	assert.Error(t, err)
+}
+
 func TestPlan_NilCollection(t *testing.T) {
 	collection := &Collection{
 		id: defaultCollectionID,
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 
 	"github.com/golang/protobuf/proto"
@@ -32,6 +34,8 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
 	"github.com/milvus-io/milvus/internal/storage"
@@ -41,10 +45,28 @@ import (
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
+// inFlightQueryCheckInterval is the interval to check whether in-flight queries are done when stopping
+const inFlightQueryCheckInterval = 10 * time.Millisecond
+
+// serviceableCheckInterval is the interval to check the serviceable time when a shard search waits for the guarantee timestamp
+const serviceableCheckInterval = 10 * time.Millisecond
+
 type queryMsg interface {
 	msgstream.TsMsg
 	GuaranteeTs() Timestamp
 	TravelTs() Timestamp
+	TimeoutTs() Timestamp
+}
+
+// isQueryMsgExpired returns whether the timeout timestamp of the query message has passed,
+// the proxy has given up waiting for the expired query messages so they don't need to be executed.
+func isQueryMsgExpired(msg queryMsg, now time.Time) bool {
+	timeoutTs := msg.TimeoutTs()
+	if timeoutTs == 0 {
+		return false
+	}
+	deadline, _ := tsoutil.ParseTS(timeoutTs)
+	return now.After(deadline)
 }
 
 type queryCollection struct {
@@ -75,6 +97,12 @@ type queryCollection struct {
 	localCacheEnabled  bool
 
 	globalSegmentManager *globalSealedSegmentManager
+
+	// retrieveBudget is shared by the queryCollections of the query node
+	retrieveBudget *retrieveBudget
+
+	stopped   int32 // set when the queryCollection stops accepting query messages
+	executing int32 // number of query messages being executed
 }
 
 type ResultEntityIds []UniqueID
@@ -88,6 +116,7 @@ func newQueryCollection(releaseCtx context.Context,
 	localChunkManager storage.ChunkManager,
 	remoteChunkManager storage.ChunkManager,
 	localCacheEnabled bool,
+	retrieveBudget *retrieveBudget,
 ) (*queryCollection, error) {
 
 	unsolvedMsg := make([]queryMsg, 0)
@@ -118,6 +147,7 @@ func newQueryCollection(releaseCtx context.Context,
 		remoteChunkManager:   remoteChunkManager,
 		localCacheEnabled:    localCacheEnabled,
 		globalSegmentManager: newGlobalSealedSegmentManager(collectionID),
+		retrieveBudget:       retrieveBudget,
 	}
 
 	err := qc.registerCollectionTSafe()
@@ -135,6 +165,13 @@ func (q *queryCollection) start() {
 }
 
 func (q *queryCollection) close() {
+	// stop the watchers and wake up doUnsolvedQueryMsg waiting for a new tSafe, so that they could quit
+	q.cancel()
+	q.removeTSafeWatchers()
+	q.watcherCond.L.Lock()
+	q.watcherCond.Broadcast()
+	q.watcherCond.L.Unlock()
+
 	if q.queryMsgStream != nil {
 		q.queryMsgStream.Close()
 	}
@@ -186,14 +223,33 @@ func (q *queryCollection) addTSafeWatcher(vChannel Channel) error {
 	return nil
 }
 
-// TODO: add stopWatcher(), add close() to tSafeWatcher
+// removeTSafeWatchers unregisters the tSafe watchers of the queryCollection from tSafeReplica
+func (q *queryCollection) removeTSafeWatchers() {
+	q.tSafeWatchersMu.Lock()
+	defer q.tSafeWatchersMu.Unlock()
+	for channel, watcher := range q.tSafeWatchers {
+		// the tSafe may have been removed by release, don't return error
+		err := q.streaming.tSafeReplica.removeTSafeWatcher(channel, watcher)
+		if err != nil {
+			log.Debug("remove tSafeWatcher of queryCollection failed",
+				zap.Any("collectionID", q.collectionID),
+				zap.Any("channel", channel),
+				zap.Error(err))
+		}
+	}
+	q.tSafeWatchers = make(map[Channel]*tSafeWatcher)
+}
+
 func (q *queryCollection) startWatcher(channel <-chan bool) {
 	for {
 		select {
 		case <-q.releaseCtx.Done():
 			return
-		case <-channel:
-			// TODO: check if channel is closed
+		case _, ok := <-channel:
+			if !ok {
+				// the tSafe is closed, the watcher would never be notified again
+				return
+			}
 			q.watcherCond.L.Lock()
 			q.tSafeUpdate = true
 			q.watcherCond.Broadcast()
@@ -219,9 +275,13 @@ func (q *queryCollection) popAllUnsolvedMsg() []queryMsg {
 
 func (q *queryCollection) waitNewTSafe() (Timestamp, error) {
 	q.watcherCond.L.Lock()
-	for !q.tSafeUpdate {
+	for !q.tSafeUpdate && q.releaseCtx.Err() == nil {
 		q.watcherCond.Wait()
 	}
+	if err := q.releaseCtx.Err(); err != nil {
+		q.watcherCond.L.Unlock()
+		return 0, err
+	}
 	q.tSafeUpdate = false
 	q.watcherCond.Broadcast()
 	q.watcherCond.L.Unlock()
@@ -284,12 +344,12 @@ func (q *queryCollection) consumeQuery() {
 			for _, msg := range msgPack.Msgs {
 				switch sm := msg.(type) {
 				case *msgstream.SearchMsg:
-					err := q.receiveQueryMsg(sm)
+					err := q.acceptQueryMsg(sm)
 					if err != nil {
 						log.Warn(err.Error())
 					}
 				case *msgstream.RetrieveMsg:
-					err := q.receiveQueryMsg(sm)
+					err := q.acceptQueryMsg(sm)
 					if err != nil {
 						log.Warn(err.Error())
 					}
@@ -307,6 +367,61 @@ func (q *queryCollection) consumeQuery() {
 	}
 }
 
+// acceptQueryMsg receives the query message if the queryCollection is still accepting query messages,
+// otherwise a failed result would be published so that the proxy doesn't need to wait until timeout.
+func (q *queryCollection) acceptQueryMsg(msg queryMsg) error {
+	atomic.AddInt32(&q.executing, 1)
+	defer atomic.AddInt32(&q.executing, -1)
+
+	if atomic.LoadInt32(&q.stopped) == 1 {
+		err := fmt.Errorf("query node is stopping, msgID = %d, collectionID = %d", msg.ID(), q.collectionID)
+		publishErr := q.publishFailedQueryResult(msg, err.Error())
+		if publishErr != nil {
+			return fmt.Errorf("first err = %s, second err = %s", err, publishErr)
+		}
+		return err
+	}
+	return q.receiveQueryMsg(msg)
+}
+
+// stopAcceptingQuery makes the queryCollection reject all the following query messages,
+// the query messages which have been accepted would still be executed.
+func (q *queryCollection) stopAcceptingQuery() {
+	atomic.StoreInt32(&q.stopped, 1)
+}
+
+// hasInFlightQuery returns whether there are query messages executing or waiting for tSafe.
+func (q *queryCollection) hasInFlightQuery() bool {
+	if atomic.LoadInt32(&q.executing) > 0 {
+		return true
+	}
+	q.unsolvedMsgMu.Lock()
+	defer q.unsolvedMsgMu.Unlock()
+	return len(q.unsolvedMsg) > 0
+}
+
+// waitInFlightQuery waits until all the accepted query messages are done or timeout,
+// the query messages which are still waiting for tSafe after timeout would be published as failed.
+func (q *queryCollection) waitInFlightQuery(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for q.hasInFlightQuery() {
+		if time.Now().After(deadline) {
+			for _, msg := range q.popAllUnsolvedMsg() {
+				errMsg := fmt.Sprintf("query node is stopping, msgID = %d, collectionID = %d", msg.ID(), q.collectionID)
+				if err := q.publishFailedQueryResult(msg, errMsg); err != nil {
+					log.Warn(err.Error())
+				}
+			}
+			log.Warn("wait in-flight query timeout",
+				zap.Int64("collectionID", q.collectionID),
+				zap.Int32("executing", atomic.LoadInt32(&q.executing)))
+			return false
+		}
+		time.Sleep(inFlightQueryCheckInterval)
+	}
+	return true
+}
+
 func (q *queryCollection) adjustByChangeInfo(msg *msgstream.SealedSegmentsChangeInfoMsg) error {
 	for _, info := range msg.Infos {
 		// for OnlineSegments:
@@ -336,8 +451,8 @@ func (q *queryCollection) adjustByChangeInfo(msg *msgstream.SealedSegmentsChange
 
 		// for OfflineSegments:
 		for _, segment := range info.OfflineSegments {
-			// 1. update global sealed segments
-			q.globalSegmentManager.removeGlobalSegmentInfo(segment.SegmentID)
+			// 1. update global sealed segments, the online one of load balance has been added above
+			q.globalSegmentManager.removeOfflineSegmentInfo(segment)
 		}
 	}
 	return nil
@@ -377,6 +492,15 @@ func (q *queryCollection) receiveQueryMsg(msg queryMsg) error {
 		return nil
 	}
 
+	if isQueryMsgExpired(msg, time.Now()) {
+		log.Debug("drop expired query message in receiveQueryMsg",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64("msgID", msg.ID()),
+			zap.String("msgType", msgTypeStr),
+		)
+		return nil
+	}
+
 	sp, ctx := trace.StartSpanFromContext(msg.TraceCtx())
 	msg.SetTraceCtx(ctx)
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("receiveQueryMsg %d", msg.ID()))
@@ -485,6 +609,10 @@ func (q *queryCollection) doUnsolvedQueryMsg() {
 		default:
 			//time.Sleep(10 * time.Millisecond)
 			serviceTime, err := q.waitNewTSafe()
+			if q.releaseCtx.Err() != nil {
+				log.Debug("stop Collection's doUnsolvedMsg", zap.Int64("collectionID", q.collectionID))
+				return
+			}
 			if err != nil {
 				log.Error(err.Error())
 				return
@@ -501,6 +629,13 @@ func (q *queryCollection) doUnsolvedQueryMsg() {
 			tempMsg := q.popAllUnsolvedMsg()
 
 			for _, m := range tempMsg {
+				if isQueryMsgExpired(m, time.Now()) {
+					log.Debug("drop expired query message from unsolvedMsg",
+						zap.Int64("collectionID", q.collectionID),
+						zap.Int64("msgID", m.ID()),
+					)
+					continue
+				}
 				guaranteeTs := m.GuaranteeTs()
 				gt, _ := tsoutil.ParseTS(guaranteeTs)
 				st, _ := tsoutil.ParseTS(serviceTime)
@@ -529,7 +664,17 @@ func (q *queryCollection) doUnsolvedQueryMsg() {
 			if len(unSolvedMsg) <= 0 {
 				continue
 			}
-			for _, m := range unSolvedMsg {
+			atomic.AddInt32(&q.executing, int32(len(unSolvedMsg)))
+			for i, m := range unSolvedMsg {
+				// the former messages may take long, check the timeout again before executing
+				if isQueryMsgExpired(m, time.Now()) {
+					log.Debug("drop expired query message in doUnsolvedMsg",
+						zap.Int64("collectionID", q.collectionID),
+						zap.Int64("msgID", m.ID()),
+					)
+					atomic.AddInt32(&q.executing, -1)
+					continue
+				}
 				msgType := m.Type()
 				var err error
 				sp, ctx := trace.StartSpanFromContext(m.TraceCtx())
@@ -546,6 +691,7 @@ func (q *queryCollection) doUnsolvedQueryMsg() {
 				default:
 					err := fmt.Errorf("receive invalid msgType = %d", msgType)
 					log.Warn(err.Error())
+					atomic.AddInt32(&q.executing, -int32(len(unSolvedMsg)-i))
 					return
 				}
 
@@ -562,6 +708,7 @@ func (q *queryCollection) doUnsolvedQueryMsg() {
 					}
 				}
 				sp.Finish()
+				atomic.AddInt32(&q.executing, -1)
 				log.Debug("do query done in doUnsolvedMsg",
 					zap.Int64("collectionID", q.collectionID),
 					zap.Int64("msgID", m.ID()),
@@ -863,9 +1010,112 @@ func (q *queryCollection) search(msg queryMsg) error {
 	}
 }
 
+func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error {
+	results, err := q.searchByVectorsInScope(searchMsg, nil)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		resultChannelInt := 0
+		searchResultMsg := &msgstream.SearchResultMsg{
+			BaseMsg:       msgstream.BaseMsg{Ctx: searchMsg.Ctx, HashValues: []uint32{uint32(resultChannelInt)}},
+			SearchResults: *result,
+		}
+		err = q.publishQueryResult(searchResultMsg, searchMsg.CollectionID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchShard searches the dm channel and the sealed segments in the request directly rather than through the query channel,
+// the search waits until the serviceable time reaches the guarantee timestamp if a dm channel is searched.
+func (q *queryCollection) searchShard(ctx context.Context, req *querypb.SearchRequest) ([]*internalpb.SearchResults, error) {
+	atomic.AddInt32(&q.executing, 1)
+	defer atomic.AddInt32(&q.executing, -1)
+
+	if atomic.LoadInt32(&q.stopped) == 1 {
+		return nil, fmt.Errorf("query node is stopping, collectionID = %d", q.collectionID)
+	}
+	searchReq := req.Req
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("search expired before execution, collectionID = %d, err = %s", q.collectionID, ctx.Err())
+	}
+	if len(searchReq.PlaceholderGroup) == 0 {
+		return nil, fmt.Errorf("no search vectors specified, collectionID = %d", q.collectionID)
+	}
+
+	collection, err := q.historical.replica.getCollectionByID(q.collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if searchReq.GuaranteeTimestamp >= collection.getReleaseTime() {
+		return nil, fmt.Errorf("search failed, collection has been released, collectionID = %d", q.collectionID)
+	}
+
+	scope := &searchScope{
+		segmentIDs: req.SegmentIDs,
+	}
+	if req.DmlChannel != "" {
+		watched := false
+		for _, channel := range collection.getVChannels() {
+			if channel == req.DmlChannel {
+				watched = true
+				break
+			}
+		}
+		if !watched {
+			return nil, fmt.Errorf("dm channel %s of collection %d is not watched by query node %d", req.DmlChannel, q.collectionID, Params.QueryNodeID)
+		}
+		scope.channels = []Channel{req.DmlChannel}
+
+		if err = q.waitServiceable(ctx, searchReq.GuaranteeTimestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	searchMsg := &msgstream.SearchMsg{
+		BaseMsg: msgstream.BaseMsg{
+			Ctx:            ctx,
+			BeginTimestamp: searchReq.Base.Timestamp,
+			EndTimestamp:   searchReq.Base.Timestamp,
+		},
+		SearchRequest: *searchReq,
+	}
+	// the search may wait long for the serviceable time
+	if isQueryMsgExpired(searchMsg, time.Now()) {
+		return nil, fmt.Errorf("search expired before execution, collectionID = %d", q.collectionID)
+	}
+	return q.searchByVectorsInScope(searchMsg, scope)
+}
+
+// waitServiceable waits until the serviceable time reaches the guarantee timestamp
+func (q *queryCollection) waitServiceable(ctx context.Context, guaranteeTs Timestamp) error {
+	ticker := time.NewTicker(serviceableCheckInterval)
+	defer ticker.Stop()
+	for q.getServiceableTime() < guaranteeTs {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for serviceable time failed, collectionID = %d, err = %s", q.collectionID, ctx.Err())
+		case <-q.releaseCtx.Done():
+			return fmt.Errorf("collection has been released, collectionID = %d", q.collectionID)
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// searchScope limits a search to the growing segments of some dm channels and some sealed segments,
+// a search without scope searches all the data of the collection held by the query node.
+type searchScope struct {
+	channels   []Channel
+	segmentIDs []UniqueID
+}
+
 // TODO:: cache map[dsl]plan
 // TODO: reBatched search requests
-func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error {
+func (q *queryCollection) searchByVectorsInScope(searchMsg *msgstream.SearchMsg, scope *searchScope) ([]*internalpb.SearchResults, error) {
 	sp, ctx := trace.StartSpanFromContext(searchMsg.TraceCtx())
 	defer sp.Finish()
 	searchMsg.SetTraceCtx(ctx)
@@ -874,12 +1124,16 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 
 	collection, err := q.streaming.replica.getCollectionByID(searchMsg.CollectionID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	channels := collection.getVChannels()
+	if scope != nil {
+		channels = scope.channels
 	}
 
 	schema, err := typeutil.CreateSchemaHelper(collection.schema)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var plan *SearchPlan
@@ -887,26 +1141,26 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 		expr := searchMsg.SerializedExprPlan
 		plan, err = createSearchPlanByExpr(collection, expr)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		dsl := searchMsg.Dsl
 		plan, err = createSearchPlan(collection, dsl)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	topK := plan.getTopK()
 	if topK == 0 {
-		return fmt.Errorf("limit must be greater than 0")
+		return nil, fmt.Errorf("limit must be greater than 0")
 	}
 	if topK >= 16385 {
-		return fmt.Errorf("limit %d is too large", topK)
+		return nil, fmt.Errorf("limit %d is too large", topK)
 	}
 	searchRequestBlob := searchMsg.PlaceholderGroup
 	searchReq, err := parseSearchRequest(plan, searchRequestBlob)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	queryNum := searchReq.getNumOfQuery()
 	searchRequests := make([]*searchRequest, 0)
@@ -924,77 +1178,92 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("search %d(nq=%d, k=%d)", searchMsg.CollectionID, queryNum, topK))
 
-	// get global sealed segments
+	// get global sealed segments, from the same view as the segments to search are picked from,
+	// the view is updated by the change infos in the query channel, so all query nodes agree on it
 	var globalSealedSegments []UniqueID
 	if len(searchMsg.PartitionIDs) > 0 {
-		globalSealedSegments = q.historical.getGlobalSegmentIDsByPartitionIds(searchMsg.PartitionIDs)
+		globalSealedSegments = q.globalSegmentManager.getGlobalSegmentIDsByPartitionIds(searchMsg.PartitionIDs)
 	} else {
-		globalSealedSegments = q.historical.getGlobalSegmentIDsByCollectionID(collection.id)
+		globalSealedSegments = q.globalSegmentManager.getGlobalSegmentIDs()
+	}
+
+	// a segment is searched only once in the cluster, even if it's on several query nodes during load balance
+	// or handoff: only the sealed copy of the published version is searched, and a growing segment is skipped
+	// once its sealed copy is published
+	sealedFilter := func(segment *Segment) bool {
+		return q.globalSegmentManager.isServing(segment.segmentID, segment.getVersion())
+	}
+	growingFilter := func(segment *Segment) bool {
+		return !q.globalSegmentManager.hasGlobalSegment(segment.segmentID)
 	}
 
 	searchResults := make([]*SearchResult, 0)
 
 	// historical search
-	hisSearchResults, sealedSegmentSearched, err1 := q.historical.search(searchRequests, collection.id, searchMsg.PartitionIDs, plan, travelTimestamp)
+	var hisSearchResults []*SearchResult
+	var sealedSegmentSearched []UniqueID
+	var err1 error
+	if scope != nil {
+		hisSearchResults, sealedSegmentSearched, err1 = q.historical.searchSegments(searchRequests, collection.id, searchMsg.PartitionIDs, scope.segmentIDs, plan, travelTimestamp)
+	} else {
+		hisSearchResults, sealedSegmentSearched, err1 = q.historical.search(searchRequests, collection.id, searchMsg.PartitionIDs, plan, travelTimestamp, sealedFilter)
+	}
 	if err1 != nil {
 		log.Warn(err1.Error())
-		return err1
+		return nil, err1
 	}
 	searchResults = append(searchResults, hisSearchResults...)
 	tr.Record("historical search done")
 
 	// streaming search
 	var err2 error
-	for _, channel := range collection.getVChannels() {
+	for _, channel := range channels {
 		var strSearchResults []*SearchResult
-		strSearchResults, err2 = q.streaming.search(searchRequests, collection.id, searchMsg.PartitionIDs, channel, plan, travelTimestamp)
+		strSearchResults, err2 = q.streaming.search(searchRequests, collection.id, searchMsg.PartitionIDs, channel, plan, travelTimestamp, growingFilter)
 		if err2 != nil {
 			log.Warn(err2.Error())
-			return err2
+			return nil, err2
 		}
 		searchResults = append(searchResults, strSearchResults...)
 	}
 	tr.Record("streaming search done")
 
 	sp.LogFields(oplog.String("statistical time", "segment search end"))
+	var rowsScanned int64
+	for _, result := range searchResults {
+		rowsScanned += result.rowCount
+	}
 	if len(searchResults) <= 0 {
 		for range searchRequests {
-			resultChannelInt := 0
-			searchResultMsg := &msgstream.SearchResultMsg{
-				BaseMsg: msgstream.BaseMsg{Ctx: searchMsg.Ctx, HashValues: []uint32{uint32(resultChannelInt)}},
-				SearchResults: internalpb.SearchResults{
-					Base: &commonpb.MsgBase{
-						MsgType:   commonpb.MsgType_SearchResult,
-						MsgID:     searchMsg.Base.MsgID,
-						Timestamp: searchTimestamp,
-						SourceID:  searchMsg.Base.SourceID,
-					},
-					Status:                   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
-					ResultChannelID:          searchMsg.ResultChannelID,
-					MetricType:               plan.getMetricType(),
-					NumQueries:               queryNum,
-					TopK:                     topK,
-					SlicedBlob:               nil,
-					SlicedOffset:             1,
-					SlicedNumCount:           1,
-					SealedSegmentIDsSearched: sealedSegmentSearched,
-					ChannelIDsSearched:       collection.getVChannels(),
-					GlobalSealedSegmentIDs:   globalSealedSegments,
+			searchResult := &internalpb.SearchResults{
+				Base: &commonpb.MsgBase{
+					MsgType:   commonpb.MsgType_SearchResult,
+					MsgID:     searchMsg.Base.MsgID,
+					Timestamp: searchTimestamp,
+					SourceID:  searchMsg.Base.SourceID,
 				},
+				Status:                   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+				ResultChannelID:          searchMsg.ResultChannelID,
+				MetricType:               plan.getMetricType(),
+				NumQueries:               queryNum,
+				TopK:                     topK,
+				SlicedBlob:               nil,
+				SlicedOffset:             1,
+				SlicedNumCount:           1,
+				SealedSegmentIDsSearched: sealedSegmentSearched,
+				ChannelIDsSearched:       channels,
+				GlobalSealedSegmentIDs:   globalSealedSegments,
+				RowsScanned:              rowsScanned,
+				SegmentsHit:              int64(len(searchResults)),
 			}
 			log.Debug("QueryNode Empty SearchResultMsg",
 				zap.Any("collectionID", collection.id),
 				zap.Any("msgID", searchMsg.ID()),
-				zap.Any("vChannels", collection.getVChannels()),
+				zap.Any("vChannels", channels),
 				zap.Any("sealedSegmentSearched", sealedSegmentSearched),
 			)
-			err = q.publishQueryResult(searchResultMsg, searchMsg.CollectionID)
-			if err != nil {
-				return err
-			}
-			tr.Record("publish empty search result done")
 			tr.Elapse("all done")
-			return nil
+			return []*internalpb.SearchResults{searchResult}, nil
 		}
 	}
 
@@ -1003,26 +1272,27 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 	err = reduceSearchResultsAndFillData(plan, searchResults, numSegment)
 	sp.LogFields(oplog.String("statistical time", "reduceSearchResults end"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	marshaledHits, err = reorganizeSearchResults(searchResults, numSegment)
 	sp.LogFields(oplog.String("statistical time", "reorganizeSearchResults end"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	hitsBlob, err := marshaledHits.getHitsBlob()
 	sp.LogFields(oplog.String("statistical time", "getHitsBlob end"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	tr.Record("reduce result done")
 
 	var offset int64 = 0
+	results := make([]*internalpb.SearchResults, 0, len(searchRequests))
 	for index := range searchRequests {
 		hitBlobSizePeerQuery, err := marshaledHits.hitBlobSizeInGroup(int64(index))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		hits := make([][]byte, len(hitBlobSizePeerQuery))
 		for i, len := range hitBlobSizePeerQuery {
@@ -1032,7 +1302,7 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 			//unMarshaledHit := milvuspb.Hits{}
 			//err = proto.Unmarshal(marshaledHit, &unMarshaledHit)
 			//if err != nil {
-			//	return err
+			//	return nil, err
 			//}
 			//log.Debug("hits msg  = ", unMarshaledHit)
 			offset += len
@@ -1044,40 +1314,38 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 
 		transformed, err := translateHits(schema, searchMsg.OutputFieldsId, hits)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		byteBlobs, err := proto.Marshal(transformed)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		resultChannelInt := 0
-		searchResultMsg := &msgstream.SearchResultMsg{
-			BaseMsg: msgstream.BaseMsg{Ctx: searchMsg.Ctx, HashValues: []uint32{uint32(resultChannelInt)}},
-			SearchResults: internalpb.SearchResults{
-				Base: &commonpb.MsgBase{
-					MsgType:   commonpb.MsgType_SearchResult,
-					MsgID:     searchMsg.Base.MsgID,
-					Timestamp: searchTimestamp,
-					SourceID:  searchMsg.Base.SourceID,
-				},
-				Status:                   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
-				ResultChannelID:          searchMsg.ResultChannelID,
-				MetricType:               plan.getMetricType(),
-				NumQueries:               queryNum,
-				TopK:                     topK,
-				SlicedBlob:               byteBlobs,
-				SlicedOffset:             1,
-				SlicedNumCount:           1,
-				SealedSegmentIDsSearched: sealedSegmentSearched,
-				ChannelIDsSearched:       collection.getVChannels(),
-				GlobalSealedSegmentIDs:   globalSealedSegments,
+		searchResult := &internalpb.SearchResults{
+			Base: &commonpb.MsgBase{
+				MsgType:   commonpb.MsgType_SearchResult,
+				MsgID:     searchMsg.Base.MsgID,
+				Timestamp: searchTimestamp,
+				SourceID:  searchMsg.Base.SourceID,
 			},
+			Status:                   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			ResultChannelID:          searchMsg.ResultChannelID,
+			MetricType:               plan.getMetricType(),
+			NumQueries:               queryNum,
+			TopK:                     topK,
+			SlicedBlob:               byteBlobs,
+			SlicedOffset:             1,
+			SlicedNumCount:           1,
+			SealedSegmentIDsSearched: sealedSegmentSearched,
+			ChannelIDsSearched:       channels,
+			GlobalSealedSegmentIDs:   globalSealedSegments,
+			RowsScanned:              rowsScanned,
+			SegmentsHit:              numSegment,
 		}
 		log.Debug("QueryNode SearchResultMsg",
 			zap.Any("collectionID", collection.id),
 			zap.Any("msgID", searchMsg.ID()),
-			zap.Any("vChannels", collection.getVChannels()),
+			zap.Any("vChannels", channels),
 			zap.Any("sealedSegmentSearched", sealedSegmentSearched),
 		)
 
@@ -1092,11 +1360,7 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 		//	fmt.Println(testHits.IDs)
 		//	fmt.Println(testHits.Scores)
 		//}
-		err = q.publishQueryResult(searchResultMsg, searchMsg.CollectionID)
-		if err != nil {
-			return err
-		}
-		tr.Record("publish search result")
+		results = append(results, searchResult)
 	}
 
 	sp.LogFields(oplog.String("statistical time", "before free c++ memory"))
@@ -1106,7 +1370,7 @@ func (q *queryCollection) searchByVectors(searchMsg *msgstream.SearchMsg) error
 	plan.delete()
 	searchReq.delete()
 	tr.Elapse("all done")
-	return nil
+	return results, nil
 }
 
 func (q *queryCollection) retrieve(msg queryMsg, publishResult bool) (*msgstream.RetrieveResultMsg, error) {
@@ -1128,7 +1392,10 @@ func (q *queryCollection) retrieve(msg queryMsg, publishResult bool) (*msgstream
 		return nil, err
 	}
 
-	expr := retrieveMsg.SerializedExprPlan
+	expr, err := restrictRetrieveOutputFields(retrieveMsg.SerializedExprPlan, collection.Schema(), retrieveMsg.OutputFieldsId)
+	if err != nil {
+		return nil, err
+	}
 	plan, err := createRetrievePlanByExpr(collection, expr, timestamp)
 	if err != nil {
 		return nil, err
@@ -1160,7 +1427,7 @@ func (q *queryCollection) retrieve(msg queryMsg, publishResult bool) (*msgstream
 			}, q.localCacheEnabled)
 	}
 	// historical retrieve
-	hisRetrieveResults, sealedSegmentRetrieved, err1 := q.historical.retrieve(collectionID, retrieveMsg.PartitionIDs, q.vectorChunkManager, plan)
+	hisRetrieveResults, sealedSegmentRetrieved, err1 := q.historical.retrieve(collectionID, retrieveMsg.PartitionIDs, plan)
 	if err1 != nil {
 		log.Warn(err1.Error())
 		return nil, err1
@@ -1177,12 +1444,48 @@ func (q *queryCollection) retrieve(msg queryMsg, publishResult bool) (*msgstream
 	mergeList = append(mergeList, strRetrieveResults...)
 	tr.Record("streaming retrieve done")
 
+	// the results are estimated before the vectors are filled and the results are merged, which take most of
+	// the memory of a large result
+	for _, segResult := range mergeList {
+		if err := limitSegmentRetrieveResult(segResult, retrieveMsg.Limit); err != nil {
+			return nil, err
+		}
+	}
+	sizePerRecord, err := estimateRetrieveSizePerRecord(collection.Schema(), retrieveMsg.OutputFieldsId)
+	if err != nil {
+		return nil, err
+	}
+	resultSize := estimateRetrieveResultSize(mergeList, sizePerRecord)
+	if Params.RetrieveMaxResultSize > 0 && resultSize > Params.RetrieveMaxResultSize {
+		log.Warn("retrieve result too large",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64("msgID", retrieveMsg.ID()),
+			zap.Int64("estimated size", resultSize),
+			zap.Int64("max size", Params.RetrieveMaxResultSize))
+		return nil, errRetrieveResultTooLarge(resultSize, Params.RetrieveMaxResultSize)
+	}
+	if q.retrieveBudget != nil {
+		if err := q.retrieveBudget.acquire(q.releaseCtx, resultSize); err != nil {
+			return nil, err
+		}
+		defer q.retrieveBudget.release(resultSize)
+		tr.Record("retrieve budget acquired")
+	}
+
+	if err := q.historical.fillVectorFieldsData(collectionID, sealedSegmentRetrieved, q.vectorChunkManager, hisRetrieveResults); err != nil {
+		return nil, err
+	}
+
 	result, err := mergeRetrieveResults(mergeList)
 	if err != nil {
 		return nil, err
 	}
 	tr.Record("merge result done")
 
+	if err := limitRetrieveResults(result, retrieveMsg.Limit); err != nil {
+		return nil, err
+	}
+
 	resultChannelInt := 0
 	retrieveResultMsg := &msgstream.RetrieveResultMsg{
 		BaseMsg: msgstream.BaseMsg{Ctx: retrieveMsg.Ctx, HashValues: []uint32{uint32(resultChannelInt)}},
@@ -1219,6 +1522,37 @@ func (q *queryCollection) retrieve(msg queryMsg, publishResult bool) (*msgstream
 	return retrieveResultMsg, nil
 }
 
+// restrictRetrieveOutputFields rewrites the output fields of the serialized retrieve plan to the requested
+// output fields, so that segcore only reads the requested columns. The primary key field has been added
+// to the requested output fields by proxy. The plan is returned unchanged if no output field is requested.
+func restrictRetrieveOutputFields(expr []byte, schema *schemapb.CollectionSchema, outputFieldIDs []int64) ([]byte, error) {
+	if len(outputFieldIDs) == 0 {
+		return expr, nil
+	}
+
+	fieldIDs := make(map[int64]bool)
+	for _, field := range schema.GetFields() {
+		fieldIDs[field.FieldID] = true
+	}
+
+	planNode := &planpb.PlanNode{}
+	if err := proto.Unmarshal(expr, planNode); err != nil {
+		return nil, err
+	}
+	planNode.OutputFieldIds = make([]int64, 0, len(outputFieldIDs))
+	added := make(map[int64]bool)
+	for _, fieldID := range outputFieldIDs {
+		if !fieldIDs[fieldID] {
+			return nil, fmt.Errorf("output field %d not exist in collection %s", fieldID, schema.GetName())
+		}
+		if !added[fieldID] {
+			planNode.OutputFieldIds = append(planNode.OutputFieldIds, fieldID)
+			added[fieldID] = true
+		}
+	}
+	return proto.Marshal(planNode)
+}
+
 func (q *queryCollection) searchByID(searchByIDMsg *msgstream.SearchMsg) error {
 	collectionID := searchByIDMsg.CollectionID
 	col, err := q.streaming.replica.getCollectionByID(collectionID)
@@ -1355,6 +1689,26 @@ func mergeRetrieveResults(dataArr []*segcorepb.RetrieveResults) (*segcorepb.Retr
 	return final, nil
 }
 
+// limitRetrieveResults keeps the limit entities of result with the smallest primary keys sorted by primary key,
+// so that the proxy can paginate the entities merged from all query nodes. result is unchanged if limit <= 0.
+func limitRetrieveResults(result *segcorepb.RetrieveResults, limit int64) error {
+	if limit <= 0 || result.GetIds() == nil {
+		return nil
+	}
+	ids, fieldsData, err := typeutil.SliceRowsByPrimaryKey(result.Ids.GetIntId().GetData(), result.FieldsData, 0, limit)
+	if err != nil {
+		return err
+	}
+	result.Ids = &schemapb.IDs{
+		IdField: &schemapb.IDs_IntId{
+			IntId: &schemapb.LongArray{Data: ids},
+		},
+	}
+	result.FieldsData = fieldsData
+	result.Offset = nil
+	return nil
+}
+
 func (q *queryCollection) publishQueryResult(msg msgstream.TsMsg, collectionID UniqueID) error {
 	span, ctx := trace.StartSpanFromContext(msg.TraceCtx())
 	defer span.Finish()
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"fmt"
 	"math"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
@@ -57,7 +60,8 @@ func genSimpleQueryCollection(ctx context.Context, cancel context.CancelFunc) (*
 		fac,
 		localCM,
 		remoteCM,
-		false)
+		false,
+		newRetrieveBudget(Params.RetrieveMemoryBudget))
 	return queryCollection, err
 }
 
@@ -145,7 +149,7 @@ func TestQueryCollection_withoutVChannel(t *testing.T) {
 	assert.Nil(t, err)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	queryCollection, err := newQueryCollection(ctx, cancel, 0, historical, streaming, factory, nil, nil, false)
+	queryCollection, err := newQueryCollection(ctx, cancel, 0, historical, streaming, factory, nil, nil, false, nil)
 	assert.NoError(t, err)
 
 	producerChannels := []string{"testResultChannel"}
@@ -518,6 +522,45 @@ func TestQueryCollection_mergeRetrieveResults(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestQueryCollection_limitRetrieveResults(t *testing.T) {
+	newResult := func() *segcorepb.RetrieveResults {
+		return &segcorepb.RetrieveResults{
+			Ids: &schemapb.IDs{
+				IdField: &schemapb.IDs_IntId{
+					IntId: &schemapb.LongArray{Data: []int64{3, 1, 2}},
+				},
+			},
+			Offset: []int64{0, 1, 2},
+			FieldsData: []*schemapb.FieldData{
+				{
+					Type:      schemapb.DataType_Int64,
+					FieldName: "int64",
+					Field: &schemapb.FieldData_Scalars{
+						Scalars: &schemapb.ScalarField{
+							Data: &schemapb.ScalarField_LongData{
+								LongData: &schemapb.LongArray{Data: []int64{30, 10, 20}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	result := newResult()
+	assert.NoError(t, limitRetrieveResults(result, 2))
+	assert.Equal(t, []int64{1, 2}, result.Ids.GetIntId().GetData())
+	assert.Equal(t, []int64{10, 20}, result.FieldsData[0].GetScalars().GetLongData().GetData())
+
+	// no limit
+	result = newResult()
+	assert.NoError(t, limitRetrieveResults(result, 0))
+	assert.Equal(t, []int64{3, 1, 2}, result.Ids.GetIntId().GetData())
+
+	// empty result
+	assert.NoError(t, limitRetrieveResults(&segcorepb.RetrieveResults{}, 2))
+}
+
 func TestQueryCollection_doUnsolvedQueryMsg(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -559,6 +602,135 @@ func TestQueryCollection_search(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestQueryCollection_searchShard(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genShardSearchRequest := func() *querypb.SearchRequest {
+		req, err := genSimpleSearchRequest()
+		assert.NoError(t, err)
+		return &querypb.SearchRequest{
+			Req:        req,
+			SegmentIDs: []UniqueID{defaultSegmentID},
+		}
+	}
+
+	t.Run("test search sealed segments", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		results, err := queryCollection.searchShard(ctx, genShardSearchRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, []UniqueID{defaultSegmentID}, results[0].SealedSegmentIDsSearched)
+		assert.Equal(t, 0, len(results[0].ChannelIDsSearched))
+		assert.Equal(t, int64(1), results[0].SegmentsHit)
+		assert.Equal(t, int64(defaultMsgLength), results[0].RowsScanned)
+	})
+
+	t.Run("test segment not exist", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		err = queryCollection.historical.replica.removeSegment(defaultSegmentID)
+		assert.NoError(t, err)
+
+		_, err = queryCollection.searchShard(ctx, genShardSearchRequest())
+		assert.Error(t, err)
+	})
+
+	t.Run("test segment of other partitions", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		req := genShardSearchRequest()
+		req.Req.PartitionIDs = []UniqueID{defaultPartitionID + 1}
+		results, err := queryCollection.searchShard(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, 0, len(results[0].SealedSegmentIDsSearched))
+	})
+
+	t.Run("test no search vectors", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		req := genShardSearchRequest()
+		req.Req.PlaceholderGroup = nil
+		_, err = queryCollection.searchShard(ctx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("test dm channel not watched", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		req := genShardSearchRequest()
+		req.DmlChannel = "invalid-channel"
+		_, err = queryCollection.searchShard(ctx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("test stopped", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		queryCollection.stopAcceptingQuery()
+		_, err = queryCollection.searchShard(ctx, genShardSearchRequest())
+		assert.Error(t, err)
+		assert.False(t, queryCollection.hasInFlightQuery())
+	})
+
+	t.Run("test expired", func(t *testing.T) {
+		queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+		assert.NoError(t, err)
+
+		expiredCtx, expiredCancel := context.WithCancel(ctx)
+		expiredCancel()
+		_, err = queryCollection.searchShard(expiredCtx, genShardSearchRequest())
+		assert.Error(t, err)
+
+		req := genShardSearchRequest()
+		req.Req.TimeoutTimestamp = tsoutil.ComposeTS(time.Now().Add(-time.Second).UnixNano()/int64(time.Millisecond), 0)
+		_, err = queryCollection.searchShard(ctx, req)
+		assert.Error(t, err)
+	})
+}
+
+func TestQueryCollection_isQueryMsgExpired(t *testing.T) {
+	now := time.Now()
+	msg := &msgstream.SearchMsg{}
+	assert.False(t, isQueryMsgExpired(msg, now))
+
+	msg.TimeoutTimestamp = tsoutil.ComposeTS(now.Add(time.Second).UnixNano()/int64(time.Millisecond), 0)
+	assert.False(t, isQueryMsgExpired(msg, now))
+	assert.True(t, isQueryMsgExpired(msg, now.Add(2*time.Second)))
+
+	assert.False(t, isQueryMsgExpired(&msgstream.RetrieveMsg{}, now))
+}
+
+func TestQueryCollection_waitServiceable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+	assert.NoError(t, err)
+
+	guaranteeTs := tsoutil.ComposeTS(time.Now().UnixNano()/int64(time.Millisecond), 0)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer waitCancel()
+	err = queryCollection.waitServiceable(waitCtx, guaranteeTs)
+	assert.Error(t, err)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		queryCollection.setServiceableTime(guaranteeTs)
+	}()
+	err = queryCollection.waitServiceable(ctx, guaranteeTs)
+	assert.NoError(t, err)
+}
+
 func TestQueryCollection_preparePlaceHolderGroupByVectors(t *testing.T) {
 	vector := genSimpleFloatVectors()
 	_, err := preparePlaceHolderGroupByVectors(vector, defaultDim, defaultCollectionID)
@@ -715,3 +887,322 @@ func TestQueryCollection_adjustByChangeInfo(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestQueryCollection_searchDuringLoadBalance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// node1 watches the dm channel and holds the growing segment, the sealed segment is handed off to node2,
+	// then balanced to node1 and back to node2, every query node handles the messages in the order of the query channel
+	node1, err := genSimpleQueryCollection(ctx, cancel)
+	assert.NoError(t, err)
+	node2, err := genSimpleQueryCollection(ctx, cancel)
+	assert.NoError(t, err)
+
+	insertMsg, err := genSimpleInsertMsg()
+	assert.NoError(t, err)
+	growing, err := node1.streaming.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	offset, err := growing.segmentPreInsert(len(insertMsg.RowIDs))
+	assert.NoError(t, err)
+	err = growing.segmentInsert(offset, &insertMsg.RowIDs, &insertMsg.Timestamps, &insertMsg.RowData)
+	assert.NoError(t, err)
+	err = node2.streaming.replica.removeSegment(defaultSegmentID)
+	assert.NoError(t, err)
+
+	sealed1, err := node1.historical.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	sealed2, err := node2.historical.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	sealed2.setVersion(1)
+	sealed1.setVersion(2)
+
+	segmentInfo := func(nodeID UniqueID, version int64) *querypb.SegmentInfo {
+		info := genSimpleSegmentInfo()
+		info.NodeID = nodeID
+		info.Version = version
+		return info
+	}
+	changeInfo := func(online, offline *querypb.SegmentInfo) func(node *queryCollection) {
+		msg := genSimpleSealedSegmentsChangeInfoMsg()
+		if online != nil {
+			msg.Infos[0].OnlineNodeID = online.NodeID
+			msg.Infos[0].OnlineSegments = []*querypb.SegmentInfo{online}
+		}
+		if offline != nil {
+			msg.Infos[0].OfflineNodeID = offline.NodeID
+			msg.Infos[0].OfflineSegments = []*querypb.SegmentInfo{offline}
+		}
+		return func(node *queryCollection) {
+			err := node.adjustByChangeInfo(msg)
+			assert.NoError(t, err)
+		}
+	}
+	steps := []func(node *queryCollection){
+		// handoff, the growing segment is replaced by the sealed one on node2
+		changeInfo(segmentInfo(2, 1), nil),
+		// load balance to node1
+		changeInfo(segmentInfo(1, 2), segmentInfo(2, 1)),
+		// node2 loads the segment again, it's not published yet
+		func(node *queryCollection) {
+			if node == node2 {
+				sealed2.setVersion(3)
+			}
+		},
+		// load balance back to node2
+		changeInfo(segmentInfo(2, 3), segmentInfo(1, 2)),
+	}
+
+	const searchesPerStep = 5
+	numSearches := (len(steps) + 1) * searchesPerStep
+	countHits := func(node *queryCollection) []int {
+		hits := make([]int, 0, numSearches)
+		search := func() {
+			msg, err := genSimpleSearchMsg()
+			assert.NoError(t, err)
+			results, err := node.searchByVectorsInScope(msg, nil)
+			assert.NoError(t, err)
+			count := 0
+			for _, result := range results {
+				data := &schemapb.SearchResultData{}
+				err = proto.Unmarshal(result.SlicedBlob, data)
+				assert.NoError(t, err)
+				count += len(data.GetIds().GetIntId().GetData())
+			}
+			hits = append(hits, count)
+		}
+		for _, step := range steps {
+			for i := 0; i < searchesPerStep; i++ {
+				search()
+			}
+			step(node)
+		}
+		for i := 0; i < searchesPerStep; i++ {
+			search()
+		}
+		return hits
+	}
+
+	var wg sync.WaitGroup
+	var hits1, hits2 []int
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		hits1 = countHits(node1)
+	}()
+	go func() {
+		defer wg.Done()
+		hits2 = countHits(node2)
+	}()
+	wg.Wait()
+
+	// the segment is searched exactly once in the cluster for every search
+	assert.Equal(t, numSearches, len(hits1))
+	assert.Equal(t, numSearches, len(hits2))
+	for i := 0; i < numSearches; i++ {
+		assert.Equal(t, int(defaultTopK*defaultTopK), hits1[i]+hits2[i], "search %d", i)
+	}
+}
+
+func TestQueryCollection_gracefulStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+	assert.NoError(t, err)
+
+	queryChannel := genQueryChannel()
+	queryCollection.queryResultMsgStream.AsProducer([]Channel{queryChannel})
+	queryCollection.queryResultMsgStream.Start()
+
+	t.Run("no in-flight query", func(t *testing.T) {
+		assert.False(t, queryCollection.hasInFlightQuery())
+		assert.True(t, queryCollection.waitInFlightQuery(time.Second))
+	})
+
+	t.Run("wait in-flight query timeout", func(t *testing.T) {
+		msg, err := genSimpleSearchMsg()
+		assert.NoError(t, err)
+		queryCollection.addToUnsolvedMsg(msg)
+		assert.True(t, queryCollection.hasInFlightQuery())
+
+		assert.False(t, queryCollection.waitInFlightQuery(50*time.Millisecond))
+		assert.False(t, queryCollection.hasInFlightQuery())
+	})
+
+	t.Run("reject query after stop", func(t *testing.T) {
+		queryCollection.stopAcceptingQuery()
+		msg, err := genSimpleSearchMsg()
+		assert.NoError(t, err)
+		err = queryCollection.acceptQueryMsg(msg)
+		assert.Error(t, err)
+		assert.False(t, queryCollection.hasInFlightQuery())
+	})
+}
+
+// genWideSchema returns a schema with fieldNum fields, including a float vector field and a primary key field,
+// and the schema to load data into sealed segment which includes the row id field and timestamp field.
+func genWideSchema(fieldNum int) (*schemapb.CollectionSchema, *schemapb.CollectionSchema) {
+	scalarTypes := []schemapb.DataType{
+		schemapb.DataType_Int32,
+		schemapb.DataType_Int64,
+		schemapb.DataType_Float,
+		schemapb.DataType_Double,
+	}
+	fieldPK := genPKField(constFieldParam{id: 101, dataType: schemapb.DataType_Int64})
+	schema := &schemapb.CollectionSchema{
+		Name:   defaultCollectionName,
+		AutoID: false,
+		Fields: []*schemapb.FieldSchema{
+			genFloatVectorField(simpleVecField),
+			fieldPK,
+		},
+	}
+	for i := 0; len(schema.Fields) < fieldNum; i++ {
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			FieldID:  int64(102 + i),
+			Name:     fmt.Sprintf("field_%d", 102+i),
+			DataType: scalarTypes[i%len(scalarTypes)],
+		})
+	}
+
+	loadSchema := &schemapb.CollectionSchema{
+		Name:   defaultCollectionName,
+		AutoID: true,
+		Fields: []*schemapb.FieldSchema{
+			genConstantField(uidField),
+			genConstantField(timestampField),
+		},
+	}
+	loadSchema.Fields = append(loadSchema.Fields, schema.Fields...)
+	return schema, loadSchema
+}
+
+func TestQueryCollection_restrictRetrieveOutputFields(t *testing.T) {
+	const fieldNum = 20
+	schema, loadSchema := genWideSchema(fieldNum)
+	seg, err := genSealedSegment(schema,
+		loadSchema,
+		defaultCollectionID,
+		defaultPartitionID,
+		defaultSegmentID,
+		defaultVChannel,
+		defaultMsgLength)
+	assert.NoError(t, err)
+	defer deleteSegment(seg)
+
+	col := newCollection(defaultCollectionID, schema)
+	defer deleteCollection(col)
+
+	filterField := schema.Fields[2]
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_TermExpr{
+					TermExpr: &planpb.TermExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:  filterField.FieldID,
+							DataType: filterField.DataType,
+						},
+						Values: []*planpb.GenericValue{
+							{Val: &planpb.GenericValue_Int64Val{Int64Val: 1}},
+							{Val: &planpb.GenericValue_Int64Val{Int64Val: 2}},
+							{Val: &planpb.GenericValue_Int64Val{Int64Val: 3}},
+						},
+					},
+				},
+			},
+		},
+	}
+	expr, err := proto.Marshal(planNode)
+	assert.NoError(t, err)
+
+	retrieve := func(outputFieldIDs []int64) *segcorepb.RetrieveResults {
+		restricted, err := restrictRetrieveOutputFields(expr, schema, outputFieldIDs)
+		assert.NoError(t, err)
+		plan, err := createRetrievePlanByExpr(col, restricted, Timestamp(1000))
+		assert.NoError(t, err)
+		defer plan.delete()
+		result, err := seg.getEntityByIds(plan)
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("reduced payload", func(t *testing.T) {
+		allFieldIDs := make([]int64, 0, fieldNum)
+		for _, field := range schema.Fields {
+			allFieldIDs = append(allFieldIDs, field.FieldID)
+		}
+		full := retrieve(allFieldIDs)
+		assert.Equal(t, fieldNum, len(full.FieldsData))
+
+		outputFieldIDs := []int64{filterField.FieldID, schema.Fields[1].FieldID}
+		requested := retrieve(outputFieldIDs)
+		assert.Equal(t, len(outputFieldIDs), len(requested.FieldsData))
+		for i, fieldData := range requested.FieldsData {
+			assert.Equal(t, outputFieldIDs[i], fieldData.FieldId)
+		}
+		assert.Equal(t, len(full.Offset), len(requested.Offset))
+		assert.Less(t, proto.Size(requested), proto.Size(full))
+		t.Logf("retrieve payload of %d fields: %d bytes, payload of all %d fields: %d bytes",
+			len(outputFieldIDs), proto.Size(requested), fieldNum, proto.Size(full))
+	})
+
+	t.Run("duplicated output fields", func(t *testing.T) {
+		result := retrieve([]int64{filterField.FieldID, filterField.FieldID})
+		assert.Equal(t, 1, len(result.FieldsData))
+	})
+
+	t.Run("no output fields", func(t *testing.T) {
+		restricted, err := restrictRetrieveOutputFields(expr, schema, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expr, restricted)
+	})
+
+	t.Run("field not exist", func(t *testing.T) {
+		_, err := restrictRetrieveOutputFields(expr, schema, []int64{10000})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, err := restrictRetrieveOutputFields([]byte{1, 2, 3}, schema, []int64{filterField.FieldID})
+		assert.Error(t, err)
+	})
+}
+
+func TestQueryCollection_receiveQueryMsgWithGuaranteeTs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+	assert.NoError(t, err)
+
+	queryChannel := genQueryChannel()
+	queryCollection.queryResultMsgStream.AsProducer([]Channel{queryChannel})
+	queryCollection.queryResultMsgStream.Start()
+
+	// tSafe never advances in this test
+	serviceTime := queryCollection.getServiceableTime()
+
+	t.Run("eventually consistency doesn't wait for tSafe", func(t *testing.T) {
+		msg, err := genSimpleSearchMsg()
+		assert.NoError(t, err)
+		msg.GuaranteeTimestamp = 0
+
+		start := time.Now()
+		err = queryCollection.receiveQueryMsg(msg)
+		assert.NoError(t, err)
+		t.Logf("search of eventually consistency done in %v", time.Since(start))
+		assert.Len(t, queryCollection.popAllUnsolvedMsg(), 0)
+	})
+
+	t.Run("strong consistency waits for tSafe", func(t *testing.T) {
+		msg, err := genSimpleSearchMsg()
+		assert.NoError(t, err)
+		msg.GuaranteeTimestamp = serviceTime + tsoutil.ComposeTS(time.Now().UnixNano()/int64(time.Millisecond), 0)
+
+		err = queryCollection.receiveQueryMsg(msg)
+		assert.NoError(t, err)
+		assert.Len(t, queryCollection.popAllUnsolvedMsg(), 1)
+	})
+}
@@ -39,15 +39,18 @@ import (
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.uber.org/zap"
 
+	grpcquerynodeclient "github.com/milvus-io/milvus/internal/distributed/querynode/client"
 	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
@@ -86,6 +89,9 @@ type QueryNode struct {
 	rootCoord  types.RootCoord
 	indexCoord types.IndexCoord
 
+	// clients of the other query nodes, which the shard searches are forwarded to
+	shardClients *shardclient.Manager
+
 	msFactory msgstream.Factory
 	scheduler *taskScheduler
 
@@ -106,6 +112,9 @@ func NewQueryNode(ctx context.Context, factory msgstream.Factory) *QueryNode {
 	}
 
 	node.scheduler = newTaskScheduler(ctx1)
+	node.shardClients = shardclient.NewManager(ctx1, func(ctx context.Context, address string) (types.QueryNode, error) {
+		return grpcquerynodeclient.NewClient(ctx, address)
+	})
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
 
 	return node
@@ -151,6 +160,7 @@ func (node *QueryNode) InitSegcore() {
 func (node *QueryNode) Init() error {
 	var initError error = nil
 	node.initOnce.Do(func() {
+		storage.SetChecksumWarnOnly(Params.StorageChecksumWarnOnly)
 		//ctx := context.Background()
 		connectEtcdFn := func() error {
 			etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, Params.MetaRootPath)
@@ -180,6 +190,7 @@ func (node *QueryNode) Init() error {
 			node.indexCoord,
 			node.msFactory,
 			node.etcdKV)
+		node.historical.loader.peerClients = node.shardClients
 		node.streaming = newStreaming(node.queryNodeLoopCtx, node.msFactory, node.etcdKV, node.historical.replica)
 
 		node.InitSegcore()
@@ -201,7 +212,8 @@ func (node *QueryNode) Start() error {
 	m := map[string]interface{}{
 		"PulsarAddress":  Params.PulsarAddress,
 		"ReceiveBufSize": 1024,
-		"PulsarBufSize":  1024}
+		"PulsarBufSize":  1024,
+		"DeadLetter":     Params.DeadLetter}
 	err = node.msFactory.SetParams(m)
 	if err != nil {
 		return err
@@ -228,8 +240,18 @@ func (node *QueryNode) Start() error {
 	return nil
 }
 
+// Stop stops the query node gracefully: the session is marked as stopping so that query coord could move
+// segments away proactively, then the in-flight searches and queries are drained before releasing all the resources.
 func (node *QueryNode) Stop() error {
 	node.UpdateStateCode(internalpb.StateCode_Abnormal)
+	if node.session != nil {
+		if err := node.session.GoingStop(); err != nil {
+			log.Warn("failed to mark query node session as stopping", zap.Error(err))
+		}
+	}
+	if node.queryService != nil {
+		node.queryService.drain(time.Duration(Params.GracefulStopTimeout) * time.Second)
+	}
 	node.queryNodeLoopCancel()
 
 	// close services
@@ -242,6 +264,9 @@ func (node *QueryNode) Stop() error {
 	if node.queryService != nil {
 		node.queryService.close()
 	}
+	if node.shardClients != nil {
+		node.shardClients.Close()
+	}
 	return nil
 }
 
@@ -332,7 +357,7 @@ func (node *QueryNode) waitChangeInfo(segmentChangeInfos *querypb.SealedSegments
 						canDoLoadBalance = false
 						break
 					}
-					if info.OfflineNodeID == Params.QueryNodeID && qc.globalSegmentManager.hasGlobalSegment(segmentInfo.SegmentID) {
+					if info.OfflineNodeID == Params.QueryNodeID && qc.globalSegmentManager.isServing(segmentInfo.SegmentID, segmentInfo.Version) {
 						canDoLoadBalance = false
 						break
 					}
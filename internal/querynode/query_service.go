@@ -18,10 +18,10 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
-	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
 	"github.com/milvus-io/milvus/internal/storage"
@@ -42,6 +42,8 @@ type queryService struct {
 	localChunkManager  storage.ChunkManager
 	remoteChunkManager storage.ChunkManager
 	localCacheEnabled  bool
+
+	retrieveBudget *retrieveBudget
 }
 
 func newQueryService(ctx context.Context,
@@ -61,20 +63,10 @@ func newQueryService(ctx context.Context,
 
 	localChunkManager := storage.NewLocalChunkManager(path)
 
-	option := &miniokv.Option{
-		Address:           Params.MinioEndPoint,
-		AccessKeyID:       Params.MinioAccessKeyID,
-		SecretAccessKeyID: Params.MinioSecretAccessKey,
-		UseSSL:            Params.MinioUseSSLStr,
-		CreateBucket:      true,
-		BucketName:        Params.MinioBucketName,
-	}
-
-	client, err := miniokv.NewMinIOKV(ctx, option)
+	remoteChunkManager, err := newChunkManagerFactory().NewChunkManager(ctx)
 	if err != nil {
 		panic(err)
 	}
-	remoteChunkManager := storage.NewMinioChunkManager(client)
 
 	return &queryService{
 		ctx:    queryServiceCtx,
@@ -90,6 +82,8 @@ func newQueryService(ctx context.Context,
 		localChunkManager:  localChunkManager,
 		remoteChunkManager: remoteChunkManager,
 		localCacheEnabled:  localCacheEnabled,
+
+		retrieveBudget: newRetrieveBudget(Params.RetrieveMemoryBudget),
 	}
 }
 
@@ -106,6 +100,31 @@ func (q *queryService) close() {
 	q.cancel()
 }
 
+// drain stops all the queryCollections accepting new query messages, and waits at most timeout
+// for the in-flight query messages to be done. It returns false if timeout.
+func (q *queryService) drain(timeout time.Duration) bool {
+	q.queryCollectionMu.Lock()
+	collections := make([]*queryCollection, 0, len(q.queryCollections))
+	for _, qc := range q.queryCollections {
+		collections = append(collections, qc)
+	}
+	q.queryCollectionMu.Unlock()
+
+	for _, qc := range collections {
+		qc.stopAcceptingQuery()
+	}
+
+	deadline := time.Now().Add(timeout)
+	drained := true
+	for _, qc := range collections {
+		if !qc.waitInFlightQuery(time.Until(deadline)) {
+			drained = false
+		}
+	}
+	log.Debug("query service drained", zap.Bool("all in-flight queries done", drained))
+	return drained
+}
+
 func (q *queryService) addQueryCollection(collectionID UniqueID) error {
 	q.queryCollectionMu.Lock()
 	defer q.queryCollectionMu.Unlock()
@@ -124,6 +143,7 @@ func (q *queryService) addQueryCollection(collectionID UniqueID) error {
 		q.localChunkManager,
 		q.remoteChunkManager,
 		q.localCacheEnabled,
+		q.retrieveBudget,
 	)
 	if err != nil {
 		return err
@@ -16,6 +16,7 @@ import (
 	"encoding/binary"
 	"math"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -245,3 +246,29 @@ func TestQueryService_addQueryCollection(t *testing.T) {
 	qs.close()
 	assert.Len(t, qs.queryCollections, 0)
 }
+
+func TestQueryService_drain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	his, err := genSimpleHistorical(ctx)
+	assert.NoError(t, err)
+
+	str, err := genSimpleStreaming(ctx)
+	assert.NoError(t, err)
+
+	fac, err := genFactory()
+	assert.NoError(t, err)
+
+	qs := newQueryService(ctx, his, str, fac)
+	assert.NotNil(t, qs)
+	assert.True(t, qs.drain(time.Second))
+
+	err = qs.addQueryCollection(defaultCollectionID)
+	assert.NoError(t, err)
+	qc, err := qs.getQueryCollection(defaultCollectionID)
+	assert.NoError(t, err)
+
+	assert.True(t, qs.drain(time.Second))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&qc.stopped))
+}
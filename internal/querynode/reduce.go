@@ -28,6 +28,8 @@ import (
 
 type SearchResult struct {
 	cSearchResult C.CSearchResult
+	// rows of the segment when it's searched
+	rowCount int64
 }
 
 type MarshaledHits struct {
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// retrieveBudget admits the retrieve requests by the estimated size of their results, so that the results
+// assembled concurrently on the query node do not exceed the memory budget.
+type retrieveBudget struct {
+	capacity int64 // 0 means unlimited
+
+	mu       sync.Mutex // guards the fields below
+	used     int64
+	waiting  int
+	released chan struct{} // closed and replaced when some budget is released
+}
+
+func newRetrieveBudget(capacity int64) *retrieveBudget {
+	return &retrieveBudget{
+		capacity: capacity,
+		released: make(chan struct{}),
+	}
+}
+
+// acquire waits until the budget has room for size, or ctx is done. A request larger than the whole budget is
+// admitted when no other request is being executed, the per request limit is supposed to reject it earlier.
+func (b *retrieveBudget) acquire(ctx context.Context, size int64) error {
+	b.mu.Lock()
+	for b.capacity > 0 && b.used > 0 && b.used+size > b.capacity {
+		released := b.released
+		b.waiting++
+		b.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.waiting--
+			b.mu.Unlock()
+			return ctx.Err()
+		}
+
+		b.mu.Lock()
+		b.waiting--
+	}
+	b.used += size
+	b.mu.Unlock()
+	return nil
+}
+
+// release returns size acquired before to the budget, and wakes up the waiting requests.
+func (b *retrieveBudget) release(size int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= size
+	close(b.released)
+	b.released = make(chan struct{})
+}
+
+// stats returns the size in use and the number of the waiting requests.
+func (b *retrieveBudget) stats() (used int64, waiting int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used, b.waiting
+}
+
+// estimateRetrieveSizePerRecord returns the estimated size of a row of the output fields, all the fields are
+// output if outputFieldIDs is empty.
+func estimateRetrieveSizePerRecord(schema *schemapb.CollectionSchema, outputFieldIDs []int64) (int64, error) {
+	outputSchema := schema
+	if len(outputFieldIDs) > 0 {
+		outputs := make(map[int64]bool)
+		for _, id := range outputFieldIDs {
+			outputs[id] = true
+		}
+		outputSchema = &schemapb.CollectionSchema{}
+		for _, field := range schema.GetFields() {
+			if outputs[field.FieldID] {
+				outputSchema.Fields = append(outputSchema.Fields, field)
+			}
+		}
+	}
+	size, err := typeutil.EstimateSizePerRecord(outputSchema)
+	if err != nil {
+		return 0, err
+	}
+	return int64(size), nil
+}
+
+// estimateRetrieveResultSize returns the estimated size of the retrieve results after merged, the rows
+// duplicated in several results are counted more than once.
+func estimateRetrieveResultSize(results []*segcorepb.RetrieveResults, sizePerRecord int64) int64 {
+	var rows int64
+	for _, result := range results {
+		rows += int64(len(result.GetOffset()))
+	}
+	return rows * sizePerRecord
+}
+
+// limitSegmentRetrieveResult keeps the limit entities of the retrieve result of a segment with the smallest
+// primary keys, which include all the entities of the segment kept in the limited merged result.
+func limitSegmentRetrieveResult(result *segcorepb.RetrieveResults, limit int64) error {
+	ids := result.GetIds().GetIntId().GetData()
+	if limit <= 0 || int64(len(ids)) <= limit {
+		return nil
+	}
+	if len(result.Offset) != len(ids) {
+		return fmt.Errorf("mismatch offsets and ids in RetrieveResults, offsets = %d, ids = %d", len(result.Offset), len(ids))
+	}
+
+	indexes := make([]int, len(ids))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return ids[indexes[i]] < ids[indexes[j]]
+	})
+	indexes = indexes[:limit]
+
+	retIDs := make([]int64, 0, limit)
+	retOffset := make([]int64, 0, limit)
+	retFieldsData := typeutil.PrepareResultFieldData(result.FieldsData, limit)
+	for _, idx := range indexes {
+		retIDs = append(retIDs, ids[idx])
+		retOffset = append(retOffset, result.Offset[idx])
+		if err := typeutil.AppendFieldData(retFieldsData, result.FieldsData, int64(idx)); err != nil {
+			return err
+		}
+	}
+	result.Ids = &schemapb.IDs{
+		IdField: &schemapb.IDs_IntId{
+			IntId: &schemapb.LongArray{Data: retIDs},
+		},
+	}
+	result.Offset = retOffset
+	result.FieldsData = retFieldsData
+	return nil
+}
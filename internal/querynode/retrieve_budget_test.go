@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+)
+
+func TestRetrieveBudget_acquire(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		b := newRetrieveBudget(0)
+		assert.NoError(t, b.acquire(context.Background(), 1<<40))
+		assert.NoError(t, b.acquire(context.Background(), 1<<40))
+		used, waiting := b.stats()
+		assert.Equal(t, int64(2<<40), used)
+		assert.Equal(t, 0, waiting)
+	})
+
+	t.Run("larger than budget", func(t *testing.T) {
+		b := newRetrieveBudget(100)
+		assert.NoError(t, b.acquire(context.Background(), 200))
+		b.release(200)
+		used, _ := b.stats()
+		assert.Equal(t, int64(0), used)
+	})
+
+	t.Run("queue until released", func(t *testing.T) {
+		b := newRetrieveBudget(100)
+		require.NoError(t, b.acquire(context.Background(), 60))
+
+		acquired := make(chan error)
+		go func() {
+			acquired <- b.acquire(context.Background(), 60)
+		}()
+		assert.Eventually(t, func() bool {
+			_, waiting := b.stats()
+			return waiting == 1
+		}, time.Second, 10*time.Millisecond)
+		select {
+		case <-acquired:
+			t.Fatal("acquired without headroom")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		b.release(60)
+		assert.NoError(t, <-acquired)
+		used, waiting := b.stats()
+		assert.Equal(t, int64(60), used)
+		assert.Equal(t, 0, waiting)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		b := newRetrieveBudget(100)
+		require.NoError(t, b.acquire(context.Background(), 100))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, b.acquire(ctx, 1), context.DeadlineExceeded)
+		used, waiting := b.stats()
+		assert.Equal(t, int64(100), used)
+		assert.Equal(t, 0, waiting)
+	})
+}
+
+func TestRetrieveBudget_estimate(t *testing.T) {
+	schema := genSimpleSegCoreSchema()
+
+	all, err := estimateRetrieveSizePerRecord(schema, nil)
+	assert.NoError(t, err)
+	vec, err := estimateRetrieveSizePerRecord(schema, []int64{simpleVecField.id})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(simpleVecField.dim*4), vec)
+	assert.Greater(t, all, vec)
+
+	results := []*segcorepb.RetrieveResults{
+		{Offset: []int64{0, 1, 2}},
+		{Offset: []int64{5}},
+		{},
+	}
+	assert.Equal(t, 4*vec, estimateRetrieveResultSize(results, vec))
+}
+
+func TestRetrieveBudget_limitSegmentRetrieveResult(t *testing.T) {
+	result := &segcorepb.RetrieveResults{
+		Ids: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{5, 3, 9, 1}}},
+		},
+		Offset: []int64{0, 1, 2, 3},
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:    schemapb.DataType_Int64,
+				FieldId: simplePKField.id,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{5, 3, 9, 1}}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, limitSegmentRetrieveResult(result, 0))
+	assert.Equal(t, 4, len(result.Offset))
+
+	assert.NoError(t, limitSegmentRetrieveResult(result, 2))
+	assert.Equal(t, []int64{1, 3}, result.Ids.GetIntId().GetData())
+	assert.Equal(t, []int64{3, 1}, result.Offset)
+	assert.Equal(t, []int64{1, 3}, result.FieldsData[0].GetScalars().GetLongData().GetData())
+
+	result.Offset = result.Offset[:1]
+	assert.Error(t, limitSegmentRetrieveResult(result, 1))
+}
+
+func TestQueryCollection_retrieveResultTooLarge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queryCollection, err := genSimpleQueryCollection(ctx, cancel)
+	require.NoError(t, err)
+	vecCM, err := genVectorChunkManager(ctx)
+	require.NoError(t, err)
+	queryCollection.vectorChunkManager = vecCM
+
+	const largeNumRows = 20000
+	seg, err := genSealedSegment(genSimpleSegCoreSchema(),
+		genSimpleInsertDataSchema(),
+		defaultCollectionID,
+		defaultPartitionID,
+		defaultSegmentID+1,
+		defaultVChannel,
+		largeNumRows)
+	require.NoError(t, err)
+	require.NoError(t, queryCollection.historical.replica.setSegment(seg))
+
+	// the expression matches all the rows
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_UnaryRangeExpr{
+					UnaryRangeExpr: &planpb.UnaryRangeExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:  simpleConstField.id,
+							DataType: simpleConstField.dataType,
+						},
+						Op:    planpb.OpType_GreaterEqual,
+						Value: &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 0}},
+					},
+				},
+			},
+		},
+		OutputFieldIds: []int64{simpleVecField.id},
+	}
+	expr, err := proto.Marshal(planNode)
+	require.NoError(t, err)
+	msg, err := genSimpleRetrieveMsg()
+	require.NoError(t, err)
+	msg.SerializedExprPlan = expr
+
+	sizePerRecord := int64(simpleVecField.dim * 4)
+	maxResultSize := Params.RetrieveMaxResultSize
+	defer func() { Params.RetrieveMaxResultSize = maxResultSize }()
+	Params.RetrieveMaxResultSize = 1000 * sizePerRecord
+
+	_, err = queryCollection.retrieve(msg, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "result too large, add a limit")
+	used, waiting := queryCollection.retrieveBudget.stats()
+	assert.Equal(t, int64(0), used)
+	assert.Equal(t, 0, waiting)
+
+	msg.Limit = 100
+	result, err := queryCollection.retrieve(msg, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, len(result.Ids.GetIntId().GetData()))
+	used, _ = queryCollection.retrieveBudget.stats()
+	assert.Equal(t, int64(0), used)
+}
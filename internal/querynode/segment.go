@@ -25,8 +25,10 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/bits-and-blooms/bloom/v3"
@@ -55,6 +57,9 @@ const (
 	maxBloomFalsePositive float64 = 0.005
 )
 
+// segmentFilter tells whether the segment should be searched
+type segmentFilter func(segment *Segment) bool
+
 type VectorFieldInfo struct {
 	fieldBinlog *datapb.FieldBinlog
 }
@@ -75,6 +80,7 @@ type Segment struct {
 	collectionID UniqueID
 
 	onService bool
+	version   int64 // version of the assignment the sealed segment is loaded for
 
 	vChannelID   Channel
 	lastMemSize  int64
@@ -91,13 +97,27 @@ type Segment struct {
 
 	paramMutex sync.RWMutex // guards index
 	indexInfos map[FieldID]*indexInfo
+	// vector fields whose index is unavailable, they are searched by brute force on the raw data
+	unindexedFieldIDs []FieldID
 
 	idBinlogRowSizes []int64
 
 	vectorFieldMutex sync.RWMutex // guards vectorFieldInfos
 	vectorFieldInfos map[UniqueID]*VectorFieldInfo
 
-	pkFilter *bloom.BloomFilter //  bloom filter of pk inside a segment
+	pkFilter *bloom.BloomFilter //  bloom filter of pk inserted into a growing segment
+	minPK    int64              // min pk inside a segment
+	maxPK    int64              // max pk inside a segment
+	// bloom filters of pk loaded from statslogs, they're sized by the rows of each binlog and can't be merged
+	statsFilters []*bloom.BloomFilter
+	// pkStatsMissing is set if a sealed segment is loaded without pk statistics,
+	// every delete candidate is handed to segcore since the pk filter can't be trusted then.
+	pkStatsMissing bool
+
+	statistics segmentStatistics // search/query counters of the segment
+
+	loadCost   time.Duration // time spent on loading the segment, warm-up included
+	warmupCost time.Duration // time spent on warming up the segment after loading
 }
 
 //-------------------------------------------------------------------------------------- common interfaces
@@ -157,6 +177,14 @@ func (s *Segment) setOnService(onService bool) {
 	s.onService = onService
 }
 
+func (s *Segment) getVersion() int64 {
+	return s.version
+}
+
+func (s *Segment) setVersion(version int64) {
+	s.version = version
+}
+
 func (s *Segment) setVectorFieldInfo(fieldID UniqueID, info *VectorFieldInfo) {
 	s.vectorFieldMutex.Lock()
 	defer s.vectorFieldMutex.Unlock()
@@ -203,8 +231,11 @@ func newSegment(collection *Collection, segmentID UniqueID, partitionID UniqueID
 		onService:        onService,
 		indexInfos:       make(map[int64]*indexInfo),
 		vectorFieldInfos: make(map[UniqueID]*VectorFieldInfo),
+		statistics:       newSegmentStatistics(),
 
 		pkFilter: bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive),
+		minPK:    math.MaxInt64,
+		maxPK:    math.MinInt64,
 	}
 
 	return segment
@@ -299,7 +330,9 @@ func (s *Segment) search(plan *SearchPlan,
 	ts := C.uint64_t(timestamp[0])
 	cPlaceHolderGroup := cPlaceholderGroups[0]
 
-	log.Debug("do search on segment", zap.Int64("segmentID", s.segmentID), zap.Int32("segmentType", int32(s.segmentType)))
+	segType := s.getType()
+	log.Debug("do search on segment", zap.Int64("segmentID", s.segmentID), zap.Int32("segmentType", int32(segType)))
+	start := time.Now()
 	var status = C.Search(s.segmentPtr, plan.cSearchPlan, cPlaceHolderGroup, ts, &searchResult.cSearchResult)
 	errorCode := status.error_code
 	if errorCode != 0 {
@@ -307,6 +340,8 @@ func (s *Segment) search(plan *SearchPlan,
 		defer C.free(unsafe.Pointer(status.error_msg))
 		return nil, errors.New("Search failed, C runtime error detected, error code = " + strconv.Itoa(int(errorCode)) + ", error msg = " + errorMsg)
 	}
+	searchResult.rowCount = int64(C.GetRowCount(s.segmentPtr))
+	s.statistics.recordSearch(searchResult.rowCount, time.Since(start), segType == segmentTypeIndexing)
 
 	return &searchResult, nil
 }
@@ -317,12 +352,14 @@ func (s *Segment) getEntityByIds(plan *RetrievePlan) (*segcorepb.RetrieveResults
 	if s.segmentPtr == nil {
 		return nil, errors.New("null seg core pointer")
 	}
+	start := time.Now()
 	resProto := C.Retrieve(s.segmentPtr, plan.cRetrievePlan, C.uint64_t(plan.Timestamp))
 	result := new(segcorepb.RetrieveResults)
 	err := HandleCProtoResult(&resProto, result)
 	if err != nil {
 		return nil, err
 	}
+	s.statistics.recordRetrieve(int64(C.GetRowCount(s.segmentPtr)), time.Since(start))
 	return result, nil
 }
 
@@ -541,12 +578,78 @@ func (s *Segment) checkIndexReady(fieldID int64) bool {
 	return s.indexInfos[fieldID].getReadyLoad()
 }
 
+// setFieldUnindexed drops the index info of the vector field,
+// the field is then served by brute force search on its raw data.
+func (s *Segment) setFieldUnindexed(fieldID int64) {
+	s.paramMutex.Lock()
+	defer s.paramMutex.Unlock()
+	delete(s.indexInfos, fieldID)
+	for _, id := range s.unindexedFieldIDs {
+		if id == fieldID {
+			return
+		}
+	}
+	s.unindexedFieldIDs = append(s.unindexedFieldIDs, fieldID)
+}
+
+// getUnindexedFieldIDs returns the vector fields whose index failed to load
+func (s *Segment) getUnindexedFieldIDs() []FieldID {
+	s.paramMutex.RLock()
+	defer s.paramMutex.RUnlock()
+	ids := make([]FieldID, len(s.unindexedFieldIDs))
+	copy(ids, s.unindexedFieldIDs)
+	return ids
+}
+
+// updateBloomFilter adds pks into the pk statistics of the segment,
+// pk is encoded in the same way as the statslog written by data node.
 func (s *Segment) updateBloomFilter(pks []int64) {
 	buf := make([]byte, 8)
 	for _, pk := range pks {
-		binary.BigEndian.PutUint64(buf, uint64(pk))
+		binary.LittleEndian.PutUint64(buf, uint64(pk))
 		s.pkFilter.Add(buf)
+		if pk < s.minPK {
+			s.minPK = pk
+		}
+		if pk > s.maxPK {
+			s.maxPK = pk
+		}
+	}
+}
+
+// mergePKStats merges the pk statistics loaded from statslog into the segment
+func (s *Segment) mergePKStats(stats *storage.Int64Stats) error {
+	if stats.BF == nil {
+		return fmt.Errorf("nil bloom filter in pk statistics, segmentID = %d", s.segmentID)
+	}
+	s.statsFilters = append(s.statsFilters, stats.BF)
+	if stats.Min < s.minPK {
+		s.minPK = stats.Min
+	}
+	if stats.Max > s.maxPK {
+		s.maxPK = stats.Max
+	}
+	return nil
+}
+
+// mayContainPK returns whether pk may be inside the segment, false positive is possible while false negative is not
+func (s *Segment) mayContainPK(pk int64, buf []byte) bool {
+	if s.pkStatsMissing {
+		return true
+	}
+	if pk < s.minPK || pk > s.maxPK {
+		return false
+	}
+	binary.LittleEndian.PutUint64(buf, uint64(pk))
+	if s.pkFilter.Test(buf) {
+		return true
+	}
+	for _, filter := range s.statsFilters {
+		if filter.Test(buf) {
+			return true
+		}
 	}
+	return false
 }
 
 //-------------------------------------------------------------------------------------- interfaces for growing segment
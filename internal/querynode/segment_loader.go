@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"path"
 	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -25,11 +26,13 @@ import (
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	minioKV "github.com/milvus-io/milvus/internal/kv/minio"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
 )
 
 const (
@@ -45,6 +48,11 @@ type segmentLoader struct {
 	minioKV kv.DataKV // minio minioKV
 	etcdKV  *etcdkv.EtcdKV
 
+	// chunkCache caches the binlogs and index files read on local disk, it's nil if the chunk cache is disabled
+	chunkCache *storage.CachedChunkManager
+	// peerClients are the clients of the query nodes the binlogs of the balanced segments are fetched from
+	peerClients *shardclient.Manager
+
 	indexLoader *indexLoader
 }
 
@@ -77,6 +85,7 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest) error
 			return err
 		}
 		segment := newSegment(collection, segmentID, partitionID, collectionID, "", segmentTypeSealed, true)
+		segment.setVersion(info.Version)
 		newSegments[segmentID] = segment
 		fieldBinlog, indexedFieldID, err := loader.getFieldAndIndexInfo(segment, info)
 		if err != nil {
@@ -101,13 +110,20 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest) error
 	}
 
 	// start to load
+	var warmupDeadline time.Time
+	if Params.WarmupEnabled {
+		warmupDeadline = time.Now().Add(Params.WarmupTimeout)
+	}
 	for _, info := range req.Infos {
 		segmentID := info.SegmentID
 		if newSegments[segmentID] == nil || segmentFieldBinLogs[segmentID] == nil || segmentIndexedFieldIDs[segmentID] == nil {
 			segmentGC()
 			return errors.New(fmt.Sprintln("unexpected error, cannot find load infos, this error should not happen, collectionID = ", req.Infos[0].CollectionID))
 		}
+		start := time.Now()
+		binlogKV := loader.binlogKV(req, info, segmentFieldBinLogs[segmentID])
 		err = loader.loadSegmentInternal(newSegments[segmentID],
+			binlogKV,
 			segmentFieldBinLogs[segmentID],
 			segmentIndexedFieldIDs[segmentID],
 			info)
@@ -115,11 +131,27 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest) error
 			segmentGC()
 			return err
 		}
+		// warm up before the segment is set into replica, so that the segment serves no query until it's warm
+		if Params.WarmupEnabled {
+			collection, err := loader.historicalReplica.getCollectionByID(info.CollectionID)
+			if err != nil {
+				segmentGC()
+				return err
+			}
+			warmupSegment(collection, newSegments[segmentID], warmupDeadline)
+			// synthetic requests are not the workload of the segment
+			newSegments[segmentID].statistics.reset()
+		}
+		newSegments[segmentID].loadCost = time.Since(start)
+		log.Debug("load segment done",
+			zap.Int64("segmentID", segmentID),
+			zap.Duration("loadCost", newSegments[segmentID].loadCost),
+			zap.Duration("warmupCost", newSegments[segmentID].warmupCost))
 	}
 
 	// set segments
-	for _, s := range newSegments {
-		err := loader.historicalReplica.setSegment(s)
+	for _, info := range req.Infos {
+		err := loader.setSegmentWithBufferedDeletes(newSegments[info.SegmentID])
 		if err != nil {
 			segmentGC()
 			return err
@@ -128,12 +160,42 @@ func (loader *segmentLoader) loadSegment(req *querypb.LoadSegmentsRequest) error
 	return nil
 }
 
+// setSegmentWithBufferedDeletes applies the buffered deletes of the collection to the segment and sets it into the
+// historical replica. The deletes consumed after its deltalogs were dumped are applied before the segment serves
+// any query, the ones consumed afterwards are applied by the insert node.
+func (loader *segmentLoader) setSegmentWithBufferedDeletes(segment *Segment) error {
+	collection, err := loader.historicalReplica.getCollectionByID(segment.collectionID)
+	if err != nil {
+		return err
+	}
+	return collection.deleteBuffer.applyThen(segment.partitionID, func(pks []int64, tss []Timestamp) error {
+		if len(pks) > 0 {
+			pks, tss, err = filterSegmentsByPKs(pks, tss, segment)
+			if err != nil {
+				return err
+			}
+		}
+		if len(pks) > 0 {
+			offset := segment.segmentPreDelete(len(pks))
+			err = segment.segmentDelete(offset, &pks, &tss)
+			if err != nil {
+				return err
+			}
+			log.Debug("apply buffered deletes to segment",
+				zap.Int64("segmentID", segment.segmentID),
+				zap.Int("deletes", len(pks)))
+		}
+		return loader.historicalReplica.setSegment(segment)
+	})
+}
+
 func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
+	binlogKV kv.DataKV,
 	fieldBinLogs []*datapb.FieldBinlog,
 	indexFieldIDs []FieldID,
 	segmentLoadInfo *querypb.SegmentLoadInfo) error {
 	log.Debug("loading insert...")
-	err := loader.loadSegmentFieldsData(segment, fieldBinLogs)
+	err := loader.loadSegmentFieldsData(segment, binlogKV, fieldBinLogs)
 	if err != nil {
 		return err
 	}
@@ -147,14 +209,14 @@ func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
 	} else {
 		log.Debug("loading bloom filter...")
 		pkStatsBinlogs := loader.filterPKStatsBinlogs(segmentLoadInfo.Statslogs, pkIDField)
-		err = loader.loadSegmentBloomFilter(segment, pkStatsBinlogs)
+		err = loader.loadSegmentBloomFilter(segment, binlogKV, pkStatsBinlogs)
 		if err != nil {
 			return err
 		}
 	}
 
 	log.Debug("loading delta...")
-	err = loader.loadDeltaLogs(segment, segmentLoadInfo.Deltalogs)
+	err = loader.loadDeltaLogs(segment, binlogKV, segmentLoadInfo.Deltalogs)
 	if err != nil {
 		return err
 	}
@@ -163,13 +225,41 @@ func (loader *segmentLoader) loadSegmentInternal(segment *Segment,
 		log.Debug("loading index...")
 		err = loader.indexLoader.loadIndex(segment, id)
 		if err != nil {
-			return err
+			if err = loader.fallbackToBruteForce(segment, id, err); err != nil {
+				return err
+			}
+			// the raw data of the field was skipped since the index was expected to be loaded
+			vecFieldInfo, err := segment.getVectorFieldInfo(id)
+			if err != nil {
+				return err
+			}
+			err = loader.loadSegmentFieldsData(segment, binlogKV, []*datapb.FieldBinlog{vecFieldInfo.fieldBinlog})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// fallbackToBruteForce marks the vector field of the segment as unindexed after its index failed to load,
+// so that the field is served by brute force search on its raw data.
+// An error is returned instead if the query node is configured to load index strictly.
+func (loader *segmentLoader) fallbackToBruteForce(segment *Segment, fieldID FieldID, cause error) error {
+	if Params.LoadIndexStrict {
+		return fmt.Errorf("failed to load index, segmentID = %d, fieldID = %d, err = %s", segment.ID(), fieldID, cause)
+	}
+	log.Warn("failed to load index, fall back to brute force search",
+		zap.Int64("collectionID", segment.collectionID),
+		zap.Int64("segmentID", segment.ID()),
+		zap.Int64("fieldID", fieldID),
+		zap.Error(cause))
+	segment.setFieldUnindexed(fieldID)
+	metrics.QueryNodeIndexFallbackCounter.WithLabelValues(strconv.FormatInt(segment.collectionID, 10)).Inc()
+	return nil
+}
+
 func (loader *segmentLoader) filterPKStatsBinlogs(fieldBinlogs []*datapb.FieldBinlog, pkFieldID int64) []string {
 	result := make([]string, 0)
 	for _, fieldBinlog := range fieldBinlogs {
@@ -190,7 +280,7 @@ func (loader *segmentLoader) filterFieldBinlogs(fieldBinlogs []*datapb.FieldBinl
 	return result
 }
 
-func (loader *segmentLoader) loadSegmentFieldsData(segment *Segment, fieldBinlogs []*datapb.FieldBinlog) error {
+func (loader *segmentLoader) loadSegmentFieldsData(segment *Segment, binlogKV kv.DataKV, fieldBinlogs []*datapb.FieldBinlog) error {
 	iCodec := storage.InsertCodec{}
 	defer func() {
 		err := iCodec.Close()
@@ -207,7 +297,7 @@ func (loader *segmentLoader) loadSegmentFieldsData(segment *Segment, fieldBinlog
 		)
 		for _, path := range fb.Binlogs {
 			p := path
-			binLog, err := loader.minioKV.Load(path)
+			binLog, err := binlogKV.Load(path)
 			if err != nil {
 				// TODO: return or continue?
 				return err
@@ -280,13 +370,14 @@ func (loader *segmentLoader) loadSegmentFieldsData(segment *Segment, fieldBinlog
 	return nil
 }
 
-func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogPaths []string) error {
+func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogKV kv.DataKV, binlogPaths []string) error {
 	if len(binlogPaths) == 0 {
 		log.Info("there are no stats logs saved with segment", zap.Any("segmentID", segment.segmentID))
+		segment.pkStatsMissing = true
 		return nil
 	}
 
-	values, err := loader.minioKV.MultiLoad(binlogPaths)
+	values, err := binlogKV.MultiLoad(binlogPaths)
 	if err != nil {
 		return err
 	}
@@ -302,9 +393,10 @@ func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogPath
 	for _, stat := range stats {
 		if stat.BF == nil {
 			log.Warn("stat log with nil bloom filter", zap.Int64("segmentID", segment.segmentID), zap.Any("stat", stat))
+			segment.pkStatsMissing = true
 			continue
 		}
-		err = segment.pkFilter.Merge(stat.BF)
+		err = segment.mergePKStats(stat)
 		if err != nil {
 			return err
 		}
@@ -312,15 +404,15 @@ func (loader *segmentLoader) loadSegmentBloomFilter(segment *Segment, binlogPath
 	return nil
 }
 
-func (loader *segmentLoader) loadDeltaLogs(segment *Segment, deltaLogs []*datapb.DeltaLogInfo) error {
+func (loader *segmentLoader) loadDeltaLogs(segment *Segment, binlogKV kv.DataKV, deltaLogs []*datapb.DeltaLogInfo) error {
 	if len(deltaLogs) == 0 {
 		log.Info("there are no delta logs saved with segment", zap.Any("segmentID", segment.segmentID))
 		return nil
 	}
-	dCodec := storage.DeleteCodec{}
-	blobs := make([]*storage.Blob, 0)
+	pks := make([]int64, 0)
+	tss := make([]Timestamp, 0)
 	for _, deltaLog := range deltaLogs {
-		value, err := loader.minioKV.Load(deltaLog.DeltaLogPath)
+		value, err := binlogKV.Load(deltaLog.DeltaLogPath)
 		if err != nil {
 			return err
 		}
@@ -328,21 +420,23 @@ func (loader *segmentLoader) loadDeltaLogs(segment *Segment, deltaLogs []*datapb
 			Key:   deltaLog.DeltaLogPath,
 			Value: []byte(value),
 		}
-		blobs = append(blobs, blob)
+		// deserialize the deltalogs one by one, a primary key deleted more than once, e.g. deleted again
+		// after being inserted again, has a delete record in each of the deltalogs
+		dCodec := storage.DeleteCodec{}
+		_, _, deltaData, err := dCodec.Deserialize([]*storage.Blob{blob})
+		if err != nil {
+			return err
+		}
+		for pk, ts := range deltaData.Data {
+			pks = append(pks, pk)
+			tss = append(tss, Timestamp(ts))
+		}
 	}
-	_, _, deltaData, err := dCodec.Deserialize(blobs)
-	if err != nil {
-		return err
+	if len(pks) == 0 {
+		return nil
 	}
 
-	rowCount := len(deltaData.Data)
-	pks := make([]int64, 0)
-	tss := make([]Timestamp, 0)
-	for pk, ts := range deltaData.Data {
-		pks = append(pks, pk)
-		tss = append(tss, Timestamp(ts))
-	}
-	err = segment.segmentLoadDeletedRecord(pks, tss, int64(rowCount))
+	err := segment.segmentLoadDeletedRecord(pks, tss, int64(len(pks)))
 	if err != nil {
 		return err
 	}
@@ -380,8 +474,20 @@ func (loader *segmentLoader) getFieldAndIndexInfo(segment *Segment,
 	indexedFieldIDs := make([]FieldID, 0)
 	for _, vecFieldID := range vectorFieldIDs {
 		err = loader.indexLoader.setIndexInfo(collectionID, segment, vecFieldID)
+		if err == errSegmentNotIndexed {
+			log.Debug("vector field is not indexed",
+				zap.Int64("segmentID", segment.ID()),
+				zap.Int64("fieldID", vecFieldID))
+			continue
+		}
+		if err == nil {
+			// make sure the index files are readable before the raw data is skipped
+			_, err = loader.indexLoader.estimateIndexBinlogSize(segment, vecFieldID)
+		}
 		if err != nil {
-			log.Warn(err.Error())
+			if err = loader.fallbackToBruteForce(segment, vecFieldID, err); err != nil {
+				return nil, nil, err
+			}
 			continue
 		}
 		indexedFieldIDs = append(indexedFieldIDs, vecFieldID)
@@ -453,7 +559,7 @@ func (loader *segmentLoader) checkSegmentSize(collectionID UniqueID, segmentSize
 			zap.Any("thresholdFactor", thresholdFactor),
 		)
 		if int64(usedMem)+segmentTotalSize+size > int64(float64(totalMem)*thresholdFactor) {
-			return errors.New(fmt.Sprintln("load segment failed, OOM if load, "+
+			return fmt.Errorf("%w, %s", errOutOfMemory, fmt.Sprintln(
 				"collectionID = ", collectionID, ", ",
 				"usedMem = ", usedMem, ", ",
 				"segmentTotalSize = ", segmentTotalSize, ", ",
@@ -467,7 +573,8 @@ func (loader *segmentLoader) checkSegmentSize(collectionID UniqueID, segmentSize
 	return nil
 }
 
-func newSegmentLoader(ctx context.Context, rootCoord types.RootCoord, indexCoord types.IndexCoord, replica ReplicaInterface, etcdKV *etcdkv.EtcdKV) *segmentLoader {
+// newChunkManagerFactory creates the factory of the storage of binlogs and index files by the storage type.
+func newChunkManagerFactory() *storage.ChunkManagerFactory {
 	option := &minioKV.Option{
 		Address:           Params.MinioEndPoint,
 		AccessKeyID:       Params.MinioAccessKeyID,
@@ -476,19 +583,43 @@ func newSegmentLoader(ctx context.Context, rootCoord types.RootCoord, indexCoord
 		CreateBucket:      true,
 		BucketName:        Params.MinioBucketName,
 	}
+	return storage.NewChunkManagerFactory(Params.StorageType, Params.LocalStoragePath, Params.LocalStorageSyncOnWrite, option)
+}
 
-	client, err := minioKV.NewMinIOKV(ctx, option)
+// newStorageKV creates the kv to read binlogs and index files, the files are cached on local disk if the chunk cache
+// is enabled, the cache is returned as well.
+func newStorageKV(ctx context.Context) (kv.DataKV, *storage.CachedChunkManager, error) {
+	factory := newChunkManagerFactory()
+	if !Params.ChunkCacheEnabled {
+		dataKV, err := factory.NewDataKV(ctx)
+		return dataKV, nil, err
+	}
+	cm, err := factory.NewChunkManager(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cachedCM, err := storage.NewCachedChunkManager(cm, Params.ChunkCachePath, Params.ChunkCacheMaxSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return storage.NewChunkManagerKV(cachedCM), cachedCM, nil
+}
+
+func newSegmentLoader(ctx context.Context, rootCoord types.RootCoord, indexCoord types.IndexCoord, replica ReplicaInterface, etcdKV *etcdkv.EtcdKV) *segmentLoader {
+	client, chunkCache, err := newStorageKV(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	iLoader := newIndexLoader(ctx, rootCoord, indexCoord, replica)
+	iLoader := newIndexLoader(ctx, rootCoord, indexCoord, replica, client)
 	return &segmentLoader{
 		historicalReplica: replica,
 
 		minioKV: client,
 		etcdKV:  etcdKV,
 
+		chunkCache: chunkCache,
+
 		indexLoader: iLoader,
 	}
 }
@@ -13,15 +13,22 @@ package querynode
 
 import (
 	"context"
+	"errors"
 	"math/rand"
+	"path"
+	"sort"
+	"strconv"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
 )
 
 func TestSegmentLoader_loadSegment(t *testing.T) {
@@ -165,7 +172,7 @@ func TestSegmentLoader_loadSegmentFieldsData(t *testing.T) {
 		binlog, err := saveBinLog(ctx, defaultCollectionID, defaultPartitionID, defaultSegmentID, defaultMsgLength, schema)
 		assert.NoError(t, err)
 
-		err = historical.loader.loadSegmentFieldsData(segment, binlog)
+		err = historical.loader.loadSegmentFieldsData(segment, historical.loader.minioKV, binlog)
 		assert.NoError(t, err)
 	}
 
@@ -297,6 +304,9 @@ func TestSegmentLoader_checkSegmentSize(t *testing.T) {
 	err = historical.loader.checkSegmentSize(defaultSegmentID, map[UniqueID]int64{defaultSegmentID: 1024})
 	assert.NoError(t, err)
 
+	err = historical.loader.checkSegmentSize(defaultSegmentID, map[UniqueID]int64{defaultSegmentID: 1 << 60})
+	assert.True(t, errors.Is(err, errOutOfMemory))
+
 	//totalMem, err := getTotalMemory()
 	//assert.NoError(t, err)
 	//err = historical.loader.checkSegmentSize(defaultSegmentID, map[UniqueID]int64{defaultSegmentID: int64(totalMem * 2)})
@@ -347,3 +357,238 @@ func TestSegmentLoader_estimateSegmentSize(t *testing.T) {
 	_, err = historical.loader.estimateSegmentSize(seg, binlog, []FieldID{simpleVecField.id})
 	assert.Error(t, err)
 }
+
+func TestSegmentLoader_getFieldAndIndexInfo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fieldBinlog, err := saveSimpleBinLog(ctx)
+	assert.NoError(t, err)
+
+	genLoadInfo := func() *querypb.SegmentLoadInfo {
+		return &querypb.SegmentLoadInfo{
+			SegmentID:    defaultSegmentID,
+			PartitionID:  defaultPartitionID,
+			CollectionID: defaultCollectionID,
+			BinlogPaths:  fieldBinlog,
+		}
+	}
+
+	t.Run("test indexed", func(t *testing.T) {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+		historical.loader.indexLoader.rootCoord = newMockRootCoord()
+		historical.loader.indexLoader.indexCoord = newMockIndexCoord()
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		binlogs, indexedFieldIDs, err := historical.loader.getFieldAndIndexInfo(segment, genLoadInfo())
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldID{simpleVecField.id}, indexedFieldIDs)
+		assert.Equal(t, len(fieldBinlog)-1, len(binlogs))
+		assert.Empty(t, segment.getUnindexedFieldIDs())
+	})
+
+	t.Run("test not indexed", func(t *testing.T) {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+		rc := newMockRootCoord()
+		rc.disableIndex = true
+		historical.loader.indexLoader.rootCoord = rc
+		historical.loader.indexLoader.indexCoord = newMockIndexCoord()
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		binlogs, indexedFieldIDs, err := historical.loader.getFieldAndIndexInfo(segment, genLoadInfo())
+		assert.NoError(t, err)
+		assert.Empty(t, indexedFieldIDs)
+		assert.Equal(t, len(fieldBinlog), len(binlogs))
+		assert.Empty(t, segment.getUnindexedFieldIDs())
+	})
+
+	t.Run("test index files missing", func(t *testing.T) {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+		ic := newMockIndexCoord()
+		ic.filePaths = []string{"&*^*(^*(&*%^&*^(&"}
+		historical.loader.indexLoader.rootCoord = newMockRootCoord()
+		historical.loader.indexLoader.indexCoord = ic
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		binlogs, indexedFieldIDs, err := historical.loader.getFieldAndIndexInfo(segment, genLoadInfo())
+		assert.NoError(t, err)
+		assert.Empty(t, indexedFieldIDs)
+		assert.Equal(t, len(fieldBinlog), len(binlogs))
+		assert.Equal(t, []FieldID{simpleVecField.id}, segment.getUnindexedFieldIDs())
+		assert.False(t, segment.checkIndexReady(simpleVecField.id))
+	})
+
+	t.Run("test index build failed", func(t *testing.T) {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+		ic := newMockIndexCoord()
+		ic.statusFailed = true
+		historical.loader.indexLoader.rootCoord = newMockRootCoord()
+		historical.loader.indexLoader.indexCoord = ic
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		binlogs, indexedFieldIDs, err := historical.loader.getFieldAndIndexInfo(segment, genLoadInfo())
+		assert.NoError(t, err)
+		assert.Empty(t, indexedFieldIDs)
+		assert.Equal(t, len(fieldBinlog), len(binlogs))
+		assert.Equal(t, []FieldID{simpleVecField.id}, segment.getUnindexedFieldIDs())
+	})
+
+	t.Run("test strict", func(t *testing.T) {
+		Params.LoadIndexStrict = true
+		defer func() {
+			Params.LoadIndexStrict = false
+		}()
+
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+		ic := newMockIndexCoord()
+		ic.filePaths = []string{"&*^*(^*(&*%^&*^(&"}
+		historical.loader.indexLoader.rootCoord = newMockRootCoord()
+		historical.loader.indexLoader.indexCoord = ic
+
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		_, _, err = historical.loader.getFieldAndIndexInfo(segment, genLoadInfo())
+		assert.Error(t, err)
+		assert.Empty(t, segment.getUnindexedFieldIDs())
+	})
+}
+
+func TestSegmentLoader_loadSegmentBloomFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	historical, err := genSimpleHistorical(ctx)
+	assert.NoError(t, err)
+
+	kv, err := genEtcdKV()
+	assert.NoError(t, err)
+	loader := newSegmentLoader(ctx, nil, nil, historical.replica, kv)
+	assert.NotNil(t, loader)
+
+	segment, err := historical.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+	assert.False(t, segment.pkStatsMissing)
+
+	err = loader.loadSegmentBloomFilter(segment, loader.minioKV, nil)
+	assert.NoError(t, err)
+	assert.True(t, segment.pkStatsMissing)
+}
+
+func TestSegmentLoader_loadSegmentWithDeletes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kv, err := genEtcdKV()
+	assert.NoError(t, err)
+	minioKV, err := genMinioKV(ctx)
+	assert.NoError(t, err)
+
+	schema := genSimpleInsertDataSchema()
+	schema.Fields = append(schema.Fields, genPKField(simplePKField))
+	// row i of the segment has pk i and is inserted at timestamp i
+	fieldBinlog, err := saveBinLog(ctx, defaultCollectionID, defaultPartitionID, defaultSegmentID, defaultMsgLength, schema)
+	assert.NoError(t, err)
+
+	// pk 10 and pk 11 are deleted at timestamp 50 and 52, in two deltalogs
+	deltaLogs := make([]*datapb.DeltaLogInfo, 0)
+	for i, deleted := range []map[int64]int64{{10: 50}, {11: 52}} {
+		dCodec := storage.NewDeleteCodec()
+		blob, err := dCodec.Serialize(defaultCollectionID, defaultPartitionID, defaultSegmentID, &storage.DeleteData{Data: deleted})
+		assert.NoError(t, err)
+		key := path.Join(defaultKVRootPath, "delta", strconv.Itoa(i))
+		err = minioKV.Save(key, string(blob.Value))
+		assert.NoError(t, err)
+		deltaLogs = append(deltaLogs, &datapb.DeltaLogInfo{DeltaLogPath: key})
+	}
+
+	historical, err := genSimpleHistorical(ctx)
+	assert.NoError(t, err)
+	err = historical.replica.removeSegment(defaultSegmentID)
+	assert.NoError(t, err)
+	loader := newSegmentLoader(ctx, nil, nil, historical.replica, kv)
+
+	// pk 20 is deleted at timestamp 60 after the deltalogs were dumped, it's consumed before the segment is loaded
+	collection, err := historical.replica.getCollectionByID(defaultCollectionID)
+	assert.NoError(t, err)
+	collection.deleteBuffer.add(defaultPartitionID, []int64{20}, []Timestamp{60})
+	// the deletes of the other partitions are not applied
+	collection.deleteBuffer.add(defaultPartitionID+1, []int64{30}, []Timestamp{60})
+
+	req := &querypb.LoadSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadSegments,
+			MsgID:   rand.Int63(),
+		},
+		Schema:        schema,
+		LoadCondition: querypb.TriggerCondition_grpcRequest,
+		Infos: []*querypb.SegmentLoadInfo{
+			{
+				SegmentID:    defaultSegmentID,
+				PartitionID:  defaultPartitionID,
+				CollectionID: defaultCollectionID,
+				BinlogPaths:  fieldBinlog,
+				Deltalogs:    deltaLogs,
+			},
+		},
+	}
+	err = loader.loadSegment(req)
+	assert.NoError(t, err)
+
+	segment, err := historical.replica.getSegmentByID(defaultSegmentID)
+	assert.NoError(t, err)
+
+	retrievePKs := func(ts Timestamp) []int64 {
+		values := make([]*planpb.GenericValue, 0)
+		for _, pk := range []int64{10, 11, 20, 30} {
+			values = append(values, &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: pk}})
+		}
+		planNode := &planpb.PlanNode{
+			Node: &planpb.PlanNode_Predicates{
+				Predicates: &planpb.Expr{
+					Expr: &planpb.Expr_TermExpr{
+						TermExpr: &planpb.TermExpr{
+							ColumnInfo: &planpb.ColumnInfo{
+								FieldId:  simplePKField.id,
+								DataType: simplePKField.dataType,
+							},
+							Values: values,
+						},
+					},
+				},
+			},
+			OutputFieldIds: []int64{simplePKField.id},
+		}
+		expr, err := proto.Marshal(planNode)
+		assert.NoError(t, err)
+		plan, err := createRetrievePlanByExpr(collection, expr, ts)
+		assert.NoError(t, err)
+		defer plan.delete()
+		res, err := segment.getEntityByIds(plan)
+		assert.NoError(t, err)
+		pks := res.GetIds().GetIntId().GetData()
+		sort.Slice(pks, func(i, j int) bool { return pks[i] < pks[j] })
+		return pks
+	}
+
+	// a row deleted at ts is still visible to the queries at ts, and invisible to the later ones
+	assert.Equal(t, []int64{10, 11, 20, 30}, retrievePKs(50))
+	assert.Equal(t, []int64{11, 20, 30}, retrievePKs(51))
+	assert.Equal(t, []int64{11, 20, 30}, retrievePKs(52))
+	assert.Equal(t, []int64{20, 30}, retrievePKs(53))
+	assert.Equal(t, []int64{20, 30}, retrievePKs(60))
+	assert.Equal(t, []int64{30}, retrievePKs(61))
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+// segmentStatistics records the search/query counters of a segment.
+// All the counters are updated by atomic operations, so that the search path never waits for a lock.
+type segmentStatistics struct {
+	searchCount     int64
+	retrieveCount   int64
+	rowsScanned     int64
+	totalLatency    int64 // in nanoseconds
+	indexHitCount   int64
+	bruteForceCount int64
+	deleteCount     int64 // number of pks handed to segcore for deletion, bloom filter false positives included
+	lastAccessTime  int64 // unix nanoseconds of the last search/retrieve, not cleared by reset
+}
+
+// newSegmentStatistics creates the statistics of a segment created just now
+func newSegmentStatistics() segmentStatistics {
+	return segmentStatistics{lastAccessTime: time.Now().UnixNano()}
+}
+
+// recordSearch records a search served by the segment
+func (s *segmentStatistics) recordSearch(rows int64, latency time.Duration, indexHit bool) {
+	atomic.StoreInt64(&s.lastAccessTime, time.Now().UnixNano())
+	atomic.AddInt64(&s.searchCount, 1)
+	if rows > 0 {
+		atomic.AddInt64(&s.rowsScanned, rows)
+	}
+	atomic.AddInt64(&s.totalLatency, int64(latency))
+	if indexHit {
+		atomic.AddInt64(&s.indexHitCount, 1)
+	} else {
+		atomic.AddInt64(&s.bruteForceCount, 1)
+	}
+}
+
+// recordRetrieve records a retrieval served by the segment
+func (s *segmentStatistics) recordRetrieve(rows int64, latency time.Duration) {
+	atomic.StoreInt64(&s.lastAccessTime, time.Now().UnixNano())
+	atomic.AddInt64(&s.retrieveCount, 1)
+	if rows > 0 {
+		atomic.AddInt64(&s.rowsScanned, rows)
+	}
+	atomic.AddInt64(&s.totalLatency, int64(latency))
+}
+
+// recordDelete records the deletion of rows applied to the segment
+func (s *segmentStatistics) recordDelete(rows int64) {
+	atomic.AddInt64(&s.deleteCount, rows)
+}
+
+// reset sets all the counters to zero, the last access time is kept
+func (s *segmentStatistics) reset() {
+	atomic.StoreInt64(&s.searchCount, 0)
+	atomic.StoreInt64(&s.retrieveCount, 0)
+	atomic.StoreInt64(&s.rowsScanned, 0)
+	atomic.StoreInt64(&s.totalLatency, 0)
+	atomic.StoreInt64(&s.indexHitCount, 0)
+	atomic.StoreInt64(&s.bruteForceCount, 0)
+	atomic.StoreInt64(&s.deleteCount, 0)
+}
+
+// snapshot returns the current counters of the segment
+func (s *segmentStatistics) snapshot() metricsinfo.SegmentStatistics {
+	searchCount := atomic.LoadInt64(&s.searchCount)
+	retrieveCount := atomic.LoadInt64(&s.retrieveCount)
+	totalLatency := atomic.LoadInt64(&s.totalLatency)
+	stat := metricsinfo.SegmentStatistics{
+		SearchCount:      searchCount,
+		RetrieveCount:    retrieveCount,
+		RowsScanned:      atomic.LoadInt64(&s.rowsScanned),
+		TotalLatencyInMs: time.Duration(totalLatency).Milliseconds(),
+		IndexHitCount:    atomic.LoadInt64(&s.indexHitCount),
+		BruteForceCount:  atomic.LoadInt64(&s.bruteForceCount),
+		DeleteCount:      atomic.LoadInt64(&s.deleteCount),
+		IdleInMs:         time.Since(time.Unix(0, atomic.LoadInt64(&s.lastAccessTime))).Milliseconds(),
+	}
+	if total := searchCount + retrieveCount; total > 0 {
+		stat.AvgLatencyInMs = time.Duration(totalLatency / total).Milliseconds()
+	}
+	return stat
+}
+
+func (t segmentType) String() string {
+	switch t {
+	case segmentTypeGrowing:
+		return "Growing"
+	case segmentTypeSealed:
+		return "Sealed"
+	case segmentTypeIndexing:
+		return "Indexing"
+	default:
+		return "Invalid"
+	}
+}
+
+// getSegmentStatistics returns the search/query statistics of all the segments in replica,
+// the counters would be reset after being collected if reset is true.
+func getSegmentStatistics(replica ReplicaInterface, reset bool) []metricsinfo.SegmentStatistics {
+	ret := make([]metricsinfo.SegmentStatistics, 0)
+	for _, segment := range replica.getSegments() {
+		stat := segment.statistics.snapshot()
+		if reset {
+			segment.statistics.reset()
+		}
+		stat.SegmentID = segment.segmentID
+		stat.CollectionID = segment.collectionID
+		stat.PartitionID = segment.partitionID
+		stat.SegmentType = segment.getType().String()
+		stat.LoadCostInMs = segment.loadCost.Milliseconds()
+		stat.WarmupCostInMs = segment.warmupCost.Milliseconds()
+		ret = append(ret, stat)
+	}
+	return ret
+}
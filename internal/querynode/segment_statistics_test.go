@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+func TestSegmentStatistics_Counters(t *testing.T) {
+	stats := &segmentStatistics{}
+	stats.recordSearch(100, 2*time.Millisecond, true)
+	stats.recordSearch(100, 4*time.Millisecond, false)
+	stats.recordRetrieve(100, 6*time.Millisecond)
+	stats.recordDelete(10)
+
+	snapshot := stats.snapshot()
+	assert.Equal(t, int64(2), snapshot.SearchCount)
+	assert.Equal(t, int64(1), snapshot.RetrieveCount)
+	assert.Equal(t, int64(300), snapshot.RowsScanned)
+	assert.Equal(t, int64(12), snapshot.TotalLatencyInMs)
+	assert.Equal(t, int64(4), snapshot.AvgLatencyInMs)
+	assert.Equal(t, int64(1), snapshot.IndexHitCount)
+	assert.Equal(t, int64(1), snapshot.BruteForceCount)
+	assert.Equal(t, int64(10), snapshot.DeleteCount)
+
+	stats.reset()
+	snapshot = stats.snapshot()
+	snapshot.IdleInMs = 0
+	assert.Equal(t, metricsinfo.SegmentStatistics{}, snapshot)
+}
+
+func TestSegmentStatistics_Idle(t *testing.T) {
+	stats := newSegmentStatistics()
+	stats.lastAccessTime = time.Now().Add(-time.Hour).UnixNano()
+	assert.GreaterOrEqual(t, stats.snapshot().IdleInMs, time.Hour.Milliseconds())
+
+	stats.recordRetrieve(1, time.Millisecond)
+	assert.Less(t, stats.snapshot().IdleInMs, time.Minute.Milliseconds())
+
+	stats.lastAccessTime = time.Now().Add(-time.Hour).UnixNano()
+	stats.recordSearch(1, time.Millisecond, true)
+	stats.reset()
+	assert.Less(t, stats.snapshot().IdleInMs, time.Minute.Milliseconds())
+}
+
+func TestSegmentStatistics_SearchMultiSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	his, err := genSimpleHistorical(ctx)
+	assert.NoError(t, err)
+
+	schema := genSimpleSegCoreSchema()
+	schema2 := genSimpleInsertDataSchema()
+	secondSegmentID := defaultSegmentID + 1
+	seg, err := genSealedSegment(schema,
+		schema2,
+		defaultCollectionID,
+		defaultPartitionID,
+		secondSegmentID,
+		defaultVChannel,
+		defaultMsgLength)
+	assert.NoError(t, err)
+	err = his.replica.setSegment(seg)
+	assert.NoError(t, err)
+
+	plan, searchReqs, err := genSimpleSearchPlanAndRequests()
+	assert.NoError(t, err)
+
+	searchTimes := 3
+	for i := 0; i < searchTimes; i++ {
+		_, segIDs, err := his.search(searchReqs, defaultCollectionID, []UniqueID{defaultPartitionID}, plan, Timestamp(0), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(segIDs))
+	}
+
+	stats := getSegmentStatistics(his.replica, false)
+	assert.Equal(t, 2, len(stats))
+	for _, stat := range stats {
+		assert.Equal(t, defaultCollectionID, stat.CollectionID)
+		assert.Equal(t, defaultPartitionID, stat.PartitionID)
+		assert.Equal(t, int64(searchTimes), stat.SearchCount)
+		assert.Equal(t, int64(searchTimes*defaultMsgLength), stat.RowsScanned)
+		assert.Equal(t, int64(searchTimes), stat.BruteForceCount)
+		assert.Equal(t, int64(0), stat.IndexHitCount)
+	}
+
+	// collect and reset
+	stats = getSegmentStatistics(his.replica, true)
+	assert.Equal(t, 2, len(stats))
+	stats = getSegmentStatistics(his.replica, false)
+	for _, stat := range stats {
+		assert.Equal(t, int64(0), stat.SearchCount)
+		assert.Equal(t, int64(0), stat.RowsScanned)
+	}
+}
+
+func TestGetSegmentStatisticsMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, err := genSimpleQueryNode(ctx)
+	assert.NoError(t, err)
+
+	req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.SegmentStatisticsMetrics)
+	assert.NoError(t, err)
+	resp, err := getSegmentStatisticsMetrics(ctx, req, node)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+
+	var nodeStatistics metricsinfo.QueryNodeSegmentStatistics
+	err = metricsinfo.UnmarshalComponentInfos(resp.Response, &nodeStatistics)
+	assert.NoError(t, err)
+	assert.Equal(t, Params.QueryNodeID, nodeStatistics.ID)
+
+	resp, err = node.GetMetrics(ctx, &milvuspb.GetMetricsRequest{Request: req.Request})
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+}
@@ -16,18 +16,22 @@ import (
 	"encoding/binary"
 	"log"
 	"math"
+	"strconv"
 	"sync"
 	"testing"
 
+	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/milvus-io/milvus/internal/indexnode"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/storage"
 )
 
 //-------------------------------------------------------------------------------------- constructor and destructor
@@ -1259,3 +1263,239 @@ func TestSegment_dropSegmentIndex(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSegment_pkStats(t *testing.T) {
+	schema := genSimpleSegCoreSchema()
+	collection := newCollection(defaultCollectionID, schema)
+	defer deleteCollection(collection)
+	segment := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultVChannel, segmentTypeSealed, true)
+	defer deleteSegment(segment)
+
+	buf := make([]byte, 8)
+	assert.False(t, segment.mayContainPK(0, buf))
+
+	sw := &storage.StatsWriter{}
+	err := sw.StatsInt64(simplePKField.id, true, []int64{30, 10, 20})
+	assert.NoError(t, err)
+	stats, err := storage.DeserializeStats([]*storage.Blob{{Value: sw.GetBuffer()}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(stats))
+	err = segment.mergePKStats(stats[0])
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(10), segment.minPK)
+	assert.Equal(t, int64(30), segment.maxPK)
+	for _, pk := range []int64{10, 20, 30} {
+		assert.True(t, segment.mayContainPK(pk, buf))
+	}
+	assert.False(t, segment.mayContainPK(9, buf))
+	assert.False(t, segment.mayContainPK(31, buf))
+
+	// pks inserted into growing segment are encoded the same as statslog
+	segment.updateBloomFilter([]int64{40})
+	assert.Equal(t, int64(40), segment.maxPK)
+	assert.True(t, segment.mayContainPK(40, buf))
+
+	err = segment.mergePKStats(&storage.Int64Stats{Min: 0, Max: 1})
+	assert.Error(t, err)
+
+	// the filters sized differently are kept apart
+	err = segment.mergePKStats(&storage.Int64Stats{Min: 50, Max: 50, BF: bloom.New(10, 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(segment.statsFilters))
+	assert.True(t, segment.mayContainPK(20, buf))
+
+	segment.pkStatsMissing = true
+	assert.True(t, segment.mayContainPK(1000, buf))
+}
+
+func TestSegment_searchBinaryMetrics(t *testing.T) {
+	const dim = 16
+	rowIDs := []int64{10, 11, 12, 13}
+	vectors := []byte{
+		0xFF, 0xFF,
+		0xFF, 0x00,
+		0x0F, 0x00,
+		0xF0, 0x0F,
+	}
+	query := []byte{0xFF, 0x00}
+
+	// the distances between query and the matched vectors, the structure metrics only hit the matched vectors
+	type metricCase struct {
+		metricType string
+		distances  map[int64]float32
+	}
+	hamming := metricCase{"HAMMING", map[int64]float32{10: 8, 11: 0, 12: 4, 13: 8}}
+	jaccard := metricCase{"JACCARD", map[int64]float32{10: 0.5, 11: 0, 12: 0.5, 13: float32(2) / 3}}
+	tanimoto := metricCase{"TANIMOTO", map[int64]float32{10: 1, 11: 0, 12: 1, 13: float32(math.Log2(3))}}
+	substructure := metricCase{"SUBSTRUCTURE", map[int64]float32{10: 0, 11: 0}}
+	superstructure := metricCase{"SUPERSTRUCTURE", map[int64]float32{11: 0, 12: 0}}
+	allMetrics := []metricCase{hamming, jaccard, tanimoto, substructure, superstructure}
+
+	// the dimension of the binary vectors is 8 times of the given one
+	schema := genTestCollectionSchema(defaultCollectionID, true, dim/8)
+
+	search := func(t *testing.T, collection *Collection, segment *Segment, metricType string) map[int64]float32 {
+		dslString := "{\"bool\": { \n\"vector\": {\n \"vec\": {\n \"metric_type\": \"" + metricType + "\", \n \"params\": {\n \"nprobe\": 1 \n},\n \"query\": \"$0\",\n \"topk\": 4 \n,\"round_decimal\": 6\n } \n } \n } \n }"
+		placeholderGroup := milvuspb.PlaceholderGroup{
+			Placeholders: []*milvuspb.PlaceholderValue{
+				{
+					Tag:    "$0",
+					Type:   milvuspb.PlaceholderType_BinaryVector,
+					Values: [][]byte{query},
+				},
+			},
+		}
+		placeHolderGroupBlob, err := proto.Marshal(&placeholderGroup)
+		assert.NoError(t, err)
+
+		plan, err := createSearchPlan(collection, dslString)
+		assert.NoError(t, err)
+		defer plan.delete()
+		holder, err := parseSearchRequest(plan, placeHolderGroupBlob)
+		assert.NoError(t, err)
+		defer holder.delete()
+
+		searchResult, err := segment.search(plan, []*searchRequest{holder}, []Timestamp{Timestamp(1020)})
+		assert.NoError(t, err)
+		searchResults := []*SearchResult{searchResult}
+		defer deleteSearchResults(searchResults)
+
+		err = reduceSearchResultsAndFillData(plan, searchResults, 1)
+		assert.NoError(t, err)
+		marshaledHits, err := reorganizeSearchResults(searchResults, 1)
+		assert.NoError(t, err)
+		defer deleteMarshaledHits(marshaledHits)
+		hitsBlob, err := marshaledHits.getHitsBlob()
+		assert.NoError(t, err)
+
+		var hits milvuspb.Hits
+		err = proto.Unmarshal(hitsBlob, &hits)
+		assert.NoError(t, err)
+		distances := make(map[int64]float32)
+		for i, id := range hits.IDs {
+			// the slots not matched are filled with -1
+			if id == -1 {
+				continue
+			}
+			// the scores are the negative distances
+			distances[id] = -hits.Scores[i]
+		}
+		return distances
+	}
+
+	checkDistances := func(t *testing.T, expected, actual map[int64]float32) {
+		assert.Equal(t, len(expected), len(actual))
+		for id, distance := range expected {
+			assert.Contains(t, actual, id)
+			assert.InDelta(t, distance, actual[id], 1e-3, "id = %d", id)
+		}
+	}
+
+	genSealedBinarySegment := func(t *testing.T, collection *Collection, withRawData bool) *Segment {
+		segment := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultVChannel, segmentTypeSealed, true)
+		err := segment.segmentLoadFieldData(rowIDFieldID, len(rowIDs), rowIDs)
+		assert.NoError(t, err)
+		err = segment.segmentLoadFieldData(timestampFieldID, len(rowIDs), make([]int64, len(rowIDs)))
+		assert.NoError(t, err)
+		err = segment.segmentLoadFieldData(101, len(rowIDs), make([]int32, len(rowIDs)))
+		assert.NoError(t, err)
+		if withRawData {
+			err = segment.segmentLoadFieldData(100, len(rowIDs), vectors)
+			assert.NoError(t, err)
+		}
+		return segment
+	}
+
+	t.Run("test growing segment without index", func(t *testing.T) {
+		collection := newCollection(defaultCollectionID, schema)
+		defer deleteCollection(collection)
+		segment := newSegment(collection, defaultSegmentID, defaultPartitionID, defaultCollectionID, defaultVChannel, segmentTypeGrowing, true)
+		defer deleteSegment(segment)
+
+		var records []*commonpb.Blob
+		for i := range rowIDs {
+			rawData := append([]byte{}, vectors[i*dim/8:(i+1)*dim/8]...)
+			bs := make([]byte, 4)
+			binary.LittleEndian.PutUint32(bs, 1)
+			rawData = append(rawData, bs...)
+			records = append(records, &commonpb.Blob{Value: rawData})
+		}
+		timestamps := make([]Timestamp, len(rowIDs))
+		offset, err := segment.segmentPreInsert(len(rowIDs))
+		assert.NoError(t, err)
+		err = segment.segmentInsert(offset, &rowIDs, &timestamps, &records)
+		assert.NoError(t, err)
+
+		for _, c := range allMetrics {
+			t.Run(c.metricType, func(t *testing.T) {
+				checkDistances(t, c.distances, search(t, collection, segment, c.metricType))
+			})
+		}
+	})
+
+	t.Run("test sealed segment without index", func(t *testing.T) {
+		collection := newCollection(defaultCollectionID, schema)
+		defer deleteCollection(collection)
+		segment := genSealedBinarySegment(t, collection, true)
+		defer deleteSegment(segment)
+
+		for _, c := range allMetrics {
+			t.Run(c.metricType, func(t *testing.T) {
+				checkDistances(t, c.distances, search(t, collection, segment, c.metricType))
+			})
+		}
+	})
+
+	indexCases := []struct {
+		indexType   string
+		indexParams map[string]string
+		metrics     []metricCase
+	}{
+		{"BIN_FLAT", map[string]string{}, allMetrics},
+		{"BIN_IVF_FLAT", map[string]string{"nlist": "1"}, []metricCase{hamming, jaccard, tanimoto}},
+	}
+	for _, ic := range indexCases {
+		for _, c := range ic.metrics {
+			t.Run("test sealed segment with "+ic.indexType+" index "+c.metricType, func(t *testing.T) {
+				collection := newCollection(defaultCollectionID, schema)
+				defer deleteCollection(collection)
+				segment := genSealedBinarySegment(t, collection, false)
+				defer deleteSegment(segment)
+
+				indexParams := map[string]string{
+					"index_type":  ic.indexType,
+					"metric_type": c.metricType,
+					"dim":         strconv.Itoa(dim),
+				}
+				for k, v := range ic.indexParams {
+					indexParams[k] = v
+				}
+				typeParams := map[string]string{"dim": strconv.Itoa(dim)}
+				index, err := indexnode.NewCIndex(typeParams, indexParams)
+				assert.NoError(t, err)
+				defer index.Delete()
+				err = index.BuildBinaryVecIndexWithoutIds(vectors)
+				assert.NoError(t, err)
+				binarySet, err := index.Serialize()
+				assert.NoError(t, err)
+				indexBytes := make([][]byte, 0)
+				indexPaths := make([]string, 0)
+				for _, blob := range binarySet {
+					indexBytes = append(indexBytes, blob.Value)
+					indexPaths = append(indexPaths, blob.Key)
+				}
+
+				segment.indexInfos[100] = newIndexInfo()
+				err = segment.setIndexParam(100, indexParams)
+				assert.NoError(t, err)
+				err = segment.setIndexPaths(100, indexPaths)
+				assert.NoError(t, err)
+				err = segment.updateSegmentIndex(indexBytes, 100)
+				assert.NoError(t, err)
+
+				checkDistances(t, c.distances, search(t, collection, segment, c.metricType))
+			})
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+const (
+	// binlogChunkSize is the max size of the binlog data in a chunk streamed between query nodes
+	binlogChunkSize = 1024 * 1024
+	// fetchBinlogsTimeout is the timeout to fetch the binlogs of a segment from another query node
+	fetchBinlogsTimeout = 5 * time.Minute
+)
+
+// peerBinlogKV serves the binlogs fetched from the source query node of a segment, the others are loaded from the
+// storage.
+type peerBinlogKV struct {
+	kv.DataKV
+	binlogs map[string][]byte
+}
+
+// Load loads the binlog of the key.
+func (pkv *peerBinlogKV) Load(key string) (string, error) {
+	if binlog, ok := pkv.binlogs[key]; ok {
+		return string(binlog), nil
+	}
+	return pkv.DataKV.Load(key)
+}
+
+// MultiLoad loads the binlogs of the keys.
+func (pkv *peerBinlogKV) MultiLoad(keys []string) ([]string, error) {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := pkv.Load(key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// segmentBinlogPaths returns the paths of the binlogs read to load the segment, which are the binlogs of the fields
+// loaded, the statslogs and the deltalogs.
+func segmentBinlogPaths(fieldBinlogs []*datapb.FieldBinlog, info *querypb.SegmentLoadInfo) []string {
+	paths := make([]string, 0)
+	for _, fieldBinlog := range fieldBinlogs {
+		paths = append(paths, fieldBinlog.Binlogs...)
+	}
+	for _, fieldBinlog := range info.Statslogs {
+		paths = append(paths, fieldBinlog.Binlogs...)
+	}
+	for _, deltaLog := range info.Deltalogs {
+		paths = append(paths, deltaLog.DeltaLogPath)
+	}
+	return paths
+}
+
+// binlogKV returns the kv to read the binlogs of the segment from. If the request carries the source query node of
+// the segments, the binlogs cached on it are fetched, so that they're not downloaded from the storage again.
+// The binlogs are loaded from the storage if the fetch fails for any reason.
+func (loader *segmentLoader) binlogKV(req *querypb.LoadSegmentsRequest, info *querypb.SegmentLoadInfo, fieldBinlogs []*datapb.FieldBinlog) kv.DataKV {
+	if req.SourceAddress == "" || loader.peerClients == nil {
+		return loader.minioKV
+	}
+	start := time.Now()
+	paths := segmentBinlogPaths(fieldBinlogs, info)
+	binlogs, err := loader.fetchBinlogs(req.SourceNodeID, req.SourceAddress, info.SegmentID, paths)
+	if err != nil {
+		log.Warn("failed to fetch binlogs from the source query node, load them from the storage",
+			zap.Int64("segmentID", info.SegmentID),
+			zap.Int64("sourceNodeID", req.SourceNodeID),
+			zap.String("sourceAddress", req.SourceAddress),
+			zap.Error(err))
+		return loader.minioKV
+	}
+	log.Debug("fetched binlogs from the source query node",
+		zap.Int64("segmentID", info.SegmentID),
+		zap.Int64("sourceNodeID", req.SourceNodeID),
+		zap.Int("fetched", len(binlogs)),
+		zap.Int("total", len(paths)),
+		zap.Duration("cost", time.Since(start)))
+	return &peerBinlogKV{
+		DataKV:  loader.minioKV,
+		binlogs: binlogs,
+	}
+}
+
+// fetchBinlogs fetches the binlogs cached on the query node, the binlogs not cached are absent in the result.
+// Each binlog fetched is verified by its checksum.
+func (loader *segmentLoader) fetchBinlogs(nodeID UniqueID, address string, segmentID UniqueID, paths []string) (map[string][]byte, error) {
+	client, err := loader.peerClients.GetClient(nodeID, address)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fetchBinlogsTimeout)
+	defer cancel()
+	binlogs, err := fetchBinlogsFromClient(ctx, client, segmentID, paths)
+	if err != nil {
+		// reconnect next time, the query node may have been restarted
+		loader.peerClients.RemoveClient(nodeID)
+	}
+	return binlogs, err
+}
+
+// fetchBinlogsFromClient fetches the binlogs cached on the query node of the client and verifies their checksums.
+func fetchBinlogsFromClient(ctx context.Context, client types.QueryNode, segmentID UniqueID, paths []string) (map[string][]byte, error) {
+	requested := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		requested[path] = true
+	}
+	binlogs := make(map[string][]byte)
+	var current string
+	var data []byte
+	hash := storage.NewChecksum()
+	err := client.FetchSegmentBinlogs(ctx, &querypb.FetchSegmentBinlogsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_LoadSegments,
+			SourceID: Params.QueryNodeID,
+		},
+		SegmentID: segmentID,
+		Paths:     paths,
+	}, func(chunk *querypb.SegmentBinlogChunk) error {
+		if chunk.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			return errors.New(chunk.GetStatus().GetReason())
+		}
+		if current != "" && chunk.Path != current {
+			return fmt.Errorf("binlog %s is incomplete", current)
+		}
+		if current == "" {
+			if _, ok := binlogs[chunk.Path]; ok || !requested[chunk.Path] {
+				return fmt.Errorf("unexpected binlog %s, it's not requested or sent more than once", chunk.Path)
+			}
+			current = chunk.Path
+			data = make([]byte, 0, len(chunk.Data))
+			hash.Reset()
+		}
+		data = append(data, chunk.Data...)
+		hash.Write(chunk.Data)
+		if chunk.Last {
+			if actual := hash.Sum32(); actual != chunk.Checksum {
+				return &storage.ChecksumError{Path: current, Offset: -1, Expected: chunk.Checksum, Actual: actual}
+			}
+			binlogs[current] = data
+			current = ""
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if current != "" {
+		return nil, fmt.Errorf("binlog %s is incomplete", current)
+	}
+	return binlogs, nil
+}
+
+// sendCachedBinlogs sends the binlogs cached in the chunk cache in chunks, the binlogs not cached are skipped.
+func (loader *segmentLoader) sendCachedBinlogs(ctx context.Context, paths []string, send func(*querypb.SegmentBinlogChunk) error) error {
+	if loader.chunkCache == nil {
+		return errors.New("chunk cache is disabled")
+	}
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := loader.sendCachedBinlog(path, send)
+		if errors.Is(err, storage.ErrChunkNotCached) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendCachedBinlog sends the cached binlog in chunks, the checksum of the binlog is set on the last chunk.
+func (loader *segmentLoader) sendCachedBinlog(path string, send func(*querypb.SegmentBinlogChunk) error) error {
+	reader, err := loader.chunkCache.ReaderIfCached(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	hash := storage.NewChecksum()
+	for {
+		data := make([]byte, binlogChunkSize)
+		n, err := io.ReadFull(reader, data)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		hash.Write(data[:n])
+		chunk := &querypb.SegmentBinlogChunk{
+			Path: path,
+			Data: data[:n],
+			Last: err != nil,
+		}
+		if chunk.Last {
+			chunk.Checksum = hash.Sum32()
+		}
+		if err := send(chunk); err != nil {
+			return err
+		}
+		if chunk.Last {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/shardclient"
+)
+
+// throttledChunkManager simulates a remote storage, each read takes latency plus the time to transfer the chunk
+// at bandwidth bytes per second.
+type throttledChunkManager struct {
+	*storage.LocalChunkManager
+	latency   time.Duration
+	bandwidth int64
+}
+
+func (cm *throttledChunkManager) throttle(key string) {
+	size, err := cm.LocalChunkManager.Size(key)
+	if err != nil {
+		return
+	}
+	time.Sleep(cm.latency + time.Duration(size*int64(time.Second)/cm.bandwidth))
+}
+
+func (cm *throttledChunkManager) Read(key string) ([]byte, error) {
+	cm.throttle(key)
+	return cm.LocalChunkManager.Read(key)
+}
+
+func (cm *throttledChunkManager) Reader(key string) (io.ReadCloser, error) {
+	cm.throttle(key)
+	return cm.LocalChunkManager.Reader(key)
+}
+
+// binlogSourceServer serves the binlogs cached by the loader through grpc, as the source query node does.
+type binlogSourceServer struct {
+	querypb.UnimplementedQueryNodeServer
+	loader *segmentLoader
+}
+
+func (s *binlogSourceServer) FetchSegmentBinlogs(req *querypb.FetchSegmentBinlogsRequest, stream querypb.QueryNode_FetchSegmentBinlogsServer) error {
+	return s.loader.sendCachedBinlogs(stream.Context(), req.Paths, stream.Send)
+}
+
+// binlogSourceClient fetches the binlogs from a binlogSourceServer.
+type binlogSourceClient struct {
+	types.QueryNode
+	client querypb.QueryNodeClient
+	// corrupt modifies the chunks received if it's set
+	corrupt func(chunk *querypb.SegmentBinlogChunk)
+}
+
+func (c *binlogSourceClient) Init() error {
+	return nil
+}
+
+func (c *binlogSourceClient) Start() error {
+	return nil
+}
+
+func (c *binlogSourceClient) Stop() error {
+	return nil
+}
+
+func (c *binlogSourceClient) FetchSegmentBinlogs(ctx context.Context, req *querypb.FetchSegmentBinlogsRequest, recv func(*querypb.SegmentBinlogChunk) error) error {
+	stream, err := c.client.FetchSegmentBinlogs(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if c.corrupt != nil {
+			c.corrupt(chunk)
+		}
+		if err := recv(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// genBinlogTransferLoaders returns the loader of the source query node with the chunk cache, and the loader of the
+// destination query node fetching the binlogs from it through grpc. Both read the binlogs from remote.
+func genBinlogTransferLoaders(t testing.TB, remote storage.ChunkManager) (*segmentLoader, *segmentLoader, *binlogSourceClient) {
+	cache, err := storage.NewCachedChunkManager(remote, t.TempDir(), 1024*1024*1024)
+	require.NoError(t, err)
+	source := &segmentLoader{
+		minioKV:    storage.NewChunkManagerKV(cache),
+		chunkCache: cache,
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	querypb.RegisterQueryNodeServer(server, &binlogSourceServer{loader: source})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	client := &binlogSourceClient{client: querypb.NewQueryNodeClient(conn)}
+	destination := &segmentLoader{
+		minioKV: storage.NewChunkManagerKV(remote),
+		peerClients: shardclient.NewManager(context.Background(), func(ctx context.Context, address string) (types.QueryNode, error) {
+			return client, nil
+		}),
+	}
+	return source, destination, client
+}
+
+// genTransferredSegment writes the binlogs of a segment to remote, the size of each field binlog is binlogSize.
+func genTransferredSegment(t testing.TB, remote storage.ChunkManager, numFields int, binlogSize int) ([]*datapb.FieldBinlog, *querypb.SegmentLoadInfo) {
+	write := func(key string, size int) string {
+		content := make([]byte, size)
+		rand.Read(content)
+		require.NoError(t, remote.Write(key, content))
+		return key
+	}
+	fieldBinlogs := make([]*datapb.FieldBinlog, 0)
+	for i := 0; i < numFields; i++ {
+		fieldID := int64(100 + i)
+		fieldBinlogs = append(fieldBinlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []string{write(fmt.Sprintf("insert_log/%d/0", fieldID), binlogSize)},
+		})
+	}
+	info := &querypb.SegmentLoadInfo{
+		SegmentID:    defaultSegmentID,
+		PartitionID:  defaultPartitionID,
+		CollectionID: defaultCollectionID,
+		BinlogPaths:  fieldBinlogs,
+		Statslogs: []*datapb.FieldBinlog{
+			{FieldID: 100, Binlogs: []string{write("stats_log/100/0", 128)}},
+		},
+		Deltalogs: []*datapb.DeltaLogInfo{
+			{DeltaLogPath: write("delta_log/0", 64)},
+		},
+	}
+	return fieldBinlogs, info
+}
+
+func genTransferLoadSegmentsRequest(info *querypb.SegmentLoadInfo) *querypb.LoadSegmentsRequest {
+	return &querypb.LoadSegmentsRequest{
+		Base: &commonpb.MsgBase{
+			MsgType: commonpb.MsgType_LoadSegments,
+		},
+		Infos:         []*querypb.SegmentLoadInfo{info},
+		SourceNodeID:  Params.QueryNodeID + 1,
+		SourceAddress: "source",
+	}
+}
+
+func TestSegmentTransfer_binlogKV(t *testing.T) {
+	remote := storage.NewLocalChunkManager(t.TempDir())
+	source, destination, client := genBinlogTransferLoaders(t, remote)
+	// a binlog larger than a chunk
+	fieldBinlogs, info := genTransferredSegment(t, remote, 2, binlogChunkSize*2+100)
+	paths := segmentBinlogPaths(fieldBinlogs, info)
+	assert.Equal(t, 4, len(paths))
+
+	expected, err := destination.minioKV.MultiLoad(paths)
+	require.NoError(t, err)
+	// the binlogs except the first field are cached on the source query node
+	for _, path := range paths[1:] {
+		_, err = source.minioKV.Load(path)
+		require.NoError(t, err)
+	}
+
+	t.Run("test fetch cached binlogs", func(t *testing.T) {
+		binlogKV := destination.binlogKV(genTransferLoadSegmentsRequest(info), info, fieldBinlogs)
+		peerKV, ok := binlogKV.(*peerBinlogKV)
+		assert.True(t, ok)
+		assert.Equal(t, 3, len(peerKV.binlogs))
+		assert.NotContains(t, peerKV.binlogs, paths[0])
+
+		// the binlogs not cached are loaded from the storage
+		values, err := binlogKV.MultiLoad(paths)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, values)
+	})
+
+	t.Run("test no source", func(t *testing.T) {
+		req := genTransferLoadSegmentsRequest(info)
+		req.SourceAddress = ""
+		assert.Equal(t, destination.minioKV, destination.binlogKV(req, info, fieldBinlogs))
+	})
+
+	t.Run("test chunk cache disabled", func(t *testing.T) {
+		chunkCache := source.chunkCache
+		source.chunkCache = nil
+		defer func() { source.chunkCache = chunkCache }()
+		assert.Equal(t, destination.minioKV, destination.binlogKV(genTransferLoadSegmentsRequest(info), info, fieldBinlogs))
+	})
+
+	t.Run("test checksum mismatch", func(t *testing.T) {
+		client.corrupt = func(chunk *querypb.SegmentBinlogChunk) {
+			if len(chunk.Data) > 0 {
+				chunk.Data[0]++
+			}
+		}
+		defer func() { client.corrupt = nil }()
+		_, err := destination.fetchBinlogs(Params.QueryNodeID+1, "source", info.SegmentID, paths)
+		var checksumErr *storage.ChecksumError
+		assert.True(t, errors.As(err, &checksumErr))
+		assert.Equal(t, paths[1], checksumErr.Path)
+		assert.Equal(t, destination.minioKV, destination.binlogKV(genTransferLoadSegmentsRequest(info), info, fieldBinlogs))
+	})
+
+	t.Run("test incomplete binlog", func(t *testing.T) {
+		client.corrupt = func(chunk *querypb.SegmentBinlogChunk) {
+			if chunk.Path == paths[1] {
+				chunk.Last = false
+			}
+		}
+		defer func() { client.corrupt = nil }()
+		_, err := destination.fetchBinlogs(Params.QueryNodeID+1, "source", info.SegmentID, paths)
+		assert.Error(t, err)
+	})
+
+	t.Run("test unexpected binlog", func(t *testing.T) {
+		_, err := destination.fetchBinlogs(Params.QueryNodeID+1, "source", info.SegmentID, paths[2:])
+		assert.NoError(t, err)
+		client.corrupt = func(chunk *querypb.SegmentBinlogChunk) {
+			chunk.Path = "unknown"
+		}
+		defer func() { client.corrupt = nil }()
+		_, err = destination.fetchBinlogs(Params.QueryNodeID+1, "source", info.SegmentID, paths[2:])
+		assert.Error(t, err)
+	})
+
+	t.Run("test source offline", func(t *testing.T) {
+		loader := &segmentLoader{
+			minioKV: destination.minioKV,
+			peerClients: shardclient.NewManager(context.Background(), func(ctx context.Context, address string) (types.QueryNode, error) {
+				return nil, errors.New("connection refused")
+			}),
+		}
+		assert.Equal(t, loader.minioKV, loader.binlogKV(genTransferLoadSegmentsRequest(info), info, fieldBinlogs))
+	})
+}
+
+// BenchmarkSegmentTransfer compares reading the binlogs of a segment from the storage with fetching them from the
+// source query node caching them.
+func BenchmarkSegmentTransfer(b *testing.B) {
+	remote := &throttledChunkManager{
+		LocalChunkManager: storage.NewLocalChunkManager(b.TempDir()),
+		latency:           10 * time.Millisecond,
+		bandwidth:         100 * 1024 * 1024,
+	}
+	source, destination, _ := genBinlogTransferLoaders(b, remote)
+	fieldBinlogs, info := genTransferredSegment(b, remote, 4, 4*1024*1024)
+	paths := segmentBinlogPaths(fieldBinlogs, info)
+	for _, path := range paths {
+		_, err := source.minioKV.Load(path)
+		require.NoError(b, err)
+	}
+
+	b.Run("storage", func(b *testing.B) {
+		req := genTransferLoadSegmentsRequest(info)
+		req.SourceAddress = ""
+		for i := 0; i < b.N; i++ {
+			_, err := destination.binlogKV(req, info, fieldBinlogs).MultiLoad(paths)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("transfer", func(b *testing.B) {
+		req := genTransferLoadSegmentsRequest(info)
+		for i := 0; i < b.N; i++ {
+			binlogKV := destination.binlogKV(req, info, fieldBinlogs)
+			_, ok := binlogKV.(*peerBinlogKV)
+			require.True(b, ok)
+			_, err := binlogKV.MultiLoad(paths)
+			require.NoError(b, err)
+		}
+	})
+}
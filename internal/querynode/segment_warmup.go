@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	warmupPlaceholderTag = "$0"
+	// warmupSearchParams covers the search params required by all kinds of index, unused keys are ignored by segcore
+	warmupSearchParams = `{"nprobe": 1, "ef": 10, "search_k": 10, "search_length": 10}`
+)
+
+// warmupSegment issues a synthetic search against every vector field and a synthetic retrieval against every
+// scalar field of a loaded segment, so that the index and the column data are paged in before the first real query.
+// Warm-up is best effort: failures are logged and the remaining fields are skipped once deadline is exceeded.
+// The time spent is recorded as the warm-up cost of the segment.
+func warmupSegment(collection *Collection, segment *Segment, deadline time.Time) {
+	start := time.Now()
+	defer func() {
+		segment.warmupCost = time.Since(start)
+	}()
+
+	for _, field := range collection.Schema().Fields {
+		if field.FieldID < common.StartOfUserFieldID {
+			continue
+		}
+		if time.Now().After(deadline) {
+			log.Warn("segment warm-up timeout, skip the remaining fields",
+				zap.Int64("collectionID", collection.ID()),
+				zap.Int64("segmentID", segment.ID()),
+				zap.Int64("fieldID", field.FieldID))
+			return
+		}
+		var err error
+		switch field.DataType {
+		case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector:
+			err = warmupVectorField(collection, segment, field)
+		default:
+			err = warmupScalarField(collection, segment, field)
+		}
+		if err != nil {
+			log.Warn("segment warm-up failed",
+				zap.Int64("collectionID", collection.ID()),
+				zap.Int64("segmentID", segment.ID()),
+				zap.Int64("fieldID", field.FieldID),
+				zap.Error(err))
+		}
+	}
+}
+
+func warmupVectorField(collection *Collection, segment *Segment, field *schemapb.FieldSchema) error {
+	dim, err := getFieldDim(field)
+	if err != nil {
+		return err
+	}
+	isBinary := field.DataType == schemapb.DataType_BinaryVector
+
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{
+				IsBinary: isBinary,
+				FieldId:  field.FieldID,
+				QueryInfo: &planpb.QueryInfo{
+					Topk:         1,
+					MetricType:   getWarmupMetricType(segment, field),
+					SearchParams: warmupSearchParams,
+					RoundDecimal: -1,
+				},
+				PlaceholderTag: warmupPlaceholderTag,
+			},
+		},
+	}
+	expr, err := proto.Marshal(planNode)
+	if err != nil {
+		return err
+	}
+
+	placeholderValue := &milvuspb.PlaceholderValue{
+		Tag:  warmupPlaceholderTag,
+		Type: milvuspb.PlaceholderType_FloatVector,
+	}
+	if isBinary {
+		placeholderValue.Type = milvuspb.PlaceholderType_BinaryVector
+		placeholderValue.Values = [][]byte{make([]byte, dim/8)}
+	} else {
+		placeholderValue.Values = [][]byte{make([]byte, dim*4)}
+	}
+	placeholderGroup, err := proto.Marshal(&milvuspb.PlaceholderGroup{
+		Placeholders: []*milvuspb.PlaceholderValue{placeholderValue},
+	})
+	if err != nil {
+		return err
+	}
+
+	plan, err := createSearchPlanByExpr(collection, expr)
+	if err != nil {
+		return err
+	}
+	defer plan.delete()
+	req, err := parseSearchRequest(plan, placeholderGroup)
+	if err != nil {
+		return err
+	}
+	defer req.delete()
+
+	result, err := segment.search(plan, []*searchRequest{req}, []Timestamp{typeutil.MaxTimestamp})
+	if err != nil {
+		return err
+	}
+	deleteSearchResults([]*SearchResult{result})
+	return nil
+}
+
+func warmupScalarField(collection *Collection, segment *Segment, field *schemapb.FieldSchema) error {
+	var value *planpb.GenericValue
+	switch field.DataType {
+	case schemapb.DataType_Bool:
+		value = &planpb.GenericValue{Val: &planpb.GenericValue_BoolVal{BoolVal: false}}
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64:
+		value = &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 0}}
+	case schemapb.DataType_Float, schemapb.DataType_Double:
+		value = &planpb.GenericValue{Val: &planpb.GenericValue_FloatVal{FloatVal: 0}}
+	default:
+		// no term expression on other data types
+		return nil
+	}
+
+	planNode := &planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{
+			Predicates: &planpb.Expr{
+				Expr: &planpb.Expr_TermExpr{
+					TermExpr: &planpb.TermExpr{
+						ColumnInfo: &planpb.ColumnInfo{
+							FieldId:  field.FieldID,
+							DataType: field.DataType,
+						},
+						Values: []*planpb.GenericValue{value},
+					},
+				},
+			},
+		},
+		OutputFieldIds: []int64{field.FieldID},
+	}
+	expr, err := proto.Marshal(planNode)
+	if err != nil {
+		return err
+	}
+
+	plan, err := createRetrievePlanByExpr(collection, expr, typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	defer plan.delete()
+	_, err = segment.getEntityByIds(plan)
+	return err
+}
+
+// getWarmupMetricType returns the metric type of the index built on field,
+// the default metric type of the data type is used if there is no index.
+func getWarmupMetricType(segment *Segment, field *schemapb.FieldSchema) string {
+	if metricType, ok := segment.getIndexParams(field.FieldID)["metric_type"]; ok {
+		return metricType
+	}
+	for _, kv := range field.IndexParams {
+		if kv.Key == "metric_type" {
+			return kv.Value
+		}
+	}
+	if field.DataType == schemapb.DataType_BinaryVector {
+		return "JACCARD"
+	}
+	return "L2"
+}
+
+func getFieldDim(field *schemapb.FieldSchema) (int, error) {
+	for _, kv := range field.TypeParams {
+		if kv.Key == "dim" {
+			dim, err := strconv.Atoi(kv.Value)
+			if err != nil {
+				return 0, err
+			}
+			return dim, nil
+		}
+	}
+	return 0, errors.New(fmt.Sprintln("dim not found in type params, fieldID = ", field.FieldID))
+}
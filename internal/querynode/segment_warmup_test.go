@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func TestSegment_warmup(t *testing.T) {
+	t.Run("test warmup", func(t *testing.T) {
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+		collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+
+		warmupSegment(collection, segment, time.Now().Add(time.Minute))
+		stat := segment.statistics.snapshot()
+		assert.Equal(t, int64(1), stat.SearchCount)
+		assert.True(t, stat.RetrieveCount > 0)
+		assert.True(t, segment.warmupCost > 0)
+	})
+
+	t.Run("test warmup timeout", func(t *testing.T) {
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+		collection := newCollection(defaultCollectionID, genSimpleSegCoreSchema())
+
+		warmupSegment(collection, segment, time.Now().Add(-time.Second))
+		stat := segment.statistics.snapshot()
+		assert.Equal(t, int64(0), stat.SearchCount)
+		assert.Equal(t, int64(0), stat.RetrieveCount)
+	})
+
+	t.Run("test getWarmupMetricType", func(t *testing.T) {
+		segment, err := genSimpleSealedSegment()
+		assert.NoError(t, err)
+
+		field := &schemapb.FieldSchema{
+			FieldID:  simpleVecField.id,
+			DataType: schemapb.DataType_FloatVector,
+		}
+		assert.Equal(t, "L2", getWarmupMetricType(segment, field))
+
+		field.DataType = schemapb.DataType_BinaryVector
+		assert.Equal(t, "JACCARD", getWarmupMetricType(segment, field))
+
+		field.IndexParams = []*commonpb.KeyValuePair{{Key: "metric_type", Value: "HAMMING"}}
+		assert.Equal(t, "HAMMING", getWarmupMetricType(segment, field))
+
+		err = segment.setIndexInfo(simpleVecField.id, &indexInfo{indexParams: map[string]string{"metric_type": "TANIMOTO"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "TANIMOTO", getWarmupMetricType(segment, field))
+	})
+
+	t.Run("test getFieldDim", func(t *testing.T) {
+		field := &schemapb.FieldSchema{
+			TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "128"}},
+		}
+		dim, err := getFieldDim(field)
+		assert.NoError(t, err)
+		assert.Equal(t, 128, dim)
+
+		field.TypeParams[0].Value = "illegal"
+		_, err = getFieldDim(field)
+		assert.Error(t, err)
+
+		field.TypeParams = nil
+		_, err = getFieldDim(field)
+		assert.Error(t, err)
+	})
+}
+
+func TestSegmentLoader_loadSegmentWithWarmup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kv, err := genEtcdKV()
+	assert.NoError(t, err)
+
+	fieldBinlog, err := saveSimpleBinLog(ctx)
+	assert.NoError(t, err)
+
+	loadSegment := func(t *testing.T) *Segment {
+		historical, err := genSimpleHistorical(ctx)
+		assert.NoError(t, err)
+
+		err = historical.replica.removeSegment(defaultSegmentID)
+		assert.NoError(t, err)
+		loader := newSegmentLoader(ctx, nil, nil, historical.replica, kv)
+		assert.NotNil(t, loader)
+
+		req := &querypb.LoadSegmentsRequest{
+			Base: &commonpb.MsgBase{
+				MsgType: commonpb.MsgType_LoadSegments,
+				MsgID:   rand.Int63(),
+			},
+			Schema:        genSimpleInsertDataSchema(),
+			LoadCondition: querypb.TriggerCondition_grpcRequest,
+			Infos: []*querypb.SegmentLoadInfo{
+				{
+					SegmentID:    defaultSegmentID,
+					PartitionID:  defaultPartitionID,
+					CollectionID: defaultCollectionID,
+					BinlogPaths:  fieldBinlog,
+				},
+			},
+		}
+		err = loader.loadSegment(req)
+		assert.NoError(t, err)
+
+		segment, err := historical.replica.getSegmentByID(defaultSegmentID)
+		assert.NoError(t, err)
+		return segment
+	}
+
+	enabled, timeout := Params.WarmupEnabled, Params.WarmupTimeout
+	defer func() {
+		Params.WarmupEnabled, Params.WarmupTimeout = enabled, timeout
+	}()
+
+	t.Run("test warmup disabled", func(t *testing.T) {
+		Params.WarmupEnabled = false
+		segment := loadSegment(t)
+		assert.Equal(t, time.Duration(0), segment.warmupCost)
+		assert.True(t, segment.loadCost > 0)
+	})
+
+	t.Run("test warmup enabled", func(t *testing.T) {
+		Params.WarmupEnabled = true
+		Params.WarmupTimeout = time.Minute
+		segment := loadSegment(t)
+		assert.True(t, segment.warmupCost > 0)
+		assert.True(t, segment.loadCost >= segment.warmupCost)
+		// synthetic requests are not counted into segment statistics
+		stat := segment.statistics.snapshot()
+		assert.Equal(t, int64(0), stat.SearchCount)
+		assert.Equal(t, int64(0), stat.RetrieveCount)
+	})
+}
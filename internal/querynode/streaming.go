@@ -107,7 +107,7 @@ func (s *streaming) retrieve(collID UniqueID, partIDs []UniqueID, plan *Retrieve
 
 // search will search all the target segments in streaming
 func (s *streaming) search(searchReqs []*searchRequest, collID UniqueID, partIDs []UniqueID, vChannel Channel,
-	plan *SearchPlan, searchTs Timestamp) ([]*SearchResult, error) {
+	plan *SearchPlan, searchTs Timestamp, filter segmentFilter) ([]*SearchResult, error) {
 
 	searchResults := make([]*SearchResult, 0)
 
@@ -211,6 +211,9 @@ func (s *streaming) search(searchReqs []*searchRequest, collID UniqueID, partIDs
 			//	continue
 			//}
 
+			if filter != nil && !filter(seg) {
+				continue
+			}
 			searchResult, err := seg.search(plan, searchReqs, []Timestamp{searchTs})
 			if err != nil {
 				return searchResults, err
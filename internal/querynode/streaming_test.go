@@ -46,7 +46,8 @@ func TestStreaming_search(t *testing.T) {
 			[]UniqueID{defaultPartitionID},
 			defaultVChannel,
 			plan,
-			Timestamp(0))
+			Timestamp(0),
+			nil)
 		assert.NoError(t, err)
 		assert.Len(t, res, 1)
 	})
@@ -64,7 +65,8 @@ func TestStreaming_search(t *testing.T) {
 			[]UniqueID{},
 			defaultVChannel,
 			plan,
-			Timestamp(0))
+			Timestamp(0),
+			nil)
 		assert.NoError(t, err)
 		assert.Len(t, res, 1)
 	})
@@ -89,7 +91,8 @@ func TestStreaming_search(t *testing.T) {
 			[]UniqueID{defaultPartitionID},
 			defaultVChannel,
 			plan,
-			Timestamp(0))
+			Timestamp(0),
+			nil)
 		assert.NoError(t, err)
 		assert.Nil(t, res)
 	})
@@ -114,7 +117,8 @@ func TestStreaming_search(t *testing.T) {
 			[]UniqueID{defaultPartitionID},
 			defaultVChannel,
 			plan,
-			Timestamp(0))
+			Timestamp(0),
+			nil)
 		assert.Error(t, err)
 	})
 
@@ -134,7 +138,8 @@ func TestStreaming_search(t *testing.T) {
 			[]UniqueID{},
 			defaultVChannel,
 			plan,
-			Timestamp(0))
+			Timestamp(0),
+			nil)
 		assert.NoError(t, err)
 		assert.Nil(t, res)
 	})
@@ -157,7 +162,8 @@ func TestStreaming_search(t *testing.T) {
 			[]UniqueID{},
 			defaultVChannel,
 			plan,
-			Timestamp(0))
+			Timestamp(0),
+			nil)
 		assert.Error(t, err)
 	})
 }
@@ -25,7 +25,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
-	"github.com/milvus-io/milvus/internal/rootcoord"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 )
 
 type task interface {
@@ -121,7 +121,7 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 	VPChannels := make(map[string]string) // map[vChannel]pChannel
 	for _, info := range w.req.Infos {
 		v := info.ChannelName
-		p := rootcoord.ToPhysicalChannel(info.ChannelName)
+		p := channelutil.ToPhysicalChannel(info.ChannelName)
 		vChannels = append(vChannels, v)
 		pChannels = append(pChannels, p)
 		VPChannels[v] = p
@@ -218,12 +218,7 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) error {
 	w.node.streaming.replica.addExcludedSegments(collectionID, checkPointInfos)
 	log.Debug("watchDMChannel, add check points info done", zap.Any("collectionID", collectionID))
 
-	// create tSafe
-	for _, channel := range vChannels {
-		w.node.streaming.tSafeReplica.addTSafe(channel)
-	}
-
-	// add flow graph
+	// add flow graph, the flow graphs hold the tSafes of their channels
 	if loadPartition {
 		w.node.streaming.dataSyncService.addPartitionFlowGraph(collectionID, partitionID, vChannels)
 		log.Debug("Query node add partition flow graphs", zap.Any("channels", vChannels))
@@ -459,19 +454,6 @@ func (r *releaseCollectionTask) Execute(ctx context.Context) error {
 		r.node.streaming.dataSyncService.removePartitionFlowGraph(partitionID)
 	}
 
-	// remove all tSafes of the target collection
-	for _, channel := range collection.getVChannels() {
-		log.Debug("Releasing tSafe in releaseCollectionTask...",
-			zap.Any("collectionID", r.req.CollectionID),
-			zap.Any("vChannel", channel),
-		)
-		// no tSafe in tSafeReplica, don't return error
-		err = r.node.streaming.tSafeReplica.removeTSafe(channel)
-		if err != nil {
-			log.Warn(err.Error())
-		}
-	}
-
 	// remove excludedSegments record
 	r.node.streaming.replica.removeExcludedSegments(r.req.CollectionID)
 
@@ -555,20 +537,8 @@ func (r *releasePartitionsTask) Execute(ctx context.Context) error {
 	vChannels := sCol.getVChannels()
 	for _, id := range r.req.PartitionIDs {
 		if _, err = r.node.streaming.dataSyncService.getPartitionFlowGraphs(id, vChannels); err == nil {
+			// the tSafes of the partition are released with its flow graphs
 			r.node.streaming.dataSyncService.removePartitionFlowGraph(id)
-			// remove all tSafes of the target partition
-			for _, channel := range vChannels {
-				log.Debug("Releasing tSafe in releasePartitionTask...",
-					zap.Any("collectionID", r.req.CollectionID),
-					zap.Any("partitionID", id),
-					zap.Any("vChannel", channel),
-				)
-				// no tSafe in tSafeReplica, don't return error
-				err = r.node.streaming.tSafeReplica.removeTSafe(channel)
-				if err != nil {
-					log.Warn(err.Error())
-				}
-			}
 		}
 
 		// remove partition from streaming and historical
@@ -22,6 +22,8 @@ import (
 	"github.com/milvus-io/milvus/internal/log"
 )
 
+// tSafeWatcher is notified when the tSafe it watches changes, the notifications are merged if the
+// watcher doesn't receive them in time. The channel of the watcher is closed when the tSafe is closed.
 type tSafeWatcher struct {
 	notifyChan chan bool
 }
@@ -32,9 +34,11 @@ func newTSafeWatcher() *tSafeWatcher {
 	}
 }
 
+// notify never blocks, the caller must hold the lock of the tSafe
 func (watcher *tSafeWatcher) notify() {
-	if len(watcher.notifyChan) == 0 {
-		watcher.notifyChan <- true
+	select {
+	case watcher.notifyChan <- true:
+	default:
 	}
 }
 
@@ -45,95 +49,58 @@ func (watcher *tSafeWatcher) watcherChan() <-chan bool {
 type tSafer interface {
 	get() Timestamp
 	set(id UniqueID, t Timestamp)
+	waitFor(ctx context.Context, t Timestamp) (Timestamp, error)
 	registerTSafeWatcher(t *tSafeWatcher) error
-	start()
+	removeTSafeWatcher(t *tSafeWatcher)
 	close()
 	removeRecord(partitionID UniqueID)
 }
 
-type tSafeMsg struct {
-	t  Timestamp
-	id UniqueID // collectionID or partitionID
-}
+var errTSafeClosed = errors.New("tSafe is closed")
 
 type tSafe struct {
-	ctx         context.Context
-	cancel      context.CancelFunc
-	channel     Channel
-	tSafeMu     sync.Mutex // guards all fields
-	tSafe       Timestamp
-	watcherList []*tSafeWatcher
-	tSafeChan   chan tSafeMsg
+	channel Channel
+	tSafeMu sync.Mutex // guards all fields
+	tSafe   Timestamp
+	// version is increased on every change of the tSafe, the watchers registered after any change
+	// are notified at once, so that they never miss the tSafe set before the registration
+	version uint64
+	// updated is closed and replaced on every change of the tSafe, waitFor takes it under tSafeMu
+	// together with checking the tSafe, so that no change between the check and the wait is lost
+	updated chan struct{}
+	// watchers are reference counted, a watcher registered n times is removed after n removals
+	watchers    map[*tSafeWatcher]int
 	tSafeRecord map[UniqueID]Timestamp
 	isClose     bool
 }
 
-func newTSafe(ctx context.Context, channel Channel) tSafer {
-	ctx1, cancel := context.WithCancel(ctx)
-	const channelSize = 4096
-
+func newTSafe(channel Channel) tSafer {
 	var t tSafer = &tSafe{
-		ctx:         ctx1,
-		cancel:      cancel,
 		channel:     channel,
-		watcherList: make([]*tSafeWatcher, 0),
-		tSafeChan:   make(chan tSafeMsg, channelSize),
+		updated:     make(chan struct{}),
+		watchers:    make(map[*tSafeWatcher]int),
 		tSafeRecord: make(map[UniqueID]Timestamp),
 	}
 	return t
 }
 
-func (ts *tSafe) start() {
-	go func() {
-		for {
-			select {
-			case <-ts.ctx.Done():
-				ts.tSafeMu.Lock()
-				ts.isClose = true
-				log.Debug("tSafe context done",
-					zap.Any("channel", ts.channel),
-				)
-				for _, watcher := range ts.watcherList {
-					close(watcher.notifyChan)
-				}
-				ts.watcherList = nil
-				close(ts.tSafeChan)
-				ts.tSafeMu.Unlock()
-				return
-			case m, ok := <-ts.tSafeChan:
-				if !ok {
-					// should not happen!!
-					return
-				}
-				ts.tSafeMu.Lock()
-				ts.tSafeRecord[m.id] = m.t
-				var tmpT Timestamp = math.MaxUint64
-				for _, t := range ts.tSafeRecord {
-					if t <= tmpT {
-						tmpT = t
-					}
-				}
-				ts.tSafe = tmpT
-				for _, watcher := range ts.watcherList {
-					watcher.notify()
-				}
-
-				//log.Debug("set tSafe done",
-				//	zap.Any("id", m.id),
-				//	zap.Any("channel", ts.channel),
-				//	zap.Any("t", m.t),
-				//	zap.Any("tSafe", ts.tSafe))
-				ts.tSafeMu.Unlock()
-			}
+// updateTSafe recomputes the tSafe from the records and notifies the watchers, the caller must hold tSafeMu
+func (ts *tSafe) updateTSafe() {
+	var tmpT Timestamp = math.MaxUint64
+	for _, t := range ts.tSafeRecord {
+		if t <= tmpT {
+			tmpT = t
 		}
-	}()
+	}
+	ts.tSafe = tmpT
+	ts.version++
+	for watcher := range ts.watchers {
+		watcher.notify()
+	}
+	close(ts.updated)
+	ts.updated = make(chan struct{})
 }
 
-// removeRecord for deleting the old partition which has been released,
-// if we don't delete this, tSafe would always be the old partition's timestamp
-// (because we set tSafe to the minimum timestamp) from old partition
-// flow graph which has been closed and would not update tSafe any more.
-// removeRecord should be called when flow graph is been removed.
 func (ts *tSafe) removeRecord(partitionID UniqueID) {
 	ts.tSafeMu.Lock()
 	defer ts.tSafeMu.Unlock()
@@ -148,28 +115,37 @@ func (ts *tSafe) removeRecord(partitionID UniqueID) {
 		zap.Any("partitionID", partitionID),
 	)
 	delete(ts.tSafeRecord, partitionID)
-	var tmpT Timestamp = math.MaxUint64
-	for _, t := range ts.tSafeRecord {
-		if t <= tmpT {
-			tmpT = t
-		}
-	}
-	ts.tSafe = tmpT
-	for _, watcher := range ts.watcherList {
-		watcher.notify()
-	}
+	ts.updateTSafe()
 }
 
 func (ts *tSafe) registerTSafeWatcher(t *tSafeWatcher) error {
 	ts.tSafeMu.Lock()
+	defer ts.tSafeMu.Unlock()
 	if ts.isClose {
 		return errors.New("Failed to register tsafe watcher because tsafe is closed " + ts.channel)
 	}
-	defer ts.tSafeMu.Unlock()
-	ts.watcherList = append(ts.watcherList, t)
+	ts.watchers[t]++
+	if ts.version > 0 {
+		// the tSafe has been set before the registration
+		t.notify()
+	}
 	return nil
 }
 
+func (ts *tSafe) removeTSafeWatcher(t *tSafeWatcher) {
+	ts.tSafeMu.Lock()
+	defer ts.tSafeMu.Unlock()
+	ref, ok := ts.watchers[t]
+	if !ok {
+		return
+	}
+	if ref > 1 {
+		ts.watchers[t] = ref - 1
+		return
+	}
+	delete(ts.watchers, t)
+}
+
 func (ts *tSafe) get() Timestamp {
 	ts.tSafeMu.Lock()
 	defer ts.tSafeMu.Unlock()
@@ -186,13 +162,49 @@ func (ts *tSafe) set(id UniqueID, t Timestamp) {
 			zap.Any("id", id))
 		return
 	}
-	msg := tSafeMsg{
-		t:  t,
-		id: id,
+	ts.tSafeRecord[id] = t
+	ts.updateTSafe()
+}
+
+// waitFor blocks until the tSafe reaches t and returns the tSafe, it fails if ctx is done or the tSafe is closed
+func (ts *tSafe) waitFor(ctx context.Context, t Timestamp) (Timestamp, error) {
+	for {
+		ts.tSafeMu.Lock()
+		if ts.isClose {
+			ts.tSafeMu.Unlock()
+			return 0, errTSafeClosed
+		}
+		if ts.version > 0 && ts.tSafe >= t {
+			cur := ts.tSafe
+			ts.tSafeMu.Unlock()
+			return cur, nil
+		}
+		updated := ts.updated
+		ts.tSafeMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-updated:
+		}
 	}
-	ts.tSafeChan <- msg
 }
 
+// close closes the channels of all the watchers and wakes up the waiters, the watchers are removed
 func (ts *tSafe) close() {
-	ts.cancel()
+	ts.tSafeMu.Lock()
+	defer ts.tSafeMu.Unlock()
+	if ts.isClose {
+		return
+	}
+	ts.isClose = true
+	log.Debug("close tSafe",
+		zap.Any("channel", ts.channel),
+		zap.Int("watchers", len(ts.watchers)),
+	)
+	for watcher := range ts.watchers {
+		close(watcher.notifyChan)
+	}
+	ts.watchers = nil
+	close(ts.updated)
 }
@@ -28,9 +28,12 @@ type TSafeReplicaInterface interface {
 	addTSafe(vChannel Channel)
 	removeTSafe(vChannel Channel) error
 	registerTSafeWatcher(vChannel Channel, watcher *tSafeWatcher) error
+	removeTSafeWatcher(vChannel Channel, watcher *tSafeWatcher) error
 	removeRecord(vChannel Channel, partitionID UniqueID) error
+	WaitForTSafe(ctx context.Context, vChannel Channel, ts Timestamp) (Timestamp, error)
 }
 
+// tSafeRef counts the flow graphs of the channel, the tSafe is closed with its watchers after the last one is released
 type tSafeRef struct {
 	tSafer tSafer
 	ref    int
@@ -80,13 +83,11 @@ func (t *tSafeReplica) getTSaferPrivate(vChannel Channel) (tSafer, error) {
 func (t *tSafeReplica) addTSafe(vChannel Channel) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	ctx := context.Background()
 	if _, ok := t.tSafes[vChannel]; !ok {
 		t.tSafes[vChannel] = &tSafeRef{
-			tSafer: newTSafe(ctx, vChannel),
+			tSafer: newTSafe(vChannel),
 			ref:    1,
 		}
-		t.tSafes[vChannel].tSafer.start()
 		log.Debug("add tSafe done",
 			zap.Any("channel", vChannel),
 			zap.Any("count", t.tSafes[vChannel].ref),
@@ -143,10 +144,32 @@ func (t *tSafeReplica) registerTSafeWatcher(vChannel Channel, watcher *tSafeWatc
 	if err != nil {
 		return err
 	}
-	safer.registerTSafeWatcher(watcher)
+	return safer.registerTSafeWatcher(watcher)
+}
+
+func (t *tSafeReplica) removeTSafeWatcher(vChannel Channel, watcher *tSafeWatcher) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	safer, err := t.getTSaferPrivate(vChannel)
+	if err != nil {
+		return err
+	}
+	safer.removeTSafeWatcher(watcher)
 	return nil
 }
 
+// WaitForTSafe blocks until the tSafe of vChannel reaches ts and returns the tSafe, it fails if ctx is done
+// or the tSafe is removed before that
+func (t *tSafeReplica) WaitForTSafe(ctx context.Context, vChannel Channel, ts Timestamp) (Timestamp, error) {
+	t.mu.Lock()
+	safer, err := t.getTSaferPrivate(vChannel)
+	t.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	return safer.waitFor(ctx, ts)
+}
+
 func newTSafeReplica() TSafeReplicaInterface {
 	var replica TSafeReplicaInterface = &tSafeReplica{
 		tSafes: make(map[string]*tSafeRef),
@@ -12,10 +12,13 @@
 package querynode
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
 )
 
 func TestTSafeReplica_valid(t *testing.T) {
@@ -29,13 +32,18 @@ func TestTSafeReplica_valid(t *testing.T) {
 	timestamp := Timestamp(1000)
 	err = replica.setTSafe(defaultVChannel, defaultCollectionID, timestamp)
 	assert.NoError(t, err)
-	time.Sleep(20 * time.Millisecond)
 	resT, err := replica.getTSafe(defaultVChannel)
 	assert.NoError(t, err)
 	assert.Equal(t, timestamp, resT)
 
+	err = replica.removeTSafeWatcher(defaultVChannel, watcher)
+	assert.NoError(t, err)
+
 	err = replica.removeTSafe(defaultVChannel)
 	assert.NoError(t, err)
+
+	err = replica.removeTSafeWatcher(defaultVChannel, watcher)
+	assert.Error(t, err)
 }
 
 func TestTSafeReplica_invalid(t *testing.T) {
@@ -49,7 +57,6 @@ func TestTSafeReplica_invalid(t *testing.T) {
 	timestamp := Timestamp(1000)
 	err = replica.setTSafe(defaultVChannel, defaultCollectionID, timestamp)
 	assert.NoError(t, err)
-	time.Sleep(20 * time.Millisecond)
 	resT, err := replica.getTSafe(defaultVChannel)
 	assert.NoError(t, err)
 	assert.Equal(t, timestamp, resT)
@@ -60,3 +67,99 @@ func TestTSafeReplica_invalid(t *testing.T) {
 	replica.addTSafe(defaultVChannel)
 	replica.addTSafe(defaultVChannel)
 }
+
+func TestTSafeReplica_removeTSafeClosesWatchers(t *testing.T) {
+	replica := newTSafeReplica()
+	replica.addTSafe(defaultVChannel)
+	replica.addTSafe(defaultVChannel)
+
+	watcher := newTSafeWatcher()
+	assert.NoError(t, replica.registerTSafeWatcher(defaultVChannel, watcher))
+
+	// the tSafe is kept until its last reference is removed
+	assert.NoError(t, replica.removeTSafe(defaultVChannel))
+	assert.NoError(t, replica.setTSafe(defaultVChannel, defaultCollectionID, Timestamp(1000)))
+	_, ok := <-watcher.watcherChan()
+	assert.True(t, ok)
+
+	assert.NoError(t, replica.removeTSafe(defaultVChannel))
+	_, ok = <-watcher.watcherChan()
+	assert.False(t, ok)
+	assert.Error(t, replica.removeTSafe(defaultVChannel))
+}
+
+func TestTSafeReplica_WaitForTSafe(t *testing.T) {
+	replica := newTSafeReplica()
+
+	_, err := replica.WaitForTSafe(context.Background(), defaultVChannel, Timestamp(1000))
+	assert.Error(t, err)
+
+	replica.addTSafe(defaultVChannel)
+	done := make(chan Timestamp)
+	go func() {
+		ts, err := replica.WaitForTSafe(context.Background(), defaultVChannel, Timestamp(1000))
+		assert.NoError(t, err)
+		done <- ts
+	}()
+	assert.NoError(t, replica.setTSafe(defaultVChannel, defaultCollectionID, Timestamp(1000)))
+	assert.Equal(t, Timestamp(1000), <-done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		_, err := replica.WaitForTSafe(ctx, defaultVChannel, Timestamp(2000))
+		errCh <- err
+	}()
+	cancel()
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+
+	// the waiters quit when the tSafe is removed
+	go func() {
+		_, err := replica.WaitForTSafe(context.Background(), defaultVChannel, Timestamp(2000))
+		errCh <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, replica.removeTSafe(defaultVChannel))
+	assert.ErrorIs(t, <-errCh, errTSafeClosed)
+}
+
+// run with -race, the flow graphs of a channel are added and released while the queries wait for the tSafe
+func TestTSafeReplica_concurrentWaitAndRelease(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	replica := newTSafeReplica()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		replica.addTSafe(defaultVChannel)
+
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			watcher := newTSafeWatcher()
+			if err := replica.registerTSafeWatcher(defaultVChannel, watcher); err != nil {
+				return
+			}
+			select {
+			case <-watcher.watcherChan():
+			case <-time.After(time.Second):
+			}
+			// the tSafe may have been removed
+			_ = replica.removeTSafeWatcher(defaultVChannel, watcher)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, _ = replica.WaitForTSafe(ctx, defaultVChannel, Timestamp(i))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			_ = replica.setTSafe(defaultVChannel, defaultCollectionID, Timestamp(i))
+			_ = replica.removeTSafe(defaultVChannel)
+		}(i)
+	}
+	wg.Wait()
+
+	_, err := replica.getTSafe(defaultVChannel)
+	assert.Error(t, err)
+}
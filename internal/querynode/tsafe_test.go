@@ -15,13 +15,14 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
 )
 
 func TestTSafe_GetAndSet(t *testing.T) {
-	tSafe := newTSafe(context.Background(), "TestTSafe-channel")
-	tSafe.start()
+	tSafe := newTSafe("TestTSafe-channel")
 	watcher := newTSafeWatcher()
 	tSafe.registerTSafeWatcher(watcher)
 
@@ -39,8 +40,7 @@ func TestTSafe_GetAndSet(t *testing.T) {
 }
 
 func TestTSafe_Remove(t *testing.T) {
-	tSafe := newTSafe(context.Background(), "TestTSafe-remove")
-	tSafe.start()
+	tSafe := newTSafe("TestTSafe-remove")
 	watcher := newTSafeWatcher()
 	tSafe.registerTSafeWatcher(watcher)
 
@@ -57,8 +57,7 @@ func TestTSafe_Remove(t *testing.T) {
 }
 
 func TestTSafe_Close(t *testing.T) {
-	tSafe := newTSafe(context.Background(), "TestTSafe-close")
-	tSafe.start()
+	tSafe := newTSafe("TestTSafe-close")
 	watcher := newTSafeWatcher()
 	tSafe.registerTSafeWatcher(watcher)
 
@@ -69,6 +68,8 @@ func TestTSafe_Close(t *testing.T) {
 		}
 	}()
 
+	tSafe.close()
+	// close twice is a no-op
 	tSafe.close()
 
 	// wait until channel close
@@ -81,4 +82,170 @@ func TestTSafe_Close(t *testing.T) {
 	// register TSafe will fail
 	err := tSafe.registerTSafeWatcher(watcher)
 	assert.Error(t, err)
+	// the failed registration must not keep holding the lock
+	tSafe.get()
+	// removing a watcher of the closed tSafe is a no-op
+	tSafe.removeTSafeWatcher(watcher)
+}
+
+func TestTSafe_removeTSafeWatcher(t *testing.T) {
+	tSafe := newTSafe("TestTSafe-removeWatcher")
+	defer tSafe.close()
+	removed := newTSafeWatcher()
+	kept := newTSafeWatcher()
+	assert.NoError(t, tSafe.registerTSafeWatcher(removed))
+	assert.NoError(t, tSafe.registerTSafeWatcher(kept))
+
+	tSafe.removeTSafeWatcher(removed)
+	// removing an unknown watcher is a no-op
+	tSafe.removeTSafeWatcher(newTSafeWatcher())
+
+	tSafe.set(UniqueID(1), Timestamp(1000))
+	<-kept.watcherChan()
+	assert.Len(t, removed.watcherChan(), 0)
+}
+
+func TestTSafe_watcherRef(t *testing.T) {
+	tSafe := newTSafe("TestTSafe-watcherRef")
+	defer tSafe.close()
+	watcher := newTSafeWatcher()
+	assert.NoError(t, tSafe.registerTSafeWatcher(watcher))
+	assert.NoError(t, tSafe.registerTSafeWatcher(watcher))
+
+	// the watcher is kept until it's removed as many times as registered
+	tSafe.removeTSafeWatcher(watcher)
+	tSafe.set(UniqueID(1), Timestamp(1000))
+	<-watcher.watcherChan()
+
+	tSafe.removeTSafeWatcher(watcher)
+	tSafe.set(UniqueID(1), Timestamp(1001))
+	assert.Len(t, watcher.watcherChan(), 0)
+}
+
+func TestTSafe_registerAfterSet(t *testing.T) {
+	tSafe := newTSafe("TestTSafe-registerAfterSet")
+	defer tSafe.close()
+
+	// no notification before the tSafe is set
+	early := newTSafeWatcher()
+	assert.NoError(t, tSafe.registerTSafeWatcher(early))
+	assert.Len(t, early.watcherChan(), 0)
+
+	tSafe.set(UniqueID(1), Timestamp(1000))
+
+	// the watcher registered after the tSafe is set doesn't wait for the next update
+	late := newTSafeWatcher()
+	assert.NoError(t, tSafe.registerTSafeWatcher(late))
+	select {
+	case <-late.watcherChan():
+	case <-time.After(time.Second):
+		assert.Fail(t, "the watcher registered after set is not notified")
+	}
+	assert.Equal(t, Timestamp(1000), tSafe.get())
+}
+
+func TestTSafe_waitFor(t *testing.T) {
+	t.Run("reach", func(t *testing.T) {
+		tSafe := newTSafe("TestTSafe-waitFor")
+		defer tSafe.close()
+
+		done := make(chan Timestamp)
+		go func() {
+			ts, err := tSafe.waitFor(context.Background(), Timestamp(1000))
+			assert.NoError(t, err)
+			done <- ts
+		}()
+		tSafe.set(UniqueID(1), Timestamp(500))
+		tSafe.set(UniqueID(1), Timestamp(1200))
+		assert.Equal(t, Timestamp(1200), <-done)
+
+		// return at once if the tSafe has reached
+		ts, err := tSafe.waitFor(context.Background(), Timestamp(1100))
+		assert.NoError(t, err)
+		assert.Equal(t, Timestamp(1200), ts)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		tSafe := newTSafe("TestTSafe-waitFor-cancel")
+		defer tSafe.close()
+		tSafe.set(UniqueID(1), Timestamp(500))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := tSafe.waitFor(ctx, Timestamp(1000))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		tSafe := newTSafe("TestTSafe-waitFor-close")
+		errCh := make(chan error)
+		go func() {
+			_, err := tSafe.waitFor(context.Background(), Timestamp(1000))
+			errCh <- err
+		}()
+		tSafe.close()
+		assert.ErrorIs(t, <-errCh, errTSafeClosed)
+	})
+}
+
+// run with -race, the registrations, notifications and the release of the tSafe interleave
+func TestTSafe_concurrentRegisterNotifyClose(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	for round := 0; round < 20; round++ {
+		tSafe := newTSafe("TestTSafe-concurrent")
+		var wg sync.WaitGroup
+
+		// the watchers drain their notifications until the tSafe is closed
+		const watcherNum = 8
+		for i := 0; i < watcherNum; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				watcher := newTSafeWatcher()
+				if err := tSafe.registerTSafeWatcher(watcher); err != nil {
+					return
+				}
+				for range watcher.watcherChan() {
+				}
+			}()
+		}
+
+		// the waiters are woken up by set or close
+		for i := 0; i < watcherNum; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ts, err := tSafe.waitFor(context.Background(), Timestamp(100+i))
+				if err == nil {
+					assert.True(t, ts >= Timestamp(100+i))
+				}
+			}(i)
+		}
+
+		// registered and removed watchers are never notified after removal
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				watcher := newTSafeWatcher()
+				if err := tSafe.registerTSafeWatcher(watcher); err != nil {
+					return
+				}
+				tSafe.removeTSafeWatcher(watcher)
+			}
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				tSafe.set(UniqueID(i%4), Timestamp(i))
+			}
+		}()
+
+		time.Sleep(time.Millisecond)
+		tSafe.close()
+		wg.Wait()
+	}
 }
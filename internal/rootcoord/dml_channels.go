@@ -20,6 +20,7 @@ import (
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/msgstream"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 )
 
 type dmlChannels struct {
@@ -29,6 +30,8 @@ type dmlChannels struct {
 	refcnt     sync.Map
 	idx        *atomic.Int64
 	pool       sync.Map
+	compatible bool
+	legacy     sync.Map // channels outside the pool, only used in compatible mode
 }
 
 func newDmlChannels(c *Core, chanNamePrefix string, chanNum int64) *dmlChannels {
@@ -39,11 +42,12 @@ func newDmlChannels(c *Core, chanNamePrefix string, chanNum int64) *dmlChannels
 		refcnt:     sync.Map{},
 		idx:        atomic.NewInt64(0),
 		pool:       sync.Map{},
+		compatible: Params.DmlChannelCompatibleMode,
 	}
 
 	var i int64
 	for i = 0; i < chanNum; i++ {
-		name := fmt.Sprintf("%s_%d", d.namePrefix, i)
+		name := channelutil.PhysicalChannelName(d.namePrefix, i)
 		ms, err := c.msFactory.NewMsgStream(c.ctx)
 		if err != nil {
 			log.Error("add msgstream failed", zap.String("name", name), zap.Error(err))
@@ -58,11 +62,16 @@ func newDmlChannels(c *Core, chanNamePrefix string, chanNum int64) *dmlChannels
 
 func (d *dmlChannels) GetDmlMsgStreamName() string {
 	cnt := d.idx.Load()
-	name := fmt.Sprintf("%s_%d", d.namePrefix, cnt)
+	name := channelutil.PhysicalChannelName(d.namePrefix, cnt)
 	d.idx.Store((cnt + 1) % d.capacity)
 	return name
 }
 
+// GetDmlChannelNames returns the physical channels for the shards of a collection, picked by hashing the collection id
+func (d *dmlChannels) GetDmlChannelNames(collectionID int64, shardsNum int32) []string {
+	return channelutil.AssignPhysicalChannels(d.namePrefix, d.capacity, collectionID, shardsNum)
+}
+
 // ListChannels lists all dml channel names
 func (d *dmlChannels) ListChannels() []string {
 	chanNames := make([]string, 0)
@@ -132,6 +141,8 @@ func (d *dmlChannels) AddProducerChannels(names ...string) {
 			}
 			d.refcnt.Store(name, cnt)
 			log.Debug("assign dml channel", zap.String("chanName", name), zap.Int64("refcnt", cnt))
+		} else if d.compatible {
+			d.addLegacyChannel(name)
 		} else {
 			log.Error("invalid channel name", zap.String("chanName", name))
 			panic("invalid channel name: " + name)
@@ -139,6 +150,20 @@ func (d *dmlChannels) AddProducerChannels(names ...string) {
 	}
 }
 
+// addLegacyChannel produces to a channel outside the pool, which was created before the pool was introduced
+func (d *dmlChannels) addLegacyChannel(name string) {
+	ms, err := d.core.msFactory.NewMsgStream(d.core.ctx)
+	if err != nil {
+		log.Error("add msgstream failed", zap.String("name", name), zap.Error(err))
+		panic("add msgstream failed")
+	}
+	ms.AsProducer([]string{name})
+	d.pool.Store(name, &ms)
+	d.legacy.Store(name, struct{}{})
+	d.refcnt.Store(name, int64(1))
+	log.Warn("assign legacy dml channel outside the pool", zap.String("chanName", name))
+}
+
 // RemoveProducerChannels removes specified channels
 func (d *dmlChannels) RemoveProducerChannels(names ...string) {
 	for _, name := range names {
@@ -148,7 +173,21 @@ func (d *dmlChannels) RemoveProducerChannels(names ...string) {
 				d.refcnt.Store(name, cnt-1)
 			} else {
 				d.refcnt.Delete(name)
+				d.removeLegacyChannel(name)
 			}
 		}
 	}
 }
+
+// removeLegacyChannel closes the producer of a legacy channel once no collection uses it
+func (d *dmlChannels) removeLegacyChannel(name string) {
+	if _, ok := d.legacy.Load(name); !ok {
+		return
+	}
+	if v, ok := d.pool.Load(name); ok {
+		(*(v.(*msgstream.MsgStream))).Close()
+	}
+	d.pool.Delete(name)
+	d.legacy.Delete(name)
+	log.Debug("remove legacy dml channel", zap.String("chanName", name))
+}
@@ -75,4 +75,27 @@ func TestDmlChannels(t *testing.T) {
 
 	dml.RemoveProducerChannels(chanName0)
 	assert.Equal(t, 0, dml.GetNumChannels())
+
+	// shards of a collection are spread over the pool
+	shardChans := dml.GetDmlChannelNames(1000, totalDmlChannelNum)
+	assert.Equal(t, totalDmlChannelNum, len(shardChans))
+	assert.NotEqual(t, shardChans[0], shardChans[1])
+	assert.Equal(t, shardChans, dml.GetDmlChannelNames(1000, totalDmlChannelNum))
+	dml.AddProducerChannels(shardChans...)
+	assert.Equal(t, 2, dml.GetNumChannels())
+	dml.RemoveProducerChannels(shardChans...)
+	assert.Equal(t, 0, dml.GetNumChannels())
+
+	// channels outside the pool are only accepted in compatible mode
+	dml.compatible = true
+	defer func() { dml.compatible = false }()
+	dml.AddProducerChannels(randStr)
+	assert.Equal(t, 1, dml.GetNumChannels())
+	dml.AddProducerChannels(randStr)
+	dml.RemoveProducerChannels(randStr)
+	assert.Equal(t, 1, dml.GetNumChannels())
+	dml.RemoveProducerChannels(randStr)
+	assert.Equal(t, 0, dml.GetNumChannels())
+	_, ok := dml.pool.Load(randStr)
+	assert.False(t, ok)
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -60,6 +61,9 @@ const (
 	// DDMsgSendPrefix prefix to indicate whether DD msg has been send
 	DDMsgSendPrefix = ComponentPrefix + "/dd-msg-send"
 
+	// ChannelPrefixKey key of the cluster channel prefix the deployment was created with
+	ChannelPrefixKey = ComponentPrefix + "/channel-prefix"
+
 	// CreateCollectionDDType name of DD type for create collection
 	CreateCollectionDDType = "CreateCollection"
 
@@ -1323,3 +1327,36 @@ func (mt *MetaTable) IsAlias(collectionAlias string) bool {
 	_, ok := mt.collAlias2ID[collectionAlias]
 	return ok
 }
+
+// AlterCollection merges the properties into the collection meta and records ts as the properties version,
+// the properties should be validated by caller
+func (mt *MetaTable) AlterCollection(collID typeutil.UniqueID, properties []*commonpb.KeyValuePair, ts typeutil.Timestamp) error {
+	mt.ddLock.Lock()
+	defer mt.ddLock.Unlock()
+	coll, ok := mt.collID2Meta[collID]
+	if !ok {
+		return fmt.Errorf("can't find collection. id = %d", collID)
+	}
+	if ts <= coll.PropertiesVersion {
+		return fmt.Errorf("stale properties version %d, current version = %d", ts, coll.PropertiesVersion)
+	}
+
+	coll.Properties = common.MergeCollectionProperties(coll.Properties, properties)
+	coll.PropertiesVersion = ts
+
+	k := fmt.Sprintf("%s/%d", CollectionMetaPrefix, collID)
+	v, err := proto.Marshal(&coll)
+	if err != nil {
+		log.Error("MetaTable AlterCollection Marshal CollectionInfo fail",
+			zap.String("key", k), zap.Error(err))
+		return fmt.Errorf("MetaTable AlterCollection Marshal CollectionInfo fail key:%s, err:%w", k, err)
+	}
+
+	err = mt.snapshot.Save(k, string(v), ts)
+	if err != nil {
+		log.Error("SnapShotKV Save fail", zap.Error(err))
+		panic("SnapShotKV Save fail")
+	}
+	mt.collID2Meta[collID] = coll
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
@@ -335,6 +336,32 @@ func TestMetaTable(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("alter collection", func(t *testing.T) {
+		ts := ftso()
+		err = mt.AlterCollection(collID, []*commonpb.KeyValuePair{
+			{Key: common.CollectionTTLKey, Value: "10"},
+			{Key: common.CollectionReplicaNumberKey, Value: "2"},
+		}, ts)
+		assert.Nil(t, err)
+		// a stale version is rejected
+		err = mt.AlterCollection(collID, []*commonpb.KeyValuePair{{Key: common.CollectionTTLKey, Value: "20"}}, ts)
+		assert.NotNil(t, err)
+
+		ts = ftso()
+		err = mt.AlterCollection(collID, []*commonpb.KeyValuePair{
+			{Key: common.CollectionTTLKey, Value: "20"},
+			{Key: common.CollectionReplicaNumberKey, Value: ""},
+		}, ts)
+		assert.Nil(t, err)
+		collMeta, err := mt.GetCollectionByID(collID, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, []*commonpb.KeyValuePair{{Key: common.CollectionTTLKey, Value: "20"}}, collMeta.Properties)
+		assert.Equal(t, ts, collMeta.PropertiesVersion)
+
+		err = mt.AlterCollection(collIDInvalid, nil, ftso())
+		assert.NotNil(t, err)
+	})
+
 	t.Run("delete alias", func(t *testing.T) {
 		ts := ftso()
 		err = mt.DropAlias(aliasName1, ts)
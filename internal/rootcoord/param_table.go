@@ -12,10 +12,12 @@
 package rootcoord
 
 import (
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 )
 
@@ -42,13 +44,15 @@ type ParamTable struct {
 	DmlChannelName       string
 
 	DmlChannelNum               int64
+	DmlChannelCompatibleMode    bool
 	MaxPartitionNum             int64
 	DefaultPartitionName        string
 	DefaultIndexName            string
 	MinSegmentSizeToEnableIndex int64
 
-	Timeout          int
-	TimeTickInterval int
+	Timeout                     int
+	TimeTickInterval            int
+	ProxyTimeTickStaleThreshold time.Duration
 
 	CreatedTime time.Time
 	UpdatedTime time.Time
@@ -83,6 +87,7 @@ func (p *ParamTable) Init() {
 	p.initDmlChannelName()
 
 	p.initDmlChannelNum()
+	p.initDmlChannelCompatibleMode()
 	p.initMaxPartitionNum()
 	p.initMinSegmentSizeToEnableIndex()
 	p.initDefaultPartitionName()
@@ -90,6 +95,7 @@ func (p *ParamTable) Init() {
 
 	p.initTimeout()
 	p.initTimeTickInterval()
+	p.initProxyTimeTickStaleThreshold()
 
 	p.initRoleName()
 }
@@ -139,6 +145,9 @@ func (p *ParamTable) initClusterMsgChannelPrefix() {
 	if err != nil {
 		panic(err)
 	}
+	if err = channelutil.ValidatePrefix(config); err != nil {
+		panic(err)
+	}
 	p.ClusterChannelPrefix = config
 }
 
@@ -147,8 +156,7 @@ func (p *ParamTable) initMsgChannelSubName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.MsgChannelSubName = strings.Join(s, "-")
+	p.MsgChannelSubName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initTimeTickChannel() {
@@ -156,8 +164,7 @@ func (p *ParamTable) initTimeTickChannel() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.TimeTickChannel = strings.Join(s, "-")
+	p.TimeTickChannel = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initStatisticsChannelName() {
@@ -165,8 +172,7 @@ func (p *ParamTable) initStatisticsChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.StatisticsChannel = strings.Join(s, "-")
+	p.StatisticsChannel = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initDmlChannelName() {
@@ -174,14 +180,17 @@ func (p *ParamTable) initDmlChannelName() {
 	if err != nil {
 		panic(err)
 	}
-	s := []string{p.ClusterChannelPrefix, config}
-	p.DmlChannelName = strings.Join(s, "-")
+	p.DmlChannelName = channelutil.JoinName(p.ClusterChannelPrefix, config)
 }
 
 func (p *ParamTable) initDmlChannelNum() {
 	p.DmlChannelNum = p.ParseInt64("rootcoord.dmlChannelNum")
 }
 
+func (p *ParamTable) initDmlChannelCompatibleMode() {
+	p.DmlChannelCompatibleMode = p.ParseBool("rootcoord.dmlChannelCompatibleMode", false)
+}
+
 func (p *ParamTable) initMaxPartitionNum() {
 	p.MaxPartitionNum = p.ParseInt64("rootcoord.maxPartitionNum")
 }
@@ -214,6 +223,18 @@ func (p *ParamTable) initTimeTickInterval() {
 	p.TimeTickInterval = p.ParseInt("rootcoord.timeTickInterval")
 }
 
+func (p *ParamTable) initProxyTimeTickStaleThreshold() {
+	threshold, err := p.LoadWithDefault("rootcoord.proxyTimeTickStaleThreshold", "10000")
+	if err != nil {
+		panic(err)
+	}
+	ms, err := strconv.ParseInt(threshold, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.ProxyTimeTickStaleThreshold = time.Duration(ms) * time.Millisecond
+}
+
 func (p *ParamTable) initRoleName() {
 	p.RoleName = "rootcoord"
 }
@@ -45,6 +45,9 @@ func TestParamTable(t *testing.T) {
 	assert.Equal(t, Params.DmlChannelName, "by-dev-rootcoord-dml")
 	t.Logf("dml channel = %s", Params.DmlChannelName)
 
+	assert.False(t, Params.DmlChannelCompatibleMode)
+	t.Logf("dml channel compatible mode = %v", Params.DmlChannelCompatibleMode)
+
 	assert.NotEqual(t, Params.MaxPartitionNum, 0)
 	t.Logf("master MaxPartitionNum = %d", Params.MaxPartitionNum)
 
@@ -63,6 +66,9 @@ func TestParamTable(t *testing.T) {
 	assert.NotZero(t, Params.TimeTickInterval)
 	t.Logf("master timetickerInterval = %d", Params.TimeTickInterval)
 
+	assert.Equal(t, 10*time.Second, Params.ProxyTimeTickStaleThreshold)
+	t.Logf("proxy time tick stale threshold = %v", Params.ProxyTimeTickStaleThreshold)
+
 	Params.CreatedTime = time.Now()
 	Params.UpdatedTime = time.Now()
 	t.Logf("created time: %v", Params.CreatedTime)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/log"
@@ -41,7 +42,10 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/tso"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
+	"github.com/milvus-io/milvus/internal/util/healthz"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/trace"
@@ -115,8 +119,10 @@ type Core struct {
 	CallGetNumRowsService         func(ctx context.Context, segID typeutil.UniqueID, isFromFlushedChan bool) (int64, error)
 	CallGetFlushedSegmentsService func(ctx context.Context, collID, partID typeutil.UniqueID) ([]typeutil.UniqueID, error)
 
+	//call index builder's client to create index, return the id of the existing index if the same index has been created
+	CallCreateIndexService func(ctx context.Context, collID typeutil.UniqueID, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (typeutil.UniqueID, error)
 	//call index builder's client to build index, return build id
-	CallBuildIndexService func(ctx context.Context, binlog []string, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (typeutil.UniqueID, error)
+	CallBuildIndexService func(ctx context.Context, collID, segID typeutil.UniqueID, numRows, minSegmentRows int64, binlog []string, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (typeutil.UniqueID, error)
 	CallDropIndexService  func(ctx context.Context, indexID typeutil.UniqueID) error
 
 	NewProxyClient func(sess *sessionutil.Session) (types.Proxy, error)
@@ -136,6 +142,7 @@ type Core struct {
 
 	// metrics cache manager
 	metricsCacheManager *metricsinfo.MetricsCacheManager
+	configUpdater       *paramtable.ConfigUpdater
 
 	// channel timetick
 	chanTimeTick *timetickSync
@@ -222,6 +229,9 @@ func (c *Core) checkInit() error {
 	if c.CallGetNumRowsService == nil {
 		return fmt.Errorf("CallGetNumRowsService is nil")
 	}
+	if c.CallCreateIndexService == nil {
+		return fmt.Errorf("CallCreateIndexService is nil")
+	}
 	if c.CallBuildIndexService == nil {
 		return fmt.Errorf("CallBuildIndexService is nil")
 	}
@@ -355,7 +365,7 @@ func (c *Core) checkFlushedSegments(ctx context.Context) {
 							zap.Int64("segment_id", segID),
 							zap.Int64("index_id", indexMeta.IndexID),
 							zap.Int64("collection_id", collMeta.ID))
-						info.BuildID, err = c.BuildIndex(ctx2, segID, field, &indexMeta, false)
+						info.BuildID, err = c.BuildIndex(ctx2, collMeta.ID, segID, field, &indexMeta, false)
 						if err != nil {
 							log.Debug("build index failed",
 								zap.Int64("segment_id", segID),
@@ -689,7 +699,38 @@ func (c *Core) SetIndexCoord(s types.IndexCoord) error {
 		}
 	}()
 
-	c.CallBuildIndexService = func(ctx context.Context, binlog []string, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (retID typeutil.UniqueID, retErr error) {
+	c.CallCreateIndexService = func(ctx context.Context, collID typeutil.UniqueID, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (retID typeutil.UniqueID, retErr error) {
+		defer func() {
+			if err := recover(); err != nil {
+				retErr = fmt.Errorf("create index panic, msg = %v", err)
+			}
+		}()
+		<-initCh
+		rsp, err := s.CreateIndexDefinition(ctx, &indexpb.CreateIndexDefinitionRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:  commonpb.MsgType_CreateIndex,
+				SourceID: c.session.ServerID,
+			},
+			Definition: &indexpb.IndexDefinition{
+				CollectionID: collID,
+				FieldID:      field.FieldID,
+				IndexName:    idxInfo.IndexName,
+				IndexID:      idxInfo.IndexID,
+				TypeParams:   field.TypeParams,
+				IndexParams:  idxInfo.IndexParams,
+				FieldType:    field.DataType,
+			},
+		})
+		if err != nil {
+			return retID, err
+		}
+		if rsp.Status.ErrorCode != commonpb.ErrorCode_Success {
+			return retID, fmt.Errorf("CreateIndexDefinition from index service failed, error = %s", rsp.Status.Reason)
+		}
+		return rsp.IndexID, nil
+	}
+
+	c.CallBuildIndexService = func(ctx context.Context, collID, segID typeutil.UniqueID, numRows, minSegmentRows int64, binlog []string, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (retID typeutil.UniqueID, retErr error) {
 		defer func() {
 			if err := recover(); err != nil {
 				retErr = fmt.Errorf("build index panic, msg = %v", err)
@@ -697,11 +738,16 @@ func (c *Core) SetIndexCoord(s types.IndexCoord) error {
 		}()
 		<-initCh
 		rsp, err := s.BuildIndex(ctx, &indexpb.BuildIndexRequest{
-			DataPaths:   binlog,
-			TypeParams:  field.TypeParams,
-			IndexParams: idxInfo.IndexParams,
-			IndexID:     idxInfo.IndexID,
-			IndexName:   idxInfo.IndexName,
+			DataPaths:      binlog,
+			TypeParams:     field.TypeParams,
+			IndexParams:    idxInfo.IndexParams,
+			IndexID:        idxInfo.IndexID,
+			IndexName:      idxInfo.IndexName,
+			SegmentID:      segID,
+			FieldID:        field.FieldID,
+			CollectionID:   collID,
+			NumRows:        numRows,
+			MinSegmentRows: minSegmentRows,
 		})
 		if err != nil {
 			return retID, err
@@ -805,8 +851,9 @@ func (c *Core) SetQueryCoord(s types.QueryCoord) error {
 	return nil
 }
 
-// BuildIndex will check row num and call build index service
-func (c *Core) BuildIndex(ctx context.Context, segID typeutil.UniqueID, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo, isFlush bool) (typeutil.UniqueID, error) {
+// BuildIndex will get row num and call build index service, IndexCoord decides whether the segment is large enough
+// to build index, the build ID is 0 if the build is skipped
+func (c *Core) BuildIndex(ctx context.Context, collID, segID typeutil.UniqueID, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo, isFlush bool) (typeutil.UniqueID, error) {
 	sp, ctx := trace.StartSpanFromContext(ctx)
 	defer sp.Finish()
 	if c.MetaTable.IsSegmentIndexed(segID, field, idxInfo.IndexParams) {
@@ -816,21 +863,22 @@ func (c *Core) BuildIndex(ctx context.Context, segID typeutil.UniqueID, field *s
 	if err != nil {
 		return 0, err
 	}
-	var bldID typeutil.UniqueID
-	if rows < Params.MinSegmentSizeToEnableIndex {
-		log.Debug("num of rows is less than MinSegmentSizeToEnableIndex", zap.Int64("num rows", rows))
-	} else {
-		binlogs, err := c.CallGetBinlogFilePathsService(ctx, segID, field.FieldID)
-		if err != nil {
-			return 0, err
-		}
-		bldID, err = c.CallBuildIndexService(ctx, binlogs, field, idxInfo)
-		if err != nil {
-			return 0, err
-		}
+	// 0 lets IndexCoord use its default threshold
+	var minSegmentRows int64
+	if coll, err := c.MetaTable.GetCollectionByID(collID, 0); err == nil {
+		minSegmentRows, _ = common.NewCollectionProperties(coll.Properties).IndexMinSegmentRows()
+	}
+	binlogs, err := c.CallGetBinlogFilePathsService(ctx, segID, field.FieldID)
+	if err != nil {
+		return 0, err
+	}
+	bldID, err := c.CallBuildIndexService(ctx, collID, segID, rows, minSegmentRows, binlogs, field, idxInfo)
+	if err != nil {
+		return 0, err
 	}
 	log.Debug("build index", zap.String("index name", idxInfo.IndexName),
-		zap.String("field name", field.Name), zap.Int64("segment id", segID), zap.Int64("num rows", rows))
+		zap.String("field name", field.Name), zap.Int64("segment id", segID), zap.Int64("num rows", rows),
+		zap.Int64("build id", bldID))
 	return bldID, nil
 }
 
@@ -845,12 +893,25 @@ func (c *Core) Register() error {
 	return nil
 }
 
+// fenceKV guards the writes of etcdKV with the fencing token of the session,
+// so a stale rootcoord can't write meta once a newer one registered
+func (c *Core) fenceKV(etcdKV *etcdkv.EtcdKV) {
+	if c.session != nil {
+		etcdKV.SetFence(c.session.FencingKey(), c.session.FencingToken)
+	}
+}
+
 // Init initialize routine
 func (c *Core) Init() error {
 	var initError error = nil
 	if c.kvBaseCreate == nil {
 		c.kvBaseCreate = func(root string) (kv.TxnKV, error) {
-			return etcdkv.NewEtcdKV(Params.EtcdEndpoints, root)
+			etcdKV, err := etcdkv.NewEtcdKV(Params.EtcdEndpoints, root)
+			if err != nil {
+				return nil, err
+			}
+			c.fenceKV(etcdKV)
+			return etcdKV, nil
 		}
 	}
 	c.initOnce.Do(func() {
@@ -887,11 +948,19 @@ func (c *Core) Init() error {
 			return
 		}
 
+		// refuse to start if the channel prefix changed, otherwise a parallel set of channels is created silently
+		pc := c.MetaTable.ListCollectionPhysicalChannels()
+		if initError = channelutil.CheckPrefix(c.kvBase, ChannelPrefixKey, Params.ClusterChannelPrefix, pc); initError != nil {
+			log.Error("RootCoord, cluster channel prefix check failed", zap.Error(initError))
+			return
+		}
+
 		log.Debug("RootCoord, Setting TSO and ID Allocator")
 		kv, initError := tsoutil.NewTSOKVBase(Params.EtcdEndpoints, Params.KvRootPath, "gid")
 		if initError != nil {
 			return
 		}
+		c.fenceKV(kv)
 		idAllocator := allocator.NewGlobalIDAllocator("idTimestamp", kv)
 		if initError = idAllocator.Initialize(); initError != nil {
 			return
@@ -907,6 +976,7 @@ func (c *Core) Init() error {
 		if initError != nil {
 			return
 		}
+		c.fenceKV(kv)
 		tsoAllocator := tso.NewGlobalTSOAllocator("timestamp", kv)
 		if initError = tsoAllocator.Initialize(); initError != nil {
 			return
@@ -929,11 +999,10 @@ func (c *Core) Init() error {
 		c.dmlChannels = newDmlChannels(c, Params.DmlChannelName, Params.DmlChannelNum)
 
 		// recover physical channels for all collections
-		pc := c.MetaTable.ListCollectionPhysicalChannels()
 		c.dmlChannels.AddProducerChannels(pc...)
 		log.Debug("recover all physical channels", zap.Any("chanNames", pc))
 
-		c.chanTimeTick = newTimeTickSync(c)
+		c.chanTimeTick = newTimeTickSync(c.ctx, c)
 		c.chanTimeTick.AddProxy(c.session)
 		c.proxyClientManager = newProxyClientManager(c)
 
@@ -951,6 +1020,7 @@ func (c *Core) Init() error {
 		c.proxyManager.DelSession(c.chanTimeTick.DelProxy, c.proxyClientManager.DelProxyClient)
 
 		c.metricsCacheManager = metricsinfo.NewMetricsCacheManager()
+		c.configUpdater = paramtable.NewConfigUpdater(&Params.BaseTable)
 
 		initError = c.setMsgStreams()
 		if initError != nil {
@@ -1096,9 +1166,10 @@ func (c *Core) Start() error {
 			log.Debug("RootCoord Start reSendDdMsg failed", zap.Error(err))
 			return
 		}
-		c.wg.Add(4)
+		c.wg.Add(5)
 		go c.startTimeTickLoop()
 		go c.tsLoop()
+		go c.chanTimeTick.StartAggregation(&c.wg)
 		go c.chanTimeTick.StartWatch(&c.wg)
 		go c.checkFlushedSegmentsLoop()
 
@@ -1155,6 +1226,11 @@ func (c *Core) GetComponentStates(ctx context.Context) (*internalpb.ComponentSta
 	}, nil
 }
 
+// CheckReady checks whether RootCoord is healthy and its session is registered in etcd
+func (c *Core) CheckReady(ctx context.Context) error {
+	return healthz.CheckComponentReady(ctx, c, c.session)
+}
+
 // GetTimeTickChannel get timetick channel name
 func (c *Core) GetTimeTickChannel(ctx context.Context) (*milvuspb.StringResponse, error) {
 	return &milvuspb.StringResponse{
@@ -1928,13 +2004,16 @@ func (c *Core) SegmentFlushCompleted(ctx context.Context, in *datapb.SegmentFlus
 			IndexID:      idxInfo.IndexID,
 			EnableIndex:  false,
 		}
-		info.BuildID, err = c.BuildIndex(ctx, segID, fieldSch, idxInfo, true)
-		if err == nil && info.BuildID != 0 {
-			info.EnableIndex = true
-		} else {
+		info.BuildID, err = c.BuildIndex(ctx, in.Segment.CollectionID, segID, fieldSch, idxInfo, true)
+		if err != nil {
 			log.Error("build index fail", zap.Int64("buildid", info.BuildID), zap.Error(err))
 			continue
 		}
+		if info.BuildID == 0 {
+			log.Debug("build index skipped, the segment is too small", zap.Int64("segment id", segID))
+			continue
+		}
+		info.EnableIndex = true
 		err = c.MetaTable.AddIndex(&info)
 		if err != nil {
 			log.Error("AddIndex fail", zap.String("err", err.Error()))
@@ -2009,6 +2088,32 @@ func (c *Core) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest)
 		return systemInfoMetrics, err
 	}
 
+	if metricType == metricsinfo.UpdateConfigMetrics {
+		metrics := c.configUpdater.UpdateByMetricsRequest(req,
+			metricsinfo.ConstructComponentName(typeutil.RootCoordRole, c.session.ServerID))
+
+		log.Debug("RootCoord.GetMetrics",
+			zap.Int64("node_id", c.session.ServerID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
+	if metricType == metricsinfo.SetLogLevelMetrics {
+		name := metricsinfo.ConstructComponentName(typeutil.RootCoordRole, c.session.ServerID)
+		metrics := metricsinfo.LogLevelsResponse([]metricsinfo.ComponentLogLevels{metricsinfo.SetLogLevel(req, name)}, name)
+
+		log.Debug("RootCoord.GetMetrics",
+			zap.Int64("node_id", c.session.ServerID),
+			zap.String("req", req.Request),
+			zap.String("metric_type", metricType),
+			zap.Any("metrics", metrics))
+
+		return metrics, nil
+	}
+
 	log.Debug("RootCoord.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("node_id", c.session.ServerID),
 		zap.String("req", req.Request),
@@ -2118,3 +2223,35 @@ func (c *Core) AlterAlias(ctx context.Context, in *milvuspb.AlterAliasRequest) (
 		Reason:    "",
 	}, nil
 }
+
+// AlterCollection alter collection properties
+func (c *Core) AlterCollection(ctx context.Context, in *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	code := c.stateCode.Load().(internalpb.StateCode)
+	if code != internalpb.StateCode_Healthy {
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    fmt.Sprintf("state code = %s", internalpb.StateCode_name[int32(code)]),
+		}, nil
+	}
+	log.Debug("AlterCollection ", zap.String("name", in.CollectionName))
+	t := &AlterCollectionReqTask{
+		baseReqTask: baseReqTask{
+			ctx:  ctx,
+			core: c,
+		},
+		Req: in,
+	}
+	err := executeTask(t)
+	if err != nil {
+		log.Debug("AlterCollection failed", zap.String("name", in.CollectionName), zap.Error(err))
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    "Alter collection failed: " + err.Error(),
+		}, nil
+	}
+	log.Debug("AlterCollection Success", zap.String("name", in.CollectionName))
+	return &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_Success,
+		Reason:    "",
+	}, nil
+}
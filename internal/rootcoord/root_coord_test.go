@@ -196,6 +196,16 @@ func (idx *indexMock) Start() error {
 	return nil
 }
 
+func (idx *indexMock) CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error) {
+	return &indexpb.CreateIndexDefinitionResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+			Reason:    "",
+		},
+		IndexID: req.Definition.IndexID,
+	}, nil
+}
+
 func (idx *indexMock) BuildIndex(ctx context.Context, req *indexpb.BuildIndexRequest) (*indexpb.BuildIndexResponse, error) {
 	idx.mutex.Lock()
 	defer idx.mutex.Unlock()
@@ -837,6 +847,49 @@ func TestRootCoord(t *testing.T) {
 		assert.Equal(t, shardsNum, rsp.ShardsNum)
 	})
 
+	t.Run("alter collection", func(t *testing.T) {
+		alter := func(properties ...*commonpb.KeyValuePair) *commonpb.Status {
+			status, err := core.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgType: commonpb.MsgType_AlterCollection,
+				},
+				DbName:         dbName,
+				CollectionName: collName,
+				Properties:     properties,
+			})
+			assert.Nil(t, err)
+			return status
+		}
+		describe := func() []*commonpb.KeyValuePair {
+			rsp, err := core.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+				Base: &commonpb.MsgBase{
+					MsgType: commonpb.MsgType_DescribeCollection,
+				},
+				DbName:         dbName,
+				CollectionName: collName,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, commonpb.ErrorCode_Success, rsp.Status.ErrorCode)
+			return rsp.Properties
+		}
+
+		status := alter(&commonpb.KeyValuePair{Key: common.CollectionTTLKey, Value: "60"})
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		assert.Equal(t, []*commonpb.KeyValuePair{{Key: common.CollectionTTLKey, Value: "60"}}, describe())
+
+		status = alter(&commonpb.KeyValuePair{Key: common.CollectionTTLKey, Value: "120"})
+		assert.Equal(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		ttl, ok := common.NewCollectionProperties(describe()).TTL()
+		assert.True(t, ok)
+		assert.Equal(t, 120*time.Second, ttl)
+
+		status = alter(&commonpb.KeyValuePair{Key: common.CollectionTTLKey, Value: "-1"})
+		assert.NotEqual(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		status = alter(&commonpb.KeyValuePair{Key: "unknown", Value: "1"})
+		assert.NotEqual(t, commonpb.ErrorCode_Success, status.ErrorCode)
+		assert.Equal(t, []*commonpb.KeyValuePair{{Key: common.CollectionTTLKey, Value: "120"}}, describe())
+	})
+
 	t.Run("show collection", func(t *testing.T) {
 		req := &milvuspb.ShowCollectionsRequest{
 			Base: &commonpb.MsgBase{
@@ -2399,7 +2452,13 @@ func TestCheckInit(t *testing.T) {
 	err = c.checkInit()
 	assert.NotNil(t, err)
 
-	c.CallBuildIndexService = func(ctx context.Context, binlog []string, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (typeutil.UniqueID, error) {
+	c.CallCreateIndexService = func(ctx context.Context, collID typeutil.UniqueID, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (typeutil.UniqueID, error) {
+		return 0, nil
+	}
+	err = c.checkInit()
+	assert.NotNil(t, err)
+
+	c.CallBuildIndexService = func(ctx context.Context, collID, segID typeutil.UniqueID, numRows, minSegmentRows int64, binlog []string, field *schemapb.FieldSchema, idxInfo *etcdpb.IndexInfo) (typeutil.UniqueID, error) {
 		return 0, nil
 	}
 	err = c.checkInit()
@@ -2569,7 +2628,8 @@ func TestCheckFlushedSegments(t *testing.T) {
 		core.MetaTable.indexID2Meta[indexID] = etcdpb.IndexInfo{
 			IndexID: indexID,
 		}
-		core.CallBuildIndexService = func(_ context.Context, binlog []string, field *schemapb.FieldSchema, idx *etcdpb.IndexInfo) (int64, error) {
+		core.CallBuildIndexService = func(_ context.Context, cid, segID, numRows, minSegmentRows int64, binlog []string, field *schemapb.FieldSchema, idx *etcdpb.IndexInfo) (int64, error) {
+			assert.Equal(t, collID, cid)
 			assert.Equal(t, fieldID, field.FieldID)
 			assert.Equal(t, indexID, idx.IndexID)
 			return -1, errors.New("build index build")
@@ -2578,7 +2638,7 @@ func TestCheckFlushedSegments(t *testing.T) {
 		core.checkFlushedSegments(ctx)
 
 		var indexBuildID int64 = 10001
-		core.CallBuildIndexService = func(_ context.Context, binlog []string, field *schemapb.FieldSchema, idx *etcdpb.IndexInfo) (int64, error) {
+		core.CallBuildIndexService = func(_ context.Context, cid, segID, numRows, minSegmentRows int64, binlog []string, field *schemapb.FieldSchema, idx *etcdpb.IndexInfo) (int64, error) {
 			return indexBuildID, nil
 		}
 		core.checkFlushedSegments(core.ctx)
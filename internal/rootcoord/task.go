@@ -24,6 +24,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"go.uber.org/zap"
@@ -133,11 +134,10 @@ func (t *CreateCollectionReqTask) Execute(ctx context.Context) error {
 		zap.Int64("collection_id", collID),
 		zap.Int64("default partition id", partID))
 
+	chanNames := t.core.dmlChannels.GetDmlChannelNames(collID, t.Req.ShardsNum)
 	vchanNames := make([]string, t.Req.ShardsNum)
-	chanNames := make([]string, t.Req.ShardsNum)
 	for i := int32(0); i < t.Req.ShardsNum; i++ {
-		vchanNames[i] = fmt.Sprintf("%s_%dv%d", t.core.dmlChannels.GetDmlMsgStreamName(), collID, i)
-		chanNames[i] = ToPhysicalChannel(vchanNames[i])
+		vchanNames[i] = channelutil.VirtualChannelName(chanNames[i], collID, i)
 	}
 
 	collInfo := etcdpb.CollectionInfo{
@@ -426,6 +426,7 @@ func (t *DescribeCollectionReqTask) Execute(ctx context.Context) error {
 	t.Rsp.CreatedUtcTimestamp = createdPhysicalTime
 	t.Rsp.Aliases = t.core.MetaTable.ListAliases(collInfo.ID)
 	t.Rsp.StartPositions = collInfo.GetStartPositions()
+	t.Rsp.Properties = collInfo.GetProperties()
 	return nil
 }
 
@@ -548,6 +549,7 @@ func (t *CreatePartitionReqTask) Execute(ctx context.Context) error {
 		},
 		DbName:         t.Req.DbName,
 		CollectionName: t.Req.CollectionName,
+		MetaType:       proxypb.CollectionMetaType_Partitions,
 	}
 	// error doesn't matter here
 	t.core.proxyClientManager.InvalidateCollectionMetaCache(ctx, &req)
@@ -644,6 +646,7 @@ func (t *DropPartitionReqTask) Execute(ctx context.Context) error {
 		},
 		DbName:         t.Req.DbName,
 		CollectionName: t.Req.CollectionName,
+		MetaType:       proxypb.CollectionMetaType_Partitions,
 	}
 	// error doesn't matter here
 	t.core.proxyClientManager.InvalidateCollectionMetaCache(ctx, &req)
@@ -845,6 +848,17 @@ func (t *CreateIndexReqTask) Execute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	field, err := t.core.MetaTable.GetFieldSchema(t.Req.CollectionName, t.Req.FieldName)
+	if err != nil {
+		return err
+	}
+	// index coord rejects a different index on a field which has been indexed,
+	// and returns the id of the existing index if the same index has been created
+	idxInfo.IndexID, err = t.core.CallCreateIndexService(ctx, collMeta.ID, &field, idxInfo)
+	if err != nil {
+		log.Debug("RootCoord CreateIndexReqTask CallCreateIndexService failed", zap.Error(err))
+		return err
+	}
 	segID2PartID, err := t.core.getSegments(ctx, collMeta.ID)
 	flushedSegs := make([]typeutil.UniqueID, 0, len(segID2PartID))
 	for k := range segID2PartID {
@@ -873,7 +887,7 @@ func (t *CreateIndexReqTask) Execute(ctx context.Context) error {
 			IndexID:      idxInfo.IndexID,
 			EnableIndex:  false,
 		}
-		info.BuildID, err = t.core.BuildIndex(ctx, segID, &field, idxInfo, false)
+		info.BuildID, err = t.core.BuildIndex(ctx, collMeta.ID, segID, &field, idxInfo, false)
 		if err != nil {
 			return err
 		}
@@ -1071,3 +1085,56 @@ func (t *AlterAliasReqTask) Execute(ctx context.Context) error {
 
 	return nil
 }
+
+// AlterCollectionReqTask alter collection properties request task
+type AlterCollectionReqTask struct {
+	baseReqTask
+	Req *milvuspb.AlterCollectionRequest
+}
+
+// Type return msg type
+func (t *AlterCollectionReqTask) Type() commonpb.MsgType {
+	return t.Req.Base.MsgType
+}
+
+// Execute task execution
+func (t *AlterCollectionReqTask) Execute(ctx context.Context) error {
+	if t.Type() != commonpb.MsgType_AlterCollection {
+		return fmt.Errorf("alter collection, msg type = %s", commonpb.MsgType_name[int32(t.Type())])
+	}
+	if err := common.ValidateCollectionProperties(t.Req.Properties); err != nil {
+		return err
+	}
+
+	collInfo, err := t.core.MetaTable.GetCollectionByName(t.Req.CollectionName, 0)
+	if err != nil {
+		return err
+	}
+
+	ts, err := t.core.TSOAllocator(1)
+	if err != nil {
+		return fmt.Errorf("TSO alloc fail, error = %w", err)
+	}
+	err = t.core.MetaTable.AlterCollection(collInfo.ID, t.Req.Properties, ts)
+	if err != nil {
+		return fmt.Errorf("meta table alter collection failed, error = %w", err)
+	}
+
+	// the proxy caches the collection meta by the name it is requested with, so invalidate all of the aliases too
+	names := append([]string{collInfo.Schema.Name}, t.core.MetaTable.ListAliases(collInfo.ID)...)
+	for _, name := range names {
+		req := proxypb.InvalidateCollMetaCacheRequest{
+			Base: &commonpb.MsgBase{
+				MsgType:   0, //TODO, msg type
+				MsgID:     0, //TODO, msg id
+				Timestamp: ts,
+				SourceID:  t.core.session.ServerID,
+			},
+			CollectionName: name,
+		}
+		// error doesn't matter here
+		t.core.proxyClientManager.InvalidateCollectionMetaCache(ctx, &req)
+	}
+
+	return nil
+}
@@ -12,9 +12,12 @@
 package rootcoord
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/metrics"
@@ -29,10 +32,21 @@ import (
 )
 
 type timetickSync struct {
-	core          *Core
-	lock          sync.Mutex
-	proxyTimeTick map[typeutil.UniqueID]*channelTimeTickMsg
-	sendChan      chan map[typeutil.UniqueID]*channelTimeTickMsg
+	ctx  context.Context
+	core *Core
+
+	// lock protects proxyTimeTick and proxyLastReport, the reports are applied by the aggregation loop,
+	// the report path only takes the read lock to recognize the proxy
+	lock            sync.RWMutex
+	proxyTimeTick   map[typeutil.UniqueID]*channelTimeTickMsg
+	proxyLastReport map[typeutil.UniqueID]time.Time
+	// proxyStale records the proxies excluded from the watermark, it's only accessed by the aggregation loop
+	proxyStale map[typeutil.UniqueID]bool
+	// staleThreshold is the time a proxy may stay silent before it's excluded from the watermark, 0 means never
+	staleThreshold time.Duration
+
+	inputChan chan *channelTimeTickMsg
+	sendChan  chan map[typeutil.UniqueID]*channelTimeTickMsg
 
 	// record ddl timetick info
 	ddlLock  sync.RWMutex
@@ -64,12 +78,17 @@ func (c *channelTimeTickMsg) getTimetick(channelName string) typeutil.Timestamp
 	return c.in.DefaultTimestamp
 }
 
-func newTimeTickSync(core *Core) *timetickSync {
+func newTimeTickSync(ctx context.Context, core *Core) *timetickSync {
 	return &timetickSync{
-		lock:          sync.Mutex{},
-		core:          core,
-		proxyTimeTick: make(map[typeutil.UniqueID]*channelTimeTickMsg),
-		sendChan:      make(chan map[typeutil.UniqueID]*channelTimeTickMsg, 16),
+		ctx:             ctx,
+		core:            core,
+		lock:            sync.RWMutex{},
+		proxyTimeTick:   make(map[typeutil.UniqueID]*channelTimeTickMsg),
+		proxyLastReport: make(map[typeutil.UniqueID]time.Time),
+		proxyStale:      make(map[typeutil.UniqueID]bool),
+		staleThreshold:  Params.ProxyTimeTickStaleThreshold,
+		inputChan:       make(chan *channelTimeTickMsg, 1024),
+		sendChan:        make(chan map[typeutil.UniqueID]*channelTimeTickMsg, 16),
 
 		ddlLock:  sync.RWMutex{},
 		ddlMinTs: typeutil.Timestamp(math.MaxUint64),
@@ -77,24 +96,51 @@ func newTimeTickSync(core *Core) *timetickSync {
 	}
 }
 
-// sendToChannel send all channels' timetick to sendChan
+// isStale tells if the proxy doesn't report longer than the threshold, rootcoord itself is never stale
 // lock is needed by the invoker
-func (t *timetickSync) sendToChannel() {
+func (t *timetickSync) isStale(sourceID typeutil.UniqueID, now time.Time) bool {
+	if t.staleThreshold <= 0 || sourceID == t.core.session.ServerID {
+		return false
+	}
+	return now.Sub(t.proxyLastReport[sourceID]) > t.staleThreshold
+}
+
+// collectTimeTick returns all channels' timetick once every live proxy reports, stale proxies are skipped,
+// it returns nil if some live proxy hasn't reported yet
+// lock is needed by the invoker
+func (t *timetickSync) collectTimeTick(now time.Time) map[typeutil.UniqueID]*channelTimeTickMsg {
 	if len(t.proxyTimeTick) == 0 {
-		return
+		return nil
 	}
-	for _, v := range t.proxyTimeTick {
-		if v == nil {
-			return
+	for k, v := range t.proxyTimeTick {
+		if v == nil && !t.isStale(k, now) {
+			return nil
 		}
 	}
-	// clear proxyTimeTick and send a clone
+	// clear proxyTimeTick and return a clone
 	ptt := make(map[typeutil.UniqueID]*channelTimeTickMsg)
 	for k, v := range t.proxyTimeTick {
+		if v == nil {
+			continue
+		}
 		ptt[k] = v
 		t.proxyTimeTick[k] = nil
 	}
-	t.sendChan <- ptt
+	if len(ptt) == 0 {
+		return nil
+	}
+	return ptt
+}
+
+// sendToChannel send the collected timetick to sendChan, it must not be invoked with lock held
+func (t *timetickSync) sendToChannel(ptt map[typeutil.UniqueID]*channelTimeTickMsg) {
+	if ptt == nil {
+		return
+	}
+	select {
+	case t.sendChan <- ptt:
+	case <-t.ctx.Done():
+	}
 }
 
 // AddDmlTimeTick add ts into ddlTimetickInfos[sourceID],
@@ -143,10 +189,8 @@ func (t *timetickSync) GetDdlMinTimeTick() typeutil.Timestamp {
 	return t.ddlMinTs
 }
 
-// UpdateTimeTick check msg validation and send it to local channel
+// UpdateTimeTick check msg validation and send it to the aggregation loop
 func (t *timetickSync) UpdateTimeTick(in *internalpb.ChannelTimeTickMsg, reason string) error {
-	t.lock.Lock()
-	defer t.lock.Unlock()
 	if len(in.ChannelNames) == 0 && in.DefaultTimestamp == 0 {
 		return nil
 	}
@@ -154,7 +198,9 @@ func (t *timetickSync) UpdateTimeTick(in *internalpb.ChannelTimeTickMsg, reason
 		return fmt.Errorf("Invalid TimeTickMsg")
 	}
 
-	prev, ok := t.proxyTimeTick[in.Base.SourceID]
+	t.lock.RLock()
+	_, ok := t.proxyTimeTick[in.Base.SourceID]
+	t.lock.RUnlock()
 	if !ok {
 		return fmt.Errorf("Skip ChannelTimeTickMsg from un-recognized proxy node %d", in.Base.SourceID)
 	}
@@ -169,15 +215,6 @@ func (t *timetickSync) UpdateTimeTick(in *internalpb.ChannelTimeTickMsg, reason
 		return nil
 	}
 
-	if in.Base.SourceID == t.core.session.ServerID {
-		if prev != nil && in.DefaultTimestamp <= prev.in.DefaultTimestamp {
-			log.Debug("timestamp go back", zap.Int64("source id", in.Base.SourceID),
-				zap.Uint64("curr ts", in.DefaultTimestamp),
-				zap.Uint64("prev ts", prev.in.DefaultTimestamp),
-				zap.String("reason", reason))
-			return nil
-		}
-	}
 	if in.DefaultTimestamp == 0 {
 		mints := minTimeTick(in.Timestamps...)
 		log.Debug("default time stamp is zero, set it to the min value of inputs",
@@ -185,34 +222,119 @@ func (t *timetickSync) UpdateTimeTick(in *internalpb.ChannelTimeTickMsg, reason
 		in.DefaultTimestamp = mints
 	}
 
-	t.proxyTimeTick[in.Base.SourceID] = newChannelTimeTickMsg(in)
-	//log.Debug("update proxyTimeTick", zap.Int64("source id", in.Base.SourceID),
-	//	zap.Any("Ts", in.Timestamps), zap.Uint64("inTs", in.DefaultTimestamp), zap.String("reason", reason))
+	select {
+	case t.inputChan <- newChannelTimeTickMsg(in):
+		return nil
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	}
+}
 
-	t.sendToChannel()
-	return nil
+// applyTimeTick records the timetick reported by a proxy, it's invoked by the aggregation loop
+func (t *timetickSync) applyTimeTick(msg *channelTimeTickMsg, now time.Time) {
+	sourceID := msg.in.Base.SourceID
+	t.lock.Lock()
+	prev, ok := t.proxyTimeTick[sourceID]
+	if !ok {
+		t.lock.Unlock()
+		log.Debug("skip ChannelTimeTickMsg from removed proxy node", zap.Int64("source id", sourceID))
+		return
+	}
+	if sourceID == t.core.session.ServerID {
+		if prev != nil && msg.in.DefaultTimestamp <= prev.in.DefaultTimestamp {
+			t.lock.Unlock()
+			log.Debug("timestamp go back", zap.Int64("source id", sourceID),
+				zap.Uint64("curr ts", msg.in.DefaultTimestamp),
+				zap.Uint64("prev ts", prev.in.DefaultTimestamp))
+			return
+		}
+	}
+	t.proxyTimeTick[sourceID] = msg
+	t.proxyLastReport[sourceID] = now
+	ptt := t.collectTimeTick(now)
+	t.lock.Unlock()
+
+	if t.proxyStale[sourceID] {
+		delete(t.proxyStale, sourceID)
+		log.Info("proxy reports time tick again, include it in the time tick watermark", zap.Int64("proxy id", sourceID))
+	}
+	t.sendToChannel(ptt)
+}
+
+// checkStaleProxies logs the proxies which become stale, and sends the timetick which was blocked by them
+func (t *timetickSync) checkStaleProxies(now time.Time) {
+	t.lock.Lock()
+	for sourceID, last := range t.proxyLastReport {
+		staleness := now.Sub(last)
+		metrics.RootCoordProxyTimeTickStaleness.WithLabelValues(strconv.FormatInt(sourceID, 10)).Set(float64(staleness.Milliseconds()))
+		if t.isStale(sourceID, now) && !t.proxyStale[sourceID] {
+			t.proxyStale[sourceID] = true
+			log.Warn("proxy doesn't report time tick, exclude it from the time tick watermark until it reports again",
+				zap.Int64("proxy id", sourceID),
+				zap.Duration("staleness", staleness),
+				zap.Duration("threshold", t.staleThreshold))
+		}
+	}
+	ptt := t.collectTimeTick(now)
+	t.lock.Unlock()
+	t.sendToChannel(ptt)
+}
+
+// staleCheckInterval returns the interval to check stale proxies, it's short enough to resume the watermark in time
+func (t *timetickSync) staleCheckInterval() time.Duration {
+	if t.staleThreshold > 0 && t.staleThreshold/2 < time.Second {
+		return t.staleThreshold / 2
+	}
+	return time.Second
+}
+
+// StartAggregation applies the timetick reported by proxies, and sends all channels' timetick to StartWatch
+// once every live proxy reports
+func (t *timetickSync) StartAggregation(wg *sync.WaitGroup) {
+	defer wg.Done()
+	ticker := time.NewTicker(t.staleCheckInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.ctx.Done():
+			log.Debug("timetickSync aggregation context done", zap.Error(t.ctx.Err()))
+			return
+		case msg := <-t.inputChan:
+			t.applyTimeTick(msg, time.Now())
+		case now := <-ticker.C:
+			t.checkStaleProxies(now)
+		}
+	}
 }
 
 func (t *timetickSync) AddProxy(sess *sessionutil.Session) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	t.proxyTimeTick[sess.ServerID] = nil
+	t.proxyLastReport[sess.ServerID] = time.Now()
 }
 
 func (t *timetickSync) DelProxy(sess *sessionutil.Session) {
 	t.lock.Lock()
-	defer t.lock.Unlock()
-	if _, ok := t.proxyTimeTick[sess.ServerID]; ok {
-		delete(t.proxyTimeTick, sess.ServerID)
-		t.sendToChannel()
+	if _, ok := t.proxyTimeTick[sess.ServerID]; !ok {
+		t.lock.Unlock()
+		return
 	}
+	delete(t.proxyTimeTick, sess.ServerID)
+	delete(t.proxyLastReport, sess.ServerID)
+	metrics.RootCoordProxyTimeTickStaleness.DeleteLabelValues(strconv.FormatInt(sess.ServerID, 10))
+	ptt := t.collectTimeTick(time.Now())
+	t.lock.Unlock()
+	t.sendToChannel(ptt)
 }
 
 func (t *timetickSync) GetProxy(sess []*sessionutil.Session) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
+	now := time.Now()
 	for _, s := range sess {
 		t.proxyTimeTick[s.ServerID] = nil
+		t.proxyLastReport[s.ServerID] = now
 	}
 }
 
@@ -221,8 +343,8 @@ func (t *timetickSync) StartWatch(wg *sync.WaitGroup) {
 	defer wg.Done()
 	for {
 		select {
-		case <-t.core.ctx.Done():
-			log.Debug("rootcoord context done", zap.Error(t.core.ctx.Err()))
+		case <-t.ctx.Done():
+			log.Debug("rootcoord context done", zap.Error(t.ctx.Err()))
 			return
 		case proxyTimetick, ok := <-t.sendChan:
 			if !ok {
@@ -232,7 +354,7 @@ func (t *timetickSync) StartWatch(wg *sync.WaitGroup) {
 
 			// reduce each channel to get min timestamp
 			local := proxyTimetick[t.core.session.ServerID]
-			if len(local.in.ChannelNames) == 0 {
+			if local == nil || len(local.in.ChannelNames) == 0 {
 				continue
 			}
 
@@ -293,16 +415,19 @@ func (t *timetickSync) SendTimeTickToChannel(chanNames []string, ts typeutil.Tim
 		return err
 	}
 
+	physicalTs, _ := tsoutil.ParseTS(ts)
+	lag := time.Since(physicalTs).Milliseconds()
 	for _, chanName := range chanNames {
 		metrics.RootCoordInsertChannelTimeTick.WithLabelValues(chanName).Set(float64(tsoutil.Mod24H(ts)))
+		metrics.RootCoordTimeTickWatermarkLag.WithLabelValues(chanName).Set(float64(lag))
 	}
 	return nil
 }
 
 // GetProxyNum return the num of detected proxy node
 func (t *timetickSync) GetProxyNum() int {
-	t.lock.Lock()
-	defer t.lock.Unlock()
+	t.lock.RLock()
+	defer t.lock.RUnlock()
 	return len(t.proxyTimeTick)
 }
 
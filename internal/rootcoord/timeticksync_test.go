@@ -15,16 +15,41 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestTimetickSync_sendToChannel(t *testing.T) {
-	tt := newTimeTickSync(nil)
-	tt.sendToChannel()
+func newTestCore(serverID typeutil.UniqueID) *Core {
+	return &Core{
+		ctx:     context.TODO(),
+		ddlLock: sync.Mutex{},
+		session: &sessionutil.Session{
+			ServerID: serverID,
+		},
+	}
+}
+
+func newTestChannelTimeTickMsg(sourceID typeutil.UniqueID, ts typeutil.Timestamp) *internalpb.ChannelTimeTickMsg {
+	return &internalpb.ChannelTimeTickMsg{
+		Base: &commonpb.MsgBase{
+			MsgType:  commonpb.MsgType_TimeTick,
+			SourceID: sourceID,
+		},
+		ChannelNames:     []string{"a"},
+		Timestamps:       []uint64{ts},
+		DefaultTimestamp: ts,
+	}
+}
+
+func TestTimetickSync_collectTimeTick(t *testing.T) {
+	tt := newTimeTickSync(context.TODO(), newTestCore(100))
+	now := time.Now()
+	assert.Nil(t, tt.collectTimeTick(now))
 
 	ctt := &internalpb.ChannelTimeTickMsg{
 		Base: &commonpb.MsgBase{
@@ -34,14 +59,31 @@ func TestTimetickSync_sendToChannel(t *testing.T) {
 
 	cttm := newChannelTimeTickMsg(ctt)
 	tt.proxyTimeTick[1] = cttm
-	tt.sendToChannel()
-
+	tt.proxyLastReport[1] = now
 	tt.proxyTimeTick[2] = nil
-	tt.sendToChannel()
+	tt.proxyLastReport[2] = now
+	tt.staleThreshold = time.Second
+	// proxy 2 hasn't reported
+	assert.Nil(t, tt.collectTimeTick(now))
+	assert.Equal(t, cttm, tt.proxyTimeTick[1])
+
+	// proxy 2 is stale
+	ptt := tt.collectTimeTick(now.Add(2 * time.Second))
+	assert.Equal(t, 1, len(ptt))
+	assert.Equal(t, cttm, ptt[1])
+	assert.Nil(t, tt.proxyTimeTick[1])
+
+	// nothing reported since last collection
+	assert.Nil(t, tt.collectTimeTick(now.Add(2*time.Second)))
+
+	// never stale without threshold
+	tt.staleThreshold = 0
+	tt.proxyTimeTick[1] = cttm
+	assert.Nil(t, tt.collectTimeTick(now.Add(time.Hour)))
 }
 
 func TestTimetickSync_RemoveDdlTimeTick(t *testing.T) {
-	tt := newTimeTickSync(nil)
+	tt := newTimeTickSync(context.TODO(), nil)
 	tt.AddDdlTimeTick(uint64(1), "1")
 	tt.AddDdlTimeTick(uint64(2), "2")
 	tt.RemoveDdlTimeTick(uint64(1), "1")
@@ -49,7 +91,7 @@ func TestTimetickSync_RemoveDdlTimeTick(t *testing.T) {
 }
 
 func TestTimetickSync_UpdateTimeTick(t *testing.T) {
-	tt := newTimeTickSync(nil)
+	tt := newTimeTickSync(context.TODO(), newTestCore(100))
 
 	ctt := &internalpb.ChannelTimeTickMsg{
 		Base: &commonpb.MsgBase{
@@ -65,31 +107,135 @@ func TestTimetickSync_UpdateTimeTick(t *testing.T) {
 	err = tt.UpdateTimeTick(ctt, "1")
 	assert.Error(t, err)
 
-	core := &Core{
-		ctx:       context.TODO(),
-		cancel:    nil,
-		ddlLock:   sync.Mutex{},
-		msFactory: nil,
-		session: &sessionutil.Session{
-			ServerID: 100,
-		},
-	}
-	tt.core = core
-
 	ctt.Timestamps = append(ctt.Timestamps, uint64(2))
 	ctt.Base.SourceID = int64(1)
-	cttm := newChannelTimeTickMsg(ctt)
-	tt.proxyTimeTick[ctt.Base.SourceID] = cttm
+	// un-recognized proxy
+	err = tt.UpdateTimeTick(ctt, "1")
+	assert.Error(t, err)
+
+	tt.proxyTimeTick[ctt.Base.SourceID] = nil
 	ctt.DefaultTimestamp = uint64(200)
 	tt.ddlMinTs = uint64(100)
+	// ddl not finished
 	err = tt.UpdateTimeTick(ctt, "1")
 	assert.Nil(t, err)
+	assert.Equal(t, 0, len(tt.inputChan))
 
 	tt.ddlMinTs = uint64(300)
-	tt.proxyTimeTick[ctt.Base.SourceID].in.DefaultTimestamp = uint64(1)
-	tt.core.session.ServerID = int64(1)
 	err = tt.UpdateTimeTick(ctt, "1")
 	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tt.inputChan))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	tt = newTimeTickSync(ctx, newTestCore(100))
+	tt.inputChan = make(chan *channelTimeTickMsg)
+	tt.proxyTimeTick[ctt.Base.SourceID] = nil
+	err = tt.UpdateTimeTick(ctt, "1")
+	assert.Error(t, err)
+}
+
+func TestTimetickSync_applyTimeTick(t *testing.T) {
+	tt := newTimeTickSync(context.TODO(), newTestCore(100))
+	now := time.Now()
+
+	// removed proxy
+	tt.applyTimeTick(newChannelTimeTickMsg(newTestChannelTimeTickMsg(1, 10)), now)
+	assert.Equal(t, 0, len(tt.proxyTimeTick))
+
+	tt.AddProxy(&sessionutil.Session{ServerID: 100})
+	tt.AddProxy(&sessionutil.Session{ServerID: 1})
+	tt.applyTimeTick(newChannelTimeTickMsg(newTestChannelTimeTickMsg(100, 10)), now)
+	// timestamp of rootcoord goes back
+	tt.applyTimeTick(newChannelTimeTickMsg(newTestChannelTimeTickMsg(100, 5)), now)
+	assert.Equal(t, uint64(10), tt.proxyTimeTick[100].in.DefaultTimestamp)
+	assert.Equal(t, 0, len(tt.sendChan))
+
+	tt.applyTimeTick(newChannelTimeTickMsg(newTestChannelTimeTickMsg(1, 8)), now)
+	assert.Equal(t, 1, len(tt.sendChan))
+	ptt := <-tt.sendChan
+	assert.Equal(t, 2, len(ptt))
+	assert.Equal(t, uint64(8), ptt[1].getTimetick("a"))
+	assert.Equal(t, uint64(10), ptt[100].getTimetick("a"))
+	assert.Nil(t, tt.proxyTimeTick[1])
+	assert.Nil(t, tt.proxyTimeTick[100])
+	assert.Equal(t, 2, tt.GetProxyNum())
+
+	// the session of proxy 1 is gone
+	tt.applyTimeTick(newChannelTimeTickMsg(newTestChannelTimeTickMsg(100, 20)), now)
+	assert.Equal(t, 0, len(tt.sendChan))
+	tt.DelProxy(&sessionutil.Session{ServerID: 1})
+	assert.Equal(t, 1, len(tt.sendChan))
+	ptt = <-tt.sendChan
+	assert.Equal(t, 1, len(ptt))
+	assert.Equal(t, uint64(20), ptt[100].getTimetick("a"))
+	assert.Equal(t, 1, tt.GetProxyNum())
+	tt.DelProxy(&sessionutil.Session{ServerID: 1})
+}
+
+func TestTimetickSync_StaleProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	tt := newTimeTickSync(ctx, newTestCore(100))
+	tt.staleThreshold = 200 * time.Millisecond
+	tt.GetProxy([]*sessionutil.Session{{ServerID: 100}, {ServerID: 1}, {ServerID: 2}})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go tt.StartAggregation(&wg)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var ts typeutil.Timestamp
+	report := func(sourceIDs ...typeutil.UniqueID) {
+		ts++
+		for _, sourceID := range sourceIDs {
+			assert.Nil(t, tt.UpdateTimeTick(newTestChannelTimeTickMsg(sourceID, ts), "test"))
+		}
+	}
+	receive := func(timeout time.Duration) map[typeutil.UniqueID]*channelTimeTickMsg {
+		select {
+		case ptt := <-tt.sendChan:
+			return ptt
+		case <-time.After(timeout):
+			return nil
+		}
+	}
+
+	report(100, 1, 2)
+	ptt := receive(time.Second)
+	assert.Equal(t, 3, len(ptt))
+
+	// proxy 2 is killed, the watermark stops until proxy 2 is stale
+	report(100, 1)
+	assert.Nil(t, receive(50*time.Millisecond))
+
+	deadline := time.Now().Add(tt.staleThreshold + time.Second)
+	for ptt = nil; ptt == nil && time.Now().Before(deadline); {
+		report(100, 1)
+		ptt = receive(20 * time.Millisecond)
+	}
+	assert.Equal(t, 2, len(ptt))
+	_, ok := ptt[2]
+	assert.False(t, ok)
+
+	// the watermark keeps advancing without proxy 2
+	report(100, 1)
+	ptt = receive(time.Second)
+	assert.Equal(t, 2, len(ptt))
+	assert.Equal(t, ts, ptt[100].getTimetick("a"))
+
+	// proxy 2 recovers, and it's waited for again
+	report(2)
+	report(100, 1)
+	ptt = receive(time.Second)
+	assert.Equal(t, 3, len(ptt))
+	report(100, 1)
+	assert.Nil(t, receive(50*time.Millisecond))
+	report(2)
+	ptt = receive(time.Second)
+	assert.Equal(t, 3, len(ptt))
 }
 
 func Test_minTimeTick(t *testing.T) {
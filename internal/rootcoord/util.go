@@ -20,6 +20,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/channelutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
@@ -125,14 +126,5 @@ func DecodeMsgPositions(str string, msgPositions *[]*msgstream.MsgPosition) erro
 
 // ToPhysicalChannel get physical channel name from virtual channel name
 func ToPhysicalChannel(vchannel string) string {
-	var idx int
-	for idx = len(vchannel) - 1; idx >= 0; idx-- {
-		if vchannel[idx] == '_' {
-			break
-		}
-	}
-	if idx < 0 {
-		return vchannel
-	}
-	return vchannel[:idx]
+	return channelutil.ToPhysicalChannel(vchannel)
 }
@@ -13,6 +13,7 @@ package storage
 
 import (
 	"errors"
+	"fmt"
 	"sync/atomic"
 
 	"github.com/milvus-io/milvus/internal/rootcoord"
@@ -43,6 +44,22 @@ type Value struct {
 	value     interface{}
 }
 
+// ID returns the row id of the record
+func (v *Value) ID() int64 {
+	return v.id
+}
+
+// Timestamp returns the timestamp of the record
+func (v *Value) Timestamp() int64 {
+	return v.timestamp
+}
+
+// Row returns the values of the fields of the record, it's nil if the record isn't a row of binlogs
+func (v *Value) Row() map[FieldID]interface{} {
+	row, _ := v.value.(map[FieldID]interface{})
+	return row
+}
+
 // InsertBinlogIterator is the iterator of binlog
 type InsertBinlogIterator struct {
 	dispose int32 // 0: false, 1: true
@@ -113,6 +130,143 @@ func (itr *InsertBinlogIterator) isDisposed() bool {
 	return atomic.LoadInt32(&itr.dispose) == 1
 }
 
+// BinlogRowIterator is the iterator of the insert binlogs of a segment, the binlogs are read in batches, so that
+// only a batch of rows of each field is kept in memory.
+type BinlogRowIterator struct {
+	dispose   int32 // 0: false, 1: true
+	readers   map[FieldID]*BinlogReader
+	batchSize int
+	batch     map[FieldID]FieldData
+	pos       int
+	rows      int
+	err       error
+}
+
+// NewBinlogRowIterator creates a new iterator of the binlog readers of the fields, the readers are closed
+// when the iterator is disposed.
+func NewBinlogRowIterator(readers map[FieldID]*BinlogReader, batchSize int) (*BinlogRowIterator, error) {
+	if _, ok := readers[rootcoord.RowIDField]; !ok {
+		return nil, errors.New("binlog of row id field is missing")
+	}
+	if _, ok := readers[rootcoord.TimeStampField]; !ok {
+		return nil, errors.New("binlog of timestamp field is missing")
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", batchSize)
+	}
+	return &BinlogRowIterator{
+		readers:   readers,
+		batchSize: batchSize,
+	}, nil
+}
+
+// HasNext returns true if the iterator have unread record
+func (itr *BinlogRowIterator) HasNext() bool {
+	return !itr.isDisposed() && itr.hasNext()
+}
+
+// Next returns the next record
+func (itr *BinlogRowIterator) Next() (interface{}, error) {
+	if itr.isDisposed() {
+		return nil, ErrDisposed
+	}
+
+	if !itr.hasNext() {
+		if itr.err != nil {
+			return nil, itr.err
+		}
+		return nil, ErrNoMoreRecord
+	}
+
+	m := make(map[FieldID]interface{})
+	for fieldID, fieldData := range itr.batch {
+		m[fieldID] = getFieldDataRow(fieldData, itr.pos)
+	}
+
+	v := &Value{
+		id:        m[rootcoord.RowIDField].(int64),
+		timestamp: m[rootcoord.TimeStampField].(int64),
+		isDeleted: false,
+		value:     m,
+	}
+	itr.pos++
+	return v, nil
+}
+
+// Err returns the error stopped the iteration, HasNext returns false once the binlogs fail to read.
+func (itr *BinlogRowIterator) Err() error {
+	return itr.err
+}
+
+// Dispose disposes the iterator
+func (itr *BinlogRowIterator) Dispose() {
+	if atomic.CompareAndSwapInt32(&itr.dispose, 0, 1) {
+		for _, reader := range itr.readers {
+			reader.Close()
+		}
+		itr.batch = nil
+	}
+}
+
+func (itr *BinlogRowIterator) hasNext() bool {
+	if itr.err != nil {
+		return false
+	}
+	if itr.pos < itr.rows {
+		return true
+	}
+
+	rows := -1
+	batch := make(map[FieldID]FieldData, len(itr.readers))
+	for fieldID, reader := range itr.readers {
+		data, err := reader.NextBatch(itr.batchSize)
+		if err != nil {
+			itr.err = err
+			return false
+		}
+		n := 0
+		if data != nil {
+			n = getFieldDataRows(data)
+		}
+		if rows >= 0 && n != rows {
+			itr.err = fmt.Errorf("binlog of field %d has %d rows, expected %d rows", fieldID, n, rows)
+			return false
+		}
+		rows = n
+		batch[fieldID] = data
+	}
+	itr.batch, itr.pos, itr.rows = batch, 0, rows
+	return rows > 0
+}
+
+func (itr *BinlogRowIterator) isDisposed() bool {
+	return atomic.LoadInt32(&itr.dispose) == 1
+}
+
+// getFieldDataRows returns the number of rows of the field data, the vectors are counted by dimension.
+func getFieldDataRows(data FieldData) int {
+	switch d := data.(type) {
+	case *BinaryVectorFieldData:
+		return len(d.Data) * 8 / d.Dim
+	case *FloatVectorFieldData:
+		return len(d.Data) / d.Dim
+	default:
+		return data.Length()
+	}
+}
+
+// getFieldDataRow returns the i-th row of the field data, the row of vectors is the vector.
+func getFieldDataRow(data FieldData, i int) interface{} {
+	switch d := data.(type) {
+	case *BinaryVectorFieldData:
+		return d.Data[i*d.Dim/8 : (i+1)*d.Dim/8]
+	case *FloatVectorFieldData:
+		return d.Data[i*d.Dim : (i+1)*d.Dim]
+	default:
+		return data.Get(i)
+	}
+}
+
 /*
 type DeltalogIterator struct {
 	dispose int32
@@ -182,12 +336,25 @@ type MergeIterator struct {
 	iteraotrs  []Iterator
 	tmpRecords []*Value
 	nextRecord *Value
+	less       func(a, b *Value) bool
 }
 
+// NewMergeIterator creates an iterator merging the records of the iterators sorted by id.
 func NewMergeIterator(iterators []Iterator) *MergeIterator {
 	return &MergeIterator{
 		iteraotrs:  iterators,
 		tmpRecords: make([]*Value, len(iterators)),
+		less:       func(a, b *Value) bool { return a.id < b.id },
+	}
+}
+
+// NewTimestampMergeIterator creates an iterator merging the records of the iterators sorted by timestamp,
+// the records of each iterator should be sorted by timestamp, e.g. the rows of the binlogs of segments.
+func NewTimestampMergeIterator(iterators []Iterator) *MergeIterator {
+	return &MergeIterator{
+		iteraotrs:  iterators,
+		tmpRecords: make([]*Value, len(iterators)),
+		less:       func(a, b *Value) bool { return a.timestamp < b.timestamp },
 	}
 }
 
@@ -225,6 +392,16 @@ func (itr *MergeIterator) Dispose() {
 	atomic.CompareAndSwapInt32(&itr.disposed, 0, 1)
 }
 
+// Err returns the first error stopped the iterators merged.
+func (itr *MergeIterator) Err() error {
+	for _, tmpItr := range itr.iteraotrs {
+		if errItr, ok := tmpItr.(interface{ Err() error }); ok && errItr.Err() != nil {
+			return errItr.Err()
+		}
+	}
+	return nil
+}
+
 func (itr *MergeIterator) isDisposed() bool {
 	return atomic.LoadInt32(&itr.disposed) == 1
 }
@@ -247,7 +424,7 @@ func (itr *MergeIterator) hasNext() bool {
 		if tmpRecord == nil {
 			continue
 		}
-		if minRecord == nil || tmpRecord.id < minRecord.id {
+		if minRecord == nil || itr.less(tmpRecord, minRecord) {
 			minRecord = tmpRecord
 			minPos = i
 		}
@@ -12,12 +12,15 @@
 package storage
 
 import (
+	"bytes"
+	"strconv"
 	"testing"
 
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
 	"github.com/milvus-io/milvus/internal/rootcoord"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func generateTestData(t *testing.T, num int) []*Blob {
@@ -174,3 +177,124 @@ func TestMergeIterator(t *testing.T) {
 		assert.Equal(t, ErrDisposed, err)
 	})
 }
+
+func generateTimestampTestData(t *testing.T, rowIDs []int64, timestamps []int64) []*Blob {
+	schema := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{
+		{FieldID: rootcoord.TimeStampField, Name: "ts", DataType: schemapb.DataType_Int64},
+		{FieldID: rootcoord.RowIDField, Name: "rowid", DataType: schemapb.DataType_Int64},
+		{FieldID: 101, Name: "int32", DataType: schemapb.DataType_Int32},
+	}}
+	insertCodec := NewInsertCodec(&etcdpb.CollectionMeta{ID: 1, Schema: schema})
+	defer insertCodec.Close()
+
+	values := make([]int32, len(rowIDs))
+	for i, rowID := range rowIDs {
+		values[i] = int32(rowID)
+	}
+	data := &InsertData{Data: map[FieldID]FieldData{
+		rootcoord.TimeStampField: &Int64FieldData{NumRows: []int64{int64(len(timestamps))}, Data: timestamps},
+		rootcoord.RowIDField:     &Int64FieldData{NumRows: []int64{int64(len(rowIDs))}, Data: rowIDs},
+		101:                      &Int32FieldData{NumRows: []int64{int64(len(values))}, Data: values},
+	}}
+	blobs, _, err := insertCodec.Serialize(1, 1, data)
+	require.Nil(t, err)
+	return blobs
+}
+
+func newTestBinlogRowIterator(t *testing.T, blobs []*Blob, batchSize int) *BinlogRowIterator {
+	readers := make(map[FieldID]*BinlogReader)
+	for _, blob := range blobs {
+		fieldID, err := strconv.ParseInt(blob.Key, 10, 64)
+		require.Nil(t, err)
+		readers[fieldID], err = NewBinlogStreamReader(bytes.NewReader(blob.Value))
+		require.Nil(t, err)
+	}
+	itr, err := NewBinlogRowIterator(readers, batchSize)
+	require.Nil(t, err)
+	return itr
+}
+
+func TestBinlogRowIterator(t *testing.T) {
+	t.Run("invalid readers", func(t *testing.T) {
+		_, err := NewBinlogRowIterator(map[FieldID]*BinlogReader{}, 1)
+		assert.Error(t, err)
+		_, err = NewBinlogRowIterator(map[FieldID]*BinlogReader{rootcoord.RowIDField: nil}, 1)
+		assert.Error(t, err)
+		_, err = NewBinlogRowIterator(map[FieldID]*BinlogReader{rootcoord.RowIDField: nil, rootcoord.TimeStampField: nil}, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		itr := newTestBinlogRowIterator(t, generateTestData(t, 5), 2)
+		defer itr.Dispose()
+
+		for i := 1; i <= 5; i++ {
+			assert.True(t, itr.HasNext())
+			v, err := itr.Next()
+			assert.Nil(t, err)
+			expected := &Value{
+				int64(i),
+				int64(i),
+				false,
+				map[FieldID]interface{}{rootcoord.TimeStampField: int64(i), rootcoord.RowIDField: int64(i), 101: int32(i)},
+			}
+			assert.EqualValues(t, expected, v)
+			assert.Equal(t, int64(i), v.(*Value).ID())
+			assert.Equal(t, int64(i), v.(*Value).Timestamp())
+			assert.Equal(t, int32(i), v.(*Value).Row()[101])
+		}
+		assert.False(t, itr.HasNext())
+		_, err := itr.Next()
+		assert.Equal(t, ErrNoMoreRecord, err)
+		assert.Nil(t, itr.Err())
+	})
+
+	t.Run("mismatched rows", func(t *testing.T) {
+		// the int32 field has less rows than the others
+		blobs := generateTestData(t, 3)
+		for i, blob := range generateTestData(t, 2) {
+			if blob.Key == strconv.FormatInt(101, 10) {
+				blobs[i] = blob
+			}
+		}
+		itr := newTestBinlogRowIterator(t, blobs, 10)
+		defer itr.Dispose()
+
+		assert.False(t, itr.HasNext())
+		_, err := itr.Next()
+		assert.Error(t, err)
+		assert.Equal(t, err, itr.Err())
+	})
+
+	t.Run("test dispose", func(t *testing.T) {
+		itr := newTestBinlogRowIterator(t, generateTestData(t, 3), 2)
+		itr.Dispose()
+		assert.False(t, itr.HasNext())
+		_, err := itr.Next()
+		assert.Equal(t, ErrDisposed, err)
+	})
+}
+
+func TestTimestampMergeIterator(t *testing.T) {
+	itr1 := newTestBinlogRowIterator(t, generateTimestampTestData(t, []int64{1, 2, 3}, []int64{10, 40, 50}), 2)
+	itr2 := newTestBinlogRowIterator(t, generateTimestampTestData(t, []int64{4, 5}, []int64{20, 30}), 2)
+	defer func() {
+		itr1.Dispose()
+		itr2.Dispose()
+	}()
+	itr := NewTimestampMergeIterator([]Iterator{itr1, &InsertBinlogIterator{data: &InsertData{}}, itr2})
+
+	var rowIDs, timestamps []int64
+	for itr.HasNext() {
+		v, err := itr.Next()
+		assert.Nil(t, err)
+		value := v.(*Value)
+		rowIDs = append(rowIDs, value.id)
+		timestamps = append(timestamps, value.timestamp)
+	}
+	assert.Nil(t, itr.Err())
+	assert.Equal(t, []int64{1, 4, 5, 2, 3}, rowIDs)
+	assert.Equal(t, []int64{10, 20, 30, 40, 50}, timestamps)
+	_, err := itr.Next()
+	assert.Equal(t, ErrNoMoreRecord, err)
+}
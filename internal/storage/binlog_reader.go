@@ -13,7 +13,12 @@ package storage
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"io"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
 )
 
 // BinlogReader is an object to read binlog file. Binlog file's format can be
@@ -21,9 +26,22 @@ import (
 type BinlogReader struct {
 	magicNumber int32
 	descriptorEvent
+	// buffer is the whole binlog file, it's nil if the binlog is read from a stream
 	buffer    *bytes.Buffer
+	reader    io.Reader
 	eventList []*EventReader
 	isClose   bool
+	codec     CompressionType
+	// checksum is true if each event ends with its checksum, offset is the offset of the next event
+	checksum bool
+	offset   int64
+
+	// batchEvent is the event being read by NextBatch
+	batchEvent *EventReader
+	batchData  interface{}
+	batchDim   int
+	batchPos   int
+	batchRows  int
 }
 
 // NextEventReader iters all events reader to read the binlog file.
@@ -31,10 +49,11 @@ func (reader *BinlogReader) NextEventReader() (*EventReader, error) {
 	if reader.isClose {
 		return nil, errors.New("bin log reader is closed")
 	}
-	if reader.buffer.Len() <= 0 {
-		return nil, nil
+	buffer, err := reader.nextEvent()
+	if err != nil || buffer == nil {
+		return nil, err
 	}
-	eventReader, err := newEventReader(reader.descriptorEvent.PayloadDataType, reader.buffer)
+	eventReader, err := newChecksumEventReader(reader.descriptorEvent.PayloadDataType, buffer, reader.codec, reader.checksum)
 	if err != nil {
 		return nil, err
 	}
@@ -42,18 +61,263 @@ func (reader *BinlogReader) NextEventReader() (*EventReader, error) {
 	return eventReader, nil
 }
 
+// nextEvent returns the buffer to read the next event, it's nil if there is no more event.
+// If the binlog is read from a stream, only the bytes of the next event are read.
+// The checksum of the event is verified if the binlog has checksums.
+func (reader *BinlogReader) nextEvent() (*bytes.Buffer, error) {
+	if reader.buffer != nil {
+		if reader.buffer.Len() <= 0 {
+			return nil, nil
+		}
+		if err := reader.verifyEvent(reader.buffer.Bytes()); err != nil {
+			return nil, err
+		}
+		return reader.buffer, nil
+	}
+
+	buffer := new(bytes.Buffer)
+	header, err := readEventHeader(io.TeeReader(reader.reader, buffer))
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rest := int64(header.EventLength) - int64(buffer.Len())
+	if rest < 0 {
+		return nil, fmt.Errorf("invalid event length %d", header.EventLength)
+	}
+	buffer.Grow(int(rest))
+	if _, err := io.CopyN(buffer, reader.reader, rest); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if err := reader.verifyEvent(buffer.Bytes()); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// verifyEvent verifies the checksum of the event at the beginning of data, and moves the offset to the next event.
+func (reader *BinlogReader) verifyEvent(data []byte) error {
+	if !reader.checksum {
+		return nil
+	}
+	length, err := verifyEventChecksum(data, reader.offset)
+	if err != nil {
+		return err
+	}
+	reader.offset += int64(length)
+	return nil
+}
+
+// NextBatch reads the next n rows at most, the rows may span events. Only the event being read is kept in memory,
+// the events read are released. It returns nil if there is no more row.
+func (reader *BinlogReader) NextBatch(n int) (FieldData, error) {
+	if reader.isClose {
+		return nil, errors.New("bin log reader is closed")
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", n)
+	}
+
+	var batch FieldData
+	rows := 0
+	for rows < n {
+		if reader.batchEvent == nil || reader.batchPos >= reader.batchRows {
+			ok, err := reader.nextBatchEvent()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			continue
+		}
+		if batch == nil {
+			var err error
+			batch, err = newBatchFieldData(reader.PayloadDataType, reader.batchDim, n)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		end := reader.batchPos + n - rows
+		if end > reader.batchRows {
+			end = reader.batchRows
+		}
+		if err := appendEventRows(batch, reader.batchEvent, reader.batchData, reader.batchPos, end); err != nil {
+			return nil, err
+		}
+		rows += end - reader.batchPos
+		reader.batchPos = end
+	}
+	if batch == nil {
+		return nil, nil
+	}
+	setBatchNumRows(batch, int64(rows))
+	return batch, nil
+}
+
+// nextBatchEvent releases the event read by NextBatch and opens the next one, it returns false if there is no more event.
+func (reader *BinlogReader) nextBatchEvent() (bool, error) {
+	if reader.batchEvent != nil {
+		if err := reader.batchEvent.Close(); err != nil {
+			return false, err
+		}
+		reader.batchEvent, reader.batchData = nil, nil
+		reader.batchPos, reader.batchRows = 0, 0
+	}
+
+	event, err := reader.NextEventReader()
+	if err != nil || event == nil {
+		return false, err
+	}
+	rows, err := event.GetPayloadLengthFromReader()
+	if err != nil {
+		return false, err
+	}
+	// the strings are read one by one
+	if reader.PayloadDataType != schemapb.DataType_String {
+		reader.batchData, reader.batchDim, err = event.GetDataFromPayload()
+		if err != nil {
+			return false, err
+		}
+	}
+	reader.batchEvent = event
+	reader.batchRows = rows
+	// the event released is removed, so that it's not kept until the binlog is closed
+	reader.eventList = reader.eventList[:len(reader.eventList)-1]
+	return true, nil
+}
+
+// newBatchFieldData creates an empty FieldData of the data type with the capacity of rows.
+func newBatchFieldData(dataType schemapb.DataType, dim int, rows int) (FieldData, error) {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		return &BoolFieldData{Data: make([]bool, 0, rows)}, nil
+	case schemapb.DataType_Int8:
+		return &Int8FieldData{Data: make([]int8, 0, rows)}, nil
+	case schemapb.DataType_Int16:
+		return &Int16FieldData{Data: make([]int16, 0, rows)}, nil
+	case schemapb.DataType_Int32:
+		return &Int32FieldData{Data: make([]int32, 0, rows)}, nil
+	case schemapb.DataType_Int64:
+		return &Int64FieldData{Data: make([]int64, 0, rows)}, nil
+	case schemapb.DataType_Float:
+		return &FloatFieldData{Data: make([]float32, 0, rows)}, nil
+	case schemapb.DataType_Double:
+		return &DoubleFieldData{Data: make([]float64, 0, rows)}, nil
+	case schemapb.DataType_String:
+		return &StringFieldData{Data: make([]string, 0, rows)}, nil
+	case schemapb.DataType_BinaryVector:
+		return &BinaryVectorFieldData{Data: make([]byte, 0, rows*dim/8), Dim: dim}, nil
+	case schemapb.DataType_FloatVector:
+		return &FloatVectorFieldData{Data: make([]float32, 0, rows*dim), Dim: dim}, nil
+	default:
+		return nil, fmt.Errorf("unsupported data type %s", dataType.String())
+	}
+}
+
+// appendEventRows copies the rows in [start, end) of the event to the batch, the data of the event is released
+// once the event is closed.
+func appendEventRows(batch FieldData, event *EventReader, data interface{}, start, end int) error {
+	switch b := batch.(type) {
+	case *BoolFieldData:
+		b.Data = append(b.Data, data.([]bool)[start:end]...)
+	case *Int8FieldData:
+		b.Data = append(b.Data, data.([]int8)[start:end]...)
+	case *Int16FieldData:
+		b.Data = append(b.Data, data.([]int16)[start:end]...)
+	case *Int32FieldData:
+		b.Data = append(b.Data, data.([]int32)[start:end]...)
+	case *Int64FieldData:
+		b.Data = append(b.Data, data.([]int64)[start:end]...)
+	case *FloatFieldData:
+		b.Data = append(b.Data, data.([]float32)[start:end]...)
+	case *DoubleFieldData:
+		b.Data = append(b.Data, data.([]float64)[start:end]...)
+	case *StringFieldData:
+		for i := start; i < end; i++ {
+			str, err := event.GetOneStringFromPayload(i)
+			if err != nil {
+				return err
+			}
+			b.Data = append(b.Data, str)
+		}
+	case *BinaryVectorFieldData:
+		b.Data = append(b.Data, data.([]byte)[start*b.Dim/8:end*b.Dim/8]...)
+	case *FloatVectorFieldData:
+		b.Data = append(b.Data, data.([]float32)[start*b.Dim:end*b.Dim]...)
+	default:
+		return fmt.Errorf("unsupported field data %T", batch)
+	}
+	return nil
+}
+
+func setBatchNumRows(batch FieldData, rows int64) {
+	numRows := []int64{rows}
+	switch b := batch.(type) {
+	case *BoolFieldData:
+		b.NumRows = numRows
+	case *Int8FieldData:
+		b.NumRows = numRows
+	case *Int16FieldData:
+		b.NumRows = numRows
+	case *Int32FieldData:
+		b.NumRows = numRows
+	case *Int64FieldData:
+		b.NumRows = numRows
+	case *FloatFieldData:
+		b.NumRows = numRows
+	case *DoubleFieldData:
+		b.NumRows = numRows
+	case *StringFieldData:
+		b.NumRows = numRows
+	case *BinaryVectorFieldData:
+		b.NumRows = numRows
+	case *FloatVectorFieldData:
+		b.NumRows = numRows
+	}
+}
+
 func (reader *BinlogReader) readMagicNumber() (int32, error) {
 	var err error
-	reader.magicNumber, err = readMagicNumber(reader.buffer)
+	reader.magicNumber, err = readMagicNumber(reader.reader)
 	return reader.magicNumber, err
 }
 
 func (reader *BinlogReader) readDescriptorEvent() (*descriptorEvent, error) {
-	event, err := ReadDescriptorEvent(reader.buffer)
+	event, err := ReadDescriptorEvent(reader.reader)
 	if err != nil {
 		return nil, err
 	}
 	reader.descriptorEvent = *event
+
+	// binlogs without compression in extras are written uncompressed
+	reader.codec = CompressionNone
+	if v, ok := event.Extras[CompressionKey]; ok {
+		name, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of %v must in string format", CompressionKey)
+		}
+		reader.codec, err = ParseCompressionType(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// binlogs without checksum in extras are written without checksums
+	reader.checksum = false
+	if v, ok := event.Extras[ChecksumKey]; ok {
+		if v != ChecksumCRC32C {
+			return nil, fmt.Errorf("unknown checksum type %v", v)
+		}
+		reader.checksum = true
+		reader.offset = int64(binary.Size(MagicNumber)) + int64(event.descriptorEventHeader.EventLength)
+	}
 	return &reader.descriptorEvent, nil
 }
 
@@ -63,6 +327,12 @@ func (reader *BinlogReader) Close() error {
 	if reader.isClose {
 		return nil
 	}
+	if reader.batchEvent != nil {
+		if err := reader.batchEvent.Close(); err != nil {
+			return err
+		}
+		reader.batchEvent, reader.batchData = nil, nil
+	}
 	for _, e := range reader.eventList {
 		if err := e.Close(); err != nil {
 			return err
@@ -74,8 +344,20 @@ func (reader *BinlogReader) Close() error {
 
 // NewBinlogReader creates binlogReader to read binlog file.
 func NewBinlogReader(data []byte) (*BinlogReader, error) {
+	buffer := bytes.NewBuffer(data)
+	return newBinlogReader(buffer, buffer)
+}
+
+// NewBinlogStreamReader creates binlogReader to read binlog file from the stream, the events are read from the stream
+// one by one, so that the whole binlog file is not loaded into memory.
+func NewBinlogStreamReader(r io.Reader) (*BinlogReader, error) {
+	return newBinlogReader(nil, r)
+}
+
+func newBinlogReader(buffer *bytes.Buffer, r io.Reader) (*BinlogReader, error) {
 	reader := &BinlogReader{
-		buffer:    bytes.NewBuffer(data),
+		buffer:    buffer,
+		reader:    r,
 		eventList: []*EventReader{},
 		isClose:   false,
 	}
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// generateBinlog writes an insert binlog with an event of each payload, dim is 0 for the scalar payloads.
+func generateBinlog(t *testing.T, dataType schemapb.DataType, payloads []interface{}, dim int) []byte {
+	w := NewInsertBinlogWriter(dataType, 10, 20, 30, 40)
+	for i, payload := range payloads {
+		e, err := w.NextInsertEventWriter()
+		require.Nil(t, err)
+		switch {
+		case dataType == schemapb.DataType_String:
+			for _, str := range payload.([]string) {
+				err = e.AddOneStringToPayload(str)
+				require.Nil(t, err)
+			}
+		case dim > 0:
+			err = e.AddDataToPayload(payload, dim)
+		default:
+			err = e.AddDataToPayload(payload)
+		}
+		require.Nil(t, err)
+		e.SetEventTimestamp(uint64(i*100+1), uint64(i*100+100))
+	}
+	w.SetEventTimeStamp(1000, 2000)
+	w.baseBinlogWriter.descriptorEventData.AddExtra(originalSizeKey, "0")
+	require.Nil(t, w.Close())
+	buf, err := w.GetBuffer()
+	require.Nil(t, err)
+	return buf
+}
+
+func TestBinlogReader_NextBatch(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		buf := generateBinlog(t, schemapb.DataType_Int64, []interface{}{[]int64{1, 2, 3}, []int64{4, 5}, []int64{6, 7, 8, 9}}, 0)
+
+		for _, newReader := range []func() (*BinlogReader, error){
+			func() (*BinlogReader, error) { return NewBinlogReader(buf) },
+			func() (*BinlogReader, error) { return NewBinlogStreamReader(bytes.NewReader(buf)) },
+		} {
+			reader, err := newReader()
+			require.Nil(t, err)
+
+			_, err = reader.NextBatch(0)
+			assert.Error(t, err)
+
+			// the batches span the events
+			var batches [][]int64
+			for {
+				batch, err := reader.NextBatch(4)
+				require.Nil(t, err)
+				if batch == nil {
+					break
+				}
+				data := batch.(*Int64FieldData)
+				assert.Equal(t, []int64{int64(len(data.Data))}, data.NumRows)
+				batches = append(batches, data.Data)
+			}
+			assert.Equal(t, [][]int64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9}}, batches)
+			assert.Empty(t, reader.eventList)
+
+			assert.Nil(t, reader.Close())
+			_, err = reader.NextBatch(4)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		buf := generateBinlog(t, schemapb.DataType_String, []interface{}{[]string{"a", "b"}, []string{"c"}}, 0)
+		reader, err := NewBinlogStreamReader(bytes.NewReader(buf))
+		require.Nil(t, err)
+		defer reader.Close()
+
+		batch, err := reader.NextBatch(10)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, batch.(*StringFieldData).Data)
+		batch, err = reader.NextBatch(10)
+		assert.Nil(t, err)
+		assert.Nil(t, batch)
+	})
+
+	t.Run("vector", func(t *testing.T) {
+		floatBuf := generateBinlog(t, schemapb.DataType_FloatVector,
+			[]interface{}{[]float32{1, 2, 3, 4}, []float32{5, 6}}, 2)
+		reader, err := NewBinlogStreamReader(bytes.NewReader(floatBuf))
+		require.Nil(t, err)
+		defer reader.Close()
+
+		batch, err := reader.NextBatch(1)
+		assert.Nil(t, err)
+		assert.Equal(t, &FloatVectorFieldData{NumRows: []int64{1}, Data: []float32{1, 2}, Dim: 2}, batch)
+		batch, err = reader.NextBatch(2)
+		assert.Nil(t, err)
+		assert.Equal(t, &FloatVectorFieldData{NumRows: []int64{2}, Data: []float32{3, 4, 5, 6}, Dim: 2}, batch)
+
+		binaryBuf := generateBinlog(t, schemapb.DataType_BinaryVector,
+			[]interface{}{[]byte{1, 2}, []byte{3}}, 8)
+		reader, err = NewBinlogReader(binaryBuf)
+		require.Nil(t, err)
+		defer reader.Close()
+
+		batch, err = reader.NextBatch(3)
+		assert.Nil(t, err)
+		assert.Equal(t, &BinaryVectorFieldData{NumRows: []int64{3}, Data: []byte{1, 2, 3}, Dim: 8}, batch)
+	})
+
+	t.Run("mixed with NextEventReader", func(t *testing.T) {
+		buf := generateBinlog(t, schemapb.DataType_Int32, []interface{}{[]int32{1, 2}, []int32{3}}, 0)
+		reader, err := NewBinlogStreamReader(bytes.NewReader(buf))
+		require.Nil(t, err)
+		defer reader.Close()
+
+		event, err := reader.NextEventReader()
+		assert.Nil(t, err)
+		data, err := event.GetInt32FromPayload()
+		assert.Nil(t, err)
+		assert.Equal(t, []int32{1, 2}, data)
+
+		batch, err := reader.NextBatch(2)
+		assert.Nil(t, err)
+		assert.Equal(t, []int32{3}, batch.(*Int32FieldData).Data)
+		event, err = reader.NextEventReader()
+		assert.Nil(t, err)
+		assert.Nil(t, event)
+	})
+}
+
+func TestBinlogStreamReader_Error(t *testing.T) {
+	buf := generateBinlog(t, schemapb.DataType_Int64, []interface{}{[]int64{1, 2, 3}}, 0)
+
+	_, err := NewBinlogStreamReader(bytes.NewReader(buf[:2]))
+	assert.Error(t, err)
+
+	// the event is truncated
+	reader, err := NewBinlogStreamReader(bytes.NewReader(buf[:len(buf)-1]))
+	require.Nil(t, err)
+	_, err = reader.NextBatch(1)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Nil(t, reader.Close())
+}
+
+// TestBinlogStreamReader_Memory reads a large binlog in batches, only an event and a batch should be kept in memory.
+func TestBinlogStreamReader_Memory(t *testing.T) {
+	const (
+		dim          = 128
+		events       = 64
+		rowsPerEvent = 1024
+		batchRows    = 256
+	)
+	eventVectors := make([]float32, rowsPerEvent*dim)
+	for i := range eventVectors {
+		eventVectors[i] = float32(i)
+	}
+	payloads := make([]interface{}, events)
+	for i := range payloads {
+		payloads[i] = eventVectors
+	}
+	// 64 events of 512KB vectors
+	binlogPath := path.Join(t.TempDir(), "binlog")
+	err := ioutil.WriteFile(binlogPath, generateBinlog(t, schemapb.DataType_FloatVector, payloads, dim), 0600)
+	require.Nil(t, err)
+	payloads, eventVectors = nil, nil
+
+	file, err := os.Open(binlogPath)
+	require.Nil(t, err)
+	defer file.Close()
+	info, err := file.Stat()
+	require.Nil(t, err)
+	fileSize := uint64(info.Size())
+
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := stats.HeapAlloc
+
+	reader, err := NewBinlogStreamReader(file)
+	require.Nil(t, err)
+	defer reader.Close()
+
+	var peak uint64
+	rows := 0
+	for {
+		batch, err := reader.NextBatch(batchRows)
+		require.Nil(t, err)
+		if batch == nil {
+			break
+		}
+		vectors := batch.(*FloatVectorFieldData)
+		require.Equal(t, batchRows*dim, len(vectors.Data))
+		assert.Equal(t, float32((rows%rowsPerEvent)*dim), vectors.Data[0])
+		rows += batchRows
+
+		runtime.GC()
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > baseline && stats.HeapAlloc-baseline > peak {
+			peak = stats.HeapAlloc - baseline
+		}
+	}
+	assert.Equal(t, events*rowsPerEvent, rows)
+
+	// an event is 512KB and a batch is 128KB, while the binlog is 32MB
+	t.Logf("binlog size %d, peak heap %d", fileSize, peak)
+	assert.Less(t, peak, fileSize/8)
+}
@@ -44,6 +44,8 @@ type baseBinlogWriter struct {
 	eventWriters []EventWriter
 	buffer       *bytes.Buffer
 	length       int32
+	codec        CompressionType
+	checksum     bool
 }
 
 func (writer *baseBinlogWriter) isClosed() bool {
@@ -70,6 +72,41 @@ func (writer *baseBinlogWriter) GetRowNums() (int32, error) {
 	return int32(length), nil
 }
 
+// SetCompression sets the codec to compress the payloads of events, and records it in the extras for readers.
+// It must be called before any event writer is created.
+func (writer *baseBinlogWriter) SetCompression(codec CompressionType) error {
+	codec, err := ParseCompressionType(string(codec))
+	if err != nil {
+		return err
+	}
+	if len(writer.eventWriters) > 0 {
+		return fmt.Errorf("set compression after event writers are created")
+	}
+	writer.codec = codec
+	if codec == CompressionNone {
+		// keep binlogs the same as the ones without compression support
+		delete(writer.Extras, CompressionKey)
+	} else {
+		writer.AddExtra(CompressionKey, string(codec))
+	}
+	return nil
+}
+
+// SetChecksum sets whether to append the checksum to each event, and records the algorithm in the extras for readers.
+// It must be called before any event writer is created.
+func (writer *baseBinlogWriter) SetChecksum(enabled bool) error {
+	if len(writer.eventWriters) > 0 {
+		return fmt.Errorf("set checksum after event writers are created")
+	}
+	writer.checksum = enabled
+	if enabled {
+		writer.AddExtra(ChecksumKey, ChecksumCRC32C)
+	} else {
+		delete(writer.Extras, ChecksumKey)
+	}
+	return nil
+}
+
 // GetBinlogType returns writer's binlogType
 func (writer *baseBinlogWriter) GetBinlogType() BinlogType {
 	return writer.binlogType
@@ -143,6 +180,8 @@ func (writer *InsertBinlogWriter) NextInsertEventWriter() (*insertEventWriter, e
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -161,6 +200,8 @@ func (writer *DeleteBinlogWriter) NextDeleteEventWriter() (*deleteEventWriter, e
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -180,6 +221,8 @@ func (writer *DDLBinlogWriter) NextCreateCollectionEventWriter() (*createCollect
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -194,6 +237,8 @@ func (writer *DDLBinlogWriter) NextDropCollectionEventWriter() (*dropCollectionE
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -208,6 +253,8 @@ func (writer *DDLBinlogWriter) NextCreatePartitionEventWriter() (*createPartitio
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -222,6 +269,8 @@ func (writer *DDLBinlogWriter) NextDropPartitionEventWriter() (*dropPartitionEve
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -240,6 +289,8 @@ func (writer *IndexFileBinlogWriter) NextIndexFileEventWriter() (*indexFileEvent
 	if err != nil {
 		return nil, err
 	}
+	event.codec = writer.codec
+	event.checksum = writer.checksum
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
 }
@@ -0,0 +1,433 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+const (
+	// cacheVersionSep separates the key and the version in the name of a cached file.
+	cacheVersionSep = "@"
+
+	chunkCacheHitLabel   = "hit"
+	chunkCacheMissLabel  = "miss"
+	chunkCacheEvictLabel = "evict"
+)
+
+// ErrChunkNotCached is returned when the chunk read from the cache only isn't cached.
+var ErrChunkNotCached = errors.New("chunk is not cached")
+
+// chunkStater is implemented by the ChunkManagers able to tell the version of a chunk,
+// the version changes whenever the chunk is rewritten.
+type chunkStater interface {
+	Stat(key string) (size int64, version string, err error)
+}
+
+// cacheEntry is a chunk cached on local disk.
+type cacheEntry struct {
+	key     string
+	version string
+	size    int64
+	// refs is the number of the ongoing reads, the file is not removed while it's being read
+	refs int
+	// removed is set when the entry is evicted or outdated while it's being read
+	removed bool
+}
+
+func (e *cacheEntry) localKey() string {
+	return cacheLocalKey(e.key, e.version)
+}
+
+func cacheLocalKey(key, version string) string {
+	return key + cacheVersionSep + strings.ReplaceAll(version, "/", "_")
+}
+
+// cacheLoad is an ongoing download of a chunk, the concurrent reads of the chunk wait for it.
+type cacheLoad struct {
+	done chan struct{}
+	err  error
+}
+
+// CachedChunkManager is a read-through cache of the remote ChunkManager, the chunks read are cached on local disk.
+// A cached chunk is keyed by its path and version, it's served only if the version in the remote storage is unchanged.
+// The cached size is limited by maxSize, the least recently used chunks are evicted. The cached files are kept
+// across restarts.
+type CachedChunkManager struct {
+	remote  ChunkManager
+	local   *LocalChunkManager
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // key -> *cacheEntry
+	lru     *list.List               // the most recently used entry is at front
+	size    int64
+	loading map[string]*cacheLoad // local key -> download
+}
+
+var _ ChunkManager = (*CachedChunkManager)(nil)
+
+// NewCachedChunkManager creates a CachedChunkManager caching the chunks of remote in cachePath, the chunks cached
+// by the previous runs are loaded.
+func NewCachedChunkManager(remote ChunkManager, cachePath string, maxSize int64) (*CachedChunkManager, error) {
+	ccm := &CachedChunkManager{
+		remote:  remote,
+		local:   NewLocalChunkManager(cachePath),
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		loading: make(map[string]*cacheLoad),
+	}
+	if err := ccm.loadCachedFiles(cachePath); err != nil {
+		return nil, err
+	}
+	return ccm, nil
+}
+
+// loadCachedFiles adds the files in cachePath to the cache, the recently modified files are the recently used.
+func (ccm *CachedChunkManager) loadCachedFiles(cachePath string) error {
+	var entries []*cacheEntry
+	modTimes := make(map[*cacheEntry]int64)
+	err := filepath.Walk(cachePath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		localKey, err := filepath.Rel(cachePath, filePath)
+		if err != nil {
+			return err
+		}
+		localKey = filepath.ToSlash(localKey)
+		sep := strings.LastIndex(localKey, cacheVersionSep)
+		if isTempFile(filePath) || sep < 0 {
+			log.Debug("CachedChunkManager remove unknown file", zap.String("path", filePath))
+			return os.Remove(filePath)
+		}
+		entry := &cacheEntry{
+			key:     localKey[:sep],
+			version: localKey[sep+len(cacheVersionSep):],
+			size:    info.Size(),
+		}
+		entries = append(entries, entry)
+		modTimes[entry] = info.ModTime().UnixNano()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return modTimes[entries[i]] < modTimes[entries[j]]
+	})
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+	for _, entry := range entries {
+		if elem, ok := ccm.entries[entry.key]; ok {
+			ccm.removeLocked(elem)
+		}
+		ccm.entries[entry.key] = ccm.lru.PushFront(entry)
+		ccm.size += entry.size
+	}
+	ccm.evictLocked()
+	log.Debug("CachedChunkManager loaded cached files", zap.String("path", cachePath),
+		zap.Int("num", ccm.lru.Len()), zap.Int64("size", ccm.size))
+	return nil
+}
+
+// stat returns the size and the version of the chunk in the remote storage.
+func (ccm *CachedChunkManager) stat(key string) (int64, string, error) {
+	if stater, ok := ccm.remote.(chunkStater); ok {
+		return stater.Stat(key)
+	}
+	// the chunk rewritten with the same size is not detected
+	size, err := ccm.remote.Size(key)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, strconv.FormatInt(size, 10), nil
+}
+
+// acquire returns the cached entry of the up-to-date chunk, the chunk is downloaded once if it isn't cached.
+// The entry is not removed until it's released.
+func (ccm *CachedChunkManager) acquire(key string) (*cacheEntry, error) {
+	size, version, err := ccm.stat(key)
+	if err != nil {
+		return nil, err
+	}
+	localKey := cacheLocalKey(key, version)
+
+	ccm.mu.Lock()
+	for {
+		if elem, ok := ccm.entries[key]; ok {
+			entry := elem.Value.(*cacheEntry)
+			if entry.version == version && entry.size == size {
+				entry.refs++
+				ccm.lru.MoveToFront(elem)
+				ccm.mu.Unlock()
+				metrics.QueryNodeChunkCacheCounter.WithLabelValues(chunkCacheHitLabel).Inc()
+				return entry, nil
+			}
+		}
+		load, ok := ccm.loading[localKey]
+		if !ok {
+			break
+		}
+		// the chunk is being downloaded by another read
+		ccm.mu.Unlock()
+		<-load.done
+		if load.err != nil {
+			return nil, load.err
+		}
+		ccm.mu.Lock()
+	}
+	load := &cacheLoad{done: make(chan struct{})}
+	ccm.loading[localKey] = load
+	ccm.mu.Unlock()
+	metrics.QueryNodeChunkCacheCounter.WithLabelValues(chunkCacheMissLabel).Inc()
+
+	entry := &cacheEntry{
+		key:     key,
+		version: version,
+		size:    size,
+		refs:    1,
+	}
+	load.err = ccm.download(entry)
+
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+	delete(ccm.loading, localKey)
+	close(load.done)
+	if load.err != nil {
+		return nil, load.err
+	}
+	if elem, ok := ccm.entries[key]; ok {
+		ccm.removeLocked(elem)
+	}
+	ccm.entries[key] = ccm.lru.PushFront(entry)
+	ccm.size += entry.size
+	ccm.evictLocked()
+	return entry, nil
+}
+
+// download writes the chunk in the remote storage to local disk.
+func (ccm *CachedChunkManager) download(entry *cacheEntry) error {
+	reader, err := ccm.remote.Reader(entry.key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	// the write fails if the chunk is rewritten during downloading, since the size doesn't match
+	err = ccm.local.WriteFrom(entry.localKey(), reader, entry.size)
+	if err != nil {
+		log.Warn("CachedChunkManager failed to download", zap.String("key", entry.key), zap.Error(err))
+	}
+	return err
+}
+
+// release releases the entry acquired, the entry removed meanwhile is deleted from local disk.
+func (ccm *CachedChunkManager) release(entry *cacheEntry) {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+	entry.refs--
+	if entry.refs == 0 && entry.removed {
+		ccm.deleteFile(entry)
+	}
+	ccm.evictLocked()
+}
+
+// removeLocked removes the entry from the cache, its file is deleted once it's not being read.
+func (ccm *CachedChunkManager) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry)
+	ccm.lru.Remove(elem)
+	delete(ccm.entries, entry.key)
+	ccm.size -= entry.size
+	entry.removed = true
+	if entry.refs == 0 {
+		ccm.deleteFile(entry)
+	}
+}
+
+func (ccm *CachedChunkManager) deleteFile(entry *cacheEntry) {
+	if err := ccm.local.Remove(entry.localKey()); err != nil {
+		log.Warn("CachedChunkManager failed to remove cached file", zap.String("key", entry.key), zap.Error(err))
+	}
+}
+
+// evictLocked evicts the least recently used entries until the cached size is under maxSize,
+// the entries being read are skipped.
+func (ccm *CachedChunkManager) evictLocked() {
+	for elem := ccm.lru.Back(); elem != nil && ccm.size > ccm.maxSize; {
+		prev := elem.Prev()
+		if elem.Value.(*cacheEntry).refs == 0 {
+			ccm.removeLocked(elem)
+			metrics.QueryNodeChunkCacheCounter.WithLabelValues(chunkCacheEvictLabel).Inc()
+		}
+		elem = prev
+	}
+	metrics.QueryNodeChunkCacheSize.Set(float64(ccm.size))
+}
+
+// invalidate removes the cached chunks of the keys accepted by match.
+func (ccm *CachedChunkManager) invalidate(match func(key string) bool) {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+	for key, elem := range ccm.entries {
+		if match(key) {
+			ccm.removeLocked(elem)
+		}
+	}
+	metrics.QueryNodeChunkCacheSize.Set(float64(ccm.size))
+}
+
+// GetPath returns the path of the chunk cached on local disk, the file may be removed once it's evicted.
+func (ccm *CachedChunkManager) GetPath(key string) (string, error) {
+	entry, err := ccm.acquire(key)
+	if err != nil {
+		return "", err
+	}
+	defer ccm.release(entry)
+	return ccm.local.GetPath(entry.localKey())
+}
+
+// Write writes the data to the remote storage.
+func (ccm *CachedChunkManager) Write(key string, content []byte) error {
+	defer ccm.invalidate(func(k string) bool { return k == key })
+	return ccm.remote.Write(key, content)
+}
+
+// WriteFrom writes the data from the reader to the remote storage.
+func (ccm *CachedChunkManager) WriteFrom(key string, reader io.Reader, size int64) error {
+	defer ccm.invalidate(func(k string) bool { return k == key })
+	return ccm.remote.WriteFrom(key, reader, size)
+}
+
+// Exist checks whether the chunk is saved to the remote storage.
+func (ccm *CachedChunkManager) Exist(key string) bool {
+	return ccm.remote.Exist(key)
+}
+
+// Read reads the chunk, it's read from local disk if cached.
+func (ccm *CachedChunkManager) Read(key string) ([]byte, error) {
+	entry, err := ccm.acquire(key)
+	if err != nil {
+		return nil, err
+	}
+	defer ccm.release(entry)
+	return ccm.local.Read(entry.localKey())
+}
+
+// cachedReader releases the cached entry when it's closed.
+type cachedReader struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (r *cachedReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// Reader opens the chunk for reading, it's read from local disk if cached.
+func (ccm *CachedChunkManager) Reader(key string) (io.ReadCloser, error) {
+	entry, err := ccm.acquire(key)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := ccm.local.Reader(entry.localKey())
+	if err != nil {
+		ccm.release(entry)
+		return nil, err
+	}
+	return &cachedReader{
+		ReadCloser: reader,
+		release:    func() { ccm.release(entry) },
+	}, nil
+}
+
+// ReaderIfCached opens the chunk cached on local disk for reading, ErrChunkNotCached is returned if it isn't cached.
+// The remote storage is not accessed, so the cached version is served even if the chunk is rewritten, it's for the
+// chunks never rewritten such as binlogs.
+func (ccm *CachedChunkManager) ReaderIfCached(key string) (io.ReadCloser, error) {
+	ccm.mu.Lock()
+	elem, ok := ccm.entries[key]
+	if !ok {
+		ccm.mu.Unlock()
+		return nil, ErrChunkNotCached
+	}
+	entry := elem.Value.(*cacheEntry)
+	entry.refs++
+	ccm.lru.MoveToFront(elem)
+	ccm.mu.Unlock()
+
+	reader, err := ccm.local.Reader(entry.localKey())
+	if err != nil {
+		ccm.release(entry)
+		return nil, err
+	}
+	return &cachedReader{
+		ReadCloser: reader,
+		release:    func() { ccm.release(entry) },
+	}, nil
+}
+
+// ReadAt reads specific position data of the chunk, it's read from local disk if cached.
+func (ccm *CachedChunkManager) ReadAt(key string, p []byte, off int64) (int, error) {
+	entry, err := ccm.acquire(key)
+	if err != nil {
+		return -1, err
+	}
+	defer ccm.release(entry)
+	return ccm.local.ReadAt(entry.localKey(), p, off)
+}
+
+// Size returns the size of the chunk in the remote storage.
+func (ccm *CachedChunkManager) Size(key string) (int64, error) {
+	size, _, err := ccm.stat(key)
+	return size, err
+}
+
+// ListWithPrefix lists the keys of the remote storage with the prefix.
+func (ccm *CachedChunkManager) ListWithPrefix(prefix string) ([]string, error) {
+	return ccm.remote.ListWithPrefix(prefix)
+}
+
+// Remove deletes the chunk from the remote storage and the cache.
+func (ccm *CachedChunkManager) Remove(key string) error {
+	defer ccm.invalidate(func(k string) bool { return k == key })
+	return ccm.remote.Remove(key)
+}
+
+// RemoveWithPrefix deletes the chunks with the prefix from the remote storage and the cache.
+func (ccm *CachedChunkManager) RemoveWithPrefix(prefix string) error {
+	defer ccm.invalidate(func(k string) bool { return strings.HasPrefix(k, prefix) })
+	return ccm.remote.RemoveWithPrefix(prefix)
+}
@@ -0,0 +1,315 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// countingChunkManager counts the chunks downloaded, the downloads wait for block if it's set.
+type countingChunkManager struct {
+	*LocalChunkManager
+	downloads int32
+	block     chan struct{}
+}
+
+func (cm *countingChunkManager) Reader(key string) (io.ReadCloser, error) {
+	atomic.AddInt32(&cm.downloads, 1)
+	if cm.block != nil {
+		<-cm.block
+	}
+	return cm.LocalChunkManager.Reader(key)
+}
+
+func newCachedChunkManagerForTest(t *testing.T, maxSize int64) (*CachedChunkManager, *countingChunkManager, string) {
+	remote := &countingChunkManager{LocalChunkManager: NewLocalChunkManager(t.TempDir())}
+	cachePath := t.TempDir()
+	ccm, err := NewCachedChunkManager(remote, cachePath, maxSize)
+	require.Nil(t, err)
+	return ccm, remote, cachePath
+}
+
+func chunkCacheCount(label string) float64 {
+	return testutil.ToFloat64(metrics.QueryNodeChunkCacheCounter.WithLabelValues(label))
+}
+
+func TestCachedChunkManager_ReadThrough(t *testing.T) {
+	ccm, remote, _ := newCachedChunkManagerForTest(t, 1024)
+
+	_, err := ccm.Read("invalid")
+	assert.Error(t, err)
+
+	err = ccm.Write("a/1", []byte("12345678"))
+	assert.Nil(t, err)
+	assert.True(t, ccm.Exist("a/1"))
+
+	hits, misses := chunkCacheCount(chunkCacheHitLabel), chunkCacheCount(chunkCacheMissLabel)
+	content, err := ccm.Read("a/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("12345678"), content)
+
+	p := make([]byte, 4)
+	n, err := ccm.ReadAt("a/1", p, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []byte("3456"), p)
+
+	reader, err := ccm.Reader("a/1")
+	assert.Nil(t, err)
+	content, err = ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("12345678"), content)
+	assert.Nil(t, reader.Close())
+
+	filePath, err := ccm.GetPath("a/1")
+	assert.Nil(t, err)
+	content, err = ioutil.ReadFile(filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("12345678"), content)
+
+	size, err := ccm.Size("a/1")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 8, size)
+
+	// downloaded once, and served from local disk after
+	assert.EqualValues(t, 1, atomic.LoadInt32(&remote.downloads))
+	assert.Equal(t, misses+1, chunkCacheCount(chunkCacheMissLabel))
+	assert.Equal(t, hits+3, chunkCacheCount(chunkCacheHitLabel))
+
+	keys, err := ccm.ListWithPrefix("a/")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a/1"}, keys)
+}
+
+func TestCachedChunkManager_Stale(t *testing.T) {
+	ccm, remote, _ := newCachedChunkManagerForTest(t, 1024)
+
+	err := remote.Write("a/1", []byte("123"))
+	assert.Nil(t, err)
+	content, err := ccm.Read("a/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("123"), content)
+
+	// rewritten in the remote storage, not through the cache
+	err = remote.Write("a/1", []byte("12345"))
+	assert.Nil(t, err)
+	content, err = ccm.Read("a/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("12345"), content)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&remote.downloads))
+	assert.EqualValues(t, 5, ccm.size)
+
+	// rewritten through the cache
+	err = ccm.Write("a/1", []byte("abcde"))
+	assert.Nil(t, err)
+	content, err = ccm.Read("a/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("abcde"), content)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&remote.downloads))
+
+	err = ccm.Remove("a/1")
+	assert.Nil(t, err)
+	_, err = ccm.Read("a/1")
+	assert.Error(t, err)
+	assert.Zero(t, ccm.size)
+	assert.Zero(t, ccm.lru.Len())
+}
+
+func TestCachedChunkManager_Evict(t *testing.T) {
+	ccm, remote, cachePath := newCachedChunkManagerForTest(t, 300)
+
+	keys := make([]string, 10)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("a/%d", i)
+		err := remote.Write(keys[i], bytes.Repeat([]byte{byte(i)}, 100))
+		require.Nil(t, err)
+	}
+
+	evictions := chunkCacheCount(chunkCacheEvictLabel)
+	for _, key := range keys {
+		_, err := ccm.Read(key)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, ccm.size, int64(300))
+	}
+	assert.Equal(t, evictions+7, chunkCacheCount(chunkCacheEvictLabel))
+	assert.Equal(t, float64(300), testutil.ToFloat64(metrics.QueryNodeChunkCacheSize))
+
+	cachedKeys := func() []string {
+		lcm := NewLocalChunkManager(cachePath)
+		localKeys, err := lcm.ListWithPrefix("")
+		require.Nil(t, err)
+		keys := make([]string, 0, len(localKeys))
+		for _, localKey := range localKeys {
+			keys = append(keys, localKey[:strings.LastIndex(localKey, cacheVersionSep)])
+		}
+		return keys
+	}
+	assert.ElementsMatch(t, []string{"a/7", "a/8", "a/9"}, cachedKeys())
+
+	// a/7 is the most recently used, a/8 is evicted
+	_, err := ccm.Read("a/7")
+	assert.Nil(t, err)
+	_, err = ccm.Read("a/0")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a/0", "a/7", "a/9"}, cachedKeys())
+	assert.EqualValues(t, 11, atomic.LoadInt32(&remote.downloads))
+
+	// the chunk being read is not evicted until it's closed
+	reader, err := ccm.Reader("a/9")
+	assert.Nil(t, err)
+	for _, key := range keys[1:4] {
+		_, err = ccm.Read(key)
+		assert.Nil(t, err)
+	}
+	// a/9 is the least recently used, a/1 is evicted instead
+	assert.ElementsMatch(t, []string{"a/2", "a/3", "a/9"}, cachedKeys())
+	assert.EqualValues(t, 300, ccm.size)
+	content, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{9}, 100), content)
+	assert.Nil(t, reader.Close())
+	_, err = ccm.Read("a/4")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a/2", "a/3", "a/4"}, cachedKeys())
+
+	// the chunk larger than the cache is read, and evicted after
+	err = remote.Write("b/1", bytes.Repeat([]byte{1}, 500))
+	assert.Nil(t, err)
+	content, err = ccm.Read("b/1")
+	assert.Nil(t, err)
+	assert.Equal(t, 500, len(content))
+	assert.NotContains(t, cachedKeys(), "b/1")
+	assert.LessOrEqual(t, ccm.size, int64(300))
+}
+
+func TestCachedChunkManager_SingleFlight(t *testing.T) {
+	ccm, remote, _ := newCachedChunkManagerForTest(t, 1024)
+	err := remote.Write("a/1", []byte("12345678"))
+	require.Nil(t, err)
+
+	remote.block = make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, err := ccm.Read("a/1")
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("12345678"), content)
+		}()
+	}
+	close(remote.block)
+	wg.Wait()
+	assert.EqualValues(t, 1, atomic.LoadInt32(&remote.downloads))
+	assert.EqualValues(t, 8, ccm.size)
+
+	// the failed reads leave no download behind
+	remote.block = make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ccm.Read("invalid")
+			assert.Error(t, err)
+		}()
+	}
+	close(remote.block)
+	wg.Wait()
+	assert.Empty(t, ccm.loading)
+}
+
+func TestCachedChunkManager_Restart(t *testing.T) {
+	ccm, remote, cachePath := newCachedChunkManagerForTest(t, 1024)
+	for _, key := range []string{"a/1", "a/2"} {
+		err := remote.Write(key, []byte(key))
+		require.Nil(t, err)
+		_, err = ccm.Read(key)
+		require.Nil(t, err)
+	}
+	// the file of an interrupted download
+	err := ioutil.WriteFile(cachePath+"/a/.3.tmp-1", []byte{1}, 0600)
+	require.Nil(t, err)
+	err = remote.Write("a/2", []byte("a/2-v2"))
+	require.Nil(t, err)
+
+	ccm, err = NewCachedChunkManager(remote, cachePath, 1024)
+	require.Nil(t, err)
+	assert.Equal(t, 2, ccm.lru.Len())
+	assert.EqualValues(t, 6, ccm.size)
+	_, err = os.Stat(cachePath + "/a/.3.tmp-1")
+	assert.True(t, os.IsNotExist(err))
+
+	content, err := ccm.Read("a/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a/1"), content)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&remote.downloads))
+
+	// the outdated chunk is downloaded again
+	content, err = ccm.Read("a/2")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a/2-v2"), content)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&remote.downloads))
+	assert.EqualValues(t, 9, ccm.size)
+
+	err = ccm.RemoveWithPrefix("a/")
+	assert.Nil(t, err)
+	assert.Zero(t, ccm.lru.Len())
+	keys, err := NewLocalChunkManager(cachePath).ListWithPrefix("")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestCachedChunkManager_ReaderIfCached(t *testing.T) {
+	ccm, remote, _ := newCachedChunkManagerForTest(t, 200)
+	err := remote.Write("a/1", bytes.Repeat([]byte{1}, 100))
+	require.Nil(t, err)
+
+	_, err = ccm.ReaderIfCached("a/1")
+	assert.ErrorIs(t, err, ErrChunkNotCached)
+	assert.Zero(t, atomic.LoadInt32(&remote.downloads))
+
+	_, err = ccm.Read("a/1")
+	require.Nil(t, err)
+	reader, err := ccm.ReaderIfCached("a/1")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&remote.downloads))
+
+	// the chunk being read is not evicted until it's closed
+	for _, key := range []string{"a/2", "a/3"} {
+		err = remote.Write(key, bytes.Repeat([]byte{2}, 100))
+		require.Nil(t, err)
+		_, err = ccm.Read(key)
+		require.Nil(t, err)
+	}
+	content, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{1}, 100), content)
+	assert.Nil(t, reader.Close())
+	_, err = ccm.Read("a/2")
+	assert.Nil(t, err)
+	_, err = ccm.ReaderIfCached("a/1")
+	assert.ErrorIs(t, err, ErrChunkNotCached)
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+const (
+	// ChecksumKey is the key of checksum algorithm in the extras of binlog, binlogs without it have no checksums
+	ChecksumKey = "checksum"
+	// ChecksumCRC32C is the CRC-32 checksum with Castagnoli polynomial, which is appended to each event
+	ChecksumCRC32C = "crc32c"
+
+	// indexChecksumKey is the key of the checksum of the index file content in the extras of index file binlog
+	indexChecksumKey = "indexChecksum"
+
+	checksumSize = 4
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// checksumWarnOnly is 1 if checksum mismatches are logged as warnings instead of failing the reads
+var checksumWarnOnly int32
+
+// SetChecksumWarnOnly sets whether checksum mismatches are logged as warnings instead of failing the reads,
+// it's for emergency reads of the corrupted files only.
+func SetChecksumWarnOnly(warnOnly bool) {
+	var v int32
+	if warnOnly {
+		v = 1
+	}
+	atomic.StoreInt32(&checksumWarnOnly, v)
+}
+
+// ChecksumError is returned when the checksum of the data read mismatches the one written.
+type ChecksumError struct {
+	// Path is the path of the file, it's empty if the file is read without path
+	Path string
+	// Offset is the offset of the event in the binlog, or -1 for the whole file
+	Offset   int64
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *ChecksumError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "<unknown>"
+	}
+	if e.Offset < 0 {
+		return fmt.Sprintf("checksum mismatch of %s, expected: %08x, actual: %08x", path, e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("checksum mismatch of %s at offset %d, expected: %08x, actual: %08x", path, e.Offset, e.Expected, e.Actual)
+}
+
+// withChecksumPath sets the path of the file to the checksum error, the other errors are returned as is.
+func withChecksumPath(err error, path string) error {
+	var checksumErr *ChecksumError
+	if errors.As(err, &checksumErr) && checksumErr.Path == "" {
+		checksumErr.Path = path
+	}
+	return err
+}
+
+// NewChecksum returns a hash computing the CRC-32C checksum, the same as the checksums of the binlogs.
+func NewChecksum() hash.Hash32 {
+	return crc32.New(crc32cTable)
+}
+
+func checksum(data []byte) uint32 {
+	return crc32.Checksum(data, crc32cTable)
+}
+
+// checkChecksum returns a ChecksumError if the checksums mismatch, unless checksum mismatches are warnings only.
+func checkChecksum(path string, offset int64, expected uint32, actual uint32) error {
+	if expected == actual {
+		return nil
+	}
+	err := &ChecksumError{Path: path, Offset: offset, Expected: expected, Actual: actual}
+	if atomic.LoadInt32(&checksumWarnOnly) == 1 {
+		log.Warn("ignore checksum mismatch", zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// verifyEventChecksum verifies the checksum appended to the event at the beginning of data, and returns the length
+// of the event.
+func verifyEventChecksum(data []byte, offset int64) (int, error) {
+	header, err := readEventHeader(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	length := int(header.EventLength)
+	if length < int(header.GetMemoryUsageInBytes())+checksumSize || length > len(data) {
+		return 0, fmt.Errorf("invalid event length %d at offset %d", header.EventLength, offset)
+	}
+	expected := binary.LittleEndian.Uint32(data[length-checksumSize : length])
+	return length, checkChecksum("", offset, expected, checksum(data[:length-checksumSize]))
+}
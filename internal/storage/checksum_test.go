@@ -0,0 +1,276 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/rootcoord"
+)
+
+func serializeChecksumTestData(t *testing.T, checksum bool) []*Blob {
+	schema := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{
+		{FieldID: rootcoord.TimeStampField, Name: "ts", DataType: schemapb.DataType_Int64},
+		{FieldID: rootcoord.RowIDField, Name: "rowid", DataType: schemapb.DataType_Int64},
+		{FieldID: 101, Name: "int32", DataType: schemapb.DataType_Int32},
+	}}
+	insertCodec := NewInsertCodec(&etcdpb.CollectionMeta{ID: 1, Schema: schema})
+	insertCodec.Checksum = checksum
+	data := &InsertData{Data: map[FieldID]FieldData{
+		rootcoord.TimeStampField: &Int64FieldData{NumRows: []int64{3}, Data: []int64{1, 2, 3}},
+		rootcoord.RowIDField:     &Int64FieldData{NumRows: []int64{3}, Data: []int64{1, 2, 3}},
+		101:                      &Int32FieldData{NumRows: []int64{3}, Data: []int32{1, 2, 3}},
+	}}
+	blobs, _, err := insertCodec.Serialize(1, 1, data)
+	require.Nil(t, err)
+	for _, blob := range blobs {
+		blob.Key = "insert_log/1/1/1/" + blob.Key + "/1"
+	}
+	return blobs
+}
+
+// firstEventOffset returns the offset of the first event in binlog
+func firstEventOffset(t *testing.T, binlog []byte) int {
+	header, err := readDescriptorEventHeader(bytes.NewReader(binlog[binary.Size(MagicNumber):]))
+	require.Nil(t, err)
+	return binary.Size(MagicNumber) + int(header.EventLength)
+}
+
+func TestBinlogChecksum(t *testing.T) {
+	blobs := serializeChecksumTestData(t, true)
+
+	t.Run("read", func(t *testing.T) {
+		reader, err := NewBinlogReader(blobs[0].Value)
+		require.Nil(t, err)
+		defer reader.Close()
+		assert.Equal(t, ChecksumCRC32C, reader.Extras[ChecksumKey])
+
+		event, err := reader.NextEventReader()
+		assert.Nil(t, err)
+		data, err := event.GetInt64FromPayload()
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, data)
+		event, err = reader.NextEventReader()
+		assert.Nil(t, err)
+		assert.Nil(t, event)
+
+		codec := NewInsertCodec(nil)
+		defer codec.Close()
+		_, _, result, err := codec.Deserialize(blobs)
+		assert.Nil(t, err)
+		assert.Equal(t, []int32{1, 2, 3}, result.Data[101].(*Int32FieldData).Data)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		corrupted := make([]*Blob, len(blobs))
+		for i, blob := range blobs {
+			corrupted[i] = &Blob{Key: blob.Key, Value: append([]byte{}, blob.Value...)}
+		}
+		// flip the last byte of the payload of the int32 field
+		offset := firstEventOffset(t, corrupted[2].Value)
+		corrupted[2].Value[len(corrupted[2].Value)-checksumSize-1] ^= 0xff
+
+		for _, newReader := range []func() (*BinlogReader, error){
+			func() (*BinlogReader, error) { return NewBinlogReader(corrupted[2].Value) },
+			func() (*BinlogReader, error) { return NewBinlogStreamReader(bytes.NewReader(corrupted[2].Value)) },
+		} {
+			reader, err := newReader()
+			require.Nil(t, err)
+			_, err = reader.NextEventReader()
+			var checksumErr *ChecksumError
+			require.True(t, errors.As(err, &checksumErr))
+			assert.Equal(t, int64(offset), checksumErr.Offset)
+			assert.NotEqual(t, checksumErr.Expected, checksumErr.Actual)
+			assert.Nil(t, reader.Close())
+		}
+
+		codec := NewInsertCodec(nil)
+		defer codec.Close()
+		_, _, _, err := codec.Deserialize(corrupted)
+		var checksumErr *ChecksumError
+		require.True(t, errors.As(err, &checksumErr))
+		assert.Equal(t, corrupted[2].Key, checksumErr.Path)
+		assert.Contains(t, err.Error(), corrupted[2].Key)
+		assert.Contains(t, err.Error(), "offset "+strconv.Itoa(offset))
+	})
+
+	t.Run("warn only", func(t *testing.T) {
+		SetChecksumWarnOnly(true)
+		defer SetChecksumWarnOnly(false)
+
+		// flip the start timestamp of the event, which keeps the payload readable
+		value := append([]byte{}, blobs[0].Value...)
+		value[firstEventOffset(t, value)+binary.Size(baseEventHeader{})] ^= 0xff
+		reader, err := NewBinlogReader(value)
+		require.Nil(t, err)
+		defer reader.Close()
+		event, err := reader.NextEventReader()
+		assert.Nil(t, err)
+		data, err := event.GetInt64FromPayload()
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, data)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		reader, err := NewBinlogReader(blobs[0].Value[:len(blobs[0].Value)-1])
+		require.Nil(t, err)
+		defer reader.Close()
+		_, err = reader.NextEventReader()
+		assert.Error(t, err)
+	})
+}
+
+func TestBinlogChecksum_Legacy(t *testing.T) {
+	blobs := serializeChecksumTestData(t, false)
+	reader, err := NewBinlogReader(blobs[0].Value)
+	require.Nil(t, err)
+	_, ok := reader.Extras[ChecksumKey]
+	assert.False(t, ok)
+	assert.Nil(t, reader.Close())
+
+	// the binlogs without checksums are not verified
+	value := append([]byte{}, blobs[0].Value...)
+	value[firstEventOffset(t, value)+binary.Size(baseEventHeader{})] ^= 0xff
+	reader, err = NewBinlogReader(value)
+	require.Nil(t, err)
+	defer reader.Close()
+	event, err := reader.NextEventReader()
+	assert.Nil(t, err)
+	data, err := event.GetInt64FromPayload()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, data)
+
+	// the binlog with unknown checksum type
+	w := NewInsertBinlogWriter(schemapb.DataType_Int64, 1, 1, 1, 1)
+	w.AddExtra(ChecksumKey, "md5")
+	e, err := w.NextInsertEventWriter()
+	require.Nil(t, err)
+	require.Nil(t, e.AddInt64ToPayload([]int64{1}))
+	e.SetEventTimestamp(1, 1)
+	w.SetEventTimeStamp(1, 1)
+	w.AddExtra(originalSizeKey, "8")
+	require.Nil(t, w.Close())
+	buf, err := w.GetBuffer()
+	require.Nil(t, err)
+	_, err = NewBinlogReader(buf)
+	assert.Error(t, err)
+}
+
+func TestDeleteCodecChecksum(t *testing.T) {
+	codec := NewDeleteCodec()
+	codec.Checksum = true
+	blob, err := codec.Serialize(1, 1, 1, &DeleteData{Data: map[int64]int64{1: 100, 2: 200}})
+	require.Nil(t, err)
+	blob.Key = "delta_log/1/1/1/1"
+
+	_, _, data, err := codec.Deserialize([]*Blob{blob})
+	assert.Nil(t, err)
+	assert.Equal(t, map[int64]int64{1: 100, 2: 200}, data.Data)
+
+	blob.Value[len(blob.Value)-checksumSize-1] ^= 0xff
+	_, _, _, err = codec.Deserialize([]*Blob{blob})
+	var checksumErr *ChecksumError
+	require.True(t, errors.As(err, &checksumErr))
+	assert.Equal(t, blob.Key, checksumErr.Path)
+}
+
+func TestIndexFileBinlogCodecChecksum(t *testing.T) {
+	content := []byte("index file content to verify")
+	codec := NewIndexFileBinlogCodec()
+	blobs, err := codec.Serialize(1, 1, 1, 1, 1, 1, map[string]string{"index_type": "IVF_FLAT"}, "index", 1,
+		[]*Blob{{Key: "ivf", Value: content}})
+	require.Nil(t, err)
+	require.Nil(t, codec.Close())
+	blobs[0].Key = "index_files/1/1/1/1/ivf"
+
+	codec = NewIndexFileBinlogCodec()
+	datas, _, _, _, err := codec.Deserialize(blobs)
+	assert.Nil(t, err)
+	assert.Equal(t, content, datas[0].Value)
+	assert.Nil(t, codec.Close())
+
+	t.Run("corrupted content", func(t *testing.T) {
+		value := append([]byte{}, blobs[0].Value...)
+		pos := bytes.Index(value, content)
+		require.True(t, pos >= 0)
+		value[pos] ^= 0xff
+
+		codec := NewIndexFileBinlogCodec()
+		defer codec.Close()
+		_, _, _, _, err := codec.Deserialize([]*Blob{{Key: blobs[0].Key, Value: value}})
+		var checksumErr *ChecksumError
+		require.True(t, errors.As(err, &checksumErr))
+		assert.Equal(t, blobs[0].Key, checksumErr.Path)
+		assert.Equal(t, checksum(content), checksumErr.Expected)
+		assert.Contains(t, err.Error(), blobs[0].Key)
+
+		SetChecksumWarnOnly(true)
+		defer SetChecksumWarnOnly(false)
+		datas, _, _, _, err := codec.Deserialize([]*Blob{{Key: blobs[0].Key, Value: value}})
+		assert.Nil(t, err)
+		assert.NotEqual(t, content, datas[0].Value)
+	})
+
+	t.Run("corrupted checksum", func(t *testing.T) {
+		value := append([]byte{}, blobs[0].Value...)
+		key := []byte(`"` + indexChecksumKey + `":"`)
+		pos := bytes.Index(value, key)
+		require.True(t, pos >= 0)
+		// replace the first digit of checksum with another digit
+		digit := &value[pos+len(key)]
+		*digit = '0' + (*digit-'0'+1)%10
+
+		codec := NewIndexFileBinlogCodec()
+		defer codec.Close()
+		_, _, _, _, err := codec.Deserialize([]*Blob{{Key: blobs[0].Key, Value: value}})
+		var checksumErr *ChecksumError
+		assert.True(t, errors.As(err, &checksumErr))
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		w := NewIndexFileBinlogWriter(1, 1, 1, 1, 1, 1, "index", 1, "ivf")
+		e, err := w.NextIndexFileEventWriter()
+		require.Nil(t, err)
+		require.Nil(t, e.AddOneStringToPayload(string(content)))
+		e.SetEventTimestamp(1, 1)
+		w.SetEventTimeStamp(1, 1)
+		w.AddExtra(originalSizeKey, strconv.Itoa(len(content)))
+		require.Nil(t, w.Close())
+		buf, err := w.GetBuffer()
+		require.Nil(t, err)
+
+		codec := NewIndexFileBinlogCodec()
+		defer codec.Close()
+		datas, _, _, _, err := codec.Deserialize([]*Blob{{Key: "ivf", Value: buf}})
+		assert.Nil(t, err)
+		assert.Equal(t, content, datas[0].Value)
+	})
+}
+
+func TestNewChecksum(t *testing.T) {
+	data := []byte("12345678")
+	h := NewChecksum()
+	_, err := h.Write(data[:3])
+	assert.Nil(t, err)
+	_, err = h.Write(data[3:])
+	assert.Nil(t, err)
+	assert.Equal(t, checksum(data), h.Sum32())
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
+)
+
+const (
+	// MinIO stores the chunks in minio, it's the default storage type.
+	MinIO = "minio"
+	// LocalDisk stores the chunks in the local file system, it's used by the standalone deployments.
+	LocalDisk = "local"
+)
+
+// ChunkManagerFactory creates the ChunkManager of the storage type, so the components store
+// the chunks in minio or the local file system by the configuration.
+type ChunkManagerFactory struct {
+	storageType string
+	localPath   string
+	syncOnWrite bool
+	minioOption *miniokv.Option
+}
+
+// NewChunkManagerFactory creates a ChunkManagerFactory, localPath and syncOnWrite are used by the local storage,
+// and minioOption is used by minio.
+func NewChunkManagerFactory(storageType string, localPath string, syncOnWrite bool, minioOption *miniokv.Option) *ChunkManagerFactory {
+	return &ChunkManagerFactory{
+		storageType: storageType,
+		localPath:   localPath,
+		syncOnWrite: syncOnWrite,
+		minioOption: minioOption,
+	}
+}
+
+// NewChunkManager creates a ChunkManager of the storage type.
+func (f *ChunkManagerFactory) NewChunkManager(ctx context.Context) (ChunkManager, error) {
+	switch f.storageType {
+	case LocalDisk:
+		return NewLocalChunkManager(f.localPath, WithSyncOnWrite(f.syncOnWrite)), nil
+	case MinIO, "":
+		minioKV, err := miniokv.NewMinIOKV(ctx, f.minioOption)
+		if err != nil {
+			return nil, err
+		}
+		return NewMinioChunkManager(minioKV), nil
+	default:
+		return nil, fmt.Errorf("unknown storage type %s", f.storageType)
+	}
+}
+
+// NewDataKV creates a kv.DataKV of the storage type.
+func (f *ChunkManagerFactory) NewDataKV(ctx context.Context) (kv.DataKV, error) {
+	switch f.storageType {
+	case MinIO, "":
+		return miniokv.NewMinIOKV(ctx, f.minioOption)
+	default:
+		cm, err := f.NewChunkManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return NewChunkManagerKV(cm), nil
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/kv"
+)
+
+// ChunkManagerKV wraps a ChunkManager as kv.DataKV, it's used by the components reading and writing
+// the binlogs and index files through kv.DataKV, so they run with any kind of ChunkManager.
+type ChunkManagerKV struct {
+	ChunkManager
+}
+
+var _ kv.DataKV = (*ChunkManagerKV)(nil)
+
+// NewChunkManagerKV creates a kv.DataKV backed by the ChunkManager.
+func NewChunkManagerKV(cm ChunkManager) *ChunkManagerKV {
+	return &ChunkManagerKV{
+		ChunkManager: cm,
+	}
+}
+
+// Load loads the data of the key.
+func (cmkv *ChunkManagerKV) Load(key string) (string, error) {
+	content, err := cmkv.Read(key)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// MultiLoad loads the data of the keys.
+func (cmkv *ChunkManagerKV) MultiLoad(keys []string) ([]string, error) {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := cmkv.Load(key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// LoadWithPrefix loads the keys and the data with the prefix.
+func (cmkv *ChunkManagerKV) LoadWithPrefix(prefix string) ([]string, []string, error) {
+	keys, err := cmkv.ListWithPrefix(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := cmkv.MultiLoad(keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+// Save saves the data of the key.
+func (cmkv *ChunkManagerKV) Save(key, value string) error {
+	return cmkv.Write(key, []byte(value))
+}
+
+// MultiSave saves the data of the keys.
+func (cmkv *ChunkManagerKV) MultiSave(kvs map[string]string) error {
+	for key, value := range kvs {
+		if err := cmkv.Save(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MultiRemove removes the data of the keys.
+func (cmkv *ChunkManagerKV) MultiRemove(keys []string) error {
+	for _, key := range keys {
+		if err := cmkv.Remove(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadPartial loads the data of the key ranged in [start, end).
+func (cmkv *ChunkManagerKV) LoadPartial(key string, start, end int64) ([]byte, error) {
+	if start < 0 || start >= end {
+		return nil, fmt.Errorf("invalid range specified: start=%d end=%d", start, end)
+	}
+	p := make([]byte, end-start)
+	n, err := cmkv.ReadAt(key, p, start)
+	if err != nil && n <= 0 {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, errors.New("ChunkManagerKV: failed to load partial data")
+	}
+	return p[:n], nil
+}
+
+// GetSize returns the size of the data of the key.
+func (cmkv *ChunkManagerKV) GetSize(key string) (int64, error) {
+	return cmkv.Size(key)
+}
+
+// Close does nothing, the ChunkManager has no resource to release.
+func (cmkv *ChunkManagerKV) Close() {
+}
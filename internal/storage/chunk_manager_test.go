@@ -0,0 +1,254 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
+)
+
+// testChunkManager runs the common cases of ChunkManager, the keys are written under the prefix.
+func testChunkManager(t *testing.T, cm ChunkManager, prefix string) {
+	key := func(name string) string {
+		return prefix + "/" + name
+	}
+	defer cm.RemoveWithPrefix(prefix)
+
+	t.Run("write and read", func(t *testing.T) {
+		assert.False(t, cm.Exist(key("a")))
+		_, err := cm.Read(key("a"))
+		assert.Error(t, err)
+		_, err = cm.Size(key("a"))
+		assert.Error(t, err)
+
+		err = cm.Write(key("a"), []byte("12345678"))
+		assert.Nil(t, err)
+		assert.True(t, cm.Exist(key("a")))
+		res, err := cm.Read(key("a"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("12345678"), res)
+		size, err := cm.Size(key("a"))
+		assert.Nil(t, err)
+		assert.EqualValues(t, 8, size)
+
+		// overwrite
+		err = cm.Write(key("a"), []byte("abc"))
+		assert.Nil(t, err)
+		res, err = cm.Read(key("a"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("abc"), res)
+
+		err = cm.WriteFrom(key("b"), bytes.NewReader([]byte("xyz")), 3)
+		assert.Nil(t, err)
+		reader, err := cm.Reader(key("b"))
+		assert.Nil(t, err)
+		res, err = ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("xyz"), res)
+		assert.Nil(t, reader.Close())
+	})
+
+	t.Run("read at", func(t *testing.T) {
+		err := cm.Write(key("c"), []byte("12345678"))
+		assert.Nil(t, err)
+
+		p := make([]byte, 4)
+		n, err := cm.ReadAt(key("c"), p, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, n)
+		assert.Equal(t, []byte("3456"), p)
+
+		n, err = cm.ReadAt(key("c"), p, 6)
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, []byte("78"), p[:n])
+
+		_, err = cm.ReadAt(key("c"), p, 9)
+		assert.Error(t, err)
+	})
+
+	t.Run("list and remove", func(t *testing.T) {
+		for _, name := range []string{"dir/1/a", "dir/1/b", "dir/10/c", "other/d"} {
+			err := cm.Write(key(name), []byte{1, 2, 3})
+			assert.Nil(t, err)
+		}
+		keys, err := cm.ListWithPrefix(key("dir/1/"))
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{key("dir/1/a"), key("dir/1/b")}, keys)
+
+		err = cm.Remove(key("dir/1/a"))
+		assert.Nil(t, err)
+		assert.False(t, cm.Exist(key("dir/1/a")))
+		err = cm.Remove(key("dir/1/a"))
+		assert.Nil(t, err)
+
+		err = cm.RemoveWithPrefix(key("dir/"))
+		assert.Nil(t, err)
+		keys, err = cm.ListWithPrefix(key("dir/"))
+		assert.Nil(t, err)
+		assert.Empty(t, keys)
+		assert.True(t, cm.Exist(key("other/d")))
+	})
+
+	t.Run("concurrent write", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				content := bytes.Repeat([]byte{byte(i)}, 1024)
+				assert.Nil(t, cm.Write(key("concurrent"), content))
+			}(i)
+		}
+		wg.Wait()
+
+		// the last writer wins, the content is never mixed
+		res, err := cm.Read(key("concurrent"))
+		assert.Nil(t, err)
+		require.Equal(t, 1024, len(res))
+		assert.Equal(t, bytes.Repeat(res[:1], 1024), res)
+	})
+}
+
+func TestChunkManager(t *testing.T) {
+	t.Run("local", func(t *testing.T) {
+		testChunkManager(t, NewLocalChunkManager(t.TempDir()), "chunk-manager")
+	})
+
+	t.Run("local sync on write", func(t *testing.T) {
+		testChunkManager(t, NewLocalChunkManager(t.TempDir(), WithSyncOnWrite(true)), "chunk-manager")
+	})
+
+	t.Run("minio", func(t *testing.T) {
+		kv, err := newMinIOKVClient(context.TODO(), "minio-chunk-manager")
+		require.Nil(t, err)
+		testChunkManager(t, NewMinioChunkManager(kv), "chunk-manager")
+	})
+}
+
+func TestLocalChunkManager_InvalidKey(t *testing.T) {
+	root := t.TempDir()
+	lcm := NewLocalChunkManager(path.Join(root, "data"))
+
+	for _, key := range []string{"../a", "dir/../../a", "/../a"} {
+		err := lcm.Write(key, []byte{1})
+		assert.Error(t, err, key)
+		assert.False(t, lcm.Exist(key), key)
+		_, err = lcm.Read(key)
+		assert.Error(t, err, key)
+		_, err = lcm.ReadAt(key, make([]byte, 1), 0)
+		assert.Error(t, err, key)
+		err = lcm.Remove(key)
+		assert.Error(t, err, key)
+	}
+	files, err := ioutil.ReadDir(root)
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+
+	// the key is cleaned inside the root path
+	err = lcm.Write("dir/../a", []byte{1})
+	assert.Nil(t, err)
+	assert.True(t, lcm.Exist("a"))
+}
+
+func TestChunkManagerFactory(t *testing.T) {
+	ctx := context.TODO()
+	localPath := t.TempDir()
+
+	factory := NewChunkManagerFactory(LocalDisk, localPath, true, nil)
+	cm, err := factory.NewChunkManager(ctx)
+	assert.Nil(t, err)
+	lcm, ok := cm.(*LocalChunkManager)
+	assert.True(t, ok)
+	assert.Equal(t, localPath, lcm.localPath)
+	assert.True(t, lcm.syncOnWrite)
+
+	dataKV, err := factory.NewDataKV(ctx)
+	assert.Nil(t, err)
+	_, ok = dataKV.(*ChunkManagerKV)
+	assert.True(t, ok)
+
+	factory = NewChunkManagerFactory("unknown", localPath, false, nil)
+	_, err = factory.NewChunkManager(ctx)
+	assert.Error(t, err)
+	_, err = factory.NewDataKV(ctx)
+	assert.Error(t, err)
+
+	endPoint, _ := Params.Load("_MinioAddress")
+	accessKeyID, _ := Params.Load("minio.accessKeyID")
+	secretAccessKey, _ := Params.Load("minio.secretAccessKey")
+	option := &miniokv.Option{
+		Address:           endPoint,
+		AccessKeyID:       accessKeyID,
+		SecretAccessKeyID: secretAccessKey,
+		BucketName:        "minio-chunk-manager",
+		CreateBucket:      true,
+	}
+	factory = NewChunkManagerFactory(MinIO, localPath, false, option)
+	cm, err = factory.NewChunkManager(ctx)
+	assert.Nil(t, err)
+	_, ok = cm.(*MinioChunkManager)
+	assert.True(t, ok)
+}
+
+func TestChunkManagerKV(t *testing.T) {
+	kv := NewChunkManagerKV(NewLocalChunkManager(t.TempDir()))
+	defer kv.Close()
+
+	err := kv.MultiSave(map[string]string{"kv/a": "123", "kv/b": "456789"})
+	assert.Nil(t, err)
+
+	value, err := kv.Load("kv/a")
+	assert.Nil(t, err)
+	assert.Equal(t, "123", value)
+	_, err = kv.Load("kv/c")
+	assert.Error(t, err)
+
+	values, err := kv.MultiLoad([]string{"kv/a", "kv/b"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"123", "456789"}, values)
+
+	keys, values, err := kv.LoadWithPrefix("kv/")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"kv/a", "kv/b"}, keys)
+	assert.ElementsMatch(t, []string{"123", "456789"}, values)
+
+	size, err := kv.GetSize("kv/b")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 6, size)
+	partial, err := kv.LoadPartial("kv/b", 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("56"), partial)
+	partial, err = kv.LoadPartial("kv/b", 4, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("89"), partial)
+	_, err = kv.LoadPartial("kv/b", 3, 3)
+	assert.Error(t, err)
+
+	err = kv.MultiRemove([]string{"kv/a"})
+	assert.Nil(t, err)
+	err = kv.RemoveWithPrefix("kv/")
+	assert.Nil(t, err)
+	keys, _, err = kv.LoadWithPrefix("kv/")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionType is the codec to compress the event payloads of binlog
+type CompressionType string
+
+const (
+	// CompressionNone writes the payloads as they are, binlogs written before compression support are read with it
+	CompressionNone CompressionType = "none"
+	// CompressionSnappy compresses the payloads with snappy, fast but with lower ratio
+	CompressionSnappy CompressionType = "snappy"
+	// CompressionZstd compresses the payloads with zstd, slower but with higher ratio
+	CompressionZstd CompressionType = "zstd"
+)
+
+// CompressionKey is the key of compression codec in the extras of binlog, and in the type params of field
+const CompressionKey = "compression"
+
+var (
+	// the encoder and decoder are safe for concurrent EncodeAll and DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// ParseCompressionType parses the name of compression codec, empty name means CompressionNone
+func ParseCompressionType(name string) (CompressionType, error) {
+	switch CompressionType(name) {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionSnappy, CompressionZstd:
+		return CompressionType(name), nil
+	default:
+		return CompressionNone, fmt.Errorf("unknown compression type %s", name)
+	}
+}
+
+func compress(codec CompressionType, data []byte) ([]byte, error) {
+	switch codec {
+	case "", CompressionNone:
+		return data, nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, data), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data))), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type %s", codec)
+	}
+}
+
+func decompress(codec CompressionType, data []byte) ([]byte, error) {
+	switch codec {
+	case "", CompressionNone:
+		return data, nil
+	case CompressionSnappy:
+		return snappy.Decode(nil, data)
+	case CompressionZstd:
+		return zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unknown compression type %s", codec)
+	}
+}
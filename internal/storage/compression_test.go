@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+var compressionTypes = []CompressionType{CompressionNone, CompressionSnappy, CompressionZstd}
+
+// genTimestampPayload generates increasing int64 values, like the timestamps and auto ids
+func genTimestampPayload(rows int) []byte {
+	data := make([]byte, 8*rows)
+	ts := uint64(428000000000000000)
+	for i := 0; i < rows; i++ {
+		ts += uint64(rand.Intn(1000))
+		binary.LittleEndian.PutUint64(data[8*i:], ts)
+	}
+	return data
+}
+
+// genTextPayload generates text of words from a small vocabulary
+func genTextPayload(rows int) []byte {
+	words := []string{"milvus", "vector", "database", "segment", "binlog", "query", "search", "index", "field", "partition"}
+	var sb strings.Builder
+	for i := 0; i < rows; i++ {
+		for j := 0; j < 8; j++ {
+			sb.WriteString(words[rand.Intn(len(words))])
+			sb.WriteByte(' ')
+		}
+	}
+	return []byte(sb.String())
+}
+
+// genFloatVectorPayload generates random float vectors, which are hardly compressible
+func genFloatVectorPayload(rows, dim int) []byte {
+	data := make([]byte, 4*rows*dim)
+	for i := 0; i < rows*dim; i++ {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(rand.Float32()))
+	}
+	return data
+}
+
+func TestParseCompressionType(t *testing.T) {
+	for _, name := range []string{"", "none"} {
+		codec, err := ParseCompressionType(name)
+		assert.NoError(t, err)
+		assert.Equal(t, CompressionNone, codec)
+	}
+	for _, codec := range []CompressionType{CompressionSnappy, CompressionZstd} {
+		parsed, err := ParseCompressionType(string(codec))
+		assert.NoError(t, err)
+		assert.Equal(t, codec, parsed)
+	}
+	_, err := ParseCompressionType("lz4")
+	assert.Error(t, err)
+}
+
+func TestCompression(t *testing.T) {
+	payloads := map[string][]byte{
+		"timestamp": genTimestampPayload(1000),
+		"text":      genTextPayload(1000),
+		"vector":    genFloatVectorPayload(100, 128),
+	}
+	for name, payload := range payloads {
+		for _, codec := range compressionTypes {
+			t.Run(fmt.Sprintf("%s_%s", name, codec), func(t *testing.T) {
+				compressed, err := compress(codec, payload)
+				require.NoError(t, err)
+				if codec != CompressionNone && name != "vector" {
+					assert.Less(t, len(compressed), len(payload))
+				}
+				decompressed, err := decompress(codec, compressed)
+				require.NoError(t, err)
+				assert.Equal(t, payload, decompressed)
+			})
+		}
+	}
+
+	t.Run("unknown codec", func(t *testing.T) {
+		_, err := compress("lz4", []byte{1, 2, 3})
+		assert.Error(t, err)
+		_, err = decompress("lz4", []byte{1, 2, 3})
+		assert.Error(t, err)
+	})
+
+	t.Run("corrupted data", func(t *testing.T) {
+		for _, codec := range []CompressionType{CompressionSnappy, CompressionZstd} {
+			_, err := decompress(codec, []byte("not compressed"))
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestBinlogCompression(t *testing.T) {
+	for _, codec := range compressionTypes {
+		t.Run(string(codec), func(t *testing.T) {
+			w := NewInsertBinlogWriter(schemapb.DataType_String, 10, 20, 30, 40)
+			require.NoError(t, w.SetCompression(codec))
+			e, err := w.NextInsertEventWriter()
+			require.NoError(t, err)
+			values := strings.Fields(string(genTextPayload(100)))
+			for _, value := range values {
+				require.NoError(t, e.AddOneStringToPayload(value))
+			}
+			e.SetEventTimestamp(100, 200)
+			w.SetEventTimeStamp(1000, 2000)
+			w.AddExtra(originalSizeKey, "1000")
+			require.NoError(t, w.Close())
+			buf, err := w.GetBuffer()
+			require.NoError(t, err)
+
+			r, err := NewBinlogReader(buf)
+			require.NoError(t, err)
+			defer r.Close()
+			if codec == CompressionNone {
+				// the same as the binlogs without compression support
+				_, ok := r.Extras[CompressionKey]
+				assert.False(t, ok)
+			} else {
+				assert.Equal(t, string(codec), r.Extras[CompressionKey])
+			}
+			er, err := r.NextEventReader()
+			require.NoError(t, err)
+			for i, value := range values {
+				s, err := er.GetOneStringFromPayload(i)
+				require.NoError(t, err)
+				assert.Equal(t, value, s)
+			}
+			er, err = r.NextEventReader()
+			assert.NoError(t, err)
+			assert.Nil(t, er)
+		})
+	}
+
+	t.Run("set compression after event writer", func(t *testing.T) {
+		w := NewInsertBinlogWriter(schemapb.DataType_Int64, 10, 20, 30, 40)
+		defer w.Close()
+		assert.Error(t, w.SetCompression("lz4"))
+		_, err := w.NextInsertEventWriter()
+		require.NoError(t, err)
+		assert.Error(t, w.SetCompression(CompressionZstd))
+	})
+
+	t.Run("unknown compression in extras", func(t *testing.T) {
+		w := NewInsertBinlogWriter(schemapb.DataType_Int64, 10, 20, 30, 40)
+		e, err := w.NextInsertEventWriter()
+		require.NoError(t, err)
+		require.NoError(t, e.AddInt64ToPayload([]int64{1, 2, 3}))
+		e.SetEventTimestamp(100, 200)
+		w.SetEventTimeStamp(1000, 2000)
+		w.AddExtra(originalSizeKey, "24")
+		w.AddExtra(CompressionKey, "lz4")
+		require.NoError(t, w.Close())
+		buf, err := w.GetBuffer()
+		require.NoError(t, err)
+
+		_, err = NewBinlogReader(buf)
+		assert.Error(t, err)
+	})
+}
+
+func TestInsertCodecCompression(t *testing.T) {
+	schema := &etcdpb.CollectionMeta{
+		ID: CollectionID,
+		Schema: &schemapb.CollectionSchema{
+			Name: "schema",
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: RowIDField, Name: "row_id", DataType: schemapb.DataType_Int64},
+				{FieldID: TimestampField, Name: "Timestamp", DataType: schemapb.DataType_Int64},
+				{
+					FieldID:    Int64Field,
+					Name:       "field_int64",
+					DataType:   schemapb.DataType_Int64,
+					TypeParams: []*commonpb.KeyValuePair{{Key: CompressionKey, Value: string(CompressionSnappy)}},
+				},
+				{
+					FieldID:    FloatVectorField,
+					Name:       "field_float_vector",
+					DataType:   schemapb.DataType_FloatVector,
+					TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "4"}, {Key: CompressionKey, Value: string(CompressionNone)}},
+				},
+			},
+		},
+	}
+	data := &InsertData{
+		Data: map[int64]FieldData{
+			RowIDField:       &Int64FieldData{NumRows: []int64{2}, Data: []int64{3, 4}},
+			TimestampField:   &Int64FieldData{NumRows: []int64{2}, Data: []int64{3, 4}},
+			Int64Field:       &Int64FieldData{NumRows: []int64{2}, Data: []int64{3, 4}},
+			FloatVectorField: &FloatVectorFieldData{NumRows: []int64{2}, Data: []float32{0, 1, 2, 3, 4, 5, 6, 7}, Dim: 4},
+		},
+	}
+
+	codec := NewInsertCodec(schema)
+	codec.Compression = CompressionZstd
+	blobs, _, err := codec.Serialize(PartitionID, SegmentID, data)
+	require.NoError(t, err)
+
+	// the fields without compression in type params use the codec of InsertCodec
+	expected := map[string]interface{}{
+		fmt.Sprint(RowIDField):       string(CompressionZstd),
+		fmt.Sprint(TimestampField):   string(CompressionZstd),
+		fmt.Sprint(Int64Field):       string(CompressionSnappy),
+		fmt.Sprint(FloatVectorField): nil,
+	}
+	for _, blob := range blobs {
+		r, err := NewBinlogReader(blob.Value)
+		require.NoError(t, err)
+		assert.Equal(t, expected[blob.Key], r.Extras[CompressionKey], "field %s", blob.Key)
+		r.Close()
+	}
+
+	_, _, deserialized, err := NewInsertCodec(nil).Deserialize(blobs)
+	require.NoError(t, err)
+	assert.Equal(t, data.Data[Int64Field].(*Int64FieldData).Data, deserialized.Data[Int64Field].(*Int64FieldData).Data)
+	assert.Equal(t, data.Data[FloatVectorField].(*FloatVectorFieldData).Data, deserialized.Data[FloatVectorField].(*FloatVectorFieldData).Data)
+
+	codec.Compression = "lz4"
+	_, _, err = codec.Serialize(PartitionID, SegmentID, data)
+	assert.Error(t, err)
+}
+
+func BenchmarkCompression(b *testing.B) {
+	payloads := []struct {
+		name string
+		data []byte
+	}{
+		{"timestamp", genTimestampPayload(64 * 1024)},
+		{"text", genTextPayload(8 * 1024)},
+		{"float_vector", genFloatVectorPayload(1024, 128)},
+	}
+	for _, payload := range payloads {
+		for _, codec := range compressionTypes {
+			compressed, err := compress(codec, payload.data)
+			if err != nil {
+				b.Fatal(err)
+			}
+			ratio := float64(len(payload.data)) / float64(len(compressed))
+
+			b.Run(fmt.Sprintf("compress/%s/%s", payload.name, codec), func(b *testing.B) {
+				b.SetBytes(int64(len(payload.data)))
+				b.ReportMetric(ratio, "ratio")
+				for i := 0; i < b.N; i++ {
+					if _, err := compress(codec, payload.data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("decompress/%s/%s", payload.name, codec), func(b *testing.B) {
+				b.SetBytes(int64(len(payload.data)))
+				for i := 0; i < b.N; i++ {
+					if _, err := decompress(codec, compressed); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
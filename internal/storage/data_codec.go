@@ -231,7 +231,14 @@ type InsertData struct {
 // Blob key example:
 // ${tenant}/insert_log/${collection_id}/${partition_id}/${segment_id}/${field_id}/${log_idx}
 type InsertCodec struct {
-	Schema          *etcdpb.CollectionMeta
+	Schema *etcdpb.CollectionMeta
+	// Compression is the codec of fields without compression in type params
+	Compression CompressionType
+	// Checksum appends the checksums to the events of binlogs
+	Checksum bool
+	// KeepOrder serializes the rows in the order of the insert data without sorting them,
+	// e.g. the rows merged by timestamp in compaction
+	KeepOrder       bool
 	readerCloseFunc []func() error
 }
 
@@ -255,17 +262,29 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 	startTs := ts[0]
 	endTs := ts[len(ts)-1]
 
-	dataSorter := &DataSorter{
-		InsertCodec: insertCodec,
-		InsertData:  data,
+	if !insertCodec.KeepOrder {
+		dataSorter := &DataSorter{
+			InsertCodec: insertCodec,
+			InsertData:  data,
+		}
+		sort.Sort(dataSorter)
 	}
-	sort.Sort(dataSorter)
 
 	for _, field := range insertCodec.Schema.Schema.Fields {
 		singleData := data.Data[field.FieldID]
 
 		// encode fields
 		writer = NewInsertBinlogWriter(field.DataType, insertCodec.Schema.ID, partitionID, segmentID, field.FieldID)
+		codec, err := insertCodec.getFieldCompression(field)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = writer.SetCompression(codec); err != nil {
+			return nil, nil, err
+		}
+		if err = writer.SetChecksum(insertCodec.Checksum); err != nil {
+			return nil, nil, err
+		}
 		eventWriter, err := writer.NextInsertEventWriter()
 		if err != nil {
 			return nil, nil, err
@@ -350,6 +369,16 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 	return blobs, statsBlobs, nil
 }
 
+// getFieldCompression returns the compression codec of field, which is set by compression in type params
+func (insertCodec *InsertCodec) getFieldCompression(field *schemapb.FieldSchema) (CompressionType, error) {
+	for _, param := range field.GetTypeParams() {
+		if param.GetKey() == CompressionKey {
+			return ParseCompressionType(param.GetValue())
+		}
+	}
+	return ParseCompressionType(string(insertCodec.Compression))
+}
+
 func (insertCodec *InsertCodec) DeserializeAll(blobs []*Blob) (
 	collectionID UniqueID,
 	partitionID UniqueID,
@@ -387,7 +416,7 @@ func (insertCodec *InsertCodec) DeserializeAll(blobs []*Blob) (
 		for {
 			eventReader, err := binlogReader.NextEventReader()
 			if err != nil {
-				return InvalidUniqueID, InvalidUniqueID, InvalidUniqueID, nil, err
+				return InvalidUniqueID, InvalidUniqueID, InvalidUniqueID, nil, withChecksumPath(err, blob.Key)
 			}
 			if eventReader == nil {
 				break
@@ -610,6 +639,8 @@ type DeleteData struct {
 
 // DeleteCodec serializes and deserializes the delete data
 type DeleteCodec struct {
+	// Checksum appends the checksums to the events of deltalogs
+	Checksum        bool
 	readerCloseFunc []func() error
 }
 
@@ -622,6 +653,9 @@ func NewDeleteCodec() *DeleteCodec {
 // For each delete message, it will save "pk,ts" string to binlog.
 func (deleteCodec *DeleteCodec) Serialize(collectionID UniqueID, partitionID UniqueID, segmentID UniqueID, data *DeleteData) (*Blob, error) {
 	binlogWriter := NewDeleteBinlogWriter(schemapb.DataType_String, collectionID, partitionID, segmentID)
+	if err := binlogWriter.SetChecksum(deleteCodec.Checksum); err != nil {
+		return nil, err
+	}
 	eventWriter, err := binlogWriter.NextDeleteEventWriter()
 	if err != nil {
 		return nil, err
@@ -686,7 +720,7 @@ func (deleteCodec *DeleteCodec) Deserialize(blobs []*Blob) (partitionID UniqueID
 		pid, sid = binlogReader.PartitionID, binlogReader.SegmentID
 		eventReader, err := binlogReader.NextEventReader()
 		if err != nil {
-			return InvalidUniqueID, InvalidUniqueID, nil, err
+			return InvalidUniqueID, InvalidUniqueID, nil, withChecksumPath(err, blob.Key)
 		}
 
 		length, err := eventReader.GetPayloadLengthFromReader()
@@ -957,6 +991,7 @@ func (codec *IndexFileBinlogCodec) Serialize(
 		// store index parameters to extra, in bytes format.
 		params, _ := json.Marshal(indexParams)
 		writer.descriptorEvent.AddExtra(IndexParamsKey, params)
+		writer.descriptorEvent.AddExtra(indexChecksumKey, strconv.FormatUint(uint64(checksum(datas[pos].Value)), 10))
 
 		eventWriter, err := writer.NextIndexFileEventWriter()
 		if err != nil {
@@ -1008,6 +1043,7 @@ func (codec *IndexFileBinlogCodec) Serialize(
 	}
 
 	params, _ := json.Marshal(indexParams)
+	writer.descriptorEvent.AddExtra(indexChecksumKey, strconv.FormatUint(uint64(checksum(params)), 10))
 	length := (len(params) + maxLengthPerRowOfIndexFile - 1) / maxLengthPerRowOfIndexFile
 	for i := 0; i < length; i++ {
 		start := i * maxLengthPerRowOfIndexFile
@@ -1115,7 +1151,7 @@ func (codec *IndexFileBinlogCodec) DeserializeImpl(blobs []*Blob) (
 			if err != nil {
 				log.Warn("failed to get next event reader",
 					zap.Error(err))
-				return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
+				return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, withChecksumPath(err, blob.Key)
 			}
 			if eventReader == nil {
 				break
@@ -1141,6 +1177,18 @@ func (codec *IndexFileBinlogCodec) DeserializeImpl(blobs []*Blob) (
 					content = append(content, []byte(singleString)...)
 				}
 
+				// index files without checksum in extras are written before checksum is supported
+				if v, ok := extra[indexChecksumKey]; ok {
+					expected, err := strconv.ParseUint(fmt.Sprint(v), 10, 32)
+					if err != nil {
+						return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, fmt.Errorf("invalid checksum of index file %s: %v", blob.Key, v)
+					}
+					if err := checkChecksum(blob.Key, -1, uint32(expected), checksum(content)); err != nil {
+						log.Warn("failed to verify index file", zap.Error(err))
+						return 0, 0, 0, 0, 0, 0, nil, "", 0, nil, err
+					}
+				}
+
 				if key == IndexParamsKey {
 					_ = json.Unmarshal(content, &indexParams)
 				} else {
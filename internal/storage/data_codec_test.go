@@ -487,6 +487,40 @@ func TestIndexCodec(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestInsertCodecKeepOrder(t *testing.T) {
+	schema := &etcdpb.CollectionMeta{
+		ID: CollectionID,
+		Schema: &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: RowIDField, Name: "row_id", DataType: schemapb.DataType_Int64},
+				{FieldID: TimestampField, Name: "Timestamp", DataType: schemapb.DataType_Int64},
+			},
+		},
+	}
+	genInsertData := func() *InsertData {
+		return &InsertData{
+			Data: map[int64]FieldData{
+				RowIDField:     &Int64FieldData{NumRows: []int64{3}, Data: []int64{3, 1, 2}},
+				TimestampField: &Int64FieldData{NumRows: []int64{3}, Data: []int64{10, 20, 30}},
+			},
+		}
+	}
+	deserializeTimestamps := func(codec *InsertCodec) []int64 {
+		blobs, _, err := codec.Serialize(PartitionID, SegmentID, genInsertData())
+		assert.Nil(t, err)
+		_, _, data, err := NewInsertCodec(schema).Deserialize(blobs)
+		assert.Nil(t, err)
+		return data.Data[TimestampField].(*Int64FieldData).Data
+	}
+
+	// rows are sorted by row id by default
+	assert.Equal(t, []int64{20, 30, 10}, deserializeTimestamps(NewInsertCodec(schema)))
+
+	codec := NewInsertCodec(schema)
+	codec.KeepOrder = true
+	assert.Equal(t, []int64{10, 20, 30}, deserializeTimestamps(codec))
+}
+
 func TestTsError(t *testing.T) {
 	insertData := &InsertData{}
 	insertCodec := NewInsertCodec(nil)
@@ -25,6 +25,8 @@ type EventReader struct {
 	PayloadReaderInterface
 	buffer   *bytes.Buffer
 	isClosed bool
+	// payload keeps the decompressed payload alive for payload reader
+	payload []byte
 }
 
 func (reader *EventReader) readHeader() error {
@@ -76,12 +78,19 @@ func (reader *EventReader) readData() error {
 func (reader *EventReader) Close() error {
 	if !reader.isClosed {
 		reader.isClosed = true
+		reader.buffer, reader.payload = nil, nil
 		return reader.PayloadReaderInterface.Close()
 	}
 	return nil
 }
 
-func newEventReader(datatype schemapb.DataType, buffer *bytes.Buffer) (*EventReader, error) {
+func newEventReader(datatype schemapb.DataType, buffer *bytes.Buffer, codec CompressionType) (*EventReader, error) {
+	return newChecksumEventReader(datatype, buffer, codec, false)
+}
+
+// newChecksumEventReader creates the event reader of the event ends with its checksum if checksum is true,
+// the checksum is skipped, which should be verified before.
+func newChecksumEventReader(datatype schemapb.DataType, buffer *bytes.Buffer, codec CompressionType, checksum bool) (*EventReader, error) {
 	reader := &EventReader{
 		eventHeader: eventHeader{
 			baseEventHeader{},
@@ -98,7 +107,17 @@ func newEventReader(datatype schemapb.DataType, buffer *bytes.Buffer) (*EventRea
 	}
 
 	next := int(reader.EventLength - reader.eventHeader.GetMemoryUsageInBytes() - reader.GetEventDataFixPartSize())
-	payloadBuffer := buffer.Next(next)
+	if checksum {
+		next -= checksumSize
+	}
+	payloadBuffer, err := decompress(codec, buffer.Next(next))
+	if err != nil {
+		return nil, err
+	}
+	if checksum {
+		buffer.Next(checksumSize)
+	}
+	reader.payload = payloadBuffer
 	payloadReader, err := NewPayloadReader(datatype, payloadBuffer)
 	if err != nil {
 		return nil, err
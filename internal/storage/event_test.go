@@ -184,7 +184,7 @@ func TestInsertEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(dt, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(dt, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -367,7 +367,7 @@ func TestInsertEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 
 		s0, err = r.GetOneStringFromPayload(0)
@@ -430,7 +430,7 @@ func TestDeleteEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(dt, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(dt, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -613,7 +613,7 @@ func TestDeleteEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 
 		s0, err = r.GetOneStringFromPayload(0)
@@ -676,7 +676,7 @@ func TestCreateCollectionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -733,7 +733,7 @@ func TestCreateCollectionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 
 		s0, err = r.GetOneStringFromPayload(0)
@@ -796,7 +796,7 @@ func TestDropCollectionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -853,7 +853,7 @@ func TestDropCollectionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 
 		s0, err = r.GetOneStringFromPayload(0)
@@ -916,7 +916,7 @@ func TestCreatePartitionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -973,7 +973,7 @@ func TestCreatePartitionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 
 		s0, err = r.GetOneStringFromPayload(0)
@@ -1036,7 +1036,7 @@ func TestDropPartitionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -1093,7 +1093,7 @@ func TestDropPartitionEvent(t *testing.T) {
 		err = pR.Close()
 		assert.Nil(t, err)
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 		assert.Nil(t, err)
 
 		s0, err = r.GetOneStringFromPayload(0)
@@ -1253,7 +1253,7 @@ func TestReadFixPartError(t *testing.T) {
 
 func TestEventReaderError(t *testing.T) {
 	buf := new(bytes.Buffer)
-	r, err := newEventReader(schemapb.DataType_Int64, buf)
+	r, err := newEventReader(schemapb.DataType_Int64, buf, CompressionNone)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1261,7 +1261,7 @@ func TestEventReaderError(t *testing.T) {
 	err = header.Write(buf)
 	assert.Nil(t, err)
 
-	r, err = newEventReader(schemapb.DataType_Int64, buf)
+	r, err = newEventReader(schemapb.DataType_Int64, buf, CompressionNone)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1270,7 +1270,7 @@ func TestEventReaderError(t *testing.T) {
 	err = header.Write(buf)
 	assert.Nil(t, err)
 
-	r, err = newEventReader(schemapb.DataType_Int64, buf)
+	r, err = newEventReader(schemapb.DataType_Int64, buf, CompressionNone)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1287,7 +1287,7 @@ func TestEventReaderError(t *testing.T) {
 	err = binary.Write(buf, binary.LittleEndian, insertData)
 	assert.Nil(t, err)
 
-	r, err = newEventReader(schemapb.DataType_Int64, buf)
+	r, err = newEventReader(schemapb.DataType_Int64, buf, CompressionNone)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1309,7 +1309,7 @@ func TestEventClose(t *testing.T) {
 	assert.Nil(t, err)
 
 	wBuf := buf.Bytes()
-	r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+	r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf), CompressionNone)
 	assert.Nil(t, err)
 
 	err = r.Close()
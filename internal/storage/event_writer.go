@@ -126,31 +126,54 @@ type baseEventWriter struct {
 	offset           int32
 	getEventDataSize func() int32
 	writeEventData   func(buffer io.Writer) error
+
+	// codec compresses the payload when finished
+	codec             CompressionType
+	compressedPayload []byte
+	// checksum appends the checksum of event to the end of event
+	checksum bool
+}
+
+// getPayload returns the payload written to binlog, which is compressed once the writer is finished
+func (writer *baseEventWriter) getPayload() ([]byte, error) {
+	if writer.compressedPayload != nil {
+		return writer.compressedPayload, nil
+	}
+	return writer.GetPayloadBufferFromWriter()
 }
 
 func (writer *baseEventWriter) GetMemoryUsageInBytes() (int32, error) {
-	data, err := writer.GetPayloadBufferFromWriter()
+	data, err := writer.getPayload()
 	if err != nil {
 		return -1, err
 	}
 	size := writer.getEventDataSize() + writer.eventHeader.GetMemoryUsageInBytes() + int32(len(data))
+	if writer.checksum {
+		size += checksumSize
+	}
 	return size, nil
 }
 
 func (writer *baseEventWriter) Write(buffer *bytes.Buffer) error {
+	start := buffer.Len()
 	if err := writer.eventHeader.Write(buffer); err != nil {
 		return err
 	}
 	if err := writer.writeEventData(buffer); err != nil {
 		return err
 	}
-	data, err := writer.GetPayloadBufferFromWriter()
+	data, err := writer.getPayload()
 	if err != nil {
 		return err
 	}
 	if err := binary.Write(buffer, binary.LittleEndian, data); err != nil {
 		return err
 	}
+	if writer.checksum {
+		if err := binary.Write(buffer, binary.LittleEndian, checksum(buffer.Bytes()[start:])); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -160,6 +183,15 @@ func (writer *baseEventWriter) Finish() error {
 		if err := writer.FinishPayloadWriter(); err != nil {
 			return err
 		}
+		if writer.codec != "" && writer.codec != CompressionNone {
+			data, err := writer.GetPayloadBufferFromWriter()
+			if err != nil {
+				return err
+			}
+			if writer.compressedPayload, err = compress(writer.codec, data); err != nil {
+				return err
+			}
+		}
 		eventLength, err := writer.GetMemoryUsageInBytes()
 		if err != nil {
 			return err
@@ -12,10 +12,15 @@
 package storage
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/exp/mmap"
 
@@ -23,15 +28,46 @@ import (
 )
 
 // LocalChunkManager is responsible for read and write local file.
+// The keys are the paths relative to localPath, the keys escaping localPath are rejected.
+// A file is written to a temporary file and renamed, so the concurrent writers of a key never interleave,
+// and the readers never see a partial file.
 type LocalChunkManager struct {
-	localPath string
+	localPath   string
+	syncOnWrite bool
+}
+
+// LocalOption is an option of LocalChunkManager.
+type LocalOption func(*LocalChunkManager)
+
+// WithSyncOnWrite makes LocalChunkManager fsync the files before a write returns.
+func WithSyncOnWrite(syncOnWrite bool) LocalOption {
+	return func(lcm *LocalChunkManager) {
+		lcm.syncOnWrite = syncOnWrite
+	}
 }
 
 // NewLocalChunkManager create a new local manager object.
-func NewLocalChunkManager(localPath string) *LocalChunkManager {
-	return &LocalChunkManager{
+func NewLocalChunkManager(localPath string, opts ...LocalOption) *LocalChunkManager {
+	lcm := &LocalChunkManager{
 		localPath: localPath,
 	}
+	for _, opt := range opts {
+		opt(lcm)
+	}
+	return lcm
+}
+
+// filePath returns the path of the local file of the key, it fails if the key escapes localPath.
+func (lcm *LocalChunkManager) filePath(key string) (string, error) {
+	filePath := path.Join(lcm.localPath, key)
+	rel, err := filepath.Rel(lcm.localPath, filePath)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("local chunk key %s is out of %s", key, lcm.localPath)
+	}
+	return filePath, nil
 }
 
 // GetPath returns the path of local data if exist.
@@ -39,13 +75,20 @@ func (lcm *LocalChunkManager) GetPath(key string) (string, error) {
 	if !lcm.Exist(key) {
 		return "", errors.New("local file cannot be found with key:" + key)
 	}
-	path := path.Join(lcm.localPath, key)
-	return path, nil
+	return lcm.filePath(key)
 }
 
 // Write writes the data to local storage.
 func (lcm *LocalChunkManager) Write(key string, content []byte) error {
-	filePath := path.Join(lcm.localPath, key)
+	return lcm.WriteFrom(key, bytes.NewReader(content), int64(len(content)))
+}
+
+// WriteFrom writes the data from the reader to local storage.
+func (lcm *LocalChunkManager) WriteFrom(key string, reader io.Reader, size int64) error {
+	filePath, err := lcm.filePath(key)
+	if err != nil {
+		return err
+	}
 	dir := path.Dir(filePath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		err := os.MkdirAll(dir, os.ModePerm)
@@ -53,52 +96,197 @@ func (lcm *LocalChunkManager) Write(key string, content []byte) error {
 			return err
 		}
 	}
-	err := ioutil.WriteFile(filePath, content, 0600)
+
+	file, err := ioutil.TempFile(dir, "."+path.Base(filePath)+".tmp-*")
 	if err != nil {
 		return err
 	}
+	tmpPath := file.Name()
+	writeFn := func() error {
+		written, err := io.Copy(file, reader)
+		if err != nil {
+			return err
+		}
+		if size >= 0 && written != size {
+			return fmt.Errorf("local chunk %s is written with %d bytes, expected %d bytes", key, written, size)
+		}
+		if lcm.syncOnWrite {
+			if err := file.Sync(); err != nil {
+				return err
+			}
+		}
+		return file.Close()
+	}
+	if err := writeFn(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if lcm.syncOnWrite {
+		return syncDir(dir)
+	}
 	return nil
 }
 
+// syncDir makes the renaming of the files in the directory durable.
+func syncDir(dir string) error {
+	d, err := os.Open(path.Clean(dir))
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
 // Exist checks whether chunk is saved to local storage.
 func (lcm *LocalChunkManager) Exist(key string) bool {
-	path := path.Join(lcm.localPath, key)
-	_, err := os.Stat(path)
+	filePath, err := lcm.filePath(key)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filePath)
 	if err != nil {
 		return os.IsExist(err)
 	}
 	return true
 }
 
+// Size returns the size of the local storage data.
+func (lcm *LocalChunkManager) Size(key string) (int64, error) {
+	filePath, err := lcm.filePath(key)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Stat returns the size and the version of the local storage data, the version is made of the modification time
+// and the size, it changes when the data is rewritten.
+func (lcm *LocalChunkManager) Stat(key string) (int64, string, error) {
+	filePath, err := lcm.filePath(key)
+	if err != nil {
+		return 0, "", err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return 0, "", err
+	}
+	return info.Size(), fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size()), nil
+}
+
 // Read reads the local storage data if exist.
 func (lcm *LocalChunkManager) Read(key string) ([]byte, error) {
-	filePath := path.Join(lcm.localPath, key)
-	file, err := os.Open(path.Clean(filePath))
+	file, err := lcm.Reader(key)
 	if err != nil {
 		return nil, err
 	}
 	content, err := ioutil.ReadAll(file)
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
 	return content, file.Close()
 }
 
+// Reader opens the local storage data for reading if exist.
+func (lcm *LocalChunkManager) Reader(key string) (io.ReadCloser, error) {
+	filePath, err := lcm.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path.Clean(filePath))
+}
+
 // ReadAt reads specific position data of local storage if exist.
-func (lcm *LocalChunkManager) ReadAt(key string, p []byte, off int64) (n int, err error) {
-	path := path.Join(lcm.localPath, key)
-	at, err := mmap.Open(path)
+func (lcm *LocalChunkManager) ReadAt(key string, p []byte, off int64) (int, error) {
+	filePath, err := lcm.filePath(key)
+	if err != nil {
+		return 0, err
+	}
+	at, err := mmap.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
 	defer func() {
-		if at != nil {
-			err = at.Close()
-			if err != nil {
-				log.Error(err.Error())
-			}
+		// the error of ReadAt is returned, the error of closing is only logged
+		if err := at.Close(); err != nil {
+			log.Error(err.Error())
 		}
 	}()
+
+	return at.ReadAt(p, off)
+}
+
+// isTempFile returns whether the file is a temporary file of an ongoing write.
+func isTempFile(filePath string) bool {
+	base := path.Base(filePath)
+	return strings.HasPrefix(base, ".") && strings.Contains(base, ".tmp-")
+}
+
+// ListWithPrefix lists the keys of local storage data with the prefix.
+func (lcm *LocalChunkManager) ListWithPrefix(prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(lcm.localPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || isTempFile(filePath) {
+			return nil
+		}
+		key, err := filepath.Rel(lcm.localPath, filePath)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// Remove deletes the local storage data, it's not an error if the data doesn't exist.
+func (lcm *LocalChunkManager) Remove(key string) error {
+	filePath, err := lcm.filePath(key)
 	if err != nil {
-		return 0, err
+		return err
 	}
+	err = os.Remove(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
 
-	return at.ReadAt(p, off)
+// RemoveWithPrefix deletes the local storage data with the prefix.
+func (lcm *LocalChunkManager) RemoveWithPrefix(prefix string) error {
+	keys, err := lcm.ListWithPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := lcm.Remove(key); err != nil {
+			return err
+		}
+	}
+	return nil
 }
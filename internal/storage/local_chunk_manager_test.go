@@ -12,6 +12,8 @@
 package storage
 
 import (
+	"bytes"
+	"io/ioutil"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +52,56 @@ func TestLocalChunkManager_Read(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, len(res), len(bin))
 }
+
+func TestLocalChunkManager_Remove(t *testing.T) {
+	lcm := NewLocalChunkManager(t.TempDir())
+
+	keys, err := lcm.ListWithPrefix("index/1/")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+
+	for _, key := range []string{"index/1/1/a", "index/1/2/b", "index/10/1/c"} {
+		err = lcm.Write(key, []byte{1, 2, 3})
+		assert.Nil(t, err)
+	}
+	keys, err = lcm.ListWithPrefix("index/1/")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"index/1/1/a", "index/1/2/b"}, keys)
+
+	err = lcm.Remove("index/1/1/a")
+	assert.Nil(t, err)
+	assert.False(t, lcm.Exist("index/1/1/a"))
+	err = lcm.Remove("index/1/1/a")
+	assert.Nil(t, err)
+
+	keys, err = lcm.ListWithPrefix("index/")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"index/1/2/b", "index/10/1/c"}, keys)
+}
+
+func TestLocalChunkManager_WriteFromAndReader(t *testing.T) {
+	lcm := NewLocalChunkManager(t.TempDir())
+
+	_, err := lcm.Reader("invalid")
+	assert.Error(t, err)
+
+	bin := []byte{1, 2, 3}
+	err = lcm.WriteFrom("stream/1", bytes.NewReader(bin), int64(len(bin)))
+	assert.Nil(t, err)
+	reader, err := lcm.Reader("stream/1")
+	assert.Nil(t, err)
+	res, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, bin, res)
+	assert.Nil(t, reader.Close())
+
+	// the size is unknown
+	err = lcm.WriteFrom("stream/1", bytes.NewReader(bin[:2]), -1)
+	assert.Nil(t, err)
+	res, err = lcm.Read("stream/1")
+	assert.Nil(t, err)
+	assert.Equal(t, bin[:2], res)
+
+	err = lcm.WriteFrom("stream/2", bytes.NewReader(bin), 4)
+	assert.Error(t, err)
+}
@@ -12,12 +12,19 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"io"
 
 	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/retry"
+	"go.uber.org/zap"
 )
 
+// minioRetryAttempts is the max attempts of a request to minio, only the transient errors are retried.
+const minioRetryAttempts = 5
+
 // MinioChunkManager is responsible for read and write data stored in minio.
 type MinioChunkManager struct {
 	minio *miniokv.MinIOKV
@@ -30,6 +37,25 @@ func NewMinioChunkManager(minio *miniokv.MinIOKV) *MinioChunkManager {
 	}
 }
 
+// retry calls fn until it succeeds or fails with a permanent error, it returns the last error of fn.
+func (mcm *MinioChunkManager) retry(key string, fn func() error) error {
+	var lastErr error
+	err := retry.Do(context.TODO(), func() error {
+		lastErr = fn()
+		if lastErr != nil && !miniokv.IsRetryable(lastErr) {
+			return retry.Unrecoverable(lastErr)
+		}
+		if lastErr != nil {
+			log.Warn("MinioChunkManager request failed, retrying", zap.String("key", key), zap.Error(lastErr))
+		}
+		return lastErr
+	}, retry.Attempts(minioRetryAttempts))
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
 // GetPath returns the path of minio data if exist.
 func (mcm *MinioChunkManager) GetPath(key string) (string, error) {
 	if !mcm.Exist(key) {
@@ -40,7 +66,28 @@ func (mcm *MinioChunkManager) GetPath(key string) (string, error) {
 
 // Write writes the data to minio storage.
 func (mcm *MinioChunkManager) Write(key string, content []byte) error {
-	return mcm.minio.Save(key, string(content))
+	return mcm.retry(key, func() error {
+		return mcm.minio.Save(key, string(content))
+	})
+}
+
+// WriteFrom writes the data from the reader to minio storage, the large data is uploaded in parts.
+// The request is retried only if the reader can seek back to the start.
+func (mcm *MinioChunkManager) WriteFrom(key string, reader io.Reader, size int64) error {
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return mcm.minio.SaveObject(key, reader, size)
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	return mcm.retry(key, func() error {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return retry.Unrecoverable(err)
+		}
+		return mcm.minio.SaveObject(key, reader, size)
+	})
 }
 
 // Exist checks whether chunk is saved to minio storage.
@@ -50,21 +97,109 @@ func (mcm *MinioChunkManager) Exist(key string) bool {
 
 // Read reads the minio storage data if exist.
 func (mcm *MinioChunkManager) Read(key string) ([]byte, error) {
-	results, err := mcm.minio.Load(key)
+	var results string
+	err := mcm.retry(key, func() error {
+		var err error
+		results, err = mcm.minio.Load(key)
+		return err
+	})
 	return []byte(results), err
 }
 
-// ReadAt reads specific position data of minio storage if exist.
+// Reader opens the minio storage data for reading if exist.
+func (mcm *MinioChunkManager) Reader(key string) (io.ReadCloser, error) {
+	var reader io.ReadCloser
+	err := mcm.retry(key, func() error {
+		var err error
+		reader, err = mcm.minio.LoadObject(key)
+		return err
+	})
+	return reader, err
+}
+
+// ListWithPrefix lists the keys of minio storage data with the prefix.
+func (mcm *MinioChunkManager) ListWithPrefix(prefix string) ([]string, error) {
+	var keys []string
+	err := mcm.retry(prefix, func() error {
+		var err error
+		keys, err = mcm.minio.ListWithPrefix(prefix)
+		return err
+	})
+	return keys, err
+}
+
+// Remove deletes the minio storage data, it's not an error if the data doesn't exist.
+func (mcm *MinioChunkManager) Remove(key string) error {
+	err := mcm.retry(key, func() error {
+		return mcm.minio.Remove(key)
+	})
+	if miniokv.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// RemoveWithPrefix deletes the minio storage data with the prefix.
+func (mcm *MinioChunkManager) RemoveWithPrefix(prefix string) error {
+	return mcm.retry(prefix, func() error {
+		return mcm.minio.RemoveWithPrefix(prefix)
+	})
+}
+
+// Size returns the size of the minio storage data.
+func (mcm *MinioChunkManager) Size(key string) (int64, error) {
+	var size int64
+	err := mcm.retry(key, func() error {
+		var err error
+		size, err = mcm.minio.GetSize(key)
+		return err
+	})
+	return size, err
+}
+
+// Stat returns the size and the etag of the minio storage data.
+func (mcm *MinioChunkManager) Stat(key string) (int64, string, error) {
+	var size int64
+	var etag string
+	err := mcm.retry(key, func() error {
+		var err error
+		size, etag, err = mcm.minio.Stat(key)
+		return err
+	})
+	return size, etag, err
+}
+
+// ReadAt reads specific position data of minio storage if exist, only the range of the data is fetched.
 func (mcm *MinioChunkManager) ReadAt(key string, p []byte, off int64) (int, error) {
-	results, err := mcm.minio.Load(key)
+	size, err := mcm.Size(key)
 	if err != nil {
 		return -1, err
 	}
 
-	if off < 0 || int64(len([]byte(results))) < off {
+	if off < 0 || size < off {
 		return 0, errors.New("MinioChunkManager: invalid offset")
 	}
-	n := copy(p, []byte(results)[off:])
+	end := off + int64(len(p))
+	if end > size {
+		end = size
+	}
+	if off == end {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+
+	var results []byte
+	err = mcm.retry(key, func() error {
+		var err error
+		results, err = mcm.minio.LoadPartial(key, off, end)
+		return err
+	})
+	if err != nil {
+		return -1, err
+	}
+	n := copy(p, results)
 	if n < len(p) {
 		return n, io.EOF
 	}
@@ -12,9 +12,14 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"io"
 	"testing"
 
+	miniokv "github.com/milvus-io/milvus/internal/kv/minio"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,3 +74,122 @@ func TestMinioChunkManager_ReadAt(t *testing.T) {
 		assert.Equal(t, content[i-offset], bin[i])
 	}
 }
+
+func TestMinioChunkManager_Remove(t *testing.T) {
+	bucketName := "minio-chunk-manager"
+	kv, err := newMinIOKVClient(context.TODO(), bucketName)
+	assert.Nil(t, err)
+
+	minioMgr := NewMinioChunkManager(kv)
+	err = minioMgr.minio.RemoveWithPrefix("remove/")
+	assert.Nil(t, err)
+
+	for _, key := range []string{"remove/1/a", "remove/1/b", "remove/10/c"} {
+		err = minioMgr.Write(key, []byte{1, 2, 3})
+		assert.Nil(t, err)
+	}
+	keys, err := minioMgr.ListWithPrefix("remove/1/")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"remove/1/a", "remove/1/b"}, keys)
+
+	err = minioMgr.Remove("remove/1/a")
+	assert.Nil(t, err)
+	assert.False(t, minioMgr.Exist("remove/1/a"))
+	err = minioMgr.Remove("remove/1/a")
+	assert.Nil(t, err)
+
+	keys, err = minioMgr.ListWithPrefix("remove/")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"remove/1/b", "remove/10/c"}, keys)
+}
+
+// patternReader generates size bytes of a pattern, the byte at offset i is byte(i % 251).
+type patternReader struct {
+	off  int64
+	size int64
+}
+
+func (r *patternReader) Read(p []byte) (int, error) {
+	if r.off >= r.size {
+		return 0, io.EOF
+	}
+	n := int64(len(p))
+	if n > r.size-r.off {
+		n = r.size - r.off
+	}
+	for i := int64(0); i < n; i++ {
+		p[i] = byte((r.off + i) % 251)
+	}
+	r.off += n
+	return int(n), nil
+}
+
+func TestMinioChunkManager_LargeObject(t *testing.T) {
+	bucketName := "minio-chunk-manager"
+	kv, err := newMinIOKVClient(context.TODO(), bucketName)
+	assert.Nil(t, err)
+
+	minioMgr := NewMinioChunkManager(kv)
+	key := "large/1"
+	// uploaded in 16 parts without buffering the whole object
+	const size = 16 * miniokv.DefaultPartSize
+	err = minioMgr.WriteFrom(key, &patternReader{size: size}, -1)
+	assert.Nil(t, err)
+	defer minioMgr.Remove(key)
+
+	expected := sha256.New()
+	_, err = io.Copy(expected, &patternReader{size: size})
+	assert.Nil(t, err)
+	reader, err := minioMgr.Reader(key)
+	assert.Nil(t, err)
+	actual := sha256.New()
+	n, err := io.Copy(actual, reader)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(size), n)
+	assert.Equal(t, expected.Sum(nil), actual.Sum(nil))
+	assert.Nil(t, reader.Close())
+
+	// ranged reads across the parts
+	content := make([]byte, 1024)
+	for _, off := range []int64{0, miniokv.DefaultPartSize - 512, 7*miniokv.DefaultPartSize + 3} {
+		n, err := minioMgr.ReadAt(key, content, off)
+		assert.Nil(t, err)
+		assert.Equal(t, len(content), n)
+		for i := range content {
+			assert.Equal(t, byte((off+int64(i))%251), content[i])
+		}
+	}
+	n2, err := minioMgr.ReadAt(key, content, size-10)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 10, n2)
+	n2, err = minioMgr.ReadAt(key, content, size)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, n2)
+
+	// the seekable reader is uploaded with a known size
+	bin := []byte{1, 2, 3}
+	err = minioMgr.WriteFrom("large/2", bytes.NewReader(bin), int64(len(bin)))
+	assert.Nil(t, err)
+	res, err := minioMgr.Read("large/2")
+	assert.Nil(t, err)
+	assert.Equal(t, bin, res)
+	err = minioMgr.Remove("large/2")
+	assert.Nil(t, err)
+}
+
+func TestMinioChunkManager_NotExist(t *testing.T) {
+	bucketName := "minio-chunk-manager"
+	kv, err := newMinIOKVClient(context.TODO(), bucketName)
+	assert.Nil(t, err)
+
+	minioMgr := NewMinioChunkManager(kv)
+	// a missing object is a permanent error which is returned without retrying
+	_, err = minioMgr.Read("not-exist")
+	assert.True(t, miniokv.IsNotExist(err))
+	reader, err := minioMgr.Reader("not-exist")
+	assert.Nil(t, reader)
+	assert.True(t, miniokv.IsNotExist(err))
+	n, err := minioMgr.ReadAt("not-exist", make([]byte, 8), 0)
+	assert.Equal(t, -1, n)
+	assert.True(t, miniokv.IsNotExist(err))
+}
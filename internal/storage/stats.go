@@ -19,11 +19,22 @@ import (
 )
 
 const (
-	// TODO silverxia maybe need set from config
-	bloomFilterSize       uint    = 100000
+	// the bloom filter of pk statslog is sized by the rows of the binlog, but no smaller than minBloomFilterSize,
+	// so that the filter of a small binlog doesn't take the space of a large one
+	minBloomFilterSize    uint    = 1024
 	maxBloomFalsePositive float64 = 0.005
 )
 
+// NewPKBloomFilter creates the bloom filter for rows primary keys, which keeps the false positive rate
+// under maxBloomFalsePositive
+func NewPKBloomFilter(rows int) *bloom.BloomFilter {
+	size := minBloomFilterSize
+	if uint(rows) > size {
+		size = uint(rows)
+	}
+	return bloom.NewWithEstimates(size, maxBloomFalsePositive)
+}
+
 type Stats interface {
 }
 
@@ -50,11 +61,20 @@ func (sw *StatsWriter) StatsInt64(fieldID int64, isPrimaryKey bool, msgs []int64
 
 	stats := &Int64Stats{
 		FieldID: fieldID,
-		Max:     msgs[len(msgs)-1],
+		Max:     msgs[0],
 		Min:     msgs[0],
 	}
+	// msgs are in insertion order, which is not sorted if primary keys are specified by user
+	for _, msg := range msgs {
+		if msg > stats.Max {
+			stats.Max = msg
+		}
+		if msg < stats.Min {
+			stats.Min = msg
+		}
+	}
 	if isPrimaryKey {
-		stats.BF = bloom.NewWithEstimates(bloomFilterSize, maxBloomFalsePositive)
+		stats.BF = NewPKBloomFilter(len(msgs))
 		b := make([]byte, 8)
 		for _, msg := range msgs {
 			binary.LittleEndian.PutUint64(b, uint64(msg))
@@ -13,6 +13,8 @@ package storage
 
 import (
 	"encoding/binary"
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/milvus-io/milvus/internal/common"
@@ -42,4 +44,51 @@ func TestStatsWriter_StatsInt64(t *testing.T) {
 	msgs := []int64{}
 	err = sw.StatsInt64(rootcoord.RowIDField, true, msgs)
 	assert.Nil(t, err)
+
+	// unsorted primary keys
+	err = sw.StatsInt64(common.RowIDField, true, []int64{5, 9, -3, 7, 1})
+	assert.NoError(t, err)
+	sr.SetBuffer(sw.GetBuffer())
+	stats, err = sr.GetInt64Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), stats.Max)
+	assert.Equal(t, int64(-3), stats.Min)
+}
+
+func TestStatsWriter_BloomFilterScale(t *testing.T) {
+	buffer := make([]byte, 8)
+	caps := make([]uint, 0)
+	for _, rows := range []int{10, 10000, 100000} {
+		data := make([]int64, 0, rows)
+		for i := 0; i < rows; i++ {
+			data = append(data, rand.Int63n(math.MaxInt32))
+		}
+		sw := &StatsWriter{}
+		err := sw.StatsInt64(common.RowIDField, true, data)
+		assert.NoError(t, err)
+
+		stats, err := DeserializeStats([]*Blob{{Value: sw.GetBuffer()}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(stats))
+		bf := stats[0].BF
+		caps = append(caps, bf.Cap())
+
+		// no false negative after reload
+		for _, id := range data {
+			binary.LittleEndian.PutUint64(buffer, uint64(id))
+			assert.True(t, bf.Test(buffer))
+		}
+		// the false positive rate is kept whatever the rows are
+		falsePositive := 0
+		for i := 0; i < 10000; i++ {
+			binary.LittleEndian.PutUint64(buffer, uint64(math.MaxInt32+1+i))
+			if bf.Test(buffer) {
+				falsePositive++
+			}
+		}
+		assert.Less(t, float64(falsePositive)/10000, 2*maxBloomFalsePositive)
+	}
+	assert.Equal(t, NewPKBloomFilter(0).Cap(), caps[0])
+	assert.Less(t, caps[0], caps[1])
+	assert.Less(t, caps[1], caps[2])
 }
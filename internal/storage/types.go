@@ -11,12 +11,23 @@
 
 package storage
 
+import "io"
+
 // ChunkManager is to manager chunks.
 // Include Read, Write, Remove chunks.
 type ChunkManager interface {
 	GetPath(key string) (string, error)
 	Write(key string, content []byte) error
+	// WriteFrom writes the chunk from the reader, size is the size of the chunk, or -1 if it's unknown.
+	WriteFrom(key string, reader io.Reader, size int64) error
 	Exist(key string) bool
 	Read(key string) ([]byte, error)
+	// Reader opens the chunk for reading, the caller should close the reader.
+	Reader(key string) (io.ReadCloser, error)
 	ReadAt(key string, p []byte, off int64) (n int, err error)
+	// Size returns the size of the chunk in bytes.
+	Size(key string) (int64, error)
+	ListWithPrefix(prefix string) ([]string, error)
+	Remove(key string) error
+	RemoveWithPrefix(prefix string) error
 }
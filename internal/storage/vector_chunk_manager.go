@@ -16,6 +16,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
+	"io/ioutil"
 
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 )
@@ -100,6 +101,14 @@ func (vcm *VectorChunkManager) Write(key string, content []byte) error {
 	return vcm.localChunkManager.Write(key, content)
 }
 
+// WriteFrom writes the vector data from the reader to local cache if cache enabled.
+func (vcm *VectorChunkManager) WriteFrom(key string, reader io.Reader, size int64) error {
+	if !vcm.localCacheEnable {
+		return errors.New("Cannot write local file for local cache is not allowed")
+	}
+	return vcm.localChunkManager.WriteFrom(key, reader, size)
+}
+
 // Exist checks whether vector data is saved to local cache.
 func (vcm *VectorChunkManager) Exist(key string) bool {
 	return vcm.localChunkManager.Exist(key)
@@ -124,6 +133,19 @@ func (vcm *VectorChunkManager) Read(key string) ([]byte, error) {
 	return vcm.downloadVectorFile(key)
 }
 
+// Reader opens the pure vector data for reading. If cached, it reads from local.
+func (vcm *VectorChunkManager) Reader(key string) (io.ReadCloser, error) {
+	if vcm.localCacheEnable && vcm.localChunkManager.Exist(key) {
+		return vcm.localChunkManager.Reader(key)
+	}
+	// the vector data is deserialized from the whole binlog
+	content, err := vcm.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
 // ReadAt reads specific position data of vector. If cached, it reads from local.
 func (vcm *VectorChunkManager) ReadAt(key string, p []byte, off int64) (int, error) {
 	if vcm.localCacheEnable {
@@ -159,3 +181,36 @@ func (vcm *VectorChunkManager) ReadAt(key string, p []byte, off int64) (int, err
 
 	return n, nil
 }
+
+// Size returns the size of the vector data. If cached, it's the size of the local data.
+func (vcm *VectorChunkManager) Size(key string) (int64, error) {
+	if vcm.localCacheEnable && vcm.localChunkManager.Exist(key) {
+		return vcm.localChunkManager.Size(key)
+	}
+	content, err := vcm.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(content)), nil
+}
+
+// ListWithPrefix lists the keys of remote vector data with the prefix.
+func (vcm *VectorChunkManager) ListWithPrefix(prefix string) ([]string, error) {
+	return vcm.remoteChunkManager.ListWithPrefix(prefix)
+}
+
+// Remove deletes the vector data from remote storage and local cache.
+func (vcm *VectorChunkManager) Remove(key string) error {
+	if err := vcm.remoteChunkManager.Remove(key); err != nil {
+		return err
+	}
+	return vcm.localChunkManager.Remove(key)
+}
+
+// RemoveWithPrefix deletes the vector data with the prefix from remote storage and local cache.
+func (vcm *VectorChunkManager) RemoveWithPrefix(prefix string) error {
+	if err := vcm.remoteChunkManager.RemoveWithPrefix(prefix); err != nil {
+		return err
+	}
+	return vcm.localChunkManager.RemoveWithPrefix(prefix)
+}
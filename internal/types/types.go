@@ -58,6 +58,14 @@ type DataNode interface {
 	FlushSegments(ctx context.Context, req *datapb.FlushSegmentsRequest) (*commonpb.Status, error)
 
 	GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
+
+	// Import notifies DataNode to import the files of req. The import task is async to this rpc,
+	//  DataNode parses the files and writes the binlogs of segments in the background, and reports
+	//  the state of the task to DataCoord.
+	//
+	// Return UnexpectedError code in status:
+	//     If DataNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY
+	Import(ctx context.Context, req *datapb.ImportTask) (*commonpb.Status, error)
 }
 
 // DataNodeComponent is used by grpc server of DataNode
@@ -218,6 +226,42 @@ type DataCoord interface {
 	GetFlushedSegments(ctx context.Context, req *datapb.GetFlushedSegmentsRequest) (*datapb.GetFlushedSegmentsResponse, error)
 
 	GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
+
+	// Import creates an import task for the files in req and assigns it to a DataNode
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the collection/partition id and the files to import
+	//
+	// response struct `ImportTaskResponse` contains the id of the task and the DataNode it's assigned to
+	// error is returned only when some communication issue occurs
+	Import(ctx context.Context, req *datapb.ImportTaskRequest) (*datapb.ImportTaskResponse, error)
+
+	// GetImportState returns the state of the import task, with the segments generated and the errors of failed files
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the id of the import task
+	//
+	// error is returned only when some communication issue occurs
+	GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error)
+
+	// ReportImport is called by DataNode to report the state of the import task it runs.
+	//  The segments reported with ImportPersisted state are added as flushed segments, which are indexed afterwards.
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the state of the task, and the segments persisted
+	//
+	// error is returned only when some communication issue occurs
+	ReportImport(ctx context.Context, req *datapb.ImportResult) (*commonpb.Status, error)
+
+	// DropSegment is an admin operation dropping a segment, the segment is marked dropped in meta, the loaded segment
+	//  is released by QueryCoord, the index builds of it are dropped by IndexCoord, and its binlogs are garbage
+	//  collected after the retention duration. A segment still being written or flushed is refused unless forced.
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the segment id and whether to force the drop
+	//
+	// error is returned only when some communication issue occurs
+	DropSegment(ctx context.Context, req *datapb.DropSegmentRequest) (*commonpb.Status, error)
 }
 
 // IndexNode is the interface `indexnode` package implements
@@ -228,6 +272,16 @@ type IndexNode interface {
 	// CreateIndex receives request from IndexCoordinator to build an index.
 	// Index building is asynchronous, so when an index building request comes, IndexNode records the task and returns.
 	CreateIndex(ctx context.Context, req *indexpb.CreateIndexRequest) (*commonpb.Status, error)
+	// CancelIndexBuild notifies IndexNode that the index building tasks are no longer needed, e.g. the index is dropped.
+	// The tasks waiting in the queue are removed, and the tasks in progress are canceled.
+	CancelIndexBuild(ctx context.Context, req *indexpb.CancelIndexBuildRequest) (*commonpb.Status, error)
+	// GetTaskSlots returns the number of index building tasks IndexNode can start right now, which is limited by the
+	// max concurrent builds and the memory and disk the running builds are estimated to take.
+	// It also reports the states of the requested tasks, including the positions of the waiting ones in the queue.
+	GetTaskSlots(ctx context.Context, req *indexpb.GetTaskSlotsRequest) (*indexpb.GetTaskSlotsResponse, error)
+	// GetCapabilities returns the build version of IndexNode, the index types it can build and the bitmap of the
+	// optional features it supports, so that IndexCoord only assigns the tasks IndexNode can run.
+	GetCapabilities(ctx context.Context, req *indexpb.GetCapabilitiesRequest) (*indexpb.GetCapabilitiesResponse, error)
 	// GetMetrics gets the metrics about IndexNode.
 	GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -237,6 +291,10 @@ type IndexCoord interface {
 	Component
 	TimeTickProvider
 
+	// CreateIndexDefinition receives request from RootCoordinator to create an index on a field of a collection.
+	// Creating the same index again succeeds with the ID of the existing index, and creating an index with different
+	// params on a field which already has an index fails until the existing one is dropped.
+	CreateIndexDefinition(ctx context.Context, req *indexpb.CreateIndexDefinitionRequest) (*indexpb.CreateIndexDefinitionResponse, error)
 	// BuildIndex receives request from RootCoordinator to build an index.
 	// Index building is asynchronous, so when an index building request comes, an IndexBuildID is assigned to the task and
 	// the task is recorded in Meta. The background process assignTaskLoop will find this task and assign it to IndexNode for
@@ -250,10 +308,30 @@ type IndexCoord interface {
 	GetIndexStates(ctx context.Context, req *indexpb.GetIndexStatesRequest) (*indexpb.GetIndexStatesResponse, error)
 	// GetIndexFilePaths gets the index files of the IndexBuildIDs in the request from RootCoordinator.
 	GetIndexFilePaths(ctx context.Context, req *indexpb.GetIndexFilePathsRequest) (*indexpb.GetIndexFilePathsResponse, error)
+	// GetIndexBuildProgress gets the indexed rows and total rows of the index on a field of a collection, the rows of
+	// flushed segments are counted, and the segments too small to build index are counted as indexed.
+	GetIndexBuildProgress(ctx context.Context, req *indexpb.GetIndexBuildProgressRequest) (*indexpb.GetIndexBuildProgressResponse, error)
+	// GetIndexState gets the state of the index on a field of a collection, which is rolled up from the states of its
+	// builds, and the failures of the builds are rolled up into one reason.
+	GetIndexState(ctx context.Context, req *indexpb.GetIndexStateRequest) (*indexpb.GetIndexStateResponse, error)
+	// DropSegmentIndex drops the index builds of a segment dropped by DataCoord, the builds in progress are cancelled
+	// and the index files are recycled as the builds of a dropped index.
+	DropSegmentIndex(ctx context.Context, req *indexpb.DropSegmentIndexRequest) (*commonpb.Status, error)
 	// GetMetrics gets the metrics about IndexCoord.
 	GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
 
+// IndexCoordComponent is used by grpc server of IndexCoord
+type IndexCoordComponent interface {
+	IndexCoord
+
+	// SetDataCoord set DataCoord for IndexCoord
+	// `dataCoord` is a client of data coordinator, which provides the segments of collections.
+	//
+	// Return an error if the dataCoord is nil.
+	SetDataCoord(dataCoord DataCoord) error
+}
+
 // RootCoord is the interface `rootcoord` package implements
 type RootCoord interface {
 	Component
@@ -424,6 +502,16 @@ type RootCoord interface {
 	// error is always nil
 	AlterAlias(ctx context.Context, req *milvuspb.AlterAliasRequest) (*commonpb.Status, error)
 
+	// AlterCollection notifies RootCoord to alter the properties of the collection
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the request params, including collection name and properties
+	//
+	// The `ErrorCode` of `Status` is `Success` if alter collection successfully;
+	// otherwise, the `ErrorCode` of `Status` will be `Error`, and the `Reason` of `Status` will record the fail cause.
+	// error is always nil
+	AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error)
+
 	// AllocTimestamp notifies RootCoord to alloc timestamps
 	//
 	// ctx is the context to control request deadline and cancellation
@@ -567,6 +655,42 @@ type Proxy interface {
 	//
 	// error is returned only when some communication issue occurs.
 	ReleaseDQLMessageStream(ctx context.Context, in *proxypb.ReleaseDQLMessageStreamRequest) (*commonpb.Status, error)
+
+	// SetRateLimit adjusts the DML and DQL rate limits of Proxy at runtime.
+	//
+	// ctx is the context to control request deadline and cancellation.
+	// request contains the limits to set, the rate types not in request keep their limits.
+	// A limit which is not positive removes the limit on that rate type.
+	//
+	// The `ErrorCode` of status is `Success` if the limits are set,
+	// `IllegalArgument` if there is an unknown rate type in request.
+	//
+	// error is returned only when some communication issue occurs.
+	SetRateLimit(ctx context.Context, request *proxypb.SetRateLimitRequest) (*commonpb.Status, error)
+
+	// UpdateConfig updates the whitelisted configs of Proxy at runtime.
+	//
+	// ctx is the context to control request deadline and cancellation.
+	// request contains the configs to update, such as `log.level` and `proxy.rateLimit.dmlRows`.
+	//
+	// The configs are validated before any of them is applied, so either all or none of them take effect.
+	// The `ErrorCode` of status is `Success` and the effective values are returned if the configs are updated,
+	// `IllegalArgument` if there is a config not in the whitelist or with an invalid value.
+	//
+	// error is returned only when some communication issue occurs.
+	UpdateConfig(ctx context.Context, request *proxypb.UpdateConfigRequest) (*proxypb.UpdateConfigResponse, error)
+
+	// SetLogLevel sets the log level of Proxy and all the coordinators, which pass it on to their nodes.
+	//
+	// ctx is the context to control request deadline and cancellation.
+	// request contains the level and the optional logger name, the global level is set if the logger name is empty,
+	// an empty level removes the level of the logger so it follows the global level again.
+	//
+	// The `ErrorCode` of status is `Success` if the level of all the components is set, and `UnexpectedError` otherwise.
+	// The response contains the current log levels of the components in json even if some of them failed.
+	//
+	// error is returned only when some communication issue occurs.
+	SetLogLevel(ctx context.Context, request *proxypb.SetLogLevelRequest) (*proxypb.SetLogLevelResponse, error)
 }
 
 type ProxyComponent interface {
@@ -588,6 +712,10 @@ type ProxyComponent interface {
 	//  `queryCoord` is a client of query coordinator.
 	SetQueryCoordClient(queryCoord QueryCoord)
 
+	// SetQueryNodeCreator set the function creating query node clients for Proxy
+	//  `creator` creates a client of the query node at `addr`, Proxy searches the shard leaders through the clients.
+	SetQueryNodeCreator(creator func(ctx context.Context, addr string) (QueryNode, error))
+
 	// UpdateStateCode updates state code for Proxy
 	//  `stateCode` is current statement of this proxy node, indicating whether it's healthy.
 	UpdateStateCode(stateCode internalpb.StateCode)
@@ -884,6 +1012,36 @@ type ProxyComponent interface {
 	// error is always nil
 	GetQuerySegmentInfo(ctx context.Context, request *milvuspb.GetQuerySegmentInfoRequest) (*milvuspb.GetQuerySegmentInfoResponse, error)
 
+	// LoadBalance notifies Proxy to move the sealed segments off a query node through query coord
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the request params, including source node id, destination node ids(optional) and sealed segment ids(optional)
+	//
+	// The `ErrorCode` of `Status` is `Success` if the segments are moved;
+	// otherwise, the `ErrorCode` is `UnexpectedError` and the reason is in `Reason`.
+	// error is always nil
+	LoadBalance(ctx context.Context, request *milvuspb.LoadBalanceRequest) (*commonpb.Status, error)
+
+	// Import notifies Proxy to import the files in object storage into a collection, bypassing the insert messages
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the request params, including collection name, partition name, and the files
+	//
+	// The `Status` in response struct `ImportResponse` indicates if the import tasks are created successfully or fail cause;
+	// the `Tasks` in `ImportResponse` return the id of the import tasks.
+	// error is always nil
+	Import(ctx context.Context, req *milvuspb.ImportRequest) (*milvuspb.ImportResponse, error)
+
+	// GetImportState notifies Proxy to return the state of an import task
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the request params, including the id of import task
+	//
+	// The `Status` in response struct `GetImportStateResponse` indicates if this operation is processed successfully or fail cause;
+	// the `State` in `GetImportStateResponse` return the state of the task, with the rows imported and the errors of failed files.
+	// error is always nil
+	GetImportState(ctx context.Context, req *milvuspb.GetImportStateRequest) (*milvuspb.GetImportStateResponse, error)
+
 	// For internal usage
 	Dummy(ctx context.Context, request *milvuspb.DummyRequest) (*milvuspb.DummyResponse, error)
 
@@ -927,6 +1085,16 @@ type ProxyComponent interface {
 	// otherwise, the `ErrorCode` of `Status` will be `Error`, and the `Reason` of `Status` will record the fail cause.
 	// error is always nil
 	AlterAlias(ctx context.Context, request *milvuspb.AlterAliasRequest) (*commonpb.Status, error)
+
+	// AlterCollection notifies Proxy to alter the properties of a collection
+	//
+	// ctx is the context to control request deadline and cancellation
+	// req contains the request params, including database name(reserved), collection name, properties
+	//
+	// The `ErrorCode` of `Status` is `Success` if alter collection successfully;
+	// otherwise, the `ErrorCode` of `Status` will be `Error`, and the `Reason` of `Status` will record the fail cause.
+	// error is always nil
+	AlterCollection(ctx context.Context, request *milvuspb.AlterCollectionRequest) (*commonpb.Status, error)
 }
 
 // QueryNode is the interface `querynode` package implements
@@ -958,6 +1126,23 @@ type QueryNode interface {
 	ReleasePartitions(ctx context.Context, req *querypb.ReleasePartitionsRequest) (*commonpb.Status, error)
 	ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error)
 	GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error)
+	// Search searches a shard of a collection, which is the growing data of a dm channel and a set of sealed segments.
+	// The search is forwarded to the other query nodes holding the remote sealed segments in the request,
+	// all the results are returned without reduction.
+	//
+	// Return UnexpectedError code in status:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the collection isn't loaded, or any of the sealed segments isn't held by the query nodes.
+	// Return Success code in status:
+	//     The shard is searched, the results of all the query nodes involved are returned.
+	Search(ctx context.Context, req *querypb.SearchRequest) (*querypb.SearchResponse, error)
+	// FetchSegmentBinlogs streams the binlogs of a segment cached on QueryNode to the QueryNode loading it, each
+	// chunk of the binlogs is passed to `send`. The binlogs not cached are skipped.
+	//
+	// Return UnexpectedError code in the status of the chunk sent:
+	//     If QueryNode isn't in HEALTHY: states not HEALTHY or dynamic checks not HEALTHY.
+	//     If the chunk cache of QueryNode is disabled.
+	FetchSegmentBinlogs(ctx context.Context, req *querypb.FetchSegmentBinlogsRequest, send func(*querypb.SegmentBinlogChunk) error) error
 
 	GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -1003,6 +1188,14 @@ type QueryCoord interface {
 	CreateQueryChannel(ctx context.Context, req *querypb.CreateQueryChannelRequest) (*querypb.CreateQueryChannelResponse, error)
 	GetPartitionStates(ctx context.Context, req *querypb.GetPartitionStatesRequest) (*querypb.GetPartitionStatesResponse, error)
 	GetSegmentInfo(ctx context.Context, req *querypb.GetSegmentInfoRequest) (*querypb.GetSegmentInfoResponse, error)
+	// GetShardLeaders returns the shard leaders of a loaded collection, a shard leader is the query node
+	// watching a dm channel, together with the sealed segments searched through it.
+	GetShardLeaders(ctx context.Context, req *querypb.GetShardLeadersRequest) (*querypb.GetShardLeadersResponse, error)
+	// LoadBalance moves the sealed segments off the source query nodes, it returns after the segments are moved.
+	LoadBalance(ctx context.Context, req *querypb.LoadBalanceRequest) (*commonpb.Status, error)
+	// ReleaseSegments releases the sealed segments dropped by DataCoord from the query nodes loading them,
+	// the segments not loaded are ignored.
+	ReleaseSegments(ctx context.Context, req *querypb.ReleaseSegmentsRequest) (*commonpb.Status, error)
 
 	GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error)
 }
@@ -1032,4 +1225,13 @@ type QueryCoordComponent interface {
 	// Return nil in status:
 	//     The rootCoord is not nil.
 	SetRootCoord(rootCoord RootCoord) error
+
+	// SetIndexCoord set IndexCoord for QueryCoord
+	// `indexCoord` is a client of index coordinator.
+	//
+	// Return a generic error in status:
+	//     If the indexCoord is nil.
+	// Return nil in status:
+	//     The indexCoord is not nil.
+	SetIndexCoord(indexCoord IndexCoord) error
 }
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package channelutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	// MaxPrefixLen is the maximum length of a cluster channel prefix
+	MaxPrefixLen = 64
+
+	nameSep     = "-"
+	physicalSep = "_"
+	shardSep    = "v"
+)
+
+// ErrInvalidPrefix is returned when a cluster channel prefix can't be used in Pulsar or RocksMQ topic names
+var ErrInvalidPrefix = errors.New("invalid channel prefix")
+
+// ErrInvalidChannelName is returned when a channel name doesn't follow the naming rules of this package
+var ErrInvalidChannelName = errors.New("invalid channel name")
+
+// ValidatePrefix checks that prefix is a legal leading part of Pulsar and RocksMQ topic names.
+// Only letters, digits, '-', '_' and '.' are allowed, and the prefix must start with a letter or digit.
+func ValidatePrefix(prefix string) error {
+	if len(prefix) == 0 {
+		return fmt.Errorf("%w: prefix is empty", ErrInvalidPrefix)
+	}
+	if len(prefix) > MaxPrefixLen {
+		return fmt.Errorf("%w: %s exceeds %d characters", ErrInvalidPrefix, prefix, MaxPrefixLen)
+	}
+	for i, c := range prefix {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case i > 0 && (c == '-' || c == '_' || c == '.'):
+		default:
+			return fmt.Errorf("%w: %s contains illegal character %q at %d", ErrInvalidPrefix, prefix, c, i)
+		}
+	}
+	return nil
+}
+
+// JoinName builds a channel or subscription name by prepending the cluster prefix to parts
+func JoinName(prefix string, parts ...string) string {
+	s := make([]string, 0, len(parts)+1)
+	s = append(s, prefix)
+	s = append(s, parts...)
+	return strings.Join(s, nameSep)
+}
+
+// PhysicalChannelName returns the name of the idx-th physical channel created under namePrefix
+func PhysicalChannelName(namePrefix string, idx int64) string {
+	return fmt.Sprintf("%s%s%d", namePrefix, physicalSep, idx)
+}
+
+// VirtualChannelName returns the name of the shard-th virtual channel of a collection on pchannel
+func VirtualChannelName(pchannel string, collectionID int64, shard int32) string {
+	return fmt.Sprintf("%s%s%d%s%d", pchannel, physicalSep, collectionID, shardSep, shard)
+}
+
+// AssignPhysicalChannels maps the shards of a collection onto a pool of poolSize physical channels.
+// The first shard lands on the channel the collection id hashes to and the others on the following ones,
+// so shards of one collection never share a physical channel while shardsNum <= poolSize.
+func AssignPhysicalChannels(namePrefix string, poolSize int64, collectionID int64, shardsNum int32) []string {
+	if poolSize <= 0 {
+		return nil
+	}
+	h, err := typeutil.Hash32Int64(collectionID)
+	if err != nil {
+		h = uint32(collectionID & 0x7fffffff)
+	}
+	start := int64(h) % poolSize
+	chanNames := make([]string, 0, shardsNum)
+	for i := int64(0); i < int64(shardsNum); i++ {
+		chanNames = append(chanNames, PhysicalChannelName(namePrefix, (start+i)%poolSize))
+	}
+	return chanNames
+}
+
+// ToPhysicalChannel get physical channel name from virtual channel name
+func ToPhysicalChannel(vchannel string) string {
+	idx := strings.LastIndex(vchannel, physicalSep)
+	if idx < 0 {
+		return vchannel
+	}
+	return vchannel[:idx]
+}
+
+// ParseVirtualChannel splits a virtual channel name into its physical channel, collection id and shard index.
+// Only the part after the last '_' is parsed, so the result doesn't depend on the cluster prefix.
+func ParseVirtualChannel(vchannel string) (string, int64, int32, error) {
+	idx := strings.LastIndex(vchannel, physicalSep)
+	if idx <= 0 {
+		return "", 0, 0, fmt.Errorf("%w: %s is not a virtual channel", ErrInvalidChannelName, vchannel)
+	}
+	suffix := vchannel[idx+1:]
+	sep := strings.LastIndex(suffix, shardSep)
+	if sep <= 0 {
+		return "", 0, 0, fmt.Errorf("%w: %s is not a virtual channel", ErrInvalidChannelName, vchannel)
+	}
+	collectionID, err := strconv.ParseInt(suffix[:sep], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("%w: %s has no collection id", ErrInvalidChannelName, vchannel)
+	}
+	shard, err := strconv.ParseInt(suffix[sep+1:], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("%w: %s has no shard index", ErrInvalidChannelName, vchannel)
+	}
+	return vchannel[:idx], collectionID, int32(shard), nil
+}
+
+// QueryChannelName returns the name of the query (or query result) channel of a collection
+func QueryChannelName(namePrefix string, collectionID int64) string {
+	return JoinName(namePrefix, strconv.FormatInt(collectionID, 10))
+}
+
+// ParseQueryChannel returns the collection id encoded in a query (or query result) channel name
+func ParseQueryChannel(name string) (int64, error) {
+	idx := strings.LastIndex(name, nameSep)
+	if idx <= 0 {
+		return 0, fmt.Errorf("%w: %s is not a query channel", ErrInvalidChannelName, name)
+	}
+	collectionID, err := strconv.ParseInt(name[idx+1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s has no collection id", ErrInvalidChannelName, name)
+	}
+	return collectionID, nil
+}
+
+// HasPrefix checks whether the channel name was built with the given cluster prefix
+func HasPrefix(name string, prefix string) bool {
+	return strings.HasPrefix(name, prefix+nameSep)
+}
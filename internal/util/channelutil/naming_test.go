@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package channelutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePrefix(t *testing.T) {
+	for _, prefix := range []string{"by-dev", "tenant_a", "cluster.1", "A0"} {
+		assert.NoError(t, ValidatePrefix(prefix), prefix)
+	}
+	long := make([]byte, MaxPrefixLen+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	for _, prefix := range []string{"", "-dev", "_dev", "by/dev", "by:dev", "by dev", "persistent://x", string(long)} {
+		err := ValidatePrefix(prefix)
+		assert.True(t, errors.Is(err, ErrInvalidPrefix), prefix)
+	}
+}
+
+func TestJoinName(t *testing.T) {
+	assert.Equal(t, "by-dev-rootcoord-timetick", JoinName("by-dev", "rootcoord-timetick"))
+	assert.Equal(t, "by-dev-dataNode-1", JoinName("by-dev", "dataNode", "1"))
+	assert.Equal(t, "by-dev", JoinName("by-dev"))
+}
+
+func TestVirtualChannel_RoundTrip(t *testing.T) {
+	for _, prefix := range []string{"by-dev", "tenant_a", "a-b_c.d"} {
+		pchannel := PhysicalChannelName(JoinName(prefix, "rootcoord-dml"), 3)
+		vchannel := VirtualChannelName(pchannel, 434343, 2)
+		assert.True(t, HasPrefix(vchannel, prefix))
+		assert.Equal(t, pchannel, ToPhysicalChannel(vchannel))
+
+		p, collectionID, shard, err := ParseVirtualChannel(vchannel)
+		assert.NoError(t, err)
+		assert.Equal(t, pchannel, p)
+		assert.Equal(t, int64(434343), collectionID)
+		assert.Equal(t, int32(2), shard)
+	}
+
+	for _, name := range []string{"abc", "_1v0", "abc_", "abc_1", "abc_v1", "abc_1v", "abc_xv1"} {
+		_, _, _, err := ParseVirtualChannel(name)
+		assert.True(t, errors.Is(err, ErrInvalidChannelName), name)
+	}
+}
+
+func TestToPhysicalChannel(t *testing.T) {
+	assert.Equal(t, "abc", ToPhysicalChannel("abc_"))
+	assert.Equal(t, "abc", ToPhysicalChannel("abc_123"))
+	assert.Equal(t, "abc__", ToPhysicalChannel("abc___defgsg"))
+	assert.Equal(t, "abcdef", ToPhysicalChannel("abcdef"))
+}
+
+func TestQueryChannel_RoundTrip(t *testing.T) {
+	for _, prefix := range []string{"by-dev", "tenant_a", "a-b_c.d"} {
+		name := QueryChannelName(JoinName(prefix, "search"), 1024)
+		assert.True(t, HasPrefix(name, prefix))
+		collectionID, err := ParseQueryChannel(name)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1024), collectionID)
+	}
+
+	for _, name := range []string{"search", "-1", "by-dev-search-", "by-dev-search-x"} {
+		_, err := ParseQueryChannel(name)
+		assert.True(t, errors.Is(err, ErrInvalidChannelName), name)
+	}
+}
+
+func TestAssignPhysicalChannels(t *testing.T) {
+	const (
+		poolSize = 4
+		shards   = 2
+	)
+	prefix := JoinName("by-dev", "rootcoord-dml")
+	pool := make(map[string]struct{})
+	for i := int64(0); i < poolSize; i++ {
+		pool[PhysicalChannelName(prefix, i)] = struct{}{}
+	}
+
+	used := make(map[string]int)
+	vchannels := make(map[string]int64)
+	for collectionID := int64(1000); collectionID < 1100; collectionID++ {
+		pchannels := AssignPhysicalChannels(prefix, poolSize, collectionID, shards)
+		assert.Equal(t, pchannels, AssignPhysicalChannels(prefix, poolSize, collectionID, shards))
+		assert.Equal(t, shards, len(pchannels))
+		assert.NotEqual(t, pchannels[0], pchannels[1])
+
+		for shard, pchannel := range pchannels {
+			_, ok := pool[pchannel]
+			assert.True(t, ok, pchannel)
+			used[pchannel]++
+
+			vchannel := VirtualChannelName(pchannel, collectionID, int32(shard))
+			_, dup := vchannels[vchannel]
+			assert.False(t, dup, vchannel)
+			vchannels[vchannel] = collectionID
+
+			p, id, s, err := ParseVirtualChannel(vchannel)
+			assert.NoError(t, err)
+			assert.Equal(t, pchannel, p)
+			assert.Equal(t, collectionID, id)
+			assert.Equal(t, int32(shard), s)
+		}
+	}
+	assert.Equal(t, poolSize, len(used))
+	assert.Equal(t, 200, len(vchannels))
+
+	assert.Nil(t, AssignPhysicalChannels(prefix, 0, 1, shards))
+	assert.Equal(t, []string{PhysicalChannelName(prefix, 0), PhysicalChannelName(prefix, 0)},
+		AssignPhysicalChannels(prefix, 1, 1, shards))
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package channelutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/kv"
+)
+
+// ErrPrefixChanged is returned when the configured cluster prefix differs from the one the deployment was created with
+var ErrPrefixChanged = errors.New("channel prefix changed")
+
+// CheckPrefix compares prefix with the one recorded under key in metaKV and records it on first start.
+// Deployments created before the prefix was recorded are checked against existing physical channels,
+// so that changing the prefix never silently creates a parallel set of channels.
+func CheckPrefix(metaKV kv.BaseKV, key string, prefix string, channels []string) error {
+	if err := ValidatePrefix(prefix); err != nil {
+		return err
+	}
+	keys, values, err := metaKV.LoadWithPrefix(key)
+	if err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if k != key && !strings.HasSuffix(k, "/"+key) {
+			continue
+		}
+		if values[i] != prefix {
+			return fmt.Errorf("%w: deployment uses %s, configured %s", ErrPrefixChanged, values[i], prefix)
+		}
+		return nil
+	}
+	for _, ch := range channels {
+		if !HasPrefix(ch, prefix) {
+			return fmt.Errorf("%w: channel %s doesn't use configured prefix %s", ErrPrefixChanged, ch, prefix)
+		}
+	}
+	return metaKV.Save(key, prefix)
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package channelutil
+
+import (
+	"errors"
+	"testing"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPrefix(t *testing.T) {
+	key := "root-coord/channel-prefix"
+
+	t.Run("first start", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		assert.NoError(t, CheckPrefix(kv, key, "by-dev", nil))
+		v, err := kv.Load(key)
+		assert.NoError(t, err)
+		assert.Equal(t, "by-dev", v)
+		assert.NoError(t, CheckPrefix(kv, key, "by-dev", nil))
+	})
+
+	t.Run("prefix changed", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		assert.NoError(t, CheckPrefix(kv, key, "by-dev", nil))
+		err := CheckPrefix(kv, key, "tenant-a", nil)
+		assert.True(t, errors.Is(err, ErrPrefixChanged))
+	})
+
+	t.Run("similar key", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		assert.NoError(t, kv.Save(key+"-bak", "other"))
+		assert.NoError(t, CheckPrefix(kv, key, "by-dev", nil))
+	})
+
+	t.Run("existing channels", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		channels := []string{"by-dev-rootcoord-dml_0", "by-dev-rootcoord-dml_1"}
+		err := CheckPrefix(kv, key, "tenant-a", channels)
+		assert.True(t, errors.Is(err, ErrPrefixChanged))
+		assert.NoError(t, CheckPrefix(kv, key, "by-dev", channels))
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		kv := memkv.NewMemoryKV()
+		err := CheckPrefix(kv, key, "by/dev", nil)
+		assert.True(t, errors.Is(err, ErrInvalidPrefix))
+	})
+}
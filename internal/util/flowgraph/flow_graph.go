@@ -23,6 +23,12 @@ type TimeTickedFlowGraph struct {
 	nodeCtx   map[NodeName]*nodeCtx
 	stopOnce  sync.Once
 	startOnce sync.Once
+
+	// workers tracks the worker goroutines of the nodes, Close waits for them to quit
+	workers sync.WaitGroup
+
+	// profile is set by EnableProfiling
+	profile *profile
 }
 
 // AddNode add Node into flowgraph
@@ -33,6 +39,7 @@ func (fg *TimeTickedFlowGraph) AddNode(node Node) {
 		inputChannels:          make([]chan Msg, 0),
 		downstreamInputChanIdx: make(map[string]int),
 		closeCh:                make(chan struct{}),
+		workers:                &fg.workers,
 	}
 	fg.nodeCtx[nodeName] = &nodeCtx
 }
@@ -76,6 +83,9 @@ func (fg *TimeTickedFlowGraph) SetEdges(nodeName string, in []string, out []stri
 // Start starts all nodes in timetick flowgragh
 func (fg *TimeTickedFlowGraph) Start() {
 	fg.startOnce.Do(func() {
+		if fg.profile != nil {
+			fg.profile.start(fg.nodeCtx)
+		}
 		wg := sync.WaitGroup{}
 		for _, v := range fg.nodeCtx {
 			wg.Add(1)
@@ -85,13 +95,17 @@ func (fg *TimeTickedFlowGraph) Start() {
 	})
 }
 
-// Close closes all nodes in flowgraph
+// Close closes all nodes in flowgraph, and waits for the worker goroutines of the nodes to quit
 func (fg *TimeTickedFlowGraph) Close() {
 	fg.stopOnce.Do(func() {
 		for _, v := range fg.nodeCtx {
 			// maybe need to stop in order
 			v.Close()
 		}
+		fg.workers.Wait()
+		if fg.profile != nil {
+			fg.profile.close(fg.nodeCtx)
+		}
 	})
 }
 
@@ -304,3 +304,29 @@ func TestTimeTickedFlowGraph_Close(t *testing.T) {
 	defer cancel()
 	fg.Close()
 }
+
+func TestTimeTickedFlowGraph_CloseWaitsForWorkers(t *testing.T) {
+	fg, inputChan, _, cancel := createExampleFlowGraph()
+	defer cancel()
+	fg.Start()
+
+	closed := make(chan struct{})
+	go func() {
+		fg.Close()
+		close(closed)
+	}()
+
+	// nodeA is blocked in Operate, Close must wait for it
+	select {
+	case <-closed:
+		t.Fatal("Close returned before the worker of nodeA quits")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	inputChan <- 1
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close didn't return after all workers quit")
+	}
+}
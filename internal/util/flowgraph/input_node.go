@@ -25,6 +25,9 @@ type InputNode struct {
 	BaseNode
 	inStream msgstream.MsgStream
 	name     string
+
+	// unsubscribeOnClose is set if the subscriptions of inStream should be deleted when the node is closed
+	unsubscribeOnClose bool
 }
 
 // IsInputNode returns whether Node is InputNode
@@ -39,12 +42,23 @@ func (inNode *InputNode) Start() {
 
 // Close implements node
 func (inNode *InputNode) Close() {
-	inNode.inStream.Close()
+	if inNode.unsubscribeOnClose {
+		inNode.inStream.Unsubscribe()
+	} else {
+		inNode.inStream.Close()
+	}
 	log.Debug("message stream closed",
 		zap.String("node name", inNode.name),
+		zap.Bool("unsubscribe", inNode.unsubscribeOnClose),
 	)
 }
 
+// SetUnsubscribeOnClose makes Close delete the subscriptions of the input msgstream, it should be set if the
+// subscriptions are exclusive to the flowgraph and won't be resumed
+func (inNode *InputNode) SetUnsubscribeOnClose(unsubscribe bool) {
+	inNode.unsubscribeOnClose = unsubscribe
+}
+
 // Name returns node name
 func (inNode *InputNode) Name() string {
 	return inNode.name
@@ -47,12 +47,21 @@ type nodeCtx struct {
 	downstreamInputChanIdx map[string]int
 
 	closeCh chan struct{}
+	// workers is the wait group of the flowgraph the node belongs to, it's nil if the node is not added by a flowgraph
+	workers *sync.WaitGroup
+
+	// profile is set if the flowgraph is profiled, lastConsumed is the unix nano time the node consumed its inputs
+	profile      *profile
+	lastConsumed int64
 }
 
 // Start invoke Node `Start` method and start a worker goroutine
 func (nodeCtx *nodeCtx) Start(wg *sync.WaitGroup) {
 	nodeCtx.node.Start()
 
+	if nodeCtx.workers != nil {
+		nodeCtx.workers.Add(1)
+	}
 	go nodeCtx.work()
 	wg.Done()
 }
@@ -62,6 +71,9 @@ func (nodeCtx *nodeCtx) Start(wg *sync.WaitGroup) {
 // 2. invoke node.Operate
 // 3. deliver the Operate result to downstream nodes
 func (nodeCtx *nodeCtx) work() {
+	if nodeCtx.workers != nil {
+		defer nodeCtx.workers.Done()
+	}
 	for {
 		select {
 		case <-nodeCtx.closeCh:
@@ -75,8 +87,7 @@ func (nodeCtx *nodeCtx) work() {
 				nodeCtx.collectInputMessages()
 				inputs = nodeCtx.inputMessages
 			}
-			n := nodeCtx.node
-			res = n.Operate(inputs)
+			res = nodeCtx.operate(inputs)
 
 			downstreamLength := len(nodeCtx.downstreamInputChanIdx)
 			if len(nodeCtx.downstream) < downstreamLength {
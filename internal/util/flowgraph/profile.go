@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package flowgraph
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// maxProfileInterval is the max interval the profile samples the input queues of the nodes
+const maxProfileInterval = time.Second
+
+// profile records the operating latency and the input queue length of the nodes of a flowgraph, and watches for
+// the stalled nodes. It's off unless enabled by EnableProfiling, which costs a clock read per operation and a
+// goroutine per flowgraph.
+type profile struct {
+	graph          string
+	stallThreshold time.Duration
+
+	// stalled records the nodes warned as stalled, only accessed by the watch goroutine
+	stalled map[NodeName]bool
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// EnableProfiling enables the profiling of the flowgraph, the metrics are labeled by graph. The nodes which haven't
+// consumed their non-empty inputs for longer than stallThreshold are logged, 0 means not watching for the stalls.
+// It must be called before Start.
+func (fg *TimeTickedFlowGraph) EnableProfiling(graph string, stallThreshold time.Duration) {
+	fg.profile = &profile{
+		graph:          graph,
+		stallThreshold: stallThreshold,
+		stalled:        make(map[NodeName]bool),
+		closeCh:        make(chan struct{}),
+	}
+}
+
+// start starts watching the nodes of the flowgraph
+func (p *profile) start(nodes map[NodeName]*nodeCtx) {
+	now := time.Now().UnixNano()
+	for _, nodeCtx := range nodes {
+		nodeCtx.profile = p
+		atomic.StoreInt64(&nodeCtx.lastConsumed, now)
+	}
+
+	interval := maxProfileInterval
+	if p.stallThreshold > 0 && p.stallThreshold/2 < interval {
+		interval = p.stallThreshold / 2
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case now := <-ticker.C:
+				for _, nodeCtx := range nodes {
+					if !nodeCtx.node.IsInputNode() {
+						p.check(nodeCtx, now)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// close stops watching the nodes and removes their metrics
+func (p *profile) close(nodes map[NodeName]*nodeCtx) {
+	close(p.closeCh)
+	p.wg.Wait()
+	for name := range nodes {
+		metrics.FlowGraphNodeOperateLatency.DeleteLabelValues(p.graph, name)
+		metrics.FlowGraphNodeInputQueueLength.DeleteLabelValues(p.graph, name)
+		metrics.FlowGraphNodeStallCounter.DeleteLabelValues(p.graph, name)
+	}
+}
+
+// check records the input queue length of the node, and logs a warning once the node stalls
+func (p *profile) check(nodeCtx *nodeCtx, now time.Time) {
+	name := nodeCtx.node.Name()
+	queued := 0
+	for _, channel := range nodeCtx.inputChannels {
+		queued += len(channel)
+	}
+	metrics.FlowGraphNodeInputQueueLength.WithLabelValues(p.graph, name).Set(float64(queued))
+	if p.stallThreshold <= 0 {
+		return
+	}
+
+	idle := now.Sub(time.Unix(0, atomic.LoadInt64(&nodeCtx.lastConsumed)))
+	if queued == 0 || idle < p.stallThreshold {
+		delete(p.stalled, name)
+		return
+	}
+	if p.stalled[name] {
+		return
+	}
+	p.stalled[name] = true
+	metrics.FlowGraphNodeStallCounter.WithLabelValues(p.graph, name).Inc()
+	log.Warn("flowgraph node stalled",
+		zap.String("graph", p.graph),
+		zap.String("node", name),
+		zap.Duration("idle", idle),
+		zap.Int("queued", queued))
+}
+
+// operate calls Operate of the node, the latency is recorded if the flowgraph is profiled
+func (nodeCtx *nodeCtx) operate(inputs []Msg) []Msg {
+	if nodeCtx.profile == nil || nodeCtx.node.IsInputNode() {
+		return nodeCtx.node.Operate(inputs)
+	}
+	atomic.StoreInt64(&nodeCtx.lastConsumed, time.Now().UnixNano())
+	start := time.Now()
+	res := nodeCtx.node.Operate(inputs)
+	metrics.FlowGraphNodeOperateLatency.WithLabelValues(nodeCtx.profile.graph, nodeCtx.node.Name()).Observe(time.Since(start).Seconds())
+	return res
+}
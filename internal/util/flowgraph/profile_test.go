@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package flowgraph
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+type syncBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+// sourceNode emits messages as fast as the downstream consumes
+type sourceNode struct {
+	BaseNode
+}
+
+func (n *sourceNode) Name() string {
+	return "SourceNode"
+}
+
+func (n *sourceNode) Operate(in []Msg) []Msg {
+	return []Msg{&numMsg{}}
+}
+
+// slowNode takes delay to operate on each message
+type slowNode struct {
+	BaseNode
+	delay time.Duration
+}
+
+func (n *slowNode) Name() string {
+	return "SlowNode"
+}
+
+func (n *slowNode) Operate(in []Msg) []Msg {
+	time.Sleep(n.delay)
+	return []Msg{}
+}
+
+func TestTimeTickedFlowGraph_Profiling(t *testing.T) {
+	buf := &syncBuffer{}
+	logger, p, err := log.InitLoggerWithWriteSyncer(&log.Config{Level: "info", DisableTimestamp: true}, zapcore.AddSync(buf))
+	require.NoError(t, err)
+	log.ReplaceGlobals(logger, p)
+
+	const graph = "profiled-graph"
+	const queueLength = 4
+	source := &sourceNode{}
+	source.SetMaxQueueLength(queueLength)
+	slow := &slowNode{delay: 500 * time.Millisecond}
+	slow.SetMaxQueueLength(queueLength)
+
+	fg := NewTimeTickedFlowGraph(context.Background())
+	fg.AddNode(source)
+	fg.AddNode(slow)
+	require.NoError(t, fg.SetEdges(source.Name(), []string{}, []string{slow.Name()}))
+	require.NoError(t, fg.SetEdges(slow.Name(), []string{source.Name()}, []string{}))
+	fg.EnableProfiling(graph, 100*time.Millisecond)
+	fg.Start()
+
+	stalled := func() bool {
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if strings.Contains(line, "flowgraph node stalled") &&
+				strings.Contains(line, "[graph="+graph+"]") && strings.Contains(line, "[node=SlowNode]") {
+				return true
+			}
+		}
+		return false
+	}
+	assert.Eventually(t, stalled, 2*time.Second, 10*time.Millisecond)
+
+	// the stall is warned once until the node consumes again
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.FlowGraphNodeStallCounter.WithLabelValues(graph, slow.Name())))
+	assert.Equal(t, float64(queueLength), testutil.ToFloat64(metrics.FlowGraphNodeInputQueueLength.WithLabelValues(graph, slow.Name())))
+	assert.Equal(t, 0, strings.Count(buf.String(), "[node=SourceNode]"))
+	// the latency of both nodes is recorded once the slow node finishes its first operation
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCount(metrics.FlowGraphNodeOperateLatency) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	fg.Close()
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.FlowGraphNodeInputQueueLength))
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.FlowGraphNodeStallCounter))
+}
+
+func TestTimeTickedFlowGraph_NoProfiling(t *testing.T) {
+	source := &sourceNode{}
+	source.SetMaxQueueLength(1)
+	slow := &slowNode{delay: time.Millisecond}
+	slow.SetMaxQueueLength(1)
+
+	fg := NewTimeTickedFlowGraph(context.Background())
+	fg.AddNode(source)
+	fg.AddNode(slow)
+	require.NoError(t, fg.SetEdges(source.Name(), []string{}, []string{slow.Name()}))
+	require.NoError(t, fg.SetEdges(slow.Name(), []string{source.Name()}, []string{}))
+	fg.Start()
+	time.Sleep(20 * time.Millisecond)
+	fg.Close()
+
+	assert.Nil(t, fg.profile)
+	for _, nodeCtx := range fg.nodeCtx {
+		assert.Nil(t, nodeCtx.profile)
+		assert.Zero(t, nodeCtx.lastConsumed)
+	}
+}
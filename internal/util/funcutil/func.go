@@ -69,47 +69,105 @@ func GetLocalIP() string {
 	return ipv4.LocalIP()
 }
 
-// WaitForComponentStates wait for component's state to be one of the specific states
-func WaitForComponentStates(ctx context.Context, service types.Component, serviceName string, states []internalpb.StateCode, attempts uint, sleep time.Duration) error {
-	checkFunc := func() error {
+// ComponentNotReadyError is returned when a component doesn't reach the expected states while waiting for it,
+// it records the state and the reason last reported by the component. StateCode is Abnormal if the component
+// never reported its state.
+type ComponentNotReadyError struct {
+	ServiceName string
+	States      []internalpb.StateCode
+	StateCode   internalpb.StateCode
+	Reason      string
+	Attempts    uint
+	Err         error
+}
+
+func (e *ComponentNotReadyError) Error() string {
+	return fmt.Sprintf("%s is not ready after %d attempts, expected states: %v, last state: %s, reason: %s",
+		e.ServiceName, e.Attempts, e.States, e.StateCode.String(), e.Reason)
+}
+
+// Unwrap returns the last error met while waiting, such as the error of the context.
+func (e *ComponentNotReadyError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForComponentStates wait for component's state to be one of the specific states, it checks the component
+// at most attempts times with interval between the checks. The states reported are logged only when changed,
+// a *ComponentNotReadyError is returned if the component is not ready in the end.
+func WaitForComponentStates(ctx context.Context, service types.Component, serviceName string, states []internalpb.StateCode, attempts uint, interval time.Duration) error {
+	notReady := &ComponentNotReadyError{
+		ServiceName: serviceName,
+		States:      states,
+		StateCode:   internalpb.StateCode_Abnormal,
+	}
+	check := func() bool {
+		notReady.Attempts++
+		lastState, lastReason := notReady.StateCode, notReady.Reason
+		defer func() {
+			if notReady.StateCode != lastState || notReady.Reason != lastReason {
+				log.Debug("WaitForComponentStates, component state changed",
+					zap.String("service", serviceName),
+					zap.String("state", notReady.StateCode.String()),
+					zap.String("reason", notReady.Reason))
+			}
+		}()
+
 		resp, err := service.GetComponentStates(ctx)
 		if err != nil {
-			return err
+			notReady.Reason, notReady.Err = err.Error(), err
+			return false
 		}
-
 		if resp.Status.ErrorCode != commonpb.ErrorCode_Success {
-			return errors.New(resp.Status.Reason)
+			notReady.Reason, notReady.Err = resp.Status.Reason, errors.New(resp.Status.Reason)
+			return false
 		}
+		notReady.StateCode, notReady.Reason, notReady.Err = resp.GetState().GetStateCode(), "", nil
+		return SliceContain(states, notReady.StateCode)
+	}
 
-		meet := false
-		for _, state := range states {
-			if resp.State.StateCode == state {
-				meet = true
-				break
-			}
+	log.Debug("WaitForComponentStates, start to wait",
+		zap.String("service", serviceName),
+		zap.Any("states", states))
+	for i := uint(0); i < attempts; i++ {
+		if check() {
+			log.Debug("WaitForComponentStates, component is ready",
+				zap.String("service", serviceName),
+				zap.String("state", notReady.StateCode.String()),
+				zap.Uint("attempts", notReady.Attempts))
+			return nil
 		}
-		if !meet {
-			msg := fmt.Sprintf("WaitForComponentStates, not meet, %s current state:%d", serviceName, resp.State.StateCode)
-			return errors.New(msg)
+		if i+1 == attempts {
+			break
 		}
-		return nil
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			notReady.Err = ctx.Err()
+			if notReady.Reason == "" {
+				notReady.Reason = ctx.Err().Error()
+			}
+			return notReady
+		}
+	}
+	if notReady.Reason == "" {
+		notReady.Reason = "state not met"
 	}
-	return retry.Do(ctx, checkFunc, retry.Attempts(attempts), retry.Sleep(sleep))
+	return notReady
 }
 
 // WaitForComponentInitOrHealthy wait for component's state to be initializing or healthy
-func WaitForComponentInitOrHealthy(ctx context.Context, service types.Component, serviceName string, attempts uint, sleep time.Duration) error {
-	return WaitForComponentStates(ctx, service, serviceName, []internalpb.StateCode{internalpb.StateCode_Initializing, internalpb.StateCode_Healthy}, attempts, sleep)
+func WaitForComponentInitOrHealthy(ctx context.Context, service types.Component, serviceName string, attempts uint, interval time.Duration) error {
+	return WaitForComponentStates(ctx, service, serviceName, []internalpb.StateCode{internalpb.StateCode_Initializing, internalpb.StateCode_Healthy}, attempts, interval)
 }
 
 // WaitForComponentInit wait for component's state to be initializing
-func WaitForComponentInit(ctx context.Context, service types.Component, serviceName string, attempts uint, sleep time.Duration) error {
-	return WaitForComponentStates(ctx, service, serviceName, []internalpb.StateCode{internalpb.StateCode_Initializing}, attempts, sleep)
+func WaitForComponentInit(ctx context.Context, service types.Component, serviceName string, attempts uint, interval time.Duration) error {
+	return WaitForComponentStates(ctx, service, serviceName, []internalpb.StateCode{internalpb.StateCode_Initializing}, attempts, interval)
 }
 
 // WaitForComponentHealthy wait for component's state to be healthy
-func WaitForComponentHealthy(ctx context.Context, service types.Component, serviceName string, attempts uint, sleep time.Duration) error {
-	return WaitForComponentStates(ctx, service, serviceName, []internalpb.StateCode{internalpb.StateCode_Healthy}, attempts, sleep)
+func WaitForComponentHealthy(ctx context.Context, service types.Component, serviceName string, attempts uint, interval time.Duration) error {
+	return WaitForComponentStates(ctx, service, serviceName, []internalpb.StateCode{internalpb.StateCode_Healthy}, attempts, interval)
 }
 
 // ParseIndexParamsMap parse the jsonic index parameters to map
@@ -176,6 +176,71 @@ func Test_WaitForComponentHealthy(t *testing.T) {
 	}
 }
 
+// transitionComponent reports Initializing until GetComponentStates is called healthyAfter times
+type transitionComponent struct {
+	MockComponent
+	calls        int
+	healthyAfter int
+}
+
+func (tc *transitionComponent) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
+	tc.calls++
+	code := internalpb.StateCode_Initializing
+	if tc.calls >= tc.healthyAfter {
+		code = internalpb.StateCode_Healthy
+	}
+	return buildMockComponent(code).compState, nil
+}
+
+func Test_WaitForComponentStates(t *testing.T) {
+	t.Run("initializing to healthy", func(t *testing.T) {
+		tc := &transitionComponent{healthyAfter: 3}
+		err := WaitForComponentHealthy(context.TODO(), tc, "mockService", 5, time.Millisecond)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, tc.calls)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		tc := &transitionComponent{healthyAfter: 10}
+		err := WaitForComponentHealthy(context.TODO(), tc, "mockService", 3, time.Millisecond)
+		assert.Equal(t, 3, tc.calls)
+		var notReady *ComponentNotReadyError
+		assert.True(t, errors.As(err, &notReady))
+		assert.Equal(t, "mockService", notReady.ServiceName)
+		assert.Equal(t, internalpb.StateCode_Initializing, notReady.StateCode)
+		assert.Equal(t, uint(3), notReady.Attempts)
+		assert.Contains(t, err.Error(), "last state: Initializing")
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		tc := &transitionComponent{healthyAfter: 10}
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+		err := WaitForComponentHealthy(ctx, tc, "mockService", 1000000, time.Millisecond*200)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, 1, tc.calls)
+	})
+
+	t.Run("error reason", func(t *testing.T) {
+		mc := &MockComponent{compErr: errors.New("connection refused")}
+		err := WaitForComponentHealthy(context.TODO(), mc, "mockService", 2, time.Millisecond)
+		var notReady *ComponentNotReadyError
+		assert.True(t, errors.As(err, &notReady))
+		assert.Equal(t, internalpb.StateCode_Abnormal, notReady.StateCode)
+		assert.Equal(t, "connection refused", notReady.Reason)
+		assert.Equal(t, mc.compErr, errors.Unwrap(err))
+
+		mc = &MockComponent{
+			compState: &internalpb.ComponentStates{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "not serving"},
+			},
+		}
+		err = WaitForComponentHealthy(context.TODO(), mc, "mockService", 1, time.Millisecond)
+		assert.True(t, errors.As(err, &notReady))
+		assert.Equal(t, "not serving", notReady.Reason)
+	})
+}
+
 func Test_ParseIndexParamsMap(t *testing.T) {
 	num := 10
 	keys := make([]string, 0)
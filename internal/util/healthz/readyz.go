@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package healthz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
+)
+
+// Checker is implemented by the components to report whether they are ready to serve
+type Checker interface {
+	// CheckReady returns nil if the component is ready, the error tells the reason otherwise
+	CheckReady(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to Checker
+type CheckerFunc func(ctx context.Context) error
+
+// CheckReady calls f(ctx)
+func (f CheckerFunc) CheckReady(ctx context.Context) error {
+	return f(ctx)
+}
+
+// StateComponent is a component reporting its state by GetComponentStates
+type StateComponent interface {
+	GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error)
+}
+
+// CheckComponentState returns nil if the state of component is healthy
+func CheckComponentState(ctx context.Context, component StateComponent) error {
+	states, err := component.GetComponentStates(ctx)
+	if err != nil {
+		return err
+	}
+	if states.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return errors.New(states.GetStatus().GetReason())
+	}
+	if states.GetState().GetStateCode() != internalpb.StateCode_Healthy {
+		return fmt.Errorf("state code is %s", states.GetState().GetStateCode())
+	}
+	return nil
+}
+
+// CheckComponentReady returns nil if the state of component is healthy and its session is registered in etcd
+func CheckComponentReady(ctx context.Context, component StateComponent, session *sessionutil.Session) error {
+	if err := CheckComponentState(ctx, component); err != nil {
+		return err
+	}
+	if session == nil {
+		return errors.New("session is not initialized")
+	}
+	if err := session.CheckRegistered(ctx); err != nil {
+		return fmt.Errorf("session check failed: %w", err)
+	}
+	return nil
+}
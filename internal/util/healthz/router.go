@@ -13,3 +13,7 @@ package healthz
 
 // HealthzRouterPath is default path for check health state.
 const HealthzRouterPath = "/healthz"
+
+// ReadyzRouterPath is default path for check whether the components are ready to serve,
+// ReadyzRouterPath/<role> checks the component of the role only.
+const ReadyzRouterPath = "/readyz"
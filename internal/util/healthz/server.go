@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package healthz
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// DefaultCheckTimeout is the max duration to check the readiness of the components of a request
+const DefaultCheckTimeout = 3 * time.Second
+
+// Server serves the liveness and readiness of the components running in the process.
+// HealthzRouterPath answers OK as long as the process is alive, ReadyzRouterPath answers OK only if all the
+// registered components are ready, ReadyzRouterPath/<role> checks the component of the role only.
+type Server struct {
+	timeout      time.Duration
+	shuttingDown int32
+
+	mu       sync.RWMutex
+	checkers map[string]Checker
+
+	mux      *http.ServeMux
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewServer creates a Server, the readiness checks of a request are cancelled after timeout
+func NewServer(timeout time.Duration) *Server {
+	s := &Server{
+		timeout:  timeout,
+		checkers: make(map[string]Checker),
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc(HealthzRouterPath, s.handleHealthz)
+	s.mux.HandleFunc(ReadyzRouterPath, s.handleReadyz)
+	s.mux.HandleFunc(ReadyzRouterPath+"/", s.handleReadyz)
+	return s
+}
+
+// Register adds the component of role, its readiness is served at ReadyzRouterPath/<role in lower case>
+func (s *Server) Register(role string, checker Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkers[strings.ToLower(role)] = checker
+}
+
+// SetShuttingDown makes the server report not ready, it's called before the components start stopping
+func (s *Server) SetShuttingDown() {
+	atomic.StoreInt32(&s.shuttingDown, 1)
+}
+
+func (s *Server) isShuttingDown() bool {
+	return atomic.LoadInt32(&s.shuttingDown) == 1
+}
+
+// Handler returns the http handler of the server
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Start listens on port and serves in the background, a random port is used if port is 0
+func (s *Server) Start(port int) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: s.mux}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Warn("health check server stopped", zap.Error(err))
+		}
+	}()
+	log.Info("health check server started", zap.String("address", listener.Addr().String()))
+	return nil
+}
+
+// Addr returns the address the server listens on, nil if it's not started
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop closes the server
+func (s *Server) Stop() error {
+	s.SetShuttingDown()
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Close()
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, "OK")
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	role := strings.Trim(strings.TrimPrefix(r.URL.Path, ReadyzRouterPath), "/")
+	checkers, ok := s.selectCheckers(strings.ToLower(role))
+	if !ok {
+		writeResponse(w, http.StatusNotFound, fmt.Sprintf("unknown role %s", role))
+		return
+	}
+	if s.isShuttingDown() {
+		writeResponse(w, http.StatusServiceUnavailable, "shutting down")
+		return
+	}
+	if len(checkers) == 0 {
+		writeResponse(w, http.StatusServiceUnavailable, "no component registered")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	failures := s.check(ctx, checkers)
+	if len(failures) > 0 {
+		writeResponse(w, http.StatusServiceUnavailable, strings.Join(failures, "\n"))
+		return
+	}
+	writeResponse(w, http.StatusOK, "OK")
+}
+
+// selectCheckers returns the checker of role, all the checkers if role is empty.
+// false is returned if role is not registered.
+func (s *Server) selectCheckers(role string) (map[string]Checker, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if role == "" {
+		checkers := make(map[string]Checker, len(s.checkers))
+		for name, checker := range s.checkers {
+			checkers[name] = checker
+		}
+		return checkers, true
+	}
+	checker, ok := s.checkers[role]
+	if !ok {
+		return nil, false
+	}
+	return map[string]Checker{role: checker}, true
+}
+
+// check runs the checkers concurrently, the failures are returned sorted by role
+func (s *Server) check(ctx context.Context, checkers map[string]Checker) []string {
+	var mu sync.Mutex
+	var failures []string
+	wg := sync.WaitGroup{}
+	for role, checker := range checkers {
+		wg.Add(1)
+		go func(role string, checker Checker) {
+			defer wg.Done()
+			if err := checker.CheckReady(ctx); err != nil {
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("%s: %s", role, err.Error()))
+				mu.Unlock()
+			}
+		}(role, checker)
+	}
+	wg.Wait()
+	sort.Strings(failures)
+	return failures
+}
+
+func writeResponse(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set(ContentTypeHeader, ContentTypeText)
+	w.WriteHeader(code)
+	if _, err := fmt.Fprint(w, msg); err != nil {
+		log.Warn("failed to send response", zap.Error(err))
+	}
+}
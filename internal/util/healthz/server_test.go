@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package healthz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+)
+
+type mockStateComponent struct {
+	code internalpb.StateCode
+	err  error
+}
+
+func (m *mockStateComponent) GetComponentStates(ctx context.Context) (*internalpb.ComponentStates, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &internalpb.ComponentStates{
+		State:  &internalpb.ComponentInfo{StateCode: m.code},
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+	}, nil
+}
+
+func get(t *testing.T, handler http.Handler, path string) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	body, err := ioutil.ReadAll(w.Result().Body)
+	assert.NoError(t, err)
+	return w.Code, string(body)
+}
+
+func TestCheckComponentState(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, CheckComponentState(ctx, &mockStateComponent{code: internalpb.StateCode_Healthy}))
+	assert.Error(t, CheckComponentState(ctx, &mockStateComponent{code: internalpb.StateCode_Initializing}))
+	assert.Error(t, CheckComponentState(ctx, &mockStateComponent{err: errors.New("mock")}))
+
+	// the session is checked after the state
+	assert.Error(t, CheckComponentReady(ctx, &mockStateComponent{code: internalpb.StateCode_Abnormal}, nil))
+	assert.Error(t, CheckComponentReady(ctx, &mockStateComponent{code: internalpb.StateCode_Healthy}, nil))
+}
+
+func TestServer(t *testing.T) {
+	s := NewServer(time.Second)
+	handler := s.Handler()
+
+	// startup, nothing registered yet
+	code, body := get(t, handler, HealthzRouterPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+	code, _ = get(t, handler, ReadyzRouterPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	rootCoord := &mockStateComponent{code: internalpb.StateCode_Initializing}
+	s.Register("RootCoord", CheckerFunc(func(ctx context.Context) error {
+		return CheckComponentState(ctx, rootCoord)
+	}))
+	var dataNodeErr error
+	s.Register("DataNode", CheckerFunc(func(ctx context.Context) error {
+		return dataNodeErr
+	}))
+
+	code, body = get(t, handler, ReadyzRouterPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "rootcoord")
+	code, _ = get(t, handler, ReadyzRouterPath+"/rootcoord")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = get(t, handler, ReadyzRouterPath+"/datanode")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = get(t, handler, ReadyzRouterPath+"/querynode")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// steady state
+	rootCoord.code = internalpb.StateCode_Healthy
+	code, body = get(t, handler, ReadyzRouterPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+	code, _ = get(t, handler, ReadyzRouterPath+"/rootcoord/")
+	assert.Equal(t, http.StatusOK, code)
+
+	dataNodeErr = errors.New("etcd unreachable")
+	code, body = get(t, handler, ReadyzRouterPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "datanode: etcd unreachable", body)
+	code, _ = get(t, handler, ReadyzRouterPath+"/rootcoord")
+	assert.Equal(t, http.StatusOK, code)
+	dataNodeErr = nil
+
+	// shutdown, still alive but not ready
+	s.SetShuttingDown()
+	code, _ = get(t, handler, HealthzRouterPath)
+	assert.Equal(t, http.StatusOK, code)
+	code, body = get(t, handler, ReadyzRouterPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "shutting down", body)
+	code, _ = get(t, handler, ReadyzRouterPath+"/rootcoord")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestServer_CheckTimeout(t *testing.T) {
+	s := NewServer(10 * time.Millisecond)
+	s.Register("proxy", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	code, body := get(t, s.Handler(), ReadyzRouterPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "proxy")
+}
+
+func TestServer_StartStop(t *testing.T) {
+	s := NewServer(time.Second)
+	assert.Nil(t, s.Addr())
+	assert.NoError(t, s.Stop())
+
+	s = NewServer(time.Second)
+	s.Register("proxy", CheckerFunc(func(ctx context.Context) error { return nil }))
+	assert.NoError(t, s.Start(0))
+	url := fmt.Sprintf("http://%s", s.Addr().String())
+
+	resp, err := http.Get(url + ReadyzRouterPath + "/proxy")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	assert.NoError(t, s.Stop())
+	_, err = http.Get(url + HealthzRouterPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package importutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+// column collects the values of a field parsed from the import files
+type column struct {
+	field *schemapb.FieldSchema
+	// dim is the dimension of vector fields
+	dim  int
+	rows int
+
+	bools    []bool
+	ints     []int32
+	longs    []int64
+	floats   []float32
+	doubles  []float64
+	strs     []string
+	floatVec []float32
+	binVec   []byte
+}
+
+func newColumn(field *schemapb.FieldSchema) (*column, error) {
+	c := &column{field: field}
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32,
+		schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double, schemapb.DataType_String:
+	case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector:
+		dim, err := vectorDim(field)
+		if err != nil {
+			return nil, err
+		}
+		c.dim = dim
+	default:
+		return nil, fmt.Errorf("field %s has unsupported data type %s", field.GetName(), field.GetDataType())
+	}
+	return c, nil
+}
+
+func vectorDim(field *schemapb.FieldSchema) (int, error) {
+	for _, kv := range field.GetTypeParams() {
+		if kv.GetKey() != "dim" {
+			continue
+		}
+		dim, err := strconv.Atoi(kv.GetValue())
+		if err != nil || dim <= 0 {
+			return 0, fmt.Errorf("field %s has invalid dim %s", field.GetName(), kv.GetValue())
+		}
+		if field.GetDataType() == schemapb.DataType_BinaryVector && dim%8 != 0 {
+			return 0, fmt.Errorf("field %s has invalid dim %d, binary vector dim must be a multiple of 8", field.GetName(), dim)
+		}
+		return dim, nil
+	}
+	return 0, fmt.Errorf("field %s has no dim", field.GetName())
+}
+
+// emptyCopy returns an empty column of the same field
+func (c *column) emptyCopy() *column {
+	return &column{field: c.field, dim: c.dim}
+}
+
+// appendJSON appends a value decoded by a json.Decoder with UseNumber
+func (c *column) appendJSON(v interface{}) error {
+	name := c.field.GetName()
+	switch c.field.GetDataType() {
+	case schemapb.DataType_Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("field %s expects bool, got %v", name, v)
+		}
+		c.bools = append(c.bools, b)
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		n, err := jsonInt(name, v, intBits(c.field.GetDataType()))
+		if err != nil {
+			return err
+		}
+		c.ints = append(c.ints, int32(n))
+	case schemapb.DataType_Int64:
+		n, err := jsonInt(name, v, 64)
+		if err != nil {
+			return err
+		}
+		c.longs = append(c.longs, n)
+	case schemapb.DataType_Float:
+		f, err := jsonFloat(name, v, 32)
+		if err != nil {
+			return err
+		}
+		c.floats = append(c.floats, float32(f))
+	case schemapb.DataType_Double:
+		f, err := jsonFloat(name, v, 64)
+		if err != nil {
+			return err
+		}
+		c.doubles = append(c.doubles, f)
+	case schemapb.DataType_String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("field %s expects string, got %v", name, v)
+		}
+		c.strs = append(c.strs, s)
+	case schemapb.DataType_FloatVector:
+		values, ok := v.([]interface{})
+		if !ok || len(values) != c.dim {
+			return fmt.Errorf("field %s expects float vector of dim %d, got %v", name, c.dim, v)
+		}
+		for _, value := range values {
+			f, err := jsonFloat(name, value, 32)
+			if err != nil {
+				return err
+			}
+			c.floatVec = append(c.floatVec, float32(f))
+		}
+	case schemapb.DataType_BinaryVector:
+		values, ok := v.([]interface{})
+		if !ok || len(values) != c.dim/8 {
+			return fmt.Errorf("field %s expects binary vector of %d bytes, got %v", name, c.dim/8, v)
+		}
+		for _, value := range values {
+			n, err := jsonInt(name, value, 0)
+			if err != nil {
+				return err
+			}
+			c.binVec = append(c.binVec, byte(n))
+		}
+	}
+	c.rows++
+	return nil
+}
+
+// intBits returns the bit size of integer types
+func intBits(dataType schemapb.DataType) int {
+	switch dataType {
+	case schemapb.DataType_Int8:
+		return 8
+	case schemapb.DataType_Int16:
+		return 16
+	case schemapb.DataType_Int32:
+		return 32
+	default:
+		return 64
+	}
+}
+
+// jsonInt parses an integer of bitSize bits, the bitSize 0 means a byte in [0, 255]
+func jsonInt(name string, v interface{}, bitSize int) (int64, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("field %s expects integer, got %v", name, v)
+	}
+	if bitSize == 0 {
+		n, err := strconv.ParseUint(string(num), 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("field %s expects byte, got %s", name, num)
+		}
+		return int64(n), nil
+	}
+	n, err := strconv.ParseInt(string(num), 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("field %s expects int%d, got %s", name, bitSize, num)
+	}
+	return n, nil
+}
+
+func jsonFloat(name string, v interface{}, bitSize int) (float64, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("field %s expects float, got %v", name, v)
+	}
+	f, err := strconv.ParseFloat(string(num), bitSize)
+	if err != nil || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("field %s expects float%d, got %s", name, bitSize, num)
+	}
+	return f, nil
+}
+
+// merge appends the values of other column of the same field
+func (c *column) merge(other *column) {
+	c.bools = append(c.bools, other.bools...)
+	c.ints = append(c.ints, other.ints...)
+	c.longs = append(c.longs, other.longs...)
+	c.floats = append(c.floats, other.floats...)
+	c.doubles = append(c.doubles, other.doubles...)
+	c.strs = append(c.strs, other.strs...)
+	c.floatVec = append(c.floatVec, other.floatVec...)
+	c.binVec = append(c.binVec, other.binVec...)
+	c.rows += other.rows
+}
+
+// fieldData returns the values in rows [start, end)
+func (c *column) fieldData(start, end int) *schemapb.FieldData {
+	fd := &schemapb.FieldData{
+		Type:      c.field.GetDataType(),
+		FieldName: c.field.GetName(),
+		FieldId:   c.field.GetFieldID(),
+	}
+	scalars := func(data *schemapb.ScalarField) {
+		fd.Field = &schemapb.FieldData_Scalars{Scalars: data}
+	}
+	switch c.field.GetDataType() {
+	case schemapb.DataType_Bool:
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: c.bools[start:end]}}})
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: c.ints[start:end]}}})
+	case schemapb.DataType_Int64:
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: c.longs[start:end]}}})
+	case schemapb.DataType_Float:
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: c.floats[start:end]}}})
+	case schemapb.DataType_Double:
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: c.doubles[start:end]}}})
+	case schemapb.DataType_String:
+		scalars(&schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: c.strs[start:end]}}})
+	case schemapb.DataType_FloatVector:
+		fd.Field = &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim:  int64(c.dim),
+			Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: c.floatVec[start*c.dim : end*c.dim]}},
+		}}
+	case schemapb.DataType_BinaryVector:
+		fd.Field = &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim:  int64(c.dim),
+			Data: &schemapb.VectorField_BinaryVector{BinaryVector: c.binVec[start*c.dim/8 : end*c.dim/8]},
+		}}
+	}
+	return fd
+}
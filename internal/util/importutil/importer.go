@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package importutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+const (
+	// JSONFileExt is the extension of JSON files, row based files are like {"rows": [{"field": value}]},
+	// and column based files are like {"field": [value]}
+	JSONFileExt = ".json"
+	// NumpyFileExt is the extension of NumPy files, a column based file holds the array of a field,
+	// which is named by the file name
+	NumpyFileExt = ".npy"
+
+	rowsKey = "rows"
+)
+
+// Importer parses the import files of a collection into columns, the values of each file are
+// validated against the schema, and the columns are changed only if the whole file is valid
+type Importer struct {
+	schema *schemapb.CollectionSchema
+	// columns of the user fields to import, keyed by field name
+	columns map[string]*column
+	// names of the fields in schema order
+	names []string
+}
+
+// NewImporter creates an Importer of schema, the system fields and auto id primary key are not imported
+func NewImporter(schema *schemapb.CollectionSchema) (*Importer, error) {
+	imp := &Importer{
+		schema:  schema,
+		columns: make(map[string]*column),
+	}
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() < common.StartOfUserFieldID || (field.GetIsPrimaryKey() && field.GetAutoID()) {
+			continue
+		}
+		c, err := newColumn(field)
+		if err != nil {
+			return nil, err
+		}
+		imp.columns[field.GetName()] = c
+		imp.names = append(imp.names, field.GetName())
+	}
+	if len(imp.names) == 0 {
+		return nil, fmt.Errorf("collection %s has no field to import", schema.GetName())
+	}
+	return imp, nil
+}
+
+// ParseFile parses the data of file by its extension, rowBased tells whether the file is row based
+func (imp *Importer) ParseFile(file string, data []byte, rowBased bool) error {
+	ext := path.Ext(file)
+	switch {
+	case ext == JSONFileExt && rowBased:
+		return imp.ParseJSONRows(data)
+	case ext == JSONFileExt:
+		return imp.ParseJSONColumns(data)
+	case ext == NumpyFileExt && !rowBased:
+		return imp.ParseNumpyColumn(strings.TrimSuffix(path.Base(file), ext), data)
+	case ext == NumpyFileExt:
+		return errors.New("numpy file can only be imported column based")
+	default:
+		return fmt.Errorf("unsupported file type %s", ext)
+	}
+}
+
+func newJSONDecoder(data []byte) *json.Decoder {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder
+}
+
+// ParseJSONRows parses JSON data like {"rows": [{"field": value}]}, every row must have all the fields to import
+func (imp *Importer) ParseJSONRows(data []byte) error {
+	var doc map[string][]map[string]interface{}
+	if err := newJSONDecoder(data).Decode(&doc); err != nil {
+		return fmt.Errorf("invalid row based json: %w", err)
+	}
+	rows, ok := doc[rowsKey]
+	if !ok {
+		return fmt.Errorf("row based json has no key %s", rowsKey)
+	}
+
+	parsed := make(map[string]*column, len(imp.columns))
+	for name, c := range imp.columns {
+		parsed[name] = c.emptyCopy()
+	}
+	for i, row := range rows {
+		for name := range row {
+			if _, ok := parsed[name]; !ok {
+				return fmt.Errorf("row %d has unknown field %s", i, name)
+			}
+		}
+		for _, name := range imp.names {
+			value, ok := row[name]
+			if !ok {
+				return fmt.Errorf("row %d has no field %s", i, name)
+			}
+			if err := parsed[name].appendJSON(value); err != nil {
+				return fmt.Errorf("row %d: %w", i, err)
+			}
+		}
+	}
+	for name, c := range parsed {
+		imp.columns[name].merge(c)
+	}
+	return nil
+}
+
+// ParseJSONColumns parses JSON data like {"field": [value]}, the file may hold part of the fields
+func (imp *Importer) ParseJSONColumns(data []byte) error {
+	var doc map[string][]interface{}
+	if err := newJSONDecoder(data).Decode(&doc); err != nil {
+		return fmt.Errorf("invalid column based json: %w", err)
+	}
+
+	parsed := make(map[string]*column, len(doc))
+	for name, values := range doc {
+		c, ok := imp.columns[name]
+		if !ok {
+			return fmt.Errorf("unknown field %s", name)
+		}
+		parsed[name] = c.emptyCopy()
+		for i, value := range values {
+			if err := parsed[name].appendJSON(value); err != nil {
+				return fmt.Errorf("row %d: %w", i, err)
+			}
+		}
+	}
+	for name, c := range parsed {
+		imp.columns[name].merge(c)
+	}
+	return nil
+}
+
+// ParseNumpyColumn parses the .npy data of the field named name
+func (imp *Importer) ParseNumpyColumn(name string, data []byte) error {
+	c, ok := imp.columns[name]
+	if !ok {
+		return fmt.Errorf("unknown field %s", name)
+	}
+	parsed := c.emptyCopy()
+	if err := parsed.appendNpy(data); err != nil {
+		return err
+	}
+	c.merge(parsed)
+	return nil
+}
+
+// RowCount returns the number of rows parsed, it fails if the fields have different numbers of rows
+func (imp *Importer) RowCount() (int, error) {
+	rows := imp.columns[imp.names[0]].rows
+	for _, name := range imp.names[1:] {
+		if imp.columns[name].rows != rows {
+			return 0, fmt.Errorf("field %s has %d rows, but field %s has %d rows",
+				imp.names[0], rows, name, imp.columns[name].rows)
+		}
+	}
+	return rows, nil
+}
+
+// FieldsData returns the values of the imported fields in rows [start, end)
+func (imp *Importer) FieldsData(start, end int) ([]*schemapb.FieldData, error) {
+	rows, err := imp.RowCount()
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 || start > end || end > rows {
+		return nil, fmt.Errorf("invalid rows [%d, %d) of %d rows", start, end, rows)
+	}
+	fieldsData := make([]*schemapb.FieldData, 0, len(imp.names))
+	for _, name := range imp.names {
+		fieldsData = append(fieldsData, imp.columns[name].fieldData(start, end))
+	}
+	return fieldsData, nil
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package importutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+func newTestSchema(autoID bool) *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test_import",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: "RowID", DataType: schemapb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: "Timestamp", DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true, AutoID: autoID},
+			{FieldID: 101, Name: "flag", DataType: schemapb.DataType_Bool},
+			{FieldID: 102, Name: "age", DataType: schemapb.DataType_Int8},
+			{FieldID: 103, Name: "score", DataType: schemapb.DataType_Double},
+			{FieldID: 104, Name: "name", DataType: schemapb.DataType_String},
+			{
+				FieldID:    105,
+				Name:       "vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}},
+			},
+			{
+				FieldID:    106,
+				Name:       "bin",
+				DataType:   schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "16"}},
+			},
+		},
+	}
+}
+
+// genNpy generates .npy data of version 1
+func genNpy(descr string, shape string, data []byte) []byte {
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, shape)
+	for (10+len(header)+1)%64 != 0 {
+		header += " "
+	}
+	header += "\n"
+	var buf bytes.Buffer
+	buf.Write(npyMagic)
+	buf.Write([]byte{1, 0})
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func genNpyValues(descr string, shape string, values interface{}) []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, values)
+	return genNpy(descr, shape, buf.Bytes())
+}
+
+const testRowsJSON = `{
+	"rows": [
+		{"pk": 1, "flag": true, "age": 10, "score": 1.5, "name": "a", "vec": [0.1, 0.2], "bin": [1, 255]},
+		{"pk": 2, "flag": false, "age": -10, "score": 2.5, "name": "b", "vec": [0.3, 0.4], "bin": [2, 0]},
+		{"pk": 3, "flag": true, "age": 127, "score": 3, "name": "c", "vec": [0.5, 0.6], "bin": [3, 128]}
+	]
+}`
+
+func checkTestFieldsData(t *testing.T, fieldsData []*schemapb.FieldData) {
+	require.Equal(t, 7, len(fieldsData))
+	assert.Equal(t, int64(100), fieldsData[0].GetFieldId())
+	assert.Equal(t, []int64{1, 2, 3}, fieldsData[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []bool{true, false, true}, fieldsData[1].GetScalars().GetBoolData().GetData())
+	assert.Equal(t, []int32{10, -10, 127}, fieldsData[2].GetScalars().GetIntData().GetData())
+	assert.Equal(t, []float64{1.5, 2.5, 3}, fieldsData[3].GetScalars().GetDoubleData().GetData())
+	assert.Equal(t, []string{"a", "b", "c"}, fieldsData[4].GetScalars().GetStringData().GetData())
+	assert.Equal(t, int64(2), fieldsData[5].GetVectors().GetDim())
+	assert.Equal(t, []float32{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}, fieldsData[5].GetVectors().GetFloatVector().GetData())
+	assert.Equal(t, int64(16), fieldsData[6].GetVectors().GetDim())
+	assert.Equal(t, []byte{1, 255, 2, 0, 3, 128}, fieldsData[6].GetVectors().GetBinaryVector())
+}
+
+func TestImporter_JSONRows(t *testing.T) {
+	imp, err := NewImporter(newTestSchema(false))
+	require.NoError(t, err)
+	require.NoError(t, imp.ParseFile("a/b/rows.json", []byte(testRowsJSON), true))
+	rows, err := imp.RowCount()
+	require.NoError(t, err)
+	assert.Equal(t, 3, rows)
+
+	fieldsData, err := imp.FieldsData(0, rows)
+	require.NoError(t, err)
+	checkTestFieldsData(t, fieldsData)
+
+	fieldsData, err = imp.FieldsData(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, fieldsData[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []float32{0.3, 0.4}, fieldsData[5].GetVectors().GetFloatVector().GetData())
+	assert.Equal(t, []byte{2, 0}, fieldsData[6].GetVectors().GetBinaryVector())
+	_, err = imp.FieldsData(2, 4)
+	assert.Error(t, err)
+
+	// the rows of the second file are appended
+	require.NoError(t, imp.ParseJSONRows([]byte(testRowsJSON)))
+	rows, err = imp.RowCount()
+	require.NoError(t, err)
+	assert.Equal(t, 6, rows)
+}
+
+func TestImporter_JSONRowsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"not json":        `{"rows": [`,
+		"no rows":         `{"data": []}`,
+		"missing field":   `{"rows": [{"pk": 1}]}`,
+		"unknown field":   `{"rows": [{"pk": 1, "flag": true, "age": 10, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 2], "x": 1}]}`,
+		"int overflow":    `{"rows": [{"pk": 1, "flag": true, "age": 128, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 2]}]}`,
+		"wrong type":      `{"rows": [{"pk": "1", "flag": true, "age": 10, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 2]}]}`,
+		"wrong dim":       `{"rows": [{"pk": 1, "flag": true, "age": 10, "score": 1, "name": "a", "vec": [1, 2, 3], "bin": [1, 2]}]}`,
+		"byte overflow":   `{"rows": [{"pk": 1, "flag": true, "age": 10, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 256]}]}`,
+		"float pk":        `{"rows": [{"pk": 1.5, "flag": true, "age": 10, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 2]}]}`,
+		"string not bool": `{"rows": [{"pk": 1, "flag": "true", "age": 10, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 2]}]}`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			imp, err := NewImporter(newTestSchema(false))
+			require.NoError(t, err)
+			require.NoError(t, imp.ParseJSONRows([]byte(testRowsJSON)))
+			assert.Error(t, imp.ParseJSONRows([]byte(data)))
+
+			// the columns are not changed by the invalid file
+			rows, err := imp.RowCount()
+			require.NoError(t, err)
+			assert.Equal(t, 3, rows)
+		})
+	}
+}
+
+func TestImporter_AutoID(t *testing.T) {
+	imp, err := NewImporter(newTestSchema(true))
+	require.NoError(t, err)
+	// the auto id primary key must be absent
+	assert.Error(t, imp.ParseJSONRows([]byte(testRowsJSON)))
+
+	require.NoError(t, imp.ParseJSONRows([]byte(`{"rows": [{"flag": true, "age": 10, "score": 1, "name": "a", "vec": [1, 2], "bin": [1, 2]}]}`)))
+	fieldsData, err := imp.FieldsData(0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 6, len(fieldsData))
+	assert.Equal(t, int64(101), fieldsData[0].GetFieldId())
+}
+
+func TestImporter_JSONColumns(t *testing.T) {
+	imp, err := NewImporter(newTestSchema(false))
+	require.NoError(t, err)
+	require.NoError(t, imp.ParseFile("scalars.json", []byte(`{
+		"pk": [1, 2, 3],
+		"flag": [true, false, true],
+		"age": [10, -10, 127],
+		"score": [1.5, 2.5, 3],
+		"name": ["a", "b", "c"]
+	}`), false))
+	// vectors are not parsed yet
+	_, err = imp.RowCount()
+	assert.Error(t, err)
+
+	require.NoError(t, imp.ParseFile("vectors.json", []byte(`{"vec": [[0.1, 0.2], [0.3, 0.4], [0.5, 0.6]], "bin": [[1, 255], [2, 0], [3, 128]]}`), false))
+	rows, err := imp.RowCount()
+	require.NoError(t, err)
+	fieldsData, err := imp.FieldsData(0, rows)
+	require.NoError(t, err)
+	checkTestFieldsData(t, fieldsData)
+
+	assert.Error(t, imp.ParseJSONColumns([]byte(`{"x": [1]}`)))
+	assert.Error(t, imp.ParseJSONColumns([]byte(`{"pk": [1, "2"]}`)))
+	assert.Error(t, imp.ParseJSONColumns([]byte(`[1, 2]`)))
+}
+
+func TestImporter_Numpy(t *testing.T) {
+	imp, err := NewImporter(newTestSchema(false))
+	require.NoError(t, err)
+	files := map[string][]byte{
+		"pk.npy":    genNpyValues("<i8", "3,", []int64{1, 2, 3}),
+		"flag.npy":  genNpy("|b1", "3,", []byte{1, 0, 1}),
+		"age.npy":   genNpyValues("|i1", "3,", []int8{10, -10, 127}),
+		"score.npy": genNpyValues("<f8", "3,", []float64{1.5, 2.5, 3}),
+		"vec.npy":   genNpyValues("<f4", "3, 2", []float32{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}),
+		"bin.npy":   genNpy("|u1", "3, 2", []byte{1, 255, 2, 0, 3, 128}),
+	}
+	for file, data := range files {
+		require.NoError(t, imp.ParseFile("dir/"+file, data, false), file)
+	}
+	// string is not supported in numpy files, but can be imported from json
+	assert.Error(t, imp.ParseFile("name.npy", genNpy("<U1", "3,", []byte("abc")), false))
+	require.NoError(t, imp.ParseFile("name.json", []byte(`{"name": ["a", "b", "c"]}`), false))
+
+	rows, err := imp.RowCount()
+	require.NoError(t, err)
+	fieldsData, err := imp.FieldsData(0, rows)
+	require.NoError(t, err)
+	checkTestFieldsData(t, fieldsData)
+}
+
+func TestImporter_NumpyInvalid(t *testing.T) {
+	imp, err := NewImporter(newTestSchema(false))
+	require.NoError(t, err)
+	cases := map[string][]byte{
+		"pk.npy":      []byte("not numpy"),
+		"age.npy":     genNpyValues("<i8", "3,", []int64{1, 2, 3}),
+		"score.npy":   genNpyValues(">f8", "3,", []float64{1.5, 2.5, 3}),
+		"vec.npy":     genNpyValues("<f4", "2, 3", []float32{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}),
+		"bin.npy":     genNpy("|u1", "3, 2", []byte{1, 255, 2}),
+		"flag.npy":    genNpy("|b1", "3, 1", []byte{1, 0, 1}),
+		"unknown.npy": genNpyValues("<i8", "3,", []int64{1, 2, 3}),
+	}
+	for file, data := range cases {
+		assert.Error(t, imp.ParseFile(file, data, false), file)
+	}
+	fortran := bytes.Replace(genNpyValues("<i8", "3,", []int64{1, 2, 3}), []byte("False"), []byte("True "), 1)
+	assert.Error(t, imp.ParseFile("pk.npy", fortran, false))
+
+	// numpy files are column based
+	assert.Error(t, imp.ParseFile("pk.npy", genNpyValues("<i8", "3,", []int64{1, 2, 3}), true))
+	assert.Error(t, imp.ParseFile("pk.csv", []byte("1,2,3"), false))
+}
+
+func TestNewImporter(t *testing.T) {
+	_, err := NewImporter(&schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: "RowID", DataType: schemapb.DataType_Int64},
+		},
+	})
+	assert.Error(t, err)
+
+	invalid := []*schemapb.FieldSchema{
+		{FieldID: 100, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		{FieldID: 100, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "x"}}},
+		{FieldID: 100, Name: "bin", DataType: schemapb.DataType_BinaryVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "12"}}},
+		{FieldID: 100, Name: "none", DataType: schemapb.DataType_None},
+	}
+	for _, field := range invalid {
+		_, err := NewImporter(&schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{field}})
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package importutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+)
+
+var npyMagic = []byte("\x93NUMPY")
+
+var (
+	npyDescrRegexp   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranRegexp = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapeRegexp   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// npyHeader is the header of a NumPy .npy file
+type npyHeader struct {
+	descr string
+	shape []int
+}
+
+// parseNpy parses the header of .npy data, and returns the header and the array data following it
+func parseNpy(data []byte) (*npyHeader, []byte, error) {
+	if len(data) < 10 || !bytes.Equal(data[:6], npyMagic) {
+		return nil, nil, errors.New("not a numpy file")
+	}
+	var headerLen, offset int
+	switch data[6] {
+	case 1:
+		headerLen, offset = int(binary.LittleEndian.Uint16(data[8:10])), 10
+	case 2, 3:
+		if len(data) < 12 {
+			return nil, nil, errors.New("numpy file is truncated")
+		}
+		headerLen, offset = int(binary.LittleEndian.Uint32(data[8:12])), 12
+	default:
+		return nil, nil, fmt.Errorf("unsupported numpy file version %d", data[6])
+	}
+	if len(data) < offset+headerLen {
+		return nil, nil, errors.New("numpy file is truncated")
+	}
+	header := string(data[offset : offset+headerLen])
+
+	descr := npyDescrRegexp.FindStringSubmatch(header)
+	fortran := npyFortranRegexp.FindStringSubmatch(header)
+	shape := npyShapeRegexp.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, nil, fmt.Errorf("invalid numpy header %s", header)
+	}
+	if fortran[1] == "True" {
+		return nil, nil, errors.New("numpy array in fortran order is not supported")
+	}
+	h := &npyHeader{descr: descr[1]}
+	for _, s := range strings.Split(shape[1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, nil, fmt.Errorf("invalid numpy shape %s", shape[1])
+		}
+		h.shape = append(h.shape, n)
+	}
+	return h, data[offset+headerLen:], nil
+}
+
+// npyDescr returns the dtype of numpy array expected by the data type, in little endian
+func npyDescr(dataType schemapb.DataType) (string, error) {
+	switch dataType {
+	case schemapb.DataType_Bool:
+		return "|b1", nil
+	case schemapb.DataType_Int8:
+		return "|i1", nil
+	case schemapb.DataType_Int16:
+		return "<i2", nil
+	case schemapb.DataType_Int32:
+		return "<i4", nil
+	case schemapb.DataType_Int64:
+		return "<i8", nil
+	case schemapb.DataType_Float, schemapb.DataType_FloatVector:
+		return "<f4", nil
+	case schemapb.DataType_Double:
+		return "<f8", nil
+	case schemapb.DataType_BinaryVector:
+		return "|u1", nil
+	default:
+		return "", fmt.Errorf("data type %s is not supported in numpy file", dataType)
+	}
+}
+
+// appendNpy appends the values of .npy data, which is a 1-D array for scalar fields,
+// or a 2-D array of shape (rows, dim) for float vectors and (rows, dim/8) for binary vectors
+func (c *column) appendNpy(data []byte) error {
+	name := c.field.GetName()
+	expected, err := npyDescr(c.field.GetDataType())
+	if err != nil {
+		return err
+	}
+	header, array, err := parseNpy(data)
+	if err != nil {
+		return err
+	}
+	// '=' is the native byte order of the writer, which is little endian on the platforms we support
+	descr := header.descr
+	if strings.HasPrefix(descr, "=") {
+		descr = "<" + descr[1:]
+	}
+	if descr != expected && !(expected[0] == '|' && descr[1:] == expected[1:]) {
+		return fmt.Errorf("field %s expects numpy dtype %s, got %s", name, expected, header.descr)
+	}
+
+	var rows, width int
+	switch c.field.GetDataType() {
+	case schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector:
+		width = c.dim
+		if c.field.GetDataType() == schemapb.DataType_BinaryVector {
+			width = c.dim / 8
+		}
+		if len(header.shape) != 2 || header.shape[1] != width {
+			return fmt.Errorf("field %s expects numpy shape (rows, %d), got %v", name, width, header.shape)
+		}
+		rows = header.shape[0]
+	default:
+		if len(header.shape) != 1 {
+			return fmt.Errorf("field %s expects 1-D numpy array, got shape %v", name, header.shape)
+		}
+		rows, width = header.shape[0], 1
+	}
+	itemSize, _ := strconv.Atoi(expected[2:])
+	if len(array) != rows*width*itemSize {
+		return fmt.Errorf("field %s has %d bytes of numpy data, expects %d", name, len(array), rows*width*itemSize)
+	}
+
+	n := rows * width
+	switch c.field.GetDataType() {
+	case schemapb.DataType_Bool:
+		for i := 0; i < n; i++ {
+			c.bools = append(c.bools, array[i] != 0)
+		}
+	case schemapb.DataType_Int8:
+		for i := 0; i < n; i++ {
+			c.ints = append(c.ints, int32(int8(array[i])))
+		}
+	case schemapb.DataType_Int16:
+		for i := 0; i < n; i++ {
+			c.ints = append(c.ints, int32(int16(binary.LittleEndian.Uint16(array[2*i:]))))
+		}
+	case schemapb.DataType_Int32:
+		for i := 0; i < n; i++ {
+			c.ints = append(c.ints, int32(binary.LittleEndian.Uint32(array[4*i:])))
+		}
+	case schemapb.DataType_Int64:
+		for i := 0; i < n; i++ {
+			c.longs = append(c.longs, int64(binary.LittleEndian.Uint64(array[8*i:])))
+		}
+	case schemapb.DataType_Float:
+		for i := 0; i < n; i++ {
+			c.floats = append(c.floats, math.Float32frombits(binary.LittleEndian.Uint32(array[4*i:])))
+		}
+	case schemapb.DataType_Double:
+		for i := 0; i < n; i++ {
+			c.doubles = append(c.doubles, math.Float64frombits(binary.LittleEndian.Uint64(array[8*i:])))
+		}
+	case schemapb.DataType_FloatVector:
+		for i := 0; i < n; i++ {
+			c.floatVec = append(c.floatVec, math.Float32frombits(binary.LittleEndian.Uint32(array[4*i:])))
+		}
+	case schemapb.DataType_BinaryVector:
+		c.binVec = append(c.binVec, array...)
+	}
+	c.rows += rows
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexparamcheck
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+type indexParamRange struct {
+	key      string
+	min      int64
+	max      int64
+	optional bool
+}
+
+type indexRule struct {
+	// binary is true if the index is built on binary vectors, otherwise on float vectors
+	binary  bool
+	metrics []string
+	params  []indexParamRange
+	// check validates the params depending on each other or on the dimension, after they are checked by range
+	check func(indexType IndexType, params map[string]string, dim int64) error
+}
+
+var (
+	nlistRange          = indexParamRange{key: NLIST, min: MinNList, max: MaxNList}
+	hnswMRange          = indexParamRange{key: HNSWM, min: HNSWMinM, max: HNSWMaxM}
+	efConstructionRange = indexParamRange{key: EFConstruction, min: HNSWMinEfConstruction, max: HNSWMaxEfConstruction}
+	nTreesRange         = indexParamRange{key: NTREES, min: MinNTrees, max: MaxNTrees}
+	ivfMRange           = indexParamRange{key: IVFM, min: 1, max: DefaultMaxDim}
+	nbitsRange          = indexParamRange{key: NBITS, min: MinNBits, max: MaxNBits, optional: true}
+	pqmRange            = indexParamRange{key: PQM, min: 1, max: DefaultMaxDim}
+	edgeSizeRange       = indexParamRange{key: EdgeSize, min: NgtMinEdgeSize, max: NgtMaxEdgeSize}
+)
+
+// indexRules are the rules of building indexes, the metric types are shared with the rules of searching them
+var indexRules = map[IndexType]indexRule{
+	IndexFaissIDMap:      {metrics: METRICS},
+	IndexFaissIvfFlat:    {metrics: METRICS, params: []indexParamRange{nlistRange}},
+	IndexFaissIvfPQ:      {metrics: METRICS, params: []indexParamRange{nlistRange, ivfMRange, nbitsRange}, check: checkIVFPQParams},
+	IndexFaissIvfSQ8:     {metrics: METRICS, params: []indexParamRange{nlistRange}},
+	IndexFaissIvfSQ8H:    {metrics: METRICS, params: []indexParamRange{nlistRange}},
+	IndexFaissBinIDMap:   {binary: true, metrics: BinIDMapMetrics},
+	IndexFaissBinIvfFlat: {binary: true, metrics: BinIvfMetrics, params: []indexParamRange{nlistRange}},
+	IndexNSG: {metrics: METRICS, params: []indexParamRange{
+		{key: KNNG, min: MinKNNG, max: MaxKNNG},
+		{key: SearchLength, min: MinSearchLength, max: MaxSearchLength},
+		{key: OutDegree, min: MinOutDegree, max: MaxOutDegree},
+		{key: CANDIDATE, min: MinCandidatePoolSize, max: MaxCandidatePoolSize},
+	}},
+	IndexHNSW:      {metrics: METRICS, params: []indexParamRange{hnswMRange, efConstructionRange}},
+	IndexRHNSWFlat: {metrics: METRICS, params: []indexParamRange{hnswMRange, efConstructionRange}},
+	IndexRHNSWPQ:   {metrics: METRICS, params: []indexParamRange{hnswMRange, efConstructionRange, pqmRange}, check: checkRHNSWPQParams},
+	IndexRHNSWSQ:   {metrics: METRICS, params: []indexParamRange{hnswMRange, efConstructionRange}},
+	IndexANNOY:     {metrics: METRICS, params: []indexParamRange{nTreesRange}},
+	IndexNGTPANNG: {metrics: METRICS, params: []indexParamRange{
+		edgeSizeRange,
+		{key: ForcedlyPrunedEdgeSize, min: NgtMinEdgeSize, max: NgtMaxEdgeSize},
+		{key: SelectivelyPrunedEdgeSize, min: NgtMinEdgeSize, max: NgtMaxEdgeSize},
+	}, check: checkNGTPANNGParams},
+	IndexNGTONNG: {metrics: METRICS, params: []indexParamRange{
+		edgeSizeRange,
+		{key: OutgoingEdgeSize, min: NgtMinEdgeSize, max: NgtMaxEdgeSize},
+		{key: IncomingEdgeSize, min: NgtMinEdgeSize, max: NgtMaxEdgeSize},
+	}},
+}
+
+// checkPQM checks the number of sub-quantizers divides the dimension, which is skipped if the dimension is unknown
+func checkPQM(indexType IndexType, key string, params map[string]string, dim int64) error {
+	m, _ := strconv.ParseInt(params[key], 10, 64)
+	if dim > 0 && dim%m != 0 {
+		return fmt.Errorf("%s(%d) in index params of index %s should divide dim(%d)", key, m, indexType, dim)
+	}
+	return nil
+}
+
+func checkIVFPQParams(indexType IndexType, params map[string]string, dim int64) error {
+	if err := checkPQM(indexType, IVFM, params, dim); err != nil {
+		return err
+	}
+	if params[IndexMode] != GPUMode || dim <= 0 {
+		return nil
+	}
+	// Faiss only supports some numbers of sub-quantizers and dims per sub-quantizer on GPU without precomputed codes
+	m, _ := strconv.Atoi(params[IVFM])
+	if !funcutil.SliceContain(supportSubQuantizer, m) {
+		return fmt.Errorf("%s(%d) in index params of index %s should be one of %v in GPU mode", IVFM, m, indexType, supportSubQuantizer)
+	}
+	if subDim := int(dim) / m; !funcutil.SliceContain(supportDimPerSubQuantizer, subDim) {
+		return fmt.Errorf("dim(%d) / %s(%d) of index %s should be one of %v in GPU mode", dim, IVFM, m, indexType, supportDimPerSubQuantizer)
+	}
+	if nbits, ok := params[NBITS]; ok && nbits != strconv.Itoa(DefaultNBits) {
+		return fmt.Errorf("%s(%s) in index params of index %s should be %d in GPU mode", NBITS, nbits, indexType, DefaultNBits)
+	}
+	return nil
+}
+
+func checkRHNSWPQParams(indexType IndexType, params map[string]string, dim int64) error {
+	return checkPQM(indexType, PQM, params, dim)
+}
+
+func checkNGTPANNGParams(indexType IndexType, params map[string]string, _ int64) error {
+	selectively, _ := strconv.ParseInt(params[SelectivelyPrunedEdgeSize], 10, 64)
+	forcedly, _ := strconv.ParseInt(params[ForcedlyPrunedEdgeSize], 10, 64)
+	if selectively >= forcedly {
+		return fmt.Errorf("%s(%d) in index params of index %s should be less than %s(%d)",
+			SelectivelyPrunedEdgeSize, selectively, indexType, ForcedlyPrunedEdgeSize, forcedly)
+	}
+	return nil
+}
+
+// CheckIndexParams checks the params of building an index of indexType on a field of dataType, the params include
+// the type params of the field such as dim. The check of the field type is skipped if dataType is DataType_None.
+func CheckIndexParams(indexType IndexType, dataType schemapb.DataType, params map[string]string) error {
+	rule, ok := indexRules[indexType]
+	if !ok {
+		return fmt.Errorf("unsupported index type %s", indexType)
+	}
+
+	switch dataType {
+	case schemapb.DataType_None:
+	case schemapb.DataType_FloatVector:
+		if rule.binary {
+			return fmt.Errorf("index %s is built on binary vectors, which is not supported by field of type %s", indexType, dataType)
+		}
+	case schemapb.DataType_BinaryVector:
+		if !rule.binary {
+			return fmt.Errorf("index %s is built on float vectors, which is not supported by field of type %s", indexType, dataType)
+		}
+	default:
+		return fmt.Errorf("index %s is not supported by field of type %s, only vector fields can be indexed", indexType, dataType)
+	}
+
+	var dim int64
+	if dimStr, ok := params[DIM]; ok {
+		var err error
+		dim, err = strconv.ParseInt(dimStr, 10, 64)
+		if err != nil || dim < DefaultMinDim || dim > DefaultMaxDim {
+			return fmt.Errorf("%s(%s) should be an integer in range [%d, %d]", DIM, dimStr, DefaultMinDim, DefaultMaxDim)
+		}
+		if rule.binary && dim%8 != 0 {
+			return fmt.Errorf("%s(%d) of binary vectors should be a multiple of 8", DIM, dim)
+		}
+	}
+
+	metricType, ok := params[Metric]
+	if !ok {
+		return fmt.Errorf("%s is required in index params of index %s", Metric, indexType)
+	}
+	if !funcutil.SliceContain(rule.metrics, metricType) {
+		return fmt.Errorf("%s(%s) is not supported by index %s, supported metric types: %v", Metric, metricType, indexType, rule.metrics)
+	}
+
+	for _, r := range rule.params {
+		value, ok := params[r.key]
+		if !ok {
+			if r.optional {
+				continue
+			}
+			return fmt.Errorf("%s is required in index params of index %s", r.key, indexType)
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s(%s) in index params should be an integer", r.key, value)
+		}
+		if v < r.min || v > r.max {
+			return fmt.Errorf("%s(%d) in index params of index %s should be in range [%d, %d]", r.key, v, indexType, r.min, r.max)
+		}
+	}
+
+	if rule.check != nil {
+		return rule.check(indexType, params, dim)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexparamcheck
+
+import (
+	"testing"
+
+	"github.com/milvus-io/milvus/internal/proto/schemapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckIndexParams(t *testing.T) {
+	// with builds the params from the base ones of an index type, overridden by kvs, and an empty value removes the key
+	with := func(base map[string]string, kvs ...string) map[string]string {
+		params := make(map[string]string)
+		for k, v := range base {
+			params[k] = v
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			if kvs[i+1] == "" {
+				delete(params, kvs[i])
+				continue
+			}
+			params[kvs[i]] = kvs[i+1]
+		}
+		return params
+	}
+
+	flat := map[string]string{DIM: "128", Metric: L2}
+	ivf := map[string]string{DIM: "128", Metric: L2, NLIST: "1024"}
+	ivfPQ := map[string]string{DIM: "128", Metric: L2, NLIST: "1024", IVFM: "16", NBITS: "8"}
+	hnsw := map[string]string{DIM: "128", Metric: IP, HNSWM: "16", EFConstruction: "200"}
+	rhnswPQ := map[string]string{DIM: "128", Metric: L2, HNSWM: "16", EFConstruction: "200", PQM: "8"}
+	annoy := map[string]string{DIM: "128", Metric: L2, NTREES: "8"}
+	nsg := map[string]string{DIM: "128", Metric: L2, KNNG: "20", SearchLength: "40", OutDegree: "30", CANDIDATE: "100"}
+	panng := map[string]string{DIM: "128", Metric: L2, EdgeSize: "10", ForcedlyPrunedEdgeSize: "60", SelectivelyPrunedEdgeSize: "30"}
+	onng := map[string]string{DIM: "128", Metric: L2, EdgeSize: "10", OutgoingEdgeSize: "5", IncomingEdgeSize: "40"}
+	binFlat := map[string]string{DIM: "128", Metric: SUBSTRUCTURE}
+	binIvf := map[string]string{DIM: "128", Metric: JACCARD, NLIST: "64"}
+
+	floatVec, binVec := schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector
+	cases := []struct {
+		name      string
+		indexType IndexType
+		dataType  schemapb.DataType
+		params    map[string]string
+		errMsg    string
+	}{
+		{"unknown index type", "UNKNOWN", floatVec, flat, "unsupported index type UNKNOWN"},
+
+		// field type
+		{"unknown field type", IndexFaissIDMap, schemapb.DataType_None, flat, ""},
+		{"scalar field", IndexFaissIDMap, schemapb.DataType_Int64, flat, "only vector fields can be indexed"},
+		{"float index on binary field", IndexFaissIvfFlat, binVec, ivf, "index IVF_FLAT is built on float vectors"},
+		{"binary index on float field", IndexFaissBinIvfFlat, floatVec, binIvf, "index BIN_IVF_FLAT is built on binary vectors"},
+
+		// dim
+		{"invalid dim", IndexFaissIDMap, floatVec, with(flat, DIM, "abc"), "dim(abc) should be an integer"},
+		{"dim too large", IndexFaissIDMap, floatVec, with(flat, DIM, "32769"), "dim(32769) should be an integer in range [1, 32768]"},
+		{"binary dim not multiple of 8", IndexFaissBinIDMap, binVec, with(binFlat, DIM, "100"), "dim(100) of binary vectors should be a multiple of 8"},
+
+		// flat
+		{"flat", IndexFaissIDMap, floatVec, flat, ""},
+		{"flat without metric", IndexFaissIDMap, floatVec, with(flat, Metric, ""), "metric_type is required in index params of index FLAT"},
+		{"flat binary metric", IndexFaissIDMap, floatVec, with(flat, Metric, HAMMING), "metric_type(HAMMING) is not supported by index FLAT"},
+
+		// ivf flat & sq8
+		{"ivf flat", IndexFaissIvfFlat, floatVec, ivf, ""},
+		{"ivf flat without nlist", IndexFaissIvfFlat, floatVec, with(ivf, NLIST, ""), "nlist is required in index params of index IVF_FLAT"},
+		{"ivf flat nlist zero", IndexFaissIvfFlat, floatVec, with(ivf, NLIST, "0"), "nlist(0) in index params of index IVF_FLAT should be in range [1, 65536]"},
+		{"ivf flat nlist too large", IndexFaissIvfFlat, floatVec, with(ivf, NLIST, "65537"), "nlist(65537)"},
+		{"ivf flat invalid nlist", IndexFaissIvfFlat, floatVec, with(ivf, NLIST, "1.5"), "nlist(1.5) in index params should be an integer"},
+		{"ivf sq8", IndexFaissIvfSQ8, floatVec, ivf, ""},
+		{"ivf sq8 nlist too large", IndexFaissIvfSQ8, floatVec, with(ivf, NLIST, "100000"), "nlist(100000)"},
+		{"ivf sq8 hybrid", IndexFaissIvfSQ8H, floatVec, with(ivf, Metric, IP), ""},
+
+		// ivf pq
+		{"ivf pq", IndexFaissIvfPQ, floatVec, ivfPQ, ""},
+		{"ivf pq without nbits", IndexFaissIvfPQ, floatVec, with(ivfPQ, NBITS, ""), ""},
+		{"ivf pq without dim", IndexFaissIvfPQ, floatVec, with(ivfPQ, DIM, "", IVFM, "7"), ""},
+		{"ivf pq without m", IndexFaissIvfPQ, floatVec, with(ivfPQ, IVFM, ""), "m is required in index params of index IVF_PQ"},
+		{"ivf pq m not divides dim", IndexFaissIvfPQ, floatVec, with(ivfPQ, IVFM, "7"), "m(7) in index params of index IVF_PQ should divide dim(128)"},
+		{"ivf pq nbits too large", IndexFaissIvfPQ, floatVec, with(ivfPQ, NBITS, "17"), "nbits(17)"},
+		{"ivf pq gpu", IndexFaissIvfPQ, floatVec, with(ivfPQ, IndexMode, GPUMode), ""},
+		{"ivf pq gpu m", IndexFaissIvfPQ, floatVec, with(ivfPQ, DIM, "1280", IVFM, "10", IndexMode, GPUMode), "m(10) in index params of index IVF_PQ should be one of"},
+		{"ivf pq gpu sub dim", IndexFaissIvfPQ, floatVec, with(ivfPQ, DIM, "1024", IVFM, "16", IndexMode, GPUMode), "dim(1024) / m(16) of index IVF_PQ should be one of"},
+		{"ivf pq gpu nbits", IndexFaissIvfPQ, floatVec, with(ivfPQ, NBITS, "4", IndexMode, GPUMode), "nbits(4) in index params of index IVF_PQ should be 8 in GPU mode"},
+
+		// hnsw
+		{"hnsw", IndexHNSW, floatVec, hnsw, ""},
+		{"hnsw without M", IndexHNSW, floatVec, with(hnsw, HNSWM, ""), "M is required in index params of index HNSW"},
+		{"hnsw M too small", IndexHNSW, floatVec, with(hnsw, HNSWM, "3"), "M(3) in index params of index HNSW should be in range [4, 64]"},
+		{"hnsw efConstruction too large", IndexHNSW, floatVec, with(hnsw, EFConstruction, "513"), "efConstruction(513) in index params of index HNSW should be in range [8, 512]"},
+		{"rhnsw flat", IndexRHNSWFlat, floatVec, hnsw, ""},
+		{"rhnsw sq", IndexRHNSWSQ, floatVec, with(hnsw, HNSWM, "65"), "M(65)"},
+		{"rhnsw pq", IndexRHNSWPQ, floatVec, rhnswPQ, ""},
+		{"rhnsw pq PQM not divides dim", IndexRHNSWPQ, floatVec, with(rhnswPQ, PQM, "5"), "PQM(5) in index params of index RHNSW_PQ should divide dim(128)"},
+
+		// annoy
+		{"annoy", IndexANNOY, floatVec, annoy, ""},
+		{"annoy n_trees too large", IndexANNOY, floatVec, with(annoy, NTREES, "1025"), "n_trees(1025) in index params of index ANNOY should be in range [1, 1024]"},
+
+		// nsg
+		{"nsg", IndexNSG, floatVec, nsg, ""},
+		{"nsg knng too small", IndexNSG, floatVec, with(nsg, KNNG, "4"), "knng(4)"},
+		{"nsg without candidate_pool_size", IndexNSG, floatVec, with(nsg, CANDIDATE, ""), "candidate_pool_size is required"},
+
+		// ngt
+		{"ngt panng", IndexNGTPANNG, floatVec, panng, ""},
+		{"ngt panng pruned edge size", IndexNGTPANNG, floatVec, with(panng, SelectivelyPrunedEdgeSize, "60"), "selectively_pruned_edge_size(60) in index params of index NGT_PANNG should be less than forcedly_pruned_edge_size(60)"},
+		{"ngt onng", IndexNGTONNG, floatVec, onng, ""},
+		{"ngt onng edge size too large", IndexNGTONNG, floatVec, with(onng, IncomingEdgeSize, "201"), "incoming_edge_size(201)"},
+
+		// binary
+		{"bin flat", IndexFaissBinIDMap, binVec, binFlat, ""},
+		{"bin flat float metric", IndexFaissBinIDMap, binVec, with(binFlat, Metric, L2), "metric_type(L2) is not supported by index BIN_FLAT"},
+		{"bin ivf flat", IndexFaissBinIvfFlat, binVec, binIvf, ""},
+		{"bin ivf flat substructure", IndexFaissBinIvfFlat, binVec, with(binIvf, Metric, SUBSTRUCTURE), "metric_type(SUBSTRUCTURE) is not supported by index BIN_IVF_FLAT"},
+		{"bin ivf flat without nlist", IndexFaissBinIvfFlat, binVec, with(binIvf, NLIST, ""), "nlist is required in index params of index BIN_IVF_FLAT"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := CheckIndexParams(c.indexType, c.dataType, c.params)
+			if c.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), c.errMsg)
+			}
+		})
+	}
+}
+
+func TestIndexRules(t *testing.T) {
+	// the index types searched are the ones built
+	for indexType := range indexRules {
+		_, ok := searchRules[indexType]
+		assert.True(t, ok, indexType)
+	}
+	for indexType := range searchRules {
+		_, ok := indexRules[indexType]
+		assert.True(t, ok, indexType)
+	}
+}
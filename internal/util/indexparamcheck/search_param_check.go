@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexparamcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+const (
+	// IndexTypeKey is the key of index type in index params
+	IndexTypeKey = "index_type"
+
+	// NPROBE is the number of buckets searched in Index IVFxxx
+	NPROBE = "nprobe"
+	// EF is the size of the dynamic candidate list of Index HNSW
+	EF = "ef"
+	// SearchK is the number of nodes inspected in Index ANNOY
+	SearchK = "search_k"
+	// MaxSearchEdges is the number of edges explored in Index NGT
+	MaxSearchEdges = "max_search_edges"
+
+	// MinTopK is the lower limit of topk of search
+	MinTopK = 1
+	// MaxTopK is the upper limit of topk of search
+	MaxTopK = 16384
+
+	HNSWMaxEf         = 32768
+	NgtMaxSearchEdges = 200
+)
+
+// searchParamBound computes a bound of a search param from the index params and the topk of search
+type searchParamBound func(indexParams map[string]string, topK int64) int64
+
+func constBound(v int64) searchParamBound {
+	return func(map[string]string, int64) int64 {
+		return v
+	}
+}
+
+func topKBound(_ map[string]string, topK int64) int64 {
+	return topK
+}
+
+// indexParamBound returns the value of key in index params, defaultValue if it's absent or invalid
+func indexParamBound(key string, defaultValue int64) searchParamBound {
+	return func(indexParams map[string]string, _ int64) int64 {
+		v, err := strconv.ParseInt(indexParams[key], 10, 64)
+		if err != nil {
+			return defaultValue
+		}
+		return v
+	}
+}
+
+// searchParamRange requires the search param key is an integer in [min, max] or one of the special values
+type searchParamRange struct {
+	key      string
+	min      searchParamBound
+	max      searchParamBound
+	special  []int64
+	optional bool
+}
+
+// searchRule is the rule of the search params of an index type
+type searchRule struct {
+	params []searchParamRange
+}
+
+var (
+	nprobeRange = searchParamRange{
+		key: NPROBE,
+		min: constBound(1),
+		max: indexParamBound(NLIST, MaxNList),
+	}
+	efRange = searchParamRange{
+		key: EF,
+		min: topKBound,
+		max: constBound(HNSWMaxEf),
+	}
+	searchKRange = searchParamRange{
+		key:     SearchK,
+		min:     topKBound,
+		max:     constBound(math.MaxInt64),
+		special: []int64{-1},
+	}
+	searchLengthRange = searchParamRange{
+		key: SearchLength,
+		min: constBound(MinSearchLength),
+		max: constBound(MaxSearchLength),
+	}
+	maxSearchEdgesRange = searchParamRange{
+		key:      MaxSearchEdges,
+		min:      constBound(-1),
+		max:      constBound(NgtMaxSearchEdges),
+		optional: true,
+	}
+)
+
+// searchRules are the rules of the search params of every index type
+var searchRules = map[IndexType]searchRule{
+	IndexFaissIDMap:      {},
+	IndexFaissIvfFlat:    {params: []searchParamRange{nprobeRange}},
+	IndexFaissIvfPQ:      {params: []searchParamRange{nprobeRange}},
+	IndexFaissIvfSQ8:     {params: []searchParamRange{nprobeRange}},
+	IndexFaissIvfSQ8H:    {params: []searchParamRange{nprobeRange}},
+	IndexFaissBinIDMap:   {},
+	IndexFaissBinIvfFlat: {params: []searchParamRange{nprobeRange}},
+	IndexNSG:             {params: []searchParamRange{searchLengthRange}},
+	IndexHNSW:            {params: []searchParamRange{efRange}},
+	IndexRHNSWFlat:       {params: []searchParamRange{efRange}},
+	IndexRHNSWPQ:         {params: []searchParamRange{efRange}},
+	IndexRHNSWSQ:         {params: []searchParamRange{efRange}},
+	IndexANNOY:           {params: []searchParamRange{searchKRange}},
+	IndexNGTPANNG:        {params: []searchParamRange{maxSearchEdgesRange}},
+	IndexNGTONNG:         {params: []searchParamRange{maxSearchEdgesRange}},
+}
+
+// parseSearchParamInt parses an integer search param, which is a json number or a numeric string
+func parseSearchParamInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// CheckSearchParams checks the metric type, the json search params and the topk of a search on the index of indexType
+// built with indexParams. The search params not in the rule of the index type are ignored. The metric types are case
+// insensitive as segcore takes them.
+func CheckSearchParams(indexType IndexType, indexParams map[string]string, metricType string, searchParams string, topK int64) error {
+	if topK < MinTopK || topK > MaxTopK {
+		return fmt.Errorf("topk(%d) should be in range [%d, %d]", topK, MinTopK, MaxTopK)
+	}
+
+	rule, ok := searchRules[indexType]
+	if !ok {
+		return fmt.Errorf("unsupported index type %s", indexType)
+	}
+	// the metric types are checked by the rules of building the index, so that they never diverge
+	metrics := indexRules[indexType].metrics
+	if !funcutil.SliceContain(metrics, strings.ToUpper(metricType)) {
+		return fmt.Errorf("metric type %s is not supported by index %s, supported metric types: %v", metricType, indexType, metrics)
+	}
+	if indexMetric, ok := indexParams[Metric]; ok && !strings.EqualFold(indexMetric, metricType) {
+		return fmt.Errorf("metric type %s mismatch with the metric type %s of index", metricType, indexMetric)
+	}
+
+	params := make(map[string]interface{})
+	if searchParams != "" {
+		if err := json.Unmarshal([]byte(searchParams), &params); err != nil {
+			return fmt.Errorf("search params should be a json object, error = %w", err)
+		}
+	}
+	for _, r := range rule.params {
+		value, ok := params[r.key]
+		if !ok {
+			if r.optional {
+				continue
+			}
+			return fmt.Errorf("%s is required in search params of index %s", r.key, indexType)
+		}
+		v, ok := parseSearchParamInt(value)
+		if !ok {
+			return fmt.Errorf("%s(%v) in search params should be an integer", r.key, value)
+		}
+		if funcutil.SliceContain(r.special, v) {
+			continue
+		}
+		min, max := r.min(indexParams, topK), r.max(indexParams, topK)
+		if v < min || v > max {
+			return fmt.Errorf("%s(%d) in search params of index %s should be in range [%d, %d]", r.key, v, indexType, min, max)
+		}
+	}
+	return nil
+}
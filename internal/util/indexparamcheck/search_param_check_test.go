@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package indexparamcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSearchParams(t *testing.T) {
+	ivfParams := map[string]string{IndexTypeKey: IndexFaissIvfFlat, Metric: L2, NLIST: "128"}
+	hnswParams := map[string]string{IndexTypeKey: IndexHNSW, Metric: IP, HNSWM: "16", EFConstruction: "200"}
+	binIvfParams := map[string]string{IndexTypeKey: IndexFaissBinIvfFlat, Metric: JACCARD, NLIST: "64"}
+
+	cases := []struct {
+		name         string
+		indexType    IndexType
+		indexParams  map[string]string
+		metricType   string
+		searchParams string
+		topK         int64
+		errMsg       string
+	}{
+		// topk
+		{"topk too small", IndexFaissIDMap, nil, L2, "", 0, "topk(0)"},
+		{"topk too large", IndexFaissIDMap, nil, L2, "", MaxTopK + 1, "topk(16385)"},
+		{"max topk", IndexFaissIDMap, nil, L2, "", MaxTopK, ""},
+
+		// index type
+		{"unknown index type", "UNKNOWN", nil, L2, "", 10, "unsupported index type UNKNOWN"},
+
+		// metric type
+		{"flat", IndexFaissIDMap, nil, L2, `{"nprobe": 100000}`, 10, ""},
+		{"flat ip", IndexFaissIDMap, nil, IP, "", 10, ""},
+		{"flat unknown metric", IndexFaissIDMap, nil, "COSINE", "", 10, "metric type COSINE is not supported"},
+		{"flat binary metric", IndexFaissIDMap, nil, HAMMING, "", 10, "metric type HAMMING is not supported"},
+		{"metric mismatch with index", IndexFaissIvfFlat, ivfParams, IP, `{"nprobe": 10}`, 10, "mismatch with the metric type L2"},
+
+		// ivf
+		{"ivf", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": 10}`, 10, ""},
+		{"ivf nprobe equals nlist", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": 128}`, 10, ""},
+		{"ivf nprobe as string", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": "16"}`, 10, ""},
+		{"ivf nprobe larger than nlist", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": 129}`, 10, "nprobe(129) in search params of index IVF_FLAT should be in range [1, 128]"},
+		{"ivf nprobe zero", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": 0}`, 10, "nprobe(0)"},
+		{"ivf no nprobe", IndexFaissIvfFlat, ivfParams, L2, `{"ef": 10}`, 10, "nprobe is required"},
+		{"ivf empty search params", IndexFaissIvfFlat, ivfParams, L2, "", 10, "nprobe is required"},
+		{"ivf float nprobe", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": 1.5}`, 10, "should be an integer"},
+		{"ivf invalid nprobe", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": "abc"}`, 10, "should be an integer"},
+		{"ivf invalid json", IndexFaissIvfFlat, ivfParams, L2, `{"nprobe": `, 10, "should be a json object"},
+		{"ivf without nlist", IndexFaissIvfPQ, map[string]string{}, L2, `{"nprobe": 65536}`, 10, ""},
+		{"ivf without nlist too large", IndexFaissIvfSQ8, map[string]string{}, L2, `{"nprobe": 65537}`, 10, "should be in range [1, 65536]"},
+		{"ivf sq8h", IndexFaissIvfSQ8H, ivfParams, L2, `{"nprobe": 1}`, 10, ""},
+
+		// binary
+		{"bin flat", IndexFaissBinIDMap, nil, SUBSTRUCTURE, "", 10, ""},
+		{"bin flat float metric", IndexFaissBinIDMap, nil, L2, "", 10, "metric type L2 is not supported"},
+		{"bin ivf", IndexFaissBinIvfFlat, binIvfParams, JACCARD, `{"nprobe": 64}`, 10, ""},
+		{"bin ivf nprobe larger than nlist", IndexFaissBinIvfFlat, binIvfParams, JACCARD, `{"nprobe": 65}`, 10, "nprobe(65)"},
+		{"bin ivf unsupported metric", IndexFaissBinIvfFlat, binIvfParams, SUBSTRUCTURE, `{"nprobe": 8}`, 10, "metric type SUBSTRUCTURE is not supported"},
+		{"bin flat hamming", IndexFaissBinIDMap, nil, HAMMING, "", 10, ""},
+		{"bin flat jaccard", IndexFaissBinIDMap, nil, JACCARD, "", 10, ""},
+		{"bin flat tanimoto", IndexFaissBinIDMap, nil, TANIMOTO, "", 10, ""},
+		{"bin flat superstructure", IndexFaissBinIDMap, nil, SUPERSTRUCTURE, "", 10, ""},
+		{"bin flat lower case metric", IndexFaissBinIDMap, nil, "tanimoto", "", 10, ""},
+		{"bin ivf hamming", IndexFaissBinIvfFlat, map[string]string{Metric: HAMMING}, HAMMING, `{"nprobe": 8}`, 10, ""},
+		{"bin ivf tanimoto", IndexFaissBinIvfFlat, map[string]string{Metric: TANIMOTO}, TANIMOTO, `{"nprobe": 8}`, 10, ""},
+		{"bin ivf lower case metric", IndexFaissBinIvfFlat, binIvfParams, "jaccard", `{"nprobe": 8}`, 10, ""},
+		{"bin ivf unsupported superstructure", IndexFaissBinIvfFlat, nil, SUPERSTRUCTURE, `{"nprobe": 8}`, 10, "metric type SUPERSTRUCTURE is not supported"},
+		{"bin ivf metric mismatch with index", IndexFaissBinIvfFlat, binIvfParams, "hamming", `{"nprobe": 8}`, 10, "mismatch with the metric type JACCARD"},
+
+		// hnsw
+		{"hnsw", IndexHNSW, hnswParams, IP, `{"ef": 64}`, 10, ""},
+		{"hnsw ef equals topk", IndexHNSW, hnswParams, IP, `{"ef": 10}`, 10, ""},
+		{"hnsw ef less than topk", IndexHNSW, hnswParams, IP, `{"ef": 9}`, 10, "ef(9) in search params of index HNSW should be in range [10, 32768]"},
+		{"hnsw ef too large", IndexHNSW, hnswParams, IP, `{"ef": 32769}`, 10, "ef(32769)"},
+		{"hnsw no ef", IndexHNSW, hnswParams, IP, `{"nprobe": 10}`, 10, "ef is required"},
+		{"rhnsw flat", IndexRHNSWFlat, nil, L2, `{"ef": 100}`, 100, ""},
+		{"rhnsw pq", IndexRHNSWPQ, nil, L2, `{"ef": 99}`, 100, "ef(99)"},
+		{"rhnsw sq", IndexRHNSWSQ, nil, L2, `{"ef": 32768}`, 100, ""},
+
+		// annoy
+		{"annoy", IndexANNOY, nil, L2, `{"search_k": 100}`, 10, ""},
+		{"annoy default search_k", IndexANNOY, nil, L2, `{"search_k": -1}`, 10, ""},
+		{"annoy search_k less than topk", IndexANNOY, nil, L2, `{"search_k": 5}`, 10, "search_k(5)"},
+		{"annoy no search_k", IndexANNOY, nil, L2, "", 10, "search_k is required"},
+
+		// nsg
+		{"nsg", IndexNSG, nil, L2, `{"search_length": 100}`, 10, ""},
+		{"nsg search_length too small", IndexNSG, nil, L2, `{"search_length": 9}`, 10, "search_length(9)"},
+		{"nsg search_length too large", IndexNSG, nil, L2, `{"search_length": 301}`, 10, "search_length(301)"},
+
+		// ngt
+		{"ngt panng", IndexNGTPANNG, nil, L2, "", 10, ""},
+		{"ngt onng", IndexNGTONNG, nil, IP, `{"max_search_edges": -1}`, 10, ""},
+		{"ngt max_search_edges too large", IndexNGTONNG, nil, IP, `{"max_search_edges": 201}`, 10, "max_search_edges(201)"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := CheckSearchParams(c.indexType, c.indexParams, c.metricType, c.searchParams, c.topK)
+			if c.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), c.errMsg)
+		})
+	}
+}
+
+func TestSearchRules(t *testing.T) {
+	// every index type which can be built has a search rule
+	mgr := newConfAdapterMgrImpl()
+	mgr.registerConfAdapter()
+	for indexType := range mgr.adapters {
+		_, ok := searchRules[indexType]
+		assert.True(t, ok, indexType)
+	}
+}
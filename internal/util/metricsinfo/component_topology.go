@@ -0,0 +1,326 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package metricsinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/uniquegenerator"
+)
+
+// TopologyVersion is the version of ComponentTopology and SystemTopology, increase it on incompatible changes.
+// The responses without version are the component specific ones before, which are still understood.
+const TopologyVersion = 1
+
+// ConfigSnapshot is the snapshot of the configurations of a component, keyed by the config name
+type ConfigSnapshot map[string]interface{}
+
+// NewConfigSnapshot returns the snapshot of a configuration struct, keyed by the json names of its fields
+func NewConfigSnapshot(configs interface{}) ConfigSnapshot {
+	snapshot := ConfigSnapshot{}
+	if configs == nil {
+		return snapshot
+	}
+	binary, err := json.Marshal(configs)
+	if err == nil {
+		_ = json.Unmarshal(binary, &snapshot)
+	}
+	return snapshot
+}
+
+// ComponentInfo is the information of a component, all kinds of components share the same shape
+type ComponentInfo struct {
+	BaseComponentInfos
+	SystemConfigurations ConfigSnapshot `json:"system_configurations"`
+	// Tasks are the tasks queued or executing, only filled by query coordinator for now
+	Tasks []TaskInfo `json:"tasks,omitempty"`
+	// NodeTasks are the child tasks of every query node, only filled by query coordinator
+	NodeTasks []QueryNodeTaskInfos `json:"node_tasks,omitempty"`
+	// BuildDirs are the local directories of the index building tasks, only filled by index node
+	BuildDirs *IndexBuildDirInfos `json:"build_dirs,omitempty"`
+}
+
+// NewComponentInfo returns the information of the component running in this process
+func NewComponentInfo(role string, id int64, address string, createdTime, updatedTime time.Time, configs interface{}) ComponentInfo {
+	return ComponentInfo{
+		BaseComponentInfos: BaseComponentInfos{
+			Name: ConstructComponentName(role, id),
+			HardwareInfos: HardwareMetrics{
+				IP:           address,
+				CPUCoreCount: GetCPUCoreCount(false),
+				CPUCoreUsage: GetCPUUsage(),
+				Memory:       GetMemoryCount(),
+				MemoryUsage:  GetUsedMemoryCount(),
+				Disk:         GetDiskCount(),
+				DiskUsage:    GetDiskUsage(),
+			},
+			SystemInfo: DeployMetrics{
+				SystemVersion: os.Getenv(GitCommitEnvKey),
+				DeployMode:    os.Getenv(DeployModeEnvKey),
+			},
+			CreatedTime: createdTime.String(),
+			UpdatedTime: updatedTime.String(),
+			Type:        role,
+			ID:          id,
+		},
+		SystemConfigurations: NewConfigSnapshot(configs),
+	}
+}
+
+// ComponentTopology is the response of SystemInfoMetrics request of every component,
+// the coordinators fill ConnectedNodes with their nodes, which is always empty for the nodes and proxy.
+type ComponentTopology struct {
+	Version        int             `json:"version"`
+	Self           ComponentInfo   `json:"self"`
+	ConnectedNodes []ComponentInfo `json:"connected_nodes"`
+	Connections    ConnTopology    `json:"connections"`
+}
+
+// NewComponentTopology returns the topology of the component self, connected to nothing yet
+func NewComponentTopology(self ComponentInfo, connectedRoles ...string) *ComponentTopology {
+	topology := &ComponentTopology{
+		Version:        TopologyVersion,
+		Self:           self,
+		ConnectedNodes: make([]ComponentInfo, 0),
+		Connections: ConnTopology{
+			Name:                self.Name,
+			ConnectedComponents: make([]ConnectionInfo, 0, len(connectedRoles)),
+		},
+	}
+	for _, role := range connectedRoles {
+		// the id of the connected coordinator is unknown, it's resolved by role in the system topology
+		topology.Connections.ConnectedComponents = append(topology.Connections.ConnectedComponents, ConnectionInfo{
+			TargetType: role,
+		})
+	}
+	return topology
+}
+
+// AddNode adds the node in the SystemInfoMetrics response of a node, the node is added with error if the request failed
+func (t *ComponentTopology) AddNode(resp *milvuspb.GetMetricsResponse, err error) error {
+	node, err := ParseComponentTopology(resp, err)
+	t.ConnectedNodes = append(t.ConnectedNodes, node.Self)
+	return err
+}
+
+// ParseComponentTopology returns the topology in the SystemInfoMetrics response of another component,
+// if the request failed, the error is returned together with a topology of the component with error.
+func ParseComponentTopology(resp *milvuspb.GetMetricsResponse, err error) (*ComponentTopology, error) {
+	failed := func(err error) (*ComponentTopology, error) {
+		return NewComponentTopology(ComponentInfo{
+			BaseComponentInfos: BaseComponentInfos{
+				HasError:    true,
+				ErrorReason: err.Error(),
+				Name:        resp.GetComponentName(),
+				// the id is unknown, but should be unique in the topology graph
+				ID: int64(uniquegenerator.GetUniqueIntGeneratorIns().GetInt()),
+			},
+			SystemConfigurations: ConfigSnapshot{},
+		}), err
+	}
+	if err != nil {
+		return failed(err)
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return failed(errors.New(resp.GetStatus().GetReason()))
+	}
+	topology, err := UnmarshalComponentTopology(resp.GetResponse())
+	if err != nil {
+		return failed(err)
+	}
+	return topology, nil
+}
+
+// MarshalComponentTopology returns the json string of the topology in the latest version
+func MarshalComponentTopology(topology *ComponentTopology) (string, error) {
+	topology.Version = TopologyVersion
+	binary, err := json.Marshal(topology)
+	return string(binary), err
+}
+
+// legacyComponentTopology covers the responses without version: the coordinators of nodes wrapped their topology
+// in "cluster", the root coordinator reported its "self" only and the nodes reported their infos directly.
+type legacyComponentTopology struct {
+	Cluster *struct {
+		Self           ComponentInfo   `json:"self"`
+		ConnectedNodes []ComponentInfo `json:"connected_nodes"`
+	} `json:"cluster"`
+	Self        *ComponentInfo `json:"self"`
+	Connections ConnTopology   `json:"connections"`
+}
+
+// UnmarshalComponentTopology constructs a ComponentTopology from the json string of any version
+func UnmarshalComponentTopology(s string) (*ComponentTopology, error) {
+	var versioned struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(s), &versioned); err != nil {
+		return nil, err
+	}
+	if versioned.Version > TopologyVersion {
+		return nil, fmt.Errorf("unsupported topology version %d, the latest known is %d", versioned.Version, TopologyVersion)
+	}
+	if versioned.Version > 0 {
+		topology := &ComponentTopology{}
+		if err := json.Unmarshal([]byte(s), topology); err != nil {
+			return nil, err
+		}
+		return topology, nil
+	}
+
+	var legacy legacyComponentTopology
+	if err := json.Unmarshal([]byte(s), &legacy); err != nil {
+		return nil, err
+	}
+	var topology *ComponentTopology
+	switch {
+	case legacy.Cluster != nil:
+		topology = NewComponentTopology(legacy.Cluster.Self)
+		topology.ConnectedNodes = append(topology.ConnectedNodes, legacy.Cluster.ConnectedNodes...)
+	case legacy.Self != nil:
+		topology = NewComponentTopology(*legacy.Self)
+	default:
+		var node ComponentInfo
+		if err := json.Unmarshal([]byte(s), &node); err != nil {
+			return nil, err
+		}
+		return NewComponentTopology(node), nil
+	}
+	topology.Connections.ConnectedComponents = append(topology.Connections.ConnectedComponents, legacy.Connections.ConnectedComponents...)
+	return topology, nil
+}
+
+// ComponentTopologyResponse returns the topology as the response of a SystemInfoMetrics request
+func ComponentTopologyResponse(topology *ComponentTopology) *milvuspb.GetMetricsResponse {
+	resp, err := MarshalComponentTopology(topology)
+	if err != nil {
+		return &milvuspb.GetMetricsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    err.Error(),
+			},
+			Response:      "",
+			ComponentName: topology.Self.Name,
+		}
+	}
+	return &milvuspb.GetMetricsResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_Success,
+			Reason:    "",
+		},
+		Response:      resp,
+		ComponentName: topology.Self.Name,
+	}
+}
+
+// NewSystemTopology builds the topology graph of the system from the topology of proxy and the coordinators,
+// proxy forwards requests to every coordinator, the coordinators connect to each other and manage their nodes.
+func NewSystemTopology(proxy *ComponentTopology, coords []*ComponentTopology) *SystemTopology {
+	systemTopology := &SystemTopology{
+		Version:   TopologyVersion,
+		NodesInfo: make([]SystemTopologyNode, 0),
+	}
+	identifiers := make(map[string]int, len(coords))
+	for _, coord := range coords {
+		identifiers[coord.Self.Type] = int(coord.Self.ID)
+	}
+
+	proxyNode := SystemTopologyNode{
+		Identifier: int(proxy.Self.ID),
+		Connected:  make([]ConnectionEdge, 0, len(coords)),
+		Infos:      &proxy.Self,
+	}
+	for _, coord := range coords {
+		proxyNode.Connected = append(proxyNode.Connected, ConnectionEdge{
+			ConnectedIdentifier: int(coord.Self.ID),
+			Type:                Forward,
+			TargetType:          coord.Self.Type,
+		})
+
+		coordNode := SystemTopologyNode{
+			Identifier: int(coord.Self.ID),
+			Connected:  make([]ConnectionEdge, 0),
+			Infos:      &coord.Self,
+		}
+		for _, conn := range coord.Connections.ConnectedComponents {
+			if identifier, ok := identifiers[conn.TargetType]; ok {
+				coordNode.Connected = append(coordNode.Connected, ConnectionEdge{
+					ConnectedIdentifier: identifier,
+					Type:                Forward,
+					TargetType:          conn.TargetType,
+				})
+			}
+		}
+		for i := range coord.ConnectedNodes {
+			node := &coord.ConnectedNodes[i]
+			systemTopology.NodesInfo = append(systemTopology.NodesInfo, SystemTopologyNode{
+				Identifier: int(node.ID),
+				Connected:  nil,
+				Infos:      node,
+			})
+			coordNode.Connected = append(coordNode.Connected, ConnectionEdge{
+				ConnectedIdentifier: int(node.ID),
+				Type:                CoordConnectToNode,
+				TargetType:          node.Type,
+			})
+		}
+		systemTopology.NodesInfo = append(systemTopology.NodesInfo, coordNode)
+	}
+	systemTopology.NodesInfo = append(systemTopology.NodesInfo, proxyNode)
+	return systemTopology
+}
+
+// MarshalSystemTopology returns the json string of the system topology in the latest version
+func MarshalSystemTopology(topology *SystemTopology) (string, error) {
+	topology.Version = TopologyVersion
+	binary, err := json.Marshal(topology)
+	return string(binary), err
+}
+
+// UnmarshalSystemTopology constructs a SystemTopology from the json string, with the infos of nodes as ComponentInfo
+func UnmarshalSystemTopology(s string) (*SystemTopology, error) {
+	var decoded struct {
+		Version   int `json:"version"`
+		NodesInfo []struct {
+			Identifier int              `json:"identifier"`
+			Connected  []ConnectionEdge `json:"connected"`
+			Infos      *ComponentInfo   `json:"infos"`
+		} `json:"nodes_info"`
+	}
+	if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Version > TopologyVersion {
+		return nil, fmt.Errorf("unsupported topology version %d, the latest known is %d", decoded.Version, TopologyVersion)
+	}
+	topology := &SystemTopology{
+		Version:   decoded.Version,
+		NodesInfo: make([]SystemTopologyNode, 0, len(decoded.NodesInfo)),
+	}
+	for _, node := range decoded.NodesInfo {
+		topologyNode := SystemTopologyNode{
+			Identifier: node.Identifier,
+			Connected:  node.Connected,
+		}
+		if node.Infos != nil {
+			topologyNode.Infos = node.Infos
+		}
+		topology.NodesInfo = append(topology.NodesInfo, topologyNode)
+	}
+	return topology, nil
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package metricsinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// testComponentInfo returns the information of a component with fixed hardware, for the golden files
+func testComponentInfo(role string, id int64, configs interface{}) ComponentInfo {
+	return ComponentInfo{
+		BaseComponentInfos: BaseComponentInfos{
+			Name: ConstructComponentName(role, id),
+			HardwareInfos: HardwareMetrics{
+				IP:           "192.168.1." + ConstructComponentName("", id),
+				CPUCoreCount: 8,
+				CPUCoreUsage: 0.5,
+				Memory:       16 * 1024 * 1024 * 1024,
+				MemoryUsage:  4 * 1024 * 1024 * 1024,
+				Disk:         100 * 1024 * 1024 * 1024,
+				DiskUsage:    10 * 1024 * 1024 * 1024,
+			},
+			SystemInfo: DeployMetrics{
+				SystemVersion: "8b1ae98fa97ce1c7ba853e8b9ff1c7ce24458dc1",
+				DeployMode:    ClusterDeployMode,
+			},
+			CreatedTime: "2021-10-01 00:00:00 +0000 UTC",
+			UpdatedTime: "2021-10-01 00:00:00 +0000 UTC",
+			Type:        role,
+			ID:          id,
+		},
+		SystemConfigurations: NewConfigSnapshot(configs),
+	}
+}
+
+func topologyResponse(t *testing.T, topology interface{}) (*milvuspb.GetMetricsResponse, error) {
+	resp, err := MarshalTopology(topology)
+	require.NoError(t, err)
+	return &milvuspb.GetMetricsResponse{
+		Status:   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Response: resp,
+	}, nil
+}
+
+func TestNewComponentInfo(t *testing.T) {
+	created := time.Now()
+	info := NewComponentInfo(typeutil.DataNodeRole, 3, "127.0.0.1:21124", created, created,
+		DataNodeConfiguration{FlushInsertBufferSize: 16})
+	assert.Equal(t, ConstructComponentName(typeutil.DataNodeRole, 3), info.Name)
+	assert.Equal(t, typeutil.DataNodeRole, info.Type)
+	assert.Equal(t, int64(3), info.ID)
+	assert.Equal(t, "127.0.0.1:21124", info.HardwareInfos.IP)
+	assert.Equal(t, created.String(), info.CreatedTime)
+	assert.Equal(t, ConfigSnapshot{"flush_insert_buffer_size": float64(16)}, info.SystemConfigurations)
+
+	assert.Equal(t, ConfigSnapshot{}, NewConfigSnapshot(nil))
+}
+
+func TestComponentTopology_Codec(t *testing.T) {
+	topology := NewComponentTopology(testComponentInfo(typeutil.QueryCoordRole, 1, QueryCoordConfiguration{}),
+		typeutil.RootCoordRole, typeutil.DataCoordRole)
+	node := NewComponentTopology(testComponentInfo(typeutil.QueryNodeRole, 2, QueryNodeConfiguration{SimdType: "auto"}))
+	assert.NoError(t, topology.AddNode(ComponentTopologyResponse(node), nil))
+	assert.Equal(t, []ComponentInfo{node.Self}, topology.ConnectedNodes)
+
+	resp := ComponentTopologyResponse(topology)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	assert.Equal(t, topology.Self.Name, resp.ComponentName)
+	decoded, err := UnmarshalComponentTopology(resp.Response)
+	assert.NoError(t, err)
+	assert.Equal(t, topology, decoded)
+	assert.Equal(t, TopologyVersion, decoded.Version)
+	assert.Equal(t, []ConnectionInfo{{TargetType: typeutil.RootCoordRole}, {TargetType: typeutil.DataCoordRole}},
+		decoded.Connections.ConnectedComponents)
+
+	_, err = UnmarshalComponentTopology(`{"version": 100}`)
+	assert.Error(t, err)
+	_, err = UnmarshalComponentTopology(`{"version": "1"}`)
+	assert.Error(t, err)
+	_, err = UnmarshalComponentTopology(`{"version": 1, "self": []}`)
+	assert.Error(t, err)
+}
+
+func TestUnmarshalComponentTopology_Legacy(t *testing.T) {
+	queryNode := QueryNodeInfos{
+		BaseComponentInfos: BaseComponentInfos{
+			Name: ConstructComponentName(typeutil.QueryNodeRole, 2),
+			Type: typeutil.QueryNodeRole,
+			ID:   2,
+		},
+		SystemConfigurations: QueryNodeConfiguration{SimdType: "auto"},
+	}
+	queryCoord := QueryCoordTopology{
+		Cluster: QueryClusterTopology{
+			Self: QueryCoordInfos{
+				BaseComponentInfos: BaseComponentInfos{
+					Name: ConstructComponentName(typeutil.QueryCoordRole, 1),
+					Type: typeutil.QueryCoordRole,
+					ID:   1,
+				},
+			},
+			ConnectedNodes: []QueryNodeInfos{queryNode},
+		},
+		Connections: ConnTopology{
+			Name: ConstructComponentName(typeutil.QueryCoordRole, 1),
+			ConnectedComponents: []ConnectionInfo{{
+				TargetName: ConstructComponentName(typeutil.RootCoordRole, 3),
+				TargetType: typeutil.RootCoordRole,
+			}},
+		},
+	}
+
+	topology, err := ParseComponentTopology(topologyResponse(t, queryCoord))
+	assert.NoError(t, err)
+	assert.Equal(t, TopologyVersion, topology.Version)
+	assert.Equal(t, queryCoord.Cluster.Self.BaseComponentInfos, topology.Self.BaseComponentInfos)
+	assert.Equal(t, ConfigSnapshot{"search_channel_prefix": "", "search_result_channel_prefix": ""}, topology.Self.SystemConfigurations)
+	assert.Equal(t, 1, len(topology.ConnectedNodes))
+	assert.Equal(t, queryNode.BaseComponentInfos, topology.ConnectedNodes[0].BaseComponentInfos)
+	assert.Equal(t, "auto", topology.ConnectedNodes[0].SystemConfigurations["simd_type"])
+	assert.Equal(t, queryCoord.Connections, topology.Connections)
+
+	rootCoord := RootCoordTopology{
+		Self: RootCoordInfos{
+			BaseComponentInfos: BaseComponentInfos{
+				Name: ConstructComponentName(typeutil.RootCoordRole, 3),
+				Type: typeutil.RootCoordRole,
+				ID:   3,
+			},
+		},
+		Connections: ConnTopology{Name: ConstructComponentName(typeutil.RootCoordRole, 3)},
+	}
+	topology, err = ParseComponentTopology(topologyResponse(t, rootCoord))
+	assert.NoError(t, err)
+	assert.Equal(t, rootCoord.Self.BaseComponentInfos, topology.Self.BaseComponentInfos)
+	assert.Empty(t, topology.ConnectedNodes)
+	assert.Empty(t, topology.Connections.ConnectedComponents)
+
+	topology, err = ParseComponentTopology(topologyResponse(t, queryNode))
+	assert.NoError(t, err)
+	assert.Equal(t, queryNode.BaseComponentInfos, topology.Self.BaseComponentInfos)
+	assert.Equal(t, queryNode.Name, topology.Connections.Name)
+}
+
+func TestParseComponentTopology_Failed(t *testing.T) {
+	topology, err := ParseComponentTopology(nil, errors.New("mocked"))
+	assert.Error(t, err)
+	assert.True(t, topology.Self.HasError)
+	assert.Equal(t, "mocked", topology.Self.ErrorReason)
+
+	name := ConstructComponentName(typeutil.IndexNodeRole, 1)
+	topology, err = ParseComponentTopology(&milvuspb.GetMetricsResponse{
+		Status:        &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "mocked error"},
+		ComponentName: name,
+	}, nil)
+	assert.Error(t, err)
+	assert.True(t, topology.Self.HasError)
+	assert.Equal(t, "mocked error", topology.Self.ErrorReason)
+	assert.Equal(t, name, topology.Self.Name)
+
+	coord := NewComponentTopology(testComponentInfo(typeutil.IndexCoordRole, 2, nil))
+	assert.Error(t, coord.AddNode(&milvuspb.GetMetricsResponse{
+		Status:        &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Response:      `{"error_reason": 1}`,
+		ComponentName: name,
+	}, nil))
+	assert.Equal(t, 1, len(coord.ConnectedNodes))
+	assert.True(t, coord.ConnectedNodes[0].HasError)
+	assert.Equal(t, name, coord.ConnectedNodes[0].Name)
+	// the failed nodes have different identifiers in the topology graph
+	assert.Error(t, coord.AddNode(nil, errors.New("mocked")))
+	assert.NotEqual(t, coord.ConnectedNodes[0].ID, coord.ConnectedNodes[1].ID)
+}
+
+func TestSystemTopology_Golden(t *testing.T) {
+	proxy := NewComponentTopology(testComponentInfo(typeutil.ProxyRole, 1, ProxyConfiguration{
+		DefaultPartitionName: "_default",
+		DefaultIndexName:     "_default_idx",
+	}))
+
+	queryCoord := NewComponentTopology(testComponentInfo(typeutil.QueryCoordRole, 2, QueryCoordConfiguration{
+		SearchChannelPrefix:       "search",
+		SearchResultChannelPrefix: "searchResult",
+	}), typeutil.RootCoordRole, typeutil.DataCoordRole)
+	queryCoord.AddNode(ComponentTopologyResponse(NewComponentTopology(testComponentInfo(typeutil.QueryNodeRole, 11,
+		QueryNodeConfiguration{SearchReceiveBufSize: 512, SimdType: "auto"}))), nil)
+	queryCoord.AddNode(ComponentTopologyResponse(NewComponentTopology(testComponentInfo(typeutil.QueryNodeRole, 12,
+		QueryNodeConfiguration{SearchReceiveBufSize: 512, SimdType: "avx2"}))), nil)
+
+	dataCoord := NewComponentTopology(testComponentInfo(typeutil.DataCoordRole, 3, DataCoordConfiguration{
+		SegmentMaxSize: 512,
+	}), typeutil.RootCoordRole)
+	dataCoord.AddNode(ComponentTopologyResponse(NewComponentTopology(testComponentInfo(typeutil.DataNodeRole, 13,
+		DataNodeConfiguration{FlushInsertBufferSize: 16777216}))), nil)
+
+	// index coordinator of an older version
+	legacyIndexCoord := IndexCoordTopology{
+		Cluster: IndexClusterTopology{
+			Self: IndexCoordInfos{
+				BaseComponentInfos:   testComponentInfo(typeutil.IndexCoordRole, 4, nil).BaseComponentInfos,
+				SystemConfigurations: IndexCoordConfiguration{MinioBucketName: "a-bucket"},
+			},
+			ConnectedNodes: []IndexNodeInfos{{
+				BaseComponentInfos:   testComponentInfo(typeutil.IndexNodeRole, 14, nil).BaseComponentInfos,
+				SystemConfigurations: IndexNodeConfiguration{MinioBucketName: "a-bucket", SimdType: "auto"},
+			}},
+		},
+		Connections: ConnTopology{Name: ConstructComponentName(typeutil.IndexCoordRole, 4)},
+	}
+	indexCoord, err := ParseComponentTopology(topologyResponse(t, legacyIndexCoord))
+	require.NoError(t, err)
+
+	rootCoord := NewComponentTopology(testComponentInfo(typeutil.RootCoordRole, 5, RootCoordConfiguration{
+		MinSegmentSizeToEnableIndex: 1024,
+	}), typeutil.DataCoordRole, typeutil.IndexCoordRole, typeutil.QueryCoordRole)
+
+	systemTopology := NewSystemTopology(proxy, []*ComponentTopology{queryCoord, dataCoord, indexCoord, rootCoord})
+	s, err := MarshalSystemTopology(systemTopology)
+	require.NoError(t, err)
+
+	// the golden file is indented for review
+	decoded, err := UnmarshalSystemTopology(s)
+	require.NoError(t, err)
+	actual, err := json.MarshalIndent(decoded, "", "  ")
+	require.NoError(t, err)
+	actual = append(actual, '\n')
+
+	golden := filepath.Join("testdata", "system_topology.golden")
+	if *updateGolden {
+		require.NoError(t, ioutil.WriteFile(golden, actual, 0644))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+
+	_, err = UnmarshalSystemTopology(`{"version": 100}`)
+	assert.Error(t, err)
+	_, err = UnmarshalSystemTopology(`{"nodes_info": {}}`)
+	assert.Error(t, err)
+}
+
+func TestSystemTopology_QueryCoordNodeTasks(t *testing.T) {
+	proxy := NewComponentTopology(testComponentInfo(typeutil.ProxyRole, 1, ProxyConfiguration{}))
+	queryCoord := NewComponentTopology(testComponentInfo(typeutil.QueryCoordRole, 2, QueryCoordConfiguration{}))
+	queryCoord.Self.NodeTasks = []QueryNodeTaskInfos{{
+		NodeID:          11,
+		LoadingSegments: []LoadingSegmentInfo{{TaskID: 100, SegmentID: 1000, Phase: "executing", ElapsedMs: 10}},
+		DispatchQueue:   []TaskInfo{{TaskID: 101, Phase: "enqueued", NodeIDs: []int64{11}}},
+		FailedTasks:     []FailedTaskInfo{{TaskID: 99, NodeID: 11, SegmentIDs: []int64{999}, Reason: "mocked"}},
+	}}
+	resp := ComponentTopologyResponse(queryCoord)
+	assert.Contains(t, resp.Response, `"node_tasks"`)
+	parsed, err := ParseComponentTopology(resp, nil)
+	require.NoError(t, err)
+
+	s, err := MarshalSystemTopology(NewSystemTopology(proxy, []*ComponentTopology{parsed}))
+	require.NoError(t, err)
+	for _, field := range []string{`"loading_segments"`, `"dispatch_queue"`, `"failed_tasks"`, `"reason":"mocked"`} {
+		assert.Contains(t, s, field)
+	}
+	decoded, err := UnmarshalSystemTopology(s)
+	require.NoError(t, err)
+	var found bool
+	for _, node := range decoded.NodesInfo {
+		infos, ok := node.Infos.(*ComponentInfo)
+		require.True(t, ok)
+		if infos.Type == typeutil.QueryCoordRole {
+			found = true
+			assert.Equal(t, queryCoord.Self.NodeTasks, infos.NodeTasks)
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package metricsinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+)
+
+// ComponentLogLevels shows the log levels of a component after a SetLogLevelMetrics request
+type ComponentLogLevels struct {
+	Name        string            `json:"name"`
+	HasError    bool              `json:"has_error"`
+	ErrorReason string            `json:"error_reason"`
+	Level       string            `json:"level"`
+	Loggers     map[string]string `json:"loggers,omitempty"`
+}
+
+// ConstructSetLogLevelRequest constructs a request to set the level of logger, the global level if logger is empty
+func ConstructSetLogLevelRequest(logger, level string) (*milvuspb.GetMetricsRequest, error) {
+	m := make(map[string]interface{})
+	m[MetricTypeKey] = SetLogLevelMetrics
+	m[LogLevelKey] = level
+	if logger != "" {
+		m[LoggerNameKey] = logger
+	}
+	binary, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct request to set log level: %s", err.Error())
+	}
+	return &milvuspb.GetMetricsRequest{
+		Base:    nil,
+		Request: string(binary),
+	}, nil
+}
+
+// ParseLogLevel returns the logger name and the level to set in req
+func ParseLogLevel(req string) (string, string, error) {
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(req), &m)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode the request: %s", err.Error())
+	}
+	level, ok := m[LogLevelKey].(string)
+	if !ok {
+		return "", "", fmt.Errorf("%s not found in request", LogLevelKey)
+	}
+	logger, ok := m[LoggerNameKey].(string)
+	if _, exist := m[LoggerNameKey]; exist && !ok {
+		return "", "", fmt.Errorf("%s should be a string", LoggerNameKey)
+	}
+	if level == "" {
+		if logger == "" {
+			return "", "", errors.New("the global log level can't be empty")
+		}
+		return logger, level, nil
+	}
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return "", "", fmt.Errorf("invalid log level %s: %w", level, err)
+	}
+	return logger, level, nil
+}
+
+// SetLogLevel applies the log level in a GetMetrics request of SetLogLevelMetrics, the current levels are returned
+func SetLogLevel(req *milvuspb.GetMetricsRequest, componentName string) ComponentLogLevels {
+	logger, level, err := ParseLogLevel(req.Request)
+	if err != nil {
+		return ComponentLogLevels{
+			Name:        componentName,
+			HasError:    true,
+			ErrorReason: err.Error(),
+		}
+	}
+	if level == "" {
+		log.ResetNamedLevel(logger)
+	} else {
+		var l zapcore.Level
+		_ = l.UnmarshalText([]byte(level))
+		log.SetNamedLevel(logger, l)
+	}
+	return ComponentLogLevels{
+		Name:    componentName,
+		Level:   log.GetLevel().String(),
+		Loggers: log.GetNamedLevels(),
+	}
+}
+
+// CollectLogLevels returns the log levels in the response of a SetLogLevelMetrics request sent to another component,
+// the failure of the request is returned as a component with error.
+func CollectLogLevels(resp *milvuspb.GetMetricsResponse, err error) []ComponentLogLevels {
+	failed := func(reason string) []ComponentLogLevels {
+		return []ComponentLogLevels{{
+			Name:        resp.GetComponentName(),
+			HasError:    true,
+			ErrorReason: reason,
+		}}
+	}
+	if err != nil {
+		return failed(err.Error())
+	}
+	var levels []ComponentLogLevels
+	if err := json.Unmarshal([]byte(resp.GetResponse()), &levels); err != nil {
+		if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			return failed(resp.GetStatus().GetReason())
+		}
+		return failed(err.Error())
+	}
+	return levels
+}
+
+// MarshalLogLevels returns the log levels of components in json, and an error listing the components failed to set
+func MarshalLogLevels(levels []ComponentLogLevels) (string, error) {
+	binary, err := json.Marshal(levels)
+	if err != nil {
+		return "", err
+	}
+	var failed []string
+	for _, l := range levels {
+		if l.HasError {
+			failed = append(failed, fmt.Sprintf("%s: %s", l.Name, l.ErrorReason))
+		}
+	}
+	if len(failed) > 0 {
+		return string(binary), fmt.Errorf("failed to set log level of %s", strings.Join(failed, "; "))
+	}
+	return string(binary), nil
+}
+
+// LogLevelsResponse returns the log levels of components as the response of a SetLogLevelMetrics request,
+// the levels are always returned even if some of the components failed.
+func LogLevelsResponse(levels []ComponentLogLevels, componentName string) *milvuspb.GetMetricsResponse {
+	resp, err := MarshalLogLevels(levels)
+	status := &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}
+	if err != nil {
+		status = &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    err.Error(),
+		}
+	}
+	return &milvuspb.GetMetricsResponse{
+		Status:        status,
+		Response:      resp,
+		ComponentName: componentName,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package metricsinfo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+)
+
+func Test_ParseLogLevel(t *testing.T) {
+	req, err := ConstructSetLogLevelRequest("querycoord.scheduler", "debug")
+	assert.Nil(t, err)
+	metricType, err := ParseMetricType(req.Request)
+	assert.Nil(t, err)
+	assert.Equal(t, SetLogLevelMetrics, metricType)
+	logger, level, err := ParseLogLevel(req.Request)
+	assert.Nil(t, err)
+	assert.Equal(t, "querycoord.scheduler", logger)
+	assert.Equal(t, "debug", level)
+
+	req, err = ConstructSetLogLevelRequest("", "warn")
+	assert.Nil(t, err)
+	logger, level, err = ParseLogLevel(req.Request)
+	assert.Nil(t, err)
+	assert.Equal(t, "", logger)
+	assert.Equal(t, "warn", level)
+
+	req, err = ConstructSetLogLevelRequest("querycoord", "")
+	assert.Nil(t, err)
+	logger, level, err = ParseLogLevel(req.Request)
+	assert.Nil(t, err)
+	assert.Equal(t, "querycoord", logger)
+	assert.Equal(t, "", level)
+
+	invalids := []string{
+		"not in json format",
+		`{"metric_type": "set_log_level"}`,
+		`{"metric_type": "set_log_level", "level": "verbose"}`,
+		`{"metric_type": "set_log_level", "level": ""}`,
+		`{"metric_type": "set_log_level", "level": "info", "logger": 1}`,
+	}
+	for _, req := range invalids {
+		_, _, err = ParseLogLevel(req)
+		assert.NotNil(t, err, req)
+	}
+}
+
+func Test_SetLogLevel(t *testing.T) {
+	defer log.SetLevel(log.GetLevel())
+	log.SetLevel(zap.InfoLevel)
+	defer log.ResetNamedLevel("querycoord.scheduler")
+
+	debugEnabled := func(name string) bool {
+		return log.Named(name).Check(zap.DebugLevel, "debug line") != nil
+	}
+	assert.False(t, debugEnabled("querycoord.scheduler"))
+
+	req, err := ConstructSetLogLevelRequest("querycoord.scheduler", "debug")
+	assert.Nil(t, err)
+	levels := SetLogLevel(req, "querycoord-1")
+	assert.False(t, levels.HasError)
+	assert.Equal(t, "info", levels.Level)
+	assert.Equal(t, map[string]string{"querycoord.scheduler": "debug"}, levels.Loggers)
+	assert.True(t, debugEnabled("querycoord.scheduler"))
+	assert.False(t, debugEnabled("querycoord"))
+
+	req, err = ConstructSetLogLevelRequest("querycoord.scheduler", "")
+	assert.Nil(t, err)
+	levels = SetLogLevel(req, "querycoord-1")
+	assert.False(t, levels.HasError)
+	assert.Empty(t, levels.Loggers)
+	assert.False(t, debugEnabled("querycoord.scheduler"))
+
+	levels = SetLogLevel(&milvuspb.GetMetricsRequest{Request: `{"level": "verbose"}`}, "querycoord-1")
+	assert.True(t, levels.HasError)
+	assert.Equal(t, "querycoord-1", levels.Name)
+}
+
+func Test_CollectLogLevels(t *testing.T) {
+	levels := []ComponentLogLevels{{Name: "querynode-1", Level: "info", Loggers: map[string]string{"querynode": "debug"}}}
+	resp := LogLevelsResponse(levels, "querynode-1")
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+	assert.Equal(t, levels, CollectLogLevels(resp, nil))
+
+	collected := CollectLogLevels(nil, errors.New("unreachable"))
+	assert.Equal(t, 1, len(collected))
+	assert.True(t, collected[0].HasError)
+	assert.Equal(t, "unreachable", collected[0].ErrorReason)
+
+	collected = CollectLogLevels(&milvuspb.GetMetricsResponse{
+		Status:        &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "unhealthy"},
+		ComponentName: "querynode-2",
+	}, nil)
+	assert.Equal(t, []ComponentLogLevels{{Name: "querynode-2", HasError: true, ErrorReason: "unhealthy"}}, collected)
+
+	resp = LogLevelsResponse(append(levels, collected...), "querycoord-1")
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	assert.Contains(t, resp.Status.Reason, "querynode-2")
+	assert.Equal(t, 2, len(CollectLogLevels(resp, nil)))
+}
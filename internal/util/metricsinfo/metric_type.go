@@ -24,6 +24,32 @@ const (
 
 	// SystemInfoMetrics means users request for system information metrics.
 	SystemInfoMetrics = "system_info"
+
+	// SegmentStatisticsMetrics means users request for per-segment search/query statistics.
+	SegmentStatisticsMetrics = "segment_statistics"
+
+	// TaskInfoMetrics means users request for the progress of the tasks known to the query coordinator.
+	TaskInfoMetrics = "task_info"
+
+	// ResetKey is the key of the optional reset flag in GetMetrics request, only used by statistics metrics.
+	ResetKey = "reset"
+
+	// UpdateConfigMetrics means users request to update the runtime configs, the effective values are returned.
+	UpdateConfigMetrics = "update_config"
+
+	// ConfigsKey is the key of the configs to update in GetMetrics request of UpdateConfigMetrics.
+	ConfigsKey = "configs"
+
+	// SetLogLevelMetrics means users request to set the log level at runtime, the current levels are returned.
+	SetLogLevelMetrics = "set_log_level"
+
+	// LogLevelKey is the key of the log level in GetMetrics request of SetLogLevelMetrics,
+	// an empty level removes the level of the logger so it follows the global level again.
+	LogLevelKey = "level"
+
+	// LoggerNameKey is the key of the optional logger name in GetMetrics request of SetLogLevelMetrics,
+	// the global level is set if it's absent.
+	LoggerNameKey = "logger"
 )
 
 // ParseMetricType returns the metric type of req
@@ -40,6 +66,18 @@ func ParseMetricType(req string) (string, error) {
 	return metricType.(string), nil
 }
 
+// ParseResetFlag returns whether the counters should be reset after being collected.
+// A request without the reset flag never resets the counters.
+func ParseResetFlag(req string) bool {
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(req), &m)
+	if err != nil {
+		return false
+	}
+	reset, ok := m[ResetKey].(bool)
+	return ok && reset
+}
+
 // ConstructRequestByMetricType constructs a request according to the metric type
 func ConstructRequestByMetricType(metricType string) (*milvuspb.GetMetricsRequest, error) {
 	m := make(map[string]interface{})
@@ -53,3 +91,40 @@ func ConstructRequestByMetricType(metricType string) (*milvuspb.GetMetricsReques
 		Request: string(binary),
 	}, nil
 }
+
+// ParseConfigs returns the configs to update in req, the values should be strings
+func ParseConfigs(req string) (map[string]string, error) {
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(req), &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the request: %s", err.Error())
+	}
+	configs, ok := m[ConfigsKey].(map[string]interface{})
+	if !ok || len(configs) == 0 {
+		return nil, fmt.Errorf("%s not found in request", ConfigsKey)
+	}
+	ret := make(map[string]string, len(configs))
+	for key, value := range configs {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("the value of config %s should be a string", key)
+		}
+		ret[key] = str
+	}
+	return ret, nil
+}
+
+// ConstructUpdateConfigRequest constructs a request to update configs
+func ConstructUpdateConfigRequest(configs map[string]string) (*milvuspb.GetMetricsRequest, error) {
+	m := make(map[string]interface{})
+	m[MetricTypeKey] = UpdateConfigMetrics
+	m[ConfigsKey] = configs
+	binary, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct request to update configs: %s", err.Error())
+	}
+	return &milvuspb.GetMetricsRequest{
+		Base:    nil,
+		Request: string(binary),
+	}, nil
+}
@@ -78,3 +78,46 @@ func Test_ConstructRequestByMetricType(t *testing.T) {
 		}
 	}
 }
+
+func Test_ParseResetFlag(t *testing.T) {
+	m := make(map[string]interface{})
+	m[MetricTypeKey] = SegmentStatisticsMetrics
+	b, err := json.Marshal(m)
+	assert.Equal(t, nil, err)
+	assert.False(t, ParseResetFlag(string(b)))
+
+	m[ResetKey] = true
+	b, err = json.Marshal(m)
+	assert.Equal(t, nil, err)
+	assert.True(t, ParseResetFlag(string(b)))
+
+	m[ResetKey] = "true"
+	b, err = json.Marshal(m)
+	assert.Equal(t, nil, err)
+	assert.False(t, ParseResetFlag(string(b)))
+
+	assert.False(t, ParseResetFlag("not in json format"))
+}
+
+func Test_ParseConfigs(t *testing.T) {
+	req, err := ConstructUpdateConfigRequest(map[string]string{"log.level": "info"})
+	assert.Nil(t, err)
+	metricType, err := ParseMetricType(req.Request)
+	assert.Nil(t, err)
+	assert.Equal(t, UpdateConfigMetrics, metricType)
+	configs, err := ParseConfigs(req.Request)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"log.level": "info"}, configs)
+
+	_, err = ParseConfigs("not in json format")
+	assert.NotNil(t, err)
+
+	_, err = ParseConfigs(`{"metric_type": "update_config"}`)
+	assert.NotNil(t, err)
+
+	_, err = ParseConfigs(`{"metric_type": "update_config", "configs": {}}`)
+	assert.NotNil(t, err)
+
+	_, err = ParseConfigs(`{"metric_type": "update_config", "configs": {"proxy.rateLimit.dmlRows": 10}}`)
+	assert.NotNil(t, err)
+}
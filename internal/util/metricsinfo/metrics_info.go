@@ -87,6 +87,8 @@ type QueryNodeConfiguration struct {
 	RetrieveReceiveBufSize       int64 `json:"retrieve_receive_buf_size"`
 	RetrievePulsarBufSize        int64 `json:"retrieve_pulsar_buf_size"`
 	RetrieveResultReceiveBufSize int64 `json:"retrieve_result_receive_buf_size"`
+	RetrieveMaxResultSize        int64 `json:"retrieve_max_result_size"`
+	RetrieveMemoryBudget         int64 `json:"retrieve_memory_budget"`
 
 	SimdType string `json:"simd_type"`
 }
@@ -109,6 +111,70 @@ type QueryCoordInfos struct {
 	SystemConfigurations QueryCoordConfiguration `json:"system_configurations"`
 }
 
+// TaskInfo records the progress of a task known to a coordinator,
+// ChildTaskStates and ChildTasks are only filled for the tasks with child tasks.
+type TaskInfo struct {
+	TaskID          int64          `json:"task_id"`
+	Type            string         `json:"type"`
+	CollectionID    int64          `json:"collection_id"`
+	State           string         `json:"state"`
+	Phase           string         `json:"phase"`
+	PhaseElapsedMs  int64          `json:"phase_elapsed_ms"`
+	ElapsedMs       int64          `json:"elapsed_ms"`
+	NodeIDs         []int64        `json:"node_ids"`
+	ChildTaskStates map[string]int `json:"child_task_states,omitempty"`
+	ChildTasks      []TaskInfo     `json:"child_tasks,omitempty"`
+}
+
+// TaskInfos is the response of TaskInfoMetrics request.
+type TaskInfos struct {
+	Name  string     `json:"name"`
+	Tasks []TaskInfo `json:"tasks"`
+}
+
+// LoadingSegmentInfo records a segment being loaded to a query node by a child task of query coordinator
+type LoadingSegmentInfo struct {
+	TaskID       int64  `json:"task_id"`
+	CollectionID int64  `json:"collection_id"`
+	SegmentID    int64  `json:"segment_id"`
+	Phase        string `json:"phase"`
+	EnqueueTime  string `json:"enqueue_time"`
+	ElapsedMs    int64  `json:"elapsed_ms"`
+}
+
+// FailedTaskInfo records a child task of query coordinator that failed, it may be retried or rescheduled later
+type FailedTaskInfo struct {
+	TaskID        int64   `json:"task_id"`
+	TriggerTaskID int64   `json:"trigger_task_id"`
+	Type          string  `json:"type"`
+	CollectionID  int64   `json:"collection_id"`
+	NodeID        int64   `json:"node_id"`
+	SegmentIDs    []int64 `json:"segment_ids,omitempty"`
+	Reason        string  `json:"reason"`
+	FailedTime    string  `json:"failed_time"`
+}
+
+// QueryNodeTaskInfos records the child tasks of query coordinator sent to a query node.
+// DispatchQueue are the child tasks waiting to be dispatched, FailedTasks are the ones failed recently.
+type QueryNodeTaskInfos struct {
+	NodeID          int64                `json:"node_id"`
+	LoadingSegments []LoadingSegmentInfo `json:"loading_segments"`
+	DispatchQueue   []TaskInfo           `json:"dispatch_queue"`
+	FailedTasks     []FailedTaskInfo     `json:"failed_tasks"`
+}
+
+// IndexBuildDirInfos records the local directories of the index building tasks of an index node.
+// CleanedDirs are removed when the tasks ended, SweptDirs are left by the tasks of a previous run.
+type IndexBuildDirInfos struct {
+	Root            string `json:"root"`
+	UsedBytes       uint64 `json:"used_bytes"`
+	AvailableBytes  uint64 `json:"available_bytes"`
+	ActiveDirs      int    `json:"active_dirs"`
+	CleanedDirs     int64  `json:"cleaned_dirs"`
+	SweptDirs       int64  `json:"swept_dirs"`
+	CleanupFailures int64  `json:"cleanup_failures"`
+}
+
 // ProxyConfiguration records the configuration of proxy.
 type ProxyConfiguration struct {
 	DefaultPartitionName string `json:"default_partition_name"`
@@ -177,3 +243,81 @@ type RootCoordInfos struct {
 	BaseComponentInfos
 	SystemConfigurations RootCoordConfiguration `json:"system_configurations"`
 }
+
+// SegmentStatistics records the search/query statistics of a segment on query node.
+type SegmentStatistics struct {
+	SegmentID        int64  `json:"segment_id"`
+	CollectionID     int64  `json:"collection_id"`
+	PartitionID      int64  `json:"partition_id"`
+	SearchCount      int64  `json:"search_count"`
+	RetrieveCount    int64  `json:"retrieve_count"`
+	RowsScanned      int64  `json:"rows_scanned"`
+	TotalLatencyInMs int64  `json:"total_latency_in_ms"`
+	AvgLatencyInMs   int64  `json:"avg_latency_in_ms"`
+	IndexHitCount    int64  `json:"index_hit_count"`
+	BruteForceCount  int64  `json:"brute_force_count"`
+	DeleteCount      int64  `json:"delete_count"`
+	SegmentType      string `json:"segment_type"`
+	LoadCostInMs     int64  `json:"load_cost_in_ms"`
+	WarmupCostInMs   int64  `json:"warmup_cost_in_ms"`
+	// IdleInMs is the time since the segment was searched or queried last time, or since it was created,
+	// it's not cleared by the reset of the counters
+	IdleInMs int64 `json:"idle_in_ms"`
+}
+
+// QueryNodeSegmentStatistics contains the segment statistics of a query node.
+type QueryNodeSegmentStatistics struct {
+	Name     string              `json:"name"`
+	ID       int64               `json:"id"`
+	Segments []SegmentStatistics `json:"segments"`
+}
+
+// QueryClusterSegmentStatistics contains the segment statistics of all query nodes.
+type QueryClusterSegmentStatistics struct {
+	Nodes []QueryNodeSegmentStatistics `json:"nodes"`
+}
+
+// CollectionSegmentStatistics contains the segment statistics of a collection, aggregated across query nodes.
+type CollectionSegmentStatistics struct {
+	CollectionID    int64               `json:"collection_id"`
+	SearchCount     int64               `json:"search_count"`
+	RetrieveCount   int64               `json:"retrieve_count"`
+	RowsScanned     int64               `json:"rows_scanned"`
+	IndexHitCount   int64               `json:"index_hit_count"`
+	BruteForceCount int64               `json:"brute_force_count"`
+	DeleteCount     int64               `json:"delete_count"`
+	Segments        []SegmentStatistics `json:"segments"`
+}
+
+// AggregateSegmentStatistics groups the segment statistics of all query nodes by collection.
+// A segment served by multiple query nodes appears once per query node.
+func AggregateSegmentStatistics(cluster *QueryClusterSegmentStatistics) []CollectionSegmentStatistics {
+	collections := make(map[int64]*CollectionSegmentStatistics)
+	order := make([]int64, 0)
+	for _, node := range cluster.Nodes {
+		for _, segment := range node.Segments {
+			collection, ok := collections[segment.CollectionID]
+			if !ok {
+				collection = &CollectionSegmentStatistics{
+					CollectionID: segment.CollectionID,
+					Segments:     make([]SegmentStatistics, 0),
+				}
+				collections[segment.CollectionID] = collection
+				order = append(order, segment.CollectionID)
+			}
+			collection.SearchCount += segment.SearchCount
+			collection.RetrieveCount += segment.RetrieveCount
+			collection.RowsScanned += segment.RowsScanned
+			collection.IndexHitCount += segment.IndexHitCount
+			collection.BruteForceCount += segment.BruteForceCount
+			collection.DeleteCount += segment.DeleteCount
+			collection.Segments = append(collection.Segments, segment)
+		}
+	}
+
+	ret := make([]CollectionSegmentStatistics, 0, len(order))
+	for _, collectionID := range order {
+		ret = append(ret, *collections[collectionID])
+	}
+	return ret
+}
@@ -331,3 +331,45 @@ func TestRootCoordInfos_Codec(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, infos1, infos2)
 }
+
+func TestAggregateSegmentStatistics(t *testing.T) {
+	cluster := &QueryClusterSegmentStatistics{
+		Nodes: []QueryNodeSegmentStatistics{
+			{
+				Name: ConstructComponentName(typeutil.QueryNodeRole, 1),
+				ID:   1,
+				Segments: []SegmentStatistics{
+					{SegmentID: 1, CollectionID: 100, SearchCount: 2, RowsScanned: 20, IndexHitCount: 2},
+					{SegmentID: 2, CollectionID: 200, SearchCount: 1, RowsScanned: 5, BruteForceCount: 1},
+				},
+			},
+			{
+				Name: ConstructComponentName(typeutil.QueryNodeRole, 2),
+				ID:   2,
+				Segments: []SegmentStatistics{
+					{SegmentID: 3, CollectionID: 100, SearchCount: 3, RetrieveCount: 1, RowsScanned: 30, BruteForceCount: 3, DeleteCount: 4},
+				},
+			},
+		},
+	}
+
+	s, err := MarshalComponentInfos(cluster)
+	assert.Nil(t, err)
+	var decoded QueryClusterSegmentStatistics
+	err = UnmarshalComponentInfos(s, &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, *cluster, decoded)
+
+	collections := AggregateSegmentStatistics(&decoded)
+	assert.Equal(t, 2, len(collections))
+	assert.Equal(t, int64(100), collections[0].CollectionID)
+	assert.Equal(t, int64(5), collections[0].SearchCount)
+	assert.Equal(t, int64(1), collections[0].RetrieveCount)
+	assert.Equal(t, int64(50), collections[0].RowsScanned)
+	assert.Equal(t, int64(2), collections[0].IndexHitCount)
+	assert.Equal(t, int64(3), collections[0].BruteForceCount)
+	assert.Equal(t, int64(4), collections[0].DeleteCount)
+	assert.Equal(t, 2, len(collections[0].Segments))
+	assert.Equal(t, int64(200), collections[1].CollectionID)
+	assert.Equal(t, 1, len(collections[1].Segments))
+}
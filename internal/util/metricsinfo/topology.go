@@ -57,7 +57,7 @@ const (
 type ConnectionTargetType = string
 
 type ConnectionInfo struct {
-	TargetName string               `json:"target_name"`
+	TargetName string               `json:"target_name,omitempty"`
 	TargetType ConnectionTargetType `json:"target_type"`
 }
 
@@ -72,6 +72,7 @@ type ConnTopology struct {
 }
 
 // QueryCoordTopology shows the whole metrics of query cluster
+// Deprecated: the components respond with ComponentTopology, which still understands this shape.
 type QueryCoordTopology struct {
 	Cluster     QueryClusterTopology `json:"cluster"`
 	Connections ConnTopology         `json:"connections"`
@@ -84,6 +85,7 @@ type IndexClusterTopology struct {
 }
 
 // IndexCoordTopology shows the whole metrics of index cluster
+// Deprecated: the components respond with ComponentTopology, which still understands this shape.
 type IndexCoordTopology struct {
 	Cluster     IndexClusterTopology `json:"cluster"`
 	Connections ConnTopology         `json:"connections"`
@@ -96,12 +98,14 @@ type DataClusterTopology struct {
 }
 
 // DataCoordTopology shows the whole metrics of index cluster
+// Deprecated: the components respond with ComponentTopology, which still understands this shape.
 type DataCoordTopology struct {
 	Cluster     DataClusterTopology `json:"cluster"`
 	Connections ConnTopology        `json:"connections"`
 }
 
 // RootCoordTopology shows the whole metrics of root coordinator
+// Deprecated: the components respond with ComponentTopology, which still understands this shape.
 type RootCoordTopology struct {
 	Self        RootCoordInfos `json:"self"`
 	Connections ConnTopology   `json:"connections"`
@@ -122,5 +126,6 @@ type SystemTopologyNode struct {
 
 // SystemTopology shows the system topology
 type SystemTopology struct {
+	Version   int                  `json:"version,omitempty"`
 	NodesInfo []SystemTopologyNode `json:"nodes_info"`
 }
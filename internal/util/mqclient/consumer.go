@@ -11,6 +11,15 @@
 
 package mqclient
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrPositionExpired is returned by Seek when the messages after the position are removed by the retention
+// of message queue, the consumer can't resume from the position and should recover from other sources
+var ErrPositionExpired = errors.New("mq position expired")
+
 // SubscriptionInitialPosition is the type of a subscription initial position
 type SubscriptionInitialPosition int
 
@@ -82,9 +91,15 @@ type Consumer interface {
 	// Seek to the uniqueID position
 	Seek(MessageID) error //nolint:govet
 
+	// SeekByTime seeks to the first message published at or after t
+	SeekByTime(t time.Time) error
+
 	// Make sure that msg is received. Only used in pulsar
 	Ack(ConsumerMessage)
 
 	// Close consumer
 	Close()
+
+	// Unsubscribe deletes the subscription of the consumer and closes it
+	Unsubscribe() error
 }
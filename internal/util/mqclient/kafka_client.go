@@ -0,0 +1,208 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package mqclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/retry"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// kafkaTimeoutMs is the timeout of the requests to the kafka brokers, e.g. metadata and offset queries
+const kafkaTimeoutMs = 10000
+
+// kafkaClient implements Client on kafka.
+//
+// The msgstream assumes that the messages of a channel are consumed in the order they are produced,
+// e.g. a time tick of a channel never overtakes the messages produced before it. Kafka only keeps
+// the order inside a partition, so each channel is mapped to exactly one partition of its topic,
+// selected by the hash of the channel name, and both the producers and the consumers of the channel
+// only use that partition. The topics created by the client have a single partition.
+type kafkaClient struct {
+	brokerList string
+	// producer is shared by all the producers created by the client, it's thread safe
+	producer *kafka.Producer
+}
+
+var kc *kafkaClient
+var kafkaOnce sync.Once
+
+// GetKafkaClientInstance returns the kafka client of the process connecting to brokerList,
+// brokerList is the comma separated host:port list of the bootstrap brokers
+func GetKafkaClientInstance(brokerList string) (*kafkaClient, error) {
+	var err error
+	kafkaOnce.Do(func() {
+		var p *kafka.Producer
+		p, err = kafka.NewProducer(&kafka.ConfigMap{
+			"bootstrap.servers": brokerList,
+			// the retries of the producer never reorder the messages of a partition
+			"enable.idempotence": true,
+		})
+		if err != nil {
+			log.Error("Failed to set kafka client: ", zap.Error(err))
+			return
+		}
+		kc = &kafkaClient{brokerList: brokerList, producer: p}
+	})
+	if kc == nil {
+		if err == nil {
+			err = errors.New("kafka client is not initialized")
+		}
+		return nil, err
+	}
+	return kc, nil
+}
+
+// kafkaChannelPartition returns the partition of the topic with numPartitions partitions the channel is mapped to
+func kafkaChannelPartition(channel string, numPartitions int) (int32, error) {
+	if numPartitions <= 0 {
+		return 0, fmt.Errorf("kafka topic of channel %s has no partition", channel)
+	}
+	hash, err := typeutil.Hash32String(channel)
+	if err != nil {
+		return 0, err
+	}
+	return int32(hash % int64(numPartitions)), nil
+}
+
+// channelPartition returns the partition the channel is mapped to, the topic of the channel is created if not exists
+func (kc *kafkaClient) channelPartition(channel string) (int32, error) {
+	var partition int32
+	fn := func() error {
+		md, err := kc.producer.GetMetadata(&channel, false, kafkaTimeoutMs)
+		if err != nil {
+			return err
+		}
+		tm, ok := md.Topics[channel]
+		if !ok || tm.Error.Code() == kafka.ErrUnknownTopicOrPart || tm.Error.Code() == kafka.ErrUnknownTopic {
+			if err := kc.createTopic(channel); err != nil {
+				return err
+			}
+			return fmt.Errorf("kafka topic %s is being created", channel)
+		}
+		if tm.Error.Code() != kafka.ErrNoError {
+			return tm.Error
+		}
+		partition, err = kafkaChannelPartition(channel, len(tm.Partitions))
+		return err
+	}
+	err := retry.Do(context.TODO(), fn, retry.Attempts(20), retry.Sleep(time.Millisecond*200))
+	if err != nil {
+		return 0, err
+	}
+	return partition, nil
+}
+
+func (kc *kafkaClient) createTopic(topic string) error {
+	admin, err := kafka.NewAdminClientFromProducer(kc.producer)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaTimeoutMs*time.Millisecond)
+	defer cancel()
+	results, err := admin.CreateTopics(ctx, []kafka.TopicSpecification{{Topic: topic, NumPartitions: 1}})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if code := result.Error.Code(); code != kafka.ErrNoError && code != kafka.ErrTopicAlreadyExists {
+			return result.Error
+		}
+	}
+	return nil
+}
+
+// CreateProducer creates a producer publishing to the partition the topic is mapped to
+func (kc *kafkaClient) CreateProducer(options ProducerOptions) (Producer, error) {
+	partition, err := kc.channelPartition(options.Topic)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaProducer{p: kc.producer, topic: options.Topic, partition: partition}, nil
+}
+
+// Subscribe creates a consumer of the consumer group named by the subscription name, the consumer
+// is assigned the partition the topic is mapped to, so every consumer of a channel receives all the
+// messages of the channel whatever the subscription type is
+func (kc *kafkaClient) Subscribe(options ConsumerOptions) (Consumer, error) {
+	partition, err := kc.channelPartition(options.Topic)
+	if err != nil {
+		return nil, err
+	}
+	offsetReset := "latest"
+	if options.SubscriptionInitialPosition == SubscriptionPositionEarliest {
+		offsetReset = "earliest"
+	}
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  kc.brokerList,
+		"group.id":           options.SubscriptionName,
+		"auto.offset.reset":  offsetReset,
+		"enable.auto.commit": true,
+		// the offsets are stored when the messages are acked
+		"enable.auto.offset.store": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	consumer := &kafkaConsumer{
+		c:         c,
+		topic:     options.Topic,
+		partition: partition,
+		subName:   options.SubscriptionName,
+		closeCh:   make(chan struct{}),
+	}
+	// resume from the committed offset of the group, or from the initial position if there is none
+	if err := consumer.assign(kafka.OffsetStored); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return consumer, nil
+}
+
+// EarliestMessageID returns the position of the first message retained in a channel
+func (kc *kafkaClient) EarliestMessageID() MessageID {
+	return kafkaEarliestID()
+}
+
+// StringToMsgID converts a partition:offset string to MessageID
+func (kc *kafkaClient) StringToMsgID(id string) (MessageID, error) {
+	partition, offset, err := StringToKafkaMsgID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaID{partition: partition, offset: offset}, nil
+}
+
+// BytesToMsgID deserializes MessageID from a byte array
+func (kc *kafkaClient) BytesToMsgID(id []byte) (MessageID, error) {
+	partition, offset, err := DeserializeKafkaID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaID{partition: partition, offset: offset}, nil
+}
+
+// Close waits for the outstanding messages to be delivered and closes the shared producer
+func (kc *kafkaClient) Close() {
+	kc.producer.Flush(kafkaTimeoutMs)
+	kc.producer.Close()
+}
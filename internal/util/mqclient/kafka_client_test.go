@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package mqclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_KafkaChannelPartition(t *testing.T) {
+	partition, err := kafkaChannelPartition("by-dev-dml_0", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), partition)
+
+	// a channel is always mapped to the same partition
+	for _, channel := range []string{"by-dev-dml_0", "by-dev-dml_1", "by-dev-rootcoord-tt"} {
+		first, err := kafkaChannelPartition(channel, 8)
+		assert.Nil(t, err)
+		assert.True(t, first >= 0 && first < 8)
+		for i := 0; i < 10; i++ {
+			p, err := kafkaChannelPartition(channel, 8)
+			assert.Nil(t, err)
+			assert.Equal(t, first, p)
+		}
+	}
+
+	_, err = kafkaChannelPartition("by-dev-dml_0", 0)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package mqclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+var _ Consumer = (*kafkaConsumer)(nil)
+
+// kafkaConsumer consumes the single partition a channel is mapped to, as a member of the consumer group
+// named by the subscription
+type kafkaConsumer struct {
+	c          *kafka.Consumer
+	topic      string
+	partition  int32
+	subName    string
+	msgChannel chan ConsumerMessage
+	closeCh    chan struct{}
+	once       sync.Once
+	closeOnce  sync.Once
+	wg         sync.WaitGroup
+}
+
+func (kc *kafkaConsumer) Subscription() string {
+	return kc.subName
+}
+
+// Chan returns a channel to read the messages of the partition
+func (kc *kafkaConsumer) Chan() <-chan ConsumerMessage {
+	kc.once.Do(func() {
+		kc.msgChannel = make(chan ConsumerMessage, 256)
+		kc.wg.Add(1)
+		go func() {
+			defer kc.wg.Done()
+			defer close(kc.msgChannel)
+			for {
+				select {
+				case <-kc.closeCh:
+					return
+				default:
+				}
+				switch e := kc.c.Poll(100).(type) {
+				case *kafka.Message:
+					select {
+					case kc.msgChannel <- &kafkaMessage{msg: e}:
+					case <-kc.closeCh:
+						return
+					}
+				case kafka.Error:
+					log.Warn("kafka consumer error", zap.String("topic", kc.topic), zap.Error(e))
+				}
+			}
+		}()
+	})
+	return kc.msgChannel
+}
+
+func (kc *kafkaConsumer) assign(offset kafka.Offset) error {
+	return kc.c.Assign([]kafka.TopicPartition{{Topic: &kc.topic, Partition: kc.partition, Offset: offset}})
+}
+
+// Seek moves the consumer to the message at id, the next message received is the one at id
+func (kc *kafkaConsumer) Seek(id MessageID) error {
+	kid := id.(*kafkaID)
+	if kid.offset < 0 {
+		// logical offsets, e.g. the earliest message id
+		return kc.assign(kafka.Offset(kid.offset))
+	}
+	if kid.partition != kc.partition {
+		return fmt.Errorf("position of partition %d doesn't belong to channel %s mapped to partition %d", kid.partition, kc.topic, kc.partition)
+	}
+	low, _, err := kc.c.QueryWatermarkOffsets(kc.topic, kc.partition, kafkaTimeoutMs)
+	if err != nil {
+		return err
+	}
+	if kid.offset < low {
+		return fmt.Errorf("%w: offset %d of channel %s is older than the first retained offset %d", ErrPositionExpired, kid.offset, kc.topic, low)
+	}
+	return kc.assign(kafka.Offset(kid.offset))
+}
+
+// SeekByTime moves the consumer to the first message published at or after t
+func (kc *kafkaConsumer) SeekByTime(t time.Time) error {
+	offsets, err := kc.c.OffsetsForTimes([]kafka.TopicPartition{{
+		Topic:     &kc.topic,
+		Partition: kc.partition,
+		Offset:    kafka.Offset(t.UnixNano() / int64(time.Millisecond)),
+	}}, kafkaTimeoutMs)
+	if err != nil {
+		return err
+	}
+	if len(offsets) != 1 {
+		return errors.New("kafka returns no offset of channel " + kc.topic)
+	}
+	if offsets[0].Error != nil {
+		return offsets[0].Error
+	}
+	// the offset is kafka.OffsetEnd if no message is published at or after t
+	return kc.assign(offsets[0].Offset)
+}
+
+// Ack stores the offset after the message, which is committed to the consumer group periodically
+func (kc *kafkaConsumer) Ack(message ConsumerMessage) {
+	km := message.(*kafkaMessage)
+	tp := km.msg.TopicPartition
+	tp.Offset++
+	if _, err := kc.c.StoreOffsets([]kafka.TopicPartition{tp}); err != nil {
+		log.Warn("failed to store kafka offset", zap.String("topic", kc.topic), zap.Error(err))
+	}
+}
+
+// Close stops receiving messages, commits the stored offsets and leaves the consumer group
+func (kc *kafkaConsumer) Close() {
+	kc.closeOnce.Do(func() {
+		close(kc.closeCh)
+		kc.wg.Wait()
+		if err := kc.c.Close(); err != nil {
+			log.Warn("failed to close kafka consumer", zap.String("topic", kc.topic), zap.Error(err))
+		}
+	})
+}
+
+// Unsubscribe closes the consumer, the committed offsets of the consumer group are expired by the brokers
+// once the group has no member for offsets.retention.minutes
+func (kc *kafkaConsumer) Unsubscribe() error {
+	kc.Close()
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package mqclient
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// kafkaID wraps the position of a kafka message, the partition and the offset in it
+type kafkaID struct {
+	partition int32
+	offset    int64
+}
+
+// Check if kafkaID implements MessageID interface
+var _ MessageID = &kafkaID{}
+
+func (kid *kafkaID) Serialize() []byte {
+	return SerializeKafkaID(kid.partition, kid.offset)
+}
+
+func (kid *kafkaID) LedgerID() int64 {
+	return 0
+}
+
+// EntryID returns the offset of the message in the partition
+func (kid *kafkaID) EntryID() int64 {
+	return kid.offset
+}
+
+func (kid *kafkaID) BatchIdx() int32 {
+	return 0
+}
+
+func (kid *kafkaID) PartitionIdx() int32 {
+	return kid.partition
+}
+
+// kafkaEarliestID points to the first message retained in the partition of a channel
+func kafkaEarliestID() *kafkaID {
+	return &kafkaID{partition: 0, offset: int64(kafka.OffsetBeginning)}
+}
+
+// SerializeKafkaID is used to serialize the partition and the offset of a message to byte array
+func SerializeKafkaID(partition int32, offset int64) []byte {
+	b := make([]byte, 12)
+	binary.LittleEndian.PutUint32(b, uint32(partition))
+	binary.LittleEndian.PutUint64(b[4:], uint64(offset))
+	return b
+}
+
+// DeserializeKafkaID is used to deserialize the partition and the offset of a message from byte array
+func DeserializeKafkaID(messageID []byte) (int32, int64, error) {
+	if len(messageID) != 12 {
+		return 0, 0, fmt.Errorf("invalid kafka message id length %d", len(messageID))
+	}
+	partition := int32(binary.LittleEndian.Uint32(messageID))
+	offset := int64(binary.LittleEndian.Uint64(messageID[4:]))
+	return partition, offset, nil
+}
+
+// KafkaMsgIDToString formats the position of a message as partition:offset
+func KafkaMsgIDToString(partition int32, offset int64) string {
+	return fmt.Sprintf("%d:%d", partition, offset)
+}
+
+// StringToKafkaMsgID parses the position of a message formatted by KafkaMsgIDToString
+func StringToKafkaMsgID(id string) (int32, int64, error) {
+	fields := strings.Split(id, ":")
+	if len(fields) != 2 {
+		return 0, 0, errors.New("invalid kafka message id " + id)
+	}
+	partition, err := strconv.ParseInt(fields[0], 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(partition), offset, nil
+}
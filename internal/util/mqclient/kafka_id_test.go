@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package mqclient
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaID_Serialize(t *testing.T) {
+	kid := &kafkaID{partition: 3, offset: 1024}
+
+	binary := kid.Serialize()
+	assert.Equal(t, 12, len(binary))
+	assert.Equal(t, int32(3), kid.PartitionIdx())
+	assert.Equal(t, int64(1024), kid.EntryID())
+	assert.Equal(t, int64(0), kid.LedgerID())
+	assert.Equal(t, int32(0), kid.BatchIdx())
+}
+
+func Test_DeserializeKafkaID(t *testing.T) {
+	binary := SerializeKafkaID(2, 1<<40)
+	partition, offset, err := DeserializeKafkaID(binary)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), partition)
+	assert.Equal(t, int64(1<<40), offset)
+
+	earliest := kafkaEarliestID()
+	partition, offset, err = DeserializeKafkaID(earliest.Serialize())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), partition)
+	assert.Equal(t, int64(kafka.OffsetBeginning), offset)
+
+	_, _, err = DeserializeKafkaID([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+}
+
+func Test_StringToKafkaMsgID(t *testing.T) {
+	str := KafkaMsgIDToString(1, 100)
+	assert.Equal(t, "1:100", str)
+	partition, offset, err := StringToKafkaMsgID(str)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), partition)
+	assert.Equal(t, int64(100), offset)
+
+	_, _, err = StringToKafkaMsgID("100")
+	assert.NotNil(t, err)
+	_, _, err = StringToKafkaMsgID("a:100")
+	assert.NotNil(t, err)
+	_, _, err = StringToKafkaMsgID("1:b")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package mqclient
+
+import (
+	"context"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+var _ Producer = (*kafkaProducer)(nil)
+
+// kafkaProducer publishes the messages of a channel to the single partition the channel is mapped to
+type kafkaProducer struct {
+	p         *kafka.Producer
+	topic     string
+	partition int32
+}
+
+func (kp *kafkaProducer) Topic() string {
+	return kp.topic
+}
+
+// Send publishes the message and waits until it's delivered
+func (kp *kafkaProducer) Send(ctx context.Context, message *ProducerMessage) (MessageID, error) {
+	headers := make([]kafka.Header, 0, len(message.Properties))
+	for key, value := range message.Properties {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	deliveryChan := make(chan kafka.Event, 1)
+	err := kp.p.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &kp.topic, Partition: kp.partition},
+		Value:          message.Payload,
+		Headers:        headers,
+	}, deliveryChan)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case e := <-deliveryChan:
+		m := e.(*kafka.Message)
+		if m.TopicPartition.Error != nil {
+			return nil, m.TopicPartition.Error
+		}
+		return &kafkaID{partition: m.TopicPartition.Partition, offset: int64(m.TopicPartition.Offset)}, nil
+	}
+}
+
+// Close does nothing, the underlying producer is shared by the client and closed with it
+func (kp *kafkaProducer) Close() {
+}
@@ -13,6 +13,7 @@ package mqclient
 
 import (
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/apache/pulsar-client-go/pulsar"
@@ -77,6 +78,15 @@ func (pc *pulsarConsumer) Seek(id MessageID) error {
 	return err
 }
 
+// SeekByTime seeks to the first message published at or after t by the time based seek of broker
+func (pc *pulsarConsumer) SeekByTime(t time.Time) error {
+	err := pc.c.SeekByTime(t)
+	if err == nil {
+		pc.hasSeek = true
+	}
+	return err
+}
+
 func (pc *pulsarConsumer) Ack(message ConsumerMessage) {
 	pm := message.(*pulsarMessage)
 	pc.c.Ack(pm.msg)
@@ -87,6 +97,14 @@ func (pc *pulsarConsumer) Close() {
 	close(pc.closeCh)
 }
 
+// Unsubscribe deletes the subscription from pulsar, otherwise the subscription and its backlog are retained after
+// the consumer is closed
+func (pc *pulsarConsumer) Unsubscribe() error {
+	err := pc.c.Unsubscribe()
+	pc.Close()
+	return err
+}
+
 // patchEarliestMessageID unsafe patch logic to change messageID partitionIdx to 0
 // ONLY used in Chan() function
 // DON'T use elsewhere
@@ -12,7 +12,10 @@
 package mqclient
 
 import (
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/rocksmq/client/rocksmq"
 )
@@ -58,7 +61,16 @@ func (rc *RmqConsumer) Chan() <-chan ConsumerMessage {
 // Seek is used to seek the position in rocksmq topic
 func (rc *RmqConsumer) Seek(id MessageID) error {
 	msgID := id.(*rmqID).messageID
-	return rc.c.Seek(msgID)
+	err := rc.c.Seek(msgID)
+	if errors.Is(err, rocksmq.ErrPositionExpired) {
+		return fmt.Errorf("%w: %s", ErrPositionExpired, err.Error())
+	}
+	return err
+}
+
+// SeekByTime is used to seek to the first message published at or after t in rocksmq topic
+func (rc *RmqConsumer) SeekByTime(t time.Time) error {
+	return rc.c.SeekByTime(t)
 }
 
 // Ack is used to ask a rocksmq message
@@ -70,3 +82,9 @@ func (rc *RmqConsumer) Close() {
 	rc.c.Close()
 	close(rc.closeCh)
 }
+
+// Unsubscribe closes the consumer, the consumer group of rocksmq is destroyed when the consumer is closed
+func (rc *RmqConsumer) Unsubscribe() error {
+	rc.Close()
+	return nil
+}
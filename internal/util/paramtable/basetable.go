@@ -12,15 +12,20 @@
 package paramtable
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/kv"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -41,10 +46,20 @@ type Base interface {
 	Init()
 }
 
+// Param sources reported when logging where the effective value of a key comes from
+const (
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceDefault = "default"
+)
+
 type BaseTable struct {
 	params    *memkv.MemoryKV
 	configDir string
 
+	// defaultsLogged records the keys whose default value usage has been logged
+	defaultsLogged *sync.Map
+
 	RoleName          string
 	Log               log.Config
 	LogConfigFunction func(log.Config)
@@ -52,6 +67,7 @@ type BaseTable struct {
 
 func (gp *BaseTable) Init() {
 	gp.params = memkv.NewMemoryKV()
+	gp.defaultsLogged = &sync.Map{}
 
 	gp.configDir = gp.initConfPath()
 	log.Debug("config directory", zap.String("configDir", gp.configDir))
@@ -163,6 +179,18 @@ func (gp *BaseTable) tryloadFromEnv() {
 		panic(err)
 	}
 
+	kafkaAddress := os.Getenv("KAFKA_ADDRESS")
+	if kafkaAddress == "" {
+		kafkaAddress, err = gp.LoadWithDefault("kafka.brokerList", "localhost:9092")
+		if err != nil {
+			panic(err)
+		}
+	}
+	err = gp.Save("_KafkaAddress", kafkaAddress)
+	if err != nil {
+		panic(err)
+	}
+
 	rocksmqPath := os.Getenv("ROCKSMQ_PATH")
 	if rocksmqPath == "" {
 		path, err := gp.Load("rocksmq.path")
@@ -309,12 +337,46 @@ func (gp *BaseTable) tryloadFromEnv() {
 	}
 }
 
+// EnvKey returns the environment variable that overrides key, which is the key
+// with dots replaced by underscores and uppercased, e.g. QUERYCOORD_PORT for queryCoord.port
+func EnvKey(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// loadFromEnv returns the value of the environment variable overriding key,
+// an empty variable is treated as not set
+func loadFromEnv(key string) (string, bool) {
+	value := os.Getenv(EnvKey(key))
+	return value, value != ""
+}
+
+// Load returns the value of key, an empty string is returned if the key is not set
 func (gp *BaseTable) Load(key string) (string, error) {
-	return gp.params.Load(strings.ToLower(key))
+	return gp.LoadWithDefault(key, "")
 }
 
+// LoadWithDefault returns the value of key, the environment variable of the key takes
+// precedence over the config files and defaultValue is returned if neither is set
 func (gp *BaseTable) LoadWithDefault(key string, defaultValue string) (string, error) {
-	return gp.params.LoadWithDefault(strings.ToLower(key), defaultValue)
+	if value, ok := loadFromEnv(key); ok {
+		return value, nil
+	}
+	value, err := gp.params.Load(strings.ToLower(key))
+	if errors.Is(err, kv.ErrKeyNotFound) {
+		gp.logDefault(key, defaultValue)
+		return defaultValue, nil
+	}
+	return value, err
+}
+
+// logDefault logs the first time the default value of key is used
+func (gp *BaseTable) logDefault(key string, defaultValue string) {
+	if defaultValue == "" || gp.defaultsLogged == nil {
+		return
+	}
+	if _, loaded := gp.defaultsLogged.LoadOrStore(strings.ToLower(key), struct{}{}); !loaded {
+		log.Debug("param loaded", zap.String("key", key), zap.String("source", sourceDefault))
+	}
 }
 
 func (gp *BaseTable) LoadRange(key, endKey string, limit int) ([]string, []string, error) {
@@ -361,6 +423,11 @@ func (gp *BaseTable) LoadYaml(fileName string) error {
 			panic(err)
 		}
 
+		source := sourceFile
+		if _, ok := loadFromEnv(key); ok {
+			source = sourceEnv
+		}
+		log.Debug("param loaded", zap.String("key", key), zap.String("source", source))
 	}
 
 	return nil
@@ -374,16 +441,30 @@ func (gp *BaseTable) Save(key, value string) error {
 	return gp.params.Save(strings.ToLower(key), value)
 }
 
+// paramError reports that value of key can not be used as the param
+func paramError(key string, value string, err error) error {
+	return fmt.Errorf("invalid value %q of param %s: %w", value, key, err)
+}
+
 func (gp *BaseTable) ParseBool(key string, defaultValue bool) bool {
-	valueStr, err := gp.LoadWithDefault(key, strconv.FormatBool(defaultValue))
+	value, err := gp.TryParseBool(key, defaultValue)
 	if err != nil {
 		panic(err)
 	}
+	return value
+}
+
+// TryParseBool returns the bool value of key, defaultValue is returned if the key is not set
+func (gp *BaseTable) TryParseBool(key string, defaultValue bool) (bool, error) {
+	valueStr, err := gp.LoadWithDefault(key, strconv.FormatBool(defaultValue))
+	if err != nil {
+		return false, err
+	}
 	value, err := strconv.ParseBool(valueStr)
 	if err != nil {
-		panic(err)
+		return false, paramError(key, valueStr, err)
 	}
-	return value
+	return value, nil
 }
 
 func (gp *BaseTable) ParseFloat(key string) float64 {
@@ -393,7 +474,7 @@ func (gp *BaseTable) ParseFloat(key string) float64 {
 	}
 	value, err := strconv.ParseFloat(valueStr, 64)
 	if err != nil {
-		panic(err)
+		panic(paramError(key, valueStr, err))
 	}
 	return value
 }
@@ -405,11 +486,50 @@ func (gp *BaseTable) ParseInt64(key string) int64 {
 	}
 	value, err := strconv.ParseInt(valueStr, 10, 64)
 	if err != nil {
-		panic(err)
+		panic(paramError(key, valueStr, err))
 	}
 	return value
 }
 
+// ParseInt64WithBounds returns the int64 value of key, defaultValue is returned if the key is not set.
+// An error is returned if the value is not within [min, max]
+func (gp *BaseTable) ParseInt64WithBounds(key string, defaultValue, min, max int64) (int64, error) {
+	valueStr, err := gp.LoadWithDefault(key, strconv.FormatInt(defaultValue, 10))
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(valueStr, 10, 64)
+	if err != nil {
+		return 0, paramError(key, valueStr, err)
+	}
+	if value < min || value > max {
+		return 0, paramError(key, valueStr, fmt.Errorf("out of range [%d, %d]", min, max))
+	}
+	return value, nil
+}
+
+// ParseDuration returns the duration value of key, defaultValue is returned if the key is not set.
+// The value is either a duration string such as "1m30s" or a plain integer counted in unit
+func (gp *BaseTable) ParseDuration(key string, defaultValue time.Duration, unit time.Duration) (time.Duration, error) {
+	valueStr, err := gp.LoadWithDefault(key, "")
+	if err != nil {
+		return 0, err
+	}
+	if valueStr == "" {
+		return defaultValue, nil
+	}
+	var value time.Duration
+	if n, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
+		value = time.Duration(n) * unit
+	} else if value, err = time.ParseDuration(valueStr); err != nil {
+		return 0, paramError(key, valueStr, err)
+	}
+	if value < 0 {
+		return 0, paramError(key, valueStr, errors.New("negative duration"))
+	}
+	return value, nil
+}
+
 func (gp *BaseTable) ParseInt32(key string) int32 {
 	valueStr, err := gp.Load(key)
 	if err != nil {
@@ -417,7 +537,7 @@ func (gp *BaseTable) ParseInt32(key string) int32 {
 	}
 	value, err := strconv.ParseInt(valueStr, 10, 32)
 	if err != nil {
-		panic(err)
+		panic(paramError(key, valueStr, err))
 	}
 	return int32(value)
 }
@@ -429,7 +549,7 @@ func (gp *BaseTable) ParseInt(key string) int {
 	}
 	value, err := strconv.Atoi(valueStr)
 	if err != nil {
-		panic(err)
+		panic(paramError(key, valueStr, err))
 	}
 	return value
 }
@@ -494,6 +614,7 @@ func (gp *BaseTable) SetLogConfig(f func(log.Config)) {
 }
 
 func (gp *BaseTable) SetLogger(id UniqueID) {
+	log.SetRole(gp.RoleName)
 	rootPath, err := gp.Load("log.file.rootPath")
 	if err != nil {
 		panic(err)
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"path/filepath"
 
@@ -211,6 +212,109 @@ func TestBaseTable_Parse(t *testing.T) {
 	})
 }
 
+func TestBaseTable_EnvOverride(t *testing.T) {
+	assert.Equal(t, "QUERYCOORD_STALLTHRESHOLD", EnvKey("queryCoord.stallThreshold"))
+
+	assert.Nil(t, baseParams.Save("test.envOverride", "file"))
+	defer baseParams.Remove("test.envOverride")
+
+	value, err := baseParams.Load("test.envOverride")
+	assert.Nil(t, err)
+	assert.Equal(t, "file", value)
+
+	os.Setenv("TEST_ENVOVERRIDE", "env")
+	defer os.Unsetenv("TEST_ENVOVERRIDE")
+	value, err = baseParams.Load("test.envOverride")
+	assert.Nil(t, err)
+	assert.Equal(t, "env", value)
+	value, err = baseParams.LoadWithDefault("test.envOverride", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, "env", value)
+
+	// an empty variable doesn't override the config
+	os.Setenv("TEST_ENVOVERRIDE", "")
+	value, err = baseParams.Load("test.envOverride")
+	assert.Nil(t, err)
+	assert.Equal(t, "file", value)
+
+	value, err = baseParams.LoadWithDefault("test.notExist", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, "default", value)
+	os.Setenv("TEST_NOTEXIST", "env")
+	defer os.Unsetenv("TEST_NOTEXIST")
+	value, err = baseParams.LoadWithDefault("test.notExist", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, "env", value)
+}
+
+func TestBaseTable_TypedParse(t *testing.T) {
+	t.Run("TryParseBool", func(t *testing.T) {
+		value, err := baseParams.TryParseBool("not_exist_key", true)
+		assert.Nil(t, err)
+		assert.True(t, value)
+
+		assert.Nil(t, baseParams.Save("key", "rand"))
+		_, err = baseParams.TryParseBool("key", false)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "key")
+
+		os.Setenv("KEY", "false")
+		defer os.Unsetenv("KEY")
+		value, err = baseParams.TryParseBool("key", true)
+		assert.Nil(t, err)
+		assert.False(t, value)
+	})
+
+	t.Run("ParseInt64WithBounds", func(t *testing.T) {
+		value, err := baseParams.ParseInt64WithBounds("not_exist_key", 5, 0, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(5), value)
+
+		assert.Nil(t, baseParams.Save("test.int", "7"))
+		value, err = baseParams.ParseInt64WithBounds("test.int", 5, 0, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(7), value)
+
+		_, err = baseParams.ParseInt64WithBounds("test.int", 5, 0, 6)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "test.int")
+
+		assert.Nil(t, baseParams.Save("test.int", "abc"))
+		_, err = baseParams.ParseInt64WithBounds("test.int", 5, 0, 10)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "test.int")
+	})
+
+	t.Run("ParseDuration", func(t *testing.T) {
+		value, err := baseParams.ParseDuration("not_exist_key", time.Minute, time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Minute, value)
+
+		assert.Nil(t, baseParams.Save("test.duration", "30"))
+		value, err = baseParams.ParseDuration("test.duration", time.Minute, time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, 30*time.Second, value)
+
+		assert.Nil(t, baseParams.Save("test.duration", "1m30s"))
+		value, err = baseParams.ParseDuration("test.duration", time.Minute, time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, 90*time.Second, value)
+
+		for _, invalid := range []string{"abc", "-5", "-1s"} {
+			assert.Nil(t, baseParams.Save("test.duration", invalid))
+			_, err = baseParams.ParseDuration("test.duration", time.Minute, time.Second)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "test.duration")
+		}
+
+		os.Setenv("TEST_DURATION", "2h")
+		defer os.Unsetenv("TEST_DURATION")
+		value, err = baseParams.ParseDuration("test.duration", time.Minute, time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, 2*time.Hour, value)
+	})
+}
+
 func Test_ConvertRangeToIntSlice(t *testing.T) {
 	t.Run("ConvertRangeToIntSlice", func(t *testing.T) {
 		slice := ConvertRangeToIntSlice("0,10", ",")
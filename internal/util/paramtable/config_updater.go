@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package paramtable
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+// LogLevelKey is the key of the log level, which every component can update at runtime
+const LogLevelKey = "log.level"
+
+// ConfigHandler validates, applies and gets a config which can be updated at runtime
+type ConfigHandler struct {
+	// Validate checks value before any config of an update is applied
+	Validate func(value string) error
+	// Apply applies the validated value
+	Apply func(value string)
+	// Get returns the effective value
+	Get func() string
+}
+
+// ConfigUpdater updates the whitelisted configs at runtime. An update is applied only if all of its configs are
+// whitelisted and valid, the applied values are saved into the table as well.
+type ConfigUpdater struct {
+	mu       sync.Mutex
+	table    *BaseTable
+	keys     map[string]string // lower case key -> key
+	handlers map[string]ConfigHandler
+}
+
+// NewConfigUpdater returns a ConfigUpdater saving the applied values into table, the log level is whitelisted
+func NewConfigUpdater(table *BaseTable) *ConfigUpdater {
+	u := &ConfigUpdater{
+		table:    table,
+		keys:     make(map[string]string),
+		handlers: make(map[string]ConfigHandler),
+	}
+	u.Register(LogLevelKey, ConfigHandler{
+		Validate: func(value string) error {
+			var level zapcore.Level
+			return level.UnmarshalText([]byte(value))
+		},
+		Apply: func(value string) {
+			var level zapcore.Level
+			_ = level.UnmarshalText([]byte(value))
+			log.SetLevel(level)
+		},
+		Get: func() string {
+			return log.GetLevel().String()
+		},
+	})
+	return u
+}
+
+// Register whitelists key, the keys are case insensitive
+func (u *ConfigUpdater) Register(key string, handler ConfigHandler) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	lower := strings.ToLower(key)
+	u.keys[lower] = key
+	u.handlers[lower] = handler
+}
+
+// Keys returns the whitelisted keys in order
+func (u *ConfigUpdater) Keys() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	keys := make([]string, 0, len(u.keys))
+	for _, key := range u.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Update applies configs and returns the effective values of them, nothing is applied if any of configs is not
+// whitelisted or invalid.
+func (u *ConfigUpdater) Update(configs map[string]string) (map[string]string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for key, value := range configs {
+		handler, ok := u.handlers[strings.ToLower(key)]
+		if !ok {
+			return nil, fmt.Errorf("config %s can't be updated at runtime", key)
+		}
+		if err := handler.Validate(value); err != nil {
+			return nil, fmt.Errorf("invalid value %s of config %s: %w", value, key, err)
+		}
+	}
+
+	effective := make(map[string]string, len(configs))
+	for key, value := range configs {
+		handler := u.handlers[strings.ToLower(key)]
+		handler.Apply(value)
+		effective[key] = handler.Get()
+		if u.table != nil {
+			if err := u.table.Save(key, effective[key]); err != nil {
+				log.Warn("failed to save config", zap.String("key", key), zap.Error(err))
+			}
+		}
+	}
+	log.Info("configs updated", zap.Any("configs", effective))
+	return effective, nil
+}
+
+// UpdateByMetricsRequest applies the configs in a GetMetrics request of UpdateConfigMetrics,
+// the effective values are returned in json.
+func (u *ConfigUpdater) UpdateByMetricsRequest(req *milvuspb.GetMetricsRequest, componentName string) *milvuspb.GetMetricsResponse {
+	failed := func(err error) *milvuspb.GetMetricsResponse {
+		return &milvuspb.GetMetricsResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_UnexpectedError,
+				Reason:    err.Error(),
+			},
+			ComponentName: componentName,
+		}
+	}
+
+	configs, err := metricsinfo.ParseConfigs(req.Request)
+	if err != nil {
+		return failed(err)
+	}
+	effective, err := u.Update(configs)
+	if err != nil {
+		return failed(err)
+	}
+	resp, err := json.Marshal(effective)
+	if err != nil {
+		return failed(err)
+	}
+	return &milvuspb.GetMetricsResponse{
+		Status:        &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Response:      string(resp),
+		ComponentName: componentName,
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package paramtable
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/commonpb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+func TestConfigUpdater(t *testing.T) {
+	level := log.GetLevel()
+	defer log.SetLevel(level)
+	log.SetLevel(zap.DebugLevel)
+
+	limit := 0
+	u := NewConfigUpdater(&baseParams)
+	u.Register("test.limit", ConfigHandler{
+		Validate: func(value string) error {
+			v, err := strconv.Atoi(value)
+			if err == nil && v < 0 {
+				return errors.New("negative limit")
+			}
+			return err
+		},
+		Apply: func(value string) {
+			limit, _ = strconv.Atoi(value)
+		},
+		Get: func() string {
+			return strconv.Itoa(limit)
+		},
+	})
+	assert.Equal(t, []string{LogLevelKey, "test.limit"}, u.Keys())
+
+	t.Run("update log level", func(t *testing.T) {
+		assert.True(t, log.L().Core().Enabled(zap.DebugLevel))
+
+		effective, err := u.Update(map[string]string{LogLevelKey: "warn"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{LogLevelKey: "warn"}, effective)
+		assert.False(t, log.L().Core().Enabled(zap.DebugLevel))
+		assert.False(t, log.L().Core().Enabled(zap.InfoLevel))
+		assert.True(t, log.L().Core().Enabled(zap.WarnLevel))
+		value, err := baseParams.Load(LogLevelKey)
+		assert.NoError(t, err)
+		assert.Equal(t, "warn", value)
+
+		effective, err = u.Update(map[string]string{"LOG.Level": "DEBUG"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"LOG.Level": "debug"}, effective)
+		assert.True(t, log.L().Core().Enabled(zap.DebugLevel))
+	})
+
+	t.Run("update atomically", func(t *testing.T) {
+		_, err := u.Update(map[string]string{LogLevelKey: "error", "test.limit": "10"})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, limit)
+		assert.Equal(t, zap.ErrorLevel, log.GetLevel())
+
+		// nothing is applied if any config is invalid
+		_, err = u.Update(map[string]string{LogLevelKey: "info", "test.limit": "-1"})
+		assert.Error(t, err)
+		assert.Equal(t, 10, limit)
+		assert.Equal(t, zap.ErrorLevel, log.GetLevel())
+
+		_, err = u.Update(map[string]string{LogLevelKey: "verbose", "test.limit": "20"})
+		assert.Error(t, err)
+		assert.Equal(t, 10, limit)
+		assert.Equal(t, zap.ErrorLevel, log.GetLevel())
+	})
+
+	t.Run("reject not whitelisted config", func(t *testing.T) {
+		_, err := u.Update(map[string]string{"test.limit": "30", "etcd.endpoints": "localhost:2379"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "etcd.endpoints")
+		assert.Equal(t, 10, limit)
+	})
+
+	t.Run("update by metrics request", func(t *testing.T) {
+		req, err := metricsinfo.ConstructUpdateConfigRequest(map[string]string{"test.limit": "40"})
+		assert.NoError(t, err)
+		resp := u.UpdateByMetricsRequest(req, "test")
+		assert.Equal(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.Equal(t, "test", resp.ComponentName)
+		effective := make(map[string]string)
+		assert.NoError(t, json.Unmarshal([]byte(resp.Response), &effective))
+		assert.Equal(t, map[string]string{"test.limit": "40"}, effective)
+		assert.Equal(t, 40, limit)
+
+		req, err = metricsinfo.ConstructUpdateConfigRequest(map[string]string{"unknown": "1"})
+		assert.NoError(t, err)
+		resp = u.UpdateByMetricsRequest(req, "test")
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+
+		req, err = metricsinfo.ConstructRequestByMetricType(metricsinfo.UpdateConfigMetrics)
+		assert.NoError(t, err)
+		resp = u.UpdateByMetricsRequest(req, "test")
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, resp.Status.ErrorCode)
+	})
+}
@@ -13,14 +13,23 @@ package paramtable
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 
 	"github.com/milvus-io/milvus/internal/log"
 )
 
+const (
+	// MsgStreamTypePulsar selects pulsar as the message queue of the msgstreams in cluster mode
+	MsgStreamTypePulsar = "pulsar"
+	// MsgStreamTypeKafka selects kafka as the message queue of the msgstreams in cluster mode
+	MsgStreamTypeKafka = "kafka"
+)
+
 // Params is a package scoped variable of type BaseParamTable.
 var Params BaseParamTable
 var once sync.Once
@@ -43,6 +52,22 @@ type BaseParamTable struct {
 	initOnce sync.Once
 
 	LogConfig *log.Config
+
+	// MetricsPort is the port serving the prometheus metrics and the health check of the process
+	MetricsPort int
+
+	// HealthzEnabled enables the health check server serving /healthz and /readyz of the components in the process
+	HealthzEnabled bool
+	// HealthzPort is the port of the health check server
+	HealthzPort int
+
+	// MsgStreamType is the message queue of the msgstreams in cluster mode, standalone always uses rocksmq
+	MsgStreamType string
+	// KafkaAddress is the comma separated host:port list of the kafka bootstrap brokers
+	KafkaAddress string
+
+	// ShutdownStageTimeout is the max duration to wait for each stage of the components to stop in standalone mode
+	ShutdownStageTimeout time.Duration
 }
 
 // Init is an override method of BaseTable's Init. It mainly calls the
@@ -60,6 +85,11 @@ func (p *BaseParamTable) LoadCfgToMemory() {
 	p.initMetaRootPath()
 	p.initKvRootPath()
 	p.initLogCfg()
+	p.initMetricsPort()
+	p.initHealthz()
+	p.initShutdownStageTimeout()
+	p.initMsgStreamType()
+	p.initKafkaAddress()
 }
 
 func (p *BaseParamTable) initEtcdConf() {
@@ -147,3 +177,59 @@ func (p *BaseParamTable) initLogCfg() {
 		panic(err)
 	}
 }
+
+func (p *BaseParamTable) initMetricsPort() {
+	port, err := p.LoadWithDefault("metrics.port", "9091")
+	if err != nil {
+		panic(err)
+	}
+	p.MetricsPort, err = strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (p *BaseParamTable) initHealthz() {
+	p.HealthzEnabled = p.ParseBool("healthz.enabled", true)
+	port, err := p.LoadWithDefault("healthz.port", "9095")
+	if err != nil {
+		panic(err)
+	}
+	p.HealthzPort, err = strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (p *BaseParamTable) initShutdownStageTimeout() {
+	timeout, err := p.LoadWithDefault("common.shutdownStageTimeout", "60")
+	if err != nil {
+		panic(err)
+	}
+	seconds, err := strconv.ParseInt(timeout, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	p.ShutdownStageTimeout = time.Duration(seconds) * time.Second
+}
+
+func (p *BaseParamTable) initMsgStreamType() {
+	msgStreamType, err := p.LoadWithDefault("msgStream.type", MsgStreamTypePulsar)
+	if err != nil {
+		panic(err)
+	}
+	switch msgStreamType {
+	case MsgStreamTypePulsar, MsgStreamTypeKafka:
+		p.MsgStreamType = msgStreamType
+	default:
+		panic("unknown msgStream.type " + msgStreamType)
+	}
+}
+
+func (p *BaseParamTable) initKafkaAddress() {
+	address, err := p.Load("_KafkaAddress")
+	if err != nil {
+		panic(err)
+	}
+	p.KafkaAddress = address
+}
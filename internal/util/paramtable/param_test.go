@@ -14,6 +14,7 @@ package paramtable
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 
@@ -32,6 +33,13 @@ func TestBaseParamTable(t *testing.T) {
 	assert.NotEqual(t, Params.KvRootPath, "")
 	t.Logf("kv root path = %s", Params.KvRootPath)
 
+	assert.Equal(t, 9091, Params.MetricsPort)
+	assert.True(t, Params.HealthzEnabled)
+	assert.Equal(t, 9095, Params.HealthzPort)
+	assert.Equal(t, 60*time.Second, Params.ShutdownStageTimeout)
+	assert.Equal(t, MsgStreamTypePulsar, Params.MsgStreamType)
+	assert.Equal(t, "localhost:9092", Params.KafkaAddress)
+
 	// test UseEmbedEtcd
 	Params.Save("etcd.use.embed", "true")
 	assert.Nil(t, os.Setenv(metricsinfo.DeployModeEnvKey, metricsinfo.ClusterDeployMode))
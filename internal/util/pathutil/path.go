@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package pathutil
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// The layout versions of the objects in storage, a segment records the layout its objects are written with,
+// so that segments of different layouts can coexist while the objects are migrated
+const (
+	// LegacyLayoutVersion is recorded by the segments created before the layout version is introduced,
+	// their objects follow LayoutVersionV1
+	LegacyLayoutVersion int32 = 0
+	// LayoutVersionV1 is {root}/{kind}/{collection}/{partition}/{segment}[/{field}]/{log}
+	// for binlogs, and {root}/index_files/{build}/{version}/{partition}/{segment}/{key} for index files
+	LayoutVersionV1 int32 = 1
+	// CurrentLayoutVersion is the layout the new objects are written with
+	CurrentLayoutVersion = LayoutVersionV1
+)
+
+const (
+	// InsertLogDir is the directory of insert binlogs under the storage root path
+	InsertLogDir = "insert_log"
+	// StatsLogDir is the directory of statslogs under the storage root path
+	StatsLogDir = "stats_log"
+	// DeltaLogDir is the directory of deltalogs under the storage root path
+	DeltaLogDir = "delta_log"
+	// IndexFileDir is the directory of index files under the storage root path
+	IndexFileDir = "index_files"
+
+	pathSep = "/"
+)
+
+// ErrInvalidPath is returned when a path doesn't follow the layout of this package
+var ErrInvalidPath = errors.New("invalid storage path")
+
+// ErrUnsupportedLayout is returned when a layout version is unknown to this package
+var ErrUnsupportedLayout = errors.New("unsupported storage layout version")
+
+// CheckLayoutVersion checks that the objects written with version can be located by this package
+func CheckLayoutVersion(version int32) error {
+	if version < LegacyLayoutVersion || version > CurrentLayoutVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedLayout, version)
+	}
+	return nil
+}
+
+// BinlogPathInfo holds the ids encoded in an insert binlog, statslog or deltalog path,
+// FieldID is 0 for deltalogs
+type BinlogPathInfo struct {
+	CollectionID int64
+	PartitionID  int64
+	SegmentID    int64
+	FieldID      int64
+	LogID        int64
+}
+
+// IndexFilePathInfo holds the ids and the key encoded in an index file path
+type IndexFilePathInfo struct {
+	IndexBuildID int64
+	Version      int64
+	PartitionID  int64
+	SegmentID    int64
+	Key          string
+}
+
+// InsertLogRootPath returns the directory of insert binlogs under rootPath
+func InsertLogRootPath(rootPath string) string {
+	return path.Join(rootPath, InsertLogDir)
+}
+
+// StatsLogRootPath returns the directory of statslogs under rootPath
+func StatsLogRootPath(rootPath string) string {
+	return path.Join(rootPath, StatsLogDir)
+}
+
+// DeltaLogRootPath returns the directory of deltalogs under rootPath
+func DeltaLogRootPath(rootPath string) string {
+	return path.Join(rootPath, DeltaLogDir)
+}
+
+// IndexFileRootPath returns the directory of index files under rootPath
+func IndexFileRootPath(rootPath string) string {
+	return path.Join(rootPath, IndexFileDir)
+}
+
+// InsertBinlogPath returns the path of an insert binlog of a field
+func InsertBinlogPath(rootPath string, collectionID, partitionID, segmentID, fieldID, logID int64) string {
+	return joinIDs(InsertLogRootPath(rootPath), collectionID, partitionID, segmentID, fieldID, logID)
+}
+
+// StatslogPath returns the path of a statslog of a field
+func StatslogPath(rootPath string, collectionID, partitionID, segmentID, fieldID, logID int64) string {
+	return joinIDs(StatsLogRootPath(rootPath), collectionID, partitionID, segmentID, fieldID, logID)
+}
+
+// DeltalogPath returns the path of a deltalog of a segment
+func DeltalogPath(rootPath string, collectionID, partitionID, segmentID, logID int64) string {
+	return joinIDs(DeltaLogRootPath(rootPath), collectionID, partitionID, segmentID, logID)
+}
+
+// IndexFilePath returns the path of an index file saved by a version of an index build
+func IndexFilePath(rootPath string, indexBuildID, version, partitionID, segmentID int64, key string) string {
+	return path.Join(joinIDs(IndexFileRootPath(rootPath), indexBuildID, version, partitionID, segmentID), key)
+}
+
+// ParseInsertBinlogPath validates p as an insert binlog path under rootPath and extracts its ids
+func ParseInsertBinlogPath(rootPath string, p string) (*BinlogPathInfo, error) {
+	ids, err := splitIDs(InsertLogRootPath(rootPath), p, 5)
+	if err != nil {
+		return nil, err
+	}
+	return &BinlogPathInfo{CollectionID: ids[0], PartitionID: ids[1], SegmentID: ids[2], FieldID: ids[3], LogID: ids[4]}, nil
+}
+
+// ParseStatslogPath validates p as a statslog path under rootPath and extracts its ids
+func ParseStatslogPath(rootPath string, p string) (*BinlogPathInfo, error) {
+	ids, err := splitIDs(StatsLogRootPath(rootPath), p, 5)
+	if err != nil {
+		return nil, err
+	}
+	return &BinlogPathInfo{CollectionID: ids[0], PartitionID: ids[1], SegmentID: ids[2], FieldID: ids[3], LogID: ids[4]}, nil
+}
+
+// ParseDeltalogPath validates p as a deltalog path under rootPath and extracts its ids
+func ParseDeltalogPath(rootPath string, p string) (*BinlogPathInfo, error) {
+	ids, err := splitIDs(DeltaLogRootPath(rootPath), p, 4)
+	if err != nil {
+		return nil, err
+	}
+	return &BinlogPathInfo{CollectionID: ids[0], PartitionID: ids[1], SegmentID: ids[2], LogID: ids[3]}, nil
+}
+
+// ParseIndexFilePath validates p as an index file path under rootPath and extracts its ids and key
+func ParseIndexFilePath(rootPath string, p string) (*IndexFilePathInfo, error) {
+	idx := strings.LastIndex(p, pathSep)
+	if idx < 0 || idx == len(p)-1 {
+		return nil, fmt.Errorf("%w: %s has no index file key", ErrInvalidPath, p)
+	}
+	ids, err := splitIDs(IndexFileRootPath(rootPath), p[:idx], 4)
+	if err != nil {
+		return nil, err
+	}
+	return &IndexFilePathInfo{IndexBuildID: ids[0], Version: ids[1], PartitionID: ids[2], SegmentID: ids[3], Key: p[idx+1:]}, nil
+}
+
+func joinIDs(dir string, ids ...int64) string {
+	elems := make([]string, 0, len(ids)+1)
+	elems = append(elems, dir)
+	for _, id := range ids {
+		elems = append(elems, strconv.FormatInt(id, 10))
+	}
+	return path.Join(elems...)
+}
+
+// splitIDs checks that p is dir followed by exactly n non-negative ids, and returns the ids
+func splitIDs(dir string, p string, n int) ([]int64, error) {
+	prefix := dir + pathSep
+	if !strings.HasPrefix(p, prefix) {
+		return nil, fmt.Errorf("%w: %s is not under %s", ErrInvalidPath, p, dir)
+	}
+	elems := strings.Split(p[len(prefix):], pathSep)
+	if len(elems) != n {
+		return nil, fmt.Errorf("%w: %s has %d ids, expected %d", ErrInvalidPath, p, len(elems), n)
+	}
+	ids := make([]int64, 0, n)
+	for _, elem := range elems {
+		id, err := strconv.ParseInt(elem, 10, 64)
+		if err != nil || id < 0 || strconv.FormatInt(id, 10) != elem {
+			return nil, fmt.Errorf("%w: %s has illegal id %q", ErrInvalidPath, p, elem)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
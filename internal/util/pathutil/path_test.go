@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package pathutil
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLayoutVersion(t *testing.T) {
+	assert.NoError(t, CheckLayoutVersion(LegacyLayoutVersion))
+	assert.NoError(t, CheckLayoutVersion(LayoutVersionV1))
+	assert.NoError(t, CheckLayoutVersion(CurrentLayoutVersion))
+	for _, version := range []int32{-1, CurrentLayoutVersion + 1} {
+		err := CheckLayoutVersion(version)
+		assert.True(t, errors.Is(err, ErrUnsupportedLayout), version)
+	}
+}
+
+func TestBinlogPath(t *testing.T) {
+	for _, rootPath := range []string{"files", "/var/lib/milvus/files", ""} {
+		p := InsertBinlogPath(rootPath, 1, 2, 3, 100, 4)
+		assert.Equal(t, InsertLogRootPath(rootPath)+"/1/2/3/100/4", p)
+		info, err := ParseInsertBinlogPath(rootPath, p)
+		assert.NoError(t, err)
+		assert.Equal(t, &BinlogPathInfo{CollectionID: 1, PartitionID: 2, SegmentID: 3, FieldID: 100, LogID: 4}, info)
+
+		p = StatslogPath(rootPath, 1, 2, 3, 100, math.MaxInt64)
+		assert.Equal(t, StatsLogRootPath(rootPath)+"/1/2/3/100/9223372036854775807", p)
+		info, err = ParseStatslogPath(rootPath, p)
+		assert.NoError(t, err)
+		assert.Equal(t, &BinlogPathInfo{CollectionID: 1, PartitionID: 2, SegmentID: 3, FieldID: 100, LogID: math.MaxInt64}, info)
+
+		p = DeltalogPath(rootPath, 1, 2, 3, 0)
+		assert.Equal(t, DeltaLogRootPath(rootPath)+"/1/2/3/0", p)
+		info, err = ParseDeltalogPath(rootPath, p)
+		assert.NoError(t, err)
+		assert.Equal(t, &BinlogPathInfo{CollectionID: 1, PartitionID: 2, SegmentID: 3, LogID: 0}, info)
+	}
+	assert.Equal(t, "files/insert_log", InsertLogRootPath("files"))
+	assert.Equal(t, "files/stats_log", StatsLogRootPath("files/"))
+	assert.Equal(t, "delta_log", DeltaLogRootPath(""))
+
+	invalid := []string{
+		"files/insert_log/1/2/3/100",
+		"files/insert_log/1/2/3/100/4/5",
+		"files/insert_log/1/2/3/100/a",
+		"files/insert_log/1/2/3/100/-4",
+		"files/insert_log/1/2/3/100/04",
+		"files/insert_log/1/2/3/100/",
+		"files/insert_log1/2/3/100/4",
+		"files/stats_log/1/2/3/100/4",
+		"other/insert_log/1/2/3/100/4",
+		"",
+	}
+	for _, p := range invalid {
+		_, err := ParseInsertBinlogPath("files", p)
+		assert.True(t, errors.Is(err, ErrInvalidPath), p)
+	}
+	_, err := ParseStatslogPath("files", "files/insert_log/1/2/3/100/4")
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+	_, err = ParseDeltalogPath("files", "files/delta_log/1/2/3/100/4")
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+}
+
+func TestIndexFilePath(t *testing.T) {
+	for _, rootPath := range []string{"files", "/var/lib/milvus/files", ""} {
+		p := IndexFilePath(rootPath, 1, 2, 3, 4, "IVF")
+		assert.Equal(t, IndexFileRootPath(rootPath)+"/1/2/3/4/IVF", p)
+		info, err := ParseIndexFilePath(rootPath, p)
+		assert.NoError(t, err)
+		assert.Equal(t, &IndexFilePathInfo{IndexBuildID: 1, Version: 2, PartitionID: 3, SegmentID: 4, Key: "IVF"}, info)
+	}
+
+	invalid := []string{
+		"files/index_files/1/2/3/4/",
+		"files/index_files/1/2/3/IVF",
+		"files/index_files/1/2/3/4/5/IVF",
+		"files/index_files/1/x/3/4/IVF",
+		"files/insert_log/1/2/3/4/IVF",
+		"IVF",
+	}
+	for _, p := range invalid {
+		_, err := ParseIndexFilePath("files", p)
+		assert.True(t, errors.Is(err, ErrInvalidPath), p)
+	}
+}
@@ -80,6 +80,15 @@ func (c *client) Subscribe(options ConsumerOptions) (Consumer, error) {
 				return nil, err
 			}
 		}
+		if options.Owner != "" {
+			// the consumer group is taken over by the new owner
+			c.server.RegisterConsumer(&server.Consumer{
+				Topic:     options.Topic,
+				GroupName: options.SubscriptionName,
+				MsgMutex:  con.MsgMutex,
+				Owner:     options.Owner,
+			})
+		}
 		c.wg.Add(1)
 		go c.consume(consumer)
 		return consumer, nil
@@ -106,6 +115,7 @@ func (c *client) Subscribe(options ConsumerOptions) (Consumer, error) {
 		Topic:     consumer.topic,
 		GroupName: consumer.consumerName,
 		MsgMutex:  consumer.msgMutex,
+		Owner:     options.Owner,
 	}
 	c.server.RegisterConsumer(cons)
 
@@ -11,7 +11,11 @@
 
 package rocksmq
 
-import server "github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
+import (
+	"time"
+
+	server "github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
+)
 
 // SubscriptionInitialPosition is the initial subscription position
 type SubscriptionInitialPosition int
@@ -45,6 +49,10 @@ type ConsumerOptions struct {
 	// Message for this consumer
 	// When a message is received, it will be pushed to this channel for consumption
 	MessageChannel chan ConsumerMessage
+
+	// Owner is the session owning this consumer, the consumer group is expired by rocksmq once the owner is gone.
+	// Empty means the consumer group is kept until it's closed
+	Owner string
 }
 
 // ConsumerMessage is the message content of a consumer message
@@ -72,6 +80,9 @@ type Consumer interface {
 	// Seek to the uniqueID position
 	Seek(UniqueID) error //nolint:govet
 
+	// SeekByTime seeks to the first message published at or after t
+	SeekByTime(t time.Time) error
+
 	// Close consumer
 	Close()
 }
@@ -12,6 +12,8 @@
 package rocksmq
 
 import (
+	"time"
+
 	"github.com/milvus-io/milvus/internal/log"
 	"go.uber.org/zap"
 )
@@ -108,6 +110,16 @@ func (c *consumer) Seek(id UniqueID) error { //nolint:govet
 	return nil
 }
 
+// SeekByTime seek rocksmq position to the first message published at or after t and notify consumer to consume
+func (c *consumer) SeekByTime(t time.Time) error {
+	err := c.client.server.SeekByTime(c.topic, c.consumerName, t)
+	if err != nil {
+		return err
+	}
+	c.client.server.Notify(c.topic, c.consumerName)
+	return nil
+}
+
 // Close destroy current consumer in rocksmq
 func (c *consumer) Close() {
 	err := c.client.server.DestroyConsumerGroup(c.topic, c.consumerName)
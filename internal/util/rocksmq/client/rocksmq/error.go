@@ -11,7 +11,14 @@
 
 package rocksmq
 
-import "fmt"
+import (
+	"fmt"
+
+	server "github.com/milvus-io/milvus/internal/util/rocksmq/server/rocksmq"
+)
+
+// ErrPositionExpired is returned when seeking to a position whose following messages are removed by retention
+var ErrPositionExpired = server.ErrPositionExpired
 
 // Result is the type of int and represent error result
 type Result int
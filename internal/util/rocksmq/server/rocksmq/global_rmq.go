@@ -13,11 +13,13 @@ package rocksmq
 
 import (
 	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
 	"github.com/milvus-io/milvus/internal/allocator"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"go.uber.org/zap"
 
@@ -64,17 +66,36 @@ func InitRocksMQ() error {
 		idAllocator := allocator.NewGlobalIDAllocator("rmq_id", rocksdbKV)
 		_ = idAllocator.Initialize()
 
-		atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, params.ParseInt64("rocksmq.retentionTimeInMinutes"))
+		retentionDuration, _ := params.LoadWithDefault("common.retentionDuration", "432000")
+		retentionSeconds, err := strconv.ParseInt(retentionDuration, 10, 64)
+		if err != nil {
+			panic(err)
+		}
+		atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, retentionTimeInMinutes(params.ParseInt64("rocksmq.retentionTimeInMinutes"), retentionSeconds))
 		atomic.StoreInt64(&RocksmqRetentionSizeInMB, params.ParseInt64("rocksmq.retentionSizeInMB"))
 		log.Debug("Rocksmq retention: ", zap.Any("RocksmqRetentionTimeInMinutes", RocksmqRetentionTimeInMinutes), zap.Any("RocksmqRetentionSizeInMB", RocksmqRetentionSizeInMB))
 		Rmq, err = NewRocksMQ(rocksdbName, idAllocator)
 		if err != nil {
 			panic(err)
 		}
+		metrics.RegisterRocksMQ()
 	})
 	return err
 }
 
+// retentionTimeInMinutes keeps the acked messages at least as long as the time travel retention
+// duration, so that the history inside the retention window can still be replayed
+func retentionTimeInMinutes(configured int64, retentionSeconds int64) int64 {
+	if configured == -1 || retentionSeconds <= 0 {
+		return configured
+	}
+	minimum := (retentionSeconds + 59) / 60
+	if configured < minimum {
+		return minimum
+	}
+	return configured
+}
+
 // CloseRocksMQ is used to close global rocksmq
 func CloseRocksMQ() {
 	log.Debug("Close Rocksmq!")
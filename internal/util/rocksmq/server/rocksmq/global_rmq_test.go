@@ -69,3 +69,15 @@ func Test_InitRocksMQ(t *testing.T) {
 	}
 	Rmq.RegisterConsumer(consumer)
 }
+
+func Test_retentionTimeInMinutes(t *testing.T) {
+	// no limit is kept
+	assert.Equal(t, int64(-1), retentionTimeInMinutes(-1, 60))
+	// no time travel retention
+	assert.Equal(t, int64(10), retentionTimeInMinutes(10, 0))
+	// raised to cover the time travel retention
+	assert.Equal(t, int64(2), retentionTimeInMinutes(1, 61))
+	assert.Equal(t, int64(7200), retentionTimeInMinutes(4320, 432000))
+	// longer retention is kept
+	assert.Equal(t, int64(10), retentionTimeInMinutes(10, 60))
+}
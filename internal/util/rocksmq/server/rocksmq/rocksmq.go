@@ -11,6 +11,8 @@
 
 package rocksmq
 
+import "time"
+
 // ProducerMessage that will be write to rocksdb
 type ProducerMessage struct {
 	Payload []byte
@@ -21,8 +23,13 @@ type Consumer struct {
 	Topic     string
 	GroupName string
 	MsgMutex  chan struct{}
+	// Owner is the session owning the consumer, empty means the consumer is never expired by the janitor
+	Owner string
 }
 
+// ConsumerOwnerChecker reports whether the session owning consumers is still alive
+type ConsumerOwnerChecker func(owner string) bool
+
 // ConsumerMessage that consumed from rocksdb
 type ConsumerMessage struct {
 	MsgID   UniqueID
@@ -34,15 +41,19 @@ type ConsumerMessage struct {
 type RocksMQ interface {
 	CreateTopic(topicName string) error
 	DestroyTopic(topicName string) error
+	SetTopicRetention(topicName string, retentionTimeInMinutes, retentionSizeInMB int64) error
 	CreateConsumerGroup(topicName string, groupName string) error
 	DestroyConsumerGroup(topicName string, groupName string) error
 	Close()
 
 	RegisterConsumer(consumer *Consumer)
+	SetConsumerOwnerChecker(checker ConsumerOwnerChecker)
+	ConsumerLag(topicName string) (int64, error)
 
 	Produce(topicName string, messages []ProducerMessage) ([]UniqueID, error)
 	Consume(topicName string, groupName string, n int) ([]ConsumerMessage, error)
 	Seek(topicName string, groupName string, msgID UniqueID) error
+	SeekByTime(topicName string, groupName string, t time.Time) error
 	SeekToLatest(topicName, groupName string) error
 	ExistConsumerGroup(topicName string, groupName string) (bool, *Consumer)
 
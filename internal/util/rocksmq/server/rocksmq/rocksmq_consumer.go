@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package rocksmq
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// ConsumerJanitorTickerInSeconds is the interval of expiring the consumer groups whose owner is gone
+// and updating the lag metrics of topics, default 1 minute
+var ConsumerJanitorTickerInSeconds int64 = MINUTE
+
+// SetConsumerOwnerChecker sets the checker used by the janitor to find the consumer groups whose owner
+// session is gone. The consumer groups are never expired if no checker is set.
+func (rmq *rocksmq) SetConsumerOwnerChecker(checker ConsumerOwnerChecker) {
+	rmq.ownerChecker.Store(checker)
+}
+
+// ConsumerLag returns the id of the latest message of topic minus the smallest offset of the registered
+// consumer groups, which is 0 if the topic has no messages or no registered consumer groups
+func (rmq *rocksmq) ConsumerLag(topicName string) (int64, error) {
+	endIDVal, err := rmq.kv.LoadWithDefault(topicName+"/end_id", "")
+	if err != nil {
+		return 0, err
+	}
+	if endIDVal == "" || endIDVal == "0" {
+		return 0, nil
+	}
+	endID, err := strconv.ParseInt(endIDVal, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	beginIDVal, err := rmq.kv.LoadWithDefault(topicName+"/begin_id", "")
+	if err != nil {
+		return 0, err
+	}
+	beginID, err := strconv.ParseInt(beginIDVal, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	vals, ok := rmq.consumers.Load(topicName)
+	if !ok {
+		return 0, nil
+	}
+	var minOffset UniqueID = math.MaxInt64
+	for _, v := range vals.([]*Consumer) {
+		currentIDVal, err := rmq.kv.LoadWithDefault(constructCurrentID(topicName, v.GroupName), "")
+		if err != nil {
+			return 0, err
+		}
+		if currentIDVal == "" {
+			continue
+		}
+		// the group hasn't consumed any message, all the messages are lagged
+		offset := beginID - 1
+		if currentIDVal != DefaultMessageID {
+			offset, err = strconv.ParseInt(currentIDVal, 10, 64)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if offset < minOffset {
+			minOffset = offset
+		}
+	}
+	if minOffset == math.MaxInt64 {
+		return 0, nil
+	}
+	// end id is the one after the latest message
+	lag := endID - 1 - minOffset
+	if lag < 0 {
+		lag = 0
+	}
+	return lag, nil
+}
+
+func (rmq *rocksmq) saveConsumerOwner(topicName, groupName, owner string) error {
+	fixedOwnerKey, err := constructKey(ConsumerOwnerTitle, topicName)
+	if err != nil {
+		return err
+	}
+	return rmq.kv.Save(fixedOwnerKey+"/"+groupName, owner)
+}
+
+// expireConsumers destroys the consumer groups of topic whose owner session is gone, including the ones saved
+// before restart which are not registered any more, and returns the names of the expired groups
+func (rmq *rocksmq) expireConsumers(topicName string) ([]string, error) {
+	checker, _ := rmq.ownerChecker.Load().(ConsumerOwnerChecker)
+	if checker == nil {
+		return nil, nil
+	}
+	fixedOwnerKey, err := constructKey(ConsumerOwnerTitle, topicName)
+	if err != nil {
+		return nil, err
+	}
+	keys, owners, err := prefixLoad(rmq.kv.DB, fixedOwnerKey+"/")
+	if err != nil {
+		return nil, err
+	}
+	expired := make([]string, 0)
+	for i, key := range keys {
+		groupName := key[FixedChannelNameLen+1:]
+		if checker(owners[i]) {
+			continue
+		}
+		err = rmq.DestroyConsumerGroup(topicName, groupName)
+		if err != nil {
+			return expired, err
+		}
+		log.Info("Rocksmq expire consumer group of the gone owner", zap.String("topic", topicName),
+			zap.String("group", groupName), zap.String("owner", owners[i]))
+		metrics.RocksMQExpiredConsumerCounter.WithLabelValues(topicName).Inc()
+		expired = append(expired, groupName)
+	}
+	return expired, nil
+}
+
+// consumerJanitor expires the consumer groups whose owner is gone periodically, which pin the retention of
+// topics otherwise, and updates the lag metrics of topics
+type consumerJanitor struct {
+	rmq *rocksmq
+
+	closeCh   chan struct{}
+	closeWg   sync.WaitGroup
+	closeOnce sync.Once
+}
+
+func newConsumerJanitor(rmq *rocksmq) *consumerJanitor {
+	return &consumerJanitor{
+		rmq:     rmq,
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (j *consumerJanitor) start() {
+	j.closeWg.Add(1)
+	go j.work()
+}
+
+func (j *consumerJanitor) stop() {
+	j.closeOnce.Do(func() {
+		close(j.closeCh)
+		j.closeWg.Wait()
+	})
+}
+
+func (j *consumerJanitor) work() {
+	defer j.closeWg.Done()
+	ticker := time.NewTicker(time.Duration(atomic.LoadInt64(&ConsumerJanitorTickerInSeconds)) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-j.closeCh:
+			log.Debug("Rocksmq consumer janitor finish!")
+			return
+		case <-ticker.C:
+			j.clean()
+		}
+	}
+}
+
+func (j *consumerJanitor) clean() {
+	for _, topic := range j.rmq.retentionInfo.listTopics() {
+		if _, err := j.rmq.expireConsumers(topic); err != nil {
+			log.Warn("Rocksmq expire consumers failed", zap.String("topic", topic), zap.Error(err))
+		}
+		lag, err := j.rmq.ConsumerLag(topic)
+		if err != nil {
+			log.Warn("Rocksmq get consumer lag failed", zap.String("topic", topic), zap.Error(err))
+			continue
+		}
+		metrics.RocksMQConsumerLag.WithLabelValues(topic).Set(float64(lag))
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package rocksmq
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func topicBeginID(t *testing.T, rmq *rocksmq, topicName string) UniqueID {
+	val, err := rmq.kv.Load(TopicBeginIDTitle + topicName)
+	assert.Nil(t, err)
+	id, err := strconv.ParseInt(val, 10, 64)
+	assert.Nil(t, err)
+	return id
+}
+
+func TestRocksmq_DestroyConsumerGroupReleaseOffset(t *testing.T) {
+	atomic.StoreInt64(&RocksmqRetentionSizeInMB, 0)
+	atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, 0)
+	atomic.StoreInt64(&RocksmqPageSize, 10)
+	suffix := "release_offset"
+	kvPath := retentionPath + kvPathSuffix + suffix
+	defer os.RemoveAll(kvPath)
+	idAllocator := InitIDAllocator(kvPath)
+
+	rocksdbPath := retentionPath + dbPathSuffix + suffix
+	defer os.RemoveAll(rocksdbPath)
+
+	rmq, err := NewRocksMQ(rocksdbPath, idAllocator)
+	assert.Nil(t, err)
+	defer rmq.Close()
+
+	topicName := "topic_release_offset"
+	err = rmq.CreateTopic(topicName)
+	assert.Nil(t, err)
+
+	slowGroup := "slow_group"
+	err = rmq.CreateConsumerGroup(topicName, slowGroup)
+	assert.Nil(t, err)
+	rmq.RegisterConsumer(&Consumer{
+		Topic:     topicName,
+		GroupName: slowGroup,
+		MsgMutex:  make(chan struct{}, 1),
+	})
+
+	msgNum := 100
+	fastGroup := "fast_group"
+	cMsgs := produceAndConsume(t, rmq, topicName, fastGroup, msgNum, 0)
+	for i := 0; i < msgNum/2; i++ {
+		_, err := rmq.Consume(topicName, slowGroup, 1)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, cMsgs[msgNum/2-1].MsgID, topicBeginID(t, rmq, topicName))
+
+	// The slow group is abandoned, the messages acked by the fast group can be removed
+	err = rmq.DestroyConsumerGroup(topicName, slowGroup)
+	assert.Nil(t, err)
+	assert.Equal(t, cMsgs[msgNum-1].MsgID, topicBeginID(t, rmq, topicName))
+
+	time.Sleep(1100 * time.Millisecond)
+	err = rmq.retentionInfo.newExpiredCleanUp(topicName)
+	assert.Nil(t, err)
+	err = rmq.Seek(topicName, fastGroup, cMsgs[msgNum/2].MsgID)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
+}
+
+func TestRocksmq_ExpireConsumers(t *testing.T) {
+	atomic.StoreInt64(&RocksmqRetentionSizeInMB, 0)
+	atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, 0)
+	atomic.StoreInt64(&RocksmqPageSize, 10)
+	suffix := "expire_consumers"
+	kvPath := retentionPath + kvPathSuffix + suffix
+	defer os.RemoveAll(kvPath)
+	idAllocator := InitIDAllocator(kvPath)
+
+	rocksdbPath := retentionPath + dbPathSuffix + suffix
+	defer os.RemoveAll(rocksdbPath)
+
+	rmq, err := NewRocksMQ(rocksdbPath, idAllocator)
+	assert.Nil(t, err)
+	defer rmq.Close()
+
+	topicName := "topic_expire_consumers"
+	err = rmq.CreateTopic(topicName)
+	assert.Nil(t, err)
+
+	// The idle group never consumes, and the slow group consumes half of the messages,
+	// both of them are abandoned by their owners
+	idleGroup := "idle_group"
+	slowGroup := "slow_group"
+	for _, group := range []string{idleGroup, slowGroup} {
+		err = rmq.CreateConsumerGroup(topicName, group)
+		assert.Nil(t, err)
+		rmq.RegisterConsumer(&Consumer{
+			Topic:     topicName,
+			GroupName: group,
+			MsgMutex:  make(chan struct{}, 1),
+			Owner:     "owner_" + group,
+		})
+	}
+
+	msgNum := 100
+	fastGroup := "fast_group"
+	cMsgs := produceAndConsume(t, rmq, topicName, fastGroup, msgNum, 0)
+	for i := 0; i < msgNum/2; i++ {
+		_, err := rmq.Consume(topicName, slowGroup, 1)
+		assert.Nil(t, err)
+	}
+
+	// The idle group lags all the messages
+	lag, err := rmq.ConsumerLag(topicName)
+	assert.Nil(t, err)
+	assert.Equal(t, cMsgs[msgNum-1].MsgID-cMsgs[0].MsgID+1, lag)
+	rmq.janitor.clean()
+	assert.Equal(t, float64(lag), testutil.ToFloat64(metrics.RocksMQConsumerLag.WithLabelValues(topicName)))
+
+	// No consumer group is expired without owner checker
+	expired, err := rmq.expireConsumers(topicName)
+	assert.Nil(t, err)
+	assert.Empty(t, expired)
+	assert.Equal(t, UniqueID(-1), topicBeginID(t, rmq, topicName))
+
+	rmq.SetConsumerOwnerChecker(func(owner string) bool {
+		return owner == "owner_"+fastGroup
+	})
+	expired, err = rmq.expireConsumers(topicName)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{idleGroup, slowGroup}, expired)
+	for _, group := range []string{idleGroup, slowGroup} {
+		exist, _ := rmq.ExistConsumerGroup(topicName, group)
+		assert.False(t, exist)
+	}
+	lag, err = rmq.ConsumerLag(topicName)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), lag)
+	assert.Equal(t, cMsgs[msgNum-1].MsgID, topicBeginID(t, rmq, topicName))
+
+	// The retention advances after the abandoned groups are expired
+	time.Sleep(1100 * time.Millisecond)
+	err = rmq.retentionInfo.newExpiredCleanUp(topicName)
+	assert.Nil(t, err)
+	err = rmq.Seek(topicName, fastGroup, cMsgs[0].MsgID)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
+
+	// The active group consumes the new messages as usual
+	ids, err := rmq.Produce(topicName, []ProducerMessage{{Payload: []byte("new_message")}})
+	assert.Nil(t, err)
+	res, err := rmq.Consume(topicName, fastGroup, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, ids[0], res[0].MsgID)
+}
@@ -14,13 +14,15 @@ package rocksmq
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/milvus-io/milvus/internal/allocator"
-	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/tecbot/gorocksdb"
 	"go.uber.org/zap"
@@ -42,13 +44,18 @@ const (
 
 	kvSuffix = "_meta_kv"
 
-	MessageSizeTitle  = "message_size/"
-	PageMsgSizeTitle  = "page_message_size/"
-	TopicBeginIDTitle = "topic_begin_id/"
-	BeginIDTitle      = "begin_id/"
-	AckedTsTitle      = "acked_ts/"
-	AckedSizeTitle    = "acked_size/"
-	LastRetTsTitle    = "last_retention_ts/"
+	MessageSizeTitle   = "message_size/"
+	PageMsgSizeTitle   = "page_message_size/"
+	TopicBeginIDTitle  = "topic_begin_id/"
+	BeginIDTitle       = "begin_id/"
+	AckedTsTitle       = "acked_ts/"
+	AckedSizeTitle     = "acked_size/"
+	LastRetTsTitle     = "last_retention_ts/"
+	PublishTsTitle     = "publish_ts/"
+	RetTimeTitle       = "retention_time/"
+	RetSizeTitle       = "retention_size/"
+	PurgedIDTitle      = "purged_id/"
+	ConsumerOwnerTitle = "consumer_owner/"
 
 	CurrentIDSuffix = "current_id"
 )
@@ -108,21 +115,24 @@ func constructKey(metaName, topic string) (string, error) {
 	return metaName + topic + string(nameBytes), nil
 }
 
-func checkRetention() bool {
-	return RocksmqRetentionTimeInMinutes != -1 && RocksmqRetentionSizeInMB != -1
-}
+// ErrPositionExpired is returned when seeking to a position whose following messages are removed by retention,
+// the consumers should recover from the other sources instead of the messages
+var ErrPositionExpired = errors.New("position expired")
 
 var topicMu sync.Map = sync.Map{}
 
 type rocksmq struct {
 	store       *gorocksdb.DB
-	kv          kv.BaseKV
+	kv          *rocksdbkv.RocksdbKV
 	idAllocator allocator.GIDAllocator
 	storeMu     *sync.Mutex
 	consumers   sync.Map
 	ackedMu     sync.Map
 
 	retentionInfo *retentionInfo
+
+	ownerChecker atomic.Value // ConsumerOwnerChecker
+	janitor      *consumerJanitor
 }
 
 // NewRocksMQ step:
@@ -164,20 +174,22 @@ func NewRocksMQ(name string, idAllocator allocator.GIDAllocator) (*rocksmq, erro
 		return nil, err
 	}
 	rmq.retentionInfo = ri
-
-	if checkRetention() {
-		rmq.retentionInfo.startRetentionInfo()
-	}
+	rmq.retentionInfo.startRetentionInfo()
+	rmq.janitor = newConsumerJanitor(rmq)
+	rmq.janitor.start()
 
 	return rmq, nil
 }
 
 // Close step:
-// 1. Stop retention
+// 1. Stop retention and the consumer janitor
 // 2. Destroy all consumer groups and topics
 // 3. Close rocksdb instance
 func (rmq *rocksmq) Close() {
 	rmq.stopRetention()
+	if rmq.janitor != nil {
+		rmq.janitor.stop()
+	}
 	rmq.storeMu.Lock()
 	defer rmq.storeMu.Unlock()
 	rmq.consumers.Range(func(k, v interface{}) bool {
@@ -207,7 +219,7 @@ func (rmq *rocksmq) stopRetention() {
 }
 
 func (rmq *rocksmq) checkKeyExist(key string) bool {
-	val, _ := rmq.kv.Load(key)
+	val, _ := rmq.kv.LoadWithDefault(key, "")
 	return val != ""
 }
 
@@ -323,8 +335,13 @@ func (rmq *rocksmq) DestroyTopic(topicName string) error {
 	if err != nil {
 		return err
 	}
+	err = rmq.kv.MultiRemove([]string{RetTimeTitle + topicName, RetSizeTitle + topicName, PurgedIDTitle + topicName})
+	if err != nil {
+		return err
+	}
 
 	topicMu.Delete(topicName)
+	metrics.RocksMQConsumerLag.DeleteLabelValues(topicName)
 	for i, name := range rmq.retentionInfo.topics {
 		if topicName == name {
 			rmq.retentionInfo.topics = append(rmq.retentionInfo.topics[:i], rmq.retentionInfo.topics[i+1:]...)
@@ -338,6 +355,19 @@ func (rmq *rocksmq) DestroyTopic(topicName string) error {
 	return nil
 }
 
+// SetTopicRetention sets the retention limits of topic, which override RocksmqRetentionTimeInMinutes and
+// RocksmqRetentionSizeInMB. The acked messages are removed if they are acked longer than retentionTimeInMinutes
+// or the size of acked messages exceeds retentionSizeInMB, and -1 means no limit.
+func (rmq *rocksmq) SetTopicRetention(topicName string, retentionTimeInMinutes, retentionSizeInMB int64) error {
+	if _, ok := topicMu.Load(topicName); !ok {
+		return fmt.Errorf("topic name = %s not exist", topicName)
+	}
+	return rmq.kv.MultiSave(map[string]string{
+		RetTimeTitle + topicName: strconv.FormatInt(retentionTimeInMinutes, 10),
+		RetSizeTitle + topicName: strconv.FormatInt(retentionSizeInMB, 10),
+	})
+}
+
 // ExistConsumerGroup check if a consumer exists and return the existed consumer
 func (rmq *rocksmq) ExistConsumerGroup(topicName, groupName string) (bool, *Consumer) {
 	key := constructCurrentID(topicName, groupName)
@@ -371,12 +401,22 @@ func (rmq *rocksmq) CreateConsumerGroup(topicName, groupName string) error {
 	return nil
 }
 
-// RegisterConsumer registers a consumer in rocksmq consumers
+// RegisterConsumer registers a consumer in rocksmq consumers, the owner of the consumer is saved so that
+// the janitor can expire the consumer group once the owner is gone
 func (rmq *rocksmq) RegisterConsumer(consumer *Consumer) {
 	start := time.Now()
+	if consumer.Owner != "" {
+		if err := rmq.saveConsumerOwner(consumer.Topic, consumer.GroupName, consumer.Owner); err != nil {
+			log.Warn("Rocksmq save consumer owner failed", zap.String("topic", consumer.Topic),
+				zap.String("group", consumer.GroupName), zap.String("owner", consumer.Owner), zap.Error(err))
+		}
+	}
 	if vals, ok := rmq.consumers.Load(consumer.Topic); ok {
 		for _, v := range vals.([]*Consumer) {
 			if v.GroupName == consumer.GroupName {
+				if consumer.Owner != "" {
+					v.Owner = consumer.Owner
+				}
 				return
 			}
 		}
@@ -391,7 +431,8 @@ func (rmq *rocksmq) RegisterConsumer(consumer *Consumer) {
 	log.Debug("Rocksmq register consumer successfully ", zap.String("topic", consumer.Topic), zap.Int64("elapsed", time.Since(start).Milliseconds()))
 }
 
-// DestroyConsumerGroup removes a consumer group from rocksdb_kv
+// DestroyConsumerGroup removes a consumer group from rocksdb_kv and deregisters its consumer. The offset of
+// the group is released, so the messages acked by the remaining groups can be removed by retention.
 func (rmq *rocksmq) DestroyConsumerGroup(topicName, groupName string) error {
 	start := time.Now()
 	ll, ok := topicMu.Load(topicName)
@@ -410,6 +451,23 @@ func (rmq *rocksmq) DestroyConsumerGroup(topicName, groupName string) error {
 	if err != nil {
 		return err
 	}
+	// The begin id of a destroyed group must not hold or release the retention of topic
+	fixedBeginIDKey, err := constructKey(BeginIDTitle, topicName)
+	if err != nil {
+		return err
+	}
+	err = rmq.kv.Remove(fixedBeginIDKey + "/" + groupName)
+	if err != nil {
+		return err
+	}
+	fixedOwnerKey, err := constructKey(ConsumerOwnerTitle, topicName)
+	if err != nil {
+		return err
+	}
+	err = rmq.kv.Remove(fixedOwnerKey + "/" + groupName)
+	if err != nil {
+		return err
+	}
 	if vals, ok := rmq.consumers.Load(topicName); ok {
 		consumers := vals.([]*Consumer)
 		for index, v := range consumers {
@@ -421,6 +479,10 @@ func (rmq *rocksmq) DestroyConsumerGroup(topicName, groupName string) error {
 			}
 		}
 	}
+	err = rmq.advanceTopicBeginID(topicName)
+	if err != nil {
+		return err
+	}
 	log.Debug("Rocksmq destroy consumer group successfully ", zap.String("topic", topicName),
 		zap.String("group", groupName),
 		zap.Int64("elapsed", time.Since(start).Milliseconds()))
@@ -479,7 +541,7 @@ func (rmq *rocksmq) Produce(topicName string, messages []ProducerMessage) ([]Uni
 
 	/* Step II: Update meta data to kv system */
 	kvChannelBeginID := topicName + "/begin_id"
-	beginIDValue, err := rmq.kv.Load(kvChannelBeginID)
+	beginIDValue, err := rmq.kv.LoadWithDefault(kvChannelBeginID, "")
 	if err != nil {
 		log.Debug("RocksMQ: load " + kvChannelBeginID + " failed")
 		return []UniqueID{}, err
@@ -495,6 +557,14 @@ func (rmq *rocksmq) Produce(topicName string, messages []ProducerMessage) ([]Uni
 	kvChannelEndID := topicName + "/end_id"
 	kvValues[kvChannelEndID] = strconv.FormatInt(idEnd, 10)
 
+	// Index the publish time of messages by the first id of them, for seeking by time
+	fixedPublishTsKey, err := constructKey(PublishTsTitle, topicName)
+	if err != nil {
+		return []UniqueID{}, err
+	}
+	publishTsKey := fixedPublishTsKey + "/" + strconv.FormatInt(idStart, 10)
+	kvValues[publishTsKey] = strconv.FormatInt(time.Now().UnixNano(), 10)
+
 	err = rmq.kv.MultiSave(kvValues)
 	if err != nil {
 		log.Debug("RocksMQ: multisave failed")
@@ -523,7 +593,7 @@ func (rmq *rocksmq) Produce(topicName string, messages []ProducerMessage) ([]Uni
 
 func (rmq *rocksmq) updatePageInfo(topicName string, msgIDs []UniqueID, msgSizes map[UniqueID]int64) error {
 	msgSizeKey := MessageSizeTitle + topicName
-	msgSizeVal, err := rmq.kv.Load(msgSizeKey)
+	msgSizeVal, err := rmq.kv.LoadWithDefault(msgSizeKey, "")
 	if err != nil {
 		return err
 	}
@@ -589,7 +659,7 @@ func (rmq *rocksmq) Consume(topicName string, groupName string, n int) ([]Consum
 	defer lock.Unlock()
 
 	metaKey := constructCurrentID(topicName, groupName)
-	currentID, err := rmq.kv.Load(metaKey)
+	currentID, err := rmq.kv.LoadWithDefault(metaKey, "")
 	if err != nil {
 		log.Debug("RocksMQ: load " + metaKey + " failed")
 		return nil, err
@@ -615,7 +685,12 @@ func (rmq *rocksmq) Consume(topicName string, groupName string, n int) ([]Consum
 	// we move currentID to first location.
 	// Note that we assume currentId is always correct and not larger than the latest endID.
 	if iter.Seek([]byte(dataKey)); currentID != DefaultMessageID && iter.Valid() {
-		iter.Next()
+		// currentID may be not a message of topic if it's seeked by time, the message found is the next one
+		key := iter.Key()
+		if string(key.Data()) == dataKey {
+			iter.Next()
+		}
+		key.Free()
 	} else {
 		newKey := fixChanName + "/"
 		iter.Seek([]byte(newKey))
@@ -662,8 +737,11 @@ func (rmq *rocksmq) Consume(topicName string, groupName string, n int) ([]Consum
 		return nil, err
 	}
 
-	msgSize := len(consumerMessage[len(consumerMessage)-1].Payload)
-	go rmq.updateAckedInfo(topicName, groupName, newID, int64(msgSize))
+	// The acked size is read from the store, which is not available in goroutines after rocksmq is closed
+	err = rmq.updateAckedInfo(topicName, groupName, newID)
+	if err != nil {
+		log.Warn("RocksMQ: update acked info failed", zap.String("topic", topicName), zap.String("group", groupName), zap.Error(err))
+	}
 
 	return consumerMessage, nil
 }
@@ -679,6 +757,23 @@ func (rmq *rocksmq) Seek(topicName string, groupName string, msgID UniqueID) err
 		return fmt.Errorf("ConsumerGroup %s, channel %s not exists", groupName, topicName)
 	}
 
+	// The messages after msgID are consumed after seeking, which are lost if they are removed by retention
+	purgedIDVal, err := rmq.kv.LoadWithDefault(PurgedIDTitle+topicName, "")
+	if err != nil {
+		return err
+	}
+	if purgedIDVal != "" {
+		purgedID, err := strconv.ParseInt(purgedIDVal, 10, 64)
+		if err != nil {
+			return err
+		}
+		if msgID < purgedID {
+			log.Warn("RocksMQ: seek to an expired position", zap.String("topic", topicName),
+				zap.String("group", groupName), zap.Int64("msgID", msgID), zap.Int64("purgedID", purgedID))
+			return fmt.Errorf("%w, topic %s, msgID %d, messages till %d are removed", ErrPositionExpired, topicName, msgID, purgedID)
+		}
+	}
+
 	storeKey, err := combKey(topicName, msgID)
 	if err != nil {
 		log.Warn("RocksMQ: combKey(" + topicName + "," + strconv.FormatInt(msgID, 10) + ") failed")
@@ -704,6 +799,69 @@ func (rmq *rocksmq) Seek(topicName string, groupName string, msgID UniqueID) err
 	return nil
 }
 
+// SeekByTime updates the current id to the one before the first message published at or after t.
+// It seeks to the earliest message if no message is indexed before t, e.g. they are dropped by retention,
+// and seeks to the latest message if all messages are published before t.
+func (rmq *rocksmq) SeekByTime(topicName, groupName string, t time.Time) error {
+	rmq.storeMu.Lock()
+	defer rmq.storeMu.Unlock()
+	key := constructCurrentID(topicName, groupName)
+	if !rmq.checkKeyExist(key) {
+		log.Warn("RocksMQ: channel " + key + " not exists")
+		return fmt.Errorf("ConsumerGroup %s, channel %s not exists", groupName, topicName)
+	}
+	fixedPublishTsKey, err := constructKey(PublishTsTitle, topicName)
+	if err != nil {
+		return err
+	}
+
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	defer readOpts.Destroy()
+	readOpts.SetPrefixSameAsStart(true)
+	iter := rmq.retentionInfo.kv.DB.NewIterator(readOpts)
+	defer iter.Close()
+
+	currentID := DefaultMessageID
+	first, found := true, false
+	for iter.Seek([]byte(fixedPublishTsKey + "/")); iter.Valid(); iter.Next() {
+		pKey := iter.Key()
+		pValue := iter.Value()
+		msgID, err := strconv.ParseInt(string(pKey.Data())[FixedChannelNameLen+1:], 10, 64)
+		pKey.Free()
+		if err != nil {
+			pValue.Free()
+			return err
+		}
+		publishTs, err := strconv.ParseInt(string(pValue.Data()), 10, 64)
+		pValue.Free()
+		if err != nil {
+			return err
+		}
+		if publishTs >= t.UnixNano() {
+			if !first {
+				currentID = strconv.FormatInt(msgID-1, 10)
+			}
+			found = true
+			break
+		}
+		first = false
+	}
+	if !found && !first {
+		endID, err := rmq.kv.LoadWithDefault(topicName+"/end_id", "")
+		if err != nil {
+			return err
+		}
+		lastID, err := strconv.ParseInt(endID, 10, 64)
+		if err != nil {
+			return err
+		}
+		currentID = strconv.FormatInt(lastID-1, 10)
+	}
+	log.Debug("RocksMQ: seek by time", zap.String("topic", topicName), zap.String("group", groupName),
+		zap.Time("time", t), zap.String("currentID", currentID))
+	return rmq.kv.Save(key, currentID)
+}
+
 // SeekToLatest updates current id to the msg id of latest message
 func (rmq *rocksmq) SeekToLatest(topicName, groupName string) error {
 	rmq.storeMu.Lock()
@@ -753,8 +911,9 @@ func (rmq *rocksmq) Notify(topicName, groupName string) {
 	}
 }
 
-// updateAckedInfo update acked informations for retention after consume
-func (rmq *rocksmq) updateAckedInfo(topicName, groupName string, newID UniqueID, msgSize int64) error {
+// updateAckedInfo update acked informations for retention after consume, the begin id of topic is
+// the minimum one of the consumer groups, the messages after it are never removed by retention
+func (rmq *rocksmq) updateAckedInfo(topicName, groupName string, newID UniqueID) error {
 	ll, ok := topicMu.Load(topicName)
 	if !ok {
 		return fmt.Errorf("topic name = %s not exist", topicName)
@@ -776,25 +935,54 @@ func (rmq *rocksmq) updateAckedInfo(topicName, groupName string, newID UniqueID,
 	if err != nil {
 		return err
 	}
+	return rmq.advanceTopicBeginID(topicName)
+}
 
-	// Update begin_id for topic
+// advanceTopicBeginID updates the begin id of topic to the minimum begin id of the registered consumer groups,
+// and the acked infos of the messages before it. It must be called with the topic lock held.
+func (rmq *rocksmq) advanceTopicBeginID(topicName string) error {
+	fixedBeginIDKey, err := constructKey(BeginIDTitle, topicName)
+	if err != nil {
+		return err
+	}
 	if vals, ok := rmq.consumers.Load(topicName); ok {
-		var minBeginID int64 = -1
+		var minBeginID UniqueID = math.MaxInt64
 		for _, v := range vals.([]*Consumer) {
 			curBeginIDKey := fixedBeginIDKey + "/" + v.GroupName
-			curBeginIDVal, err := rmq.kv.Load(curBeginIDKey)
+			curBeginIDVal, err := rmq.kv.LoadWithDefault(curBeginIDKey, "")
 			if err != nil {
 				return err
 			}
+			// the group hasn't consumed any message
+			if curBeginIDVal == "" {
+				return nil
+			}
 			curBeginID, err := strconv.ParseInt(curBeginIDVal, 10, 64)
 			if err != nil {
 				return err
 			}
-			if curBeginID > minBeginID {
+			if curBeginID < minBeginID {
 				minBeginID = curBeginID
 			}
 		}
+		if minBeginID == math.MaxInt64 {
+			return nil
+		}
 		topicBeginIDKey := TopicBeginIDTitle + topicName
+		topicBeginIDVal, err := rmq.kv.LoadWithDefault(topicBeginIDKey, "")
+		if err != nil {
+			return err
+		}
+		var topicBeginID UniqueID = -1
+		if topicBeginIDVal != "" {
+			topicBeginID, err = strconv.ParseInt(topicBeginIDVal, 10, 64)
+			if err != nil {
+				return err
+			}
+		}
+		if minBeginID <= topicBeginID {
+			return nil
+		}
 		err = rmq.kv.Save(topicBeginIDKey, strconv.FormatInt(minBeginID, 10))
 		if err != nil {
 			return err
@@ -811,33 +999,61 @@ func (rmq *rocksmq) updateAckedInfo(topicName, groupName string, newID UniqueID,
 		if err != nil {
 			return err
 		}
-		// if info, ok := rmq.retentionInfo.ackedInfo.Load(topicName); ok {
-		// 	ackedInfo := info.(*topicAckedInfo)
-		// 	ackedInfo.ackedTs[minBeginID] = ts
-		// 	rmq.retentionInfo.ackedInfo.Store(topicName, ackedInfo)
-		// }
-		if minBeginID == newID {
-			// Means the begin_id of topic update to newID, so needs to update acked size
-			ackedSizeKey := AckedSizeTitle + topicName
-			ackedSizeVal, err := rmq.kv.Load(ackedSizeKey)
-			if err != nil {
-				return err
-			}
-			ackedSize, err := strconv.ParseInt(ackedSizeVal, 10, 64)
-			if err != nil {
-				return err
-			}
-			ackedSize += msgSize
-			err = rmq.kv.Save(ackedSizeKey, strconv.FormatInt(ackedSize, 10))
-			if err != nil {
-				return err
-			}
-			// if info, ok := rmq.retentionInfo.ackedInfo.Load(topicName); ok {
-			// 	ackedInfo := info.(*topicAckedInfo)
-			// 	ackedInfo.ackedSize = ackedSize
-			// 	rmq.retentionInfo.ackedInfo.Store(topicName, ackedInfo)
-			// }
+
+		// The messages in (topicBeginID, minBeginID] are acked by all the consumer groups
+		msgSize, err := rmq.messagesSize(topicName, topicBeginID, minBeginID)
+		if err != nil {
+			return err
+		}
+		ackedSizeKey := AckedSizeTitle + topicName
+		ackedSizeVal, err := rmq.kv.LoadWithDefault(ackedSizeKey, "")
+		if err != nil {
+			return err
+		}
+		ackedSize, err := strconv.ParseInt(ackedSizeVal, 10, 64)
+		if err != nil {
+			return err
+		}
+		ackedSize += msgSize
+		err = rmq.kv.Save(ackedSizeKey, strconv.FormatInt(ackedSize, 10))
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
+
+// messagesSize returns the size of payloads of the messages in (startID, endID]
+func (rmq *rocksmq) messagesSize(topicName string, startID, endID UniqueID) (int64, error) {
+	fixChanName, err := fixChannelName(topicName)
+	if err != nil {
+		return 0, err
+	}
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	defer readOpts.Destroy()
+	readOpts.SetPrefixSameAsStart(true)
+	iter := rmq.store.NewIterator(readOpts)
+	defer iter.Close()
+
+	startKey := fixChanName + "/"
+	if startID >= 0 {
+		startKey += strconv.FormatInt(startID, 10)
+	}
+	var size int64
+	for iter.Seek([]byte(startKey)); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		msgID, err := strconv.ParseInt(string(key.Data())[FixedChannelNameLen+1:], 10, 64)
+		key.Free()
+		if err != nil {
+			return 0, err
+		}
+		if msgID <= startID {
+			continue
+		}
+		if msgID > endID {
+			break
+		}
+		size += int64(iter.Value().Size())
+	}
+	return size, nil
+}
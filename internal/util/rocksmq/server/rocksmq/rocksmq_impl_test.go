@@ -604,3 +604,70 @@ func TestRocksmq_SeekToLatest(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, len(cMsgs), 0)
 }
+
+func TestRocksmq_SeekByTime(t *testing.T) {
+	ep := etcdEndpoints()
+	etcdKV, err := etcdkv.NewEtcdKV(ep, "/etcd/test/root")
+	assert.Nil(t, err)
+	defer etcdKV.Close()
+	idAllocator := allocator.NewGlobalIDAllocator("dummy", etcdKV)
+	_ = idAllocator.Initialize()
+
+	name := "/tmp/rocksmq_seekbytime"
+	defer os.RemoveAll(name)
+	kvName := name + "_meta_kv"
+	_ = os.RemoveAll(kvName)
+	defer os.RemoveAll(kvName)
+	rmq, err := NewRocksMQ(name, idAllocator)
+	assert.Nil(t, err)
+	defer rmq.Close()
+
+	channelName := "channel_test"
+	err = rmq.CreateTopic(channelName)
+	assert.Nil(t, err)
+	defer rmq.DestroyTopic(channelName)
+
+	err = rmq.SeekByTime(channelName, "dummy_group", time.Now())
+	assert.Error(t, err)
+
+	groupName := "group_test"
+	_ = rmq.DestroyConsumerGroup(channelName, groupName)
+	err = rmq.CreateConsumerGroup(channelName, groupName)
+	assert.Nil(t, err)
+
+	// seek on an empty topic starts from the earliest message
+	err = rmq.SeekByTime(channelName, groupName, time.Now())
+	assert.Nil(t, err)
+
+	before := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = rmq.Produce(channelName, []ProducerMessage{{Payload: []byte("message_0")}, {Payload: []byte("message_1")}})
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	middle := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = rmq.Produce(channelName, []ProducerMessage{{Payload: []byte("message_2")}, {Payload: []byte("message_3")}})
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	after := time.Now()
+
+	err = rmq.SeekByTime(channelName, groupName, middle)
+	assert.Nil(t, err)
+	cMsgs, err := rmq.Consume(channelName, groupName, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(cMsgs))
+	assert.Equal(t, "message_2", string(cMsgs[0].Payload))
+
+	err = rmq.SeekByTime(channelName, groupName, before)
+	assert.Nil(t, err)
+	cMsgs, err = rmq.Consume(channelName, groupName, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(cMsgs))
+	assert.Equal(t, "message_0", string(cMsgs[0].Payload))
+
+	err = rmq.SeekByTime(channelName, groupName, after)
+	assert.Nil(t, err)
+	cMsgs, err = rmq.Consume(channelName, groupName, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(cMsgs))
+}
@@ -34,13 +34,16 @@ var RocksmqRetentionSizeInMB int64
 
 // Const value that used to convert unit
 const (
-	MB     = 2 << 20
+	MB     = 1 << 20
 	MINUTE = 60
 )
 
 // TickerTimeInSeconds is the time of expired check, default 10 minutes
 var TickerTimeInSeconds int64 = 10 * MINUTE
 
+// compactChSize is the max number of compactions waiting to be done
+const compactChSize = 1024
+
 type topicPageInfo struct {
 	pageEndID   []UniqueID
 	pageMsgSize map[UniqueID]int64
@@ -68,6 +71,9 @@ type retentionInfo struct {
 	kv *rocksdbkv.RocksdbKV
 	db *gorocksdb.DB
 
+	// compactCh receives the ranges of removed messages to compact
+	compactCh chan gorocksdb.Range
+
 	closeCh   chan struct{}
 	closeWg   sync.WaitGroup
 	closeOnce sync.Once
@@ -108,6 +114,7 @@ func initRetentionInfo(kv *rocksdbkv.RocksdbKV, db *gorocksdb.DB) (*retentionInf
 		mutex:             sync.RWMutex{},
 		kv:                kv,
 		db:                db,
+		compactCh:         make(chan gorocksdb.Range, compactChSize),
 		closeCh:           make(chan struct{}),
 		closeWg:           sync.WaitGroup{},
 	}
@@ -137,8 +144,9 @@ func (ri *retentionInfo) startRetentionInfo() {
 	// }
 	// wg.Wait()
 	// log.Debug("Finish load retention info, start retention")
-	ri.closeWg.Add(1)
+	ri.closeWg.Add(2)
 	go ri.retention()
+	go ri.compaction()
 }
 
 // Read retention infos from rocksdb so that retention check can be done based on memory data
@@ -193,7 +201,7 @@ func (ri *retentionInfo) loadRetentionInfo(topic string, wg *sync.WaitGroup) {
 	ackedTs := make(map[UniqueID]UniqueID)
 
 	topicBeginIDKey := TopicBeginIDTitle + topic
-	topicBeginIDVal, err := ri.kv.Load(topicBeginIDKey)
+	topicBeginIDVal, err := ri.kv.LoadWithDefault(topicBeginIDKey, "")
 	if err != nil {
 		return
 	}
@@ -230,7 +238,7 @@ func (ri *retentionInfo) loadRetentionInfo(topic string, wg *sync.WaitGroup) {
 	}
 
 	ackedSizeKey := AckedSizeTitle + topic
-	ackedSizeVal, err := ri.kv.Load(ackedSizeKey)
+	ackedSizeVal, err := ri.kv.LoadWithDefault(ackedSizeKey, "")
 	if err != nil {
 		log.Debug("Load failed", zap.Any("error", err))
 		return
@@ -254,7 +262,7 @@ func (ri *retentionInfo) loadRetentionInfo(topic string, wg *sync.WaitGroup) {
 
 	//Load last retention timestamp
 	lastRetentionTsKey := LastRetTsTitle + topic
-	lastRetentionTsVal, err := ri.kv.Load(lastRetentionTsKey)
+	lastRetentionTsVal, err := ri.kv.LoadWithDefault(lastRetentionTsKey, "")
 	if err != nil {
 		log.Debug("Load failed", zap.Any("error", err))
 		return
@@ -289,12 +297,19 @@ func (ri *retentionInfo) retention() error {
 			return nil
 		case t := <-ticker.C:
 			timeNow := t.Unix()
-			checkTime := atomic.LoadInt64(&RocksmqRetentionTimeInMinutes) * MINUTE / 10
 			log.Debug("In ticker: ", zap.Any("ticker", timeNow))
 			ri.mutex.RLock()
 			for _, topic := range ri.topics {
+				retentionTime, retentionSize := ri.topicRetention(topic)
+				if retentionTime < 0 && retentionSize < 0 {
+					continue
+				}
+				var checkTime int64
+				if retentionTime > 0 {
+					checkTime = retentionTime * MINUTE / 10
+				}
 				lastRetentionTsKey := LastRetTsTitle + topic
-				lastRetentionTsVal, err := ri.kv.Load(lastRetentionTsKey)
+				lastRetentionTsVal, err := ri.kv.LoadWithDefault(lastRetentionTsKey, "")
 				if err != nil || lastRetentionTsVal == "" {
 					log.Warn("Can't get lastRetentionTs", zap.Any("lastRetentionTsKey", lastRetentionTsKey))
 					continue
@@ -316,6 +331,33 @@ func (ri *retentionInfo) retention() error {
 	}
 }
 
+// topicRetention returns the retention time in minutes and the retention size in MB of topic,
+// the global ones are used if they are not set by SetTopicRetention
+func (ri *retentionInfo) topicRetention(topic string) (int64, int64) {
+	retentionTime := atomic.LoadInt64(&RocksmqRetentionTimeInMinutes)
+	retentionSize := atomic.LoadInt64(&RocksmqRetentionSizeInMB)
+	if val, err := ri.kv.LoadWithDefault(RetTimeTitle+topic, ""); err == nil && val != "" {
+		if t, err := strconv.ParseInt(val, 10, 64); err == nil {
+			retentionTime = t
+		}
+	}
+	if val, err := ri.kv.LoadWithDefault(RetSizeTitle+topic, ""); err == nil && val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			retentionSize = size
+		}
+	}
+	return retentionTime, retentionSize
+}
+
+// listTopics returns a copy of the topics of retention
+func (ri *retentionInfo) listTopics() []string {
+	ri.mutex.RLock()
+	defer ri.mutex.RUnlock()
+	topics := make([]string, len(ri.topics))
+	copy(topics, ri.topics)
+	return topics
+}
+
 func (ri *retentionInfo) Stop() {
 	ri.closeOnce.Do(func() {
 		close(ri.closeCh)
@@ -336,14 +378,30 @@ func (ri *retentionInfo) newExpiredCleanUp(topic string) error {
 	lock.Lock()
 	defer lock.Unlock()
 
+	retentionTime, retentionSize := ri.topicRetention(topic)
+
+	// The messages after topic begin id are not acked by all the consumer groups, which are never removed
+	topicBeginIDVal, err := ri.kv.LoadWithDefault(TopicBeginIDTitle+topic, "")
+	if err != nil {
+		return err
+	}
+	topicBeginID, err := strconv.ParseInt(topicBeginIDVal, 10, 64)
+	if err != nil {
+		return err
+	}
+
 	var deletedAckedSize int64 = 0
-	var startID UniqueID
 	var endID UniqueID
-	var pageStartID UniqueID = 0
-	var err error
+	var pageEndID UniqueID
 
 	fixedAckedTsKey, _ := constructKey(AckedTsTitle, topic)
 
+	ackedReadOpts := gorocksdb.NewDefaultReadOptions()
+	defer ackedReadOpts.Destroy()
+	ackedReadOpts.SetPrefixSameAsStart(true)
+	ackedIter := ri.kv.DB.NewIterator(ackedReadOpts)
+	defer ackedIter.Close()
+
 	pageReadOpts := gorocksdb.NewDefaultReadOptions()
 	defer pageReadOpts.Destroy()
 	pageReadOpts.SetPrefixSameAsStart(true)
@@ -351,108 +409,47 @@ func (ri *retentionInfo) newExpiredCleanUp(topic string) error {
 	defer pageIter.Close()
 	pageMsgPrefix, _ := constructKey(PageMsgSizeTitle, topic)
 	pageIter.Seek([]byte(pageMsgPrefix))
-	if pageIter.Valid() {
-		pageStartID, err = strconv.ParseInt(string(pageIter.Key().Data())[FixedChannelNameLen+1:], 10, 64)
+	for ; pageIter.Valid(); pageIter.Next() {
+		pageID, size, err := parsePageInfo(pageIter)
 		if err != nil {
 			return err
 		}
-
-		for ; pageIter.Valid(); pageIter.Next() {
-			pKey := pageIter.Key()
-			pageID, err := strconv.ParseInt(string(pKey.Data())[FixedChannelNameLen+1:], 10, 64)
-			if pKey != nil {
-				pKey.Free()
-			}
-			if err != nil {
-				return err
-			}
-
-			ackedTsKey := fixedAckedTsKey + "/" + strconv.FormatInt(pageID, 10)
-			ackedTsVal, err := ri.kv.Load(ackedTsKey)
-			if err != nil {
-				return err
-			}
-			ackedTs, err := strconv.ParseInt(ackedTsVal, 10, 64)
-			if err != nil {
-				return err
-			}
-			if msgTimeExpiredCheck(ackedTs) {
-				endID = pageID
-				pValue := pageIter.Value()
-				size, err := strconv.ParseInt(string(pValue.Data()), 10, 64)
-				if pValue != nil {
-					pValue.Free()
-				}
-				if err != nil {
-					return err
-				}
-				deletedAckedSize += size
-			} else {
-				break
-			}
+		if pageID > topicBeginID {
+			break
 		}
-	}
-
-	pageEndID := endID
-
-	ackedReadOpts := gorocksdb.NewDefaultReadOptions()
-	defer ackedReadOpts.Destroy()
-	ackedReadOpts.SetPrefixSameAsStart(true)
-	ackedIter := ri.kv.DB.NewIterator(ackedReadOpts)
-	defer ackedIter.Close()
-	if err != nil {
-		return err
-	}
-	ackedIter.Seek([]byte(fixedAckedTsKey))
-	if !ackedIter.Valid() {
-		return nil
-	}
-
-	startID, err = strconv.ParseInt(string(ackedIter.Key().Data())[FixedChannelNameLen+1:], 10, 64)
-	if err != nil {
-		return err
-	}
-	if endID > startID {
-		newPos := fixedAckedTsKey + "/" + strconv.FormatInt(endID, 10)
-		ackedIter.Seek([]byte(newPos))
-	}
-
-	for ; ackedIter.Valid(); ackedIter.Next() {
-		aKey := ackedIter.Key()
-		aValue := ackedIter.Value()
-		ackedTs, err := strconv.ParseInt(string(aValue.Data()), 10, 64)
-		if aValue != nil {
-			aValue.Free()
+		// The page is acked when the first message at or after its end is acked
+		ackedIter.Seek([]byte(fixedAckedTsKey + "/" + strconv.FormatInt(pageID, 10)))
+		if !ackedIter.Valid() {
+			break
 		}
+		_, ackedTs, err := parseAckedTs(ackedIter)
 		if err != nil {
-			if aKey != nil {
-				aKey.Free()
-			}
 			return err
 		}
-		if msgTimeExpiredCheck(ackedTs) {
-			endID, err = strconv.ParseInt(string(aKey.Data())[FixedChannelNameLen+1:], 10, 64)
-			if aKey != nil {
-				aKey.Free()
-			}
-			if err != nil {
-				return err
-			}
-		} else {
-			if aKey != nil {
-				aKey.Free()
-			}
+		if !msgTimeExpiredCheck(ackedTs, retentionTime) {
 			break
 		}
+		endID = pageID
+		pageEndID = pageID
+		deletedAckedSize += size
 	}
 
-	if endID == 0 {
-		log.Debug("All messages are not time expired")
+	// The end msg of the page is not expired, find the last expired msg in this page
+	ackedIter.Seek([]byte(fixedAckedTsKey + "/" + strconv.FormatInt(endID, 10)))
+	for ; ackedIter.Valid(); ackedIter.Next() {
+		ackedID, ackedTs, err := parseAckedTs(ackedIter)
+		if err != nil {
+			return err
+		}
+		if ackedID > topicBeginID || !msgTimeExpiredCheck(ackedTs, retentionTime) {
+			break
+		}
+		endID = ackedID
 	}
-	log.Debug("Expired check by retention time", zap.Any("topic", topic), zap.Any("startID", startID), zap.Any("endID", endID), zap.Any("deletedAckedSize", deletedAckedSize))
+	log.Debug("Expired check by retention time", zap.Any("topic", topic), zap.Any("endID", endID), zap.Any("deletedAckedSize", deletedAckedSize))
 
 	ackedSizeKey := AckedSizeTitle + topic
-	totalAckedSizeVal, err := ri.kv.Load(ackedSizeKey)
+	totalAckedSizeVal, err := ri.kv.LoadWithDefault(ackedSizeKey, "")
 	if err != nil {
 		return err
 	}
@@ -462,75 +459,143 @@ func (ri *retentionInfo) newExpiredCleanUp(topic string) error {
 	}
 
 	for ; pageIter.Valid(); pageIter.Next() {
-		pValue := pageIter.Value()
-		size, err := strconv.ParseInt(string(pValue.Data()), 10, 64)
-		if pValue != nil {
-			pValue.Free()
-		}
-		pKey := pageIter.Key()
-		pKeyStr := string(pKey.Data())
-		if pKey != nil {
-			pKey.Free()
-		}
+		pageID, size, err := parsePageInfo(pageIter)
 		if err != nil {
 			return err
 		}
+		if pageID > topicBeginID {
+			break
+		}
 		curDeleteSize := deletedAckedSize + size
-		if msgSizeExpiredCheck(curDeleteSize, totalAckedSize) {
-			endID, err = strconv.ParseInt(pKeyStr[FixedChannelNameLen+1:], 10, 64)
-			if err != nil {
-				return err
-			}
-			pageEndID = endID
-			deletedAckedSize += size
-		} else {
+		if !msgSizeExpiredCheck(curDeleteSize, totalAckedSize, retentionSize) {
 			break
 		}
+		if pageID > endID {
+			endID = pageID
+		}
+		pageEndID = pageID
+		deletedAckedSize = curDeleteSize
 	}
 	if endID == 0 {
 		log.Debug("All messages are not expired")
 		return nil
 	}
-	log.Debug("ExpiredCleanUp: ", zap.Any("topic", topic), zap.Any("startID", startID), zap.Any("endID", endID), zap.Any("deletedAckedSize", deletedAckedSize))
+	log.Debug("ExpiredCleanUp: ", zap.Any("topic", topic), zap.Any("endID", endID), zap.Any("deletedAckedSize", deletedAckedSize))
 
 	writeBatch := gorocksdb.NewWriteBatch()
 	defer writeBatch.Destroy()
 
-	pageStartIDKey := pageMsgPrefix + "/" + strconv.FormatInt(pageStartID, 10)
-	pageEndIDKey := pageMsgPrefix + "/" + strconv.FormatInt(pageEndID+1, 10)
-	if pageStartID == pageEndID {
-		if pageStartID != 0 {
-			writeBatch.Delete([]byte(pageStartIDKey))
-		}
-	} else if pageStartID < pageEndID {
-		writeBatch.DeleteRange([]byte(pageStartIDKey), []byte(pageEndIDKey))
+	if pageEndID > 0 {
+		pageEndIDKey := pageMsgPrefix + "/" + strconv.FormatInt(pageEndID+1, 10)
+		writeBatch.DeleteRange([]byte(pageMsgPrefix+"/"), []byte(pageEndIDKey))
 	}
+	ackedEndIDKey := fixedAckedTsKey + "/" + strconv.FormatInt(endID+1, 10)
+	writeBatch.DeleteRange([]byte(fixedAckedTsKey+"/"), []byte(ackedEndIDKey))
 
-	ackedStartIDKey := fixedAckedTsKey + "/" + strconv.Itoa(int(startID))
-	ackedEndIDKey := fixedAckedTsKey + "/" + strconv.Itoa(int(endID+1))
-	if startID > endID {
-		return nil
-	} else if startID == endID {
-		writeBatch.Delete([]byte(ackedStartIDKey))
-	} else {
-		writeBatch.DeleteRange([]byte(ackedStartIDKey), []byte(ackedEndIDKey))
-	}
+	// the messages of the publish time indexed after endID are seeked as the earliest ones
+	fixedPublishTsKey, _ := constructKey(PublishTsTitle, topic)
+	writeBatch.DeleteRange([]byte(fixedPublishTsKey+"/"), []byte(fixedPublishTsKey+"/"+strconv.FormatInt(endID+1, 10)))
 
 	newAckedSize := totalAckedSize - deletedAckedSize
 	writeBatch.Put([]byte(ackedSizeKey), []byte(strconv.FormatInt(newAckedSize, 10)))
 
-	err = DeleteMessages(ri.db, topic, startID, endID)
+	// Seeking to the positions before purged id are rejected, since the messages after them are lost
+	purgedIDKey := PurgedIDTitle + topic
+	purgedIDVal, err := ri.kv.LoadWithDefault(purgedIDKey, "")
+	if err != nil {
+		return err
+	}
+	if purgedIDVal != "" {
+		purgedID, err := strconv.ParseInt(purgedIDVal, 10, 64)
+		if err == nil && purgedID > endID {
+			endID = purgedID
+		}
+	}
+	writeBatch.Put([]byte(purgedIDKey), []byte(strconv.FormatInt(endID, 10)))
+	writeBatch.Put([]byte(LastRetTsTitle+topic), []byte(strconv.FormatInt(time.Now().Unix(), 10)))
+
+	// Delete from the first message of topic, the messages before the first acked one are acked as well
+	err = DeleteMessages(ri.db, topic, 0, endID)
 	if err != nil {
 		return err
 	}
 
 	writeOpts := gorocksdb.NewDefaultWriteOptions()
 	defer writeOpts.Destroy()
-	ri.kv.DB.Write(writeOpts, writeBatch)
+	err = ri.kv.DB.Write(writeOpts, writeBatch)
+	if err != nil {
+		return err
+	}
 
+	ri.scheduleCompaction(topic, endID)
 	return nil
 }
 
+// scheduleCompaction asks the compaction goroutine to compact the removed messages of topic, DeleteRange only
+// writes range tombstones and the disk space is reclaimed after compaction
+func (ri *retentionInfo) scheduleCompaction(topic string, endID UniqueID) {
+	fixChanName, err := fixChannelName(topic)
+	if err != nil {
+		return
+	}
+	r := gorocksdb.Range{
+		Start: []byte(fixChanName + "/"),
+		Limit: []byte(fixChanName + "/" + strconv.FormatInt(endID+1, 10)),
+	}
+	select {
+	case ri.compactCh <- r:
+	default:
+		log.Warn("Rocksmq compaction is busy, skip compaction", zap.Any("topic", topic), zap.Any("endID", endID))
+	}
+}
+
+// compaction compacts the ranges of removed messages in background
+func (ri *retentionInfo) compaction() {
+	defer ri.closeWg.Done()
+	for {
+		select {
+		case <-ri.closeCh:
+			log.Debug("Rocksmq compaction finish!")
+			return
+		case r := <-ri.compactCh:
+			ri.db.CompactRange(r)
+			log.Debug("Rocksmq compaction done", zap.ByteString("start", r.Start), zap.ByteString("limit", r.Limit))
+		}
+	}
+}
+
+func parsePageInfo(pageIter *gorocksdb.Iterator) (UniqueID, int64, error) {
+	pKey := pageIter.Key()
+	pageID, err := strconv.ParseInt(string(pKey.Data())[FixedChannelNameLen+1:], 10, 64)
+	pKey.Free()
+	if err != nil {
+		return 0, 0, err
+	}
+	pValue := pageIter.Value()
+	size, err := strconv.ParseInt(string(pValue.Data()), 10, 64)
+	pValue.Free()
+	if err != nil {
+		return 0, 0, err
+	}
+	return pageID, size, nil
+}
+
+func parseAckedTs(ackedIter *gorocksdb.Iterator) (UniqueID, int64, error) {
+	aKey := ackedIter.Key()
+	ackedID, err := strconv.ParseInt(string(aKey.Data())[FixedChannelNameLen+1:], 10, 64)
+	aKey.Free()
+	if err != nil {
+		return 0, 0, err
+	}
+	aValue := ackedIter.Value()
+	ackedTs, err := strconv.ParseInt(string(aValue.Data()), 10, 64)
+	aValue.Free()
+	if err != nil {
+		return 0, 0, err
+	}
+	return ackedID, ackedTs, nil
+}
+
 /*
 // 1. Obtain pageAckedInfo and do time expired check, get the expired page scope;
 // 2. Do iteration in the page after the last page in step 1 and get the last time expired message id;
@@ -760,10 +825,20 @@ func DeleteMessages(db *gorocksdb.DB, topic string, startID, endID UniqueID) err
 	return nil
 }
 
-func msgTimeExpiredCheck(ackedTs int64) bool {
-	return ackedTs+atomic.LoadInt64(&RocksmqRetentionTimeInMinutes)*MINUTE < time.Now().Unix()
+// msgTimeExpiredCheck checks if the messages acked at ackedTs are retained longer than retentionTime minutes,
+// negative retentionTime means no limit
+func msgTimeExpiredCheck(ackedTs, retentionTime int64) bool {
+	if retentionTime < 0 {
+		return false
+	}
+	return ackedTs+retentionTime*MINUTE < time.Now().Unix()
 }
 
-func msgSizeExpiredCheck(deletedAckedSize, ackedSize int64) bool {
-	return ackedSize-deletedAckedSize > atomic.LoadInt64(&RocksmqRetentionSizeInMB)*MB
+// msgSizeExpiredCheck checks if the acked messages exceed retentionSize MB after deletedAckedSize are removed,
+// negative retentionSize means no limit
+func msgSizeExpiredCheck(deletedAckedSize, ackedSize, retentionSize int64) bool {
+	if retentionSize < 0 {
+		return false
+	}
+	return ackedSize-deletedAckedSize > retentionSize*MB
 }
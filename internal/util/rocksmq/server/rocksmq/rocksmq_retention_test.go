@@ -12,8 +12,10 @@
 package rocksmq
 
 import (
+	"errors"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,8 +23,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/tecbot/gorocksdb"
 	"go.uber.org/zap"
 )
 
@@ -107,7 +111,7 @@ func TestRmqRetention(t *testing.T) {
 	time.Sleep(time.Duration(checkTimeInterval+1) * time.Second)
 	// Seek to a previous consumed message, the message should be clean up
 	err = rmq.Seek(topicName, groupName, cMsgs[msgNum/2].MsgID)
-	assert.Nil(t, err)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
 	newRes, err := rmq.Consume(topicName, groupName, 1)
 	assert.Nil(t, err)
 	assert.Equal(t, len(newRes), 0)
@@ -398,12 +402,10 @@ func TestRmqRetention_Complex(t *testing.T) {
 	// Seek to a previous consumed message, the message should be clean up
 	log.Debug("cMsg", zap.Any("id", cMsgs[10].MsgID))
 	err = rmq.Seek(topicName, groupName, cMsgs[10].MsgID)
-	assert.Nil(t, err)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
 	newRes, err := rmq.Consume(topicName, groupName, 1)
 	assert.Nil(t, err)
-	//TODO(yukun)
-	log.Debug("Consume result", zap.Any("result len", len(newRes)))
-	// assert.NotEqual(t, newRes[0].MsgID, cMsgs[11].MsgID)
+	assert.Equal(t, len(newRes), 0)
 }
 
 func TestRmqRetention_PageTimeExpire(t *testing.T) {
@@ -463,9 +465,212 @@ func TestRmqRetention_PageTimeExpire(t *testing.T) {
 	// Seek to a previous consumed message, the message should be clean up
 	log.Debug("cMsg", zap.Any("id", cMsgs[10].MsgID))
 	err = rmq.Seek(topicName, groupName, cMsgs[len(cMsgs)/2].MsgID)
-	assert.Nil(t, err)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
 	newRes, err := rmq.Consume(topicName, groupName, 1)
 	assert.Nil(t, err)
 	assert.Equal(t, len(newRes), 0)
 	// assert.NotEqual(t, newRes[0].MsgID, cMsgs[11].MsgID)
 }
+
+func produceAndConsume(t *testing.T, rmq *rocksmq, topicName, groupName string, msgNum int, payloadSize int) []ConsumerMessage {
+	pMsgs := make([]ProducerMessage, msgNum)
+	for i := 0; i < msgNum; i++ {
+		payload := []byte("message_" + strconv.Itoa(i))
+		if payloadSize > len(payload) {
+			payload = append(payload, make([]byte, payloadSize-len(payload))...)
+		}
+		pMsgs[i] = ProducerMessage{Payload: payload}
+	}
+	ids, err := rmq.Produce(topicName, pMsgs)
+	assert.Nil(t, err)
+	assert.Equal(t, len(pMsgs), len(ids))
+
+	err = rmq.CreateConsumerGroup(topicName, groupName)
+	assert.Nil(t, err)
+	rmq.RegisterConsumer(&Consumer{
+		Topic:     topicName,
+		GroupName: groupName,
+		MsgMutex:  make(chan struct{}, 1),
+	})
+	cMsgs := make([]ConsumerMessage, 0, msgNum)
+	for i := 0; i < msgNum; i++ {
+		cMsg, err := rmq.Consume(topicName, groupName, 1)
+		assert.Nil(t, err)
+		cMsgs = append(cMsgs, cMsg[0])
+	}
+	return cMsgs
+}
+
+func TestRmqRetention_TopicRetention(t *testing.T) {
+	atomic.StoreInt64(&RocksmqRetentionSizeInMB, -1)
+	atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, -1)
+	atomic.StoreInt64(&RocksmqPageSize, 10)
+	defer atomic.StoreInt64(&RocksmqRetentionSizeInMB, 0)
+	defer atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, 0)
+	suffix := "topic_ret"
+	kvPath := retentionPath + kvPathSuffix + suffix
+	defer os.RemoveAll(kvPath)
+	idAllocator := InitIDAllocator(kvPath)
+
+	rocksdbPath := retentionPath + dbPathSuffix + suffix
+	defer os.RemoveAll(rocksdbPath)
+
+	rmq, err := NewRocksMQ(rocksdbPath, idAllocator)
+	assert.Nil(t, err)
+	defer rmq.Close()
+
+	err = rmq.SetTopicRetention("topic_not_exist", 0, 0)
+	assert.Error(t, err)
+
+	topicA := "topic_retention_a"
+	topicB := "topic_retention_b"
+	groupName := "test_group"
+	err = rmq.CreateTopic(topicA)
+	assert.Nil(t, err)
+	err = rmq.CreateTopic(topicB)
+	assert.Nil(t, err)
+
+	// Only the acked messages of topicA expire
+	err = rmq.SetTopicRetention(topicA, 0, -1)
+	assert.Nil(t, err)
+
+	msgNum := 100
+	msgsA := produceAndConsume(t, rmq, topicA, groupName, msgNum, 0)
+	msgsB := produceAndConsume(t, rmq, topicB, groupName, msgNum, 0)
+
+	time.Sleep(1100 * time.Millisecond)
+	err = rmq.retentionInfo.newExpiredCleanUp(topicA)
+	assert.Nil(t, err)
+	err = rmq.retentionInfo.newExpiredCleanUp(topicB)
+	assert.Nil(t, err)
+
+	err = rmq.Seek(topicA, groupName, msgsA[msgNum/2].MsgID)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
+	// Seeking to the last removed message loses nothing
+	err = rmq.Seek(topicA, groupName, msgsA[msgNum-1].MsgID)
+	assert.Nil(t, err)
+
+	err = rmq.Seek(topicB, groupName, msgsB[msgNum/2].MsgID)
+	assert.Nil(t, err)
+	cMsgs, err := rmq.Consume(topicB, groupName, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cMsgs))
+	assert.Equal(t, msgsB[msgNum/2+1].MsgID, cMsgs[0].MsgID)
+
+	err = rmq.DestroyTopic(topicA)
+	assert.Nil(t, err)
+	_, err = rmq.kv.Load(PurgedIDTitle + topicA)
+	assert.True(t, errors.Is(err, kv.ErrKeyNotFound))
+}
+
+func TestRmqRetention_MinConsumerPosition(t *testing.T) {
+	atomic.StoreInt64(&RocksmqRetentionSizeInMB, 0)
+	atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, 0)
+	atomic.StoreInt64(&RocksmqPageSize, 10)
+	suffix := "min_pos"
+	kvPath := retentionPath + kvPathSuffix + suffix
+	defer os.RemoveAll(kvPath)
+	idAllocator := InitIDAllocator(kvPath)
+
+	rocksdbPath := retentionPath + dbPathSuffix + suffix
+	defer os.RemoveAll(rocksdbPath)
+
+	rmq, err := NewRocksMQ(rocksdbPath, idAllocator)
+	assert.Nil(t, err)
+	defer rmq.Close()
+
+	topicName := "topic_min_pos"
+	err = rmq.CreateTopic(topicName)
+	assert.Nil(t, err)
+
+	// The slow group only consumes half of the messages
+	slowGroup := "slow_group"
+	err = rmq.CreateConsumerGroup(topicName, slowGroup)
+	assert.Nil(t, err)
+	rmq.RegisterConsumer(&Consumer{
+		Topic:     topicName,
+		GroupName: slowGroup,
+		MsgMutex:  make(chan struct{}, 1),
+	})
+
+	msgNum := 100
+	fastGroup := "fast_group"
+	cMsgs := produceAndConsume(t, rmq, topicName, fastGroup, msgNum, 0)
+	for i := 0; i < msgNum/2; i++ {
+		_, err := rmq.Consume(topicName, slowGroup, 1)
+		assert.Nil(t, err)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	err = rmq.retentionInfo.newExpiredCleanUp(topicName)
+	assert.Nil(t, err)
+
+	err = rmq.Seek(topicName, fastGroup, cMsgs[0].MsgID)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
+
+	// The messages not consumed by the slow group are retained
+	res, err := rmq.Consume(topicName, slowGroup, msgNum)
+	assert.Nil(t, err)
+	assert.Equal(t, msgNum/2, len(res))
+	assert.Equal(t, cMsgs[msgNum/2].MsgID, res[0].MsgID)
+}
+
+func dirSize(t *testing.T, path string) int64 {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return size
+}
+
+func TestRmqRetention_DiskUsage(t *testing.T) {
+	atomic.StoreInt64(&RocksmqRetentionSizeInMB, -1)
+	atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, -1)
+	atomic.StoreInt64(&RocksmqPageSize, MB)
+	defer atomic.StoreInt64(&RocksmqRetentionSizeInMB, 0)
+	defer atomic.StoreInt64(&RocksmqRetentionTimeInMinutes, 0)
+	suffix := "disk_usage"
+	kvPath := retentionPath + kvPathSuffix + suffix
+	defer os.RemoveAll(kvPath)
+	idAllocator := InitIDAllocator(kvPath)
+
+	rocksdbPath := retentionPath + dbPathSuffix + suffix
+	defer os.RemoveAll(rocksdbPath)
+
+	rmq, err := NewRocksMQ(rocksdbPath, idAllocator)
+	assert.Nil(t, err)
+	defer rmq.Close()
+
+	topicName := "topic_disk_usage"
+	groupName := "test_group"
+	err = rmq.CreateTopic(topicName)
+	assert.Nil(t, err)
+
+	msgNum := 256
+	cMsgs := produceAndConsume(t, rmq, topicName, groupName, msgNum, 64*1024)
+
+	flushOpts := gorocksdb.NewDefaultFlushOptions()
+	defer flushOpts.Destroy()
+	err = rmq.store.Flush(flushOpts)
+	assert.Nil(t, err)
+	sizeBefore := dirSize(t, rocksdbPath)
+
+	// Retain at most 1MB acked messages
+	err = rmq.SetTopicRetention(topicName, -1, 1)
+	assert.Nil(t, err)
+	err = rmq.retentionInfo.newExpiredCleanUp(topicName)
+	assert.Nil(t, err)
+
+	err = rmq.Seek(topicName, groupName, cMsgs[0].MsgID)
+	assert.True(t, errors.Is(err, ErrPositionExpired))
+	assert.Eventually(t, func() bool {
+		return dirSize(t, rocksdbPath) < sizeBefore/2
+	}, 10*time.Second, 100*time.Millisecond)
+}
@@ -19,12 +19,28 @@ import (
 const (
 	// DefaultServiceRoot default root path used in kv by Session
 	DefaultServiceRoot = "session/"
+	// DefaultFencingRoot default root path of the fencing tokens of exclusive sessions,
+	// kept out of DefaultServiceRoot so the tokens outlive the session keys
+	DefaultFencingRoot = "fencing/"
 	// DefaultIDKey default id key for Session
 	DefaultIDKey = "id"
 	// DefaultRetryTimes default retry times when registerService or getServerByID
 	DefaultRetryTimes = 30
 	// DefaultTTL default ttl value when granting a lease
 	DefaultTTL = 60
+	// DefaultReregisterAttempts default retry times when re-registering a session whose lease is lost
+	DefaultReregisterAttempts = 5
+	// DefaultReregisterGrace default time watchers wait for a deleted session to be re-registered,
+	// the owner only notices the lost lease on its next keepalive, sent every DefaultTTL/3 seconds
+	DefaultReregisterGrace = DefaultTTL / 2 * time.Second
+)
+
+var (
+	// ErrSessionTaken is returned when a lost session can't be re-registered because its key is owned by another lease
+	ErrSessionTaken = errors.New("session key is owned by another lease")
+	// ErrExclusiveSessionLost is returned when an exclusive session lost its key,
+	// registering again would fence the writes the server made with its current token
+	ErrExclusiveSessionLost = errors.New("exclusive session is lost")
 )
 
 // SessionEventType session event type
@@ -37,23 +53,32 @@ const (
 	SessionAddEvent
 	// SessionDelEvent event type for a Session deleted
 	SessionDelEvent
+	// SessionUpdateEvent event type for a Session updated, e.g. marked as stopping
+	SessionUpdateEvent
 )
 
 // Session is a struct to store service's session, including ServerID, ServerName,
 // Address.
 // Exclusive indicates that this server can only start one.
+// Stopping indicates that this server is stopping gracefully and shouldn't be assigned new work.
+// FencingToken is increased by each registration of an exclusive session, writes guarded by it
+// are rejected once a newer server of the same role registered.
 type Session struct {
 	ctx        context.Context
 	ServerID   int64  `json:"ServerID,omitempty"`
 	ServerName string `json:"ServerName,omitempty"`
 	Address    string `json:"Address,omitempty"`
 	Exclusive  bool   `json:"Exclusive,omitempty"`
+	Stopping   bool   `json:"Stopping,omitempty"`
+
+	FencingToken int64 `json:"FencingToken,omitempty"`
 
-	liveCh   <-chan bool
-	etcdCli  *clientv3.Client
-	leaseID  clientv3.LeaseID
-	cancel   context.CancelFunc
-	metaRoot string
+	liveCh      <-chan bool
+	etcdCli     *clientv3.Client
+	leaseID     clientv3.LeaseID
+	cancel      context.CancelFunc
+	metaRoot    string
+	onLeaseLost func()
 }
 
 // NewSession is a helper to build Session object.
@@ -105,13 +130,19 @@ func (s *Session) Init(serverName, address string, exclusive bool) {
 		panic(err)
 	}
 	s.ServerID = serverID
-	ch, err := s.registerService()
+	ch, err := s.registerService(DefaultRetryTimes)
 	if err != nil {
 		panic(err)
 	}
 	s.liveCh = s.processKeepAliveResponse(ch)
 }
 
+// OnLeaseLost sets a callback invoked by LivenessCheck each time the session lease is lost,
+// before re-registration is attempted
+func (s *Session) OnLeaseLost(fn func()) {
+	s.onLeaseLost = fn
+}
+
 func (s *Session) getServerID() (int64, error) {
 	return s.getServerIDWithKey(DefaultIDKey, DefaultRetryTimes)
 }
@@ -178,7 +209,9 @@ func (s *Session) getServerIDWithKey(key string, retryTimes uint) (int64, error)
 // }
 // Exclusive means whether this service can exist two at the same time, if so,
 // it is false. Otherwise, set it to true.
-func (s *Session) registerService() (<-chan *clientv3.LeaseKeepAliveResponse, error) {
+// An exclusive service is registered in the same transaction that increases its fencing token,
+// the transaction fails if another session of the role is alive or the token was taken meanwhile.
+func (s *Session) registerService(attempts uint) (<-chan *clientv3.LeaseKeepAliveResponse, error) {
 	var ch <-chan *clientv3.LeaseKeepAliveResponse
 	log.Debug("Session Register Begin")
 	registerFn := func() error {
@@ -189,21 +222,27 @@ func (s *Session) registerService() (<-chan *clientv3.LeaseKeepAliveResponse, er
 		}
 		s.leaseID = resp.ID
 
+		key := s.getServiceKey()
+		cmps := []clientv3.Cmp{clientv3.Compare(clientv3.Version(key), "=", 0)}
+		var ops []clientv3.Op
+		if s.Exclusive {
+			fencingKey := s.FencingKey()
+			token, modRevision, err := s.loadFencingToken()
+			if err != nil {
+				return err
+			}
+			s.FencingToken = token + 1
+			cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(fencingKey), "=", modRevision))
+			ops = append(ops, clientv3.OpPut(fencingKey, strconv.FormatInt(s.FencingToken, 10)))
+		}
+
 		sessionJSON, err := json.Marshal(s)
 		if err != nil {
 			return err
 		}
+		ops = append(ops, clientv3.OpPut(key, string(sessionJSON), clientv3.WithLease(resp.ID)))
 
-		key := s.ServerName
-		if !s.Exclusive {
-			key = key + "-" + strconv.FormatInt(s.ServerID, 10)
-		}
-		txnResp, err := s.etcdCli.Txn(s.ctx).If(
-			clientv3.Compare(
-				clientv3.Version(path.Join(s.metaRoot, DefaultServiceRoot, key)),
-				"=",
-				0)).
-			Then(clientv3.OpPut(path.Join(s.metaRoot, DefaultServiceRoot, key), string(sessionJSON), clientv3.WithLease(resp.ID))).Commit()
+		txnResp, err := s.etcdCli.Txn(s.ctx).If(cmps...).Then(ops...).Commit()
 
 		if err != nil {
 			log.Warn("compare and swap error, maybe the key has ben registered", zap.Error(err))
@@ -211,6 +250,11 @@ func (s *Session) registerService() (<-chan *clientv3.LeaseKeepAliveResponse, er
 		}
 
 		if !txnResp.Succeeded {
+			if s.Exclusive {
+				// the token belongs to the winner of the race
+				s.FencingToken = 0
+				return fmt.Errorf("another %s session is alive or registering, key: %s", s.ServerName, key)
+			}
 			return fmt.Errorf("function CompareAndSwap error for compare is false for key: %s", key)
 		}
 
@@ -219,16 +263,139 @@ func (s *Session) registerService() (<-chan *clientv3.LeaseKeepAliveResponse, er
 			fmt.Printf("keep alive error %s\n", err)
 			return err
 		}
-		log.Debug("Session Register End", zap.Int64("ServerID", s.ServerID))
+		log.Debug("Session Register End", zap.Int64("ServerID", s.ServerID), zap.Int64("FencingToken", s.FencingToken))
 		return nil
 	}
-	err := retry.Do(s.ctx, registerFn, retry.Attempts(DefaultRetryTimes), retry.Sleep(500*time.Millisecond))
+	err := retry.Do(s.ctx, registerFn, retry.Attempts(attempts), retry.Sleep(500*time.Millisecond))
 	if err != nil {
 		return nil, err
 	}
 	return ch, nil
 }
 
+// reregister registers the session again with the same ServerID after its lease is lost.
+// It only succeeds when the previous key is verifiably gone, or still attached to our own lease,
+// otherwise another process may own the ServerID now and ErrSessionTaken is returned.
+// An exclusive session is never registered again, ErrExclusiveSessionLost is returned instead.
+func (s *Session) reregister() error {
+	if s.etcdCli == nil {
+		return errors.New("session is not initialized")
+	}
+	key := s.getServiceKey()
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
+	defer cancel()
+	resp, err := s.etcdCli.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if resp.Count > 0 {
+		if clientv3.LeaseID(resp.Kvs[0].Lease) != s.leaseID {
+			return fmt.Errorf("%w: %s", ErrSessionTaken, key)
+		}
+		// the lease outlived the keepalive stream, keep using it
+		ch, err := s.etcdCli.KeepAlive(s.ctx, s.leaseID)
+		if err != nil {
+			return err
+		}
+		s.liveCh = s.processKeepAliveResponse(ch)
+		log.Debug("Session keepalive resumed", zap.String("key", key), zap.Int64("ServerID", s.ServerID))
+		return nil
+	}
+	if s.Exclusive {
+		return fmt.Errorf("%w: %s", ErrExclusiveSessionLost, key)
+	}
+	ch, err := s.registerService(DefaultReregisterAttempts)
+	if err != nil {
+		return err
+	}
+	s.liveCh = s.processKeepAliveResponse(ch)
+	log.Info("Session re-registered", zap.String("key", key), zap.Int64("ServerID", s.ServerID))
+	return nil
+}
+
+// FencingKey returns the key of the fencing token of the session role in etcd,
+// meta writes compare it with FencingToken to reject a stale server
+func (s *Session) FencingKey() string {
+	return path.Join(s.metaRoot, DefaultFencingRoot, s.ServerName)
+}
+
+// loadFencingToken returns the current fencing token of the session role and the mod revision of its key,
+// both are 0 if no server of the role has registered yet
+func (s *Session) loadFencingToken() (int64, int64, error) {
+	resp, err := s.etcdCli.Get(s.ctx, s.FencingKey())
+	if err != nil {
+		return 0, 0, err
+	}
+	if resp.Count == 0 {
+		return 0, 0, nil
+	}
+	token, err := strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid fencing token %s: %w", string(resp.Kvs[0].Value), err)
+	}
+	return token, resp.Kvs[0].ModRevision, nil
+}
+
+// getServiceKey returns the key of the session in etcd
+func (s *Session) getServiceKey() string {
+	key := s.ServerName
+	if !s.Exclusive {
+		key = key + "-" + strconv.FormatInt(s.ServerID, 10)
+	}
+	return path.Join(s.metaRoot, DefaultServiceRoot, key)
+}
+
+// GoingStop marks the session as stopping in etcd, watchers would receive a SessionUpdateEvent.
+// The session is kept alive until the lease expires or the server exits.
+func (s *Session) GoingStop() error {
+	if s.etcdCli == nil {
+		return errors.New("session is not initialized")
+	}
+	s.Stopping = true
+	sessionJSON, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	key := s.getServiceKey()
+	txnResp, err := s.etcdCli.Txn(s.ctx).If(
+		clientv3.Compare(
+			clientv3.LeaseValue(key),
+			"=",
+			s.leaseID)).
+		Then(clientv3.OpPut(key, string(sessionJSON), clientv3.WithLease(s.leaseID))).Commit()
+	if err != nil {
+		return err
+	}
+	if !txnResp.Succeeded {
+		return fmt.Errorf("session of %s has expired", key)
+	}
+	log.Debug("Session marked as stopping", zap.String("key", key))
+	return nil
+}
+
+// CheckRegistered checks the session is still registered in etcd with its own lease,
+// which makes etcd reachable as well. It fails for a stopping session.
+func (s *Session) CheckRegistered(ctx context.Context) error {
+	if s.etcdCli == nil || s.ServerName == "" {
+		return errors.New("session is not initialized")
+	}
+	if s.Stopping {
+		return fmt.Errorf("session of %s is stopping", s.ServerName)
+	}
+	key := s.getServiceKey()
+	resp, err := s.etcdCli.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if resp.Count == 0 {
+		return fmt.Errorf("session of %s is not registered", key)
+	}
+	if clientv3.LeaseID(resp.Kvs[0].Lease) != s.leaseID {
+		return fmt.Errorf("%w: %s", ErrSessionTaken, key)
+	}
+	return nil
+}
+
 // processKeepAliveResponse processes the response of etcd keepAlive interface
 // If keepAlive fails for unexpected error, it will send a signal to the channel.
 func (s *Session) processKeepAliveResponse(ch <-chan *clientv3.LeaseKeepAliveResponse) (failChannel <-chan bool) {
@@ -289,6 +456,27 @@ type SessionEvent struct {
 	Session   *Session
 }
 
+// WatchOption customizes the behavior of WatchServices
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	reregisterGrace time.Duration
+}
+
+// WithReregisterGrace holds session delete events for grace. If the session is re-registered
+// with the same ServerID within grace, it is treated as the same server and neither the delete
+// nor the add event is sent.
+func WithReregisterGrace(grace time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		c.reregisterGrace = grace
+	}
+}
+
+type pendingDelEvent struct {
+	event    *SessionEvent
+	deadline time.Time
+}
+
 // WatchServices watch the service's up and down in etcd, and send event to
 // eventChannel.
 // prefix is a parameter to know which service to watch and can be obtained in
@@ -297,14 +485,40 @@ type SessionEvent struct {
 // in GetSessions.
 // If a server up, a event will be add to channel with eventType SessionAddType.
 // If a server down, a event will be add to channel with eventType SessionDelType.
-func (s *Session) WatchServices(prefix string, revision int64) (eventChannel <-chan *SessionEvent) {
+func (s *Session) WatchServices(prefix string, revision int64, opts ...WatchOption) (eventChannel <-chan *SessionEvent) {
+	cfg := &watchConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 	eventCh := make(chan *SessionEvent, 100)
 	rch := s.etcdCli.Watch(s.ctx, path.Join(s.metaRoot, DefaultServiceRoot, prefix), clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithRev(revision))
 	go func() {
+		// delete events waiting for the session to be re-registered, keyed by session key
+		pending := make(map[string]*pendingDelEvent)
+		flushPending := func(now time.Time, all bool) {
+			for key, p := range pending {
+				if all || now.After(p.deadline) {
+					delete(pending, key)
+					eventCh <- p.event
+				}
+			}
+		}
+		var tickCh <-chan time.Time
+		if cfg.reregisterGrace > 0 {
+			interval := cfg.reregisterGrace / 4
+			if interval <= 0 {
+				interval = cfg.reregisterGrace
+			}
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			tickCh = ticker.C
+		}
 		for {
 			select {
 			case <-s.ctx.Done():
 				return
+			case now := <-tickCh:
+				flushPending(now, false)
 			case wresp, ok := <-rch:
 				if !ok {
 					return
@@ -312,6 +526,7 @@ func (s *Session) WatchServices(prefix string, revision int64) (eventChannel <-c
 				if wresp.Err() != nil {
 					//close event channel
 					log.Warn("Watch service found error", zap.Error(wresp.Err()))
+					flushPending(time.Now(), true)
 					close(eventCh)
 					return
 				}
@@ -328,6 +543,24 @@ func (s *Session) WatchServices(prefix string, revision int64) (eventChannel <-c
 							continue
 						}
 						eventType = SessionAddEvent
+						if ev.IsModify() {
+							eventType = SessionUpdateEvent
+						}
+						if p, ok := pending[string(ev.Kv.Key)]; ok {
+							delete(pending, string(ev.Kv.Key))
+							if p.event.Session.ServerID == session.ServerID {
+								log.Info("watch services, session re-registered",
+									zap.String("key", string(ev.Kv.Key)),
+									zap.Int64("serverID", session.ServerID))
+								if p.event.Session.Stopping == session.Stopping {
+									continue
+								}
+								eventType = SessionUpdateEvent
+							} else {
+								// another server took over the key, the previous one is gone
+								eventCh <- p.event
+							}
+						}
 					case mvccpb.DELETE:
 						log.Debug("watch services",
 							zap.Any("delete kv", ev.PrevKv))
@@ -337,6 +570,16 @@ func (s *Session) WatchServices(prefix string, revision int64) (eventChannel <-c
 							continue
 						}
 						eventType = SessionDelEvent
+						if cfg.reregisterGrace > 0 {
+							pending[string(ev.Kv.Key)] = &pendingDelEvent{
+								event: &SessionEvent{
+									EventType: eventType,
+									Session:   session,
+								},
+								deadline: time.Now().Add(cfg.reregisterGrace),
+							}
+							continue
+						}
 					}
 					log.Debug("WatchService", zap.Any("event type", eventType))
 					eventCh <- &SessionEvent{
@@ -354,7 +597,8 @@ func (s *Session) WatchServices(prefix string, revision int64) (eventChannel <-c
 // LivenessCheck performs liveness check with provided context and channel
 // ctx controls the liveness check loop
 // ch is the liveness signal channel, ch is closed only when the session is expired
-// callback is the function to call when ch is closed, note that callback will not be invoked when loop exits due to context
+// When ch is closed, the OnLeaseLost callback is invoked and the session is re-registered with the same ServerID.
+// callback is the function to call when re-registration fails, note that callback will not be invoked when loop exits due to context
 func (s *Session) LivenessCheck(ctx context.Context, callback func()) {
 	for {
 		select {
@@ -364,7 +608,15 @@ func (s *Session) LivenessCheck(ctx context.Context, callback func()) {
 				continue
 			}
 			// not ok, connection lost
-			log.Warn("connection lost detected, shuting down")
+			log.Warn("session lease lost", zap.String("ServerName", s.ServerName), zap.Int64("ServerID", s.ServerID))
+			if s.onLeaseLost != nil {
+				s.onLeaseLost()
+			}
+			err := s.reregister()
+			if err == nil {
+				continue
+			}
+			log.Warn("connection lost detected, shuting down", zap.Error(err))
 			if callback != nil {
 				go callback()
 			}
@@ -2,6 +2,7 @@ package sessionutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -202,3 +203,329 @@ func TestSessionLivenessCheck(t *testing.T) {
 
 	assert.False(t, flag)
 }
+
+func TestSessionGoingStop(t *testing.T) {
+	ctx := context.Background()
+	Params.Init()
+
+	endpoints, err := Params.Load("_EtcdEndpoints")
+	if err != nil {
+		panic(err)
+	}
+
+	etcdEndpoints := strings.Split(endpoints, ",")
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+	etcdKV, err := etcdkv.NewEtcdKV(etcdEndpoints, "")
+	assert.NoError(t, err)
+
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix("")
+
+	s := NewSession(ctx, metaRoot, etcdEndpoints)
+	_, rev, err := s.GetSessions("test")
+	assert.Nil(t, err)
+	eventCh := s.WatchServices("test", rev)
+
+	stopping := NewSession(ctx, metaRoot, etcdEndpoints)
+	stopping.Init("test", "testAddr", false)
+	err = stopping.GoingStop()
+	assert.NoError(t, err)
+
+	sessions, _, err := s.GetSessions("test")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(sessions))
+	for _, session := range sessions {
+		assert.True(t, session.Stopping)
+	}
+
+	addEvent := <-eventCh
+	assert.Equal(t, SessionAddEvent, addEvent.EventType)
+	assert.False(t, addEvent.Session.Stopping)
+	updateEvent := <-eventCh
+	assert.Equal(t, SessionUpdateEvent, updateEvent.EventType)
+	assert.True(t, updateEvent.Session.Stopping)
+
+	uninitialized := &Session{}
+	assert.Error(t, uninitialized.GoingStop())
+}
+
+func TestSessionCheckRegistered(t *testing.T) {
+	ctx := context.Background()
+	Params.Init()
+
+	endpoints, err := Params.Load("_EtcdEndpoints")
+	if err != nil {
+		panic(err)
+	}
+
+	etcdEndpoints := strings.Split(endpoints, ",")
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+	etcdKV, err := etcdkv.NewEtcdKV(etcdEndpoints, "")
+	assert.NoError(t, err)
+
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix("")
+
+	s := NewSession(ctx, metaRoot, etcdEndpoints)
+	assert.Error(t, s.CheckRegistered(ctx))
+
+	s.Init("test", "testAddr", false)
+	assert.NoError(t, s.CheckRegistered(ctx))
+
+	_, err = s.etcdCli.Revoke(ctx, s.leaseID)
+	assert.NoError(t, err)
+	assert.Error(t, s.CheckRegistered(ctx))
+
+	stopping := NewSession(ctx, metaRoot, etcdEndpoints)
+	stopping.Init("test", "testAddr", false)
+	assert.NoError(t, stopping.GoingStop())
+	assert.Error(t, stopping.CheckRegistered(ctx))
+
+	uninitialized := &Session{}
+	assert.Error(t, uninitialized.CheckRegistered(ctx))
+}
+
+func TestSessionReregister(t *testing.T) {
+	ctx := context.Background()
+	Params.Init()
+
+	endpoints, err := Params.Load("_EtcdEndpoints")
+	if err != nil {
+		panic(err)
+	}
+
+	etcdEndpoints := strings.Split(endpoints, ",")
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+	etcdKV, err := etcdkv.NewEtcdKV(etcdEndpoints, "")
+	assert.NoError(t, err)
+
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix("")
+
+	watcher := NewSession(ctx, metaRoot, etcdEndpoints)
+	_, rev, err := watcher.GetSessions("test")
+	assert.NoError(t, err)
+	eventCh := watcher.WatchServices("test", rev, WithReregisterGrace(DefaultReregisterGrace))
+
+	s := NewSession(ctx, metaRoot, etcdEndpoints)
+	s.Init("test", "testAddr", false)
+	addEvent := <-eventCh
+	assert.Equal(t, SessionAddEvent, addEvent.EventType)
+	assert.Equal(t, s.ServerID, addEvent.Session.ServerID)
+
+	lost := make(chan struct{}, 1)
+	s.OnLeaseLost(func() {
+		lost <- struct{}{}
+	})
+	exited := make(chan struct{}, 1)
+	checkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.LivenessCheck(checkCtx, func() {
+		exited <- struct{}{}
+	})
+
+	// kill the lease, the session must come back with the same ServerID
+	oldLease := s.leaseID
+	_, err = s.etcdCli.Revoke(ctx, oldLease)
+	assert.NoError(t, err)
+	<-lost
+	assert.Eventually(t, func() bool {
+		sessions, _, err := watcher.GetSessions("test")
+		if err != nil || len(sessions) != 1 {
+			return false
+		}
+		for _, session := range sessions {
+			if session.ServerID != s.ServerID {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.NotEqual(t, oldLease, s.leaseID)
+
+	// the watcher must not see the server leave and join again,
+	// a delete event would have been sent before the re-registration was observed
+	select {
+	case event := <-eventCh:
+		t.Errorf("unexpected session event %v", event.EventType)
+	case <-exited:
+		t.Error("session exited after re-registration")
+	case <-time.After(2 * time.Second):
+	}
+}
+
+func TestSessionReregisterTaken(t *testing.T) {
+	ctx := context.Background()
+	Params.Init()
+
+	endpoints, err := Params.Load("_EtcdEndpoints")
+	if err != nil {
+		panic(err)
+	}
+
+	etcdEndpoints := strings.Split(endpoints, ",")
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+	etcdKV, err := etcdkv.NewEtcdKV(etcdEndpoints, "")
+	assert.NoError(t, err)
+
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix("")
+
+	watcher := NewSession(ctx, metaRoot, etcdEndpoints)
+	_, rev, err := watcher.GetSessions("test")
+	assert.NoError(t, err)
+	eventCh := watcher.WatchServices("test", rev, WithReregisterGrace(time.Second))
+
+	s := NewSession(ctx, metaRoot, etcdEndpoints)
+	s.Init("test", "testAddr", false)
+	addEvent := <-eventCh
+	assert.Equal(t, SessionAddEvent, addEvent.EventType)
+
+	// kill the lease and let another owner take the key before the liveness check notices
+	_, err = s.etcdCli.Revoke(ctx, s.leaseID)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		select {
+		case _, ok := <-s.liveCh:
+			return !ok
+		default:
+			return false
+		}
+	}, DefaultTTL*time.Second, 100*time.Millisecond)
+	_, err = s.etcdCli.Put(ctx, s.getServiceKey(), `{"ServerID":-1}`)
+	assert.NoError(t, err)
+
+	exited := make(chan struct{}, 1)
+	go s.LivenessCheck(ctx, func() {
+		exited <- struct{}{}
+	})
+	select {
+	case <-exited:
+	case <-time.After(10 * time.Second):
+		t.Error("session neither re-registered nor exited")
+	}
+
+	// the pending delete is flushed when another server takes the key
+	delEvent := <-eventCh
+	assert.Equal(t, SessionDelEvent, delEvent.EventType)
+	assert.Equal(t, s.ServerID, delEvent.Session.ServerID)
+	takenEvent := <-eventCh
+	assert.Equal(t, SessionAddEvent, takenEvent.EventType)
+	assert.Equal(t, int64(-1), takenEvent.Session.ServerID)
+}
+
+func TestWatchServicesReregisterGrace(t *testing.T) {
+	ctx := context.Background()
+	Params.Init()
+
+	endpoints, err := Params.Load("_EtcdEndpoints")
+	if err != nil {
+		panic(err)
+	}
+
+	etcdEndpoints := strings.Split(endpoints, ",")
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+	etcdKV, err := etcdkv.NewEtcdKV(etcdEndpoints, "")
+	assert.NoError(t, err)
+
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix("")
+
+	watcher := NewSession(ctx, metaRoot, etcdEndpoints)
+	_, rev, err := watcher.GetSessions("test")
+	assert.NoError(t, err)
+	eventCh := watcher.WatchServices("test", rev, WithReregisterGrace(time.Second))
+
+	s := NewSession(ctx, metaRoot, etcdEndpoints)
+	s.Init("test", "testAddr", false)
+	addEvent := <-eventCh
+	assert.Equal(t, SessionAddEvent, addEvent.EventType)
+
+	// nobody re-registers, the delete event is sent once the grace period is over
+	start := time.Now()
+	_, err = s.etcdCli.Revoke(ctx, s.leaseID)
+	assert.NoError(t, err)
+	delEvent := <-eventCh
+	assert.Equal(t, SessionDelEvent, delEvent.EventType)
+	assert.Equal(t, s.ServerID, delEvent.Session.ServerID)
+	assert.True(t, time.Since(start) >= time.Second)
+}
+
+func TestExclusiveSessionFencing(t *testing.T) {
+	ctx := context.Background()
+	Params.Init()
+
+	endpoints, err := Params.Load("_EtcdEndpoints")
+	if err != nil {
+		panic(err)
+	}
+
+	etcdEndpoints := strings.Split(endpoints, ",")
+	metaRoot := fmt.Sprintf("%d/%s", rand.Int(), DefaultServiceRoot)
+	etcdKV, err := etcdkv.NewEtcdKV(etcdEndpoints, "")
+	assert.NoError(t, err)
+
+	defer etcdKV.Close()
+	defer etcdKV.RemoveWithPrefix("")
+
+	// two coordinators register at the same time, only one of them wins
+	sessions := []*Session{NewSession(ctx, metaRoot, etcdEndpoints), NewSession(ctx, metaRoot, etcdEndpoints)}
+	errs := make([]error, len(sessions))
+	var wg sync.WaitGroup
+	for i, s := range sessions {
+		s.ServerName = "coord"
+		s.Exclusive = true
+		s.checkIDExist()
+		s.ServerID, err = s.getServerID()
+		assert.NoError(t, err)
+		wg.Add(1)
+		go func(i int, s *Session) {
+			defer wg.Done()
+			_, errs[i] = s.registerService(1)
+		}(i, s)
+	}
+	wg.Wait()
+	assert.True(t, (errs[0] == nil) != (errs[1] == nil))
+	winner, loser := sessions[0], sessions[1]
+	if errs[0] != nil {
+		winner, loser = sessions[1], sessions[0]
+	}
+	assert.Equal(t, int64(1), winner.FencingToken)
+	assert.Equal(t, int64(0), loser.FencingToken)
+
+	newFencedKV := func(s *Session) *etcdkv.EtcdKV {
+		kv, err := etcdkv.NewEtcdKV(etcdEndpoints, metaRoot+"/meta")
+		assert.NoError(t, err)
+		kv.SetFence(s.FencingKey(), s.FencingToken)
+		return kv
+	}
+	winnerKV := newFencedKV(winner)
+	defer winnerKV.Close()
+	loserKV := newFencedKV(loser)
+	defer loserKV.Close()
+
+	assert.NoError(t, winnerKV.Save("key", "winner"))
+	err = loserKV.Save("key", "loser")
+	assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+
+	// the winner loses its lease, the loser takes over with a newer token
+	_, err = winner.etcdCli.Revoke(ctx, winner.leaseID)
+	assert.NoError(t, err)
+	err = winner.reregister()
+	assert.True(t, errors.Is(err, ErrExclusiveSessionLost))
+
+	_, err = loser.registerService(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), loser.FencingToken)
+	loserKV.SetFence(loser.FencingKey(), loser.FencingToken)
+
+	assert.NoError(t, loserKV.Save("key", "loser"))
+	err = winnerKV.Save("key", "winner")
+	assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+	err = winnerKV.CompareValueAndSwap("key", "loser", "winner")
+	assert.True(t, errors.Is(err, etcdkv.ErrFenced))
+
+	value, err := loserKV.Load("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "loser", value)
+}
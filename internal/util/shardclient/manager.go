@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+// Creator creates a client of the query node at the address.
+type Creator func(ctx context.Context, address string) (types.QueryNode, error)
+
+type nodeClient struct {
+	address string
+	client  types.QueryNode
+}
+
+// Manager caches the clients of the query nodes serving the shards, keyed by node id.
+// A client is recreated when the node comes back at another address.
+type Manager struct {
+	ctx     context.Context
+	creator Creator
+
+	mu      sync.Mutex
+	clients map[int64]*nodeClient
+}
+
+// NewManager returns a Manager creating the clients by creator.
+func NewManager(ctx context.Context, creator Creator) *Manager {
+	return &Manager{
+		ctx:     ctx,
+		creator: creator,
+		clients: make(map[int64]*nodeClient),
+	}
+}
+
+// GetClient returns the client of the query node, the client is created and started if not cached.
+func (m *Manager) GetClient(nodeID int64, address string) (types.QueryNode, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[nodeID]; ok {
+		if c.address == address {
+			return c.client, nil
+		}
+		m.stopClient(nodeID, c)
+	}
+
+	client, err := m.creator(m.ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Init(); err != nil {
+		return nil, err
+	}
+	if err = client.Start(); err != nil {
+		return nil, err
+	}
+	m.clients[nodeID] = &nodeClient{
+		address: address,
+		client:  client,
+	}
+	return client, nil
+}
+
+// RemoveClient stops and removes the client of the query node, the next GetClient reconnects the node.
+func (m *Manager) RemoveClient(nodeID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[nodeID]; ok {
+		m.stopClient(nodeID, c)
+	}
+}
+
+// Close stops all the clients.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for nodeID, c := range m.clients {
+		m.stopClient(nodeID, c)
+	}
+}
+
+func (m *Manager) stopClient(nodeID int64, c *nodeClient) {
+	if err := c.client.Stop(); err != nil {
+		log.Warn("stop query node client failed", zap.Int64("nodeID", nodeID), zap.String("address", c.address), zap.Error(err))
+	}
+	delete(m.clients, nodeID)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/types"
+)
+
+type mockQueryNode struct {
+	types.QueryNode
+	address string
+	initErr error
+	stopped bool
+}
+
+func (m *mockQueryNode) Init() error {
+	return m.initErr
+}
+
+func (m *mockQueryNode) Start() error {
+	return nil
+}
+
+func (m *mockQueryNode) Stop() error {
+	m.stopped = true
+	return nil
+}
+
+func TestManager(t *testing.T) {
+	created := make([]*mockQueryNode, 0)
+	var initErr error
+	creator := func(ctx context.Context, address string) (types.QueryNode, error) {
+		if address == "" {
+			return nil, errors.New("address is empty")
+		}
+		node := &mockQueryNode{address: address, initErr: initErr}
+		created = append(created, node)
+		return node, nil
+	}
+	m := NewManager(context.Background(), creator)
+
+	c1, err := m.GetClient(1, "node1")
+	assert.Nil(t, err)
+	assert.Equal(t, "node1", c1.(*mockQueryNode).address)
+
+	c, err := m.GetClient(1, "node1")
+	assert.Nil(t, err)
+	assert.True(t, c == c1)
+	assert.Equal(t, 1, len(created))
+
+	// node restarted at another address
+	c2, err := m.GetClient(1, "node1-new")
+	assert.Nil(t, err)
+	assert.Equal(t, "node1-new", c2.(*mockQueryNode).address)
+	assert.True(t, c1.(*mockQueryNode).stopped)
+
+	m.RemoveClient(1)
+	assert.True(t, c2.(*mockQueryNode).stopped)
+	c3, err := m.GetClient(1, "node1-new")
+	assert.Nil(t, err)
+	assert.False(t, c3 == c2)
+
+	_, err = m.GetClient(2, "")
+	assert.NotNil(t, err)
+
+	initErr = errors.New("init failed")
+	_, err = m.GetClient(3, "node3")
+	assert.NotNil(t, err)
+	initErr = nil
+
+	c4, err := m.GetClient(4, "node4")
+	assert.Nil(t, err)
+	m.Close()
+	assert.True(t, c3.(*mockQueryNode).stopped)
+	assert.True(t, c4.(*mockQueryNode).stopped)
+	assert.Equal(t, 0, len(m.clients))
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// mockQueryCoord logs the requests it receives the way the coordinators do
+type mockQueryCoord struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (m *mockQueryCoord) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	log.Ctx(log.WithRole(ctx, "querycoord")).Named("querycoord.scheduler").Info("querycoord handles the request")
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+type syncBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestInterceptorTraceIDLogging(t *testing.T) {
+	buf := &syncBuffer{}
+	logger, p, err := log.InitLoggerWithWriteSyncer(&log.Config{Level: "info", DisableTimestamp: true}, zapcore.AddSync(buf))
+	require.NoError(t, err)
+	log.ReplaceGlobals(logger, p)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpc_opentracing.UnaryServerInterceptor(GetInterceptorOpts()...)))
+	grpc_health_v1.RegisterHealthServer(server, &mockQueryCoord{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(grpc_opentracing.UnaryClientInterceptor(GetInterceptorOpts()...)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the proxy starts a span for the search and calls the coordinator with it
+	sp, ctx := StartSpanFromContext(ctx)
+	defer sp.Finish()
+	traceID, _, found := InfoFromContext(ctx)
+	require.True(t, found)
+	log.Ctx(log.WithRole(ctx, "proxy")).Named("proxy.scheduler").Info("proxy sends the request")
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	log.Sync()
+
+	var proxyLine, coordLine string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "proxy sends the request") {
+			proxyLine = line
+		} else if strings.Contains(line, "querycoord handles the request") {
+			coordLine = line
+		}
+	}
+	assert.Contains(t, proxyLine, "[traceID="+traceID+"] [role=proxy]")
+	assert.Contains(t, coordLine, "[traceID="+traceID+"] [role=querycoord]")
+}
@@ -14,6 +14,7 @@ package typeutil
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/milvus-io/milvus/internal/proto/schemapb"
@@ -181,3 +182,245 @@ func IsBoolType(dataType schemapb.DataType) bool {
 		return false
 	}
 }
+
+// PrepareResultFieldData creates the empty fields data of the same types as sample,
+// the data of each field is preallocated to hold topK rows.
+func PrepareResultFieldData(sample []*schemapb.FieldData, topK int64) []*schemapb.FieldData {
+	result := make([]*schemapb.FieldData, len(sample))
+	for i, fieldData := range sample {
+		fd := &schemapb.FieldData{
+			Type:      fieldData.Type,
+			FieldName: fieldData.FieldName,
+			FieldId:   fieldData.FieldId,
+		}
+		switch fieldType := fieldData.Field.(type) {
+		case *schemapb.FieldData_Scalars:
+			scalars := &schemapb.ScalarField{}
+			switch fieldType.Scalars.Data.(type) {
+			case *schemapb.ScalarField_BoolData:
+				scalars.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: make([]bool, 0, topK)}}
+			case *schemapb.ScalarField_IntData:
+				scalars.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: make([]int32, 0, topK)}}
+			case *schemapb.ScalarField_LongData:
+				scalars.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: make([]int64, 0, topK)}}
+			case *schemapb.ScalarField_FloatData:
+				scalars.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: make([]float32, 0, topK)}}
+			case *schemapb.ScalarField_DoubleData:
+				scalars.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: make([]float64, 0, topK)}}
+			case *schemapb.ScalarField_StringData:
+				scalars.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: make([]string, 0, topK)}}
+			}
+			fd.Field = &schemapb.FieldData_Scalars{Scalars: scalars}
+		case *schemapb.FieldData_Vectors:
+			dim := fieldType.Vectors.Dim
+			vectors := &schemapb.VectorField{Dim: dim}
+			switch fieldType.Vectors.Data.(type) {
+			case *schemapb.VectorField_FloatVector:
+				vectors.Data = &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: make([]float32, 0, topK*dim)}}
+			case *schemapb.VectorField_BinaryVector:
+				vectors.Data = &schemapb.VectorField_BinaryVector{BinaryVector: make([]byte, 0, topK*dim/8)}
+			}
+			fd.Field = &schemapb.FieldData_Vectors{Vectors: vectors}
+		}
+		result[i] = fd
+	}
+	return result
+}
+
+// AppendFieldData appends the row at idx of every field in src to the same field in dst,
+// the missing fields in dst are created on demand.
+func AppendFieldData(dst []*schemapb.FieldData, src []*schemapb.FieldData, idx int64) error {
+	for i, fieldData := range src {
+		switch fieldType := fieldData.Field.(type) {
+		case *schemapb.FieldData_Scalars:
+			if dst[i] == nil || dst[i].GetScalars() == nil {
+				dst[i] = &schemapb.FieldData{
+					Type:      fieldData.Type,
+					FieldName: fieldData.FieldName,
+					FieldId:   fieldData.FieldId,
+					Field: &schemapb.FieldData_Scalars{
+						Scalars: &schemapb.ScalarField{},
+					},
+				}
+			}
+			dstScalars := dst[i].GetScalars()
+			switch srcScalar := fieldType.Scalars.Data.(type) {
+			case *schemapb.ScalarField_BoolData:
+				if dstScalars.GetBoolData() == nil {
+					dstScalars.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{}}
+				}
+				dstScalars.GetBoolData().Data = append(dstScalars.GetBoolData().Data, srcScalar.BoolData.Data[idx])
+			case *schemapb.ScalarField_IntData:
+				if dstScalars.GetIntData() == nil {
+					dstScalars.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{}}
+				}
+				dstScalars.GetIntData().Data = append(dstScalars.GetIntData().Data, srcScalar.IntData.Data[idx])
+			case *schemapb.ScalarField_LongData:
+				if dstScalars.GetLongData() == nil {
+					dstScalars.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{}}
+				}
+				dstScalars.GetLongData().Data = append(dstScalars.GetLongData().Data, srcScalar.LongData.Data[idx])
+			case *schemapb.ScalarField_FloatData:
+				if dstScalars.GetFloatData() == nil {
+					dstScalars.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{}}
+				}
+				dstScalars.GetFloatData().Data = append(dstScalars.GetFloatData().Data, srcScalar.FloatData.Data[idx])
+			case *schemapb.ScalarField_DoubleData:
+				if dstScalars.GetDoubleData() == nil {
+					dstScalars.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{}}
+				}
+				dstScalars.GetDoubleData().Data = append(dstScalars.GetDoubleData().Data, srcScalar.DoubleData.Data[idx])
+			case *schemapb.ScalarField_StringData:
+				if dstScalars.GetStringData() == nil {
+					dstScalars.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{}}
+				}
+				dstScalars.GetStringData().Data = append(dstScalars.GetStringData().Data, srcScalar.StringData.Data[idx])
+			default:
+				return fmt.Errorf("not supported field type: %s", fieldData.Type.String())
+			}
+		case *schemapb.FieldData_Vectors:
+			dim := fieldType.Vectors.Dim
+			if dst[i] == nil || dst[i].GetVectors() == nil {
+				dst[i] = &schemapb.FieldData{
+					Type:      fieldData.Type,
+					FieldName: fieldData.FieldName,
+					FieldId:   fieldData.FieldId,
+					Field: &schemapb.FieldData_Vectors{
+						Vectors: &schemapb.VectorField{
+							Dim: dim,
+						},
+					},
+				}
+			}
+			dstVectors := dst[i].GetVectors()
+			switch srcVector := fieldType.Vectors.Data.(type) {
+			case *schemapb.VectorField_BinaryVector:
+				if dstVectors.GetBinaryVector() == nil {
+					dstVectors.Data = &schemapb.VectorField_BinaryVector{BinaryVector: []byte{}}
+				}
+				bvec := dstVectors.Data.(*schemapb.VectorField_BinaryVector)
+				bvec.BinaryVector = append(bvec.BinaryVector, srcVector.BinaryVector[idx*(dim/8):(idx+1)*(dim/8)]...)
+			case *schemapb.VectorField_FloatVector:
+				if dstVectors.GetFloatVector() == nil {
+					dstVectors.Data = &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{}}
+				}
+				dstVectors.GetFloatVector().Data = append(dstVectors.GetFloatVector().Data, srcVector.FloatVector.Data[idx*dim:(idx+1)*dim]...)
+			default:
+				return fmt.Errorf("not supported field type: %s", fieldData.Type.String())
+			}
+		default:
+			return fmt.Errorf("not supported field type: %s", fieldData.Type.String())
+		}
+	}
+	return nil
+}
+
+// SliceRowsByPrimaryKey sorts the rows of fieldsData by their primary keys ids, removes the rows of duplicated
+// primary keys and returns the rows in [offset, offset+limit) of the sorted rows, limit <= 0 means no limit.
+// The rows of the same primary key are considered identical, the first one is kept.
+func SliceRowsByPrimaryKey(ids []int64, fieldsData []*schemapb.FieldData, offset int64, limit int64) ([]int64, []*schemapb.FieldData, error) {
+	if offset < 0 {
+		return nil, nil, fmt.Errorf("invalid offset %d", offset)
+	}
+	indexes := make([]int, len(ids))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return ids[indexes[i]] < ids[indexes[j]]
+	})
+
+	unique := make([]int, 0, len(indexes))
+	for _, idx := range indexes {
+		if len(unique) > 0 && ids[unique[len(unique)-1]] == ids[idx] {
+			continue
+		}
+		unique = append(unique, idx)
+	}
+	if offset >= int64(len(unique)) {
+		unique = unique[:0]
+	} else {
+		unique = unique[offset:]
+	}
+	if limit > 0 && limit < int64(len(unique)) {
+		unique = unique[:limit]
+	}
+
+	retIDs := make([]int64, 0, len(unique))
+	retFieldsData := PrepareResultFieldData(fieldsData, int64(len(unique)))
+	for _, idx := range unique {
+		retIDs = append(retIDs, ids[idx])
+		if err := AppendFieldData(retFieldsData, fieldsData, int64(idx)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return retIDs, retFieldsData, nil
+}
+
+// GetPrimaryFieldSchema returns the schema of the primary key field
+func GetPrimaryFieldSchema(schema *schemapb.CollectionSchema) (*schemapb.FieldSchema, error) {
+	for _, field := range schema.GetFields() {
+		if field.IsPrimaryKey {
+			return field, nil
+		}
+	}
+	return nil, errors.New("primary field is not found")
+}
+
+// GetPrimaryFieldData returns the data of the primary key field in datas, the field is matched by name
+// since the fields data of requests don't carry the field id
+func GetPrimaryFieldData(datas []*schemapb.FieldData, primaryFieldSchema *schemapb.FieldSchema) (*schemapb.FieldData, error) {
+	for _, field := range datas {
+		if field.GetFieldName() == primaryFieldSchema.GetName() {
+			return field, nil
+		}
+	}
+	return nil, fmt.Errorf("data of primary field %s is not found", primaryFieldSchema.GetName())
+}
+
+// GetInt64PrimaryKeys returns the int64 primary keys in the data of the primary key field
+func GetInt64PrimaryKeys(primaryFieldData *schemapb.FieldData) ([]int64, error) {
+	longData := primaryFieldData.GetScalars().GetLongData()
+	if longData == nil {
+		return nil, fmt.Errorf("primary field %s is not of int64 data", primaryFieldData.GetFieldName())
+	}
+	return longData.Data, nil
+}
+
+// FindDuplicatePrimaryKeys returns the offsets of the last occurrences of every primary key in ascending order,
+// and the primary keys occurring more than once in the order of their first occurrences
+func FindDuplicatePrimaryKeys(pks []int64) ([]int, []int64) {
+	type occurrence struct {
+		last  int
+		count int
+	}
+	occurrences := make(map[int64]occurrence, len(pks))
+	var duplicates []int64
+	for offset, pk := range pks {
+		o := occurrences[pk]
+		o.count++
+		if o.count == 2 {
+			duplicates = append(duplicates, pk)
+		}
+		o.last = offset
+		occurrences[pk] = o
+	}
+	lastOccurrences := make([]int, 0, len(occurrences))
+	for offset, pk := range pks {
+		if occurrences[pk].last == offset {
+			lastOccurrences = append(lastOccurrences, offset)
+		}
+	}
+	return lastOccurrences, duplicates
+}
+
+// SelectRows returns the rows at offsets of every field in fieldsData
+func SelectRows(fieldsData []*schemapb.FieldData, offsets []int) ([]*schemapb.FieldData, error) {
+	ret := PrepareResultFieldData(fieldsData, int64(len(offsets)))
+	for _, offset := range offsets {
+		if err := AppendFieldData(ret, fieldsData, int64(offset)); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
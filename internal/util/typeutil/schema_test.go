@@ -12,6 +12,8 @@
 package typeutil
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/milvus-io/milvus/internal/proto/commonpb"
@@ -324,3 +326,293 @@ func TestSchema_invalid(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func genFieldData(fieldName string, fieldID int64, fieldType schemapb.DataType, fieldValue interface{}, dim int64) *schemapb.FieldData {
+	var fieldData *schemapb.FieldData
+	switch fieldType {
+	case schemapb.DataType_Bool:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: fieldValue.([]bool)}},
+				},
+			},
+		}
+	case schemapb.DataType_Int32:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: fieldValue.([]int32)}},
+				},
+			},
+		}
+	case schemapb.DataType_Int64:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: fieldValue.([]int64)}},
+				},
+			},
+		}
+	case schemapb.DataType_Float:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: fieldValue.([]float32)}},
+				},
+			},
+		}
+	case schemapb.DataType_Double:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: fieldValue.([]float64)}},
+				},
+			},
+		}
+	case schemapb.DataType_String:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: fieldValue.([]string)}},
+				},
+			},
+		}
+	case schemapb.DataType_BinaryVector:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Dim:  dim,
+					Data: &schemapb.VectorField_BinaryVector{BinaryVector: fieldValue.([]byte)},
+				},
+			},
+		}
+	case schemapb.DataType_FloatVector:
+		fieldData = &schemapb.FieldData{
+			Field: &schemapb.FieldData_Vectors{
+				Vectors: &schemapb.VectorField{
+					Dim:  dim,
+					Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: fieldValue.([]float32)}},
+				},
+			},
+		}
+	}
+	fieldData.Type = fieldType
+	fieldData.FieldName = fieldName
+	fieldData.FieldId = fieldID
+	return fieldData
+}
+
+func TestAppendFieldData(t *testing.T) {
+	const dim = 16
+	src := []*schemapb.FieldData{
+		genFieldData("bool", 100, schemapb.DataType_Bool, []bool{true, false}, 1),
+		genFieldData("int32", 101, schemapb.DataType_Int32, []int32{1, 2}, 1),
+		genFieldData("int64", 102, schemapb.DataType_Int64, []int64{3, 4}, 1),
+		genFieldData("float", 103, schemapb.DataType_Float, []float32{1.1, 2.2}, 1),
+		genFieldData("double", 104, schemapb.DataType_Double, []float64{3.3, 4.4}, 1),
+		genFieldData("string", 105, schemapb.DataType_String, []string{"a", "b"}, 1),
+		genFieldData("bvec", 106, schemapb.DataType_BinaryVector, []byte{1, 2, 3, 4}, dim),
+		genFieldData("fvec", 107, schemapb.DataType_FloatVector, make([]float32, 2*dim), dim),
+	}
+	src[7].GetVectors().GetFloatVector().Data[dim] = 1.0
+
+	t.Run("append into prepared fields", func(t *testing.T) {
+		dst := PrepareResultFieldData(src, 2)
+		assert.Equal(t, len(src), len(dst))
+		for i := range dst {
+			assert.Equal(t, src[i].Type, dst[i].Type)
+			assert.Equal(t, src[i].FieldName, dst[i].FieldName)
+			assert.Equal(t, src[i].FieldId, dst[i].FieldId)
+		}
+		assert.Equal(t, 2, cap(dst[2].GetScalars().GetLongData().Data))
+		assert.Equal(t, 2*dim, cap(dst[7].GetVectors().GetFloatVector().Data))
+
+		assert.NoError(t, AppendFieldData(dst, src, 1))
+		assert.NoError(t, AppendFieldData(dst, src, 0))
+		assert.Equal(t, []bool{false, true}, dst[0].GetScalars().GetBoolData().Data)
+		assert.Equal(t, []int32{2, 1}, dst[1].GetScalars().GetIntData().Data)
+		assert.Equal(t, []int64{4, 3}, dst[2].GetScalars().GetLongData().Data)
+		assert.Equal(t, []float32{2.2, 1.1}, dst[3].GetScalars().GetFloatData().Data)
+		assert.Equal(t, []float64{4.4, 3.3}, dst[4].GetScalars().GetDoubleData().Data)
+		assert.Equal(t, []string{"b", "a"}, dst[5].GetScalars().GetStringData().Data)
+		assert.Equal(t, []byte{3, 4, 1, 2}, dst[6].GetVectors().GetBinaryVector())
+		assert.Equal(t, float32(1.0), dst[7].GetVectors().GetFloatVector().Data[0])
+		assert.Equal(t, 2*dim, len(dst[7].GetVectors().GetFloatVector().Data))
+		// src is not modified
+		assert.Equal(t, []byte{1, 2, 3, 4}, src[6].GetVectors().GetBinaryVector())
+	})
+
+	t.Run("append into empty fields", func(t *testing.T) {
+		dst := make([]*schemapb.FieldData, len(src))
+		assert.NoError(t, AppendFieldData(dst, src, 0))
+		assert.Equal(t, []int64{3}, dst[2].GetScalars().GetLongData().Data)
+		assert.Equal(t, []byte{1, 2}, dst[6].GetVectors().GetBinaryVector())
+		assert.Equal(t, int64(dim), dst[7].GetVectors().Dim)
+	})
+
+	t.Run("not supported field", func(t *testing.T) {
+		dst := make([]*schemapb.FieldData, 1)
+		err := AppendFieldData(dst, []*schemapb.FieldData{{Type: schemapb.DataType_None}}, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestSliceRowsByPrimaryKey(t *testing.T) {
+	const dim = 8
+	ids := []int64{5, 3, 9, 3, 1}
+	fieldsData := []*schemapb.FieldData{
+		genFieldData("int64", 100, schemapb.DataType_Int64, []int64{50, 30, 90, 31, 10}, 1),
+		genFieldData("bvec", 101, schemapb.DataType_BinaryVector, []byte{5, 3, 9, 4, 1}, dim),
+	}
+
+	retIDs, retFieldsData, err := SliceRowsByPrimaryKey(ids, fieldsData, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 3, 5, 9}, retIDs)
+	// the first row of the duplicated primary key is kept
+	assert.Equal(t, []int64{10, 30, 50, 90}, retFieldsData[0].GetScalars().GetLongData().Data)
+	assert.Equal(t, []byte{1, 3, 5, 9}, retFieldsData[1].GetVectors().GetBinaryVector())
+
+	retIDs, retFieldsData, err = SliceRowsByPrimaryKey(ids, fieldsData, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 5}, retIDs)
+	assert.Equal(t, []int64{30, 50}, retFieldsData[0].GetScalars().GetLongData().Data)
+
+	retIDs, retFieldsData, err = SliceRowsByPrimaryKey(ids, fieldsData, 3, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9}, retIDs)
+	assert.Equal(t, []int64{90}, retFieldsData[0].GetScalars().GetLongData().Data)
+
+	// the fields are kept even if no row is left
+	retIDs, retFieldsData, err = SliceRowsByPrimaryKey(ids, fieldsData, 4, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(retIDs))
+	assert.Equal(t, 2, len(retFieldsData))
+	assert.Equal(t, 0, len(retFieldsData[0].GetScalars().GetLongData().Data))
+
+	_, _, err = SliceRowsByPrimaryKey(ids, fieldsData, -1, 10)
+	assert.Error(t, err)
+
+	_, _, err = SliceRowsByPrimaryKey(ids, []*schemapb.FieldData{{Type: schemapb.DataType_None}}, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestSliceRowsByPrimaryKey_Paginate(t *testing.T) {
+	const (
+		rowNum   = 10000
+		shardNum = 4
+		pageSize = 128
+	)
+
+	// the rows are spread over the shards in random order, some rows are duplicated in two shards
+	// as a segment being handed off
+	r := rand.New(rand.NewSource(0))
+	shardIDs := make([][]int64, shardNum)
+	shardValues := make([][]int64, shardNum)
+	for _, pk := range r.Perm(rowNum) {
+		shard := r.Intn(shardNum)
+		shardIDs[shard] = append(shardIDs[shard], int64(pk))
+		shardValues[shard] = append(shardValues[shard], int64(pk)*2)
+		if pk%100 == 0 {
+			shard = (shard + 1) % shardNum
+			shardIDs[shard] = append(shardIDs[shard], int64(pk))
+			shardValues[shard] = append(shardValues[shard], int64(pk)*2)
+		}
+	}
+
+	var all []int64
+	for offset := int64(0); ; offset += pageSize {
+		// every shard returns offset+limit rows, the final offset and limit is applied after merging
+		var mergedIDs, mergedValues []int64
+		for shard := 0; shard < shardNum; shard++ {
+			fieldsData := []*schemapb.FieldData{genFieldData("int64", 100, schemapb.DataType_Int64, shardValues[shard], 1)}
+			ids, retFieldsData, err := SliceRowsByPrimaryKey(shardIDs[shard], fieldsData, 0, offset+pageSize)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, int64(len(ids)), offset+pageSize)
+			mergedIDs = append(mergedIDs, ids...)
+			mergedValues = append(mergedValues, retFieldsData[0].GetScalars().GetLongData().Data...)
+		}
+		fieldsData := []*schemapb.FieldData{genFieldData("int64", 100, schemapb.DataType_Int64, mergedValues, 1)}
+		ids, retFieldsData, err := SliceRowsByPrimaryKey(mergedIDs, fieldsData, offset, pageSize)
+		assert.NoError(t, err)
+		values := retFieldsData[0].GetScalars().GetLongData().Data
+		assert.Equal(t, len(ids), len(values))
+		for i := range ids {
+			assert.Equal(t, ids[i]*2, values[i])
+		}
+		if len(ids) == 0 {
+			break
+		}
+		assert.LessOrEqual(t, len(ids), pageSize)
+		all = append(all, ids...)
+	}
+
+	// no duplicates or gaps
+	assert.Equal(t, rowNum, len(all))
+	for i, pk := range all {
+		assert.Equal(t, int64(i), pk)
+	}
+}
+
+func TestGetPrimaryFieldData(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "vec", DataType: schemapb.DataType_FloatVector},
+			{FieldID: 101, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+		},
+	}
+	pkField, err := GetPrimaryFieldSchema(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, "pk", pkField.Name)
+
+	_, err = GetPrimaryFieldSchema(&schemapb.CollectionSchema{})
+	assert.Error(t, err)
+
+	datas := []*schemapb.FieldData{
+		genFieldData("vec", 0, schemapb.DataType_FloatVector, make([]float32, 4), 2),
+		genFieldData("pk", 0, schemapb.DataType_Int64, []int64{1, 2}, 1),
+	}
+	pkData, err := GetPrimaryFieldData(datas, pkField)
+	assert.NoError(t, err)
+	pks, err := GetInt64PrimaryKeys(pkData)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, pks)
+
+	_, err = GetPrimaryFieldData(datas[:1], pkField)
+	assert.Error(t, err)
+	_, err = GetInt64PrimaryKeys(datas[0])
+	assert.Error(t, err)
+}
+
+func TestFindDuplicatePrimaryKeys(t *testing.T) {
+	lastOccurrences, duplicates := FindDuplicatePrimaryKeys(nil)
+	assert.Equal(t, 0, len(lastOccurrences))
+	assert.Equal(t, 0, len(duplicates))
+
+	lastOccurrences, duplicates = FindDuplicatePrimaryKeys([]int64{3, 1, 2})
+	assert.Equal(t, []int{0, 1, 2}, lastOccurrences)
+	assert.Equal(t, 0, len(duplicates))
+
+	pks := []int64{
+		math.MaxInt64, 0, math.MinInt64, -1, math.MaxInt64,
+		math.MinInt64, 1, math.MaxInt64, -1, 0,
+	}
+	lastOccurrences, duplicates = FindDuplicatePrimaryKeys(pks)
+	assert.Equal(t, []int{5, 6, 7, 8, 9}, lastOccurrences)
+	assert.Equal(t, []int64{math.MaxInt64, math.MinInt64, -1, 0}, duplicates)
+}
+
+func TestSelectRows(t *testing.T) {
+	const dim = 8
+	fieldsData := []*schemapb.FieldData{
+		genFieldData("int64", 100, schemapb.DataType_Int64, []int64{10, 20, 30}, 1),
+		genFieldData("bvec", 101, schemapb.DataType_BinaryVector, []byte{1, 2, 3}, dim),
+	}
+	ret, err := SelectRows(fieldsData, []int{0, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{10, 30}, ret[0].GetScalars().GetLongData().Data)
+	assert.Equal(t, []byte{1, 3}, ret[1].GetVectors().GetBinaryVector())
+
+	_, err = SelectRows([]*schemapb.FieldData{{Type: schemapb.DataType_None}}, []int{0})
+	assert.Error(t, err)
+}
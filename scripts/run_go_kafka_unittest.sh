@@ -0,0 +1,48 @@
+#!/usr/bin/env bash
+
+# Licensed to the LF AI & Data foundation under one
+# or more contributor license agreements. See the NOTICE file
+# distributed with this work for additional information
+# regarding copyright ownership. The ASF licenses this file
+# to you under the Apache License, Version 2.0 (the
+# "License"); you may not use this file except in compliance
+# with the License. You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -e
+
+SOURCE="${BASH_SOURCE[0]}"
+while [ -h "$SOURCE" ]; do # resolve $SOURCE until the file is no longer a symlink
+  DIR="$( cd -P "$( dirname "$SOURCE" )" && pwd )"
+  SOURCE="$(readlink "$SOURCE")"
+  [[ $SOURCE != /* ]] && SOURCE="$DIR/$SOURCE" # if $SOURCE was a relative symlink, we need to resolve it relative to the path where the symlink file was located
+done
+ROOT_DIR="$( cd -P "$( dirname "$SOURCE" )/.." && pwd )"
+
+# run the msgstream tests against kafka brokers started in containers
+MILVUS_DIR="${ROOT_DIR}/internal/"
+COMPOSE_FILE="${ROOT_DIR}/deployments/docker/dev/docker-compose-kafka.yml"
+
+docker-compose -f "${COMPOSE_FILE}" up -d
+trap 'docker-compose -f "${COMPOSE_FILE}" down' EXIT
+
+export KAFKA_ADDRESS="${KAFKA_ADDRESS:-localhost:9092}"
+echo "Waiting for kafka at ${KAFKA_ADDRESS}"
+for i in $(seq 1 60); do
+  if docker-compose -f "${COMPOSE_FILE}" exec -T kafka kafka-topics.sh --bootstrap-server localhost:9092 --list >/dev/null 2>&1; then
+    break
+  fi
+  sleep 2
+done
+
+go test -race -cover -tags kafka "${MILVUS_DIR}/util/mqclient/..." -failfast
+go test -race -cover -tags kafka "${MILVUS_DIR}/msgstream/..." -failfast
+
+echo " Go kafka unittest finished"
@@ -203,7 +203,6 @@ class TestQueryParams(TestcaseBase):
                                        check_task=CheckTasks.check_query_results, check_items={exp_res: res})
 
     @pytest.mark.tags(CaseLabel.L2)
-    @pytest.mark.xfail(reason="issue #7521 #7522")
     def test_query_expr_by_bool_field(self):
         """
         target: test query by bool field and output binary field
@@ -224,6 +223,50 @@ class TestQueryParams(TestcaseBase):
         assert len(res) == ct.default_nb / 2
         assert set(res[0].keys()) == {ct.default_int64_field_name, ct.default_bool_field_name}
 
+    @pytest.mark.tags(CaseLabel.L2)
+    @pytest.mark.parametrize("expr", [f'{ct.default_bool_field_name} not in [True]',
+                                      f'{ct.default_bool_field_name} == False',
+                                      f'not ({ct.default_bool_field_name} != False)'])
+    def test_query_expr_bool_field_negation(self, expr):
+        """
+        target: test query by negated and equality exprs on bool field
+        method: 1.create and insert with [int64, float, bool, float_vec] fields
+                2.query by `not in`, `==` and `!=` on bool field
+        expected: only the rows whose bool value is False are returned
+        """
+        self._connect()
+        df = cf.gen_default_dataframe_data()
+        bool_values = pd.Series(data=[True if i % 2 == 0 else False for i in range(ct.default_nb)], dtype="bool")
+        df.insert(2, ct.default_bool_field_name, bool_values)
+        self.collection_wrap.construct_from_dataframe(cf.gen_unique_str(prefix), df,
+                                                      primary_field=ct.default_int64_field_name)
+        assert self.collection_wrap.num_entities == ct.default_nb
+        self.collection_wrap.load()
+        res, _ = self.collection_wrap.query(expr, output_fields=[ct.default_bool_field_name])
+        assert len(res) == ct.default_nb / 2
+        for r in res:
+            assert r[ct.default_bool_field_name] is False
+
+    @pytest.mark.tags(CaseLabel.L1)
+    def test_query_expr_bool_field_invalid(self):
+        """
+        target: test query with invalid exprs on bool field
+        method: query bool field with range operator and non-bool term values
+        expected: raise exception naming the bool field
+        """
+        self._connect()
+        df = cf.gen_default_dataframe_data()
+        bool_values = pd.Series(data=[True if i % 2 == 0 else False for i in range(ct.default_nb)], dtype="bool")
+        df.insert(2, ct.default_bool_field_name, bool_values)
+        self.collection_wrap.construct_from_dataframe(cf.gen_unique_str(prefix), df,
+                                                      primary_field=ct.default_int64_field_name)
+        self.collection_wrap.load()
+        error = {ct.err_code: 1, ct.err_msg: f'field {ct.default_bool_field_name}'}
+        for expr in [f'{ct.default_bool_field_name} > True',
+                     f'{ct.default_bool_field_name} in [1.0]',
+                     f'{ct.default_bool_field_name} in ["True"]']:
+            self.collection_wrap.query(expr, check_task=CheckTasks.err_res, check_items=error)
+
     @pytest.mark.tags(CaseLabel.L2)
     def test_query_expr_by_int8_field(self):
         """